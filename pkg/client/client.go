@@ -17,10 +17,16 @@ const (
 
 type tokenAuth struct {
 	token string
+	// as is the user impersonated by an admin
+	as string
 }
 
 func (t *tokenAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
-	return map[string]string{"token": t.token}, nil
+	md := map[string]string{"token": t.token}
+	if t.as != "" {
+		md["as"] = t.as
+	}
+	return md, nil
 }
 
 func (*tokenAuth) RequireTransportSecurity() bool { return false }
@@ -29,7 +35,7 @@ func New(cfg ClusterConfig) (*grpc.ClientConn, error) {
 	tlsConfig := new(tls.Config)
 
 	opts := []grpc.DialOption{
-		grpc.WithPerRPCCredentials(&tokenAuth{cfg.Token}),
+		grpc.WithPerRPCCredentials(&tokenAuth{token: cfg.Token, as: cfg.Impersonate}),
 		grpc.WithBlock(),
 		grpc.WithTimeout(defaultConnTimeout),
 	}
@@ -22,3 +22,22 @@ func TestGetRequestMetadaReturnToken(t *testing.T) {
 		t.Errorf("expected %s, got %s", expectedToken, token)
 	}
 }
+
+func TestGetRequestMetadataImpersonation(t *testing.T) {
+	ta := &tokenAuth{token: "gopher"}
+	metadata, err := ta.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatal("Error on get request metadata: ", err)
+	}
+	if _, ok := metadata["as"]; ok {
+		t.Error("expected no impersonation")
+	}
+
+	ta.as = "teresa@luizalabs.com"
+	if metadata, err = ta.GetRequestMetadata(context.Background()); err != nil {
+		t.Fatal("Error on get request metadata: ", err)
+	}
+	if metadata["as"] != ta.as {
+		t.Errorf("expected %s, got %s", ta.as, metadata["as"])
+	}
+}
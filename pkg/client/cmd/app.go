@@ -6,6 +6,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/app"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"golang.org/x/net/context"
 )
@@ -66,6 +69,9 @@ The app name must follow this rules:
   An app that uses the grpc protocol:
   $ teresa create foo --team bar --protocol grpc
 
+  On the Kubernetes cluster prod-us-east, one registered by the admins
+  $ teresa app create foo --team bar --cluster prod-us-east
+
   With all flags...
   $ teresa app create foo --team bar --cpu 200m --max-cpu 500m --memory 512Mi --max-memory 1Gi \
     --scale-min 2 --scale-max 10 --scale-cpu 70 --process-type web --protocol http`,
@@ -142,6 +148,11 @@ func createApp(cmd *cobra.Command, args []string) {
 		client.PrintErrorAndExit("Invalid protocol parameter")
 	}
 
+	cluster, err := cmd.Flags().GetString("cluster")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid cluster parameter")
+	}
+
 	lim := newLimits(cpu, maxCPU, memory, maxMemory)
 	if err := ValidateLimits(lim); err != nil {
 		client.PrintErrorAndExit(err.Error())
@@ -170,6 +181,7 @@ func createApp(cmd *cobra.Command, args []string) {
 			Autoscale:   as,
 			Internal:    internal,
 			Protocol:    protocol,
+			Cluster:     cluster,
 		},
 	)
 	if err != nil {
@@ -320,6 +332,9 @@ func appInfo(cmd *cobra.Command, args []string) {
 	bold := color.New(color.Bold).SprintFunc()
 
 	fmt.Println(bold("team:"), info.Team)
+	if info.Cluster != "" {
+		fmt.Println(bold("cluster:"), info.Cluster)
+	}
 	if len(info.Addresses) > 0 {
 		fmt.Println(bold("addresses:"))
 		for _, addr := range info.Addresses {
@@ -332,6 +347,15 @@ func appInfo(cmd *cobra.Command, args []string) {
 	if info.Protocol != "" {
 		fmt.Println(bold("protocol:"), info.Protocol)
 	}
+	if info.Maintenance {
+		fmt.Println(bold("maintenance:"), color.YellowString("on"))
+	}
+	if info.Tls != "" {
+		fmt.Println(bold("tls:"), info.Tls)
+	}
+	if len(info.SourceRanges) > 0 {
+		fmt.Println(bold("allowed source ranges:"), strings.Join(info.SourceRanges, ", "))
+	}
 	if len(info.EnvVars) > 0 {
 		client.SortEnvsByKey(info.EnvVars)
 		fmt.Println(bold("env vars:"))
@@ -345,6 +369,59 @@ func appInfo(cmd *cobra.Command, args []string) {
 			fmt.Println("  ", vol)
 		}
 	}
+	if len(info.VolumeClaims) > 0 {
+		fmt.Println(bold("persistent volumes:"))
+		for _, vc := range info.VolumeClaims {
+			fmt.Printf("  %s %s at %s", vc.Name, vc.Size, vc.MountPath)
+			if vc.StorageClass != "" {
+				fmt.Printf(" class: %s", vc.StorageClass)
+			}
+			if vc.AccessMode != "" {
+				fmt.Printf(" mode: %s", vc.AccessMode)
+			}
+			fmt.Println()
+		}
+	}
+	if info.TerminationGracePeriodSeconds > 0 || info.DrainTimeoutSeconds > 0 {
+		fmt.Println(bold("lifecycle:"))
+		fmt.Printf("  drain timeout: %ds\n", info.DrainTimeoutSeconds)
+		if info.TerminationGracePeriodSeconds > 0 {
+			fmt.Printf("  termination grace period: %ds\n", info.TerminationGracePeriodSeconds)
+		}
+	}
+	if info.MaxSurge != "" || info.MaxUnavailable != "" {
+		fmt.Println(bold("rolling update:"))
+		if info.MaxSurge != "" {
+			fmt.Println("  max surge:", info.MaxSurge)
+		}
+		if info.MaxUnavailable != "" {
+			fmt.Println("  max unavailable:", info.MaxUnavailable)
+		}
+	}
+	if info.RolloutTimeoutSeconds > 0 {
+		fmt.Println(bold("rollout timeout:"), time.Duration(info.RolloutTimeoutSeconds)*time.Second)
+	}
+	if d := info.Deploy; d != nil && (d.Author != "" || d.Commit != "") {
+		fmt.Println(bold("current deploy:"))
+		if d.Author != "" {
+			fmt.Println("  author:", d.Author)
+		}
+		if d.Commit != "" {
+			fmt.Println("  commit:", d.Commit)
+		}
+		if d.Branch != "" {
+			fmt.Println("  branch:", d.Branch)
+		}
+		if d.Message != "" {
+			fmt.Println("  message:", d.Message)
+		}
+	}
+	if len(info.ConfigFiles) > 0 {
+		fmt.Println(bold("config files:"))
+		for _, cf := range info.ConfigFiles {
+			fmt.Println("  ", filepath.ToSlash(filepath.Join(cf.MountPath, cf.Name)))
+		}
+	}
 	if info.Status != nil {
 		pods := make([]*appb.InfoResponse_Status_Pod, 0)
 		for _, pod := range info.Status.Pods {
@@ -367,8 +444,40 @@ func appInfo(cmd *cobra.Command, args []string) {
 	if info.Autoscale != nil {
 		fmt.Println(bold("autoscale:"))
 		fmt.Printf("  %s %d%%\n", bold("cpu:"), info.Autoscale.CpuTargetUtilization)
+		if info.Autoscale.MemoryTargetUtilization > 0 {
+			fmt.Printf("  %s %d%%\n", bold("memory:"), info.Autoscale.MemoryTargetUtilization)
+		}
 		fmt.Printf("  %s %d\n", bold("max:"), info.Autoscale.Max)
 		fmt.Printf("  %s %d\n", bold("min:"), info.Autoscale.Min)
+		for _, m := range info.Autoscale.Metrics {
+			fmt.Printf("  %s %s\n", bold(m.Name+":"), m.TargetAverageValue)
+		}
+	}
+	if info.MinAvailable != "" {
+		fmt.Println(bold("min available pods:"), info.MinAvailable)
+	}
+	if info.LockedBy != "" {
+		fmt.Println(bold("locked by:"), info.LockedBy, info.LockReason)
+	}
+	if info.Builder != "" {
+		fmt.Println(bold("builder:"), info.Builder)
+	}
+	if len(info.BuildEnvVars) > 0 {
+		fmt.Println(bold("build env vars:"), strings.Join(info.BuildEnvVars, ", "))
+	}
+	if len(info.ScaleWindows) > 0 {
+		fmt.Println(bold("autoscale schedule:"))
+		for _, w := range info.ScaleWindows {
+			days := "every day"
+			if len(w.Days) > 0 {
+				days = strings.Join(w.Days, ",")
+			}
+			fmt.Printf("  %s-%s %s min: %d", w.Start, w.End, days, w.Min)
+			if w.Max > 0 {
+				fmt.Printf(" max: %d", w.Max)
+			}
+			fmt.Println()
+		}
 	}
 	fmt.Println(bold("limits:"))
 	if len(info.Limits.Default) > 0 {
@@ -394,6 +503,10 @@ func prepareSecretFileSet(filename, currentClusterName string, cmd *cobra.Comman
 	if err != nil || appName == "" {
 		return nil, fmt.Errorf("Invalid app parameter")
 	}
+	mountPath, err := cmd.Flags().GetString("mount-path")
+	if err != nil {
+		return nil, fmt.Errorf("Invalid mount-path parameter")
+	}
 	_, filename = filepath.Split(filename)
 
 	fmt.Printf(
@@ -403,6 +516,9 @@ func prepareSecretFileSet(filename, currentClusterName string, cmd *cobra.Comman
 		color.CyanString(`"%s"`, appName),
 		color.YellowString(`"%s"`, currentClusterName),
 	)
+	if mountPath != "" {
+		fmt.Printf("  mounted at %s\n", filepath.ToSlash(filepath.Join(mountPath, filename)))
+	}
 	noinput, err := cmd.Flags().GetBool("no-input")
 	if err != nil {
 		return nil, fmt.Errorf("Invalid no-input parameter")
@@ -416,13 +532,34 @@ func prepareSecretFileSet(filename, currentClusterName string, cmd *cobra.Comman
 	req := &appb.SetSecretRequest{
 		Name: appName,
 		SecretFile: &appb.SetSecretRequest_SecretFile{
-			Key:     filename,
-			Content: content,
+			Key:       filename,
+			Content:   content,
+			MountPath: mountPath,
 		},
 	}
 	return req, nil
 }
 
+// parseEnvVars parses the KEY=value args, the last value of a repeated key
+// wins
+func parseEnvVars(label string, args []string) ([]*appb.SetEnvRequest_EnvVar, error) {
+	evs := make([]*appb.SetEnvRequest_EnvVar, 0, len(args))
+	idx := make(map[string]int)
+	for _, item := range args {
+		tmp := strings.SplitN(item, "=", 2)
+		if len(tmp) != 2 {
+			return nil, fmt.Errorf("%s must be in the format FOO=bar", label)
+		}
+		if i, found := idx[tmp[0]]; found {
+			evs[i].Value = tmp[1]
+			continue
+		}
+		idx[tmp[0]] = len(evs)
+		evs = append(evs, &appb.SetEnvRequest_EnvVar{Key: tmp[0], Value: tmp[1]})
+	}
+	return evs, nil
+}
+
 func prepareEnvAndSecretSet(label, currentClusterName string, cmd *cobra.Command, args []string) (*appb.SetEnvRequest, error) {
 	if len(args) == 0 {
 		cmd.Usage()
@@ -434,13 +571,9 @@ func prepareEnvAndSecretSet(label, currentClusterName string, cmd *cobra.Command
 		return nil, fmt.Errorf("Invalid app parameter")
 	}
 
-	evs := make([]*appb.SetEnvRequest_EnvVar, len(args))
-	for i, item := range args {
-		tmp := strings.SplitN(item, "=", 2)
-		if len(tmp) != 2 {
-			return nil, fmt.Errorf("%s must be in the format FOO=bar", label)
-		}
-		evs[i] = &appb.SetEnvRequest_EnvVar{Key: tmp[0], Value: tmp[1]}
+	evs, err := parseEnvVars(label, args)
+	if err != nil {
+		return nil, err
 	}
 
 	fmt.Printf("Setting %s and %s %s on %s...\n", label, color.YellowString("restarting"), color.CyanString(`"%s"`, appName), color.YellowString(`"%s"`, currentClusterName))
@@ -469,6 +602,9 @@ var appEnvSetCmd = &cobra.Command{
 
 You can add a new environment variable for the app, or update if it already exists.
 
+The env vars can also be read from a dotenv file, all of them are applied
+at once in a single rolling update.
+
 WARNING:
   If you need to set more than one env var to the application, provide all at once.
   Every time this command is called, the application needs to be restared.`,
@@ -478,16 +614,47 @@ WARNING:
 
   You can also provide more than one env var at a time:
 
-  $ teresa app env-set FOO=bar BAR=foo --app myapp`,
+  $ teresa app env-set FOO=bar BAR=foo --app myapp
+
+  To set all env vars of a dotenv file (values given as arguments take precedence):
+
+  $ teresa app env-set --file .env --app myapp`,
 	Run: appEnvSet,
 }
 
+func readDotEnvFile(cmd *cobra.Command, args []string) ([]string, error) {
+	fn, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return nil, fmt.Errorf("Invalid file parameter")
+	}
+	if fn == "" {
+		return args, nil
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %v", fn, err)
+	}
+	defer f.Close()
+
+	evs, err := client.ParseDotEnv(f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing file %s: %v", fn, err)
+	}
+	return append(evs, args...), nil
+}
+
 func appEnvSet(cmd *cobra.Command, args []string) {
 	currentClusterName, err := getClusterName()
 	if err != nil {
 		client.PrintErrorAndExit("error reading config file: %v", err)
 	}
 
+	args, err = readDotEnvFile(cmd, args)
+	if err != nil {
+		client.PrintErrorAndExit("%s", err)
+	}
+
 	req, err := prepareEnvAndSecretSet("Env vars", currentClusterName, cmd, args)
 	if err != nil {
 		client.PrintErrorAndExit("%s", err)
@@ -583,6 +750,78 @@ func appEnvUnset(cmd *cobra.Command, args []string) {
 	fmt.Println("Env vars updated with success")
 }
 
+var appBuildEnvSetCmd = &cobra.Command{
+	Use:   "build-env-set [KEY=value, ...]",
+	Short: "Set build env vars for the app",
+	Long: `Create or update env vars available only to the builds of the app.
+
+Use them for build credentials, like private npm tokens, they never reach the
+environment of the running app. The app isn't restarted, the vars are used
+from the next build on.`,
+	Example: `  $ teresa app build-env-set NPM_TOKEN=secret --app myapp`,
+	Run:     appBuildEnvSet,
+}
+
+func appBuildEnvSet(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cmd.Usage()
+		return
+	}
+	appName, err := cmd.Flags().GetString("app")
+	if err != nil || appName == "" {
+		client.PrintErrorAndExit("Invalid app parameter")
+	}
+	evs, err := parseEnvVars("Build env vars", args)
+	if err != nil {
+		client.PrintErrorAndExit("%s", err)
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetEnvRequest{Name: appName, EnvVars: evs}
+	if _, err := cli.SetBuildEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Build env vars updated with success")
+}
+
+var appBuildEnvUnsetCmd = &cobra.Command{
+	Use:     "build-env-unset [KEY, ...]",
+	Short:   "Unset build env vars for the app",
+	Long:    "Remove env vars from the builds of the app, from the next build on.",
+	Example: `  $ teresa app build-env-unset NPM_TOKEN --app myapp`,
+	Run:     appBuildEnvUnset,
+}
+
+func appBuildEnvUnset(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cmd.Usage()
+		return
+	}
+	appName, err := cmd.Flags().GetString("app")
+	if err != nil || appName == "" {
+		client.PrintErrorAndExit("Invalid app parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UnsetEnvRequest{Name: appName, EnvVars: args}
+	if _, err := cli.UnsetBuildEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Build env vars updated with success")
+}
+
 var appSecretSetCmd = &cobra.Command{
 	Use:   "secret-set [KEY=value, ...]",
 	Short: "Set secert (as env vars) for the app",
@@ -594,6 +833,7 @@ If you need to create a file secret (monted as a file in the app container file
 use the flag '-f' pointing to a file in your current file system.
 When you create a secret based on a file the name of secret will be the name of file
 and Teresa will mount that secret in the default directory %s.
+Use the flag '--mount-path' to also mount the file in another directory.
 
 WARNING:
   If you need to set more than one secret to the application, provide all at once.
@@ -608,7 +848,11 @@ WARNING:
 
   For file based secrets use '-f' flag:
 
-  $ tersa app secret-set -f my-secret-file.txt`,
+  $ tersa app secret-set -f my-secret-file.txt
+
+  To mount the file at /secrets/credentials.json:
+
+  $ teresa app secret-set --file ./credentials.json --mount-path /secrets --app myapp`,
 	Run: appSecretSet,
 }
 
@@ -701,10 +945,13 @@ func appSecretUnset(cmd *cobra.Command, args []string) {
 }
 
 var appLogsCmd = &cobra.Command{
-	Use:   "logs <name>",
+	Use:   "logs <name> [<name>...]",
 	Short: "Show app logs",
 	Long: `Show application logs.
 
+With several apps their logs are shown together, each line prefixed by its
+app. Without --follow the logs are shown app by app, in the given order.
+
 WARNING:
   Lines are collected from all pods.`,
 	Example: `  $ teresa app logs foo
@@ -721,9 +968,30 @@ WARNING:
 
   $ teresa app logs foo --container nginx
 
+  To show the logs of the last hour with their timestamps:
+
+  $ teresa app logs foo --since 1h --timestamps
+
+  To read the logs as JSON lines, like with jq:
+
+  $ teresa app logs foo --output json | jq -r 'select(.process == "worker") | .line'
+
+  To filter by process type and show only the lines with errors:
+
+  $ teresa app logs foo --process worker --grep 'ERROR|Traceback'
+
+  To see why the pods are crash looping, the logs of the previous container
+  of the restarted pods:
+
+  $ teresa app logs foo --previous
+
   You can also simulate tail -f:
 
-  $ teresa app logs foo --lines=20 --follow`,
+  $ teresa app logs foo --lines=20 --follow
+
+  To follow the logs of several apps, like the ones of an incident:
+
+  $ teresa app logs foo bar baz --follow`,
 	Run:     appLogs,
 	Aliases: []string{"log"},
 }
@@ -734,11 +1002,21 @@ var appAutoscaleSetCmd = &cobra.Command{
 	Long: `Set application's autoscaling.
 
 You can set the lower and upper limit for the number of pods of the application, as well as the
-target CPU utilization to trigger the autoscaler.
+target CPU and memory utilization to trigger the autoscaler.
+
+Custom metrics of the app pods (served by a metrics adapter, like the Prometheus one)
+can be used too, the autoscaler keeps the average of the metric over all pods near
+the target value. Each --metric replaces all the current ones, use --metric "" to
+remove them.
 
 	Example:   To set the number minimum of replicas to 2:
 
-  $ teresa app autoscale myapp --min 2`,
+  $ teresa app autoscale myapp --min 2
+
+  To scale on requests per second and queue depth:
+
+  $ teresa app autoscale myapp --min 2 --max 10 \
+    --metric http_requests_per_second=100 --metric queue_depth=30`,
 	Run: appAutoscaleSet,
 }
 
@@ -765,10 +1043,24 @@ func appAutoscaleSet(cmd *cobra.Command, args []string) {
 		client.PrintErrorAndExit("invalid cpu-percent parameter")
 	}
 
+	memory, err := cmd.Flags().GetInt32("memory-percent")
+	if err != nil {
+		client.PrintErrorAndExit("invalid memory-percent parameter")
+	}
+
 	if msg, isValid := validateFlags(min, max); !isValid {
 		client.PrintErrorAndExit(msg)
 	}
 
+	metricArgs, err := cmd.Flags().GetStringArray("metric")
+	if err != nil {
+		client.PrintErrorAndExit("invalid metric parameter")
+	}
+	metrics, err := parseAutoscaleMetrics(metricArgs)
+	if err != nil {
+		client.PrintErrorAndExit("%s", err)
+	}
+
 	conn, err := connection.New(cfgFile, cfgCluster)
 	if err != nil {
 		client.PrintConnectionErrorAndExit(err)
@@ -776,13 +1068,16 @@ func appAutoscaleSet(cmd *cobra.Command, args []string) {
 	defer conn.Close()
 
 	as := &appb.SetAutoscaleRequest_Autoscale{
-		Min:                  min,
-		Max:                  max,
-		CpuTargetUtilization: cpu,
+		Min:                     min,
+		Max:                     max,
+		CpuTargetUtilization:    cpu,
+		MemoryTargetUtilization: memory,
+		Metrics:                 metrics,
 	}
 	req := &appb.SetAutoscaleRequest{
-		Name:      name,
-		Autoscale: as,
+		Name:           name,
+		Autoscale:      as,
+		ReplaceMetrics: cmd.Flags().Changed("metric"),
 	}
 	cli := appb.NewAppClient(conn)
 	if _, err := cli.SetAutoscale(context.Background(), req); err != nil {
@@ -791,6 +1086,22 @@ func appAutoscaleSet(cmd *cobra.Command, args []string) {
 	fmt.Println("Autoscale updated with success")
 }
 
+// parseAutoscaleMetrics parses name=target args, blank ones are ignored
+func parseAutoscaleMetrics(args []string) ([]*appb.AutoscaleMetric, error) {
+	var metrics []*appb.AutoscaleMetric
+	for _, arg := range args {
+		if arg == "" {
+			continue
+		}
+		tmp := strings.SplitN(arg, "=", 2)
+		if len(tmp) != 2 || tmp[0] == "" || tmp[1] == "" {
+			return nil, fmt.Errorf("invalid metric: %s", arg)
+		}
+		metrics = append(metrics, &appb.AutoscaleMetric{Name: tmp[0], TargetAverageValue: tmp[1]})
+	}
+	return metrics, nil
+}
+
 func validateFlags(min, max int32) (string, bool) {
 	if max == flagNotDefined || min == flagNotDefined {
 		return "--min and --max are required", false
@@ -889,129 +1200,1232 @@ func appStop(cmd *cobra.Command, args []string) {
 	fmt.Println("App stopped with success")
 }
 
-func init() {
-	// add AppCmd
-	RootCmd.AddCommand(appCmd)
-	// App commands
-	appCmd.AddCommand(appCreateCmd)
-	appCmd.AddCommand(appListCmd)
-	appCmd.AddCommand(appDelCmd)
-	appCmd.AddCommand(appInfoCmd)
-	appCmd.AddCommand(appEnvSetCmd)
-	appCmd.AddCommand(appEnvUnSetCmd)
-	appCmd.AddCommand(appSecretSetCmd)
-	appCmd.AddCommand(appSecretUnSetCmd)
-	appCmd.AddCommand(appLogsCmd)
-	appCmd.AddCommand(appAutoscaleSetCmd)
-	appCmd.AddCommand(appStartCmd)
-	appCmd.AddCommand(appStopCmd)
-	appCmd.AddCommand(appDeletePodsCmd)
-	appCmd.AddCommand(appChangeTeamCmd)
-	appCmd.AddCommand(appSetVHostsCmd)
+var appPauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Pause the app",
+	Long: `Scale all deploys of the app to 0 and disable its autoscale.
 
-	appCreateCmd.Flags().String("team", "", "team owner of the app")
-	appCreateCmd.Flags().Int32("scale-min", 1, "minimum number of replicas")
-	appCreateCmd.Flags().Int32("scale-max", 2, "maximum number of replicas")
-	appCreateCmd.Flags().Int32("scale-cpu", 70, "auto scale target cpu percentage to scale")
-	appCreateCmd.Flags().String("cpu", "200m", "allocated pod cpu")
-	appCreateCmd.Flags().String("memory", "512Mi", "allocated pod memory")
-	appCreateCmd.Flags().String("max-cpu", "400m", "when set, allows the pod to burst cpu usage up to 'max-cpu'")
-	appCreateCmd.Flags().String("max-memory", "512Mi", "when set, allows the pod to burst memory usage up to 'max-memory'")
-	appCreateCmd.Flags().String("process-type", "", "app process type")
-	appCreateCmd.Flags().String("vhost", "", "comma separated list of the app's virtual hosts")
-	appCreateCmd.Flags().Bool("internal", false, "create an internal app (without external endpoint)")
-	appCreateCmd.Flags().String("protocol", "", "app protocol: http, http2, grpc, etc.")
+The previous replicas count and autoscale are restored by app resume.`,
+	Example: "  $ teresa app pause myapp",
+	Run:     appPause,
+}
 
-	appEnvSetCmd.Flags().String("app", "", "app name")
-	appEnvSetCmd.Flags().Bool("no-input", false, "set env vars without warning")
+func appPause(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
 
-	appEnvUnSetCmd.Flags().String("app", "", "app name")
-	appEnvUnSetCmd.Flags().Bool("no-input", false, "unset env vars without warning")
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
 
-	appSecretSetCmd.Flags().String("app", "", "app name")
-	appSecretSetCmd.Flags().Bool("no-input", false, "set env vars without warning")
-	appSecretSetCmd.Flags().StringP("filename", "f", "", "Filename with secret content")
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Pause(context.Background(), &appb.PauseRequest{Name: name}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("App paused with success")
+}
 
-	appSecretUnSetCmd.Flags().String("app", "", "app name")
-	appSecretUnSetCmd.Flags().Bool("no-input", false, "unset env vars without warning")
-	// App logs
-	appLogsCmd.Flags().Int64P("lines", "n", 10, "number of lines")
-	appLogsCmd.Flags().BoolP("follow", "f", false, "follow logs")
-	appLogsCmd.Flags().String("pod", "", "filter logs by pod name")
-	appLogsCmd.Flags().BoolP("previous", "p", false, "print the logs for the previous instance")
-	appLogsCmd.Flags().String("container", "", "filter logs by container name")
-	// App autoscale
-	appAutoscaleSetCmd.Flags().Int32("min", flagNotDefined, "Minimum number of replicas")
-	appAutoscaleSetCmd.Flags().Int32("max", flagNotDefined, "Maximum number of replicas")
-	appAutoscaleSetCmd.Flags().Int32("cpu-percent", flagNotDefined, "The target average CPU utilization (represented as a percent of requested CPU) over all the pods. If it's not specified or negative, the current autoscaling policy will be used.")
-	// App Start
-	appStartCmd.Flags().Int32("replicas", 1, "Number of replicas")
-	// App delete-pods
-	appDeletePodsCmd.Flags().String("app", "", "app name")
+var appResumeCmd = &cobra.Command{
+	Use:     "resume <name>",
+	Short:   "Resume a paused app",
+	Long:    "Restore the replicas count and autoscale of an app paused by app pause.",
+	Example: "  $ teresa app resume myapp",
+	Run:     appResume,
 }
 
-func appLogs(cmd *cobra.Command, args []string) {
+func appResume(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		cmd.Usage()
 		return
 	}
-	appName := args[0]
+	name := args[0]
 
-	lines, err := cmd.Flags().GetInt64("lines")
+	conn, err := connection.New(cfgFile, cfgCluster)
 	if err != nil {
-		client.PrintErrorAndExit("Invalid lines parameter")
+		client.PrintConnectionErrorAndExit(err)
 	}
+	defer conn.Close()
 
-	follow, err := cmd.Flags().GetBool("follow")
-	if err != nil {
-		client.PrintErrorAndExit("Invalid follow parameter")
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Resume(context.Background(), &appb.ResumeRequest{Name: name}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
 	}
+	fmt.Println("App resumed with success")
+}
 
-	pod, err := cmd.Flags().GetString("pod")
-	if err != nil {
-		client.PrintErrorAndExit("Invalid pod parameter")
-	}
+var appLockCmd = &cobra.Command{
+	Use:   "lock <name>",
+	Short: "Lock the deploys of the app",
+	Long: `Reject the deploys of the app until it's unlocked by app unlock.
 
-	previous, err := cmd.Flags().GetBool("previous")
-	if err != nil {
-		client.PrintErrorAndExit("Invalid previous parameter")
+Only admins can force a deploy of a locked app, with the --force flag.`,
+	Example: "  $ teresa app lock myapp --reason \"holiday freeze\"",
+	Run:     appLock,
+}
+
+func appLock(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
 	}
+	name := args[0]
 
-	container, err := cmd.Flags().GetString("container")
+	reason, err := cmd.Flags().GetString("reason")
 	if err != nil {
-		client.PrintErrorAndExit("Invalid container parameter")
+		client.PrintErrorAndExit("Invalid reason parameter")
 	}
 
 	conn, err := connection.New(cfgFile, cfgCluster)
 	if err != nil {
-		client.PrintErrorAndExit("Error connecting to server: %v", err)
+		client.PrintConnectionErrorAndExit(err)
 	}
 	defer conn.Close()
 
 	cli := appb.NewAppClient(conn)
-	req := &appb.LogsRequest{
-		Name:      appName,
-		Lines:     lines,
-		Follow:    follow,
-		PodName:   pod,
-		Previous:  previous,
-		Container: container,
-	}
-	stream, err := cli.Logs(context.Background(), req)
-	if err != nil {
+	req := &appb.LockRequest{Name: name, Reason: reason}
+	if _, err := cli.Lock(context.Background(), req); err != nil {
 		client.PrintErrorAndExit(client.GetErrorMsg(err))
 	}
+	fmt.Println("App locked with success")
+}
 
-	for {
-		msg, err := stream.Recv()
-		if err != nil {
-			if err == io.EOF {
-				return
-			}
-			client.PrintErrorAndExit(client.GetErrorMsg(err))
-		}
-		fmt.Println(msg.Text)
-	}
+var appUnlockCmd = &cobra.Command{
+	Use:     "unlock <name>",
+	Short:   "Unlock the deploys of the app",
+	Long:    "Let the app locked by app lock be deployed again.",
+	Example: "  $ teresa app unlock myapp",
+	Run:     appUnlock,
+}
+
+func appUnlock(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Unlock(context.Background(), &appb.UnlockRequest{Name: name}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("App unlocked with success")
+}
+
+var appRestartCmd = &cobra.Command{
+	Use:     "restart <name>",
+	Short:   "Restart the app",
+	Long:    "Do a rolling restart of all pods of the app, without a new deploy.",
+	Example: "  $ teresa app restart myapp",
+	Run:     appRestart,
+}
+
+func appRestart(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Restart(context.Background(), &appb.RestartRequest{Name: name}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("App restarted with success")
+}
+
+var appMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance <name> on|off",
+	Short: "Turn the maintenance mode of the app on or off",
+	Long: `Turn the maintenance mode of the app on or off.
+
+In maintenance mode the app ingress serves a static maintenance page
+instead of the app, the pods are kept running (useful for database
+migrations). Only apps exposed by ingress are supported.`,
+	Example: "  $ teresa app maintenance myapp on",
+	Run:     appMaintenance,
+}
+
+func appMaintenance(cmd *cobra.Command, args []string) {
+	if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+		cmd.Usage()
+		return
+	}
+	name, enabled := args[0], args[1] == "on"
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetMaintenanceRequest{Name: name, Enabled: enabled}
+	if _, err := cli.SetMaintenance(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Maintenance mode turned %s with success\n", args[1])
+}
+
+var appTLSCmd = &cobra.Command{
+	Use:   "tls",
+	Short: "Manage the TLS certificate of the app",
+}
+
+var appTLSEnableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Enable HTTPS for the app",
+	Long: `Enable HTTPS for the app on clusters with cert-manager integration.
+
+A certificate for all the app vhosts is issued and renewed automatically,
+its status is shown by 'teresa app info'. Only apps exposed by ingress
+are supported.`,
+	Example: "  $ teresa app tls enable myapp",
+	Run:     appTLSEnable,
+}
+
+func appTLSEnable(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.EnableTLSRequest{Name: args[0]}
+	if _, err := cli.EnableTLS(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("TLS enabled with success, the certificate will be issued shortly")
+}
+
+var appIngressCmd = &cobra.Command{
+	Use:   "ingress <name> [option=value, ...]",
+	Short: "Set ingress options of the app",
+	Long: `Set ingress options of the app on clusters with ingress integration.
+
+The available options are class, proxy-body-size, proxy-connect-timeout,
+proxy-read-timeout, proxy-send-timeout (in seconds) and sticky-sessions,
+as long as they're allowed by the cluster operator. An option without
+value is unset.
+
+The options can also be set in the ingress section of teresa.yaml, they
+are applied on every deploy.`,
+	Example: `  $ teresa app ingress myapp proxy-body-size=10m proxy-read-timeout=120
+
+  To unset an option:
+
+  $ teresa app ingress myapp proxy-body-size=`,
+	Run: appIngress,
+}
+
+func parseIngressOptions(args []string) ([]*appb.UpdateIngressRequest_Option, error) {
+	opts := make([]*appb.UpdateIngressRequest_Option, len(args))
+	for i, arg := range args {
+		tmp := strings.SplitN(arg, "=", 2)
+		if len(tmp) != 2 || tmp[0] == "" {
+			return nil, fmt.Errorf("invalid ingress option: %s", arg)
+		}
+		opts[i] = &appb.UpdateIngressRequest_Option{Key: tmp[0], Value: tmp[1]}
+	}
+	return opts, nil
+}
+
+func appIngress(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Usage()
+		return
+	}
+	opts, err := parseIngressOptions(args[1:])
+	if err != nil {
+		client.PrintErrorAndExit("%s", err)
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UpdateIngressRequest{Name: args[0], Options: opts}
+	if _, err := cli.UpdateIngress(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Ingress options updated with success")
+}
+
+var appACLCmd = &cobra.Command{
+	Use:   "acl",
+	Short: "Manage the source IP allowlist of the app",
+}
+
+var appACLSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Restrict the access to the app to a list of CIDRs",
+	Long: `Restrict the access to the app ingress, or load balancer, to a list of CIDRs.
+
+Calling it without --allow lifts the restriction.`,
+	Example: "  $ teresa app acl set myapp --allow 10.0.0.0/8 --allow 200.1.2.3/32",
+	Run:     appACLSet,
+}
+
+func appACLSet(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	cidrs, err := cmd.Flags().GetStringArray("allow")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid allow parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetACLRequest{Name: args[0], Allow: cidrs}
+	if _, err := cli.SetACL(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if len(cidrs) == 0 {
+		fmt.Println("Source IP allowlist removed with success")
+		return
+	}
+	fmt.Println("Source IP allowlist updated with success")
+}
+
+var appAutoscaleScheduleCmd = &cobra.Command{
+	Use:   "autoscale-schedule <name> [flags]",
+	Short: "Set time-based scaling windows for the app",
+	Long: `Set time-based scaling windows for the app.
+
+Each window sets the min (and optionally the max) replicas of the app between two
+times of the day (server time), optionally on some days of the week only. Outside
+of the windows the min and max set by app autoscale are restored. The first
+matching window wins and a window ending before its start ends on the next day.
+
+Call it without --window to remove the schedule.`,
+	Example: `  Keep 10 replicas during business hours and 2 on weekend nights:
+
+  $ teresa app autoscale-schedule myapp \
+    --window "08:00-20:00 min=10 days=mon-fri" \
+    --window "22:00-06:00 min=2 max=4 days=fri,sat"`,
+	Run: appAutoscaleSchedule,
+}
+
+var scheduleDays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func dayIndex(day string) int {
+	for i, d := range scheduleDays {
+		if d == day {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseScheduleDays parses a comma separated list of days and day ranges,
+// like mon-fri
+func parseScheduleDays(s string) ([]string, error) {
+	var days []string
+	for _, item := range strings.Split(s, ",") {
+		tmp := strings.SplitN(item, "-", 2)
+		first := dayIndex(tmp[0])
+		last := first
+		if len(tmp) == 2 {
+			last = dayIndex(tmp[1])
+		}
+		if first < 0 || last < 0 {
+			return nil, fmt.Errorf("invalid day: %s", item)
+		}
+		for i := first; ; i = (i + 1) % len(scheduleDays) {
+			days = append(days, scheduleDays[i])
+			if i == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseScaleWindow parses a window like "08:00-20:00 min=10 max=20 days=mon-fri"
+func parseScaleWindow(s string) (*appb.ScaleWindow, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("invalid window: %s", s)
+	}
+	times := strings.SplitN(fields[0], "-", 2)
+	if len(times) != 2 {
+		return nil, fmt.Errorf("invalid window: %s", s)
+	}
+	w := &appb.ScaleWindow{Start: times[0], End: times[1]}
+	for _, f := range fields[1:] {
+		tmp := strings.SplitN(f, "=", 2)
+		if len(tmp) != 2 {
+			return nil, fmt.Errorf("invalid window: %s", s)
+		}
+		var err error
+		switch tmp[0] {
+		case "min", "max":
+			var n int64
+			n, err = strconv.ParseInt(tmp[1], 10, 32)
+			if tmp[0] == "min" {
+				w.Min = int32(n)
+			} else {
+				w.Max = int32(n)
+			}
+		case "days":
+			w.Days, err = parseScheduleDays(tmp[1])
+		default:
+			err = fmt.Errorf("unknown option %s", tmp[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid window: %s: %v", s, err)
+		}
+	}
+	if w.Min == 0 {
+		return nil, fmt.Errorf("invalid window: %s: missing min", s)
+	}
+	return w, nil
+}
+
+func appAutoscaleSchedule(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	windowArgs, err := cmd.Flags().GetStringArray("window")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid window parameter")
+	}
+	windows := make([]*appb.ScaleWindow, len(windowArgs))
+	for i, arg := range windowArgs {
+		if windows[i], err = parseScaleWindow(arg); err != nil {
+			client.PrintErrorAndExit("%s", err)
+		}
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetAutoscaleScheduleRequest{Name: args[0], Windows: windows}
+	if _, err := cli.SetAutoscaleSchedule(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if len(windows) == 0 {
+		fmt.Println("Autoscale schedule removed with success")
+		return
+	}
+	fmt.Println("Autoscale schedule updated with success")
+}
+
+var appSetPDBCmd = &cobra.Command{
+	Use:   "set-pdb <name> <min-available>",
+	Short: "Set the minimum available pods of the app during node drains",
+	Long: `Set the minimum available pods of the app during voluntary disruptions, like
+node drains, as a number of pods or a percentage of them.
+
+Use 0 to disable it and "" to use the cluster default.`,
+	Example: `  $ teresa app set-pdb myapp 2
+
+  $ teresa app set-pdb myapp 50%`,
+	Run: appSetPDB,
+}
+
+func appSetPDB(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetPDBRequest{Name: args[0], MinAvailable: args[1]}
+	if _, err := cli.SetPDB(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Min available pods updated with success")
+}
+
+var appSetBuilderCmd = &cobra.Command{
+	Use:   "set-builder <name> <slug|cnb>",
+	Short: "Set how the app is built",
+	Long: `Set how the next deploys of the app are built.
+
+slug (default) builds the app with the Heroku buildpacks, cnb builds it into
+an image with Cloud Native Buildpacks (e.g. paketo), caching their layers
+between builds. cnb requires a build registry on the cluster.`,
+	Example: `  $ teresa app set-builder myapp cnb`,
+	Run:     appSetBuilder,
+}
+
+func appSetBuilder(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetBuilderRequest{Name: args[0], Builder: args[1]}
+	if _, err := cli.SetBuilder(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Builder updated with success, it's used from the next deploy on")
+}
+
+var appSetLifecycleCmd = &cobra.Command{
+	Use:   "set-lifecycle <name>",
+	Short: "Set how the app pods shut down",
+	Long: `Set how the app pods shut down during rollouts.
+
+The pods wait the drain timeout before receiving the SIGTERM signal and are
+killed when the termination grace period (30s by default) ends. The drain
+timeout must fit in the grace period.`,
+	Example: "  $ teresa app set-lifecycle myapp --drain-timeout 60 --grace-period 120",
+	Run:     appSetLifecycle,
+}
+
+func appSetLifecycle(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	drain, err := cmd.Flags().GetInt32("drain-timeout")
+	if err != nil {
+		client.PrintErrorAndExit("invalid drain-timeout parameter")
+	}
+	grace, err := cmd.Flags().GetInt32("grace-period")
+	if err != nil {
+		client.PrintErrorAndExit("invalid grace-period parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UpdateLifecycleRequest{
+		Name:                          args[0],
+		DrainTimeoutSeconds:           drain,
+		TerminationGracePeriodSeconds: grace,
+	}
+	if _, err := cli.UpdateLifecycle(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Lifecycle updated with success")
+}
+
+var appSetStrategyCmd = &cobra.Command{
+	Use:   "set-strategy <name>",
+	Short: "Set the rolling update strategy of the app",
+	Long: `Set the max surge and max unavailable pods of the app rollouts.
+
+Both are numbers of pods or percentages of the replicas, blank values use the
+default (25%). They can't be both zero. The running pods aren't replaced, the
+strategy is used by the next rollout.`,
+	Example: "  $ teresa app set-strategy myapp --max-surge 50% --max-unavailable 0",
+	Run:     appSetStrategy,
+}
+
+func appSetStrategy(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	maxSurge, err := cmd.Flags().GetString("max-surge")
+	if err != nil {
+		client.PrintErrorAndExit("invalid max-surge parameter")
+	}
+	maxUnavailable, err := cmd.Flags().GetString("max-unavailable")
+	if err != nil {
+		client.PrintErrorAndExit("invalid max-unavailable parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UpdateStrategyRequest{
+		Name:           args[0],
+		MaxSurge:       maxSurge,
+		MaxUnavailable: maxUnavailable,
+	}
+	if _, err := cli.UpdateStrategy(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Rolling update strategy updated with success")
+}
+
+var appSetRolloutTimeoutCmd = &cobra.Command{
+	Use:   "set-rollout-timeout <name>",
+	Short: "Set the time the pods of a deploy have to get ready",
+	Long: `Set the time the pods of the app deploys have to get ready.
+
+A deploy whose pods aren't ready in time is rolled back to the previous
+revision. It's between 30s and 1h, 0 uses the one of the cluster. The running
+deploy isn't changed, the timeout is used by the next deploys.`,
+	Example: "  $ teresa app set-rollout-timeout myapp --timeout 15m",
+	Run:     appSetRolloutTimeout,
+}
+
+func appSetRolloutTimeout(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	timeout, err := cmd.Flags().GetDuration("timeout")
+	if err != nil || timeout < 0 {
+		client.PrintErrorAndExit("invalid timeout parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UpdateRolloutTimeoutRequest{
+		Name:           args[0],
+		TimeoutSeconds: int32(timeout / time.Second),
+	}
+	if _, err := cli.UpdateRolloutTimeout(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Rollout timeout updated with success")
+}
+
+var appVolumeCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "Add or remove persistent volumes of the app",
+	Long: `Add or remove persistent volumes of the app.
+
+  The volumes are mounted in the app container from the next deploy on.
+  ReadWriteOnce volumes (the default) can only be mounted by pods running on
+  the same node, use ReadWriteMany for apps with replicas spread across
+  nodes, if the storage class supports it.`,
+}
+
+var appVolumeAddCmd = &cobra.Command{
+	Use:   "add <name> <volume-name>",
+	Short: "Add a persistent volume to the app",
+	Example: `  $ teresa app volume add myapp data --size 10Gi --mount-path /data
+
+  $ teresa app volume add myapp shared --size 1Gi --mount-path /shared --storage-class nfs --access-mode ReadWriteMany`,
+	Run: appVolumeAdd,
+}
+
+func appVolumeAdd(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	size, _ := cmd.Flags().GetString("size")
+	mountPath, _ := cmd.Flags().GetString("mount-path")
+	if size == "" || mountPath == "" {
+		client.PrintErrorAndExit("--size and --mount-path are required")
+	}
+	storageClass, _ := cmd.Flags().GetString("storage-class")
+	accessMode, _ := cmd.Flags().GetString("access-mode")
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.AddVolumeRequest{
+		Name: args[0],
+		Volume: &appb.VolumeClaim{
+			Name:         args[1],
+			Size:         size,
+			StorageClass: storageClass,
+			AccessMode:   accessMode,
+			MountPath:    mountPath,
+		},
+	}
+	if _, err := cli.AddVolume(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Volume %s added with success, it will be mounted on the next deploy\n", color.CyanString(args[1]))
+}
+
+var appVolumeRemoveCmd = &cobra.Command{
+	Use:     "remove <name> <volume-name>",
+	Short:   "Remove a persistent volume of the app, deleting its data",
+	Example: "  $ teresa app volume remove myapp data",
+	Run:     appVolumeRemove,
+}
+
+func appVolumeRemove(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName, volumeName := args[0], args[1]
+
+	noinput, _ := cmd.Flags().GetBool("no-input")
+	if !noinput {
+		fmt.Printf(
+			"The volume %s of the app %s and all its data will be %s\n",
+			color.CyanString(volumeName),
+			color.CyanString(appName),
+			color.YellowString("deleted"),
+		)
+		s, _ := client.GetInput("Are you sure? (yes/NO)? ")
+		if s != "yes" {
+			fmt.Println("Remove process aborted!")
+			return
+		}
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.RemoveVolumeRequest{Name: appName, VolumeName: volumeName}
+	if _, err := cli.RemoveVolume(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Volume %s removed with success, deploy the app to unmount it\n", color.CyanString(volumeName))
+}
+
+var appConfigFileCmd = &cobra.Command{
+	Use:   "config-file",
+	Short: "Set or unset config files of the app",
+	Long: `Set or unset config files of the app.
+
+  The files are mounted read only in the app container, the pods are
+  restarted to pick up changes.`,
+}
+
+var appConfigFileSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Create or update a config file of the app",
+	Example: `  $ teresa app config-file set myapp --file nginx.conf --mount-path /etc/nginx/
+
+  The file above is found at /etc/nginx/nginx.conf`,
+	Run: appConfigFileSet,
+}
+
+func appConfigFileSet(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	filename, _ := cmd.Flags().GetString("file")
+	mountPath, _ := cmd.Flags().GetString("mount-path")
+	if filename == "" || mountPath == "" {
+		client.PrintErrorAndExit("--file and --mount-path are required")
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		client.PrintErrorAndExit("error processing file %s: %v", filename, err)
+	}
+	_, filename = filepath.Split(filename)
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.SetConfigFileRequest{
+		Name:      args[0],
+		FileName:  filename,
+		Content:   content,
+		MountPath: mountPath,
+	}
+	if _, err := cli.SetConfigFile(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf(
+		"Config file %s set with success, mounted at %s\n",
+		color.CyanString(filename),
+		filepath.ToSlash(filepath.Join(mountPath, filename)),
+	)
+}
+
+var appConfigFileUnsetCmd = &cobra.Command{
+	Use:     "unset <name> <file-name>",
+	Short:   "Remove a config file of the app",
+	Example: "  $ teresa app config-file unset myapp nginx.conf",
+	Run:     appConfigFileUnset,
+}
+
+func appConfigFileUnset(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.UnsetConfigFileRequest{Name: args[0], FileName: args[1]}
+	if _, err := cli.UnsetConfigFile(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Config file %s removed with success\n", color.CyanString(args[1]))
+}
+
+var appStatusCmd = &cobra.Command{
+	Use:     "status <name>",
+	Short:   "Show the state of the app pods",
+	Long:    "Show the state, restarts, last termination reason, node and age of each pod of the app.",
+	Example: "  $ teresa app status myapp",
+	Run:     appStatus,
+}
+
+func appStatus(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	resp, err := cli.Status(context.Background(), &appb.StatusRequest{Name: name})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Pods) == 0 {
+		fmt.Println("The app doesn't have any pod")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "STATE", "READY", "RESTARTS", "LAST TERMINATION", "NODE", "AGE"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, pod := range resp.Pods {
+		reason := pod.LastTerminationReason
+		if reason == "" {
+			reason = "n/a"
+		}
+		table.Append([]string{
+			pod.Name,
+			pod.State,
+			strconv.FormatBool(pod.Ready),
+			strconv.Itoa(int(pod.Restarts)),
+			reason,
+			pod.Node,
+			shortHumanDuration(time.Duration(pod.Age)),
+		})
+	}
+	table.Render()
+}
+
+var appTopCmd = &cobra.Command{
+	Use:     "top <name>",
+	Short:   "Show the app resource usage",
+	Long:    "Show the current CPU and memory usage of each pod of the app and the total.",
+	Example: "  $ teresa app top myapp",
+	Run:     appTop,
+}
+
+func appTop(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	resp, err := cli.Top(context.Background(), &appb.TopRequest{Name: name})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Pods) == 0 {
+		fmt.Println("There are no metrics available for the app yet")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"POD", "CPU", "MEMORY"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	var cpu, mem int64
+	for _, pod := range resp.Pods {
+		table.Append([]string{pod.Name, formatCPU(pod.Cpu), formatMemory(pod.Memory)})
+		cpu += pod.Cpu
+		mem += pod.Memory
+	}
+	table.SetFooter([]string{"TOTAL", formatCPU(cpu), formatMemory(mem)})
+	table.Render()
+}
+
+func formatCPU(milli int64) string {
+	return fmt.Sprintf("%dm", milli)
+}
+
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMi", bytes/(1024*1024))
+}
+
+func init() {
+	// add AppCmd
+	RootCmd.AddCommand(appCmd)
+	// App commands
+	appCmd.AddCommand(appCreateCmd)
+	appCmd.AddCommand(appListCmd)
+	appCmd.AddCommand(appDelCmd)
+	appCmd.AddCommand(appInfoCmd)
+	appCmd.AddCommand(appEnvSetCmd)
+	appCmd.AddCommand(appEnvUnSetCmd)
+	appCmd.AddCommand(appBuildEnvSetCmd)
+	appCmd.AddCommand(appBuildEnvUnsetCmd)
+	appCmd.AddCommand(appSecretSetCmd)
+	appCmd.AddCommand(appSecretUnSetCmd)
+	appCmd.AddCommand(appLogsCmd)
+	appCmd.AddCommand(appAutoscaleSetCmd)
+	appCmd.AddCommand(appStartCmd)
+	appCmd.AddCommand(appStopCmd)
+	appCmd.AddCommand(appDeletePodsCmd)
+	appCmd.AddCommand(appChangeTeamCmd)
+	appCmd.AddCommand(appSetVHostsCmd)
+	appCmd.AddCommand(appVHostCmd)
+	appVHostCmd.AddCommand(appVHostAddCmd)
+	appVHostCmd.AddCommand(appVHostRemoveCmd)
+	appCmd.AddCommand(appRenameCmd)
+	appCmd.AddCommand(appCloneCmd)
+	appCmd.AddCommand(appScaleCmd)
+	appCmd.AddCommand(appPauseCmd)
+	appCmd.AddCommand(appResumeCmd)
+	appCmd.AddCommand(appLockCmd)
+	appCmd.AddCommand(appUnlockCmd)
+	appCmd.AddCommand(appRestartCmd)
+	appCmd.AddCommand(appMaintenanceCmd)
+	appCmd.AddCommand(appTLSCmd)
+	appCmd.AddCommand(appIngressCmd)
+	appCmd.AddCommand(appACLCmd)
+	appACLCmd.AddCommand(appACLSetCmd)
+	appCmd.AddCommand(appAutoscaleScheduleCmd)
+	appCmd.AddCommand(appSetPDBCmd)
+	appCmd.AddCommand(appSetBuilderCmd)
+	appCmd.AddCommand(appSetLifecycleCmd)
+	appCmd.AddCommand(appSetStrategyCmd)
+	appCmd.AddCommand(appSetRolloutTimeoutCmd)
+	appCmd.AddCommand(appVolumeCmd)
+	appVolumeCmd.AddCommand(appVolumeAddCmd)
+	appVolumeCmd.AddCommand(appVolumeRemoveCmd)
+	appCmd.AddCommand(appConfigFileCmd)
+	appConfigFileCmd.AddCommand(appConfigFileSetCmd)
+	appConfigFileCmd.AddCommand(appConfigFileUnsetCmd)
+	appTLSCmd.AddCommand(appTLSEnableCmd)
+	appCmd.AddCommand(appStatusCmd)
+	appCmd.AddCommand(appEventsCmd)
+	appCmd.AddCommand(appTopCmd)
+	appCmd.AddCommand(appSetLimitsCmd)
+	appCmd.AddCommand(appEnvHistoryCmd)
+	appCmd.AddCommand(appEnvRollbackCmd)
+
+	appLockCmd.Flags().String("reason", "", "why the deploys are locked")
+	appACLSetCmd.Flags().StringArray("allow", nil, "CIDR allowed to access the app, can be repeated")
+	appVolumeAddCmd.Flags().String("size", "", "size of the volume, like 10Gi")
+	appVolumeAddCmd.Flags().String("mount-path", "", "directory to mount the volume in")
+	appVolumeAddCmd.Flags().String("storage-class", "", "storage class of the volume, blank uses the cluster default")
+	appVolumeAddCmd.Flags().String("access-mode", "", "ReadWriteOnce (default) or ReadWriteMany")
+	appVolumeRemoveCmd.Flags().Bool("no-input", false, "remove the volume without warning")
+	appConfigFileSetCmd.Flags().String("file", "", "config file to upload")
+	appSetLifecycleCmd.Flags().Int32("drain-timeout", 10, "seconds to wait before sending SIGTERM to the app")
+	appSetLifecycleCmd.Flags().Int32("grace-period", 0, "seconds to wait for the app to shut down, 0 uses the default")
+	appSetStrategyCmd.Flags().String("max-surge", "", "pods created above the replicas, number or percentage")
+	appSetStrategyCmd.Flags().String("max-unavailable", "", "pods missing during the rollout, number or percentage")
+	appSetRolloutTimeoutCmd.Flags().Duration("timeout", 0, "time the pods have to get ready, 0 uses the one of the cluster")
+	appConfigFileSetCmd.Flags().String("mount-path", "", "directory to mount the file in")
+	appAutoscaleScheduleCmd.Flags().StringArray("window", nil, `scaling window, as "HH:MM-HH:MM min=N [max=N] [days=mon-fri]", can be repeated`)
+
+	appCreateCmd.Flags().String("team", "", "team owner of the app")
+	appCreateCmd.Flags().Int32("scale-min", 1, "minimum number of replicas")
+	appCreateCmd.Flags().Int32("scale-max", 2, "maximum number of replicas")
+	appCreateCmd.Flags().Int32("scale-cpu", 70, "auto scale target cpu percentage to scale")
+	appCreateCmd.Flags().String("cpu", "200m", "allocated pod cpu")
+	appCreateCmd.Flags().String("memory", "512Mi", "allocated pod memory")
+	appCreateCmd.Flags().String("max-cpu", "400m", "when set, allows the pod to burst cpu usage up to 'max-cpu'")
+	appCreateCmd.Flags().String("max-memory", "512Mi", "when set, allows the pod to burst memory usage up to 'max-memory'")
+	appCreateCmd.Flags().String("process-type", "", "app process type")
+	appCreateCmd.Flags().String("vhost", "", "comma separated list of the app's virtual hosts")
+	appCreateCmd.Flags().Bool("internal", false, "create an internal app (without external endpoint)")
+	appCreateCmd.Flags().String("protocol", "", "app protocol: http, h2c (cleartext HTTP/2) or grpc")
+	// shadows the global --cluster, the server is the one of the config
+	appCreateCmd.Flags().String("cluster", "", "Kubernetes cluster of the app, the one of the server by default")
+
+	appEnvSetCmd.Flags().String("app", "", "app name")
+	appEnvSetCmd.Flags().Bool("no-input", false, "set env vars without warning")
+	appEnvSetCmd.Flags().StringP("file", "f", "", "dotenv file with the env vars")
+
+	appEnvUnSetCmd.Flags().String("app", "", "app name")
+	appEnvUnSetCmd.Flags().Bool("no-input", false, "unset env vars without warning")
+
+	appBuildEnvSetCmd.Flags().String("app", "", "app name")
+	appBuildEnvUnsetCmd.Flags().String("app", "", "app name")
+
+	appSecretSetCmd.Flags().String("app", "", "app name")
+	appSecretSetCmd.Flags().Bool("no-input", false, "set env vars without warning")
+	appSecretSetCmd.Flags().StringP("filename", "f", "", "Filename with secret content")
+	appSecretSetCmd.Flags().String("mount-path", "", "directory to mount the secret file in")
+	appSecretSetCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "file" {
+			name = "filename"
+		}
+		return pflag.NormalizedName(name)
+	})
+
+	appSecretUnSetCmd.Flags().String("app", "", "app name")
+	appSecretUnSetCmd.Flags().Bool("no-input", false, "unset env vars without warning")
+	// App logs
+	appLogsCmd.Flags().Int64P("lines", "n", 10, "number of lines, -1 shows all of them (alias --tail)")
+	appLogsCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "tail" {
+			name = "lines"
+		}
+		return pflag.NormalizedName(name)
+	})
+	appLogsCmd.Flags().Duration("since", 0, "only logs newer than a relative duration like 5s, 2m or 3h, from the log store of the server if any")
+	appLogsCmd.Flags().Bool("timestamps", false, "include the timestamp of each line")
+	appLogsCmd.Flags().StringP("output", "o", "text", "output format, text or json (a record by line with timestamp, app, pod, process and line)")
+	appLogsCmd.Flags().BoolP("follow", "f", false, "follow logs")
+	appLogsCmd.Flags().String("pod", "", "filter logs by pod name")
+	appLogsCmd.Flags().BoolP("previous", "p", false, "print the logs of the previous container of the restarted pods")
+	appLogsCmd.Flags().String("container", "", "filter logs by container name")
+	appLogsCmd.Flags().String("process", "", "filter logs by process type, like web or worker")
+	appLogsCmd.Flags().String("grep", "", "show only the lines matching the regular expression")
+	appEventsCmd.Flags().BoolP("follow", "f", false, "follow events")
+	// App autoscale
+	appAutoscaleSetCmd.Flags().Int32("min", flagNotDefined, "Minimum number of replicas")
+	appAutoscaleSetCmd.Flags().Int32("max", flagNotDefined, "Maximum number of replicas")
+	appAutoscaleSetCmd.Flags().Int32("memory-percent", flagNotDefined, "The target average memory utilization (represented as a percent of requested memory) over all the pods, 0 disables it. If it's not specified or negative, the current one will be used.")
+	appAutoscaleSetCmd.Flags().StringArray("metric", nil, "Custom metric of the pods and its target average value, as name=value (can be repeated)")
+	appAutoscaleSetCmd.Flags().Int32("cpu-percent", flagNotDefined, "The target average CPU utilization (represented as a percent of requested CPU) over all the pods. If it's not specified or negative, the current autoscaling policy will be used.")
+	// App Start
+	appStartCmd.Flags().Int32("replicas", 1, "Number of replicas")
+	// App delete-pods
+	appDeletePodsCmd.Flags().String("app", "", "app name")
+	// App clone
+	appCloneCmd.Flags().String("vhost", "", "comma separated list of the new app's virtual hosts")
+	// App set-limits
+	appSetLimitsCmd.Flags().String("cpu", "", "cpu limit of the app containers")
+	appSetLimitsCmd.Flags().String("memory", "", "memory limit of the app containers")
+	appSetLimitsCmd.Flags().String("cpu-request", "", "cpu request of the app containers")
+	appSetLimitsCmd.Flags().String("memory-request", "", "memory request of the app containers")
+	// App env-rollback
+	appEnvRollbackCmd.Flags().Bool("no-input", false, "rollback env vars without warning")
+}
+
+func appLogs(cmd *cobra.Command, args []string) {
+	if len(args) < 1 {
+		cmd.Usage()
+		return
+	}
+
+	lines, err := cmd.Flags().GetInt64("lines")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid lines parameter")
+	}
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid follow parameter")
+	}
+
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid pod parameter")
+	}
+
+	previous, err := cmd.Flags().GetBool("previous")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid previous parameter")
+	}
+
+	container, err := cmd.Flags().GetString("container")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid container parameter")
+	}
+
+	process, err := cmd.Flags().GetString("process")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid process parameter")
+	}
+
+	grep, err := cmd.Flags().GetString("grep")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid grep parameter")
+	}
+
+	since, err := cmd.Flags().GetDuration("since")
+	if err != nil || since < 0 {
+		client.PrintErrorAndExit("Invalid since parameter")
+	}
+	// like kubectl, all the lines since the duration unless limited
+	if since > 0 && !cmd.Flags().Changed("lines") {
+		lines = -1
+	}
+
+	timestamps, err := cmd.Flags().GetBool("timestamps")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid timestamps parameter")
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid output parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.LogsRequest{
+		Name:         args[0],
+		Lines:        lines,
+		Follow:       follow,
+		PodName:      pod,
+		Previous:     previous,
+		Container:    container,
+		Process:      process,
+		Grep:         grep,
+		SinceSeconds: int64(since.Seconds()),
+		Timestamps:   timestamps,
+		Output:       output,
+	}
+	if len(args) > 1 {
+		if !multiAppLogs(cli, args, req, os.Stdout, os.Stderr) {
+			conn.Close()
+			os.Exit(1)
+		}
+		return
+	}
+	err = streamLogs(cli, req, func(text string) {
+		fmt.Println(text)
+	})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+}
+
+var appEventsCmd = &cobra.Command{
+	Use:   "events <name>",
+	Short: "Show app events",
+	Long: `Show the Kubernetes events of the app, like image pull failures,
+OOMKills and scheduling problems.`,
+	Example: `  $ teresa app events foo
+
+  To keep waiting for new events:
+
+  $ teresa app events foo --follow`,
+	Run: appEvents,
+}
+
+func appEvents(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid follow parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.EventsRequest{Name: appName, Follow: follow}
+	stream, err := cli.Events(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return
+			}
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		lastSeen := "n/a"
+		if ev.LastSeen != 0 {
+			lastSeen = shortHumanDuration(time.Since(time.Unix(ev.LastSeen, 0)))
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\t%s (x%d)\n", lastSeen, ev.Type, ev.Reason, ev.Object, ev.Message, ev.Count)
+	}
 }
 
 var appDeletePodsCmd = &cobra.Command{
@@ -1058,7 +2472,10 @@ func appDeletePods(cmd *cobra.Command, args []string) {
 var appChangeTeamCmd = &cobra.Command{
 	Use:   "change-team <app-name> <team-name>",
 	Short: "Change app team",
-	Long:  "Change app team",
+	Long: `Change app team.
+
+Only admins or members of both the current and the new team can move
+an app between teams.`,
 	Example: `  To change myapp team to myteam:
 
   $ teresa app change-team myapp myteam`,
@@ -1124,6 +2541,220 @@ func appSetVHosts(cmd *cobra.Command, args []string) {
 	fmt.Println("Virtual hosts updated with success")
 }
 
+var appVHostCmd = &cobra.Command{
+	Use:   "vhost",
+	Short: "Add or remove a single vhost of the app",
+	Long: `Add or remove a single vhost of the app on clusters with ingress integration.
+
+  The vhost must belong to one of the domains allowed for the app's team,
+  if the team has any.`,
+}
+
+var appVHostAddCmd = &cobra.Command{
+	Use:     "add <name> <vhost>",
+	Short:   "Add a vhost to the app",
+	Example: "  $ teresa app vhost add myapp myapp.mydomain",
+	Run:     appVHostAdd,
+}
+
+func appVHostAdd(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName, vHost := args[0], args[1]
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+	req := &appb.VHostRequest{AppName: appName, Vhost: vHost}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.AddVHost(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Virtual host %s added with success\n", color.CyanString(vHost))
+}
+
+var appVHostRemoveCmd = &cobra.Command{
+	Use:     "remove <name> <vhost>",
+	Short:   "Remove a vhost from the app",
+	Example: "  $ teresa app vhost remove myapp myapp.mydomain",
+	Run:     appVHostRemove,
+}
+
+func appVHostRemove(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName, vHost := args[0], args[1]
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+	req := &appb.VHostRequest{AppName: appName, Vhost: vHost}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.RemoveVHost(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Virtual host %s removed with success\n", color.CyanString(vHost))
+}
+
+var appRenameCmd = &cobra.Command{
+	Use:   "rename <old-name> <new-name>",
+	Short: "Rename an app",
+	Long: `Rename an app.
+
+The app is moved to a new namespace with the same env vars, secrets,
+limits, autoscale and vhosts. The old one keeps serving until the pods of
+the new one are ready and is removed afterwards.`,
+	Example: "  $ teresa app rename myap myapp",
+	Run:     appRename,
+}
+
+func appRename(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	oldName, newName := args[0], args[1]
+
+	currentClusterName, err := getClusterName()
+	if err != nil {
+		client.PrintErrorAndExit("error reading config file: %v", err)
+	}
+
+	inputMsg := fmt.Sprintf(
+		"Are you sure you want to rename %s to %s on %s? (yes/NO) ",
+		color.CyanString(oldName),
+		color.CyanString(newName),
+		color.YellowString(currentClusterName),
+	)
+	s, _ := client.GetInput(inputMsg)
+	if s != "yes" {
+		fmt.Println("Rename process aborted!")
+		return
+	}
+
+	conn, err := connection.New(cfgFile, currentClusterName)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &appb.RenameRequest{OldName: oldName, NewName: newName}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Rename(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("The app %s was renamed to %s\n", oldName, newName)
+}
+
+var appCloneCmd = &cobra.Command{
+	Use:   "clone <src-name> <dst-name>",
+	Short: "Clone an app",
+	Long: `Create a new app with the env vars, secrets, limits, autoscale and team
+of an existing one.
+
+The new app doesn't run anything until it's deployed.`,
+	Example: `  $ teresa app clone myapp myapp-staging
+
+  On clusters with ingress integration you must provide the new app's vhost:
+
+  $ teresa app clone myapp myapp-staging --vhost staging.myapp.mydomain`,
+	Run: appClone,
+}
+
+func appClone(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	srcName, dstName := args[0], args[1]
+
+	vHost, err := cmd.Flags().GetString("vhost")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid vhost parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &appb.CloneRequest{
+		SrcName:     srcName,
+		DstName:     dstName,
+		VirtualHost: vHost,
+	}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Clone(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("The app %s was cloned to %s\n", srcName, dstName)
+}
+
+var appScaleCmd = &cobra.Command{
+	Use:   "scale <name> <process-type>=<replicas> [<process-type>=<replicas>, ...]",
+	Short: "Scale the process types of an app",
+	Long: `Set the number of replicas of each process type of an app.
+
+Process types other than the app's own run in separate deploys, one for
+each Procfile entry. They are created on deploy with one replica and
+deleted when removed from the Procfile.`,
+	Example: `  $ teresa app scale myapp worker=3
+
+  You can also scale more than one process type at a time:
+
+  $ teresa app scale myapp worker=3 web=5`,
+	Run: appScale,
+}
+
+func parseProcessReplicas(args []string) (map[string]int32, error) {
+	replicas := make(map[string]int32)
+	for _, arg := range args {
+		tmp := strings.SplitN(arg, "=", 2)
+		if len(tmp) != 2 || tmp[0] == "" {
+			return nil, fmt.Errorf("invalid process type and replicas: %s", arg)
+		}
+		n, err := strconv.ParseInt(tmp[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number of replicas: %s", arg)
+		}
+		replicas[tmp[0]] = int32(n)
+	}
+	return replicas, nil
+}
+
+func appScale(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	replicas, err := parseProcessReplicas(args[1:])
+	if err != nil {
+		client.PrintErrorAndExit(err.Error())
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &appb.ScaleRequest{Name: appName, Replicas: replicas}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.Scale(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("App scaled with success")
+}
+
 // Shamelessly copied from Kubernetes
 func shortHumanDuration(d time.Duration) string {
 	// Allow deviation no more than 2 seconds(excluded) to tolerate machine time
@@ -1168,3 +2799,203 @@ func newLimits(cpu, maxCPU, mem, maxMem string) *appb.CreateRequest_Limits {
 		},
 	}
 }
+
+var appSetLimitsCmd = &cobra.Command{
+	Use:   "set-limits <name>",
+	Short: "Set the app resource limits",
+	Long: `Set the CPU and memory limits and requests of the app containers.
+
+The deploys are updated in place, without the need of a new deploy.
+Omitted values keep their current setting.`,
+	Example: `  $ teresa app set-limits myapp --cpu 500m --memory 512Mi
+
+  You can also set the requests:
+
+  $ teresa app set-limits myapp --cpu 500m --cpu-request 100m --memory-request 256Mi`,
+	Run: appSetLimits,
+}
+
+func appSetLimits(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	cpu, err := cmd.Flags().GetString("cpu")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid cpu parameter")
+	}
+
+	mem, err := cmd.Flags().GetString("memory")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid memory parameter")
+	}
+
+	cpuReq, err := cmd.Flags().GetString("cpu-request")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid cpu-request parameter")
+	}
+
+	memReq, err := cmd.Flags().GetString("memory-request")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid memory-request parameter")
+	}
+
+	if cpu == "" && mem == "" && cpuReq == "" && memReq == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &appb.SetResourcesRequest{
+		Name:          appName,
+		Cpu:           cpu,
+		Memory:        mem,
+		CpuRequest:    cpuReq,
+		MemoryRequest: memReq,
+	}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.SetResources(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Limits updated with success")
+}
+
+var appEnvHistoryCmd = &cobra.Command{
+	Use:   "env-history <name> [version]",
+	Short: "Show the env vars history of the app",
+	Long: `Show every change of the app env vars, with the author and age.
+
+Provide a version to see all the env vars of that revision.`,
+	Example: `  $ teresa app env-history myapp
+
+  $ teresa app env-history myapp 3`,
+	Run: appEnvHistory,
+}
+
+func appEnvHistory(cmd *cobra.Command, args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	var version int64
+	if len(args) == 2 {
+		v, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil || v <= 0 {
+			client.PrintErrorAndExit("Invalid version: %s", args[1])
+		}
+		version = v
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	resp, err := cli.EnvHistory(context.Background(), &appb.EnvHistoryRequest{Name: appName})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if version > 0 {
+		for _, rev := range resp.Revisions {
+			if int64(rev.Version) != version {
+				continue
+			}
+			sort.Slice(rev.EnvVars, func(i, j int) bool {
+				return rev.EnvVars[i].Key < rev.EnvVars[j].Key
+			})
+			for _, ev := range rev.EnvVars {
+				fmt.Printf("%s=%s\n", ev.Key, ev.Value)
+			}
+			return
+		}
+		client.PrintErrorAndExit("Version %d not found", version)
+	}
+
+	if len(resp.Revisions) == 0 {
+		fmt.Println("There are no env vars changes recorded for the app")
+		return
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"VERSION", "AUTHOR", "AGE", "ENV VARS"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, rev := range resp.Revisions {
+		keys := make([]string, len(rev.EnvVars))
+		for i, ev := range rev.EnvVars {
+			keys[i] = ev.Key
+		}
+		table.Append([]string{
+			strconv.Itoa(int(rev.Version)),
+			rev.Author,
+			shortHumanDuration(time.Since(time.Unix(rev.CreatedAt, 0))),
+			strings.Join(keys, ", "),
+		})
+	}
+	table.Render()
+}
+
+var appEnvRollbackCmd = &cobra.Command{
+	Use:   "env-rollback <name> <version>",
+	Short: "Rollback the app env vars to a previous version",
+	Long: `Restore the env vars of the app as they were in the given version.
+
+Env vars created after that version are removed. Use the env-history
+command to see the available versions.`,
+	Example: "  $ teresa app env-rollback myapp 3",
+	Run:     appEnvRollback,
+}
+
+func appEnvRollback(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	version, err := strconv.ParseInt(args[1], 10, 32)
+	if err != nil || version <= 0 {
+		client.PrintErrorAndExit("Invalid version: %s", args[1])
+	}
+
+	noinput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+	fmt.Printf(
+		"Rolling back env vars to version %d and %s %s...\n",
+		version,
+		color.YellowString("restarting"),
+		color.CyanString(`"%s"`, appName),
+	)
+	if !noinput {
+		s, _ := client.GetInput("Are you sure? (yes/NO)? ")
+		if s != "yes" {
+			return
+		}
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &appb.EnvRollbackRequest{Name: appName, Version: int32(version)}
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.EnvRollback(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Env vars rolled back with success")
+}
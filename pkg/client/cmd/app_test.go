@@ -1,6 +1,11 @@
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
 
 func TestValidateFlags(t *testing.T) {
 
@@ -27,3 +32,103 @@ func TestValidateFlags(t *testing.T) {
 		}
 	}
 }
+
+func TestParseProcessReplicas(t *testing.T) {
+	replicas, err := parseProcessReplicas([]string{"worker=3", "web=5"})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if replicas["worker"] != 3 || replicas["web"] != 5 {
+		t.Errorf("expected worker=3 and web=5, got %v", replicas)
+	}
+}
+
+func TestParseProcessReplicasInvalid(t *testing.T) {
+	for _, arg := range []string{"worker", "=3", "worker=three", "worker="} {
+		if _, err := parseProcessReplicas([]string{arg}); err == nil {
+			t.Errorf("expected error, got nil [case: %s]", arg)
+		}
+	}
+}
+
+func TestParseIngressOptions(t *testing.T) {
+	opts, err := parseIngressOptions([]string{"proxy-body-size=10m", "class="})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(opts) != 2 || opts[0].Key != "proxy-body-size" || opts[0].Value != "10m" || opts[1].Value != "" {
+		t.Errorf("expected proxy-body-size=10m and class=, got %v", opts)
+	}
+}
+
+func TestParseIngressOptionsInvalid(t *testing.T) {
+	for _, arg := range []string{"class", "=nginx"} {
+		if _, err := parseIngressOptions([]string{arg}); err == nil {
+			t.Errorf("expected error, got nil [case: %s]", arg)
+		}
+	}
+}
+
+func TestParseAutoscaleMetrics(t *testing.T) {
+	metrics, err := parseAutoscaleMetrics([]string{"queue_depth=30", ""})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(metrics) != 1 || metrics[0].Name != "queue_depth" || metrics[0].TargetAverageValue != "30" {
+		t.Errorf("expected queue_depth=30, got %v", metrics)
+	}
+}
+
+func TestParseAutoscaleMetricsInvalid(t *testing.T) {
+	for _, arg := range []string{"queue_depth", "=30", "queue_depth="} {
+		if _, err := parseAutoscaleMetrics([]string{arg}); err == nil {
+			t.Errorf("expected error, got nil [case: %s]", arg)
+		}
+	}
+}
+
+func TestPrepareEnvAndSecretSetDedupKeys(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("app", "teresa", "")
+	cmd.Flags().Bool("no-input", true, "")
+
+	req, err := prepareEnvAndSecretSet("Env vars", "test", cmd, []string{"FOO=bar", "BAR=foo", "FOO=baz"})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(req.EnvVars) != 2 {
+		t.Fatalf("expected 2 env vars, got %d", len(req.EnvVars))
+	}
+	if ev := req.EnvVars[0]; ev.Key != "FOO" || ev.Value != "baz" {
+		t.Errorf("expected FOO=baz, got %s=%s", ev.Key, ev.Value)
+	}
+}
+
+func TestParseScaleWindow(t *testing.T) {
+	w, err := parseScaleWindow("22:00-06:00 min=2 max=4 days=fri-sun,tue")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if w.Start != "22:00" || w.End != "06:00" || w.Min != 2 || w.Max != 4 {
+		t.Errorf("expected 22:00-06:00 min=2 max=4, got %v", w)
+	}
+	want := []string{"fri", "sat", "sun", "tue"}
+	if !reflect.DeepEqual(w.Days, want) {
+		t.Errorf("expected %v, got %v", want, w.Days)
+	}
+}
+
+func TestParseScaleWindowInvalid(t *testing.T) {
+	for _, arg := range []string{
+		"08:00-20:00",
+		"08:00 min=2",
+		"08:00-20:00 max=4",
+		"08:00-20:00 min=two",
+		"08:00-20:00 min=2 days=monday",
+		"08:00-20:00 min=2 foo=bar",
+	} {
+		if _, err := parseScaleWindow(arg); err == nil {
+			t.Errorf("expected error, got nil [case: %s]", arg)
+		}
+	}
+}
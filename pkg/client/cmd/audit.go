@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	context "golang.org/x/net/context"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	auditpb "github.com/luizalabs/teresa/pkg/protobuf/audit"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log",
+	Long: `Show the audit log, the changes made by the users, newest first.
+
+Every call changing apps, teams, users and deploys is recorded with its
+author, target and result. Only admins can see the whole audit log, the
+admins of a team can see the entries of the team with 'teresa team audit'.
+
+The --since and --until flags take a time like "2026-12-20 15:04" (local
+time) or a duration before now, like 30m or 24h.`,
+	Example: `  $ teresa audit --since 24h
+  $ teresa audit --user gopher@luizalabs.com --since "2026-12-20 00:00" --until "2026-12-21 00:00"`,
+	Run: audit,
+}
+
+var teamAuditCmd = &cobra.Command{
+	Use:   "audit <team-name>",
+	Short: "Show the audit log of a team",
+	Long: `Show the changes made to the team and its apps, newest first.
+
+Only admins and the admins of the team can see its audit log. The --since
+and --until flags take a time like "2026-12-20 15:04" (local time) or a
+duration before now, like 30m or 24h.`,
+	Example: "$ teresa team audit foo --since 24h --user gopher@luizalabs.com",
+	Run:     teamAudit,
+}
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+	teamCmd.AddCommand(teamAuditCmd)
+
+	for _, cmd := range []*cobra.Command{auditCmd, teamAuditCmd} {
+		cmd.Flags().String("user", "", "only the entries of the user (email)")
+		cmd.Flags().String("since", "", "only the entries from this time on")
+		cmd.Flags().String("until", "", "only the entries before this time")
+		cmd.Flags().Int32("limit", 100, "max number of entries")
+	}
+}
+
+func audit(cmd *cobra.Command, args []string) {
+	listAudit(cmd, "")
+}
+
+func teamAudit(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	listAudit(cmd, args[0])
+}
+
+// parseAuditTime parses the time of the --since and --until flags, a local
+// time or a duration before now
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("negative duration %s", value)
+		}
+		return now.Add(-d), nil
+	}
+	return time.ParseInLocation(freezeTimeLayout, value, time.Local)
+}
+
+func listAudit(cmd *cobra.Command, team string) {
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid user parameter")
+	}
+	limit, err := cmd.Flags().GetInt32("limit")
+	if err != nil || limit < 1 {
+		client.PrintErrorAndExit("Invalid limit parameter")
+	}
+
+	req := &auditpb.ListRequest{Team: team, User: user, Limit: limit}
+	now := time.Now()
+	for name, dst := range map[string]*int64{"since": &req.Since, "until": &req.Until} {
+		value, err := cmd.Flags().GetString(name)
+		if err != nil {
+			client.PrintErrorAndExit("Invalid %s parameter", name)
+		}
+		if value == "" {
+			continue
+		}
+		t, err := parseAuditTime(value, now)
+		if err != nil {
+			client.PrintErrorAndExit("Invalid %s parameter, use a duration like 24h or the format %s", name, freezeTimeLayout)
+		}
+		*dst = t.Unix()
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := auditpb.NewAuditClient(conn)
+	resp, err := cli.List(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if len(resp.Entries) == 0 {
+		fmt.Println("No entries found")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"TIME", "USER", "METHOD", "TEAM", "APP", "STATUS", "REQUEST"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, e := range resp.Entries {
+		user := e.User
+		if e.Impersonator != "" {
+			user = fmt.Sprintf("%s (by %s)", e.User, e.Impersonator)
+		}
+		table.Append([]string{
+			time.Unix(e.CreatedAt, 0).Format(freezeTimeLayout),
+			user,
+			e.Method,
+			e.Team,
+			e.App,
+			e.Status,
+			e.Request,
+		})
+	}
+	table.Render()
+}
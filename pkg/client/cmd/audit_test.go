@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAuditTime(t *testing.T) {
+	now := time.Date(2026, 12, 20, 15, 0, 0, 0, time.Local)
+
+	got, err := parseAuditTime("24h", now)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := now.Add(-24 * time.Hour); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	got, err = parseAuditTime("2026-12-19 08:30", now)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := time.Date(2026, 12, 19, 8, 30, 0, 0, time.Local); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	for _, value := range []string{"-1h", "yesterday", "2026-12-19"} {
+		if _, err := parseAuditTime(value, now); err == nil {
+			t.Errorf("expected error for %s", value)
+		}
+	}
+}
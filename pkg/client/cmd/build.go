@@ -16,6 +16,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/client/connection"
 	"github.com/luizalabs/teresa/pkg/client/tar"
 	bpb "github.com/luizalabs/teresa/pkg/protobuf/build"
+	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
 )
 
 var buildCmd = &cobra.Command{
@@ -66,6 +67,30 @@ var buildDeleteCmd = &cobra.Command{
 	Run: buildDelete,
 }
 
+var buildClearCacheCmd = &cobra.Command{
+	Use:   "clear-cache <app-name>",
+	Short: "Clear the build cache of an app",
+	Long: `Clear the build cache of an app.
+
+The cache of the buildpacks (e.g. node_modules, .m2) is kept between builds,
+the next build after clearing it starts from scratch. The layer cache image
+of the Cloud Native Buildpacks builds is deleted from the registry too.`,
+	Example: "	$ teresa build clear-cache myapp",
+	Run: buildClearCache,
+}
+
+var buildPromoteCmd = &cobra.Command{
+	Use:   "promote <app-name> <build-name>",
+	Short: "Deploy a build to another app",
+	Long: `Deploy the slug of a build to another app without building it again.
+
+The same slug runs in both apps, e.g. the one tested on staging goes to
+production. The Procfile and teresa.yaml of the build apply to the target
+app.`,
+	Example: "	$ teresa build promote myapp-staging v1-0-0 --to myapp",
+	Run: buildPromote,
+}
+
 func buildApp(cmd *cobra.Command, args []string) {
 	if len(args) == 0 {
 		cmd.Usage()
@@ -131,6 +156,11 @@ func buildApp(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
+	checksum, err := uploadTarball(conn, appName, tarPath)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
 	ctx := context.Background()
 
 	cli := bpb.NewBuildClient(conn)
@@ -140,16 +170,21 @@ func buildApp(cmd *cobra.Command, args []string) {
 	}
 
 	info := &bpb.BuildRequest{Value: &bpb.BuildRequest_Info_{&bpb.BuildRequest_Info{
-		App:  appName,
-		Name: buildName,
-		Run:  runApp,
+		App:            appName,
+		Name:           buildName,
+		Run:            runApp,
+		UploadChecksum: checksum,
 	}}}
 	if err := stream.Send(info); err != nil {
 		client.PrintErrorAndExit("Error sending build information: %v", err)
 	}
 
 	g, _ := errgroup.WithContext(ctx)
-	g.Go(func() error { return sendBuildTarball(tarPath, stream) })
+	if checksum == "" {
+		g.Go(func() error { return sendBuildTarball(tarPath, stream) })
+	} else {
+		stream.CloseSend()
+	}
 	g.Go(func() error { return streamServerBuildMsgs(stream) })
 
 	if err := g.Wait(); err != nil {
@@ -334,6 +369,88 @@ func buildDelete(cmd *cobra.Command, args []string) {
 	fmt.Printf("Build %s deleted!\n", buildName)
 }
 
+func buildPromote(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName, buildName := args[0], args[1]
+
+	toApp, err := cmd.Flags().GetString("to")
+	if err != nil || toApp == "" {
+		client.PrintErrorAndExit("Invalid to parameter")
+	}
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid description parameter")
+	}
+	noInput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+	canaryWeight, blueGreen := deployStrategyFlags(cmd)
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
+	currentClusterName := currentClusterNameOrExit()
+	fmt.Printf(
+		"Deploying the build %s of %s to app %s on cluster %s...\n",
+		color.CyanString(`"%s"`, buildName),
+		color.CyanString(`"%s"`, appName),
+		color.CyanString(`"%s"`, toApp),
+		color.YellowString(`"%s"`, currentClusterName),
+	)
+	if !noInput {
+		readStdinYesOrExit()
+	}
+
+	conn, err := connection.New(cfgFile, currentClusterName)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.PromoteBuildRequest{
+		AppName:      appName,
+		BuildName:    buildName,
+		ToApp:        toApp,
+		Description:  description,
+		CanaryWeight: canaryWeight,
+		BlueGreen:    blueGreen,
+		Force:        force,
+	}
+	stream, err := cli.PromoteBuild(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if err := streamServerMsgs(stream); err != nil {
+		client.PrintServerErrorAndExit(err)
+	}
+}
+
+func buildClearCache(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := bpb.NewBuildClient(conn)
+	req := &bpb.ClearCacheRequest{AppName: args[0]}
+	if _, err := cli.ClearCache(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Build cache cleared with success")
+}
+
 func init() {
 	RootCmd.AddCommand(buildCmd)
 
@@ -341,8 +458,18 @@ func init() {
 	buildCmd.AddCommand(buildListCmd)
 	buildCmd.AddCommand(buildRunCmd)
 	buildCmd.AddCommand(buildDeleteCmd)
+	buildCmd.AddCommand(buildClearCacheCmd)
+	buildCmd.AddCommand(buildPromoteCmd)
 
 	buildCreateCmd.Flags().String("app", "", "app name (required)")
 	buildCreateCmd.Flags().String("name", "", "build name (required)")
 	buildCreateCmd.Flags().Bool("run", false, "run build in an isolate replica with a temporary service")
+
+	buildPromoteCmd.Flags().String("to", "", "app to deploy the build to (required)")
+	buildPromoteCmd.Flags().String("description", "", "deploy description")
+	buildPromoteCmd.Flags().Bool("no-input", false, "deploy without warning")
+	buildPromoteCmd.Flags().Bool("canary", false, "deploy alongside the current release")
+	buildPromoteCmd.Flags().Int32("weight", 10, "percent of the traffic of the canary deploy")
+	buildPromoteCmd.Flags().Bool("blue-green", false, "deploy aside the current release, without traffic until switched")
+	buildPromoteCmd.Flags().Bool("force", false, "deploy even if the app is locked or frozen (admins only)")
 }
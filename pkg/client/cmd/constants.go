@@ -5,4 +5,5 @@ var (
 	cfgFile    string
 	cfgCluster string
 	debugFlag  bool
+	asUser     string
 )
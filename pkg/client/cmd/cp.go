@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	context "golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	"github.com/luizalabs/teresa/pkg/client/tar"
+	execpb "github.com/luizalabs/teresa/pkg/protobuf/exec"
+)
+
+var appCopyCmd = &cobra.Command{
+	Use:   "cp <app-name>:<path> <local-path> | <local-path> <app-name>:<path>",
+	Short: "Copy files from and to a pod of the app",
+	Long: `Copy files and directories from and to a running pod of the app.
+
+The files are copied through the Teresa server, there is no need of access to
+the Kubernetes cluster. The container of the app must have tar. To copy into
+a directory of the pod end the path with a slash.`,
+	Example: `  Copy a heap dump of the app:
+
+  $ teresa app cp foo:/tmp/heap.hprof ./heap.hprof
+
+  Copy a local directory into /tmp of the pod:
+
+  $ teresa app cp ./scripts foo:/tmp/`,
+	Run: appCopy,
+}
+
+func init() {
+	appCmd.AddCommand(appCopyCmd)
+}
+
+// parseCopyArg returns the app and the path of a remote path like
+// foo:/tmp, the app is blank for a local path
+func parseCopyArg(arg string) (string, string) {
+	i := strings.Index(arg, ":")
+	// a single letter is a windows drive like C:\
+	if i <= 1 || strings.ContainsAny(arg[:i], `/\`) {
+		return "", arg
+	}
+	return arg[:i], arg[i+1:]
+}
+
+func appCopy(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	srcApp, src := parseCopyArg(args[0])
+	dstApp, dst := parseCopyArg(args[1])
+	if (srcApp == "") == (dstApp == "") || src == "" || dst == "" {
+		client.PrintErrorAndExit("One of the paths must be a path of the app, like foo:/tmp")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := execpb.NewExecClient(conn)
+	if srcApp != "" {
+		err = copyFromApp(cli, srcApp, src, dst)
+	} else {
+		err = copyToApp(cli, dstApp, src, dst)
+	}
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Copied with success")
+}
+
+func copyFromApp(cli execpb.ExecClient, appName, src, dst string) error {
+	req := &execpb.CopyFromRequest{AppName: appName, Path: src}
+	stream, err := cli.CopyFrom(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	name := path.Base(src)
+	if info, err := os.Stat(dst); err == nil && info.IsDir() {
+		dst = filepath.Join(dst, name)
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				w.CloseWithError(err)
+				return
+			}
+			if _, err := w.Write(msg.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	err = tar.Extract(r, name, dst)
+	r.CloseWithError(err)
+	return err
+}
+
+func copyToApp(cli execpb.ExecClient, appName, src, dst string) error {
+	if _, err := os.Stat(src); err != nil {
+		return err
+	}
+	dir, name := path.Dir(dst), path.Base(dst)
+	if strings.HasSuffix(dst, "/") {
+		dir, name = dst, filepath.Base(src)
+	}
+
+	stream, err := cli.CopyTo(context.Background())
+	if err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+	defer r.Close()
+	go func() {
+		w.CloseWithError(tar.Write(w, src, name))
+	}()
+
+	req := &execpb.CopyToRequest{AppName: appName, Path: dir}
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			req.Data = buf[:n]
+			if err := stream.Send(req); err != nil {
+				break
+			}
+			req = &execpb.CopyToRequest{}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import "testing"
+
+func TestParseCopyArg(t *testing.T) {
+	var testCases = []struct {
+		arg     string
+		appName string
+		path    string
+	}{
+		{"foo:/tmp/heap.hprof", "foo", "/tmp/heap.hprof"},
+		{"foo:", "foo", ""},
+		{"./heap.hprof", "", "./heap.hprof"},
+		{`C:\heap.hprof`, "", `C:\heap.hprof`},
+		{"./dir:with-colon", "", "./dir:with-colon"},
+	}
+
+	for _, tc := range testCases {
+		appName, path := parseCopyArg(tc.arg)
+		if appName != tc.appName || path != tc.path {
+			t.Errorf("expected %s and %s, got %s and %s [case: %s]", tc.appName, tc.path, appName, path, tc.arg)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+	"github.com/luizalabs/teresa/pkg/server/app"
+)
+
+var appCronCmd = &cobra.Command{
+	Use:   "cron",
+	Short: "Manage the cronjob of an app",
+	Long: `Manage the cronjob of an app.
+
+The app must be a cronjob, see the process type of the app.`,
+}
+
+var appCronListCmd = &cobra.Command{
+	Use:     "list <app-name>",
+	Short:   "Show the schedule and the last run of the cronjob",
+	Example: "$ teresa app cron list foo",
+	Run:     appCronList,
+}
+
+var appCronSuspendCmd = &cobra.Command{
+	Use:     "suspend <app-name>",
+	Short:   "Suspend the cronjob",
+	Long:    "Suspend the cronjob, no new runs are scheduled until it's resumed.",
+	Example: "$ teresa app cron suspend foo",
+	Run:     appCronSuspend,
+}
+
+var appCronResumeCmd = &cobra.Command{
+	Use:     "resume <app-name>",
+	Short:   "Resume a suspended cronjob",
+	Example: "$ teresa app cron resume foo",
+	Run:     appCronResume,
+}
+
+var appCronRunNowCmd = &cobra.Command{
+	Use:     "run-now <app-name>",
+	Short:   "Trigger a manual run of the cronjob",
+	Long:    "Trigger a manual run of the cronjob, outside of its schedule.",
+	Example: "$ teresa app cron run-now foo",
+	Run:     appCronRunNow,
+}
+
+var appCronRunsCmd = &cobra.Command{
+	Use:     "runs <app-name> [process-type]",
+	Short:   "Show the recent runs of the cronjob",
+	Long:    "Show the recent runs of the cronjob with their status, duration and exit code.",
+	Example: "$ teresa app cron runs foo cron",
+	Run:     appCronRuns,
+}
+
+var appCronLogsCmd = &cobra.Command{
+	Use:   "logs <app-name> [process-type] --run <run-name>",
+	Short: "Show the logs of a run of the cronjob",
+	Example: `  To see the logs of the run foo-1500000000, see the runs with "teresa app cron runs":
+
+  $ teresa app cron logs foo cron --run foo-1500000000`,
+	Run: appCronLogs,
+}
+
+func init() {
+	appCmd.AddCommand(appCronCmd)
+	appCronCmd.AddCommand(appCronListCmd)
+	appCronCmd.AddCommand(appCronSuspendCmd)
+	appCronCmd.AddCommand(appCronResumeCmd)
+	appCronCmd.AddCommand(appCronRunNowCmd)
+	appCronCmd.AddCommand(appCronRunsCmd)
+	appCronCmd.AddCommand(appCronLogsCmd)
+
+	appCronLogsCmd.Flags().String("run", "", "name of the run")
+	appCronLogsCmd.Flags().Int64P("lines", "n", 10, "number of lines")
+	appCronLogsCmd.Flags().BoolP("follow", "f", false, "follow logs")
+}
+
+func appCronList(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	resp, err := cli.CronList(context.Background(), &appb.CronRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "SCHEDULE", "SUSPENDED", "LAST RUN", "ACTIVE"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, c := range resp.Crons {
+		lastRun := "never"
+		if c.LastSchedule != 0 {
+			lastRun = shortHumanDuration(time.Since(time.Unix(c.LastSchedule, 0)))
+		}
+		table.Append([]string{
+			c.Name,
+			c.Schedule,
+			strconv.FormatBool(c.Suspended),
+			lastRun,
+			strconv.Itoa(int(c.Active)),
+		})
+	}
+	table.Render()
+}
+
+func appCronSuspend(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.CronSuspend(context.Background(), &appb.CronRequest{Name: args[0]}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Cronjob suspended with success")
+}
+
+func appCronResume(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	if _, err := cli.CronResume(context.Background(), &appb.CronRequest{Name: args[0]}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Cronjob resumed with success")
+}
+
+func appCronRunNow(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	resp, err := cli.CronRunNow(context.Background(), &appb.CronRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Cronjob triggered with success, job:", resp.JobName)
+}
+
+// cronProcess returns the optional process type argument of the cron commands
+func cronProcess(args []string) string {
+	if len(args) > 1 {
+		return args[1]
+	}
+	return ""
+}
+
+func appCronRuns(cmd *cobra.Command, args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.CronRunsRequest{Name: args[0], Process: cronProcess(args)}
+	resp, err := cli.CronRuns(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Runs) == 0 {
+		fmt.Println("The cronjob didn't run yet")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"RUN", "STATUS", "STARTED", "DURATION", "EXIT CODE"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, r := range resp.Runs {
+		started, exitCode := "n/a", "n/a"
+		if r.StartTime != 0 {
+			started = shortHumanDuration(time.Since(time.Unix(r.StartTime, 0)))
+		}
+		if r.Status != app.CronRunStatusRunning {
+			exitCode = strconv.Itoa(int(r.ExitCode))
+		}
+		table.Append([]string{
+			r.Name,
+			r.Status,
+			started,
+			shortHumanDuration(time.Duration(r.DurationSeconds) * time.Second),
+			exitCode,
+		})
+	}
+	table.Render()
+}
+
+func appCronLogs(cmd *cobra.Command, args []string) {
+	run, err := cmd.Flags().GetString("run")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid run parameter")
+	}
+	if len(args) < 1 || len(args) > 2 || run == "" {
+		cmd.Usage()
+		return
+	}
+
+	lines, err := cmd.Flags().GetInt64("lines")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid lines parameter")
+	}
+
+	follow, err := cmd.Flags().GetBool("follow")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid follow parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.CronRunLogsRequest{
+		Name:    args[0],
+		Process: cronProcess(args),
+		Run:     run,
+		Lines:   lines,
+		Follow:  follow,
+	}
+	stream, err := cli.CronRunLogs(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return
+			}
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		fmt.Println(msg.Text)
+	}
+}
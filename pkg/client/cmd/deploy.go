@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
@@ -55,26 +56,105 @@ eg.:
   $ teresa deploy create /my/path/webapi.tgz --app webapi --description "release 1.2 with new checkout"
 
   $ teresa deploy create 'https://api.github.com/repos/owner/webapi/tarball/v1.0?access_token=xxx' --app webapi --description "release 1.0"
+
+With --canary the release is deployed alongside the current one, taking
+--weight percent of the traffic of the app ingress until it's either
+promoted or aborted:
+
+  $ teresa deploy create . --app webapi --description "release 1.3" --canary --weight 10
+
+With --blue-green the release is deployed aside the current one, without
+traffic until "teresa deploy switch" sends all of it to the new pods:
+
+  $ teresa deploy create . --app webapi --description "release 1.3" --blue-green
+
+The git commit, branch and message of the release can be given too, they're
+shown by "teresa deploy list" and "teresa app info":
+
+  $ teresa deploy create . --app webapi --commit $(git rev-parse HEAD) --branch master --message "$(git log -1 --format=%s)"
+
+With --dry-run nothing is built or deployed, the diff of the Deployment,
+Service and Ingress of the app against the live ones is shown instead:
+
+  $ teresa deploy create . --app webapi --dry-run
 	`,
 	Run: deployApp,
 }
 
 var deployListCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "List app deploys",
-	Long:    "Return all deploys from a given app.",
-	Example: "  $ teresa deploy list --app myapp",
+	Use:   "list",
+	Short: "List app deploys",
+	Long: `Return all deploys from a given app.
+
+With --details the git branch and message, slug, image and env vars of each
+revision are shown too.`,
+	Example: "  $ teresa deploy list --app myapp --details",
 	Run:     deployList,
 }
 
 var deployRollbackCmd = &cobra.Command{
-	Use:     "rollback",
-	Short:   "rollback app to a given revision",
-	Long:    "Rollback an application to a given revision.",
+	Use:   "rollback",
+	Short: "rollback app to a given revision",
+	Long: `Rollback an application to a given revision, restoring its slug and env vars.
+
+Without --revision the app goes back to the revision before the current one.`,
 	Example: "  $ teresa deploy rollback myapp --revision 1",
 	Run:     deployRollback,
 }
 
+var deployPromoteCmd = &cobra.Command{
+	Use:     "promote <app name>",
+	Short:   "promote the canary deploy of an app",
+	Long:    "Roll out the canary deploy of an application, taking all the traffic.",
+	Example: "  $ teresa deploy promote myapp",
+	Run:     deployPromote,
+}
+
+var deployAbortCmd = &cobra.Command{
+	Use:     "abort <app name>",
+	Short:   "abort the canary deploy of an app",
+	Long:    "Remove the canary deploy of an application, its traffic goes back to the current release.",
+	Example: "  $ teresa deploy abort myapp",
+	Run:     deployAbort,
+}
+
+var deployApproveCmd = &cobra.Command{
+	Use:   "approve <deploy id>",
+	Short: "approve the deploy of a protected app",
+	Long: `Approve a deploy waiting for approval, letting its rollout proceed.
+
+Deploys of the apps protected by their team must be approved by one of the
+team admins other than the author of the deploy. The deploy id is shown
+by the deploy waiting for approval.`,
+	Example: "  $ teresa deploy approve 8ab5f0c2",
+	Run:     deployApprove,
+}
+
+var deploySwitchCmd = &cobra.Command{
+	Use:   "switch <app name>",
+	Short: "switch the traffic of a blue/green deploy",
+	Long: `Send the traffic of an application to its blue/green deploy, or back to the
+previous release if it was already switched. The previous release is removed
+after a grace period set by the cluster admin.`,
+	Example: "  $ teresa deploy switch myapp",
+	Run:     deploySwitch,
+}
+
+var deployImageCmd = &cobra.Command{
+	Use:   "image <app name> <image>",
+	Short: "Deploy an app from a container image",
+	Long: `Deploy an application from an image already built, skipping the build of
+the slug.
+
+The app container runs the command of the teresa.yaml given by --teresa-yaml,
+the entrypoint of the image by default. Apps with process types and cron
+jobs can't be deployed from images.`,
+	Example: `  $ teresa deploy image webapi registry.example.com/team/webapi:1.3 --description "release 1.3"
+
+  $ teresa deploy image webapi registry.example.com/team/webapi:1.3 --teresa-yaml teresa.yaml --commit $(git rev-parse HEAD)`,
+	Run: deployImage,
+}
+
 func getCurrentClusterName() (string, error) {
 	cfg, err := client.ReadConfigFile(cfgFile)
 	if err != nil {
@@ -122,15 +202,44 @@ func init() {
 	deployCmd.AddCommand(deployCreateCmd)
 	deployCmd.AddCommand(deployListCmd)
 	deployCmd.AddCommand(deployRollbackCmd)
+	deployCmd.AddCommand(deployPromoteCmd)
+	deployCmd.AddCommand(deployAbortCmd)
+	deployCmd.AddCommand(deploySwitchCmd)
+	deployCmd.AddCommand(deployImageCmd)
+	deployCmd.AddCommand(deployApproveCmd)
 
 	deployCreateCmd.Flags().String("app", "", "app name (required)")
 	deployCreateCmd.Flags().String("description", "", "deploy description (required)")
 	deployCreateCmd.Flags().Bool("no-input", false, "deploy app without warning")
+	deployCreateCmd.Flags().Bool("canary", false, "deploy alongside the current release")
+	deployCreateCmd.Flags().Int32("weight", 10, "percent of the traffic of the canary deploy")
+	deployCreateCmd.Flags().Bool("blue-green", false, "deploy aside the current release, without traffic until switched")
+	deployCreateCmd.Flags().String("commit", "", "git commit hash of the release")
+	deployCreateCmd.Flags().String("branch", "", "git branch of the release")
+	deployCreateCmd.Flags().String("message", "", "git commit message of the release")
+	deployCreateCmd.Flags().Bool("force", false, "deploy even if the app is locked or frozen (admins only)")
+	deployCreateCmd.Flags().Bool("dry-run", false, "show the diff of the deploy against the live app instead of deploying it")
+
+	deployImageCmd.Flags().String("description", "", "deploy description")
+	deployImageCmd.Flags().String("teresa-yaml", "", "path of the teresa.yaml of the app")
+	deployImageCmd.Flags().Bool("no-input", false, "deploy app without warning")
+	deployImageCmd.Flags().String("commit", "", "git commit hash of the release")
+	deployImageCmd.Flags().String("branch", "", "git branch of the release")
+	deployImageCmd.Flags().String("message", "", "git commit message of the release")
+	deployImageCmd.Flags().Bool("force", false, "deploy even if the app is locked or frozen (admins only)")
 
 	deployListCmd.Flags().String("app", "", "app name (required)")
+	deployListCmd.Flags().Bool("details", false, "show the git branch and message, slug, image and env vars of the revisions")
 
-	deployRollbackCmd.Flags().String("revision", "", "app revision (required)")
+	deployRollbackCmd.Flags().String("revision", "", "app revision (default the one before the current)")
 	deployRollbackCmd.Flags().Bool("no-input", false, "rollback deploy without warning")
+	deployRollbackCmd.Flags().Bool("force", false, "rollback even if the app is locked or frozen (admins only)")
+
+	deployPromoteCmd.Flags().Bool("no-input", false, "promote canary without warning")
+	deployPromoteCmd.Flags().Bool("force", false, "promote even if the app is locked or frozen (admins only)")
+	deployAbortCmd.Flags().Bool("no-input", false, "abort canary without warning")
+	deploySwitchCmd.Flags().Bool("no-input", false, "switch traffic without warning")
+	deploySwitchCmd.Flags().Bool("force", false, "switch even if the app is locked or frozen (admins only)")
 }
 
 func deployApp(cmd *cobra.Command, args []string) {
@@ -155,11 +264,28 @@ func deployApp(cmd *cobra.Command, args []string) {
 		client.PrintErrorAndExit("Invalid no-input parameter")
 	}
 
-	currentClusterName := currentClusterNameOrExit()
-	fmt.Printf("Deploying app %s to the cluster %s...\n", color.CyanString(`"%s"`, appName), color.YellowString(`"%s"`, currentClusterName))
+	canaryWeight, blueGreen := deployStrategyFlags(cmd)
 
-	if !noInput {
-		readStdinYesOrExit()
+	commit, branch, message := gitMetadataFlags(cmd)
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid dry-run parameter")
+	}
+
+	currentClusterName := currentClusterNameOrExit()
+	if dryRun {
+		fmt.Printf("Diffing the deploy of app %s on the cluster %s...\n", color.CyanString(`"%s"`, appName), color.YellowString(`"%s"`, currentClusterName))
+	} else {
+		fmt.Printf("Deploying app %s to the cluster %s...\n", color.CyanString(`"%s"`, appName), color.YellowString(`"%s"`, currentClusterName))
+		if !noInput {
+			readStdinYesOrExit()
+		}
 	}
 
 	path, cleanup := fetchApp(appURL)
@@ -190,6 +316,11 @@ func deployApp(cmd *cobra.Command, args []string) {
 	}
 	defer conn.Close()
 
+	checksum, err := uploadTarball(conn, appName, tarPath)
+	if err != nil {
+		client.PrintServerErrorAndExit(err)
+	}
+
 	ctx := context.Background()
 
 	cli := dpb.NewDeployClient(conn)
@@ -199,19 +330,31 @@ func deployApp(cmd *cobra.Command, args []string) {
 	}
 
 	info := &dpb.DeployRequest{Value: &dpb.DeployRequest_Info_{&dpb.DeployRequest_Info{
-		App:         appName,
-		Description: deployDescription,
+		App:            appName,
+		Description:    deployDescription,
+		CanaryWeight:   canaryWeight,
+		BlueGreen:      blueGreen,
+		Commit:         commit,
+		Branch:         branch,
+		Message:        message,
+		Force:          force,
+		DryRun:         dryRun,
+		UploadChecksum: checksum,
 	}}}
 	if err := stream.Send(info); err != nil {
 		client.PrintErrorAndExit("Error sending deploy information: %v", err)
 	}
 
 	g, _ := errgroup.WithContext(ctx)
-	g.Go(func() error { return sendAppTarball(tarPath, stream) })
+	if checksum == "" {
+		g.Go(func() error { return sendAppTarball(tarPath, stream) })
+	} else {
+		stream.CloseSend()
+	}
 	g.Go(func() error { return streamServerMsgs(stream) })
 
 	if err := g.Wait(); err != nil {
-		client.PrintErrorAndExit(client.GetErrorMsg(err))
+		client.PrintServerErrorAndExit(err)
 	}
 }
 
@@ -280,7 +423,30 @@ func sendAppTarball(tarPath string, stream dpb.Deploy_MakeClient) error {
 	return nil
 }
 
-func streamServerMsgs(stream dpb.Deploy_MakeClient) error {
+// gitMetadataFlags returns the git commit, branch and message flags
+func gitMetadataFlags(cmd *cobra.Command) (string, string, string) {
+	commit, err := cmd.Flags().GetString("commit")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid commit parameter")
+	}
+	branch, err := cmd.Flags().GetString("branch")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid branch parameter")
+	}
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid message parameter")
+	}
+	return commit, branch, message
+}
+
+type deployResponseReceiver interface {
+	Recv() (*dpb.DeployResponse, error)
+}
+
+// streamServerMsgs prints the events of the deploy stream, the failure is
+// printed on exit
+func streamServerMsgs(stream deployResponseReceiver) error {
 	for {
 		msg, err := stream.Recv()
 		if err != nil {
@@ -289,17 +455,38 @@ func streamServerMsgs(stream dpb.Deploy_MakeClient) error {
 			}
 			return err
 		}
-		fmt.Print(msg.Text)
+		switch msg.Type {
+		case deploy.EventRolloutProgress:
+			fmt.Printf("%s %s", progressBar(msg.Ready, msg.Total), msg.Text)
+		case deploy.EventFailed:
+		default:
+			fmt.Print(msg.Text)
+		}
 	}
 	return nil
 }
 
+// progressBar renders the ready pods out of the total, like [####    ]
+func progressBar(ready, total int32) string {
+	const width = 20
+	done := width
+	if total > 0 && ready < total {
+		done = int(ready) * width / int(total)
+	}
+	return fmt.Sprintf("[%s%s]", strings.Repeat("#", done), strings.Repeat(" ", width-done))
+}
+
 func deployList(cmd *cobra.Command, args []string) {
 	appName, err := cmd.Flags().GetString("app")
 	if err != nil || appName == "" {
 		client.PrintErrorAndExit("Invalid app parameter")
 	}
 
+	details, err := cmd.Flags().GetBool("details")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid details parameter")
+	}
+
 	conn, err := connection.New(cfgFile, cfgCluster)
 	if err != nil {
 		client.PrintErrorAndExit("Error connecting to server: %v", err)
@@ -318,7 +505,11 @@ func deployList(cmd *cobra.Command, args []string) {
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"REVISION", "CREATED AT", "DESCRIPTION"})
+	header := []string{"REVISION", "CREATED AT", "AUTHOR", "COMMIT", "DESCRIPTION"}
+	if details {
+		header = append(header, "BRANCH", "MESSAGE", "SLUG", "IMAGE", "ENV")
+	}
+	table.SetHeader(header)
 	table.SetRowLine(true)
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 	table.SetRowSeparator("-")
@@ -332,13 +523,30 @@ func deployList(cmd *cobra.Command, args []string) {
 		r := []string{
 			d.Revision,
 			d.CreatedAt,
+			d.Author,
+			shortCommit(d.Commit),
 			d.Description,
 		}
+		if details {
+			env := make([]string, len(d.Env))
+			for i, ev := range d.Env {
+				env[i] = fmt.Sprintf("%s=%s", ev.Key, ev.Value)
+			}
+			r = append(r, d.Branch, d.Message, d.Slug, d.Image, strings.Join(env, "\n"))
+		}
 		table.Append(r)
 	}
 	table.Render()
 }
 
+// shortCommit abbreviates the commit hash the way git does
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
 func deployRollback(cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		cmd.Usage()
@@ -347,7 +555,7 @@ func deployRollback(cmd *cobra.Command, args []string) {
 	appName := args[0]
 
 	revision, err := cmd.Flags().GetString("revision")
-	if err != nil || revision == "" {
+	if err != nil {
 		client.PrintErrorAndExit("invalid revision parameter")
 	}
 
@@ -356,12 +564,21 @@ func deployRollback(cmd *cobra.Command, args []string) {
 		client.PrintErrorAndExit("Invalid no-input parameter")
 	}
 
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
 	currentClusterName := currentClusterNameOrExit()
 
+	target := "the previous revision"
+	if revision != "" {
+		target = fmt.Sprintf("revision %s", color.CyanString(`"%s"`, revision))
+	}
 	fmt.Printf(
-		"Rolling back app %s to revision %s on cluster %s...\n",
+		"Rolling back app %s to %s on cluster %s...\n",
 		color.CyanString(`"%s"`, appName),
-		color.CyanString(`"%s"`, revision),
+		target,
 		color.YellowString(`"%s"`, currentClusterName),
 	)
 
@@ -379,6 +596,7 @@ func deployRollback(cmd *cobra.Command, args []string) {
 	req := &dpb.RollbackRequest{
 		AppName:  appName,
 		Revision: revision,
+		Force:    force,
 	}
 	cli := dpb.NewDeployClient(conn)
 	if _, err = cli.Rollback(context.Background(), req); err != nil {
@@ -388,6 +606,243 @@ func deployRollback(cmd *cobra.Command, args []string) {
 	fmt.Println("rollback done")
 }
 
+func deployPromote(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
+	fmt.Printf(
+		"Promoting the canary of app %s on cluster %s...\n",
+		color.CyanString(`"%s"`, appName),
+		color.YellowString(`"%s"`, currentClusterNameOrExit()),
+	)
+	if !noInput {
+		readStdinYesOrExit()
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.PromoteRequest{AppName: appName, Force: force}
+	if _, err = cli.Promote(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Println("promote done")
+}
+
+func deployAbort(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+
+	fmt.Printf(
+		"Aborting the canary of app %s on cluster %s...\n",
+		color.CyanString(`"%s"`, appName),
+		color.YellowString(`"%s"`, currentClusterNameOrExit()),
+	)
+	if !noInput {
+		readStdinYesOrExit()
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.AbortRequest{AppName: appName}
+	if _, err = cli.Abort(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Println("abort done")
+}
+
+func deployApprove(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.ApproveRequest{DeployId: args[0]}
+	if _, err = cli.Approve(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Deploy %s approved with success\n", color.CyanString(args[0]))
+}
+
+func deploySwitch(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	appName := args[0]
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
+	fmt.Printf(
+		"Switching the traffic of app %s on cluster %s...\n",
+		color.CyanString(`"%s"`, appName),
+		color.YellowString(`"%s"`, currentClusterNameOrExit()),
+	)
+	if !noInput {
+		readStdinYesOrExit()
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.SwitchRequest{AppName: appName, Force: force}
+	if _, err = cli.Switch(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Println("switch done")
+}
+
+func deployImage(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	appName, image := args[0], args[1]
+
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid description parameter")
+	}
+
+	noInput, err := cmd.Flags().GetBool("no-input")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid no-input parameter")
+	}
+
+	tyPath, err := cmd.Flags().GetString("teresa-yaml")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid teresa-yaml parameter")
+	}
+	var ty []byte
+	if tyPath != "" {
+		if ty, err = ioutil.ReadFile(tyPath); err != nil {
+			client.PrintErrorAndExit("Error reading teresa.yaml: %v", err)
+		}
+	}
+
+	commit, branch, message := gitMetadataFlags(cmd)
+
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid force parameter")
+	}
+
+	currentClusterName := currentClusterNameOrExit()
+	fmt.Printf(
+		"Deploying image %s to app %s on cluster %s...\n",
+		color.CyanString(`"%s"`, image),
+		color.CyanString(`"%s"`, appName),
+		color.YellowString(`"%s"`, currentClusterName),
+	)
+	if !noInput {
+		readStdinYesOrExit()
+	}
+
+	conn, err := connection.New(cfgFile, currentClusterName)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := dpb.NewDeployClient(conn)
+	req := &dpb.ImageRequest{
+		AppName:     appName,
+		Image:       image,
+		Description: description,
+		TeresaYaml:  ty,
+		Commit:      commit,
+		Branch:      branch,
+		Message:     message,
+		Force:       force,
+	}
+	stream, err := cli.Image(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if err := streamServerMsgs(stream); err != nil {
+		client.PrintServerErrorAndExit(err)
+	}
+}
+
+// deployStrategyFlags returns the canary weight, zero without --canary,
+// and the --blue-green flag
+func deployStrategyFlags(cmd *cobra.Command) (int32, bool) {
+	canary, err := cmd.Flags().GetBool("canary")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid canary parameter")
+	}
+
+	var canaryWeight int32
+	if canary {
+		canaryWeight, err = cmd.Flags().GetInt32("weight")
+		if err != nil || canaryWeight < 1 || canaryWeight > 99 {
+			client.PrintErrorAndExit("Invalid weight parameter, it must be between 1 and 99")
+		}
+	}
+
+	blueGreen, err := cmd.Flags().GetBool("blue-green")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid blue-green parameter")
+	}
+	if canary && blueGreen {
+		client.PrintErrorAndExit("Canary and blue/green deploys can't be combined")
+	}
+	return canaryWeight, blueGreen
+}
+
 func currentClusterNameOrExit() string {
 	name := cfgCluster
 	if name == "" {
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	context "golang.org/x/net/context"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	egpb "github.com/luizalabs/teresa/pkg/protobuf/envgroup"
+)
+
+var envGroupCmd = &cobra.Command{
+	Use:   "envgroup",
+	Short: "Everything about env groups",
+	Long: `Everything about env groups.
+
+An env group is a set of env vars owned by a team that can be attached
+to many apps. Every change on the group is rolled out to the attached
+apps, the env vars of the app take precedence over the ones of the group.`,
+}
+
+var envGroupCreateCmd = &cobra.Command{
+	Use:     "create <name>",
+	Short:   "Create an env group",
+	Example: "$ teresa envgroup create shared-db --team foo",
+	Run:     envGroupCreate,
+}
+
+var envGroupDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Short:   "Delete an env group",
+	Long:    "Delete an env group, it must be detached from all apps first.",
+	Example: "$ teresa envgroup delete shared-db",
+	Run:     envGroupDelete,
+}
+
+var envGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the env groups of your teams",
+	Run:   envGroupList,
+}
+
+var envGroupInfoCmd = &cobra.Command{
+	Use:     "info <name>",
+	Short:   "Show the env vars and apps of an env group",
+	Example: "$ teresa envgroup info shared-db",
+	Run:     envGroupInfo,
+}
+
+var envGroupSetCmd = &cobra.Command{
+	Use:     "set <name> [KEY=value, ...]",
+	Short:   "Set env vars of an env group",
+	Long:    "Create or update env vars of an env group, the attached apps are restarted.",
+	Example: "$ teresa envgroup set shared-db DB_HOST=db.foodomain.com DB_PORT=5432",
+	Run:     envGroupSet,
+}
+
+var envGroupUnsetCmd = &cobra.Command{
+	Use:     "unset <name> [KEY, ...]",
+	Short:   "Unset env vars of an env group",
+	Long:    "Remove env vars of an env group, the attached apps are restarted.",
+	Example: "$ teresa envgroup unset shared-db DB_PORT",
+	Run:     envGroupUnset,
+}
+
+var envGroupAttachCmd = &cobra.Command{
+	Use:     "attach <name> <app-name>",
+	Short:   "Attach an env group to an app",
+	Example: "$ teresa envgroup attach shared-db foo",
+	Run:     envGroupAttach,
+}
+
+var envGroupDetachCmd = &cobra.Command{
+	Use:     "detach <name> <app-name>",
+	Short:   "Detach an env group from an app",
+	Example: "$ teresa envgroup detach shared-db foo",
+	Run:     envGroupDetach,
+}
+
+func init() {
+	RootCmd.AddCommand(envGroupCmd)
+	// Commands
+	envGroupCmd.AddCommand(envGroupCreateCmd)
+	envGroupCmd.AddCommand(envGroupDeleteCmd)
+	envGroupCmd.AddCommand(envGroupListCmd)
+	envGroupCmd.AddCommand(envGroupInfoCmd)
+	envGroupCmd.AddCommand(envGroupSetCmd)
+	envGroupCmd.AddCommand(envGroupUnsetCmd)
+	envGroupCmd.AddCommand(envGroupAttachCmd)
+	envGroupCmd.AddCommand(envGroupDetachCmd)
+
+	envGroupCreateCmd.Flags().String("team", "", "team owner of the env group")
+}
+
+func envGroupCreate(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter: %v", err)
+	}
+	if len(args) != 1 || team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	req := &egpb.CreateRequest{Name: args[0], Team: team}
+	if _, err := cli.Create(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Env group created with success")
+}
+
+func envGroupDelete(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	if _, err := cli.Delete(context.Background(), &egpb.DeleteRequest{Name: args[0]}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Env group deleted with success")
+}
+
+func envGroupList(cmd *cobra.Command, args []string) {
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	resp, err := cli.List(context.Background(), &egpb.Empty{})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.EnvGroups) == 0 {
+		fmt.Println("Your teams do not have any env group")
+		return
+	}
+
+	fmt.Println("Env groups:")
+	for _, eg := range resp.EnvGroups {
+		fmt.Printf("%s - %s", color.CyanString(eg.Name), eg.Team)
+		if len(eg.Apps) > 0 {
+			fmt.Printf(" (%s)", strings.Join(eg.Apps, ", "))
+		}
+		fmt.Print("\n")
+	}
+}
+
+func envGroupInfo(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	resp, err := cli.Info(context.Background(), &egpb.InfoRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	color.New(color.FgCyan, color.Bold).Printf("[%s]\n", resp.Name)
+	bold := color.New(color.Bold).SprintFunc()
+	fmt.Println(bold("team:"), resp.Team)
+	if len(resp.Apps) > 0 {
+		fmt.Println(bold("apps:"))
+		for _, a := range resp.Apps {
+			fmt.Printf("  %s\n", a)
+		}
+	}
+	if len(resp.EnvVars) > 0 {
+		fmt.Println(bold("env:"))
+		for _, ev := range resp.EnvVars {
+			fmt.Printf("  %s=%s\n", ev.Key, ev.Value)
+		}
+	}
+}
+
+func envGroupSet(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Usage()
+		return
+	}
+
+	req := &egpb.SetEnvRequest{Name: args[0]}
+	for _, item := range args[1:] {
+		tmp := strings.SplitN(item, "=", 2)
+		if len(tmp) != 2 {
+			client.PrintErrorAndExit("Env vars must be in the format FOO=bar")
+		}
+		req.EnvVars = append(req.EnvVars, &egpb.SetEnvRequest_EnvVar{Key: tmp[0], Value: tmp[1]})
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	if _, err := cli.SetEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Env group updated with success")
+}
+
+func envGroupUnset(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	req := &egpb.UnsetEnvRequest{Name: args[0], EnvVars: args[1:]}
+	if _, err := cli.UnsetEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Env group updated with success")
+}
+
+func envGroupAttach(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	req := &egpb.AttachRequest{Name: args[0], AppName: args[1]}
+	if _, err := cli.Attach(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Env group %s attached to the app %s\n", color.CyanString(args[0]), color.CyanString(args[1]))
+}
+
+func envGroupDetach(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := egpb.NewEnvGroupClient(conn)
+
+	req := &egpb.DetachRequest{Name: args[0], AppName: args[1]}
+	if _, err := cli.Detach(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Printf("Env group %s detached from the app %s\n", color.CyanString(args[0]), color.CyanString(args[1]))
+}
@@ -3,11 +3,14 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
+	"sync"
 
 	"github.com/luizalabs/teresa/pkg/client"
 	"github.com/luizalabs/teresa/pkg/client/connection"
 	execpb "github.com/luizalabs/teresa/pkg/protobuf/exec"
 	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
 	context "golang.org/x/net/context"
 )
 
@@ -17,9 +20,16 @@ var execCmd = &cobra.Command{
 	Long: `Exec a command on an app replica.
 
 You can execute a non-interactive command on an app replica (same of current deploy),
-Teresa will collect and stream the stdout of replica until the command ends`,
-	Example: "  $ teresa exec <app-name> -- python manage.py start_job_x -a arg1 -s arg2",
-	Run:     execCommand,
+Teresa will collect and stream the stdout of replica until the command ends.
+
+With --tty the command runs interactively in a running replica of the app,
+with a terminal, like a shell.`,
+	Example: `  $ teresa exec <app-name> -- python manage.py start_job_x -a arg1 -s arg2
+
+  To open a shell in a running replica:
+
+  $ teresa exec <app-name> --tty -- bash`,
+	Run: execCommand,
 }
 
 func execCommand(cmd *cobra.Command, args []string) {
@@ -30,6 +40,15 @@ func execCommand(cmd *cobra.Command, args []string) {
 	appName := args[0]
 	command := args[1:]
 
+	tty, err := cmd.Flags().GetBool("tty")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid tty parameter")
+	}
+	if tty {
+		execShell(appName, command)
+		return
+	}
+
 	conn, err := connection.New(cfgFile, cfgCluster)
 	if err != nil {
 		client.PrintConnectionErrorAndExit(err)
@@ -60,6 +79,92 @@ func execCommand(cmd *cobra.Command, args []string) {
 
 }
 
+// shellSender serializes the messages of the stdin and of the resize events
+type shellSender struct {
+	mu     sync.Mutex
+	stream execpb.Exec_ShellClient
+}
+
+func (s *shellSender) Send(req *execpb.ShellRequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(req)
+}
+
+func execShell(appName string, command []string) {
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	cli := execpb.NewExecClient(conn)
+	stream, err := cli.Shell(context.Background())
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fd := int(os.Stdin.Fd())
+	req := &execpb.ShellRequest{AppName: appName, Command: command, Tty: terminal.IsTerminal(fd)}
+	restore := func() {}
+	if req.Tty {
+		if w, h, err := terminal.GetSize(fd); err == nil {
+			req.Width, req.Height = uint32(w), uint32(h)
+		}
+		state, err := terminal.MakeRaw(fd)
+		if err != nil {
+			client.PrintErrorAndExit("Error setting up the terminal: %v", err)
+		}
+		restore = func() { terminal.Restore(fd, state) }
+	}
+
+	sender := &shellSender{stream: stream}
+	if err := sender.Send(req); err != nil {
+		restore()
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				in := make([]byte, n)
+				copy(in, buf[:n])
+				if sender.Send(&execpb.ShellRequest{Stdin: in}) != nil {
+					return
+				}
+			}
+			if err != nil {
+				stream.CloseSend()
+				return
+			}
+		}
+	}()
+	if req.Tty {
+		go watchTerminalResize(fd, func(w, h int) {
+			sender.Send(&execpb.ShellRequest{Width: uint32(w), Height: uint32(h)})
+		})
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			restore()
+			if err == io.EOF {
+				return
+			}
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		if resp.Finished {
+			restore()
+			conn.Close()
+			os.Exit(int(resp.ExitCode))
+		}
+		os.Stdout.Write(resp.Stdout)
+	}
+}
+
 func init() {
 	RootCmd.AddCommand(execCmd)
+	execCmd.Flags().BoolP("tty", "t", false, "run the command interactively with a terminal")
 }
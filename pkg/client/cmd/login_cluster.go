@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"fmt"
 	"time"
 
 	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/fatih/color"
 	"github.com/luizalabs/teresa/pkg/client"
@@ -16,6 +19,8 @@ import (
 var (
 	userName  string
 	expiresIn time.Duration
+	sso       bool
+	ssoPort   int
 )
 
 var loginCmd = &cobra.Command{
@@ -27,6 +32,10 @@ eg.:
 
 	$ teresa login --user user@mydomain.com [--expires-in 168h]
 
+Or, on clusters with single sign-on, sign in on the browser:
+
+	$ teresa login --sso
+
 Where valid "expires-in" units are "ns", "us" (or "µs"), "ms", "s", "m", "h",
 as accepted by Go's time.ParseDuration.
 	`,
@@ -34,6 +43,10 @@ as accepted by Go's time.ParseDuration.
 }
 
 func login(cmd *cobra.Command, args []string) {
+	if sso {
+		ssoLogin(ssoPort)
+		return
+	}
 	if userName == "" {
 		cmd.Usage()
 		return
@@ -51,19 +64,43 @@ func login(cmd *cobra.Command, args []string) {
 
 	exp := float64(expiresIn)
 	cli := userpb.NewUserClient(conn)
-	res, err := cli.Login(context.Background(), &userpb.LoginRequest{Email: userName, Password: p, ExpiresIn: exp})
+	req := &userpb.LoginRequest{Email: userName, Password: p, ExpiresIn: exp}
+	res, err := cli.Login(context.Background(), req)
+	for err != nil {
+		stat, ok := status.FromError(err)
+		if !ok {
+			break
+		}
+		if stat.Code() == codes.Unauthenticated && req.Otp == "" {
+			// the user has the two-factor authentication enabled
+			if req.Otp, err = client.GetInput("Code: "); err != nil {
+				client.PrintErrorAndExit("Error trying to get the code: %v", err)
+			}
+		} else if stat.Code() == codes.FailedPrecondition && req.NewPassword == "" {
+			// the password expired or doesn't follow the password policy
+			fmt.Println(stat.Message())
+			if req.NewPassword, err = client.GetMaskedPassword("New Password: "); err != nil {
+				client.PrintErrorAndExit("Error trying to get the user password: %v", err)
+			}
+		} else {
+			break
+		}
+		res, err = cli.Login(context.Background(), req)
+	}
 	if err != nil {
 		client.PrintErrorAndExit(client.GetErrorMsg(err))
 	}
 	color.Green("Login OK")
 
-	if err = client.SaveToken(cfgFile, cfgCluster, res.Token); err != nil {
+	if err = client.SaveSession(cfgFile, cfgCluster, res.Token, res.RefreshToken, res.ExpiresAt); err != nil {
 		client.PrintErrorAndExit("Error trying to save token in configuration file: %v", err)
 	}
 }
 
 func init() {
 	loginCmd.Flags().StringVar(&userName, "user", "", "e-mail to login with (required)")
-	loginCmd.Flags().DurationVar(&expiresIn, "expires-in", 15*24*time.Hour, "duration of login token")
+	loginCmd.Flags().DurationVar(&expiresIn, "expires-in", 15*24*time.Hour, "duration of the login session")
+	loginCmd.Flags().BoolVar(&sso, "sso", false, "sign in on the browser with the single sign-on")
+	loginCmd.Flags().IntVar(&ssoPort, "sso-port", 0, "local port of the single sign-on callback, random if 0")
 	RootCmd.AddCommand(loginCmd)
 }
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+	"time"
+
+	context "golang.org/x/net/context"
+
+	"github.com/fatih/color"
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+
+	ssopb "github.com/luizalabs/teresa/pkg/protobuf/sso"
+)
+
+// ssoTimeout is how long the client waits for the user to sign in
+const ssoTimeout = 5 * time.Minute
+
+// ssoResult is the code (or the error) sent by the provider to the local
+// callback
+type ssoResult struct {
+	code string
+	err  error
+}
+
+// ssoCallback handles the redirect of the provider, checking its state
+func ssoCallback(state string, results chan<- *ssoResult) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		res := new(ssoResult)
+		switch {
+		case q.Get("state") != state:
+			res.err = fmt.Errorf("invalid state")
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("%s: %s", q.Get("error"), q.Get("error_description"))
+		case q.Get("code") == "":
+			res.err = fmt.Errorf("missing code")
+		default:
+			res.code = q.Get("code")
+		}
+
+		if res.err != nil {
+			http.Error(w, "Login failed: "+res.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login OK, you can close this window and go back to the terminal.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	}
+}
+
+// openBrowser opens the url in the browser of the user, the error is only
+// informative, the url is printed as well
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+func ssoLogin(port int) {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		client.PrintErrorAndExit("Error listening for the login callback: %v", err)
+	}
+	defer l.Close()
+	redirectURL := fmt.Sprintf("http://%s/callback", l.Addr())
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		client.PrintErrorAndExit("Error generating the login state: %v", err)
+	}
+	state := hex.EncodeToString(b)
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := ssopb.NewSSOClient(conn)
+	begin, err := cli.BeginLogin(context.Background(), &ssopb.BeginLoginRequest{RedirectUrl: redirectURL, State: state})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	results := make(chan *ssoResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", ssoCallback(state, results))
+	go http.Serve(l, mux)
+
+	fmt.Println("Sign in on the browser, opening:")
+	fmt.Println(begin.AuthUrl)
+	openBrowser(begin.AuthUrl)
+
+	var res *ssoResult
+	select {
+	case res = <-results:
+	case <-time.After(ssoTimeout):
+		client.PrintErrorAndExit("Timed out waiting for the login")
+	}
+	if res.err != nil {
+		client.PrintErrorAndExit("Login failed: %v", res.err)
+	}
+
+	req := &ssopb.LoginRequest{Code: res.code, RedirectUrl: redirectURL, ExpiresIn: float64(expiresIn)}
+	resp, err := cli.Login(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	color.Green("Login OK as %s", resp.Email)
+
+	if err = client.SaveSession(cfgFile, cfgCluster, resp.Token, resp.RefreshToken, resp.ExpiresAt); err != nil {
+		client.PrintErrorAndExit("Error trying to save token in configuration file: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSSOCallback(t *testing.T) {
+	var testCases = []struct {
+		query        string
+		expectedCode string
+		expectedErr  bool
+	}{
+		{"?state=xyz&code=abc", "abc", false},
+		{"?state=other&code=abc", "", true},
+		{"?state=xyz&error=access_denied", "", true},
+		{"?state=xyz", "", true},
+	}
+
+	for _, tc := range testCases {
+		results := make(chan *ssoResult, 1)
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/callback"+tc.query, nil)
+		ssoCallback("xyz", results)(w, r)
+
+		res := <-results
+		if res.code != tc.expectedCode || (res.err != nil) != tc.expectedErr {
+			t.Errorf("expected code %q (err: %v) for %s, got %q (err: %v)", tc.expectedCode, tc.expectedErr, tc.query, res.code, res.err)
+		}
+		if tc.expectedErr && w.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400 for %s, got %d", tc.query, w.Code)
+		}
+	}
+}
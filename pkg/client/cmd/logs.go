@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+	"github.com/luizalabs/teresa/pkg/server/app"
+)
+
+const logsReconnectRetries = 5
+
+var logsReconnectInterval = 2 * time.Second
+
+// retryableLogsErr tells if the logs were interrupted by the connection,
+// the other errors are the ones of the request itself
+func retryableLogsErr(err error) bool {
+	switch grpc.Code(err) {
+	case codes.Unavailable, codes.Internal, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// streamLogs prints the logs of the request until the server ends them. On
+// follow the logs interrupted by the connection are requested again, since
+// the last line received
+func streamLogs(cli appb.AppClient, req *appb.LogsRequest, print func(string)) error {
+	last := time.Now()
+	for attempt := 0; ; attempt++ {
+		err := recvLogs(cli, req, func(text string) {
+			last = time.Now()
+			attempt = 0
+			print(text)
+		})
+		if err == nil {
+			return nil
+		}
+		if !req.Follow || !retryableLogsErr(err) || attempt >= logsReconnectRetries {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Logs interrupted (%s), reconnecting...\n", client.GetErrorMsg(err))
+		time.Sleep(time.Duration(attempt+1) * logsReconnectInterval)
+		r := *req
+		r.Lines = -1
+		r.SinceSeconds = int64(time.Since(last).Seconds()) + 1
+		req = &r
+	}
+}
+
+func recvLogs(cli appb.AppClient, req *appb.LogsRequest, print func(string)) error {
+	stream, err := cli.Logs(context.Background(), req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		print(msg.Text)
+	}
+}
+
+// multiAppLogs prints the logs of the apps, each line prefixed by its app
+// (the JSON records have the app). Without follow the logs are printed app
+// by app, in the order of the apps, on follow the lines are printed as they
+// arrive. The logs of an app which fail, like one the user can't access,
+// are reported and the ones of the other apps go on. It returns false if
+// any of them failed
+func multiAppLogs(cli appb.AppClient, apps []string, req *appb.LogsRequest, stdout, stderr io.Writer) bool {
+	prefix := func(appName, text string) string {
+		if req.Output == app.LogOutputJSON {
+			return text
+		}
+		return fmt.Sprintf("[%s] %s", appName, text)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	buffered := make([][]string, len(apps))
+	errs := make([]error, len(apps))
+	for i, appName := range apps {
+		r := *req
+		r.Name = appName
+		wg.Add(1)
+		go func(i int, r *appb.LogsRequest) {
+			defer wg.Done()
+			errs[i] = streamLogs(cli, r, func(text string) {
+				line := prefix(r.Name, text)
+				if !r.Follow {
+					buffered[i] = append(buffered[i], line)
+					return
+				}
+				mu.Lock()
+				fmt.Fprintln(stdout, line)
+				mu.Unlock()
+			})
+			if errs[i] != nil && r.Follow {
+				mu.Lock()
+				fmt.Fprintf(stderr, "Error on the logs of %s: %s\n", r.Name, client.GetErrorMsg(errs[i]))
+				mu.Unlock()
+			}
+		}(i, &r)
+	}
+	wg.Wait()
+
+	ok := true
+	for i, appName := range apps {
+		for _, line := range buffered[i] {
+			fmt.Fprintln(stdout, line)
+		}
+		if errs[i] == nil {
+			continue
+		}
+		ok = false
+		if !req.Follow {
+			fmt.Fprintf(stderr, "Error on the logs of %s: %s\n", appName, client.GetErrorMsg(errs[i]))
+		}
+	}
+	return ok
+}
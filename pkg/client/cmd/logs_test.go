@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+)
+
+type fakeLogsClient struct {
+	appb.App_LogsClient
+	msgs []string
+	err  error
+}
+
+func (f *fakeLogsClient) Recv() (*appb.LogsResponse, error) {
+	if len(f.msgs) == 0 {
+		return nil, f.err
+	}
+	msg := f.msgs[0]
+	f.msgs = f.msgs[1:]
+	return &appb.LogsResponse{Text: msg}, nil
+}
+
+type fakeAppLogsClient struct {
+	appb.AppClient
+	streams []*fakeLogsClient
+	reqs    []*appb.LogsRequest
+}
+
+func (f *fakeAppLogsClient) Logs(ctx context.Context, req *appb.LogsRequest, opts ...grpc.CallOption) (appb.App_LogsClient, error) {
+	f.reqs = append(f.reqs, req)
+	stream := f.streams[0]
+	f.streams = f.streams[1:]
+	return stream, nil
+}
+
+func TestRetryableLogsErr(t *testing.T) {
+	var testCases = []struct {
+		err      error
+		expected bool
+	}{
+		{status.Errorf(codes.Unavailable, "transport is closing"), true},
+		{status.Errorf(codes.Internal, "stream terminated by RST_STREAM"), true},
+		{status.Errorf(codes.PermissionDenied, "permission denied"), false},
+		{status.Errorf(codes.InvalidArgument, "Invalid grep expression"), false},
+		{errors.New("unknown"), false},
+	}
+
+	for _, tc := range testCases {
+		if got := retryableLogsErr(tc.err); got != tc.expected {
+			t.Errorf("expected %t, got %t for %v", tc.expected, got, tc.err)
+		}
+	}
+}
+
+func TestStreamLogsReconnects(t *testing.T) {
+	interval := logsReconnectInterval
+	logsReconnectInterval = 0
+	defer func() { logsReconnectInterval = interval }()
+
+	cli := &fakeAppLogsClient{streams: []*fakeLogsClient{
+		{msgs: []string{"foo"}, err: status.Errorf(codes.Unavailable, "transport is closing")},
+		{msgs: []string{"bar"}, err: io.EOF},
+	}}
+	var lines []string
+	req := &appb.LogsRequest{Name: "teresa", Lines: 10, Follow: true}
+	if err := streamLogs(cli, req, func(s string) { lines = append(lines, s) }); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	if !reflect.DeepEqual(lines, []string{"foo", "bar"}) {
+		t.Errorf("expected foo and bar, got %v", lines)
+	}
+	if len(cli.reqs) != 2 || cli.reqs[1].Lines != -1 || cli.reqs[1].SinceSeconds < 1 {
+		t.Errorf("expected the logs requested again since the last line, got %v", cli.reqs)
+	}
+}
+
+func TestStreamLogsWithoutFollow(t *testing.T) {
+	cli := &fakeAppLogsClient{streams: []*fakeLogsClient{
+		{err: status.Errorf(codes.Unavailable, "transport is closing")},
+	}}
+	req := &appb.LogsRequest{Name: "teresa", Lines: 10}
+	if err := streamLogs(cli, req, func(string) {}); grpc.Code(err) != codes.Unavailable {
+		t.Errorf("expected the Unavailable error, got %v", err)
+	}
+}
+
+// fakeAppsLogsClient streams the logs of each app
+type fakeAppsLogsClient struct {
+	appb.AppClient
+	mu    sync.Mutex
+	msgs  map[string][]string
+	errs  map[string]error
+	names []string
+}
+
+func (f *fakeAppsLogsClient) Logs(ctx context.Context, req *appb.LogsRequest, opts ...grpc.CallOption) (appb.App_LogsClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.names = append(f.names, req.Name)
+	if err, found := f.errs[req.Name]; found {
+		return nil, err
+	}
+	return &fakeLogsClient{msgs: f.msgs[req.Name], err: io.EOF}, nil
+}
+
+func TestMultiAppLogs(t *testing.T) {
+	cli := &fakeAppsLogsClient{
+		msgs: map[string][]string{
+			"foo": {"[foo-1] - a", "[foo-1] - b"},
+			"bar": {"[bar-1] - c"},
+		},
+		errs: map[string]error{"baz": status.Errorf(codes.PermissionDenied, "Permission denied")},
+	}
+	var stdout, stderr bytes.Buffer
+	ok := multiAppLogs(cli, []string{"foo", "baz", "bar"}, &appb.LogsRequest{Lines: 10}, &stdout, &stderr)
+
+	if ok {
+		t.Error("expected the failure of the logs of baz")
+	}
+	expected := "[foo] [foo-1] - a\n[foo] [foo-1] - b\n[bar] [bar-1] - c\n"
+	if stdout.String() != expected {
+		t.Errorf("expected %q, got %q", expected, stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "baz") {
+		t.Errorf("expected the error of baz, got %q", stderr.String())
+	}
+	if len(cli.names) != 3 {
+		t.Errorf("expected the logs of the 3 apps, got %v", cli.names)
+	}
+}
+
+func TestMultiAppLogsJSON(t *testing.T) {
+	cli := &fakeAppsLogsClient{msgs: map[string][]string{
+		"foo": {`{"app":"foo","pod":"foo-1","line":"a"}`},
+		"bar": {`{"app":"bar","pod":"bar-1","line":"b"}`},
+	}}
+	var stdout, stderr bytes.Buffer
+	req := &appb.LogsRequest{Lines: 10, Follow: true, Output: "json"}
+	if !multiAppLogs(cli, []string{"foo", "bar"}, req, &stdout, &stderr) {
+		t.Fatal("got unexpected error:", stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	sort.Strings(lines)
+	expected := []string{`{"app":"bar","pod":"bar-1","line":"b"}`, `{"app":"foo","pod":"foo-1","line":"a"}`}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("expected %v, got %v", expected, lines)
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	context "golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+)
+
+var appPortForwardCmd = &cobra.Command{
+	Use:   "port-forward <app-name> <[local-port:]remote-port>",
+	Short: "Forward a local port to a pod of the app",
+	Long: `Forward a local port to a port of a pod of the app.
+
+The connections are tunneled through the Teresa server, there is no need of
+access to the Kubernetes cluster. A running pod of the app is used unless
+the pod is given with --pod.
+
+Only the pods of the app are reachable, not other services (like a
+database the app uses) nor the pods of other namespaces.`,
+	Example: `  Forward the local port 8080 to the port 8080 of the app:
+
+  $ teresa app port-forward foo 8080
+
+  Forward the local port 5000 to the port 8080 of the pod foo-1234:
+
+  $ teresa app port-forward foo 5000:8080 --pod foo-1234`,
+	Run: appPortForward,
+}
+
+func init() {
+	appCmd.AddCommand(appPortForwardCmd)
+	appPortForwardCmd.Flags().String("pod", "", "name of the pod")
+}
+
+// parsePortMapping parses the local and the remote ports, a single port is
+// used as both
+func parsePortMapping(s string) (int, int32, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 2 {
+		return 0, 0, errors.Errorf("invalid port mapping %s", s)
+	}
+	ports := make([]int, len(parts))
+	for i, p := range parts {
+		port, err := strconv.Atoi(p)
+		if err != nil || port <= 0 || port > 65535 {
+			return 0, 0, errors.Errorf("invalid port %s", p)
+		}
+		ports[i] = port
+	}
+	return ports[0], int32(ports[len(ports)-1]), nil
+}
+
+func appPortForward(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cmd.Usage()
+		return
+	}
+	local, remote, err := parsePortMapping(args[1])
+	if err != nil {
+		client.PrintErrorAndExit(err.Error())
+	}
+	pod, err := cmd.Flags().GetString("pod")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid pod parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", local))
+	if err != nil {
+		client.PrintErrorAndExit("Error listening on port %d: %v", local, err)
+	}
+	defer l.Close()
+	fmt.Printf("Forwarding from 127.0.0.1:%d -> %d\n", local, remote)
+
+	cli := appb.NewAppClient(conn)
+	req := &appb.PortForwardRequest{Name: args[0], PodName: pod, Port: remote}
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			client.PrintErrorAndExit("Error accepting connection: %v", err)
+		}
+		go portForwardConn(cli, req, c)
+	}
+}
+
+// portForwardConn tunnels a local connection, each connection is a stream
+func portForwardConn(cli appb.AppClient, req *appb.PortForwardRequest, c net.Conn) {
+	defer c.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := cli.PortForward(ctx)
+	if err == nil {
+		err = stream.Send(req)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, client.GetErrorMsg(err))
+		return
+	}
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := c.Read(buf)
+			if n > 0 {
+				data := make([]byte, n)
+				copy(data, buf[:n])
+				if err := stream.Send(&appb.PortForwardRequest{Data: data}); err != nil {
+					return
+				}
+			}
+			if err != nil {
+				stream.CloseSend()
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				fmt.Fprintln(os.Stderr, client.GetErrorMsg(err))
+			}
+			return
+		}
+		if _, err := c.Write(msg.Data); err != nil {
+			return
+		}
+	}
+}
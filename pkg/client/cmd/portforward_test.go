@@ -0,0 +1,31 @@
+package cmd
+
+import "testing"
+
+func TestParsePortMapping(t *testing.T) {
+	var testCases = []struct {
+		mapping string
+		local   int
+		remote  int32
+		hasErr  bool
+	}{
+		{"8080", 8080, 8080, false},
+		{"5000:8080", 5000, 8080, false},
+		{"0", 0, 0, true},
+		{"5000:70000", 0, 0, true},
+		{"foo:8080", 0, 0, true},
+		{"1:2:3", 0, 0, true},
+		{"", 0, 0, true},
+	}
+
+	for _, tc := range testCases {
+		local, remote, err := parsePortMapping(tc.mapping)
+		if (err != nil) != tc.hasErr {
+			t.Errorf("expected error %v, got %v [case: %s]", tc.hasErr, err, tc.mapping)
+			continue
+		}
+		if local != tc.local || remote != tc.remote {
+			t.Errorf("expected %d:%d, got %d:%d [case: %s]", tc.local, tc.remote, local, remote, tc.mapping)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+// +build !windows
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// watchTerminalResize calls resize with the new size of the terminal on
+// each SIGWINCH
+func watchTerminalResize(fd int, resize func(width, height int)) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGWINCH)
+	for range ch {
+		if w, h, err := terminal.GetSize(fd); err == nil {
+			resize(w, h)
+		}
+	}
+}
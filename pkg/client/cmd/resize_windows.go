@@ -0,0 +1,4 @@
+package cmd
+
+// watchTerminalResize does nothing, there's no SIGWINCH on windows
+func watchTerminalResize(fd int, resize func(width, height int)) {}
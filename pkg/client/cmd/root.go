@@ -7,6 +7,7 @@ import (
 	"runtime"
 
 	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -50,7 +51,7 @@ view the whole configuration anytime by running:
 func init() {
 	// the config is only loaded if the command is valid,
 	// that is why we use OnInitialize
-	cobra.OnInitialize(initConfig)
+	cobra.OnInitialize(initConfig, initImpersonation)
 	// using this so i will check manually for strange behavior of the cli
 	RootCmd.SilenceErrors = true
 	RootCmd.SilenceUsage = true
@@ -59,10 +60,15 @@ func init() {
 	RootCmd.SuggestionsMinimumDistance = 3
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
 	RootCmd.PersistentFlags().StringVar(&cfgCluster, "cluster", "", "teresa cluster")
+	RootCmd.PersistentFlags().StringVar(&asUser, "as", "", "act as the user, admins only")
 	RootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "debug mode")
 	RootCmd.PersistentFlags().MarkHidden("debug")
 }
 
+func initImpersonation() {
+	connection.ImpersonateUser(asUser)
+}
+
 // from https://github.com/spf13/viper
 func getUserHomeDir() string {
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	context "golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	execpb "github.com/luizalabs/teresa/pkg/protobuf/exec"
+)
+
+var appRunCmd = &cobra.Command{
+	Use:   "run <app-name> -- <command>",
+	Short: "Run a one-off command",
+	Long: `Run a one-off command, like migrations or maintenance scripts.
+
+The command runs in a Kubernetes Job from the current deploy of the app, with
+its env vars. The output is streamed until the command ends and the client
+exits with the exit code of the command.`,
+	Example: "  $ teresa app run foo -- python manage.py migrate",
+	Run:     appRun,
+}
+
+func init() {
+	appCmd.AddCommand(appRunCmd)
+}
+
+func appRun(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintConnectionErrorAndExit(err)
+	}
+	defer conn.Close()
+
+	req := &execpb.RunRequest{AppName: args[0], Command: args[1:]}
+	cli := execpb.NewExecClient(conn)
+	stream, err := cli.Run(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return
+			}
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		if msg.Finished {
+			conn.Close()
+			os.Exit(int(msg.ExitCode))
+		}
+		fmt.Print(msg.Text)
+	}
+}
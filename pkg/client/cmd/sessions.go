@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	context "golang.org/x/net/context"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	userpb "github.com/luizalabs/teresa/pkg/protobuf/user"
+)
+
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Everything about login sessions",
+}
+
+var sessionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the login sessions",
+	Long: `List the login sessions not expired yet.
+
+Admins list the sessions of other users with --user.`,
+	Example: "$ teresa sessions list",
+	Run:     sessionsList,
+}
+
+var sessionsRevokeCmd = &cobra.Command{
+	Use:   "revoke <id>",
+	Short: "Revoke a login session",
+	Long: `Revoke a login session, its tokens stop working right away.
+
+Use --all to revoke all sessions, the current one included. Admins revoke the
+sessions of other users with --user.`,
+	Example: `  $ teresa sessions revoke 42
+
+  $ teresa sessions revoke --all --user gopher@luizalabs.com`,
+	Run: sessionsRevoke,
+}
+
+func init() {
+	RootCmd.AddCommand(sessionsCmd)
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsRevokeCmd)
+
+	sessionsListCmd.Flags().String("user", "", "user e-mail, admins only")
+	sessionsRevokeCmd.Flags().String("user", "", "user e-mail, admins only")
+	sessionsRevokeCmd.Flags().Bool("all", false, "revoke all sessions")
+}
+
+func sessionsList(cmd *cobra.Command, args []string) {
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid user parameter")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := userpb.NewUserClient(conn)
+	resp, err := cli.ListSessions(context.Background(), &userpb.ListSessionsRequest{User: user})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Sessions) == 0 {
+		fmt.Println("No sessions found")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "CREATED AT", "REFRESHED AT", "EXPIRES AT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, s := range resp.Sessions {
+		table.Append([]string{
+			strconv.FormatUint(s.Id, 10),
+			time.Unix(s.CreatedAt, 0).Format(freezeTimeLayout),
+			time.Unix(s.RefreshedAt, 0).Format(freezeTimeLayout),
+			time.Unix(s.ExpiresAt, 0).Format(freezeTimeLayout),
+		})
+	}
+	table.Render()
+}
+
+func sessionsRevoke(cmd *cobra.Command, args []string) {
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid user parameter")
+	}
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid all parameter")
+	}
+
+	req := &userpb.RevokeSessionRequest{User: user, All: all}
+	if !all {
+		if len(args) != 1 {
+			cmd.Usage()
+			return
+		}
+		if req.Id, err = strconv.ParseUint(args[0], 10, 64); err != nil {
+			client.PrintErrorAndExit("Invalid session id: %s", args[0])
+		}
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := userpb.NewUserClient(conn)
+	if _, err := cli.RevokeSession(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if all {
+		color.Green("Sessions revoked with success")
+	} else {
+		fmt.Printf("Session %s revoked with success\n", color.CyanString(args[0]))
+	}
+}
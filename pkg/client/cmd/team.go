@@ -2,10 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	context "golang.org/x/net/context"
 
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 
 	"github.com/luizalabs/teresa/pkg/client"
@@ -41,6 +46,14 @@ You can add a new user as a member of a team with:
 
   $ teresa team add-user --user john.doe@foodomain.com --team foo
 
+The role of the member in the team is set by --role:
+
+  admin      manages the members and creates and deletes the apps
+  developer  deploys and changes the apps (default)
+  viewer     only reads the apps, like their info and logs
+
+  $ teresa team add-user --user john.doe@foodomain.com --team foo --role viewer
+
 You need to create a user before use this command.`,
 	Run: teamAddUser,
 }
@@ -57,6 +70,24 @@ You can remove an user of a team with:
 	Run: teamRemoveUser,
 }
 
+var teamListUsersCmd = &cobra.Command{
+	Use:     "list-users <team-name>",
+	Short:   "List the members of a team",
+	Long:    "List the members of a team with their roles in the team.",
+	Example: "$ teresa team list-users foo",
+	Run:     teamListUsers,
+}
+
+var teamSetRoleCmd = &cobra.Command{
+	Use:   "set-role",
+	Short: "Change the role of a member of a team",
+	Long: `Change the role of a member of a team to admin, developer, deployer or viewer.
+
+Only admins, of teresa or of the team, can change the roles of the members.`,
+	Example: "$ teresa team set-role --team foo --user john.doe@foodomain.com --role admin",
+	Run:     teamSetRole,
+}
+
 var teamRenameCmd = &cobra.Command{
 	Use:     "rename",
 	Short:   "Rename a team",
@@ -65,6 +96,111 @@ var teamRenameCmd = &cobra.Command{
 	Run:     teamRename,
 }
 
+var teamDeleteCmd = &cobra.Command{
+	Use:   "delete <team-name>",
+	Short: "Delete a team",
+	Long: `Delete a team and its memberships.
+
+Only admins can delete a team. A team that still has apps isn't deleted,
+unless the --cascade flag is set, which deletes its apps as well.`,
+	Example: "$ teresa team delete foo",
+	Run:     teamDelete,
+}
+
+var teamInfoCmd = &cobra.Command{
+	Use:     "info <team-name>",
+	Short:   "Show the details of a team",
+	Long:    "Show the details of a team, like its quota and how much of it the apps use.",
+	Example: "$ teresa team info foo",
+	Run:     teamInfo,
+}
+
+var teamSetQuotaCmd = &cobra.Command{
+	Use:   "set-quota",
+	Short: "Set the quota of the team's apps",
+	Long: `Set the quota of the team's apps: the max number of apps, the max total of
+replicas and the max total of cpu and memory requested by their pods.
+
+Only admins can set the quota of a team. Creating, scaling, changing the
+resources or deploying an app over the quota fails, scaling down is always
+allowed. Zero (or blank for cpu and memory) means no limit.`,
+	Example: "$ teresa team set-quota --team foo --apps 10 --replicas 30 --cpu 8 --memory 16Gi",
+	Run:     teamSetQuota,
+}
+
+var teamEnvSetCmd = &cobra.Command{
+	Use:   "env-set [KEY=value, ...]",
+	Short: "Set default env vars of the team's apps",
+	Long: `Create or update default env vars of the team's apps.
+
+The default env vars are merged into every app of the team on its next
+deploy, the env vars of the app win. Only team admins can set them.`,
+	Example: "$ teresa team env-set --team foo LOG_LEVEL=info SENTRY_DSN=https://sentry.foo.com/1",
+	Run:     teamEnvSet,
+}
+
+var teamEnvUnsetCmd = &cobra.Command{
+	Use:     "env-unset [KEY, ...]",
+	Short:   "Unset default env vars of the team's apps",
+	Long:    "Remove default env vars of the team's apps, the apps lose them on their next deploy.",
+	Example: "$ teresa team env-unset --team foo SENTRY_DSN",
+	Run:     teamEnvUnset,
+}
+
+var teamSetDomainsCmd = &cobra.Command{
+	Use:   "set-domains [domain, ...]",
+	Short: "Set the domains allowed for the team's vhosts",
+	Long: `Set the domains allowed for the vhosts of the team's apps.
+
+Only admins can set the domains of a team. Calling it without domains
+lifts the restriction.`,
+	Example: "$ teresa team set-domains --team foo foo.com foo.io",
+	Run:     teamSetDomains,
+}
+
+var teamSetApprovalCmd = &cobra.Command{
+	Use:   "set-approval",
+	Short: "Set the team's apps whose deploys need approval",
+	Long: `Set the team's protected apps, whose deploys need the approval of a team admin.
+
+Deploys of protected apps wait for one of the team admins, other than the
+author of the deploy, to run 'teresa deploy approve <deploy id>'. Only admins
+can set the approval of a team. Calling it without apps lifts the protection.`,
+	Example: "$ teresa team set-approval --team foo --apps app1,app2",
+	Run:     teamSetApproval,
+}
+
+// freezeTimeLayout is the layout of the start and end of freeze windows,
+// in the local time
+const freezeTimeLayout = "2006-01-02 15:04"
+
+var teamAddFreezeCmd = &cobra.Command{
+	Use:   "add-freeze",
+	Short: "Freeze the deploys of the team's apps for a period",
+	Long: `Add a freeze window to the team, rejecting the deploys of its apps from start
+to end (local time, like "2026-12-20 00:00").
+
+Only admins can add freeze windows and force deploys during them, with the
+--force flag of the deploy.`,
+	Example: `$ teresa team add-freeze --team foo --start "2026-12-20 00:00" --end "2027-01-05 00:00" --reason "holiday freeze"`,
+	Run:     teamAddFreeze,
+}
+
+var teamRemoveFreezeCmd = &cobra.Command{
+	Use:     "remove-freeze <id>",
+	Short:   "Remove a freeze window of the team",
+	Long:    "Remove a freeze window of the team, the ids are shown by team list-freezes.",
+	Example: "$ teresa team remove-freeze --team foo 3",
+	Run:     teamRemoveFreeze,
+}
+
+var teamListFreezesCmd = &cobra.Command{
+	Use:     "list-freezes",
+	Short:   "List the freeze windows of the team",
+	Example: "$ teresa team list-freezes --team foo",
+	Run:     teamListFreezes,
+}
+
 func init() {
 	RootCmd.AddCommand(teamCmd)
 	// Commands
@@ -72,7 +208,19 @@ func init() {
 	teamCmd.AddCommand(teamCreateCmd)
 	teamCmd.AddCommand(teamAddUserCmd)
 	teamCmd.AddCommand(teamRemoveUserCmd)
+	teamCmd.AddCommand(teamListUsersCmd)
+	teamCmd.AddCommand(teamSetRoleCmd)
 	teamCmd.AddCommand(teamRenameCmd)
+	teamCmd.AddCommand(teamDeleteCmd)
+	teamCmd.AddCommand(teamInfoCmd)
+	teamCmd.AddCommand(teamSetQuotaCmd)
+	teamCmd.AddCommand(teamEnvSetCmd)
+	teamCmd.AddCommand(teamEnvUnsetCmd)
+	teamCmd.AddCommand(teamSetDomainsCmd)
+	teamCmd.AddCommand(teamSetApprovalCmd)
+	teamCmd.AddCommand(teamAddFreezeCmd)
+	teamCmd.AddCommand(teamRemoveFreezeCmd)
+	teamCmd.AddCommand(teamListFreezesCmd)
 
 	teamListCmd.Flags().Bool("show-users", false, "show members of team")
 
@@ -81,12 +229,43 @@ func init() {
 
 	teamAddUserCmd.Flags().String("user", "", "user email")
 	teamAddUserCmd.Flags().String("team", "", "team name")
+	teamAddUserCmd.Flags().String("role", "developer", "role of the user in the team: admin, developer, deployer or viewer")
 
 	teamRemoveUserCmd.Flags().String("user", "", "user email")
 	teamRemoveUserCmd.Flags().String("team", "", "team name")
 
+	teamSetRoleCmd.Flags().String("user", "", "user email")
+	teamSetRoleCmd.Flags().String("team", "", "team name")
+	teamSetRoleCmd.Flags().String("role", "", "role of the user in the team: admin, developer, deployer or viewer")
+
 	teamRenameCmd.Flags().String("old", "", "old team name")
 	teamRenameCmd.Flags().String("new", "", "new team name")
+
+	teamDeleteCmd.Flags().Bool("cascade", false, "delete the apps of the team as well")
+
+	teamSetQuotaCmd.Flags().String("team", "", "team name")
+	teamSetQuotaCmd.Flags().Int32("apps", 0, "max number of apps")
+	teamSetQuotaCmd.Flags().Int32("replicas", 0, "max total of replicas")
+	teamSetQuotaCmd.Flags().String("cpu", "", "max total of cpu requested, like 8 or 8000m")
+	teamSetQuotaCmd.Flags().String("memory", "", "max total of memory requested, like 16Gi")
+
+	teamEnvSetCmd.Flags().String("team", "", "team name")
+
+	teamEnvUnsetCmd.Flags().String("team", "", "team name")
+
+	teamSetDomainsCmd.Flags().String("team", "", "team name")
+
+	teamSetApprovalCmd.Flags().String("team", "", "team name")
+	teamSetApprovalCmd.Flags().StringSlice("apps", nil, "protected apps")
+
+	teamAddFreezeCmd.Flags().String("team", "", "team name")
+	teamAddFreezeCmd.Flags().String("start", "", "start of the freeze window")
+	teamAddFreezeCmd.Flags().String("end", "", "end of the freeze window")
+	teamAddFreezeCmd.Flags().String("reason", "", "why the deploys are frozen")
+
+	teamRemoveFreezeCmd.Flags().String("team", "", "team name")
+
+	teamListFreezesCmd.Flags().String("team", "", "team name")
 }
 
 func createTeam(cmd *cobra.Command, args []string) {
@@ -121,6 +300,10 @@ func teamAddUser(cmd *cobra.Command, args []string) {
 	if err != nil {
 		client.PrintErrorAndExit("Invalid user parameter: %v", err)
 	}
+	role, err := cmd.Flags().GetString("role")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid role parameter: %v", err)
+	}
 	if team == "" || user == "" {
 		cmd.Usage()
 		return
@@ -133,11 +316,11 @@ func teamAddUser(cmd *cobra.Command, args []string) {
 	defer conn.Close()
 
 	cli := teampb.NewTeamClient(conn)
-	req := &teampb.AddUserRequest{Name: team, User: user}
+	req := &teampb.AddUserRequest{Name: team, User: user, Role: role}
 	if _, err := cli.AddUser(context.Background(), req); err != nil {
 		client.PrintErrorAndExit(client.GetErrorMsg(err))
 	}
-	fmt.Printf("User %s is now member of the team %s\n", color.CyanString(user), color.CyanString(team))
+	fmt.Printf("User %s is now %s of the team %s\n", color.CyanString(user), role, color.CyanString(team))
 }
 
 func teamList(cmd *cobra.Command, args []string) {
@@ -169,6 +352,9 @@ func teamList(cmd *cobra.Command, args []string) {
 			}
 		}
 		fmt.Print("\n")
+		if len(t.Domains) > 0 {
+			fmt.Printf("Domains: %s\n", strings.Join(t.Domains, ", "))
+		}
 		if !showUsers {
 			continue
 		}
@@ -209,6 +395,73 @@ func teamRemoveUser(cmd *cobra.Command, args []string) {
 	fmt.Printf("User %s has been removed from the team %s\n", color.CyanString(user), color.CyanString(team))
 }
 
+func teamListUsers(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	resp, err := cli.ListUsers(context.Background(), &teampb.ListUsersRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Users) == 0 {
+		fmt.Println("The team has no members")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "EMAIL", "ROLE"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, u := range resp.Users {
+		table.Append([]string{u.Name, u.Email, u.Role})
+	}
+	table.Render()
+}
+
+func teamSetRole(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid user parameter")
+	}
+	role, err := cmd.Flags().GetString("role")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid role parameter")
+	}
+
+	if team == "" || user == "" || role == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.SetRoleRequest{Name: team, User: user, Role: role}
+	if _, err := cli.SetRole(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("User %s is now %s of the team %s\n", color.CyanString(user), role, color.CyanString(team))
+}
+
 func teamRename(cmd *cobra.Command, args []string) {
 	oldTeam, err := cmd.Flags().GetString("old")
 	if err != nil {
@@ -239,3 +492,391 @@ func teamRename(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Team %s renamed to %s with success\n", color.CyanString(oldTeam), color.CyanString(newTeam))
 }
+
+func teamDelete(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	name := args[0]
+
+	cascade, err := cmd.Flags().GetBool("cascade")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid cascade parameter")
+	}
+
+	currentClusterName, err := getClusterName()
+	if err != nil {
+		client.PrintErrorAndExit("error reading config file: %v", err)
+	}
+
+	conn, err := connection.New(cfgFile, currentClusterName)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	what := color.CyanString(name)
+	if cascade {
+		what = fmt.Sprintf("%s and all its apps", what)
+	}
+	inputMsg := fmt.Sprintf(
+		"Are you sure you want to delete %s on %s? (yes/NO) ",
+		what,
+		color.YellowString(currentClusterName),
+	)
+	s, _ := client.GetInput(inputMsg)
+	if s != "yes" {
+		fmt.Println("Delete process aborted!")
+		return
+	}
+
+	resp, _ := client.GetInput("Please re type the team name: ")
+	if resp != name {
+		fmt.Println("Delete process aborted!")
+		return
+	}
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.DeleteRequest{Name: name, Cascade: cascade}
+	if _, err := cli.Delete(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Team %s deleted with success\n", color.CyanString(name))
+}
+
+func teamInfo(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	resp, err := cli.Info(context.Background(), &teampb.InfoRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Println("Team:", color.CyanString(resp.Name))
+	if resp.Email != "" {
+		fmt.Println("Email:", resp.Email)
+	}
+	if resp.Url != "" {
+		fmt.Println("URL:", resp.Url)
+	}
+	if len(resp.Domains) > 0 {
+		fmt.Println("Domains:", strings.Join(resp.Domains, ", "))
+	}
+	if resp.TwoFactorRequired {
+		fmt.Println("Two-factor: required")
+	}
+
+	fmt.Println("Quota:")
+	q, u := resp.Quota, resp.Usage
+	fmt.Printf("  apps: %d of %s\n", u.Apps, quotaLimit(strconv.Itoa(int(q.Apps))))
+	fmt.Printf("  replicas: %d of %s\n", u.Replicas, quotaLimit(strconv.Itoa(int(q.Replicas))))
+	fmt.Printf("  cpu: %s of %s\n", u.Cpu, quotaLimit(q.Cpu))
+	fmt.Printf("  memory: %s of %s\n", u.Memory, quotaLimit(q.Memory))
+
+	if len(resp.EnvVars) > 0 {
+		fmt.Println("Env:")
+		for _, ev := range resp.EnvVars {
+			fmt.Printf("  %s=%s\n", ev.Key, ev.Value)
+		}
+	}
+}
+
+// quotaLimit returns the limit of a quota for humans
+func quotaLimit(limit string) string {
+	if limit == "" || limit == "0" {
+		return "unlimited"
+	}
+	return limit
+}
+
+func teamSetQuota(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+	apps, err := cmd.Flags().GetInt32("apps")
+	if err != nil || apps < 0 {
+		client.PrintErrorAndExit("Invalid apps parameter")
+	}
+	replicas, err := cmd.Flags().GetInt32("replicas")
+	if err != nil || replicas < 0 {
+		client.PrintErrorAndExit("Invalid replicas parameter")
+	}
+	cpu, err := cmd.Flags().GetString("cpu")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid cpu parameter")
+	}
+	memory, err := cmd.Flags().GetString("memory")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid memory parameter")
+	}
+
+	if team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.SetQuotaRequest{
+		Name:     team,
+		Apps:     apps,
+		Replicas: replicas,
+		Cpu:      cpu,
+		Memory:   memory,
+	}
+	if _, err := cli.SetQuota(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Quota of the team %s updated with success\n", color.CyanString(team))
+}
+
+func teamEnvSet(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+
+	if team == "" || len(args) == 0 {
+		cmd.Usage()
+		return
+	}
+
+	req := &teampb.SetEnvRequest{Name: team}
+	for _, item := range args {
+		tmp := strings.SplitN(item, "=", 2)
+		if len(tmp) != 2 {
+			client.PrintErrorAndExit("Env vars must be in the format FOO=bar")
+		}
+		req.EnvVars = append(req.EnvVars, &teampb.SetEnvRequest_EnvVar{Key: tmp[0], Value: tmp[1]})
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	if _, err := cli.SetEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Env vars of the team %s updated with success, they're applied on the next deploy of each app\n", color.CyanString(team))
+}
+
+func teamEnvUnset(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+
+	if team == "" || len(args) == 0 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.UnsetEnvRequest{Name: team, EnvVars: args}
+	if _, err := cli.UnsetEnv(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Env vars of the team %s removed with success, they're applied on the next deploy of each app\n", color.CyanString(team))
+}
+
+func teamSetDomains(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+
+	if team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.SetDomainsRequest{Name: team, Domains: args}
+	if _, err := cli.SetDomains(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Domains of the team %s updated with success\n", color.CyanString(team))
+}
+
+func teamSetApproval(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+	apps, err := cmd.Flags().GetStringSlice("apps")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid apps parameter")
+	}
+
+	if team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.SetApprovalRequest{Name: team, Apps: apps}
+	if _, err := cli.SetApproval(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Approval of the team %s updated with success\n", color.CyanString(team))
+}
+
+func teamAddFreeze(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+	reason, err := cmd.Flags().GetString("reason")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid reason parameter")
+	}
+
+	var bounds [2]time.Time
+	for i, name := range []string{"start", "end"} {
+		value, err := cmd.Flags().GetString(name)
+		if err != nil || value == "" {
+			cmd.Usage()
+			return
+		}
+		bounds[i], err = time.ParseInLocation(freezeTimeLayout, value, time.Local)
+		if err != nil {
+			client.PrintErrorAndExit("Invalid %s parameter, use the format %s", name, freezeTimeLayout)
+		}
+	}
+
+	if team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.AddFreezeWindowRequest{
+		Name:   team,
+		Start:  bounds[0].Unix(),
+		End:    bounds[1].Unix(),
+		Reason: reason,
+	}
+	if _, err := cli.AddFreezeWindow(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Freeze window added to the team %s with success\n", color.CyanString(team))
+}
+
+func teamRemoveFreeze(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+
+	if team == "" || len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		client.PrintErrorAndExit("Invalid freeze window id")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.RemoveFreezeWindowRequest{Name: team, Id: id}
+	if _, err := cli.RemoveFreezeWindow(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Freeze window removed from the team %s with success\n", color.CyanString(team))
+}
+
+func teamListFreezes(cmd *cobra.Command, args []string) {
+	team, err := cmd.Flags().GetString("team")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid team parameter")
+	}
+
+	if team == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	resp, err := cli.ListFreezeWindows(context.Background(), &teampb.ListFreezeWindowsRequest{Name: team})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Windows) == 0 {
+		fmt.Println("The team has no freeze windows")
+		return
+	}
+	for _, w := range resp.Windows {
+		fmt.Printf(
+			"%d: %s to %s %s\n",
+			w.Id,
+			time.Unix(w.Start, 0).Format(freezeTimeLayout),
+			time.Unix(w.End, 0).Format(freezeTimeLayout),
+			w.Reason,
+		)
+	}
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	context "golang.org/x/net/context"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	teampb "github.com/luizalabs/teresa/pkg/protobuf/team"
+)
+
+var teamCreateTokenCmd = &cobra.Command{
+	Use:   "create-token <team-name>",
+	Short: "Create an API token of the team",
+	Long: `Create an API token of the team, for CI pipelines and other machines.
+
+The token acts as a member of the team with the given role (developer,
+deployer or viewer), the deployer role is enough to deploy the team's apps.
+It expires after --expires, like 90d or 12h (up to 365d). Only admins and the
+admins of the team can create tokens.
+
+The token is shown only once, keep it in the secrets of the pipeline and use
+it in the TERESA_TOKEN env var of the client.`,
+	Example: "$ teresa team create-token foo --name ci --role deployer --expires 90d",
+	Run:     teamCreateToken,
+}
+
+var teamRevokeTokenCmd = &cobra.Command{
+	Use:     "revoke-token <team-name>",
+	Short:   "Revoke an API token of the team",
+	Long:    "Revoke an API token of the team, it stops working right away.",
+	Example: "$ teresa team revoke-token foo --name ci",
+	Run:     teamRevokeToken,
+}
+
+var teamListTokensCmd = &cobra.Command{
+	Use:     "list-tokens <team-name>",
+	Short:   "List the API tokens of the team",
+	Example: "$ teresa team list-tokens foo",
+	Run:     teamListTokens,
+}
+
+func init() {
+	teamCmd.AddCommand(teamCreateTokenCmd)
+	teamCmd.AddCommand(teamRevokeTokenCmd)
+	teamCmd.AddCommand(teamListTokensCmd)
+
+	teamCreateTokenCmd.Flags().String("name", "", "token name, like ci")
+	teamCreateTokenCmd.Flags().String("role", "deployer", "role of the token in the team: developer, deployer or viewer")
+	teamCreateTokenCmd.Flags().String("expires", "90d", "expiration of the token, like 90d or 12h")
+
+	teamRevokeTokenCmd.Flags().String("name", "", "token name")
+}
+
+// parseTokenExpiration parses the expiration of a token, a number of days
+// like 90d or a duration like 12h
+func parseTokenExpiration(value string) (time.Duration, error) {
+	if strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days < 1 {
+			return 0, fmt.Errorf("invalid number of days %s", value)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("non positive duration %s", value)
+	}
+	return d, nil
+}
+
+func teamCreateToken(cmd *cobra.Command, args []string) {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid name parameter")
+	}
+	role, err := cmd.Flags().GetString("role")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid role parameter")
+	}
+	expires, err := cmd.Flags().GetString("expires")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid expires parameter")
+	}
+
+	if len(args) != 1 || name == "" {
+		cmd.Usage()
+		return
+	}
+
+	exp, err := parseTokenExpiration(expires)
+	if err != nil {
+		client.PrintErrorAndExit("Invalid expires parameter: %v", err)
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.CreateTokenRequest{
+		Name:             args[0],
+		TokenName:        name,
+		Role:             role,
+		ExpiresInSeconds: int64(exp / time.Second),
+	}
+	resp, err := cli.CreateToken(context.Background(), req)
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Token %s created for the team %s with success\n", color.CyanString(name), color.CyanString(args[0]))
+	fmt.Println("User:", resp.User)
+	fmt.Println("Expires at:", time.Unix(resp.ExpiresAt, 0).Format(freezeTimeLayout))
+	fmt.Println("Token:", resp.Token)
+	fmt.Println(color.YellowString("Keep the token now, it isn't shown again"))
+}
+
+func teamRevokeToken(cmd *cobra.Command, args []string) {
+	name, err := cmd.Flags().GetString("name")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid name parameter")
+	}
+
+	if len(args) != 1 || name == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.RevokeTokenRequest{Name: args[0], TokenName: name}
+	if _, err := cli.RevokeToken(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	fmt.Printf("Token %s of the team %s revoked with success\n", color.CyanString(name), color.CyanString(args[0]))
+}
+
+func teamListTokens(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	resp, err := cli.ListTokens(context.Background(), &teampb.ListTokensRequest{Name: args[0]})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+
+	if len(resp.Tokens) == 0 {
+		fmt.Println("The team has no tokens")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "ROLE", "USER", "CREATED AT", "EXPIRES AT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, tk := range resp.Tokens {
+		table.Append([]string{
+			tk.Name,
+			tk.Role,
+			tk.User,
+			time.Unix(tk.CreatedAt, 0).Format(freezeTimeLayout),
+			time.Unix(tk.ExpiresAt, 0).Format(freezeTimeLayout),
+		})
+	}
+	table.Render()
+}
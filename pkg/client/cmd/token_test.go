@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTokenExpiration(t *testing.T) {
+	var testCases = []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"90d", 90 * 24 * time.Hour},
+		{"1d", 24 * time.Hour},
+		{"12h", 12 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		got, err := parseTokenExpiration(tc.value)
+		if err != nil {
+			t.Errorf("got unexpected error for %s: %v", tc.value, err)
+		} else if got != tc.expected {
+			t.Errorf("expected %v for %s, got %v", tc.expected, tc.value, got)
+		}
+	}
+
+	for _, value := range []string{"0d", "-1d", "xd", "-1h", "0s", "90"} {
+		if _, err := parseTokenExpiration(value); err == nil {
+			t.Errorf("expected error for %s", value)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	context "golang.org/x/net/context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	"github.com/luizalabs/teresa/pkg/client/connection"
+	teampb "github.com/luizalabs/teresa/pkg/protobuf/team"
+	userpb "github.com/luizalabs/teresa/pkg/protobuf/user"
+)
+
+var setTwoFactorCmd = &cobra.Command{
+	Use:   "set-2fa",
+	Short: "Enable the two-factor authentication",
+	Long: `Enable the two-factor authentication of the current user.
+
+Add the secret shown to an authenticator app (most of them take the otpauth
+URL as a QR code) and type the code generated by it to confirm. From then on
+the login asks for a code as well.
+
+To disable it:
+
+	$ teresa set-2fa --disable
+
+To disable it for another user, who lost the device (needs admin):
+
+	$ teresa set-2fa --disable --user user@mydomain.com`,
+	Run: setTwoFactor,
+}
+
+var teamSetTwoFactorRequiredCmd = &cobra.Command{
+	Use:   "set-2fa-required <team-name>",
+	Short: "Require the two-factor authentication of the members",
+	Long: `Require the two-factor authentication of the members of the team.
+
+The members without it enabled lose access to the apps of the team until
+they enable it with "teresa set-2fa", the API tokens of the team keep working.`,
+	Example: "$ teresa team set-2fa-required foo [--disable]",
+	Run:     teamSetTwoFactorRequired,
+}
+
+func init() {
+	RootCmd.AddCommand(setTwoFactorCmd)
+	setTwoFactorCmd.Flags().Bool("disable", false, "disable the two-factor authentication")
+	setTwoFactorCmd.Flags().String("user", "", "user to disable the two-factor authentication, if not provided the current user")
+
+	teamCmd.AddCommand(teamSetTwoFactorRequiredCmd)
+	teamSetTwoFactorRequiredCmd.Flags().Bool("disable", false, "stop requiring the two-factor authentication")
+}
+
+func setTwoFactor(cmd *cobra.Command, args []string) {
+	disable, _ := cmd.Flags().GetBool("disable")
+	user, err := cmd.Flags().GetString("user")
+	if err != nil {
+		client.PrintErrorAndExit("Invalid user parameter: %v", err)
+	}
+	if user != "" && !disable {
+		client.PrintErrorAndExit("The user parameter is only valid with --disable")
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+	cli := userpb.NewUserClient(conn)
+
+	if disable {
+		var code string
+		if user == "" {
+			if code, err = client.GetInput("Code: "); err != nil {
+				client.PrintErrorAndExit("Error trying to get the code: %v", err)
+			}
+		}
+		req := &userpb.DisableTwoFactorRequest{Code: code, User: user}
+		if _, err := cli.DisableTwoFactor(context.Background(), req); err != nil {
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		fmt.Println("Two-factor authentication disabled")
+		return
+	}
+
+	resp, err := cli.SetTwoFactor(context.Background(), &userpb.SetTwoFactorRequest{})
+	if err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Secret:", color.CyanString(resp.Secret))
+	fmt.Println("URL:", resp.Url)
+
+	code, err := client.GetInput("Code: ")
+	if err != nil {
+		client.PrintErrorAndExit("Error trying to get the code: %v", err)
+	}
+	if _, err := cli.SetTwoFactor(context.Background(), &userpb.SetTwoFactorRequest{Code: code}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	color.Green("Two-factor authentication enabled")
+}
+
+func teamSetTwoFactorRequired(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	disable, _ := cmd.Flags().GetBool("disable")
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := teampb.NewTeamClient(conn)
+	req := &teampb.SetTwoFactorRequiredRequest{Name: args[0], Required: !disable}
+	if _, err := cli.SetTwoFactorRequired(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	if disable {
+		fmt.Println("Two-factor authentication not required anymore")
+	} else {
+		fmt.Println("Two-factor authentication required")
+	}
+}
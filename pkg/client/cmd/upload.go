@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	upb "github.com/luizalabs/teresa/pkg/protobuf/upload"
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	uploadChunkSize = 1 << 20
+	uploadRetries   = 5
+)
+
+var uploadRetryInterval = 2 * time.Second
+
+// uploadTarball sends the tarball in chunks, resuming from what the server
+// already has if the connection drops, and returns its checksum. Servers
+// without resumable uploads return a blank checksum, the tarball must be
+// sent along with the request then
+func uploadTarball(conn *grpc.ClientConn, appName, tarPath string) (string, error) {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	checksum, size, err := fileChecksum(f)
+	if err != nil {
+		return "", err
+	}
+
+	cli := upb.NewUploadClient(conn)
+	var lastErr error
+	for attempt := 0; attempt <= uploadRetries; attempt++ {
+		if attempt > 0 {
+			fmt.Fprintf(os.Stderr, "\nUpload interrupted (%s), resuming...\n", client.GetErrorMsg(lastErr))
+			time.Sleep(time.Duration(attempt) * uploadRetryInterval)
+		}
+
+		req := &upb.StatusRequest{App: appName, Checksum: checksum}
+		resp, err := cli.Status(context.Background(), req)
+		if err == nil {
+			err = sendChunks(cli, f, req, resp.Offset, size)
+		}
+		if err == nil {
+			return checksum, nil
+		}
+		if grpc.Code(err) == codes.Unimplemented {
+			return "", nil
+		}
+		if !retryableUploadErr(err) {
+			return "", err
+		}
+		lastErr = err
+	}
+	return "", lastErr
+}
+
+// sendChunks sends the file from offset on, printing the progress
+func sendChunks(cli upb.UploadClient, f *os.File, req *upb.StatusRequest, offset, size int64) error {
+	printUploadProgress(offset, size)
+	if offset >= size {
+		fmt.Println()
+		return nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	stream, err := cli.Send(context.Background())
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, uploadChunkSize)
+	for offset < size {
+		n, err := io.ReadFull(f, buf)
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		chunk := buf[:n]
+		sum := sha256.Sum256(chunk)
+		msg := &upb.SendRequest{
+			App:           req.App,
+			Checksum:      req.Checksum,
+			Offset:        offset,
+			Chunk:         chunk,
+			ChunkChecksum: hex.EncodeToString(sum[:]),
+		}
+		if err := stream.Send(msg); err != nil {
+			// the server error, if any, is returned on close
+			_, err = stream.CloseAndRecv()
+			return err
+		}
+		offset += int64(n)
+		printUploadProgress(offset, size)
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+func fileChecksum(f *os.File) (string, int64, error) {
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// retryableUploadErr tells if the upload may succeed when resumed, the
+// other errors are the ones of the request itself
+func retryableUploadErr(err error) bool {
+	switch grpc.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied,
+		codes.Unauthenticated, codes.FailedPrecondition:
+		return false
+	}
+	return true
+}
+
+func printUploadProgress(sent, size int64) {
+	percent := int32(100)
+	if size > 0 {
+		percent = int32(sent * 100 / size)
+	}
+	fmt.Printf("\rUploading %s %3d%% (%s/%s)", progressBar(percent, 100), percent, humanBytes(sent), humanBytes(size))
+}
+
+// humanBytes formats the size in B, KB or MB
+func humanBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHumanBytes(t *testing.T) {
+	var testCases = []struct {
+		n        int64
+		expected string
+	}{
+		{512, "512B"},
+		{1536, "1.5KB"},
+		{300 << 20, "300.0MB"},
+	}
+
+	for _, tc := range testCases {
+		if got := humanBytes(tc.n); got != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, got)
+		}
+	}
+}
+
+func TestRetryableUploadErr(t *testing.T) {
+	var testCases = []struct {
+		err      error
+		expected bool
+	}{
+		{errors.New("connection reset"), true},
+		{status.Errorf(codes.Unavailable, "transport is closing"), true},
+		{status.Errorf(codes.OutOfRange, "chunk out of the upload offset"), true},
+		{status.Errorf(codes.PermissionDenied, "permission denied"), false},
+		{status.Errorf(codes.InvalidArgument, "invalid upload checksum"), false},
+	}
+
+	for _, tc := range testCases {
+		if got := retryableUploadErr(tc.err); got != tc.expected {
+			t.Errorf("expected %t, got %t for %v", tc.expected, got, tc.err)
+		}
+	}
+}
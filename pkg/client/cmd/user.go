@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	context "golang.org/x/net/context"
 
@@ -33,6 +34,31 @@ var deleteUserCmd = &cobra.Command{
 	Run:   deleteUser,
 }
 
+// manage the users
+var usersCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Everything about users",
+}
+
+var suspendUserCmd = &cobra.Command{
+	Use:   "suspend <email>",
+	Short: "Suspend an user",
+	Long: `Suspend an user, admins only.
+
+The user is kept, with its history, but can't login nor use its current
+tokens, including the API tokens of the teams. Undo it with reactivate.`,
+	Example: "  $ teresa user suspend gopher@luizalabs.com",
+	Run:     suspendUser,
+}
+
+var reactivateUserCmd = &cobra.Command{
+	Use:     "reactivate <email>",
+	Short:   "Reactivate a suspended user",
+	Long:    "Reactivate a suspended user, admins only. The user must login again.",
+	Example: "  $ teresa user reactivate gopher@luizalabs.com",
+	Run:     reactivateUser,
+}
+
 // set password for an user
 var setUserPasswordCmd = &cobra.Command{
 	Use:   "set-password",
@@ -43,6 +69,21 @@ To set password for another user (needs admin):
 	Run: setPassword,
 }
 
+// reset the password of an user through an e-mail
+var resetPasswordCmd = &cobra.Command{
+	Use:   "reset-password",
+	Short: "Reset a forgotten password",
+	Long: `Reset a forgotten password.
+
+A token is sent to the e-mail of the user, valid for an hour, and asked along
+with the new password:
+
+	$ teresa reset-password --user user@mydomain.com
+
+The token of an e-mail already received is given by --token.`,
+	Run: resetPassword,
+}
+
 func setPassword(cmd *cobra.Command, args []string) {
 	p, err := client.GetMaskedPassword("New Password: ")
 	if err != nil {
@@ -73,6 +114,47 @@ func setPassword(cmd *cobra.Command, args []string) {
 	fmt.Println("Password updated")
 }
 
+func resetPassword(cmd *cobra.Command, args []string) {
+	user, _ := cmd.Flags().GetString("user")
+	token, _ := cmd.Flags().GetString("token")
+	if user == "" {
+		cmd.Usage()
+		return
+	}
+
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+	cli := userpb.NewUserClient(conn)
+
+	if token == "" {
+		req := &userpb.RequestPasswordResetRequest{Email: user}
+		if _, err := cli.RequestPasswordReset(context.Background(), req); err != nil {
+			client.PrintErrorAndExit(client.GetErrorMsg(err))
+		}
+		fmt.Println("If the user exists, a password reset token was sent to", user)
+		if token, err = client.GetMaskedPassword("Token: "); err != nil {
+			client.PrintErrorAndExit("Error trying to get the token: %v", err)
+		}
+	}
+
+	p, err := client.GetMaskedPassword("New Password: ")
+	if err != nil {
+		client.PrintErrorAndExit("Error trying to get the user password: %v", err)
+	}
+	if err = client.EnsurePasswordLength(p); err != nil {
+		client.PrintErrorAndExit(err.Error())
+	}
+
+	req := &userpb.ResetPasswordRequest{Token: strings.TrimSpace(token), Password: p}
+	if _, err := cli.ResetPassword(context.Background(), req); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("Password updated")
+}
+
 func deleteUser(cmd *cobra.Command, args []string) {
 	email, _ := cmd.Flags().GetString("email")
 	if email == "" {
@@ -96,6 +178,42 @@ func deleteUser(cmd *cobra.Command, args []string) {
 	fmt.Println("User deleted")
 }
 
+func suspendUser(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := userpb.NewUserClient(conn)
+	if _, err := cli.Suspend(context.Background(), &userpb.SuspendRequest{Email: args[0]}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("User suspended")
+}
+
+func reactivateUser(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	conn, err := connection.New(cfgFile, cfgCluster)
+	if err != nil {
+		client.PrintErrorAndExit("Error connecting to server: %v", err)
+	}
+	defer conn.Close()
+
+	cli := userpb.NewUserClient(conn)
+	if _, err := cli.Reactivate(context.Background(), &userpb.ReactivateRequest{Email: args[0]}); err != nil {
+		client.PrintErrorAndExit(client.GetErrorMsg(err))
+	}
+	fmt.Println("User reactivated")
+}
+
 func createUser(cmd *cobra.Command, args []string) {
 	name, err := cmd.Flags().GetString("name")
 	if err != nil {
@@ -144,6 +262,14 @@ func init() {
 	deleteCmd.AddCommand(deleteUserCmd)
 	deleteUserCmd.Flags().String("email", "", "user email [required]")
 
+	RootCmd.AddCommand(usersCmd)
+	usersCmd.AddCommand(suspendUserCmd)
+	usersCmd.AddCommand(reactivateUserCmd)
+
 	RootCmd.AddCommand(setUserPasswordCmd)
 	setUserPasswordCmd.Flags().String("user", "", "user to set the password, if not provided will set the current user password")
+
+	RootCmd.AddCommand(resetPasswordCmd)
+	resetPasswordCmd.Flags().String("user", "", "e-mail of the user [required]")
+	resetPasswordCmd.Flags().String("token", "", "token of the password reset e-mail, if already received")
 }
@@ -12,10 +12,14 @@ import (
 )
 
 type ClusterConfig struct {
-	Server   string `yaml:"server"`
-	Token    string `yaml:"token"`
-	UseTLS   bool   `yaml:"tls"`
-	Insecure bool   `yaml:"insecure"`
+	Server         string `yaml:"server"`
+	Token          string `yaml:"token"`
+	RefreshToken   string `yaml:"refresh_token,omitempty"`
+	TokenExpiresAt int64  `yaml:"token_expires_at,omitempty"`
+	UseTLS         bool   `yaml:"tls"`
+	Insecure       bool   `yaml:"insecure"`
+	// Impersonate is the user the requests act as, set by --as
+	Impersonate string `yaml:"-"`
 }
 
 type Config struct {
@@ -39,6 +43,12 @@ func init() {
 }
 
 func SaveToken(cfgFile, cfgCluster, token string) error {
+	return SaveSession(cfgFile, cfgCluster, token, "", 0)
+}
+
+// SaveSession saves the access token of a session with its refresh token and
+// expiration (unix time), used to renew the access token before it expires
+func SaveSession(cfgFile, cfgCluster, token, refreshToken string, expiresAt int64) error {
 	cfg, err := ReadConfigFile(cfgFile)
 	if err != nil {
 		return err
@@ -53,6 +63,8 @@ func SaveToken(cfgFile, cfgCluster, token string) error {
 	}
 
 	cc.Token = token
+	cc.RefreshToken = refreshToken
+	cc.TokenExpiresAt = expiresAt
 	cfg.Clusters[cluster] = cc
 	return SaveConfigFile(cfgFile, cfg)
 }
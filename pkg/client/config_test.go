@@ -172,3 +172,40 @@ func TestSaveToken(t *testing.T) {
 		t.Errorf("expected %s, got %s", expectedToken, c.Token)
 	}
 }
+
+func TestSaveSession(t *testing.T) {
+	conf := &Config{
+		CurrentCluster: "cluster-a",
+		Clusters: map[string]ClusterConfig{
+			"cluster-a": {Token: "token-a", Server: "http://teresa.com"},
+		},
+	}
+
+	confPath := filepath.Join("testdata", "temp.yaml")
+	if err := SaveConfigFile(confPath, conf); err != nil {
+		t.Fatal("error trying to save config file: ", err)
+	}
+	defer os.Remove(confPath)
+
+	if err := SaveSession(confPath, "", "gopher", "refresh", 42); err != nil {
+		t.Fatal("error trying to save session: ", err)
+	}
+	c, err := GetConfig(confPath, "")
+	if err != nil {
+		t.Fatal("error trying to get config: ", err)
+	}
+	if c.Token != "gopher" || c.RefreshToken != "refresh" || c.TokenExpiresAt != 42 {
+		t.Errorf("expected gopher, refresh and 42, got %s, %s and %d", c.Token, c.RefreshToken, c.TokenExpiresAt)
+	}
+
+	if err := SaveToken(confPath, "", "gopher"); err != nil {
+		t.Fatal("error trying to save token: ", err)
+	}
+	c, err = GetConfig(confPath, "")
+	if err != nil {
+		t.Fatal("error trying to get config: ", err)
+	}
+	if c.RefreshToken != "" || c.TokenExpiresAt != 0 {
+		t.Errorf("expected the session cleared, got %s and %d", c.RefreshToken, c.TokenExpiresAt)
+	}
+}
@@ -1,14 +1,73 @@
 package connection
 
 import (
-	"github.com/luizalabs/teresa/pkg/client"
+	"os"
+	"time"
+
+	context "golang.org/x/net/context"
 	"google.golang.org/grpc"
+
+	"github.com/luizalabs/teresa/pkg/client"
+	userpb "github.com/luizalabs/teresa/pkg/protobuf/user"
 )
 
+// tokenEnvVar overrides the token of the config file, for the team tokens of
+// CI pipelines
+const tokenEnvVar = "TERESA_TOKEN"
+
+// refreshMargin is how long before its expiration the access token of a
+// session is renewed
+const refreshMargin = time.Minute
+
+// impersonated is the user the connections act as
+var impersonated string
+
+// ImpersonateUser makes the next connections act as the user, for the admins
+// to reproduce its permissions
+func ImpersonateUser(email string) {
+	impersonated = email
+}
+
 func New(cfgFile, cfgCluster string) (*grpc.ClientConn, error) {
 	cfg, err := client.GetConfig(cfgFile, cfgCluster)
 	if err != nil {
 		return nil, err
 	}
+	if token := os.Getenv(tokenEnvVar); token != "" {
+		cfg.Token = token
+	} else if needsRefresh(cfg, time.Now()) {
+		refresh(cfgFile, cfgCluster, cfg)
+	}
+	cfg.Impersonate = impersonated
 	return client.New(*cfg)
 }
+
+func needsRefresh(cfg *client.ClusterConfig, now time.Time) bool {
+	if cfg.RefreshToken == "" {
+		return false
+	}
+	return now.Add(refreshMargin).Unix() >= cfg.TokenExpiresAt
+}
+
+// refresh renews the access token of the session of cfg and saves it on the
+// config file. On failure the current token is kept, the server tells the
+// user to login again if it is not valid anymore.
+func refresh(cfgFile, cfgCluster string, cfg *client.ClusterConfig) {
+	conn, err := client.New(*cfg)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	cli := userpb.NewUserClient(conn)
+	req := &userpb.RefreshRequest{RefreshToken: cfg.RefreshToken}
+	res, err := cli.Refresh(context.Background(), req)
+	if err != nil {
+		return
+	}
+
+	cfg.Token = res.Token
+	cfg.RefreshToken = res.RefreshToken
+	cfg.TokenExpiresAt = res.ExpiresAt
+	client.SaveSession(cfgFile, cfgCluster, res.Token, res.RefreshToken, res.ExpiresAt)
+}
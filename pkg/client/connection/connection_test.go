@@ -0,0 +1,27 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/client"
+)
+
+func TestNeedsRefresh(t *testing.T) {
+	now := time.Now()
+	var testCases = []struct {
+		cfg      *client.ClusterConfig
+		expected bool
+	}{
+		{&client.ClusterConfig{Token: "token"}, false},
+		{&client.ClusterConfig{RefreshToken: "refresh", TokenExpiresAt: now.Add(time.Hour).Unix()}, false},
+		{&client.ClusterConfig{RefreshToken: "refresh", TokenExpiresAt: now.Add(time.Second).Unix()}, true},
+		{&client.ClusterConfig{RefreshToken: "refresh", TokenExpiresAt: now.Add(-time.Hour).Unix()}, true},
+	}
+
+	for _, tc := range testCases {
+		if actual := needsRefresh(tc.cfg, now); actual != tc.expected {
+			t.Errorf("expected %v, got %v for %v", tc.expected, actual, tc.cfg)
+		}
+	}
+}
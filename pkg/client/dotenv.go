@@ -0,0 +1,55 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseDotEnv reads a dotenv file and returns its env vars in the KEY=value
+// format. Blank lines, comments and the export prefix are ignored, values
+// may be single or double quoted.
+func ParseDotEnv(r io.Reader) ([]string, error) {
+	evs := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		tmp := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(tmp[0])
+		if len(tmp) != 2 || key == "" {
+			return nil, fmt.Errorf("invalid line %d: env vars must be in the format FOO=bar", n)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(tmp[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in line %d: %v", n, err)
+		}
+		evs = append(evs, fmt.Sprintf("%s=%s", key, value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return evs, nil
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if len(value) > 1 {
+		switch q := value[0]; {
+		case q == '"' && value[len(value)-1] == '"':
+			return strconv.Unquote(value)
+		case q == '\'' && value[len(value)-1] == '\'':
+			return value[1 : len(value)-1], nil
+		}
+	}
+	if i := strings.Index(value, " #"); i != -1 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value, nil
+}
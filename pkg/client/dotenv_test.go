@@ -0,0 +1,47 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	content := `# database
+DB_HOST=localhost
+export DB_PORT=5432
+
+DB_PASSWORD="s3cr3t # not a comment"
+GREETING='hello world'
+MULTILINE="foo\nbar"
+DSN=postgres://user@localhost/db?sslmode=disable
+DEBUG=true # enable debug
+EMPTY=
+`
+	want := []string{
+		"DB_HOST=localhost",
+		"DB_PORT=5432",
+		"DB_PASSWORD=s3cr3t # not a comment",
+		"GREETING=hello world",
+		"MULTILINE=foo\nbar",
+		"DSN=postgres://user@localhost/db?sslmode=disable",
+		"DEBUG=true",
+		"EMPTY=",
+	}
+
+	evs, err := ParseDotEnv(strings.NewReader(content))
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(evs, want) {
+		t.Errorf("got %q; want %q", evs, want)
+	}
+}
+
+func TestParseDotEnvInvalid(t *testing.T) {
+	for _, content := range []string{"FOO", "=bar", `FOO="bar\"`} {
+		if _, err := ParseDotEnv(strings.NewReader(content)); err == nil {
+			t.Errorf("expected error, got nil [case: %s]", content)
+		}
+	}
+}
@@ -6,9 +6,22 @@ import (
 
 	"github.com/fatih/color"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+// exitCodes are the exit codes of the server errors by their gRPC code, the
+// other errors exit with 1
+var exitCodes = map[codes.Code]int{
+	codes.InvalidArgument:    2,
+	codes.Unauthenticated:    3,
+	codes.PermissionDenied:   3,
+	codes.NotFound:           4,
+	codes.FailedPrecondition: 5,
+	codes.DeadlineExceeded:   6,
+	codes.Aborted:            7,
+}
+
 func GetErrorMsg(err error) string {
 	stat, ok := status.FromError(err)
 	if !ok {
@@ -17,6 +30,25 @@ func GetErrorMsg(err error) string {
 	return stat.Message()
 }
 
+// ExitCode returns the exit code of the error by its gRPC code
+func ExitCode(err error) int {
+	stat, ok := status.FromError(err)
+	if !ok {
+		return 1
+	}
+	if code, found := exitCodes[stat.Code()]; found {
+		return code
+	}
+	return 1
+}
+
+// PrintServerErrorAndExit prints the message of the error, exiting with its
+// exit code
+func PrintServerErrorAndExit(err error) {
+	fmt.Fprintln(os.Stderr, color.RedString(GetErrorMsg(err)))
+	os.Exit(ExitCode(err))
+}
+
 func PrintErrorAndExit(format string, args ...interface{}) {
 	fmt.Fprintln(os.Stderr, color.RedString(format, args...))
 	os.Exit(1)
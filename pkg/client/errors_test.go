@@ -31,6 +31,24 @@ func TestGetErrorMsg(t *testing.T) {
 
 }
 
+func TestExitCode(t *testing.T) {
+	var testCases = []struct {
+		err      error
+		expected int
+	}{
+		{auth.ErrPermissionDenied, 3},
+		{status.Errorf(codes.Aborted, "Deploy rolled back"), 7},
+		{status.Errorf(codes.Unavailable, "Server Unavailable"), 1},
+		{errors.New("Generic Error"), 1},
+	}
+
+	for _, tc := range testCases {
+		if actual := ExitCode(tc.err); actual != tc.expected {
+			t.Errorf("expected %d, got %d for %v", tc.expected, actual, tc.err)
+		}
+	}
+}
+
 func TestPrintErrorAndExit(t *testing.T) {
 	if os.Getenv("PRINT_ERROR_AND_EXIT") == "1" {
 		PrintErrorAndExit("Some terrible error")
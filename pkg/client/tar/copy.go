@@ -0,0 +1,91 @@
+package tar
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Write writes an uncompressed tar of the file or directory src to w, the
+// entries are named after name
+func Write(w io.Writer, src, name string) error {
+	tw := tar.NewWriter(w)
+	src = filepath.Clean(src)
+
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Wrap(err, "on walk call")
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return errors.Wrap(err, "invalid path")
+		}
+		entry := path.Join(name, filepath.ToSlash(rel))
+
+		if !info.IsDir() {
+			return addFile(tw, p, entry, info)
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return errors.Wrap(err, "failed to build tar header")
+		}
+		header.Name = entry + "/"
+		return errors.Wrap(tw.WriteHeader(header), "failed to write tar header")
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(tw.Close(), "failed to close tarball")
+}
+
+// Extract extracts the entries named after name of the tar read from r to
+// dst, any other entry is an error so nothing is written outside of dst
+func Extract(r io.Reader, name, dst string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "tar iteration failed")
+		}
+
+		entry := path.Clean(hdr.Name)
+		if entry != name && !strings.HasPrefix(entry, name+"/") {
+			return errors.Errorf("unexpected entry %s", hdr.Name)
+		}
+		target := filepath.Join(dst, filepath.FromSlash(strings.TrimPrefix(entry, name)))
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, os.FileMode(hdr.Mode)|0700); err != nil {
+				return errors.Wrap(err, "mkdir failed")
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			if err := extractFile(tr, target, os.FileMode(hdr.Mode)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return errors.Wrap(err, "mkdir failed")
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return errors.Wrap(err, "failed to create file")
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, r)
+	return errors.Wrap(err, "copy failed")
+}
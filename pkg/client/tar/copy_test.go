@@ -0,0 +1,89 @@
+package tar
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestWriteAndExtractDir(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, "testdata/create", "create"); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp, err := ioutil.TempDir("", "teresa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dst := filepath.Join(tmp, "copy")
+	if err := Extract(buf, "create", dst); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := tree(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(names)
+
+	if len(names) != 2 {
+		t.Fatalf("want 2; got %d", len(names))
+	}
+	if names[0] != filepath.Join("dir", "file2.txt") {
+		t.Errorf("want dir/file2.txt; got %s", names[0])
+	}
+	if names[1] != "file1.txt" {
+		t.Errorf("want file1.txt; got %s", names[1])
+	}
+}
+
+func TestWriteAndExtractFile(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := Write(buf, "testdata/create/file1.txt", "renamed.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp, err := ioutil.TempDir("", "teresa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	dst := filepath.Join(tmp, "file.txt")
+	if err := Extract(buf, "renamed.txt", dst); err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := ioutil.ReadFile("testdata/create/file1.txt")
+	got, err := ioutil.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("want %q; got %q", want, got)
+	}
+}
+
+func TestExtractUnexpectedEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	tw.WriteHeader(&tar.Header{Name: "foo/../../etc/passwd", Mode: 0600, Typeflag: tar.TypeReg})
+	tw.Close()
+
+	tmp, err := ioutil.TempDir("", "teresa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := Extract(buf, "foo", tmp); err == nil {
+		t.Error("want error; got nil")
+	}
+}
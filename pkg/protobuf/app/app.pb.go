@@ -24,6 +24,52 @@ It has these top-level messages:
 	DeletePodsRequest
 	ChangeTeamRequest
 	SetVHostsRequest
+	RenameRequest
+	CloneRequest
+	ScaleRequest
+	PauseRequest
+	ResumeRequest
+	RestartRequest
+	StatusRequest
+	StatusResponse
+	EventsRequest
+	EventsResponse
+	TopRequest
+	TopResponse
+	SetResourcesRequest
+	EnvHistoryRequest
+	EnvHistoryResponse
+	EnvRollbackRequest
+	SetMaintenanceRequest
+	VHostRequest
+	EnableTLSRequest
+	UpdateIngressRequest
+	SetACLRequest
+	AutoscaleMetric
+	ScaleWindow
+	SetAutoscaleScheduleRequest
+	SetPDBRequest
+	VolumeClaim
+	AddVolumeRequest
+	RemoveVolumeRequest
+	ConfigFile
+	SetConfigFileRequest
+	UnsetConfigFileRequest
+	UpdateLifecycleRequest
+	UpdateStrategyRequest
+	DeployMetadata
+	SetBuilderRequest
+	LockRequest
+	UnlockRequest
+	UpdateRolloutTimeoutRequest
+	CronRequest
+	CronListResponse
+	CronRunNowResponse
+	CronRunsRequest
+	CronRunsResponse
+	CronRunLogsRequest
+	PortForwardRequest
+	PortForwardResponse
 	Empty
 */
 package app
@@ -57,6 +103,7 @@ type CreateRequest struct {
 	VirtualHost string                   `protobuf:"bytes,6,opt,name=virtual_host,json=virtualHost" json:"virtual_host,omitempty"`
 	Internal    bool                     `protobuf:"varint,7,opt,name=internal" json:"internal,omitempty"`
 	Protocol    string                   `protobuf:"bytes,8,opt,name=protocol" json:"protocol,omitempty"`
+	Cluster     string                   `protobuf:"bytes,9,opt,name=cluster" json:"cluster,omitempty"`
 }
 
 func (m *CreateRequest) Reset()                    { *m = CreateRequest{} }
@@ -120,6 +167,13 @@ func (m *CreateRequest) GetProtocol() string {
 	return ""
 }
 
+func (m *CreateRequest) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
 type CreateRequest_Limits struct {
 	Default        []*CreateRequest_Limits_LimitRangeQuantity `protobuf:"bytes,1,rep,name=default" json:"default,omitempty"`
 	DefaultRequest []*CreateRequest_Limits_LimitRangeQuantity `protobuf:"bytes,2,rep,name=default_request,json=defaultRequest" json:"default_request,omitempty"`
@@ -253,12 +307,17 @@ func (m *ListResponse_App) GetUrls() []string {
 }
 
 type LogsRequest struct {
-	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Lines     int64  `protobuf:"varint,2,opt,name=lines" json:"lines,omitempty"`
-	Follow    bool   `protobuf:"varint,3,opt,name=follow" json:"follow,omitempty"`
-	PodName   string `protobuf:"bytes,4,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
-	Previous  bool   `protobuf:"varint,5,opt,name=previous" json:"previous,omitempty"`
-	Container string `protobuf:"bytes,6,opt,name=container" json:"container,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Lines        int64  `protobuf:"varint,2,opt,name=lines" json:"lines,omitempty"`
+	Follow       bool   `protobuf:"varint,3,opt,name=follow" json:"follow,omitempty"`
+	PodName      string `protobuf:"bytes,4,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	Previous     bool   `protobuf:"varint,5,opt,name=previous" json:"previous,omitempty"`
+	Container    string `protobuf:"bytes,6,opt,name=container" json:"container,omitempty"`
+	Process      string `protobuf:"bytes,7,opt,name=process" json:"process,omitempty"`
+	Grep         string `protobuf:"bytes,8,opt,name=grep" json:"grep,omitempty"`
+	SinceSeconds int64  `protobuf:"varint,9,opt,name=since_seconds,json=sinceSeconds" json:"since_seconds,omitempty"`
+	Timestamps   bool   `protobuf:"varint,10,opt,name=timestamps" json:"timestamps,omitempty"`
+	Output       string `protobuf:"bytes,11,opt,name=output" json:"output,omitempty"`
 }
 
 func (m *LogsRequest) Reset()                    { *m = LogsRequest{} }
@@ -308,6 +367,41 @@ func (m *LogsRequest) GetContainer() string {
 	return ""
 }
 
+func (m *LogsRequest) GetProcess() string {
+	if m != nil {
+		return m.Process
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetGrep() string {
+	if m != nil {
+		return m.Grep
+	}
+	return ""
+}
+
+func (m *LogsRequest) GetSinceSeconds() int64 {
+	if m != nil {
+		return m.SinceSeconds
+	}
+	return 0
+}
+
+func (m *LogsRequest) GetTimestamps() bool {
+	if m != nil {
+		return m.Timestamps
+	}
+	return false
+}
+
+func (m *LogsRequest) GetOutput() string {
+	if m != nil {
+		return m.Output
+	}
+	return ""
+}
+
 type LogsResponse struct {
 	Text string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
 }
@@ -341,14 +435,32 @@ func (m *InfoRequest) GetName() string {
 }
 
 type InfoResponse struct {
-	Team      string                  `protobuf:"bytes,1,opt,name=team" json:"team,omitempty"`
-	Addresses []*InfoResponse_Address `protobuf:"bytes,2,rep,name=addresses" json:"addresses,omitempty"`
-	EnvVars   []*InfoResponse_EnvVar  `protobuf:"bytes,3,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
-	Status    *InfoResponse_Status    `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
-	Autoscale *InfoResponse_Autoscale `protobuf:"bytes,5,opt,name=autoscale" json:"autoscale,omitempty"`
-	Limits    *InfoResponse_Limits    `protobuf:"bytes,6,opt,name=limits" json:"limits,omitempty"`
-	Protocol  string                  `protobuf:"bytes,7,opt,name=protocol" json:"protocol,omitempty"`
-	Volumes   []string                `protobuf:"bytes,8,rep,name=volumes" json:"volumes,omitempty"`
+	Team                          string                  `protobuf:"bytes,1,opt,name=team" json:"team,omitempty"`
+	Addresses                     []*InfoResponse_Address `protobuf:"bytes,2,rep,name=addresses" json:"addresses,omitempty"`
+	EnvVars                       []*InfoResponse_EnvVar  `protobuf:"bytes,3,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+	Status                        *InfoResponse_Status    `protobuf:"bytes,4,opt,name=status" json:"status,omitempty"`
+	Autoscale                     *InfoResponse_Autoscale `protobuf:"bytes,5,opt,name=autoscale" json:"autoscale,omitempty"`
+	Limits                        *InfoResponse_Limits    `protobuf:"bytes,6,opt,name=limits" json:"limits,omitempty"`
+	Protocol                      string                  `protobuf:"bytes,7,opt,name=protocol" json:"protocol,omitempty"`
+	Volumes                       []string                `protobuf:"bytes,8,rep,name=volumes" json:"volumes,omitempty"`
+	Maintenance                   bool                    `protobuf:"varint,9,opt,name=maintenance" json:"maintenance,omitempty"`
+	Tls                           string                  `protobuf:"bytes,10,opt,name=tls" json:"tls,omitempty"`
+	SourceRanges                  []string                `protobuf:"bytes,11,rep,name=source_ranges,json=sourceRanges" json:"source_ranges,omitempty"`
+	ScaleWindows                  []*ScaleWindow          `protobuf:"bytes,12,rep,name=scale_windows,json=scaleWindows" json:"scale_windows,omitempty"`
+	MinAvailable                  string                  `protobuf:"bytes,13,opt,name=min_available,json=minAvailable" json:"min_available,omitempty"`
+	VolumeClaims                  []*VolumeClaim          `protobuf:"bytes,14,rep,name=volume_claims,json=volumeClaims" json:"volume_claims,omitempty"`
+	ConfigFiles                   []*ConfigFile           `protobuf:"bytes,15,rep,name=config_files,json=configFiles" json:"config_files,omitempty"`
+	DrainTimeoutSeconds           int32                   `protobuf:"varint,16,opt,name=drain_timeout_seconds,json=drainTimeoutSeconds" json:"drain_timeout_seconds,omitempty"`
+	TerminationGracePeriodSeconds int32                   `protobuf:"varint,17,opt,name=termination_grace_period_seconds,json=terminationGracePeriodSeconds" json:"termination_grace_period_seconds,omitempty"`
+	MaxSurge                      string                  `protobuf:"bytes,18,opt,name=max_surge,json=maxSurge" json:"max_surge,omitempty"`
+	MaxUnavailable                string                  `protobuf:"bytes,19,opt,name=max_unavailable,json=maxUnavailable" json:"max_unavailable,omitempty"`
+	Deploy                        *DeployMetadata         `protobuf:"bytes,20,opt,name=deploy" json:"deploy,omitempty"`
+	Builder                       string                  `protobuf:"bytes,21,opt,name=builder" json:"builder,omitempty"`
+	BuildEnvVars                  []string                `protobuf:"bytes,22,rep,name=build_env_vars,json=buildEnvVars" json:"build_env_vars,omitempty"`
+	LockReason                    string                  `protobuf:"bytes,23,opt,name=lock_reason,json=lockReason" json:"lock_reason,omitempty"`
+	LockedBy                      string                  `protobuf:"bytes,24,opt,name=locked_by,json=lockedBy" json:"locked_by,omitempty"`
+	RolloutTimeoutSeconds         int32                   `protobuf:"varint,25,opt,name=rollout_timeout_seconds,json=rolloutTimeoutSeconds" json:"rollout_timeout_seconds,omitempty"`
+	Cluster                       string                  `protobuf:"bytes,26,opt,name=cluster" json:"cluster,omitempty"`
 }
 
 func (m *InfoResponse) Reset()                    { *m = InfoResponse{} }
@@ -412,6 +524,132 @@ func (m *InfoResponse) GetVolumes() []string {
 	return nil
 }
 
+func (m *InfoResponse) GetMaintenance() bool {
+	if m != nil {
+		return m.Maintenance
+	}
+	return false
+}
+
+func (m *InfoResponse) GetTls() string {
+	if m != nil {
+		return m.Tls
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetSourceRanges() []string {
+	if m != nil {
+		return m.SourceRanges
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetScaleWindows() []*ScaleWindow {
+	if m != nil {
+		return m.ScaleWindows
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetMinAvailable() string {
+	if m != nil {
+		return m.MinAvailable
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetVolumeClaims() []*VolumeClaim {
+	if m != nil {
+		return m.VolumeClaims
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetConfigFiles() []*ConfigFile {
+	if m != nil {
+		return m.ConfigFiles
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetDrainTimeoutSeconds() int32 {
+	if m != nil {
+		return m.DrainTimeoutSeconds
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetTerminationGracePeriodSeconds() int32 {
+	if m != nil {
+		return m.TerminationGracePeriodSeconds
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetMaxSurge() string {
+	if m != nil {
+		return m.MaxSurge
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetMaxUnavailable() string {
+	if m != nil {
+		return m.MaxUnavailable
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetDeploy() *DeployMetadata {
+	if m != nil {
+		return m.Deploy
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetBuilder() string {
+	if m != nil {
+		return m.Builder
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetBuildEnvVars() []string {
+	if m != nil {
+		return m.BuildEnvVars
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetLockReason() string {
+	if m != nil {
+		return m.LockReason
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetLockedBy() string {
+	if m != nil {
+		return m.LockedBy
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetRolloutTimeoutSeconds() int32 {
+	if m != nil {
+		return m.RolloutTimeoutSeconds
+	}
+	return 0
+}
+
+func (m *InfoResponse) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
 type InfoResponse_Address struct {
 	Hostname string `protobuf:"bytes,1,opt,name=hostname" json:"hostname,omitempty"`
 }
@@ -525,9 +763,11 @@ func (m *InfoResponse_Status_Pod) GetReady() bool {
 }
 
 type InfoResponse_Autoscale struct {
-	CpuTargetUtilization int32 `protobuf:"varint,1,opt,name=cpu_target_utilization,json=cpuTargetUtilization" json:"cpu_target_utilization,omitempty"`
-	Max                  int32 `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
-	Min                  int32 `protobuf:"varint,3,opt,name=min" json:"min,omitempty"`
+	CpuTargetUtilization    int32              `protobuf:"varint,1,opt,name=cpu_target_utilization,json=cpuTargetUtilization" json:"cpu_target_utilization,omitempty"`
+	Max                     int32              `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
+	Min                     int32              `protobuf:"varint,3,opt,name=min" json:"min,omitempty"`
+	Metrics                 []*AutoscaleMetric `protobuf:"bytes,4,rep,name=metrics" json:"metrics,omitempty"`
+	MemoryTargetUtilization int32              `protobuf:"varint,5,opt,name=memory_target_utilization,json=memoryTargetUtilization" json:"memory_target_utilization,omitempty"`
 }
 
 func (m *InfoResponse_Autoscale) Reset()                    { *m = InfoResponse_Autoscale{} }
@@ -556,6 +796,20 @@ func (m *InfoResponse_Autoscale) GetMin() int32 {
 	return 0
 }
 
+func (m *InfoResponse_Autoscale) GetMetrics() []*AutoscaleMetric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *InfoResponse_Autoscale) GetMemoryTargetUtilization() int32 {
+	if m != nil {
+		return m.MemoryTargetUtilization
+	}
+	return 0
+}
+
 type InfoResponse_Limits struct {
 	Default        []*InfoResponse_Limits_LimitRangeQuantity `protobuf:"bytes,1,rep,name=default" json:"default,omitempty"`
 	DefaultRequest []*InfoResponse_Limits_LimitRangeQuantity `protobuf:"bytes,2,rep,name=default_request,json=defaultRequest" json:"default_request,omitempty"`
@@ -713,8 +967,9 @@ func (m *SetSecretRequest) GetSecretFile() *SetSecretRequest_SecretFile {
 }
 
 type SetSecretRequest_SecretFile struct {
-	Key     string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
-	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Key       string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Content   []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	MountPath string `protobuf:"bytes,3,opt,name=mount_path,json=mountPath" json:"mount_path,omitempty"`
 }
 
 func (m *SetSecretRequest_SecretFile) Reset()                    { *m = SetSecretRequest_SecretFile{} }
@@ -736,9 +991,17 @@ func (m *SetSecretRequest_SecretFile) GetContent() []byte {
 	return nil
 }
 
+func (m *SetSecretRequest_SecretFile) GetMountPath() string {
+	if m != nil {
+		return m.MountPath
+	}
+	return ""
+}
+
 type SetAutoscaleRequest struct {
-	Name      string                         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Autoscale *SetAutoscaleRequest_Autoscale `protobuf:"bytes,2,opt,name=autoscale" json:"autoscale,omitempty"`
+	Name           string                         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Autoscale      *SetAutoscaleRequest_Autoscale `protobuf:"bytes,2,opt,name=autoscale" json:"autoscale,omitempty"`
+	ReplaceMetrics bool                           `protobuf:"varint,3,opt,name=replace_metrics,json=replaceMetrics" json:"replace_metrics,omitempty"`
 }
 
 func (m *SetAutoscaleRequest) Reset()                    { *m = SetAutoscaleRequest{} }
@@ -760,10 +1023,19 @@ func (m *SetAutoscaleRequest) GetAutoscale() *SetAutoscaleRequest_Autoscale {
 	return nil
 }
 
+func (m *SetAutoscaleRequest) GetReplaceMetrics() bool {
+	if m != nil {
+		return m.ReplaceMetrics
+	}
+	return false
+}
+
 type SetAutoscaleRequest_Autoscale struct {
-	CpuTargetUtilization int32 `protobuf:"varint,1,opt,name=cpu_target_utilization,json=cpuTargetUtilization" json:"cpu_target_utilization,omitempty"`
-	Max                  int32 `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
-	Min                  int32 `protobuf:"varint,3,opt,name=min" json:"min,omitempty"`
+	CpuTargetUtilization    int32              `protobuf:"varint,1,opt,name=cpu_target_utilization,json=cpuTargetUtilization" json:"cpu_target_utilization,omitempty"`
+	Max                     int32              `protobuf:"varint,2,opt,name=max" json:"max,omitempty"`
+	Min                     int32              `protobuf:"varint,3,opt,name=min" json:"min,omitempty"`
+	Metrics                 []*AutoscaleMetric `protobuf:"bytes,4,rep,name=metrics" json:"metrics,omitempty"`
+	MemoryTargetUtilization int32              `protobuf:"varint,5,opt,name=memory_target_utilization,json=memoryTargetUtilization" json:"memory_target_utilization,omitempty"`
 }
 
 func (m *SetAutoscaleRequest_Autoscale) Reset()         { *m = SetAutoscaleRequest_Autoscale{} }
@@ -794,6 +1066,20 @@ func (m *SetAutoscaleRequest_Autoscale) GetMin() int32 {
 	return 0
 }
 
+func (m *SetAutoscaleRequest_Autoscale) GetMetrics() []*AutoscaleMetric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *SetAutoscaleRequest_Autoscale) GetMemoryTargetUtilization() int32 {
+	if m != nil {
+		return m.MemoryTargetUtilization
+	}
+	return 0
+}
+
 type SetReplicasRequest struct {
 	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	Replicas int32  `protobuf:"varint,2,opt,name=replicas" json:"replicas,omitempty"`
@@ -906,509 +1192,3211 @@ func (m *SetVHostsRequest) GetVhosts() []string {
 	return nil
 }
 
-type Empty struct {
+type RenameRequest struct {
+	OldName string `protobuf:"bytes,1,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewName string `protobuf:"bytes,2,opt,name=new_name,json=newName" json:"new_name,omitempty"`
 }
 
-func (m *Empty) Reset()                    { *m = Empty{} }
-func (m *Empty) String() string            { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (m *RenameRequest) Reset()                    { *m = RenameRequest{} }
+func (m *RenameRequest) String() string            { return proto.CompactTextString(m) }
+func (*RenameRequest) ProtoMessage()               {}
+func (*RenameRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
-func init() {
-	proto.RegisterType((*CreateRequest)(nil), "app.CreateRequest")
-	proto.RegisterType((*CreateRequest_Limits)(nil), "app.CreateRequest.Limits")
-	proto.RegisterType((*CreateRequest_Limits_LimitRangeQuantity)(nil), "app.CreateRequest.Limits.LimitRangeQuantity")
-	proto.RegisterType((*CreateRequest_Autoscale)(nil), "app.CreateRequest.Autoscale")
-	proto.RegisterType((*ListResponse)(nil), "app.ListResponse")
-	proto.RegisterType((*ListResponse_App)(nil), "app.ListResponse.App")
-	proto.RegisterType((*LogsRequest)(nil), "app.LogsRequest")
-	proto.RegisterType((*LogsResponse)(nil), "app.LogsResponse")
-	proto.RegisterType((*InfoRequest)(nil), "app.InfoRequest")
-	proto.RegisterType((*InfoResponse)(nil), "app.InfoResponse")
-	proto.RegisterType((*InfoResponse_Address)(nil), "app.InfoResponse.Address")
-	proto.RegisterType((*InfoResponse_EnvVar)(nil), "app.InfoResponse.EnvVar")
-	proto.RegisterType((*InfoResponse_Status)(nil), "app.InfoResponse.Status")
-	proto.RegisterType((*InfoResponse_Status_Pod)(nil), "app.InfoResponse.Status.Pod")
-	proto.RegisterType((*InfoResponse_Autoscale)(nil), "app.InfoResponse.Autoscale")
-	proto.RegisterType((*InfoResponse_Limits)(nil), "app.InfoResponse.Limits")
-	proto.RegisterType((*InfoResponse_Limits_LimitRangeQuantity)(nil), "app.InfoResponse.Limits.LimitRangeQuantity")
-	proto.RegisterType((*SetEnvRequest)(nil), "app.SetEnvRequest")
-	proto.RegisterType((*SetEnvRequest_EnvVar)(nil), "app.SetEnvRequest.EnvVar")
-	proto.RegisterType((*UnsetEnvRequest)(nil), "app.UnsetEnvRequest")
-	proto.RegisterType((*SetSecretRequest)(nil), "app.SetSecretRequest")
-	proto.RegisterType((*SetSecretRequest_SecretFile)(nil), "app.SetSecretRequest.SecretFile")
-	proto.RegisterType((*SetAutoscaleRequest)(nil), "app.SetAutoscaleRequest")
-	proto.RegisterType((*SetAutoscaleRequest_Autoscale)(nil), "app.SetAutoscaleRequest.Autoscale")
-	proto.RegisterType((*SetReplicasRequest)(nil), "app.SetReplicasRequest")
-	proto.RegisterType((*DeleteRequest)(nil), "app.DeleteRequest")
-	proto.RegisterType((*DeletePodsRequest)(nil), "app.DeletePodsRequest")
-	proto.RegisterType((*ChangeTeamRequest)(nil), "app.ChangeTeamRequest")
-	proto.RegisterType((*SetVHostsRequest)(nil), "app.SetVHostsRequest")
-	proto.RegisterType((*Empty)(nil), "app.Empty")
+func (m *RenameRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *RenameRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type CloneRequest struct {
+	SrcName     string `protobuf:"bytes,1,opt,name=src_name,json=srcName" json:"src_name,omitempty"`
+	DstName     string `protobuf:"bytes,2,opt,name=dst_name,json=dstName" json:"dst_name,omitempty"`
+	VirtualHost string `protobuf:"bytes,3,opt,name=virtual_host,json=virtualHost" json:"virtual_host,omitempty"`
+}
 
-// Client API for App service
+func (m *CloneRequest) Reset()                    { *m = CloneRequest{} }
+func (m *CloneRequest) String() string            { return proto.CompactTextString(m) }
+func (*CloneRequest) ProtoMessage()               {}
+func (*CloneRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
-type AppClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
-	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (App_LogsClient, error)
-	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
-	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
-	UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
-	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error)
-	SetAutoscale(ctx context.Context, in *SetAutoscaleRequest, opts ...grpc.CallOption) (*Empty, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
-	SetReplicas(ctx context.Context, in *SetReplicasRequest, opts ...grpc.CallOption) (*Empty, error)
-	DeletePods(ctx context.Context, in *DeletePodsRequest, opts ...grpc.CallOption) (*Empty, error)
-	SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*Empty, error)
-	UnsetSecret(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
-	ChangeTeam(ctx context.Context, in *ChangeTeamRequest, opts ...grpc.CallOption) (*Empty, error)
-	SetVHosts(ctx context.Context, in *SetVHostsRequest, opts ...grpc.CallOption) (*Empty, error)
+func (m *CloneRequest) GetSrcName() string {
+	if m != nil {
+		return m.SrcName
+	}
+	return ""
 }
 
-type appClient struct {
-	cc *grpc.ClientConn
+func (m *CloneRequest) GetDstName() string {
+	if m != nil {
+		return m.DstName
+	}
+	return ""
 }
 
-func NewAppClient(cc *grpc.ClientConn) AppClient {
-	return &appClient{cc}
+func (m *CloneRequest) GetVirtualHost() string {
+	if m != nil {
+		return m.VirtualHost
+	}
+	return ""
 }
 
-func (c *appClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/Create", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type ScaleRequest struct {
+	Name     string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Replicas map[string]int32 `protobuf:"bytes,2,rep,name=replicas" json:"replicas,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *ScaleRequest) Reset()                    { *m = ScaleRequest{} }
+func (m *ScaleRequest) String() string            { return proto.CompactTextString(m) }
+func (*ScaleRequest) ProtoMessage()               {}
+func (*ScaleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ScaleRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *appClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (App_LogsClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_App_serviceDesc.Streams[0], c.cc, "/app.App/Logs", opts...)
-	if err != nil {
-		return nil, err
+func (m *ScaleRequest) GetReplicas() map[string]int32 {
+	if m != nil {
+		return m.Replicas
 	}
-	x := &appLogsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
+	return nil
+}
+
+type PauseRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *PauseRequest) Reset()                    { *m = PauseRequest{} }
+func (m *PauseRequest) String() string            { return proto.CompactTextString(m) }
+func (*PauseRequest) ProtoMessage()               {}
+func (*PauseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *PauseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ResumeRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ResumeRequest) Reset()                    { *m = ResumeRequest{} }
+func (m *ResumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResumeRequest) ProtoMessage()               {}
+func (*ResumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *ResumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type RestartRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *RestartRequest) Reset()                    { *m = RestartRequest{} }
+func (m *RestartRequest) String() string            { return proto.CompactTextString(m) }
+func (*RestartRequest) ProtoMessage()               {}
+func (*RestartRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *RestartRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type StatusRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
+func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()               {}
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *StatusRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type StatusResponse struct {
+	Pods []*StatusResponse_Pod `protobuf:"bytes,1,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
+func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()               {}
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *StatusResponse) GetPods() []*StatusResponse_Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type StatusResponse_Pod struct {
+	Name                  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	State                 string `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+	Age                   int64  `protobuf:"varint,3,opt,name=age" json:"age,omitempty"`
+	Restarts              int32  `protobuf:"varint,4,opt,name=restarts" json:"restarts,omitempty"`
+	Ready                 bool   `protobuf:"varint,5,opt,name=ready" json:"ready,omitempty"`
+	Node                  string `protobuf:"bytes,6,opt,name=node" json:"node,omitempty"`
+	LastTerminationReason string `protobuf:"bytes,7,opt,name=last_termination_reason,json=lastTerminationReason" json:"last_termination_reason,omitempty"`
+}
+
+func (m *StatusResponse_Pod) Reset()                    { *m = StatusResponse_Pod{} }
+func (m *StatusResponse_Pod) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse_Pod) ProtoMessage()               {}
+func (*StatusResponse_Pod) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22, 0} }
+
+func (m *StatusResponse_Pod) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *StatusResponse_Pod) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *StatusResponse_Pod) GetAge() int64 {
+	if m != nil {
+		return m.Age
+	}
+	return 0
+}
+
+func (m *StatusResponse_Pod) GetRestarts() int32 {
+	if m != nil {
+		return m.Restarts
+	}
+	return 0
+}
+
+func (m *StatusResponse_Pod) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *StatusResponse_Pod) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *StatusResponse_Pod) GetLastTerminationReason() string {
+	if m != nil {
+		return m.LastTerminationReason
+	}
+	return ""
+}
+
+type EventsRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Follow bool   `protobuf:"varint,2,opt,name=follow" json:"follow,omitempty"`
+}
+
+func (m *EventsRequest) Reset()                    { *m = EventsRequest{} }
+func (m *EventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()               {}
+func (*EventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *EventsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EventsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type EventsResponse struct {
+	Type     string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	Object   string `protobuf:"bytes,3,opt,name=object" json:"object,omitempty"`
+	Message  string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+	Count    int32  `protobuf:"varint,5,opt,name=count" json:"count,omitempty"`
+	LastSeen int64  `protobuf:"varint,6,opt,name=last_seen,json=lastSeen" json:"last_seen,omitempty"`
+}
+
+func (m *EventsResponse) Reset()                    { *m = EventsResponse{} }
+func (m *EventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*EventsResponse) ProtoMessage()               {}
+func (*EventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *EventsResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *EventsResponse) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *EventsResponse) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
+}
+
+func (m *EventsResponse) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *EventsResponse) GetCount() int32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *EventsResponse) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+type TopRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *TopRequest) Reset()                    { *m = TopRequest{} }
+func (m *TopRequest) String() string            { return proto.CompactTextString(m) }
+func (*TopRequest) ProtoMessage()               {}
+func (*TopRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *TopRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type TopResponse struct {
+	Pods []*TopResponse_Pod `protobuf:"bytes,1,rep,name=pods" json:"pods,omitempty"`
+}
+
+func (m *TopResponse) Reset()                    { *m = TopResponse{} }
+func (m *TopResponse) String() string            { return proto.CompactTextString(m) }
+func (*TopResponse) ProtoMessage()               {}
+func (*TopResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *TopResponse) GetPods() []*TopResponse_Pod {
+	if m != nil {
+		return m.Pods
+	}
+	return nil
+}
+
+type TopResponse_Pod struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Cpu    int64  `protobuf:"varint,2,opt,name=cpu" json:"cpu,omitempty"`
+	Memory int64  `protobuf:"varint,3,opt,name=memory" json:"memory,omitempty"`
+}
+
+func (m *TopResponse_Pod) Reset()                    { *m = TopResponse_Pod{} }
+func (m *TopResponse_Pod) String() string            { return proto.CompactTextString(m) }
+func (*TopResponse_Pod) ProtoMessage()               {}
+func (*TopResponse_Pod) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26, 0} }
+
+func (m *TopResponse_Pod) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *TopResponse_Pod) GetCpu() int64 {
+	if m != nil {
+		return m.Cpu
+	}
+	return 0
+}
+
+func (m *TopResponse_Pod) GetMemory() int64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+type SetResourcesRequest struct {
+	Name          string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Cpu           string `protobuf:"bytes,2,opt,name=cpu" json:"cpu,omitempty"`
+	Memory        string `protobuf:"bytes,3,opt,name=memory" json:"memory,omitempty"`
+	CpuRequest    string `protobuf:"bytes,4,opt,name=cpu_request,json=cpuRequest" json:"cpu_request,omitempty"`
+	MemoryRequest string `protobuf:"bytes,5,opt,name=memory_request,json=memoryRequest" json:"memory_request,omitempty"`
+}
+
+func (m *SetResourcesRequest) Reset()                    { *m = SetResourcesRequest{} }
+func (m *SetResourcesRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetResourcesRequest) ProtoMessage()               {}
+func (*SetResourcesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *SetResourcesRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetResourcesRequest) GetCpu() string {
+	if m != nil {
+		return m.Cpu
+	}
+	return ""
+}
+
+func (m *SetResourcesRequest) GetMemory() string {
+	if m != nil {
+		return m.Memory
+	}
+	return ""
+}
+
+func (m *SetResourcesRequest) GetCpuRequest() string {
+	if m != nil {
+		return m.CpuRequest
+	}
+	return ""
+}
+
+func (m *SetResourcesRequest) GetMemoryRequest() string {
+	if m != nil {
+		return m.MemoryRequest
+	}
+	return ""
+}
+
+type EnvHistoryRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *EnvHistoryRequest) Reset()                    { *m = EnvHistoryRequest{} }
+func (m *EnvHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnvHistoryRequest) ProtoMessage()               {}
+func (*EnvHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *EnvHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type EnvHistoryResponse struct {
+	Revisions []*EnvHistoryResponse_Revision `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
+}
+
+func (m *EnvHistoryResponse) Reset()                    { *m = EnvHistoryResponse{} }
+func (m *EnvHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*EnvHistoryResponse) ProtoMessage()               {}
+func (*EnvHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *EnvHistoryResponse) GetRevisions() []*EnvHistoryResponse_Revision {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type EnvHistoryResponse_Revision struct {
+	Version   int32                   `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	Author    string                  `protobuf:"bytes,2,opt,name=author" json:"author,omitempty"`
+	CreatedAt int64                   `protobuf:"varint,3,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	EnvVars   []*SetEnvRequest_EnvVar `protobuf:"bytes,4,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *EnvHistoryResponse_Revision) Reset()         { *m = EnvHistoryResponse_Revision{} }
+func (m *EnvHistoryResponse_Revision) String() string { return proto.CompactTextString(m) }
+func (*EnvHistoryResponse_Revision) ProtoMessage()    {}
+func (*EnvHistoryResponse_Revision) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{29, 0}
+}
+
+func (m *EnvHistoryResponse_Revision) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *EnvHistoryResponse_Revision) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *EnvHistoryResponse_Revision) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *EnvHistoryResponse_Revision) GetEnvVars() []*SetEnvRequest_EnvVar {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type EnvRollbackRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version int32  `protobuf:"varint,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *EnvRollbackRequest) Reset()                    { *m = EnvRollbackRequest{} }
+func (m *EnvRollbackRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnvRollbackRequest) ProtoMessage()               {}
+func (*EnvRollbackRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *EnvRollbackRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EnvRollbackRequest) GetVersion() int32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type SetMaintenanceRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Enabled bool   `protobuf:"varint,2,opt,name=enabled" json:"enabled,omitempty"`
+}
+
+func (m *SetMaintenanceRequest) Reset()                    { *m = SetMaintenanceRequest{} }
+func (m *SetMaintenanceRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetMaintenanceRequest) ProtoMessage()               {}
+func (*SetMaintenanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *SetMaintenanceRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetMaintenanceRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+type VHostRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Vhost   string `protobuf:"bytes,2,opt,name=vhost" json:"vhost,omitempty"`
+}
+
+func (m *VHostRequest) Reset()                    { *m = VHostRequest{} }
+func (m *VHostRequest) String() string            { return proto.CompactTextString(m) }
+func (*VHostRequest) ProtoMessage()               {}
+func (*VHostRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *VHostRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *VHostRequest) GetVhost() string {
+	if m != nil {
+		return m.Vhost
+	}
+	return ""
+}
+
+type EnableTLSRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *EnableTLSRequest) Reset()                    { *m = EnableTLSRequest{} }
+func (m *EnableTLSRequest) String() string            { return proto.CompactTextString(m) }
+func (*EnableTLSRequest) ProtoMessage()               {}
+func (*EnableTLSRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *EnableTLSRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdateIngressRequest struct {
+	Name    string                         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Options []*UpdateIngressRequest_Option `protobuf:"bytes,2,rep,name=options" json:"options,omitempty"`
+}
+
+func (m *UpdateIngressRequest) Reset()                    { *m = UpdateIngressRequest{} }
+func (m *UpdateIngressRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateIngressRequest) ProtoMessage()               {}
+func (*UpdateIngressRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *UpdateIngressRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateIngressRequest) GetOptions() []*UpdateIngressRequest_Option {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+type UpdateIngressRequest_Option struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *UpdateIngressRequest_Option) Reset()         { *m = UpdateIngressRequest_Option{} }
+func (m *UpdateIngressRequest_Option) String() string { return proto.CompactTextString(m) }
+func (*UpdateIngressRequest_Option) ProtoMessage()    {}
+func (*UpdateIngressRequest_Option) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{34, 0}
+}
+
+func (m *UpdateIngressRequest_Option) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *UpdateIngressRequest_Option) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetACLRequest struct {
+	Name  string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Allow []string `protobuf:"bytes,2,rep,name=allow" json:"allow,omitempty"`
+}
+
+func (m *SetACLRequest) Reset()                    { *m = SetACLRequest{} }
+func (m *SetACLRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetACLRequest) ProtoMessage()               {}
+func (*SetACLRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SetACLRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetACLRequest) GetAllow() []string {
+	if m != nil {
+		return m.Allow
+	}
+	return nil
+}
+
+type AutoscaleMetric struct {
+	Name               string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	TargetAverageValue string `protobuf:"bytes,2,opt,name=target_average_value,json=targetAverageValue" json:"target_average_value,omitempty"`
+}
+
+func (m *AutoscaleMetric) Reset()                    { *m = AutoscaleMetric{} }
+func (m *AutoscaleMetric) String() string            { return proto.CompactTextString(m) }
+func (*AutoscaleMetric) ProtoMessage()               {}
+func (*AutoscaleMetric) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *AutoscaleMetric) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AutoscaleMetric) GetTargetAverageValue() string {
+	if m != nil {
+		return m.TargetAverageValue
+	}
+	return ""
+}
+
+type ScaleWindow struct {
+	Start string   `protobuf:"bytes,1,opt,name=start" json:"start,omitempty"`
+	End   string   `protobuf:"bytes,2,opt,name=end" json:"end,omitempty"`
+	Days  []string `protobuf:"bytes,3,rep,name=days" json:"days,omitempty"`
+	Min   int32    `protobuf:"varint,4,opt,name=min" json:"min,omitempty"`
+	Max   int32    `protobuf:"varint,5,opt,name=max" json:"max,omitempty"`
+}
+
+func (m *ScaleWindow) Reset()                    { *m = ScaleWindow{} }
+func (m *ScaleWindow) String() string            { return proto.CompactTextString(m) }
+func (*ScaleWindow) ProtoMessage()               {}
+func (*ScaleWindow) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ScaleWindow) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *ScaleWindow) GetEnd() string {
+	if m != nil {
+		return m.End
+	}
+	return ""
+}
+
+func (m *ScaleWindow) GetDays() []string {
+	if m != nil {
+		return m.Days
+	}
+	return nil
+}
+
+func (m *ScaleWindow) GetMin() int32 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *ScaleWindow) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+type SetAutoscaleScheduleRequest struct {
+	Name    string         `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Windows []*ScaleWindow `protobuf:"bytes,2,rep,name=windows" json:"windows,omitempty"`
+}
+
+func (m *SetAutoscaleScheduleRequest) Reset()                    { *m = SetAutoscaleScheduleRequest{} }
+func (m *SetAutoscaleScheduleRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetAutoscaleScheduleRequest) ProtoMessage()               {}
+func (*SetAutoscaleScheduleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SetAutoscaleScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetAutoscaleScheduleRequest) GetWindows() []*ScaleWindow {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+type SetPDBRequest struct {
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	MinAvailable string `protobuf:"bytes,2,opt,name=min_available,json=minAvailable" json:"min_available,omitempty"`
+}
+
+func (m *SetPDBRequest) Reset()                    { *m = SetPDBRequest{} }
+func (m *SetPDBRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetPDBRequest) ProtoMessage()               {}
+func (*SetPDBRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *SetPDBRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetPDBRequest) GetMinAvailable() string {
+	if m != nil {
+		return m.MinAvailable
+	}
+	return ""
+}
+
+type VolumeClaim struct {
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Size         string `protobuf:"bytes,2,opt,name=size" json:"size,omitempty"`
+	StorageClass string `protobuf:"bytes,3,opt,name=storage_class,json=storageClass" json:"storage_class,omitempty"`
+	AccessMode   string `protobuf:"bytes,4,opt,name=access_mode,json=accessMode" json:"access_mode,omitempty"`
+	MountPath    string `protobuf:"bytes,5,opt,name=mount_path,json=mountPath" json:"mount_path,omitempty"`
+}
+
+func (m *VolumeClaim) Reset()                    { *m = VolumeClaim{} }
+func (m *VolumeClaim) String() string            { return proto.CompactTextString(m) }
+func (*VolumeClaim) ProtoMessage()               {}
+func (*VolumeClaim) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *VolumeClaim) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VolumeClaim) GetSize() string {
+	if m != nil {
+		return m.Size
+	}
+	return ""
+}
+
+func (m *VolumeClaim) GetStorageClass() string {
+	if m != nil {
+		return m.StorageClass
+	}
+	return ""
+}
+
+func (m *VolumeClaim) GetAccessMode() string {
+	if m != nil {
+		return m.AccessMode
+	}
+	return ""
+}
+
+func (m *VolumeClaim) GetMountPath() string {
+	if m != nil {
+		return m.MountPath
+	}
+	return ""
+}
+
+type AddVolumeRequest struct {
+	Name   string       `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Volume *VolumeClaim `protobuf:"bytes,2,opt,name=volume" json:"volume,omitempty"`
+}
+
+func (m *AddVolumeRequest) Reset()                    { *m = AddVolumeRequest{} }
+func (m *AddVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddVolumeRequest) ProtoMessage()               {}
+func (*AddVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *AddVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AddVolumeRequest) GetVolume() *VolumeClaim {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type RemoveVolumeRequest struct {
+	Name       string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	VolumeName string `protobuf:"bytes,2,opt,name=volume_name,json=volumeName" json:"volume_name,omitempty"`
+}
+
+func (m *RemoveVolumeRequest) Reset()                    { *m = RemoveVolumeRequest{} }
+func (m *RemoveVolumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveVolumeRequest) ProtoMessage()               {}
+func (*RemoveVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *RemoveVolumeRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RemoveVolumeRequest) GetVolumeName() string {
+	if m != nil {
+		return m.VolumeName
+	}
+	return ""
+}
+
+type ConfigFile struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	MountPath string `protobuf:"bytes,2,opt,name=mount_path,json=mountPath" json:"mount_path,omitempty"`
+}
+
+func (m *ConfigFile) Reset()                    { *m = ConfigFile{} }
+func (m *ConfigFile) String() string            { return proto.CompactTextString(m) }
+func (*ConfigFile) ProtoMessage()               {}
+func (*ConfigFile) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ConfigFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ConfigFile) GetMountPath() string {
+	if m != nil {
+		return m.MountPath
+	}
+	return ""
+}
+
+type SetConfigFileRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	FileName  string `protobuf:"bytes,2,opt,name=file_name,json=fileName" json:"file_name,omitempty"`
+	Content   []byte `protobuf:"bytes,3,opt,name=content" json:"content,omitempty"`
+	MountPath string `protobuf:"bytes,4,opt,name=mount_path,json=mountPath" json:"mount_path,omitempty"`
+}
+
+func (m *SetConfigFileRequest) Reset()                    { *m = SetConfigFileRequest{} }
+func (m *SetConfigFileRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetConfigFileRequest) ProtoMessage()               {}
+func (*SetConfigFileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *SetConfigFileRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetConfigFileRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+func (m *SetConfigFileRequest) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *SetConfigFileRequest) GetMountPath() string {
+	if m != nil {
+		return m.MountPath
+	}
+	return ""
+}
+
+type UnsetConfigFileRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	FileName string `protobuf:"bytes,2,opt,name=file_name,json=fileName" json:"file_name,omitempty"`
+}
+
+func (m *UnsetConfigFileRequest) Reset()                    { *m = UnsetConfigFileRequest{} }
+func (m *UnsetConfigFileRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnsetConfigFileRequest) ProtoMessage()               {}
+func (*UnsetConfigFileRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *UnsetConfigFileRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UnsetConfigFileRequest) GetFileName() string {
+	if m != nil {
+		return m.FileName
+	}
+	return ""
+}
+
+type UpdateLifecycleRequest struct {
+	Name                          string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	DrainTimeoutSeconds           int32  `protobuf:"varint,2,opt,name=drain_timeout_seconds,json=drainTimeoutSeconds" json:"drain_timeout_seconds,omitempty"`
+	TerminationGracePeriodSeconds int32  `protobuf:"varint,3,opt,name=termination_grace_period_seconds,json=terminationGracePeriodSeconds" json:"termination_grace_period_seconds,omitempty"`
+}
+
+func (m *UpdateLifecycleRequest) Reset()                    { *m = UpdateLifecycleRequest{} }
+func (m *UpdateLifecycleRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateLifecycleRequest) ProtoMessage()               {}
+func (*UpdateLifecycleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *UpdateLifecycleRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateLifecycleRequest) GetDrainTimeoutSeconds() int32 {
+	if m != nil {
+		return m.DrainTimeoutSeconds
+	}
+	return 0
+}
+
+func (m *UpdateLifecycleRequest) GetTerminationGracePeriodSeconds() int32 {
+	if m != nil {
+		return m.TerminationGracePeriodSeconds
+	}
+	return 0
+}
+
+type UpdateStrategyRequest struct {
+	Name           string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	MaxSurge       string `protobuf:"bytes,2,opt,name=max_surge,json=maxSurge" json:"max_surge,omitempty"`
+	MaxUnavailable string `protobuf:"bytes,3,opt,name=max_unavailable,json=maxUnavailable" json:"max_unavailable,omitempty"`
+}
+
+func (m *UpdateStrategyRequest) Reset()                    { *m = UpdateStrategyRequest{} }
+func (m *UpdateStrategyRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateStrategyRequest) ProtoMessage()               {}
+func (*UpdateStrategyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+func (m *UpdateStrategyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateStrategyRequest) GetMaxSurge() string {
+	if m != nil {
+		return m.MaxSurge
+	}
+	return ""
+}
+
+func (m *UpdateStrategyRequest) GetMaxUnavailable() string {
+	if m != nil {
+		return m.MaxUnavailable
+	}
+	return ""
+}
+
+type DeployMetadata struct {
+	Author  string `protobuf:"bytes,1,opt,name=author" json:"author,omitempty"`
+	Commit  string `protobuf:"bytes,2,opt,name=commit" json:"commit,omitempty"`
+	Branch  string `protobuf:"bytes,3,opt,name=branch" json:"branch,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message" json:"message,omitempty"`
+}
+
+func (m *DeployMetadata) Reset()                    { *m = DeployMetadata{} }
+func (m *DeployMetadata) String() string            { return proto.CompactTextString(m) }
+func (*DeployMetadata) ProtoMessage()               {}
+func (*DeployMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *DeployMetadata) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *DeployMetadata) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *DeployMetadata) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *DeployMetadata) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+type SetBuilderRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Builder string `protobuf:"bytes,2,opt,name=builder" json:"builder,omitempty"`
+}
+
+func (m *SetBuilderRequest) Reset()                    { *m = SetBuilderRequest{} }
+func (m *SetBuilderRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetBuilderRequest) ProtoMessage()               {}
+func (*SetBuilderRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+func (m *SetBuilderRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetBuilderRequest) GetBuilder() string {
+	if m != nil {
+		return m.Builder
+	}
+	return ""
+}
+
+
+type LockRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *LockRequest) Reset()                    { *m = LockRequest{} }
+func (m *LockRequest) String() string            { return proto.CompactTextString(m) }
+func (*LockRequest) ProtoMessage()               {}
+func (*LockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *LockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LockRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type UnlockRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *UnlockRequest) Reset()                    { *m = UnlockRequest{} }
+func (m *UnlockRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnlockRequest) ProtoMessage()               {}
+func (*UnlockRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+func (m *UnlockRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type UpdateRolloutTimeoutRequest struct {
+	Name           string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	TimeoutSeconds int32  `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds" json:"timeout_seconds,omitempty"`
+}
+
+func (m *UpdateRolloutTimeoutRequest) Reset()                    { *m = UpdateRolloutTimeoutRequest{} }
+func (m *UpdateRolloutTimeoutRequest) String() string            { return proto.CompactTextString(m) }
+func (*UpdateRolloutTimeoutRequest) ProtoMessage()               {}
+func (*UpdateRolloutTimeoutRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *UpdateRolloutTimeoutRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpdateRolloutTimeoutRequest) GetTimeoutSeconds() int32 {
+	if m != nil {
+		return m.TimeoutSeconds
+	}
+	return 0
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+type CronRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *CronRequest) Reset()                    { *m = CronRequest{} }
+func (m *CronRequest) String() string            { return proto.CompactTextString(m) }
+func (*CronRequest) ProtoMessage()               {}
+func (*CronRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *CronRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CronListResponse struct {
+	Crons []*CronListResponse_Cron `protobuf:"bytes,1,rep,name=crons" json:"crons,omitempty"`
+}
+
+func (m *CronListResponse) Reset()                    { *m = CronListResponse{} }
+func (m *CronListResponse) String() string            { return proto.CompactTextString(m) }
+func (*CronListResponse) ProtoMessage()               {}
+func (*CronListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *CronListResponse) GetCrons() []*CronListResponse_Cron {
+	if m != nil {
+		return m.Crons
+	}
+	return nil
+}
+
+type CronListResponse_Cron struct {
+	Name         string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Schedule     string `protobuf:"bytes,2,opt,name=schedule" json:"schedule,omitempty"`
+	Suspended    bool   `protobuf:"varint,3,opt,name=suspended" json:"suspended,omitempty"`
+	LastSchedule int64  `protobuf:"varint,4,opt,name=last_schedule,json=lastSchedule" json:"last_schedule,omitempty"`
+	Active       int32  `protobuf:"varint,5,opt,name=active" json:"active,omitempty"`
+}
+
+func (m *CronListResponse_Cron) Reset()                    { *m = CronListResponse_Cron{} }
+func (m *CronListResponse_Cron) String() string            { return proto.CompactTextString(m) }
+func (*CronListResponse_Cron) ProtoMessage()               {}
+func (*CronListResponse_Cron) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55, 0} }
+
+func (m *CronListResponse_Cron) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CronListResponse_Cron) GetSchedule() string {
+	if m != nil {
+		return m.Schedule
+	}
+	return ""
+}
+
+func (m *CronListResponse_Cron) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+func (m *CronListResponse_Cron) GetLastSchedule() int64 {
+	if m != nil {
+		return m.LastSchedule
+	}
+	return 0
+}
+
+func (m *CronListResponse_Cron) GetActive() int32 {
+	if m != nil {
+		return m.Active
+	}
+	return 0
+}
+
+type CronRunNowResponse struct {
+	JobName string `protobuf:"bytes,1,opt,name=job_name,json=jobName" json:"job_name,omitempty"`
+}
+
+func (m *CronRunNowResponse) Reset()                    { *m = CronRunNowResponse{} }
+func (m *CronRunNowResponse) String() string            { return proto.CompactTextString(m) }
+func (*CronRunNowResponse) ProtoMessage()               {}
+func (*CronRunNowResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *CronRunNowResponse) GetJobName() string {
+	if m != nil {
+		return m.JobName
+	}
+	return ""
+}
+
+type CronRunsRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Process string `protobuf:"bytes,2,opt,name=process" json:"process,omitempty"`
+}
+
+func (m *CronRunsRequest) Reset()                    { *m = CronRunsRequest{} }
+func (m *CronRunsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CronRunsRequest) ProtoMessage()               {}
+func (*CronRunsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *CronRunsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CronRunsRequest) GetProcess() string {
+	if m != nil {
+		return m.Process
+	}
+	return ""
+}
+
+type CronRunsResponse struct {
+	Runs []*CronRunsResponse_Run `protobuf:"bytes,1,rep,name=runs" json:"runs,omitempty"`
+}
+
+func (m *CronRunsResponse) Reset()                    { *m = CronRunsResponse{} }
+func (m *CronRunsResponse) String() string            { return proto.CompactTextString(m) }
+func (*CronRunsResponse) ProtoMessage()               {}
+func (*CronRunsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *CronRunsResponse) GetRuns() []*CronRunsResponse_Run {
+	if m != nil {
+		return m.Runs
+	}
+	return nil
+}
+
+type CronRunsResponse_Run struct {
+	Name            string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Status          string `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	StartTime       int64  `protobuf:"varint,3,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	DurationSeconds int64  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds" json:"duration_seconds,omitempty"`
+	ExitCode        int32  `protobuf:"varint,5,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+}
+
+func (m *CronRunsResponse_Run) Reset()                    { *m = CronRunsResponse_Run{} }
+func (m *CronRunsResponse_Run) String() string            { return proto.CompactTextString(m) }
+func (*CronRunsResponse_Run) ProtoMessage()               {}
+func (*CronRunsResponse_Run) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58, 0} }
+
+func (m *CronRunsResponse_Run) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CronRunsResponse_Run) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CronRunsResponse_Run) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *CronRunsResponse_Run) GetDurationSeconds() int64 {
+	if m != nil {
+		return m.DurationSeconds
+	}
+	return 0
+}
+
+func (m *CronRunsResponse_Run) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type CronRunLogsRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Process string `protobuf:"bytes,2,opt,name=process" json:"process,omitempty"`
+	Run     string `protobuf:"bytes,3,opt,name=run" json:"run,omitempty"`
+	Lines   int64  `protobuf:"varint,4,opt,name=lines" json:"lines,omitempty"`
+	Follow  bool   `protobuf:"varint,5,opt,name=follow" json:"follow,omitempty"`
+}
+
+func (m *CronRunLogsRequest) Reset()                    { *m = CronRunLogsRequest{} }
+func (m *CronRunLogsRequest) String() string            { return proto.CompactTextString(m) }
+func (*CronRunLogsRequest) ProtoMessage()               {}
+func (*CronRunLogsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+func (m *CronRunLogsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CronRunLogsRequest) GetProcess() string {
+	if m != nil {
+		return m.Process
+	}
+	return ""
+}
+
+func (m *CronRunLogsRequest) GetRun() string {
+	if m != nil {
+		return m.Run
+	}
+	return ""
+}
+
+func (m *CronRunLogsRequest) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *CronRunLogsRequest) GetFollow() bool {
+	if m != nil {
+		return m.Follow
+	}
+	return false
+}
+
+type PortForwardRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	PodName string `protobuf:"bytes,2,opt,name=pod_name,json=podName" json:"pod_name,omitempty"`
+	Port    int32  `protobuf:"varint,3,opt,name=port" json:"port,omitempty"`
+	Data    []byte `protobuf:"bytes,4,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *PortForwardRequest) Reset()                    { *m = PortForwardRequest{} }
+func (m *PortForwardRequest) String() string            { return proto.CompactTextString(m) }
+func (*PortForwardRequest) ProtoMessage()               {}
+func (*PortForwardRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *PortForwardRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PortForwardRequest) GetPodName() string {
+	if m != nil {
+		return m.PodName
+	}
+	return ""
+}
+
+func (m *PortForwardRequest) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *PortForwardRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type PortForwardResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *PortForwardResponse) Reset()                    { *m = PortForwardResponse{} }
+func (m *PortForwardResponse) String() string            { return proto.CompactTextString(m) }
+func (*PortForwardResponse) ProtoMessage()               {}
+func (*PortForwardResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *PortForwardResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*CreateRequest)(nil), "app.CreateRequest")
+	proto.RegisterType((*CreateRequest_Limits)(nil), "app.CreateRequest.Limits")
+	proto.RegisterType((*CreateRequest_Limits_LimitRangeQuantity)(nil), "app.CreateRequest.Limits.LimitRangeQuantity")
+	proto.RegisterType((*CreateRequest_Autoscale)(nil), "app.CreateRequest.Autoscale")
+	proto.RegisterType((*ListResponse)(nil), "app.ListResponse")
+	proto.RegisterType((*ListResponse_App)(nil), "app.ListResponse.App")
+	proto.RegisterType((*LogsRequest)(nil), "app.LogsRequest")
+	proto.RegisterType((*LogsResponse)(nil), "app.LogsResponse")
+	proto.RegisterType((*InfoRequest)(nil), "app.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "app.InfoResponse")
+	proto.RegisterType((*InfoResponse_Address)(nil), "app.InfoResponse.Address")
+	proto.RegisterType((*InfoResponse_EnvVar)(nil), "app.InfoResponse.EnvVar")
+	proto.RegisterType((*InfoResponse_Status)(nil), "app.InfoResponse.Status")
+	proto.RegisterType((*InfoResponse_Status_Pod)(nil), "app.InfoResponse.Status.Pod")
+	proto.RegisterType((*InfoResponse_Autoscale)(nil), "app.InfoResponse.Autoscale")
+	proto.RegisterType((*InfoResponse_Limits)(nil), "app.InfoResponse.Limits")
+	proto.RegisterType((*InfoResponse_Limits_LimitRangeQuantity)(nil), "app.InfoResponse.Limits.LimitRangeQuantity")
+	proto.RegisterType((*SetEnvRequest)(nil), "app.SetEnvRequest")
+	proto.RegisterType((*SetEnvRequest_EnvVar)(nil), "app.SetEnvRequest.EnvVar")
+	proto.RegisterType((*UnsetEnvRequest)(nil), "app.UnsetEnvRequest")
+	proto.RegisterType((*SetSecretRequest)(nil), "app.SetSecretRequest")
+	proto.RegisterType((*SetSecretRequest_SecretFile)(nil), "app.SetSecretRequest.SecretFile")
+	proto.RegisterType((*SetAutoscaleRequest)(nil), "app.SetAutoscaleRequest")
+	proto.RegisterType((*SetAutoscaleRequest_Autoscale)(nil), "app.SetAutoscaleRequest.Autoscale")
+	proto.RegisterType((*SetReplicasRequest)(nil), "app.SetReplicasRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "app.DeleteRequest")
+	proto.RegisterType((*DeletePodsRequest)(nil), "app.DeletePodsRequest")
+	proto.RegisterType((*ChangeTeamRequest)(nil), "app.ChangeTeamRequest")
+	proto.RegisterType((*SetVHostsRequest)(nil), "app.SetVHostsRequest")
+	proto.RegisterType((*RenameRequest)(nil), "app.RenameRequest")
+	proto.RegisterType((*CloneRequest)(nil), "app.CloneRequest")
+	proto.RegisterType((*ScaleRequest)(nil), "app.ScaleRequest")
+	proto.RegisterType((*PauseRequest)(nil), "app.PauseRequest")
+	proto.RegisterType((*ResumeRequest)(nil), "app.ResumeRequest")
+	proto.RegisterType((*RestartRequest)(nil), "app.RestartRequest")
+	proto.RegisterType((*StatusRequest)(nil), "app.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "app.StatusResponse")
+	proto.RegisterType((*StatusResponse_Pod)(nil), "app.StatusResponse.Pod")
+	proto.RegisterType((*EventsRequest)(nil), "app.EventsRequest")
+	proto.RegisterType((*EventsResponse)(nil), "app.EventsResponse")
+	proto.RegisterType((*TopRequest)(nil), "app.TopRequest")
+	proto.RegisterType((*TopResponse)(nil), "app.TopResponse")
+	proto.RegisterType((*TopResponse_Pod)(nil), "app.TopResponse.Pod")
+	proto.RegisterType((*SetResourcesRequest)(nil), "app.SetResourcesRequest")
+	proto.RegisterType((*EnvHistoryRequest)(nil), "app.EnvHistoryRequest")
+	proto.RegisterType((*EnvHistoryResponse)(nil), "app.EnvHistoryResponse")
+	proto.RegisterType((*EnvHistoryResponse_Revision)(nil), "app.EnvHistoryResponse.Revision")
+	proto.RegisterType((*EnvRollbackRequest)(nil), "app.EnvRollbackRequest")
+	proto.RegisterType((*SetMaintenanceRequest)(nil), "app.SetMaintenanceRequest")
+	proto.RegisterType((*VHostRequest)(nil), "app.VHostRequest")
+	proto.RegisterType((*EnableTLSRequest)(nil), "app.EnableTLSRequest")
+	proto.RegisterType((*UpdateIngressRequest)(nil), "app.UpdateIngressRequest")
+	proto.RegisterType((*UpdateIngressRequest_Option)(nil), "app.UpdateIngressRequest.Option")
+	proto.RegisterType((*SetACLRequest)(nil), "app.SetACLRequest")
+	proto.RegisterType((*AutoscaleMetric)(nil), "app.AutoscaleMetric")
+	proto.RegisterType((*ScaleWindow)(nil), "app.ScaleWindow")
+	proto.RegisterType((*SetAutoscaleScheduleRequest)(nil), "app.SetAutoscaleScheduleRequest")
+	proto.RegisterType((*SetPDBRequest)(nil), "app.SetPDBRequest")
+	proto.RegisterType((*VolumeClaim)(nil), "app.VolumeClaim")
+	proto.RegisterType((*AddVolumeRequest)(nil), "app.AddVolumeRequest")
+	proto.RegisterType((*RemoveVolumeRequest)(nil), "app.RemoveVolumeRequest")
+	proto.RegisterType((*ConfigFile)(nil), "app.ConfigFile")
+	proto.RegisterType((*SetConfigFileRequest)(nil), "app.SetConfigFileRequest")
+	proto.RegisterType((*UnsetConfigFileRequest)(nil), "app.UnsetConfigFileRequest")
+	proto.RegisterType((*UpdateLifecycleRequest)(nil), "app.UpdateLifecycleRequest")
+	proto.RegisterType((*UpdateStrategyRequest)(nil), "app.UpdateStrategyRequest")
+	proto.RegisterType((*DeployMetadata)(nil), "app.DeployMetadata")
+	proto.RegisterType((*SetBuilderRequest)(nil), "app.SetBuilderRequest")
+	proto.RegisterType((*LockRequest)(nil), "app.LockRequest")
+	proto.RegisterType((*UnlockRequest)(nil), "app.UnlockRequest")
+	proto.RegisterType((*UpdateRolloutTimeoutRequest)(nil), "app.UpdateRolloutTimeoutRequest")
+	proto.RegisterType((*CronRequest)(nil), "app.CronRequest")
+	proto.RegisterType((*CronListResponse)(nil), "app.CronListResponse")
+	proto.RegisterType((*CronListResponse_Cron)(nil), "app.CronListResponse.Cron")
+	proto.RegisterType((*CronRunNowResponse)(nil), "app.CronRunNowResponse")
+	proto.RegisterType((*CronRunsRequest)(nil), "app.CronRunsRequest")
+	proto.RegisterType((*CronRunsResponse)(nil), "app.CronRunsResponse")
+	proto.RegisterType((*CronRunsResponse_Run)(nil), "app.CronRunsResponse.Run")
+	proto.RegisterType((*CronRunLogsRequest)(nil), "app.CronRunLogsRequest")
+	proto.RegisterType((*PortForwardRequest)(nil), "app.PortForwardRequest")
+	proto.RegisterType((*PortForwardResponse)(nil), "app.PortForwardResponse")
+	proto.RegisterType((*Empty)(nil), "app.Empty")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for App service
+
+type AppClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (App_LogsClient, error)
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error)
+	SetAutoscale(ctx context.Context, in *SetAutoscaleRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetReplicas(ctx context.Context, in *SetReplicasRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeletePods(ctx context.Context, in *DeletePodsRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetSecret(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	ChangeTeam(ctx context.Context, in *ChangeTeamRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetVHosts(ctx context.Context, in *SetVHostsRequest, opts ...grpc.CallOption) (*Empty, error)
+	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error)
+	Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*Empty, error)
+	Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*Empty, error)
+	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*Empty, error)
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*Empty, error)
+	Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*Empty, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (App_EventsClient, error)
+	Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error)
+	SetResources(ctx context.Context, in *SetResourcesRequest, opts ...grpc.CallOption) (*Empty, error)
+	EnvHistory(ctx context.Context, in *EnvHistoryRequest, opts ...grpc.CallOption) (*EnvHistoryResponse, error)
+	EnvRollback(ctx context.Context, in *EnvRollbackRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddVHost(ctx context.Context, in *VHostRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveVHost(ctx context.Context, in *VHostRequest, opts ...grpc.CallOption) (*Empty, error)
+	EnableTLS(ctx context.Context, in *EnableTLSRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateIngress(ctx context.Context, in *UpdateIngressRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetACL(ctx context.Context, in *SetACLRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetAutoscaleSchedule(ctx context.Context, in *SetAutoscaleScheduleRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetPDB(ctx context.Context, in *SetPDBRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddVolume(ctx context.Context, in *AddVolumeRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetConfigFile(ctx context.Context, in *SetConfigFileRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetConfigFile(ctx context.Context, in *UnsetConfigFileRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateLifecycle(ctx context.Context, in *UpdateLifecycleRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateStrategy(ctx context.Context, in *UpdateStrategyRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetBuilder(ctx context.Context, in *SetBuilderRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetBuildEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetBuildEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*Empty, error)
+	Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*Empty, error)
+	UpdateRolloutTimeout(ctx context.Context, in *UpdateRolloutTimeoutRequest, opts ...grpc.CallOption) (*Empty, error)
+	CronList(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*CronListResponse, error)
+	CronSuspend(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*Empty, error)
+	CronResume(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*Empty, error)
+	CronRunNow(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*CronRunNowResponse, error)
+	CronRuns(ctx context.Context, in *CronRunsRequest, opts ...grpc.CallOption) (*CronRunsResponse, error)
+	CronRunLogs(ctx context.Context, in *CronRunLogsRequest, opts ...grpc.CallOption) (App_CronRunLogsClient, error)
+	PortForward(ctx context.Context, opts ...grpc.CallOption) (App_PortForwardClient, error)
+}
+
+type appClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAppClient(cc *grpc.ClientConn) AppClient {
+	return &appClient{cc}
+}
+
+func (c *appClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (App_LogsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_App_serviceDesc.Streams[0], c.cc, "/app.App/Logs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &appLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type App_LogsClient interface {
+	Recv() (*LogsResponse, error)
+	grpc.ClientStream
+}
+
+type appLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *appLogsClient) Recv() (*LogsResponse, error) {
+	m := new(LogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *appClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := grpc.Invoke(ctx, "/app.App/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UnsetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := grpc.Invoke(ctx, "/app.App/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetAutoscale(ctx context.Context, in *SetAutoscaleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetAutoscale", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetReplicas(ctx context.Context, in *SetReplicasRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetReplicas", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) DeletePods(ctx context.Context, in *DeletePodsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/DeletePods", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UnsetSecret(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UnsetSecret", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) ChangeTeam(ctx context.Context, in *ChangeTeamRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/ChangeTeam", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetVHosts(ctx context.Context, in *SetVHostsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetVHosts", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Rename", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Clone", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Scale(ctx context.Context, in *ScaleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Scale", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Pause", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Resume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Restart(ctx context.Context, in *RestartRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Restart", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := grpc.Invoke(ctx, "/app.App/Status", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (App_EventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_App_serviceDesc.Streams[1], c.cc, "/app.App/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &appEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type App_EventsClient interface {
+	Recv() (*EventsResponse, error)
+	grpc.ClientStream
+}
+
+type appEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *appEventsClient) Recv() (*EventsResponse, error) {
+	m := new(EventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *appClient) Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*TopResponse, error) {
+	out := new(TopResponse)
+	err := grpc.Invoke(ctx, "/app.App/Top", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetResources(ctx context.Context, in *SetResourcesRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetResources", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) EnvHistory(ctx context.Context, in *EnvHistoryRequest, opts ...grpc.CallOption) (*EnvHistoryResponse, error) {
+	out := new(EnvHistoryResponse)
+	err := grpc.Invoke(ctx, "/app.App/EnvHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) EnvRollback(ctx context.Context, in *EnvRollbackRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/EnvRollback", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetMaintenance(ctx context.Context, in *SetMaintenanceRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetMaintenance", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) AddVHost(ctx context.Context, in *VHostRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/AddVHost", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) RemoveVHost(ctx context.Context, in *VHostRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/RemoveVHost", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) EnableTLS(ctx context.Context, in *EnableTLSRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/EnableTLS", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UpdateIngress(ctx context.Context, in *UpdateIngressRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UpdateIngress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetACL(ctx context.Context, in *SetACLRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetACL", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetAutoscaleSchedule(ctx context.Context, in *SetAutoscaleScheduleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetAutoscaleSchedule", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetPDB(ctx context.Context, in *SetPDBRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetPDB", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) AddVolume(ctx context.Context, in *AddVolumeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/AddVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) RemoveVolume(ctx context.Context, in *RemoveVolumeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/RemoveVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetConfigFile(ctx context.Context, in *SetConfigFileRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetConfigFile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UnsetConfigFile(ctx context.Context, in *UnsetConfigFileRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UnsetConfigFile", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UpdateLifecycle(ctx context.Context, in *UpdateLifecycleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UpdateLifecycle", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UpdateStrategy(ctx context.Context, in *UpdateStrategyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UpdateStrategy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetBuilder(ctx context.Context, in *SetBuilderRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetBuilder", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) SetBuildEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/SetBuildEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UnsetBuildEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UnsetBuildEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Lock(ctx context.Context, in *LockRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Lock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) Unlock(ctx context.Context, in *UnlockRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/Unlock", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) UpdateRolloutTimeout(ctx context.Context, in *UpdateRolloutTimeoutRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/UpdateRolloutTimeout", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronList(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*CronListResponse, error) {
+	out := new(CronListResponse)
+	err := grpc.Invoke(ctx, "/app.App/CronList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronSuspend(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/CronSuspend", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronResume(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/app.App/CronResume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronRunNow(ctx context.Context, in *CronRequest, opts ...grpc.CallOption) (*CronRunNowResponse, error) {
+	out := new(CronRunNowResponse)
+	err := grpc.Invoke(ctx, "/app.App/CronRunNow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronRuns(ctx context.Context, in *CronRunsRequest, opts ...grpc.CallOption) (*CronRunsResponse, error) {
+	out := new(CronRunsResponse)
+	err := grpc.Invoke(ctx, "/app.App/CronRuns", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appClient) CronRunLogs(ctx context.Context, in *CronRunLogsRequest, opts ...grpc.CallOption) (App_CronRunLogsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_App_serviceDesc.Streams[2], c.cc, "/app.App/CronRunLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &appCronRunLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type App_CronRunLogsClient interface {
+	Recv() (*LogsResponse, error)
+	grpc.ClientStream
+}
+
+type appCronRunLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *appCronRunLogsClient) Recv() (*LogsResponse, error) {
+	m := new(LogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *appClient) PortForward(ctx context.Context, opts ...grpc.CallOption) (App_PortForwardClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_App_serviceDesc.Streams[3], c.cc, "/app.App/PortForward", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &appPortForwardClient{stream}
+	return x, nil
+}
+
+type App_PortForwardClient interface {
+	Send(*PortForwardRequest) error
+	Recv() (*PortForwardResponse, error)
+	grpc.ClientStream
+}
+
+type appPortForwardClient struct {
+	grpc.ClientStream
+}
+
+func (x *appPortForwardClient) Send(m *PortForwardRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *appPortForwardClient) Recv() (*PortForwardResponse, error) {
+	m := new(PortForwardResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for App service
+
+type AppServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	Logs(*LogsRequest, App_LogsServer) error
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	SetEnv(context.Context, *SetEnvRequest) (*Empty, error)
+	UnsetEnv(context.Context, *UnsetEnvRequest) (*Empty, error)
+	List(context.Context, *Empty) (*ListResponse, error)
+	SetAutoscale(context.Context, *SetAutoscaleRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+	SetReplicas(context.Context, *SetReplicasRequest) (*Empty, error)
+	DeletePods(context.Context, *DeletePodsRequest) (*Empty, error)
+	SetSecret(context.Context, *SetSecretRequest) (*Empty, error)
+	UnsetSecret(context.Context, *UnsetEnvRequest) (*Empty, error)
+	ChangeTeam(context.Context, *ChangeTeamRequest) (*Empty, error)
+	SetVHosts(context.Context, *SetVHostsRequest) (*Empty, error)
+	Rename(context.Context, *RenameRequest) (*Empty, error)
+	Clone(context.Context, *CloneRequest) (*Empty, error)
+	Scale(context.Context, *ScaleRequest) (*Empty, error)
+	Pause(context.Context, *PauseRequest) (*Empty, error)
+	Resume(context.Context, *ResumeRequest) (*Empty, error)
+	Restart(context.Context, *RestartRequest) (*Empty, error)
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	Events(*EventsRequest, App_EventsServer) error
+	Top(context.Context, *TopRequest) (*TopResponse, error)
+	SetResources(context.Context, *SetResourcesRequest) (*Empty, error)
+	EnvHistory(context.Context, *EnvHistoryRequest) (*EnvHistoryResponse, error)
+	EnvRollback(context.Context, *EnvRollbackRequest) (*Empty, error)
+	SetMaintenance(context.Context, *SetMaintenanceRequest) (*Empty, error)
+	AddVHost(context.Context, *VHostRequest) (*Empty, error)
+	RemoveVHost(context.Context, *VHostRequest) (*Empty, error)
+	EnableTLS(context.Context, *EnableTLSRequest) (*Empty, error)
+	UpdateIngress(context.Context, *UpdateIngressRequest) (*Empty, error)
+	SetACL(context.Context, *SetACLRequest) (*Empty, error)
+	SetAutoscaleSchedule(context.Context, *SetAutoscaleScheduleRequest) (*Empty, error)
+	SetPDB(context.Context, *SetPDBRequest) (*Empty, error)
+	AddVolume(context.Context, *AddVolumeRequest) (*Empty, error)
+	RemoveVolume(context.Context, *RemoveVolumeRequest) (*Empty, error)
+	SetConfigFile(context.Context, *SetConfigFileRequest) (*Empty, error)
+	UnsetConfigFile(context.Context, *UnsetConfigFileRequest) (*Empty, error)
+	UpdateLifecycle(context.Context, *UpdateLifecycleRequest) (*Empty, error)
+	UpdateStrategy(context.Context, *UpdateStrategyRequest) (*Empty, error)
+	SetBuilder(context.Context, *SetBuilderRequest) (*Empty, error)
+	SetBuildEnv(context.Context, *SetEnvRequest) (*Empty, error)
+	UnsetBuildEnv(context.Context, *UnsetEnvRequest) (*Empty, error)
+	Lock(context.Context, *LockRequest) (*Empty, error)
+	Unlock(context.Context, *UnlockRequest) (*Empty, error)
+	UpdateRolloutTimeout(context.Context, *UpdateRolloutTimeoutRequest) (*Empty, error)
+	CronList(context.Context, *CronRequest) (*CronListResponse, error)
+	CronSuspend(context.Context, *CronRequest) (*Empty, error)
+	CronResume(context.Context, *CronRequest) (*Empty, error)
+	CronRunNow(context.Context, *CronRequest) (*CronRunNowResponse, error)
+	CronRuns(context.Context, *CronRunsRequest) (*CronRunsResponse, error)
+	CronRunLogs(*CronRunLogsRequest, App_CronRunLogsServer) error
+	PortForward(App_PortForwardServer) error
+}
+
+func RegisterAppServer(s *grpc.Server, srv AppServer) {
+	s.RegisterService(&_App_serviceDesc, srv)
+}
+
+func _App_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AppServer).Logs(m, &appLogsServer{stream})
+}
+
+type App_LogsServer interface {
+	Send(*LogsResponse) error
+	grpc.ServerStream
+}
+
+type appLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *appLogsServer) Send(m *LogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _App_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_SetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetEnv(ctx, req.(*SetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_UnsetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).UnsetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/UnsetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).UnsetEnv(ctx, req.(*UnsetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).List(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_SetAutoscale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoscaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetAutoscale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetAutoscale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetAutoscale(ctx, req.(*SetAutoscaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_SetReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetReplicasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetReplicas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetReplicas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetReplicas(ctx, req.(*SetReplicasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_DeletePods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).DeletePods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/DeletePods",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).DeletePods(ctx, req.(*DeletePodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_SetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetSecret(ctx, req.(*SetSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_UnsetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).UnsetSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/UnsetSecret",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).UnsetSecret(ctx, req.(*UnsetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_ChangeTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeTeamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).ChangeTeam(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/ChangeTeam",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).ChangeTeam(ctx, req.(*ChangeTeamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_SetVHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetVHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetVHosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetVHosts(ctx, req.(*SetVHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
+	if interceptor == nil {
+		return srv.(AppServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Rename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Rename(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _App_Clone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return x, nil
+	if interceptor == nil {
+		return srv.(AppServer).Clone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Clone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Clone(ctx, req.(*CloneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type App_LogsClient interface {
-	Recv() (*LogsResponse, error)
-	grpc.ClientStream
+func _App_Scale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Scale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Scale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Scale(ctx, req.(*ScaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type appLogsClient struct {
-	grpc.ClientStream
+func _App_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Pause(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *appLogsClient) Recv() (*LogsResponse, error) {
-	m := new(LogsResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _App_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(AppServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
-	out := new(InfoResponse)
-	err := grpc.Invoke(ctx, "/app.App/Info", in, out, c.cc, opts...)
-	if err != nil {
+func _App_Restart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).Restart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Restart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Restart(ctx, req.(*RestartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/SetEnv", in, out, c.cc, opts...)
-	if err != nil {
+func _App_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/UnsetEnv", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func _App_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return out, nil
+	return srv.(AppServer).Events(m, &appEventsServer{stream})
 }
 
-func (c *appClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := grpc.Invoke(ctx, "/app.App/List", in, out, c.cc, opts...)
-	if err != nil {
+type App_EventsServer interface {
+	Send(*EventsResponse) error
+	grpc.ServerStream
+}
+
+type appEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *appEventsServer) Send(m *EventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _App_Top_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).Top(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/Top",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).Top(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) SetAutoscale(ctx context.Context, in *SetAutoscaleRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/SetAutoscale", in, out, c.cc, opts...)
-	if err != nil {
+func _App_SetResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetResourcesRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).SetResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetResources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetResources(ctx, req.(*SetResourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/Delete", in, out, c.cc, opts...)
-	if err != nil {
+func _App_EnvHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnvHistoryRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).EnvHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/EnvHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).EnvHistory(ctx, req.(*EnvHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) SetReplicas(ctx context.Context, in *SetReplicasRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/SetReplicas", in, out, c.cc, opts...)
-	if err != nil {
+func _App_EnvRollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnvRollbackRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).EnvRollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/EnvRollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).EnvRollback(ctx, req.(*EnvRollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) DeletePods(ctx context.Context, in *DeletePodsRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/DeletePods", in, out, c.cc, opts...)
-	if err != nil {
+func _App_SetMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMaintenanceRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).SetMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetMaintenance(ctx, req.(*SetMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) SetSecret(ctx context.Context, in *SetSecretRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/SetSecret", in, out, c.cc, opts...)
-	if err != nil {
+func _App_AddVHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VHostRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).AddVHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/AddVHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).AddVHost(ctx, req.(*VHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) UnsetSecret(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/UnsetSecret", in, out, c.cc, opts...)
-	if err != nil {
+func _App_RemoveVHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VHostRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).RemoveVHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/RemoveVHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).RemoveVHost(ctx, req.(*VHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) ChangeTeam(ctx context.Context, in *ChangeTeamRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/ChangeTeam", in, out, c.cc, opts...)
-	if err != nil {
+func _App_EnableTLS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnableTLSRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).EnableTLS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/EnableTLS",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).EnableTLS(ctx, req.(*EnableTLSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *appClient) SetVHosts(ctx context.Context, in *SetVHostsRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/app.App/SetVHosts", in, out, c.cc, opts...)
-	if err != nil {
+func _App_UpdateIngress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateIngressRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(AppServer).UpdateIngress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/UpdateIngress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).UpdateIngress(ctx, req.(*UpdateIngressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// Server API for App service
+func _App_SetACL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetACLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetACL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetACL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetACL(ctx, req.(*SetACLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-type AppServer interface {
-	Create(context.Context, *CreateRequest) (*Empty, error)
-	Logs(*LogsRequest, App_LogsServer) error
-	Info(context.Context, *InfoRequest) (*InfoResponse, error)
-	SetEnv(context.Context, *SetEnvRequest) (*Empty, error)
-	UnsetEnv(context.Context, *UnsetEnvRequest) (*Empty, error)
-	List(context.Context, *Empty) (*ListResponse, error)
-	SetAutoscale(context.Context, *SetAutoscaleRequest) (*Empty, error)
-	Delete(context.Context, *DeleteRequest) (*Empty, error)
-	SetReplicas(context.Context, *SetReplicasRequest) (*Empty, error)
-	DeletePods(context.Context, *DeletePodsRequest) (*Empty, error)
-	SetSecret(context.Context, *SetSecretRequest) (*Empty, error)
-	UnsetSecret(context.Context, *UnsetEnvRequest) (*Empty, error)
-	ChangeTeam(context.Context, *ChangeTeamRequest) (*Empty, error)
-	SetVHosts(context.Context, *SetVHostsRequest) (*Empty, error)
+func _App_SetAutoscaleSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutoscaleScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetAutoscaleSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetAutoscaleSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetAutoscaleSchedule(ctx, req.(*SetAutoscaleScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAppServer(s *grpc.Server, srv AppServer) {
-	s.RegisterService(&_App_serviceDesc, srv)
+func _App_SetPDB_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPDBRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetPDB(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetPDB",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetPDB(ctx, req.(*SetPDBRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _App_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
+func _App_AddVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddVolumeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).Create(ctx, in)
+		return srv.(AppServer).AddVolume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/Create",
+		FullMethod: "/app.App/AddVolume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).Create(ctx, req.(*CreateRequest))
+		return srv.(AppServer).AddVolume(ctx, req.(*AddVolumeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_Logs_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LogsRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _App_RemoveVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVolumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(AppServer).Logs(m, &appLogsServer{stream})
+	if interceptor == nil {
+		return srv.(AppServer).RemoveVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/RemoveVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).RemoveVolume(ctx, req.(*RemoveVolumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type App_LogsServer interface {
-	Send(*LogsResponse) error
-	grpc.ServerStream
+func _App_SetConfigFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetConfigFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).SetConfigFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/SetConfigFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).SetConfigFile(ctx, req.(*SetConfigFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type appLogsServer struct {
-	grpc.ServerStream
+func _App_UnsetConfigFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetConfigFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).UnsetConfigFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/UnsetConfigFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).UnsetConfigFile(ctx, req.(*UnsetConfigFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *appLogsServer) Send(m *LogsResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _App_UpdateLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLifecycleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppServer).UpdateLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/app.App/UpdateLifecycle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppServer).UpdateLifecycle(ctx, req.(*UpdateLifecycleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _App_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InfoRequest)
+func _App_UpdateStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStrategyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).Info(ctx, in)
+		return srv.(AppServer).UpdateStrategy(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/Info",
+		FullMethod: "/app.App/UpdateStrategy",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).Info(ctx, req.(*InfoRequest))
+		return srv.(AppServer).UpdateStrategy(ctx, req.(*UpdateStrategyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_SetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetEnvRequest)
+func _App_SetBuilder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBuilderRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).SetEnv(ctx, in)
+		return srv.(AppServer).SetBuilder(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/SetEnv",
+		FullMethod: "/app.App/SetBuilder",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).SetEnv(ctx, req.(*SetEnvRequest))
+		return srv.(AppServer).SetBuilder(ctx, req.(*SetBuilderRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_UnsetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnsetEnvRequest)
+func _App_SetBuildEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).UnsetEnv(ctx, in)
+		return srv.(AppServer).SetBuildEnv(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/UnsetEnv",
+		FullMethod: "/app.App/SetBuildEnv",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).UnsetEnv(ctx, req.(*UnsetEnvRequest))
+		return srv.(AppServer).SetBuildEnv(ctx, req.(*SetEnvRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _App_UnsetBuildEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).List(ctx, in)
+		return srv.(AppServer).UnsetBuildEnv(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/List",
+		FullMethod: "/app.App/UnsetBuildEnv",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).List(ctx, req.(*Empty))
+		return srv.(AppServer).UnsetBuildEnv(ctx, req.(*UnsetEnvRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_SetAutoscale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetAutoscaleRequest)
+func _App_Lock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).SetAutoscale(ctx, in)
+		return srv.(AppServer).Lock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/SetAutoscale",
+		FullMethod: "/app.App/Lock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).SetAutoscale(ctx, req.(*SetAutoscaleRequest))
+		return srv.(AppServer).Lock(ctx, req.(*LockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRequest)
+func _App_Unlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).Delete(ctx, in)
+		return srv.(AppServer).Unlock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/Delete",
+		FullMethod: "/app.App/Unlock",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(AppServer).Unlock(ctx, req.(*UnlockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_SetReplicas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetReplicasRequest)
+func _App_UpdateRolloutTimeout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRolloutTimeoutRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).SetReplicas(ctx, in)
+		return srv.(AppServer).UpdateRolloutTimeout(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/SetReplicas",
+		FullMethod: "/app.App/UpdateRolloutTimeout",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).SetReplicas(ctx, req.(*SetReplicasRequest))
+		return srv.(AppServer).UpdateRolloutTimeout(ctx, req.(*UpdateRolloutTimeoutRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_DeletePods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeletePodsRequest)
+func _App_CronList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).DeletePods(ctx, in)
+		return srv.(AppServer).CronList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/DeletePods",
+		FullMethod: "/app.App/CronList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).DeletePods(ctx, req.(*DeletePodsRequest))
+		return srv.(AppServer).CronList(ctx, req.(*CronRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_SetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetSecretRequest)
+func _App_CronSuspend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).SetSecret(ctx, in)
+		return srv.(AppServer).CronSuspend(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/SetSecret",
+		FullMethod: "/app.App/CronSuspend",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).SetSecret(ctx, req.(*SetSecretRequest))
+		return srv.(AppServer).CronSuspend(ctx, req.(*CronRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_UnsetSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UnsetEnvRequest)
+func _App_CronResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).UnsetSecret(ctx, in)
+		return srv.(AppServer).CronResume(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/UnsetSecret",
+		FullMethod: "/app.App/CronResume",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).UnsetSecret(ctx, req.(*UnsetEnvRequest))
+		return srv.(AppServer).CronResume(ctx, req.(*CronRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_ChangeTeam_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangeTeamRequest)
+func _App_CronRunNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).ChangeTeam(ctx, in)
+		return srv.(AppServer).CronRunNow(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/ChangeTeam",
+		FullMethod: "/app.App/CronRunNow",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).ChangeTeam(ctx, req.(*ChangeTeamRequest))
+		return srv.(AppServer).CronRunNow(ctx, req.(*CronRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _App_SetVHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetVHostsRequest)
+func _App_CronRuns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CronRunsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AppServer).SetVHosts(ctx, in)
+		return srv.(AppServer).CronRuns(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/app.App/SetVHosts",
+		FullMethod: "/app.App/CronRuns",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AppServer).SetVHosts(ctx, req.(*SetVHostsRequest))
+		return srv.(AppServer).CronRuns(ctx, req.(*CronRunsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _App_CronRunLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CronRunLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AppServer).CronRunLogs(m, &appCronRunLogsServer{stream})
+}
+
+type App_CronRunLogsServer interface {
+	Send(*LogsResponse) error
+	grpc.ServerStream
+}
+
+type appCronRunLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *appCronRunLogsServer) Send(m *LogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _App_PortForward_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AppServer).PortForward(&appPortForwardServer{stream})
+}
+
+type App_PortForwardServer interface {
+	Send(*PortForwardResponse) error
+	Recv() (*PortForwardRequest, error)
+	grpc.ServerStream
+}
+
+type appPortForwardServer struct {
+	grpc.ServerStream
+}
+
+func (x *appPortForwardServer) Send(m *PortForwardResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *appPortForwardServer) Recv() (*PortForwardRequest, error) {
+	m := new(PortForwardRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _App_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "app.App",
 	HandlerType: (*AppServer)(nil),
@@ -1465,6 +4453,150 @@ var _App_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetVHosts",
 			Handler:    _App_SetVHosts_Handler,
 		},
+		{
+			MethodName: "Rename",
+			Handler:    _App_Rename_Handler,
+		},
+		{
+			MethodName: "Clone",
+			Handler:    _App_Clone_Handler,
+		},
+		{
+			MethodName: "Scale",
+			Handler:    _App_Scale_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _App_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _App_Resume_Handler,
+		},
+		{
+			MethodName: "Restart",
+			Handler:    _App_Restart_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _App_Status_Handler,
+		},
+		{
+			MethodName: "Top",
+			Handler:    _App_Top_Handler,
+		},
+		{
+			MethodName: "SetResources",
+			Handler:    _App_SetResources_Handler,
+		},
+		{
+			MethodName: "EnvHistory",
+			Handler:    _App_EnvHistory_Handler,
+		},
+		{
+			MethodName: "EnvRollback",
+			Handler:    _App_EnvRollback_Handler,
+		},
+		{
+			MethodName: "SetMaintenance",
+			Handler:    _App_SetMaintenance_Handler,
+		},
+		{
+			MethodName: "AddVHost",
+			Handler:    _App_AddVHost_Handler,
+		},
+		{
+			MethodName: "RemoveVHost",
+			Handler:    _App_RemoveVHost_Handler,
+		},
+		{
+			MethodName: "EnableTLS",
+			Handler:    _App_EnableTLS_Handler,
+		},
+		{
+			MethodName: "UpdateIngress",
+			Handler:    _App_UpdateIngress_Handler,
+		},
+		{
+			MethodName: "SetACL",
+			Handler:    _App_SetACL_Handler,
+		},
+		{
+			MethodName: "SetAutoscaleSchedule",
+			Handler:    _App_SetAutoscaleSchedule_Handler,
+		},
+		{
+			MethodName: "SetPDB",
+			Handler:    _App_SetPDB_Handler,
+		},
+		{
+			MethodName: "AddVolume",
+			Handler:    _App_AddVolume_Handler,
+		},
+		{
+			MethodName: "RemoveVolume",
+			Handler:    _App_RemoveVolume_Handler,
+		},
+		{
+			MethodName: "SetConfigFile",
+			Handler:    _App_SetConfigFile_Handler,
+		},
+		{
+			MethodName: "UnsetConfigFile",
+			Handler:    _App_UnsetConfigFile_Handler,
+		},
+		{
+			MethodName: "UpdateLifecycle",
+			Handler:    _App_UpdateLifecycle_Handler,
+		},
+		{
+			MethodName: "UpdateStrategy",
+			Handler:    _App_UpdateStrategy_Handler,
+		},
+		{
+			MethodName: "SetBuilder",
+			Handler:    _App_SetBuilder_Handler,
+		},
+		{
+			MethodName: "SetBuildEnv",
+			Handler:    _App_SetBuildEnv_Handler,
+		},
+		{
+			MethodName: "UnsetBuildEnv",
+			Handler:    _App_UnsetBuildEnv_Handler,
+		},
+		{
+			MethodName: "Lock",
+			Handler:    _App_Lock_Handler,
+		},
+		{
+			MethodName: "Unlock",
+			Handler:    _App_Unlock_Handler,
+		},
+		{
+			MethodName: "UpdateRolloutTimeout",
+			Handler:    _App_UpdateRolloutTimeout_Handler,
+		},
+		{
+			MethodName: "CronList",
+			Handler:    _App_CronList_Handler,
+		},
+		{
+			MethodName: "CronSuspend",
+			Handler:    _App_CronSuspend_Handler,
+		},
+		{
+			MethodName: "CronResume",
+			Handler:    _App_CronResume_Handler,
+		},
+		{
+			MethodName: "CronRunNow",
+			Handler:    _App_CronRunNow_Handler,
+		},
+		{
+			MethodName: "CronRuns",
+			Handler:    _App_CronRuns_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1472,6 +4604,22 @@ var _App_serviceDesc = grpc.ServiceDesc{
 			Handler:       _App_Logs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Events",
+			Handler:       _App_Events_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CronRunLogs",
+			Handler:       _App_CronRunLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PortForward",
+			Handler:       _App_PortForward_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "pkg/protobuf/app/app.proto",
 }
@@ -1479,83 +4627,223 @@ var _App_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/app/app.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1234 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xd7, 0xc5, 0xf6, 0xd9, 0x1e, 0xbb, 0x34, 0x59, 0xd2, 0x70, 0xb9, 0x06, 0xc9, 0x3d, 0x54,
-	0xc9, 0xa8, 0xc5, 0x31, 0x6e, 0x24, 0xa0, 0x4f, 0xb5, 0x5a, 0x47, 0x20, 0x22, 0x14, 0xce, 0x49,
-	0x5f, 0xad, 0xad, 0xbd, 0x49, 0x4f, 0x3d, 0xdf, 0x6e, 0x6f, 0xf7, 0x4c, 0x82, 0x78, 0xe3, 0x91,
-	0x47, 0xbe, 0x02, 0x5f, 0x84, 0xaf, 0xc0, 0x17, 0xe8, 0x87, 0x40, 0xbc, 0xa3, 0xfd, 0x73, 0xff,
-	0x1c, 0xdb, 0x6d, 0x91, 0xe8, 0x83, 0xe5, 0x9d, 0xd9, 0x99, 0xd9, 0xd9, 0xb9, 0xdf, 0xfc, 0x76,
-	0xc0, 0x65, 0xaf, 0x2e, 0x0f, 0x59, 0x4c, 0x05, 0x7d, 0x91, 0x5c, 0x1c, 0x62, 0xc6, 0xe4, 0xaf,
-	0xa7, 0x14, 0xa8, 0x82, 0x19, 0xf3, 0x7e, 0xad, 0xc1, 0xad, 0xa7, 0x31, 0xc1, 0x82, 0xf8, 0xe4,
-	0x75, 0x42, 0xb8, 0x40, 0x08, 0xaa, 0x11, 0x9e, 0x13, 0xc7, 0xea, 0x58, 0xdd, 0xa6, 0xaf, 0xd6,
-	0x52, 0x27, 0x08, 0x9e, 0x3b, 0x5b, 0x5a, 0x27, 0xd7, 0xe8, 0x1e, 0xb4, 0x59, 0x4c, 0xa7, 0x84,
-	0xf3, 0x89, 0xb8, 0x66, 0xc4, 0xa9, 0xa8, 0xbd, 0x96, 0xd1, 0x9d, 0x5d, 0x33, 0x82, 0xbe, 0x04,
-	0x3b, 0x0c, 0xe6, 0x81, 0xe0, 0x4e, 0xb5, 0x63, 0x75, 0x5b, 0x83, 0xfd, 0x9e, 0x3c, 0xbd, 0x74,
-	0x5c, 0xef, 0x44, 0x19, 0xf8, 0xc6, 0x10, 0x3d, 0x86, 0x26, 0x4e, 0x04, 0xe5, 0x53, 0x1c, 0x12,
-	0xa7, 0xa6, 0xbc, 0x0e, 0x56, 0x78, 0x0d, 0x53, 0x1b, 0x3f, 0x37, 0x97, 0x19, 0x2d, 0x82, 0x58,
-	0x24, 0x38, 0x9c, 0xbc, 0xa4, 0x5c, 0x38, 0xb6, 0xce, 0xc8, 0xe8, 0xbe, 0xa5, 0x5c, 0x20, 0x17,
-	0x1a, 0x41, 0x24, 0x48, 0x1c, 0xe1, 0xd0, 0xa9, 0x77, 0xac, 0x6e, 0xc3, 0xcf, 0x64, 0xb9, 0xa7,
-	0x0a, 0x33, 0xa5, 0xa1, 0xd3, 0x50, 0xae, 0x99, 0xec, 0xfe, 0x63, 0x81, 0xad, 0x33, 0x45, 0xc7,
-	0x50, 0x9f, 0x91, 0x0b, 0x9c, 0x84, 0xc2, 0xb1, 0x3a, 0x95, 0x6e, 0x6b, 0xf0, 0x70, 0xed, 0xad,
-	0xf4, 0x9f, 0x8f, 0xa3, 0x4b, 0xf2, 0x63, 0x82, 0x23, 0x11, 0x88, 0x6b, 0x3f, 0x75, 0x46, 0xe7,
-	0x70, 0xdb, 0x2c, 0x27, 0xb1, 0xf6, 0x72, 0xb6, 0xfe, 0x43, 0xbc, 0x8f, 0x4c, 0x10, 0x63, 0xe9,
-	0x9e, 0x00, 0xba, 0x69, 0x25, 0xef, 0xf6, 0xda, 0xac, 0xcd, 0x87, 0xcd, 0x64, 0xb9, 0x17, 0x13,
-	0x4e, 0x93, 0x78, 0x4a, 0xcc, 0x07, 0xce, 0x64, 0x97, 0x40, 0x33, 0x2b, 0x35, 0x3a, 0x82, 0xbd,
-	0x29, 0x4b, 0x26, 0x02, 0xc7, 0x97, 0x44, 0x4c, 0x12, 0x11, 0x84, 0xc1, 0xcf, 0x58, 0x04, 0x34,
-	0x52, 0x21, 0x6b, 0xfe, 0xee, 0x94, 0x25, 0x67, 0x6a, 0xf3, 0x3c, 0xdf, 0x43, 0xdb, 0x50, 0x99,
-	0xe3, 0x2b, 0x15, 0xb9, 0xe6, 0xcb, 0xa5, 0xd2, 0x04, 0x91, 0x02, 0x8c, 0xd4, 0x04, 0x91, 0xf7,
-	0x0b, 0xb4, 0x4f, 0x02, 0x2e, 0x7c, 0xc2, 0x19, 0x8d, 0x38, 0x41, 0x9f, 0x43, 0x15, 0x33, 0xc6,
-	0x4d, 0x81, 0xef, 0xa8, 0x82, 0x14, 0x0d, 0x7a, 0x43, 0xc6, 0x7c, 0x65, 0xe2, 0x0e, 0xa1, 0x32,
-	0x64, 0x2c, 0x43, 0xa8, 0x55, 0x40, 0x68, 0x8a, 0xe4, 0xad, 0x32, 0x92, 0x93, 0x38, 0xe4, 0x4e,
-	0xa5, 0x53, 0x91, 0x3a, 0xb9, 0xf6, 0xfe, 0xb0, 0xa0, 0x75, 0x42, 0x2f, 0xf9, 0xa6, 0x0e, 0xd8,
-	0x85, 0x5a, 0x18, 0x44, 0x84, 0xab, 0x60, 0x15, 0x5f, 0x0b, 0x68, 0x0f, 0xec, 0x0b, 0x1a, 0x86,
-	0xf4, 0x27, 0x75, 0x99, 0x86, 0x6f, 0x24, 0xb4, 0x0f, 0x0d, 0x46, 0x67, 0x13, 0x15, 0xa5, 0xaa,
-	0xa2, 0xd4, 0x19, 0x9d, 0xfd, 0x20, 0x03, 0x29, 0x94, 0x91, 0x45, 0x40, 0x13, 0xae, 0xf0, 0xdd,
-	0xf0, 0x33, 0x19, 0x1d, 0x40, 0x73, 0x4a, 0x23, 0x81, 0x83, 0x88, 0xc4, 0x06, 0xbd, 0xb9, 0xc2,
-	0xf3, 0xa0, 0xad, 0xb3, 0x34, 0x45, 0x52, 0x57, 0xbe, 0x12, 0xf9, 0x95, 0xaf, 0x84, 0x77, 0x0f,
-	0x5a, 0xdf, 0x45, 0x17, 0x74, 0xc3, 0x4d, 0xbc, 0x37, 0x75, 0x68, 0x6b, 0x9b, 0x62, 0x9c, 0xa5,
-	0xd2, 0x7d, 0x05, 0x4d, 0x3c, 0x9b, 0xc5, 0x84, 0x73, 0x75, 0xe5, 0x4a, 0xd6, 0xbc, 0x45, 0xcf,
-	0xde, 0x50, 0x9b, 0xf8, 0xb9, 0x2d, 0x7a, 0x04, 0x0d, 0x12, 0x2d, 0x26, 0x0b, 0x1c, 0xeb, 0x1a,
-	0xb7, 0x06, 0xce, 0x4d, 0xbf, 0x51, 0xb4, 0x78, 0x8e, 0x63, 0xbf, 0x4e, 0xd4, 0x3f, 0x47, 0x7d,
-	0xb0, 0xb9, 0xc0, 0x22, 0x49, 0x79, 0x62, 0x85, 0xcb, 0x58, 0xed, 0xfb, 0xc6, 0x0e, 0x7d, 0x73,
-	0x93, 0x26, 0xee, 0xae, 0xc8, 0x6f, 0x15, 0x4b, 0xf4, 0x33, 0x52, 0xb2, 0xd7, 0x1d, 0xb6, 0xc4,
-	0x49, 0x45, 0x62, 0xa8, 0x97, 0x89, 0x01, 0x39, 0x50, 0x5f, 0xd0, 0x30, 0x99, 0x13, 0xee, 0x34,
-	0x14, 0xa4, 0x52, 0xd1, 0xbd, 0x0f, 0x75, 0x53, 0x1f, 0x19, 0x40, 0x12, 0x52, 0xe1, 0x53, 0x64,
-	0xb2, 0xdb, 0x07, 0x5b, 0x97, 0x43, 0xb6, 0xc5, 0x2b, 0x92, 0xb6, 0xa7, 0x5c, 0x4a, 0xd0, 0x2d,
-	0x70, 0x98, 0xa4, 0x08, 0xd6, 0x82, 0xfb, 0xa7, 0x05, 0xb6, 0x2e, 0x87, 0x74, 0x99, 0xb2, 0xc4,
-	0xb4, 0x9f, 0x5c, 0xa2, 0x3e, 0x54, 0x19, 0x9d, 0xa5, 0xb5, 0x3f, 0x58, 0x57, 0xc8, 0xde, 0x29,
-	0x9d, 0xf9, 0xca, 0xd2, 0xe5, 0x50, 0x39, 0xa5, 0xb3, 0x75, 0xa0, 0x97, 0xf5, 0xce, 0xce, 0x57,
-	0x82, 0x3c, 0x14, 0x5f, 0x6a, 0xbe, 0xaf, 0xf8, 0x72, 0x69, 0x18, 0x44, 0xe0, 0xd8, 0x30, 0x7d,
-	0xcd, 0xcf, 0x64, 0x19, 0x23, 0x26, 0x78, 0x76, 0x6d, 0xc0, 0xae, 0x85, 0x0f, 0xc4, 0x2b, 0xee,
-	0xdf, 0x39, 0x6d, 0x8f, 0x96, 0x69, 0xfb, 0xc1, 0xba, 0xef, 0xbe, 0x91, 0xb5, 0xcf, 0xd6, 0xb1,
-	0xf6, 0x7b, 0x85, 0xfb, 0x5f, 0x49, 0xdb, 0xfb, 0xcd, 0x82, 0x5b, 0x63, 0x22, 0x46, 0xd1, 0x62,
-	0x13, 0xa3, 0x1d, 0x15, 0x3a, 0xb5, 0xd8, 0xe1, 0x25, 0xcf, 0xe5, 0x56, 0x7d, 0x7f, 0xb8, 0x7a,
-	0x4f, 0xe0, 0xf6, 0x79, 0xc4, 0xdf, 0x9a, 0xce, 0xfe, 0x52, 0x3a, 0xcd, 0xec, 0x4c, 0xef, 0x8d,
-	0x05, 0xdb, 0x63, 0x22, 0xc6, 0x64, 0x1a, 0x13, 0xb1, 0x29, 0xc6, 0x63, 0x68, 0x71, 0x65, 0x34,
-	0x21, 0xd1, 0xe2, 0x1d, 0x6e, 0x05, 0xda, 0x7a, 0x14, 0x2d, 0x38, 0x1a, 0x66, 0xbe, 0x17, 0x41,
-	0xa8, 0xd1, 0xdd, 0x1a, 0x74, 0x52, 0xdf, 0xd2, 0xd9, 0x3d, 0x2d, 0x1d, 0x07, 0x21, 0x49, 0x43,
-	0xc8, 0xb5, 0xfb, 0x35, 0x40, 0xbe, 0xb3, 0xa2, 0x3e, 0x0e, 0xd4, 0x25, 0x9b, 0x93, 0x48, 0xa8,
-	0x0a, 0xb5, 0xfd, 0x54, 0xf4, 0xfe, 0xb2, 0xe0, 0xe3, 0x31, 0x11, 0x39, 0x5f, 0x6d, 0xb8, 0xe4,
-	0x93, 0x22, 0xf5, 0x6d, 0xa9, 0x34, 0xbd, 0x34, 0xcd, 0xe5, 0x00, 0x2b, 0x19, 0xf0, 0x43, 0x3d,
-	0xea, 0xcf, 0x00, 0x8d, 0x65, 0xcd, 0x58, 0x18, 0x4c, 0xf1, 0xc6, 0xc7, 0x55, 0x81, 0x59, 0x9b,
-	0x99, 0x90, 0x99, 0xec, 0x7d, 0x06, 0xb7, 0x9e, 0x91, 0x90, 0x6c, 0x9c, 0x4f, 0xbd, 0x63, 0xd8,
-	0xd1, 0x46, 0xa7, 0x74, 0xb6, 0xf1, 0xa4, 0x4f, 0x01, 0x24, 0xe9, 0xa9, 0x97, 0x39, 0xc5, 0x59,
-	0x53, 0x6a, 0xe4, 0xdb, 0xcc, 0xbd, 0xef, 0x61, 0xe7, 0xe9, 0x4b, 0xd9, 0x83, 0x67, 0x04, 0xcf,
-	0xd3, 0x38, 0xfb, 0xd0, 0xc0, 0x8c, 0x4d, 0x0a, 0xb1, 0xea, 0x98, 0x31, 0xf5, 0x98, 0xdf, 0x85,
-	0xa6, 0x7c, 0x2e, 0x27, 0x85, 0x31, 0xa3, 0x21, 0x15, 0x72, 0xd3, 0x1b, 0x29, 0xd4, 0x3e, 0x97,
-	0x73, 0x27, 0x7f, 0x87, 0x58, 0x7b, 0x60, 0x2f, 0xe4, 0xab, 0x90, 0xa6, 0x65, 0x24, 0xaf, 0x0e,
-	0xb5, 0xd1, 0x9c, 0x89, 0xeb, 0xc1, 0xef, 0x35, 0x3d, 0xea, 0x74, 0xc1, 0xd6, 0xc3, 0x21, 0x42,
-	0x37, 0x27, 0x45, 0x17, 0x94, 0x4e, 0x79, 0xa0, 0x2f, 0xa0, 0x2a, 0x27, 0x06, 0xb4, 0xad, 0x07,
-	0xa8, 0x7c, 0xc4, 0x71, 0x77, 0x0a, 0x1a, 0xcd, 0x56, 0x7d, 0x0b, 0x3d, 0x80, 0xaa, 0xe4, 0x2f,
-	0x63, 0x5e, 0x98, 0x23, 0x8c, 0x79, 0x69, 0x6a, 0xe8, 0x82, 0xad, 0x7b, 0xca, 0x64, 0x51, 0x6a,
-	0xb0, 0x52, 0x16, 0x0f, 0xa1, 0x91, 0x12, 0x00, 0xda, 0x55, 0xfa, 0x25, 0x3e, 0x28, 0x59, 0xdf,
-	0x87, 0xaa, 0x9c, 0xf4, 0x50, 0x41, 0x97, 0x66, 0x5b, 0x9c, 0x10, 0x8f, 0xa0, 0x5d, 0xc4, 0x3b,
-	0x72, 0xd6, 0xb5, 0x40, 0x29, 0x78, 0x17, 0x6c, 0x8d, 0x13, 0x93, 0x74, 0x09, 0x59, 0x25, 0xcb,
-	0x01, 0xb4, 0x0a, 0xe0, 0x45, 0x9f, 0xa4, 0xe1, 0x97, 0xe0, 0x5c, 0xf2, 0xe9, 0x03, 0xe4, 0x28,
-	0x44, 0x7b, 0x85, 0x13, 0x0a, 0xb0, 0x2c, 0x79, 0xf4, 0xa0, 0x99, 0x91, 0x0b, 0xba, 0xb3, 0x92,
-	0x6c, 0x4a, 0xf6, 0x87, 0xd0, 0x52, 0xb5, 0x33, 0x1e, 0x6f, 0xaf, 0x66, 0x1f, 0x20, 0x07, 0xb4,
-	0x49, 0xe9, 0x06, 0xc2, 0x57, 0xa4, 0xa4, 0x51, 0x9b, 0xa7, 0x54, 0x42, 0x71, 0xd1, 0xfe, 0x85,
-	0xad, 0x46, 0xa1, 0x47, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x1d, 0xcc, 0x54, 0xeb, 0x6a, 0x0e,
-	0x00, 0x00,
+	// 3484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x3a, 0xcb, 0x6e, 0x1c, 0xc7,
+	0xb5, 0xe8, 0x19, 0xce, 0x70, 0xe6, 0xcc, 0xf0, 0xa1, 0xe2, 0x43, 0xcd, 0x91, 0x7d, 0x4d, 0xb7,
+	0x1f, 0xa2, 0x2c, 0x5f, 0x8a, 0x96, 0x74, 0xfd, 0x90, 0x70, 0xaf, 0x4d, 0x51, 0x94, 0xed, 0x7b,
+	0x25, 0x5f, 0xde, 0x1e, 0x4a, 0x06, 0x2e, 0x10, 0x0c, 0x8a, 0xdd, 0x25, 0xaa, 0xad, 0x9e, 0xee,
+	0x56, 0x57, 0xf5, 0x48, 0x34, 0xbc, 0x08, 0xb2, 0xcd, 0x2a, 0x3b, 0x23, 0x8f, 0x45, 0x96, 0x01,
+	0x02, 0xe4, 0x13, 0x82, 0x7c, 0x42, 0x76, 0x5e, 0xe4, 0x03, 0xbc, 0x0f, 0xb2, 0x4d, 0x82, 0x7a,
+	0x75, 0x57, 0xf7, 0xbc, 0xe8, 0x18, 0x49, 0x80, 0x2c, 0x08, 0xd6, 0x39, 0x75, 0xce, 0xe9, 0xaa,
+	0x53, 0xa7, 0xce, 0xab, 0x06, 0x7a, 0xc9, 0xd3, 0xd3, 0x6b, 0x49, 0x1a, 0xb3, 0xf8, 0x24, 0x7b,
+	0x7c, 0x0d, 0x27, 0x09, 0xff, 0xdb, 0x15, 0x08, 0x54, 0xc7, 0x49, 0xe2, 0xfc, 0xa2, 0x01, 0x4b,
+	0x07, 0x29, 0xc1, 0x8c, 0xb8, 0xe4, 0x59, 0x46, 0x28, 0x43, 0x08, 0x16, 0x22, 0x3c, 0x24, 0xb6,
+	0xb5, 0x6d, 0xed, 0xb4, 0x5d, 0x31, 0xe6, 0x38, 0x46, 0xf0, 0xd0, 0xae, 0x49, 0x1c, 0x1f, 0xa3,
+	0x57, 0xa1, 0x9b, 0xa4, 0xb1, 0x47, 0x28, 0x1d, 0xb0, 0xb3, 0x84, 0xd8, 0x75, 0x31, 0xd7, 0x51,
+	0xb8, 0xe3, 0xb3, 0x84, 0xa0, 0x77, 0xa0, 0x19, 0x06, 0xc3, 0x80, 0x51, 0x7b, 0x61, 0xdb, 0xda,
+	0xe9, 0x5c, 0xdf, 0xda, 0xe5, 0x5f, 0x2f, 0x7d, 0x6e, 0xf7, 0xbe, 0x20, 0x70, 0x15, 0x21, 0xba,
+	0x05, 0x6d, 0x9c, 0xb1, 0x98, 0x7a, 0x38, 0x24, 0x76, 0x43, 0x70, 0xbd, 0x34, 0x81, 0x6b, 0x5f,
+	0xd3, 0xb8, 0x05, 0x39, 0x5f, 0xd1, 0x28, 0x48, 0x59, 0x86, 0xc3, 0xc1, 0x93, 0x98, 0x32, 0xbb,
+	0x29, 0x57, 0xa4, 0x70, 0x9f, 0xc4, 0x94, 0xa1, 0x1e, 0xb4, 0x82, 0x88, 0x91, 0x34, 0xc2, 0xa1,
+	0xbd, 0xb8, 0x6d, 0xed, 0xb4, 0xdc, 0x1c, 0xe6, 0x73, 0x42, 0x31, 0x5e, 0x1c, 0xda, 0x2d, 0xc1,
+	0x9a, 0xc3, 0xc8, 0x86, 0x45, 0x2f, 0xcc, 0x28, 0x23, 0xa9, 0xdd, 0x16, 0x53, 0x1a, 0xec, 0xfd,
+	0xc9, 0x82, 0xa6, 0xdc, 0x03, 0xba, 0x07, 0x8b, 0x3e, 0x79, 0x8c, 0xb3, 0x90, 0xd9, 0xd6, 0x76,
+	0x7d, 0xa7, 0x73, 0xfd, 0xed, 0xa9, 0xfb, 0x95, 0xff, 0x5c, 0x1c, 0x9d, 0x92, 0xff, 0xcb, 0x70,
+	0xc4, 0x02, 0x76, 0xe6, 0x6a, 0x66, 0xf4, 0x10, 0x56, 0xd4, 0x70, 0x90, 0x4a, 0x2e, 0xbb, 0xf6,
+	0x37, 0xc8, 0x5b, 0x56, 0x42, 0x14, 0x65, 0xef, 0x3e, 0xa0, 0x71, 0x2a, 0xbe, 0xeb, 0x67, 0x6a,
+	0xac, 0x8e, 0xbc, 0xf5, 0xcc, 0x98, 0x4b, 0x09, 0x8d, 0xb3, 0xd4, 0x23, 0xea, 0xe8, 0x73, 0xb8,
+	0x47, 0xa0, 0x9d, 0x1f, 0x02, 0xba, 0x09, 0x9b, 0x5e, 0x92, 0x0d, 0x18, 0x4e, 0x4f, 0x09, 0x1b,
+	0x64, 0x2c, 0x08, 0x83, 0x2f, 0x31, 0x0b, 0xe2, 0x48, 0x88, 0x6c, 0xb8, 0xeb, 0x5e, 0x92, 0x1d,
+	0x8b, 0xc9, 0x87, 0xc5, 0x1c, 0x5a, 0x85, 0xfa, 0x10, 0xbf, 0x10, 0x92, 0x1b, 0x2e, 0x1f, 0x0a,
+	0x4c, 0x10, 0xd9, 0x75, 0x85, 0x09, 0x22, 0xe7, 0x2b, 0xe8, 0xde, 0x0f, 0x28, 0x73, 0x09, 0x4d,
+	0xe2, 0x88, 0x12, 0x74, 0x05, 0x16, 0x70, 0x92, 0x50, 0xa5, 0xe0, 0x0d, 0xa1, 0x10, 0x93, 0x60,
+	0x77, 0x3f, 0x49, 0x5c, 0x41, 0xd2, 0xdb, 0x87, 0xfa, 0x7e, 0x92, 0xe4, 0xb6, 0x6b, 0x19, 0xb6,
+	0xab, 0x6d, 0xbc, 0x56, 0xb6, 0xf1, 0x2c, 0x0d, 0xa9, 0x5d, 0xdf, 0xae, 0x73, 0x1c, 0x1f, 0x3b,
+	0xbf, 0xa9, 0x41, 0xe7, 0x7e, 0x7c, 0x4a, 0x67, 0xdd, 0x8d, 0x75, 0x68, 0x84, 0x41, 0x44, 0xa8,
+	0x10, 0x56, 0x77, 0x25, 0x80, 0x36, 0xa1, 0xf9, 0x38, 0x0e, 0xc3, 0xf8, 0xb9, 0xd8, 0x4c, 0xcb,
+	0x55, 0x10, 0xda, 0x82, 0x56, 0x12, 0xfb, 0x03, 0x21, 0x65, 0x41, 0x5a, 0x52, 0x12, 0xfb, 0x9f,
+	0x71, 0x41, 0xc2, 0xfe, 0xc8, 0x28, 0x88, 0x33, 0x2a, 0x2c, 0xbf, 0xe5, 0xe6, 0x30, 0x7a, 0x09,
+	0xda, 0x5e, 0x1c, 0x31, 0x1c, 0x44, 0x24, 0x55, 0x76, 0x5d, 0x20, 0xb8, 0x75, 0xaa, 0x6b, 0x67,
+	0x2f, 0x2a, 0x99, 0x12, 0xe4, 0x0b, 0x3e, 0x4d, 0x49, 0xa2, 0xec, 0x59, 0x8c, 0xd1, 0x6b, 0xb0,
+	0x44, 0x83, 0xc8, 0x23, 0x03, 0x4a, 0xbc, 0x38, 0xf2, 0xa9, 0xb0, 0xe8, 0xba, 0xdb, 0x15, 0xc8,
+	0xbe, 0xc4, 0xa1, 0x7f, 0x03, 0x60, 0xc1, 0x90, 0x50, 0x86, 0x87, 0x09, 0xb5, 0x41, 0x2c, 0xc7,
+	0xc0, 0xf0, 0xfd, 0xc5, 0x19, 0x4b, 0x32, 0x66, 0x77, 0x84, 0x68, 0x05, 0x39, 0x0e, 0x74, 0xa5,
+	0xc2, 0xd4, 0x79, 0x09, 0xed, 0xbf, 0x60, 0x85, 0xf6, 0x5f, 0x30, 0xe7, 0x55, 0xe8, 0x7c, 0x1a,
+	0x3d, 0x8e, 0x67, 0x28, 0xd5, 0xf9, 0xf5, 0x32, 0x74, 0x25, 0x8d, 0x29, 0xa7, 0x72, 0x8a, 0xef,
+	0x41, 0x1b, 0xfb, 0x7e, 0x4a, 0x28, 0x15, 0xda, 0xaf, 0xe7, 0x1e, 0xc6, 0xe4, 0xdc, 0xdd, 0x97,
+	0x24, 0x6e, 0x41, 0x8b, 0x6e, 0x40, 0x8b, 0x44, 0xa3, 0xc1, 0x08, 0xa7, 0xf2, 0xb8, 0x3b, 0xd7,
+	0xed, 0x71, 0xbe, 0xc3, 0x68, 0xf4, 0x08, 0xa7, 0xee, 0x22, 0x11, 0xff, 0x29, 0xda, 0x83, 0x26,
+	0x65, 0x98, 0x65, 0xda, 0x99, 0x4d, 0x60, 0xe9, 0x8b, 0x79, 0x57, 0xd1, 0xa1, 0x0f, 0xc6, 0x7d,
+	0xd9, 0xa5, 0x09, 0xeb, 0x9b, 0xe4, 0xca, 0xf6, 0x72, 0xcf, 0xd9, 0x9c, 0xf6, 0xb1, 0x8a, 0xe3,
+	0x34, 0xbd, 0xd7, 0xe2, 0xb8, 0xf7, 0x1a, 0xc5, 0x61, 0x36, 0x24, 0xd4, 0x6e, 0x09, 0xeb, 0xd6,
+	0x20, 0xda, 0x86, 0xce, 0x10, 0x73, 0x0f, 0x18, 0xe1, 0xc8, 0x23, 0xc2, 0x12, 0x5a, 0xae, 0x89,
+	0xe2, 0x57, 0x92, 0x85, 0xd2, 0x02, 0xda, 0x2e, 0x1f, 0x0a, 0xfb, 0x11, 0x3e, 0x60, 0x90, 0x72,
+	0x4f, 0x42, 0xed, 0x8e, 0x90, 0xd9, 0x95, 0x48, 0xe1, 0x5d, 0x28, 0xfa, 0x0f, 0x58, 0x12, 0x3b,
+	0x19, 0x3c, 0x0f, 0x22, 0x3f, 0x7e, 0x4e, 0xed, 0xae, 0xd0, 0xf3, 0xaa, 0xd8, 0x47, 0x9f, 0xcf,
+	0x7c, 0x2e, 0x26, 0xdc, 0x2e, 0x2d, 0x00, 0x21, 0x7b, 0x18, 0x44, 0x03, 0x3c, 0xc2, 0x41, 0x88,
+	0x4f, 0x42, 0x62, 0x2f, 0x89, 0xef, 0x76, 0x87, 0x41, 0xb4, 0xaf, 0x71, 0x5c, 0xb6, 0x5c, 0xff,
+	0xc0, 0x0b, 0x71, 0x30, 0xa4, 0xf6, 0xb2, 0x21, 0xfb, 0x91, 0x98, 0x39, 0xe0, 0x13, 0x6e, 0x77,
+	0x54, 0x00, 0x14, 0x5d, 0x87, 0xae, 0x17, 0x47, 0x8f, 0x83, 0xd3, 0xc1, 0xe3, 0x20, 0x24, 0xd4,
+	0x5e, 0x11, 0x5c, 0x2b, 0xd2, 0xa7, 0x8a, 0x89, 0x7b, 0x41, 0x48, 0xdc, 0x8e, 0x97, 0x8f, 0x39,
+	0xcf, 0x86, 0x9f, 0xe2, 0x20, 0x1a, 0x70, 0xd3, 0x8f, 0x33, 0x96, 0xdf, 0x99, 0x55, 0xe1, 0xa2,
+	0xd6, 0xc4, 0xe4, 0xb1, 0x9c, 0xd3, 0x57, 0xe7, 0x63, 0xd8, 0x66, 0x24, 0x1d, 0x06, 0x91, 0xf0,
+	0x72, 0x83, 0xd3, 0x14, 0x7b, 0x64, 0x90, 0x90, 0x34, 0x88, 0xfd, 0x9c, 0xfd, 0x82, 0x60, 0x7f,
+	0xd9, 0xa0, 0xfb, 0x98, 0x93, 0x1d, 0x09, 0x2a, 0x2d, 0xe8, 0x12, 0xb4, 0x87, 0xf8, 0xc5, 0x80,
+	0x66, 0xe9, 0x29, 0xb1, 0x91, 0x3c, 0xd3, 0x21, 0x7e, 0xd1, 0xe7, 0x30, 0xba, 0x0c, 0x2b, 0x7c,
+	0x32, 0x8b, 0x0a, 0x5d, 0xad, 0x09, 0x92, 0xe5, 0x21, 0x7e, 0xf1, 0xb0, 0xc0, 0xa2, 0xab, 0xd0,
+	0xf4, 0x49, 0x12, 0xc6, 0x67, 0xf6, 0xba, 0x30, 0xa5, 0x35, 0xb1, 0xe1, 0xbb, 0x02, 0xf5, 0x80,
+	0x30, 0xec, 0x63, 0x86, 0x5d, 0x45, 0xc2, 0x2d, 0xe5, 0x24, 0x0b, 0x42, 0x9f, 0xa4, 0xf6, 0x86,
+	0xf4, 0x24, 0x0a, 0x44, 0xaf, 0xc3, 0xb2, 0x18, 0x0e, 0xf2, 0x9b, 0xb3, 0x29, 0x8f, 0x5d, 0x60,
+	0x0f, 0xd5, 0x25, 0x79, 0x05, 0x3a, 0x61, 0xec, 0x3d, 0x1d, 0xa4, 0x04, 0xd3, 0x38, 0xb2, 0x2f,
+	0x0a, 0x19, 0xc0, 0x51, 0xae, 0xc0, 0xf0, 0x3d, 0x71, 0x88, 0xf8, 0x83, 0x93, 0x33, 0xdb, 0x96,
+	0x7b, 0x92, 0x88, 0x3b, 0x67, 0xe8, 0x5d, 0xb8, 0x98, 0x72, 0x37, 0x99, 0xb1, 0x31, 0x7d, 0x6f,
+	0x09, 0x85, 0x6d, 0xa8, 0xe9, 0x8a, 0xc6, 0x8d, 0xe8, 0xdc, 0x2b, 0x47, 0xe7, 0x37, 0x60, 0x51,
+	0xdd, 0x7f, 0x7e, 0x41, 0x78, 0x56, 0x60, 0xb8, 0x9a, 0x1c, 0xee, 0xed, 0x41, 0x53, 0xee, 0x80,
+	0x9b, 0xfb, 0x53, 0xa2, 0x23, 0x21, 0x1f, 0x72, 0xff, 0x3e, 0xc2, 0x61, 0xa6, 0x83, 0x85, 0x04,
+	0x7a, 0xbf, 0xb3, 0xa0, 0x29, 0xaf, 0x3b, 0x67, 0xf1, 0x92, 0x4c, 0x45, 0x3a, 0x3e, 0x44, 0x7b,
+	0xb0, 0x90, 0xc4, 0xbe, 0xf6, 0x2d, 0x2f, 0x4d, 0x73, 0x14, 0xbb, 0x47, 0xb1, 0xef, 0x0a, 0xca,
+	0x1e, 0x85, 0xfa, 0x51, 0xec, 0x4f, 0x8b, 0x2f, 0x94, 0x61, 0x96, 0x7f, 0x5f, 0x00, 0xfc, 0xa3,
+	0xf8, 0x54, 0x26, 0x5d, 0x75, 0x97, 0x0f, 0x55, 0xb0, 0x66, 0x38, 0x55, 0xe9, 0x56, 0xc3, 0xcd,
+	0x61, 0x2e, 0x23, 0x25, 0xd8, 0x3f, 0x53, 0x71, 0x45, 0x02, 0xbd, 0xdf, 0x5b, 0xff, 0x90, 0x18,
+	0x8e, 0x76, 0x61, 0x71, 0x48, 0x58, 0x1a, 0x78, 0x7c, 0x61, 0x5c, 0x23, 0xeb, 0x42, 0x23, 0xf9,
+	0xa7, 0x1f, 0x88, 0x49, 0x57, 0x13, 0xa1, 0x5b, 0xb0, 0x35, 0x24, 0xc3, 0x38, 0x3d, 0x9b, 0xb4,
+	0x98, 0x86, 0x90, 0x7b, 0x51, 0x12, 0x8c, 0xad, 0xa7, 0xf7, 0xc7, 0x22, 0x1d, 0x3b, 0xac, 0xa6,
+	0x63, 0x57, 0xa7, 0x39, 0xd1, 0x99, 0xd9, 0xd8, 0xf1, 0xb4, 0x6c, 0xec, 0x3b, 0x89, 0xfb, 0xbb,
+	0x26, 0x63, 0xce, 0x8f, 0x2d, 0x58, 0xea, 0x13, 0x76, 0x18, 0x8d, 0x66, 0x65, 0x2a, 0x37, 0x8d,
+	0xb0, 0x67, 0x86, 0xcb, 0x12, 0x67, 0x35, 0xee, 0x7d, 0xf7, 0xbb, 0xe1, 0x7c, 0x04, 0x2b, 0x0f,
+	0x23, 0x3a, 0x77, 0x39, 0x5b, 0x95, 0xe5, 0xb4, 0xf3, 0x6f, 0x3a, 0x7f, 0xb6, 0x60, 0xb5, 0x4f,
+	0xf8, 0xfd, 0x4e, 0x09, 0x9b, 0x25, 0xe3, 0x16, 0x74, 0xa8, 0x20, 0xe2, 0x6e, 0xe9, 0x1c, 0xbb,
+	0x02, 0x49, 0x7d, 0x18, 0x8d, 0x28, 0xda, 0xcf, 0x79, 0x79, 0x3c, 0x10, 0x06, 0xdb, 0xb9, 0xbe,
+	0xad, 0x79, 0x4b, 0xdf, 0xde, 0x95, 0x90, 0x88, 0x0f, 0x40, 0xf3, 0x71, 0xef, 0x73, 0x80, 0x62,
+	0x66, 0x82, 0x7e, 0xb8, 0x63, 0x8a, 0x79, 0x28, 0x65, 0x42, 0x43, 0x5d, 0x57, 0x83, 0xe8, 0x65,
+	0x80, 0x61, 0x9c, 0x45, 0x6c, 0x90, 0x60, 0xf6, 0x44, 0xd5, 0x4e, 0x6d, 0x81, 0x39, 0xc2, 0xec,
+	0x89, 0xf3, 0x6d, 0x0d, 0xd6, 0xfa, 0x84, 0x15, 0xb9, 0xc1, 0x0c, 0x1d, 0x7c, 0x64, 0xa6, 0x19,
+	0x35, 0xb1, 0x0b, 0x47, 0xef, 0xa2, 0x2a, 0x60, 0x72, 0xb6, 0x71, 0x19, 0x56, 0x52, 0x92, 0x84,
+	0x3c, 0x4e, 0xe9, 0x8b, 0x2a, 0xb3, 0xd6, 0x65, 0x85, 0x96, 0x37, 0x94, 0xfe, 0x2b, 0x7a, 0x0c,
+	0xe7, 0x2e, 0xa0, 0x3e, 0x3f, 0xe8, 0x24, 0x0c, 0x3c, 0x3c, 0x33, 0xd3, 0x17, 0x37, 0x50, 0x92,
+	0xa9, 0xe5, 0xe7, 0xb0, 0xf3, 0x1a, 0x2c, 0xdd, 0x25, 0x21, 0x99, 0x59, 0x46, 0x3b, 0xf7, 0xe0,
+	0x82, 0x24, 0x3a, 0x8a, 0xfd, 0x99, 0x5f, 0x7a, 0x19, 0x80, 0x87, 0x05, 0x51, 0x26, 0xe8, 0xcb,
+	0xd1, 0xe6, 0x18, 0x5e, 0x28, 0x50, 0xe7, 0x7f, 0xe0, 0xc2, 0xc1, 0x13, 0xee, 0x38, 0x8e, 0x09,
+	0x1e, 0x6a, 0x39, 0x5b, 0xd0, 0xc2, 0x49, 0x32, 0x30, 0x64, 0x2d, 0xe2, 0x24, 0xe1, 0x0c, 0x3c,
+	0xe8, 0x32, 0x82, 0x87, 0x03, 0xa3, 0xe6, 0x69, 0x71, 0x04, 0x9f, 0x74, 0x0e, 0xc5, 0x55, 0x7b,
+	0xc4, 0xcb, 0x63, 0x7a, 0x0e, 0x59, 0x9b, 0xd0, 0x1c, 0xf1, 0xb8, 0xa9, 0x97, 0xa5, 0x20, 0xe7,
+	0x10, 0x96, 0x5c, 0xc2, 0x19, 0x0c, 0x19, 0x71, 0xe8, 0x97, 0x64, 0xc4, 0xa1, 0xac, 0x74, 0xb6,
+	0xa0, 0x15, 0x91, 0xe7, 0xe6, 0x72, 0x16, 0x23, 0xf2, 0x5c, 0xac, 0xe6, 0x14, 0xba, 0x07, 0x61,
+	0x1c, 0x99, 0x52, 0x68, 0xea, 0x95, 0xa4, 0xd0, 0xd4, 0xd3, 0x52, 0x7c, 0xca, 0x4a, 0x52, 0x7c,
+	0xca, 0xc4, 0x54, 0xb5, 0x13, 0x50, 0x1f, 0xeb, 0x04, 0x38, 0x3f, 0xb7, 0xa0, 0xdb, 0x9f, 0x77,
+	0xb5, 0x6e, 0x97, 0x4e, 0x9c, 0x1b, 0xe2, 0x2b, 0x45, 0x02, 0xab, 0xaf, 0x94, 0x36, 0x9d, 0xc3,
+	0x88, 0xa5, 0x67, 0x85, 0x49, 0xf4, 0x6e, 0x73, 0x8d, 0x18, 0x53, 0xf3, 0xfc, 0x67, 0x43, 0xf9,
+	0xcf, 0x5b, 0xb5, 0xf7, 0x2d, 0x5e, 0x47, 0x1d, 0xe1, 0x8c, 0xce, 0x34, 0xa7, 0xd7, 0xf8, 0x07,
+	0x68, 0x36, 0x9c, 0x49, 0xf4, 0x3a, 0x2c, 0xbb, 0x32, 0x0d, 0x98, 0x23, 0x4a, 0x15, 0x2f, 0x33,
+	0x88, 0xfe, 0x62, 0xc1, 0xb2, 0xa6, 0x52, 0x65, 0xd9, 0x55, 0x95, 0xe9, 0xc8, 0x00, 0x7b, 0x51,
+	0x2a, 0xa7, 0x44, 0x62, 0x24, 0x39, 0xbf, 0xb5, 0xfe, 0x09, 0x59, 0x8e, 0xf8, 0x5a, 0xec, 0x13,
+	0x55, 0x35, 0x8b, 0x31, 0x4f, 0x34, 0x43, 0x4c, 0xd9, 0xc0, 0xcc, 0xd3, 0x55, 0xca, 0x2a, 0x6b,
+	0xa7, 0x0d, 0x3e, 0x7d, 0x5c, 0xcc, 0xca, 0xec, 0xd5, 0xb9, 0x0d, 0x4b, 0x87, 0x23, 0x12, 0xb1,
+	0x99, 0x97, 0xb7, 0x28, 0xfd, 0x6b, 0x66, 0xe9, 0xef, 0xfc, 0xd2, 0x82, 0x65, 0xcd, 0x6d, 0x54,
+	0xb5, 0x67, 0x49, 0xce, 0xce, 0xc7, 0x9c, 0x5d, 0x2d, 0x45, 0xaa, 0x42, 0x41, 0x1c, 0x1f, 0x9f,
+	0x7c, 0x41, 0x3c, 0x6d, 0xcd, 0x0a, 0xe2, 0x31, 0x66, 0x48, 0x28, 0xe5, 0x7a, 0x52, 0x0d, 0x05,
+	0x05, 0x72, 0x7d, 0x78, 0x3c, 0xa2, 0x28, 0x0f, 0x28, 0x01, 0x91, 0x81, 0xf3, 0xbd, 0x53, 0x42,
+	0x22, 0xa1, 0x94, 0xba, 0xdb, 0xe2, 0x88, 0x3e, 0x21, 0x91, 0xb3, 0x0d, 0x70, 0x1c, 0x27, 0xb3,
+	0x8c, 0xe0, 0x2b, 0xe8, 0x08, 0x0a, 0xb5, 0x83, 0x9d, 0x92, 0x01, 0x48, 0x37, 0x6d, 0xcc, 0x1b,
+	0xa7, 0x7f, 0x30, 0xfd, 0xf0, 0x55, 0x06, 0x2d, 0x1b, 0x28, 0x7c, 0xc8, 0x37, 0x2b, 0xfd, 0xb5,
+	0x3a, 0x7b, 0x05, 0x39, 0x3f, 0xb3, 0x44, 0x5c, 0x74, 0x55, 0xe2, 0x33, 0xf3, 0x1c, 0x0c, 0xa9,
+	0xed, 0x49, 0x52, 0xdb, 0x5a, 0x2a, 0xaf, 0x5a, 0x78, 0x20, 0xd3, 0xe9, 0x9d, 0x54, 0x23, 0x78,
+	0x49, 0xa6, 0xc5, 0xbf, 0x01, 0xcb, 0x2a, 0xbe, 0x68, 0x9a, 0x86, 0xa0, 0x59, 0x92, 0x58, 0x45,
+	0xe6, 0x5c, 0x86, 0x0b, 0x87, 0xd1, 0xe8, 0x93, 0x80, 0xb2, 0x02, 0x39, 0x51, 0x89, 0xdf, 0x5a,
+	0x80, 0x4c, 0x4a, 0xa5, 0xcc, 0xff, 0x82, 0x36, 0x6f, 0xf8, 0xd0, 0x20, 0x8e, 0xb4, 0x46, 0x65,
+	0x3e, 0x32, 0x4e, 0xbb, 0xeb, 0x2a, 0x42, 0xb7, 0x60, 0xe9, 0xfd, 0xc4, 0x82, 0x96, 0xc6, 0x8b,
+	0xa2, 0x9f, 0xa4, 0xb4, 0x08, 0xc7, 0x1a, 0xe4, 0x6a, 0xc0, 0x19, 0x7b, 0x12, 0xa7, 0xda, 0xc2,
+	0x24, 0xc4, 0xa3, 0x8e, 0x27, 0x7a, 0x8b, 0xfe, 0x00, 0x33, 0xa5, 0xf8, 0xb6, 0xc2, 0xec, 0xb3,
+	0x52, 0xfa, 0xb8, 0x70, 0xde, 0xf4, 0xd1, 0xb9, 0x23, 0x76, 0xea, 0xc6, 0x61, 0x78, 0x82, 0xbd,
+	0xa7, 0x8a, 0x6a, 0xe2, 0x79, 0x19, 0x0b, 0xae, 0x95, 0x16, 0xec, 0x1c, 0xc2, 0x46, 0x9f, 0xb0,
+	0x07, 0x45, 0x57, 0x62, 0x8e, 0x18, 0x12, 0xf1, 0xca, 0xd7, 0x57, 0xf7, 0x4f, 0x83, 0xce, 0x87,
+	0xd0, 0x15, 0x61, 0xee, 0x1c, 0x51, 0x8e, 0x3b, 0x66, 0x11, 0x39, 0x74, 0x62, 0xcb, 0x01, 0xe7,
+	0x4d, 0x58, 0x3d, 0x14, 0xb2, 0x8e, 0xef, 0xf7, 0x67, 0x1d, 0xef, 0xd7, 0x16, 0xac, 0x3f, 0x4c,
+	0x7c, 0xcc, 0xc8, 0xa7, 0xd1, 0xa9, 0x68, 0x3e, 0xcd, 0x4c, 0x61, 0x17, 0xe3, 0x84, 0x89, 0x23,
+	0xaf, 0x19, 0x47, 0x3e, 0x89, 0x7f, 0xf7, 0x7f, 0x05, 0xa1, 0xab, 0x19, 0x78, 0x6e, 0x2e, 0x51,
+	0xe7, 0xce, 0xcd, 0x3f, 0x10, 0x85, 0xc2, 0xfe, 0xc1, 0xfd, 0x39, 0x2d, 0x4d, 0xac, 0x1c, 0x18,
+	0x0f, 0xf1, 0x12, 0x70, 0x3e, 0x87, 0x95, 0x4a, 0x02, 0x36, 0x91, 0x79, 0x0f, 0xd6, 0x55, 0x12,
+	0x86, 0x47, 0x24, 0xc5, 0xa7, 0x64, 0x60, 0x2e, 0x03, 0xc9, 0xb9, 0x7d, 0x39, 0xf5, 0x48, 0xac,
+	0x69, 0x08, 0x1d, 0xa3, 0x23, 0xa4, 0x42, 0x41, 0xaa, 0x7b, 0x86, 0x12, 0xe0, 0x1b, 0x24, 0x91,
+	0xaf, 0x6f, 0x33, 0x89, 0x84, 0x27, 0xf1, 0xf1, 0x59, 0xde, 0xb0, 0xe5, 0x63, 0x9d, 0x4a, 0x2e,
+	0x14, 0xa9, 0xa4, 0x4a, 0x37, 0x1b, 0x79, 0xba, 0xe9, 0xfc, 0x00, 0x2e, 0x99, 0x99, 0x71, 0xdf,
+	0x7b, 0x42, 0xfc, 0x6c, 0x76, 0x1e, 0xf0, 0x16, 0x2c, 0xea, 0x3e, 0x56, 0x6d, 0x4a, 0x1f, 0x4b,
+	0x13, 0x38, 0x9f, 0x08, 0x0d, 0x1f, 0xdd, 0xbd, 0x33, 0x4b, 0xe0, 0x58, 0x9f, 0xab, 0x36, 0xde,
+	0xe7, 0x72, 0x7e, 0x6a, 0x41, 0xc7, 0x68, 0x67, 0x4d, 0x7b, 0x99, 0xa1, 0xc1, 0x97, 0x9a, 0x5f,
+	0x8c, 0xb9, 0x70, 0xee, 0x2b, 0xb8, 0xea, 0xbd, 0x10, 0x53, 0xaa, 0xbc, 0x5d, 0x57, 0x21, 0x0f,
+	0x38, 0x8e, 0xfb, 0x3c, 0xec, 0x89, 0xd7, 0x9b, 0x21, 0x8f, 0x8e, 0xca, 0xe7, 0x49, 0xd4, 0x03,
+	0x1e, 0x23, 0xcb, 0x15, 0x4a, 0xa3, 0x5a, 0xa1, 0x1c, 0xc1, 0xea, 0xbe, 0xef, 0xcb, 0xe5, 0xcd,
+	0xda, 0xe9, 0x0e, 0x34, 0x65, 0x17, 0x4e, 0x95, 0x26, 0xe3, 0x5d, 0x3a, 0x35, 0xef, 0xfc, 0x37,
+	0xac, 0xb9, 0x64, 0x18, 0x8f, 0xc8, 0x7c, 0xa1, 0xaf, 0x40, 0x47, 0x32, 0x99, 0xd9, 0x1f, 0x48,
+	0x94, 0x48, 0x23, 0x3f, 0x04, 0x28, 0x5a, 0x7a, 0xd3, 0x52, 0x6c, 0x63, 0x7b, 0xb5, 0xea, 0xf6,
+	0x7e, 0x68, 0xc1, 0x7a, 0x9f, 0xb0, 0x42, 0xc8, 0xac, 0xe5, 0x5c, 0x82, 0x36, 0x2f, 0x21, 0x4b,
+	0xf9, 0x35, 0x47, 0x7c, 0xa6, 0xfc, 0x91, 0xae, 0x01, 0xeb, 0xb3, 0x6a, 0xc0, 0x85, 0xea, 0x12,
+	0x3e, 0x85, 0x4d, 0x51, 0x46, 0x7f, 0xff, 0x35, 0x38, 0xbf, 0xb2, 0x60, 0x53, 0x3a, 0x94, 0xfb,
+	0xc1, 0x63, 0xe2, 0x9d, 0x79, 0xb3, 0x65, 0x4d, 0xed, 0x7a, 0xd6, 0xbe, 0x5f, 0xd7, 0xb3, 0x7e,
+	0x8e, 0xae, 0xa7, 0xf3, 0x0c, 0x36, 0xe4, 0x52, 0xfb, 0x2c, 0xc5, 0x8c, 0x9c, 0x9e, 0xcd, 0xd9,
+	0x75, 0xd1, 0x22, 0xad, 0xcd, 0x6f, 0x91, 0xd6, 0x27, 0xb5, 0x48, 0x9d, 0x7d, 0xb8, 0xd0, 0x27,
+	0xec, 0x8e, 0xec, 0x74, 0xce, 0x89, 0x2d, 0xba, 0x3d, 0x5a, 0x2b, 0xb5, 0x47, 0x9d, 0x14, 0x96,
+	0xcb, 0x2d, 0x55, 0x23, 0xca, 0x5a, 0xa5, 0x28, 0xbb, 0x09, 0x4d, 0x2f, 0x1e, 0x0e, 0x03, 0x1d,
+	0x5b, 0x14, 0xc4, 0xf1, 0x27, 0x29, 0x8e, 0x3c, 0xdd, 0x0d, 0x50, 0xd0, 0xf4, 0xfc, 0xce, 0x59,
+	0x84, 0xc6, 0xe1, 0x30, 0x61, 0x67, 0xce, 0x07, 0xfc, 0x95, 0x6a, 0x76, 0x70, 0x9d, 0x92, 0x55,
+	0xf2, 0xc4, 0xff, 0x61, 0x14, 0xce, 0x66, 0x76, 0xfe, 0x1f, 0x2e, 0xc9, 0x23, 0x71, 0x4b, 0xed,
+	0xd7, 0x59, 0xdf, 0xbb, 0x0c, 0x2b, 0x93, 0x8d, 0x67, 0x99, 0x95, 0xec, 0x86, 0x3f, 0x06, 0x1d,
+	0xa4, 0x71, 0x34, 0x43, 0x96, 0xf3, 0x8d, 0x05, 0xab, 0x9c, 0xa6, 0xf4, 0x10, 0xb8, 0x07, 0x0d,
+	0x2f, 0x2d, 0xf2, 0xa4, 0x9e, 0x7a, 0x1a, 0x2d, 0x53, 0x09, 0x84, 0x2b, 0x09, 0x79, 0x76, 0xb4,
+	0xc0, 0xe1, 0x69, 0xb5, 0x3d, 0x55, 0x81, 0x40, 0xdb, 0x91, 0x86, 0xf9, 0xe3, 0x1b, 0xcd, 0x68,
+	0x42, 0x22, 0x9f, 0xf8, 0xaa, 0x31, 0x52, 0x20, 0xb8, 0xb7, 0x95, 0xf9, 0xb4, 0x66, 0x5f, 0x90,
+	0xcf, 0x69, 0x1c, 0xa9, 0x63, 0x8b, 0x30, 0x06, 0x8f, 0x05, 0x23, 0xa2, 0x02, 0x91, 0x82, 0x9c,
+	0x6b, 0x80, 0xc4, 0x12, 0xb3, 0xe8, 0xb3, 0xf8, 0x79, 0xbe, 0xb7, 0x2d, 0x68, 0x7d, 0x11, 0x9f,
+	0x94, 0x12, 0x93, 0x2f, 0xe2, 0x13, 0xe5, 0xd8, 0x56, 0x14, 0x03, 0x9d, 0x63, 0xa8, 0xfa, 0x45,
+	0xb0, 0x56, 0x7a, 0x11, 0x74, 0xfe, 0xa0, 0x94, 0x29, 0x25, 0xa8, 0x0f, 0xfe, 0x3b, 0x2c, 0xa4,
+	0x59, 0xae, 0xcb, 0xad, 0x5c, 0x97, 0x26, 0xd1, 0xae, 0x9b, 0x45, 0xae, 0x20, 0xeb, 0x7d, 0x6d,
+	0x41, 0xdd, 0xcd, 0xa2, 0x69, 0x86, 0xa6, 0x9e, 0xc9, 0x94, 0xa1, 0x49, 0x88, 0x3b, 0x3b, 0x11,
+	0xc8, 0x85, 0x4f, 0xd1, 0xc9, 0xa5, 0xc0, 0x70, 0x6b, 0x42, 0x57, 0x60, 0xd5, 0xcf, 0x52, 0xe9,
+	0x3b, 0xb4, 0xc1, 0x48, 0x45, 0xae, 0x68, 0xbc, 0xf1, 0x2c, 0x42, 0x5e, 0x04, 0x6c, 0xe0, 0xc5,
+	0xbe, 0x56, 0x67, 0x8b, 0x23, 0x0e, 0x62, 0x9f, 0x38, 0x3f, 0xb2, 0x72, 0x8d, 0xce, 0x7b, 0xb8,
+	0x9d, 0xaa, 0x23, 0x9e, 0x33, 0xa4, 0x59, 0xa4, 0xee, 0x21, 0x1f, 0x16, 0x8f, 0xbc, 0x0b, 0x93,
+	0x1f, 0x79, 0x1b, 0xa5, 0x4a, 0xef, 0x29, 0xa0, 0xa3, 0x38, 0x65, 0xf7, 0xe2, 0xf4, 0x39, 0x4e,
+	0xfd, 0x39, 0x3d, 0xd0, 0xfc, 0x39, 0xb8, 0x56, 0x7e, 0x0e, 0x46, 0xbc, 0xb2, 0x4a, 0x99, 0x72,
+	0x9a, 0x62, 0x2c, 0x53, 0x1e, 0x86, 0xc5, 0x2a, 0xba, 0xae, 0x18, 0x3b, 0x57, 0x60, 0xad, 0xf4,
+	0xb1, 0xa2, 0xb4, 0x14, 0xa4, 0x56, 0x41, 0x7a, 0xfd, 0x9b, 0x75, 0xf9, 0x24, 0xbe, 0x03, 0x4d,
+	0xf9, 0x23, 0x02, 0x84, 0xc6, 0x7f, 0x51, 0xd0, 0x03, 0x81, 0x13, 0x9e, 0x85, 0x1b, 0x06, 0x57,
+	0x23, 0x92, 0x51, 0xdb, 0xd0, 0x68, 0xef, 0x82, 0x81, 0x91, 0x9f, 0xdc, 0xb3, 0x78, 0x3b, 0x80,
+	0xf7, 0xc3, 0x15, 0xb9, 0xf1, 0xc8, 0xdb, 0xbb, 0x60, 0x60, 0xf2, 0xd2, 0xb1, 0x29, 0x4b, 0x07,
+	0xb5, 0x8a, 0x52, 0x1d, 0x51, 0x5a, 0xc5, 0xdb, 0xd0, 0xd2, 0x0d, 0x65, 0x24, 0x4b, 0xcc, 0x4a,
+	0x7f, 0xb9, 0x44, 0xfd, 0x06, 0x2c, 0x70, 0x1f, 0x80, 0x0c, 0x9c, 0x5e, 0xad, 0xe9, 0x40, 0x6e,
+	0x42, 0xd7, 0x4c, 0x03, 0x91, 0x3d, 0xad, 0x67, 0x5a, 0x12, 0xbe, 0x03, 0x4d, 0xd9, 0xc2, 0x53,
+	0x8b, 0x2e, 0x35, 0xfd, 0x4a, 0x94, 0xd7, 0xa1, 0x63, 0xf4, 0x15, 0xd1, 0x45, 0x2d, 0xbe, 0xd2,
+	0x69, 0x2c, 0xf1, 0xec, 0x01, 0x14, 0x0d, 0x42, 0xb4, 0x69, 0x7c, 0xc1, 0xe8, 0x18, 0x96, 0x38,
+	0x76, 0xa1, 0x9d, 0x37, 0xab, 0xd1, 0xc6, 0xc4, 0xe6, 0x75, 0x89, 0xfe, 0x1a, 0x74, 0x84, 0xee,
+	0x14, 0xc7, 0x7c, 0x6d, 0xee, 0x01, 0x14, 0xbd, 0x46, 0xb5, 0xa4, 0xb1, 0xe6, 0xe3, 0x84, 0x25,
+	0xc9, 0x86, 0x62, 0xb1, 0xa4, 0x52, 0x83, 0xb1, 0xaa, 0x52, 0xd9, 0x39, 0x54, 0x2a, 0x2d, 0xb5,
+	0x11, 0x4b, 0x94, 0x6f, 0x42, 0x43, 0x34, 0x07, 0x91, 0x3c, 0x4e, 0xb3, 0x51, 0x58, 0xa5, 0x13,
+	0xa9, 0xb9, 0xa2, 0xeb, 0x4f, 0x3b, 0xcc, 0x37, 0xa1, 0x21, 0x9a, 0x6c, 0x8a, 0xce, 0x6c, 0xb8,
+	0x8d, 0xaf, 0x90, 0x66, 0xc6, 0x0a, 0x8d, 0xae, 0x5b, 0x89, 0xf2, 0x2d, 0x58, 0x54, 0xdd, 0x36,
+	0xb4, 0xa6, 0x49, 0x8d, 0xde, 0x5b, 0x89, 0xf6, 0x9d, 0xfc, 0x05, 0x11, 0x95, 0xfa, 0x66, 0x92,
+	0x72, 0x6d, 0x42, 0x2f, 0x0d, 0xdd, 0x80, 0xa6, 0xec, 0x20, 0x29, 0x96, 0x52, 0x33, 0xaa, 0xb7,
+	0x56, 0xc2, 0xe5, 0x97, 0x72, 0x07, 0xea, 0xc7, 0x71, 0x82, 0x56, 0x8a, 0xde, 0x8c, 0x24, 0x5f,
+	0xad, 0x36, 0x6b, 0xd4, 0x95, 0xc8, 0x9b, 0x2b, 0xc5, 0x95, 0xa8, 0xf6, 0x5b, 0x4a, 0xfb, 0xf8,
+	0x4f, 0x80, 0xa2, 0x3f, 0xa1, 0x2c, 0x64, 0xac, 0x0d, 0xd2, 0xbb, 0x38, 0xa5, 0x91, 0xc1, 0xef,
+	0x89, 0xd1, 0x20, 0x40, 0x39, 0x5d, 0xa5, 0x65, 0x50, 0xfa, 0xe4, 0xfb, 0xb0, 0x5c, 0x6e, 0x08,
+	0xa0, 0x9e, 0x5e, 0xea, 0x78, 0x97, 0xa0, 0xc4, 0x79, 0x05, 0x5a, 0xbc, 0x6c, 0x11, 0x3f, 0x06,
+	0x93, 0xa7, 0x6e, 0xb6, 0x04, 0x2a, 0x5e, 0xa7, 0xa3, 0xea, 0x91, 0xf3, 0x50, 0xef, 0x42, 0x3b,
+	0xef, 0x0d, 0x28, 0xab, 0xaf, 0xf6, 0x0a, 0x4a, 0xf4, 0xef, 0xc2, 0x52, 0xa9, 0xc4, 0x47, 0x5b,
+	0x53, 0xcb, 0xfe, 0xaa, 0x2d, 0xca, 0x02, 0xbe, 0xf0, 0x9a, 0x45, 0x35, 0x5f, 0xa2, 0xbc, 0x0b,
+	0xeb, 0xa6, 0x37, 0xcb, 0x73, 0x91, 0xed, 0x31, 0x47, 0x57, 0x29, 0x81, 0x27, 0x7c, 0xef, 0xe8,
+	0xee, 0x9d, 0xe2, 0x7b, 0x45, 0x6d, 0x5b, 0xd5, 0x40, 0x5e, 0x11, 0x2a, 0x0d, 0x54, 0x2b, 0xc4,
+	0x12, 0xfd, 0x4d, 0xe8, 0x9a, 0xf5, 0x9e, 0xb2, 0xb6, 0x09, 0x25, 0x60, 0x55, 0x6f, 0xa5, 0xba,
+	0x0c, 0xe5, 0x4d, 0xa8, 0xb1, 0x3a, 0xa9, 0xc4, 0x77, 0x4b, 0x3d, 0x4a, 0x1a, 0x9c, 0x97, 0x0a,
+	0xe7, 0x37, 0x9f, 0xb7, 0x5c, 0x3d, 0x69, 0xde, 0x89, 0x35, 0x55, 0xd5, 0x54, 0xcb, 0xe5, 0x8c,
+	0x32, 0xd5, 0x89, 0x35, 0x4e, 0xd5, 0xf3, 0x16, 0x55, 0x89, 0xba, 0x57, 0x63, 0x65, 0x4a, 0x25,
+	0x5a, 0x77, 0x34, 0xc1, 0x79, 0xc2, 0xea, 0x3b, 0x3c, 0xf7, 0xa7, 0x06, 0xc3, 0xfc, 0x68, 0xf0,
+	0x3a, 0xcf, 0x07, 0xbc, 0xa7, 0x79, 0x3e, 0x30, 0xf9, 0x7a, 0xee, 0x40, 0x53, 0x16, 0x15, 0x6a,
+	0x09, 0xa5, 0x0a, 0xa3, 0x6a, 0xa3, 0x93, 0x2a, 0x0b, 0x64, 0xf6, 0xc0, 0x26, 0x16, 0x1d, 0x25,
+	0x29, 0x37, 0xa0, 0xa5, 0x33, 0x7f, 0xb5, 0x32, 0xa3, 0xa4, 0xe8, 0x6d, 0x4c, 0x2c, 0x0d, 0xd0,
+	0x55, 0x59, 0x78, 0xf4, 0x65, 0x22, 0x3f, 0x81, 0xaf, 0xec, 0xd7, 0x41, 0x4e, 0x89, 0x28, 0x30,
+	0x9b, 0xf6, 0x3d, 0x80, 0x22, 0xa7, 0x9f, 0x40, 0x7b, 0xd1, 0x4c, 0xaf, 0xcd, 0xb4, 0xff, 0x3d,
+	0x68, 0xe9, 0xa4, 0x5b, 0x1d, 0x45, 0x25, 0xd5, 0xef, 0x6d, 0x54, 0xb0, 0x8a, 0xf1, 0x36, 0x74,
+	0x8c, 0x9c, 0x17, 0x95, 0x3e, 0x30, 0x37, 0x67, 0xbb, 0x0b, 0x1d, 0x23, 0x7f, 0x54, 0xcc, 0xe3,
+	0xe9, 0x6b, 0xcf, 0x1e, 0x9f, 0x90, 0x32, 0x76, 0xac, 0x3d, 0xeb, 0xa4, 0x29, 0x7e, 0x6c, 0x76,
+	0xe3, 0xaf, 0x03, 0x00, 0x5a, 0xb3, 0x95, 0x6f, 0x71, 0x2c, 0x00, 0x00,
 }
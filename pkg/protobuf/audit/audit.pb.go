@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go.
+// source: pkg/protobuf/audit/audit.proto
+// DO NOT EDIT!
+
+/*
+Package audit is a generated protocol buffer package.
+
+It is generated from these files:
+	pkg/protobuf/audit/audit.proto
+
+It has these top-level messages:
+	ListRequest
+	ListResponse
+*/
+package audit
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type ListRequest struct {
+	Team  string `protobuf:"bytes,1,opt,name=team" json:"team,omitempty"`
+	User  string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Since int64  `protobuf:"varint,3,opt,name=since" json:"since,omitempty"`
+	Until int64  `protobuf:"varint,4,opt,name=until" json:"until,omitempty"`
+	Limit int32  `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ListRequest) Reset()                    { *m = ListRequest{} }
+func (m *ListRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()               {}
+func (*ListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *ListRequest) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *ListRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListRequest) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *ListRequest) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *ListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ListResponse struct {
+	Entries []*ListResponse_Entry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *ListResponse) Reset()                    { *m = ListResponse{} }
+func (m *ListResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()               {}
+func (*ListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *ListResponse) GetEntries() []*ListResponse_Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type ListResponse_Entry struct {
+	CreatedAt    int64  `protobuf:"varint,1,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	User         string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Method       string `protobuf:"bytes,3,opt,name=method" json:"method,omitempty"`
+	Team         string `protobuf:"bytes,4,opt,name=team" json:"team,omitempty"`
+	App          string `protobuf:"bytes,5,opt,name=app" json:"app,omitempty"`
+	Request      string `protobuf:"bytes,6,opt,name=request" json:"request,omitempty"`
+	Status       string `protobuf:"bytes,7,opt,name=status" json:"status,omitempty"`
+	Impersonator string `protobuf:"bytes,8,opt,name=impersonator" json:"impersonator,omitempty"`
+}
+
+func (m *ListResponse_Entry) Reset()                    { *m = ListResponse_Entry{} }
+func (m *ListResponse_Entry) String() string            { return proto.CompactTextString(m) }
+func (*ListResponse_Entry) ProtoMessage()               {}
+func (*ListResponse_Entry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 0} }
+
+func (m *ListResponse_Entry) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *ListResponse_Entry) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetApp() string {
+	if m != nil {
+		return m.App
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetRequest() string {
+	if m != nil {
+		return m.Request
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListResponse_Entry) GetImpersonator() string {
+	if m != nil {
+		return m.Impersonator
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ListRequest)(nil), "audit.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "audit.ListResponse")
+	proto.RegisterType((*ListResponse_Entry)(nil), "audit.ListResponse.Entry")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Audit service
+
+type AuditClient interface {
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+}
+
+type auditClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAuditClient(cc *grpc.ClientConn) AuditClient {
+	return &auditClient{cc}
+}
+
+func (c *auditClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := grpc.Invoke(ctx, "/audit.Audit/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Audit service
+
+type AuditServer interface {
+	List(context.Context, *ListRequest) (*ListResponse, error)
+}
+
+func RegisterAuditServer(s *grpc.Server, srv AuditServer) {
+	s.RegisterService(&_Audit_serviceDesc, srv)
+}
+
+func _Audit_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuditServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/audit.Audit/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuditServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Audit_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "audit.Audit",
+	HandlerType: (*AuditServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _Audit_List_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/protobuf/audit/audit.proto",
+}
+
+func init() { proto.RegisterFile("pkg/protobuf/audit/audit.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 305 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0x41, 0x4e, 0xf3, 0x30,
+	0x10, 0x85, 0x95, 0x26, 0x69, 0xff, 0x4c, 0xbb, 0xf8, 0x65, 0x10, 0x32, 0x95, 0x40, 0x51, 0x57,
+	0x59, 0xb5, 0x52, 0xbb, 0x61, 0xdb, 0x05, 0x3b, 0x56, 0xbe, 0x00, 0x72, 0xdb, 0x01, 0x2c, 0x9a,
+	0xc4, 0xd8, 0x63, 0x21, 0xee, 0xc1, 0xb9, 0x38, 0x13, 0xf2, 0xb8, 0x45, 0xad, 0xd4, 0x4d, 0x34,
+	0xef, 0xcb, 0x68, 0x66, 0xde, 0x33, 0xdc, 0xdb, 0xf7, 0xd7, 0x85, 0x75, 0x3d, 0xf5, 0x9b, 0xf0,
+	0xb2, 0xd0, 0x61, 0x67, 0x28, 0x7d, 0xe7, 0x0c, 0x45, 0xc9, 0x62, 0xf6, 0x09, 0xe3, 0x27, 0xe3,
+	0x49, 0xe1, 0x47, 0x40, 0x4f, 0x42, 0x40, 0x41, 0xa8, 0x5b, 0x99, 0xd5, 0x59, 0x53, 0x29, 0xae,
+	0x23, 0x0b, 0x1e, 0x9d, 0x1c, 0x24, 0x16, 0x6b, 0x71, 0x0d, 0xa5, 0x37, 0xdd, 0x16, 0x65, 0x5e,
+	0x67, 0x4d, 0xae, 0x92, 0x88, 0x34, 0x74, 0x64, 0xf6, 0xb2, 0x48, 0x94, 0x45, 0xa4, 0x7b, 0xd3,
+	0x1a, 0x92, 0x65, 0x9d, 0x35, 0xa5, 0x4a, 0x62, 0xf6, 0x3d, 0x80, 0x49, 0xda, 0xec, 0x6d, 0xdf,
+	0x79, 0x14, 0x2b, 0x18, 0x61, 0x47, 0xce, 0xa0, 0x97, 0x59, 0x9d, 0x37, 0xe3, 0xe5, 0xed, 0x3c,
+	0xdd, 0x7b, 0xda, 0x35, 0x7f, 0xec, 0xc8, 0x7d, 0xa9, 0x63, 0xe7, 0xf4, 0x27, 0x83, 0x92, 0x91,
+	0xb8, 0x03, 0xd8, 0x3a, 0xd4, 0x84, 0xbb, 0x67, 0x4d, 0x7c, 0x7f, 0xae, 0xaa, 0x03, 0x59, 0xd3,
+	0x45, 0x13, 0x37, 0x30, 0x6c, 0x91, 0xde, 0xfa, 0x1d, 0xbb, 0xa8, 0xd4, 0x41, 0xfd, 0x85, 0x50,
+	0x9c, 0x84, 0xf0, 0x1f, 0x72, 0x6d, 0x2d, 0x5b, 0xa8, 0x54, 0x2c, 0x85, 0x84, 0x91, 0x4b, 0xa9,
+	0xc9, 0x21, 0xd3, 0xa3, 0x8c, 0x73, 0x3d, 0x69, 0x0a, 0x5e, 0x8e, 0xd2, 0xdc, 0xa4, 0xc4, 0x0c,
+	0x26, 0xa6, 0xb5, 0xe8, 0x7c, 0xdf, 0x69, 0xea, 0x9d, 0xfc, 0xc7, 0x7f, 0xcf, 0xd8, 0xf2, 0x01,
+	0xca, 0x75, 0x74, 0x2d, 0x16, 0x50, 0x44, 0xe3, 0x42, 0x9c, 0xa5, 0xc0, 0x0b, 0xa6, 0x57, 0x17,
+	0x92, 0xd9, 0x0c, 0xf9, 0x5d, 0x57, 0xbf, 0x03, 0x00, 0x3d, 0xdc, 0x9e, 0x63, 0xf9, 0x01, 0x00,
+	0x00,
+}
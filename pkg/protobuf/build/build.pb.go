@@ -16,6 +16,7 @@ It has these top-level messages:
 	RunRequest
 	RunResponse
 	DeleteRequest
+	ClearCacheRequest
 	Empty
 */
 package build
@@ -162,9 +163,10 @@ func _BuildRequest_OneofSizer(msg proto.Message) (n int) {
 }
 
 type BuildRequest_Info struct {
-	App  string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
-	Run  bool   `protobuf:"varint,3,opt,name=run" json:"run,omitempty"`
+	App            string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
+	Name           string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Run            bool   `protobuf:"varint,3,opt,name=run" json:"run,omitempty"`
+	UploadChecksum string `protobuf:"bytes,4,opt,name=upload_checksum,json=uploadChecksum" json:"upload_checksum,omitempty"`
 }
 
 func (m *BuildRequest_Info) Reset()                    { *m = BuildRequest_Info{} }
@@ -193,6 +195,13 @@ func (m *BuildRequest_Info) GetRun() bool {
 	return false
 }
 
+func (m *BuildRequest_Info) GetUploadChecksum() string {
+	if m != nil {
+		return m.UploadChecksum
+	}
+	return ""
+}
+
 type BuildRequest_File struct {
 	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 }
@@ -345,13 +354,29 @@ func (m *DeleteRequest) GetName() string {
 	return ""
 }
 
+type ClearCacheRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+}
+
+func (m *ClearCacheRequest) Reset()                    { *m = ClearCacheRequest{} }
+func (m *ClearCacheRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClearCacheRequest) ProtoMessage()               {}
+func (*ClearCacheRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *ClearCacheRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
 type Empty struct {
 }
 
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func init() {
 	proto.RegisterType((*BuildRequest)(nil), "build.BuildRequest")
@@ -364,6 +389,7 @@ func init() {
 	proto.RegisterType((*RunRequest)(nil), "build.RunRequest")
 	proto.RegisterType((*RunResponse)(nil), "build.RunResponse")
 	proto.RegisterType((*DeleteRequest)(nil), "build.DeleteRequest")
+	proto.RegisterType((*ClearCacheRequest)(nil), "build.ClearCacheRequest")
 	proto.RegisterType((*Empty)(nil), "build.Empty")
 }
 
@@ -382,6 +408,7 @@ type BuildClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Build_RunClient, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type buildClient struct {
@@ -473,6 +500,15 @@ func (c *buildClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grp
 	return out, nil
 }
 
+func (c *buildClient) ClearCache(ctx context.Context, in *ClearCacheRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/build.Build/ClearCache", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Build service
 
 type BuildServer interface {
@@ -480,6 +516,7 @@ type BuildServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	Run(*RunRequest, Build_RunServer) error
 	Delete(context.Context, *DeleteRequest) (*Empty, error)
+	ClearCache(context.Context, *ClearCacheRequest) (*Empty, error)
 }
 
 func RegisterBuildServer(s *grpc.Server, srv BuildServer) {
@@ -569,6 +606,24 @@ func _Build_Delete_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Build_ClearCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BuildServer).ClearCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build.Build/ClearCache",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BuildServer).ClearCache(ctx, req.(*ClearCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Build_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "build.Build",
 	HandlerType: (*BuildServer)(nil),
@@ -581,6 +636,10 @@ var _Build_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Build_Delete_Handler,
 		},
+		{
+			MethodName: "ClearCache",
+			Handler:    _Build_ClearCache_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -601,31 +660,34 @@ var _Build_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/build/build.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 414 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xcf, 0xaa, 0xd3, 0x40,
-	0x14, 0xc6, 0x1d, 0x93, 0xf4, 0xcf, 0x49, 0x0a, 0x3a, 0xed, 0x22, 0x0d, 0x22, 0x35, 0xdd, 0x64,
-	0x21, 0x69, 0xad, 0xb8, 0x12, 0x8a, 0x14, 0x15, 0x05, 0xeb, 0x62, 0x5e, 0xa0, 0x4c, 0xed, 0x44,
-	0x43, 0x93, 0xc9, 0xd8, 0xcc, 0x88, 0xae, 0x7d, 0x3e, 0x97, 0xbe, 0x8f, 0xcc, 0x9f, 0x6a, 0x0a,
-	0xf5, 0x5e, 0xb8, 0x9b, 0x70, 0xe6, 0xcb, 0xef, 0x9c, 0x39, 0xdf, 0x47, 0x02, 0x8f, 0xc5, 0xf1,
-	0xf3, 0x42, 0x9c, 0x1a, 0xd9, 0xec, 0x55, 0xb1, 0xd8, 0xab, 0xb2, 0x3a, 0xd8, 0x67, 0x6e, 0x44,
-	0x1c, 0x98, 0x43, 0xfa, 0x1b, 0x41, 0xb4, 0xd1, 0x15, 0x61, 0x5f, 0x15, 0x6b, 0x25, 0xce, 0xc1,
-	0x2f, 0x79, 0xd1, 0xc4, 0x68, 0x86, 0xb2, 0x70, 0x15, 0xe7, 0xb6, 0xa7, 0x8b, 0xe4, 0xef, 0x79,
-	0xd1, 0xbc, 0xbb, 0x47, 0x0c, 0xa7, 0xf9, 0xa2, 0xac, 0x58, 0x7c, 0xff, 0xff, 0xfc, 0xdb, 0xb2,
-	0x62, 0x9a, 0xd7, 0x5c, 0xb2, 0x06, 0x5f, 0xf7, 0xe3, 0x07, 0xe0, 0x51, 0x21, 0xcc, 0x35, 0x43,
-	0xa2, 0x4b, 0x8c, 0xc1, 0xe7, 0xb4, 0xb6, 0x93, 0x86, 0xc4, 0xd4, 0x9a, 0x3a, 0x29, 0x1e, 0x7b,
-	0x33, 0x94, 0x0d, 0x88, 0x2e, 0x93, 0x47, 0xe0, 0xeb, 0x79, 0x78, 0x02, 0xc1, 0xa7, 0x2f, 0x8a,
-	0x1f, 0xcd, 0x84, 0x88, 0xd8, 0xc3, 0xa6, 0x0f, 0xc1, 0x37, 0x5a, 0x29, 0x96, 0xce, 0x61, 0xe4,
-	0x76, 0x68, 0x45, 0xc3, 0x5b, 0xa6, 0xa7, 0x4b, 0xf6, 0x5d, 0xba, 0x0b, 0x4d, 0x9d, 0x66, 0x10,
-	0x7e, 0x28, 0x5b, 0x79, 0xb6, 0x3e, 0x85, 0x01, 0x15, 0x62, 0x67, 0x96, 0xb0, 0x58, 0x9f, 0x0a,
-	0xf1, 0x91, 0xd6, 0x2c, 0xfd, 0x89, 0x20, 0xb2, 0xa8, 0x1b, 0xf7, 0x0c, 0x7a, 0xc6, 0x69, 0x1b,
-	0xa3, 0x99, 0x97, 0x85, 0xab, 0xa9, 0x33, 0xde, 0x85, 0x5c, 0x0a, 0x0e, 0x4c, 0x5e, 0x41, 0x60,
-	0x84, 0xbf, 0x46, 0x51, 0xc7, 0xe8, 0x1c, 0x46, 0x15, 0x6d, 0xe5, 0xae, 0x6e, 0x0e, 0x65, 0x51,
-	0xb2, 0x83, 0x4b, 0x21, 0xd2, 0xe2, 0xd6, 0x69, 0xe9, 0x4b, 0x00, 0xa2, 0xf8, 0xed, 0xeb, 0x5e,
-	0x8b, 0x32, 0x7d, 0x02, 0xa1, 0x69, 0xbe, 0x21, 0x8f, 0x35, 0x8c, 0x5e, 0xb3, 0x8a, 0x49, 0x76,
-	0xc7, 0x2b, 0xfa, 0x10, 0xbc, 0xa9, 0x85, 0xfc, 0xb1, 0xfa, 0x85, 0xce, 0x5e, 0x5f, 0x80, 0xbf,
-	0xa5, 0x47, 0x86, 0xc7, 0x57, 0x3e, 0x8c, 0x64, 0x72, 0x29, 0xda, 0xcd, 0x32, 0xb4, 0x44, 0x78,
-	0x01, 0xbe, 0x4e, 0x12, 0xe3, 0x8b, 0x58, 0x6d, 0xd7, 0xf8, 0x4a, 0xd4, 0x38, 0x07, 0x8f, 0x28,
-	0x8e, 0x1f, 0xba, 0x77, 0xff, 0x62, 0x4a, 0x70, 0x57, 0xb2, 0xf4, 0x12, 0xe1, 0xa7, 0xd0, 0xb3,
-	0x56, 0xf1, 0x79, 0x89, 0x0b, 0xe7, 0x49, 0xe4, 0x54, 0xe3, 0x67, 0xdf, 0x33, 0xff, 0xcc, 0xf3,
-	0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x5a, 0x06, 0xb2, 0xaf, 0x55, 0x03, 0x00, 0x00,
+	// 461 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x5f, 0x6f, 0xd3, 0x30,
+	0x14, 0xc5, 0xf1, 0x9a, 0xb4, 0xdb, 0x6d, 0x0a, 0xec, 0x6e, 0x0f, 0x59, 0x84, 0x50, 0xc9, 0x1e,
+	0xc8, 0x03, 0x4a, 0x47, 0x81, 0x27, 0x24, 0x84, 0x56, 0x40, 0x20, 0x31, 0x1e, 0xf2, 0x05, 0x2a,
+	0xb7, 0x75, 0xd6, 0xa8, 0x8e, 0x63, 0x1a, 0x1b, 0xc1, 0xf3, 0xbe, 0x37, 0x42, 0xfe, 0x53, 0xd6,
+	0x8a, 0xf2, 0x47, 0xbc, 0x54, 0xd7, 0xa7, 0xe7, 0xfa, 0x1e, 0xff, 0x1c, 0xc3, 0x43, 0xb9, 0xba,
+	0x1e, 0xc9, 0x75, 0xa3, 0x9a, 0x99, 0x2e, 0x47, 0x33, 0x5d, 0xf1, 0x85, 0xfb, 0xcd, 0xad, 0x88,
+	0xa1, 0x5d, 0xa4, 0xdf, 0x09, 0x44, 0x97, 0xa6, 0x2a, 0xd8, 0x67, 0xcd, 0x5a, 0x85, 0x39, 0x04,
+	0x95, 0x28, 0x9b, 0x98, 0x0c, 0x49, 0xd6, 0x1f, 0xc7, 0xb9, 0xeb, 0xd9, 0xb6, 0xe4, 0x1f, 0x44,
+	0xd9, 0xbc, 0xbf, 0x53, 0x58, 0x9f, 0xf1, 0x97, 0x15, 0x67, 0xf1, 0xc1, 0xef, 0xfd, 0xef, 0x2a,
+	0xce, 0x8c, 0xdf, 0xf8, 0x92, 0x6b, 0x08, 0x4c, 0x3f, 0xde, 0x87, 0x0e, 0x95, 0xd2, 0x8e, 0x39,
+	0x2a, 0x4c, 0x89, 0x08, 0x81, 0xa0, 0xb5, 0xdb, 0xe9, 0xa8, 0xb0, 0xb5, 0x71, 0xad, 0xb5, 0x88,
+	0x3b, 0x43, 0x92, 0x1d, 0x16, 0xa6, 0xc4, 0xc7, 0x70, 0x4f, 0x4b, 0xde, 0xd0, 0xc5, 0x74, 0xbe,
+	0x64, 0xf3, 0x55, 0xab, 0xeb, 0x38, 0xb0, 0x0d, 0x77, 0x9d, 0x3c, 0xf1, 0x6a, 0xf2, 0x00, 0x02,
+	0x33, 0x18, 0x4f, 0x21, 0x9c, 0x2f, 0xb5, 0x58, 0xd9, 0x51, 0x51, 0xe1, 0x16, 0x97, 0x3d, 0x08,
+	0xbf, 0x50, 0xae, 0x59, 0x7a, 0x0e, 0x03, 0x1f, 0xb6, 0x95, 0x8d, 0x68, 0x99, 0x89, 0xa1, 0xd8,
+	0x57, 0xe5, 0x93, 0xd9, 0x3a, 0xcd, 0xa0, 0xff, 0xb1, 0x6a, 0xd5, 0x86, 0xd1, 0x19, 0x1c, 0x52,
+	0x29, 0xa7, 0x36, 0xad, 0xb3, 0xf5, 0xa8, 0x94, 0x9f, 0x68, 0xcd, 0xd2, 0x1b, 0x02, 0x91, 0xb3,
+	0xfa, 0xed, 0x9e, 0x42, 0xd7, 0x22, 0x69, 0x63, 0x32, 0xec, 0x64, 0xfd, 0xf1, 0x99, 0x27, 0xb4,
+	0x6d, 0xf2, 0xb8, 0xbc, 0x31, 0x79, 0x0d, 0xa1, 0x15, 0x7e, 0x12, 0x21, 0x5b, 0x44, 0xce, 0x61,
+	0xc0, 0x69, 0xab, 0xa6, 0x75, 0xb3, 0xa8, 0xca, 0x8a, 0x2d, 0x3c, 0xae, 0xc8, 0x88, 0x57, 0x5e,
+	0x4b, 0x5f, 0x02, 0x14, 0x5a, 0xfc, 0x3d, 0xee, 0x3e, 0xe6, 0xe9, 0x23, 0xe8, 0xdb, 0xe6, 0x3f,
+	0xf0, 0x78, 0x05, 0x83, 0x37, 0x8c, 0x33, 0xc5, 0xfe, 0x73, 0x44, 0x0e, 0xc7, 0x13, 0xce, 0xe8,
+	0x7a, 0x42, 0xe7, 0xcb, 0x7f, 0xd8, 0x23, 0xed, 0x41, 0xf8, 0xb6, 0x96, 0xea, 0xdb, 0xf8, 0xe6,
+	0x60, 0xc3, 0xe6, 0x05, 0x04, 0x57, 0x74, 0xc5, 0xf0, 0x64, 0xcf, 0x17, 0x97, 0x9c, 0xee, 0x8a,
+	0xee, 0x24, 0x19, 0xb9, 0x20, 0x38, 0x82, 0xc0, 0x90, 0x47, 0xdc, 0xb9, 0x06, 0xd7, 0x75, 0xb2,
+	0xe7, 0x6a, 0x30, 0x87, 0x4e, 0xa1, 0x05, 0x1e, 0xfb, 0xff, 0x6e, 0xb1, 0x26, 0xb8, 0x2d, 0x39,
+	0xf7, 0x05, 0xc1, 0x27, 0xd0, 0x75, 0x68, 0x70, 0x13, 0x62, 0x87, 0x54, 0x12, 0x79, 0xd5, 0x9e,
+	0x07, 0x9f, 0x03, 0xdc, 0x82, 0xc0, 0xcd, 0xeb, 0xf9, 0x85, 0xcd, 0x6e, 0xd7, 0xac, 0x6b, 0x9f,
+	0xf0, 0xb3, 0x1f, 0x03, 0x00, 0xbd, 0x83, 0x3c, 0xa6, 0xe4, 0x03, 0x00, 0x00,
 }
@@ -13,6 +13,13 @@ It has these top-level messages:
 	ListRequest
 	ListResponse
 	RollbackRequest
+	PromoteRequest
+	AbortRequest
+	SwitchRequest
+	EnvVar
+	ImageRequest
+	PromoteBuildRequest
+	ApproveRequest
 	Empty
 */
 package deploy
@@ -159,8 +166,16 @@ func _DeployRequest_OneofSizer(msg proto.Message) (n int) {
 }
 
 type DeployRequest_Info struct {
-	App         string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
-	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	App            string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
+	Description    string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	CanaryWeight   int32  `protobuf:"varint,3,opt,name=canary_weight,json=canaryWeight" json:"canary_weight,omitempty"`
+	BlueGreen      bool   `protobuf:"varint,4,opt,name=blue_green,json=blueGreen" json:"blue_green,omitempty"`
+	Commit         string `protobuf:"bytes,5,opt,name=commit" json:"commit,omitempty"`
+	Branch         string `protobuf:"bytes,6,opt,name=branch" json:"branch,omitempty"`
+	Message        string `protobuf:"bytes,7,opt,name=message" json:"message,omitempty"`
+	Force          bool   `protobuf:"varint,8,opt,name=force" json:"force,omitempty"`
+	DryRun         bool   `protobuf:"varint,9,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+	UploadChecksum string `protobuf:"bytes,10,opt,name=upload_checksum,json=uploadChecksum" json:"upload_checksum,omitempty"`
 }
 
 func (m *DeployRequest_Info) Reset()                    { *m = DeployRequest_Info{} }
@@ -182,6 +197,62 @@ func (m *DeployRequest_Info) GetDescription() string {
 	return ""
 }
 
+func (m *DeployRequest_Info) GetCanaryWeight() int32 {
+	if m != nil {
+		return m.CanaryWeight
+	}
+	return 0
+}
+
+func (m *DeployRequest_Info) GetBlueGreen() bool {
+	if m != nil {
+		return m.BlueGreen
+	}
+	return false
+}
+
+func (m *DeployRequest_Info) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *DeployRequest_Info) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *DeployRequest_Info) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *DeployRequest_Info) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+func (m *DeployRequest_Info) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *DeployRequest_Info) GetUploadChecksum() string {
+	if m != nil {
+		return m.UploadChecksum
+	}
+	return ""
+}
+
 type DeployRequest_File struct {
 	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
 }
@@ -199,7 +270,11 @@ func (m *DeployRequest_File) GetChunk() []byte {
 }
 
 type DeployResponse struct {
-	Text string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
+	Text  string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
+	Type  string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	Ready int32  `protobuf:"varint,3,opt,name=ready" json:"ready,omitempty"`
+	Total int32  `protobuf:"varint,4,opt,name=total" json:"total,omitempty"`
+	Code  int32  `protobuf:"varint,5,opt,name=code" json:"code,omitempty"`
 }
 
 func (m *DeployResponse) Reset()                    { *m = DeployResponse{} }
@@ -214,6 +289,34 @@ func (m *DeployResponse) GetText() string {
 	return ""
 }
 
+func (m *DeployResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DeployResponse) GetReady() int32 {
+	if m != nil {
+		return m.Ready
+	}
+	return 0
+}
+
+func (m *DeployResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *DeployResponse) GetCode() int32 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
 type ListRequest struct {
 	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
 }
@@ -247,10 +350,17 @@ func (m *ListResponse) GetDeploys() []*ListResponse_Deploy {
 }
 
 type ListResponse_Deploy struct {
-	Revision    string `protobuf:"bytes,1,opt,name=revision" json:"revision,omitempty"`
-	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
-	Current     bool   `protobuf:"varint,4,opt,name=current" json:"current,omitempty"`
-	CreatedAt   string `protobuf:"bytes,5,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	Revision    string    `protobuf:"bytes,1,opt,name=revision" json:"revision,omitempty"`
+	Description string    `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Current     bool      `protobuf:"varint,4,opt,name=current" json:"current,omitempty"`
+	CreatedAt   string    `protobuf:"bytes,5,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	Author      string    `protobuf:"bytes,6,opt,name=author" json:"author,omitempty"`
+	Slug        string    `protobuf:"bytes,7,opt,name=slug" json:"slug,omitempty"`
+	Image       string    `protobuf:"bytes,8,opt,name=image" json:"image,omitempty"`
+	Env         []*EnvVar `protobuf:"bytes,9,rep,name=env" json:"env,omitempty"`
+	Commit      string    `protobuf:"bytes,10,opt,name=commit" json:"commit,omitempty"`
+	Branch      string    `protobuf:"bytes,11,opt,name=branch" json:"branch,omitempty"`
+	Message     string    `protobuf:"bytes,12,opt,name=message" json:"message,omitempty"`
 }
 
 func (m *ListResponse_Deploy) Reset()                    { *m = ListResponse_Deploy{} }
@@ -286,9 +396,59 @@ func (m *ListResponse_Deploy) GetCreatedAt() string {
 	return ""
 }
 
+func (m *ListResponse_Deploy) GetAuthor() string {
+	if m != nil {
+		return m.Author
+	}
+	return ""
+}
+
+func (m *ListResponse_Deploy) GetSlug() string {
+	if m != nil {
+		return m.Slug
+	}
+	return ""
+}
+
+func (m *ListResponse_Deploy) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ListResponse_Deploy) GetEnv() []*EnvVar {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ListResponse_Deploy) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *ListResponse_Deploy) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *ListResponse_Deploy) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
 type RollbackRequest struct {
 	AppName  string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
 	Revision string `protobuf:"bytes,2,opt,name=revision" json:"revision,omitempty"`
+	Force    bool   `protobuf:"varint,3,opt,name=force" json:"force,omitempty"`
 }
 
 func (m *RollbackRequest) Reset()                    { *m = RollbackRequest{} }
@@ -310,13 +470,260 @@ func (m *RollbackRequest) GetRevision() string {
 	return ""
 }
 
+func (m *RollbackRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type PromoteRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Force   bool   `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *PromoteRequest) Reset()                    { *m = PromoteRequest{} }
+func (m *PromoteRequest) String() string            { return proto.CompactTextString(m) }
+func (*PromoteRequest) ProtoMessage()               {}
+func (*PromoteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PromoteRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *PromoteRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type AbortRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+}
+
+func (m *AbortRequest) Reset()                    { *m = AbortRequest{} }
+func (m *AbortRequest) String() string            { return proto.CompactTextString(m) }
+func (*AbortRequest) ProtoMessage()               {}
+func (*AbortRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *AbortRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+type SwitchRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Force   bool   `protobuf:"varint,2,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *SwitchRequest) Reset()                    { *m = SwitchRequest{} }
+func (m *SwitchRequest) String() string            { return proto.CompactTextString(m) }
+func (*SwitchRequest) ProtoMessage()               {}
+func (*SwitchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SwitchRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *SwitchRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type EnvVar struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *EnvVar) Reset()                    { *m = EnvVar{} }
+func (m *EnvVar) String() string            { return proto.CompactTextString(m) }
+func (*EnvVar) ProtoMessage()               {}
+func (*EnvVar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *EnvVar) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ImageRequest struct {
+	AppName     string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Image       string `protobuf:"bytes,2,opt,name=image" json:"image,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+	TeresaYaml  []byte `protobuf:"bytes,4,opt,name=teresa_yaml,json=teresaYaml" json:"teresa_yaml,omitempty"`
+	Commit      string `protobuf:"bytes,5,opt,name=commit" json:"commit,omitempty"`
+	Branch      string `protobuf:"bytes,6,opt,name=branch" json:"branch,omitempty"`
+	Message     string `protobuf:"bytes,7,opt,name=message" json:"message,omitempty"`
+	Force       bool   `protobuf:"varint,8,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *ImageRequest) Reset()                    { *m = ImageRequest{} }
+func (m *ImageRequest) String() string            { return proto.CompactTextString(m) }
+func (*ImageRequest) ProtoMessage()               {}
+func (*ImageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ImageRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetImage() string {
+	if m != nil {
+		return m.Image
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetTeresaYaml() []byte {
+	if m != nil {
+		return m.TeresaYaml
+	}
+	return nil
+}
+
+func (m *ImageRequest) GetCommit() string {
+	if m != nil {
+		return m.Commit
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetBranch() string {
+	if m != nil {
+		return m.Branch
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ImageRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type PromoteBuildRequest struct {
+	AppName      string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	BuildName    string `protobuf:"bytes,2,opt,name=build_name,json=buildName" json:"build_name,omitempty"`
+	ToApp        string `protobuf:"bytes,3,opt,name=to_app,json=toApp" json:"to_app,omitempty"`
+	Description  string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+	CanaryWeight int32  `protobuf:"varint,5,opt,name=canary_weight,json=canaryWeight" json:"canary_weight,omitempty"`
+	BlueGreen    bool   `protobuf:"varint,6,opt,name=blue_green,json=blueGreen" json:"blue_green,omitempty"`
+	Force        bool   `protobuf:"varint,7,opt,name=force" json:"force,omitempty"`
+}
+
+func (m *PromoteBuildRequest) Reset()                    { *m = PromoteBuildRequest{} }
+func (m *PromoteBuildRequest) String() string            { return proto.CompactTextString(m) }
+func (*PromoteBuildRequest) ProtoMessage()               {}
+func (*PromoteBuildRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *PromoteBuildRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *PromoteBuildRequest) GetBuildName() string {
+	if m != nil {
+		return m.BuildName
+	}
+	return ""
+}
+
+func (m *PromoteBuildRequest) GetToApp() string {
+	if m != nil {
+		return m.ToApp
+	}
+	return ""
+}
+
+func (m *PromoteBuildRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *PromoteBuildRequest) GetCanaryWeight() int32 {
+	if m != nil {
+		return m.CanaryWeight
+	}
+	return 0
+}
+
+func (m *PromoteBuildRequest) GetBlueGreen() bool {
+	if m != nil {
+		return m.BlueGreen
+	}
+	return false
+}
+
+func (m *PromoteBuildRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+type ApproveRequest struct {
+	DeployId string `protobuf:"bytes,1,opt,name=deploy_id,json=deployId" json:"deploy_id,omitempty"`
+}
+
+func (m *ApproveRequest) Reset()                    { *m = ApproveRequest{} }
+func (m *ApproveRequest) String() string            { return proto.CompactTextString(m) }
+func (*ApproveRequest) ProtoMessage()               {}
+func (*ApproveRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ApproveRequest) GetDeployId() string {
+	if m != nil {
+		return m.DeployId
+	}
+	return ""
+}
+
 type Empty struct {
 }
 
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func init() {
 	proto.RegisterType((*DeployRequest)(nil), "deploy.DeployRequest")
@@ -327,6 +734,13 @@ func init() {
 	proto.RegisterType((*ListResponse)(nil), "deploy.ListResponse")
 	proto.RegisterType((*ListResponse_Deploy)(nil), "deploy.ListResponse.Deploy")
 	proto.RegisterType((*RollbackRequest)(nil), "deploy.RollbackRequest")
+	proto.RegisterType((*PromoteRequest)(nil), "deploy.PromoteRequest")
+	proto.RegisterType((*AbortRequest)(nil), "deploy.AbortRequest")
+	proto.RegisterType((*SwitchRequest)(nil), "deploy.SwitchRequest")
+	proto.RegisterType((*EnvVar)(nil), "deploy.EnvVar")
+	proto.RegisterType((*ImageRequest)(nil), "deploy.ImageRequest")
+	proto.RegisterType((*PromoteBuildRequest)(nil), "deploy.PromoteBuildRequest")
+	proto.RegisterType((*ApproveRequest)(nil), "deploy.ApproveRequest")
 	proto.RegisterType((*Empty)(nil), "deploy.Empty")
 }
 
@@ -344,6 +758,12 @@ type DeployClient interface {
 	Make(ctx context.Context, opts ...grpc.CallOption) (Deploy_MakeClient, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*Empty, error)
+	Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*Empty, error)
+	Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*Empty, error)
+	Switch(ctx context.Context, in *SwitchRequest, opts ...grpc.CallOption) (*Empty, error)
+	Image(ctx context.Context, in *ImageRequest, opts ...grpc.CallOption) (Deploy_ImageClient, error)
+	PromoteBuild(ctx context.Context, in *PromoteBuildRequest, opts ...grpc.CallOption) (Deploy_PromoteBuildClient, error)
+	Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type deployClient struct {
@@ -403,12 +823,118 @@ func (c *deployClient) Rollback(ctx context.Context, in *RollbackRequest, opts .
 	return out, nil
 }
 
+func (c *deployClient) Promote(ctx context.Context, in *PromoteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/deploy.Deploy/Promote", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deployClient) Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/deploy.Deploy/Abort", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deployClient) Switch(ctx context.Context, in *SwitchRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/deploy.Deploy/Switch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deployClient) Image(ctx context.Context, in *ImageRequest, opts ...grpc.CallOption) (Deploy_ImageClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deploy_serviceDesc.Streams[1], c.cc, "/deploy.Deploy/Image", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deployImageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Deploy_ImageClient interface {
+	Recv() (*DeployResponse, error)
+	grpc.ClientStream
+}
+
+type deployImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *deployImageClient) Recv() (*DeployResponse, error) {
+	m := new(DeployResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deployClient) PromoteBuild(ctx context.Context, in *PromoteBuildRequest, opts ...grpc.CallOption) (Deploy_PromoteBuildClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Deploy_serviceDesc.Streams[2], c.cc, "/deploy.Deploy/PromoteBuild", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deployPromoteBuildClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Deploy_PromoteBuildClient interface {
+	Recv() (*DeployResponse, error)
+	grpc.ClientStream
+}
+
+type deployPromoteBuildClient struct {
+	grpc.ClientStream
+}
+
+func (x *deployPromoteBuildClient) Recv() (*DeployResponse, error) {
+	m := new(DeployResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *deployClient) Approve(ctx context.Context, in *ApproveRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/deploy.Deploy/Approve", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Deploy service
 
 type DeployServer interface {
 	Make(Deploy_MakeServer) error
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	Rollback(context.Context, *RollbackRequest) (*Empty, error)
+	Promote(context.Context, *PromoteRequest) (*Empty, error)
+	Abort(context.Context, *AbortRequest) (*Empty, error)
+	Switch(context.Context, *SwitchRequest) (*Empty, error)
+	Image(*ImageRequest, Deploy_ImageServer) error
+	PromoteBuild(*PromoteBuildRequest, Deploy_PromoteBuildServer) error
+	Approve(context.Context, *ApproveRequest) (*Empty, error)
 }
 
 func RegisterDeployServer(s *grpc.Server, srv DeployServer) {
@@ -477,6 +1003,120 @@ func _Deploy_Rollback_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Deploy_Promote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployServer).Promote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deploy.Deploy/Promote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployServer).Promote(ctx, req.(*PromoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Deploy_Abort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployServer).Abort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deploy.Deploy/Abort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployServer).Abort(ctx, req.(*AbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Deploy_Switch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwitchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployServer).Switch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deploy.Deploy/Switch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployServer).Switch(ctx, req.(*SwitchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Deploy_Image_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeployServer).Image(m, &deployImageServer{stream})
+}
+
+type Deploy_ImageServer interface {
+	Send(*DeployResponse) error
+	grpc.ServerStream
+}
+
+type deployImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *deployImageServer) Send(m *DeployResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Deploy_PromoteBuild_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PromoteBuildRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeployServer).PromoteBuild(m, &deployPromoteBuildServer{stream})
+}
+
+type Deploy_PromoteBuildServer interface {
+	Send(*DeployResponse) error
+	grpc.ServerStream
+}
+
+type deployPromoteBuildServer struct {
+	grpc.ServerStream
+}
+
+func (x *deployPromoteBuildServer) Send(m *DeployResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Deploy_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeployServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deploy.Deploy/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeployServer).Approve(ctx, req.(*ApproveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Deploy_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "deploy.Deploy",
 	HandlerType: (*DeployServer)(nil),
@@ -489,6 +1129,22 @@ var _Deploy_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rollback",
 			Handler:    _Deploy_Rollback_Handler,
 		},
+		{
+			MethodName: "Promote",
+			Handler:    _Deploy_Promote_Handler,
+		},
+		{
+			MethodName: "Abort",
+			Handler:    _Deploy_Abort_Handler,
+		},
+		{
+			MethodName: "Switch",
+			Handler:    _Deploy_Switch_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _Deploy_Approve_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -497,6 +1153,16 @@ var _Deploy_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Image",
+			Handler:       _Deploy_Image_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PromoteBuild",
+			Handler:       _Deploy_PromoteBuild_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "pkg/protobuf/deploy/deploy.proto",
 }
@@ -504,32 +1170,62 @@ var _Deploy_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/deploy/deploy.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 419 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xd1, 0x6e, 0x94, 0x40,
-	0x14, 0x75, 0xba, 0xb3, 0x0b, 0x7b, 0x69, 0xb5, 0x19, 0xab, 0x22, 0x6a, 0x42, 0x88, 0x0f, 0x3c,
-	0x6d, 0x2b, 0xc6, 0x07, 0x7d, 0xd3, 0xa8, 0xa9, 0x46, 0x7d, 0xe0, 0x07, 0x9a, 0x59, 0xf6, 0xa2,
-	0x04, 0x96, 0x19, 0x61, 0x68, 0xec, 0x07, 0xf8, 0x33, 0xfe, 0x8b, 0xaf, 0x7e, 0x8f, 0x99, 0x19,
-	0x46, 0x4b, 0xd3, 0x74, 0x9f, 0xb8, 0xf7, 0x70, 0xce, 0xbd, 0xf7, 0x9c, 0x00, 0xc4, 0xb2, 0xfe,
-	0x7a, 0x2c, 0x3b, 0xa1, 0xc4, 0x7a, 0x28, 0x8f, 0x37, 0x28, 0x1b, 0x71, 0x31, 0x3e, 0x56, 0x06,
-	0x66, 0x0b, 0xdb, 0x25, 0x7f, 0x08, 0x1c, 0xbc, 0x35, 0x65, 0x8e, 0xdf, 0x07, 0xec, 0x15, 0x3b,
-	0x01, 0x5a, 0xb5, 0xa5, 0x08, 0x49, 0x4c, 0xd2, 0x20, 0x8b, 0x56, 0xa3, 0x6c, 0x42, 0x5a, 0x7d,
-	0x68, 0x4b, 0x71, 0x7a, 0x2b, 0x37, 0x4c, 0xad, 0x28, 0xab, 0x06, 0xc3, 0xbd, 0x9b, 0x14, 0xef,
-	0xab, 0x06, 0xb5, 0x42, 0x33, 0xa3, 0x57, 0x40, 0xf5, 0x04, 0x76, 0x08, 0x33, 0x2e, 0xa5, 0x59,
-	0xb5, 0xcc, 0x75, 0xc9, 0x62, 0x08, 0x36, 0xd8, 0x17, 0x5d, 0x25, 0x55, 0x25, 0x5a, 0x33, 0x72,
-	0x99, 0x5f, 0x86, 0xa2, 0xc7, 0x40, 0xf5, 0x2c, 0x76, 0x04, 0xf3, 0xe2, 0xdb, 0xd0, 0xd6, 0x46,
-	0xbd, 0x9f, 0xdb, 0xe6, 0x8d, 0x07, 0xf3, 0x73, 0xde, 0x0c, 0x98, 0x3c, 0x85, 0xdb, 0xee, 0x80,
-	0x5e, 0x8a, 0xb6, 0x47, 0xc6, 0x80, 0x2a, 0xfc, 0xa1, 0xc6, 0x6d, 0xa6, 0x4e, 0x52, 0x08, 0x3e,
-	0x55, 0xbd, 0x72, 0xde, 0x1f, 0x82, 0xcf, 0xa5, 0x3c, 0x6b, 0xf9, 0x16, 0x47, 0x9a, 0xc7, 0xa5,
-	0xfc, 0xc2, 0xb7, 0x98, 0xfc, 0x26, 0xb0, 0x6f, 0xa9, 0xe3, 0xb8, 0x17, 0xe0, 0x59, 0xa3, 0x7d,
-	0x48, 0xe2, 0x59, 0x1a, 0x64, 0x8f, 0x9c, 0xf1, 0xcb, 0x34, 0x97, 0x82, 0xe3, 0x46, 0x3f, 0x09,
-	0x2c, 0x2c, 0xc6, 0x22, 0xf0, 0x3b, 0x3c, 0xaf, 0x7a, 0x6d, 0xd4, 0x6e, 0xfb, 0xd7, 0xef, 0xce,
-	0x81, 0x85, 0xe0, 0x15, 0x43, 0xd7, 0x61, 0xab, 0x42, 0x1a, 0x93, 0xd4, 0xcf, 0x5d, 0xcb, 0x9e,
-	0x00, 0x14, 0x1d, 0x72, 0x85, 0x9b, 0x33, 0xae, 0xc2, 0xb9, 0x91, 0x2e, 0x47, 0xe4, 0xb5, 0xfa,
-	0x48, 0xfd, 0xd9, 0x21, 0x4d, 0x4e, 0xe1, 0x4e, 0x2e, 0x9a, 0x66, 0xcd, 0x8b, 0x7a, 0xb7, 0xfb,
-	0xc9, 0xa9, 0x7b, 0xd3, 0x53, 0x13, 0x0f, 0xe6, 0xef, 0xb6, 0x52, 0x5d, 0x64, 0xbf, 0xfe, 0x5b,
-	0x7b, 0x09, 0xf4, 0x33, 0xaf, 0x91, 0xdd, 0xbb, 0xf6, 0x63, 0x88, 0xee, 0x5f, 0x85, 0x6d, 0x58,
-	0x29, 0x39, 0x21, 0xec, 0x19, 0x50, 0x1d, 0x20, 0xbb, 0x3b, 0x8d, 0xd3, 0x0a, 0x8f, 0xae, 0xcb,
-	0x98, 0x65, 0xe0, 0x3b, 0x2f, 0xec, 0x81, 0x63, 0x5c, 0x71, 0x17, 0x1d, 0xb8, 0x17, 0xe6, 0xd8,
-	0xf5, 0xc2, 0xfc, 0x07, 0xcf, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x89, 0xb1, 0x7d, 0xdd, 0x2b,
-	0x03, 0x00, 0x00,
+	// 909 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xcb, 0x8e, 0xe3, 0x44,
+	0x14, 0x25, 0xf1, 0x2b, 0xbe, 0x49, 0x67, 0x46, 0x35, 0x2f, 0x93, 0x06, 0x11, 0x85, 0x05, 0x41,
+	0x82, 0x9e, 0xd0, 0x08, 0x21, 0x76, 0x64, 0xa0, 0x81, 0x46, 0x80, 0x90, 0x91, 0x40, 0x6c, 0xb0,
+	0x2a, 0x76, 0x25, 0xb1, 0x62, 0xbb, 0x4c, 0xb9, 0x9c, 0x19, 0xff, 0x0d, 0xff, 0xc0, 0x9a, 0x9f,
+	0x61, 0xcd, 0x1f, 0xb0, 0x41, 0xf5, 0xb0, 0xdb, 0xf6, 0x4c, 0xcf, 0x44, 0x48, 0xb3, 0xea, 0xba,
+	0xc7, 0xb7, 0x6e, 0xd5, 0x3d, 0x75, 0xee, 0xe9, 0xc0, 0x3c, 0x3f, 0xec, 0x1e, 0xe7, 0x8c, 0x72,
+	0xba, 0x29, 0xb7, 0x8f, 0x23, 0x92, 0x27, 0xb4, 0xd2, 0x7f, 0x2e, 0x24, 0x8c, 0x6c, 0x15, 0x2d,
+	0xfe, 0x32, 0xe0, 0xec, 0x4b, 0xb9, 0xf4, 0xc9, 0xef, 0x25, 0x29, 0x38, 0x5a, 0x81, 0x19, 0x67,
+	0x5b, 0xea, 0x0d, 0xe6, 0x83, 0xe5, 0xf8, 0x72, 0x76, 0xa1, 0xb7, 0x75, 0x92, 0x2e, 0xae, 0xb3,
+	0x2d, 0xfd, 0xe6, 0x0d, 0x5f, 0x66, 0x8a, 0x1d, 0xdb, 0x38, 0x21, 0xde, 0xf0, 0x65, 0x3b, 0xbe,
+	0x8a, 0x13, 0x22, 0x76, 0x88, 0xcc, 0xd9, 0x1f, 0x43, 0x30, 0x45, 0x09, 0x74, 0x17, 0x0c, 0x9c,
+	0xe7, 0xf2, 0x2c, 0xd7, 0x17, 0x4b, 0x34, 0x87, 0x71, 0x44, 0x8a, 0x90, 0xc5, 0x39, 0x8f, 0x69,
+	0x26, 0x6b, 0xba, 0x7e, 0x1b, 0x42, 0xef, 0xc2, 0x59, 0x88, 0x33, 0xcc, 0xaa, 0xe0, 0x29, 0x89,
+	0x77, 0x7b, 0xee, 0x19, 0xf3, 0xc1, 0xd2, 0xf2, 0x27, 0x0a, 0xfc, 0x45, 0x62, 0xe8, 0x6d, 0x80,
+	0x4d, 0x52, 0x92, 0x60, 0xc7, 0x08, 0xc9, 0x3c, 0x73, 0x3e, 0x58, 0x8e, 0x7c, 0x57, 0x20, 0x5f,
+	0x0b, 0x00, 0x3d, 0x04, 0x3b, 0xa4, 0x69, 0x1a, 0x73, 0xcf, 0x92, 0x07, 0xe8, 0x48, 0xe0, 0x1b,
+	0x86, 0xb3, 0x70, 0xef, 0xd9, 0x0a, 0x57, 0x11, 0xf2, 0xc0, 0x49, 0x49, 0x51, 0xe0, 0x1d, 0xf1,
+	0x1c, 0xf9, 0xa1, 0x0e, 0xd1, 0x7d, 0xb0, 0xb6, 0x94, 0x85, 0xc4, 0x1b, 0xc9, 0x33, 0x54, 0x80,
+	0x1e, 0x81, 0x13, 0xb1, 0x2a, 0x60, 0x65, 0xe6, 0xb9, 0x12, 0xb7, 0x23, 0x56, 0xf9, 0x65, 0x86,
+	0xde, 0x83, 0x3b, 0x65, 0x9e, 0x50, 0x1c, 0x05, 0xe1, 0x9e, 0x84, 0x87, 0xa2, 0x4c, 0x3d, 0x90,
+	0x05, 0xa7, 0x0a, 0xfe, 0x42, 0xa3, 0xb3, 0xb7, 0xc0, 0x14, 0x94, 0x89, 0xfa, 0xe1, 0xbe, 0xcc,
+	0x0e, 0x92, 0xa3, 0x89, 0xaf, 0x82, 0x27, 0x0e, 0x58, 0x47, 0x9c, 0x94, 0x64, 0xf1, 0x0c, 0xa6,
+	0x35, 0xcf, 0x45, 0x4e, 0xb3, 0x82, 0x20, 0x04, 0x26, 0x27, 0xcf, 0xb8, 0xe6, 0x54, 0xae, 0x25,
+	0x56, 0xe5, 0x44, 0xb3, 0x29, 0xd7, 0xa2, 0x30, 0x23, 0x38, 0xaa, 0x34, 0x7d, 0x2a, 0x10, 0x28,
+	0xa7, 0x1c, 0x27, 0x92, 0x32, 0xcb, 0x57, 0x81, 0xd8, 0x1f, 0xd2, 0x88, 0x48, 0xb2, 0x2c, 0x5f,
+	0xae, 0x17, 0x4b, 0x18, 0x7f, 0x17, 0x17, 0xbc, 0x96, 0xcd, 0x9b, 0x30, 0xc2, 0x79, 0x1e, 0x64,
+	0x38, 0x25, 0xfa, 0x68, 0x07, 0xe7, 0xf9, 0x0f, 0x38, 0x25, 0x8b, 0x7f, 0x87, 0x30, 0x51, 0xa9,
+	0xfa, 0x8a, 0x9f, 0x80, 0xa3, 0x34, 0x52, 0x78, 0x83, 0xb9, 0xb1, 0x1c, 0x5f, 0x9e, 0xd7, 0x9a,
+	0x69, 0xa7, 0xd5, 0x02, 0xaa, 0x73, 0x67, 0x7f, 0x0e, 0xc1, 0x56, 0x18, 0x9a, 0xc1, 0x88, 0x91,
+	0x63, 0x5c, 0x08, 0x89, 0xa8, 0xd3, 0x9a, 0xf8, 0x04, 0x05, 0x79, 0xe0, 0x84, 0x25, 0x63, 0x24,
+	0xe3, 0x5a, 0x19, 0x75, 0x28, 0x64, 0x13, 0x32, 0x82, 0x39, 0x89, 0x02, 0x5c, 0x6b, 0xc3, 0xd5,
+	0xc8, 0x5a, 0xca, 0x03, 0x97, 0x7c, 0x4f, 0x59, 0x2d, 0x0f, 0x15, 0x09, 0x7e, 0x8a, 0xa4, 0xdc,
+	0x69, 0x6d, 0xc8, 0xb5, 0x60, 0x32, 0x4e, 0xf1, 0x4e, 0x09, 0xc3, 0xf5, 0x55, 0x80, 0xe6, 0x60,
+	0x90, 0xec, 0xe8, 0xb9, 0xb2, 0xed, 0x69, 0xdd, 0xf6, 0x55, 0x76, 0xfc, 0x19, 0x33, 0x5f, 0x7c,
+	0x6a, 0x49, 0x13, 0x6e, 0x91, 0xe6, 0xf8, 0x36, 0x69, 0x4e, 0x3a, 0xd2, 0xfc, 0xd6, 0x1c, 0x19,
+	0x77, 0xcd, 0xc5, 0x6f, 0x70, 0xc7, 0xa7, 0x49, 0xb2, 0xc1, 0xe1, 0xe1, 0xd5, 0x6f, 0xd5, 0x21,
+	0x76, 0xd8, 0x23, 0xb6, 0x91, 0xba, 0xd1, 0x92, 0xfa, 0x62, 0x0d, 0xd3, 0x1f, 0x19, 0x4d, 0x29,
+	0x27, 0x27, 0x94, 0x6f, 0x4a, 0x0c, 0xdb, 0x25, 0xde, 0x87, 0xc9, 0x7a, 0x43, 0xd9, 0x29, 0x5a,
+	0xfa, 0x1c, 0xce, 0x7e, 0x7a, 0x1a, 0xf3, 0x70, 0xff, 0xbf, 0x0f, 0x5b, 0x81, 0xad, 0xe8, 0x16,
+	0xe6, 0x73, 0x20, 0x55, 0x6d, 0x3e, 0x07, 0x22, 0xd5, 0x2f, 0xc7, 0x4a, 0xb7, 0xae, 0x67, 0xec,
+	0xef, 0x01, 0x4c, 0xae, 0xc5, 0xeb, 0x9d, 0x76, 0xa6, 0x7a, 0xf5, 0x61, 0xf7, 0xd5, 0x3b, 0x92,
+	0x34, 0x9e, 0x97, 0xe4, 0x3b, 0x30, 0xe6, 0x84, 0x91, 0x02, 0x07, 0x15, 0x4e, 0xd5, 0xf4, 0x4d,
+	0x7c, 0x50, 0xd0, 0xaf, 0x38, 0x4d, 0x5e, 0xb7, 0x63, 0x89, 0x26, 0xef, 0xe9, 0x77, 0x7c, 0x52,
+	0xc6, 0x49, 0x74, 0x42, 0xaf, 0xc2, 0x63, 0x45, 0xaa, 0xfa, 0xa8, 0x1a, 0x76, 0x25, 0x22, 0x3f,
+	0x3f, 0x00, 0x9b, 0xd3, 0x40, 0xd8, 0xbb, 0xea, 0xd7, 0xe2, 0x74, 0xfd, 0xbc, 0xc1, 0x9b, 0x27,
+	0x18, 0xbc, 0xf5, 0x4a, 0x83, 0xb7, 0xfb, 0x06, 0xdf, 0x34, 0xe9, 0xb4, 0x9b, 0xfc, 0x10, 0xa6,
+	0xeb, 0x3c, 0x67, 0xf4, 0xd8, 0x3c, 0xe5, 0x39, 0xb8, 0x6a, 0x06, 0x83, 0x38, 0xaa, 0x9d, 0x44,
+	0x01, 0xd7, 0xd1, 0xc2, 0x01, 0xeb, 0x2a, 0xcd, 0x79, 0x75, 0xf9, 0x8f, 0xd1, 0x38, 0xcf, 0x67,
+	0x60, 0x7e, 0x8f, 0x0f, 0x04, 0x3d, 0x78, 0xe1, 0xbf, 0xb9, 0xd9, 0xc3, 0x3e, 0xac, 0xbc, 0x6c,
+	0x39, 0x58, 0x0d, 0xd0, 0x47, 0x60, 0x0a, 0x7f, 0x43, 0xf7, 0xba, 0x6e, 0xa7, 0x36, 0xde, 0x7f,
+	0x91, 0x05, 0xa2, 0x4b, 0x18, 0xd5, 0xc3, 0x8b, 0x1e, 0xd5, 0x19, 0xbd, 0x71, 0x9e, 0x9d, 0x35,
+	0x36, 0x22, 0x2e, 0x8b, 0x56, 0xe0, 0xe8, 0x87, 0x44, 0xcd, 0x6d, 0xba, 0x13, 0xda, 0xdf, 0xf1,
+	0x01, 0x58, 0x72, 0xfe, 0x50, 0x73, 0x89, 0xf6, 0x38, 0xf6, 0xb3, 0x2f, 0xc0, 0x56, 0x23, 0x78,
+	0xc3, 0x41, 0x67, 0x24, 0xfb, 0xf9, 0x9f, 0x82, 0x25, 0xa7, 0xe7, 0xa6, 0x7a, 0x7b, 0x98, 0x6e,
+	0x63, 0x6c, 0x35, 0x40, 0x57, 0x30, 0x69, 0x2b, 0x12, 0x9d, 0xf7, 0xba, 0x69, 0xeb, 0xf4, 0x25,
+	0x65, 0x56, 0xe0, 0xe8, 0x47, 0xbf, 0xe1, 0xa3, 0xab, 0x82, 0xde, 0x8d, 0x37, 0xb6, 0xfc, 0x8d,
+	0xf4, 0xf1, 0x7f, 0x03, 0x00, 0xa5, 0x91, 0x6a, 0xe9, 0x47, 0x09, 0x00, 0x00,
 }
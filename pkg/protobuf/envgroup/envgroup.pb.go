@@ -0,0 +1,693 @@
+// Code generated by protoc-gen-go.
+// source: pkg/protobuf/envgroup/envgroup.proto
+// DO NOT EDIT!
+
+/*
+Package envgroup is a generated protocol buffer package.
+
+It is generated from these files:
+	pkg/protobuf/envgroup/envgroup.proto
+
+It has these top-level messages:
+	CreateRequest
+	DeleteRequest
+	ListResponse
+	InfoRequest
+	InfoResponse
+	SetEnvRequest
+	UnsetEnvRequest
+	AttachRequest
+	DetachRequest
+	Empty
+*/
+package envgroup
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type CreateRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Team string `protobuf:"bytes,2,opt,name=team" json:"team,omitempty"`
+}
+
+func (m *CreateRequest) Reset()                    { *m = CreateRequest{} }
+func (m *CreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateRequest) ProtoMessage()               {}
+func (*CreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *CreateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateRequest) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+type DeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *DeleteRequest) Reset()                    { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()               {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *DeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListResponse struct {
+	EnvGroups []*ListResponse_EnvGroup `protobuf:"bytes,1,rep,name=env_groups,json=envGroups" json:"env_groups,omitempty"`
+}
+
+func (m *ListResponse) Reset()                    { *m = ListResponse{} }
+func (m *ListResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListResponse) ProtoMessage()               {}
+func (*ListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *ListResponse) GetEnvGroups() []*ListResponse_EnvGroup {
+	if m != nil {
+		return m.EnvGroups
+	}
+	return nil
+}
+
+type ListResponse_EnvGroup struct {
+	Name string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Team string   `protobuf:"bytes,2,opt,name=team" json:"team,omitempty"`
+	Apps []string `protobuf:"bytes,3,rep,name=apps" json:"apps,omitempty"`
+}
+
+func (m *ListResponse_EnvGroup) Reset()                    { *m = ListResponse_EnvGroup{} }
+func (m *ListResponse_EnvGroup) String() string            { return proto.CompactTextString(m) }
+func (*ListResponse_EnvGroup) ProtoMessage()               {}
+func (*ListResponse_EnvGroup) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
+
+func (m *ListResponse_EnvGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListResponse_EnvGroup) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *ListResponse_EnvGroup) GetApps() []string {
+	if m != nil {
+		return m.Apps
+	}
+	return nil
+}
+
+type InfoRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *InfoRequest) Reset()                    { *m = InfoRequest{} }
+func (m *InfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*InfoRequest) ProtoMessage()               {}
+func (*InfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *InfoRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type InfoResponse struct {
+	Name    string                 `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Team    string                 `protobuf:"bytes,2,opt,name=team" json:"team,omitempty"`
+	Apps    []string               `protobuf:"bytes,3,rep,name=apps" json:"apps,omitempty"`
+	EnvVars []*InfoResponse_EnvVar `protobuf:"bytes,4,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *InfoResponse) Reset()                    { *m = InfoResponse{} }
+func (m *InfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*InfoResponse) ProtoMessage()               {}
+func (*InfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *InfoResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetTeam() string {
+	if m != nil {
+		return m.Team
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetApps() []string {
+	if m != nil {
+		return m.Apps
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetEnvVars() []*InfoResponse_EnvVar {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type InfoResponse_EnvVar struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *InfoResponse_EnvVar) Reset()                    { *m = InfoResponse_EnvVar{} }
+func (m *InfoResponse_EnvVar) String() string            { return proto.CompactTextString(m) }
+func (*InfoResponse_EnvVar) ProtoMessage()               {}
+func (*InfoResponse_EnvVar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4, 0} }
+
+func (m *InfoResponse_EnvVar) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *InfoResponse_EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetEnvRequest struct {
+	Name    string                  `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	EnvVars []*SetEnvRequest_EnvVar `protobuf:"bytes,2,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *SetEnvRequest) Reset()                    { *m = SetEnvRequest{} }
+func (m *SetEnvRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetEnvRequest) ProtoMessage()               {}
+func (*SetEnvRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SetEnvRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetEnvRequest) GetEnvVars() []*SetEnvRequest_EnvVar {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type SetEnvRequest_EnvVar struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *SetEnvRequest_EnvVar) Reset()                    { *m = SetEnvRequest_EnvVar{} }
+func (m *SetEnvRequest_EnvVar) String() string            { return proto.CompactTextString(m) }
+func (*SetEnvRequest_EnvVar) ProtoMessage()               {}
+func (*SetEnvRequest_EnvVar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5, 0} }
+
+func (m *SetEnvRequest_EnvVar) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetEnvRequest_EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type UnsetEnvRequest struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	EnvVars []string `protobuf:"bytes,2,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *UnsetEnvRequest) Reset()                    { *m = UnsetEnvRequest{} }
+func (m *UnsetEnvRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnsetEnvRequest) ProtoMessage()               {}
+func (*UnsetEnvRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *UnsetEnvRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UnsetEnvRequest) GetEnvVars() []string {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type AttachRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	AppName string `protobuf:"bytes,2,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+}
+
+func (m *AttachRequest) Reset()                    { *m = AttachRequest{} }
+func (m *AttachRequest) String() string            { return proto.CompactTextString(m) }
+func (*AttachRequest) ProtoMessage()               {}
+func (*AttachRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *AttachRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AttachRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+type DetachRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	AppName string `protobuf:"bytes,2,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+}
+
+func (m *DetachRequest) Reset()                    { *m = DetachRequest{} }
+func (m *DetachRequest) String() string            { return proto.CompactTextString(m) }
+func (*DetachRequest) ProtoMessage()               {}
+func (*DetachRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *DetachRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DetachRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func init() {
+	proto.RegisterType((*CreateRequest)(nil), "envgroup.CreateRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "envgroup.DeleteRequest")
+	proto.RegisterType((*ListResponse)(nil), "envgroup.ListResponse")
+	proto.RegisterType((*ListResponse_EnvGroup)(nil), "envgroup.ListResponse.EnvGroup")
+	proto.RegisterType((*InfoRequest)(nil), "envgroup.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "envgroup.InfoResponse")
+	proto.RegisterType((*InfoResponse_EnvVar)(nil), "envgroup.InfoResponse.EnvVar")
+	proto.RegisterType((*SetEnvRequest)(nil), "envgroup.SetEnvRequest")
+	proto.RegisterType((*SetEnvRequest_EnvVar)(nil), "envgroup.SetEnvRequest.EnvVar")
+	proto.RegisterType((*UnsetEnvRequest)(nil), "envgroup.UnsetEnvRequest")
+	proto.RegisterType((*AttachRequest)(nil), "envgroup.AttachRequest")
+	proto.RegisterType((*DetachRequest)(nil), "envgroup.DetachRequest")
+	proto.RegisterType((*Empty)(nil), "envgroup.Empty")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for EnvGroup service
+
+type EnvGroupClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error)
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*Empty, error)
+	Detach(ctx context.Context, in *DetachRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type envGroupClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEnvGroupClient(cc *grpc.ClientConn) EnvGroupClient {
+	return &envGroupClient{cc}
+}
+
+func (c *envGroupClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/SetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/UnsetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) Attach(ctx context.Context, in *AttachRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/Attach", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *envGroupClient) Detach(ctx context.Context, in *DetachRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/envgroup.EnvGroup/Detach", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for EnvGroup service
+
+type EnvGroupServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+	List(context.Context, *Empty) (*ListResponse, error)
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	SetEnv(context.Context, *SetEnvRequest) (*Empty, error)
+	UnsetEnv(context.Context, *UnsetEnvRequest) (*Empty, error)
+	Attach(context.Context, *AttachRequest) (*Empty, error)
+	Detach(context.Context, *DetachRequest) (*Empty, error)
+}
+
+func RegisterEnvGroupServer(s *grpc.Server, srv EnvGroupServer) {
+	s.RegisterService(&_EnvGroup_serviceDesc, srv)
+}
+
+func _EnvGroup_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).List(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_SetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).SetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/SetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).SetEnv(ctx, req.(*SetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_UnsetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).UnsetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/UnsetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).UnsetEnv(ctx, req.(*UnsetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_Attach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).Attach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/Attach",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).Attach(ctx, req.(*AttachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnvGroup_Detach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnvGroupServer).Detach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/envgroup.EnvGroup/Detach",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnvGroupServer).Detach(ctx, req.(*DetachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _EnvGroup_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "envgroup.EnvGroup",
+	HandlerType: (*EnvGroupServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _EnvGroup_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _EnvGroup_Delete_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _EnvGroup_List_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _EnvGroup_Info_Handler,
+		},
+		{
+			MethodName: "SetEnv",
+			Handler:    _EnvGroup_SetEnv_Handler,
+		},
+		{
+			MethodName: "UnsetEnv",
+			Handler:    _EnvGroup_UnsetEnv_Handler,
+		},
+		{
+			MethodName: "Attach",
+			Handler:    _EnvGroup_Attach_Handler,
+		},
+		{
+			MethodName: "Detach",
+			Handler:    _EnvGroup_Detach_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/protobuf/envgroup/envgroup.proto",
+}
+
+func init() { proto.RegisterFile("pkg/protobuf/envgroup/envgroup.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 449 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x4b, 0xaf, 0xd2, 0x40,
+	0x14, 0x4e, 0x6f, 0x7b, 0x79, 0x9c, 0x7b, 0x9b, 0x6b, 0x26, 0x7a, 0x2d, 0x4d, 0x54, 0xac, 0x2e,
+	0x58, 0x81, 0xc1, 0xf8, 0xda, 0x10, 0x8d, 0xa0, 0x31, 0x31, 0x2e, 0x6a, 0x74, 0x4b, 0x06, 0x73,
+	0x40, 0x03, 0x4c, 0xc7, 0xce, 0xb4, 0x09, 0xff, 0xc2, 0x95, 0x3f, 0xc3, 0xa5, 0xbf, 0xcf, 0xcc,
+	0x4c, 0xa1, 0x0f, 0xb0, 0x98, 0xe0, 0x8a, 0x33, 0xdf, 0x99, 0x8f, 0xef, 0x9b, 0xf3, 0x28, 0x3c,
+	0xe4, 0xcb, 0xc5, 0x80, 0xc7, 0x91, 0x8c, 0x66, 0xc9, 0x7c, 0x80, 0x2c, 0x5d, 0xc4, 0x51, 0xc2,
+	0x77, 0x41, 0x5f, 0xa7, 0x48, 0x6b, 0x7b, 0x0e, 0x9e, 0x81, 0xfb, 0x3a, 0x46, 0x2a, 0x31, 0xc4,
+	0xef, 0x09, 0x0a, 0x49, 0x08, 0x38, 0x8c, 0xae, 0xd1, 0xb3, 0xba, 0x56, 0xaf, 0x1d, 0xea, 0x58,
+	0x61, 0x12, 0xe9, 0xda, 0x3b, 0x33, 0x98, 0x8a, 0x83, 0x07, 0xe0, 0x8e, 0x71, 0x85, 0xb5, 0xc4,
+	0xe0, 0xa7, 0x05, 0x97, 0xef, 0xbf, 0x09, 0x19, 0xa2, 0xe0, 0x11, 0x13, 0x48, 0x46, 0x00, 0xc8,
+	0xd2, 0xa9, 0xd6, 0x16, 0x9e, 0xd5, 0xb5, 0x7b, 0x17, 0xc3, 0x7b, 0xfd, 0x9d, 0xbb, 0xe2, 0xdd,
+	0xfe, 0x84, 0xa5, 0x6f, 0x15, 0x1a, 0xb6, 0x31, 0x8b, 0x84, 0xff, 0x06, 0x5a, 0x5b, 0xf8, 0x5f,
+	0x9d, 0x2a, 0x8c, 0x72, 0x2e, 0x3c, 0xbb, 0x6b, 0x2b, 0x4c, 0xc5, 0xc1, 0x7d, 0xb8, 0x78, 0xc7,
+	0xe6, 0x51, 0x9d, 0xf7, 0xdf, 0x16, 0x5c, 0x9a, 0x3b, 0x99, 0xf7, 0x13, 0xf4, 0xc8, 0x73, 0x50,
+	0x25, 0x9f, 0xa6, 0x34, 0x16, 0x9e, 0xa3, 0x5f, 0x7d, 0x27, 0x7f, 0x75, 0x51, 0x45, 0xbd, 0xfa,
+	0x33, 0x8d, 0xc3, 0x26, 0xea, 0x5f, 0xe1, 0x3f, 0x82, 0x86, 0x81, 0xc8, 0x0d, 0xb0, 0x97, 0xb8,
+	0xc9, 0xe4, 0x55, 0x48, 0x6e, 0xc2, 0x79, 0x4a, 0x57, 0x09, 0x66, 0xf2, 0xe6, 0x10, 0xfc, 0xb0,
+	0xc0, 0xfd, 0x88, 0x72, 0xc2, 0xd2, 0xba, 0x9e, 0xbe, 0x28, 0x38, 0x3a, 0xd3, 0x8e, 0xee, 0xe6,
+	0x8e, 0x4a, 0xf4, 0xff, 0x60, 0xe9, 0x25, 0x5c, 0x7d, 0x62, 0xe2, 0xa8, 0xa7, 0x4e, 0xc5, 0x53,
+	0x7b, 0xa7, 0x19, 0x8c, 0xc0, 0x7d, 0x25, 0x25, 0xfd, 0xf2, 0xf5, 0x08, 0x9f, 0x72, 0x3e, 0xd5,
+	0xb8, 0xd1, 0x6f, 0x52, 0xce, 0x3f, 0xa8, 0x6e, 0x8e, 0xd4, 0xb8, 0x9e, 0xc0, 0x6f, 0xc2, 0xf9,
+	0x64, 0xcd, 0xe5, 0x66, 0xf8, 0xcb, 0x2e, 0x8c, 0xe0, 0x10, 0x1a, 0x66, 0x7b, 0xc8, 0xed, 0xbc,
+	0x78, 0xa5, 0x7d, 0xf2, 0xaf, 0xf2, 0x84, 0xfe, 0x03, 0xc5, 0x31, 0x8b, 0x53, 0xe4, 0x94, 0x56,
+	0x69, 0x9f, 0x33, 0x00, 0x47, 0xad, 0x06, 0xa9, 0x26, 0xfc, 0xeb, 0xc3, 0xbb, 0x43, 0x9e, 0x80,
+	0xa3, 0xa6, 0x8a, 0xdc, 0xaa, 0x4e, 0x99, 0x11, 0xb8, 0x3e, 0x3c, 0x7c, 0xca, 0x9b, 0x69, 0x7d,
+	0xd1, 0x5b, 0x69, 0x18, 0xf6, 0xbd, 0x3d, 0x85, 0xd6, 0xb6, 0xb7, 0xa4, 0x93, 0x27, 0x2b, 0xfd,
+	0x3e, 0x58, 0x07, 0xd3, 0xd1, 0xa2, 0x56, 0xa9, 0xc7, 0x7f, 0xa9, 0x5d, 0x95, 0x33, 0xc6, 0x3a,
+	0xce, 0xac, 0xa1, 0x3f, 0x79, 0x8f, 0xff, 0x0c, 0x00, 0x54, 0x16, 0x43, 0xfc, 0x1a, 0x05, 0x00,
+	0x00,
+}
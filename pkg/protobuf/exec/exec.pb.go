@@ -11,6 +11,14 @@ It is generated from these files:
 It has these top-level messages:
 	CommandRequest
 	CommandResponse
+	RunRequest
+	RunResponse
+	ShellRequest
+	ShellResponse
+	CopyFromRequest
+	CopyFromResponse
+	CopyToRequest
+	CopyToResponse
 */
 package exec
 
@@ -74,9 +82,241 @@ func (m *CommandResponse) GetText() string {
 	return ""
 }
 
+type RunRequest struct {
+	AppName string   `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Command []string `protobuf:"bytes,2,rep,name=command" json:"command,omitempty"`
+}
+
+func (m *RunRequest) Reset()                    { *m = RunRequest{} }
+func (m *RunRequest) String() string            { return proto.CompactTextString(m) }
+func (*RunRequest) ProtoMessage()               {}
+func (*RunRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *RunRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *RunRequest) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+type RunResponse struct {
+	Text     string `protobuf:"bytes,1,opt,name=text" json:"text,omitempty"`
+	Finished bool   `protobuf:"varint,2,opt,name=finished" json:"finished,omitempty"`
+	ExitCode int32  `protobuf:"varint,3,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+}
+
+func (m *RunResponse) Reset()                    { *m = RunResponse{} }
+func (m *RunResponse) String() string            { return proto.CompactTextString(m) }
+func (*RunResponse) ProtoMessage()               {}
+func (*RunResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *RunResponse) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *RunResponse) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *RunResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type ShellRequest struct {
+	AppName string   `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Command []string `protobuf:"bytes,2,rep,name=command" json:"command,omitempty"`
+	Tty     bool     `protobuf:"varint,3,opt,name=tty" json:"tty,omitempty"`
+	Stdin   []byte   `protobuf:"bytes,4,opt,name=stdin" json:"stdin,omitempty"`
+	Width   uint32   `protobuf:"varint,5,opt,name=width" json:"width,omitempty"`
+	Height  uint32   `protobuf:"varint,6,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *ShellRequest) Reset()                    { *m = ShellRequest{} }
+func (m *ShellRequest) String() string            { return proto.CompactTextString(m) }
+func (*ShellRequest) ProtoMessage()               {}
+func (*ShellRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ShellRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *ShellRequest) GetCommand() []string {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *ShellRequest) GetTty() bool {
+	if m != nil {
+		return m.Tty
+	}
+	return false
+}
+
+func (m *ShellRequest) GetStdin() []byte {
+	if m != nil {
+		return m.Stdin
+	}
+	return nil
+}
+
+func (m *ShellRequest) GetWidth() uint32 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ShellRequest) GetHeight() uint32 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type ShellResponse struct {
+	Stdout   []byte `protobuf:"bytes,1,opt,name=stdout" json:"stdout,omitempty"`
+	Finished bool   `protobuf:"varint,2,opt,name=finished" json:"finished,omitempty"`
+	ExitCode int32  `protobuf:"varint,3,opt,name=exit_code,json=exitCode" json:"exit_code,omitempty"`
+}
+
+func (m *ShellResponse) Reset()                    { *m = ShellResponse{} }
+func (m *ShellResponse) String() string            { return proto.CompactTextString(m) }
+func (*ShellResponse) ProtoMessage()               {}
+func (*ShellResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *ShellResponse) GetStdout() []byte {
+	if m != nil {
+		return m.Stdout
+	}
+	return nil
+}
+
+func (m *ShellResponse) GetFinished() bool {
+	if m != nil {
+		return m.Finished
+	}
+	return false
+}
+
+func (m *ShellResponse) GetExitCode() int32 {
+	if m != nil {
+		return m.ExitCode
+	}
+	return 0
+}
+
+type CopyFromRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Path    string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+}
+
+func (m *CopyFromRequest) Reset()                    { *m = CopyFromRequest{} }
+func (m *CopyFromRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyFromRequest) ProtoMessage()               {}
+func (*CopyFromRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *CopyFromRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *CopyFromRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type CopyFromResponse struct {
+	Data []byte `protobuf:"bytes,1,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *CopyFromResponse) Reset()                    { *m = CopyFromResponse{} }
+func (m *CopyFromResponse) String() string            { return proto.CompactTextString(m) }
+func (*CopyFromResponse) ProtoMessage()               {}
+func (*CopyFromResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *CopyFromResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CopyToRequest struct {
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName" json:"app_name,omitempty"`
+	Path    string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	Data    []byte `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+}
+
+func (m *CopyToRequest) Reset()                    { *m = CopyToRequest{} }
+func (m *CopyToRequest) String() string            { return proto.CompactTextString(m) }
+func (*CopyToRequest) ProtoMessage()               {}
+func (*CopyToRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CopyToRequest) GetAppName() string {
+	if m != nil {
+		return m.AppName
+	}
+	return ""
+}
+
+func (m *CopyToRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CopyToRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CopyToResponse struct {
+}
+
+func (m *CopyToResponse) Reset()                    { *m = CopyToResponse{} }
+func (m *CopyToResponse) String() string            { return proto.CompactTextString(m) }
+func (*CopyToResponse) ProtoMessage()               {}
+func (*CopyToResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
 func init() {
 	proto.RegisterType((*CommandRequest)(nil), "exec.CommandRequest")
 	proto.RegisterType((*CommandResponse)(nil), "exec.CommandResponse")
+	proto.RegisterType((*RunRequest)(nil), "exec.RunRequest")
+	proto.RegisterType((*RunResponse)(nil), "exec.RunResponse")
+	proto.RegisterType((*ShellRequest)(nil), "exec.ShellRequest")
+	proto.RegisterType((*ShellResponse)(nil), "exec.ShellResponse")
+	proto.RegisterType((*CopyFromRequest)(nil), "exec.CopyFromRequest")
+	proto.RegisterType((*CopyFromResponse)(nil), "exec.CopyFromResponse")
+	proto.RegisterType((*CopyToRequest)(nil), "exec.CopyToRequest")
+	proto.RegisterType((*CopyToResponse)(nil), "exec.CopyToResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -91,6 +331,10 @@ const _ = grpc.SupportPackageIsVersion4
 
 type ExecClient interface {
 	Command(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (Exec_CommandClient, error)
+	Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Exec_RunClient, error)
+	Shell(ctx context.Context, opts ...grpc.CallOption) (Exec_ShellClient, error)
+	CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Exec_CopyFromClient, error)
+	CopyTo(ctx context.Context, opts ...grpc.CallOption) (Exec_CopyToClient, error)
 }
 
 type execClient struct {
@@ -133,10 +377,143 @@ func (x *execCommandClient) Recv() (*CommandResponse, error) {
 	return m, nil
 }
 
+func (c *execClient) Run(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Exec_RunClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Exec_serviceDesc.Streams[1], c.cc, "/exec.Exec/Run", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execRunClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Exec_RunClient interface {
+	Recv() (*RunResponse, error)
+	grpc.ClientStream
+}
+
+type execRunClient struct {
+	grpc.ClientStream
+}
+
+func (x *execRunClient) Recv() (*RunResponse, error) {
+	m := new(RunResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *execClient) Shell(ctx context.Context, opts ...grpc.CallOption) (Exec_ShellClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Exec_serviceDesc.Streams[2], c.cc, "/exec.Exec/Shell", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execShellClient{stream}
+	return x, nil
+}
+
+type Exec_ShellClient interface {
+	Send(*ShellRequest) error
+	Recv() (*ShellResponse, error)
+	grpc.ClientStream
+}
+
+type execShellClient struct {
+	grpc.ClientStream
+}
+
+func (x *execShellClient) Send(m *ShellRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *execShellClient) Recv() (*ShellResponse, error) {
+	m := new(ShellResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *execClient) CopyFrom(ctx context.Context, in *CopyFromRequest, opts ...grpc.CallOption) (Exec_CopyFromClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Exec_serviceDesc.Streams[3], c.cc, "/exec.Exec/CopyFrom", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execCopyFromClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Exec_CopyFromClient interface {
+	Recv() (*CopyFromResponse, error)
+	grpc.ClientStream
+}
+
+type execCopyFromClient struct {
+	grpc.ClientStream
+}
+
+func (x *execCopyFromClient) Recv() (*CopyFromResponse, error) {
+	m := new(CopyFromResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *execClient) CopyTo(ctx context.Context, opts ...grpc.CallOption) (Exec_CopyToClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Exec_serviceDesc.Streams[4], c.cc, "/exec.Exec/CopyTo", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &execCopyToClient{stream}
+	return x, nil
+}
+
+type Exec_CopyToClient interface {
+	Send(*CopyToRequest) error
+	CloseAndRecv() (*CopyToResponse, error)
+	grpc.ClientStream
+}
+
+type execCopyToClient struct {
+	grpc.ClientStream
+}
+
+func (x *execCopyToClient) Send(m *CopyToRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *execCopyToClient) CloseAndRecv() (*CopyToResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(CopyToResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Exec service
 
 type ExecServer interface {
 	Command(*CommandRequest, Exec_CommandServer) error
+	Run(*RunRequest, Exec_RunServer) error
+	Shell(Exec_ShellServer) error
+	CopyFrom(*CopyFromRequest, Exec_CopyFromServer) error
+	CopyTo(Exec_CopyToServer) error
 }
 
 func RegisterExecServer(s *grpc.Server, srv ExecServer) {
@@ -164,6 +541,100 @@ func (x *execCommandServer) Send(m *CommandResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Exec_Run_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServer).Run(m, &execRunServer{stream})
+}
+
+type Exec_RunServer interface {
+	Send(*RunResponse) error
+	grpc.ServerStream
+}
+
+type execRunServer struct {
+	grpc.ServerStream
+}
+
+func (x *execRunServer) Send(m *RunResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Exec_Shell_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ExecServer).Shell(&execShellServer{stream})
+}
+
+type Exec_ShellServer interface {
+	Send(*ShellResponse) error
+	Recv() (*ShellRequest, error)
+	grpc.ServerStream
+}
+
+type execShellServer struct {
+	grpc.ServerStream
+}
+
+func (x *execShellServer) Send(m *ShellResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *execShellServer) Recv() (*ShellRequest, error) {
+	m := new(ShellRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Exec_CopyFrom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CopyFromRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExecServer).CopyFrom(m, &execCopyFromServer{stream})
+}
+
+type Exec_CopyFromServer interface {
+	Send(*CopyFromResponse) error
+	grpc.ServerStream
+}
+
+type execCopyFromServer struct {
+	grpc.ServerStream
+}
+
+func (x *execCopyFromServer) Send(m *CopyFromResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Exec_CopyTo_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ExecServer).CopyTo(&execCopyToServer{stream})
+}
+
+type Exec_CopyToServer interface {
+	SendAndClose(*CopyToResponse) error
+	Recv() (*CopyToRequest, error)
+	grpc.ServerStream
+}
+
+type execCopyToServer struct {
+	grpc.ServerStream
+}
+
+func (x *execCopyToServer) SendAndClose(m *CopyToResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *execCopyToServer) Recv() (*CopyToRequest, error) {
+	m := new(CopyToRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 var _Exec_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "exec.Exec",
 	HandlerType: (*ExecServer)(nil),
@@ -174,6 +645,27 @@ var _Exec_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Exec_Command_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Run",
+			Handler:       _Exec_Run_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Shell",
+			Handler:       _Exec_Shell_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "CopyFrom",
+			Handler:       _Exec_CopyFrom_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CopyTo",
+			Handler:       _Exec_CopyTo_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "pkg/protobuf/exec/exec.proto",
 }
@@ -181,16 +673,33 @@ var _Exec_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/exec/exec.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 170 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x29, 0xc8, 0x4e, 0xd7,
-	0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x4f, 0x2a, 0x4d, 0xd3, 0x4f, 0xad, 0x48, 0x4d, 0x06, 0x13, 0x7a,
-	0x60, 0x21, 0x21, 0x16, 0x10, 0x5b, 0xc9, 0x95, 0x8b, 0xcf, 0x39, 0x3f, 0x37, 0x37, 0x31, 0x2f,
-	0x25, 0x28, 0xb5, 0xb0, 0x34, 0xb5, 0xb8, 0x44, 0x48, 0x92, 0x8b, 0x23, 0xb1, 0xa0, 0x20, 0x3e,
-	0x2f, 0x31, 0x37, 0x55, 0x82, 0x51, 0x81, 0x51, 0x83, 0x33, 0x88, 0x3d, 0xb1, 0xa0, 0xc0, 0x2f,
-	0x31, 0x37, 0x55, 0x48, 0x82, 0x8b, 0x3d, 0x19, 0xa2, 0x58, 0x82, 0x49, 0x81, 0x19, 0x24, 0x03,
-	0xe5, 0x2a, 0xa9, 0x72, 0xf1, 0xc3, 0x8d, 0x29, 0x2e, 0xc8, 0xcf, 0x2b, 0x4e, 0x15, 0x12, 0xe2,
-	0x62, 0x29, 0x49, 0xad, 0x28, 0x81, 0x9a, 0x01, 0x66, 0x1b, 0x39, 0x70, 0xb1, 0xb8, 0x56, 0xa4,
-	0x26, 0x0b, 0x59, 0x70, 0xb1, 0x43, 0x95, 0x0b, 0x89, 0xe8, 0x81, 0xdd, 0x84, 0xea, 0x08, 0x29,
-	0x51, 0x34, 0x51, 0x88, 0x99, 0x06, 0x8c, 0x49, 0x6c, 0x60, 0xc7, 0x1b, 0x03, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0x8a, 0xe7, 0xd1, 0xc5, 0xdc, 0x00, 0x00, 0x00,
+	// 443 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xd5, 0xd6, 0x8e, 0xe3, 0x0c, 0x49, 0x09, 0xd3, 0x10, 0x19, 0xc3, 0xc1, 0xb2, 0x04, 0xf2,
+	0x01, 0xb5, 0x15, 0x1f, 0x12, 0x12, 0x17, 0x50, 0x55, 0x8e, 0x1c, 0x16, 0x4e, 0x5c, 0xca, 0x36,
+	0xde, 0xd6, 0x16, 0xb5, 0x77, 0xa9, 0xd7, 0xc2, 0xfd, 0x27, 0xf0, 0x6f, 0xd1, 0x7e, 0xc4, 0x8e,
+	0x2b, 0x21, 0xa1, 0xf6, 0x12, 0xcd, 0x7b, 0x3b, 0xfb, 0xde, 0xcc, 0xce, 0xc4, 0xf0, 0x4c, 0xfe,
+	0xb8, 0x3c, 0x92, 0xd7, 0x42, 0x89, 0xf3, 0xf6, 0xe2, 0x88, 0x77, 0x7c, 0x63, 0x7e, 0x0e, 0x0d,
+	0x85, 0xbe, 0x8e, 0xd3, 0x53, 0xd8, 0x3f, 0x11, 0x55, 0xc5, 0xea, 0x9c, 0xf2, 0x9f, 0x2d, 0x6f,
+	0x14, 0x3e, 0x81, 0x90, 0x49, 0x79, 0x56, 0xb3, 0x8a, 0x47, 0x24, 0x21, 0xd9, 0x8c, 0x4e, 0x99,
+	0x94, 0x9f, 0x59, 0xc5, 0x31, 0x82, 0xe9, 0xc6, 0x26, 0x47, 0x7b, 0x89, 0xa7, 0x4f, 0x1c, 0x4c,
+	0x9f, 0xc3, 0xc3, 0x5e, 0xa6, 0x91, 0xa2, 0x6e, 0x38, 0x22, 0xf8, 0x8a, 0x77, 0xca, 0x69, 0x98,
+	0x38, 0xfd, 0x08, 0x40, 0xdb, 0xfa, 0x5e, 0x4e, 0xdf, 0xe0, 0x81, 0x91, 0xf8, 0xb7, 0x0b, 0xc6,
+	0x10, 0x5e, 0x94, 0x75, 0xd9, 0x14, 0x5c, 0xdf, 0x26, 0x59, 0x48, 0x7b, 0x8c, 0x4f, 0x61, 0xc6,
+	0xbb, 0x52, 0x9d, 0x6d, 0x44, 0xce, 0x23, 0x2f, 0x21, 0xd9, 0x84, 0x86, 0x9a, 0x38, 0x11, 0x39,
+	0x4f, 0xff, 0x10, 0x98, 0x7f, 0x29, 0xf8, 0xd5, 0xd5, 0x7d, 0x2a, 0xc4, 0x25, 0x78, 0x4a, 0xdd,
+	0x18, 0xf1, 0x90, 0xea, 0x10, 0x57, 0x30, 0x69, 0x54, 0x5e, 0xd6, 0x91, 0x9f, 0x90, 0x6c, 0x4e,
+	0x2d, 0xd0, 0xec, 0xaf, 0x32, 0x57, 0x45, 0x34, 0x49, 0x48, 0xb6, 0xa0, 0x16, 0xe0, 0x1a, 0x82,
+	0x82, 0x97, 0x97, 0x85, 0x8a, 0x02, 0x43, 0x3b, 0x94, 0x7e, 0x87, 0x85, 0x2b, 0xcd, 0x75, 0xbe,
+	0x86, 0xa0, 0x51, 0xb9, 0x68, 0x6d, 0xef, 0x73, 0xea, 0xd0, 0xdd, 0xbb, 0xff, 0xa0, 0x67, 0x28,
+	0x6f, 0x3e, 0x5d, 0x8b, 0xea, 0x3f, 0xfa, 0x47, 0xf0, 0x25, 0x53, 0x85, 0xb1, 0x98, 0x51, 0x13,
+	0xa7, 0x2f, 0x60, 0x39, 0x28, 0x0c, 0x03, 0xca, 0x99, 0x62, 0xae, 0x48, 0x13, 0xa7, 0x14, 0x16,
+	0x3a, 0xef, 0xab, 0xb8, 0x9b, 0x4f, 0xaf, 0xe9, 0xed, 0x68, 0x2e, 0x61, 0x7f, 0xab, 0x69, 0x9d,
+	0x5f, 0xfd, 0xde, 0x03, 0xff, 0xb4, 0xe3, 0x1b, 0x7c, 0x07, 0x53, 0xb7, 0x9c, 0xb8, 0x3a, 0x34,
+	0xff, 0x80, 0xf1, 0xca, 0xc7, 0x8f, 0x6f, 0xb1, 0x56, 0xe0, 0x98, 0xe0, 0x4b, 0xf0, 0x68, 0x5b,
+	0xe3, 0xd2, 0x9e, 0x0f, 0xab, 0x1b, 0x3f, 0xda, 0x61, 0xfa, 0xec, 0x37, 0x30, 0x31, 0x23, 0x42,
+	0xb4, 0xa7, 0xbb, 0xab, 0x14, 0x1f, 0x8c, 0x38, 0x7b, 0x27, 0x23, 0xc7, 0x04, 0xdf, 0x43, 0xb8,
+	0x7d, 0x34, 0xec, 0x0b, 0x19, 0x8d, 0x21, 0x5e, 0xdf, 0xa6, 0x7b, 0xcb, 0xb7, 0x10, 0xd8, 0xae,
+	0xf1, 0x60, 0xc8, 0xe9, 0xdf, 0x35, 0x5e, 0x8d, 0xc9, 0xad, 0xeb, 0x79, 0x60, 0x3e, 0x01, 0xaf,
+	0xff, 0x0e, 0x00, 0x99, 0xac, 0x8b, 0xf0, 0x22, 0x04, 0x00, 0x00,
 }
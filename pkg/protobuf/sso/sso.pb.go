@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go.
+// source: pkg/protobuf/sso/sso.proto
+// DO NOT EDIT!
+
+/*
+Package sso is a generated protocol buffer package.
+
+It is generated from these files:
+	pkg/protobuf/sso/sso.proto
+
+It has these top-level messages:
+	BeginLoginRequest
+	BeginLoginResponse
+	LoginRequest
+	LoginResponse
+*/
+package sso
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type BeginLoginRequest struct {
+	RedirectUrl string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl" json:"redirect_url,omitempty"`
+	State       string `protobuf:"bytes,2,opt,name=state" json:"state,omitempty"`
+}
+
+func (m *BeginLoginRequest) Reset()                    { *m = BeginLoginRequest{} }
+func (m *BeginLoginRequest) String() string            { return proto.CompactTextString(m) }
+func (*BeginLoginRequest) ProtoMessage()               {}
+func (*BeginLoginRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *BeginLoginRequest) GetRedirectUrl() string {
+	if m != nil {
+		return m.RedirectUrl
+	}
+	return ""
+}
+
+func (m *BeginLoginRequest) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+type BeginLoginResponse struct {
+	AuthUrl string `protobuf:"bytes,1,opt,name=auth_url,json=authUrl" json:"auth_url,omitempty"`
+}
+
+func (m *BeginLoginResponse) Reset()                    { *m = BeginLoginResponse{} }
+func (m *BeginLoginResponse) String() string            { return proto.CompactTextString(m) }
+func (*BeginLoginResponse) ProtoMessage()               {}
+func (*BeginLoginResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *BeginLoginResponse) GetAuthUrl() string {
+	if m != nil {
+		return m.AuthUrl
+	}
+	return ""
+}
+
+type LoginRequest struct {
+	Code        string  `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	RedirectUrl string  `protobuf:"bytes,2,opt,name=redirect_url,json=redirectUrl" json:"redirect_url,omitempty"`
+	ExpiresIn   float64 `protobuf:"fixed64,3,opt,name=expires_in,json=expiresIn" json:"expires_in,omitempty"`
+}
+
+func (m *LoginRequest) Reset()                    { *m = LoginRequest{} }
+func (m *LoginRequest) String() string            { return proto.CompactTextString(m) }
+func (*LoginRequest) ProtoMessage()               {}
+func (*LoginRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *LoginRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *LoginRequest) GetRedirectUrl() string {
+	if m != nil {
+		return m.RedirectUrl
+	}
+	return ""
+}
+
+func (m *LoginRequest) GetExpiresIn() float64 {
+	if m != nil {
+		return m.ExpiresIn
+	}
+	return 0
+}
+
+type LoginResponse struct {
+	Token        string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	Email        string `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+	RefreshToken string `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+	ExpiresAt    int64  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *LoginResponse) Reset()                    { *m = LoginResponse{} }
+func (m *LoginResponse) String() string            { return proto.CompactTextString(m) }
+func (*LoginResponse) ProtoMessage()               {}
+func (*LoginResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *LoginResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *LoginResponse) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *LoginResponse) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
+func (m *LoginResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*BeginLoginRequest)(nil), "sso.BeginLoginRequest")
+	proto.RegisterType((*BeginLoginResponse)(nil), "sso.BeginLoginResponse")
+	proto.RegisterType((*LoginRequest)(nil), "sso.LoginRequest")
+	proto.RegisterType((*LoginResponse)(nil), "sso.LoginResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SSO service
+
+type SSOClient interface {
+	BeginLogin(ctx context.Context, in *BeginLoginRequest, opts ...grpc.CallOption) (*BeginLoginResponse, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+}
+
+type sSOClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSSOClient(cc *grpc.ClientConn) SSOClient {
+	return &sSOClient{cc}
+}
+
+func (c *sSOClient) BeginLogin(ctx context.Context, in *BeginLoginRequest, opts ...grpc.CallOption) (*BeginLoginResponse, error) {
+	out := new(BeginLoginResponse)
+	err := grpc.Invoke(ctx, "/sso.SSO/BeginLogin", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sSOClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := grpc.Invoke(ctx, "/sso.SSO/Login", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for SSO service
+
+type SSOServer interface {
+	BeginLogin(context.Context, *BeginLoginRequest) (*BeginLoginResponse, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+}
+
+func RegisterSSOServer(s *grpc.Server, srv SSOServer) {
+	s.RegisterService(&_SSO_serviceDesc, srv)
+}
+
+func _SSO_BeginLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SSOServer).BeginLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sso.SSO/BeginLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SSOServer).BeginLogin(ctx, req.(*BeginLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SSO_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SSOServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sso.SSO/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SSOServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _SSO_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sso.SSO",
+	HandlerType: (*SSOServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams:     []grpc.StreamDesc{},
+	Metadata:    "pkg/protobuf/sso/sso.proto",
+}
+
+func init() { proto.RegisterFile("pkg/protobuf/sso/sso.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 289 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xc1, 0x6b, 0xb3, 0x40,
+	0x10, 0xc5, 0x31, 0xc6, 0xef, 0x6b, 0xa6, 0xe6, 0x90, 0x25, 0xb4, 0x36, 0x50, 0xb0, 0xf6, 0xe2,
+	0x49, 0xa1, 0x3d, 0xf7, 0xd0, 0xde, 0x0a, 0x81, 0x82, 0x69, 0xce, 0x62, 0xe2, 0xc4, 0x2c, 0xb1,
+	0xbb, 0x76, 0x77, 0x85, 0xde, 0xfa, 0xaf, 0x17, 0x77, 0x37, 0xad, 0xe2, 0x41, 0xf0, 0xfd, 0x76,
+	0xde, 0xcc, 0x63, 0x06, 0x56, 0xcd, 0xa9, 0x4a, 0x1b, 0xc1, 0x15, 0xdf, 0xb5, 0x87, 0x54, 0x4a,
+	0xde, 0x7d, 0x89, 0x06, 0xc4, 0x95, 0x92, 0x47, 0x6b, 0x58, 0xbc, 0x60, 0x45, 0xd9, 0x9a, 0x57,
+	0x94, 0x65, 0xf8, 0xd9, 0xa2, 0x54, 0xe4, 0x0e, 0x7c, 0x81, 0x25, 0x15, 0xb8, 0x57, 0x79, 0x2b,
+	0xea, 0xc0, 0x09, 0x9d, 0x78, 0x96, 0x5d, 0x9e, 0xd9, 0x56, 0xd4, 0x64, 0x09, 0x9e, 0x54, 0x85,
+	0xc2, 0x60, 0xa2, 0xdf, 0x8c, 0x88, 0x52, 0x20, 0xfd, 0x6e, 0xb2, 0xe1, 0x4c, 0x22, 0xb9, 0x81,
+	0x8b, 0xa2, 0x55, 0xc7, 0x5e, 0xab, 0xff, 0x9d, 0xde, 0x8a, 0x3a, 0x2a, 0xc1, 0x1f, 0x4c, 0x26,
+	0x30, 0xdd, 0xf3, 0x12, 0x6d, 0x99, 0xfe, 0x1f, 0xa5, 0x99, 0x8c, 0xd3, 0xdc, 0x02, 0xe0, 0x57,
+	0x43, 0x05, 0xca, 0x9c, 0xb2, 0xc0, 0x0d, 0x9d, 0xd8, 0xc9, 0x66, 0x96, 0xbc, 0xb2, 0xe8, 0x1b,
+	0xe6, 0xc3, 0x44, 0x4b, 0xf0, 0x14, 0x3f, 0x21, 0xb3, 0x73, 0x8c, 0xe8, 0x28, 0x7e, 0x14, 0xf4,
+	0x3c, 0xc1, 0x08, 0x72, 0x0f, 0x73, 0x81, 0x07, 0x81, 0xf2, 0x98, 0x1b, 0x8f, 0xab, 0x5f, 0x7d,
+	0x0b, 0xdf, 0xb5, 0xb5, 0x17, 0xa0, 0x50, 0xc1, 0x34, 0x74, 0x62, 0xf7, 0x37, 0xc0, 0xb3, 0x7a,
+	0x50, 0xe0, 0x6e, 0x36, 0x6f, 0xe4, 0x09, 0xe0, 0x6f, 0x3d, 0xe4, 0x2a, 0xe9, 0x6e, 0x31, 0xda,
+	0xfe, 0xea, 0x7a, 0xc4, 0x6d, 0xea, 0x04, 0x3c, 0xe3, 0x5c, 0xe8, 0x8a, 0x81, 0x89, 0xf4, 0x91,
+	0xa9, 0xdf, 0xfd, 0xd3, 0x77, 0x7e, 0xfc, 0x19, 0x00, 0x2a, 0x38, 0x9c, 0x59, 0x05, 0x02, 0x00,
+	0x00,
+}
@@ -14,6 +14,27 @@ It has these top-level messages:
 	RemoveUserRequest
 	ListResponse
 	RenameRequest
+	SetDomainsRequest
+	SetApprovalRequest
+	AddFreezeWindowRequest
+	RemoveFreezeWindowRequest
+	ListFreezeWindowsRequest
+	ListFreezeWindowsResponse
+	ListUsersRequest
+	ListUsersResponse
+	SetRoleRequest
+	DeleteRequest
+	SetQuotaRequest
+	InfoRequest
+	InfoResponse
+	SetEnvRequest
+	UnsetEnvRequest
+	CreateTokenRequest
+	CreateTokenResponse
+	RevokeTokenRequest
+	ListTokensRequest
+	ListTokensResponse
+	SetTwoFactorRequiredRequest
 	Empty
 */
 package team
@@ -73,6 +94,7 @@ func (m *CreateRequest) GetUrl() string {
 type AddUserRequest struct {
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Role string `protobuf:"bytes,3,opt,name=role" json:"role,omitempty"`
 }
 
 func (m *AddUserRequest) Reset()                    { *m = AddUserRequest{} }
@@ -94,6 +116,13 @@ func (m *AddUserRequest) GetUser() string {
 	return ""
 }
 
+func (m *AddUserRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
 type RemoveUserRequest struct {
 	Team string `protobuf:"bytes,1,opt,name=team" json:"team,omitempty"`
 	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
@@ -159,10 +188,11 @@ func (m *ListResponse_User) GetEmail() string {
 }
 
 type ListResponse_Team struct {
-	Name  string               `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	Email string               `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
-	Url   string               `protobuf:"bytes,3,opt,name=url" json:"url,omitempty"`
-	Users []*ListResponse_User `protobuf:"bytes,4,rep,name=users" json:"users,omitempty"`
+	Name    string               `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Email   string               `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+	Url     string               `protobuf:"bytes,3,opt,name=url" json:"url,omitempty"`
+	Users   []*ListResponse_User `protobuf:"bytes,4,rep,name=users" json:"users,omitempty"`
+	Domains []string             `protobuf:"bytes,5,rep,name=domains" json:"domains,omitempty"`
 }
 
 func (m *ListResponse_Team) Reset()                    { *m = ListResponse_Team{} }
@@ -198,6 +228,13 @@ func (m *ListResponse_Team) GetUsers() []*ListResponse_User {
 	return nil
 }
 
+func (m *ListResponse_Team) GetDomains() []string {
+	if m != nil {
+		return m.Domains
+	}
+	return nil
+}
+
 type RenameRequest struct {
 	OldName string `protobuf:"bytes,1,opt,name=oldName" json:"oldName,omitempty"`
 	NewName string `protobuf:"bytes,2,opt,name=newName" json:"newName,omitempty"`
@@ -222,201 +259,1470 @@ func (m *RenameRequest) GetNewName() string {
 	return ""
 }
 
-type Empty struct {
+type SetDomainsRequest struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Domains []string `protobuf:"bytes,2,rep,name=domains" json:"domains,omitempty"`
 }
 
-func (m *Empty) Reset()                    { *m = Empty{} }
-func (m *Empty) String() string            { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (m *SetDomainsRequest) Reset()                    { *m = SetDomainsRequest{} }
+func (m *SetDomainsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetDomainsRequest) ProtoMessage()               {}
+func (*SetDomainsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
-func init() {
-	proto.RegisterType((*CreateRequest)(nil), "team.CreateRequest")
-	proto.RegisterType((*AddUserRequest)(nil), "team.AddUserRequest")
-	proto.RegisterType((*RemoveUserRequest)(nil), "team.RemoveUserRequest")
-	proto.RegisterType((*ListResponse)(nil), "team.ListResponse")
-	proto.RegisterType((*ListResponse_User)(nil), "team.ListResponse.User")
-	proto.RegisterType((*ListResponse_Team)(nil), "team.ListResponse.Team")
-	proto.RegisterType((*RenameRequest)(nil), "team.RenameRequest")
-	proto.RegisterType((*Empty)(nil), "team.Empty")
+func (m *SetDomainsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SetDomainsRequest) GetDomains() []string {
+	if m != nil {
+		return m.Domains
+	}
+	return nil
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+type SetApprovalRequest struct {
+	Name string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Apps []string `protobuf:"bytes,2,rep,name=apps" json:"apps,omitempty"`
+}
 
-// Client API for Team service
+func (m *SetApprovalRequest) Reset()                    { *m = SetApprovalRequest{} }
+func (m *SetApprovalRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetApprovalRequest) ProtoMessage()               {}
+func (*SetApprovalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
-type TeamClient interface {
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
-	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*Empty, error)
-	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error)
-	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*Empty, error)
-	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error)
+func (m *SetApprovalRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-type teamClient struct {
-	cc *grpc.ClientConn
+func (m *SetApprovalRequest) GetApps() []string {
+	if m != nil {
+		return m.Apps
+	}
+	return nil
 }
 
-func NewTeamClient(cc *grpc.ClientConn) TeamClient {
-	return &teamClient{cc}
+type AddFreezeWindowRequest struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Start  int64  `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	End    int64  `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason" json:"reason,omitempty"`
 }
 
-func (c *teamClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/team.Team/Create", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AddFreezeWindowRequest) Reset()                    { *m = AddFreezeWindowRequest{} }
+func (m *AddFreezeWindowRequest) String() string            { return proto.CompactTextString(m) }
+func (*AddFreezeWindowRequest) ProtoMessage()               {}
+func (*AddFreezeWindowRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *AddFreezeWindowRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return out, nil
+	return ""
 }
 
-func (c *teamClient) AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/team.Team/AddUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AddFreezeWindowRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	return out, nil
+	return 0
 }
 
-func (c *teamClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := grpc.Invoke(ctx, "/team.Team/List", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AddFreezeWindowRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	return out, nil
+	return 0
 }
 
-func (c *teamClient) RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/team.Team/RemoveUser", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *AddFreezeWindowRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return out, nil
+	return ""
 }
 
-func (c *teamClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := grpc.Invoke(ctx, "/team.Team/Rename", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type RemoveFreezeWindowRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Id   int64  `protobuf:"varint,2,opt,name=id" json:"id,omitempty"`
 }
 
-// Server API for Team service
+func (m *RemoveFreezeWindowRequest) Reset()                    { *m = RemoveFreezeWindowRequest{} }
+func (m *RemoveFreezeWindowRequest) String() string            { return proto.CompactTextString(m) }
+func (*RemoveFreezeWindowRequest) ProtoMessage()               {}
+func (*RemoveFreezeWindowRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
-type TeamServer interface {
-	Create(context.Context, *CreateRequest) (*Empty, error)
-	AddUser(context.Context, *AddUserRequest) (*Empty, error)
-	List(context.Context, *Empty) (*ListResponse, error)
-	RemoveUser(context.Context, *RemoveUserRequest) (*Empty, error)
-	Rename(context.Context, *RenameRequest) (*Empty, error)
+func (m *RemoveFreezeWindowRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
 }
 
-func RegisterTeamServer(s *grpc.Server, srv TeamServer) {
-	s.RegisterService(&_Team_serviceDesc, srv)
+func (m *RemoveFreezeWindowRequest) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
 }
 
-func _Team_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TeamServer).Create(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/team.Team/Create",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServer).Create(ctx, req.(*CreateRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type ListFreezeWindowsRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
 }
 
-func _Team_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(TeamServer).AddUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/team.Team/AddUser",
+func (m *ListFreezeWindowsRequest) Reset()                    { *m = ListFreezeWindowsRequest{} }
+func (m *ListFreezeWindowsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListFreezeWindowsRequest) ProtoMessage()               {}
+func (*ListFreezeWindowsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ListFreezeWindowsRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServer).AddUser(ctx, req.(*AddUserRequest))
+	return ""
+}
+
+type ListFreezeWindowsResponse struct {
+	Windows []*ListFreezeWindowsResponse_Window `protobuf:"bytes,1,rep,name=windows" json:"windows,omitempty"`
+}
+
+func (m *ListFreezeWindowsResponse) Reset()                    { *m = ListFreezeWindowsResponse{} }
+func (m *ListFreezeWindowsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListFreezeWindowsResponse) ProtoMessage()               {}
+func (*ListFreezeWindowsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *ListFreezeWindowsResponse) GetWindows() []*ListFreezeWindowsResponse_Window {
+	if m != nil {
+		return m.Windows
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Team_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+type ListFreezeWindowsResponse_Window struct {
+	Id     int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Start  int64  `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	End    int64  `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+	Reason string `protobuf:"bytes,4,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *ListFreezeWindowsResponse_Window) Reset()         { *m = ListFreezeWindowsResponse_Window{} }
+func (m *ListFreezeWindowsResponse_Window) String() string { return proto.CompactTextString(m) }
+func (*ListFreezeWindowsResponse_Window) ProtoMessage()    {}
+func (*ListFreezeWindowsResponse_Window) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{10, 0}
+}
+
+func (m *ListFreezeWindowsResponse_Window) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	if interceptor == nil {
-		return srv.(TeamServer).List(ctx, in)
+	return 0
+}
+
+func (m *ListFreezeWindowsResponse_Window) GetStart() int64 {
+	if m != nil {
+		return m.Start
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/team.Team/List",
+	return 0
+}
+
+func (m *ListFreezeWindowsResponse_Window) GetEnd() int64 {
+	if m != nil {
+		return m.End
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServer).List(ctx, req.(*Empty))
+	return 0
+}
+
+func (m *ListFreezeWindowsResponse_Window) GetReason() string {
+	if m != nil {
+		return m.Reason
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Team_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+type ListUsersRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ListUsersRequest) Reset()                    { *m = ListUsersRequest{} }
+func (m *ListUsersRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListUsersRequest) ProtoMessage()               {}
+func (*ListUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ListUsersRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	if interceptor == nil {
-		return srv.(TeamServer).RemoveUser(ctx, in)
+	return ""
+}
+
+type ListUsersResponse struct {
+	Users []*ListUsersResponse_User `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+}
+
+func (m *ListUsersResponse) Reset()                    { *m = ListUsersResponse{} }
+func (m *ListUsersResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListUsersResponse) ProtoMessage()               {}
+func (*ListUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ListUsersResponse) GetUsers() []*ListUsersResponse_User {
+	if m != nil {
+		return m.Users
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/team.Team/RemoveUser",
+	return nil
+}
+
+type ListUsersResponse_User struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+	Role  string `protobuf:"bytes,3,opt,name=role" json:"role,omitempty"`
+}
+
+func (m *ListUsersResponse_User) Reset()                    { *m = ListUsersResponse_User{} }
+func (m *ListUsersResponse_User) String() string            { return proto.CompactTextString(m) }
+func (*ListUsersResponse_User) ProtoMessage()               {}
+func (*ListUsersResponse_User) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
+
+func (m *ListUsersResponse_User) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServer).RemoveUser(ctx, req.(*RemoveUserRequest))
+	return ""
+}
+
+func (m *ListUsersResponse_User) GetEmail() string {
+	if m != nil {
+		return m.Email
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Team_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RenameRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *ListUsersResponse_User) GetRole() string {
+	if m != nil {
+		return m.Role
 	}
-	if interceptor == nil {
-		return srv.(TeamServer).Rename(ctx, in)
+	return ""
+}
+
+type SetRoleRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	Role string `protobuf:"bytes,3,opt,name=role" json:"role,omitempty"`
+}
+
+func (m *SetRoleRequest) Reset()                    { *m = SetRoleRequest{} }
+func (m *SetRoleRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetRoleRequest) ProtoMessage()               {}
+func (*SetRoleRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *SetRoleRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/team.Team/Rename",
+	return ""
+}
+
+func (m *SetRoleRequest) GetUser() string {
+	if m != nil {
+		return m.User
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TeamServer).Rename(ctx, req.(*RenameRequest))
+	return ""
+}
+
+func (m *SetRoleRequest) GetRole() string {
+	if m != nil {
+		return m.Role
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _Team_serviceDesc = grpc.ServiceDesc{
+type DeleteRequest struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Cascade bool   `protobuf:"varint,2,opt,name=cascade" json:"cascade,omitempty"`
+}
+
+func (m *DeleteRequest) Reset()                    { *m = DeleteRequest{} }
+func (m *DeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeleteRequest) ProtoMessage()               {}
+func (*DeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *DeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DeleteRequest) GetCascade() bool {
+	if m != nil {
+		return m.Cascade
+	}
+	return false
+}
+
+type SetQuotaRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Apps     int32  `protobuf:"varint,2,opt,name=apps" json:"apps,omitempty"`
+	Replicas int32  `protobuf:"varint,3,opt,name=replicas" json:"replicas,omitempty"`
+	Cpu      string `protobuf:"bytes,4,opt,name=cpu" json:"cpu,omitempty"`
+	Memory   string `protobuf:"bytes,5,opt,name=memory" json:"memory,omitempty"`
+}
+
+func (m *SetQuotaRequest) Reset()                    { *m = SetQuotaRequest{} }
+func (m *SetQuotaRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetQuotaRequest) ProtoMessage()               {}
+func (*SetQuotaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *SetQuotaRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetQuotaRequest) GetApps() int32 {
+	if m != nil {
+		return m.Apps
+	}
+	return 0
+}
+
+func (m *SetQuotaRequest) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *SetQuotaRequest) GetCpu() string {
+	if m != nil {
+		return m.Cpu
+	}
+	return ""
+}
+
+func (m *SetQuotaRequest) GetMemory() string {
+	if m != nil {
+		return m.Memory
+	}
+	return ""
+}
+
+type InfoRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *InfoRequest) Reset()                    { *m = InfoRequest{} }
+func (m *InfoRequest) String() string            { return proto.CompactTextString(m) }
+func (*InfoRequest) ProtoMessage()               {}
+func (*InfoRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *InfoRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type InfoResponse struct {
+	Name              string                 `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Email             string                 `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+	Url               string                 `protobuf:"bytes,3,opt,name=url" json:"url,omitempty"`
+	Domains           []string               `protobuf:"bytes,4,rep,name=domains" json:"domains,omitempty"`
+	Quota             *InfoResponse_Quota    `protobuf:"bytes,5,opt,name=quota" json:"quota,omitempty"`
+	Usage             *InfoResponse_Quota    `protobuf:"bytes,6,opt,name=usage" json:"usage,omitempty"`
+	EnvVars           []*InfoResponse_EnvVar `protobuf:"bytes,7,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+	TwoFactorRequired bool                   `protobuf:"varint,8,opt,name=two_factor_required,json=twoFactorRequired" json:"two_factor_required,omitempty"`
+}
+
+func (m *InfoResponse) Reset()                    { *m = InfoResponse{} }
+func (m *InfoResponse) String() string            { return proto.CompactTextString(m) }
+func (*InfoResponse) ProtoMessage()               {}
+func (*InfoResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *InfoResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *InfoResponse) GetDomains() []string {
+	if m != nil {
+		return m.Domains
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetQuota() *InfoResponse_Quota {
+	if m != nil {
+		return m.Quota
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetUsage() *InfoResponse_Quota {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetEnvVars() []*InfoResponse_EnvVar {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+func (m *InfoResponse) GetTwoFactorRequired() bool {
+	if m != nil {
+		return m.TwoFactorRequired
+	}
+	return false
+}
+
+type InfoResponse_Quota struct {
+	Apps     int32  `protobuf:"varint,1,opt,name=apps" json:"apps,omitempty"`
+	Replicas int32  `protobuf:"varint,2,opt,name=replicas" json:"replicas,omitempty"`
+	Cpu      string `protobuf:"bytes,3,opt,name=cpu" json:"cpu,omitempty"`
+	Memory   string `protobuf:"bytes,4,opt,name=memory" json:"memory,omitempty"`
+}
+
+func (m *InfoResponse_Quota) Reset()                    { *m = InfoResponse_Quota{} }
+func (m *InfoResponse_Quota) String() string            { return proto.CompactTextString(m) }
+func (*InfoResponse_Quota) ProtoMessage()               {}
+func (*InfoResponse_Quota) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 0} }
+
+func (m *InfoResponse_Quota) GetApps() int32 {
+	if m != nil {
+		return m.Apps
+	}
+	return 0
+}
+
+func (m *InfoResponse_Quota) GetReplicas() int32 {
+	if m != nil {
+		return m.Replicas
+	}
+	return 0
+}
+
+func (m *InfoResponse_Quota) GetCpu() string {
+	if m != nil {
+		return m.Cpu
+	}
+	return ""
+}
+
+func (m *InfoResponse_Quota) GetMemory() string {
+	if m != nil {
+		return m.Memory
+	}
+	return ""
+}
+
+type InfoResponse_EnvVar struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *InfoResponse_EnvVar) Reset()                    { *m = InfoResponse_EnvVar{} }
+func (m *InfoResponse_EnvVar) String() string            { return proto.CompactTextString(m) }
+func (*InfoResponse_EnvVar) ProtoMessage()               {}
+func (*InfoResponse_EnvVar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 1} }
+
+func (m *InfoResponse_EnvVar) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *InfoResponse_EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type SetEnvRequest struct {
+	Name    string                  `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	EnvVars []*SetEnvRequest_EnvVar `protobuf:"bytes,2,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *SetEnvRequest) Reset()                    { *m = SetEnvRequest{} }
+func (m *SetEnvRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetEnvRequest) ProtoMessage()               {}
+func (*SetEnvRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *SetEnvRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetEnvRequest) GetEnvVars() []*SetEnvRequest_EnvVar {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type SetEnvRequest_EnvVar struct {
+	Key   string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *SetEnvRequest_EnvVar) Reset()                    { *m = SetEnvRequest_EnvVar{} }
+func (m *SetEnvRequest_EnvVar) String() string            { return proto.CompactTextString(m) }
+func (*SetEnvRequest_EnvVar) ProtoMessage()               {}
+func (*SetEnvRequest_EnvVar) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18, 0} }
+
+func (m *SetEnvRequest_EnvVar) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *SetEnvRequest_EnvVar) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type UnsetEnvRequest struct {
+	Name    string   `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	EnvVars []string `protobuf:"bytes,2,rep,name=env_vars,json=envVars" json:"env_vars,omitempty"`
+}
+
+func (m *UnsetEnvRequest) Reset()                    { *m = UnsetEnvRequest{} }
+func (m *UnsetEnvRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnsetEnvRequest) ProtoMessage()               {}
+func (*UnsetEnvRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *UnsetEnvRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UnsetEnvRequest) GetEnvVars() []string {
+	if m != nil {
+		return m.EnvVars
+	}
+	return nil
+}
+
+type CreateTokenRequest struct {
+	Name             string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	TokenName        string `protobuf:"bytes,2,opt,name=token_name,json=tokenName" json:"token_name,omitempty"`
+	Role             string `protobuf:"bytes,3,opt,name=role" json:"role,omitempty"`
+	ExpiresInSeconds int64  `protobuf:"varint,4,opt,name=expires_in_seconds,json=expiresInSeconds" json:"expires_in_seconds,omitempty"`
+}
+
+func (m *CreateTokenRequest) Reset()                    { *m = CreateTokenRequest{} }
+func (m *CreateTokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateTokenRequest) ProtoMessage()               {}
+func (*CreateTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *CreateTokenRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateTokenRequest) GetTokenName() string {
+	if m != nil {
+		return m.TokenName
+	}
+	return ""
+}
+
+func (m *CreateTokenRequest) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *CreateTokenRequest) GetExpiresInSeconds() int64 {
+	if m != nil {
+		return m.ExpiresInSeconds
+	}
+	return 0
+}
+
+type CreateTokenResponse struct {
+	Token     string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	User      string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *CreateTokenResponse) Reset()                    { *m = CreateTokenResponse{} }
+func (m *CreateTokenResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateTokenResponse) ProtoMessage()               {}
+func (*CreateTokenResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *CreateTokenResponse) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *CreateTokenResponse) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *CreateTokenResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type RevokeTokenRequest struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	TokenName string `protobuf:"bytes,2,opt,name=token_name,json=tokenName" json:"token_name,omitempty"`
+}
+
+func (m *RevokeTokenRequest) Reset()                    { *m = RevokeTokenRequest{} }
+func (m *RevokeTokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeTokenRequest) ProtoMessage()               {}
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *RevokeTokenRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *RevokeTokenRequest) GetTokenName() string {
+	if m != nil {
+		return m.TokenName
+	}
+	return ""
+}
+
+type ListTokensRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *ListTokensRequest) Reset()                    { *m = ListTokensRequest{} }
+func (m *ListTokensRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListTokensRequest) ProtoMessage()               {}
+func (*ListTokensRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *ListTokensRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type ListTokensResponse struct {
+	Tokens []*ListTokensResponse_Token `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+}
+
+func (m *ListTokensResponse) Reset()                    { *m = ListTokensResponse{} }
+func (m *ListTokensResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListTokensResponse) ProtoMessage()               {}
+func (*ListTokensResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *ListTokensResponse) GetTokens() []*ListTokensResponse_Token {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type ListTokensResponse_Token struct {
+	Name      string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Role      string `protobuf:"bytes,2,opt,name=role" json:"role,omitempty"`
+	User      string `protobuf:"bytes,3,opt,name=user" json:"user,omitempty"`
+	CreatedAt int64  `protobuf:"varint,4,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	ExpiresAt int64  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *ListTokensResponse_Token) Reset()                    { *m = ListTokensResponse_Token{} }
+func (m *ListTokensResponse_Token) String() string            { return proto.CompactTextString(m) }
+func (*ListTokensResponse_Token) ProtoMessage()               {}
+func (*ListTokensResponse_Token) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24, 0} }
+
+func (m *ListTokensResponse_Token) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ListTokensResponse_Token) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ListTokensResponse_Token) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *ListTokensResponse_Token) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *ListTokensResponse_Token) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type SetTwoFactorRequiredRequest struct {
+	Name     string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Required bool   `protobuf:"varint,2,opt,name=required" json:"required,omitempty"`
+}
+
+func (m *SetTwoFactorRequiredRequest) Reset()                    { *m = SetTwoFactorRequiredRequest{} }
+func (m *SetTwoFactorRequiredRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetTwoFactorRequiredRequest) ProtoMessage()               {}
+func (*SetTwoFactorRequiredRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *SetTwoFactorRequiredRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SetTwoFactorRequiredRequest) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()                    { *m = Empty{} }
+func (m *Empty) String() string            { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()               {}
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func init() {
+	proto.RegisterType((*CreateRequest)(nil), "team.CreateRequest")
+	proto.RegisterType((*AddUserRequest)(nil), "team.AddUserRequest")
+	proto.RegisterType((*RemoveUserRequest)(nil), "team.RemoveUserRequest")
+	proto.RegisterType((*ListResponse)(nil), "team.ListResponse")
+	proto.RegisterType((*ListResponse_User)(nil), "team.ListResponse.User")
+	proto.RegisterType((*ListResponse_Team)(nil), "team.ListResponse.Team")
+	proto.RegisterType((*RenameRequest)(nil), "team.RenameRequest")
+	proto.RegisterType((*SetDomainsRequest)(nil), "team.SetDomainsRequest")
+	proto.RegisterType((*SetApprovalRequest)(nil), "team.SetApprovalRequest")
+	proto.RegisterType((*AddFreezeWindowRequest)(nil), "team.AddFreezeWindowRequest")
+	proto.RegisterType((*RemoveFreezeWindowRequest)(nil), "team.RemoveFreezeWindowRequest")
+	proto.RegisterType((*ListFreezeWindowsRequest)(nil), "team.ListFreezeWindowsRequest")
+	proto.RegisterType((*ListFreezeWindowsResponse)(nil), "team.ListFreezeWindowsResponse")
+	proto.RegisterType((*ListFreezeWindowsResponse_Window)(nil), "team.ListFreezeWindowsResponse.Window")
+	proto.RegisterType((*ListUsersRequest)(nil), "team.ListUsersRequest")
+	proto.RegisterType((*ListUsersResponse)(nil), "team.ListUsersResponse")
+	proto.RegisterType((*ListUsersResponse_User)(nil), "team.ListUsersResponse.User")
+	proto.RegisterType((*SetRoleRequest)(nil), "team.SetRoleRequest")
+	proto.RegisterType((*DeleteRequest)(nil), "team.DeleteRequest")
+	proto.RegisterType((*SetQuotaRequest)(nil), "team.SetQuotaRequest")
+	proto.RegisterType((*InfoRequest)(nil), "team.InfoRequest")
+	proto.RegisterType((*InfoResponse)(nil), "team.InfoResponse")
+	proto.RegisterType((*InfoResponse_Quota)(nil), "team.InfoResponse.Quota")
+	proto.RegisterType((*InfoResponse_EnvVar)(nil), "team.InfoResponse.EnvVar")
+	proto.RegisterType((*SetEnvRequest)(nil), "team.SetEnvRequest")
+	proto.RegisterType((*SetEnvRequest_EnvVar)(nil), "team.SetEnvRequest.EnvVar")
+	proto.RegisterType((*UnsetEnvRequest)(nil), "team.UnsetEnvRequest")
+	proto.RegisterType((*CreateTokenRequest)(nil), "team.CreateTokenRequest")
+	proto.RegisterType((*CreateTokenResponse)(nil), "team.CreateTokenResponse")
+	proto.RegisterType((*RevokeTokenRequest)(nil), "team.RevokeTokenRequest")
+	proto.RegisterType((*ListTokensRequest)(nil), "team.ListTokensRequest")
+	proto.RegisterType((*ListTokensResponse)(nil), "team.ListTokensResponse")
+	proto.RegisterType((*ListTokensResponse_Token)(nil), "team.ListTokensResponse.Token")
+	proto.RegisterType((*SetTwoFactorRequiredRequest)(nil), "team.SetTwoFactorRequiredRequest")
+	proto.RegisterType((*Empty)(nil), "team.Empty")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Team service
+
+type TeamClient interface {
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*Empty, error)
+	List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error)
+	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*Empty, error)
+	Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetDomains(ctx context.Context, in *SetDomainsRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetApproval(ctx context.Context, in *SetApprovalRequest, opts ...grpc.CallOption) (*Empty, error)
+	AddFreezeWindow(ctx context.Context, in *AddFreezeWindowRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveFreezeWindow(ctx context.Context, in *RemoveFreezeWindowRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListFreezeWindows(ctx context.Context, in *ListFreezeWindowsRequest, opts ...grpc.CallOption) (*ListFreezeWindowsResponse, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	SetRole(ctx context.Context, in *SetRoleRequest, opts ...grpc.CallOption) (*Empty, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetQuota(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*Empty, error)
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error)
+	RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error)
+	SetTwoFactorRequired(ctx context.Context, in *SetTwoFactorRequiredRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type teamClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTeamClient(cc *grpc.ClientConn) TeamClient {
+	return &teamClient{cc}
+}
+
+func (c *teamClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) AddUser(ctx context.Context, in *AddUserRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/AddUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) List(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := grpc.Invoke(ctx, "/team.Team/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/RemoveUser", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) Rename(ctx context.Context, in *RenameRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/Rename", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetDomains(ctx context.Context, in *SetDomainsRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetDomains", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetApproval(ctx context.Context, in *SetApprovalRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetApproval", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) AddFreezeWindow(ctx context.Context, in *AddFreezeWindowRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/AddFreezeWindow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) RemoveFreezeWindow(ctx context.Context, in *RemoveFreezeWindowRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/RemoveFreezeWindow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) ListFreezeWindows(ctx context.Context, in *ListFreezeWindowsRequest, opts ...grpc.CallOption) (*ListFreezeWindowsResponse, error) {
+	out := new(ListFreezeWindowsResponse)
+	err := grpc.Invoke(ctx, "/team.Team/ListFreezeWindows", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := grpc.Invoke(ctx, "/team.Team/ListUsers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetRole(ctx context.Context, in *SetRoleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetRole", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetQuota(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetQuota", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := grpc.Invoke(ctx, "/team.Team/Info", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetEnv(ctx context.Context, in *SetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) UnsetEnv(ctx context.Context, in *UnsetEnvRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/UnsetEnv", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) CreateToken(ctx context.Context, in *CreateTokenRequest, opts ...grpc.CallOption) (*CreateTokenResponse, error) {
+	out := new(CreateTokenResponse)
+	err := grpc.Invoke(ctx, "/team.Team/CreateToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) RevokeToken(ctx context.Context, in *RevokeTokenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/RevokeToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) ListTokens(ctx context.Context, in *ListTokensRequest, opts ...grpc.CallOption) (*ListTokensResponse, error) {
+	out := new(ListTokensResponse)
+	err := grpc.Invoke(ctx, "/team.Team/ListTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *teamClient) SetTwoFactorRequired(ctx context.Context, in *SetTwoFactorRequiredRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/team.Team/SetTwoFactorRequired", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Team service
+
+type TeamServer interface {
+	Create(context.Context, *CreateRequest) (*Empty, error)
+	AddUser(context.Context, *AddUserRequest) (*Empty, error)
+	List(context.Context, *Empty) (*ListResponse, error)
+	RemoveUser(context.Context, *RemoveUserRequest) (*Empty, error)
+	Rename(context.Context, *RenameRequest) (*Empty, error)
+	SetDomains(context.Context, *SetDomainsRequest) (*Empty, error)
+	SetApproval(context.Context, *SetApprovalRequest) (*Empty, error)
+	AddFreezeWindow(context.Context, *AddFreezeWindowRequest) (*Empty, error)
+	RemoveFreezeWindow(context.Context, *RemoveFreezeWindowRequest) (*Empty, error)
+	ListFreezeWindows(context.Context, *ListFreezeWindowsRequest) (*ListFreezeWindowsResponse, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	SetRole(context.Context, *SetRoleRequest) (*Empty, error)
+	Delete(context.Context, *DeleteRequest) (*Empty, error)
+	SetQuota(context.Context, *SetQuotaRequest) (*Empty, error)
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	SetEnv(context.Context, *SetEnvRequest) (*Empty, error)
+	UnsetEnv(context.Context, *UnsetEnvRequest) (*Empty, error)
+	CreateToken(context.Context, *CreateTokenRequest) (*CreateTokenResponse, error)
+	RevokeToken(context.Context, *RevokeTokenRequest) (*Empty, error)
+	ListTokens(context.Context, *ListTokensRequest) (*ListTokensResponse, error)
+	SetTwoFactorRequired(context.Context, *SetTwoFactorRequiredRequest) (*Empty, error)
+}
+
+func RegisterTeamServer(s *grpc.Server, srv TeamServer) {
+	s.RegisterService(&_Team_serviceDesc, srv)
+}
+
+func _Team_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_AddUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).AddUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/AddUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).AddUser(ctx, req.(*AddUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).List(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_RemoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).RemoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/RemoveUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).RemoveUser(ctx, req.(*RemoveUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_Rename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).Rename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/Rename",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).Rename(ctx, req.(*RenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetDomains",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetDomains(ctx, req.(*SetDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetApproval",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetApproval(ctx, req.(*SetApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_AddFreezeWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFreezeWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).AddFreezeWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/AddFreezeWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).AddFreezeWindow(ctx, req.(*AddFreezeWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_RemoveFreezeWindow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFreezeWindowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).RemoveFreezeWindow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/RemoveFreezeWindow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).RemoveFreezeWindow(ctx, req.(*RemoveFreezeWindowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_ListFreezeWindows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFreezeWindowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).ListFreezeWindows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/ListFreezeWindows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).ListFreezeWindows(ctx, req.(*ListFreezeWindowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetRole(ctx, req.(*SetRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetQuota_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetQuotaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetQuota(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetQuota",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetQuota(ctx, req.(*SetQuotaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetEnv(ctx, req.(*SetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_UnsetEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsetEnvRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).UnsetEnv(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/UnsetEnv",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).UnsetEnv(ctx, req.(*UnsetEnvRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_CreateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).CreateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/CreateToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).CreateToken(ctx, req.(*CreateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_RevokeToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).RevokeToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/RevokeToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).RevokeToken(ctx, req.(*RevokeTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_ListTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).ListTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/ListTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).ListTokens(ctx, req.(*ListTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Team_SetTwoFactorRequired_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTwoFactorRequiredRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TeamServer).SetTwoFactorRequired(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/team.Team/SetTwoFactorRequired",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TeamServer).SetTwoFactorRequired(ctx, req.(*SetTwoFactorRequiredRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Team_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "team.Team",
 	HandlerType: (*TeamServer)(nil),
 	Methods: []grpc.MethodDesc{
@@ -440,6 +1746,70 @@ var _Team_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Rename",
 			Handler:    _Team_Rename_Handler,
 		},
+		{
+			MethodName: "SetDomains",
+			Handler:    _Team_SetDomains_Handler,
+		},
+		{
+			MethodName: "SetApproval",
+			Handler:    _Team_SetApproval_Handler,
+		},
+		{
+			MethodName: "AddFreezeWindow",
+			Handler:    _Team_AddFreezeWindow_Handler,
+		},
+		{
+			MethodName: "RemoveFreezeWindow",
+			Handler:    _Team_RemoveFreezeWindow_Handler,
+		},
+		{
+			MethodName: "ListFreezeWindows",
+			Handler:    _Team_ListFreezeWindows_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Team_ListUsers_Handler,
+		},
+		{
+			MethodName: "SetRole",
+			Handler:    _Team_SetRole_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Team_Delete_Handler,
+		},
+		{
+			MethodName: "SetQuota",
+			Handler:    _Team_SetQuota_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Team_Info_Handler,
+		},
+		{
+			MethodName: "SetEnv",
+			Handler:    _Team_SetEnv_Handler,
+		},
+		{
+			MethodName: "UnsetEnv",
+			Handler:    _Team_UnsetEnv_Handler,
+		},
+		{
+			MethodName: "CreateToken",
+			Handler:    _Team_CreateToken_Handler,
+		},
+		{
+			MethodName: "RevokeToken",
+			Handler:    _Team_RevokeToken_Handler,
+		},
+		{
+			MethodName: "ListTokens",
+			Handler:    _Team_ListTokens_Handler,
+		},
+		{
+			MethodName: "SetTwoFactorRequired",
+			Handler:    _Team_SetTwoFactorRequired_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/protobuf/team/team.proto",
@@ -448,27 +1818,84 @@ var _Team_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/team/team.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 346 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0x41, 0x4b, 0xf3, 0x40,
-	0x10, 0x25, 0x5f, 0xb7, 0x2d, 0xdf, 0xd4, 0x8a, 0x8e, 0x05, 0x97, 0xe0, 0xa1, 0xe4, 0x62, 0x29,
-	0xda, 0x4a, 0xbd, 0x08, 0x9e, 0xa4, 0x78, 0x52, 0x3c, 0x2c, 0xf5, 0x07, 0xa4, 0x74, 0x94, 0x62,
-	0x36, 0x49, 0xb3, 0x1b, 0xc5, 0xbf, 0xeb, 0xcd, 0x7f, 0x21, 0xbb, 0x9b, 0xd0, 0xae, 0x55, 0x11,
-	0x2f, 0x61, 0xf6, 0xe5, 0xcd, 0xcc, 0x9b, 0x37, 0x03, 0x47, 0xf9, 0xd3, 0xe3, 0x38, 0x2f, 0x32,
-	0x9d, 0xcd, 0xcb, 0x87, 0xb1, 0xa6, 0x58, 0xda, 0xcf, 0xc8, 0x42, 0xc8, 0x4c, 0x1c, 0xdd, 0x40,
-	0x77, 0x5a, 0x50, 0xac, 0x49, 0xd0, 0xaa, 0x24, 0xa5, 0x11, 0x81, 0xa5, 0xb1, 0x24, 0x1e, 0xf4,
-	0x83, 0xc1, 0x7f, 0x61, 0x63, 0xec, 0x41, 0x93, 0x64, 0xbc, 0x4c, 0xf8, 0x3f, 0x0b, 0xba, 0x07,
-	0xee, 0x41, 0xa3, 0x2c, 0x12, 0xde, 0xb0, 0x98, 0x09, 0xa3, 0x0b, 0xd8, 0xbd, 0x5a, 0x2c, 0xee,
-	0x15, 0x15, 0x3f, 0x55, 0x43, 0x60, 0xa5, 0xa2, 0xa2, 0x2a, 0x66, 0xe3, 0xe8, 0x12, 0xf6, 0x05,
-	0xc9, 0xec, 0x99, 0x3e, 0x25, 0x1b, 0x8d, 0x75, 0xb2, 0x89, 0xbf, 0x4c, 0x7e, 0x0b, 0x60, 0xe7,
-	0x76, 0xa9, 0xb4, 0x20, 0x95, 0x67, 0xa9, 0x22, 0x3c, 0x85, 0xa6, 0x21, 0x2b, 0x1e, 0xf4, 0x1b,
-	0x83, 0xce, 0xe4, 0x70, 0x64, 0xc7, 0xde, 0xa4, 0x8c, 0x66, 0x14, 0x4b, 0xe1, 0x58, 0xe1, 0x19,
-	0x30, 0xd3, 0xf6, 0xf7, 0xa3, 0x87, 0x2b, 0x60, 0xb3, 0x4a, 0xcd, 0x5f, 0xcd, 0x32, 0x22, 0x8d,
-	0x7a, 0xc5, 0xd9, 0xb7, 0x22, 0xad, 0x19, 0x8e, 0x15, 0x4d, 0xa1, 0x2b, 0xc8, 0x34, 0xa8, 0xdd,
-	0xe1, 0xd0, 0xce, 0x92, 0xc5, 0xdd, 0xba, 0x7d, 0xfd, 0x34, 0x7f, 0x52, 0x7a, 0xb1, 0x7f, 0x9c,
-	0x86, 0xfa, 0x19, 0xb5, 0xa1, 0x79, 0x2d, 0x73, 0xfd, 0x3a, 0x79, 0x0f, 0xaa, 0x09, 0x86, 0xd0,
-	0x72, 0xfb, 0xc7, 0x03, 0x27, 0xc0, 0xbb, 0x86, 0xb0, 0xe3, 0x40, 0x9b, 0x84, 0x27, 0xd0, 0xae,
-	0xd6, 0x8b, 0x3d, 0x87, 0xfb, 0xdb, 0xf6, 0xd9, 0xc7, 0xc0, 0xcc, 0x30, 0xb8, 0x09, 0x86, 0xb8,
-	0x3d, 0x25, 0x4e, 0x00, 0xd6, 0xbb, 0xc7, 0xca, 0x87, 0xad, 0x6b, 0xf0, 0x8b, 0x0f, 0xa1, 0xe5,
-	0xdc, 0xa8, 0x65, 0x7b, 0xde, 0x78, 0xdc, 0x79, 0xcb, 0xde, 0xfb, 0xf9, 0x47, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x1f, 0x1a, 0x40, 0x2f, 0x0f, 0x03, 0x00, 0x00,
+	// 1250 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x06, 0x25, 0x52, 0x3f, 0xa3, 0x38, 0xb1, 0xd7, 0xae, 0x4d, 0xb3, 0x4e, 0xe2, 0xf0, 0x90,
+	0x18, 0x41, 0xa2, 0x04, 0xaa, 0xdb, 0x4b, 0x53, 0x24, 0x4a, 0x6c, 0x17, 0x69, 0xdd, 0x00, 0xa5,
+	0xe4, 0xb6, 0xa7, 0x0a, 0x8c, 0x38, 0x0e, 0x04, 0x4b, 0xa4, 0x4c, 0xae, 0xe4, 0xba, 0x97, 0x22,
+	0xc7, 0xa2, 0xed, 0xf3, 0xf4, 0xd8, 0x57, 0xe8, 0x0b, 0x15, 0x28, 0xf6, 0x4f, 0xe2, 0x52, 0x94,
+	0xe4, 0xc6, 0xbd, 0xd8, 0xbb, 0xcb, 0x99, 0xd9, 0x6f, 0xbf, 0x99, 0x9d, 0xfd, 0x04, 0x3b, 0xc3,
+	0xb3, 0x77, 0x4f, 0x86, 0x71, 0x44, 0xa3, 0xb7, 0xa3, 0xd3, 0x27, 0x14, 0xfd, 0x01, 0xff, 0x53,
+	0xe7, 0x4b, 0xc4, 0x64, 0x63, 0xf7, 0x6b, 0x58, 0x79, 0x15, 0xa3, 0x4f, 0xd1, 0xc3, 0xf3, 0x11,
+	0x26, 0x94, 0x10, 0x30, 0x43, 0x7f, 0x80, 0xb6, 0xb1, 0x6b, 0xec, 0x55, 0x3d, 0x3e, 0x26, 0x1b,
+	0x60, 0xe1, 0xc0, 0xef, 0xf5, 0xed, 0x02, 0x5f, 0x14, 0x13, 0xb2, 0x0a, 0xc5, 0x51, 0xdc, 0xb7,
+	0x8b, 0x7c, 0x8d, 0x0d, 0xdd, 0x63, 0xb8, 0xd9, 0x0c, 0x82, 0x93, 0x04, 0xe3, 0x45, 0xd1, 0x08,
+	0x98, 0xa3, 0x04, 0x63, 0x19, 0x8c, 0x8f, 0xd9, 0x5a, 0x1c, 0xf5, 0x51, 0x06, 0xe3, 0x63, 0xf7,
+	0x73, 0x58, 0xf3, 0x70, 0x10, 0x8d, 0x31, 0x13, 0x90, 0xe1, 0x56, 0x01, 0xd9, 0x38, 0x2f, 0xa0,
+	0xfb, 0x8f, 0x01, 0x37, 0x8e, 0x7b, 0x09, 0xf5, 0x30, 0x19, 0x46, 0x61, 0x82, 0xe4, 0x31, 0x58,
+	0xcc, 0x38, 0xb1, 0x8d, 0xdd, 0xe2, 0x5e, 0xad, 0xb1, 0x55, 0x67, 0xb3, 0x7a, 0xda, 0xa4, 0xde,
+	0x46, 0x7f, 0xe0, 0x09, 0x2b, 0xe7, 0x29, 0x98, 0x27, 0x12, 0xd8, 0xd5, 0xe8, 0x70, 0x7e, 0x33,
+	0xc0, 0x6c, 0x4b, 0x38, 0x1f, 0xca, 0x20, 0x43, 0xc9, 0xe0, 0x27, 0xb6, 0x39, 0x17, 0x25, 0x67,
+	0x43, 0x58, 0x11, 0x1b, 0xca, 0x41, 0x34, 0xf0, 0x7b, 0x61, 0x62, 0x5b, 0xbb, 0xc5, 0xbd, 0xaa,
+	0xa7, 0xa6, 0xee, 0x2b, 0x58, 0xf1, 0x90, 0x6d, 0xad, 0x88, 0xb3, 0xa1, 0x1c, 0xf5, 0x83, 0x37,
+	0x53, 0x60, 0x6a, 0xca, 0xbe, 0x84, 0x78, 0xc1, 0xbf, 0x08, 0x74, 0x6a, 0xea, 0x36, 0x61, 0xad,
+	0x85, 0xf4, 0x40, 0x84, 0x5c, 0x94, 0xd2, 0x14, 0x8e, 0x82, 0x8e, 0xe3, 0x25, 0x90, 0x16, 0xd2,
+	0xe6, 0x70, 0x18, 0x47, 0x63, 0xbf, 0xbf, 0xa4, 0x2c, 0xfc, 0xe1, 0x50, 0x05, 0xe0, 0xe3, 0xaf,
+	0xcc, 0x4a, 0x71, 0xd5, 0x74, 0xfb, 0xb0, 0xd9, 0x0c, 0x82, 0xa3, 0x18, 0xf1, 0x67, 0xfc, 0xbe,
+	0x17, 0x06, 0xd1, 0xc5, 0x92, 0x62, 0x4d, 0xa8, 0x1f, 0x53, 0x7e, 0x98, 0xa2, 0x27, 0x26, 0x8c,
+	0x6a, 0x0c, 0x03, 0x4e, 0x75, 0xd1, 0x63, 0x43, 0xb2, 0x09, 0xa5, 0x18, 0xfd, 0x24, 0x0a, 0x6d,
+	0x93, 0x7b, 0xcb, 0x99, 0xfb, 0x1c, 0xb6, 0x45, 0xd9, 0x5d, 0x75, 0xc3, 0x9b, 0x50, 0xe8, 0x05,
+	0x72, 0xb7, 0x42, 0x2f, 0x70, 0xeb, 0x60, 0xb3, 0x84, 0xa5, 0xdd, 0x17, 0x91, 0xe7, 0xfe, 0x69,
+	0xc0, 0x76, 0x8e, 0x83, 0xac, 0xdb, 0x17, 0x50, 0xbe, 0x10, 0x4b, 0xb2, 0x72, 0xef, 0x4f, 0x6b,
+	0x22, 0xd7, 0xa3, 0x2e, 0x11, 0x2b, 0x37, 0xe7, 0x07, 0x28, 0x89, 0x25, 0x89, 0xd4, 0x50, 0x48,
+	0xaf, 0x4d, 0xd5, 0x7d, 0x58, 0x65, 0x30, 0x58, 0x45, 0x2e, 0x3c, 0xe1, 0x1f, 0x06, 0xac, 0xa5,
+	0x0c, 0xe5, 0xc9, 0x1a, 0xaa, 0xd6, 0xc5, 0xb9, 0x76, 0xa6, 0xe7, 0xd2, 0xec, 0xd2, 0x05, 0xef,
+	0x1c, 0xfc, 0xd7, 0x6b, 0x99, 0xdb, 0x59, 0x8e, 0xe1, 0x66, 0x0b, 0xa9, 0x17, 0xf5, 0xf1, 0xff,
+	0xe8, 0x53, 0x5f, 0xc0, 0xca, 0x01, 0xf6, 0x71, 0x71, 0x0b, 0xb5, 0xa1, 0xdc, 0xf5, 0x93, 0xae,
+	0x1f, 0x88, 0x4b, 0x56, 0xf1, 0xd4, 0xd4, 0xfd, 0x05, 0x6e, 0xb5, 0x90, 0x7e, 0x3b, 0x8a, 0xa8,
+	0x7f, 0xb5, 0xeb, 0x61, 0xec, 0x59, 0xe2, 0x7a, 0x10, 0x07, 0x2a, 0x31, 0x0e, 0xfb, 0xbd, 0xae,
+	0x9f, 0x70, 0x44, 0x96, 0x37, 0x99, 0xb3, 0x2c, 0x76, 0x87, 0x23, 0x99, 0x30, 0x36, 0x64, 0x59,
+	0x1c, 0xe0, 0x20, 0x8a, 0x2f, 0x6d, 0x4b, 0x64, 0x51, 0xcc, 0xdc, 0x7b, 0x50, 0x7b, 0x1d, 0x9e,
+	0x46, 0x8b, 0x12, 0xf8, 0x57, 0x11, 0x6e, 0x08, 0x1b, 0x99, 0xbb, 0xeb, 0xf4, 0xb8, 0x54, 0xb3,
+	0x30, 0xb5, 0x66, 0x41, 0xea, 0x60, 0x9d, 0x33, 0x1e, 0x38, 0xc0, 0x5a, 0xc3, 0x16, 0x15, 0x91,
+	0xde, 0xb8, 0x2e, 0x78, 0x12, 0x66, 0xcc, 0x7e, 0x94, 0xf8, 0xef, 0xd0, 0x2e, 0x2d, 0xb3, 0xe7,
+	0x66, 0x64, 0x1f, 0x2a, 0x18, 0x8e, 0x3b, 0x63, 0x3f, 0x4e, 0xec, 0x32, 0x2f, 0xba, 0xed, 0x1c,
+	0x97, 0xc3, 0x70, 0xfc, 0x9d, 0x1f, 0x7b, 0x65, 0xe4, 0xff, 0x19, 0xaa, 0x75, 0x7a, 0x11, 0x75,
+	0x4e, 0xfd, 0x2e, 0x8d, 0xe2, 0x4e, 0x8c, 0xe7, 0xa3, 0x5e, 0x8c, 0x81, 0x5d, 0xe1, 0x69, 0x5c,
+	0xa3, 0x17, 0xd1, 0x11, 0xff, 0xe2, 0xc9, 0x0f, 0x8e, 0x0f, 0x16, 0xdf, 0x75, 0x92, 0x32, 0x63,
+	0x4e, 0xca, 0x0a, 0xf9, 0x29, 0x2b, 0xe6, 0xa5, 0xcc, 0x4c, 0xa7, 0xcc, 0x79, 0x0a, 0x25, 0x81,
+	0x92, 0xf9, 0x9c, 0xe1, 0xa5, 0xcc, 0x03, 0x1b, 0xb2, 0x34, 0x8c, 0xfd, 0xfe, 0x48, 0x35, 0x73,
+	0x31, 0x71, 0x7f, 0x37, 0x60, 0xa5, 0x85, 0xf4, 0x30, 0x1c, 0x2f, 0x2a, 0xb2, 0x4f, 0x53, 0x04,
+	0x15, 0x38, 0x41, 0x8e, 0x20, 0x48, 0x73, 0xcd, 0x32, 0xf4, 0x01, 0x70, 0x5e, 0xc0, 0xad, 0x93,
+	0x30, 0x59, 0x8a, 0x67, 0x3b, 0x83, 0xa7, 0x3a, 0xd9, 0xd3, 0xfd, 0xd5, 0x00, 0x22, 0x94, 0x4b,
+	0x3b, 0x3a, 0xc3, 0x70, 0x51, 0x94, 0xdb, 0x00, 0x94, 0xd9, 0x74, 0xc2, 0xe9, 0x1b, 0x57, 0xe5,
+	0x2b, 0x6f, 0xe4, 0xcd, 0xca, 0xde, 0x69, 0xf2, 0x08, 0x08, 0xfe, 0x34, 0xec, 0xc5, 0x98, 0x74,
+	0x7a, 0x61, 0x27, 0xc1, 0x6e, 0x14, 0x06, 0x09, 0x4f, 0x42, 0xd1, 0x5b, 0x95, 0x5f, 0x5e, 0x87,
+	0x2d, 0xb1, 0xee, 0xfe, 0x08, 0xeb, 0x1a, 0x14, 0x79, 0x49, 0x36, 0xc0, 0xe2, 0xbb, 0x48, 0x30,
+	0x62, 0x92, 0xdb, 0x56, 0x6e, 0x03, 0xa8, 0xed, 0x7c, 0x2a, 0x3b, 0x6f, 0x55, 0xae, 0x34, 0xa9,
+	0xfb, 0x25, 0x10, 0x0f, 0xc7, 0xd1, 0xd9, 0x75, 0x8f, 0xea, 0x3e, 0x10, 0x7d, 0x98, 0x87, 0x59,
+	0xd8, 0xb1, 0xff, 0x36, 0x80, 0xa4, 0x2d, 0xe5, 0x89, 0x3e, 0x83, 0x12, 0x0f, 0xa6, 0x7a, 0xf6,
+	0x9d, 0x69, 0xcf, 0xd6, 0x2d, 0xeb, 0x02, 0xa9, 0xb4, 0x76, 0xde, 0x1b, 0x60, 0xb5, 0xd5, 0xe9,
+	0xf3, 0x5a, 0x1b, 0x4f, 0x40, 0x21, 0x95, 0x00, 0xc5, 0x52, 0x51, 0x67, 0xa9, 0xcb, 0x69, 0x0e,
+	0x18, 0x4b, 0x22, 0x19, 0x55, 0xb9, 0xd2, 0xa4, 0x19, 0x12, 0xad, 0x2c, 0x89, 0xdf, 0xc0, 0xc7,
+	0x2d, 0xa4, 0xed, 0xec, 0x75, 0x5d, 0xc4, 0x26, 0xbf, 0xac, 0xf2, 0xba, 0x8b, 0xae, 0x3d, 0x99,
+	0xbb, 0x65, 0xb0, 0x0e, 0x07, 0x43, 0x7a, 0xd9, 0x78, 0x5f, 0x95, 0xba, 0xef, 0x21, 0x94, 0x44,
+	0x15, 0x90, 0x75, 0x41, 0x8b, 0x26, 0xac, 0x9d, 0x9a, 0x58, 0xe4, 0x4e, 0xe4, 0x11, 0x94, 0xa5,
+	0x52, 0x26, 0x1b, 0x62, 0x5d, 0x17, 0xce, 0xba, 0xf5, 0x03, 0x30, 0x19, 0xc5, 0x24, 0xbd, 0xe8,
+	0x90, 0x59, 0x6d, 0x48, 0x1a, 0x00, 0x53, 0xc9, 0x4c, 0xa4, 0x7a, 0x9c, 0x11, 0xd1, 0x7a, 0xf0,
+	0x87, 0x50, 0x12, 0x4a, 0x51, 0xc1, 0xd6, 0x74, 0xa3, 0x6e, 0xdb, 0x00, 0x98, 0x0a, 0x42, 0x15,
+	0x7f, 0x46, 0x22, 0xea, 0x3e, 0xfb, 0x50, 0x4b, 0x29, 0x40, 0x62, 0x4f, 0x9c, 0x32, 0xa2, 0x50,
+	0xf7, 0x7a, 0x06, 0xb7, 0x32, 0x9a, 0x8f, 0xec, 0x4c, 0x88, 0xca, 0x51, 0x66, 0xba, 0xf7, 0x4b,
+	0x20, 0xe2, 0xd4, 0x5a, 0x80, 0xbb, 0x69, 0x3e, 0x96, 0xc6, 0x68, 0x8b, 0xbb, 0x92, 0xb6, 0x4b,
+	0xc8, 0x9d, 0xb9, 0xe2, 0x4b, 0x44, 0xb8, 0xbb, 0x44, 0x9c, 0x91, 0x67, 0x50, 0x9d, 0x28, 0x1c,
+	0xb2, 0x39, 0x23, 0x79, 0x44, 0x94, 0xad, 0x39, 0x52, 0x88, 0x95, 0x8d, 0x14, 0x2e, 0xaa, 0x6c,
+	0x74, 0x1d, 0x33, 0x93, 0x59, 0x21, 0x4c, 0x54, 0x66, 0x35, 0x99, 0xa2, 0xdb, 0xd6, 0xa1, 0xa2,
+	0x54, 0x08, 0xf9, 0x68, 0x12, 0x3a, 0xad, 0x4a, 0x74, 0xfb, 0xc7, 0x60, 0xb2, 0x47, 0x93, 0xac,
+	0xa5, 0x1f, 0x50, 0x61, 0x47, 0x66, 0xdf, 0x54, 0x06, 0x45, 0x3c, 0x21, 0x0a, 0x8a, 0xf6, 0xa0,
+	0xcc, 0x40, 0x51, 0x6f, 0x83, 0x82, 0x92, 0x79, 0x2b, 0xb2, 0xc9, 0xae, 0xa5, 0xba, 0xaf, 0x2a,
+	0xb0, 0xd9, 0xb7, 0xc1, 0xd9, 0xce, 0xf9, 0x22, 0xf1, 0xed, 0x43, 0x2d, 0xd5, 0x61, 0x55, 0x8c,
+	0xd9, 0xa6, 0xab, 0xef, 0xfc, 0x1c, 0x60, 0xda, 0xfa, 0xc8, 0xd6, 0x6c, 0x33, 0x14, 0x3e, 0xf6,
+	0xbc, 0x2e, 0x49, 0x8e, 0x60, 0x23, 0xaf, 0x27, 0x91, 0x7b, 0x13, 0x92, 0xe6, 0xf5, 0x2b, 0x0d,
+	0xc8, 0xdb, 0x12, 0xff, 0x49, 0xff, 0xc9, 0xbf, 0x03, 0x00, 0xd9, 0x96, 0xe1, 0x7d, 0xf2, 0x0f,
+	0x00, 0x00,
 }
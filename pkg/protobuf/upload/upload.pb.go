@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go.
+// source: pkg/protobuf/upload/upload.proto
+// DO NOT EDIT!
+
+/*
+Package upload is a generated protocol buffer package.
+
+It is generated from these files:
+	pkg/protobuf/upload/upload.proto
+
+It has these top-level messages:
+	SendRequest
+	StatusRequest
+	StatusResponse
+*/
+package upload
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type SendRequest struct {
+	App           string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
+	Checksum      string `protobuf:"bytes,2,opt,name=checksum" json:"checksum,omitempty"`
+	Offset        int64  `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Chunk         []byte `protobuf:"bytes,4,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	ChunkChecksum string `protobuf:"bytes,5,opt,name=chunk_checksum,json=chunkChecksum" json:"chunk_checksum,omitempty"`
+}
+
+func (m *SendRequest) Reset()                    { *m = SendRequest{} }
+func (m *SendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SendRequest) ProtoMessage()               {}
+func (*SendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *SendRequest) GetApp() string {
+	if m != nil {
+		return m.App
+	}
+	return ""
+}
+
+func (m *SendRequest) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+func (m *SendRequest) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SendRequest) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+func (m *SendRequest) GetChunkChecksum() string {
+	if m != nil {
+		return m.ChunkChecksum
+	}
+	return ""
+}
+
+type StatusRequest struct {
+	App      string `protobuf:"bytes,1,opt,name=app" json:"app,omitempty"`
+	Checksum string `protobuf:"bytes,2,opt,name=checksum" json:"checksum,omitempty"`
+}
+
+func (m *StatusRequest) Reset()                    { *m = StatusRequest{} }
+func (m *StatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*StatusRequest) ProtoMessage()               {}
+func (*StatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *StatusRequest) GetApp() string {
+	if m != nil {
+		return m.App
+	}
+	return ""
+}
+
+func (m *StatusRequest) GetChecksum() string {
+	if m != nil {
+		return m.Checksum
+	}
+	return ""
+}
+
+type StatusResponse struct {
+	Offset int64 `protobuf:"varint,1,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *StatusResponse) Reset()                    { *m = StatusResponse{} }
+func (m *StatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*StatusResponse) ProtoMessage()               {}
+func (*StatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *StatusResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*SendRequest)(nil), "upload.SendRequest")
+	proto.RegisterType((*StatusRequest)(nil), "upload.StatusRequest")
+	proto.RegisterType((*StatusResponse)(nil), "upload.StatusResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Upload service
+
+type UploadClient interface {
+	Send(ctx context.Context, opts ...grpc.CallOption) (Upload_SendClient, error)
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type uploadClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUploadClient(cc *grpc.ClientConn) UploadClient {
+	return &uploadClient{cc}
+}
+
+func (c *uploadClient) Send(ctx context.Context, opts ...grpc.CallOption) (Upload_SendClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Upload_serviceDesc.Streams[0], c.cc, "/upload.Upload/Send", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &uploadSendClient{stream}
+	return x, nil
+}
+
+type Upload_SendClient interface {
+	Send(*SendRequest) error
+	CloseAndRecv() (*StatusResponse, error)
+	grpc.ClientStream
+}
+
+type uploadSendClient struct {
+	grpc.ClientStream
+}
+
+func (x *uploadSendClient) Send(m *SendRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *uploadSendClient) CloseAndRecv() (*StatusResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *uploadClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := grpc.Invoke(ctx, "/upload.Upload/Status", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Upload service
+
+type UploadServer interface {
+	Send(Upload_SendServer) error
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+}
+
+func RegisterUploadServer(s *grpc.Server, srv UploadServer) {
+	s.RegisterService(&_Upload_serviceDesc, srv)
+}
+
+func _Upload_Send_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UploadServer).Send(&uploadSendServer{stream})
+}
+
+type Upload_SendServer interface {
+	SendAndClose(*StatusResponse) error
+	Recv() (*SendRequest, error)
+	grpc.ServerStream
+}
+
+type uploadSendServer struct {
+	grpc.ServerStream
+}
+
+func (x *uploadSendServer) SendAndClose(m *StatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *uploadSendServer) Recv() (*SendRequest, error) {
+	m := new(SendRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Upload_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UploadServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/upload.Upload/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UploadServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Upload_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "upload.Upload",
+	HandlerType: (*UploadServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _Upload_Status_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Send",
+			Handler:       _Upload_Send_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pkg/protobuf/upload/upload.proto",
+}
+
+func init() { proto.RegisterFile("pkg/protobuf/upload/upload.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 232 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0xc8, 0x4e, 0xd7,
+	0x2f, 0x28, 0xca, 0x2f, 0xc9, 0x4f, 0x2a, 0x4d, 0xd3, 0x2f, 0x2d, 0xc8, 0xc9, 0x4f, 0x4c, 0x81,
+	0x52, 0x7a, 0x60, 0x61, 0x21, 0x36, 0x08, 0x4f, 0x69, 0x02, 0x23, 0x17, 0x77, 0x70, 0x6a, 0x5e,
+	0x4a, 0x50, 0x6a, 0x61, 0x69, 0x6a, 0x71, 0x89, 0x90, 0x00, 0x17, 0x73, 0x62, 0x41, 0x81, 0x04,
+	0xa3, 0x02, 0xa3, 0x06, 0x67, 0x10, 0x88, 0x29, 0x24, 0xc5, 0xc5, 0x91, 0x9c, 0x91, 0x9a, 0x9c,
+	0x5d, 0x5c, 0x9a, 0x2b, 0xc1, 0x04, 0x16, 0x86, 0xf3, 0x85, 0xc4, 0xb8, 0xd8, 0xf2, 0xd3, 0xd2,
+	0x8a, 0x53, 0x4b, 0x24, 0x98, 0x15, 0x18, 0x35, 0x98, 0x83, 0xa0, 0x3c, 0x21, 0x11, 0x2e, 0xd6,
+	0xe4, 0x8c, 0xd2, 0xbc, 0x6c, 0x09, 0x16, 0x05, 0x46, 0x0d, 0x9e, 0x20, 0x08, 0x47, 0x48, 0x95,
+	0x8b, 0x0f, 0xcc, 0x88, 0x87, 0x9b, 0xc7, 0x0a, 0x36, 0x8f, 0x17, 0x2c, 0xea, 0x0c, 0x15, 0x54,
+	0xb2, 0xe5, 0xe2, 0x0d, 0x2e, 0x49, 0x2c, 0x29, 0x2d, 0x26, 0xcb, 0x4d, 0x4a, 0x1a, 0x5c, 0x7c,
+	0x30, 0xed, 0xc5, 0x05, 0xf9, 0x79, 0xc5, 0xa9, 0x48, 0xae, 0x64, 0x44, 0x76, 0xa5, 0x51, 0x05,
+	0x17, 0x5b, 0x28, 0x38, 0x14, 0x84, 0x4c, 0xb9, 0x58, 0x40, 0x81, 0x20, 0x24, 0xac, 0x07, 0x0d,
+	0x24, 0xa4, 0x20, 0x91, 0x12, 0x83, 0x0b, 0xa2, 0x18, 0xab, 0xc1, 0x28, 0x64, 0xce, 0xc5, 0x06,
+	0x11, 0x13, 0x12, 0x45, 0x57, 0x83, 0x57, 0x6b, 0x12, 0x1b, 0x38, 0x12, 0x8c, 0x01, 0x03, 0x00,
+	0xa6, 0xa1, 0x88, 0x45, 0xa8, 0x01, 0x00, 0x00,
+}
@@ -13,6 +13,17 @@ It has these top-level messages:
 	SetPasswordRequest
 	DeleteRequest
 	CreateRequest
+	SetTwoFactorRequest
+	SetTwoFactorResponse
+	DisableTwoFactorRequest
+	RequestPasswordResetRequest
+	ResetPasswordRequest
+	RefreshRequest
+	ListSessionsRequest
+	ListSessionsResponse
+	RevokeSessionRequest
+	SuspendRequest
+	ReactivateRequest
 	Empty
 */
 package user
@@ -38,9 +49,11 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type LoginRequest struct {
-	Email     string  `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
-	Password  string  `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
-	ExpiresIn float64 `protobuf:"fixed64,3,opt,name=expires_in,json=expiresIn" json:"expires_in,omitempty"`
+	Email       string  `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+	Password    string  `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+	ExpiresIn   float64 `protobuf:"fixed64,3,opt,name=expires_in,json=expiresIn" json:"expires_in,omitempty"`
+	Otp         string  `protobuf:"bytes,4,opt,name=otp" json:"otp,omitempty"`
+	NewPassword string  `protobuf:"bytes,5,opt,name=new_password,json=newPassword" json:"new_password,omitempty"`
 }
 
 func (m *LoginRequest) Reset()                    { *m = LoginRequest{} }
@@ -69,8 +82,24 @@ func (m *LoginRequest) GetExpiresIn() float64 {
 	return 0
 }
 
+func (m *LoginRequest) GetOtp() string {
+	if m != nil {
+		return m.Otp
+	}
+	return ""
+}
+
+func (m *LoginRequest) GetNewPassword() string {
+	if m != nil {
+		return m.NewPassword
+	}
+	return ""
+}
+
 type LoginResponse struct {
-	Token string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	Token        string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	RefreshToken string `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+	ExpiresAt    int64  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
 }
 
 func (m *LoginResponse) Reset()                    { *m = LoginResponse{} }
@@ -85,6 +114,20 @@ func (m *LoginResponse) GetToken() string {
 	return ""
 }
 
+func (m *LoginResponse) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
+func (m *LoginResponse) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
 type SetPasswordRequest struct {
 	Password string `protobuf:"bytes,1,opt,name=password" json:"password,omitempty"`
 	User     string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
@@ -165,13 +208,269 @@ func (m *CreateRequest) GetAdmin() bool {
 	return false
 }
 
+type SetTwoFactorRequest struct {
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+}
+
+func (m *SetTwoFactorRequest) Reset()                    { *m = SetTwoFactorRequest{} }
+func (m *SetTwoFactorRequest) String() string            { return proto.CompactTextString(m) }
+func (*SetTwoFactorRequest) ProtoMessage()               {}
+func (*SetTwoFactorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *SetTwoFactorRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+type SetTwoFactorResponse struct {
+	Secret string `protobuf:"bytes,1,opt,name=secret" json:"secret,omitempty"`
+	Url    string `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
+}
+
+func (m *SetTwoFactorResponse) Reset()                    { *m = SetTwoFactorResponse{} }
+func (m *SetTwoFactorResponse) String() string            { return proto.CompactTextString(m) }
+func (*SetTwoFactorResponse) ProtoMessage()               {}
+func (*SetTwoFactorResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SetTwoFactorResponse) GetSecret() string {
+	if m != nil {
+		return m.Secret
+	}
+	return ""
+}
+
+func (m *SetTwoFactorResponse) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+type DisableTwoFactorRequest struct {
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+}
+
+func (m *DisableTwoFactorRequest) Reset()                    { *m = DisableTwoFactorRequest{} }
+func (m *DisableTwoFactorRequest) String() string            { return proto.CompactTextString(m) }
+func (*DisableTwoFactorRequest) ProtoMessage()               {}
+func (*DisableTwoFactorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *DisableTwoFactorRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *DisableTwoFactorRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+type RequestPasswordResetRequest struct {
+	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *RequestPasswordResetRequest) Reset()                    { *m = RequestPasswordResetRequest{} }
+func (m *RequestPasswordResetRequest) String() string            { return proto.CompactTextString(m) }
+func (*RequestPasswordResetRequest) ProtoMessage()               {}
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *RequestPasswordResetRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	Token    string `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password" json:"password,omitempty"`
+}
+
+func (m *ResetPasswordRequest) Reset()                    { *m = ResetPasswordRequest{} }
+func (m *ResetPasswordRequest) String() string            { return proto.CompactTextString(m) }
+func (*ResetPasswordRequest) ProtoMessage()               {}
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *ResetPasswordRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ResetPasswordRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type RefreshRequest struct {
+	RefreshToken string `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken" json:"refresh_token,omitempty"`
+}
+
+func (m *RefreshRequest) Reset()                    { *m = RefreshRequest{} }
+func (m *RefreshRequest) String() string            { return proto.CompactTextString(m) }
+func (*RefreshRequest) ProtoMessage()               {}
+func (*RefreshRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *RefreshRequest) GetRefreshToken() string {
+	if m != nil {
+		return m.RefreshToken
+	}
+	return ""
+}
+
+type ListSessionsRequest struct {
+	User string `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+}
+
+func (m *ListSessionsRequest) Reset()                    { *m = ListSessionsRequest{} }
+func (m *ListSessionsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListSessionsRequest) ProtoMessage()               {}
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ListSessionsRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	Sessions []*ListSessionsResponse_Session `protobuf:"bytes,1,rep,name=sessions" json:"sessions,omitempty"`
+}
+
+func (m *ListSessionsResponse) Reset()                    { *m = ListSessionsResponse{} }
+func (m *ListSessionsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListSessionsResponse) ProtoMessage()               {}
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *ListSessionsResponse) GetSessions() []*ListSessionsResponse_Session {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+type ListSessionsResponse_Session struct {
+	Id          uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,2,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	RefreshedAt int64  `protobuf:"varint,3,opt,name=refreshed_at,json=refreshedAt" json:"refreshed_at,omitempty"`
+	ExpiresAt   int64  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+}
+
+func (m *ListSessionsResponse_Session) Reset()                    { *m = ListSessionsResponse_Session{} }
+func (m *ListSessionsResponse_Session) String() string            { return proto.CompactTextString(m) }
+func (*ListSessionsResponse_Session) ProtoMessage()               {}
+func (*ListSessionsResponse_Session) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
+
+func (m *ListSessionsResponse_Session) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ListSessionsResponse_Session) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return 0
+}
+
+func (m *ListSessionsResponse_Session) GetRefreshedAt() int64 {
+	if m != nil {
+		return m.RefreshedAt
+	}
+	return 0
+}
+
+func (m *ListSessionsResponse_Session) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type RevokeSessionRequest struct {
+	Id   uint64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	User string `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	All  bool   `protobuf:"varint,3,opt,name=all" json:"all,omitempty"`
+}
+
+func (m *RevokeSessionRequest) Reset()                    { *m = RevokeSessionRequest{} }
+func (m *RevokeSessionRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeSessionRequest) ProtoMessage()               {}
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *RevokeSessionRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *RevokeSessionRequest) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *RevokeSessionRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+type SuspendRequest struct {
+	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *SuspendRequest) Reset()                    { *m = SuspendRequest{} }
+func (m *SuspendRequest) String() string            { return proto.CompactTextString(m) }
+func (*SuspendRequest) ProtoMessage()               {}
+func (*SuspendRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *SuspendRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
+type ReactivateRequest struct {
+	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *ReactivateRequest) Reset()                    { *m = ReactivateRequest{} }
+func (m *ReactivateRequest) String() string            { return proto.CompactTextString(m) }
+func (*ReactivateRequest) ProtoMessage()               {}
+func (*ReactivateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ReactivateRequest) GetEmail() string {
+	if m != nil {
+		return m.Email
+	}
+	return ""
+}
+
 type Empty struct {
 }
 
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func init() {
 	proto.RegisterType((*LoginRequest)(nil), "user.LoginRequest")
@@ -179,6 +478,18 @@ func init() {
 	proto.RegisterType((*SetPasswordRequest)(nil), "user.SetPasswordRequest")
 	proto.RegisterType((*DeleteRequest)(nil), "user.DeleteRequest")
 	proto.RegisterType((*CreateRequest)(nil), "user.CreateRequest")
+	proto.RegisterType((*SetTwoFactorRequest)(nil), "user.SetTwoFactorRequest")
+	proto.RegisterType((*SetTwoFactorResponse)(nil), "user.SetTwoFactorResponse")
+	proto.RegisterType((*DisableTwoFactorRequest)(nil), "user.DisableTwoFactorRequest")
+	proto.RegisterType((*RequestPasswordResetRequest)(nil), "user.RequestPasswordResetRequest")
+	proto.RegisterType((*ResetPasswordRequest)(nil), "user.ResetPasswordRequest")
+	proto.RegisterType((*RefreshRequest)(nil), "user.RefreshRequest")
+	proto.RegisterType((*ListSessionsRequest)(nil), "user.ListSessionsRequest")
+	proto.RegisterType((*ListSessionsResponse)(nil), "user.ListSessionsResponse")
+	proto.RegisterType((*ListSessionsResponse_Session)(nil), "user.ListSessionsResponse.Session")
+	proto.RegisterType((*RevokeSessionRequest)(nil), "user.RevokeSessionRequest")
+	proto.RegisterType((*SuspendRequest)(nil), "user.SuspendRequest")
+	proto.RegisterType((*ReactivateRequest)(nil), "user.ReactivateRequest")
 	proto.RegisterType((*Empty)(nil), "user.Empty")
 }
 
@@ -197,6 +508,15 @@ type UserClient interface {
 	SetPassword(ctx context.Context, in *SetPasswordRequest, opts ...grpc.CallOption) (*Empty, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*Empty, error)
+	SetTwoFactor(ctx context.Context, in *SetTwoFactorRequest, opts ...grpc.CallOption) (*SetTwoFactorResponse, error)
+	DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*Empty, error)
+	RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*Empty, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*Empty, error)
+	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error)
+	Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*Empty, error)
+	Reactivate(ctx context.Context, in *ReactivateRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type userClient struct {
@@ -243,6 +563,87 @@ func (c *userClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *userClient) SetTwoFactor(ctx context.Context, in *SetTwoFactorRequest, opts ...grpc.CallOption) (*SetTwoFactorResponse, error) {
+	out := new(SetTwoFactorResponse)
+	err := grpc.Invoke(ctx, "/user.User/SetTwoFactor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) DisableTwoFactor(ctx context.Context, in *DisableTwoFactorRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/DisableTwoFactor", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/RequestPasswordReset", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/ResetPassword", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := grpc.Invoke(ctx, "/user.User/Refresh", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := grpc.Invoke(ctx, "/user.User/ListSessions", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/RevokeSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Suspend(ctx context.Context, in *SuspendRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/Suspend", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) Reactivate(ctx context.Context, in *ReactivateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/user.User/Reactivate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for User service
 
 type UserServer interface {
@@ -250,6 +651,15 @@ type UserServer interface {
 	SetPassword(context.Context, *SetPasswordRequest) (*Empty, error)
 	Delete(context.Context, *DeleteRequest) (*Empty, error)
 	Create(context.Context, *CreateRequest) (*Empty, error)
+	SetTwoFactor(context.Context, *SetTwoFactorRequest) (*SetTwoFactorResponse, error)
+	DisableTwoFactor(context.Context, *DisableTwoFactorRequest) (*Empty, error)
+	RequestPasswordReset(context.Context, *RequestPasswordResetRequest) (*Empty, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*Empty, error)
+	Refresh(context.Context, *RefreshRequest) (*LoginResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
+	RevokeSession(context.Context, *RevokeSessionRequest) (*Empty, error)
+	Suspend(context.Context, *SuspendRequest) (*Empty, error)
+	Reactivate(context.Context, *ReactivateRequest) (*Empty, error)
 }
 
 func RegisterUserServer(s *grpc.Server, srv UserServer) {
@@ -328,6 +738,168 @@ func _User_Create_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_SetTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SetTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/SetTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SetTwoFactor(ctx, req.(*SetTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_DisableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableTwoFactorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).DisableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/DisableTwoFactor",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).DisableTwoFactor(ctx, req.(*DisableTwoFactorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/RequestPasswordReset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RequestPasswordReset(ctx, req.(*RequestPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Refresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Refresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Refresh(ctx, req.(*RefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Suspend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuspendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Suspend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Suspend",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Suspend(ctx, req.(*SuspendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_Reactivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).Reactivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/Reactivate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).Reactivate(ctx, req.(*ReactivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _User_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "user.User",
 	HandlerType: (*UserServer)(nil),
@@ -348,6 +920,42 @@ var _User_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _User_Create_Handler,
 		},
+		{
+			MethodName: "SetTwoFactor",
+			Handler:    _User_SetTwoFactor_Handler,
+		},
+		{
+			MethodName: "DisableTwoFactor",
+			Handler:    _User_DisableTwoFactor_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _User_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _User_ResetPassword_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _User_Refresh_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _User_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _User_RevokeSession_Handler,
+		},
+		{
+			MethodName: "Suspend",
+			Handler:    _User_Suspend_Handler,
+		},
+		{
+			MethodName: "Reactivate",
+			Handler:    _User_Reactivate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/protobuf/user/user.proto",
@@ -356,25 +964,51 @@ var _User_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/protobuf/user/user.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 311 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xd1, 0x4a, 0xfb, 0x30,
-	0x14, 0xc6, 0xc9, 0xd6, 0xee, 0xbf, 0x9d, 0xfd, 0x77, 0x73, 0xdc, 0x45, 0x19, 0x0a, 0xa3, 0x30,
-	0x28, 0x5e, 0x6c, 0xa2, 0xbe, 0x81, 0xf3, 0x42, 0xf0, 0x42, 0x2a, 0x5e, 0x8f, 0x8e, 0x1d, 0x47,
-	0xe9, 0x9a, 0xc4, 0x24, 0x45, 0x7d, 0x41, 0x9f, 0x4b, 0x96, 0xa4, 0xae, 0xdd, 0xd0, 0x9b, 0x92,
-	0xef, 0xe4, 0x6b, 0xbe, 0x73, 0x7e, 0x09, 0x9c, 0xcb, 0x62, 0xbb, 0x90, 0x4a, 0x18, 0xb1, 0xae,
-	0x5e, 0x17, 0x95, 0x26, 0x65, 0x3f, 0x73, 0x5b, 0xc2, 0x60, 0xbf, 0x8e, 0x57, 0xf0, 0xff, 0x51,
-	0x6c, 0x73, 0x9e, 0xd2, 0x5b, 0x45, 0xda, 0xe0, 0x18, 0x42, 0x2a, 0xb3, 0x7c, 0x17, 0xb1, 0x29,
-	0x4b, 0x06, 0xa9, 0x13, 0x38, 0x81, 0xbe, 0xcc, 0xb4, 0x7e, 0x17, 0x6a, 0x13, 0x75, 0xec, 0xc6,
-	0x8f, 0xc6, 0x0b, 0x00, 0xfa, 0x90, 0xb9, 0x22, 0xbd, 0xca, 0x79, 0xd4, 0x9d, 0xb2, 0x84, 0xa5,
-	0x03, 0x5f, 0x79, 0xe0, 0xf1, 0x0c, 0x46, 0x3e, 0x40, 0x4b, 0xc1, 0x35, 0xed, 0x13, 0x8c, 0x28,
-	0x88, 0xd7, 0x09, 0x56, 0xc4, 0x4b, 0xc0, 0x67, 0x32, 0x4f, 0xfe, 0xd0, 0xba, 0x9b, 0x66, 0x2e,
-	0x3b, 0xca, 0x45, 0xb0, 0x13, 0xf8, 0x7e, 0xdc, 0x34, 0x33, 0x18, 0x2d, 0x69, 0x47, 0x86, 0xfe,
-	0x1c, 0x27, 0x2e, 0x60, 0x74, 0xa7, 0x28, 0x3b, 0xd8, 0x10, 0x02, 0x9e, 0x95, 0xe4, 0x5d, 0x76,
-	0x7d, 0xf8, 0xb5, 0xf3, 0x1b, 0x89, 0xee, 0x51, 0x47, 0x63, 0x08, 0xb3, 0x4d, 0x99, 0xf3, 0x28,
-	0x98, 0xb2, 0xa4, 0x9f, 0x3a, 0x11, 0xff, 0x83, 0xf0, 0xbe, 0x94, 0xe6, 0xf3, 0xfa, 0x8b, 0x41,
-	0xf0, 0xa2, 0x49, 0xe1, 0x15, 0x84, 0x16, 0x09, 0xe2, 0xdc, 0xde, 0x47, 0xf3, 0x02, 0x26, 0x67,
-	0xad, 0x9a, 0x67, 0x76, 0x0b, 0xc3, 0x06, 0x1d, 0x8c, 0x9c, 0xe7, 0x14, 0xd8, 0x64, 0xe8, 0x76,
-	0x6c, 0x20, 0x5e, 0x42, 0xcf, 0xd1, 0x40, 0x7f, 0x68, 0x8b, 0xcd, 0x89, 0xd7, 0x21, 0xa9, 0xbd,
-	0x2d, 0x40, 0x2d, 0xef, 0xba, 0x67, 0x1f, 0xd0, 0xcd, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x08,
-	0x47, 0xeb, 0x18, 0x60, 0x02, 0x00, 0x00,
+	// 722 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x6e, 0xd3, 0x4c,
+	0x10, 0x95, 0xf3, 0xd3, 0xa4, 0x93, 0xa4, 0xea, 0xb7, 0xb5, 0xbe, 0x1a, 0x43, 0xa5, 0xd6, 0x08,
+	0xd4, 0x22, 0xd4, 0xa2, 0xb6, 0x48, 0x5c, 0x21, 0x22, 0xda, 0x0a, 0xa4, 0x5e, 0x20, 0xa7, 0x5c,
+	0x57, 0x6e, 0x32, 0x2d, 0x56, 0x12, 0xdb, 0x78, 0x37, 0x0d, 0xf0, 0x1c, 0x3c, 0x19, 0x4f, 0xc1,
+	0x63, 0xa0, 0xdd, 0x1d, 0x3b, 0x5e, 0xc7, 0xa4, 0xdc, 0x44, 0xeb, 0xd9, 0xb3, 0x3b, 0x67, 0x67,
+	0xce, 0x99, 0xc0, 0x93, 0x64, 0x7c, 0x77, 0x94, 0xa4, 0xb1, 0x88, 0x6f, 0x66, 0xb7, 0x47, 0x33,
+	0x8e, 0xa9, 0xfa, 0x39, 0x54, 0x21, 0xd6, 0x90, 0x6b, 0xef, 0xa7, 0x05, 0xdd, 0xcb, 0xf8, 0x2e,
+	0x8c, 0x7c, 0xfc, 0x3a, 0x43, 0x2e, 0x98, 0x0d, 0x4d, 0x9c, 0x06, 0xe1, 0xc4, 0xb1, 0x76, 0xad,
+	0xfd, 0x75, 0x5f, 0x7f, 0x30, 0x17, 0xda, 0x49, 0xc0, 0xf9, 0x3c, 0x4e, 0x47, 0x4e, 0x4d, 0x6d,
+	0xe4, 0xdf, 0x6c, 0x07, 0x00, 0xbf, 0x25, 0x61, 0x8a, 0xfc, 0x3a, 0x8c, 0x9c, 0xfa, 0xae, 0xb5,
+	0x6f, 0xf9, 0xeb, 0x14, 0xf9, 0x18, 0xb1, 0x4d, 0xa8, 0xc7, 0x22, 0x71, 0x1a, 0xea, 0x94, 0x5c,
+	0xb2, 0x3d, 0xe8, 0x46, 0x38, 0xbf, 0xce, 0x2f, 0x6c, 0xaa, 0xad, 0x4e, 0x84, 0xf3, 0x4f, 0x14,
+	0xf2, 0x42, 0xe8, 0x11, 0x2b, 0x9e, 0xc4, 0x11, 0x47, 0x49, 0x4b, 0xc4, 0x63, 0x8c, 0x32, 0x5a,
+	0xea, 0x83, 0x3d, 0x85, 0x5e, 0x8a, 0xb7, 0x29, 0xf2, 0x2f, 0xd7, 0x7a, 0x57, 0x73, 0xeb, 0x52,
+	0xf0, 0x4a, 0x81, 0x0a, 0xfc, 0x02, 0xa1, 0xf8, 0xd5, 0x73, 0x7e, 0x7d, 0xe1, 0x9d, 0x01, 0x1b,
+	0xa0, 0xc8, 0x32, 0x67, 0x65, 0x28, 0x3e, 0xd8, 0x2a, 0x3d, 0x98, 0x81, 0xaa, 0x1d, 0x25, 0xd3,
+	0x75, 0x7c, 0x06, 0xbd, 0x33, 0x9c, 0xa0, 0xc0, 0x95, 0x75, 0xf4, 0xc6, 0xd0, 0x7b, 0x9f, 0x62,
+	0xb0, 0x80, 0x31, 0x68, 0x44, 0xc1, 0x14, 0x09, 0xa5, 0xd6, 0x8b, 0xa3, 0xb5, 0xbf, 0xb5, 0xa0,
+	0x5e, 0x62, 0x64, 0x43, 0x33, 0x18, 0x4d, 0xc3, 0x48, 0x55, 0xb9, 0xed, 0xeb, 0x0f, 0xef, 0x00,
+	0xb6, 0x06, 0x28, 0xae, 0xe6, 0xf1, 0x45, 0x30, 0x14, 0x71, 0x5a, 0x48, 0x39, 0x8c, 0x47, 0x79,
+	0x4a, 0xb9, 0xf6, 0xde, 0x81, 0x6d, 0x42, 0xa9, 0xec, 0xff, 0xc3, 0x1a, 0xc7, 0x61, 0x8a, 0x82,
+	0xd0, 0xf4, 0x25, 0x9b, 0x3a, 0x4b, 0x33, 0x82, 0x72, 0xe9, 0xf5, 0x61, 0xfb, 0x2c, 0xe4, 0xc1,
+	0xcd, 0x04, 0xff, 0x25, 0x61, 0x65, 0x0d, 0x4f, 0xe0, 0x31, 0x1d, 0x59, 0x74, 0x83, 0xa3, 0x58,
+	0x5d, 0xd1, 0x0f, 0x60, 0x2b, 0x54, 0xb9, 0x81, 0xd5, 0x82, 0x59, 0xa1, 0x63, 0xef, 0x35, 0x6c,
+	0xf8, 0x5a, 0x37, 0xd9, 0x1d, 0x4b, 0xf2, 0xb2, 0x96, 0xe5, 0x25, 0xab, 0x7c, 0x19, 0x72, 0x31,
+	0x40, 0xce, 0xc3, 0x38, 0xe2, 0x85, 0x47, 0xab, 0x07, 0x5a, 0x85, 0x07, 0xfe, 0xb2, 0xc0, 0x36,
+	0xb1, 0x54, 0xe6, 0xb7, 0xd0, 0xe6, 0x14, 0x73, 0xac, 0xdd, 0xfa, 0x7e, 0xe7, 0xd8, 0x3b, 0x54,
+	0x56, 0xad, 0x42, 0x1f, 0x52, 0xc0, 0xcf, 0xcf, 0xb8, 0x3f, 0xa0, 0x45, 0x41, 0xb6, 0x01, 0xb5,
+	0x50, 0x4b, 0xb6, 0xe1, 0xd7, 0x42, 0xe5, 0xce, 0xa1, 0x52, 0xdc, 0x48, 0xaa, 0xbf, 0xa6, 0xd5,
+	0x4f, 0x91, 0xbe, 0x90, 0x5e, 0xa4, 0xd7, 0x68, 0x80, 0xb6, 0x47, 0x27, 0x8f, 0xf5, 0x45, 0xc9,
+	0x3f, 0x8d, 0xb2, 0x7f, 0x2e, 0x65, 0x03, 0xee, 0xe3, 0x31, 0x66, 0xb4, 0xa8, 0x00, 0x65, 0x22,
+	0x15, 0x1d, 0x97, 0x32, 0x0a, 0x26, 0x13, 0x95, 0xb4, 0xed, 0xcb, 0xa5, 0xf7, 0x1c, 0x36, 0x06,
+	0x33, 0x9e, 0x60, 0x34, 0x5a, 0xdd, 0xf6, 0x03, 0xf8, 0xcf, 0xc7, 0x60, 0x28, 0xc2, 0xfb, 0xe0,
+	0x21, 0xcf, 0xb5, 0xa0, 0x79, 0x3e, 0x4d, 0xc4, 0xf7, 0xe3, 0xdf, 0x4d, 0x68, 0x7c, 0x96, 0x69,
+	0x5f, 0x41, 0x53, 0x4d, 0x17, 0xc6, 0xa8, 0xca, 0x85, 0x01, 0xe8, 0x6e, 0x19, 0x31, 0x6a, 0xd0,
+	0x29, 0x74, 0x0a, 0x43, 0x82, 0x39, 0x1a, 0xb3, 0x3c, 0x37, 0xdc, 0x8e, 0xde, 0x51, 0x09, 0xd9,
+	0x0b, 0x58, 0xd3, 0x43, 0x81, 0xd1, 0xa5, 0xc6, 0x88, 0x58, 0xc2, 0xea, 0xc9, 0x90, 0x61, 0x8d,
+	0x39, 0x61, 0x62, 0xcf, 0xa1, 0x5b, 0x74, 0x2b, 0x7b, 0x94, 0xd3, 0x29, 0x7b, 0xcf, 0x75, 0xab,
+	0xb6, 0x72, 0xd5, 0x6d, 0x96, 0x2d, 0xcb, 0x76, 0x88, 0x68, 0xb5, 0x95, 0x4d, 0x1a, 0x17, 0x60,
+	0x53, 0xdc, 0xf0, 0x2b, 0xdb, 0xd3, 0xa0, 0x15, 0x5e, 0x36, 0xef, 0x79, 0x03, 0x3d, 0xc3, 0xc2,
+	0xcc, 0xcd, 0x2e, 0xe0, 0x0f, 0x14, 0xf8, 0x14, 0x5a, 0x64, 0x59, 0x66, 0x67, 0x67, 0x8a, 0x0e,
+	0xae, 0x6e, 0xe6, 0x39, 0x74, 0x8b, 0xbe, 0xca, 0xca, 0x57, 0xe1, 0x62, 0xd7, 0xad, 0xda, 0xa2,
+	0x6b, 0x14, 0xed, 0x82, 0xf0, 0x17, 0xb4, 0x97, 0xdd, 0x60, 0xd2, 0x7e, 0x09, 0x2d, 0x12, 0x79,
+	0x46, 0xdb, 0xd4, 0xbc, 0x89, 0x3e, 0x06, 0x58, 0x48, 0x9d, 0x6d, 0x67, 0x49, 0x4a, 0xe2, 0x37,
+	0xce, 0xdc, 0xac, 0xa9, 0xff, 0xf8, 0x93, 0x3f, 0x03, 0x00, 0xe1, 0xba, 0x78, 0xa5, 0x03, 0x08,
+	0x00, 0x00,
 }
@@ -1,24 +1,32 @@
 package app
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
+	"path"
 	"strings"
-	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+	"github.com/luizalabs/teresa/pkg/server/logstore"
 	st "github.com/luizalabs/teresa/pkg/server/storage"
 	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"github.com/luizalabs/teresa/pkg/server/validation"
+	"google.golang.org/grpc/status"
 )
 
-const SecretPath = "/teresa/secrets"
+const (
+	SecretPath              = "/teresa/secrets"
+	serviceTypeLoadBalancer = "LoadBalancer"
+)
 
 type Operations interface {
 	Create(user *database.User, app *App) error
@@ -27,27 +35,84 @@ type Operations interface {
 	TeamName(appName string) (string, error)
 	Get(appName string) (*App, error)
 	HasPermission(user *database.User, appName string) bool
+	HasRole(user *database.User, appName, role string) bool
 	SetEnv(user *database.User, appName string, evs []*EnvVar) error
 	UnsetEnv(user *database.User, appName string, evs []string) error
 	SetSecret(user *database.User, appName string, secrets []*EnvVar) error
 	UnsetSecret(user *database.User, appName string, secrets []string) error
-	SetSecretFile(user *database.User, appName, name string, content []byte) error
+	SetSecretFile(user *database.User, appName, name string, content []byte, mountPath string) error
+	SetConfigFile(user *database.User, appName, name string, content []byte, mountPath string) error
+	UnsetConfigFile(user *database.User, appName, name string) error
+	SetLifecycle(user *database.User, appName string, lc *Lifecycle) error
+	SetRollingUpdate(user *database.User, appName string, ru *RollingUpdate) error
+	SetRolloutTimeout(user *database.User, appName string, seconds int32) error
+	SetBuilder(user *database.User, appName, builder string) error
+	SetBuildEnv(user *database.User, appName string, evs []*EnvVar) error
+	UnsetBuildEnv(user *database.User, appName string, evNames []string) error
+	Lock(user *database.User, appName, reason string) error
+	Unlock(user *database.User, appName string) error
+	CronList(user *database.User, appName string) ([]*CronJob, error)
+	CronSuspend(user *database.User, appName string) error
+	CronResume(user *database.User, appName string) error
+	CronRunNow(user *database.User, appName string) (string, error)
+	CronRuns(user *database.User, appName, process string) ([]*CronRun, error)
+	CronRunLogs(user *database.User, appName, process, run string, opts *LogOptions) (io.ReadCloser, error)
+	PortForward(user *database.User, appName, podName string, port int32, t *Tunnel) error
 	List(user *database.User) ([]*AppListItem, error)
 	ListByTeam(teamName string) ([]string, error)
 	SetAutoscale(user *database.User, appName string, as *Autoscale) error
 	CheckPermAndGet(user *database.User, appName string) (*App, error)
+	CheckRoleAndGet(user *database.User, appName, role string) (*App, error)
 	SaveApp(app *App, lastUser string) error
 	Delete(user *database.User, appName string) error
 	ChangeTeam(appName, teamName string) error
+	DeleteApp(appName string) error
+	TeamUsage(teamName string) (*teamext.Usage, error)
+	CheckTeamQuota(a *App) error
+	TransferTeam(user *database.User, appName, teamName string) error
 	SetReplicas(user *database.User, appName string, replicas int32) error
+	Scale(user *database.User, appName string, replicas map[string]int32) error
+	Pause(user *database.User, appName string) error
+	Resume(user *database.User, appName string) error
+	Restart(user *database.User, appName string) error
+	Status(user *database.User, appName string) ([]*PodDetail, error)
+	Events(user *database.User, appName string, opts *EventOptions, stop <-chan struct{}) (<-chan *Event, error)
+	Top(user *database.User, appName string) ([]*PodMetrics, error)
+	SetResources(user *database.User, appName string, r *Resources) error
+	EnvHistory(user *database.User, appName string) ([]*EnvRevision, error)
+	EnvRollback(user *database.User, appName string, version int32) error
+	SetEnvHistory(eh EnvHistory)
+	SetLogStore(ls logstore.Store)
+	SetClusters(c Clusters)
+	SetEnvGroups(egs EnvGroups)
+	SetAppLocks(l AppLocks)
+	SetMaintenance(user *database.User, appName string, enabled bool) error
+	EnableTLS(user *database.User, appName string) error
+	SetIngressOptions(user *database.User, appName string, opts map[string]string) error
+	SetACL(user *database.User, appName string, cidrs []string) error
+	SetAutoscaleSchedule(user *database.User, appName string, windows []*ScaleWindow) error
+	RunAutoscaleScheduler(l lease.Leases, stop <-chan struct{})
+	SyncPDBs(l lease.Leases)
+	SetPDB(user *database.User, appName, minAvailable string) error
+	AddVolume(user *database.User, appName string, vc *VolumeClaim) error
+	RemoveVolume(user *database.User, appName, volumeName string) error
+	SetEnvGroup(user *database.User, appName, group string, evs []*EnvVar) error
+	UnsetEnvGroup(user *database.User, appName, group string) error
 	DeletePods(user *database.User, appName string, podsNames []string) error
 	SetVHosts(user *database.User, appName string, vHosts []string) error
+	AddVHost(user *database.User, appName, vHost string) error
+	RemoveVHost(user *database.User, appName, vHost string) error
+	Rename(user *database.User, oldName, newName string) error
+	Clone(user *database.User, srcName, dstName, vHost string) error
 }
 
 type K8sOperations interface {
 	NamespaceAnnotation(namespace, annotation string) (string, error)
 	NamespaceLabel(namespace, label string) (string, error)
 	PodList(namespace string, opts *PodListOptions) ([]*Pod, error)
+	PodDetails(namespace string) ([]*PodDetail, error)
+	Events(namespace string, opts *EventOptions, stop <-chan struct{}) (<-chan *Event, error)
+	PodMetrics(namespace string) ([]*PodMetrics, error)
 	PodLogs(namespace, podName string, opts *LogOptions) (io.ReadCloser, error)
 	CreateNamespace(app *App, userEmail string) error
 	CreateQuota(app *App) error
@@ -77,18 +142,77 @@ type K8sOperations interface {
 	HasIngress(namespace, name string) (bool, error)
 	IngressEnabled() bool
 	UpdateIngress(namespace, name string, vHosts []string) error
-	CreateOrUpdateDeploySecretFile(namespace, deploy, fileName string) error
-	CreateOrUpdateCronJobSecretFile(namespace, cronjob, filename string) error
+	MaintenanceEnabled() bool
+	IngressSetMaintenance(namespace, name string, enabled bool) error
+	CertManagerEnabled() bool
+	IngressEnableTLS(namespace, name string) error
+	IngressTLSStatus(namespace, name string) (string, error)
+	IngressAllowedOptions() []string
+	IngressSetOptions(namespace, name string, opts map[string]string) error
+	IngressSetSourceRanges(namespace, name string, cidrs []string) error
+	ServiceType(namespace, name string) (string, error)
+	IngressDeleteStreamPorts(namespace, svcName string) error
+	IngressRenameStreamPorts(src, dst string) error
+	SetLoadBalancerSourceRanges(namespace, svcName string, sourceRanges []string) error
+	CreateOrUpdatePDB(namespace, name, minAvailable string) error
+	DeletePDB(namespace, name string) error
+	DefaultPDBMinAvailable() string
+	CreatePVC(namespace string, vc *VolumeClaim) error
+	DeletePVC(namespace, name string) error
+	GetConfigMap(namespace, name string) (map[string]string, error)
+	CreateOrUpdateConfigMap(namespace, name string, data map[string]string) error
+	SetDeployConfigFile(namespace, deploy, fileName, mountPath string) error
+	SetCronJobConfigFile(namespace, cronjob, fileName, mountPath string) error
+	CreateOrUpdateDeploySecretFile(namespace, deploy, fileName, mountPath string) error
+	CreateOrUpdateCronJobSecretFile(namespace, cronjob, filename, mountPath string) error
 	DeleteDeploySecrets(namespace, deploy string, envVars, volKeys []string) error
 	DeleteCronJobSecrets(namespace, cronjob string, envVars, volKeys []string) error
 	SuspendCronJob(namespace, name string) error
 	ResumeCronJob(namespace, name string) error
+	CronJob(namespace, name string) (*CronJob, error)
+	CronJobRunNow(namespace, name string) (string, error)
+	CronJobRuns(namespace, name string) ([]*CronRun, error)
+	PortForward(namespace, podName string, port int32, t *Tunnel) error
+	CopyAppResources(srcApp, dstApp string) error
+	WaitDeploysReady(namespace string) error
+	DeployReplicas(namespace, name string) (int32, error)
+	DeleteAutoscale(namespace string) error
+	DeployRestart(namespace, name string) error
+	DeploySetResources(namespace, name string, r *Resources) error
+	DeploySetLifecycle(namespace, name string, lc *Lifecycle) error
+	DeploySetRollingUpdate(namespace, name string, ru *RollingUpdate) error
+	CronJobSetResources(namespace, name string, r *Resources) error
+	DeleteSecret(namespace, secretName string) error
+	DeploySetEnvFromSecrets(namespace, name string, secretNames []string) error
+	CronJobSetEnvFromSecrets(namespace, name string, secretNames []string) error
+	DeployMetadata(namespace, name string) (*DeployMetadata, error)
 }
 
 type AppOperations struct {
-	tops team.Operations
-	kops K8sOperations
-	st   st.Storage
+	tops     team.Operations
+	kops     K8sOperations
+	st       st.Storage
+	eh       EnvHistory
+	ls       logstore.Store
+	clusters Clusters
+	egs      EnvGroups
+	locks    AppLocks
+}
+
+// Clusters assign the Apps to the Kubernetes clusters, the K8sOperations
+// on an App go to its cluster. Blank is the cluster of the server.
+type Clusters interface {
+	AppCluster(appName string) (string, error)
+	SetAppCluster(appName, cluster string) error
+	DeleteAppCluster(appName string) error
+}
+
+// EnvGroups keep the env groups attached to the Apps that are deleted,
+// renamed or cloned
+type EnvGroups interface {
+	AttachApp(name, appName string) error
+	RenameApp(oldName, newName string) error
+	DetachApp(appName string) error
 }
 
 const (
@@ -97,29 +221,63 @@ const (
 	TeresaTeamLabel  = "teresa.io/team"
 	TeresaLastUser   = "teresa.io/last-user"
 	TeresaAppSecrets = "teresa-secrets"
+	envGroupPrefix   = "teresa-envgroup-"
 )
 
+// EnvGroupSecretName returns the name of the Secret holding the env vars
+// of the group in the namespace of an attached App
+func EnvGroupSecretName(group string) string {
+	return envGroupPrefix + group
+}
+
+// HasPermission tells if the user can change the App, a developer of its
+// team at least
 func (ops *AppOperations) HasPermission(user *database.User, appName string) bool {
+	return ops.HasRole(user, appName, team.RoleDeveloper)
+}
+
+// HasRole tells if the user is a member of the team of the App with the
+// permissions of the role
+func (ops *AppOperations) HasRole(user *database.User, appName, role string) bool {
 	teamName, err := ops.TeamName(appName)
 	if err != nil {
 		return false
 	}
-	hasPerm, err := ops.tops.HasUser(teamName, user.Email)
-	if err != nil {
-		return false
-	}
-	return hasPerm
+	return ops.hasTeamRole(user, teamName, role)
+}
+
+func (ops *AppOperations) hasTeamRole(user *database.User, teamName, role string) bool {
+	hasRole, err := ops.tops.HasRole(teamName, user, role)
+	return err == nil && hasRole
 }
 
+// Create creates the App, the developers of the team can create apps as
+// every member could before the roles
 func (ops *AppOperations) Create(user *database.User, app *App) (Err error) {
-	hasPerm, err := ops.tops.HasUser(app.Team, user.Email)
-	if err != nil || !hasPerm {
+	if !ops.hasTeamRole(user, app.Team, team.RoleDeveloper) {
 		return auth.ErrPermissionDenied
 	}
 
 	if ops.kops.IngressEnabled() && app.VirtualHost == "" && IsWebApp(app.ProcessType) {
 		return ErrMissingVirtualHost
 	}
+	if err := ops.checkVHostsAllowed(app.Team, appVHosts(app)); err != nil {
+		return err
+	}
+	if err := ops.checkTeamQuota(app.Team, app.Name, app, map[string]int32{}); err != nil {
+		return err
+	}
+
+	if app.Cluster != "" {
+		if err := ops.assignCluster(app); err != nil {
+			return err
+		}
+		defer func() {
+			if Err != nil {
+				ops.clusters.DeleteAppCluster(app.Name)
+			}
+		}()
+	}
 
 	if err := ops.kops.CreateNamespace(app, user.Email); err != nil {
 		return ops.translateError(err)
@@ -149,57 +307,54 @@ func (ops *AppOperations) Create(user *database.User, app *App) (Err error) {
 		return teresa_errors.New(ErrInvalidAutoscale, err)
 	}
 
+	if err := ops.syncPDB(app); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
 	return nil
 }
 
-func (ops *AppOperations) Logs(user *database.User, appName string, opts *LogOptions) (io.ReadCloser, error) {
-	teamName, err := ops.kops.NamespaceLabel(appName, TeresaTeamLabel)
+// Status returns the detailed state of each one of the App pods
+func (ops *AppOperations) Status(user *database.User, appName string) ([]*PodDetail, error) {
+	if _, err := ops.CheckRoleAndGet(user, appName, team.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	pods, err := ops.kops.PodDetails(appName)
 	if err != nil {
-		return nil, ops.translateError(err)
+		return nil, teresa_errors.NewInternalServerError(err)
 	}
 
-	hasPerm, err := ops.tops.HasUser(teamName, user.Email)
-	if err != nil || !hasPerm {
-		return nil, auth.ErrPermissionDenied
+	return pods, nil
+}
+
+// Events returns the Kubernetes events of the App namespace. The channel
+// is closed when there are no more events or when stop is closed
+func (ops *AppOperations) Events(user *database.User, appName string, opts *EventOptions, stop <-chan struct{}) (<-chan *Event, error) {
+	if _, err := ops.CheckRoleAndGet(user, appName, team.RoleViewer); err != nil {
+		return nil, err
 	}
 
-	pods, err := ops.kops.PodList(appName, &PodListOptions{PodName: opts.PodName})
+	evs, err := ops.kops.Events(appName, opts, stop)
 	if err != nil {
 		return nil, teresa_errors.NewInternalServerError(err)
 	}
-	if opts.Container == "" {
-		opts.Container = appName
-	}
 
-	r, w := io.Pipe()
-	var wg sync.WaitGroup
-	for _, pod := range pods {
-		wg.Add(1)
-		go func(namespace, podName string) {
-			defer wg.Done()
+	return evs, nil
+}
 
-			logs, err := ops.kops.PodLogs(namespace, podName, opts)
-			if err != nil {
-				log.WithError(err).Errorf("streaming logs from pod %s", podName)
-				return
-			}
-			defer logs.Close()
+// Top returns the current CPU and memory usage of each one of the App pods
+func (ops *AppOperations) Top(user *database.User, appName string) ([]*PodMetrics, error) {
+	if _, err := ops.CheckRoleAndGet(user, appName, team.RoleViewer); err != nil {
+		return nil, err
+	}
 
-			scanner := bufio.NewScanner(logs)
-			for scanner.Scan() {
-				fmt.Fprintf(w, "[%s] - %s\n", podName, scanner.Text())
-			}
-			if err := scanner.Err(); err != nil {
-				log.WithError(err).Errorf("streaming logs from pod %s", podName)
-			}
-		}(appName, pod.Name)
+	pods, err := ops.kops.PodMetrics(appName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
 	}
-	go func() {
-		wg.Wait()
-		w.Close()
-	}()
 
-	return r, nil
+	return pods, nil
 }
 
 func (ops *AppOperations) Info(user *database.User, appName string) (*Info, error) {
@@ -208,8 +363,7 @@ func (ops *AppOperations) Info(user *database.User, appName string) (*Info, erro
 		return nil, err
 	}
 
-	hasPerm, err := ops.tops.HasUser(teamName, user.Email)
-	if err != nil || !hasPerm {
+	if !ops.hasTeamRole(user, teamName, team.RoleViewer) {
 		return nil, auth.ErrPermissionDenied
 	}
 
@@ -238,6 +392,16 @@ func (ops *AppOperations) Info(user *database.User, appName string) (*Info, erro
 		return nil, teresa_errors.NewInternalServerError(err)
 	}
 
+	tls, err := ops.kops.IngressTLSStatus(appName, appName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	lock, err := ops.appLock(appName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
 	envVars := make([]*EnvVar, len(appMeta.EnvVars)+len(appMeta.Secrets))
 	for i, ev := range appMeta.EnvVars {
 		envVars[i] = &EnvVar{Key: ev.Key, Value: ev.Value}
@@ -247,20 +411,48 @@ func (ops *AppOperations) Info(user *database.User, appName string) (*Info, erro
 			Key: s, Value: "*****",
 		}
 	}
-	vols := make([]string, len(appMeta.SecretFiles))
-	for i := range appMeta.SecretFiles {
-		vols[i] = fmt.Sprintf("%s/%s", SecretPath, appMeta.SecretFiles[i])
+	vols := make([]string, 0, len(appMeta.SecretFiles))
+	for _, sf := range appMeta.SecretFiles {
+		vols = append(vols, secretFilePaths(appMeta, sf)...)
 	}
 
 	info := &Info{
-		Team:      teamName,
-		Addresses: addrs,
-		Status:    stat,
-		Autoscale: as,
-		Limits:    lim,
-		EnvVars:   envVars,
-		Protocol:  appMeta.Protocol,
-		Volumes:   vols,
+		Team:          teamName,
+		Addresses:     addrs,
+		Status:        stat,
+		Autoscale:     as,
+		Limits:        lim,
+		EnvVars:       envVars,
+		Protocol:      appMeta.Protocol,
+		Volumes:       vols,
+		Maintenance:   appMeta.Maintenance,
+		TLS:           tls,
+		SourceRanges:  appMeta.SourceRanges,
+		VolumeClaims:  appMeta.VolumeClaims,
+		ConfigFiles:   configFiles(appMeta),
+		Lifecycle:     appMeta.Lifecycle,
+		RollingUpdate: appMeta.RollingUpdate,
+		Builder:       appMeta.Builder,
+		BuildEnvVars:  buildEnvVarNames(appMeta),
+		Lock:          lock,
+		Cluster:       appMeta.Cluster,
+
+		RolloutTimeoutSeconds: appMeta.RolloutTimeoutSeconds,
+	}
+	if appMeta.ScaleSchedule != nil {
+		info.ScaleWindows = appMeta.ScaleSchedule.Windows
+	}
+	if !IsCronJob(appMeta.ProcessType) {
+		info.MinAvailable = appMeta.MinAvailable
+		if info.MinAvailable == "" {
+			info.MinAvailable = ops.kops.DefaultPDBMinAvailable()
+		}
+		// apps never deployed have no deploy yet
+		dm, err := ops.kops.DeployMetadata(appName, appName)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+		info.Deploy = dm
 	}
 	return info, nil
 }
@@ -287,29 +479,37 @@ func (ops *AppOperations) Get(appName string) (*App, error) {
 	return a, nil
 }
 
+// CheckPermAndGet returns the App if the user can change it, see
+// HasPermission
 func (ops *AppOperations) CheckPermAndGet(user *database.User, appName string) (*App, error) {
+	return ops.CheckRoleAndGet(user, appName, team.RoleDeveloper)
+}
+
+// CheckRoleAndGet returns the App if the user has the role in its team
+func (ops *AppOperations) CheckRoleAndGet(user *database.User, appName, role string) (*App, error) {
 	teamName, err := ops.TeamName(appName)
 	if err != nil {
 		return nil, err
 	}
 
-	hasPerm, err := ops.tops.HasUser(teamName, user.Email)
-	if err != nil || !hasPerm {
+	if !ops.hasTeamRole(user, teamName, role) {
 		return nil, auth.ErrPermissionDenied
 	}
 
 	return ops.Get(appName)
 }
 
+// SaveApp saves the App, a blank lastUser (like a background task) keeps
+// the last one
 func (ops *AppOperations) SaveApp(app *App, lastUser string) error {
 	b, err := json.Marshal(app)
 	if err != nil {
 		return fmt.Errorf("marshal app failed: %v", err)
 	}
 
-	anMap := map[string]string{
-		TeresaAnnotation: string(b),
-		TeresaLastUser:   lastUser,
+	anMap := map[string]string{TeresaAnnotation: string(b)}
+	if lastUser != "" {
+		anMap[TeresaLastUser] = lastUser
 	}
 
 	return ops.kops.SetNamespaceAnnotations(app.Name, anMap)
@@ -329,10 +529,31 @@ func (ops *AppOperations) SetEnv(user *database.User, appName string, evs []*Env
 		return err
 	}
 
+	if err := ops.applyEnvVars(app, evs); err != nil {
+		return err
+	}
+
+	setEnvVars(app, evs)
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	ops.saveEnvRevision(app, user.Email)
+
+	return nil
+}
+
+func (ops *AppOperations) applyEnvVars(app *App, evs []*EnvVar) error {
+	var err error
 	if IsCronJob(app.ProcessType) {
-		err = ops.kops.CreateOrUpdateCronJobEnvVars(appName, appName, evs)
+		err = ops.kops.CreateOrUpdateCronJobEnvVars(app.Name, app.Name, evs)
 	} else {
-		err = ops.kops.CreateOrUpdateDeployEnvVars(appName, appName, evs)
+		for _, name := range deployNames(app) {
+			err = ops.kops.CreateOrUpdateDeployEnvVars(app.Name, name, evs)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
 	}
 
 	if err != nil {
@@ -342,44 +563,258 @@ func (ops *AppOperations) SetEnv(user *database.User, appName string, evs []*Env
 			return teresa_errors.NewInternalServerError(err)
 		}
 	}
+	return nil
+}
 
-	setEnvVars(app, evs)
+func (ops *AppOperations) UnsetEnv(user *database.User, appName string, evNames []string) error {
+	if err := checkForInvalidEnvVars(evNames); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if err := ops.deleteEnvVars(app, evNames); err != nil {
+		return err
+	}
+
+	unsetEnvVars(app, evNames)
 
 	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
+	ops.saveEnvRevision(app, user.Email)
 
 	return nil
 }
 
-func (ops *AppOperations) UnsetEnv(user *database.User, appName string, evNames []string) error {
-	if err := checkForInvalidEnvVars(evNames); err != nil {
-		return err
+func (ops *AppOperations) deleteEnvVars(app *App, evNames []string) error {
+	var err error
+	if IsCronJob(app.ProcessType) {
+		err = ops.kops.DeleteCronJobEnvVars(app.Name, app.Name, evNames)
+	} else {
+		for _, name := range deployNames(app) {
+			err = ops.kops.DeleteDeployEnvVars(app.Name, name, evNames)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
+	}
+
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// saveEnvRevision records the current env vars of the app, the change was
+// already applied so a failure here is only logged.
+func (ops *AppOperations) saveEnvRevision(app *App, author string) {
+	if ops.eh == nil {
+		return
+	}
+	if err := ops.eh.Save(app.Name, author, app.EnvVars); err != nil {
+		log.WithError(err).Errorf("saving env revision of app %s", app.Name)
+	}
+}
+
+func (ops *AppOperations) EnvHistory(user *database.User, appName string) ([]*EnvRevision, error) {
+	if ops.eh == nil {
+		return nil, ErrEnvHistoryNotAvailable
 	}
+	if _, err := ops.CheckRoleAndGet(user, appName, team.RoleViewer); err != nil {
+		return nil, err
+	}
+	return ops.eh.List(appName)
+}
 
+func (ops *AppOperations) EnvRollback(user *database.User, appName string, version int32) error {
+	if ops.eh == nil {
+		return ErrEnvHistoryNotAvailable
+	}
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
 
-	if IsCronJob(app.ProcessType) {
-		err = ops.kops.DeleteCronJobEnvVars(appName, appName, evNames)
-	} else {
-		err = ops.kops.DeleteDeployEnvVars(appName, appName, evNames)
+	rev, err := ops.eh.Get(appName, version)
+	if err != nil {
+		return err
+	}
+
+	toUnset := make([]string, 0)
+	for _, ev := range app.EnvVars {
+		if !hasEnvVar(rev.EnvVars, ev.Key) {
+			toUnset = append(toUnset, ev.Key)
+		}
+	}
+
+	if len(rev.EnvVars) > 0 {
+		if err := ops.applyEnvVars(app, rev.EnvVars); err != nil {
+			return err
+		}
+		setEnvVars(app, rev.EnvVars)
+	}
+	if len(toUnset) > 0 {
+		if err := ops.deleteEnvVars(app, toUnset); err != nil {
+			return err
+		}
+		unsetEnvVars(app, toUnset)
+	}
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	ops.saveEnvRevision(app, user.Email)
+
+	return nil
+}
+
+func (ops *AppOperations) SetEnvHistory(eh EnvHistory) {
+	ops.eh = eh
+}
+
+// SetClusters sets the assignments of the Apps to the clusters, the Apps
+// can't be created on a cluster other than the one of the server without it
+func (ops *AppOperations) SetClusters(c Clusters) {
+	ops.clusters = c
+}
+
+// assignCluster assigns the new App to its cluster, the name must not be
+// taken on the cluster of the server either
+func (ops *AppOperations) assignCluster(app *App) error {
+	if ops.clusters == nil {
+		return ErrClusterNotFound
+	}
+	if _, err := ops.TeamName(app.Name); err == nil {
+		return ErrAlreadyExists
+	} else if err != ErrNotFound {
+		return err
+	}
+	if err := ops.clusters.SetAppCluster(app.Name, app.Cluster); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// deleteNamespace deletes the namespace of the App and then its assignment
+// to a cluster, needed to find the namespace
+func (ops *AppOperations) deleteNamespace(appName string) error {
+	if err := ops.kops.DeleteNamespace(appName); err != nil {
+		return err
+	}
+	if ops.clusters == nil {
+		return nil
 	}
+	return ops.clusters.DeleteAppCluster(appName)
+}
+
+// SetEnvGroups sets the env groups to update when an attached App is
+// deleted, renamed or cloned
+func (ops *AppOperations) SetEnvGroups(egs EnvGroups) {
+	ops.egs = egs
+}
 
+// SetLogStore sets the historical logs backend of the logs since a time
+func (ops *AppOperations) SetLogStore(ls logstore.Store) {
+	ops.ls = ls
+}
+
+// SetEnvGroup creates or updates the env vars of the group in the App,
+// attaching the group if needed. Permissions are checked by the caller, a
+// group change is rolled out to every attached App.
+func (ops *AppOperations) SetEnvGroup(user *database.User, appName, group string, evs []*EnvVar) error {
+	app, err := ops.Get(appName)
 	if err != nil {
-		if !ops.kops.IsNotFound(err) {
-			return teresa_errors.NewInternalServerError(err)
+		return err
+	}
+
+	data := make(map[string][]byte)
+	for _, ev := range evs {
+		data[ev.Key] = []byte(ev.Value)
+	}
+	if err := ops.kops.CreateOrUpdateSecret(appName, EnvGroupSecretName(group), data); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if hasEnvGroup(app, group) {
+		// env vars from secrets are only read on the container start
+		if IsCronJob(app.ProcessType) {
+			return nil
+		}
+		for _, name := range deployNames(app) {
+			err := ops.kops.DeployRestart(appName, name)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				return teresa_errors.NewInternalServerError(err)
+			}
 		}
+		return nil
 	}
 
-	unsetEnvVars(app, evNames)
+	app.EnvGroups = append(app.EnvGroups, group)
+	if err := ops.setEnvFromSecrets(app); err != nil {
+		return err
+	}
 
 	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
+	return nil
+}
+
+// UnsetEnvGroup detaches the group from the App, permissions are checked
+// by the caller
+func (ops *AppOperations) UnsetEnvGroup(user *database.User, appName, group string) error {
+	app, err := ops.Get(appName)
+	if err != nil {
+		return err
+	}
+
+	if hasEnvGroup(app, group) {
+		for i := range app.EnvGroups {
+			if app.EnvGroups[i] == group {
+				app.EnvGroups = append(app.EnvGroups[:i], app.EnvGroups[i+1:]...)
+				break
+			}
+		}
+		if err := ops.setEnvFromSecrets(app); err != nil {
+			return err
+		}
+		if err := ops.SaveApp(app, user.Email); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	err = ops.kops.DeleteSecret(appName, EnvGroupSecretName(group))
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+func (ops *AppOperations) setEnvFromSecrets(app *App) error {
+	names := EnvGroupsSecretNames(app)
+
+	var err error
+	if IsCronJob(app.ProcessType) {
+		err = ops.kops.CronJobSetEnvFromSecrets(app.Name, app.Name, names)
+	} else {
+		for _, name := range deployNames(app) {
+			err = ops.kops.DeploySetEnvFromSecrets(app.Name, name, names)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
+	}
 
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
 	return nil
 }
 
@@ -397,7 +832,17 @@ func (ops *AppOperations) addresses(app *App) ([]*Address, error) {
 	return ops.kops.AddressList(app.Name)
 }
 
-func (ops *AppOperations) SetSecretFile(user *database.User, appName, name string, content []byte) error {
+func (ops *AppOperations) SetSecretFile(user *database.User, appName, name string, content []byte, mountPath string) error {
+	if mountPath != "" {
+		if !validation.IsMountPath(mountPath) {
+			return ErrInvalidMountPath
+		}
+		mountPath = path.Clean(mountPath)
+		if mountPath == SecretPath {
+			mountPath = ""
+		}
+	}
+
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
@@ -422,16 +867,21 @@ func (ops *AppOperations) SetSecretFile(user *database.User, appName, name strin
 	}
 
 	if IsCronJob(app.ProcessType) {
-		err = ops.kops.CreateOrUpdateCronJobSecretFile(appName, appName, name)
+		err = ops.kops.CreateOrUpdateCronJobSecretFile(appName, appName, name, mountPath)
 	} else {
-		err = ops.kops.CreateOrUpdateDeploySecretFile(appName, appName, name)
+		for _, dn := range deployNames(app) {
+			err = ops.kops.CreateOrUpdateDeploySecretFile(appName, dn, name, mountPath)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
 	}
 
 	if err != nil && !ops.kops.IsNotFound(err) {
 		return teresa_errors.NewInternalServerError(err)
 	}
 
-	setSecretFileOnApp(app, name)
+	setSecretFileOnApp(app, name, mountPath)
 
 	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
@@ -440,56 +890,62 @@ func (ops *AppOperations) SetSecretFile(user *database.User, appName, name strin
 	return nil
 }
 
-func (ops *AppOperations) SetSecret(user *database.User, appName string, secrets []*EnvVar) error {
-	names := make([]string, len(secrets))
-	for i := range secrets {
-		names[i] = secrets[i].Key
-	}
-	if err := checkForInvalidEnvVars(names); err != nil {
+// SetConfigFile creates or updates the file name of the App config files
+// and mounts it at the mountPath directory of the app containers. Files
+// mounted from a ConfigMap aren't updated in place, so the deploys are
+// restarted when only the content changes
+func (ops *AppOperations) SetConfigFile(user *database.User, appName, name string, content []byte, mountPath string) error {
+	if err := validateConfigFile(name, mountPath); err != nil {
 		return err
 	}
+	mountPath = path.Clean(mountPath)
 
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
 
-	s, err := ops.kops.GetSecret(appName, TeresaAppSecrets)
-	if err != nil {
-		if !ops.kops.IsNotFound(err) {
-			return teresa_errors.NewInternalServerError(err)
-		}
-	}
-	if s == nil {
-		s = make(map[string][]byte)
+	data, err := ops.kops.GetConfigMap(appName, TeresaAppConfigFiles)
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
 	}
-
-	for _, secret := range secrets {
-		s[secret.Key] = []byte(secret.Value)
+	if data == nil {
+		data = make(map[string]string)
 	}
+	changed := data[name] != string(content)
+	data[name] = string(content)
 
-	if err := ops.kops.CreateOrUpdateSecret(appName, TeresaAppSecrets, s); err != nil {
+	if err := ops.kops.CreateOrUpdateConfigMap(appName, TeresaAppConfigFiles, data); err != nil {
 		if ops.kops.IsInvalid(err) {
-			return ErrInvalidSecretName
+			return ErrInvalidConfigFile
 		}
 		return teresa_errors.NewInternalServerError(err)
 	}
 
+	mount := app.ConfigFiles[name] != mountPath
 	if IsCronJob(app.ProcessType) {
-		err = ops.kops.CreateOrUpdateCronJobSecretEnvVars(appName, appName, TeresaAppSecrets, names)
+		if mount {
+			err = ops.kops.SetCronJobConfigFile(appName, appName, name, mountPath)
+		}
 	} else {
-		err = ops.kops.CreateOrUpdateDeploySecretEnvVars(appName, appName, TeresaAppSecrets, names)
+		for _, dn := range deployNames(app) {
+			// a new mount already rolls the pods out
+			if mount {
+				err = ops.kops.SetDeployConfigFile(appName, dn, name, mountPath)
+			} else if changed {
+				err = ops.kops.DeployRestart(appName, dn)
+			}
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
 	}
 
-	if err != nil {
-		if ops.kops.IsInvalid(err) {
-			return ErrInvalidSecretName
-		} else if !ops.kops.IsNotFound(err) {
-			return teresa_errors.NewInternalServerError(err)
-		}
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
 	}
 
-	setSecretsOnApp(app, names)
+	setConfigFileOnApp(app, name, mountPath)
 
 	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
@@ -498,14 +954,125 @@ func (ops *AppOperations) SetSecret(user *database.User, appName string, secrets
 	return nil
 }
 
-func (ops *AppOperations) UnsetSecret(user *database.User, appName string, secrets []string) error {
+// UnsetConfigFile unmounts the file name from the app containers and
+// removes it from the App config files
+func (ops *AppOperations) UnsetConfigFile(user *database.User, appName, name string) error {
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
-	envSecrets, fileSecrets := make([]string, 0), make([]string, 0)
+	if _, found := app.ConfigFiles[name]; !found {
+		return ErrConfigFileNotFound
+	}
 
-Loop:
+	if IsCronJob(app.ProcessType) {
+		err = ops.kops.SetCronJobConfigFile(appName, appName, name, "")
+	} else {
+		for _, dn := range deployNames(app) {
+			err = ops.kops.SetDeployConfigFile(appName, dn, name, "")
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
+	}
+
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	delete(app.ConfigFiles, name)
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	// We remove the file as last step to prevent errors on deploy/cron update
+	data, err := ops.kops.GetConfigMap(appName, TeresaAppConfigFiles)
+	if err != nil {
+		if ops.kops.IsNotFound(err) {
+			return nil
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	delete(data, name)
+	if err := ops.kops.CreateOrUpdateConfigMap(appName, TeresaAppConfigFiles, data); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+func (ops *AppOperations) SetSecret(user *database.User, appName string, secrets []*EnvVar) error {
+	names := make([]string, len(secrets))
+	for i := range secrets {
+		names[i] = secrets[i].Key
+	}
+	if err := checkForInvalidEnvVars(names); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	s, err := ops.kops.GetSecret(appName, TeresaAppSecrets)
+	if err != nil {
+		if !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+	if s == nil {
+		s = make(map[string][]byte)
+	}
+
+	for _, secret := range secrets {
+		s[secret.Key] = []byte(secret.Value)
+	}
+
+	if err := ops.kops.CreateOrUpdateSecret(appName, TeresaAppSecrets, s); err != nil {
+		if ops.kops.IsInvalid(err) {
+			return ErrInvalidSecretName
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if IsCronJob(app.ProcessType) {
+		err = ops.kops.CreateOrUpdateCronJobSecretEnvVars(appName, appName, TeresaAppSecrets, names)
+	} else {
+		for _, name := range deployNames(app) {
+			err = ops.kops.CreateOrUpdateDeploySecretEnvVars(appName, name, TeresaAppSecrets, names)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		if ops.kops.IsInvalid(err) {
+			return ErrInvalidSecretName
+		} else if !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	setSecretsOnApp(app, names)
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+func (ops *AppOperations) UnsetSecret(user *database.User, appName string, secrets []string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+	envSecrets, fileSecrets := make([]string, 0), make([]string, 0)
+
+Loop:
 	for _, s := range secrets {
 		for _, ev := range app.Secrets {
 			if ev == s {
@@ -544,7 +1111,12 @@ Loop:
 	if IsCronJob(app.ProcessType) {
 		err = ops.kops.DeleteCronJobSecrets(appName, appName, envSecrets, fileSecrets)
 	} else {
-		err = ops.kops.DeleteDeploySecrets(appName, appName, envSecrets, fileSecrets)
+		for _, name := range deployNames(app) {
+			err = ops.kops.DeleteDeploySecrets(appName, name, envSecrets, fileSecrets)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
 	}
 
 	if err != nil {
@@ -627,20 +1199,54 @@ func (ops *AppOperations) SetAutoscale(user *database.User, appName string, as *
 		return ErrInvalidActionForCronJob
 	}
 
+	if !validAutoscaleMetrics(as.Metrics) {
+		return ErrInvalidAutoscale
+	}
+
 	old, err := ops.kops.Autoscale(appName)
 	if err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
+	if old == nil {
+		old = new(Autoscale)
+	}
 
 	if c := as.CPUTargetUtilization; c < 0 || c > 100 {
 		as.CPUTargetUtilization = old.CPUTargetUtilization
 	}
+	if m := as.MemoryTargetUtilization; m < 0 || m > 100 {
+		as.MemoryTargetUtilization = old.MemoryTargetUtilization
+	}
+	if as.Metrics == nil {
+		as.Metrics = old.Metrics
+	}
+	// the schedule restores the new values outside of its windows
+	if sched := app.ScaleSchedule; sched != nil {
+		sched.Min, sched.Max = as.Min, as.Max
+		as.Min, as.Max = sched.target(time.Now())
+	}
+
+	// the autoscaler scales the main Deployment up to its min replicas
+	current, err := ops.replicasOf(app)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if current[app.Name] < as.Min {
+		current[app.Name] = as.Min
+	}
+	if err := ops.checkAppQuota(appName, app, current); err != nil {
+		return err
+	}
 	app.Autoscale = as
 
 	if err := ops.kops.CreateOrUpdateAutoscale(app); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
 
+	if err := ops.syncPDB(app); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
 	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
@@ -648,96 +1254,1137 @@ func (ops *AppOperations) SetAutoscale(user *database.User, appName string, as *
 	return nil
 }
 
-func (ops *AppOperations) Delete(user *database.User, appName string) error {
+// SetAutoscaleSchedule sets the scale windows of the App, the current min
+// and max replicas are restored outside of them. No windows removes the
+// schedule
+func (ops *AppOperations) SetAutoscaleSchedule(user *database.User, appName string, windows []*ScaleWindow) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+	if err := validateScaleWindows(windows); err != nil {
+		return err
+	}
+
+	sched := app.ScaleSchedule
+	if sched == nil {
+		sched = new(ScaleSchedule)
+		as, err := ops.kops.Autoscale(appName)
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if as != nil {
+			sched.Min, sched.Max = as.Min, as.Max
+		} else if sched.Min, err = ops.kops.DeployReplicas(appName, appName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+	sched.Windows = windows
+	app.ScaleSchedule = sched
+
+	if app.Paused == nil {
+		if err := ops.applyAutoscaleSchedule(app, time.Now()); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if len(windows) == 0 {
+		app.ScaleSchedule = nil
+	}
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetPDB sets the minimum number (or percentage) of available pods of the
+// App during voluntary disruptions, blank uses the cluster default and zero
+// disables it
+func (ops *AppOperations) SetPDB(user *database.User, appName, minAvailable string) error {
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
 
-	if err := ops.kops.DeleteNamespace(app.Name); err != nil {
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+	if !validMinAvailable(minAvailable) {
+		return ErrInvalidMinAvailable
+	}
+
+	app.MinAvailable = minAvailable
+	if err := ops.syncPDB(app); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
 
 	return nil
 }
 
-func (ops *AppOperations) SetReplicas(user *database.User, appName string, replicas int32) error {
+// AddVolume creates a PersistentVolumeClaim for the App, mounted in the app
+// container from the next deploy on
+func (ops *AppOperations) AddVolume(user *database.User, appName string, vc *VolumeClaim) error {
 	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
 
 	if IsCronJob(app.ProcessType) {
-		if replicas == 0 {
-			err = ops.kops.SuspendCronJob(appName, appName)
-		} else {
-			err = ops.kops.ResumeCronJob(appName, appName)
-		}
-		if err != nil {
-			return teresa_errors.NewInternalServerError(err)
-		}
-	} else if err := ops.kops.DeploySetReplicas(app.Name, app.Name, replicas); err != nil {
+		return ErrInvalidActionForCronJob
+	}
+	if err := validateVolumeClaim(vc, app.VolumeClaims); err != nil {
+		return err
+	}
+
+	if err := ops.kops.CreatePVC(app.Name, vc); err != nil && !ops.kops.IsAlreadyExists(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	app.VolumeClaims = append(app.VolumeClaims, vc)
+	if err := ops.SaveApp(app, user.Email); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
 
 	return nil
 }
 
-// ChangeTeam changes current team name of an App (be sure the new team exists)
-func (ops *AppOperations) ChangeTeam(appName, teamName string) error {
-	label := map[string]string{TeresaTeamLabel: teamName}
-	if err := ops.kops.SetNamespaceLabels(appName, label); err != nil {
-		return ops.translateError(err)
+// RemoveVolume deletes a PersistentVolumeClaim of the App along with its
+// data, the app container stops mounting it on the next deploy
+func (ops *AppOperations) RemoveVolume(user *database.User, appName, volumeName string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	i := volumeClaimIndex(app.VolumeClaims, volumeName)
+	if i < 0 {
+		return ErrVolumeClaimNotFound
+	}
+
+	if err := ops.kops.DeletePVC(app.Name, volumeName); err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
 	}
+
+	app.VolumeClaims = append(app.VolumeClaims[:i], app.VolumeClaims[i+1:]...)
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
 	return nil
 }
 
-func (ops *AppOperations) DeletePods(user *database.User, appName string, podsNames []string) error {
-	if _, err := ops.CheckPermAndGet(user, appName); err != nil {
+func (ops *AppOperations) Delete(user *database.User, appName string) error {
+	app, err := ops.CheckRoleAndGet(user, appName, team.RoleAdmin)
+	if err != nil {
 		return err
 	}
+	return ops.DeleteApp(app.Name)
+}
 
-	for _, pod := range podsNames {
-		if err := ops.kops.DeletePod(appName, pod); err != nil {
-			if ops.kops.IsNotFound(err) {
-				continue
-			}
+// DeleteApp deletes an App without checking the permissions of the user,
+// like when its team is deleted
+func (ops *AppOperations) DeleteApp(appName string) error {
+	if err := ops.kops.IngressDeleteStreamPorts(appName, appName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if ops.eh != nil {
+		if err := ops.eh.Delete(appName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if ops.egs != nil {
+		if err := ops.egs.DetachApp(appName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if ops.locks != nil {
+		if err := ops.locks.Delete(appName); err != nil {
 			return teresa_errors.NewInternalServerError(err)
 		}
 	}
 
+	if err := ops.deleteNamespace(appName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
 	return nil
 }
 
-func (ops *AppOperations) SetVHosts(user *database.User, appName string, vHosts []string) error {
-	a, err := ops.CheckPermAndGet(user, appName)
+func (ops *AppOperations) SetReplicas(user *database.User, appName string, replicas int32) error {
+	app, err := ops.CheckPermAndGet(user, appName)
 	if err != nil {
 		return err
 	}
 
-	if len(vHosts) == 0 && ops.kops.IngressEnabled() {
-		return ErrInvalidBlankVHost
+	if IsCronJob(app.ProcessType) {
+		if replicas == 0 {
+			err = ops.kops.SuspendCronJob(appName, appName)
+		} else {
+			err = ops.kops.ResumeCronJob(appName, appName)
+		}
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		return nil
 	}
 
-	hasIngress, err := ops.kops.HasIngress(appName, appName)
+	current, err := ops.replicasOf(app)
 	if err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
+	current[app.Name] = replicas
+	if err := ops.checkAppQuota(appName, app, current); err != nil {
+		return err
+	}
 
-	if hasIngress {
-		if err := ops.kops.UpdateIngress(appName, appName, vHosts); err != nil {
-			return teresa_errors.NewInternalServerError(err)
-		}
+	if err := ops.kops.DeploySetReplicas(app.Name, app.Name, replicas); err != nil {
+		return teresa_errors.NewInternalServerError(err)
 	}
 
-	a.VirtualHost = strings.Join(vHosts, ",")
-	if err := ops.SaveApp(a, user.Email); err != nil {
+	if err := ops.syncPDB(app); err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
 
 	return nil
 }
 
+// Scale sets the number of replicas of each process type of an App, the
+// process types other than the main one run in Deployments of their own
+func (ops *AppOperations) Scale(user *database.User, appName string, replicas map[string]int32) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+
+	for pt, n := range replicas {
+		if !IsValidProcessType(pt) {
+			return ErrInvalidProcessType
+		}
+		if n < 0 {
+			return ErrInvalidReplicas
+		}
+	}
+
+	current, err := ops.replicasOf(app)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	for pt, n := range replicas {
+		name := appName
+		if pt != app.ProcessType {
+			name = ProcessDeployName(appName, pt)
+		}
+		current[name] = n
+	}
+	if err := ops.checkAppQuota(appName, app, current); err != nil {
+		return err
+	}
+
+	if app.Processes == nil {
+		app.Processes = make(map[string]int32)
+	}
+	for pt, n := range replicas {
+		name := appName
+		if pt != app.ProcessType {
+			name = ProcessDeployName(appName, pt)
+			app.Processes[pt] = n
+		}
+		err := ops.kops.DeploySetReplicas(appName, name, n)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if _, found := replicas[app.ProcessType]; found {
+		if err := ops.syncPDB(app); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// Pause scales all Deployments of an App to zero and removes its autoscale,
+// the previous configuration is kept to be restored by Resume
+func (ops *AppOperations) Pause(user *database.User, appName string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+	if app.Paused != nil {
+		return ErrAlreadyPaused
+	}
+
+	as, err := ops.kops.Autoscale(appName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	ps := &PausedState{Replicas: make(map[string]int32), Autoscale: as}
+
+	for _, name := range deployNames(app) {
+		n, err := ops.kops.DeployReplicas(appName, name)
+		if err != nil {
+			if ops.kops.IsNotFound(err) {
+				continue
+			}
+			return teresa_errors.NewInternalServerError(err)
+		}
+		ps.Replicas[name] = n
+	}
+
+	if as != nil {
+		if err := ops.kops.DeleteAutoscale(appName); err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	for name := range ps.Replicas {
+		if err := ops.kops.DeploySetReplicas(appName, name, 0); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	app.Paused = ps
+	if err := ops.syncPDB(app); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// Resume restores the replicas and autoscale of an App paused by Pause
+func (ops *AppOperations) Resume(user *database.User, appName string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if app.Paused == nil {
+		return ErrNotPaused
+	}
+
+	replicas := make(map[string]int32)
+	for name, n := range app.Paused.Replicas {
+		if name == appName && n < 1 {
+			n = 1
+		}
+		replicas[name] = n
+	}
+	current, err := ops.replicasOf(app)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	for name, n := range replicas {
+		current[name] = n
+	}
+	if err := ops.checkAppQuota(appName, app, current); err != nil {
+		return err
+	}
+
+	for name, n := range replicas {
+		err := ops.kops.DeploySetReplicas(appName, name, n)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if as := app.Paused.Autoscale; as != nil {
+		app.Autoscale = as
+		if err := ops.kops.CreateOrUpdateAutoscale(app); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	app.Paused = nil
+	if err := ops.syncPDB(app); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// Restart does a rolling restart of the pods of all Deployments of an App
+func (ops *AppOperations) Restart(user *database.User, appName string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+
+	for _, name := range deployNames(app) {
+		err := ops.kops.DeployRestart(appName, name)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	return nil
+}
+
+// SetMaintenance routes the ingress of the App to the maintenance page, or
+// back to the App, the pods are kept running
+func (ops *AppOperations) SetMaintenance(user *database.User, appName string, enabled bool) error {
+	if !ops.kops.MaintenanceEnabled() {
+		return ErrMaintenanceNotAvailable
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	hasIngress, err := ops.kops.HasIngress(appName, appName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !hasIngress {
+		return ErrMaintenanceNeedsIngress
+	}
+
+	if err := ops.kops.IngressSetMaintenance(appName, appName, enabled); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	app.Maintenance = enabled
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// EnableTLS annotates the ingress of the App for cert-manager to issue a
+// certificate for its vhosts
+func (ops *AppOperations) EnableTLS(user *database.User, appName string) error {
+	if !ops.kops.CertManagerEnabled() {
+		return ErrTLSNotAvailable
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	hasIngress, err := ops.kops.HasIngress(appName, appName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !hasIngress {
+		return ErrTLSNeedsIngress
+	}
+
+	if err := ops.kops.IngressEnableTLS(appName, appName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	app.TLS = true
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetIngressOptions validates and sets the ingress options of the App,
+// blank values unset the option
+func (ops *AppOperations) SetIngressOptions(user *database.User, appName string, opts map[string]string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if err := validateIngressOptions(opts, ops.kops.IngressAllowedOptions()); err != nil {
+		return err
+	}
+	mergeIngressOptions(app, opts)
+
+	if err := ops.kops.IngressSetOptions(appName, appName, app.IngressOptions); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetACL restricts the access to the ingress or load balancer of the App
+// to the cidrs, an empty list lifts the restriction
+func (ops *AppOperations) SetACL(user *database.User, appName string, cidrs []string) error {
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return ErrInvalidCIDR
+		}
+	}
+
+	hasIngress, err := ops.kops.HasIngress(appName, appName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if hasIngress {
+		err = ops.kops.IngressSetSourceRanges(appName, appName, cidrs)
+	} else {
+		var svcType string
+		svcType, err = ops.kops.ServiceType(appName, appName)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if svcType != serviceTypeLoadBalancer {
+			return ErrACLNeedsExternalAccess
+		}
+		err = ops.kops.SetLoadBalancerSourceRanges(appName, appName, cidrs)
+	}
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	app.SourceRanges = cidrs
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetResources updates the CPU and memory limits and requests of the App
+// containers in place, blank values keep the current ones
+func (ops *AppOperations) SetResources(user *database.User, appName string, r *Resources) error {
+	if err := checkForInvalidResources(r); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	setResourcesOnApp(app, r)
+	if err := ops.checkAppQuota(appName, app, nil); err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		err = ops.kops.CronJobSetResources(appName, appName, app.Resources)
+	} else {
+		for _, name := range deployNames(app) {
+			err = ops.kops.DeploySetResources(appName, name, app.Resources)
+			if err != nil && !ops.kops.IsNotFound(err) {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		if ops.kops.IsInvalid(err) {
+			return teresa_errors.New(ErrInvalidLimits, err)
+		} else if !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetLifecycle sets how the pods of the App shut down, the deploys are
+// updated right away
+func (ops *AppOperations) SetLifecycle(user *database.User, appName string, lc *Lifecycle) error {
+	if err := validateLifecycle(lc); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+
+	for _, name := range deployNames(app) {
+		err = ops.kops.DeploySetLifecycle(appName, name, lc)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	app.Lifecycle = lc
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetRollingUpdate sets the max surge and max unavailable pods of the
+// rollouts of the App, the running pods aren't replaced
+func (ops *AppOperations) SetRollingUpdate(user *database.User, appName string, ru *RollingUpdate) error {
+	if err := ValidateRollingUpdate(ru); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+
+	for _, name := range deployNames(app) {
+		err = ops.kops.DeploySetRollingUpdate(appName, name, ru.WithDefaults())
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	app.RollingUpdate = ru
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetRolloutTimeout sets the time the pods of the next deploys of the App
+// have to get ready before the deploy is rolled back, zero uses the one of
+// the cluster
+func (ops *AppOperations) SetRolloutTimeout(user *database.User, appName string, seconds int32) error {
+	if err := ValidateRolloutTimeout(seconds); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	if IsCronJob(app.ProcessType) {
+		return ErrInvalidActionForCronJob
+	}
+
+	app.RolloutTimeoutSeconds = seconds
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// SetBuilder sets the build mode of the next deploys of the App, slug
+// (default) or cnb
+func (ops *AppOperations) SetBuilder(user *database.User, appName, builder string) error {
+	if !validBuilder(builder) {
+		return ErrInvalidBuilder
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	app.Builder = normalizeBuilder(builder)
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+func checkForInvalidResources(r *Resources) error {
+	qs := []string{r.CPU, r.Memory, r.CPURequest, r.MemoryRequest}
+	blank := true
+	for _, q := range qs {
+		if q == "" {
+			continue
+		}
+		if !validation.IsQuantity(q) {
+			return ErrInvalidLimits
+		}
+		blank = false
+	}
+	if blank {
+		return ErrInvalidLimits
+	}
+	return nil
+}
+
+// ChangeTeam changes current team name of an App (be sure the new team exists)
+func (ops *AppOperations) ChangeTeam(appName, teamName string) error {
+	label := map[string]string{TeresaTeamLabel: teamName}
+	if err := ops.kops.SetNamespaceLabels(appName, label); err != nil {
+		return ops.translateError(err)
+	}
+	return nil
+}
+
+// TransferTeam moves an App to another team, the user must be an admin or a
+// team admin of both the current and the new team. Apps with env groups
+// attached can't be transferred, the groups belong to the current team, and
+// protected apps stay protected on the new team
+func (ops *AppOperations) TransferTeam(user *database.User, appName, teamName string) error {
+	curTeam, err := ops.TeamName(appName)
+	if err != nil {
+		return err
+	}
+
+	adminOfNewTeam, err := ops.tops.HasRole(teamName, user, team.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsAdmin {
+		if !adminOfNewTeam || !ops.hasTeamRole(user, curTeam, team.RoleAdmin) {
+			return auth.ErrPermissionDenied
+		}
+	}
+
+	// the App counts on the quota of the new team from now on
+	a, err := ops.Get(appName)
+	if err != nil {
+		return err
+	}
+	if len(a.EnvGroups) > 0 {
+		return ErrEnvGroupsAttached
+	}
+	if err := ops.checkTeamQuota(teamName, appName, a, nil); err != nil {
+		return err
+	}
+
+	protected, err := ops.isProtected(curTeam, appName)
+	if err != nil {
+		return err
+	}
+	if protected {
+		if err := ops.setProtected(teamName, appName, true); err != nil {
+			return err
+		}
+	}
+
+	if err := ops.ChangeTeam(appName, teamName); err != nil {
+		return err
+	}
+
+	if protected {
+		if err := ops.setProtected(curTeam, appName, false); err != nil {
+			return err
+		}
+	}
+
+	an := map[string]string{TeresaLastUser: user.Email}
+	if err := ops.kops.SetNamespaceAnnotations(appName, an); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// isProtected tells if the deploys of the App need the approval of the
+// admins of the team
+func (ops *AppOperations) isProtected(teamName, appName string) (bool, error) {
+	apps, err := ops.tops.ProtectedApps(teamName)
+	if err != nil {
+		return false, err
+	}
+	return hasString(apps, appName), nil
+}
+
+// setProtected adds or removes the App from the protected apps of the team
+func (ops *AppOperations) setProtected(teamName, appName string, protected bool) error {
+	apps, err := ops.tops.ProtectedApps(teamName)
+	if err != nil {
+		return err
+	}
+	newApps := make([]string, 0, len(apps)+1)
+	for _, name := range apps {
+		if name != appName {
+			newApps = append(newApps, name)
+		}
+	}
+	if protected {
+		newApps = append(newApps, appName)
+	}
+	return ops.tops.SetApproval(teamName, newApps)
+}
+
+func (ops *AppOperations) DeletePods(user *database.User, appName string, podsNames []string) error {
+	if _, err := ops.CheckPermAndGet(user, appName); err != nil {
+		return err
+	}
+
+	for _, pod := range podsNames {
+		if err := ops.kops.DeletePod(appName, pod); err != nil {
+			if ops.kops.IsNotFound(err) {
+				continue
+			}
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	return nil
+}
+
+func (ops *AppOperations) SetVHosts(user *database.User, appName string, vHosts []string) error {
+	a, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	return ops.setVHosts(user, a, vHosts)
+}
+
+// AddVHost adds a virtual host to the App, patching its ingress rules
+func (ops *AppOperations) AddVHost(user *database.User, appName, vHost string) error {
+	a, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	cur := appVHosts(a)
+	for _, vh := range cur {
+		if vh == vHost {
+			return ErrVHostAlreadyExists
+		}
+	}
+
+	return ops.setVHosts(user, a, append(cur, vHost))
+}
+
+// RemoveVHost removes a virtual host of the App, patching its ingress rules
+func (ops *AppOperations) RemoveVHost(user *database.User, appName, vHost string) error {
+	a, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	cur := appVHosts(a)
+	for i, vh := range cur {
+		if vh == vHost {
+			return ops.setVHosts(user, a, append(cur[:i], cur[i+1:]...))
+		}
+	}
+
+	return ErrVHostNotFound
+}
+
+func (ops *AppOperations) setVHosts(user *database.User, a *App, vHosts []string) error {
+	if len(vHosts) == 0 && ops.kops.IngressEnabled() {
+		return ErrInvalidBlankVHost
+	}
+	teamName, err := ops.TeamName(a.Name)
+	if err != nil {
+		return err
+	}
+	if err := ops.checkVHostsAllowed(teamName, vHosts); err != nil {
+		return err
+	}
+
+	hasIngress, err := ops.kops.HasIngress(a.Name, a.Name)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if hasIngress {
+		if err := ops.kops.UpdateIngress(a.Name, a.Name, vHosts); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	a.VirtualHost = strings.Join(vHosts, ",")
+	if err := ops.SaveApp(a, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// checkVHostsAllowed returns ErrVHostNotAllowed if any of the vHosts isn't
+// under the domains allowed to the team, teams without domains allow any
+func (ops *AppOperations) checkVHostsAllowed(teamName string, vHosts []string) error {
+	if len(vHosts) == 0 {
+		return nil
+	}
+
+	domains, err := ops.tops.Domains(teamName)
+	if err != nil {
+		return err
+	}
+	if len(domains) == 0 {
+		return nil
+	}
+
+	for _, vh := range vHosts {
+		allowed := false
+		for _, d := range domains {
+			if validation.IsSubdomain(strings.ToLower(vh), d) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrVHostNotAllowed
+		}
+	}
+	return nil
+}
+
+// prepareCopy returns a copy of the App srcName, with its team, limits and
+// autoscale, ready to be created as dstName
+func (ops *AppOperations) prepareCopy(user *database.User, srcName, dstName string) (*App, error) {
+	a, err := ops.CheckPermAndGet(user, srcName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := ops.TeamName(dstName); err == nil {
+		return nil, ErrAlreadyExists
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+
+	teamName, err := ops.TeamName(srcName)
+	if err != nil {
+		return nil, err
+	}
+
+	lim, err := ops.kops.Limits(srcName, limitsName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	as, err := ops.kops.Autoscale(srcName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	if as == nil && !IsCronJob(a.ProcessType) {
+		err = fmt.Errorf("autoscale of app %s not found", srcName)
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	// the copy goes to the cluster of the App, the resources are copied
+	// within it
+	if ops.clusters != nil {
+		if a.Cluster, err = ops.clusters.AppCluster(srcName); err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	a.Name = dstName
+	a.Team = teamName
+	a.Limits = lim
+	a.Autoscale = as
+	return a, nil
+}
+
+// createCopy creates the App a along with the secrets and config files of
+// the App srcName
+func (ops *AppOperations) createCopy(user *database.User, srcName string, a *App) (Err error) {
+	if err := ops.Create(user, a); err != nil {
+		return err
+	}
+
+	defer func() {
+		if Err != nil {
+			ops.deleteNamespace(a.Name)
+		}
+	}()
+
+	s, err := ops.kops.GetSecret(srcName, TeresaAppSecrets)
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if s != nil {
+		if err := ops.kops.CreateOrUpdateSecret(a.Name, TeresaAppSecrets, s); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	cf, err := ops.kops.GetConfigMap(srcName, TeresaAppConfigFiles)
+	if err != nil && !ops.kops.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if cf != nil {
+		if err := ops.kops.CreateOrUpdateConfigMap(a.Name, TeresaAppConfigFiles, cf); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	for _, group := range a.EnvGroups {
+		name := EnvGroupSecretName(group)
+		s, err := ops.kops.GetSecret(srcName, name)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if s == nil {
+			continue
+		}
+		if err := ops.kops.CreateOrUpdateSecret(a.Name, name, s); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	// the volumes of the copy start empty
+	for _, vc := range a.VolumeClaims {
+		if err := ops.kops.CreatePVC(a.Name, vc); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+
+	return nil
+}
+
+// Rename moves an App to a new namespace, copying its metadata, secrets and
+// workloads before removing the old one
+func (ops *AppOperations) Rename(user *database.User, oldName, newName string) (Err error) {
+	a, err := ops.prepareCopy(user, oldName, newName)
+	if err != nil {
+		return err
+	}
+	// the data of the volumes would be lost with the old namespace
+	if len(a.VolumeClaims) > 0 {
+		return ErrRenameWithVolumeClaims
+	}
+	// the new name would escape the approval of the deploys
+	protected, err := ops.isProtected(a.Team, oldName)
+	if err != nil {
+		return err
+	}
+	if protected {
+		return ErrRenameProtected
+	}
+
+	if err := ops.createCopy(user, oldName, a); err != nil {
+		return err
+	}
+
+	defer func() {
+		if Err != nil {
+			ops.deleteNamespace(newName)
+		}
+	}()
+
+	if err := ops.kops.CopyAppResources(oldName, newName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	// the old namespace serves until the new one is ready, no downtime
+	if err := ops.kops.WaitDeploysReady(newName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if err := ops.kops.IngressRenameStreamPorts(oldName, newName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	if ops.eh != nil {
+		if err := ops.eh.Rename(oldName, newName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		defer func() {
+			if Err != nil {
+				ops.eh.Rename(newName, oldName)
+			}
+		}()
+	}
+
+	if ops.egs != nil && len(a.EnvGroups) > 0 {
+		if err := ops.egs.RenameApp(oldName, newName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		defer func() {
+			if Err != nil {
+				ops.egs.RenameApp(newName, oldName)
+			}
+		}()
+	}
+
+	if ops.locks != nil {
+		if err := ops.locks.Rename(oldName, newName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		defer func() {
+			if Err != nil {
+				ops.locks.Rename(newName, oldName)
+			}
+		}()
+	}
+
+	if err := ops.deleteNamespace(oldName); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+// Clone creates a new App with the env vars, secrets, limits, autoscale and
+// team of an existing one; the new App must be deployed to start running
+func (ops *AppOperations) Clone(user *database.User, srcName, dstName, vHost string) error {
+	a, err := ops.prepareCopy(user, srcName, dstName)
+	if err != nil {
+		return err
+	}
+	a.VirtualHost = vHost
+
+	if err := ops.createCopy(user, srcName, a); err != nil {
+		return err
+	}
+
+	if ops.egs == nil {
+		return nil
+	}
+	for _, group := range a.EnvGroups {
+		if err := ops.egs.AttachApp(group, dstName); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+	return nil
+}
+
 func (ops *AppOperations) translateError(err error) error {
 	switch {
 	case ops.kops.IsUnknown(err) || ops.kops.IsInvalid(err):
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
 
 	k8sv1 "k8s.io/api/core/v1"
 
 	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/cluster"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	st "github.com/luizalabs/teresa/pkg/server/storage"
 	"github.com/luizalabs/teresa/pkg/server/team"
@@ -53,6 +55,10 @@ type fakeK8sOperations struct {
 	DeleteCronJobSecretsErr               error
 	SuspendCronJobErr                     error
 	ResumeCronJobErr                      error
+	CronJobErr                            error
+	CronJobRunNowErr                      error
+	CronJobRunsErr                        error
+	PortForwardErr                        error
 	IsAlreadyExistsErr                    bool
 	IsNotFoundErr                         bool
 	IsInvalidErr                          bool
@@ -68,7 +74,62 @@ type fakeK8sOperations struct {
 	AppProtocol                           string
 	IngressEnabledValue                   bool
 	UpdateIngressErr                      error
-}
+	CopyAppResourcesErr                   error
+	WaitDeploysReadyErr                   error
+	CopyAppResourcesWasCalled             bool
+	MissingNamespace                      string
+	DeploySetReplicasValues               map[string]int32
+	DeployReplicasErr                     error
+	DeleteAutoscaleErr                    error
+	DeleteAutoscaleWasCalled              bool
+	AppPaused                             bool
+	DeployRestartErr                      error
+	DeleteSecretErr                       error
+	MaintenanceEnabledValue               bool
+	IngressSetMaintenanceErr              error
+	MaintenanceValue                      bool
+	CertManagerEnabledValue               bool
+	IngressEnableTLSErr                   error
+	TLSEnabled                            bool
+	TLSStatusValue                        string
+	IngressAllowedOptionsValue            []string
+	IngressSetOptionsErr                  error
+	IngressOptionsValue                   map[string]string
+	ServiceTypeValue                      string
+	IngressSourceRanges                   []string
+	SetLoadBalancerSourceRangesErr        error
+	ServiceSourceRanges                   []string
+	DeleteStreamPortsErr                  error
+	RenameStreamPortsErr                  error
+	SetEnvFromSecretsErr                  error
+	SetEnvFromSecretsNames                []string
+	DeployRestartNames                    []string
+	EventsErr                             error
+	SetResourcesErr                       error
+	SetResourcesNames                     []string
+	AutoscaleMetrics                      []*AutoscaleMetric
+	AutoscaleValue                        *Autoscale
+	NoAutoscale                           bool
+	PDBMinAvailable                       string
+	DefaultPDBMinAvailableValue           string
+	CreateOrUpdatePDBErr                  error
+	DeletePDBWasCalled                    bool
+	AppVolumeClaims                       bool
+	CreatedPVCs                           []string
+	DeletedPVCs                           []string
+	AppConfigFiles                        bool
+	AppEnvGroups                          bool
+	AppScaleSchedule                      bool
+	CreatedSecrets                        []string
+	ConfigFilesData                       map[string]string
+	ConfigFileMounts                      map[string]string
+	SetLifecycleNames                     []string
+	RollingUpdates                        map[string]*RollingUpdate
+	DeployMetadataValue                   *DeployMetadata
+	SavedAnnotations                      map[string]string
+}
+
+var errFakeNamespaceNotFound = errors.New("namespace not found")
 
 func (f *fakeK8sOperations) CreateNamespace(app *App, user string) error {
 	return f.CreateNamespaceErr
@@ -79,6 +140,7 @@ func (f *fakeK8sOperations) CreateQuota(app *App) error {
 }
 
 func (f *fakeK8sOperations) CreateOrUpdateSecret(appName, secretName string, data map[string][]byte) error {
+	f.CreatedSecrets = append(f.CreatedSecrets, secretName)
 	return f.CreateOrUpdateSecretErr
 }
 
@@ -90,6 +152,35 @@ func (f *fakeK8sOperations) PodList(namespace string, opts *PodListOptions) ([]*
 	return pl, f.PodListErr
 }
 
+func (f *fakeK8sOperations) PodDetails(namespace string) ([]*PodDetail, error) {
+	pl := []*PodDetail{
+		{
+			Pod:                   Pod{Name: "pod 1", State: string(k8sv1.PodRunning), Age: 2, Restarts: 1},
+			Node:                  "node 1",
+			LastTerminationReason: "OOMKilled",
+		},
+	}
+	return pl, f.PodListErr
+}
+
+func (f *fakeK8sOperations) Events(namespace string, opts *EventOptions, stop <-chan struct{}) (<-chan *Event, error) {
+	if f.EventsErr != nil {
+		return nil, f.EventsErr
+	}
+	ch := make(chan *Event, 1)
+	ch <- &Event{Type: "Warning", Reason: "OOMKilling", Object: "pod/pod 1"}
+	close(ch)
+	return ch, nil
+}
+
+func (f *fakeK8sOperations) PodMetrics(namespace string) ([]*PodMetrics, error) {
+	pl := []*PodMetrics{
+		{Name: "pod 1", CPU: 200, Memory: 1024},
+		{Name: "pod 2", CPU: 100, Memory: 2048},
+	}
+	return pl, f.PodListErr
+}
+
 func (f *fakeK8sOperations) PodLogs(namespace, podName string, opts *LogOptions) (io.ReadCloser, error) {
 	r := bytes.NewBufferString("foo\nbar")
 	return ioutil.NopCloser(r), f.PodLogsErr
@@ -108,23 +199,57 @@ func (f *fakeK8sOperations) NamespaceAnnotation(namespace, annotation string) (s
 		"envVars": [{"key": "ENV-KEY", "value": "ENV-VALUE"}],
 		"secrets": ["SECRET-1", "SECRET-2"],
 		"secret_files": ["SECRET-3"],
-		"protocol": "%s"
+		"protocol": "%s"%s
 	}`
+	var paused string
+	if f.AppPaused {
+		paused = `,
+		"paused": {
+			"replicas": {"test": 0, "test-worker": 0},
+			"autoscale": {"CPUTargetUtilization": 42, "Max": 10, "Min": 2}
+		}`
+	}
+	if f.AppVolumeClaims {
+		paused += `,
+		"volumeClaims": [{"name": "data", "size": "1Gi", "mountPath": "/data"}]`
+	}
+	if f.AppConfigFiles {
+		paused += `,
+		"configFiles": {"nginx.conf": "/etc/nginx"}`
+	}
+	if f.AppEnvGroups {
+		paused += `,
+		"envGroups": ["shared"]`
+	}
+	if f.AppScaleSchedule {
+		paused += `,
+		"scaleSchedule": {
+			"windows": [{"start": "08:00", "end": "20:00", "min": 5}],
+			"min": 1,
+			"max": 10,
+			"applied": "default"
+		}`
+	}
 	return fmt.Sprintf(
 		tmpl,
 		dpt,
 		f.AppInternal,
 		f.AppVirtualHost,
 		f.AppProtocol,
+		paused,
 	), f.NamespaceAnnotationErr
 }
 
 func (f *fakeK8sOperations) NamespaceLabel(namespace, label string) (string, error) {
+	if f.MissingNamespace != "" && namespace == f.MissingNamespace {
+		return "", errFakeNamespaceNotFound
+	}
 	return "luizalabs", f.NamespaceLabelErr
 }
 
 func (f *fakeK8sOperations) CreateOrUpdateAutoscale(app *App) error {
 	f.CreateOrUpdateAutoscaleWasCalled = true
+	f.AutoscaleValue = app.Autoscale
 	return f.CreateOrUpdateAutoscaleErr
 }
 
@@ -146,7 +271,16 @@ func (f *fakeK8sOperations) Status(namespace string) (*Status, error) {
 }
 
 func (f *fakeK8sOperations) Autoscale(namespace string) (*Autoscale, error) {
-	as := &Autoscale{CPUTargetUtilization: 42, Max: 10, Min: 1}
+	if f.NoAutoscale {
+		return nil, f.AutoscaleErr
+	}
+	as := &Autoscale{
+		CPUTargetUtilization:    42,
+		MemoryTargetUtilization: 60,
+		Max:                     10,
+		Min:                     1,
+		Metrics:                 f.AutoscaleMetrics,
+	}
 	return as, f.AutoscaleErr
 }
 
@@ -167,7 +301,7 @@ func (f *fakeK8sOperations) IsAlreadyExists(err error) bool {
 }
 
 func (f *fakeK8sOperations) IsNotFound(err error) bool {
-	return f.IsNotFoundErr
+	return f.IsNotFoundErr || err == errFakeNamespaceNotFound
 }
 
 func (f *fakeK8sOperations) IsInvalid(err error) bool {
@@ -175,6 +309,7 @@ func (f *fakeK8sOperations) IsInvalid(err error) bool {
 }
 
 func (f *fakeK8sOperations) SetNamespaceAnnotations(namespace string, annotations map[string]string) error {
+	f.SavedAnnotations = annotations
 	return f.SetNamespaceAnnotationsErr
 }
 
@@ -213,6 +348,9 @@ func (f *fakeK8sOperations) CreateOrUpdateCronJobSecretEnvVars(namespace, name,
 }
 
 func (f *fakeK8sOperations) DeploySetReplicas(namespace, name string, replicas int32) error {
+	if f.DeploySetReplicasValues != nil {
+		f.DeploySetReplicasValues[name] = replicas
+	}
 	return f.DeploySetReplicasErr
 }
 
@@ -241,11 +379,11 @@ func (f *fakeK8sOperations) IngressEnabled() bool {
 	return f.IngressEnabledValue
 }
 
-func (f *fakeK8sOperations) CreateOrUpdateDeploySecretFile(namespace, deploy, fileName string) error {
+func (f *fakeK8sOperations) CreateOrUpdateDeploySecretFile(namespace, deploy, fileName, mountPath string) error {
 	return f.CreateOrUpdateDeploySecretFileErr
 }
 
-func (f *fakeK8sOperations) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName string) error {
+func (f *fakeK8sOperations) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName, mountPath string) error {
 	return f.CreateOrUpdateCronJobSecretFileErr
 }
 
@@ -265,6 +403,39 @@ func (f *fakeK8sOperations) ResumeCronJob(namespace, name string) error {
 	return f.ResumeCronJobErr
 }
 
+func (f *fakeK8sOperations) CronJob(namespace, name string) (*CronJob, error) {
+	if f.CronJobErr != nil {
+		return nil, f.CronJobErr
+	}
+	return &CronJob{Name: name, Schedule: "*/5 * * * *", LastSchedule: 1500000000}, nil
+}
+
+func (f *fakeK8sOperations) CronJobRunNow(namespace, name string) (string, error) {
+	if f.CronJobRunNowErr != nil {
+		return "", f.CronJobRunNowErr
+	}
+	return name + "-manual-1500000000", nil
+}
+
+func (f *fakeK8sOperations) PortForward(namespace, podName string, port int32, t *Tunnel) error {
+	if f.PortForwardErr != nil {
+		return f.PortForwardErr
+	}
+	_, err := io.Copy(t.Out, t.In)
+	return err
+}
+
+func (f *fakeK8sOperations) CronJobRuns(namespace, name string) ([]*CronRun, error) {
+	if f.CronJobRunsErr != nil {
+		return nil, f.CronJobRunsErr
+	}
+	runs := []*CronRun{
+		{Name: name + "-1", Status: CronRunStatusSucceeded, StartTime: 1500000000, Duration: 10},
+		{Name: name + "-2", Status: CronRunStatusFailed, StartTime: 1500000300, Duration: 3, ExitCode: 1},
+	}
+	return runs, nil
+}
+
 func (f *fakeK8sOperations) UpdateIngress(namespace, name string, vHosts []string) error {
 	return f.UpdateIngressErr
 }
@@ -273,6 +444,172 @@ func (f *fakeK8sOperations) IsUnknown(err error) bool {
 	return f.IsUnknownErr
 }
 
+func (f *fakeK8sOperations) CreateOrUpdatePDB(namespace, name, minAvailable string) error {
+	f.PDBMinAvailable = minAvailable
+	return f.CreateOrUpdatePDBErr
+}
+
+func (f *fakeK8sOperations) DeletePDB(namespace, name string) error {
+	f.DeletePDBWasCalled = true
+	f.PDBMinAvailable = ""
+	return nil
+}
+
+func (f *fakeK8sOperations) CreatePVC(namespace string, vc *VolumeClaim) error {
+	f.CreatedPVCs = append(f.CreatedPVCs, vc.Name)
+	return nil
+}
+
+func (f *fakeK8sOperations) DeletePVC(namespace, name string) error {
+	f.DeletedPVCs = append(f.DeletedPVCs, name)
+	return nil
+}
+
+func (f *fakeK8sOperations) GetConfigMap(namespace, name string) (map[string]string, error) {
+	if f.ConfigFilesData == nil {
+		return nil, errFakeNamespaceNotFound
+	}
+	return f.ConfigFilesData, nil
+}
+
+func (f *fakeK8sOperations) CreateOrUpdateConfigMap(namespace, name string, data map[string]string) error {
+	f.ConfigFilesData = data
+	return nil
+}
+
+func (f *fakeK8sOperations) SetDeployConfigFile(namespace, deploy, fileName, mountPath string) error {
+	if f.ConfigFileMounts == nil {
+		f.ConfigFileMounts = make(map[string]string)
+	}
+	f.ConfigFileMounts[deploy] = mountPath
+	return nil
+}
+
+func (f *fakeK8sOperations) SetCronJobConfigFile(namespace, cronjob, fileName, mountPath string) error {
+	return f.SetDeployConfigFile(namespace, cronjob, fileName, mountPath)
+}
+
+func (f *fakeK8sOperations) DefaultPDBMinAvailable() string {
+	return f.DefaultPDBMinAvailableValue
+}
+
+func (f *fakeK8sOperations) DeployReplicas(namespace, name string) (int32, error) {
+	return 2, f.DeployReplicasErr
+}
+
+func (f *fakeK8sOperations) DeleteAutoscale(namespace string) error {
+	f.DeleteAutoscaleWasCalled = true
+	return f.DeleteAutoscaleErr
+}
+
+func (f *fakeK8sOperations) DeployRestart(namespace, name string) error {
+	f.DeployRestartNames = append(f.DeployRestartNames, name)
+	return f.DeployRestartErr
+}
+
+func (f *fakeK8sOperations) DeployMetadata(namespace, name string) (*DeployMetadata, error) {
+	return f.DeployMetadataValue, nil
+}
+
+func (f *fakeK8sOperations) DeploySetLifecycle(namespace, name string, lc *Lifecycle) error {
+	f.SetLifecycleNames = append(f.SetLifecycleNames, name)
+	return nil
+}
+
+func (f *fakeK8sOperations) DeploySetRollingUpdate(namespace, name string, ru *RollingUpdate) error {
+	if f.RollingUpdates == nil {
+		f.RollingUpdates = make(map[string]*RollingUpdate)
+	}
+	f.RollingUpdates[name] = ru
+	return nil
+}
+
+func (f *fakeK8sOperations) DeploySetResources(namespace, name string, r *Resources) error {
+	f.SetResourcesNames = append(f.SetResourcesNames, name)
+	return f.SetResourcesErr
+}
+
+func (f *fakeK8sOperations) CronJobSetResources(namespace, name string, r *Resources) error {
+	f.SetResourcesNames = append(f.SetResourcesNames, name)
+	return f.SetResourcesErr
+}
+
+func (f *fakeK8sOperations) MaintenanceEnabled() bool {
+	return f.MaintenanceEnabledValue
+}
+
+func (f *fakeK8sOperations) IngressSetMaintenance(namespace, name string, enabled bool) error {
+	f.MaintenanceValue = enabled
+	return f.IngressSetMaintenanceErr
+}
+
+func (f *fakeK8sOperations) CertManagerEnabled() bool {
+	return f.CertManagerEnabledValue
+}
+
+func (f *fakeK8sOperations) IngressEnableTLS(namespace, name string) error {
+	f.TLSEnabled = true
+	return f.IngressEnableTLSErr
+}
+
+func (f *fakeK8sOperations) IngressTLSStatus(namespace, name string) (string, error) {
+	return f.TLSStatusValue, nil
+}
+
+func (f *fakeK8sOperations) IngressAllowedOptions() []string {
+	return f.IngressAllowedOptionsValue
+}
+
+func (f *fakeK8sOperations) IngressSetOptions(namespace, name string, opts map[string]string) error {
+	f.IngressOptionsValue = opts
+	return f.IngressSetOptionsErr
+}
+
+func (f *fakeK8sOperations) IngressSetSourceRanges(namespace, name string, cidrs []string) error {
+	f.IngressSourceRanges = cidrs
+	return nil
+}
+
+func (f *fakeK8sOperations) ServiceType(namespace, name string) (string, error) {
+	return f.ServiceTypeValue, nil
+}
+
+func (f *fakeK8sOperations) SetLoadBalancerSourceRanges(namespace, svcName string, sourceRanges []string) error {
+	f.ServiceSourceRanges = sourceRanges
+	return f.SetLoadBalancerSourceRangesErr
+}
+
+func (f *fakeK8sOperations) IngressDeleteStreamPorts(namespace, svcName string) error {
+	return f.DeleteStreamPortsErr
+}
+
+func (f *fakeK8sOperations) IngressRenameStreamPorts(src, dst string) error {
+	return f.RenameStreamPortsErr
+}
+
+func (f *fakeK8sOperations) DeleteSecret(namespace, secretName string) error {
+	return f.DeleteSecretErr
+}
+
+func (f *fakeK8sOperations) DeploySetEnvFromSecrets(namespace, name string, secretNames []string) error {
+	f.SetEnvFromSecretsNames = secretNames
+	return f.SetEnvFromSecretsErr
+}
+
+func (f *fakeK8sOperations) CronJobSetEnvFromSecrets(namespace, name string, secretNames []string) error {
+	f.SetEnvFromSecretsNames = secretNames
+	return f.SetEnvFromSecretsErr
+}
+
+func (f *fakeK8sOperations) CopyAppResources(srcApp, dstApp string) error {
+	f.CopyAppResourcesWasCalled = true
+	return f.CopyAppResourcesErr
+}
+
+func (f *fakeK8sOperations) WaitDeploysReady(namespace string) error {
+	return f.WaitDeploysReadyErr
+}
+
 func TestAppOperationsCreate(t *testing.T) {
 	tops := team.NewFakeOperations()
 	fakeSt := st.NewFake()
@@ -284,6 +621,8 @@ func TestAppOperationsCreate(t *testing.T) {
 		Name:  name,
 		Users: []database.User{*user},
 	}
+	// the developers of the team create apps
+	tops.(*team.FakeOperations).Roles[name] = map[string]string{user.Email: team.RoleDeveloper}
 
 	if err := ops.Create(user, app); err != nil {
 		t.Fatal("error creating app: ", err)
@@ -401,6 +740,83 @@ func TestAppOperationsCreateErrAppAlreadyExists(t *testing.T) {
 	}
 }
 
+func TestAppOperationsCreateOnCluster(t *testing.T) {
+	tops := team.NewFakeOperations()
+	name := "luizalabs"
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage[name] = &database.Team{
+		Name:  name,
+		Users: []database.User{*user},
+	}
+	ops := NewOperations(tops, &fakeK8sOperations{MissingNamespace: "teresa"}, st.NewFake())
+	clusters := cluster.NewFakeOperations()
+	clusters.Clusters["prod-us-east"] = []byte("kubeconfig")
+	ops.SetClusters(clusters)
+
+	app := &App{Name: "teresa", Team: name, Cluster: "prod-us-east"}
+	if err := ops.Create(user, app); err != nil {
+		t.Fatal("error creating app: ", err)
+	}
+	if c := clusters.Apps["teresa"]; c != "prod-us-east" {
+		t.Errorf("expected prod-us-east, got %q", c)
+	}
+
+	if err := ops.DeleteApp("teresa"); err != nil {
+		t.Fatal("error deleting app: ", err)
+	}
+	if c, found := clusters.Apps["teresa"]; found {
+		t.Errorf("expected the app cluster deleted, got %q", c)
+	}
+}
+
+func TestAppOperationsCreateOnClusterErrors(t *testing.T) {
+	tops := team.NewFakeOperations()
+	name := "luizalabs"
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage[name] = &database.Team{
+		Name:  name,
+		Users: []database.User{*user},
+	}
+
+	var testCases = []struct {
+		cluster     string
+		kops        *fakeK8sOperations
+		noClusters  bool
+		expectedErr error
+	}{
+		{"prod-eu", &fakeK8sOperations{MissingNamespace: "teresa"}, false, cluster.ErrNotFound},
+		{"prod-us-east", &fakeK8sOperations{MissingNamespace: "teresa"}, true, ErrClusterNotFound},
+		{"prod-us-east", &fakeK8sOperations{}, false, ErrAlreadyExists},
+		{
+			"prod-us-east",
+			&fakeK8sOperations{
+				MissingNamespace:   "teresa",
+				CreateNamespaceErr: errors.New("test"),
+				IsAlreadyExistsErr: true,
+			},
+			false,
+			ErrAlreadyExists,
+		},
+	}
+
+	for _, tc := range testCases {
+		ops := NewOperations(tops, tc.kops, st.NewFake())
+		clusters := cluster.NewFakeOperations()
+		clusters.Clusters["prod-us-east"] = []byte("kubeconfig")
+		if !tc.noClusters {
+			ops.SetClusters(clusters)
+		}
+
+		app := &App{Name: "teresa", Team: name, Cluster: tc.cluster}
+		if err := ops.Create(user, app); err != tc.expectedErr {
+			t.Errorf("expected %v creating on %s, got %v", tc.expectedErr, tc.cluster, err)
+		}
+		if c, found := clusters.Apps["teresa"]; found {
+			t.Errorf("expected the app not assigned, got %q", c)
+		}
+	}
+}
+
 func TestAppCreateErrAppAlreadyExistsShouldNotTouchNamespace(t *testing.T) {
 	tops := team.NewFakeOperations()
 	fakeSt := st.NewFake()
@@ -535,6 +951,101 @@ func TestAppOperationsLogs(t *testing.T) {
 	}
 }
 
+func TestAppOperationsLogsPrevious(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	opts := &LogOptions{Lines: 10, Previous: true}
+
+	rc, err := ops.Logs(user, "teresa", opts)
+	if err != nil {
+		t.Fatal("error on get logs: ", err)
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal("error on read logs:", err)
+	}
+	if expected := "[pod 2] - foo\n[pod 2] - bar\n"; string(b) != expected {
+		t.Errorf("expected only the logs of the restarted pod, got %q", b)
+	}
+}
+
+func TestAppOperationsLogsGrep(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+
+	rc, err := ops.Logs(user, "teresa", &LogOptions{Lines: 10, Grep: "^ba"})
+	if err != nil {
+		t.Fatal("error on get logs: ", err)
+	}
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal("error on read logs:", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", b)
+	}
+	for _, line := range lines {
+		if !strings.HasSuffix(line, "] - bar") {
+			t.Errorf("expected only the bar lines, got %s", line)
+		}
+	}
+
+	if _, err := ops.Logs(user, "teresa", &LogOptions{Grep: "(foo"}); err != ErrInvalidLogFilter {
+		t.Errorf("expected ErrInvalidLogFilter, got %v", err)
+	}
+}
+
+func TestAppOperationsLogsInvalidSince(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	if _, err := ops.Logs(user, "teresa", &LogOptions{SinceSeconds: -60}); err != ErrInvalidLogSince {
+		t.Errorf("expected ErrInvalidLogSince, got %v", err)
+	}
+}
+
+func TestSetProcessPodListOptions(t *testing.T) {
+	a := &App{Name: "teresa", ProcessType: "web", Processes: map[string]int32{"worker": 1}}
+	cron := &App{Name: "teresa", ProcessType: "cron"}
+
+	var testCases = []struct {
+		app        *App
+		process    string
+		deployName string
+		anyJob     bool
+		err        error
+	}{
+		{a, "web", "teresa", false, nil},
+		{a, "worker", "teresa-worker", false, nil},
+		{a, "clock", "", false, ErrProcessTypeNotFound},
+		{cron, "cron", "", true, nil},
+		{cron, "web", "", false, ErrProcessTypeNotFound},
+	}
+
+	for _, tc := range testCases {
+		opts := new(PodListOptions)
+		err := setProcessPodListOptions(tc.app, tc.process, opts)
+		if err != tc.err {
+			t.Errorf("expected %v, got %v [case: %s]", tc.err, err, tc.process)
+			continue
+		}
+		if opts.DeployName != tc.deployName || opts.AnyJob != tc.anyJob {
+			t.Errorf("expected %s and %v, got %+v [case: %s]", tc.deployName, tc.anyJob, opts, tc.process)
+		}
+	}
+}
+
+func TestRestartedPods(t *testing.T) {
+	pods := []*Pod{{Name: "pod 1"}, {Name: "pod 2", Restarts: 3}}
+	if restarted := restartedPods(pods); len(restarted) != 1 || restarted[0].Name != "pod 2" {
+		t.Errorf("expected pod 2, got %v", restarted)
+	}
+	if restarted := restartedPods(pods[:1]); len(restarted) != 0 {
+		t.Errorf("expected no pods, got %v", restarted)
+	}
+}
+
 func TestAppOperationsLogsErrPermissionDenied(t *testing.T) {
 	tops := team.NewFakeOperations()
 	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
@@ -840,97 +1351,285 @@ func TestAppOperationsSetEnv(t *testing.T) {
 	}
 }
 
-func TestAppOperationsSetEnvErrPermissionDenied(t *testing.T) {
-	tops := team.NewFakeOperations()
-	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
-	user := &database.User{Email: "teresa@luizalabs.com"}
+type fakeEnvHistory struct {
+	revs    map[int32]*EnvRevision
+	saved   [][]*EnvVar
+	deleted []string
+	renamed map[string]string
+}
 
-	if err := ops.SetEnv(user, "teresa", nil); err != auth.ErrPermissionDenied {
-		t.Errorf("expected ErrPermissionDenied, got %v", err)
+func (f *fakeEnvHistory) Save(appName, author string, evs []*EnvVar) error {
+	f.saved = append(f.saved, evs)
+	return nil
+}
+
+func (f *fakeEnvHistory) List(appName string) ([]*EnvRevision, error) {
+	revs := make([]*EnvRevision, 0)
+	for _, rev := range f.revs {
+		revs = append(revs, rev)
 	}
+	return revs, nil
 }
 
-func TestAppOperationsSetEnvErrNotFound(t *testing.T) {
-	tops := team.NewFakeOperations()
-	k8s := &fakeK8sOperations{
-		NamespaceLabelErr: errors.New("test"),
-		IsNotFoundErr:     true,
+func (f *fakeEnvHistory) Get(appName string, version int32) (*EnvRevision, error) {
+	rev, found := f.revs[version]
+	if !found {
+		return nil, ErrEnvRevisionNotFound
 	}
-	ops := NewOperations(tops, k8s, nil)
-	user := &database.User{Email: "teresa@luizalabs.com"}
+	return rev, nil
+}
 
-	if err := ops.SetEnv(user, "teresa", nil); err != ErrNotFound {
-		t.Errorf("expected ErrNotFound, got %v", err)
+func (f *fakeEnvHistory) Delete(appName string) error {
+	f.deleted = append(f.deleted, appName)
+	return nil
+}
+
+func (f *fakeEnvHistory) Rename(oldName, newName string) error {
+	if f.renamed == nil {
+		f.renamed = make(map[string]string)
 	}
+	f.renamed[oldName] = newName
+	return nil
 }
 
-func TestAppOperationsSetEnvErrInternalServerErrorOnSaveApp(t *testing.T) {
-	tops := team.NewFakeOperations()
-	ops := NewOperations(tops, &fakeK8sOperations{SetNamespaceAnnotationsErr: errors.New("test")}, nil)
-	user := &database.User{Email: "teresa@luizalabs.com"}
-	app := &App{Name: "teresa", Team: "luizalabs"}
-	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
-		Name:  app.Team,
-		Users: []database.User{*user},
+type fakeEnvGroups struct {
+	attached map[string][]string
+	renamed  map[string]string
+	detached []string
+}
+
+func (f *fakeEnvGroups) AttachApp(name, appName string) error {
+	if f.attached == nil {
+		f.attached = make(map[string][]string)
 	}
+	f.attached[name] = append(f.attached[name], appName)
+	return nil
+}
 
-	if err := ops.SetEnv(user, app.Name, nil); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
-		t.Errorf("expected ErrInternalServerError, got %v", err)
+func (f *fakeEnvGroups) RenameApp(oldName, newName string) error {
+	if f.renamed == nil {
+		f.renamed = make(map[string]string)
 	}
+	f.renamed[oldName] = newName
+	return nil
 }
 
-func TestAppOpsSetEnvErrInvalidEnvVarName(t *testing.T) {
+func (f *fakeEnvGroups) DetachApp(appName string) error {
+	f.detached = append(f.detached, appName)
+	return nil
+}
+
+func TestAppOperationsSetEnvSavesEnvRevision(t *testing.T) {
 	tops := team.NewFakeOperations()
-	ops := NewOperations(tops, nil, nil)
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	eh := new(fakeEnvHistory)
+	ops.SetEnvHistory(eh)
 	user := &database.User{Email: "teresa@luizalabs.com"}
 	app := &App{Name: "teresa", Team: "luizalabs"}
 	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
 		Name:  app.Team,
 		Users: []database.User{*user},
 	}
-	ops.(*AppOperations).kops = &fakeK8sOperations{
-		IsInvalidErr:                   true,
-		CreateOrUpdateDeployEnvVarsErr: ErrInvalidEnvVarName,
-	}
-	evs := []*EnvVar{{Key: "key", Value: "value"}}
 
-	if err := ops.SetEnv(user, app.Name, evs); err != ErrInvalidEnvVarName {
-		t.Errorf("expected %v, got %v", ErrInvalidEnvVarName, err)
+	if err := ops.SetEnv(user, app.Name, []*EnvVar{{Key: "key1", Value: "value1"}}); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(eh.saved) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(eh.saved))
+	}
+	if evs := eh.saved[0]; !hasEnvVar(evs, "ENV-KEY") || !hasEnvVar(evs, "key1") {
+		t.Errorf("expected ENV-KEY and key1 in the revision, got %v", evs)
 	}
 }
 
-func TestAppOperationsSetEnvForACronJob(t *testing.T) {
-	validCronPt := fmt.Sprintf("%s-test", ProcessTypeCronPrefix)
+func TestAppOperationsEnvHistory(t *testing.T) {
 	tops := team.NewFakeOperations()
-	fakeK8s := &fakeK8sOperations{DefaultProcessType: validCronPt}
-	ops := NewOperations(tops, fakeK8s, nil)
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	ops.SetEnvHistory(&fakeEnvHistory{revs: map[int32]*EnvRevision{1: {Version: 1}}})
 	user := &database.User{Email: "teresa@luizalabs.com"}
 	app := &App{Name: "teresa", Team: "luizalabs"}
 	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
 		Name:  app.Team,
 		Users: []database.User{*user},
 	}
-	evs := []*EnvVar{
-		{Key: "key1", Value: "value1"},
-		{Key: "key2", Value: "value2"},
-	}
 
-	if err := ops.SetEnv(user, app.Name, evs); err != nil {
-		t.Errorf("expected no error, got %v", err)
+	revs, err := ops.EnvHistory(user, app.Name)
+	if err != nil {
+		t.Fatal("expected no error, got", err)
 	}
-
-	if !fakeK8s.CreateOrUpdateCronJobEnvVarsWasCalled {
-		t.Error("expected create or update CRON JOB env vars was called, but dont")
+	if len(revs) != 1 {
+		t.Errorf("expected 1 revision, got %d", len(revs))
 	}
 }
 
-func TestAppOperationsUnsetEnv(t *testing.T) {
+func TestAppOperationsEnvHistoryErrPermissionDenied(t *testing.T) {
 	tops := team.NewFakeOperations()
 	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	ops.SetEnvHistory(new(fakeEnvHistory))
 	user := &database.User{Email: "teresa@luizalabs.com"}
-	app := &App{Name: "teresa", Team: "luizalabs"}
-	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
-		Name:  app.Team,
+
+	if _, err := ops.EnvHistory(user, "teresa"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsEnvHistoryErrEnvHistoryNotAvailable(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if _, err := ops.EnvHistory(user, "teresa"); err != ErrEnvHistoryNotAvailable {
+		t.Errorf("expected ErrEnvHistoryNotAvailable, got %v", err)
+	}
+}
+
+func TestAppOperationsEnvRollback(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	rev := &EnvRevision{Version: 1, EnvVars: []*EnvVar{{Key: "FOO", Value: "bar"}}}
+	eh := &fakeEnvHistory{revs: map[int32]*EnvRevision{1: rev}}
+	ops.SetEnvHistory(eh)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.EnvRollback(user, app.Name, 1); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if len(eh.saved) != 1 {
+		t.Fatalf("expected 1 revision, got %d", len(eh.saved))
+	}
+	if evs := eh.saved[0]; len(evs) != 1 || evs[0].Key != "FOO" || evs[0].Value != "bar" {
+		t.Errorf("expected only FOO=bar in the revision, got %v", evs)
+	}
+}
+
+func TestAppOperationsEnvRollbackErrEnvRevisionNotFound(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	ops.SetEnvHistory(new(fakeEnvHistory))
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.EnvRollback(user, app.Name, 42); err != ErrEnvRevisionNotFound {
+		t.Errorf("expected ErrEnvRevisionNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsEnvRollbackInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{DeleteDeployEnvVarsErr: errors.New("test")}, nil)
+	rev := &EnvRevision{Version: 1, EnvVars: []*EnvVar{}}
+	ops.SetEnvHistory(&fakeEnvHistory{revs: map[int32]*EnvRevision{1: rev}})
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.EnvRollback(user, app.Name, 1); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsSetEnvErrPermissionDenied(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetEnv(user, "teresa", nil); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsSetEnvErrNotFound(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		NamespaceLabelErr: errors.New("test"),
+		IsNotFoundErr:     true,
+	}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetEnv(user, "teresa", nil); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsSetEnvErrInternalServerErrorOnSaveApp(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{SetNamespaceAnnotationsErr: errors.New("test")}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetEnv(user, app.Name, nil); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOpsSetEnvErrInvalidEnvVarName(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, nil, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	ops.(*AppOperations).kops = &fakeK8sOperations{
+		IsInvalidErr:                   true,
+		CreateOrUpdateDeployEnvVarsErr: ErrInvalidEnvVarName,
+	}
+	evs := []*EnvVar{{Key: "key", Value: "value"}}
+
+	if err := ops.SetEnv(user, app.Name, evs); err != ErrInvalidEnvVarName {
+		t.Errorf("expected %v, got %v", ErrInvalidEnvVarName, err)
+	}
+}
+
+func TestAppOperationsSetEnvForACronJob(t *testing.T) {
+	validCronPt := fmt.Sprintf("%s-test", ProcessTypeCronPrefix)
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{DefaultProcessType: validCronPt}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	evs := []*EnvVar{
+		{Key: "key1", Value: "value1"},
+		{Key: "key2", Value: "value2"},
+	}
+
+	if err := ops.SetEnv(user, app.Name, evs); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	if !fakeK8s.CreateOrUpdateCronJobEnvVarsWasCalled {
+		t.Error("expected create or update CRON JOB env vars was called, but dont")
+	}
+}
+
+func TestAppOperationsUnsetEnv(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
 		Users: []database.User{*user},
 	}
 	evs := []string{"key1", "key2"}
@@ -1131,7 +1830,7 @@ func TestAppOperationsSetSecretFile(t *testing.T) {
 		Users: []database.User{*user},
 	}
 
-	if err := ops.SetSecretFile(user, app.Name, "test", nil); err != nil {
+	if err := ops.SetSecretFile(user, app.Name, "test", nil, ""); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
@@ -1141,7 +1840,7 @@ func TestAppOperationsSetSecretFileErrPermissionDenied(t *testing.T) {
 	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
 	user := &database.User{Email: "teresa@luizalabs.com"}
 
-	if err := ops.SetSecretFile(user, "teresa", "test", nil); err != auth.ErrPermissionDenied {
+	if err := ops.SetSecretFile(user, "teresa", "test", nil, ""); err != auth.ErrPermissionDenied {
 		t.Errorf("expected ErrPermissionDenied, got %v", err)
 	}
 }
@@ -1155,7 +1854,7 @@ func TestAppOperationsSetSecretFileErrNotFound(t *testing.T) {
 	ops := NewOperations(tops, k8s, nil)
 	user := &database.User{Email: "teresa@luizalabs.com"}
 
-	if err := ops.SetSecretFile(user, "teresa", "test", nil); err != ErrNotFound {
+	if err := ops.SetSecretFile(user, "teresa", "test", nil, ""); err != ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
@@ -1170,11 +1869,23 @@ func TestAppOperationsSetSecretFileErrInternalServerErrorOnSaveApp(t *testing.T)
 		Users: []database.User{*user},
 	}
 
-	if err := ops.SetSecretFile(user, app.Name, "test", nil); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+	if err := ops.SetSecretFile(user, app.Name, "test", nil, ""); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
 		t.Errorf("expected ErrInternalServerError, got %v", err)
 	}
 }
 
+func TestAppOperationsSetSecretFileErrInvalidMountPath(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	for _, mp := range []string{"secrets", "/", "/secrets/../etc"} {
+		if err := ops.SetSecretFile(user, "teresa", "test", nil, mp); err != ErrInvalidMountPath {
+			t.Errorf("expected ErrInvalidMountPath, got %v [case: %s]", err, mp)
+		}
+	}
+}
+
 func TestAppOperationsUnsetSecret(t *testing.T) {
 	tops := team.NewFakeOperations()
 	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
@@ -1252,6 +1963,86 @@ func TestAppOperationsSetAutoscale(t *testing.T) {
 	}
 }
 
+func TestAppOperationsSetAutoscaleMetrics(t *testing.T) {
+	tops := team.NewFakeOperations()
+	current := []*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "30"}}
+	fakeK8s := &fakeK8sOperations{AutoscaleMetrics: current}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	as := newAutoscale(newAutoscaleRequest("teresa"))
+	if err := ops.SetAutoscale(user, app.Name, as); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fakeK8s.AutoscaleValue.Metrics, current) {
+		t.Errorf("expected %v, got %v", current, fakeK8s.AutoscaleValue.Metrics)
+	}
+
+	req := newAutoscaleRequest("teresa")
+	req.ReplaceMetrics = true
+	as = newAutoscale(req)
+	if err := ops.SetAutoscale(user, app.Name, as); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(fakeK8s.AutoscaleValue.Metrics) != 0 {
+		t.Errorf("expected no metrics, got %v", fakeK8s.AutoscaleValue.Metrics)
+	}
+}
+
+func TestAppOperationsSetAutoscaleMemory(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	var testCases = []struct {
+		memory   int32
+		expected int32
+	}{
+		{-1, 60}, // see fakeK8sOperations.Autoscale
+		{0, 0},
+		{75, 75},
+	}
+
+	for _, tc := range testCases {
+		as := newAutoscale(newAutoscaleRequest("teresa"))
+		as.MemoryTargetUtilization = tc.memory
+		if err := ops.SetAutoscale(user, app.Name, as); err != nil {
+			t.Fatal("got unexpected error:", err)
+		}
+		if got := fakeK8s.AutoscaleValue.MemoryTargetUtilization; got != tc.expected {
+			t.Errorf("expected %d, got %d", tc.expected, got)
+		}
+	}
+}
+
+func TestAppOperationsSetAutoscaleInvalidMetric(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	as := newAutoscale(newAutoscaleRequest("teresa"))
+	as.Metrics = []*AutoscaleMetric{{Name: "queue depth", TargetAverageValue: "10"}}
+
+	if err := ops.SetAutoscale(user, app.Name, as); err != ErrInvalidAutoscale {
+		t.Errorf("expected ErrInvalidAutoscale, got %v", err)
+	}
+}
+
 func TestAppOperationsSetAutoscaleInvalidActionForCronJob(t *testing.T) {
 	validCronPt := fmt.Sprintf("%s-test", ProcessTypeCronPrefix)
 	tops := team.NewFakeOperations()
@@ -1321,9 +2112,20 @@ func TestAppOperationsDelete(t *testing.T) {
 		Users: []database.User{*user},
 	}
 
+	eh := new(fakeEnvHistory)
+	ops.SetEnvHistory(eh)
+	egs := new(fakeEnvGroups)
+	ops.SetEnvGroups(egs)
+
 	if err := ops.Delete(user, app.Name); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
+	if len(eh.deleted) != 1 {
+		t.Errorf("expected the env history deleted, got %v", eh.deleted)
+	}
+	if len(egs.detached) != 1 {
+		t.Errorf("expected the app detached from the env groups, got %v", egs.detached)
+	}
 }
 
 func TestAppOperationsDeleteErrPermissionDenied(t *testing.T) {
@@ -1350,6 +2152,22 @@ func TestAppOperationsDeleteErrNotFound(t *testing.T) {
 	}
 }
 
+func TestAppOperationsDeleteStreamPortsInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DeleteStreamPortsErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Delete(user, app.Name); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
 func TestAppOperationsChangeTeam(t *testing.T) {
 	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
 	app := &App{Name: "teresa", Team: "luizalabs"}
@@ -1568,3 +2386,1221 @@ func TestAppOpsSetVHostsUpdateIngressErr(t *testing.T) {
 		t.Errorf("got %v; want %v", teresa_errors.Get(err), teresa_errors.ErrInternalServerError)
 	}
 }
+
+func TestAppOperationsRename(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace: "new-teresa",
+		Namespaces:       map[string]struct{}{"teresa": {}},
+		AppEnvGroups:     true,
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	eh := new(fakeEnvHistory)
+	ops.SetEnvHistory(eh)
+	egs := new(fakeEnvGroups)
+	ops.SetEnvGroups(egs)
+
+	if err := ops.Rename(user, "teresa", "new-teresa"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !k8s.CopyAppResourcesWasCalled {
+		t.Error("expected app resources to be copied, but they weren't")
+	}
+	if _, found := k8s.Namespaces["teresa"]; found {
+		t.Error("expected old namespace to be deleted, but it wasn't")
+	}
+	if actual := eh.renamed["teresa"]; actual != "new-teresa" {
+		t.Errorf("expected the env history moved to new-teresa, got %q", actual)
+	}
+	if actual := egs.renamed["teresa"]; actual != "new-teresa" {
+		t.Errorf("expected the env groups moved to new-teresa, got %q", actual)
+	}
+	if !hasString(k8s.CreatedSecrets, EnvGroupSecretName("shared")) {
+		t.Errorf("expected the env group secret copied, got %v", k8s.CreatedSecrets)
+	}
+}
+
+func TestAppOperationsRenameErrAlreadyExists(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Rename(user, "teresa", "gopher"); err != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+}
+
+func TestAppOperationsRenameErrProtected(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{MissingNamespace: "new-teresa"}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:          "luizalabs",
+		Users:         []database.User{*user},
+		ProtectedApps: "teresa",
+	}
+
+	if err := ops.Rename(user, "teresa", "new-teresa"); err != ErrRenameProtected {
+		t.Errorf("expected ErrRenameProtected, got %v", err)
+	}
+	if k8s.CopyAppResourcesWasCalled {
+		t.Error("expected app resources not copied, but they were")
+	}
+}
+
+func TestAppOperationsRenameErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Rename(user, "teresa", "gopher"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsRenameErrNotFound(t *testing.T) {
+	k8s := &fakeK8sOperations{MissingNamespace: "teresa"}
+	ops := NewOperations(team.NewFakeOperations(), k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Rename(user, "teresa", "gopher"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsRenameCopyErrRemovesNewNamespace(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace:    "new-teresa",
+		CopyAppResourcesErr: errors.New("test"),
+		Namespaces:          map[string]struct{}{"teresa": {}, "new-teresa": {}},
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Rename(user, "teresa", "new-teresa"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+	if _, found := k8s.Namespaces["new-teresa"]; found {
+		t.Error("expected new namespace to be deleted, but it wasn't")
+	}
+	if _, found := k8s.Namespaces["teresa"]; !found {
+		t.Error("expected old namespace to be kept, but it was deleted")
+	}
+}
+
+func TestAppOperationsRenameKeepsOldNamespaceUntilReady(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace:    "new-teresa",
+		WaitDeploysReadyErr: errors.New("timeout"),
+		Namespaces:          map[string]struct{}{"teresa": {}, "new-teresa": {}},
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Rename(user, "teresa", "new-teresa"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+	if _, found := k8s.Namespaces["teresa"]; !found {
+		t.Error("expected old namespace to be kept, but it was deleted")
+	}
+}
+
+func TestAppOperationsClone(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace: "teresa-staging",
+		Namespaces:       map[string]struct{}{"teresa": {}},
+		AppEnvGroups:     true,
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	egs := new(fakeEnvGroups)
+	ops.SetEnvGroups(egs)
+
+	if err := ops.Clone(user, "teresa", "teresa-staging", "staging.teresa.io"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !hasString(k8s.CreatedSecrets, EnvGroupSecretName("shared")) {
+		t.Errorf("expected the env group secret copied, got %v", k8s.CreatedSecrets)
+	}
+	if !reflect.DeepEqual(egs.attached["shared"], []string{"teresa-staging"}) {
+		t.Errorf("expected teresa-staging attached to shared, got %v", egs.attached)
+	}
+	if !k8s.CreateOrUpdateAutoscaleWasCalled {
+		t.Error("expected autoscale to be created, but it wasn't")
+	}
+	if k8s.CopyAppResourcesWasCalled {
+		t.Error("expected no app resources to be copied, but they were")
+	}
+	if _, found := k8s.Namespaces["teresa"]; !found {
+		t.Error("expected source namespace to be kept, but it was deleted")
+	}
+}
+
+func TestAppOperationsCloneErrAlreadyExists(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Clone(user, "teresa", "gopher", ""); err != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+}
+
+func TestAppOperationsCloneErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Clone(user, "teresa", "gopher", ""); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsCloneErrMissingVirtualHost(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace:    "gopher",
+		IngressEnabledValue: true,
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Clone(user, "teresa", "gopher", ""); err != ErrMissingVirtualHost {
+		t.Errorf("expected ErrMissingVirtualHost, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeam(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	for _, name := range []string{"luizalabs", "gophers"} {
+		tops.(*team.FakeOperations).Storage[name] = &database.Team{
+			Name:  name,
+			Users: []database.User{*user},
+		}
+	}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamAdmin(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	for _, name := range []string{"luizalabs", "gophers"} {
+		tops.(*team.FakeOperations).Storage[name] = &database.Team{Name: name}
+	}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamProtected(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	storage := tops.(*team.FakeOperations).Storage
+	storage["luizalabs"] = &database.Team{Name: "luizalabs", ProtectedApps: "gopher,teresa"}
+	storage["gophers"] = &database.Team{Name: "gophers", ProtectedApps: "gopher"}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if actual := storage["luizalabs"].ProtectedApps; actual != "gopher" {
+		t.Errorf("expected gopher protected on the old team, got %s", actual)
+	}
+	if actual := storage["gophers"].ProtectedApps; actual != "gopher,teresa" {
+		t.Errorf("expected gopher and teresa protected on the new team, got %s", actual)
+	}
+}
+
+func TestAppOperationsTransferTeamErrPermissionDenied(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	tops.(*team.FakeOperations).Storage["gophers"] = &database.Team{Name: "gophers"}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamErrEnvGroupsAttached(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{AppEnvGroups: true}, nil)
+	user := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	tops.(*team.FakeOperations).Storage["gophers"] = &database.Team{Name: "gophers"}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != ErrEnvGroupsAttached {
+		t.Errorf("expected ErrEnvGroupsAttached, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamErrTeamNotFound(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != team.ErrNotFound {
+		t.Errorf("expected team ErrNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamErrAppNotFound(t *testing.T) {
+	k8s := &fakeK8sOperations{MissingNamespace: "teresa"}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+
+	if err := ops.TransferTeam(user, "teresa", "gophers"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsScale(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DeploySetReplicasValues: make(map[string]int32)}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Scale(user, "teresa", map[string]int32{"web": 5, "worker": 3}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	expected := map[string]int32{"teresa": 5, "teresa-worker": 3}
+	if !reflect.DeepEqual(k8s.DeploySetReplicasValues, expected) {
+		t.Errorf("expected %v, got %v", expected, k8s.DeploySetReplicasValues)
+	}
+}
+
+func TestAppOperationsScaleErrInvalidProcessType(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	for _, pt := range []string{"release", "Worker", "my_worker"} {
+		if err := ops.Scale(user, "teresa", map[string]int32{pt: 1}); err != ErrInvalidProcessType {
+			t.Errorf("expected ErrInvalidProcessType, got %v [case: %s]", err, pt)
+		}
+	}
+}
+
+func TestAppOperationsScaleErrInvalidReplicas(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Scale(user, "teresa", map[string]int32{"worker": -1}); err != ErrInvalidReplicas {
+		t.Errorf("expected ErrInvalidReplicas, got %v", err)
+	}
+}
+
+func TestAppOperationsScaleInvalidActionForCronJob(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Scale(user, "teresa", map[string]int32{"worker": 1}); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+}
+
+func TestAppOperationsScaleErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Scale(user, "teresa", map[string]int32{"worker": 1}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsPause(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DeploySetReplicasValues: make(map[string]int32)}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Pause(user, "teresa"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !k8s.DeleteAutoscaleWasCalled {
+		t.Error("expected autoscale to be deleted, but it wasn't")
+	}
+	if n, found := k8s.DeploySetReplicasValues["test"]; !found || n != 0 {
+		t.Errorf("expected deploy scaled to 0, got %v", k8s.DeploySetReplicasValues)
+	}
+}
+
+func TestAppOperationsPauseErrAlreadyPaused(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{AppPaused: true}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Pause(user, "teresa"); err != ErrAlreadyPaused {
+		t.Errorf("expected ErrAlreadyPaused, got %v", err)
+	}
+}
+
+func TestAppOperationsPauseInvalidActionForCronJob(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Pause(user, "teresa"); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+}
+
+func TestAppOperationsPauseErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Pause(user, "teresa"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsResume(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		AppPaused:               true,
+		DeploySetReplicasValues: make(map[string]int32),
+	}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Resume(user, "test"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expected := map[string]int32{"test": 1, "test-worker": 0}
+	if !reflect.DeepEqual(k8s.DeploySetReplicasValues, expected) {
+		t.Errorf("expected %v, got %v", expected, k8s.DeploySetReplicasValues)
+	}
+	if !k8s.CreateOrUpdateAutoscaleWasCalled {
+		t.Error("expected autoscale to be restored, but it wasn't")
+	}
+}
+
+func TestAppOperationsResumeErrNotPaused(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Resume(user, "teresa"); err != ErrNotPaused {
+		t.Errorf("expected ErrNotPaused, got %v", err)
+	}
+}
+
+func TestAppOperationsResumeErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{AppPaused: true}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Resume(user, "teresa"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsRestart(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Restart(user, "test"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"test"}; !reflect.DeepEqual(k8s.DeployRestartNames, expected) {
+		t.Errorf("expected %v, got %v", expected, k8s.DeployRestartNames)
+	}
+}
+
+func TestAppOperationsRestartInvalidActionForCronJob(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Restart(user, "test"); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+}
+
+func TestAppOperationsRestartErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.Restart(user, "test"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsRestartInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{DeployRestartErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Restart(user, "test"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsStatus(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	pods, err := ops.Status(user, "test")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pods) != 1 {
+		t.Fatalf("expected 1 pod, got %d", len(pods))
+	}
+	if pods[0].Node != "node 1" || pods[0].LastTerminationReason != "OOMKilled" {
+		t.Errorf("got unexpected pod detail %v", pods[0])
+	}
+}
+
+func TestAppOperationsStatusErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if _, err := ops.Status(user, "test"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsStatusInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{PodListErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if _, err := ops.Status(user, "test"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsEvents(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	evs, err := ops.Events(user, "test", &EventOptions{}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var count int
+	for ev := range evs {
+		if ev.Reason != "OOMKilling" {
+			t.Errorf("expected OOMKilling, got %s", ev.Reason)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("expected 1 event, got %d", count)
+	}
+}
+
+func TestAppOperationsEventsErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if _, err := ops.Events(user, "test", &EventOptions{}, nil); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsEventsInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{EventsErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if _, err := ops.Events(user, "test", &EventOptions{}, nil); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsTop(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	pods, err := ops.Top(user, "test")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pods) != 2 {
+		t.Errorf("expected 2 pods, got %d", len(pods))
+	}
+}
+
+func TestAppOperationsTopErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if _, err := ops.Top(user, "test"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsTopInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{PodListErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if _, err := ops.Top(user, "test"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsSetResources(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	r := &Resources{CPU: "500m", Memory: "512Mi", CPURequest: "100m"}
+	if err := ops.SetResources(user, "test", r); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"test"}; !reflect.DeepEqual(k8s.SetResourcesNames, expected) {
+		t.Errorf("expected %v, got %v", expected, k8s.SetResourcesNames)
+	}
+}
+
+func TestAppOperationsSetResourcesInvalidLimits(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	var testCases = []*Resources{
+		{},
+		{CPU: "gopher"},
+		{Memory: "512MB"},
+		{CPU: "500m", CPURequest: "-1"},
+	}
+
+	for _, tc := range testCases {
+		if err := ops.SetResources(user, "test", tc); err != ErrInvalidLimits {
+			t.Errorf("expected ErrInvalidLimits, got %v (resources: %v)", err, tc)
+		}
+	}
+}
+
+func TestAppOperationsSetResourcesErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetResources(user, "test", &Resources{CPU: "1"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsSetResourcesInvalidRequests(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{SetResourcesErr: errors.New("test"), IsInvalidErr: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	r := &Resources{CPU: "100m", CPURequest: "200m"}
+	if err := ops.SetResources(user, "test", r); teresa_errors.Get(err) != ErrInvalidLimits {
+		t.Errorf("expected ErrInvalidLimits, got %v", err)
+	}
+}
+
+func TestAppOperationsSetResourcesInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{SetResourcesErr: errors.New("test")}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetResources(user, "test", &Resources{CPU: "1"}); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsSetEnvGroup(t *testing.T) {
+	k8s := &fakeK8sOperations{}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	evs := []*EnvVar{{Key: "KEY", Value: "VALUE"}}
+
+	if err := ops.SetEnvGroup(user, "test", "shared", evs); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := []string{"teresa-envgroup-shared"}; !reflect.DeepEqual(k8s.SetEnvFromSecretsNames, expected) {
+		t.Errorf("expected %v, got %v", expected, k8s.SetEnvFromSecretsNames)
+	}
+}
+
+func TestAppOperationsSetEnvGroupInternalServerError(t *testing.T) {
+	k8s := &fakeK8sOperations{SetEnvFromSecretsErr: errors.New("test")}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetEnvGroup(user, "test", "shared", nil); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsUnsetEnvGroup(t *testing.T) {
+	k8s := &fakeK8sOperations{}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.UnsetEnvGroup(user, "test", "shared"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestAppOperationsUnsetEnvGroupInternalServerError(t *testing.T) {
+	k8s := &fakeK8sOperations{DeleteSecretErr: errors.New("test")}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.UnsetEnvGroup(user, "test", "shared"); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsSetMaintenance(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{MaintenanceEnabledValue: true, AppIngress: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetMaintenance(user, "test", true); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !k8s.MaintenanceValue {
+		t.Error("expected maintenance enabled")
+	}
+}
+
+func TestAppOperationsSetMaintenanceNotAvailable(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetMaintenance(user, "test", true); err != ErrMaintenanceNotAvailable {
+		t.Errorf("expected ErrMaintenanceNotAvailable, got %v", err)
+	}
+}
+
+func TestAppOperationsSetMaintenanceNeedsIngress(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{MaintenanceEnabledValue: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetMaintenance(user, "test", true); err != ErrMaintenanceNeedsIngress {
+		t.Errorf("expected ErrMaintenanceNeedsIngress, got %v", err)
+	}
+}
+
+func TestAppOperationsSetMaintenanceErrPermissionDenied(t *testing.T) {
+	k8s := &fakeK8sOperations{MaintenanceEnabledValue: true, AppIngress: true}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetMaintenance(user, "test", true); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOpsSetVHostsErrVHostNotAllowed(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:    "luizalabs",
+		Users:   []database.User{*user},
+		Domains: "luizalabs.com,teresa.io",
+	}
+
+	if err := ops.SetVHosts(user, "test", []string{"test.teresa.io"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ops.SetVHosts(user, "test", []string{"test.evil.com"}); err != ErrVHostNotAllowed {
+		t.Errorf("got %v; want %v", err, ErrVHostNotAllowed)
+	}
+}
+
+func TestAppOperationsCreateErrVHostNotAllowed(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs", VirtualHost: "teresa.evil.com"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:    app.Team,
+		Users:   []database.User{*user},
+		Domains: "luizalabs.com",
+	}
+
+	if err := ops.Create(user, app); err != ErrVHostNotAllowed {
+		t.Errorf("got %v; want %v", err, ErrVHostNotAllowed)
+	}
+}
+
+func TestAppOpsAddVHost(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{AppVirtualHost: "test.luizalabs.com"}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.AddVHost(user, "test", "test.teresa.io"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ops.AddVHost(user, "test", "test.luizalabs.com"); err != ErrVHostAlreadyExists {
+		t.Errorf("got %v; want %v", err, ErrVHostAlreadyExists)
+	}
+}
+
+func TestAppOpsRemoveVHost(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{AppVirtualHost: "test.luizalabs.com,test.teresa.io", IngressEnabledValue: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.RemoveVHost(user, "test", "test.teresa.io"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ops.RemoveVHost(user, "test", "test.evil.com"); err != ErrVHostNotFound {
+		t.Errorf("got %v; want %v", err, ErrVHostNotFound)
+	}
+}
+
+func TestAppOpsRemoveVHostErrInvalidBlankVHost(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{AppVirtualHost: "test.luizalabs.com", IngressEnabledValue: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.RemoveVHost(user, "test", "test.luizalabs.com"); err != ErrInvalidBlankVHost {
+		t.Errorf("got %v; want %v", err, ErrInvalidBlankVHost)
+	}
+}
+
+func TestAppOperationsEnableTLS(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{CertManagerEnabledValue: true, AppIngress: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.EnableTLS(user, "test"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !k8s.TLSEnabled {
+		t.Error("expected tls enabled")
+	}
+}
+
+func TestAppOperationsEnableTLSNotAvailable(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.EnableTLS(user, "test"); err != ErrTLSNotAvailable {
+		t.Errorf("expected ErrTLSNotAvailable, got %v", err)
+	}
+}
+
+func TestAppOperationsEnableTLSNeedsIngress(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{CertManagerEnabledValue: true}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.EnableTLS(user, "test"); err != ErrTLSNeedsIngress {
+		t.Errorf("expected ErrTLSNeedsIngress, got %v", err)
+	}
+}
+
+func TestAppOperationsEnableTLSErrPermissionDenied(t *testing.T) {
+	k8s := &fakeK8sOperations{CertManagerEnabledValue: true, AppIngress: true}
+	ops := NewOperations(team.NewFakeOperations(), k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.EnableTLS(user, "test"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsInfoTLSStatus(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{TLSStatusValue: TLSStatusPending}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	info, err := ops.Info(user, "test")
+	if err != nil {
+		t.Fatal("error getting app info: ", err)
+	}
+	if info.TLS != TLSStatusPending {
+		t.Errorf("expected %s, got %s", TLSStatusPending, info.TLS)
+	}
+}
+
+func TestAppOperationsInfoDeployMetadata(t *testing.T) {
+	tops := team.NewFakeOperations()
+	dm := &DeployMetadata{
+		Author:  "gopher@luizalabs.com",
+		Commit:  "8a3f2c1",
+		Branch:  "master",
+		Message: "Fix checkout",
+	}
+	ops := NewOperations(tops, &fakeK8sOperations{DeployMetadataValue: dm}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	info, err := ops.Info(user, "test")
+	if err != nil {
+		t.Fatal("error getting app info: ", err)
+	}
+	if !reflect.DeepEqual(info.Deploy, dm) {
+		t.Errorf("expected %v, got %v", dm, info.Deploy)
+	}
+}
+
+func TestAppOperationsSetIngressOptions(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{IngressAllowedOptionsValue: []string{IngressOptionProxyBodySize}}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	opts := map[string]string{IngressOptionProxyBodySize: "10m"}
+
+	if err := ops.SetIngressOptions(user, "test", opts); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(k8s.IngressOptionsValue, opts) {
+		t.Errorf("expected %v, got %v", opts, k8s.IngressOptionsValue)
+	}
+}
+
+func TestAppOperationsSetIngressOptionsErrIngressOptionNotAllowed(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	opts := map[string]string{IngressOptionClass: "nginx"}
+
+	if err := ops.SetIngressOptions(user, "test", opts); err != ErrIngressOptionNotAllowed {
+		t.Errorf("expected ErrIngressOptionNotAllowed, got %v", err)
+	}
+}
+
+func TestAppOperationsSetIngressOptionsInternalServerError(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		IngressAllowedOptionsValue: []string{IngressOptionClass},
+		IngressSetOptionsErr:       errors.New("test"),
+	}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	opts := map[string]string{IngressOptionClass: "nginx"}
+
+	if err := ops.SetIngressOptions(user, "test", opts); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", err)
+	}
+}
+
+func TestAppOperationsSetIngressOptionsErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetIngressOptions(user, "test", nil); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsSetACL(t *testing.T) {
+	var testCases = []struct {
+		k8s             *fakeK8sOperations
+		expectedIngress bool
+	}{
+		{&fakeK8sOperations{AppIngress: true}, true},
+		{&fakeK8sOperations{ServiceTypeValue: "LoadBalancer"}, false},
+	}
+	cidrs := []string{"10.0.0.0/8", "200.1.2.3/32"}
+
+	for _, tc := range testCases {
+		tops := team.NewFakeOperations()
+		ops := NewOperations(tops, tc.k8s, nil)
+		user := &database.User{Email: "teresa@luizalabs.com"}
+		tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+			Name:  "luizalabs",
+			Users: []database.User{*user},
+		}
+
+		if err := ops.SetACL(user, "test", cidrs); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		got := tc.k8s.ServiceSourceRanges
+		if tc.expectedIngress {
+			got = tc.k8s.IngressSourceRanges
+		}
+		if !reflect.DeepEqual(got, cidrs) {
+			t.Errorf("expected %v, got %v", cidrs, got)
+		}
+	}
+}
+
+func TestAppOperationsSetACLErrInvalidCIDR(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{AppIngress: true}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	for _, cidr := range []string{"10.0.0.0", "10.0.0.0/33", "teresa.io/8"} {
+		if err := ops.SetACL(user, "test", []string{cidr}); err != ErrInvalidCIDR {
+			t.Errorf("expected ErrInvalidCIDR, got %v [case: %s]", err, cidr)
+		}
+	}
+}
+
+func TestAppOperationsSetACLErrACLNeedsExternalAccess(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{ServiceTypeValue: "ClusterIP"}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetACL(user, "test", []string{"10.0.0.0/8"}); err != ErrACLNeedsExternalAccess {
+		t.Errorf("expected ErrACLNeedsExternalAccess, got %v", err)
+	}
+}
+
+func TestAppOperationsSetACLLoadBalancerErr(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		ServiceTypeValue:               serviceTypeLoadBalancer,
+		SetLoadBalancerSourceRangesErr: errors.New("test"),
+	}
+	ops := NewOperations(tops, k8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	err := ops.SetACL(user, "test", []string{"10.0.0.0/8"})
+	if grpcErr := teresa_errors.Get(err); grpcErr != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected ErrInternalServerError, got %v", grpcErr)
+	}
+}
+
+func TestAppOperationsSetACLErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{AppIngress: true}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetACL(user, "test", nil); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsTeamRoles(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	roles := ops.(*AppOperations).tops.(*team.FakeOperations).Roles
+	evs := []*EnvVar{{Key: "FOO", Value: "bar"}}
+
+	roles["luizalabs"] = map[string]string{user.Email: team.RoleViewer}
+	if _, err := ops.Status(user, "teresa"); err != nil {
+		t.Errorf("expected no error for a viewer, got %v", err)
+	}
+	if err := ops.SetEnv(user, "teresa", evs); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a viewer, got %v", err)
+	}
+
+	if err := ops.Create(user, &App{Name: "new-teresa", Team: "luizalabs"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a viewer, got %v", err)
+	}
+
+	roles["luizalabs"][user.Email] = team.RoleDeveloper
+	if err := ops.SetEnv(user, "teresa", evs); err != nil {
+		t.Errorf("expected no error for a developer, got %v", err)
+	}
+	if err := ops.Delete(user, "teresa"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a developer, got %v", err)
+	}
+
+	roles["luizalabs"][user.Email] = team.RoleAdmin
+	if err := ops.Delete(user, "teresa"); err != nil {
+		t.Errorf("expected no error for an admin, got %v", err)
+	}
+}
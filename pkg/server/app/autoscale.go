@@ -0,0 +1,33 @@
+package app
+
+import "regexp"
+
+var (
+	metricNameRegexp  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	metricValueRegexp = regexp.MustCompile(`^([0-9]+|[0-9]*\.[0-9]+)(m|k|M|G)?$`)
+)
+
+// validAutoscaleMetrics checks the custom metrics of the autoscale, names
+// must be unique and the target values positive quantities (e.g. 100, 500m)
+func validAutoscaleMetrics(metrics []*AutoscaleMetric) bool {
+	names := make(map[string]bool)
+	for _, m := range metrics {
+		if !metricNameRegexp.MatchString(m.Name) || names[m.Name] {
+			return false
+		}
+		if !metricValueRegexp.MatchString(m.TargetAverageValue) || isZeroQuantity(m.TargetAverageValue) {
+			return false
+		}
+		names[m.Name] = true
+	}
+	return true
+}
+
+func isZeroQuantity(s string) bool {
+	for _, c := range s {
+		if c >= '1' && c <= '9' {
+			return false
+		}
+	}
+	return true
+}
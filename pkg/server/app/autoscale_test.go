@@ -0,0 +1,34 @@
+package app
+
+import "testing"
+
+func TestValidAutoscaleMetrics(t *testing.T) {
+	var testCases = []struct {
+		metrics  []*AutoscaleMetric
+		expected bool
+	}{
+		{nil, true},
+		{[]*AutoscaleMetric{{Name: "http_requests_per_second", TargetAverageValue: "100"}}, true},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "500m"}}, true},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "1.5k"}}, true},
+		{[]*AutoscaleMetric{{Name: "queue-depth", TargetAverageValue: "10"}}, false},
+		{[]*AutoscaleMetric{{Name: "", TargetAverageValue: "10"}}, false},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: ""}}, false},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "0"}}, false},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "-1"}}, false},
+		{[]*AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "ten"}}, false},
+		{
+			[]*AutoscaleMetric{
+				{Name: "queue_depth", TargetAverageValue: "10"},
+				{Name: "queue_depth", TargetAverageValue: "20"},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := validAutoscaleMetrics(tc.metrics); got != tc.expected {
+			t.Errorf("expected %v, got %v for %v", tc.expected, got, tc.metrics)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// buildProtectedEnvVars configure the slugbuilder pod on top of the ones
+// protected for the app containers
+var buildProtectedEnvVars = map[string]bool{
+	"TAR_PATH":   true,
+	"PUT_PATH":   true,
+	"CACHE_PATH": true,
+}
+
+func checkForInvalidBuildEnvVars(evNames []string) error {
+	for _, name := range evNames {
+		if buildProtectedEnvVars[name] {
+			return ErrProtectedEnvVar
+		}
+	}
+	return checkForInvalidEnvVars(evNames)
+}
+
+func setBuildEnvVars(app *App, evs []*EnvVar) {
+	for _, ev := range evs {
+		found := false
+		for _, tmp := range app.BuildEnvVars {
+			if tmp.Key == ev.Key {
+				tmp.Value = ev.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			app.BuildEnvVars = append(app.BuildEnvVars, &EnvVar{Key: ev.Key, Value: ev.Value})
+		}
+	}
+}
+
+func unsetBuildEnvVars(app *App, evNames []string) {
+	for _, name := range evNames {
+		for i, tmp := range app.BuildEnvVars {
+			if tmp.Key == name {
+				app.BuildEnvVars = append(app.BuildEnvVars[:i], app.BuildEnvVars[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// buildEnvVarNames returns the names of the build env vars, their values
+// (e.g. private registry tokens) aren't shown back
+func buildEnvVarNames(app *App) []string {
+	names := make([]string, len(app.BuildEnvVars))
+	for i, ev := range app.BuildEnvVars {
+		names[i] = ev.Key
+	}
+	return names
+}
+
+// SetBuildEnv sets env vars available only to the builds of the App, they
+// never reach the app containers
+func (ops *AppOperations) SetBuildEnv(user *database.User, appName string, evs []*EnvVar) error {
+	evNames := make([]string, len(evs))
+	for i := range evs {
+		evNames[i] = evs[i].Key
+	}
+	if err := checkForInvalidBuildEnvVars(evNames); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	setBuildEnvVars(app, evs)
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
+
+func (ops *AppOperations) UnsetBuildEnv(user *database.User, appName string, evNames []string) error {
+	if err := checkForInvalidBuildEnvVars(evNames); err != nil {
+		return err
+	}
+
+	app, err := ops.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	unsetBuildEnvVars(app, evNames)
+	if err := ops.SaveApp(app, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestCheckForInvalidBuildEnvVars(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		expected error
+	}{
+		{"NPM_TOKEN", nil},
+		{"TAR_PATH", ErrProtectedEnvVar},
+		{"PORT", ErrProtectedEnvVar},
+		{"1NVALID", ErrInvalidEnvVarName},
+	}
+
+	for _, tc := range testCases {
+		if got := checkForInvalidBuildEnvVars([]string{tc.name}); got != tc.expected {
+			t.Errorf("expected %v, got %v for %s", tc.expected, got, tc.name)
+		}
+	}
+}
+
+func TestSetAndUnsetBuildEnvVars(t *testing.T) {
+	a := &App{BuildEnvVars: []*EnvVar{{Key: "A", Value: "1"}, {Key: "B", Value: "2"}}}
+
+	setBuildEnvVars(a, []*EnvVar{{Key: "B", Value: "3"}, {Key: "C", Value: "4"}})
+	unsetBuildEnvVars(a, []string{"A"})
+
+	expected := []*EnvVar{{Key: "B", Value: "3"}, {Key: "C", Value: "4"}}
+	if !reflect.DeepEqual(a.BuildEnvVars, expected) {
+		t.Errorf("expected %v, got %v", expected, a.BuildEnvVars)
+	}
+	if got := buildEnvVarNames(a); !reflect.DeepEqual(got, []string{"B", "C"}) {
+		t.Errorf("expected [B C], got %v", got)
+	}
+}
+
+func TestAppOperationsSetBuildEnv(t *testing.T) {
+	tops := team.NewFakeOperations()
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+
+	evs := []*EnvVar{{Key: "NPM_TOKEN", Value: "secret"}}
+	if err := ops.SetBuildEnv(user, "teresa", evs); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	a := new(App)
+	if err := json.Unmarshal([]byte(fakeK8s.SavedAnnotations[TeresaAnnotation]), a); err != nil {
+		t.Fatal("error unmarshaling the saved app:", err)
+	}
+	if !reflect.DeepEqual(a.BuildEnvVars, evs) {
+		t.Errorf("expected %v, got %v", evs, a.BuildEnvVars)
+	}
+	if hasEnvVar(a.EnvVars, "NPM_TOKEN") {
+		t.Error("expected the build env var out of the app env vars")
+	}
+}
+
+func TestAppOperationsSetBuildEnvErrors(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	evs := []*EnvVar{{Key: "PUT_PATH", Value: "/tmp"}}
+	if err := ops.SetBuildEnv(user, "teresa", evs); err != ErrProtectedEnvVar {
+		t.Errorf("expected ErrProtectedEnvVar, got %v", err)
+	}
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.UnsetBuildEnv(bad, "teresa", []string{"NPM_TOKEN"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
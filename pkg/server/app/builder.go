@@ -0,0 +1,25 @@
+package app
+
+const (
+	// BuilderSlug builds the app into a slug with the Heroku buildpacks
+	BuilderSlug = "slug"
+	// BuilderCNB builds the app into an image with Cloud Native Buildpacks
+	BuilderCNB = "cnb"
+)
+
+// validBuilder checks the build mode of an app, blank means the slug one
+func validBuilder(s string) bool {
+	switch s {
+	case "", BuilderSlug, BuilderCNB:
+		return true
+	}
+	return false
+}
+
+// normalizeBuilder stores the default build mode as blank
+func normalizeBuilder(s string) string {
+	if s == BuilderSlug {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidBuilder(t *testing.T) {
+	var testCases = []struct {
+		builder  string
+		expected bool
+	}{
+		{"", true},
+		{BuilderSlug, true},
+		{BuilderCNB, true},
+		{"docker", false},
+		{"CNB", false},
+	}
+
+	for _, tc := range testCases {
+		if got := validBuilder(tc.builder); got != tc.expected {
+			t.Errorf("expected %v, got %v for %q", tc.expected, got, tc.builder)
+		}
+	}
+}
+
+func savedBuilder(t *testing.T, fakeK8s *fakeK8sOperations) string {
+	a := new(App)
+	if err := json.Unmarshal([]byte(fakeK8s.SavedAnnotations[TeresaAnnotation]), a); err != nil {
+		t.Fatal("error unmarshaling the saved app:", err)
+	}
+	return a.Builder
+}
+
+func TestAppOperationsSetBuilder(t *testing.T) {
+	tops := team.NewFakeOperations()
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+
+	if err := ops.SetBuilder(user, "teresa", BuilderCNB); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := savedBuilder(t, fakeK8s); got != BuilderCNB {
+		t.Errorf("expected %s, got %s", BuilderCNB, got)
+	}
+
+	if err := ops.SetBuilder(user, "teresa", BuilderSlug); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := savedBuilder(t, fakeK8s); got != "" {
+		t.Errorf("expected the default builder, got %s", got)
+	}
+}
+
+func TestAppOperationsSetBuilderErrors(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetBuilder(user, "teresa", "docker"); err != ErrInvalidBuilder {
+		t.Errorf("expected ErrInvalidBuilder, got %v", err)
+	}
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.SetBuilder(bad, "teresa", BuilderCNB); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/luizalabs/teresa/pkg/server/validation"
+)
+
+// TeresaAppConfigFiles is the ConfigMap with the config files of an App
+const TeresaAppConfigFiles = "teresa-config-files"
+
+var configFileNameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// ConfigFile is a file of the App ConfigMap mounted in the MountPath
+// directory of the app containers
+type ConfigFile struct {
+	Name      string
+	MountPath string
+}
+
+func validateConfigFile(name, mountPath string) error {
+	if name == "." || name == ".." || !configFileNameRegexp.MatchString(name) {
+		return ErrInvalidConfigFile
+	}
+	if !validation.IsMountPath(mountPath) {
+		return ErrInvalidMountPath
+	}
+	return nil
+}
+
+func setConfigFileOnApp(app *App, name, mountPath string) {
+	if app.ConfigFiles == nil {
+		app.ConfigFiles = make(map[string]string)
+	}
+	app.ConfigFiles[name] = mountPath
+}
+
+// configFiles returns the config files of the App sorted by name
+func configFiles(app *App) []*ConfigFile {
+	names := make([]string, 0, len(app.ConfigFiles))
+	for name := range app.ConfigFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]*ConfigFile, len(names))
+	for i, name := range names {
+		files[i] = &ConfigFile{Name: name, MountPath: app.ConfigFiles[name]}
+	}
+	return files
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	var testCases = []struct {
+		name      string
+		mountPath string
+		expected  error
+	}{
+		{"nginx.conf", "/etc/nginx", nil},
+		{"app_settings-1.yaml", "/etc/app/", nil},
+		{"", "/etc/nginx", ErrInvalidConfigFile},
+		{"..", "/etc/nginx", ErrInvalidConfigFile},
+		{"conf/nginx.conf", "/etc/nginx", ErrInvalidConfigFile},
+		{"nginx.conf", "", ErrInvalidMountPath},
+		{"nginx.conf", "etc/nginx", ErrInvalidMountPath},
+		{"nginx.conf", "/", ErrInvalidMountPath},
+	}
+
+	for _, tc := range testCases {
+		if got := validateConfigFile(tc.name, tc.mountPath); got != tc.expected {
+			t.Errorf("expected %v, got %v for %s at %s", tc.expected, got, tc.name, tc.mountPath)
+		}
+	}
+}
+
+func newConfigFileTestOps(fakeK8s *fakeK8sOperations) (Operations, *database.User) {
+	tops := team.NewFakeOperations()
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	return NewOperations(tops, fakeK8s, nil), user
+}
+
+func TestAppOperationsSetConfigFile(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{}
+	ops, user := newConfigFileTestOps(fakeK8s)
+
+	if err := ops.SetConfigFile(user, "teresa", "nginx.conf", []byte("foo"), "/etc/nginx/"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fakeK8s.ConfigFilesData["nginx.conf"]; got != "foo" {
+		t.Errorf("expected foo, got %s", got)
+	}
+	if got := fakeK8s.ConfigFileMounts["test"]; got != "/etc/nginx" {
+		t.Errorf("expected /etc/nginx, got %s", got)
+	}
+	if len(fakeK8s.DeployRestartNames) != 0 {
+		t.Errorf("expected no restart, got %v", fakeK8s.DeployRestartNames)
+	}
+}
+
+func TestAppOperationsSetConfigFileRestartsOnChange(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{
+		AppConfigFiles:  true,
+		ConfigFilesData: map[string]string{"nginx.conf": "foo"},
+	}
+	ops, user := newConfigFileTestOps(fakeK8s)
+
+	if err := ops.SetConfigFile(user, "teresa", "nginx.conf", []byte("bar"), "/etc/nginx"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(fakeK8s.ConfigFileMounts) != 0 {
+		t.Errorf("expected no mount changes, got %v", fakeK8s.ConfigFileMounts)
+	}
+	if !reflect.DeepEqual(fakeK8s.DeployRestartNames, []string{"test"}) {
+		t.Errorf("expected [test], got %v", fakeK8s.DeployRestartNames)
+	}
+
+	fakeK8s.DeployRestartNames = nil
+	if err := ops.SetConfigFile(user, "teresa", "nginx.conf", []byte("bar"), "/etc/nginx"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(fakeK8s.DeployRestartNames) != 0 {
+		t.Errorf("expected no restart for the same content, got %v", fakeK8s.DeployRestartNames)
+	}
+}
+
+func TestAppOperationsSetConfigFileErrPermissionDenied(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+
+	err := ops.SetConfigFile(user, "teresa", "nginx.conf", []byte("foo"), "/etc/nginx")
+	if err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsUnsetConfigFile(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{
+		AppConfigFiles:  true,
+		ConfigFilesData: map[string]string{"nginx.conf": "foo", "other.conf": "bar"},
+	}
+	ops, user := newConfigFileTestOps(fakeK8s)
+
+	if err := ops.UnsetConfigFile(user, "teresa", "nginx.conf"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if mp, found := fakeK8s.ConfigFileMounts["test"]; !found || mp != "" {
+		t.Errorf("expected the file to be unmounted, got %v", fakeK8s.ConfigFileMounts)
+	}
+	expected := map[string]string{"other.conf": "bar"}
+	if !reflect.DeepEqual(fakeK8s.ConfigFilesData, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.ConfigFilesData)
+	}
+}
+
+func TestAppOperationsUnsetConfigFileNotFound(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+
+	if err := ops.UnsetConfigFile(user, "teresa", "nginx.conf"); err != ErrConfigFileNotFound {
+		t.Errorf("expected ErrConfigFileNotFound, got %v", err)
+	}
+}
+
+func TestConfigFiles(t *testing.T) {
+	a := &App{ConfigFiles: map[string]string{"b.conf": "/etc/b", "a.conf": "/etc/a"}}
+	expected := []*ConfigFile{
+		{Name: "a.conf", MountPath: "/etc/a"},
+		{Name: "b.conf", MountPath: "/etc/b"},
+	}
+
+	if got := configFiles(a); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
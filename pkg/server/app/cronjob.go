@@ -1,7 +1,170 @@
 package app
 
-import "strings"
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// CronJob is the state of the CronJob of an App, LastSchedule is the unix
+// time of its last run (zero if it never ran) and Active the number of jobs
+// running
+type CronJob struct {
+	Name         string
+	Schedule     string
+	Suspended    bool
+	LastSchedule int64
+	Active       int32
+}
+
+const (
+	CronRunStatusRunning   = "Running"
+	CronRunStatusSucceeded = "Succeeded"
+	CronRunStatusFailed    = "Failed"
+)
+
+// CronRun is a job of the CronJob of an App, StartTime is the unix time it
+// started, Duration in seconds and ExitCode the one of the app container
+// once it finished
+type CronRun struct {
+	Name      string
+	Status    string
+	StartTime int64
+	Duration  int64
+	ExitCode  int32
+}
 
 func IsCronJob(processType string) bool {
 	return strings.HasPrefix(processType, ProcessTypeCronPrefix)
 }
+
+// cronApp returns the App if it's a cronjob one and the user has the role
+func (ops *AppOperations) cronApp(user *database.User, appName, role string) (*App, error) {
+	app, err := ops.CheckRoleAndGet(user, appName, role)
+	if err != nil {
+		return nil, err
+	}
+	if !IsCronJob(app.ProcessType) {
+		return nil, ErrNotCronJob
+	}
+	return app, nil
+}
+
+// cronProcessApp returns the App if process is its cron process type, a
+// blank one is the process type of the App
+func (ops *AppOperations) cronProcessApp(user *database.User, appName, process string) (*App, error) {
+	app, err := ops.cronApp(user, appName, team.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	if process != "" && process != app.ProcessType {
+		return nil, ErrCronProcessNotFound
+	}
+	return app, nil
+}
+
+func (ops *AppOperations) cronJobErr(err error) error {
+	if ops.kops.IsNotFound(err) {
+		return ErrCronJobNotFound
+	}
+	return teresa_errors.NewInternalServerError(err)
+}
+
+// CronList returns the CronJobs of the App with their schedule and last run
+func (ops *AppOperations) CronList(user *database.User, appName string) ([]*CronJob, error) {
+	if _, err := ops.cronApp(user, appName, team.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	cj, err := ops.kops.CronJob(appName, appName)
+	if err != nil {
+		return nil, ops.cronJobErr(err)
+	}
+	return []*CronJob{cj}, nil
+}
+
+// CronSuspend stops the scheduling of the CronJob of the App, the jobs
+// already running aren't affected
+func (ops *AppOperations) CronSuspend(user *database.User, appName string) error {
+	if _, err := ops.cronApp(user, appName, team.RoleDeveloper); err != nil {
+		return err
+	}
+
+	if err := ops.kops.SuspendCronJob(appName, appName); err != nil {
+		return ops.cronJobErr(err)
+	}
+	return nil
+}
+
+// CronResume schedules the suspended CronJob of the App again
+func (ops *AppOperations) CronResume(user *database.User, appName string) error {
+	if _, err := ops.cronApp(user, appName, team.RoleDeveloper); err != nil {
+		return err
+	}
+
+	if err := ops.kops.ResumeCronJob(appName, appName); err != nil {
+		return ops.cronJobErr(err)
+	}
+	return nil
+}
+
+// CronRunNow starts a job of the CronJob of the App out of its schedule,
+// returning the name of the job
+func (ops *AppOperations) CronRunNow(user *database.User, appName string) (string, error) {
+	if _, err := ops.cronApp(user, appName, team.RoleDeveloper); err != nil {
+		return "", err
+	}
+
+	name, err := ops.kops.CronJobRunNow(appName, appName)
+	if err != nil {
+		return "", ops.cronJobErr(err)
+	}
+	return name, nil
+}
+
+// CronRuns returns the recent jobs of the CronJob of the App, the newest
+// first
+func (ops *AppOperations) CronRuns(user *database.User, appName, process string) ([]*CronRun, error) {
+	if _, err := ops.cronProcessApp(user, appName, process); err != nil {
+		return nil, err
+	}
+
+	runs, err := ops.kops.CronJobRuns(appName, appName)
+	if err != nil {
+		return nil, ops.cronJobErr(err)
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartTime > runs[j].StartTime
+	})
+	return runs, nil
+}
+
+// CronRunLogs streams the logs of the pods of a job of the CronJob of the
+// App
+func (ops *AppOperations) CronRunLogs(user *database.User, appName, process, run string, opts *LogOptions) (io.ReadCloser, error) {
+	a, err := ops.cronProcessApp(user, appName, process)
+	if err != nil {
+		return nil, err
+	}
+	if run == "" {
+		return nil, ErrCronRunNotFound
+	}
+
+	listOpts := &PodListOptions{JobName: run}
+	pods, err := ops.kops.PodList(appName, listOpts)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	if len(pods) == 0 {
+		return nil, ErrCronRunNotFound
+	}
+	if opts.Container == "" {
+		opts.Container = appName
+	}
+
+	return ops.podsLogs(a, listOpts, pods, opts, nil, nil), nil
+}
@@ -1,6 +1,11 @@
 package app
 
-import "testing"
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
 
 func TestIsCronJob(t *testing.T) {
 	var testCases = []struct {
@@ -21,3 +26,91 @@ func TestIsCronJob(t *testing.T) {
 		}
 	}
 }
+
+func TestAppOperationsCronList(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix})
+
+	crons, err := ops.CronList(user, "teresa")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(crons) != 1 || crons[0].Name != "teresa" || crons[0].Schedule != "*/5 * * * *" {
+		t.Errorf("expected the cronjob teresa, got %v", crons)
+	}
+}
+
+func TestAppOperationsCronErrors(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	if _, err := ops.CronList(user, "teresa"); err != ErrNotCronJob {
+		t.Errorf("expected ErrNotCronJob, got %v", err)
+	}
+	if err := ops.CronSuspend(user, "teresa"); err != ErrNotCronJob {
+		t.Errorf("expected ErrNotCronJob, got %v", err)
+	}
+
+	fakeK8s := &fakeK8sOperations{
+		DefaultProcessType: ProcessTypeCronPrefix,
+		SuspendCronJobErr:  errors.New("not found"),
+		CronJobRunNowErr:   errors.New("not found"),
+		IsNotFoundErr:      true,
+	}
+	ops, user = newConfigFileTestOps(fakeK8s)
+	if err := ops.CronSuspend(user, "teresa"); err != ErrCronJobNotFound {
+		t.Errorf("expected ErrCronJobNotFound, got %v", err)
+	}
+	if _, err := ops.CronRunNow(user, "teresa"); err != ErrCronJobNotFound {
+		t.Errorf("expected ErrCronJobNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsCronRunNow(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix})
+
+	name, err := ops.CronRunNow(user, "teresa")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if name != "teresa-manual-1500000000" {
+		t.Errorf("expected teresa-manual-1500000000, got %s", name)
+	}
+	if err := ops.CronResume(user, "teresa"); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestAppOperationsCronRuns(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix})
+
+	runs, err := ops.CronRuns(user, "teresa", ProcessTypeCronPrefix)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(runs) != 2 || runs[0].Name != "teresa-2" || runs[0].ExitCode != 1 {
+		t.Errorf("expected the newest run first, got %v", runs)
+	}
+
+	if _, err := ops.CronRuns(user, "teresa", "worker"); err != ErrCronProcessNotFound {
+		t.Errorf("expected ErrCronProcessNotFound, got %v", err)
+	}
+}
+
+func TestAppOperationsCronRunLogs(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix})
+
+	rc, err := ops.CronRunLogs(user, "teresa", "", "teresa-1", &LogOptions{})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal("error reading logs:", err)
+	}
+	if !strings.Contains(string(b), "[pod 1] - foo") {
+		t.Errorf("expected the logs of the run pods, got %q", b)
+	}
+
+	if _, err := ops.CronRunLogs(user, "teresa", "", "", &LogOptions{}); err != ErrCronRunNotFound {
+		t.Errorf("expected ErrCronRunNotFound, got %v", err)
+	}
+}
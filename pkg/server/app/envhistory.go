@@ -0,0 +1,134 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/pkg/errors"
+)
+
+// EnvRevision is a snapshot of the env vars of an app after a change
+type EnvRevision struct {
+	Version   int32
+	Author    string
+	CreatedAt time.Time
+	EnvVars   []*EnvVar
+}
+
+type EnvHistory interface {
+	Save(appName, author string, evs []*EnvVar) error
+	List(appName string) ([]*EnvRevision, error)
+	Get(appName string, version int32) (*EnvRevision, error)
+	Delete(appName string) error
+	Rename(oldName, newName string) error
+}
+
+type DatabaseEnvHistory struct {
+	DB *gorm.DB
+}
+
+func (h *DatabaseEnvHistory) Save(appName, author string, evs []*EnvVar) error {
+	b, err := json.Marshal(evs)
+	if err != nil {
+		return errors.Wrap(err, "marshal env vars failed")
+	}
+
+	last := new(database.AppEnvRevision)
+	err = h.DB.Where(&database.AppEnvRevision{AppName: appName}).
+		Order("version desc").
+		First(last).
+		Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return errors.Wrap(err, fmt.Sprintf("reading env history of %s", appName))
+	}
+
+	rev := &database.AppEnvRevision{
+		AppName: appName,
+		Version: last.Version + 1,
+		Author:  author,
+		EnvVars: database.EncryptedString(b),
+	}
+	if err := h.DB.Create(rev).Error; err != nil {
+		return errors.Wrap(err, fmt.Sprintf("saving env revision of %s", appName))
+	}
+	return nil
+}
+
+func (h *DatabaseEnvHistory) List(appName string) ([]*EnvRevision, error) {
+	var revs []*database.AppEnvRevision
+	err := h.DB.Where(&database.AppEnvRevision{AppName: appName}).
+		Order("version desc").
+		Find(&revs).
+		Error
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	res := make([]*EnvRevision, len(revs))
+	for i, rev := range revs {
+		if res[i], err = newEnvRevision(rev); err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+	}
+	return res, nil
+}
+
+func (h *DatabaseEnvHistory) Get(appName string, version int32) (*EnvRevision, error) {
+	rev := new(database.AppEnvRevision)
+	q := h.DB.Where(&database.AppEnvRevision{AppName: appName, Version: version}).First(rev)
+	if q.RecordNotFound() {
+		return nil, ErrEnvRevisionNotFound
+	}
+	if q.Error != nil {
+		return nil, teresa_errors.NewInternalServerError(q.Error)
+	}
+
+	res, err := newEnvRevision(rev)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	return res, nil
+}
+
+// Delete removes the history of a deleted app, a new app with the same name
+// starts without one
+func (h *DatabaseEnvHistory) Delete(appName string) error {
+	err := h.DB.Where("app_name = ?", appName).Delete(&database.AppEnvRevision{}).Error
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("deleting env history of %s", appName))
+	}
+	return nil
+}
+
+// Rename moves the history of a renamed app to its new name
+func (h *DatabaseEnvHistory) Rename(oldName, newName string) error {
+	err := h.DB.Model(&database.AppEnvRevision{}).
+		Where("app_name = ?", oldName).
+		Update("app_name", newName).
+		Error
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("renaming env history of %s", oldName))
+	}
+	return nil
+}
+
+func newEnvRevision(rev *database.AppEnvRevision) (*EnvRevision, error) {
+	var evs []*EnvVar
+	if err := json.Unmarshal([]byte(rev.EnvVars), &evs); err != nil {
+		return nil, errors.Wrap(err, "unmarshal env vars failed")
+	}
+	return &EnvRevision{
+		Version:   rev.Version,
+		Author:    rev.Author,
+		CreatedAt: rev.CreatedAt,
+		EnvVars:   evs,
+	}, nil
+}
+
+func NewDatabaseEnvHistory(db *gorm.DB) EnvHistory {
+	return &DatabaseEnvHistory{DB: db}
+}
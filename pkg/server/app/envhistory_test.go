@@ -0,0 +1,104 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestDatabaseEnvHistory(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	eh := NewDatabaseEnvHistory(db)
+	evs := []*EnvVar{{Key: "FOO", Value: "bar"}}
+	for _, author := range []string{"gopher@luizalabs.com", "teresa@luizalabs.com"} {
+		if err := eh.Save("teresa", author, evs); err != nil {
+			t.Fatal("error saving env revision:", err)
+		}
+	}
+	if err := eh.Save("other", "gopher@luizalabs.com", nil); err != nil {
+		t.Fatal("error saving env revision:", err)
+	}
+
+	revs, err := eh.List("teresa")
+	if err != nil {
+		t.Fatal("error listing env revisions:", err)
+	}
+	if len(revs) != 2 {
+		t.Fatalf("expected 2 revisions, got %d", len(revs))
+	}
+	if revs[0].Version != 2 || revs[0].Author != "teresa@luizalabs.com" {
+		t.Errorf("expected version 2 by teresa@luizalabs.com, got %d by %s", revs[0].Version, revs[0].Author)
+	}
+
+	rev, err := eh.Get("teresa", 1)
+	if err != nil {
+		t.Fatal("error getting env revision:", err)
+	}
+	if !reflect.DeepEqual(rev.EnvVars, evs) {
+		t.Errorf("expected %v, got %v", evs, rev.EnvVars)
+	}
+}
+
+func TestDatabaseEnvHistoryGetErrEnvRevisionNotFound(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	eh := NewDatabaseEnvHistory(db)
+	if _, err := eh.Get("teresa", 1); err != ErrEnvRevisionNotFound {
+		t.Errorf("expected ErrEnvRevisionNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseEnvHistoryDeleteAndRename(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	eh := NewDatabaseEnvHistory(db)
+	for _, name := range []string{"teresa", "other"} {
+		if err := eh.Save(name, "gopher@luizalabs.com", nil); err != nil {
+			t.Fatal("error saving env revision:", err)
+		}
+	}
+
+	if err := eh.Rename("teresa", "new-teresa"); err != nil {
+		t.Fatal("error renaming env history:", err)
+	}
+	if revs, _ := eh.List("teresa"); len(revs) != 0 {
+		t.Errorf("expected no revisions of teresa, got %d", len(revs))
+	}
+	if revs, _ := eh.List("new-teresa"); len(revs) != 1 {
+		t.Errorf("expected 1 revision of new-teresa, got %d", len(revs))
+	}
+
+	if err := eh.Delete("new-teresa"); err != nil {
+		t.Fatal("error deleting env history:", err)
+	}
+	if revs, _ := eh.List("new-teresa"); len(revs) != 0 {
+		t.Errorf("expected no revisions of new-teresa, got %d", len(revs))
+	}
+	if revs, _ := eh.List("other"); len(revs) != 1 {
+		t.Errorf("expected 1 revision of other, got %d", len(revs))
+	}
+}
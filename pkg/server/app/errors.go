@@ -6,16 +6,71 @@ import (
 )
 
 var (
-	ErrAlreadyExists           = status.Errorf(codes.AlreadyExists, "App already exists")
-	ErrNotFound                = status.Errorf(codes.NotFound, "App not found")
-	ErrProtectedEnvVar         = status.Errorf(codes.InvalidArgument, "Can't change protected env vars")
-	ErrInvalidName             = status.Errorf(codes.InvalidArgument, "Invalid App Name")
-	ErrInvalidLimits           = status.Errorf(codes.InvalidArgument, "Invalid Limits")
-	ErrInvalidAutoscale        = status.Errorf(codes.InvalidArgument, "Invalid Autoscale")
-	ErrInvalidEnvVarName       = status.Errorf(codes.InvalidArgument, "Invalid Env Var Name")
-	ErrInvalidSecretName       = status.Errorf(codes.InvalidArgument, "Invalid Secret Name")
-	ErrInvalidActionForCronJob = status.Errorf(codes.InvalidArgument, "Invalid action for a cronjob app")
-	ErrMissingVirtualHost      = status.Errorf(
+	ErrAlreadyExists            = status.Errorf(codes.AlreadyExists, "App already exists")
+	ErrNotFound                 = status.Errorf(codes.NotFound, "App not found")
+	ErrProtectedEnvVar          = status.Errorf(codes.InvalidArgument, "Can't change protected env vars")
+	ErrInvalidName              = status.Errorf(codes.InvalidArgument, "Invalid App Name")
+	ErrInvalidLimits            = status.Errorf(codes.InvalidArgument, "Invalid Limits")
+	ErrInvalidAutoscale         = status.Errorf(codes.InvalidArgument, "Invalid Autoscale")
+	ErrInvalidEnvVarName        = status.Errorf(codes.InvalidArgument, "Invalid Env Var Name")
+	ErrInvalidSecretName        = status.Errorf(codes.InvalidArgument, "Invalid Secret Name")
+	ErrInvalidMountPath         = status.Errorf(codes.InvalidArgument, "Invalid mount path")
+	ErrInvalidActionForCronJob  = status.Errorf(codes.InvalidArgument, "Invalid action for a cronjob app")
+	ErrNotCronJob               = status.Errorf(codes.InvalidArgument, "App isn't a cronjob")
+	ErrCronJobNotFound          = status.Errorf(codes.NotFound, "CronJob not found, deploy the app first")
+	ErrCronProcessNotFound      = status.Errorf(codes.NotFound, "Cron process not found")
+	ErrCronRunNotFound          = status.Errorf(codes.NotFound, "Cron run not found")
+	ErrInvalidPort              = status.Errorf(codes.InvalidArgument, "Invalid port")
+	ErrPodNotFound              = status.Errorf(codes.NotFound, "Pod not found")
+	ErrInvalidReplicas          = status.Errorf(codes.InvalidArgument, "Invalid number of replicas")
+	ErrInvalidProcessType       = status.Errorf(codes.InvalidArgument, "Invalid process type")
+	ErrAlreadyPaused            = status.Errorf(codes.FailedPrecondition, "App already paused")
+	ErrNotPaused                = status.Errorf(codes.FailedPrecondition, "App is not paused")
+	ErrAlreadyLocked            = status.Errorf(codes.FailedPrecondition, "App already locked")
+	ErrNotLocked                = status.Errorf(codes.FailedPrecondition, "App is not locked")
+	ErrInvalidLockReason        = status.Errorf(codes.InvalidArgument, "Invalid lock reason, it's too long")
+	ErrEnvRevisionNotFound      = status.Errorf(codes.NotFound, "Env revision not found")
+	ErrEnvHistoryNotAvailable   = status.Errorf(codes.FailedPrecondition, "Env history not available")
+	ErrLocksNotAvailable        = status.Errorf(codes.FailedPrecondition, "App locks not available")
+	ErrMaintenanceNotAvailable  = status.Errorf(codes.FailedPrecondition, "Maintenance mode not available in this cluster")
+	ErrMaintenanceNeedsIngress  = status.Errorf(codes.FailedPrecondition, "Maintenance mode requires an app exposed by ingress")
+	ErrTLSNotAvailable          = status.Errorf(codes.FailedPrecondition, "TLS not available in this cluster")
+	ErrTLSNeedsIngress          = status.Errorf(codes.FailedPrecondition, "TLS requires an app exposed by ingress")
+	ErrInvalidIngressOption     = status.Errorf(codes.InvalidArgument, "Invalid ingress option")
+	ErrIngressOptionNotAllowed  = status.Errorf(codes.PermissionDenied, "Ingress option not allowed in this cluster")
+	ErrInvalidCIDR              = status.Errorf(codes.InvalidArgument, "Invalid CIDR")
+	ErrACLNeedsExternalAccess   = status.Errorf(codes.FailedPrecondition, "Source IP allowlist requires an app exposed by ingress or load balancer")
+	ErrVHostNotAllowed          = status.Errorf(codes.PermissionDenied, "Virtual host domain not allowed for the team")
+	ErrVHostAlreadyExists       = status.Errorf(codes.AlreadyExists, "Virtual host already set for the app")
+	ErrVHostNotFound            = status.Errorf(codes.NotFound, "Virtual host not found")
+	ErrInvalidScaleWindow       = status.Errorf(codes.InvalidArgument, "Invalid scale window")
+	ErrInvalidMinAvailable      = status.Errorf(codes.InvalidArgument, "Invalid min available pods")
+	ErrInvalidVolumeClaim       = status.Errorf(codes.InvalidArgument, "Invalid volume")
+	ErrVolumeClaimAlreadyExists = status.Errorf(codes.AlreadyExists, "Volume already exists")
+	ErrVolumeClaimNotFound      = status.Errorf(codes.NotFound, "Volume not found")
+	ErrRenameWithVolumeClaims   = status.Errorf(codes.FailedPrecondition, "Apps with volumes can't be renamed")
+	ErrRenameProtected          = status.Errorf(codes.FailedPrecondition, "Protected apps can't be renamed, lift the protection first")
+	ErrInvalidConfigFile        = status.Errorf(codes.InvalidArgument, "Invalid config file")
+	ErrConfigFileNotFound       = status.Errorf(codes.NotFound, "Config file not found")
+	ErrInvalidLifecycle         = status.Errorf(codes.InvalidArgument, "Invalid drain timeout or termination grace period")
+	ErrInvalidRollingUpdate     = status.Errorf(codes.InvalidArgument, "Invalid max surge or max unavailable")
+	ErrInvalidRolloutTimeout    = status.Errorf(codes.InvalidArgument, "Invalid rollout timeout")
+	ErrInvalidBuilder           = status.Errorf(codes.InvalidArgument, "Invalid builder, use slug or cnb")
+	ErrProcessTypeNotFound      = status.Errorf(codes.NotFound, "Process type not found")
+	ErrInvalidLogFilter         = status.Errorf(codes.InvalidArgument, "Invalid grep expression")
+	ErrInvalidLogSince          = status.Errorf(codes.InvalidArgument, "Invalid since, it must be a positive duration")
+	ErrInvalidLogOutput         = status.Errorf(codes.InvalidArgument, "Invalid output, use text or json")
+	ErrNoPreviousLogs           = status.Errorf(codes.FailedPrecondition, "No restarted pod, there are no logs of a previous container")
+	ErrAppsQuotaExceeded        = status.Errorf(codes.ResourceExhausted, "Team quota exceeded, the team can't have more apps")
+	ErrReplicasQuotaExceeded    = status.Errorf(codes.ResourceExhausted, "Team quota exceeded, the team can't have more replicas")
+	ErrCPUQuotaExceeded         = status.Errorf(codes.ResourceExhausted, "Team quota exceeded, the team can't request more cpu")
+	ErrMemoryQuotaExceeded      = status.Errorf(codes.ResourceExhausted, "Team quota exceeded, the team can't request more memory")
+	ErrClusterNotFound          = status.Errorf(codes.NotFound, "Cluster not found")
+	ErrEnvGroupsAttached        = status.Errorf(
+		codes.FailedPrecondition,
+		"App has env groups of the current team attached, unset them before transferring the app",
+	)
+	ErrMissingVirtualHost       = status.Errorf(
 		codes.InvalidArgument,
 		"Missing --vhost argument with the application domain",
 	)
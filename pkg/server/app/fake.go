@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"strings"
 	"sync"
 
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+	"github.com/luizalabs/teresa/pkg/server/logstore"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 )
 
 type FakeOperations struct {
 	mutex   *sync.RWMutex
 	Storage map[string]*App
+	Locks   map[string]*Lock
 }
 
 func hasPerm(email string) bool {
@@ -24,6 +29,10 @@ func (f *FakeOperations) HasPermission(user *database.User, appName string) bool
 	return hasPerm(user.Email)
 }
 
+func (f *FakeOperations) HasRole(user *database.User, appName, role string) bool {
+	return hasPerm(user.Email)
+}
+
 func (f *FakeOperations) Create(user *database.User, app *App) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
@@ -79,6 +88,55 @@ func (f *FakeOperations) Info(user *database.User, appName string) (*Info, error
 	return &Info{}, nil
 }
 
+func (f *FakeOperations) Status(user *database.User, appName string) ([]*PodDetail, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return nil, ErrNotFound
+	}
+
+	return []*PodDetail{}, nil
+}
+
+func (f *FakeOperations) Events(user *database.User, appName string, opts *EventOptions, stop <-chan struct{}) (<-chan *Event, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return nil, ErrNotFound
+	}
+
+	ch := make(chan *Event, 1)
+	ch <- &Event{Type: "Warning", Reason: "BackOff", Object: "pod/" + appName}
+	close(ch)
+
+	return ch, nil
+}
+
+func (f *FakeOperations) Top(user *database.User, appName string) ([]*PodMetrics, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return nil, ErrNotFound
+	}
+
+	return []*PodMetrics{}, nil
+}
+
 func (f *FakeOperations) List(user *database.User) ([]*AppListItem, error) {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
@@ -203,7 +261,7 @@ func (f *FakeOperations) UnsetSecret(user *database.User, appName string, secret
 	return nil
 }
 
-func (f *FakeOperations) SetSecretFile(user *database.User, appName, name string, content []byte) error {
+func (f *FakeOperations) SetSecretFile(user *database.User, appName, name string, content []byte, mountPath string) error {
 	return f.SetSecret(user, appName, nil)
 }
 
@@ -222,6 +280,10 @@ func (f *FakeOperations) SetAutoscale(user *database.User, appName string, as *A
 	return nil
 }
 
+func (f *FakeOperations) CheckRoleAndGet(user *database.User, appName, role string) (*App, error) {
+	return f.CheckPermAndGet(user, appName)
+}
+
 func (f *FakeOperations) CheckPermAndGet(user *database.User, appName string) (*App, error) {
 	if !hasPerm(user.Email) {
 		return nil, auth.ErrPermissionDenied
@@ -264,30 +326,52 @@ func (f *FakeOperations) SetReplicas(user *database.User, appName string, replic
 	return nil
 }
 
-func (f *FakeOperations) ChangeTeam(appName, teamName string) error {
+func (f *FakeOperations) Scale(user *database.User, appName string, replicas map[string]int32) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
-	f.Storage[appName].Team = teamName
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	a, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if a.Processes == nil {
+		a.Processes = make(map[string]int32)
+	}
+	for pt, n := range replicas {
+		if pt != a.ProcessType {
+			a.Processes[pt] = n
+		}
+	}
+
 	return nil
 }
 
-func (f *FakeOperations) DeletePods(user *database.User, appName string, podsNames []string) error {
+func (f *FakeOperations) Pause(user *database.User, appName string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
 	if !hasPerm(user.Email) {
-		return teresa_errors.New(auth.ErrPermissionDenied, fmt.Errorf("error"))
+		return auth.ErrPermissionDenied
 	}
 
-	if _, found := f.Storage[appName]; !found {
-		return teresa_errors.New(ErrNotFound, fmt.Errorf("error"))
+	a, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
 	}
+	if a.Paused != nil {
+		return ErrAlreadyPaused
+	}
+	a.Paused = &PausedState{Replicas: map[string]int32{appName: 1}}
 
 	return nil
 }
 
-func (f *FakeOperations) SetVHosts(user *database.User, appName string, vHosts []string) error {
+func (f *FakeOperations) Resume(user *database.User, appName string) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -295,6 +379,26 @@ func (f *FakeOperations) SetVHosts(user *database.User, appName string, vHosts [
 		return auth.ErrPermissionDenied
 	}
 
+	a, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+	if a.Paused == nil {
+		return ErrNotPaused
+	}
+	a.Paused = nil
+
+	return nil
+}
+
+func (f *FakeOperations) Restart(user *database.User, appName string) error {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
 	if _, found := f.Storage[appName]; !found {
 		return ErrNotFound
 	}
@@ -302,9 +406,691 @@ func (f *FakeOperations) SetVHosts(user *database.User, appName string, vHosts [
 	return nil
 }
 
-func NewFakeOperations() *FakeOperations {
-	return &FakeOperations{
-		mutex:   &sync.RWMutex{},
-		Storage: make(map[string]*App),
+func (f *FakeOperations) SetMaintenance(user *database.User, appName string, enabled bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.Maintenance = enabled
+	return nil
+}
+
+func (f *FakeOperations) EnableTLS(user *database.User, appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.TLS = true
+	return nil
+}
+
+func (f *FakeOperations) SetIngressOptions(user *database.User, appName string, opts map[string]string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	mergeIngressOptions(app, opts)
+	return nil
+}
+
+func (f *FakeOperations) SetACL(user *database.User, appName string, cidrs []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
 	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.SourceRanges = cidrs
+	return nil
+}
+
+func (f *FakeOperations) SetAutoscaleSchedule(user *database.User, appName string, windows []*ScaleWindow) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if err := validateScaleWindows(windows); err != nil {
+		return err
+	}
+	app.ScaleSchedule = &ScaleSchedule{Windows: windows}
+	return nil
+}
+
+func (f *FakeOperations) RunAutoscaleScheduler(l lease.Leases, stop <-chan struct{}) {
+	<-stop
+}
+
+func (f *FakeOperations) SyncPDBs(l lease.Leases) {}
+
+func (f *FakeOperations) SetPDB(user *database.User, appName, minAvailable string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if !validMinAvailable(minAvailable) {
+		return ErrInvalidMinAvailable
+	}
+	app.MinAvailable = minAvailable
+	return nil
+}
+
+func (f *FakeOperations) AddVolume(user *database.User, appName string, vc *VolumeClaim) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if err := validateVolumeClaim(vc, app.VolumeClaims); err != nil {
+		return err
+	}
+	app.VolumeClaims = append(app.VolumeClaims, vc)
+	return nil
+}
+
+func (f *FakeOperations) RemoveVolume(user *database.User, appName, volumeName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	i := volumeClaimIndex(app.VolumeClaims, volumeName)
+	if i < 0 {
+		return ErrVolumeClaimNotFound
+	}
+	app.VolumeClaims = append(app.VolumeClaims[:i], app.VolumeClaims[i+1:]...)
+	return nil
+}
+
+func (f *FakeOperations) SetConfigFile(user *database.User, appName, name string, content []byte, mountPath string) error {
+	if err := validateConfigFile(name, mountPath); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	setConfigFileOnApp(app, name, mountPath)
+	return nil
+}
+
+func (f *FakeOperations) UnsetConfigFile(user *database.User, appName, name string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if _, found := app.ConfigFiles[name]; !found {
+		return ErrConfigFileNotFound
+	}
+	delete(app.ConfigFiles, name)
+	return nil
+}
+
+func (f *FakeOperations) SetLifecycle(user *database.User, appName string, lc *Lifecycle) error {
+	if err := validateLifecycle(lc); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.Lifecycle = lc
+	return nil
+}
+
+func (f *FakeOperations) SetRollingUpdate(user *database.User, appName string, ru *RollingUpdate) error {
+	if err := ValidateRollingUpdate(ru); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.RollingUpdate = ru
+	return nil
+}
+
+func (f *FakeOperations) SetRolloutTimeout(user *database.User, appName string, seconds int32) error {
+	if err := ValidateRolloutTimeout(seconds); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.RolloutTimeoutSeconds = seconds
+	return nil
+}
+
+func (f *FakeOperations) SetBuilder(user *database.User, appName, builder string) error {
+	if !validBuilder(builder) {
+		return ErrInvalidBuilder
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.Builder = normalizeBuilder(builder)
+	return nil
+}
+
+func (f *FakeOperations) SetBuildEnv(user *database.User, appName string, evs []*EnvVar) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	setBuildEnvVars(app, evs)
+	return nil
+}
+
+func (f *FakeOperations) UnsetBuildEnv(user *database.User, appName string, evNames []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	unsetBuildEnvVars(app, evNames)
+	return nil
+}
+
+func (f *FakeOperations) SetResources(user *database.User, appName string, r *Resources) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	setResourcesOnApp(app, r)
+	return nil
+}
+
+func (f *FakeOperations) EnvHistory(user *database.User, appName string) ([]*EnvRevision, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	return []*EnvRevision{{Version: 1, Author: user.Email, EnvVars: app.EnvVars}}, nil
+}
+
+func (f *FakeOperations) EnvRollback(user *database.User, appName string, version int32) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return ErrNotFound
+	}
+
+	if version != 1 {
+		return ErrEnvRevisionNotFound
+	}
+	return nil
+}
+
+func (f *FakeOperations) SetEnvHistory(eh EnvHistory) {}
+
+func (f *FakeOperations) SetLogStore(ls logstore.Store) {}
+
+func (f *FakeOperations) SetClusters(c Clusters) {}
+
+func (f *FakeOperations) SetEnvGroups(egs EnvGroups) {}
+
+func (f *FakeOperations) SetAppLocks(l AppLocks) {}
+
+func (f *FakeOperations) SetEnvGroup(user *database.User, appName, group string, evs []*EnvVar) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	if !hasEnvGroup(app, group) {
+		app.EnvGroups = append(app.EnvGroups, group)
+	}
+	return nil
+}
+
+func (f *FakeOperations) UnsetEnvGroup(user *database.User, appName, group string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	for i := range app.EnvGroups {
+		if app.EnvGroups[i] == group {
+			app.EnvGroups = append(app.EnvGroups[:i], app.EnvGroups[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (f *FakeOperations) ChangeTeam(appName, teamName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.Storage[appName].Team = teamName
+	return nil
+}
+
+func (f *FakeOperations) DeleteApp(appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Storage[appName]; !found {
+		return ErrNotFound
+	}
+	delete(f.Storage, appName)
+	return nil
+}
+
+func (f *FakeOperations) TeamUsage(teamName string) (*teamext.Usage, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	u := &teamext.Usage{}
+	for _, a := range f.Storage {
+		if a.Team == teamName {
+			u.Apps++
+		}
+	}
+	return u, nil
+}
+
+func (f *FakeOperations) CheckTeamQuota(a *App) error {
+	return nil
+}
+
+func (f *FakeOperations) TransferTeam(user *database.User, appName, teamName string) error {
+	if !user.IsAdmin {
+		return auth.ErrPermissionDenied
+	}
+
+	return f.ChangeTeam(appName, teamName)
+}
+
+func (f *FakeOperations) DeletePods(user *database.User, appName string, podsNames []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return teresa_errors.New(auth.ErrPermissionDenied, fmt.Errorf("error"))
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return teresa_errors.New(ErrNotFound, fmt.Errorf("error"))
+	}
+
+	return nil
+}
+
+func (f *FakeOperations) SetVHosts(user *database.User, appName string, vHosts []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (f *FakeOperations) AddVHost(user *database.User, appName, vHost string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	app.VirtualHost = strings.Join(append(appVHosts(app), vHost), ",")
+	return nil
+}
+
+func (f *FakeOperations) RemoveVHost(user *database.User, appName, vHost string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return ErrNotFound
+	}
+
+	cur := appVHosts(app)
+	for i, vh := range cur {
+		if vh == vHost {
+			app.VirtualHost = strings.Join(append(cur[:i], cur[i+1:]...), ",")
+			return nil
+		}
+	}
+	return ErrVHostNotFound
+}
+
+func (f *FakeOperations) Rename(user *database.User, oldName, newName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	a, found := f.Storage[oldName]
+	if !found {
+		return ErrNotFound
+	}
+	if _, found := f.Storage[newName]; found {
+		return ErrAlreadyExists
+	}
+
+	a.Name = newName
+	f.Storage[newName] = a
+	delete(f.Storage, oldName)
+
+	return nil
+}
+
+func (f *FakeOperations) Clone(user *database.User, srcName, dstName, vHost string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	a, found := f.Storage[srcName]
+	if !found {
+		return ErrNotFound
+	}
+	if _, found := f.Storage[dstName]; found {
+		return ErrAlreadyExists
+	}
+
+	c := *a
+	c.Name = dstName
+	c.VirtualHost = vHost
+	f.Storage[dstName] = &c
+
+	return nil
+}
+
+func NewFakeOperations() *FakeOperations {
+	return &FakeOperations{
+		mutex:   &sync.RWMutex{},
+		Storage: make(map[string]*App),
+		Locks:   make(map[string]*Lock),
+	}
+}
+
+func (f *FakeOperations) Lock(user *database.User, appName, reason string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return ErrNotFound
+	}
+	if _, found := f.Locks[appName]; found {
+		return ErrAlreadyLocked
+	}
+
+	f.Locks[appName] = &Lock{Reason: reason, User: user.Email}
+	return nil
+}
+
+func (f *FakeOperations) Unlock(user *database.User, appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return ErrNotFound
+	}
+	if _, found := f.Locks[appName]; !found {
+		return ErrNotLocked
+	}
+
+	delete(f.Locks, appName)
+	return nil
+}
+
+func (f *FakeOperations) cronApp(user *database.User, appName string) (*App, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	app, found := f.Storage[appName]
+	if !found {
+		return nil, ErrNotFound
+	}
+	if !IsCronJob(app.ProcessType) {
+		return nil, ErrNotCronJob
+	}
+	return app, nil
+}
+
+func (f *FakeOperations) CronList(user *database.User, appName string) ([]*CronJob, error) {
+	if _, err := f.cronApp(user, appName); err != nil {
+		return nil, err
+	}
+	return []*CronJob{{Name: appName, Schedule: "*/5 * * * *"}}, nil
+}
+
+func (f *FakeOperations) CronSuspend(user *database.User, appName string) error {
+	_, err := f.cronApp(user, appName)
+	return err
+}
+
+func (f *FakeOperations) CronResume(user *database.User, appName string) error {
+	_, err := f.cronApp(user, appName)
+	return err
+}
+
+func (f *FakeOperations) CronRunNow(user *database.User, appName string) (string, error) {
+	if _, err := f.cronApp(user, appName); err != nil {
+		return "", err
+	}
+	return appName + "-manual", nil
+}
+
+func (f *FakeOperations) CronRuns(user *database.User, appName, process string) ([]*CronRun, error) {
+	if _, err := f.cronApp(user, appName); err != nil {
+		return nil, err
+	}
+	return []*CronRun{{Name: appName + "-1500000000", Status: CronRunStatusSucceeded}}, nil
+}
+
+func (f *FakeOperations) CronRunLogs(user *database.User, appName, process, run string, opts *LogOptions) (io.ReadCloser, error) {
+	if _, err := f.cronApp(user, appName); err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+		fmt.Fprintf(w, "[%s] - run %s of cron\n", run, run)
+	}()
+	return r, nil
+}
+
+func (f *FakeOperations) PortForward(user *database.User, appName, podName string, port int32, t *Tunnel) error {
+	if !isValidPort(port) {
+		return ErrInvalidPort
+	}
+	if _, err := f.CheckPermAndGet(user, appName); err != nil {
+		return err
+	}
+
+	_, err := io.Copy(t.Out, t.In)
+	return err
 }
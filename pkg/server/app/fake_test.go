@@ -380,7 +380,7 @@ func TestFakeOperationsSetSecretFile(t *testing.T) {
 	app := &App{Name: "teresa"}
 	fake.Storage[app.Name] = app
 
-	if err := fake.SetSecretFile(user, app.Name, "test", nil); err != nil {
+	if err := fake.SetSecretFile(user, app.Name, "test", nil, ""); err != nil {
 		t.Fatal("error setting app secret file:", err)
 	}
 }
@@ -391,7 +391,7 @@ func TestFakeOperationsSetSecretFileErrPermissionDenied(t *testing.T) {
 	app := &App{Name: "teresa"}
 	fake.Storage[app.Name] = app
 
-	if err := fake.SetSecretFile(user, app.Name, "test", nil); err != auth.ErrPermissionDenied {
+	if err := fake.SetSecretFile(user, app.Name, "test", nil, ""); err != auth.ErrPermissionDenied {
 		t.Errorf("expected ErrPermissionDenied, got %v", err)
 	}
 }
@@ -400,7 +400,7 @@ func TestFakeOperationsSetSecretFileErrNotFound(t *testing.T) {
 	fake := NewFakeOperations()
 	user := &database.User{Name: "gopher@luizalabs.com"}
 
-	if err := fake.SetSecretFile(user, "teresa", "test", nil); err != ErrNotFound {
+	if err := fake.SetSecretFile(user, "teresa", "test", nil, ""); err != ErrNotFound {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
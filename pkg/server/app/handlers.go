@@ -1,6 +1,7 @@
 package app
 
 import (
+	"io"
 	"time"
 
 	context "golang.org/x/net/context"
@@ -9,7 +10,6 @@ import (
 
 	"github.com/luizalabs/teresa/pkg/goutil"
 	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
-	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
 )
 
@@ -35,11 +35,16 @@ func (s *Service) Logs(req *appb.LogsRequest, stream appb.App_LogsServer) error
 	ctx := stream.Context()
 	user := ctx.Value("user").(*database.User)
 	opts := &LogOptions{
-		Lines:     req.Lines,
-		Follow:    req.Follow,
-		PodName:   req.PodName,
-		Previous:  req.Previous,
-		Container: req.Container,
+		Lines:        req.Lines,
+		Follow:       req.Follow,
+		PodName:      req.PodName,
+		Previous:     req.Previous,
+		Container:    req.Container,
+		Process:      req.Process,
+		Grep:         req.Grep,
+		SinceSeconds: req.SinceSeconds,
+		Timestamps:   req.Timestamps,
+		Output:       req.Output,
 	}
 
 	rc, err := s.ops.Logs(user, req.Name, opts)
@@ -48,6 +53,16 @@ func (s *Service) Logs(req *appb.LogsRequest, stream appb.App_LogsServer) error
 	}
 	defer rc.Close()
 
+	return sendLogs(rc, stream)
+}
+
+type logsSender interface {
+	Send(*appb.LogsResponse) error
+}
+
+// sendLogs sends the lines of rc to the stream, a separator is sent from
+// time to time to keep the stream alive when there are no new lines
+func sendLogs(rc io.Reader, stream logsSender) error {
 	chLogs, errCh := goutil.LineGenerator(rc)
 	var line string
 
@@ -70,6 +85,25 @@ func (s *Service) Logs(req *appb.LogsRequest, stream appb.App_LogsServer) error
 	}
 }
 
+func (s *Service) Events(req *appb.EventsRequest, stream appb.App_EventsServer) error {
+	ctx := stream.Context()
+	user := ctx.Value("user").(*database.User)
+	opts := &EventOptions{Follow: req.Follow}
+
+	evs, err := s.ops.Events(user, req.Name, opts, ctx.Done())
+	if err != nil {
+		return err
+	}
+
+	for ev := range evs {
+		if err := stream.Send(newEventsResponse(ev)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) Info(ctx context.Context, req *appb.InfoRequest) (*appb.InfoResponse, error) {
 	user := ctx.Value("user").(*database.User)
 
@@ -81,6 +115,28 @@ func (s *Service) Info(ctx context.Context, req *appb.InfoRequest) (*appb.InfoRe
 	return newInfoResponse(info), nil
 }
 
+func (s *Service) Status(ctx context.Context, req *appb.StatusRequest) (*appb.StatusResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	pods, err := s.ops.Status(user, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatusResponse(pods), nil
+}
+
+func (s *Service) Top(ctx context.Context, req *appb.TopRequest) (*appb.TopResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	pods, err := s.ops.Top(user, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTopResponse(pods), nil
+}
+
 func (s *Service) SetEnv(ctx context.Context, req *appb.SetEnvRequest) (*appb.Empty, error) {
 	user := ctx.Value("user").(*database.User)
 	evs := newEnvVars(req.EnvVars)
@@ -102,12 +158,33 @@ func (s *Service) UnsetEnv(ctx context.Context, req *appb.UnsetEnvRequest) (*app
 	return &appb.Empty{}, nil
 }
 
+func (s *Service) SetBuildEnv(ctx context.Context, req *appb.SetEnvRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	evs := newEnvVars(req.EnvVars)
+
+	if err := s.ops.SetBuildEnv(user, req.Name, evs); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UnsetBuildEnv(ctx context.Context, req *appb.UnsetEnvRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.UnsetBuildEnv(user, req.Name, req.EnvVars); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
 func (s *Service) SetSecret(ctx context.Context, req *appb.SetSecretRequest) (*appb.Empty, error) {
 	user := ctx.Value("user").(*database.User)
 
 	var err error
 	if sf := req.GetSecretFile(); sf != nil {
-		err = s.ops.SetSecretFile(user, req.Name, sf.Key, sf.Content)
+		err = s.ops.SetSecretFile(user, req.Name, sf.Key, sf.Content, sf.MountPath)
 	} else {
 		err = s.ops.SetSecret(user, req.Name, newEnvVars(req.SecretEnvs))
 	}
@@ -170,6 +247,366 @@ func (s *Service) SetReplicas(ctx context.Context, req *appb.SetReplicasRequest)
 	return &appb.Empty{}, nil
 }
 
+func (s *Service) Scale(ctx context.Context, req *appb.ScaleRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Scale(user, req.Name, req.Replicas); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Pause(ctx context.Context, req *appb.PauseRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Pause(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Resume(ctx context.Context, req *appb.ResumeRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Resume(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Restart(ctx context.Context, req *appb.RestartRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Restart(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetResources(ctx context.Context, req *appb.SetResourcesRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetResources(user, req.Name, newResources(req)); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) EnvHistory(ctx context.Context, req *appb.EnvHistoryRequest) (*appb.EnvHistoryResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	revs, err := s.ops.EnvHistory(user, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEnvHistoryResponse(revs), nil
+}
+
+func (s *Service) EnvRollback(ctx context.Context, req *appb.EnvRollbackRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.EnvRollback(user, req.Name, req.Version); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetMaintenance(ctx context.Context, req *appb.SetMaintenanceRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetMaintenance(user, req.Name, req.Enabled); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) EnableTLS(ctx context.Context, req *appb.EnableTLSRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.EnableTLS(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UpdateIngress(ctx context.Context, req *appb.UpdateIngressRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	opts := make(map[string]string, len(req.Options))
+	for _, opt := range req.Options {
+		opts[opt.Key] = opt.Value
+	}
+	if err := s.ops.SetIngressOptions(user, req.Name, opts); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetACL(ctx context.Context, req *appb.SetACLRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetACL(user, req.Name, req.Allow); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetAutoscaleSchedule(ctx context.Context, req *appb.SetAutoscaleScheduleRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	windows := newScaleWindows(req.Windows)
+
+	if err := s.ops.SetAutoscaleSchedule(user, req.Name, windows); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetPDB(ctx context.Context, req *appb.SetPDBRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetPDB(user, req.Name, req.MinAvailable); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) AddVolume(ctx context.Context, req *appb.AddVolumeRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.AddVolume(user, req.Name, newVolumeClaim(req.Volume)); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) RemoveVolume(ctx context.Context, req *appb.RemoveVolumeRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.RemoveVolume(user, req.Name, req.VolumeName); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetConfigFile(ctx context.Context, req *appb.SetConfigFileRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetConfigFile(user, req.Name, req.FileName, req.Content, req.MountPath); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UnsetConfigFile(ctx context.Context, req *appb.UnsetConfigFileRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.UnsetConfigFile(user, req.Name, req.FileName); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UpdateLifecycle(ctx context.Context, req *appb.UpdateLifecycleRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	lc := &Lifecycle{
+		DrainTimeoutSeconds:           req.DrainTimeoutSeconds,
+		TerminationGracePeriodSeconds: req.TerminationGracePeriodSeconds,
+	}
+	if err := s.ops.SetLifecycle(user, req.Name, lc); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UpdateStrategy(ctx context.Context, req *appb.UpdateStrategyRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	ru := &RollingUpdate{
+		MaxSurge:       req.MaxSurge,
+		MaxUnavailable: req.MaxUnavailable,
+	}
+	if err := s.ops.SetRollingUpdate(user, req.Name, ru); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) SetBuilder(ctx context.Context, req *appb.SetBuilderRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetBuilder(user, req.Name, req.Builder); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Lock(ctx context.Context, req *appb.LockRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Lock(user, req.Name, req.Reason); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Unlock(ctx context.Context, req *appb.UnlockRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Unlock(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) UpdateRolloutTimeout(ctx context.Context, req *appb.UpdateRolloutTimeoutRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.SetRolloutTimeout(user, req.Name, req.TimeoutSeconds); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) CronList(ctx context.Context, req *appb.CronRequest) (*appb.CronListResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	crons, err := s.ops.CronList(user, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCronListResponse(crons), nil
+}
+
+func (s *Service) CronSuspend(ctx context.Context, req *appb.CronRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.CronSuspend(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) CronResume(ctx context.Context, req *appb.CronRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.CronResume(user, req.Name); err != nil {
+		return nil, err
+	}
+
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) CronRunNow(ctx context.Context, req *appb.CronRequest) (*appb.CronRunNowResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	jobName, err := s.ops.CronRunNow(user, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appb.CronRunNowResponse{JobName: jobName}, nil
+}
+
+func (s *Service) CronRuns(ctx context.Context, req *appb.CronRunsRequest) (*appb.CronRunsResponse, error) {
+	user := ctx.Value("user").(*database.User)
+
+	runs, err := s.ops.CronRuns(user, req.Name, req.Process)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCronRunsResponse(runs), nil
+}
+
+func (s *Service) CronRunLogs(req *appb.CronRunLogsRequest, stream appb.App_CronRunLogsServer) error {
+	ctx := stream.Context()
+	user := ctx.Value("user").(*database.User)
+	opts := &LogOptions{Lines: req.Lines, Follow: req.Follow}
+
+	rc, err := s.ops.CronRunLogs(user, req.Name, req.Process, req.Run, opts)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return sendLogs(rc, stream)
+}
+
+// portForwardWriter sends the data of the port of the pod to the stream
+type portForwardWriter struct {
+	stream appb.App_PortForwardServer
+}
+
+func (w *portForwardWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&appb.PortForwardResponse{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// PortForward forwards a connection of the client, the first message has
+// the pod and the port and the next ones the data sent by the client
+func (s *Service) PortForward(stream appb.App_PortForwardServer) error {
+	ctx := stream.Context()
+	user := ctx.Value("user").(*database.User)
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+	defer r.Close()
+	go func() {
+		if len(req.Data) > 0 {
+			if _, err := w.Write(req.Data); err != nil {
+				return
+			}
+		}
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			if _, err := w.Write(in.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	t := &Tunnel{In: r, Out: &portForwardWriter{stream: stream}, Done: ctx.Done()}
+	return s.ops.PortForward(user, req.Name, req.PodName, req.Port, t)
+}
+
 func (s *Service) DeletePods(ctx context.Context, req *appb.DeletePodsRequest) (*appb.Empty, error) {
 	user := ctx.Value("user").(*database.User)
 
@@ -182,10 +619,7 @@ func (s *Service) DeletePods(ctx context.Context, req *appb.DeletePodsRequest) (
 
 func (s *Service) ChangeTeam(ctx context.Context, req *appb.ChangeTeamRequest) (*appb.Empty, error) {
 	user := ctx.Value("user").(*database.User)
-	if !user.IsAdmin {
-		return nil, auth.ErrPermissionDenied
-	}
-	if err := s.ops.ChangeTeam(req.AppName, req.TeamName); err != nil {
+	if err := s.ops.TransferTeam(user, req.AppName, req.TeamName); err != nil {
 		return nil, err
 	}
 	return &appb.Empty{}, nil
@@ -199,6 +633,38 @@ func (s *Service) SetVHosts(ctx context.Context, req *appb.SetVHostsRequest) (*a
 	return &appb.Empty{}, nil
 }
 
+func (s *Service) AddVHost(ctx context.Context, req *appb.VHostRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	if err := s.ops.AddVHost(user, req.AppName, req.Vhost); err != nil {
+		return nil, err
+	}
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) RemoveVHost(ctx context.Context, req *appb.VHostRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	if err := s.ops.RemoveVHost(user, req.AppName, req.Vhost); err != nil {
+		return nil, err
+	}
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Rename(ctx context.Context, req *appb.RenameRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	if err := s.ops.Rename(user, req.OldName, req.NewName); err != nil {
+		return nil, err
+	}
+	return &appb.Empty{}, nil
+}
+
+func (s *Service) Clone(ctx context.Context, req *appb.CloneRequest) (*appb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+	if err := s.ops.Clone(user, req.SrcName, req.DstName, req.VirtualHost); err != nil {
+		return nil, err
+	}
+	return &appb.Empty{}, nil
+}
+
 func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	appb.RegisterAppServer(grpcServer, s)
 }
@@ -2,6 +2,8 @@ package app
 
 import (
 	"bytes"
+	"io"
+	"reflect"
 
 	context "golang.org/x/net/context"
 
@@ -28,6 +30,46 @@ func (lsw *LogsStreamWrapper) Send(msg *appb.LogsResponse) error {
 	return nil
 }
 
+type EventsStreamWrapper struct {
+	appb.App_EventsServer
+	ctx    context.Context
+	events []*appb.EventsResponse
+}
+
+func (esw *EventsStreamWrapper) Context() context.Context {
+	return esw.ctx
+}
+
+func (esw *EventsStreamWrapper) Send(msg *appb.EventsResponse) error {
+	esw.events = append(esw.events, msg)
+	return nil
+}
+
+type portForwardStreamWrapper struct {
+	appb.App_PortForwardServer
+	ctx  context.Context
+	reqs []*appb.PortForwardRequest
+	out  []byte
+}
+
+func (sw *portForwardStreamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *portForwardStreamWrapper) Recv() (*appb.PortForwardRequest, error) {
+	if len(sw.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := sw.reqs[0]
+	sw.reqs = sw.reqs[1:]
+	return req, nil
+}
+
+func (sw *portForwardStreamWrapper) Send(resp *appb.PortForwardResponse) error {
+	sw.out = append(sw.out, resp.Data...)
+	return nil
+}
+
 func TestCreateSuccess(t *testing.T) {
 	fake := NewFakeOperations()
 	user := &database.User{Email: "gopher@luizalabs.com"}
@@ -589,3 +631,1078 @@ func TestSetVHostsPermissionDenied(t *testing.T) {
 		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
 	}
 }
+
+func TestRenameSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.RenameRequest{OldName: name, NewName: "gopher"}
+	if _, err := s.Rename(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, found := fake.Storage["gopher"]; !found {
+		t.Error("expected app with the new name, got none")
+	}
+}
+
+func TestRenameAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.RenameRequest{OldName: "teresa", NewName: "gopher"}
+	if _, err := s.Rename(ctx, req); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestRenamePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.RenameRequest{OldName: name, NewName: "gopher"}
+	if _, err := s.Rename(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestCloneSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, VirtualHost: "teresa.io"}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.CloneRequest{SrcName: name, DstName: "gopher", VirtualHost: "gopher.io"}
+	if _, err := s.Clone(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	a, found := fake.Storage["gopher"]
+	if !found {
+		t.Fatal("expected cloned app, got none")
+	}
+	if a.VirtualHost != "gopher.io" {
+		t.Errorf("got vhost %s; want gopher.io", a.VirtualHost)
+	}
+	if fake.Storage[name].VirtualHost != "teresa.io" {
+		t.Errorf("expected source app untouched, got vhost %s", fake.Storage[name].VirtualHost)
+	}
+}
+
+func TestCloneAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.CloneRequest{SrcName: "teresa", DstName: "gopher"}
+	if _, err := s.Clone(ctx, req); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestClonePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.CloneRequest{SrcName: name, DstName: "gopher"}
+	if _, err := s.Clone(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestScaleSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, ProcessType: "web"}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.ScaleRequest{Name: name, Replicas: map[string]int32{"web": 5, "worker": 3}}
+	if _, err := s.Scale(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if n := fake.Storage[name].Processes["worker"]; n != 3 {
+		t.Errorf("got %d worker replicas; want 3", n)
+	}
+}
+
+func TestScaleAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.ScaleRequest{Name: "teresa", Replicas: map[string]int32{"worker": 1}}
+	if _, err := s.Scale(ctx, req); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestScalePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.ScaleRequest{Name: name, Replicas: map[string]int32{"worker": 1}}
+	if _, err := s.Scale(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestPauseSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Pause(ctx, &appb.PauseRequest{Name: name}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fake.Storage[name].Paused == nil {
+		t.Error("expected app to be paused, but it wasn't")
+	}
+}
+
+func TestPauseAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Pause(ctx, &appb.PauseRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestPausePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Pause(ctx, &appb.PauseRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestResumeSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, Paused: &PausedState{}}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Resume(ctx, &appb.ResumeRequest{Name: name}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fake.Storage[name].Paused != nil {
+		t.Error("expected app to be resumed, but it wasn't")
+	}
+}
+
+func TestResumeErrNotPaused(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Resume(ctx, &appb.ResumeRequest{Name: name}); err != ErrNotPaused {
+		t.Errorf("got %v; want %v", err, ErrNotPaused)
+	}
+}
+
+func TestResumePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, Paused: &PausedState{}}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Resume(ctx, &appb.ResumeRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestRestartSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Restart(ctx, &appb.RestartRequest{Name: name}); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestRestartAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Restart(ctx, &appb.RestartRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestRestartPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Restart(ctx, &appb.RestartRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestStatusSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Status(ctx, &appb.StatusRequest{Name: name}); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestStatusAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Status(ctx, &appb.StatusRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestStatusPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Status(ctx, &appb.StatusRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestEventsSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	wrap := &EventsStreamWrapper{ctx: ctx}
+	if err := s.Events(&appb.EventsRequest{Name: name}, wrap); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(wrap.events) != 1 {
+		t.Errorf("got %d events; want 1", len(wrap.events))
+	}
+}
+
+func TestEventsAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	wrap := &EventsStreamWrapper{ctx: ctx}
+	if err := s.Events(&appb.EventsRequest{Name: "teresa"}, wrap); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestEventsPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	wrap := &EventsStreamWrapper{ctx: ctx}
+	if err := s.Events(&appb.EventsRequest{Name: name}, wrap); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestTopSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Top(ctx, &appb.TopRequest{Name: name}); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestTopAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Top(ctx, &appb.TopRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestTopPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Top(ctx, &appb.TopRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestSetResourcesSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+	req := &appb.SetResourcesRequest{Name: name, Cpu: "500m", Memory: "512Mi"}
+
+	if _, err := s.SetResources(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	want := Resources{CPU: "500m", Memory: "512Mi"}
+	if got := fake.Storage[name].Resources; got == nil || *got != want {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestSetResourcesAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.SetResources(ctx, &appb.SetResourcesRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestSetResourcesPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.SetResources(ctx, &appb.SetResourcesRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestEnvHistorySuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, EnvVars: []*EnvVar{{Key: "FOO", Value: "bar"}}}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	resp, err := s.EnvHistory(ctx, &appb.EnvHistoryRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.Revisions) != 1 {
+		t.Fatalf("got %d revisions; want 1", len(resp.Revisions))
+	}
+	if evs := resp.Revisions[0].EnvVars; len(evs) != 1 || evs[0].Key != "FOO" {
+		t.Errorf("got %v; want FOO=bar", evs)
+	}
+}
+
+func TestEnvHistoryAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.EnvHistory(ctx, &appb.EnvHistoryRequest{Name: "teresa"}); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestEnvHistoryPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.EnvHistory(ctx, &appb.EnvHistoryRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestEnvRollbackSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.EnvRollback(ctx, &appb.EnvRollbackRequest{Name: name, Version: 1}); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestEnvRollbackRevisionNotFound(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.EnvRollback(ctx, &appb.EnvRollbackRequest{Name: name, Version: 42}); err != ErrEnvRevisionNotFound {
+		t.Errorf("got %v; want %v", err, ErrEnvRevisionNotFound)
+	}
+}
+
+func TestEnvRollbackPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.EnvRollback(ctx, &appb.EnvRollbackRequest{Name: name, Version: 1}); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestSetMaintenanceSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetMaintenanceRequest{Name: name, Enabled: true}
+	if _, err := s.SetMaintenance(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !fake.Storage[name].Maintenance {
+		t.Error("expected app to be in maintenance, but it wasn't")
+	}
+}
+
+func TestSetMaintenanceAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetMaintenanceRequest{Name: "teresa", Enabled: true}
+	if _, err := s.SetMaintenance(ctx, req); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestSetMaintenancePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetMaintenanceRequest{Name: name, Enabled: true}
+	if _, err := s.SetMaintenance(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestEnableTLSSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.EnableTLSRequest{Name: name}
+	if _, err := s.EnableTLS(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !fake.Storage[name].TLS {
+		t.Error("expected app with tls enabled, but it wasn't")
+	}
+}
+
+func TestEnableTLSAppNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.EnableTLSRequest{Name: "teresa"}
+	if _, err := s.EnableTLS(ctx, req); err != ErrNotFound {
+		t.Errorf("got %v; want %v", err, ErrNotFound)
+	}
+}
+
+func TestEnableTLSPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.EnableTLSRequest{Name: name}
+	if _, err := s.EnableTLS(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestUpdateIngressSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateIngressRequest{
+		Name: name,
+		Options: []*appb.UpdateIngressRequest_Option{
+			{Key: IngressOptionProxyBodySize, Value: "10m"},
+		},
+	}
+	if _, err := s.UpdateIngress(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if v := fake.Storage[name].IngressOptions[IngressOptionProxyBodySize]; v != "10m" {
+		t.Errorf("got %s; want 10m", v)
+	}
+}
+
+func TestUpdateIngressPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateIngressRequest{Name: name}
+	if _, err := s.UpdateIngress(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestSetACLSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetACLRequest{Name: name, Allow: []string{"10.0.0.0/8"}}
+	if _, err := s.SetACL(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fake.Storage[name].SourceRanges, req.Allow) {
+		t.Errorf("got %v; want %v", fake.Storage[name].SourceRanges, req.Allow)
+	}
+}
+
+func TestSetACLPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetACLRequest{Name: name, Allow: []string{"10.0.0.0/8"}}
+	if _, err := s.SetACL(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestSetAutoscaleScheduleSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetAutoscaleScheduleRequest{
+		Name: name,
+		Windows: []*appb.ScaleWindow{
+			{Start: "08:00", End: "20:00", Days: []string{"mon", "fri"}, Min: 10, Max: 20},
+		},
+	}
+	if _, err := s.SetAutoscaleSchedule(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	want := []*ScaleWindow{{Start: "08:00", End: "20:00", Days: []string{"mon", "fri"}, Min: 10, Max: 20}}
+	if got := fake.Storage[name].ScaleSchedule.Windows; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestSetAutoscaleSchedulePermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetAutoscaleScheduleRequest{Name: name}
+	if _, err := s.SetAutoscaleSchedule(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestSetBuildEnvSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetEnvRequest{
+		Name:    name,
+		EnvVars: []*appb.SetEnvRequest_EnvVar{{Key: "NPM_TOKEN", Value: "secret"}},
+	}
+	if _, err := s.SetBuildEnv(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	evs := fake.Storage[name].BuildEnvVars
+	if len(evs) != 1 || evs[0].Key != "NPM_TOKEN" {
+		t.Errorf("expected NPM_TOKEN, got %v", evs)
+	}
+	if len(fake.Storage[name].EnvVars) != 0 {
+		t.Errorf("expected no app env vars, got %v", fake.Storage[name].EnvVars)
+	}
+}
+
+func TestSetBuilderSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetBuilderRequest{Name: name, Builder: BuilderCNB}
+	if _, err := s.SetBuilder(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].Builder; got != BuilderCNB {
+		t.Errorf("got %s; want %s", got, BuilderCNB)
+	}
+}
+
+func TestLockAndUnlockSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.Lock(ctx, &appb.LockRequest{Name: name, Reason: "freeze"}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if l := fake.Locks[name]; l == nil || l.Reason != "freeze" {
+		t.Errorf("expected the app locked by freeze, got %v", l)
+	}
+
+	if _, err := s.Unlock(ctx, &appb.UnlockRequest{Name: name}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if l := fake.Locks[name]; l != nil {
+		t.Errorf("expected no lock, got %v", l)
+	}
+}
+
+func TestSetPDBSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetPDBRequest{Name: name, MinAvailable: "50%"}
+	if _, err := s.SetPDB(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].MinAvailable; got != "50%" {
+		t.Errorf("got %s; want 50%%", got)
+	}
+}
+
+func TestAddVolumeSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.AddVolumeRequest{
+		Name:   name,
+		Volume: &appb.VolumeClaim{Name: "data", Size: "1Gi", MountPath: "/data"},
+	}
+	if _, err := s.AddVolume(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].VolumeClaims; len(got) != 1 || got[0].Name != "data" {
+		t.Errorf("got %v; want volume data", got)
+	}
+}
+
+func TestRemoveVolumeSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{
+		Name:         name,
+		VolumeClaims: []*VolumeClaim{{Name: "data", Size: "1Gi", MountPath: "/data"}},
+	}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.RemoveVolumeRequest{Name: name, VolumeName: "data"}
+	if _, err := s.RemoveVolume(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].VolumeClaims; len(got) != 0 {
+		t.Errorf("got %v; want no volumes", got)
+	}
+}
+
+func TestSetConfigFileSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.SetConfigFileRequest{
+		Name:      name,
+		FileName:  "nginx.conf",
+		Content:   []byte("foo"),
+		MountPath: "/etc/nginx",
+	}
+	if _, err := s.SetConfigFile(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].ConfigFiles["nginx.conf"]; got != "/etc/nginx" {
+		t.Errorf("got %s; want /etc/nginx", got)
+	}
+}
+
+func TestUnsetConfigFileSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{
+		Name:        name,
+		ConfigFiles: map[string]string{"nginx.conf": "/etc/nginx"},
+	}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UnsetConfigFileRequest{Name: name, FileName: "nginx.conf"}
+	if _, err := s.UnsetConfigFile(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].ConfigFiles; len(got) != 0 {
+		t.Errorf("got %v; want no config files", got)
+	}
+}
+
+func TestUpdateLifecycleSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateLifecycleRequest{
+		Name:                          name,
+		DrainTimeoutSeconds:           60,
+		TerminationGracePeriodSeconds: 90,
+	}
+	if _, err := s.UpdateLifecycle(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := &Lifecycle{DrainTimeoutSeconds: 60, TerminationGracePeriodSeconds: 90}
+	if got := fake.Storage[name].Lifecycle; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v; want %v", got, expected)
+	}
+}
+
+func TestUpdateLifecycleInvalid(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateLifecycleRequest{Name: name, DrainTimeoutSeconds: 60}
+	if _, err := s.UpdateLifecycle(ctx, req); err != ErrInvalidLifecycle {
+		t.Errorf("got %v; want %v", err, ErrInvalidLifecycle)
+	}
+}
+
+func TestUpdateStrategySuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateStrategyRequest{Name: name, MaxSurge: "50%", MaxUnavailable: "0"}
+	if _, err := s.UpdateStrategy(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := &RollingUpdate{MaxSurge: "50%", MaxUnavailable: "0"}
+	if got := fake.Storage[name].RollingUpdate; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v; want %v", got, expected)
+	}
+}
+
+func TestUpdateStrategyInvalid(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateStrategyRequest{Name: name, MaxSurge: "0", MaxUnavailable: "0%"}
+	if _, err := s.UpdateStrategy(ctx, req); err != ErrInvalidRollingUpdate {
+		t.Errorf("got %v; want %v", err, ErrInvalidRollingUpdate)
+	}
+}
+
+func TestUpdateRolloutTimeoutSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateRolloutTimeoutRequest{Name: name, TimeoutSeconds: 900}
+	if _, err := s.UpdateRolloutTimeout(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := fake.Storage[name].RolloutTimeoutSeconds; got != 900 {
+		t.Errorf("got %d; want 900", got)
+	}
+}
+
+func TestUpdateRolloutTimeoutInvalid(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.UpdateRolloutTimeoutRequest{Name: name, TimeoutSeconds: 7200}
+	if _, err := s.UpdateRolloutTimeout(ctx, req); err != ErrInvalidRolloutTimeout {
+		t.Errorf("got %v; want %v", err, ErrInvalidRolloutTimeout)
+	}
+}
+
+func TestAddVHostSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, VirtualHost: "teresa.luizalabs.com"}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.VHostRequest{AppName: name, Vhost: "teresa.io"}
+	if _, err := s.AddVHost(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := "teresa.luizalabs.com,teresa.io"; fake.Storage[name].VirtualHost != expected {
+		t.Errorf("got %s; want %s", fake.Storage[name].VirtualHost, expected)
+	}
+}
+
+func TestAddVHostPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.VHostRequest{AppName: name, Vhost: "teresa.io"}
+	if _, err := s.AddVHost(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("got %v; want %v", err, auth.ErrPermissionDenied)
+	}
+}
+
+func TestRemoveVHostSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, VirtualHost: "teresa.luizalabs.com,teresa.io"}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.VHostRequest{AppName: name, Vhost: "teresa.io"}
+	if _, err := s.RemoveVHost(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := "teresa.luizalabs.com"; fake.Storage[name].VirtualHost != expected {
+		t.Errorf("got %s; want %s", fake.Storage[name].VirtualHost, expected)
+	}
+}
+
+func TestRemoveVHostNotFound(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	req := &appb.VHostRequest{AppName: name, Vhost: "teresa.io"}
+	if _, err := s.RemoveVHost(ctx, req); err != ErrVHostNotFound {
+		t.Errorf("got %v; want %v", err, ErrVHostNotFound)
+	}
+}
+
+func TestCronListAndRunNowSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, ProcessType: ProcessTypeCronPrefix}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	resp, err := s.CronList(ctx, &appb.CronRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.Crons) != 1 || resp.Crons[0].Name != name {
+		t.Errorf("expected the cronjob %s, got %v", name, resp.Crons)
+	}
+
+	run, err := s.CronRunNow(ctx, &appb.CronRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if run.JobName == "" {
+		t.Error("expected the name of the job, got blank")
+	}
+}
+
+func TestCronSuspendNotCronJob(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.Storage[name] = &App{Name: name, ProcessType: "web"}
+	s := NewService(fake)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := s.CronSuspend(ctx, &appb.CronRequest{Name: name}); err != ErrNotCronJob {
+		t.Errorf("expected ErrNotCronJob, got %v", err)
+	}
+}
+
+func TestPortForwardSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	fake.Storage["teresa"] = &App{Name: "teresa"}
+	s := NewService(fake)
+
+	ctx := context.WithValue(context.Background(), "user", user)
+	wrap := &portForwardStreamWrapper{
+		ctx: ctx,
+		reqs: []*appb.PortForwardRequest{
+			{Name: "teresa", Port: 8080, Data: []byte("ping ")},
+			{Data: []byte("pong")},
+		},
+	}
+	if err := s.PortForward(wrap); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if string(wrap.out) != "ping pong" {
+		t.Errorf("expected the data forwarded, got %q", wrap.out)
+	}
+}
+
+func TestPortForwardInvalidPort(t *testing.T) {
+	fake := NewFakeOperations()
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	fake.Storage["teresa"] = &App{Name: "teresa"}
+	s := NewService(fake)
+
+	ctx := context.WithValue(context.Background(), "user", user)
+	wrap := &portForwardStreamWrapper{
+		ctx:  ctx,
+		reqs: []*appb.PortForwardRequest{{Name: "teresa", Port: 0}},
+	}
+	if err := s.PortForward(wrap); err != ErrInvalidPort {
+		t.Errorf("expected ErrInvalidPort, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"regexp"
+	"strconv"
+)
+
+const (
+	IngressOptionClass               = "class"
+	IngressOptionProxyBodySize       = "proxy-body-size"
+	IngressOptionProxyConnectTimeout = "proxy-connect-timeout"
+	IngressOptionProxyReadTimeout    = "proxy-read-timeout"
+	IngressOptionProxySendTimeout    = "proxy-send-timeout"
+	IngressOptionStickySessions      = "sticky-sessions"
+)
+
+var (
+	ingressClassRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	bodySizeRegexp     = regexp.MustCompile(`^[0-9]+[kKmMgG]?$`)
+)
+
+// ingressOptionValidators are the ingress options an app can set, each with
+// the validation of its value
+var ingressOptionValidators = map[string]func(string) bool{
+	IngressOptionClass:               ingressClassRegexp.MatchString,
+	IngressOptionProxyBodySize:       bodySizeRegexp.MatchString,
+	IngressOptionProxyConnectTimeout: isValidTimeout,
+	IngressOptionProxyReadTimeout:    isValidTimeout,
+	IngressOptionProxySendTimeout:    isValidTimeout,
+	IngressOptionStickySessions:      isValidBool,
+}
+
+func isValidTimeout(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+func isValidBool(s string) bool {
+	_, err := strconv.ParseBool(s)
+	return err == nil
+}
+
+// validateIngressOptions checks that all options are known and allowed by
+// the cluster operator, blank values unset the option
+func validateIngressOptions(opts map[string]string, allowed []string) error {
+	for k, v := range opts {
+		isValid, found := ingressOptionValidators[k]
+		if !found {
+			return ErrInvalidIngressOption
+		}
+		if !hasString(allowed, k) {
+			return ErrIngressOptionNotAllowed
+		}
+		if v != "" && !isValid(v) {
+			return ErrInvalidIngressOption
+		}
+	}
+	return nil
+}
+
+// mergeIngressOptions sets the options in the App, removing the blank ones
+func mergeIngressOptions(a *App, opts map[string]string) {
+	if a.IngressOptions == nil {
+		a.IngressOptions = make(map[string]string)
+	}
+	for k, v := range opts {
+		if v == "" {
+			delete(a.IngressOptions, k)
+		} else {
+			a.IngressOptions[k] = v
+		}
+	}
+}
+
+func hasString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateIngressOptions(t *testing.T) {
+	allowed := []string{IngressOptionClass, IngressOptionProxyBodySize, IngressOptionProxyReadTimeout, IngressOptionStickySessions}
+	var testCases = []struct {
+		opts     map[string]string
+		expected error
+	}{
+		{map[string]string{IngressOptionClass: "nginx-internal"}, nil},
+		{map[string]string{IngressOptionProxyBodySize: "10m"}, nil},
+		{map[string]string{IngressOptionProxyBodySize: "1024"}, nil},
+		{map[string]string{IngressOptionProxyReadTimeout: "120"}, nil},
+		{map[string]string{IngressOptionStickySessions: "true"}, nil},
+		{map[string]string{IngressOptionClass: ""}, nil},
+		{map[string]string{IngressOptionClass: "Nginx_Internal"}, ErrInvalidIngressOption},
+		{map[string]string{IngressOptionProxyBodySize: "10 megabytes"}, ErrInvalidIngressOption},
+		{map[string]string{IngressOptionProxyReadTimeout: "-1"}, ErrInvalidIngressOption},
+		{map[string]string{IngressOptionStickySessions: "maybe"}, ErrInvalidIngressOption},
+		{map[string]string{"server-snippet": "return 500;"}, ErrInvalidIngressOption},
+		{map[string]string{IngressOptionProxySendTimeout: "120"}, ErrIngressOptionNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		if err := validateIngressOptions(tc.opts, allowed); err != tc.expected {
+			t.Errorf("expected %v, got %v for %v", tc.expected, err, tc.opts)
+		}
+	}
+}
+
+func TestMergeIngressOptions(t *testing.T) {
+	a := &App{IngressOptions: map[string]string{
+		IngressOptionClass:         "nginx",
+		IngressOptionProxyBodySize: "1m",
+	}}
+	mergeIngressOptions(a, map[string]string{
+		IngressOptionClass:          "",
+		IngressOptionProxyBodySize:  "10m",
+		IngressOptionStickySessions: "true",
+	})
+
+	expected := map[string]string{
+		IngressOptionProxyBodySize:  "10m",
+		IngressOptionStickySessions: "true",
+	}
+	if !reflect.DeepEqual(a.IngressOptions, expected) {
+		t.Errorf("expected %v, got %v", expected, a.IngressOptions)
+	}
+}
@@ -0,0 +1,31 @@
+package app
+
+const (
+	// DefaultTerminationGracePeriodSeconds is the one used by Kubernetes
+	DefaultTerminationGracePeriodSeconds = 30
+	MaxTerminationGracePeriodSeconds     = 3600
+)
+
+// Lifecycle controls the shutdown of the pods of the App, the preStop hook
+// waits DrainTimeoutSeconds before the SIGTERM and the pods are killed
+// TerminationGracePeriodSeconds after the shutdown starts. Blank
+// TerminationGracePeriodSeconds uses the default of Kubernetes
+type Lifecycle struct {
+	DrainTimeoutSeconds           int32 `json:"drainTimeoutSeconds"`
+	TerminationGracePeriodSeconds int32 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// validateLifecycle checks that the drain fits in the grace period
+func validateLifecycle(lc *Lifecycle) error {
+	grace := lc.TerminationGracePeriodSeconds
+	if grace < 0 || grace > MaxTerminationGracePeriodSeconds {
+		return ErrInvalidLifecycle
+	}
+	if grace == 0 {
+		grace = DefaultTerminationGracePeriodSeconds
+	}
+	if lc.DrainTimeoutSeconds < 0 || lc.DrainTimeoutSeconds > grace {
+		return ErrInvalidLifecycle
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidateLifecycle(t *testing.T) {
+	var testCases = []struct {
+		lc       *Lifecycle
+		expected error
+	}{
+		{&Lifecycle{}, nil},
+		{&Lifecycle{DrainTimeoutSeconds: 30}, nil},
+		{&Lifecycle{DrainTimeoutSeconds: 120, TerminationGracePeriodSeconds: 180}, nil},
+		{&Lifecycle{DrainTimeoutSeconds: 31}, ErrInvalidLifecycle},
+		{&Lifecycle{DrainTimeoutSeconds: 60, TerminationGracePeriodSeconds: 45}, ErrInvalidLifecycle},
+		{&Lifecycle{DrainTimeoutSeconds: -1}, ErrInvalidLifecycle},
+		{&Lifecycle{TerminationGracePeriodSeconds: -1}, ErrInvalidLifecycle},
+		{&Lifecycle{TerminationGracePeriodSeconds: MaxTerminationGracePeriodSeconds + 1}, ErrInvalidLifecycle},
+	}
+
+	for _, tc := range testCases {
+		if got := validateLifecycle(tc.lc); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.lc)
+		}
+	}
+}
+
+func TestAppOperationsSetLifecycle(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	lc := &Lifecycle{DrainTimeoutSeconds: 60, TerminationGracePeriodSeconds: 90}
+
+	if err := ops.SetLifecycle(user, "teresa", lc); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fakeK8s.SetLifecycleNames, []string{"test"}) {
+		t.Errorf("expected [test], got %v", fakeK8s.SetLifecycleNames)
+	}
+}
+
+func TestAppOperationsSetLifecycleErrors(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetLifecycle(user, "teresa", &Lifecycle{DrainTimeoutSeconds: 40}); err != ErrInvalidLifecycle {
+		t.Errorf("expected ErrInvalidLifecycle, got %v", err)
+	}
+	if err := ops.SetLifecycle(user, "teresa", &Lifecycle{}); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.SetLifecycle(bad, "teresa", &Lifecycle{}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if len(fakeK8s.SetLifecycleNames) != 0 {
+		t.Errorf("expected no deploy updated, got %v", fakeK8s.SetLifecycleNames)
+	}
+}
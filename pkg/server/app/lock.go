@@ -0,0 +1,158 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/pkg/errors"
+)
+
+const maxLockReasonLength = 255
+
+// Lock blocks the deploys of an App until it's unlocked, User is the email
+// of who locked it
+type Lock struct {
+	Reason string
+	User   string
+}
+
+// AppLocks keeps the locks of the apps apart from the app itself, so a
+// concurrent save of the app can't drop or bring back a lock. Lock returns
+// false if the app is already locked and Unlock if it isn't locked
+type AppLocks interface {
+	Lock(appName, reason, user string) (bool, error)
+	Unlock(appName string) (bool, error)
+	Get(appName string) (*database.AppLock, error)
+	Delete(appName string) error
+	Rename(oldName, newName string) error
+}
+
+type DatabaseAppLocks struct {
+	DB *gorm.DB
+}
+
+func (l *DatabaseAppLocks) Lock(appName, reason, user string) (bool, error) {
+	lock := &database.AppLock{AppName: appName, Reason: reason, User: user}
+	createErr := l.DB.Create(lock).Error
+	if createErr == nil {
+		return true, nil
+	}
+
+	holder, err := l.Get(appName)
+	if err != nil {
+		return false, err
+	}
+	if holder == nil {
+		return false, errors.Wrap(createErr, fmt.Sprintf("locking %s", appName))
+	}
+	return false, nil
+}
+
+func (l *DatabaseAppLocks) Unlock(appName string) (bool, error) {
+	q := l.DB.Where(&database.AppLock{AppName: appName}).Delete(database.AppLock{})
+	if q.Error != nil {
+		return false, errors.Wrap(q.Error, fmt.Sprintf("unlocking %s", appName))
+	}
+	return q.RowsAffected > 0, nil
+}
+
+// Get returns the lock of the app, nil if it isn't locked
+func (l *DatabaseAppLocks) Get(appName string) (*database.AppLock, error) {
+	lock := new(database.AppLock)
+	q := l.DB.Where(&database.AppLock{AppName: appName}).First(lock)
+	if q.RecordNotFound() {
+		return nil, nil
+	}
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, fmt.Sprintf("getting lock of %s", appName))
+	}
+	return lock, nil
+}
+
+// Delete removes the lock of a deleted app, a new app with the same name
+// starts unlocked
+func (l *DatabaseAppLocks) Delete(appName string) error {
+	if _, err := l.Unlock(appName); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Rename moves the lock of a renamed app to its new name
+func (l *DatabaseAppLocks) Rename(oldName, newName string) error {
+	err := l.DB.Model(&database.AppLock{}).
+		Where("app_name = ?", oldName).
+		Update("app_name", newName).
+		Error
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("renaming lock of %s", oldName))
+	}
+	return nil
+}
+
+func NewDatabaseAppLocks(db *gorm.DB) AppLocks {
+	return &DatabaseAppLocks{DB: db}
+}
+
+func (ops *AppOperations) SetAppLocks(l AppLocks) {
+	ops.locks = l
+}
+
+// appLock returns the lock of the App, nil if it isn't locked
+func (ops *AppOperations) appLock(appName string) (*Lock, error) {
+	if ops.locks == nil {
+		return nil, nil
+	}
+	l, err := ops.locks.Get(appName)
+	if err != nil || l == nil {
+		return nil, err
+	}
+	return &Lock{Reason: l.Reason, User: l.User}, nil
+}
+
+// Lock blocks the deploys of the App, admins can still force them
+func (ops *AppOperations) Lock(user *database.User, appName, reason string) error {
+	if len(reason) > maxLockReasonLength {
+		return ErrInvalidLockReason
+	}
+	if ops.locks == nil {
+		return ErrLocksNotAvailable
+	}
+
+	if _, err := ops.CheckPermAndGet(user, appName); err != nil {
+		return err
+	}
+
+	locked, err := ops.locks.Lock(appName, reason, user.Email)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !locked {
+		return ErrAlreadyLocked
+	}
+
+	return nil
+}
+
+// Unlock lets the App be deployed again
+func (ops *AppOperations) Unlock(user *database.User, appName string) error {
+	if ops.locks == nil {
+		return ErrLocksNotAvailable
+	}
+
+	if _, err := ops.CheckPermAndGet(user, appName); err != nil {
+		return err
+	}
+
+	unlocked, err := ops.locks.Unlock(appName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !unlocked {
+		return ErrNotLocked
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func newLockTestOps(t *testing.T) (*gorm.DB, Operations, *database.User) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	ops.SetAppLocks(NewDatabaseAppLocks(db))
+	return db, ops, user
+}
+
+func TestAppOperationsLock(t *testing.T) {
+	db, ops, user := newLockTestOps(t)
+	defer db.Close()
+
+	if err := ops.Lock(user, "teresa", "holiday freeze"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	l, err := NewDatabaseAppLocks(db).Get("teresa")
+	if err != nil {
+		t.Fatal("error getting lock:", err)
+	}
+	if l == nil || l.Reason != "holiday freeze" || l.User != user.Email {
+		t.Errorf("expected the lock of %s, got %v", user.Email, l)
+	}
+}
+
+func TestAppOperationsLockErrors(t *testing.T) {
+	db, ops, user := newLockTestOps(t)
+	defer db.Close()
+
+	if err := ops.Lock(user, "teresa", "freeze"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if err := ops.Lock(user, "teresa", "freeze"); err != ErrAlreadyLocked {
+		t.Errorf("expected ErrAlreadyLocked, got %v", err)
+	}
+	if err := ops.Lock(user, "teresa", strings.Repeat("a", 256)); err != ErrInvalidLockReason {
+		t.Errorf("expected ErrInvalidLockReason, got %v", err)
+	}
+
+	ops = NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil)
+	if err := ops.Lock(user, "teresa", "freeze"); err != ErrLocksNotAvailable {
+		t.Errorf("expected ErrLocksNotAvailable, got %v", err)
+	}
+	ops.SetAppLocks(NewDatabaseAppLocks(db))
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.Lock(bad, "teresa", "freeze"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsUnlock(t *testing.T) {
+	db, ops, user := newLockTestOps(t)
+	defer db.Close()
+
+	if err := ops.Lock(user, "teresa", "freeze"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if err := ops.Unlock(user, "teresa"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if err := ops.Unlock(user, "teresa"); err != ErrNotLocked {
+		t.Errorf("expected ErrNotLocked, got %v", err)
+	}
+}
+
+func TestDatabaseAppLocksRename(t *testing.T) {
+	db, _, _ := newLockTestOps(t)
+	defer db.Close()
+	l := NewDatabaseAppLocks(db)
+
+	if _, err := l.Lock("teresa", "freeze", "gopher@luizalabs.com"); err != nil {
+		t.Fatal("error locking:", err)
+	}
+	if err := l.Rename("teresa", "new-teresa"); err != nil {
+		t.Fatal("error renaming lock:", err)
+	}
+	if lock, _ := l.Get("teresa"); lock != nil {
+		t.Errorf("expected the old name unlocked, got %v", lock)
+	}
+	if lock, _ := l.Get("new-teresa"); lock == nil {
+		t.Error("expected the new name locked")
+	}
+	if err := l.Delete("new-teresa"); err != nil {
+		t.Fatal("error deleting lock:", err)
+	}
+	if lock, _ := l.Get("new-teresa"); lock != nil {
+		t.Errorf("expected the deleted app unlocked, got %v", lock)
+	}
+}
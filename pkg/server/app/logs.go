@@ -0,0 +1,436 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/logstore"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// logsPodDiscoveryInterval is how often the pods are listed while following
+// the logs, to attach to the new ones
+var logsPodDiscoveryInterval = 5 * time.Second
+
+const podStateRunning = "Running"
+
+const (
+	LogOutputText = "text"
+	LogOutputJSON = "json"
+)
+
+// LogRecord is a line of the logs on the JSON output
+type LogRecord struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	App       string `json:"app"`
+	Pod       string `json:"pod"`
+	Process   string `json:"process,omitempty"`
+	Line      string `json:"line"`
+}
+
+func (ops *AppOperations) Logs(user *database.User, appName string, opts *LogOptions) (io.ReadCloser, error) {
+	a, err := ops.CheckRoleAndGet(user, appName, team.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	listOpts := &PodListOptions{PodName: opts.PodName}
+	if opts.Process != "" {
+		if err := setProcessPodListOptions(a, opts.Process, listOpts); err != nil {
+			return nil, err
+		}
+	}
+	if opts.SinceSeconds < 0 {
+		return nil, ErrInvalidLogSince
+	}
+	switch opts.Output {
+	case "", LogOutputText:
+	case LogOutputJSON:
+		// the timestamp is a field of the records
+		opts.Timestamps = true
+	default:
+		return nil, ErrInvalidLogOutput
+	}
+	filter, err := logFilter(opts.Grep)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Container == "" {
+		opts.Container = appName
+	}
+	if ops.ls != nil && opts.SinceSeconds > 0 && !opts.Previous {
+		return ops.storeLogs(a, listOpts, opts, filter)
+	}
+
+	pods, err := ops.kops.PodList(appName, listOpts)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	if opts.Previous {
+		pods = restartedPods(pods)
+		if len(pods) == 0 {
+			return nil, ErrNoPreviousLogs
+		}
+	}
+
+	return ops.podsLogs(a, listOpts, pods, opts, filter, nil), nil
+}
+
+// storeLogs reads the logs since a time from the log store, which keeps
+// them after kubelet discarded them. On follow the pods are streamed from
+// the newest stored line on
+func (ops *AppOperations) storeLogs(a *App, listOpts *PodListOptions, opts *LogOptions, filter *regexp.Regexp) (io.ReadCloser, error) {
+	var entries []*logstore.Entry
+	if opts.Lines != 0 {
+		now := time.Now()
+		q := &logstore.Query{
+			Namespace: a.Name,
+			Container: opts.Container,
+			Since:     now.Add(-time.Duration(opts.SinceSeconds) * time.Second),
+			Until:     now,
+			Pod:       opts.PodName,
+			Grep:      opts.Grep,
+		}
+		if opts.Process != "" {
+			setProcessQuery(a, opts.Process, q)
+		}
+		if opts.Lines > 0 {
+			q.Limit = int(opts.Lines)
+		}
+		var err error
+		entries, err = ops.ls.Query(q)
+		if err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+		entries = storeEntriesOf(a, opts, entries)
+	}
+	if !opts.Follow {
+		return ops.podsLogs(a, listOpts, nil, opts, filter, entries), nil
+	}
+
+	pods, err := ops.kops.PodList(a.Name, listOpts)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	live := *opts
+	live.Lines = -1
+	if len(entries) > 0 {
+		live.SinceSeconds = int64(time.Since(entries[len(entries)-1].Time).Seconds()) + 1
+	}
+	return ops.podsLogs(a, listOpts, pods, &live, filter, entries), nil
+}
+
+// storeEntriesOf keeps the stored lines of the pod or the process type of
+// the options, the stores may match the names of the pods partially
+func storeEntriesOf(a *App, opts *LogOptions, entries []*logstore.Entry) []*logstore.Entry {
+	var kept []*logstore.Entry
+	for _, e := range entries {
+		if opts.PodName != "" && e.Pod != opts.PodName {
+			continue
+		}
+		if opts.Process != "" && podProcessType(a, e.Pod) != opts.Process {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
+
+// setProcessQuery selects the stored lines of the pods of the process type
+// by their names, the pods of an extra process type are named after its
+// Deployment and the main ones are the remaining pods
+func setProcessQuery(a *App, process string, q *logstore.Query) {
+	var prefix string
+	if process != a.ProcessType {
+		prefix = ProcessDeployName(a.Name, process) + "-"
+		q.PodPrefixes = []string{prefix}
+	}
+	for p := range a.Processes {
+		other := ProcessDeployName(a.Name, p) + "-"
+		// the longer names of other process types may start with the prefix
+		if p != process && strings.HasPrefix(other, prefix) {
+			q.ExcludedPodPrefixes = append(q.ExcludedPodPrefixes, other)
+		}
+	}
+	sort.Strings(q.ExcludedPodPrefixes)
+}
+
+// setProcessPodListOptions lists only the pods of the process type of the
+// App, the main one or one of the extra process types
+func setProcessPodListOptions(a *App, process string, opts *PodListOptions) error {
+	switch {
+	case process == a.ProcessType && IsCronJob(process):
+		opts.AnyJob = true
+	case process == a.ProcessType:
+		opts.DeployName = a.Name
+	default:
+		if _, found := a.Processes[process]; !found {
+			return ErrProcessTypeNotFound
+		}
+		opts.DeployName = ProcessDeployName(a.Name, process)
+	}
+	return nil
+}
+
+// logFilter compiles the expression of the lines to keep, nil keeps all
+// of them
+func logFilter(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, ErrInvalidLogFilter
+	}
+	return re, nil
+}
+
+// restartedPods returns the pods with a terminated container, the only
+// ones with logs of a previous container
+func restartedPods(pods []*Pod) []*Pod {
+	var restarted []*Pod
+	for _, pod := range pods {
+		if pod.Restarts > 0 {
+			restarted = append(restarted, pod)
+		}
+	}
+	return restarted
+}
+
+// logsStream is the merged logs of the pods, closing it stops the streams
+// of the pods and the discovery of the new ones
+type logsStream struct {
+	*io.PipeReader
+	done chan struct{}
+	once sync.Once
+}
+
+func (s *logsStream) Close() error {
+	s.once.Do(func() { close(s.done) })
+	return s.PipeReader.Close()
+}
+
+// podsLogsMerger writes the lines of the logs of the pods, each line
+// prefixed by the name of its pod. With a filter only the matching lines
+// are kept
+type podsLogsMerger struct {
+	ops       *AppOperations
+	app       *App
+	namespace string
+	opts      *LogOptions
+	filter    *regexp.Regexp
+	w         io.Writer
+	done      <-chan struct{}
+	wg        sync.WaitGroup
+
+	mu sync.Mutex
+	// attached are the pods being streamed and ended when the stream of
+	// the other ones ended
+	attached map[string]bool
+	ended    map[string]time.Time
+}
+
+// podsLogs merges the logs of the pods, after the stored ones if any. On
+// follow the pods are listed again from time to time and the new ones, like
+// the ones of a new deploy or a restarted container, are streamed as well
+// until the logs are closed
+func (ops *AppOperations) podsLogs(a *App, listOpts *PodListOptions, pods []*Pod, opts *LogOptions, filter *regexp.Regexp, stored []*logstore.Entry) io.ReadCloser {
+	r, w := io.Pipe()
+	s := &logsStream{PipeReader: r, done: make(chan struct{})}
+	m := &podsLogsMerger{
+		ops:       ops,
+		app:       a,
+		namespace: a.Name,
+		opts:      opts,
+		filter:    filter,
+		w:         w,
+		done:      s.done,
+		attached:  make(map[string]bool),
+		ended:     make(map[string]time.Time),
+	}
+	go func() {
+		for _, e := range stored {
+			if err := m.writeStored(e); err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+		for _, pod := range pods {
+			m.attach(pod.Name, opts)
+		}
+		if opts.Follow && !opts.Previous {
+			m.discover(listOpts)
+		}
+		m.wg.Wait()
+		w.Close()
+	}()
+
+	return s
+}
+
+func (m *podsLogsMerger) attach(podName string, opts *LogOptions) {
+	m.mu.Lock()
+	m.attached[podName] = true
+	m.mu.Unlock()
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.stream(podName, opts)
+
+		m.mu.Lock()
+		delete(m.attached, podName)
+		m.ended[podName] = time.Now()
+		m.mu.Unlock()
+	}()
+}
+
+func (m *podsLogsMerger) stream(podName string, opts *LogOptions) {
+	logs, err := m.ops.kops.PodLogs(m.namespace, podName, opts)
+	if err != nil {
+		log.WithError(err).Errorf("streaming logs from pod %s", podName)
+		return
+	}
+	defer logs.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-m.done:
+			logs.Close()
+		case <-stop:
+		}
+	}()
+
+	scanner := bufio.NewScanner(logs)
+	for scanner.Scan() {
+		if err := m.writeLine(podName, scanner.Text()); err != nil {
+			return
+		}
+	}
+	select {
+	case <-m.done:
+	default:
+		if err := scanner.Err(); err != nil {
+			log.WithError(err).Errorf("streaming logs from pod %s", podName)
+		}
+	}
+}
+
+// writeLine writes a line of the pod if it matches the filter, on the JSON
+// output as a record
+func (m *podsLogsMerger) writeLine(podName, line string) error {
+	if m.opts.Output != LogOutputJSON {
+		if m.filter != nil && !m.filter.MatchString(line) {
+			return nil
+		}
+		_, err := fmt.Fprintf(m.w, "[%s] - %s\n", podName, line)
+		return err
+	}
+
+	ts, line := splitLogTimestamp(line)
+	if m.filter != nil && !m.filter.MatchString(line) {
+		return nil
+	}
+	b, err := json.Marshal(&LogRecord{
+		Timestamp: ts,
+		App:       m.app.Name,
+		Pod:       podName,
+		Process:   podProcessType(m.app, podName),
+		Line:      line,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(m.w, "%s\n", b)
+	return err
+}
+
+// writeStored writes a line of the log store like the ones of the pods
+func (m *podsLogsMerger) writeStored(e *logstore.Entry) error {
+	line := e.Line
+	if m.opts.Timestamps {
+		line = fmt.Sprintf("%s %s", e.Time.UTC().Format(time.RFC3339Nano), line)
+	}
+	return m.writeLine(e.Pod, line)
+}
+
+// splitLogTimestamp splits the timestamp added by Kubernetes from the line
+func splitLogTimestamp(line string) (string, string) {
+	i := strings.Index(line, " ")
+	if i < 0 {
+		return "", line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, line[:i]); err != nil {
+		return "", line
+	}
+	return line[:i], line[i+1:]
+}
+
+// podProcessType returns the process type of a pod of the App by its name,
+// the pods of the Deployment of a extra process type are named after it
+func podProcessType(a *App, podName string) string {
+	pt := a.ProcessType
+	longest := 0
+	for p := range a.Processes {
+		prefix := ProcessDeployName(a.Name, p) + "-"
+		if strings.HasPrefix(podName, prefix) && len(prefix) > longest {
+			pt, longest = p, len(prefix)
+		}
+	}
+	return pt
+}
+
+// discover attaches to the running pods not streamed until the logs are
+// closed. A pod streamed before, like one whose container restarted, is
+// streamed since its last stream ended
+func (m *podsLogsMerger) discover(listOpts *PodListOptions) {
+	ticker := time.NewTicker(logsPodDiscoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+		}
+
+		pods, err := m.ops.kops.PodList(m.namespace, listOpts)
+		if err != nil {
+			log.WithError(err).Errorf("listing the pods of %s to follow the logs", m.namespace)
+			continue
+		}
+		for _, pod := range pods {
+			if pod.State != podStateRunning {
+				continue
+			}
+			m.mu.Lock()
+			attached := m.attached[pod.Name]
+			ended, seen := m.ended[pod.Name]
+			m.mu.Unlock()
+			if attached {
+				continue
+			}
+
+			opts := *m.opts
+			opts.Lines = -1
+			opts.SinceSeconds = 0
+			if seen {
+				opts.SinceSeconds = int64(time.Since(ended).Seconds()) + 1
+			}
+			m.attach(pod.Name, &opts)
+		}
+	}
+}
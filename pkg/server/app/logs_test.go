@@ -0,0 +1,266 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/logstore"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// recordLogsK8sOperations records the options of the streamed logs
+type recordLogsK8sOperations struct {
+	*fakeK8sOperations
+	mu   sync.Mutex
+	opts map[string][]LogOptions
+}
+
+func (f *recordLogsK8sOperations) PodLogs(namespace, podName string, opts *LogOptions) (io.ReadCloser, error) {
+	f.mu.Lock()
+	f.opts[podName] = append(f.opts[podName], *opts)
+	f.mu.Unlock()
+	return ioutil.NopCloser(bytes.NewBufferString("foo\nbar")), nil
+}
+
+func (f *recordLogsK8sOperations) calls(podName string) []LogOptions {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]LogOptions(nil), f.opts[podName]...)
+}
+
+func TestPodsLogsFollowAttachesToNewPods(t *testing.T) {
+	interval := logsPodDiscoveryInterval
+	logsPodDiscoveryInterval = 10 * time.Millisecond
+	defer func() { logsPodDiscoveryInterval = interval }()
+
+	fakeK8s := &recordLogsK8sOperations{fakeK8sOperations: &fakeK8sOperations{}, opts: make(map[string][]LogOptions)}
+	ops := NewOperations(team.NewFakeOperations(), fakeK8s, nil).(*AppOperations)
+	opts := &LogOptions{Lines: 10, Follow: true}
+	rc := ops.podsLogs(&App{Name: "teresa"}, new(PodListOptions), []*Pod{{Name: "pod 1"}}, opts, nil, nil)
+
+	scanner := bufio.NewScanner(rc)
+	found := false
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "[pod 2] - ") {
+			found = true
+			break
+		}
+	}
+	rc.Close()
+	if !found {
+		t.Fatal("expected the logs of the new pod")
+	}
+
+	if calls := fakeK8s.calls("pod 2"); calls[0].Lines != -1 || calls[0].SinceSeconds != 0 {
+		t.Errorf("expected all the lines of the new pod, got %+v", calls[0])
+	}
+	for {
+		calls := fakeK8s.calls("pod 1")
+		if len(calls) > 1 {
+			if calls[0].Lines != 10 || calls[1].SinceSeconds <= 0 {
+				t.Errorf("expected the pod streamed again since its stream ended, got %+v", calls)
+			}
+			break
+		}
+		time.Sleep(logsPodDiscoveryInterval)
+	}
+}
+
+func TestPodsLogsWithoutFollowEnds(t *testing.T) {
+	ops := NewOperations(team.NewFakeOperations(), &fakeK8sOperations{}, nil).(*AppOperations)
+	pods := []*Pod{{Name: "pod 1"}, {Name: "pod 2"}}
+	rc := ops.podsLogs(&App{Name: "teresa"}, new(PodListOptions), pods, &LogOptions{Lines: 10}, nil, nil)
+	defer rc.Close()
+
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatal("error on read logs:", err)
+	}
+	if n := strings.Count(string(b), "\n"); n != 4 {
+		t.Errorf("expected 4 lines, got %d", n)
+	}
+}
+
+func TestAppOperationsLogsJSON(t *testing.T) {
+	fakeK8s := &recordLogsK8sOperations{fakeK8sOperations: &fakeK8sOperations{}, opts: make(map[string][]LogOptions)}
+	ops, user := newConfigFileTestOps(fakeK8s.fakeK8sOperations)
+	ops.(*AppOperations).kops = fakeK8s
+
+	rc, err := ops.Logs(user, "teresa", &LogOptions{Lines: 10, Output: LogOutputJSON, Grep: "bar"})
+	if err != nil {
+		t.Fatal("error on get logs: ", err)
+	}
+	defer rc.Close()
+
+	scanner := bufio.NewScanner(rc)
+	count := 0
+	for scanner.Scan() {
+		var rec LogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("expected a JSON record, got %s", scanner.Text())
+		}
+		if rec.Line != "bar" || rec.App != "test" || rec.Process != "web" || !strings.HasPrefix(rec.Pod, "pod ") {
+			t.Errorf("expected the bar line of a web pod of the app, got %+v", rec)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("expected 2 records, got %d", count)
+	}
+	if calls := fakeK8s.calls("pod 1"); len(calls) != 1 || !calls[0].Timestamps {
+		t.Errorf("expected the logs with timestamps, got %+v", calls)
+	}
+
+	if _, err := ops.Logs(user, "teresa", &LogOptions{Output: "yaml"}); err != ErrInvalidLogOutput {
+		t.Errorf("expected ErrInvalidLogOutput, got %v", err)
+	}
+}
+
+func TestSplitLogTimestamp(t *testing.T) {
+	var testCases = []struct {
+		line      string
+		timestamp string
+		text      string
+	}{
+		{"2018-01-02T15:04:05.123456789Z GET / 200", "2018-01-02T15:04:05.123456789Z", "GET / 200"},
+		{"GET / 200", "", "GET / 200"},
+		{"starting", "", "starting"},
+	}
+
+	for _, tc := range testCases {
+		ts, text := splitLogTimestamp(tc.line)
+		if ts != tc.timestamp || text != tc.text {
+			t.Errorf("expected %q and %q, got %q and %q", tc.timestamp, tc.text, ts, text)
+		}
+	}
+}
+
+func TestPodProcessType(t *testing.T) {
+	a := &App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 1, "worker-high": 1},
+	}
+	var testCases = []struct {
+		podName  string
+		expected string
+	}{
+		{"teresa-5d8f7c9b4-x2x7q", "web"},
+		{"teresa-worker-5d8f7c9b4-x2x7q", "worker"},
+		{"teresa-worker-high-5d8f7c9b4-x2x7q", "worker-high"},
+	}
+
+	for _, tc := range testCases {
+		if actual := podProcessType(a, tc.podName); actual != tc.expected {
+			t.Errorf("expected %s, got %s [case: %s]", tc.expected, actual, tc.podName)
+		}
+	}
+}
+
+func TestAppOperationsLogsFromStore(t *testing.T) {
+	fakeK8s := &recordLogsK8sOperations{fakeK8sOperations: &fakeK8sOperations{}, opts: make(map[string][]LogOptions)}
+	ops, user := newConfigFileTestOps(fakeK8s.fakeK8sOperations)
+	ops.(*AppOperations).kops = fakeK8s
+	now := time.Now()
+	ls := logstore.NewFake(
+		&logstore.Entry{Time: now.Add(-2 * time.Hour), Pod: "teresa-1", Line: "old"},
+		&logstore.Entry{Time: now.Add(-time.Hour), Pod: "teresa-1", Line: "stored"},
+		&logstore.Entry{Time: now.Add(-time.Hour), Pod: "teresa-2", Line: "other pod"},
+	)
+	ops.SetLogStore(ls)
+
+	rc, err := ops.Logs(user, "teresa", &LogOptions{Lines: -1, SinceSeconds: 5400, PodName: "teresa-1"})
+	if err != nil {
+		t.Fatal("error on get logs: ", err)
+	}
+	b, err := ioutil.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal("error on read logs:", err)
+	}
+	if expected := "[teresa-1] - stored\n"; string(b) != expected {
+		t.Errorf("expected %q, got %q", expected, string(b))
+	}
+	if q := ls.Queries[0]; q.Namespace != "test" || q.Container != "teresa" || q.Pod != "teresa-1" || q.Limit != 0 {
+		t.Errorf("expected the query of the container of the app, got %+v", q)
+	}
+	if calls := fakeK8s.calls("pod 1"); len(calls) != 0 {
+		t.Errorf("expected no logs from kubelet, got %+v", calls)
+	}
+}
+
+func TestSetProcessQuery(t *testing.T) {
+	a := &App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 1, "worker-x": 1},
+	}
+	var testCases = []struct {
+		process          string
+		expectedPrefixes []string
+		expectedExcluded []string
+	}{
+		{"web", nil, []string{"teresa-worker-", "teresa-worker-x-"}},
+		{"worker", []string{"teresa-worker-"}, []string{"teresa-worker-x-"}},
+		{"worker-x", []string{"teresa-worker-x-"}, nil},
+	}
+	for _, tc := range testCases {
+		q := new(logstore.Query)
+		setProcessQuery(a, tc.process, q)
+		if !reflect.DeepEqual(q.PodPrefixes, tc.expectedPrefixes) || !reflect.DeepEqual(q.ExcludedPodPrefixes, tc.expectedExcluded) {
+			t.Errorf("expected %v and %v for %s, got %v and %v", tc.expectedPrefixes, tc.expectedExcluded, tc.process, q.PodPrefixes, q.ExcludedPodPrefixes)
+		}
+	}
+}
+
+func TestAppOperationsLogsFromStoreFollow(t *testing.T) {
+	fakeK8s := &recordLogsK8sOperations{fakeK8sOperations: &fakeK8sOperations{}, opts: make(map[string][]LogOptions)}
+	ops, user := newConfigFileTestOps(fakeK8s.fakeK8sOperations)
+	ops.(*AppOperations).kops = fakeK8s
+	ops.SetLogStore(logstore.NewFake(&logstore.Entry{Time: time.Now().Add(-time.Minute), Pod: "pod 1", Line: "stored"}))
+
+	rc, err := ops.Logs(user, "teresa", &LogOptions{Lines: -1, SinceSeconds: 3600, Follow: true})
+	if err != nil {
+		t.Fatal("error on get logs: ", err)
+	}
+	scanner := bufio.NewScanner(rc)
+	var lines []string
+	for len(lines) < 3 && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	rc.Close()
+
+	if len(lines) != 3 || lines[0] != "[pod 1] - stored" {
+		t.Fatalf("expected the stored line first, got %v", lines)
+	}
+	// the pod of the first live line
+	podName := strings.TrimPrefix(strings.SplitN(lines[1], "]", 2)[0], "[")
+	calls := fakeK8s.calls(podName)
+	if len(calls) == 0 {
+		t.Fatal("expected the pod streamed after the stored lines")
+	}
+	if calls[0].Lines != -1 || calls[0].SinceSeconds < 60 || calls[0].SinceSeconds > 120 {
+		t.Errorf("expected the pod streamed since the last stored line, got %+v", calls[0])
+	}
+}
+
+func TestAppOperationsLogsStoreError(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	ls := logstore.NewFake()
+	ls.Err = errors.New("test")
+	ops.SetLogStore(ls)
+
+	if _, err := ops.Logs(user, "teresa", &LogOptions{Lines: -1, SinceSeconds: 60}); teresa_errors.Get(err) != teresa_errors.ErrInternalServerError {
+		t.Errorf("expected internal server error, got %v", err)
+	}
+}
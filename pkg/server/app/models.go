@@ -1,11 +1,21 @@
 package app
 
-import appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+import (
+	"path"
+	"strings"
+
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+)
 
 const (
 	ProcessTypeWeb        = "web"
 	ProcessTypeCronPrefix = "cron"
 	defaultAppProtocol    = "http"
+	ProtocolH2C           = "h2c"
+	ProtocolGRPC          = "grpc"
+	TLSStatusPending      = "pending"
+	TLSStatusIssued       = "issued"
+	TLSStatusExpired      = "expired"
 )
 
 type LimitRangeQuantity struct {
@@ -19,9 +29,28 @@ type Limits struct {
 }
 
 type Autoscale struct {
-	CPUTargetUtilization int32
-	Max                  int32
-	Min                  int32
+	CPUTargetUtilization    int32
+	MemoryTargetUtilization int32
+	Max                     int32
+	Min                     int32
+	Metrics                 []*AutoscaleMetric
+}
+
+// AutoscaleMetric is a custom metric of the app pods (e.g. requests per
+// second served by a metrics adapter), the autoscaler keeps its average
+// over all pods near the target value
+type AutoscaleMetric struct {
+	Name               string
+	TargetAverageValue string
+}
+
+// Resources holds the compute resources of the app containers, blank
+// values are left to the namespace defaults
+type Resources struct {
+	CPU           string `json:"cpu,omitempty"`
+	Memory        string `json:"memory,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
 }
 
 type EnvVar struct {
@@ -30,17 +59,50 @@ type EnvVar struct {
 }
 
 type App struct {
-	Name        string     `json:"name"`
-	Team        string     `json:"-"`
-	ProcessType string     `json:"processType"`
-	VirtualHost string     `json:"virtualHost"`
-	Limits      *Limits    `json:"-"`
-	Autoscale   *Autoscale `json:"-"`
-	EnvVars     []*EnvVar  `json:"envVars"`
-	Internal    bool       `json:"internal"`
-	Secrets     []string   `json:"secrets"`
-	SecretFiles []string   `json:"secret_files"`
-	Protocol    string     `json:"protocol"`
+	Name             string            `json:"name"`
+	Team             string            `json:"-"`
+	ProcessType      string            `json:"processType"`
+	VirtualHost      string            `json:"virtualHost"`
+	Limits           *Limits           `json:"-"`
+	Autoscale        *Autoscale        `json:"-"`
+	EnvVars          []*EnvVar         `json:"envVars"`
+	Internal         bool              `json:"internal"`
+	Secrets          []string          `json:"secrets"`
+	SecretFiles      []string          `json:"secret_files"`
+	SecretFileMounts map[string]string `json:"secret_file_mounts,omitempty"`
+	Protocol         string            `json:"protocol"`
+	Processes        map[string]int32  `json:"processes,omitempty"`
+	Paused           *PausedState      `json:"paused,omitempty"`
+	Resources        *Resources        `json:"resources,omitempty"`
+	EnvGroups        []string          `json:"envGroups,omitempty"`
+	Maintenance      bool              `json:"maintenance,omitempty"`
+	TLS              bool              `json:"tls,omitempty"`
+	IngressOptions   map[string]string `json:"ingressOptions,omitempty"`
+	ScaleSchedule    *ScaleSchedule    `json:"scaleSchedule,omitempty"`
+	MinAvailable     string            `json:"minAvailable,omitempty"`
+	SourceRanges     []string          `json:"sourceRanges,omitempty"`
+	VolumeClaims     []*VolumeClaim    `json:"volumeClaims,omitempty"`
+	ConfigFiles      map[string]string `json:"configFiles,omitempty"`
+	Lifecycle        *Lifecycle        `json:"lifecycle,omitempty"`
+	RollingUpdate    *RollingUpdate    `json:"rollingUpdate,omitempty"`
+	Builder          string            `json:"builder,omitempty"`
+	BuildEnvVars     []*EnvVar         `json:"buildEnvVars,omitempty"`
+	// Cluster is the one the App was created on, blank for the cluster of
+	// the server
+	Cluster string `json:"cluster,omitempty"`
+
+	// RolloutTimeoutSeconds is the time the pods of a deploy have to get
+	// ready, zero uses the one of the cluster
+	RolloutTimeoutSeconds int32 `json:"rolloutTimeoutSeconds,omitempty"`
+
+	// TeamEnvVars are the default env vars of the team, set on deploys and
+	// never saved with the app
+	TeamEnvVars []*EnvVar `json:"-"`
+}
+
+type PausedState struct {
+	Replicas  map[string]int32 `json:"replicas"`
+	Autoscale *Autoscale       `json:"autoscale,omitempty"`
 }
 
 type Pod struct {
@@ -51,6 +113,29 @@ type Pod struct {
 	Ready    bool
 }
 
+type PodDetail struct {
+	Pod
+	Node                  string
+	LastTerminationReason string
+}
+
+type Event struct {
+	Type     string
+	Reason   string
+	Object   string
+	Message  string
+	Count    int32
+	LastSeen int64
+}
+
+// PodMetrics holds the current usage of a pod, CPU in millicores and
+// Memory in bytes
+type PodMetrics struct {
+	Name   string
+	CPU    int64
+	Memory int64
+}
+
 type Address struct {
 	Hostname string
 }
@@ -61,14 +146,41 @@ type Status struct {
 }
 
 type Info struct {
-	Team      string
-	Addresses []*Address
-	EnvVars   []*EnvVar
-	Status    *Status
-	Autoscale *Autoscale
-	Limits    *Limits
-	Protocol  string
-	Volumes   []string
+	Team          string
+	Addresses     []*Address
+	EnvVars       []*EnvVar
+	Status        *Status
+	Autoscale     *Autoscale
+	Limits        *Limits
+	Protocol      string
+	Volumes       []string
+	Maintenance   bool
+	TLS           string
+	SourceRanges  []string
+	ScaleWindows  []*ScaleWindow
+	MinAvailable  string
+	VolumeClaims  []*VolumeClaim
+	ConfigFiles   []*ConfigFile
+	Lifecycle     *Lifecycle
+	RollingUpdate *RollingUpdate
+	Deploy        *DeployMetadata
+	Builder       string
+	BuildEnvVars  []string
+	Lock          *Lock
+	Cluster       string
+
+	// RolloutTimeoutSeconds is the one set for the app, zero if it uses
+	// the one of the cluster
+	RolloutTimeoutSeconds int32
+}
+
+// DeployMetadata describes what the current deploy of an app is running,
+// the git fields are the ones given on deploy, if any
+type DeployMetadata struct {
+	Author  string
+	Commit  string
+	Branch  string
+	Message string
 }
 
 type AppListItem struct {
@@ -142,10 +254,17 @@ func newApp(req *appb.CreateRequest) *App {
 		EnvVars:     []*EnvVar{},
 		Internal:    req.Internal,
 		Protocol:    protocol,
+		Cluster:     req.Cluster,
 	}
 	return app
 }
 
+// IsHTTP2Protocol tells if the app serves cleartext HTTP/2 only, these
+// apps can't be probed by the kubelet with HTTP/1.1 requests
+func IsHTTP2Protocol(protocol string) bool {
+	return protocol == ProtocolH2C || protocol == ProtocolGRPC
+}
+
 func newInfoResponse(info *Info) *appb.InfoResponse {
 	if info == nil {
 		return nil
@@ -199,9 +318,16 @@ func newInfoResponse(info *Info) *appb.InfoResponse {
 	var as *appb.InfoResponse_Autoscale
 	if info.Autoscale != nil {
 		as = &appb.InfoResponse_Autoscale{
-			CpuTargetUtilization: info.Autoscale.CPUTargetUtilization,
-			Max:                  info.Autoscale.Max,
-			Min:                  info.Autoscale.Min,
+			CpuTargetUtilization:    info.Autoscale.CPUTargetUtilization,
+			MemoryTargetUtilization: info.Autoscale.MemoryTargetUtilization,
+			Max:                     info.Autoscale.Max,
+			Min:                     info.Autoscale.Min,
+		}
+		for _, m := range info.Autoscale.Metrics {
+			as.Metrics = append(as.Metrics, &appb.AutoscaleMetric{
+				Name:               m.Name,
+				TargetAverageValue: m.TargetAverageValue,
+			})
 		}
 	}
 
@@ -215,16 +341,116 @@ func newInfoResponse(info *Info) *appb.InfoResponse {
 		}
 	}
 
-	return &appb.InfoResponse{
-		Team:      info.Team,
-		Addresses: addrs,
-		EnvVars:   evs,
-		Status:    stat,
-		Autoscale: as,
-		Limits:    lim,
-		Protocol:  info.Protocol,
-		Volumes:   vols,
+	msg := &appb.InfoResponse{
+		Team:         info.Team,
+		Addresses:    addrs,
+		EnvVars:      evs,
+		Status:       stat,
+		Autoscale:    as,
+		Limits:       lim,
+		Protocol:     info.Protocol,
+		Volumes:      vols,
+		Maintenance:  info.Maintenance,
+		Tls:          info.TLS,
+		SourceRanges: info.SourceRanges,
+		ScaleWindows: newScaleWindowsMsg(info.ScaleWindows),
+		MinAvailable: info.MinAvailable,
+		VolumeClaims: newVolumeClaimsMsg(info.VolumeClaims),
+		ConfigFiles:  newConfigFilesMsg(info.ConfigFiles),
+		Builder:      info.Builder,
+		BuildEnvVars: info.BuildEnvVars,
+		Cluster:      info.Cluster,
+
+		RolloutTimeoutSeconds: info.RolloutTimeoutSeconds,
+	}
+	if l := info.Lock; l != nil {
+		msg.LockReason = l.Reason
+		msg.LockedBy = l.User
+	}
+	if lc := info.Lifecycle; lc != nil {
+		msg.DrainTimeoutSeconds = lc.DrainTimeoutSeconds
+		msg.TerminationGracePeriodSeconds = lc.TerminationGracePeriodSeconds
+	}
+	if ru := info.RollingUpdate; ru != nil {
+		msg.MaxSurge = ru.MaxSurge
+		msg.MaxUnavailable = ru.MaxUnavailable
+	}
+	if dm := info.Deploy; dm != nil {
+		msg.Deploy = &appb.DeployMetadata{
+			Author:  dm.Author,
+			Commit:  dm.Commit,
+			Branch:  dm.Branch,
+			Message: dm.Message,
+		}
+	}
+	return msg
+}
+
+func newScaleWindowsMsg(windows []*ScaleWindow) []*appb.ScaleWindow {
+	var msgs []*appb.ScaleWindow
+	for _, w := range windows {
+		msgs = append(msgs, &appb.ScaleWindow{
+			Start: w.Start,
+			End:   w.End,
+			Days:  w.Days,
+			Min:   w.Min,
+			Max:   w.Max,
+		})
 	}
+	return msgs
+}
+
+func newScaleWindows(msgs []*appb.ScaleWindow) []*ScaleWindow {
+	windows := make([]*ScaleWindow, len(msgs))
+	for i, m := range msgs {
+		windows[i] = &ScaleWindow{
+			Start: m.Start,
+			End:   m.End,
+			Days:  m.Days,
+			Min:   m.Min,
+			Max:   m.Max,
+		}
+	}
+	return windows
+}
+
+func newVolumeClaimsMsg(claims []*VolumeClaim) []*appb.VolumeClaim {
+	var msgs []*appb.VolumeClaim
+	for _, vc := range claims {
+		msgs = append(msgs, newVolumeClaimMsg(vc))
+	}
+	return msgs
+}
+
+func newVolumeClaimMsg(vc *VolumeClaim) *appb.VolumeClaim {
+	return &appb.VolumeClaim{
+		Name:         vc.Name,
+		Size:         vc.Size,
+		StorageClass: vc.StorageClass,
+		AccessMode:   vc.AccessMode,
+		MountPath:    vc.MountPath,
+	}
+}
+
+func newVolumeClaim(msg *appb.VolumeClaim) *VolumeClaim {
+	if msg == nil {
+		return new(VolumeClaim)
+	}
+	return &VolumeClaim{
+		Name:         msg.Name,
+		Size:         msg.Size,
+		StorageClass: msg.StorageClass,
+		AccessMode:   msg.AccessMode,
+		MountPath:    msg.MountPath,
+	}
+}
+
+func newConfigFilesMsg(files []*ConfigFile) []*appb.ConfigFile {
+	var msgs []*appb.ConfigFile
+	for _, cf := range files {
+		msgs = append(msgs, &appb.ConfigFile{Name: cf.Name, MountPath: cf.MountPath})
+	}
+	return msgs
 }
 
 func newEnvVars(evs []*appb.SetEnvRequest_EnvVar) []*EnvVar {
@@ -254,6 +480,59 @@ func setEnvVars(app *App, evs []*EnvVar) {
 	}
 }
 
+func hasEnvVar(evs []*EnvVar, key string) bool {
+	for _, ev := range evs {
+		if ev.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func appVHosts(app *App) []string {
+	if app.VirtualHost == "" {
+		return nil
+	}
+	return strings.Split(app.VirtualHost, ",")
+}
+
+func hasEnvGroup(app *App, group string) bool {
+	for _, g := range app.EnvGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvGroupsSecretNames returns the Secrets of the env groups attached to the App
+func EnvGroupsSecretNames(app *App) []string {
+	names := make([]string, len(app.EnvGroups))
+	for i, g := range app.EnvGroups {
+		names[i] = EnvGroupSecretName(g)
+	}
+	return names
+}
+
+// PodEnvVars returns the env vars of the pods of the App, the default env
+// vars of its team followed by its own, which take precedence
+func PodEnvVars(app *App) []*EnvVar {
+	if len(app.TeamEnvVars) == 0 {
+		return app.EnvVars
+	}
+	own := make(map[string]bool)
+	for _, ev := range app.EnvVars {
+		own[ev.Key] = true
+	}
+	evs := make([]*EnvVar, 0, len(app.TeamEnvVars)+len(app.EnvVars))
+	for _, ev := range app.TeamEnvVars {
+		if !own[ev.Key] {
+			evs = append(evs, ev)
+		}
+	}
+	return append(evs, app.EnvVars...)
+}
+
 func unsetEnvVars(app *App, evs []string) {
 	for _, ev := range evs {
 		for i, tmp := range app.EnvVars {
@@ -291,7 +570,15 @@ func unsetSecretsOnApp(app *App, secrets []string) {
 	}
 }
 
-func setSecretFileOnApp(app *App, name string) {
+func setSecretFileOnApp(app *App, name, mountPath string) {
+	if mountPath == "" {
+		delete(app.SecretFileMounts, name)
+	} else {
+		if app.SecretFileMounts == nil {
+			app.SecretFileMounts = make(map[string]string)
+		}
+		app.SecretFileMounts[name] = mountPath
+	}
 	for _, s := range app.SecretFiles {
 		if s == name {
 			return
@@ -300,8 +587,19 @@ func setSecretFileOnApp(app *App, name string) {
 	app.SecretFiles = append(app.SecretFiles, name)
 }
 
+// secretFilePaths returns where the secret file is found in the app
+// container, the custom mount (if any) comes after the default one.
+func secretFilePaths(app *App, name string) []string {
+	paths := []string{path.Join(SecretPath, name)}
+	if mp, found := app.SecretFileMounts[name]; found {
+		paths = append(paths, path.Join(mp, name))
+	}
+	return paths
+}
+
 func unsetSecretFilesOnApp(app *App, secrets []string) {
 	for _, secret := range secrets {
+		delete(app.SecretFileMounts, secret)
 		for i := range app.SecretFiles {
 			if app.SecretFiles[i] == secret {
 				app.SecretFiles = append(app.SecretFiles[:i], app.SecretFiles[i+1:]...)
@@ -311,6 +609,124 @@ func unsetSecretFilesOnApp(app *App, secrets []string) {
 	}
 }
 
+func setResourcesOnApp(app *App, r *Resources) {
+	if app.Resources == nil {
+		app.Resources = new(Resources)
+	}
+	if r.CPU != "" {
+		app.Resources.CPU = r.CPU
+	}
+	if r.Memory != "" {
+		app.Resources.Memory = r.Memory
+	}
+	if r.CPURequest != "" {
+		app.Resources.CPURequest = r.CPURequest
+	}
+	if r.MemoryRequest != "" {
+		app.Resources.MemoryRequest = r.MemoryRequest
+	}
+}
+
+func newStatusResponse(pods []*PodDetail) *appb.StatusResponse {
+	if pods == nil {
+		return nil
+	}
+
+	items := make([]*appb.StatusResponse_Pod, 0)
+	for _, item := range pods {
+		if item == nil {
+			continue
+		}
+		items = append(items, &appb.StatusResponse_Pod{
+			Name:                  item.Name,
+			State:                 item.State,
+			Age:                   item.Age,
+			Restarts:              item.Restarts,
+			Ready:                 item.Ready,
+			Node:                  item.Node,
+			LastTerminationReason: item.LastTerminationReason,
+		})
+	}
+
+	return &appb.StatusResponse{Pods: items}
+}
+
+func newEventsResponse(ev *Event) *appb.EventsResponse {
+	return &appb.EventsResponse{
+		Type:     ev.Type,
+		Reason:   ev.Reason,
+		Object:   ev.Object,
+		Message:  ev.Message,
+		Count:    ev.Count,
+		LastSeen: ev.LastSeen,
+	}
+}
+
+func newCronListResponse(crons []*CronJob) *appb.CronListResponse {
+	items := make([]*appb.CronListResponse_Cron, 0, len(crons))
+	for _, cj := range crons {
+		items = append(items, &appb.CronListResponse_Cron{
+			Name:         cj.Name,
+			Schedule:     cj.Schedule,
+			Suspended:    cj.Suspended,
+			LastSchedule: cj.LastSchedule,
+			Active:       cj.Active,
+		})
+	}
+	return &appb.CronListResponse{Crons: items}
+}
+
+func newCronRunsResponse(runs []*CronRun) *appb.CronRunsResponse {
+	items := make([]*appb.CronRunsResponse_Run, 0, len(runs))
+	for _, r := range runs {
+		items = append(items, &appb.CronRunsResponse_Run{
+			Name:            r.Name,
+			Status:          r.Status,
+			StartTime:       r.StartTime,
+			DurationSeconds: r.Duration,
+			ExitCode:        r.ExitCode,
+		})
+	}
+	return &appb.CronRunsResponse{Runs: items}
+}
+
+func newTopResponse(pods []*PodMetrics) *appb.TopResponse {
+	if pods == nil {
+		return nil
+	}
+
+	items := make([]*appb.TopResponse_Pod, 0)
+	for _, item := range pods {
+		if item == nil {
+			continue
+		}
+		items = append(items, &appb.TopResponse_Pod{
+			Name:   item.Name,
+			Cpu:    item.CPU,
+			Memory: item.Memory,
+		})
+	}
+
+	return &appb.TopResponse{Pods: items}
+}
+
+func newEnvHistoryResponse(revs []*EnvRevision) *appb.EnvHistoryResponse {
+	items := make([]*appb.EnvHistoryResponse_Revision, len(revs))
+	for i, rev := range revs {
+		evs := make([]*appb.SetEnvRequest_EnvVar, len(rev.EnvVars))
+		for j, ev := range rev.EnvVars {
+			evs[j] = &appb.SetEnvRequest_EnvVar{Key: ev.Key, Value: ev.Value}
+		}
+		items[i] = &appb.EnvHistoryResponse_Revision{
+			Version:   rev.Version,
+			Author:    rev.Author,
+			CreatedAt: rev.CreatedAt.Unix(),
+			EnvVars:   evs,
+		}
+	}
+	return &appb.EnvHistoryResponse{Revisions: items}
+}
+
 func newListResponse(items []*AppListItem) *appb.ListResponse {
 	if items == nil {
 		return nil
@@ -332,10 +748,32 @@ func newListResponse(items []*AppListItem) *appb.ListResponse {
 	return &appb.ListResponse{Apps: apps}
 }
 
+// newAutoscale returns nil Metrics if the request doesn't replace them,
+// meaning the current ones are kept
 func newAutoscale(req *appb.SetAutoscaleRequest) *Autoscale {
-	return &Autoscale{
-		CPUTargetUtilization: req.Autoscale.CpuTargetUtilization,
-		Max:                  req.Autoscale.Max,
-		Min:                  req.Autoscale.Min,
+	as := &Autoscale{
+		CPUTargetUtilization:    req.Autoscale.CpuTargetUtilization,
+		MemoryTargetUtilization: req.Autoscale.MemoryTargetUtilization,
+		Max:                     req.Autoscale.Max,
+		Min:                     req.Autoscale.Min,
+	}
+	if req.ReplaceMetrics {
+		as.Metrics = make([]*AutoscaleMetric, len(req.Autoscale.Metrics))
+		for i, m := range req.Autoscale.Metrics {
+			as.Metrics[i] = &AutoscaleMetric{
+				Name:               m.Name,
+				TargetAverageValue: m.TargetAverageValue,
+			}
+		}
+	}
+	return as
+}
+
+func newResources(req *appb.SetResourcesRequest) *Resources {
+	return &Resources{
+		CPU:           req.Cpu,
+		Memory:        req.Memory,
+		CPURequest:    req.CpuRequest,
+		MemoryRequest: req.MemoryRequest,
 	}
 }
@@ -136,6 +136,7 @@ func TestNewInfoResponse(t *testing.T) {
 			DefaultRequest: []*LimitRangeQuantity{lrq2},
 		},
 		Volumes: []string{"/teresa/secret/foo.txt"},
+		Deploy:  &DeployMetadata{Author: "gopher@luizalabs.com", Commit: "8a3f2c1"},
 	}
 	want := &appb.InfoResponse{
 		Team:      info.Team,
@@ -164,6 +165,7 @@ func TestNewInfoResponse(t *testing.T) {
 			},
 		},
 		Volumes: []string{"/teresa/secret/foo.txt"},
+		Deploy:  &appb.DeployMetadata{Author: "gopher@luizalabs.com", Commit: "8a3f2c1"},
 	}
 
 	resp := newInfoResponse(info)
@@ -234,6 +236,28 @@ func TestSetEnvVars(t *testing.T) {
 	}
 }
 
+func TestPodEnvVars(t *testing.T) {
+	app := &App{
+		Name:        "teresa",
+		EnvVars:     []*EnvVar{{Key: "key1", Value: "app-value1"}, {Key: "key3", Value: "value3"}},
+		TeamEnvVars: []*EnvVar{{Key: "key1", Value: "team-value1"}, {Key: "key2", Value: "value2"}},
+	}
+	want := []*EnvVar{
+		{Key: "key2", Value: "value2"},
+		{Key: "key1", Value: "app-value1"},
+		{Key: "key3", Value: "value3"},
+	}
+
+	if got := PodEnvVars(app); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	app.TeamEnvVars = nil
+	if got := PodEnvVars(app); !reflect.DeepEqual(got, app.EnvVars) {
+		t.Errorf("expected %v, got %v", app.EnvVars, got)
+	}
+}
+
 func TestUnsetEnvVars(t *testing.T) {
 	app := &App{Name: "teresa", Team: "luizalabs"}
 	var testCases = []struct {
@@ -315,13 +339,31 @@ func TestSetSecretFileOnApp(t *testing.T) {
 
 	for _, tc := range testCases {
 		a.SecretFiles = tc.actual
-		setSecretFileOnApp(a, tc.secret)
+		setSecretFileOnApp(a, tc.secret, "")
 		if !reflect.DeepEqual(a.SecretFiles, tc.want) {
 			t.Errorf("expected %v, got %v", tc.want, a.SecretFiles)
 		}
 	}
 }
 
+func TestSetSecretFileOnAppWithMountPath(t *testing.T) {
+	a := &App{Name: "teresa", Team: "luizalabs"}
+
+	setSecretFileOnApp(a, "credentials.json", "/secrets")
+	want := []string{"/teresa/secrets/credentials.json", "/secrets/credentials.json"}
+	if actual := secretFilePaths(a, "credentials.json"); !reflect.DeepEqual(actual, want) {
+		t.Errorf("expected %v, got %v", want, actual)
+	}
+
+	setSecretFileOnApp(a, "credentials.json", "")
+	if _, found := a.SecretFileMounts["credentials.json"]; found {
+		t.Error("expected custom mount to be removed")
+	}
+	if actual := len(a.SecretFiles); actual != 1 {
+		t.Errorf("expected 1 secret file, got %d", actual)
+	}
+}
+
 func TestUnSetSecretFilesOnApp(t *testing.T) {
 	a := &App{Name: "teresa", Team: "luizalabs"}
 	var testCases = []struct {
@@ -341,6 +383,24 @@ func TestUnSetSecretFilesOnApp(t *testing.T) {
 			t.Errorf("expected %v, got %v", tc.want, a.SecretFiles)
 		}
 	}
+
+	a.SecretFiles = []string{"S1"}
+	a.SecretFileMounts = map[string]string{"S1": "/secrets"}
+	unsetSecretFilesOnApp(a, []string{"S1"})
+	if actual := len(a.SecretFileMounts); actual != 0 {
+		t.Errorf("expected no custom mounts, got %d", actual)
+	}
+}
+
+func TestSetResourcesOnApp(t *testing.T) {
+	a := &App{Name: "teresa", Resources: &Resources{CPU: "200m", Memory: "256Mi"}}
+
+	setResourcesOnApp(a, &Resources{Memory: "512Mi", CPURequest: "100m"})
+
+	want := &Resources{CPU: "200m", Memory: "512Mi", CPURequest: "100m"}
+	if !reflect.DeepEqual(a.Resources, want) {
+		t.Errorf("expected %v, got %v", want, a.Resources)
+	}
 }
 
 func TestNewAutoscale(t *testing.T) {
@@ -356,3 +416,17 @@ func TestNewAutoscale(t *testing.T) {
 		t.Errorf("got %v; want %v", as, want)
 	}
 }
+
+func TestNewAutoscaleReplaceMetrics(t *testing.T) {
+	req := newAutoscaleRequest("teresa")
+	req.ReplaceMetrics = true
+	req.Autoscale.Metrics = []*appb.AutoscaleMetric{
+		{Name: "http_requests_per_second", TargetAverageValue: "100"},
+	}
+	as := newAutoscale(req)
+	want := []*AutoscaleMetric{{Name: "http_requests_per_second", TargetAverageValue: "100"}}
+
+	if !reflect.DeepEqual(as.Metrics, want) {
+		t.Errorf("got %v; want %v", as.Metrics, want)
+	}
+}
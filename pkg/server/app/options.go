@@ -1,13 +1,28 @@
 package app
 
+// LogOptions selects the logs of the pods, a negative Lines is all the
+// lines and a SinceSeconds of 0 is since the start of the container
 type LogOptions struct {
-	Lines     int64
-	Follow    bool
-	PodName   string
-	Previous  bool
-	Container string
+	Lines        int64
+	Follow       bool
+	PodName      string
+	Previous     bool
+	Container    string
+	Process      string
+	Grep         string
+	SinceSeconds int64
+	Timestamps   bool
+	Output       string
+}
+
+type EventOptions struct {
+	Follow bool
 }
 
 type PodListOptions struct {
-	PodName string
+	PodName    string
+	JobName    string
+	DeployName string
+	// AnyJob lists the pods of all the Jobs, like the runs of a CronJob
+	AnyJob bool
 }
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+)
+
+const (
+	pdbSyncLease    = "pdb-sync"
+	pdbSyncLeaseTTL = 10 * time.Minute
+)
+
+var minAvailableRegexp = regexp.MustCompile(`^[0-9]+%?$`)
+
+// validMinAvailable checks a number of pods or a percentage of them, blank
+// means the cluster default and zero no PodDisruptionBudget at all
+func validMinAvailable(s string) bool {
+	if s == "" {
+		return true
+	}
+	if !minAvailableRegexp.MatchString(s) {
+		return false
+	}
+	n, _ := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	return !strings.HasSuffix(s, "%") || n <= 100
+}
+
+// clampMinAvailable keeps minAvailable below the replicas, otherwise no pod
+// could ever be evicted and the node drains would hang
+func clampMinAvailable(minAvailable string, replicas int32) string {
+	if minAvailable == "" {
+		return ""
+	}
+	n, _ := strconv.Atoi(strings.TrimSuffix(minAvailable, "%"))
+	if strings.HasSuffix(minAvailable, "%") {
+		// the pods are rounded up, like the disruption controller does
+		n = (n*int(replicas) + 99) / 100
+	}
+	if n < int(replicas) {
+		return minAvailable
+	}
+	if replicas <= 1 {
+		return "0"
+	}
+	return strconv.Itoa(int(replicas) - 1)
+}
+
+// minReplicas returns the fewest replicas the app deploy runs, the min of
+// its autoscale if any
+func (ops *AppOperations) minReplicas(app *App) (int32, error) {
+	if app.Paused != nil {
+		return 0, nil
+	}
+	as, err := ops.kops.Autoscale(app.Name)
+	if err != nil {
+		return 0, err
+	}
+	if as != nil {
+		return as.Min, nil
+	}
+	n, err := ops.kops.DeployReplicas(app.Name, app.Name)
+	if err != nil && ops.kops.IsNotFound(err) {
+		return 1, nil
+	}
+	return n, err
+}
+
+// syncPDB creates, updates or deletes the PodDisruptionBudget of the app
+// deploy according to its minAvailable or the cluster default, clamped
+// below its replicas
+func (ops *AppOperations) syncPDB(app *App) error {
+	minAvailable := app.MinAvailable
+	if minAvailable == "" {
+		minAvailable = ops.kops.DefaultPDBMinAvailable()
+	}
+	if minAvailable != "" {
+		replicas, err := ops.minReplicas(app)
+		if err != nil {
+			return err
+		}
+		minAvailable = clampMinAvailable(minAvailable, replicas)
+	}
+
+	if minAvailable == "" || strings.TrimSuffix(minAvailable, "%") == "0" {
+		if err := ops.kops.DeletePDB(app.Name, app.Name); err != nil && !ops.kops.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	return ops.kops.CreateOrUpdatePDB(app.Name, app.Name, minAvailable)
+}
+
+// SyncPDBs syncs the PodDisruptionBudgets of all apps, the ones created
+// before they were clamped below the replicas included. Only the replica of
+// the server holding the lease runs it.
+func (ops *AppOperations) SyncPDBs(l lease.Leases) {
+	if !lease.Held(l, pdbSyncLease, pdbSyncLeaseTTL) {
+		return
+	}
+	names, err := ops.kops.NamespaceListByLabel(TeresaTeamLabel, "")
+	if err != nil {
+		log.WithError(err).Error("listing apps to sync the pdbs")
+		return
+	}
+	for _, name := range names {
+		app, err := ops.Get(name)
+		if err != nil {
+			log.WithError(err).Errorf("getting app %s to sync its pdb", name)
+			continue
+		}
+		if IsCronJob(app.ProcessType) {
+			continue
+		}
+		if err := ops.syncPDB(app); err != nil {
+			log.WithError(err).Errorf("syncing pdb of app %s", name)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidMinAvailable(t *testing.T) {
+	var testCases = []struct {
+		minAvailable string
+		expected     bool
+	}{
+		{"", true},
+		{"0", true},
+		{"2", true},
+		{"50%", true},
+		{"100%", true},
+		{"101%", false},
+		{"-1", false},
+		{"half", false},
+		{"%", false},
+	}
+
+	for _, tc := range testCases {
+		if got := validMinAvailable(tc.minAvailable); got != tc.expected {
+			t.Errorf("expected %v, got %v for %q", tc.expected, got, tc.minAvailable)
+		}
+	}
+}
+
+func TestClampMinAvailable(t *testing.T) {
+	var testCases = []struct {
+		minAvailable string
+		replicas     int32
+		expected     string
+	}{
+		{"", 3, ""},
+		{"1", 3, "1"},
+		{"3", 3, "2"},
+		{"5", 3, "2"},
+		{"1", 1, "0"},
+		{"1", 0, "0"},
+		{"50%", 3, "50%"},
+		{"80%", 3, "2"},
+		{"100%", 1, "0"},
+	}
+
+	for _, tc := range testCases {
+		if actual := clampMinAvailable(tc.minAvailable, tc.replicas); actual != tc.expected {
+			t.Errorf("expected %q, got %q for %q with %d replicas", tc.expected, actual, tc.minAvailable, tc.replicas)
+		}
+	}
+}
+
+func TestAppOperationsSetPDB(t *testing.T) {
+	var testCases = []struct {
+		minAvailable   string
+		defaultValue   string
+		expected       string
+		expectedDelete bool
+	}{
+		{"50%", "", "50%", false},
+		{"", "1", "1", false},
+		{"", "", "", true},
+		{"0", "1", "", true},
+		{"2", "", "1", false},
+		{"100%", "", "1", false},
+	}
+
+	for _, tc := range testCases {
+		tops := team.NewFakeOperations()
+		// see fakeK8sOperations.DeployReplicas, 2 replicas
+		fakeK8s := &fakeK8sOperations{DefaultPDBMinAvailableValue: tc.defaultValue, NoAutoscale: true}
+		ops := NewOperations(tops, fakeK8s, nil)
+		user := &database.User{Email: "teresa@luizalabs.com"}
+		app := &App{Name: "teresa", Team: "luizalabs"}
+		tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+			Name:  app.Team,
+			Users: []database.User{*user},
+		}
+
+		if err := ops.SetPDB(user, app.Name, tc.minAvailable); err != nil {
+			t.Fatal("got unexpected error:", err)
+		}
+		if fakeK8s.PDBMinAvailable != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, fakeK8s.PDBMinAvailable)
+		}
+		if fakeK8s.DeletePDBWasCalled != tc.expectedDelete {
+			t.Errorf("expected %v, got %v", tc.expectedDelete, fakeK8s.DeletePDBWasCalled)
+		}
+	}
+}
+
+func TestAppOperationsSetPDBInvalid(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetPDB(user, app.Name, "200%"); err != ErrInvalidMinAvailable {
+		t.Errorf("expected ErrInvalidMinAvailable, got %v", err)
+	}
+}
+
+func TestAppOperationsSetPDBErrPermissionDenied(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetPDB(user, "teresa", "1"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
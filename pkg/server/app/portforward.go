@@ -0,0 +1,40 @@
+package app
+
+import (
+	"io"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// Tunnel is a connection forwarded to a port of a pod, In is what the
+// client sends and Out what the pod answers. The tunnel is closed once Done
+// is closed
+type Tunnel struct {
+	In   io.Reader
+	Out  io.Writer
+	Done <-chan struct{}
+}
+
+func isValidPort(port int32) bool {
+	return port > 0 && port <= 65535
+}
+
+// PortForward forwards the tunnel to the port of the pod of the App, a
+// blank podName is a running pod of the App deploy
+func (ops *AppOperations) PortForward(user *database.User, appName, podName string, port int32, t *Tunnel) error {
+	if !isValidPort(port) {
+		return ErrInvalidPort
+	}
+	if _, err := ops.CheckPermAndGet(user, appName); err != nil {
+		return err
+	}
+
+	if err := ops.kops.PortForward(appName, podName, port, t); err != nil {
+		if ops.kops.IsNotFound(err) {
+			return ErrPodNotFound
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
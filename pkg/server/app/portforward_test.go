@@ -0,0 +1,55 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsValidPort(t *testing.T) {
+	var testCases = []struct {
+		port     int32
+		expected bool
+	}{
+		{8080, true},
+		{1, true},
+		{65535, true},
+		{0, false},
+		{-1, false},
+		{65536, false},
+	}
+
+	for _, tc := range testCases {
+		if actual := isValidPort(tc.port); actual != tc.expected {
+			t.Errorf("expected %v, got %v [case: %d]", tc.expected, actual, tc.port)
+		}
+	}
+}
+
+func TestAppOperationsPortForward(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	out := new(bytes.Buffer)
+	tun := &Tunnel{In: strings.NewReader("GET / HTTP/1.1\r\n\r\n"), Out: out}
+
+	if err := ops.PortForward(user, "teresa", "", 8080, tun); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if out.String() != "GET / HTTP/1.1\r\n\r\n" {
+		t.Errorf("expected the data forwarded, got %q", out.String())
+	}
+}
+
+func TestAppOperationsPortForwardErrors(t *testing.T) {
+	ops, user := newConfigFileTestOps(&fakeK8sOperations{})
+	tun := &Tunnel{In: strings.NewReader(""), Out: new(bytes.Buffer)}
+	if err := ops.PortForward(user, "teresa", "", 0, tun); err != ErrInvalidPort {
+		t.Errorf("expected ErrInvalidPort, got %v", err)
+	}
+
+	fakeK8s := &fakeK8sOperations{PortForwardErr: errors.New("not found"), IsNotFoundErr: true}
+	ops, user = newConfigFileTestOps(fakeK8s)
+	if err := ops.PortForward(user, "teresa", "teresa-123", 8080, tun); err != ErrPodNotFound {
+		t.Errorf("expected ErrPodNotFound, got %v", err)
+	}
+}
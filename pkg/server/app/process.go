@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const processTypeRelease = "release"
+
+var processTypeRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ProcessDeployName returns the name of the Deployment running an extra
+// process type of an App
+func ProcessDeployName(appName, processType string) string {
+	return fmt.Sprintf("%s-%s", appName, processType)
+}
+
+// IsValidProcessType tells if a process type of the Procfile can run on its
+// own Deployment
+func IsValidProcessType(processType string) bool {
+	return processType != processTypeRelease && processTypeRegexp.MatchString(processType)
+}
+
+// deployNames returns the names of all Deployments of an App, the main one first
+func deployNames(a *App) []string {
+	pts := make([]string, 0, len(a.Processes))
+	for pt := range a.Processes {
+		pts = append(pts, pt)
+	}
+	sort.Strings(pts)
+
+	names := []string{a.Name}
+	for _, pt := range pts {
+		names = append(names, ProcessDeployName(a.Name, pt))
+	}
+	return names
+}
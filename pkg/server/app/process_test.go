@@ -0,0 +1,47 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProcessDeployName(t *testing.T) {
+	if name := ProcessDeployName("teresa", "worker"); name != "teresa-worker" {
+		t.Errorf("expected teresa-worker, got %s", name)
+	}
+}
+
+func TestIsValidProcessType(t *testing.T) {
+	var testCases = []struct {
+		processType string
+		expected    bool
+	}{
+		{"worker", true},
+		{"web", true},
+		{"celery-beat", true},
+		{"worker2", true},
+		{"release", false},
+		{"Worker", false},
+		{"worker_1", false},
+		{"-worker", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		if actual := IsValidProcessType(tc.processType); actual != tc.expected {
+			t.Errorf("expected %v, got %v [case: %s]", tc.expected, actual, tc.processType)
+		}
+	}
+}
+
+func TestDeployNames(t *testing.T) {
+	a := &App{
+		Name:      "teresa",
+		Processes: map[string]int32{"worker": 1, "clock": 0},
+	}
+	expected := []string{"teresa", "teresa-clock", "teresa-worker"}
+
+	if names := deployNames(a); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
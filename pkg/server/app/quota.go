@@ -0,0 +1,250 @@
+package app
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// quotaUsage is the usage of the resources of a team quota, cpu in
+// millicores and memory in bytes
+type quotaUsage struct {
+	apps     int32
+	replicas int32
+	cpu      int64
+	memory   int64
+}
+
+func (u *quotaUsage) add(other *quotaUsage) {
+	u.apps += other.apps
+	u.replicas += other.replicas
+	u.cpu += other.cpu
+	u.memory += other.memory
+}
+
+// replicasOf returns the replicas of the Deployments of an App by name, the
+// ones not deployed yet are left out
+func (ops *AppOperations) replicasOf(a *App) (map[string]int32, error) {
+	replicas := make(map[string]int32)
+	if IsCronJob(a.ProcessType) {
+		return replicas, nil
+	}
+	for _, name := range deployNames(a) {
+		n, err := ops.kops.DeployReplicas(a.Name, name)
+		if err != nil {
+			if ops.kops.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		replicas[name] = n
+	}
+	return replicas, nil
+}
+
+// podRequests returns the cpu (millicores) and memory (bytes) requested by
+// each pod of an App, the resources of the app take precedence over the
+// default requests of its namespace
+func (ops *AppOperations) podRequests(a *App) (int64, int64, error) {
+	var cpu, memory string
+	if a.Resources != nil {
+		cpu, memory = a.Resources.CPURequest, a.Resources.MemoryRequest
+	}
+	if cpu == "" || memory == "" {
+		lim, err := ops.kops.Limits(a.Name, limitsName)
+		if err != nil && !ops.kops.IsNotFound(err) {
+			return 0, 0, err
+		}
+		if lim != nil {
+			for _, q := range lim.DefaultRequest {
+				if q.Resource == "cpu" && cpu == "" {
+					cpu = q.Quantity
+				} else if q.Resource == "memory" && memory == "" {
+					memory = q.Quantity
+				}
+			}
+		}
+	}
+
+	var cpuQty, memoryQty resource.Quantity
+	var err error
+	if cpu != "" {
+		if cpuQty, err = resource.ParseQuantity(cpu); err != nil {
+			return 0, 0, err
+		}
+	}
+	if memory != "" {
+		if memoryQty, err = resource.ParseQuantity(memory); err != nil {
+			return 0, 0, err
+		}
+	}
+	return cpuQty.MilliValue(), memoryQty.Value(), nil
+}
+
+// appUsage returns the usage of an App running with the given replicas by
+// Deployment name
+func (ops *AppOperations) appUsage(a *App, replicas map[string]int32) (*quotaUsage, error) {
+	u := &quotaUsage{apps: 1}
+	for _, n := range replicas {
+		u.replicas += n
+	}
+	if u.replicas == 0 {
+		return u, nil
+	}
+
+	cpu, memory, err := ops.podRequests(a)
+	if err != nil {
+		return nil, err
+	}
+	u.cpu = cpu * int64(u.replicas)
+	u.memory = memory * int64(u.replicas)
+	return u, nil
+}
+
+// appsUsage returns the usage of each App of a team by name
+func (ops *AppOperations) appsUsage(teamName string) (map[string]*quotaUsage, error) {
+	names, err := ops.ListByTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make(map[string]*quotaUsage)
+	for _, name := range names {
+		a, err := ops.Get(name)
+		if err != nil {
+			return nil, err
+		}
+		replicas, err := ops.replicasOf(a)
+		if err != nil {
+			return nil, err
+		}
+		if usages[name], err = ops.appUsage(a, replicas); err != nil {
+			return nil, err
+		}
+	}
+	return usages, nil
+}
+
+func sumUsage(usages map[string]*quotaUsage) *quotaUsage {
+	total := new(quotaUsage)
+	for _, u := range usages {
+		total.add(u)
+	}
+	return total
+}
+
+// TeamUsage returns the resources used by the apps of a team
+func (ops *AppOperations) TeamUsage(teamName string) (*teamext.Usage, error) {
+	usages, err := ops.appsUsage(teamName)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	total := sumUsage(usages)
+	return &teamext.Usage{
+		Apps:     total.apps,
+		Replicas: total.replicas,
+		CPU:      resource.NewMilliQuantity(total.cpu, resource.DecimalSI).String(),
+		Memory:   resource.NewQuantity(total.memory, resource.BinarySI).String(),
+	}, nil
+}
+
+// checkTeamQuota checks the quota of a team with the App appName changed to
+// a running with the given replicas by Deployment name (nil keeps the
+// current ones). Only the resources whose usage grows are checked, so a team
+// over its quota is always able to scale down.
+func (ops *AppOperations) checkTeamQuota(teamName, appName string, a *App, replicas map[string]int32) error {
+	q, err := ops.tops.Quota(teamName)
+	if err != nil {
+		return err
+	}
+	if q.IsUnlimited() {
+		return nil
+	}
+
+	usages, err := ops.appsUsage(teamName)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	cur := sumUsage(usages)
+
+	if replicas == nil {
+		if replicas, err = ops.replicasOf(a); err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+	}
+	if usages[appName], err = ops.appUsage(a, replicas); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	next := sumUsage(usages)
+
+	return checkQuota(q, cur, next)
+}
+
+// checkAppQuota is like checkTeamQuota for the team of the App appName
+func (ops *AppOperations) checkAppQuota(appName string, a *App, replicas map[string]int32) error {
+	teamName, err := ops.TeamName(appName)
+	if err != nil {
+		return err
+	}
+	return ops.checkTeamQuota(teamName, appName, a, replicas)
+}
+
+func checkQuota(q *team.Quota, cur, next *quotaUsage) error {
+	if q.Apps > 0 && next.apps > q.Apps && next.apps > cur.apps {
+		return ErrAppsQuotaExceeded
+	}
+	if q.Replicas > 0 && next.replicas > q.Replicas && next.replicas > cur.replicas {
+		return ErrReplicasQuotaExceeded
+	}
+	if q.CPU != "" {
+		max, err := resource.ParseQuantity(q.CPU)
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if next.cpu > max.MilliValue() && next.cpu > cur.cpu {
+			return teresa_errors.New(
+				ErrCPUQuotaExceeded,
+				fmt.Errorf("%dm of cpu requested, the quota is %s", next.cpu, q.CPU),
+			)
+		}
+	}
+	if q.Memory != "" {
+		max, err := resource.ParseQuantity(q.Memory)
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if next.memory > max.Value() && next.memory > cur.memory {
+			return teresa_errors.New(
+				ErrMemoryQuotaExceeded,
+				fmt.Errorf("%d bytes of memory requested, the quota is %s", next.memory, q.Memory),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckTeamQuota checks the quota of the team of an App about to be
+// deployed, its Deployments not created yet count with their replicas
+func (ops *AppOperations) CheckTeamQuota(a *App) error {
+	replicas, err := ops.replicasOf(a)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !IsCronJob(a.ProcessType) {
+		if _, found := replicas[a.Name]; !found {
+			replicas[a.Name] = 1
+		}
+		for pt, n := range a.Processes {
+			name := ProcessDeployName(a.Name, pt)
+			if _, found := replicas[name]; !found {
+				replicas[name] = n
+			}
+		}
+	}
+	return ops.checkTeamQuota(a.Team, a.Name, a, replicas)
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	st "github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+func newQuotaTestOps(q *team.Quota) (Operations, *database.User) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{Namespaces: map[string]struct{}{"teresa": {}, "other": {}}}
+	ops := NewOperations(tops, fakeK8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:          "luizalabs",
+		Users:         []database.User{*user},
+		QuotaApps:     q.Apps,
+		QuotaReplicas: q.Replicas,
+		QuotaCPU:      q.CPU,
+		QuotaMemory:   q.Memory,
+	}
+	return ops, user
+}
+
+func TestAppOperationsTeamUsage(t *testing.T) {
+	ops, _ := newQuotaTestOps(&team.Quota{})
+
+	u, err := ops.TeamUsage("luizalabs")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if u.Apps != 2 || u.Replicas != 4 || u.CPU != "0" || u.Memory != "0" {
+		t.Errorf("expected 2 apps with 4 replicas, got %+v", u)
+	}
+}
+
+func TestAppOperationsCreateAppsQuotaExceeded(t *testing.T) {
+	ops, user := newQuotaTestOps(&team.Quota{Apps: 2})
+
+	a := &App{Name: "new", Team: "luizalabs"}
+	if err := ops.Create(user, a); err != ErrAppsQuotaExceeded {
+		t.Errorf("expected ErrAppsQuotaExceeded, got %v", err)
+	}
+}
+
+func TestAppOperationsSetReplicasQuota(t *testing.T) {
+	ops, user := newQuotaTestOps(&team.Quota{Replicas: 4})
+
+	if err := ops.SetReplicas(user, "teresa", 3); err != ErrReplicasQuotaExceeded {
+		t.Errorf("expected ErrReplicasQuotaExceeded, got %v", err)
+	}
+	if err := ops.SetReplicas(user, "teresa", 2); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ops.Scale(user, "teresa", map[string]int32{"worker": 1}); err != ErrReplicasQuotaExceeded {
+		t.Errorf("expected ErrReplicasQuotaExceeded, got %v", err)
+	}
+}
+
+func TestAppOperationsSetAutoscaleQuota(t *testing.T) {
+	ops, user := newQuotaTestOps(&team.Quota{Replicas: 4})
+
+	if err := ops.SetAutoscale(user, "teresa", &Autoscale{Min: 3, Max: 5}); err != ErrReplicasQuotaExceeded {
+		t.Errorf("expected ErrReplicasQuotaExceeded, got %v", err)
+	}
+	if err := ops.SetAutoscale(user, "teresa", &Autoscale{Min: 1, Max: 5}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestAppOperationsTransferTeamQuota(t *testing.T) {
+	tops := team.NewFakeOperations()
+	// the new team has no apps yet
+	ops := NewOperations(tops, &fakeK8sOperations{}, st.NewFake())
+	admin := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	tops.(*team.FakeOperations).Storage["small"] = &database.Team{
+		Name:          "small",
+		QuotaReplicas: 1,
+	}
+
+	if err := ops.TransferTeam(admin, "teresa", "small"); err != ErrReplicasQuotaExceeded {
+		t.Errorf("expected ErrReplicasQuotaExceeded, got %v", err)
+	}
+}
+
+func TestAppOperationsScaleDownOverQuota(t *testing.T) {
+	ops, user := newQuotaTestOps(&team.Quota{Replicas: 1})
+
+	if err := ops.SetReplicas(user, "teresa", 1); err != nil {
+		t.Errorf("expected no error scaling down, got %v", err)
+	}
+}
+
+func TestAppOperationsSetResourcesCPUQuota(t *testing.T) {
+	ops, user := newQuotaTestOps(&team.Quota{CPU: "3"})
+
+	if err := ops.SetResources(user, "teresa", &Resources{CPURequest: "1"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	err := ops.SetResources(user, "teresa", &Resources{CPURequest: "2"})
+	if teresa_errors.Get(err) != ErrCPUQuotaExceeded {
+		t.Errorf("expected ErrCPUQuotaExceeded, got %v", err)
+	}
+}
+
+func TestCheckQuota(t *testing.T) {
+	q := &team.Quota{Apps: 2, Replicas: 4, CPU: "1", Memory: "1Gi"}
+	cur := &quotaUsage{apps: 2, replicas: 4, cpu: 1000, memory: 1 << 30}
+
+	var testCases = []struct {
+		next     *quotaUsage
+		expected error
+	}{
+		{&quotaUsage{apps: 2, replicas: 4, cpu: 1000, memory: 1 << 30}, nil},
+		{&quotaUsage{apps: 3, replicas: 4, cpu: 1000, memory: 1 << 30}, ErrAppsQuotaExceeded},
+		{&quotaUsage{apps: 2, replicas: 5, cpu: 1000, memory: 1 << 30}, ErrReplicasQuotaExceeded},
+		{&quotaUsage{apps: 2, replicas: 4, cpu: 1001, memory: 1 << 30}, ErrCPUQuotaExceeded},
+		{&quotaUsage{apps: 2, replicas: 4, cpu: 1000, memory: 1<<30 + 1}, ErrMemoryQuotaExceeded},
+	}
+	for _, tc := range testCases {
+		if err := checkQuota(q, cur, tc.next); teresa_errors.Get(err) != tc.expected {
+			t.Errorf("expected %v for %+v, got %v", tc.expected, tc.next, err)
+		}
+	}
+
+	over := &quotaUsage{apps: 3, replicas: 6, cpu: 2000, memory: 2 << 30}
+	less := &quotaUsage{apps: 3, replicas: 5, cpu: 1500, memory: 2 << 30}
+	if err := checkQuota(q, over, less); err != nil {
+		t.Errorf("expected no error decreasing the usage over the quota, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+)
+
+const (
+	scheduleCheckInterval = time.Minute
+	// schedulerLease is renewed every run, another replica of the server
+	// takes over the scheduler a few runs after the holder is gone
+	schedulerLease    = "autoscale-scheduler"
+	schedulerLeaseTTL = 3 * scheduleCheckInterval
+	// periodDefault is the period outside of the windows
+	periodDefault = "default"
+)
+
+var (
+	clockRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	weekdays    = map[string]time.Weekday{
+		"sun": time.Sunday,
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+	}
+)
+
+// ScaleWindow sets the replicas of an app from Start to End (HH:MM, server
+// time) on the Days (mon, tue, etc., blank means every day). A window
+// ending before its start ends on the next day. Max zero keeps the current
+// max of the autoscale
+type ScaleWindow struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Days  []string `json:"days,omitempty"`
+	Min   int32    `json:"min"`
+	Max   int32    `json:"max,omitempty"`
+}
+
+// ScaleSchedule holds the scale windows of an app and the min and max
+// replicas restored outside of them. Applied is the last period (window)
+// applied by the scheduler, the replicas set by hand are kept until the
+// next one.
+type ScaleSchedule struct {
+	Windows []*ScaleWindow `json:"windows"`
+	Min     int32          `json:"min"`
+	Max     int32          `json:"max"`
+	Applied string         `json:"applied,omitempty"`
+}
+
+func validateScaleWindows(windows []*ScaleWindow) error {
+	for _, w := range windows {
+		if !clockRegexp.MatchString(w.Start) || !clockRegexp.MatchString(w.End) || w.Start == w.End {
+			return ErrInvalidScaleWindow
+		}
+		for _, d := range w.Days {
+			if _, found := weekdays[d]; !found {
+				return ErrInvalidScaleWindow
+			}
+		}
+		if w.Min < 1 || (w.Max != 0 && w.Max < w.Min) {
+			return ErrInvalidScaleWindow
+		}
+	}
+	return nil
+}
+
+func (w *ScaleWindow) hasDay(d time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, day := range w.Days {
+		if weekdays[day] == d {
+			return true
+		}
+	}
+	return false
+}
+
+// active tells if t is inside the window, the clock strings are compared
+// as they are zero padded
+func (w *ScaleWindow) active(t time.Time) bool {
+	now := t.Format("15:04")
+	if w.Start < w.End {
+		return w.hasDay(t.Weekday()) && now >= w.Start && now < w.End
+	}
+	if now >= w.Start {
+		return w.hasDay(t.Weekday())
+	}
+	return now < w.End && w.hasDay(t.AddDate(0, 0, -1).Weekday())
+}
+
+// period returns the window active at t, the first one wins
+func (s *ScaleSchedule) period(t time.Time) string {
+	for i, w := range s.Windows {
+		if w.active(t) {
+			return fmt.Sprintf("%d-%s-%s", i, w.Start, w.End)
+		}
+	}
+	return periodDefault
+}
+
+// target returns the min and max replicas at t, the first active window
+// wins
+func (s *ScaleSchedule) target(t time.Time) (int32, int32) {
+	for _, w := range s.Windows {
+		if !w.active(t) {
+			continue
+		}
+		max := w.Max
+		if max == 0 {
+			max = s.Max
+		}
+		if max < w.Min {
+			max = w.Min
+		}
+		return w.Min, max
+	}
+	return s.Min, s.Max
+}
+
+// applyAutoscaleSchedule sets the min and max of the app autoscale to the
+// ones of the schedule at t. Apps without autoscale have the replicas of
+// the deploy set to the min instead
+func (ops *AppOperations) applyAutoscaleSchedule(app *App, t time.Time) error {
+	min, max := app.ScaleSchedule.target(t)
+	app.ScaleSchedule.Applied = app.ScaleSchedule.period(t)
+
+	as, err := ops.kops.Autoscale(app.Name)
+	if err != nil {
+		return err
+	}
+	if as == nil {
+		n, err := ops.kops.DeployReplicas(app.Name, app.Name)
+		if err != nil || n == min {
+			return err
+		}
+		if err := ops.kops.DeploySetReplicas(app.Name, app.Name, min); err != nil {
+			return err
+		}
+		return ops.syncPDB(app)
+	}
+
+	if as.Min == min && as.Max == max {
+		return nil
+	}
+	as.Min, as.Max = min, max
+	app.Autoscale = as
+	if err := ops.kops.CreateOrUpdateAutoscale(app); err != nil {
+		return err
+	}
+	return ops.syncPDB(app)
+}
+
+func (ops *AppOperations) applyAutoscaleSchedules(t time.Time) {
+	names, err := ops.kops.NamespaceListByLabel(TeresaTeamLabel, "")
+	if err != nil {
+		log.WithError(err).Error("listing apps to apply autoscale schedules")
+		return
+	}
+	for _, name := range names {
+		app, err := ops.Get(name)
+		if err != nil {
+			log.WithError(err).Errorf("getting app %s to apply autoscale schedule", name)
+			continue
+		}
+		if app.ScaleSchedule == nil || app.Paused != nil {
+			continue
+		}
+		// the app was scaled by hand since the last transition
+		if app.ScaleSchedule.period(t) == app.ScaleSchedule.Applied {
+			continue
+		}
+		if err := ops.applyAutoscaleSchedule(app, t); err != nil {
+			log.WithError(err).Errorf("applying autoscale schedule of app %s", name)
+			continue
+		}
+		if err := ops.SaveApp(app, ""); err != nil {
+			log.WithError(err).Errorf("saving autoscale schedule of app %s", name)
+		}
+	}
+}
+
+// RunAutoscaleScheduler applies the autoscale schedules of all apps every
+// minute, until stop is closed. Only the replica of the server holding the
+// lease of the scheduler applies them.
+func (ops *AppOperations) RunAutoscaleScheduler(l lease.Leases, stop <-chan struct{}) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case t := <-ticker.C:
+			if lease.Held(l, schedulerLease, schedulerLeaseTTL) {
+				ops.applyAutoscaleSchedules(t)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
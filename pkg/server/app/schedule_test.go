@@ -0,0 +1,222 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+// 2018-01-01 is a monday
+func clock(day int, hhmm string) time.Time {
+	t, _ := time.Parse("2006-01-02 15:04", "2018-01-01 "+hhmm)
+	return t.AddDate(0, 0, day-1)
+}
+
+func TestValidateScaleWindows(t *testing.T) {
+	var testCases = []struct {
+		window *ScaleWindow
+		err    error
+	}{
+		{&ScaleWindow{Start: "08:00", End: "20:00", Min: 10}, nil},
+		{&ScaleWindow{Start: "22:00", End: "06:00", Min: 2, Max: 4, Days: []string{"fri", "sat"}}, nil},
+		{&ScaleWindow{Start: "8:00", End: "20:00", Min: 10}, ErrInvalidScaleWindow},
+		{&ScaleWindow{Start: "08:00", End: "24:00", Min: 10}, ErrInvalidScaleWindow},
+		{&ScaleWindow{Start: "08:00", End: "08:00", Min: 10}, ErrInvalidScaleWindow},
+		{&ScaleWindow{Start: "08:00", End: "20:00", Min: 0}, ErrInvalidScaleWindow},
+		{&ScaleWindow{Start: "08:00", End: "20:00", Min: 10, Max: 5}, ErrInvalidScaleWindow},
+		{&ScaleWindow{Start: "08:00", End: "20:00", Min: 10, Days: []string{"monday"}}, ErrInvalidScaleWindow},
+	}
+
+	for _, tc := range testCases {
+		if err := validateScaleWindows([]*ScaleWindow{tc.window}); err != tc.err {
+			t.Errorf("expected %v, got %v for %v", tc.err, err, tc.window)
+		}
+	}
+}
+
+func TestScaleWindowActive(t *testing.T) {
+	business := &ScaleWindow{Start: "08:00", End: "20:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}}
+	night := &ScaleWindow{Start: "22:00", End: "06:00", Days: []string{"fri"}}
+
+	var testCases = []struct {
+		window   *ScaleWindow
+		t        time.Time
+		expected bool
+	}{
+		{business, clock(1, "08:00"), true},
+		{business, clock(1, "19:59"), true},
+		{business, clock(1, "20:00"), false},
+		{business, clock(1, "07:59"), false},
+		{business, clock(6, "10:00"), false},
+		{night, clock(5, "23:00"), true},
+		{night, clock(6, "05:59"), true},
+		{night, clock(6, "06:00"), false},
+		{night, clock(5, "05:00"), false},
+		{night, clock(6, "23:00"), false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.window.active(tc.t); got != tc.expected {
+			t.Errorf("expected %v, got %v for %v at %v", tc.expected, got, tc.window, tc.t)
+		}
+	}
+}
+
+func TestScaleScheduleTarget(t *testing.T) {
+	s := &ScaleSchedule{
+		Windows: []*ScaleWindow{
+			{Start: "08:00", End: "20:00", Min: 10},
+			{Start: "22:00", End: "06:00", Min: 2, Max: 4},
+		},
+		Min: 3,
+		Max: 8,
+	}
+
+	var testCases = []struct {
+		t        time.Time
+		min, max int32
+	}{
+		{clock(1, "12:00"), 10, 10},
+		{clock(1, "23:00"), 2, 4},
+		{clock(1, "21:00"), 3, 8},
+	}
+
+	for _, tc := range testCases {
+		min, max := s.target(tc.t)
+		if min != tc.min || max != tc.max {
+			t.Errorf("expected %d-%d, got %d-%d at %v", tc.min, tc.max, min, max, tc.t)
+		}
+	}
+}
+
+func TestScaleSchedulePeriod(t *testing.T) {
+	s := &ScaleSchedule{
+		Windows: []*ScaleWindow{
+			{Start: "08:00", End: "20:00", Min: 10},
+			{Start: "22:00", End: "06:00", Min: 2},
+		},
+	}
+
+	var testCases = []struct {
+		t        time.Time
+		expected string
+	}{
+		{clock(1, "12:00"), "0-08:00-20:00"},
+		{clock(1, "23:00"), "1-22:00-06:00"},
+		{clock(1, "21:00"), periodDefault},
+	}
+
+	for _, tc := range testCases {
+		if actual := s.period(tc.t); actual != tc.expected {
+			t.Errorf("expected %s, got %s at %v", tc.expected, actual, tc.t)
+		}
+	}
+}
+
+func TestAppOperationsApplyAutoscaleSchedulesKeepsManualScale(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{
+		Namespaces:       map[string]struct{}{"test": {}},
+		AppScaleSchedule: true,
+	}
+	ops := NewOperations(team.NewFakeOperations(), fakeK8s, nil).(*AppOperations)
+
+	// same period as the last applied, the app may have been scaled by hand
+	ops.applyAutoscaleSchedules(clock(1, "21:00"))
+	if fakeK8s.CreateOrUpdateAutoscaleWasCalled {
+		t.Error("expected the autoscale to be kept until the next transition")
+	}
+
+	ops.applyAutoscaleSchedules(clock(1, "12:00"))
+	if as := fakeK8s.AutoscaleValue; as == nil || as.Min != 5 {
+		t.Errorf("expected autoscale min 5 on the transition, got %v", as)
+	}
+	if _, found := fakeK8s.SavedAnnotations[TeresaLastUser]; found {
+		t.Error("expected the last user of the app to be kept")
+	}
+	saved := new(App)
+	if err := json.Unmarshal([]byte(fakeK8s.SavedAnnotations[TeresaAnnotation]), saved); err != nil {
+		t.Fatal("error unmarshaling saved app:", err)
+	}
+	if actual := saved.ScaleSchedule.Applied; actual != "0-08:00-20:00" {
+		t.Errorf("expected applied period 0-08:00-20:00, got %s", actual)
+	}
+}
+
+func TestAppOperationsSetAutoscaleSchedule(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	windows := []*ScaleWindow{
+		{Start: "00:00", End: "12:00", Min: 5, Max: 20},
+		{Start: "12:00", End: "00:00", Min: 5, Max: 20},
+	}
+
+	if err := ops.SetAutoscaleSchedule(user, app.Name, windows); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if as := fakeK8s.AutoscaleValue; as == nil || as.Min != 5 || as.Max != 20 {
+		t.Errorf("expected autoscale 5-20, got %v", as)
+	}
+}
+
+func TestAppOperationsSetAutoscaleScheduleWithoutAutoscale(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{
+		NoAutoscale:             true,
+		DeploySetReplicasValues: make(map[string]int32),
+	}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	windows := []*ScaleWindow{
+		{Start: "00:00", End: "12:00", Min: 5},
+		{Start: "12:00", End: "00:00", Min: 5},
+	}
+
+	if err := ops.SetAutoscaleSchedule(user, app.Name, windows); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if n := fakeK8s.DeploySetReplicasValues["test"]; n != 5 { // see fakeK8sOperations.NamespaceAnnotation
+		t.Errorf("expected 5 replicas, got %d", n)
+	}
+}
+
+func TestAppOperationsSetAutoscaleScheduleInvalidWindow(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	windows := []*ScaleWindow{{Start: "20:00", End: "20:00", Min: 5}}
+
+	if err := ops.SetAutoscaleSchedule(user, app.Name, windows); err != ErrInvalidScaleWindow {
+		t.Errorf("expected ErrInvalidScaleWindow, got %v", err)
+	}
+}
+
+func TestAppOperationsSetAutoscaleScheduleErrPermissionDenied(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+
+	if err := ops.SetAutoscaleSchedule(user, "teresa", nil); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
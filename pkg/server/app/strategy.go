@@ -0,0 +1,83 @@
+package app
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultMaxSurge and DefaultMaxUnavailable are the ones used by
+	// Kubernetes
+	DefaultMaxSurge       = "25%"
+	DefaultMaxUnavailable = "25%"
+
+	// MinRolloutTimeoutSeconds and MaxRolloutTimeoutSeconds bound the time
+	// the pods of a deploy have to get ready
+	MinRolloutTimeoutSeconds = 30
+	MaxRolloutTimeoutSeconds = 3600
+)
+
+var rolloutValueRegexp = regexp.MustCompile(`^[0-9]+%?$`)
+
+// RollingUpdate controls the rollout of the deploys of the App, up to
+// MaxSurge pods are created above the replicas and up to MaxUnavailable
+// pods are missing while it runs. Both are numbers of pods or percentages
+// of the replicas, blank values use the defaults
+type RollingUpdate struct {
+	MaxSurge       string `json:"maxSurge,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+func validRolloutValue(s string) bool {
+	if s == "" {
+		return true
+	}
+	if !rolloutValueRegexp.MatchString(s) {
+		return false
+	}
+	n, _ := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	return !strings.HasSuffix(s, "%") || n <= 100
+}
+
+func isZeroRolloutValue(s string) bool {
+	n, err := strconv.Atoi(strings.TrimSuffix(s, "%"))
+	return err == nil && n == 0
+}
+
+// ValidateRollingUpdate checks the values of the strategy, the rollout
+// can't make progress if both of them are zero
+func ValidateRollingUpdate(ru *RollingUpdate) error {
+	if !validRolloutValue(ru.MaxSurge) || !validRolloutValue(ru.MaxUnavailable) {
+		return ErrInvalidRollingUpdate
+	}
+	if isZeroRolloutValue(ru.MaxSurge) && isZeroRolloutValue(ru.MaxUnavailable) {
+		return ErrInvalidRollingUpdate
+	}
+	return nil
+}
+
+// WithDefaults returns a copy of the strategy with the blank values set to
+// the defaults
+func (ru *RollingUpdate) WithDefaults() *RollingUpdate {
+	c := *ru
+	if c.MaxSurge == "" {
+		c.MaxSurge = DefaultMaxSurge
+	}
+	if c.MaxUnavailable == "" {
+		c.MaxUnavailable = DefaultMaxUnavailable
+	}
+	return &c
+}
+
+// ValidateRolloutTimeout checks the rollout timeout of the app, zero uses
+// the one of the cluster
+func ValidateRolloutTimeout(seconds int32) error {
+	if seconds == 0 {
+		return nil
+	}
+	if seconds < MinRolloutTimeoutSeconds || seconds > MaxRolloutTimeoutSeconds {
+		return ErrInvalidRolloutTimeout
+	}
+	return nil
+}
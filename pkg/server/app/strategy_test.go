@@ -0,0 +1,136 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidateRollingUpdate(t *testing.T) {
+	var testCases = []struct {
+		ru       *RollingUpdate
+		expected error
+	}{
+		{&RollingUpdate{}, nil},
+		{&RollingUpdate{MaxSurge: "3", MaxUnavailable: "0"}, nil},
+		{&RollingUpdate{MaxSurge: "100%", MaxUnavailable: "10%"}, nil},
+		{&RollingUpdate{MaxUnavailable: "0"}, nil},
+		{&RollingUpdate{MaxSurge: "0", MaxUnavailable: "0%"}, ErrInvalidRollingUpdate},
+		{&RollingUpdate{MaxSurge: "101%"}, ErrInvalidRollingUpdate},
+		{&RollingUpdate{MaxUnavailable: "-1"}, ErrInvalidRollingUpdate},
+		{&RollingUpdate{MaxSurge: "ten"}, ErrInvalidRollingUpdate},
+	}
+
+	for _, tc := range testCases {
+		if got := ValidateRollingUpdate(tc.ru); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.ru)
+		}
+	}
+}
+
+func TestRollingUpdateWithDefaults(t *testing.T) {
+	ru := &RollingUpdate{MaxUnavailable: "0"}
+	expected := &RollingUpdate{MaxSurge: DefaultMaxSurge, MaxUnavailable: "0"}
+
+	if got := ru.WithDefaults(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if ru.MaxSurge != "" {
+		t.Errorf("expected the strategy unchanged, got %v", ru)
+	}
+}
+
+func TestAppOperationsSetRollingUpdate(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetRollingUpdate(user, "teresa", &RollingUpdate{MaxSurge: "2"}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := map[string]*RollingUpdate{
+		"test": {MaxSurge: "2", MaxUnavailable: DefaultMaxUnavailable},
+	}
+	if !reflect.DeepEqual(fakeK8s.RollingUpdates, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.RollingUpdates)
+	}
+}
+
+func TestAppOperationsSetRollingUpdateErrors(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	invalid := &RollingUpdate{MaxSurge: "0", MaxUnavailable: "0"}
+	if err := ops.SetRollingUpdate(user, "teresa", invalid); err != ErrInvalidRollingUpdate {
+		t.Errorf("expected ErrInvalidRollingUpdate, got %v", err)
+	}
+	if err := ops.SetRollingUpdate(user, "teresa", &RollingUpdate{}); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.SetRollingUpdate(bad, "teresa", &RollingUpdate{}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if len(fakeK8s.RollingUpdates) != 0 {
+		t.Errorf("expected no deploy updated, got %v", fakeK8s.RollingUpdates)
+	}
+}
+
+func TestValidateRolloutTimeout(t *testing.T) {
+	var testCases = []struct {
+		seconds  int32
+		expected error
+	}{
+		{0, nil},
+		{MinRolloutTimeoutSeconds, nil},
+		{900, nil},
+		{MaxRolloutTimeoutSeconds, nil},
+		{10, ErrInvalidRolloutTimeout},
+		{-60, ErrInvalidRolloutTimeout},
+		{MaxRolloutTimeoutSeconds + 1, ErrInvalidRolloutTimeout},
+	}
+
+	for _, tc := range testCases {
+		if got := ValidateRolloutTimeout(tc.seconds); got != tc.expected {
+			t.Errorf("expected %v, got %v for %d", tc.expected, got, tc.seconds)
+		}
+	}
+}
+
+func TestAppOperationsSetRolloutTimeoutErrors(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{DefaultProcessType: ProcessTypeCronPrefix}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.SetRolloutTimeout(user, "teresa", 5); err != ErrInvalidRolloutTimeout {
+		t.Errorf("expected ErrInvalidRolloutTimeout, got %v", err)
+	}
+	if err := ops.SetRolloutTimeout(user, "teresa", 600); err != ErrInvalidActionForCronJob {
+		t.Errorf("expected ErrInvalidActionForCronJob, got %v", err)
+	}
+
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.SetRolloutTimeout(bad, "teresa", 600); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"path"
+	"regexp"
+)
+
+const (
+	AccessModeReadWriteOnce = "ReadWriteOnce"
+	AccessModeReadWriteMany = "ReadWriteMany"
+	// maxVolumeNameLength leaves room for the prefix of the pod volume
+	maxVolumeNameLength = 59
+)
+
+var (
+	volumeNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	volumeSizeRegexp = regexp.MustCompile(`^[1-9][0-9]*(Mi|Gi|Ti)$`)
+)
+
+// reservedMountPaths are used by the containers built by Teresa
+var reservedMountPaths = []string{"/", "/app", "/slug", SecretPath}
+
+// VolumeClaim is a PersistentVolumeClaim of the App mounted in the app
+// container, blank StorageClass uses the cluster default and blank
+// AccessMode ReadWriteOnce
+type VolumeClaim struct {
+	Name         string `json:"name"`
+	Size         string `json:"size"`
+	StorageClass string `json:"storageClass,omitempty"`
+	AccessMode   string `json:"accessMode,omitempty"`
+	MountPath    string `json:"mountPath"`
+}
+
+// validateVolumeClaim checks vc against the volume claims the App already
+// has, names and mount paths must be unique
+func validateVolumeClaim(vc *VolumeClaim, claims []*VolumeClaim) error {
+	if len(vc.Name) > maxVolumeNameLength || !volumeNameRegexp.MatchString(vc.Name) {
+		return ErrInvalidVolumeClaim
+	}
+	if !volumeSizeRegexp.MatchString(vc.Size) {
+		return ErrInvalidVolumeClaim
+	}
+	switch vc.AccessMode {
+	case "", AccessModeReadWriteOnce, AccessModeReadWriteMany:
+	default:
+		return ErrInvalidVolumeClaim
+	}
+	mountPath := path.Clean(vc.MountPath)
+	if !path.IsAbs(vc.MountPath) || hasString(reservedMountPaths, mountPath) {
+		return ErrInvalidVolumeClaim
+	}
+	for _, c := range claims {
+		if c.Name == vc.Name {
+			return ErrVolumeClaimAlreadyExists
+		}
+		if path.Clean(c.MountPath) == mountPath {
+			return ErrInvalidVolumeClaim
+		}
+	}
+	return nil
+}
+
+func volumeClaimIndex(claims []*VolumeClaim, name string) int {
+	for i, c := range claims {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
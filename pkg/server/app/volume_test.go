@@ -0,0 +1,154 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	st "github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func TestValidateVolumeClaim(t *testing.T) {
+	claims := []*VolumeClaim{{Name: "data", Size: "1Gi", MountPath: "/data"}}
+
+	var testCases = []struct {
+		vc       *VolumeClaim
+		expected error
+	}{
+		{&VolumeClaim{Name: "cache", Size: "10Gi", MountPath: "/cache"}, nil},
+		{&VolumeClaim{Name: "cache", Size: "512Mi", StorageClass: "ssd", AccessMode: AccessModeReadWriteMany, MountPath: "/var/cache"}, nil},
+		{&VolumeClaim{Name: "Cache", Size: "10Gi", MountPath: "/cache"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "10", MountPath: "/cache"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "0Gi", MountPath: "/cache"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "10Gi", AccessMode: "ReadOnlyMany", MountPath: "/cache"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "10Gi", MountPath: "cache"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "10Gi", MountPath: "/app/"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "cache", Size: "10Gi", MountPath: "/data"}, ErrInvalidVolumeClaim},
+		{&VolumeClaim{Name: "data", Size: "10Gi", MountPath: "/cache"}, ErrVolumeClaimAlreadyExists},
+	}
+
+	for _, tc := range testCases {
+		if got := validateVolumeClaim(tc.vc, claims); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.vc)
+		}
+	}
+}
+
+func TestAppOperationsAddVolume(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	vc := &VolumeClaim{Name: "cache", Size: "1Gi", MountPath: "/cache"}
+
+	if err := ops.AddVolume(user, app.Name, vc); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fakeK8s.CreatedPVCs, []string{"cache"}) {
+		t.Errorf("expected [cache], got %v", fakeK8s.CreatedPVCs)
+	}
+}
+
+func TestAppOperationsAddVolumeInvalid(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{AppVolumeClaims: true}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+	vc := &VolumeClaim{Name: "data", Size: "1Gi", MountPath: "/cache"}
+
+	if err := ops.AddVolume(user, app.Name, vc); err != ErrVolumeClaimAlreadyExists {
+		t.Errorf("expected ErrVolumeClaimAlreadyExists, got %v", err)
+	}
+	if len(fakeK8s.CreatedPVCs) != 0 {
+		t.Errorf("expected no PVC created, got %v", fakeK8s.CreatedPVCs)
+	}
+}
+
+func TestAppOperationsAddVolumeErrPermissionDenied(t *testing.T) {
+	tops := team.NewFakeOperations()
+	ops := NewOperations(tops, &fakeK8sOperations{}, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	vc := &VolumeClaim{Name: "cache", Size: "1Gi", MountPath: "/cache"}
+
+	if err := ops.AddVolume(user, "teresa", vc); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAppOperationsRemoveVolume(t *testing.T) {
+	tops := team.NewFakeOperations()
+	fakeK8s := &fakeK8sOperations{AppVolumeClaims: true}
+	ops := NewOperations(tops, fakeK8s, nil)
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	app := &App{Name: "teresa", Team: "luizalabs"}
+	tops.(*team.FakeOperations).Storage[app.Team] = &database.Team{
+		Name:  app.Team,
+		Users: []database.User{*user},
+	}
+
+	if err := ops.RemoveVolume(user, app.Name, "cache"); err != ErrVolumeClaimNotFound {
+		t.Errorf("expected ErrVolumeClaimNotFound, got %v", err)
+	}
+	if err := ops.RemoveVolume(user, app.Name, "data"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fakeK8s.DeletedPVCs, []string{"data"}) {
+		t.Errorf("expected [data], got %v", fakeK8s.DeletedPVCs)
+	}
+}
+
+func TestAppOperationsRenameWithVolumes(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace: "new-teresa",
+		Namespaces:       map[string]struct{}{"teresa": {}},
+		AppVolumeClaims:  true,
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Rename(user, "teresa", "new-teresa"); err != ErrRenameWithVolumeClaims {
+		t.Errorf("expected ErrRenameWithVolumeClaims, got %v", err)
+	}
+	if _, found := k8s.Namespaces["teresa"]; !found {
+		t.Error("expected old namespace to be kept, but it was deleted")
+	}
+}
+
+func TestAppOperationsCloneWithVolumes(t *testing.T) {
+	tops := team.NewFakeOperations()
+	k8s := &fakeK8sOperations{
+		MissingNamespace: "teresa-staging",
+		Namespaces:       map[string]struct{}{"teresa": {}},
+		AppVolumeClaims:  true,
+	}
+	ops := NewOperations(tops, k8s, st.NewFake())
+	user := &database.User{Email: "teresa@luizalabs.com"}
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+
+	if err := ops.Clone(user, "teresa", "teresa-staging", "staging.teresa.io"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(k8s.CreatedPVCs, []string{"data"}) {
+		t.Errorf("expected [data], got %v", k8s.CreatedPVCs)
+	}
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const defaultLimit = 100
+
+// Entry is a mutating RPC called by a user, Request is a summary of the
+// request without secrets and Status is the gRPC code of the result. The RPCs
+// of an admin impersonating a user have the admin as Impersonator.
+type Entry struct {
+	CreatedAt    time.Time
+	User         string
+	Impersonator string
+	Method       string
+	Team         string
+	App          string
+	Request      string
+	Status       string
+}
+
+// Filter selects the entries of the audit log, blank fields match any
+// entry. The newest entries come first, up to Limit of them.
+type Filter struct {
+	Team  string
+	User  string
+	Since time.Time
+	Until time.Time
+	Limit int
+}
+
+type Operations interface {
+	Record(e *Entry) error
+	List(f *Filter) ([]*Entry, error)
+}
+
+type DatabaseOperations struct {
+	DB *gorm.DB
+}
+
+func (ops *DatabaseOperations) Record(e *Entry) error {
+	dbe := &database.AuditEntry{
+		Author:       e.User,
+		Impersonator: e.Impersonator,
+		Method:       e.Method,
+		Team:         e.Team,
+		App:          e.App,
+		Request:      e.Request,
+		Status:       e.Status,
+	}
+	if err := ops.DB.Create(dbe).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+func (ops *DatabaseOperations) List(f *Filter) ([]*Entry, error) {
+	if err := checkFilter(f); err != nil {
+		return nil, err
+	}
+
+	q := ops.DB.Order("created_at desc, id desc").Limit(limitOf(f))
+	if f.Team != "" {
+		q = q.Where("team = ?", f.Team)
+	}
+	if f.User != "" {
+		q = q.Where("author = ?", f.User)
+	}
+	if !f.Since.IsZero() {
+		q = q.Where("created_at >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		q = q.Where("created_at < ?", f.Until)
+	}
+
+	var dbes []*database.AuditEntry
+	if err := q.Find(&dbes).Error; err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	entries := make([]*Entry, len(dbes))
+	for i, dbe := range dbes {
+		entries[i] = &Entry{
+			CreatedAt:    dbe.CreatedAt,
+			User:         dbe.Author,
+			Impersonator: dbe.Impersonator,
+			Method:       dbe.Method,
+			Team:         dbe.Team,
+			App:          dbe.App,
+			Request:      dbe.Request,
+			Status:       dbe.Status,
+		}
+	}
+	return entries, nil
+}
+
+func checkFilter(f *Filter) error {
+	if !f.Since.IsZero() && !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+func limitOf(f *Filter) int {
+	if f.Limit <= 0 {
+		return defaultLimit
+	}
+	return f.Limit
+}
+
+func NewDatabaseOperations(db *gorm.DB) Operations {
+	return &DatabaseOperations{DB: db}
+}
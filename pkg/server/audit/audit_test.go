@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestDatabaseOperationsRecordAndList(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	ops := NewDatabaseOperations(db)
+	entries := []*Entry{
+		{User: "gopher@luizalabs.com", Method: "/app.App/Create", Team: "luizalabs", App: "teresa", Status: "OK"},
+		{User: "admin@luizalabs.com", Method: "/team.Team/Create", Team: "gophers", Status: "OK"},
+		{User: "gopher@luizalabs.com", Method: "/app.App/Delete", Team: "luizalabs", App: "teresa", Status: "PermissionDenied"},
+	}
+	for _, e := range entries {
+		if err := ops.Record(e); err != nil {
+			t.Fatal("error recording the entry:", err)
+		}
+	}
+
+	got, err := ops.List(&Filter{})
+	if err != nil {
+		t.Fatal("error listing the entries:", err)
+	}
+	if len(got) != 3 || got[0].Method != "/app.App/Delete" {
+		t.Errorf("expected the 3 entries newest first, got %v", got)
+	}
+
+	got, err = ops.List(&Filter{Team: "luizalabs", User: "gopher@luizalabs.com", Limit: 1})
+	if err != nil {
+		t.Fatal("error listing the entries:", err)
+	}
+	if len(got) != 1 || got[0].Status != "PermissionDenied" {
+		t.Errorf("expected the last entry of the team, got %v", got)
+	}
+
+	// moves the first entry back in time
+	db.Model(&database.AuditEntry{}).Where("method = ?", "/app.App/Create").
+		Update("created_at", time.Now().Add(-48*time.Hour))
+	got, err = ops.List(&Filter{Since: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatal("error listing the entries:", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 entries since yesterday, got %d", len(got))
+	}
+	got, err = ops.List(&Filter{Until: time.Now().Add(-24 * time.Hour)})
+	if err != nil {
+		t.Fatal("error listing the entries:", err)
+	}
+	if len(got) != 1 || got[0].Method != "/app.App/Create" {
+		t.Errorf("expected the entry before yesterday, got %v", got)
+	}
+}
+
+func TestDatabaseOperationsListInvalidRange(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	ops := NewDatabaseOperations(db)
+	now := time.Now()
+	if _, err := ops.List(&Filter{Since: now, Until: now.Add(-time.Hour)}); err != ErrInvalidRange {
+		t.Errorf("expected ErrInvalidRange, got %v", err)
+	}
+}
@@ -0,0 +1,10 @@
+package audit
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidRange = status.Errorf(codes.InvalidArgument, "Invalid time range, the start must be before the end")
+)
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeOperations keeps the entries in memory, the newest first
+type FakeOperations struct {
+	mutex   *sync.RWMutex
+	Entries []*Entry
+}
+
+func (f *FakeOperations) Record(e *Entry) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	f.Entries = append([]*Entry{e}, f.Entries...)
+	return nil
+}
+
+func (f *FakeOperations) List(filter *Filter) ([]*Entry, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if err := checkFilter(filter); err != nil {
+		return nil, err
+	}
+
+	entries := []*Entry{}
+	for _, e := range f.Entries {
+		if len(entries) == limitOf(filter) {
+			break
+		}
+		if filter.Team != "" && e.Team != filter.Team {
+			continue
+		}
+		if filter.User != "" && e.User != filter.User {
+			continue
+		}
+		if !filter.Since.IsZero() && e.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !e.CreatedAt.Before(filter.Until) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func NewFakeOperations() Operations {
+	return &FakeOperations{
+		mutex:   &sync.RWMutex{},
+		Entries: []*Entry{},
+	}
+}
@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"time"
+
+	context "golang.org/x/net/context"
+
+	auditpb "github.com/luizalabs/teresa/pkg/protobuf/audit"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"google.golang.org/grpc"
+)
+
+// TeamRoles tells the roles of the members of the teams
+type TeamRoles interface {
+	HasRole(name string, user *database.User, role string) (bool, error)
+}
+
+type Service struct {
+	ops   Operations
+	roles TeamRoles
+}
+
+// checkPerm allows the admins to see the whole audit log and the admins of
+// a team to see the entries of the team
+func (s *Service) checkPerm(u *database.User, teamName string) error {
+	if u.IsAdmin {
+		return nil
+	}
+	if teamName == "" {
+		return auth.ErrPermissionDenied
+	}
+	ok, err := s.roles.HasRole(teamName, u, team.RoleAdmin)
+	if err != nil || !ok {
+		return auth.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *Service) List(ctx context.Context, req *auditpb.ListRequest) (*auditpb.ListResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkPerm(u, req.Team); err != nil {
+		return nil, err
+	}
+
+	f := &Filter{Team: req.Team, User: req.User, Limit: int(req.Limit)}
+	if req.Since > 0 {
+		f.Since = time.Unix(req.Since, 0)
+	}
+	if req.Until > 0 {
+		f.Until = time.Unix(req.Until, 0)
+	}
+	entries, err := s.ops.List(f)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &auditpb.ListResponse{}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, &auditpb.ListResponse_Entry{
+			CreatedAt:    e.CreatedAt.Unix(),
+			User:         e.User,
+			Method:       e.Method,
+			Team:         e.Team,
+			App:          e.App,
+			Request:      e.Request,
+			Status:       e.Status,
+			Impersonator: e.Impersonator,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) RegisterService(grpcServer *grpc.Server) {
+	auditpb.RegisterAuditServer(grpcServer, s)
+}
+
+func NewService(ops Operations, roles TeamRoles) *Service {
+	return &Service{ops: ops, roles: roles}
+}
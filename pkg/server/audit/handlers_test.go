@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"testing"
+
+	context "golang.org/x/net/context"
+
+	auditpb "github.com/luizalabs/teresa/pkg/protobuf/audit"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+type fakeRoles struct {
+	admins map[string]string
+}
+
+func (f *fakeRoles) HasRole(name string, user *database.User, role string) (bool, error) {
+	return role == team.RoleAdmin && f.admins[user.Email] == name, nil
+}
+
+func TestListPermissions(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.Record(&Entry{User: "gopher@luizalabs.com", Method: "/app.App/Create", Team: "luizalabs", App: "teresa"})
+	fake.Record(&Entry{User: "admin@luizalabs.com", Method: "/team.Team/Create", Team: "gophers"})
+	s := NewService(fake, &fakeRoles{admins: map[string]string{"gopher@luizalabs.com": "luizalabs"}})
+
+	teamAdmin := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+	if _, err := s.List(teamAdmin, &auditpb.ListRequest{}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := s.List(teamAdmin, &auditpb.ListRequest{Team: "gophers"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	resp, err := s.List(teamAdmin, &auditpb.ListRequest{Team: "luizalabs"})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].App != "teresa" {
+		t.Errorf("expected the entry of the team, got %v", resp.Entries)
+	}
+
+	admin := context.WithValue(context.Background(), "user", &database.User{Email: "admin@luizalabs.com", IsAdmin: true})
+	resp, err = s.List(admin, &auditpb.ListRequest{User: "admin@luizalabs.com"})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].Method != "/team.Team/Create" {
+		t.Errorf("expected the entry of the user, got %v", resp.Entries)
+	}
+}
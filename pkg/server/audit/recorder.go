@@ -0,0 +1,55 @@
+package audit
+
+import (
+	log "github.com/Sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+// AppTeams gives the team of an app, for the entries targeting apps
+type AppTeams interface {
+	TeamName(appName string) (string, error)
+}
+
+// Recorder builds the entries of the mutating RPCs and saves them
+type Recorder struct {
+	ops  Operations
+	apps AppTeams
+}
+
+// NewEntry returns the entry of the RPC fullMethod called by user, or by the
+// impersonator acting as user, it must be called before the RPC runs so the
+// team of a deleted app is known
+func (r *Recorder) NewEntry(user, impersonator *database.User, fullMethod string, req interface{}) *Entry {
+	e := &Entry{Method: fullMethod, Request: Summary(fullMethod, req)}
+	if user != nil {
+		e.User = user.Email
+	}
+	if impersonator != nil {
+		e.Impersonator = impersonator.Email
+	}
+	e.Team, e.App = target(fullMethod, req)
+	if e.Team == "" && e.App != "" && r.apps != nil {
+		// the app may not exist (yet), the entry goes without its team
+		e.Team, _ = r.apps.TeamName(e.App)
+	}
+	return e
+}
+
+// Record saves the entry with the result err of its RPC. The RPC already
+// ran, so a failure is only logged.
+func (r *Recorder) Record(e *Entry, err error) {
+	e.Status = grpc.Code(err).String()
+	if err := r.ops.Record(e); err != nil {
+		log.WithError(err).WithField("method", e.Method).Error("recording the audit entry")
+	}
+}
+
+func (r *Recorder) SetAppTeams(apps AppTeams) {
+	r.apps = apps
+}
+
+func NewRecorder(ops Operations) *Recorder {
+	return &Recorder{ops: ops}
+}
@@ -0,0 +1,163 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	maxRequestLen = 1024
+	redacted      = "<redacted>"
+)
+
+// readOnlyMethods are the RPCs, by method name, left out of the audit log
+// on top of the ones starting with List
+var readOnlyMethods = map[string]bool{
+	"Login":       true,
+	"BeginLogin":  true,
+	"Refresh":     true,
+	"Info":        true,
+	"Status":      true,
+	"Top":         true,
+	"Logs":        true,
+	"Events":      true,
+	"CronRuns":    true,
+	"CronRunLogs": true,
+	"EnvHistory":  true,
+	"PortForward": true,
+	"CopyFrom":    true,
+}
+
+// secretFields are blanked from the summaries of the requests, Value only
+// on the methods setting secrets or env vars
+var secretFields = map[string]bool{
+	"Password":     true,
+	"Token":        true,
+	"Cert":         true,
+	"Code":         true,
+	"Otp":          true,
+	"RefreshToken": true,
+	"NewPassword":  true,
+}
+
+// IsMutating tells if the RPC fullMethod, like /app.App/Create, changes
+// anything and so goes to the audit log
+func IsMutating(fullMethod string) bool {
+	name := methodName(fullMethod)
+	return !strings.HasPrefix(name, "List") && !readOnlyMethods[name]
+}
+
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func serviceName(fullMethod string) string {
+	s := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.Index(s, "/"); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// Summary returns the request req of the RPC fullMethod in the text format,
+// without binary data, secrets and the values of the env vars
+func Summary(fullMethod string, req interface{}) string {
+	msg, ok := req.(proto.Message)
+	if !ok || msg == nil {
+		return ""
+	}
+	msg = proto.Clone(msg)
+	name := methodName(fullMethod)
+	isSecret := strings.Contains(name, "Secret") || strings.Contains(name, "Env")
+	redact(reflect.ValueOf(msg), isSecret)
+
+	s := proto.CompactTextString(msg)
+	if len(s) > maxRequestLen {
+		s = s[:maxRequestLen] + "..."
+	}
+	return s
+}
+
+func redact(v reflect.Value, isSecret bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			redact(v.Elem(), isSecret)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.CanSet() {
+				v.SetBytes(nil)
+			}
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			redact(v.Index(i), isSecret)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f, name := v.Field(i), v.Type().Field(i).Name
+			if f.Kind() == reflect.String && f.CanSet() && f.String() != "" {
+				if secretFields[name] || (isSecret && name == "Value") {
+					f.SetString(redacted)
+				}
+				continue
+			}
+			redact(f, isSecret)
+		}
+	}
+}
+
+// target returns the team and the app targeted by the request req of the
+// RPC fullMethod, the ones not found are blank
+func target(fullMethod string, req interface{}) (teamName, appName string) {
+	if req == nil {
+		return "", ""
+	}
+	switch serviceName(fullMethod) {
+	case "team.Team":
+		return stringOf(req, "GetName", "GetOldName", "GetTeam"), ""
+	case "app.App":
+		appName = stringOf(req, "GetName", "GetAppName", "GetOldName", "GetSrcName")
+	default:
+		appName = stringOf(req, "GetAppName", "GetApp")
+		if appName == "" {
+			appName = stringOf(infoOf(req), "GetApp", "GetAppName")
+		}
+	}
+	return stringOf(req, "GetTeam"), appName
+}
+
+// stringOf returns the first non blank string returned by the getters of v
+func stringOf(v interface{}, getters ...string) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	for _, g := range getters {
+		m := rv.MethodByName(g)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+		if out := m.Call(nil)[0]; out.Kind() == reflect.String && out.String() != "" {
+			return out.String()
+		}
+	}
+	return ""
+}
+
+// infoOf returns the Info of the streaming requests carrying files, like
+// the ones of the deploys and builds
+func infoOf(req interface{}) interface{} {
+	m := reflect.ValueOf(req).MethodByName("GetInfo")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+	out := m.Call(nil)[0]
+	if out.Kind() == reflect.Ptr && out.IsNil() {
+		return nil
+	}
+	return out.Interface()
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
+	teampb "github.com/luizalabs/teresa/pkg/protobuf/team"
+	userpb "github.com/luizalabs/teresa/pkg/protobuf/user"
+)
+
+func TestIsMutating(t *testing.T) {
+	var testCases = []struct {
+		method   string
+		expected bool
+	}{
+		{"/app.App/Create", true},
+		{"/app.App/SetEnv", true},
+		{"/deploy.Deploy/Make", true},
+		{"/exec.Exec/Run", true},
+		{"/app.App/List", false},
+		{"/app.App/Info", false},
+		{"/app.App/Logs", false},
+		{"/team.Team/ListUsers", false},
+		{"/user.User/Login", false},
+		{"/audit.Audit/List", false},
+	}
+
+	for _, tc := range testCases {
+		if got := IsMutating(tc.method); got != tc.expected {
+			t.Errorf("expected %v for %s, got %v", tc.expected, tc.method, got)
+		}
+	}
+}
+
+func TestSummaryRedactsSecrets(t *testing.T) {
+	req := &appb.SetSecretRequest{
+		Name: "teresa",
+		SecretEnvs: []*appb.SetEnvRequest_EnvVar{
+			{Key: "DB_PASSWORD", Value: "s3cr3t"},
+		},
+		SecretFile: &appb.SetSecretRequest_SecretFile{Key: "key.pem", Content: []byte("private key")},
+	}
+	s := Summary("/app.App/SetSecret", req)
+	if strings.Contains(s, "s3cr3t") || strings.Contains(s, "private key") {
+		t.Errorf("expected the secrets redacted, got %s", s)
+	}
+	if !strings.Contains(s, "DB_PASSWORD") || !strings.Contains(s, "key.pem") {
+		t.Errorf("expected the keys of the secrets, got %s", s)
+	}
+	if len(req.SecretEnvs[0].Value) == 0 || len(req.SecretFile.Content) == 0 {
+		t.Error("expected the request untouched")
+	}
+
+	s = Summary("/user.User/SetPassword", &userpb.SetPasswordRequest{Password: "12345678"})
+	if strings.Contains(s, "12345678") {
+		t.Errorf("expected the password redacted, got %s", s)
+	}
+
+	for _, method := range []string{"/app.App/SetEnv", "/app.App/SetBuildEnv", "/envgroup.EnvGroup/SetEnv", "/team.Team/SetEnv"} {
+		s = Summary(method, &appb.SetEnvRequest{
+			Name:    "teresa",
+			EnvVars: []*appb.SetEnvRequest_EnvVar{{Key: "DB_URL", Value: "postgres://teresa:s3cr3t@db"}},
+		})
+		if strings.Contains(s, "s3cr3t") {
+			t.Errorf("expected the values of the env vars redacted for %s, got %s", method, s)
+		}
+		if !strings.Contains(s, "DB_URL") {
+			t.Errorf("expected the keys of the env vars for %s, got %s", method, s)
+		}
+	}
+}
+
+func TestTarget(t *testing.T) {
+	deployReq := &dpb.DeployRequest{
+		Value: &dpb.DeployRequest_Info_{Info: &dpb.DeployRequest_Info{App: "teresa"}},
+	}
+
+	var testCases = []struct {
+		method       string
+		req          interface{}
+		expectedTeam string
+		expectedApp  string
+	}{
+		{"/app.App/Create", &appb.CreateRequest{Name: "teresa", Team: "luizalabs"}, "luizalabs", "teresa"},
+		{"/app.App/SetEnv", &appb.SetEnvRequest{Name: "teresa"}, "", "teresa"},
+		{"/app.App/ChangeTeam", &appb.ChangeTeamRequest{AppName: "teresa", TeamName: "gophers"}, "", "teresa"},
+		{"/team.Team/AddUser", &teampb.AddUserRequest{Name: "luizalabs", User: "gopher@luizalabs.com"}, "luizalabs", ""},
+		{"/deploy.Deploy/Make", deployReq, "", "teresa"},
+		{"/deploy.Deploy/Rollback", &dpb.RollbackRequest{AppName: "teresa"}, "", "teresa"},
+		{"/deploy.Deploy/Make", nil, "", ""},
+	}
+
+	for _, tc := range testCases {
+		teamName, appName := target(tc.method, tc.req)
+		if teamName != tc.expectedTeam || appName != tc.expectedApp {
+			t.Errorf("expected %s/%s for %s, got %s/%s", tc.expectedTeam, tc.expectedApp, tc.method, teamName, appName)
+		}
+	}
+}
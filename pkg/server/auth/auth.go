@@ -2,6 +2,8 @@ package auth
 
 import (
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -9,41 +11,121 @@ import (
 
 type Auth interface {
 	GenerateToken(email string, exp time.Duration) (string, error)
+	GenerateSessionToken(email, session string, exp time.Duration) (string, error)
 	ValidateToken(token string) (string, error)
+	ParseToken(token string) (*Claims, error)
 }
 
-type tokenClaim struct {
-	Email string `json:"email"`
+// Claims are the claims of a valid token, Session is blank on the tokens
+// not bound to a session, like the ones of the team tokens
+type Claims struct {
+	Email   string `json:"email"`
+	Session string `json:"sid,omitempty"`
 	jwt.StandardClaims
 }
 
+// JWTAuth signs the tokens with the private key, naming it by the key ID in
+// the kid header, and verifies them with any of the public keys, so the
+// keypair is rotated without invalidating the tokens signed by the old one
 type JWTAuth struct {
 	privateKey *rsa.PrivateKey
-	publicKey  *rsa.PublicKey
+	keyID      string
+	publicKeys map[string]*rsa.PublicKey
+}
+
+// KeyID returns the ID of a public key, the first bytes of the hash of its
+// modulus in hex
+func KeyID(key *rsa.PublicKey) string {
+	sum := sha256.Sum256(key.N.Bytes())
+	return hex.EncodeToString(sum[:8])
 }
 
 func (a *JWTAuth) GenerateToken(email string, exp time.Duration) (string, error) {
+	return a.GenerateSessionToken(email, "", exp)
+}
+
+// GenerateSessionToken returns a token bound to the session, valid only
+// while the session is
+func (a *JWTAuth) GenerateSessionToken(email, session string, exp time.Duration) (string, error) {
 	jwtClaims := jwt.MapClaims{
 		"email": email,
 		"exp":   time.Now().Add(exp).Unix()}
+	if session != "" {
+		jwtClaims["sid"] = session
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwtClaims)
+	token.Header["kid"] = a.keyID
 	return token.SignedString(a.privateKey)
 }
 
 func (a *JWTAuth) ValidateToken(token string) (string, error) {
-	parsedToken, err := jwt.ParseWithClaims(token, &tokenClaim{}, func(*jwt.Token) (interface{}, error) {
-		return a.publicKey, nil
+	claims, err := a.ParseToken(token)
+	if err != nil {
+		return "", err
+	}
+	return claims.Email, nil
+}
+
+func (a *JWTAuth) ParseToken(token string) (*Claims, error) {
+	legacy := false
+	claims, err := parseToken(token, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			legacy = true
+			return nil, ErrPermissionDenied
+		}
+		key, found := a.publicKeys[kid]
+		if !found {
+			return nil, ErrPermissionDenied
+		}
+		return key, nil
+	})
+	if !legacy {
+		return claims, err
+	}
+
+	// the tokens signed before the key IDs have none, any key is tried
+	for _, key := range a.publicKeys {
+		k := key
+		claims, err := parseToken(token, func(*jwt.Token) (interface{}, error) {
+			return k, nil
+		})
+		if err == nil {
+			return claims, nil
+		}
+	}
+	return nil, ErrPermissionDenied
+}
+
+func parseToken(token string, keyFunc jwt.Keyfunc) (*Claims, error) {
+	parsedToken, err := jwt.ParseWithClaims(token, &Claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, ErrPermissionDenied
+		}
+		return keyFunc(t)
 	})
 	if err != nil || !parsedToken.Valid {
-		return "", ErrPermissionDenied
+		return nil, ErrPermissionDenied
 	}
-	claims, ok := parsedToken.Claims.(*tokenClaim)
+	claims, ok := parsedToken.Claims.(*Claims)
 	if !ok {
-		return "", ErrPermissionDenied
+		return nil, ErrPermissionDenied
 	}
-	return claims.Email, nil
+	return claims, nil
 }
 
-func New(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey) Auth {
-	return &JWTAuth{privateKey: privateKey, publicKey: publicKey}
+// New returns a JWTAuth signing with privateKey and verifying with its
+// public key and the verification keys, like the public key of the previous
+// keypair until its tokens expire, or the one of the next keypair while its
+// private key is rolled out
+func New(privateKey *rsa.PrivateKey, verificationKeys ...*rsa.PublicKey) Auth {
+	a := &JWTAuth{
+		privateKey: privateKey,
+		keyID:      KeyID(&privateKey.PublicKey),
+		publicKeys: map[string]*rsa.PublicKey{KeyID(&privateKey.PublicKey): &privateKey.PublicKey},
+	}
+	for _, key := range verificationKeys {
+		a.publicKeys[KeyID(key)] = key
+	}
+	return a
 }
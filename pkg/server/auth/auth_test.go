@@ -5,6 +5,8 @@ import (
 	"crypto/rsa"
 	"testing"
 	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 var (
@@ -63,3 +65,78 @@ func TestJWTAuthValidateExpiredToken(t *testing.T) {
 		t.Error("expected ErrPermissionDenied, got nil")
 	}
 }
+
+func TestJWTAuthSessionToken(t *testing.T) {
+	a := New(privateKey, publicKey)
+	token, err := a.GenerateSessionToken("gopher@luizalabs.com", "42", time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+
+	claims, err := a.ParseToken(token)
+	if err != nil {
+		t.Fatal("error on parse token: ", err)
+	}
+	if claims.Email != "gopher@luizalabs.com" || claims.Session != "42" {
+		t.Errorf("expected gopher@luizalabs.com and session 42, got %s and %s", claims.Email, claims.Session)
+	}
+
+	token, err = a.GenerateToken("gopher@luizalabs.com", time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	if claims, err = a.ParseToken(token); err != nil || claims.Session != "" {
+		t.Errorf("expected a token without session, got %v:%v", claims, err)
+	}
+}
+
+func TestJWTAuthKeyRotation(t *testing.T) {
+	newPrivateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal("error on generate key: ", err)
+	}
+	old := New(privateKey)
+	token, err := old.GenerateToken("gopher@luizalabs.com", time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+
+	if _, err := New(newPrivateKey).ValidateToken(token); err != ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied for a token of an unknown key, got", err)
+	}
+	rotated := New(newPrivateKey, publicKey)
+	if _, err := rotated.ValidateToken(token); err != nil {
+		t.Error("expected the token of the previous key valid, got", err)
+	}
+
+	newToken, err := rotated.GenerateToken("gopher@luizalabs.com", time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	if _, err := old.ValidateToken(newToken); err != ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	if _, err := New(privateKey, &newPrivateKey.PublicKey).ValidateToken(newToken); err != nil {
+		t.Error("expected the token of the next key valid, got", err)
+	}
+}
+
+func TestJWTAuthTokenWithoutKeyID(t *testing.T) {
+	claims := jwt.MapClaims{"email": "gopher@luizalabs.com", "exp": time.Now().Add(time.Minute).Unix()}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+	if err != nil {
+		t.Fatal("error on sign token: ", err)
+	}
+
+	newPrivateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal("error on generate key: ", err)
+	}
+	email, err := New(newPrivateKey, publicKey).ValidateToken(token)
+	if err != nil || email != "gopher@luizalabs.com" {
+		t.Errorf("expected gopher@luizalabs.com, got %s (err: %v)", email, err)
+	}
+	if _, err := New(newPrivateKey).ValidateToken(token); err != ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+}
@@ -8,10 +8,18 @@ func (*Fake) GenerateToken(email string, exp time.Duration) (string, error) {
 	return "good token", nil
 }
 
+func (*Fake) GenerateSessionToken(email, session string, exp time.Duration) (string, error) {
+	return "good token", nil
+}
+
 func (*Fake) ValidateToken(token string) (string, error) {
 	return "gopher@luizalabs.com", nil
 }
 
+func (*Fake) ParseToken(token string) (*Claims, error) {
+	return &Claims{Email: "gopher@luizalabs.com"}, nil
+}
+
 func NewFake() Auth {
 	return new(Fake)
 }
@@ -12,6 +12,8 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/exec"
 	"github.com/luizalabs/teresa/pkg/server/spec"
 	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 )
 
 type Operations interface {
@@ -20,6 +22,7 @@ type Operations interface {
 	List(appName string, u *database.User) ([]*Build, error)
 	Run(ctx context.Context, appName, buildName string, u *database.User) (io.ReadCloser, <-chan error)
 	Delete(appName, buildName string, u *database.User) error
+	ClearCache(appName string, u *database.User) error
 }
 
 type K8sOperations interface {
@@ -28,6 +31,7 @@ type K8sOperations interface {
 	DeleteService(namespace, name string) error
 	WatchServiceURL(namespace, name string) ([]string, error)
 	IsInvalid(err error) bool
+	CreateOrUpdateSecret(namespace, secretName string, data map[string][]byte) error
 }
 
 type Options struct {
@@ -36,6 +40,14 @@ type Options struct {
 	SlugStoreImage   string
 	BuildLimitCPU    string
 	BuildLimitMemory string
+	KanikoImage      string
+	CNBBuilderImage  string
+	// Registry is the one of the images built, it holds the layer cache of
+	// the Cloud Native Buildpacks builds too
+	Registry string
+	// RegistryConfig is the docker config.json with the credentials to push
+	// the images built to the build registry, blank if the nodes have them
+	RegistryConfig string
 }
 
 type BuildOperations struct {
@@ -47,14 +59,39 @@ type BuildOperations struct {
 	buildLimits *spec.ContainerLimits
 }
 
-// CreateOptions define arguments of method `CreateByOpts`
+// CreateOptions define arguments of method `CreateByOpts`, a non blank
+// Image builds the Dockerfile of the tarball and pushes it there instead of
+// sending a slug to SlugDest. Setting CacheImage too builds the Image with
+// Cloud Native Buildpacks, caching their layers there
 type CreateOptions struct {
-	App       *app.App
-	BuildName string
-	SlugIn    string
-	SlugDest  string
-	TarBall   io.ReadSeeker
-	Stream    io.Writer
+	App        *app.App
+	BuildName  string
+	SlugIn     string
+	SlugDest   string
+	Image      string
+	CacheImage string
+	TarBall    io.ReadSeeker
+	Stream     io.Writer
+}
+
+// Dir holds the tarball and the slug of a build of the app
+func Dir(appName, buildName string) string {
+	return fmt.Sprintf("builds/%s/%s/", appName, buildName)
+}
+
+// TarBallPath is the uploaded tarball of a build of the app
+func TarBallPath(appName, buildName string) string {
+	return Dir(appName, buildName) + "in/app.tgz"
+}
+
+// SlugPath is the slug built by a build of the app
+func SlugPath(appName, buildName string) string {
+	return Dir(appName, buildName) + "out/slug.tgz"
+}
+
+// cacheDir holds the buildpacks cache of the slug builds of an app
+func cacheDir(appName string) string {
+	return fmt.Sprintf("cache/%s/", appName)
 }
 
 func formatPodName(appName, buildName string) string {
@@ -64,7 +101,7 @@ func formatPodName(appName, buildName string) string {
 func (ops *BuildOperations) Create(ctx context.Context, appName, buildName string, u *database.User, tarBall io.ReadSeeker, runApp bool) (io.ReadCloser, <-chan error) {
 	errChan := make(chan error, 1)
 
-	a, err := ops.appOps.CheckPermAndGet(u, appName)
+	a, err := ops.appOps.CheckRoleAndGet(u, appName, team.RoleDeployer)
 	if err != nil {
 		errChan <- err
 		return nil, errChan
@@ -77,7 +114,7 @@ func (ops *BuildOperations) Create(ctx context.Context, appName, buildName strin
 		err = ops.CreateByOpts(ctx, &CreateOptions{
 			App:       a,
 			BuildName: buildName,
-			SlugIn:    fmt.Sprintf("builds/%s/%s/in/app.tgz", a.Name, buildName),
+			SlugIn:    TarBallPath(a.Name, buildName),
 			SlugDest:  fmt.Sprintf("builds/%s/%s/out", a.Name, buildName),
 			TarBall:   tarBall,
 			Stream:    w,
@@ -104,13 +141,35 @@ func (ops *BuildOperations) CreateByOpts(ctx context.Context, opts *CreateOption
 	}
 
 	podName := fmt.Sprintf("build-%s", opts.BuildName)
-	podSpec := spec.NewBuildPodBuilder(podName, ops.opts.SlugBuilderImage).
+	builderImage := ops.opts.SlugBuilderImage
+	if opts.CacheImage != "" {
+		builderImage = ops.opts.CNBBuilderImage
+	} else if opts.Image != "" {
+		builderImage = ops.opts.KanikoImage
+	}
+	builder := spec.NewBuildPodBuilder(podName, builderImage).
 		ForApp(opts.App).
 		WithTarBallPath(opts.SlugIn).
 		SendSlugTo(opts.SlugDest).
 		WithStorage(ops.fileStorage).
-		WithLimits(ops.buildLimits.CPU, ops.buildLimits.Memory).
-		Build()
+		WithCachePath(cacheDir(opts.App.Name)+"cache.tgz").
+		WithLimits(ops.buildLimits.CPU, ops.buildLimits.Memory)
+	if opts.CacheImage != "" {
+		fmt.Fprintf(opts.Stream, "Building with Cloud Native Buildpacks into %s\n", opts.Image)
+		builder = builder.BuildImageTo(opts.Image, ops.opts.SlugStoreImage).WithBuildpacks(opts.CacheImage)
+	} else if opts.Image != "" {
+		fmt.Fprintf(opts.Stream, "Building the Dockerfile into %s\n", opts.Image)
+		builder = builder.BuildImageTo(opts.Image, ops.opts.SlugStoreImage)
+	}
+	if opts.Image != "" && ops.opts.RegistryConfig != "" {
+		data := map[string][]byte{"config.json": []byte(ops.opts.RegistryConfig)}
+		if err := ops.k8s.CreateOrUpdateSecret(opts.App.Name, spec.RegistrySecretName, data); err != nil {
+			log.WithError(err).Errorf("creating the build registry secret of app %s", opts.App.Name)
+			return err
+		}
+		builder = builder.WithRegistrySecret(spec.RegistrySecretName)
+	}
+	podSpec := builder.Build()
 
 	podStream, runErrChan := ops.execOps.RunCommandBySpec(ctx, podSpec)
 	go io.Copy(opts.Stream, podStream)
@@ -129,7 +188,7 @@ func (ops *BuildOperations) CreateByOpts(ctx context.Context, opts *CreateOption
 }
 
 func (ops *BuildOperations) List(appName string, u *database.User) ([]*Build, error) {
-	if _, err := ops.appOps.CheckPermAndGet(u, appName); err != nil {
+	if _, err := ops.appOps.CheckRoleAndGet(u, appName, team.RoleViewer); err != nil {
 		return nil, err
 	}
 
@@ -158,7 +217,7 @@ func (ops *BuildOperations) Run(ctx context.Context, appName, buildName string,
 		return nil, errChan
 	}
 
-	path := fmt.Sprintf("builds/%s/%s/", appName, buildName)
+	path := Dir(appName, buildName)
 	items, err := ops.fileStorage.List(path)
 	if len(items) == 0 {
 		errChan <- ErrInvalidBuildName
@@ -180,11 +239,35 @@ func (ops *BuildOperations) Delete(appName, buildName string, u *database.User)
 		return err
 	}
 
-	return ops.fileStorage.Delete(fmt.Sprintf("builds/%s/%s/", appName, buildName))
+	return ops.fileStorage.Delete(Dir(appName, buildName))
+}
+
+// ClearCache deletes the buildpacks cache of the app, the next build starts
+// from scratch
+func (ops *BuildOperations) ClearCache(appName string, u *database.User) error {
+	if _, err := ops.appOps.CheckPermAndGet(u, appName); err != nil {
+		return err
+	}
+
+	if err := ops.fileStorage.Delete(cacheDir(appName)); err != nil {
+		return err
+	}
+	if ops.opts.Registry == "" {
+		return nil
+	}
+	rc, err := newRegistryClient(ops.opts.RegistryConfig, registryTimeout)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := rc.deleteImage(CNBCacheImage(ops.opts.Registry, appName)); err != nil {
+		log.WithError(err).Errorf("deleting the buildpacks cache image of app %s", appName)
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
 }
 
 func (ops *BuildOperations) runInternal(ctx context.Context, a *app.App, buildName string, w io.Writer) error {
-	slugURL := fmt.Sprintf("builds/%s/%s/out/slug.tgz", a.Name, buildName)
+	slugURL := SlugPath(a.Name, buildName)
 	podName := formatPodName(a.Name, buildName)
 	podSpec := spec.NewRunnerPodBuilder(podName, ops.opts.SlugRunnerImage, ops.opts.SlugStoreImage).
 		ForApp(a).
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -18,6 +19,7 @@ import (
 
 type fakeK8sOperations struct {
 	createServiceWasCalled bool
+	secrets                map[string]map[string][]byte
 }
 
 func (f *fakeK8sOperations) CreateService(svcSpec *spec.Service) error {
@@ -41,6 +43,14 @@ func (f *fakeK8sOperations) IsInvalid(err error) bool {
 	return false
 }
 
+func (f *fakeK8sOperations) CreateOrUpdateSecret(namespace, secretName string, data map[string][]byte) error {
+	if f.secrets == nil {
+		f.secrets = make(map[string]map[string][]byte)
+	}
+	f.secrets[secretName] = data
+	return nil
+}
+
 func consumeReader(rc io.ReadCloser) {
 	for {
 		b := make([]byte, 64)
@@ -83,6 +93,77 @@ func TestCreateByOpts(t *testing.T) {
 	}
 }
 
+func TestCreateByOptsImage(t *testing.T) {
+	ops := NewBuildOperations(
+		storage.NewFake(),
+		app.NewFakeOperations(),
+		exec.NewFakeOperations(),
+		&fakeK8sOperations{},
+		&Options{},
+	)
+	stream := new(bytes.Buffer)
+	err := ops.CreateByOpts(context.Background(), &CreateOptions{
+		App:     &app.App{},
+		Image:   "gcr.io/project/teresa:abc",
+		TarBall: &test.FakeReadSeeker{},
+		Stream:  stream,
+	})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := "Building the Dockerfile into gcr.io/project/teresa:abc"; !strings.Contains(stream.String(), expected) {
+		t.Errorf("expected %q in %q", expected, stream.String())
+	}
+}
+
+func TestCreateByOptsImageRegistryConfig(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	conf := `{"auths": {"gcr.io": {"auth": "dGVyZXNhOnNlY3JldA=="}}}`
+	ops := NewBuildOperations(
+		storage.NewFake(),
+		app.NewFakeOperations(),
+		exec.NewFakeOperations(),
+		fakeK8s,
+		&Options{RegistryConfig: conf},
+	)
+	err := ops.CreateByOpts(context.Background(), &CreateOptions{
+		App:     &app.App{Name: "teresa"},
+		Image:   "gcr.io/project/teresa:abc",
+		TarBall: &test.FakeReadSeeker{},
+		Stream:  new(bytes.Buffer),
+	})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := string(fakeK8s.secrets[spec.RegistrySecretName]["config.json"]); got != conf {
+		t.Errorf("expected %s, got %s", conf, got)
+	}
+}
+
+func TestCreateByOptsBuildpacks(t *testing.T) {
+	ops := NewBuildOperations(
+		storage.NewFake(),
+		app.NewFakeOperations(),
+		exec.NewFakeOperations(),
+		&fakeK8sOperations{},
+		&Options{},
+	)
+	stream := new(bytes.Buffer)
+	err := ops.CreateByOpts(context.Background(), &CreateOptions{
+		App:        &app.App{},
+		Image:      "gcr.io/project/teresa:abc",
+		CacheImage: "gcr.io/project/teresa:cache",
+		TarBall:    &test.FakeReadSeeker{},
+		Stream:     stream,
+	})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := "Building with Cloud Native Buildpacks into gcr.io/project/teresa:abc"; !strings.Contains(stream.String(), expected) {
+		t.Errorf("expected %q in %q", expected, stream.String())
+	}
+}
+
 func TestCreateAppNotFound(t *testing.T) {
 	ops := NewBuildOperations(
 		storage.NewFake(),
@@ -349,3 +430,32 @@ func TestDeletePermissionDenied(t *testing.T) {
 		t.Errorf("expected ErrPermissionDenied, got %v", err)
 	}
 }
+
+func TestClearCache(t *testing.T) {
+	ops := NewBuildOperations(
+		storage.NewFake(),
+		app.NewFakeOperations(),
+		exec.NewFakeOperations(),
+		&fakeK8sOperations{},
+		&Options{},
+	)
+
+	if err := ops.ClearCache("teresa", &database.User{}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestClearCachePermissionDenied(t *testing.T) {
+	ops := NewBuildOperations(
+		storage.NewFake(),
+		app.NewFakeOperations(),
+		exec.NewFakeOperations(),
+		&fakeK8sOperations{},
+		&Options{},
+	)
+
+	u := &database.User{Email: "bad-user@luizalabs.com"}
+	if err := ops.ClearCache("teresa", u); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
@@ -35,6 +35,10 @@ func (f *FakeOperations) Delete(appName, buildName string, u *database.User) err
 	return nil
 }
 
+func (f *FakeOperations) ClearCache(appName string, u *database.User) error {
+	return nil
+}
+
 func NewFakeOperations() *FakeOperations {
 	return new(FakeOperations)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/goutil"
 	bpb "github.com/luizalabs/teresa/pkg/protobuf/build"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/upload"
 	context "golang.org/x/net/context"
 	"google.golang.org/grpc"
 )
@@ -18,10 +19,11 @@ const KeepAliveMessage = "\u200B" // Zero width space
 type Service struct {
 	ops              Operations
 	keepAliveTimeout time.Duration
+	uploads          upload.Operations
 }
 
 func (s *Service) Make(stream bpb.Build_MakeServer) error {
-	var appName, name string
+	var appName, name, checksum string
 	var runApp bool
 
 	content := new(bytes.Buffer)
@@ -41,18 +43,23 @@ func (s *Service) Make(stream bpb.Build_MakeServer) error {
 			appName = info.App
 			name = info.Name
 			runApp = info.Run
+			checksum = info.UploadChecksum
 		}
 		if data := in.GetFile(); data != nil {
 			content.Write(data.Chunk)
 		}
 	}
 
-	rs := bytes.NewReader(content.Bytes())
+	rs, err := upload.Tarball(s.uploads, u, appName, checksum, content.Bytes())
+	if err != nil {
+		return err
+	}
 	rc, errChan := s.ops.Create(ctx, appName, name, u, rs, runApp)
 	if rc == nil {
 		return <-errChan
 	}
 	defer rc.Close()
+	upload.Discard(s.uploads, appName, checksum)
 
 	buildMsgs, buildErrCh := goutil.LineGenerator(rc)
 	return s.streamMsg(ctx, buildMsgs, errChan, buildErrCh, func(msg string) error {
@@ -104,6 +111,15 @@ func (s *Service) Delete(ctx context.Context, req *bpb.DeleteRequest) (*bpb.Empt
 	return &bpb.Empty{}, nil
 }
 
+func (s *Service) ClearCache(ctx context.Context, req *bpb.ClearCacheRequest) (*bpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+
+	if err := s.ops.ClearCache(req.AppName, u); err != nil {
+		return nil, err
+	}
+	return &bpb.Empty{}, nil
+}
+
 func (s *Service) streamMsg(ctx context.Context, msgsChan <-chan string, errChan, msgsErrChan <-chan error, sendFn func(string) error) error {
 	var msg string
 	for {
@@ -132,6 +148,11 @@ func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	bpb.RegisterBuildServer(grpcServer, s)
 }
 
+// SetUploads sets the uploads the builds can take the tarball from
+func (s *Service) SetUploads(uploads upload.Operations) {
+	s.uploads = uploads
+}
+
 func NewService(ops Operations, keepAliveTimeout time.Duration) *Service {
 	return &Service{ops: ops, keepAliveTimeout: keepAliveTimeout}
 }
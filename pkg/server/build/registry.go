@@ -0,0 +1,210 @@
+package build
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// cnbCacheTag tags the layer cache of the Cloud Native Buildpacks
+	// builds of an app
+	cnbCacheTag     = "cnb-cache"
+	registryTimeout = 30 * time.Second
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+}
+
+// CNBCacheImage is the image holding the layers cached by the Cloud Native
+// Buildpacks builds of the app
+func CNBCacheImage(registry, appName string) string {
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(registry, "/"), appName, cnbCacheTag)
+}
+
+// registryClient deletes the images of the build registry through its HTTP
+// API (v2), authenticated by the credentials of a docker config.json
+type registryClient struct {
+	client *http.Client
+	// auths are the user and password of each registry host
+	auths map[string][2]string
+}
+
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+func newRegistryClient(config string, timeout time.Duration) (*registryClient, error) {
+	c := &registryClient{
+		client: &http.Client{Timeout: timeout},
+		auths:  make(map[string][2]string),
+	}
+	if config == "" {
+		return c, nil
+	}
+	conf := new(dockerConfig)
+	if err := json.Unmarshal([]byte(config), conf); err != nil {
+		return nil, err
+	}
+	for host, a := range conf.Auths {
+		user, password := a.Username, a.Password
+		if a.Auth != "" {
+			b, err := base64.StdEncoding.DecodeString(a.Auth)
+			if err != nil {
+				return nil, err
+			}
+			parts := strings.SplitN(string(b), ":", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid auth of registry %s", host)
+			}
+			user, password = parts[0], parts[1]
+		}
+		host = strings.TrimPrefix(strings.TrimPrefix(host, "https://"), "http://")
+		c.auths[strings.TrimSuffix(host, "/")] = [2]string{user, password}
+	}
+	return c, nil
+}
+
+// splitImage returns the host, repository and tag of the image
+func splitImage(image string) (string, string, string, error) {
+	parts := strings.SplitN(image, "/", 2)
+	i := strings.LastIndex(image, ":")
+	if len(parts) != 2 || i <= len(parts[0]) {
+		return "", "", "", fmt.Errorf("invalid image %s", image)
+	}
+	return parts[0], image[len(parts[0])+1 : i], image[i+1:], nil
+}
+
+// deleteImage deletes the manifest the tag of the image points to, an image
+// already gone isn't an error
+func (c *registryClient) deleteImage(image string) error {
+	host, repo, tag, err := splitImage(image)
+	if err != nil {
+		return err
+	}
+	manifests := fmt.Sprintf("https://%s/v2/%s/manifests/", host, repo)
+
+	req, err := http.NewRequest(http.MethodHead, manifests+tag, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	resp, err := c.do(host, req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HEAD %s: %s", req.URL, resp.Status)
+	}
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		return fmt.Errorf("HEAD %s: missing digest", req.URL)
+	}
+
+	req, err = http.NewRequest(http.MethodDelete, manifests+digest, nil)
+	if err != nil {
+		return err
+	}
+	resp, err = c.do(host, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNotFound:
+		return nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("DELETE %s: %s: %s", req.URL, resp.Status, msg)
+}
+
+// do sends the request, answering the challenge of the registry (basic or
+// bearer token) with the credentials of the host on a 401
+func (c *registryClient) do(host string, req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	auth, found := c.auths[host]
+	if !found {
+		return nil, fmt.Errorf("%s %s: no credentials for %s", req.Method, req.URL, host)
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+		token, err := c.token(challenge[len("bearer "):], auth)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.SetBasicAuth(auth[0], auth[1])
+	}
+	return c.client.Do(req)
+}
+
+// token gets a bearer token from the realm of the challenge
+func (c *registryClient) token(challenge string, auth [2]string) (string, error) {
+	params := make(map[string]string)
+	for _, p := range strings.Split(challenge, ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || params["realm"] == "" {
+		return "", fmt.Errorf("invalid registry challenge %s", challenge)
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if params[k] != "" {
+			q.Set(k, params[k])
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(auth[0], auth[1])
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", realm, resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCNBCacheImage(t *testing.T) {
+	if got := CNBCacheImage("gcr.io/project/", "teresa"); got != "gcr.io/project/teresa:cnb-cache" {
+		t.Errorf("expected gcr.io/project/teresa:cnb-cache, got %s", got)
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	host, repo, tag, err := splitImage("localhost:5000/project/teresa:cnb-cache")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if host != "localhost:5000" || repo != "project/teresa" || tag != "cnb-cache" {
+		t.Errorf("expected localhost:5000 project/teresa cnb-cache, got %s %s %s", host, repo, tag)
+	}
+	for _, image := range []string{"teresa:cnb-cache", "localhost:5000/teresa"} {
+		if _, _, _, err := splitImage(image); err == nil {
+			t.Errorf("expected error for %s, got nil", image)
+		}
+	}
+}
+
+func TestRegistryClientDeleteImage(t *testing.T) {
+	var deleted string
+	var ts *httptest.Server
+	ts = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if u, p, _ := r.BasicAuth(); u != "gopher" || p != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"token": "abc"}`)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer abc" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, ts.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/teresa/manifests/cnb-cache":
+			w.Header().Set("Docker-Content-Digest", "sha256:123")
+		case r.Method == http.MethodDelete && r.URL.Path == "/v2/teresa/manifests/sha256:123":
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	host := strings.TrimPrefix(ts.URL, "https://")
+	conf := fmt.Sprintf(`{"auths": {"%s": {"auth": "Z29waGVyOnNlY3JldA=="}}}`, host)
+	rc, err := newRegistryClient(conf, registryTimeout)
+	if err != nil {
+		t.Fatal("error creating the registry client:", err)
+	}
+	rc.client = ts.Client()
+
+	if err := rc.deleteImage(CNBCacheImage(host, "teresa")); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if deleted == "" {
+		t.Error("expected the manifest deleted")
+	}
+	if err := rc.deleteImage(CNBCacheImage(host, "other")); err != nil {
+		t.Errorf("expected no error for a missing image, got %v", err)
+	}
+}
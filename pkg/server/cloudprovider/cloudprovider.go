@@ -11,15 +11,40 @@ type Operations interface {
 }
 
 type K8sOperations interface {
-	CloudProviderName() (string, error)
+	CloudProviderName(namespace string) (string, error)
 	SetServiceAnnotations(namespace, service string, annotations map[string]string) error
 	ServiceAnnotations(namespace, service string) (map[string]string, error)
 	IsNotFound(err error) bool
 	HasIngress(namespace, name string) (bool, error)
 }
 
+// NewOperations returns the operations of the cloud providers of the apps,
+// resolved on each call as the apps may be on clusters of different ones
 func NewOperations(k8s K8sOperations) Operations {
-	name, err := k8s.CloudProviderName()
+	return &clusterOperations{k8s: k8s}
+}
+
+type clusterOperations struct {
+	k8s K8sOperations
+}
+
+func (ops *clusterOperations) CreateOrUpdateSSL(appName, cert string, port int) error {
+	return operationsOf(ops.k8s, appName).CreateOrUpdateSSL(appName, cert, port)
+}
+
+func (ops *clusterOperations) SSLInfo(appName string) (*service.SSLInfo, error) {
+	return operationsOf(ops.k8s, appName).SSLInfo(appName)
+}
+
+// Name is the cloud provider of the cluster of the server
+func (ops *clusterOperations) Name() string {
+	return operationsOf(ops.k8s, "").Name()
+}
+
+// operationsOf returns the operations of the cloud provider of the cluster
+// of the app of the namespace, the one of the server when blank
+func operationsOf(k8s K8sOperations, namespace string) Operations {
+	name, err := k8s.CloudProviderName(namespace)
 	if err != nil {
 		return &fallbackOperations{}
 	}
@@ -6,29 +6,47 @@ import (
 	"github.com/pkg/errors"
 )
 
-func TestNewOperationsSuccess(t *testing.T) {
+func TestOperationsOfSuccess(t *testing.T) {
 	k8s := &FakeK8sOperations{CloudProviderNameValue: "aws"}
 
-	ops := NewOperations(k8s)
+	ops := operationsOf(k8s, "teresa")
 	if opsc, ok := ops.(*awsOperations); !ok {
 		t.Errorf("got %v; want aws", opsc)
 	}
 }
 
-func TestNewOperationsCloudProviderNameFail(t *testing.T) {
+func TestOperationsOfCloudProviderNameFail(t *testing.T) {
 	k8s := &FakeK8sOperations{CloudProviderNameErr: errors.New("test")}
 
-	ops := NewOperations(k8s)
+	ops := operationsOf(k8s, "teresa")
 	if opsc, ok := ops.(*fallbackOperations); !ok {
 		t.Errorf("got %v; want fallback", opsc)
 	}
 }
 
-func TestNewOperationsReturnsFallbackOperations(t *testing.T) {
+func TestOperationsOfReturnsFallbackOperations(t *testing.T) {
 	k8s := &FakeK8sOperations{CloudProviderNameValue: "test"}
 
-	ops := NewOperations(k8s)
+	ops := operationsOf(k8s, "teresa")
 	if _, ok := ops.(*fallbackOperations); !ok {
 		t.Error("expected fallbackOperations, but another struct was created")
 	}
 }
+
+func TestNewOperationsPerAppCluster(t *testing.T) {
+	k8s := &FakeK8sOperations{
+		CloudProviderNameValue: "gce",
+		CloudProviderNames:     map[string]string{"teresa": "aws"},
+	}
+	ops := NewOperations(k8s)
+
+	if name := ops.Name(); name != "gce" {
+		t.Errorf("expected gce, got %s", name)
+	}
+	if err := ops.CreateOrUpdateSSL("teresa", "arn:aws:acm:cert", 443); err != nil {
+		t.Errorf("expected no error on the aws cluster, got %v", err)
+	}
+	if err := ops.CreateOrUpdateSSL("other", "arn:aws:acm:cert", 443); err != ErrNotImplemented {
+		t.Errorf("expected ErrNotImplemented on the gce cluster, got %v", err)
+	}
+}
@@ -1,8 +1,11 @@
 package cloudprovider
 
 type FakeK8sOperations struct {
-	CloudProviderNameErr     error
-	CloudProviderNameValue   string
+	CloudProviderNameErr   error
+	CloudProviderNameValue string
+	// CloudProviderNames are the cloud providers by namespace, the others
+	// are CloudProviderNameValue
+	CloudProviderNames       map[string]string
 	SetServiceAnnotationsErr error
 	ServiceAnnotationsErr    error
 	ServiceAnnotationsValue  map[string]string
@@ -11,7 +14,10 @@ type FakeK8sOperations struct {
 	HasIngressErr            error
 }
 
-func (f *FakeK8sOperations) CloudProviderName() (string, error) {
+func (f *FakeK8sOperations) CloudProviderName(namespace string) (string, error) {
+	if name, found := f.CloudProviderNames[namespace]; found {
+		return name, f.CloudProviderNameErr
+	}
 	return f.CloudProviderNameValue, f.CloudProviderNameErr
 }
 
@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Cluster is a Kubernetes cluster registered on the server, the apps are
+// created on it with --cluster
+type Cluster struct {
+	Name      string
+	Apps      int
+	CreatedAt time.Time
+}
+
+// Operations manage the registered clusters and the assignments of the apps
+// to them. The apps without one, and the blank cluster, are the ones of the
+// cluster of the server.
+type Operations interface {
+	Add(name string, kubeconfig []byte) error
+	Remove(name string) error
+	List() ([]*Cluster, error)
+	Names() ([]string, error)
+	Kubeconfig(name string) ([]byte, error)
+	AppCluster(appName string) (string, error)
+	SetAppCluster(appName, cluster string) error
+	DeleteAppCluster(appName string) error
+}
+
+type DatabaseOperations struct {
+	DB *gorm.DB
+}
+
+func (ops *DatabaseOperations) get(name string) (*database.Cluster, error) {
+	c := new(database.Cluster)
+	q := ops.DB.Where(&database.Cluster{Name: name}).First(c)
+	if q.RecordNotFound() {
+		return nil, ErrNotFound
+	}
+	if q.Error != nil {
+		return nil, q.Error
+	}
+	return c, nil
+}
+
+func (ops *DatabaseOperations) countApps(name string) (int, error) {
+	var n int
+	err := ops.DB.Model(&database.AppCluster{}).Where("cluster = ?", name).Count(&n).Error
+	return n, err
+}
+
+// Add registers the cluster of the kubeconfig, its current context is the
+// one used by the server
+func (ops *DatabaseOperations) Add(name string, kubeconfig []byte) error {
+	if len(name) > 63 || !nameRegexp.MatchString(name) {
+		return ErrInvalidName
+	}
+	if len(kubeconfig) == 0 {
+		return ErrInvalidKubeconfig
+	}
+	if _, err := ops.get(name); err == nil {
+		return ErrAlreadyExists
+	} else if err != ErrNotFound {
+		return err
+	}
+	c := &database.Cluster{Name: name, Kubeconfig: database.EncryptedString(kubeconfig)}
+	return ops.DB.Create(c).Error
+}
+
+// Remove unregisters a cluster without apps
+func (ops *DatabaseOperations) Remove(name string) error {
+	c, err := ops.get(name)
+	if err != nil {
+		return err
+	}
+	n, err := ops.countApps(name)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrInUse
+	}
+	return ops.DB.Delete(c).Error
+}
+
+func (ops *DatabaseOperations) List() ([]*Cluster, error) {
+	var dbcs []*database.Cluster
+	if err := ops.DB.Order("name").Find(&dbcs).Error; err != nil {
+		return nil, err
+	}
+	cs := make([]*Cluster, len(dbcs))
+	for i, dbc := range dbcs {
+		n, err := ops.countApps(dbc.Name)
+		if err != nil {
+			return nil, err
+		}
+		cs[i] = &Cluster{Name: dbc.Name, Apps: n, CreatedAt: dbc.CreatedAt}
+	}
+	return cs, nil
+}
+
+func (ops *DatabaseOperations) Names() ([]string, error) {
+	var names []string
+	err := ops.DB.Model(&database.Cluster{}).Order("name").Pluck("name", &names).Error
+	return names, err
+}
+
+func (ops *DatabaseOperations) Kubeconfig(name string) ([]byte, error) {
+	c, err := ops.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(c.Kubeconfig), nil
+}
+
+// AppCluster returns the cluster of the app, blank for the cluster of the
+// server
+func (ops *DatabaseOperations) AppCluster(appName string) (string, error) {
+	ac := new(database.AppCluster)
+	q := ops.DB.Where(&database.AppCluster{AppName: appName}).First(ac)
+	if q.RecordNotFound() {
+		return "", nil
+	}
+	if q.Error != nil {
+		return "", q.Error
+	}
+	return ac.Cluster, nil
+}
+
+// SetAppCluster assigns a new app to the cluster, nothing is recorded for
+// the blank one
+func (ops *DatabaseOperations) SetAppCluster(appName, cluster string) error {
+	if cluster == "" {
+		return nil
+	}
+	if _, err := ops.get(cluster); err != nil {
+		return err
+	}
+	cur, err := ops.AppCluster(appName)
+	if err != nil {
+		return err
+	}
+	if cur != "" {
+		return ErrAppAssigned
+	}
+	return ops.DB.Create(&database.AppCluster{AppName: appName, Cluster: cluster}).Error
+}
+
+// DeleteAppCluster removes the assignment of a deleted app
+func (ops *DatabaseOperations) DeleteAppCluster(appName string) error {
+	return ops.DB.Where("app_name = ?", appName).Delete(&database.AppCluster{}).Error
+}
+
+func NewDatabaseOperations(db *gorm.DB) Operations {
+	return &DatabaseOperations{DB: db}
+}
@@ -0,0 +1,137 @@
+package cluster
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func newTestOperations(t *testing.T) (Operations, *gorm.DB) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	return NewDatabaseOperations(db), db
+}
+
+func TestDatabaseOperationsAdd(t *testing.T) {
+	ops, db := newTestOperations(t)
+	defer db.Close()
+
+	if err := ops.Add("prod-us-east", []byte("kubeconfig")); err != nil {
+		t.Fatal("error adding cluster: ", err)
+	}
+
+	var testCases = []struct {
+		name        string
+		kubeconfig  []byte
+		expectedErr error
+	}{
+		{"prod-us-east", []byte("kubeconfig"), ErrAlreadyExists},
+		{"Prod", []byte("kubeconfig"), ErrInvalidName},
+		{"", []byte("kubeconfig"), ErrInvalidName},
+		{"prod-eu", nil, ErrInvalidKubeconfig},
+	}
+	for _, tc := range testCases {
+		if err := ops.Add(tc.name, tc.kubeconfig); err != tc.expectedErr {
+			t.Errorf("expected %v adding %q, got %v", tc.expectedErr, tc.name, err)
+		}
+	}
+
+	kubeconfig, err := ops.Kubeconfig("prod-us-east")
+	if err != nil {
+		t.Fatal("error getting kubeconfig: ", err)
+	}
+	if string(kubeconfig) != "kubeconfig" {
+		t.Errorf("expected kubeconfig, got %s", kubeconfig)
+	}
+	if _, err := ops.Kubeconfig("prod-eu"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsAppCluster(t *testing.T) {
+	ops, db := newTestOperations(t)
+	defer db.Close()
+
+	if err := ops.Add("prod-us-east", []byte("kubeconfig")); err != nil {
+		t.Fatal("error adding cluster: ", err)
+	}
+
+	if err := ops.SetAppCluster("teresa", "prod-eu"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := ops.SetAppCluster("teresa", ""); err != nil {
+		t.Fatal("error setting app cluster: ", err)
+	}
+	if c, err := ops.AppCluster("teresa"); err != nil || c != "" {
+		t.Errorf("expected the cluster of the server, got %q (%v)", c, err)
+	}
+
+	if err := ops.SetAppCluster("teresa", "prod-us-east"); err != nil {
+		t.Fatal("error setting app cluster: ", err)
+	}
+	if err := ops.SetAppCluster("teresa", "prod-us-east"); err != ErrAppAssigned {
+		t.Errorf("expected ErrAppAssigned, got %v", err)
+	}
+	if c, err := ops.AppCluster("teresa"); err != nil || c != "prod-us-east" {
+		t.Errorf("expected prod-us-east, got %q (%v)", c, err)
+	}
+
+	if err := ops.DeleteAppCluster("teresa"); err != nil {
+		t.Fatal("error deleting app cluster: ", err)
+	}
+	if c, err := ops.AppCluster("teresa"); err != nil || c != "" {
+		t.Errorf("expected the cluster of the server, got %q (%v)", c, err)
+	}
+}
+
+func TestDatabaseOperationsListAndRemove(t *testing.T) {
+	ops, db := newTestOperations(t)
+	defer db.Close()
+
+	for _, name := range []string{"prod-us-east", "prod-eu"} {
+		if err := ops.Add(name, []byte("kubeconfig")); err != nil {
+			t.Fatal("error adding cluster: ", err)
+		}
+	}
+	if err := ops.SetAppCluster("teresa", "prod-us-east"); err != nil {
+		t.Fatal("error setting app cluster: ", err)
+	}
+
+	cs, err := ops.List()
+	if err != nil {
+		t.Fatal("error listing clusters: ", err)
+	}
+	var got []Cluster
+	for _, c := range cs {
+		got = append(got, Cluster{Name: c.Name, Apps: c.Apps})
+	}
+	expected := []Cluster{{Name: "prod-eu", Apps: 0}, {Name: "prod-us-east", Apps: 1}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if err := ops.Remove("prod-us-east"); err != ErrInUse {
+		t.Errorf("expected ErrInUse, got %v", err)
+	}
+	if err := ops.Remove("prod-eu"); err != nil {
+		t.Fatal("error removing cluster: ", err)
+	}
+	if err := ops.Remove("prod-eu"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	names, err := ops.Names()
+	if err != nil {
+		t.Fatal("error getting cluster names: ", err)
+	}
+	if !reflect.DeepEqual(names, []string{"prod-us-east"}) {
+		t.Errorf("expected [prod-us-east], got %v", names)
+	}
+}
@@ -0,0 +1,15 @@
+package cluster
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrAlreadyExists     = status.Errorf(codes.AlreadyExists, "Cluster already exists")
+	ErrNotFound          = status.Errorf(codes.NotFound, "Cluster not found")
+	ErrInvalidName       = status.Errorf(codes.InvalidArgument, "Invalid cluster name")
+	ErrInvalidKubeconfig = status.Errorf(codes.InvalidArgument, "Invalid kubeconfig")
+	ErrInUse             = status.Errorf(codes.FailedPrecondition, "Cluster has apps, delete them first")
+	ErrAppAssigned       = status.Errorf(codes.AlreadyExists, "App already assigned to a cluster")
+)
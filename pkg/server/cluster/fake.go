@@ -0,0 +1,130 @@
+package cluster
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type FakeOperations struct {
+	mutex    *sync.RWMutex
+	Clusters map[string][]byte
+	Apps     map[string]string
+}
+
+func (f *FakeOperations) countApps(name string) int {
+	n := 0
+	for _, c := range f.Apps {
+		if c == name {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *FakeOperations) Add(name string, kubeconfig []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !nameRegexp.MatchString(name) {
+		return ErrInvalidName
+	}
+	if len(kubeconfig) == 0 {
+		return ErrInvalidKubeconfig
+	}
+	if _, found := f.Clusters[name]; found {
+		return ErrAlreadyExists
+	}
+	f.Clusters[name] = kubeconfig
+	return nil
+}
+
+func (f *FakeOperations) Remove(name string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Clusters[name]; !found {
+		return ErrNotFound
+	}
+	if f.countApps(name) > 0 {
+		return ErrInUse
+	}
+	delete(f.Clusters, name)
+	return nil
+}
+
+func (f *FakeOperations) List() ([]*Cluster, error) {
+	names, _ := f.Names()
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	cs := make([]*Cluster, len(names))
+	for i, name := range names {
+		cs[i] = &Cluster{Name: name, Apps: f.countApps(name), CreatedAt: time.Now()}
+	}
+	return cs, nil
+}
+
+func (f *FakeOperations) Names() ([]string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	names := make([]string, 0, len(f.Clusters))
+	for name := range f.Clusters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (f *FakeOperations) Kubeconfig(name string) ([]byte, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	kubeconfig, found := f.Clusters[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return kubeconfig, nil
+}
+
+func (f *FakeOperations) AppCluster(appName string) (string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.Apps[appName], nil
+}
+
+func (f *FakeOperations) SetAppCluster(appName, cluster string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if cluster == "" {
+		return nil
+	}
+	if _, found := f.Clusters[cluster]; !found {
+		return ErrNotFound
+	}
+	if _, found := f.Apps[appName]; found {
+		return ErrAppAssigned
+	}
+	f.Apps[appName] = cluster
+	return nil
+}
+
+func (f *FakeOperations) DeleteAppCluster(appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	delete(f.Apps, appName)
+	return nil
+}
+
+func NewFakeOperations() *FakeOperations {
+	return &FakeOperations{
+		mutex:    &sync.RWMutex{},
+		Clusters: make(map[string][]byte),
+		Apps:     make(map[string]string),
+	}
+}
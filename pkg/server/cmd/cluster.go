@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/gorm"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/server/cluster"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/k8s"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Manage the Kubernetes clusters of the apps",
+	Long: `Manage the Kubernetes clusters the apps can be created on, besides the
+one of the server.
+
+The apps are created on a registered cluster with teresa app create --cluster,
+the ones without it stay on the cluster of the server.`,
+}
+
+var clusterAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register a cluster",
+	Long: `Register a cluster with the current context of a kubeconfig.
+
+The kubeconfig is kept in the database, encrypted when there is an encryption
+key, and its user must be allowed to manage the namespaces of the apps.`,
+	Example: "  teresa-server cluster add prod-us-east --kubeconfig prod-us-east.yaml",
+	Run:     clusterAdd,
+}
+
+var clusterListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the registered clusters",
+	Run:   clusterList,
+}
+
+var clusterRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Unregister a cluster without apps",
+	Run:   clusterRemove,
+}
+
+func init() {
+	RootCmd.AddCommand(clusterCmd)
+	clusterCmd.AddCommand(clusterAddCmd)
+	clusterCmd.AddCommand(clusterListCmd)
+	clusterCmd.AddCommand(clusterRemoveCmd)
+
+	clusterAddCmd.Flags().String("kubeconfig", "", "kubeconfig file of the cluster")
+	clusterAddCmd.Flags().Bool("no-check", false, "don't check the cluster is reachable")
+}
+
+// getClusterOps returns the operations on the clusters of the database, the
+// kubeconfigs are encrypted with the key of the server if any
+func getClusterOps(db *gorm.DB) (cluster.Operations, error) {
+	if err := checkMigrations(db); err != nil {
+		return nil, err
+	}
+	sec, err := getSecrets()
+	if err != nil {
+		return nil, err
+	}
+	cipher, err := getCipher(sec)
+	if err != nil {
+		return nil, err
+	}
+	database.SetCipher(cipher)
+	return cluster.NewDatabaseOperations(db), nil
+}
+
+func clusterAdd(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	path, err := cmd.Flags().GetString("kubeconfig")
+	if err != nil || path == "" {
+		log.WithError(err).Fatal("invalid kubeconfig parameter")
+	}
+	noCheck, err := cmd.Flags().GetBool("no-check")
+	if err != nil {
+		log.WithError(err).Fatal("invalid no-check parameter")
+	}
+
+	kubeconfig, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.WithError(err).Fatal("failed to read kubeconfig")
+	}
+	if !noCheck {
+		if err := k8s.CheckCluster(kubeconfig); err != nil {
+			log.WithError(err).Fatal("failed to reach the cluster")
+		}
+	}
+
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+	ops, err := getClusterOps(db)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get cluster operations")
+	}
+
+	if err := ops.Add(args[0], kubeconfig); err != nil {
+		log.WithError(err).Fatal("failed to add cluster")
+	}
+	fmt.Println("Cluster added with success")
+}
+
+func clusterList(cmd *cobra.Command, args []string) {
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+	ops, err := getClusterOps(db)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get cluster operations")
+	}
+
+	cs, err := ops.List()
+	if err != nil {
+		log.WithError(err).Fatal("failed to list clusters")
+	}
+	if len(cs) == 0 {
+		fmt.Println("No clusters, the apps are on the cluster of the server")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"NAME", "APPS", "ADDED AT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, c := range cs {
+		table.Append([]string{c.Name, fmt.Sprint(c.Apps), c.CreatedAt.Format("2006-01-02 15:04:05")})
+	}
+	table.Render()
+}
+
+func clusterRemove(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+	ops, err := getClusterOps(db)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get cluster operations")
+	}
+
+	if err := ops.Remove(args[0]); err != nil {
+		log.WithError(err).Fatal("failed to remove cluster")
+	}
+	fmt.Println("Cluster removed with success")
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/jinzhu/gorm"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/luizalabs/teresa/pkg/server/database"
@@ -13,3 +15,15 @@ func getDB() (*gorm.DB, error) {
 	}
 	return database.New(dbConf)
 }
+
+// checkMigrations fails when the schema of the database isn't up to date
+func checkMigrations(db *gorm.DB) error {
+	pending, err := database.PendingMigrations(db)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migrations, run teresa-server migrate up", len(pending))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage the database migrations",
+	Long: `Manage the migrations of the database schema.
+
+The server doesn't start with pending migrations unless it runs with
+--migrate, apply them before upgrading otherwise.`,
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply the pending migrations",
+	Run:   migrateUp,
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the last applied migrations",
+	Long: `Revert the last applied migrations, one by default.
+
+The tables (and data) created by the reverted migrations are dropped, run the
+version of the server matching the remaining ones.`,
+	Run: migrateDown,
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the applied and pending migrations",
+	Run:   migrateStatus,
+}
+
+func init() {
+	RootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+
+	migrateDownCmd.Flags().Int("steps", 1, "number of migrations to revert")
+}
+
+func migrateUp(cmd *cobra.Command, args []string) {
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+
+	applied, err := database.MigrateUp(db)
+	for _, m := range applied {
+		fmt.Printf("Applied %d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		log.WithError(err).Fatal("failed to apply migrations")
+	}
+	if len(applied) == 0 {
+		fmt.Println("No pending migrations")
+	}
+}
+
+func migrateDown(cmd *cobra.Command, args []string) {
+	steps, err := cmd.Flags().GetInt("steps")
+	if err != nil || steps < 1 {
+		log.Fatal("invalid steps parameter")
+	}
+
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+
+	reverted, err := database.MigrateDown(db, steps)
+	for _, m := range reverted {
+		fmt.Printf("Reverted %d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		log.WithError(err).Fatal("failed to revert migrations")
+	}
+	if len(reverted) == 0 {
+		fmt.Println("No applied migrations")
+	}
+}
+
+func migrateStatus(cmd *cobra.Command, args []string) {
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+
+	status, err := database.Migrations(db)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get migrations")
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"VERSION", "NAME", "APPLIED AT"})
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	for _, s := range status {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		table.Append([]string{fmt.Sprint(s.Version), s.Name, applied})
+	}
+	table.Render()
+}
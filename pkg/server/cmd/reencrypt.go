@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+var reencryptCmd = &cobra.Command{
+	Use:   "reencrypt",
+	Short: "Encrypt again the values of the database with the current key",
+	Long: `Encrypt again the values encrypted in the database with the current key,
+the ones stored before the encryption key was set included.
+
+To rotate the key set the new one as the encryption key, the previous one
+among the decryption keys, and run it. The previous key isn't needed anymore
+after it.`,
+	Run: reencrypt,
+}
+
+func init() {
+	RootCmd.AddCommand(reencryptCmd)
+}
+
+func reencrypt(cmd *cobra.Command, args []string) {
+	sec, err := getSecrets()
+	if err != nil {
+		log.WithError(err).Fatal("failed to get secrets data")
+	}
+	cipher, err := getCipher(sec)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get encryption key")
+	}
+	if cipher == nil {
+		log.Fatal("no encryption key")
+	}
+
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("failed to connect to database")
+	}
+	defer db.Close()
+	if err := checkMigrations(db); err != nil {
+		log.WithError(err).Fatal("database schema out of date")
+	}
+
+	database.SetCipher(cipher)
+	n, err := database.Reencrypt(db)
+	fmt.Printf("%d values encrypted\n", n)
+	if err != nil {
+		log.WithError(err).Fatal("failed to re-encrypt values")
+	}
+}
@@ -2,15 +2,23 @@ package cmd
 
 import (
 	"crypto/tls"
+	"os"
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/luizalabs/teresa/pkg/server"
 	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/deploy"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
 	"github.com/luizalabs/teresa/pkg/server/k8s"
+	"github.com/luizalabs/teresa/pkg/server/ldap"
+	"github.com/luizalabs/teresa/pkg/server/logstore"
+	"github.com/luizalabs/teresa/pkg/server/mail"
 	"github.com/luizalabs/teresa/pkg/server/secrets"
+	"github.com/luizalabs/teresa/pkg/server/sso"
 	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/user"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +33,7 @@ func init() {
 	runCmd.Flags().String("port", "50051", "TCP port to create a listener")
 	runCmd.Flags().Bool("tls", false, "enable TLS")
 	runCmd.Flags().Bool("debug", false, "enable debug mode")
+	runCmd.Flags().Bool("migrate", false, "apply the pending database migrations before starting")
 }
 
 func runServer(cmd *cobra.Command, args []string) {
@@ -43,10 +52,26 @@ func runServer(cmd *cobra.Command, args []string) {
 		log.WithError(err).Fatal("invalid debug parameter")
 	}
 
+	migrate, err := cmd.Flags().GetBool("migrate")
+	if err != nil {
+		log.WithError(err).Fatal("invalid migrate parameter")
+	}
+
 	db, err := getDB()
 	if err != nil {
 		log.WithError(err).Fatal("failed to connect to database")
 	}
+	if migrate {
+		applied, err := database.MigrateUp(db)
+		if err != nil {
+			log.WithError(err).Fatal("failed to apply migrations")
+		}
+		for _, m := range applied {
+			log.Infof("applied migration %d %s", m.Version, m.Name)
+		}
+	} else if err := checkMigrations(db); err != nil {
+		log.WithError(err).Fatal("database schema out of date")
+	}
 
 	st, err := getStorage()
 	if err != nil {
@@ -58,6 +83,14 @@ func runServer(cmd *cobra.Command, args []string) {
 		log.WithError(err).Fatal("failed to configure k8s client")
 	}
 
+	ls, err := getLogStore()
+	if err != nil {
+		log.WithError(err).Fatal("failed to configure log store")
+	}
+	if ls != nil {
+		log.Info("historical logs from ", ls.Type())
+	}
+
 	sec, err := getSecrets()
 	if err != nil {
 		log.WithError(err).Fatal("failed to get secrets data")
@@ -76,20 +109,57 @@ func runServer(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	cipher, err := getCipher(sec)
+	if err != nil {
+		log.WithError(err).Fatal("failed to get encryption key")
+	}
+	if cipher == nil {
+		log.Info("no encryption key, two-factor authentication disabled")
+	}
+
+	mailer, err := getMailer()
+	if err != nil {
+		log.WithError(err).Fatal("failed to configure mailer")
+	}
+	if mailer == nil {
+		log.Info("no SMTP host, password reset disabled")
+	}
+
+	policy, err := getPasswordPolicy()
+	if err != nil {
+		log.WithError(err).Fatal("failed to get password policy")
+	}
+
 	deployOpt, err := getDeployOpt()
 	if err != nil {
 		log.Fatal("Error getting deploy configuration:", err)
 	}
 
+	ssoConf, err := getSSOConf()
+	if err != nil {
+		log.WithError(err).Fatal("failed to get single sign-on configuration")
+	}
+
+	ldapConf, err := getLDAPConf()
+	if err != nil {
+		log.WithError(err).Fatal("failed to get LDAP configuration")
+	}
+
 	s, err := server.New(server.Options{
-		Port:      port,
-		Auth:      a,
-		DB:        db,
-		TLSCert:   tlsCert,
-		Storage:   st,
-		K8s:       kc,
-		DeployOpt: deployOpt,
-		Debug:     debug,
+		Port:           port,
+		Auth:           a,
+		DB:             db,
+		TLSCert:        tlsCert,
+		Storage:        st,
+		K8s:            kc,
+		LogStore:       ls,
+		DeployOpt:      deployOpt,
+		SSO:            ssoConf,
+		LDAP:           ldapConf,
+		Cipher:         cipher,
+		Mailer:         mailer,
+		PasswordPolicy: policy,
+		Debug:          debug,
 	})
 	if err != nil {
 		log.WithError(err).Fatal("failed to create server")
@@ -116,7 +186,55 @@ func getAuth(s secrets.Secrets) (auth.Auth, error) {
 	if err != nil {
 		return nil, err
 	}
-	return auth.New(private, public), nil
+	keys, err := s.VerificationKeys()
+	if err != nil {
+		return nil, err
+	}
+	return auth.New(private, append(keys, public)...), nil
+}
+
+// getCipher returns the cipher of the values encrypted in the database, nil
+// without the key file
+func getCipher(s secrets.Secrets) (encryption.Cipher, error) {
+	key, err := s.EncryptionKey()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	current, err := encryption.NewAESCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.DecryptionKeys()
+	if err != nil {
+		return nil, err
+	}
+	old := make([]encryption.Cipher, len(keys))
+	for i, key := range keys {
+		if old[i], err = encryption.NewAESCipher(key); err != nil {
+			return nil, err
+		}
+	}
+	return encryption.NewMultiCipher(current, old...), nil
+}
+
+func getMailer() (mail.Mailer, error) {
+	conf := new(mail.Config)
+	if err := envconfig.Process("teresa_mail", conf); err != nil {
+		return nil, err
+	}
+	return mail.New(conf)
+}
+
+func getPasswordPolicy() (*user.PasswordPolicy, error) {
+	p := new(user.PasswordPolicy)
+	if err := envconfig.Process("teresa_password", p); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
 func getStorage() (storage.Storage, error) {
@@ -127,6 +245,14 @@ func getStorage() (storage.Storage, error) {
 	return storage.New(conf)
 }
 
+func getLogStore() (logstore.Store, error) {
+	conf := new(logstore.Config)
+	if err := envconfig.Process("teresa_logstore", conf); err != nil {
+		return nil, err
+	}
+	return logstore.New(conf)
+}
+
 func getK8s() (*k8s.Client, error) {
 	conf := new(k8s.Config)
 	if err := envconfig.Process("teresa_k8s", conf); err != nil {
@@ -142,3 +268,19 @@ func getDeployOpt() (*deploy.Options, error) {
 	}
 	return conf, nil
 }
+
+func getSSOConf() (*sso.Config, error) {
+	conf := new(sso.Config)
+	if err := envconfig.Process("teresa_sso", conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func getLDAPConf() (*ldap.Config, error) {
+	conf := new(ldap.Config)
+	if err := envconfig.Process("teresa_ldap", conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
@@ -34,6 +34,17 @@ func replaceStorageSecret(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.WithError(err).Fatal("can't create k8s client")
 	}
+	db, err := getDB()
+	if err != nil {
+		log.WithError(err).Fatal("can't connect to database")
+	}
+	defer db.Close()
+	clusters, err := getClusterOps(db)
+	if err != nil {
+		log.WithError(err).Fatal("can't get cluster operations")
+	}
+	// the apps of all clusters
+	k8s.SetClusters(clusters)
 	st, err := getStorage()
 	if err != nil {
 		log.WithError(err).Fatal("can't create storage client")
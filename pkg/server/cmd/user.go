@@ -51,6 +51,9 @@ func createSuperUser(cmd *cobra.Command, args []string) {
 	if err != nil {
 		client.PrintErrorAndExit("Error on connect to Database: %v", err)
 	}
+	if err := checkMigrations(db); err != nil {
+		client.PrintErrorAndExit("Error on connect to Database: %v", err)
+	}
 
 	uOps := user.NewDatabaseOperations(db, auth.NewFake())
 	if err := uOps.Create(name, email, pass, true); err != nil {
@@ -8,37 +8,103 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// The dialects (drivers) supported
+const (
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	SQLite   = "sqlite3"
+)
+
 const (
 	maxAttempts    = 20
-	defaultDialect = "sqlite3"
+	defaultDialect = SQLite
 	defaultUri     = "teresa.sqlite"
 )
 
+var defaultPorts = map[string]int{
+	MySQL:    3306,
+	Postgres: 5432,
+}
+
 type Config struct {
+	// Driver is one of mysql, postgres or sqlite3, when blank it's mysql if
+	// Hostname is set and sqlite3 otherwise
+	Driver   string
 	Hostname string
-	Port     int `default:"3306"`
+	// Port defaults to the one of the driver
+	Port     int
 	Username string
 	Password string
 	Database string
-	ShowLogs bool `split_words:"true" default:"false"`
+	// SSLMode is the sslmode of the postgres connections
+	SSLMode  string `split_words:"true" default:"disable"`
+	ShowLogs bool   `split_words:"true" default:"false"`
+	// The connection pool, zero MaxOpenConns is unlimited, zero MaxIdleConns
+	// keeps the default of database/sql and zero ConnMaxLifetime keeps the
+	// connections forever
+	MaxOpenConns    int           `split_words:"true" default:"0"`
+	MaxIdleConns    int           `split_words:"true" default:"2"`
+	ConnMaxLifetime time.Duration `split_words:"true" default:"0"`
 }
 
-func New(conf *Config) (*gorm.DB, error) {
-	dialect := defaultDialect
-	uri := defaultUri
-	if conf.Hostname != "" {
-		dialect = "mysql"
-		uri = fmt.Sprintf(
+// dialect returns the dialect and the connection string of the config
+func (conf *Config) dialect() (string, string, error) {
+	dialect := conf.Driver
+	if dialect == "" {
+		dialect = defaultDialect
+		if conf.Hostname != "" {
+			dialect = MySQL
+		}
+	}
+
+	port := conf.Port
+	if port == 0 {
+		port = defaultPorts[dialect]
+	}
+
+	switch dialect {
+	case MySQL:
+		return dialect, fmt.Sprintf(
 			"%s:%s@tcp(%s:%d)/%s?parseTime=true",
 			conf.Username, conf.Password,
-			conf.Hostname, conf.Port, conf.Database,
-		)
-	} else if conf.Database != "" {
-		uri = conf.Database
+			conf.Hostname, port, conf.Database,
+		), nil
+	case Postgres:
+		return dialect, fmt.Sprintf(
+			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			quoteParam(conf.Hostname), port,
+			quoteParam(conf.Username), quoteParam(conf.Password),
+			quoteParam(conf.Database), quoteParam(conf.SSLMode),
+		), nil
+	case SQLite:
+		if conf.Database != "" {
+			return dialect, conf.Database, nil
+		}
+		return dialect, defaultUri, nil
+	}
+	return "", "", fmt.Errorf("unsupported database driver: %s", dialect)
+}
+
+// quoteParam quotes a value of a postgres connection string, blank values
+// and the ones with spaces or quotes aren't valid otherwise
+func quoteParam(v string) string {
+	var quoted []rune
+	for _, r := range v {
+		if r == '\'' || r == '\\' {
+			quoted = append(quoted, '\\')
+		}
+		quoted = append(quoted, r)
+	}
+	return "'" + string(quoted) + "'"
+}
+
+func New(conf *Config) (*gorm.DB, error) {
+	dialect, uri, err := conf.dialect()
+	if err != nil {
+		return nil, err
 	}
 
 	var db *gorm.DB
-	var err error
 	for attempts := 1; attempts <= maxAttempts; attempts++ {
 		db, err = gorm.Open(dialect, uri)
 		if err == nil {
@@ -66,5 +132,10 @@ func New(conf *Config) (*gorm.DB, error) {
 	}).Info("connected to database")
 
 	db.LogMode(conf.ShowLogs)
+	db.DB().SetMaxOpenConns(conf.MaxOpenConns)
+	if conf.MaxIdleConns > 0 {
+		db.DB().SetMaxIdleConns(conf.MaxIdleConns)
+	}
+	db.DB().SetConnMaxLifetime(conf.ConnMaxLifetime)
 	return db, nil
 }
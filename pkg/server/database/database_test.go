@@ -18,3 +18,54 @@ func TestNew(t *testing.T) {
 		t.Error("Error on make a ping to in memory database:", err)
 	}
 }
+
+func TestConfigDialect(t *testing.T) {
+	var testCases = []struct {
+		conf        *Config
+		expDialect  string
+		expURI      string
+		expectError bool
+	}{
+		{&Config{}, SQLite, "teresa.sqlite", false},
+		{&Config{Database: ":memory:"}, SQLite, ":memory:", false},
+		{
+			&Config{Hostname: "db", Username: "teresa", Password: "s3cr3t", Database: "teresa"},
+			MySQL,
+			"teresa:s3cr3t@tcp(db:3306)/teresa?parseTime=true",
+			false,
+		},
+		{
+			&Config{Driver: Postgres, Hostname: "db", Username: "teresa", Password: "it's s3cr3t", Database: "teresa", SSLMode: "require"},
+			Postgres,
+			`host='db' port=5432 user='teresa' password='it\'s s3cr3t' dbname='teresa' sslmode='require'`,
+			false,
+		},
+		{
+			&Config{Driver: Postgres, Hostname: "db", Port: 6432, Database: "teresa", SSLMode: "disable"},
+			Postgres,
+			`host='db' port=6432 user='' password='' dbname='teresa' sslmode='disable'`,
+			false,
+		},
+		{&Config{Driver: "oracle"}, "", "", true},
+	}
+
+	for _, tc := range testCases {
+		dialect, uri, err := tc.conf.dialect()
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("expected error for driver %s, got nil", tc.conf.Driver)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+			continue
+		}
+		if dialect != tc.expDialect {
+			t.Errorf("expected %s, got %s", tc.expDialect, dialect)
+		}
+		if uri != tc.expURI {
+			t.Errorf("expected %s, got %s", tc.expURI, uri)
+		}
+	}
+}
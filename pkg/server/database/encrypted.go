@@ -0,0 +1,129 @@
+package database
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+)
+
+// encryptedPrefix marks the encrypted values, the ones without it are
+// plaintext: stored before the encryption or without a cipher
+const encryptedPrefix = "enc:"
+
+var ErrNoCipher = errors.New("Encrypted value found but no encryption key is configured")
+
+// cipher encrypts the EncryptedString columns, they are stored as plaintext
+// without it
+var cipher encryption.Cipher
+
+// SetCipher sets the cipher of the EncryptedString columns
+func SetCipher(c encryption.Cipher) {
+	cipher = c
+}
+
+// EncryptedString is a string column encrypted at rest, the encryption is
+// transparent to the models
+type EncryptedString string
+
+// Value encrypts the string with the cipher, if any
+func (s EncryptedString) Value() (driver.Value, error) {
+	if s == "" || cipher == nil {
+		return string(s), nil
+	}
+	ciphertext, err := cipher.Encrypt([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	return encryptedPrefix + ciphertext, nil
+}
+
+// Scan decrypts the value of the column, the plaintext ones are kept as is
+func (s *EncryptedString) Scan(value interface{}) error {
+	var v string
+	switch value := value.(type) {
+	case nil:
+	case string:
+		v = value
+	case []byte:
+		v = string(value)
+	default:
+		return fmt.Errorf("cannot scan %T into EncryptedString", value)
+	}
+
+	if !strings.HasPrefix(v, encryptedPrefix) {
+		*s = EncryptedString(v)
+		return nil
+	}
+	if cipher == nil {
+		return ErrNoCipher
+	}
+	plaintext, err := cipher.Decrypt(strings.TrimPrefix(v, encryptedPrefix))
+	if err != nil {
+		return err
+	}
+	*s = EncryptedString(plaintext)
+	return nil
+}
+
+// encryptedColumns are the EncryptedString columns by table
+var encryptedColumns = map[string][]string{
+	"users":             {"totp_secret"},
+	"env_groups":        {"env_vars"},
+	"app_env_revisions": {"env_vars"},
+	"clusters":          {"kubeconfig"},
+}
+
+// Reencrypt encrypts again all EncryptedString columns with the current key
+// of the cipher, the plaintext ones included, returning the number of rows
+// updated. The previous keys aren't needed anymore after it.
+func Reencrypt(db *gorm.DB) (int, error) {
+	if cipher == nil {
+		return 0, ErrNoCipher
+	}
+	var count int
+	for table, columns := range encryptedColumns {
+		for _, column := range columns {
+			n, err := reencryptColumn(db, table, column)
+			count += n
+			if err != nil {
+				return count, fmt.Errorf("re-encrypting %s.%s: %v", table, column, err)
+			}
+		}
+	}
+	return count, nil
+}
+
+func reencryptColumn(db *gorm.DB, table, column string) (int, error) {
+	rows, err := db.Table(table).Select([]string{"id", column}).Where(column + " <> ''").Rows()
+	if err != nil {
+		return 0, err
+	}
+	values := make(map[uint]EncryptedString)
+	for rows.Next() {
+		var id uint
+		var v EncryptedString
+		if err := rows.Scan(&id, &v); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		values[id] = v
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for id, v := range values {
+		if err := db.Table(table).Where("id = ?", id).UpdateColumn(column, v).Error; err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
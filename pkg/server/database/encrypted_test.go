@@ -0,0 +1,171 @@
+package database
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+)
+
+func newTestCipher(t *testing.T, b byte) encryption.Cipher {
+	c, err := encryption.NewAESCipher(bytes.Repeat([]byte{b}, encryption.KeySize))
+	if err != nil {
+		t.Fatal("error creating cipher:", err)
+	}
+	return c
+}
+
+func newMigratedDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	return db
+}
+
+func storedEnvVars(t *testing.T, db *gorm.DB, id uint) string {
+	var stored string
+	if err := db.Table("env_groups").Where("id = ?", id).Select("env_vars").Row().Scan(&stored); err != nil {
+		t.Fatal("error getting env vars:", err)
+	}
+	return stored
+}
+
+func TestEncryptedString(t *testing.T) {
+	db := newMigratedDB(t)
+	defer db.Close()
+
+	plain := &EnvGroup{Name: "plain", EnvVars: `[{"key":"FOO","value":"bar"}]`}
+	if err := db.Create(plain).Error; err != nil {
+		t.Fatal("error creating env group:", err)
+	}
+	if stored := storedEnvVars(t, db, plain.ID); stored != string(plain.EnvVars) {
+		t.Errorf("expected %s stored without a cipher, got %s", plain.EnvVars, stored)
+	}
+
+	SetCipher(newTestCipher(t, 1))
+	defer SetCipher(nil)
+
+	eg := &EnvGroup{Name: "encrypted", EnvVars: `[{"key":"PASSWORD","value":"s3cr3t"}]`}
+	if err := db.Create(eg).Error; err != nil {
+		t.Fatal("error creating env group:", err)
+	}
+	stored := storedEnvVars(t, db, eg.ID)
+	if !strings.HasPrefix(stored, encryptedPrefix) || strings.Contains(stored, "s3cr3t") {
+		t.Errorf("expected the env vars stored encrypted, got %s", stored)
+	}
+
+	for _, exp := range []*EnvGroup{plain, eg} {
+		got := new(EnvGroup)
+		if err := db.First(got, exp.ID).Error; err != nil {
+			t.Fatal("error getting env group:", err)
+		}
+		if got.EnvVars != exp.EnvVars {
+			t.Errorf("expected %s, got %s", exp.EnvVars, got.EnvVars)
+		}
+	}
+
+	SetCipher(nil)
+	// the scan errors are wrapped by database/sql
+	if err := db.First(new(EnvGroup), eg.ID).Error; err == nil || !strings.Contains(err.Error(), ErrNoCipher.Error()) {
+		t.Errorf("expected ErrNoCipher, got %v", err)
+	}
+	SetCipher(newTestCipher(t, 2))
+	if err := db.First(new(EnvGroup), eg.ID).Error; err == nil || !strings.Contains(err.Error(), encryption.ErrInvalidCiphertext.Error()) {
+		t.Errorf("expected ErrInvalidCiphertext, got %v", err)
+	}
+}
+
+func TestReencrypt(t *testing.T) {
+	db := newMigratedDB(t)
+	defer db.Close()
+	defer SetCipher(nil)
+
+	if _, err := Reencrypt(db); err != ErrNoCipher {
+		t.Errorf("expected ErrNoCipher, got %v", err)
+	}
+
+	plain := &EnvGroup{Name: "plain", EnvVars: "[]"}
+	if err := db.Create(plain).Error; err != nil {
+		t.Fatal("error creating env group:", err)
+	}
+	old := newTestCipher(t, 1)
+	SetCipher(old)
+	eg := &EnvGroup{Name: "old", EnvVars: "[]"}
+	if err := db.Create(eg).Error; err != nil {
+		t.Fatal("error creating env group:", err)
+	}
+	u := &User{Name: "gopher", Email: "gopher@luizalabs.com", Password: "secret", TOTPSecret: "JBSWY3DPEHPK3PXP"}
+	if err := db.Create(u).Error; err != nil {
+		t.Fatal("error creating user:", err)
+	}
+
+	current := newTestCipher(t, 2)
+	SetCipher(encryption.NewMultiCipher(current, old))
+	n, err := Reencrypt(db)
+	if err != nil {
+		t.Fatal("error re-encrypting:", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 values re-encrypted, got %d", n)
+	}
+
+	SetCipher(current)
+	for _, exp := range []*EnvGroup{plain, eg} {
+		if stored := storedEnvVars(t, db, exp.ID); !strings.HasPrefix(stored, encryptedPrefix) {
+			t.Errorf("expected %s encrypted, got %s", exp.Name, stored)
+		}
+		got := new(EnvGroup)
+		if err := db.First(got, exp.ID).Error; err != nil || got.EnvVars != exp.EnvVars {
+			t.Errorf("expected %s decrypted with the current key, got %s (%v)", exp.EnvVars, got.EnvVars, err)
+		}
+	}
+	got := new(User)
+	if err := db.First(got, u.ID).Error; err != nil || got.TOTPSecret != u.TOTPSecret {
+		t.Errorf("expected %s decrypted with the current key, got %s (%v)", u.TOTPSecret, got.TOTPSecret, err)
+	}
+}
+
+func TestMarkTOTPSecrets(t *testing.T) {
+	db := newMigratedDB(t)
+	defer db.Close()
+	defer SetCipher(nil)
+
+	c := newTestCipher(t, 1)
+	legacy, _ := c.Encrypt([]byte("JBSWY3DPEHPK3PXP"))
+	// back to the initial schema, before the migration
+	afterInitial := len(migrations) - 1
+	if _, err := MigrateDown(db, afterInitial); err != nil {
+		t.Fatal("error reverting migration:", err)
+	}
+	if err := db.Table("users").Create(&User{Name: "gopher", Email: "gopher@luizalabs.com", Password: "secret"}).Error; err != nil {
+		t.Fatal("error creating user:", err)
+	}
+	// the secrets encrypted by the user operations before the migration
+	if err := db.Table("users").UpdateColumn("totp_secret", legacy).Error; err != nil {
+		t.Fatal("error setting secret:", err)
+	}
+
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal("error applying migration:", err)
+	}
+	SetCipher(c)
+	got := new(User)
+	if err := db.First(got).Error; err != nil || got.TOTPSecret != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("expected the legacy secret decrypted, got %s (%v)", got.TOTPSecret, err)
+	}
+
+	if _, err := MigrateDown(db, afterInitial); err != nil {
+		t.Fatal("error reverting migration:", err)
+	}
+	var stored string
+	if err := db.Table("users").Select("totp_secret").Row().Scan(&stored); err != nil || stored != legacy {
+		t.Errorf("expected %s, got %s (%v)", legacy, stored, err)
+	}
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/kelseyhightower/envconfig"
+)
+
+func checkPending(t *testing.T, db *gorm.DB, expected int) {
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		t.Fatal("error getting pending migrations:", err)
+	}
+	if len(pending) != expected {
+		t.Errorf("expected %d pending migrations, got %d", expected, len(pending))
+	}
+}
+
+func testMigrations(t *testing.T, db *gorm.DB) {
+	checkPending(t, db, len(migrations))
+	applied, err := MigrateUp(db)
+	if err != nil {
+		t.Fatal("error applying migrations:", err)
+	}
+	if len(applied) != len(migrations) {
+		t.Errorf("expected %d migrations applied, got %d", len(migrations), len(applied))
+	}
+	checkPending(t, db, 0)
+	if db.HasTable("app_deploys") {
+		t.Error("expected app_deploys table dropped")
+	}
+	if applied, err = MigrateUp(db); err != nil || len(applied) != 0 {
+		t.Errorf("expected no migrations applied, got %d (%v)", len(applied), err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	u := &User{Name: "gopher", Email: "gopher@luizalabs.com", Password: "secret", PasswordChangedAt: &now}
+	if err := db.Create(u).Error; err != nil {
+		t.Fatal("error creating user:", err)
+	}
+	tm := &Team{Name: "luizalabs"}
+	if err := db.Create(tm).Error; err != nil {
+		t.Fatal("error creating team:", err)
+	}
+	if err := db.Create(&TeamUser{TeamID: tm.ID, UserID: u.ID, Role: "admin"}).Error; err != nil {
+		t.Fatal("error adding user to team:", err)
+	}
+
+	got := new(User)
+	if err := db.Preload("Teams").Where(&User{Email: u.Email}).First(got).Error; err != nil {
+		t.Fatal("error getting user:", err)
+	}
+	if len(got.Teams) != 1 || got.Teams[0].Name != tm.Name {
+		t.Errorf("expected team %s, got %v", tm.Name, got.Teams)
+	}
+	if got.PasswordChangedAt == nil || !got.PasswordChangedAt.Equal(now) {
+		t.Errorf("expected %v, got %v", now, got.PasswordChangedAt)
+	}
+
+	reverted, err := MigrateDown(db, len(migrations))
+	if err != nil {
+		t.Fatal("error reverting migrations:", err)
+	}
+	if len(reverted) != len(migrations) {
+		t.Errorf("expected %d migrations reverted, got %d", len(migrations), len(reverted))
+	}
+	if db.HasTable(&User{}) {
+		t.Error("expected users table dropped")
+	}
+	checkPending(t, db, len(migrations))
+}
+
+func TestMigrate(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	defer db.Close()
+
+	testMigrations(t, db)
+}
+
+func TestMigrateUpExistingSchema(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	defer db.Close()
+
+	// the tables created on start by the servers before the migrations
+	db.AutoMigrate(&User{})
+	if err := db.Create(&User{Name: "gopher", Email: "gopher@luizalabs.com", Password: "secret"}).Error; err != nil {
+		t.Fatal("error creating user:", err)
+	}
+
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal("error applying migrations:", err)
+	}
+	var count int
+	if err := db.Model(&User{}).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("expected the user kept, got %d (%v)", count, err)
+	}
+	if !db.HasTable(&Team{}) {
+		t.Error("expected teams table created")
+	}
+}
+
+func TestInitialSchemaFrozen(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	defer db.Close()
+
+	if err := migrations[0].Up(db); err != nil {
+		t.Fatal("error applying the initial schema:", err)
+	}
+	// the columns added later are left to their migrations
+	if db.Dialect().HasColumn("users", "totp_counter") {
+		t.Error("expected no totp_counter column on the initial schema")
+	}
+}
+
+func TestMigrateDownSteps(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	defer db.Close()
+
+	if reverted, err := MigrateDown(db, 1); err != nil || len(reverted) != 0 {
+		t.Errorf("expected no migrations reverted, got %d (%v)", len(reverted), err)
+	}
+	if _, err := MigrateUp(db); err != nil {
+		t.Fatal("error applying migrations:", err)
+	}
+	reverted, err := MigrateDown(db, 1)
+	if err != nil {
+		t.Fatal("error reverting migrations:", err)
+	}
+	if len(reverted) != 1 || reverted[0] != migrations[len(migrations)-1] {
+		t.Errorf("expected the last migration reverted, got %v", reverted)
+	}
+}
+
+// TestMigrations runs the migrations on the database of the TERESA_TEST_DB_*
+// env vars, like the TERESA_DB_* ones of the server. CI sets them to check
+// each dialect.
+func TestMigrations(t *testing.T) {
+	conf := new(Config)
+	if err := envconfig.Process("teresa_test_db", conf); err != nil {
+		t.Fatal("error reading the test database config:", err)
+	}
+	if conf.Driver == "" {
+		t.Skip("TERESA_TEST_DB_DRIVER not set")
+	}
+
+	db, err := New(conf)
+	if err != nil {
+		t.Fatal("error connecting to the test database:", err)
+	}
+	defer db.Close()
+
+	testMigrations(t, db)
+}
@@ -0,0 +1,643 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	// migrationsLockName and migrationsLockID name the lock of the
+	// migrations on MySQL and PostgreSQL, each one takes its own kind
+	migrationsLockName = "teresa-migrations"
+	migrationsLockID   = 4920150
+	// migrationsLockTimeout is how long MySQL waits for the lock (seconds)
+	migrationsLockTimeout = 600
+)
+
+// Migration is a versioned change of the schema of the database, Down
+// reverts Up. The migrations must not use the models of this package, they
+// change along the schema, but types of their own.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(*gorm.DB) error
+	Down    func(*gorm.DB) error
+}
+
+// SchemaMigration represents an applied Migration
+type SchemaMigration struct {
+	Version   int    `gorm:"primary_key;auto_increment:false;"`
+	Name      string `gorm:"size:128;not null;"`
+	AppliedAt time.Time
+}
+
+// MigrationStatus is a Migration along with when it was applied, nil if it
+// wasn't yet
+type MigrationStatus struct {
+	*Migration
+	AppliedAt *time.Time
+}
+
+// migrations are the ones of the schema, by version. Append only, the
+// applied ones are never changed.
+var migrations = []*Migration{
+	{
+		Version: 1,
+		Name:    "initial schema",
+		Up:      initialSchemaUp,
+		Down:    initialSchemaDown,
+	},
+	{
+		Version: 2,
+		Name:    "mark encrypted two-factor secrets",
+		Up:      markTOTPSecretsUp,
+		Down:    markTOTPSecretsDown,
+	},
+	{
+		Version: 3,
+		Name:    "clusters",
+		Up:      clustersUp,
+		Down:    clustersDown,
+	},
+	{
+		Version: 4,
+		Name:    "leases",
+		Up:      leasesUp,
+		Down:    leasesDown,
+	},
+	{
+		Version: 5,
+		Name:    "drop app deploys",
+		Up:      dropAppDeploysUp,
+		Down:    dropAppDeploysDown,
+	},
+	{
+		Version: 6,
+		Name:    "pending deploys",
+		Up:      pendingDeploysUp,
+		Down:    pendingDeploysDown,
+	},
+	{
+		Version: 7,
+		Name:    "app locks",
+		Up:      appLocksUp,
+		Down:    appLocksDown,
+	},
+	{
+		Version: 8,
+		Name:    "two-factor counters",
+		Up:      twoFactorCountersUp,
+		Down:    twoFactorCountersDown,
+	},
+}
+
+// initialModels are the tables of the initial schema, in the order they are
+// created
+var initialModels = []interface{}{
+	&userV1{}, &passwordResetV1{}, &sessionV1{}, &passwordHistoryV1{},
+	&teamV1{}, &teamUserV1{}, &freezeWindowV1{}, &teamTokenV1{},
+	&deployLockV1{}, &appDeployV1{}, &appEnvRevisionV1{}, &auditEntryV1{},
+	&envGroupV1{},
+}
+
+// userV1 and the other V1 types are the tables of the initial schema,
+// without the associations of the models
+type userV1 struct {
+	BaseModel
+	Name              string `gorm:"size:128;not null;unique_index;"`
+	Email             string `gorm:"size:64;not null;unique_index;"`
+	Password          string `gorm:"size:60;not null;"`
+	IsAdmin           bool   `gorm:"not null;"`
+	TOTPSecret        string `gorm:"size:255;"`
+	TOTPEnabled       bool   `gorm:"not null;default:false;"`
+	Suspended         bool   `gorm:"not null;default:false;"`
+	PasswordChangedAt *time.Time
+}
+
+func (userV1) TableName() string {
+	return "users"
+}
+
+type passwordResetV1 struct {
+	BaseModel
+	UserID    uint      `gorm:"not null;index;"`
+	TokenHash string    `gorm:"size:64;not null;unique_index;"`
+	ExpiresAt time.Time `gorm:"not null;"`
+}
+
+func (passwordResetV1) TableName() string {
+	return "password_resets"
+}
+
+type sessionV1 struct {
+	BaseModel
+	UserID      uint      `gorm:"not null;index;"`
+	RefreshHash string    `gorm:"size:64;not null;unique_index;"`
+	ExpiresAt   time.Time `gorm:"not null;"`
+}
+
+func (sessionV1) TableName() string {
+	return "sessions"
+}
+
+type passwordHistoryV1 struct {
+	BaseModel
+	UserID   uint   `gorm:"not null;index;"`
+	Password string `gorm:"size:60;not null;"`
+}
+
+func (passwordHistoryV1) TableName() string {
+	return "password_histories"
+}
+
+type teamV1 struct {
+	BaseModel
+	Name             string `gorm:"size:128;not null;unique_index;"`
+	Email            string `gorm:"size:64;"`
+	URL              string `gorm:"size:1024;"`
+	Domains          string `gorm:"size:1024;"`
+	ProtectedApps    string `gorm:"size:1024;"`
+	Approvers        string `gorm:"size:1024;"`
+	QuotaApps        int32  `gorm:"not null;default:0;"`
+	QuotaReplicas    int32  `gorm:"not null;default:0;"`
+	QuotaCPU         string `gorm:"size:16;"`
+	QuotaMemory      string `gorm:"size:16;"`
+	EnvVars          string `gorm:"type:text;"`
+	RequireTwoFactor bool   `gorm:"not null;default:false;"`
+}
+
+func (teamV1) TableName() string {
+	return "teams"
+}
+
+type teamUserV1 struct {
+	TeamID uint   `gorm:"primary_key;auto_increment:false;"`
+	UserID uint   `gorm:"primary_key;auto_increment:false;"`
+	Role   string `gorm:"size:16;not null;default:'developer';"`
+}
+
+func (teamUserV1) TableName() string {
+	return "teams_users"
+}
+
+type freezeWindowV1 struct {
+	BaseModel
+	TeamID   uint      `gorm:"not null;index;"`
+	StartsAt time.Time `gorm:"not null;"`
+	EndsAt   time.Time `gorm:"not null;"`
+	Reason   string    `gorm:"size:255;"`
+}
+
+func (freezeWindowV1) TableName() string {
+	return "freeze_windows"
+}
+
+type teamTokenV1 struct {
+	BaseModel
+	TeamID    uint      `gorm:"not null;unique_index:idx_team_token;"`
+	Name      string    `gorm:"size:63;not null;unique_index:idx_team_token;"`
+	UserID    uint      `gorm:"not null;"`
+	ExpiresAt time.Time `gorm:"not null;"`
+}
+
+func (teamTokenV1) TableName() string {
+	return "team_tokens"
+}
+
+type deployLockV1 struct {
+	BaseModel
+	AppName  string `gorm:"size:63;not null;unique_index;"`
+	DeployID string `gorm:"size:64;not null;"`
+	Author   string `gorm:"size:64;not null;"`
+}
+
+func (deployLockV1) TableName() string {
+	return "deploy_locks"
+}
+
+type appEnvRevisionV1 struct {
+	BaseModel
+	AppName string `gorm:"size:63;not null;unique_index:idx_app_env_revision;"`
+	Version int32  `gorm:"not null;unique_index:idx_app_env_revision;"`
+	Author  string `gorm:"size:64;not null;"`
+	EnvVars string `gorm:"type:text;not null;"`
+}
+
+func (appEnvRevisionV1) TableName() string {
+	return "app_env_revisions"
+}
+
+type auditEntryV1 struct {
+	BaseModel
+	Author       string `gorm:"size:64;not null;index;"`
+	Method       string `gorm:"size:128;not null;"`
+	Team         string `gorm:"size:128;index;"`
+	App          string `gorm:"size:63;"`
+	Request      string `gorm:"type:text;"`
+	Status       string `gorm:"size:32;not null;"`
+	Impersonator string `gorm:"size:64;"`
+}
+
+func (auditEntryV1) TableName() string {
+	return "audit_entries"
+}
+
+type envGroupV1 struct {
+	BaseModel
+	Name    string `gorm:"size:63;not null;unique_index;"`
+	TeamID  uint   `gorm:"not null;"`
+	EnvVars string `gorm:"type:text;not null;"`
+	Apps    string `gorm:"type:text;not null;"`
+}
+
+func (envGroupV1) TableName() string {
+	return "env_groups"
+}
+
+// appDeployV1 is the table of the git metadata of the deploys, dropped by
+// the migration 5 as it's kept in the annotations of the deploys
+type appDeployV1 struct {
+	BaseModel
+	AppName  string `gorm:"size:63;not null;index;"`
+	DeployID string `gorm:"size:64;not null;unique_index;"`
+	Author   string `gorm:"size:64;not null;"`
+	Commit   string `gorm:"size:64;"`
+	Branch   string `gorm:"size:255;"`
+	Message  string `gorm:"type:text;"`
+	Override string `gorm:"size:512;"`
+}
+
+func (appDeployV1) TableName() string {
+	return "app_deploys"
+}
+
+// initialSchemaUp creates the tables, the ones of the databases created
+// before the migrations (by the server on start) are completed instead
+func initialSchemaUp(db *gorm.DB) error {
+	return db.AutoMigrate(initialModels...).Error
+}
+
+func initialSchemaDown(db *gorm.DB) error {
+	for i := len(initialModels) - 1; i >= 0; i-- {
+		if err := db.DropTableIfExists(initialModels[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appliedMigrations(db *gorm.DB) (map[int]*SchemaMigration, error) {
+	if err := db.AutoMigrate(&SchemaMigration{}).Error; err != nil {
+		return nil, err
+	}
+	var sms []*SchemaMigration
+	if err := db.Find(&sms).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int]*SchemaMigration)
+	for _, sm := range sms {
+		applied[sm.Version] = sm
+	}
+	return applied, nil
+}
+
+// Migrations returns the status of all migrations, by version
+func Migrations(db *gorm.DB) ([]*MigrationStatus, error) {
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]*MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		status[i] = &MigrationStatus{Migration: m}
+		if sm, found := applied[m.Version]; found {
+			status[i].AppliedAt = &sm.AppliedAt
+		}
+	}
+	return status, nil
+}
+
+// PendingMigrations returns the migrations not applied yet, by version
+func PendingMigrations(db *gorm.DB) ([]*Migration, error) {
+	status, err := Migrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []*Migration
+	for _, s := range status {
+		if s.AppliedAt == nil {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// runMigration runs the step of a migration and records it. There is no
+// transaction, gorm checks the tables out of it and MySQL commits the DDL
+// anyway, so the steps must be safe to run again after a failure.
+func runMigration(db *gorm.DB, m *Migration, up bool) error {
+	var err error
+	if up {
+		if err = m.Up(db); err == nil {
+			err = db.Create(&SchemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+		}
+	} else {
+		if err = m.Down(db); err == nil {
+			err = db.Delete(&SchemaMigration{Version: m.Version}).Error
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("migration %d (%s): %v", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// lockMigrations holds a lock of the database while the migrations run, so
+// the replicas of the server starting together don't run them twice. The
+// lock is bound to a connection of its own, the one released by unlock.
+// SQLite databases are of a single server and aren't locked.
+func lockMigrations(db *gorm.DB) (unlock func(), err error) {
+	dialect := db.Dialect().GetName()
+	if dialect != "postgres" && dialect != "mysql" {
+		return func() {}, nil
+	}
+
+	ctx := context.Background()
+	conn, err := db.DB().Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlockQuery, key := "SELECT pg_advisory_unlock($1)", interface{}(migrationsLockID)
+	if dialect == "postgres" {
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key)
+	} else {
+		unlockQuery, key = "SELECT RELEASE_LOCK(?)", migrationsLockName
+		// GET_LOCK returns 1 once locked and 0 on timeout
+		var locked sql.NullInt64
+		err = conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", key, migrationsLockTimeout).Scan(&locked)
+		if err == nil && locked.Int64 != 1 {
+			err = fmt.Errorf("timeout")
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("locking the migrations: %v", err)
+	}
+
+	return func() {
+		if _, err := conn.ExecContext(ctx, unlockQuery, key); err != nil {
+			log.WithError(err).Error("unlocking the migrations")
+		}
+		conn.Close()
+	}, nil
+}
+
+// MigrateUp applies the pending migrations in order, returning the ones
+// applied until the first failure
+func MigrateUp(db *gorm.DB) ([]*Migration, error) {
+	unlock, err := lockMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	pending, err := PendingMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var applied []*Migration
+	for _, m := range pending {
+		if err := runMigration(db, m, true); err != nil {
+			return applied, err
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// MigrateDown reverts the last steps applied migrations, latest first,
+// returning the ones reverted until the first failure
+func MigrateDown(db *gorm.DB, steps int) ([]*Migration, error) {
+	unlock, err := lockMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	status, err := Migrations(db)
+	if err != nil {
+		return nil, err
+	}
+	var reverted []*Migration
+	for i := len(status) - 1; i >= 0 && len(reverted) < steps; i-- {
+		if status[i].AppliedAt == nil {
+			continue
+		}
+		if err := runMigration(db, status[i].Migration, false); err != nil {
+			return reverted, err
+		}
+		reverted = append(reverted, status[i].Migration)
+	}
+	return reverted, nil
+}
+
+// updateTOTPSecrets replaces the two-factor secrets of the users by the
+// result of fn
+func updateTOTPSecrets(db *gorm.DB, fn func(string) string) error {
+	rows, err := db.Table("users").Select("id, totp_secret").Where("totp_secret <> ''").Rows()
+	if err != nil {
+		return err
+	}
+	secrets := make(map[uint]string)
+	for rows.Next() {
+		var id uint
+		var secret string
+		if err := rows.Scan(&id, &secret); err != nil {
+			rows.Close()
+			return err
+		}
+		secrets[id] = secret
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, secret := range secrets {
+		if err := db.Table("users").Where("id = ?", id).UpdateColumn("totp_secret", fn(secret)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markTOTPSecretsUp prefixes the two-factor secrets, encrypted before the
+// EncryptedString columns, like the values encrypted by them
+func markTOTPSecretsUp(db *gorm.DB) error {
+	return updateTOTPSecrets(db, func(secret string) string {
+		if strings.HasPrefix(secret, encryptedPrefix) {
+			return secret
+		}
+		return encryptedPrefix + secret
+	})
+}
+
+func markTOTPSecretsDown(db *gorm.DB) error {
+	return updateTOTPSecrets(db, func(secret string) string {
+		return strings.TrimPrefix(secret, encryptedPrefix)
+	})
+}
+
+// clusterV3 and appClusterV3 are the tables of the clusters and the
+// assignments of the apps to them
+type clusterV3 struct {
+	BaseModel
+	Name       string `gorm:"size:63;not null;unique_index;"`
+	Kubeconfig string `gorm:"type:text;not null;"`
+}
+
+func (clusterV3) TableName() string {
+	return "clusters"
+}
+
+type appClusterV3 struct {
+	BaseModel
+	AppName string `gorm:"size:63;not null;unique_index;"`
+	Cluster string `gorm:"size:63;not null;index;"`
+}
+
+func (appClusterV3) TableName() string {
+	return "app_clusters"
+}
+
+func clustersUp(db *gorm.DB) error {
+	return db.AutoMigrate(&clusterV3{}, &appClusterV3{}).Error
+}
+
+func clustersDown(db *gorm.DB) error {
+	return db.DropTableIfExists(&appClusterV3{}, &clusterV3{}).Error
+}
+
+// leaseV4 is the table of the leases of the background tasks
+type leaseV4 struct {
+	BaseModel
+	Name      string    `gorm:"size:63;not null;unique_index;"`
+	Holder    string    `gorm:"size:255;not null;"`
+	ExpiresAt time.Time `gorm:"not null;"`
+}
+
+func (leaseV4) TableName() string {
+	return "leases"
+}
+
+func leasesUp(db *gorm.DB) error {
+	return db.AutoMigrate(&leaseV4{}).Error
+}
+
+func leasesDown(db *gorm.DB) error {
+	return db.DropTableIfExists(&leaseV4{}).Error
+}
+
+func dropAppDeploysUp(db *gorm.DB) error {
+	return db.DropTableIfExists(&appDeployV1{}).Error
+}
+
+func dropAppDeploysDown(db *gorm.DB) error {
+	return db.AutoMigrate(&appDeployV1{}).Error
+}
+
+// pendingDeployV6 is the table of the deploys waiting for approval
+type pendingDeployV6 struct {
+	BaseModel
+	DeployID   string    `gorm:"size:64;not null;unique_index;"`
+	AppName    string    `gorm:"size:63;not null;"`
+	TeamName   string    `gorm:"size:64;not null;"`
+	Author     string    `gorm:"size:64;not null;"`
+	ApprovedBy string    `gorm:"size:64;"`
+	ExpiresAt  time.Time `gorm:"not null;"`
+}
+
+func (pendingDeployV6) TableName() string {
+	return "pending_deploys"
+}
+
+func pendingDeploysUp(db *gorm.DB) error {
+	return db.AutoMigrate(&pendingDeployV6{}).Error
+}
+
+func pendingDeploysDown(db *gorm.DB) error {
+	return db.DropTableIfExists(&pendingDeployV6{}).Error
+}
+
+// appLockV7 is the table of the locks blocking the deploys of the apps
+type appLockV7 struct {
+	BaseModel
+	AppName string `gorm:"size:63;not null;unique_index;"`
+	Reason  string `gorm:"size:255;"`
+	User    string `gorm:"size:64;not null;"`
+}
+
+func (appLockV7) TableName() string {
+	return "app_locks"
+}
+
+func appLocksUp(db *gorm.DB) error {
+	return db.AutoMigrate(&appLockV7{}).Error
+}
+
+func appLocksDown(db *gorm.DB) error {
+	return db.DropTableIfExists(&appLockV7{}).Error
+}
+
+// userTOTPV8 and sessionTwoFactorV8 are the columns of the last two-factor
+// code accepted of the users and of the sessions logged in with a code
+type userTOTPV8 struct {
+	TOTPCounter  int64 `gorm:"not null;default:0;"`
+	TOTPFailures int   `gorm:"not null;default:0;"`
+	TOTPFailedAt *time.Time
+}
+
+func (userTOTPV8) TableName() string {
+	return "users"
+}
+
+type sessionTwoFactorV8 struct {
+	TwoFactor bool `gorm:"not null;default:false;"`
+}
+
+func (sessionTwoFactorV8) TableName() string {
+	return "sessions"
+}
+
+func twoFactorCountersUp(db *gorm.DB) error {
+	return db.AutoMigrate(&userTOTPV8{}, &sessionTwoFactorV8{}).Error
+}
+
+// twoFactorCountersDown keeps the columns on SQLite, which can't drop them,
+// they are ignored by the previous schema
+func twoFactorCountersDown(db *gorm.DB) error {
+	if db.Dialect().GetName() == "sqlite3" {
+		return nil
+	}
+	columns := map[string][]string{
+		"users":    {"totp_counter", "totp_failures", "totp_failed_at"},
+		"sessions": {"two_factor"},
+	}
+	for table, cs := range columns {
+		for _, c := range cs {
+			if !db.Dialect().HasColumn(table, c) {
+				continue
+			}
+			if err := db.Table(table).DropColumn(c).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
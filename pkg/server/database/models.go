@@ -4,6 +4,7 @@ import (
 	"time"
 
 	_ "github.com/jinzhu/gorm/dialects/mysql"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
@@ -21,6 +22,35 @@ type Team struct {
 	Email string `gorm:"size:64;"`
 	URL   string `gorm:"size:1024;"`
 	Users []User `gorm:"many2many:teams_users;"`
+	// Domains is a comma separated list of the domains allowed as vhosts
+	// of the team apps, blank means any domain
+	Domains string `gorm:"size:1024;"`
+	// ProtectedApps is a comma separated list of the team apps whose
+	// deploys must be approved by one of the team admins
+	ProtectedApps string `gorm:"size:1024;"`
+	// The quota of the team apps, zero or blank means no limit
+	QuotaApps     int32  `gorm:"not null;default:0;"`
+	QuotaReplicas int32  `gorm:"not null;default:0;"`
+	QuotaCPU      string `gorm:"size:16;"`
+	QuotaMemory   string `gorm:"size:16;"`
+	// EnvVars are the default env vars of the team apps in JSON
+	EnvVars string `gorm:"type:text;"`
+	// RequireTwoFactor takes the roles of the members without two-factor
+	// authentication enabled
+	RequireTwoFactor bool `gorm:"not null;default:false;"`
+}
+
+// TeamUser represents the membership of a user in a team, with the role of
+// the user in the team
+type TeamUser struct {
+	TeamID uint   `gorm:"primary_key;auto_increment:false;"`
+	UserID uint   `gorm:"primary_key;auto_increment:false;"`
+	Role   string `gorm:"size:16;not null;default:'developer';"`
+}
+
+// TableName is the join table of the users of the teams
+func (TeamUser) TableName() string {
+	return "teams_users"
 }
 
 // User represents a developer
@@ -31,4 +61,165 @@ type User struct {
 	Password string `gorm:"size:60;not null;"`
 	IsAdmin  bool   `gorm:"not null;"`
 	Teams    []Team `gorm:"many2many:teams_users;"`
+	// TOTPSecret is the encrypted secret of the two-factor authentication,
+	// enabled only after the first code is checked
+	TOTPSecret  EncryptedString `gorm:"size:255;"`
+	TOTPEnabled bool            `gorm:"not null;default:false;"`
+	// TOTPCounter is the time step of the last code accepted, the codes
+	// up to it are never accepted again
+	TOTPCounter int64 `gorm:"not null;default:0;"`
+	// TOTPFailures are the codes rejected in a row, the last one at
+	// TOTPFailedAt
+	TOTPFailures int `gorm:"not null;default:0;"`
+	TOTPFailedAt *time.Time
+	// TwoFactorVerified tells if the session of the request checked a
+	// code, set on the authentication of each request and never stored
+	TwoFactorVerified bool `gorm:"-"`
+	// Suspended users are kept, for the history of their actions, but can't
+	// login nor call any RPC
+	Suspended bool `gorm:"not null;default:false;"`
+	// PasswordChangedAt is blank on the users created before it
+	PasswordChangedAt *time.Time
+}
+
+// PasswordHistory represents a previous password of a user, kept to prevent
+// its reuse
+type PasswordHistory struct {
+	BaseModel
+	User     User
+	UserID   uint   `gorm:"not null;index;"`
+	Password string `gorm:"size:60;not null;"`
+}
+
+// PasswordReset represents a pending password reset of a user, only the
+// hash of its token is stored
+type PasswordReset struct {
+	BaseModel
+	User      User
+	UserID    uint      `gorm:"not null;index;"`
+	TokenHash string    `gorm:"size:64;not null;unique_index;"`
+	ExpiresAt time.Time `gorm:"not null;"`
+}
+
+// Session represents a login of a user, its short-lived access tokens are
+// renewed by the refresh token, only the hash of it is stored
+type Session struct {
+	BaseModel
+	User        User
+	UserID      uint      `gorm:"not null;index;"`
+	RefreshHash string    `gorm:"size:64;not null;unique_index;"`
+	ExpiresAt   time.Time `gorm:"not null;"`
+	// TwoFactor tells if a two-factor code was checked on the login
+	TwoFactor bool `gorm:"not null;default:false;"`
+}
+
+// AppEnvRevision represents a snapshot of the env vars of an app
+type AppEnvRevision struct {
+	BaseModel
+	AppName string          `gorm:"size:63;not null;unique_index:idx_app_env_revision;"`
+	Version int32           `gorm:"not null;unique_index:idx_app_env_revision;"`
+	Author  string          `gorm:"size:64;not null;"`
+	EnvVars EncryptedString `gorm:"type:text;not null;"`
+}
+
+// EnvGroup represents a set of env vars owned by a team and shared by apps
+type EnvGroup struct {
+	BaseModel
+	Name    string `gorm:"size:63;not null;unique_index;"`
+	Team    Team
+	TeamID  uint            `gorm:"not null;"`
+	EnvVars EncryptedString `gorm:"type:text;not null;"`
+	Apps    string          `gorm:"type:text;not null;"`
+}
+
+// FreezeWindow represents a period the deploys of the apps of a team are
+// frozen, from StartsAt to EndsAt
+type FreezeWindow struct {
+	BaseModel
+	Team     Team
+	TeamID   uint      `gorm:"not null;index;"`
+	StartsAt time.Time `gorm:"not null;"`
+	EndsAt   time.Time `gorm:"not null;"`
+	Reason   string    `gorm:"size:255;"`
+}
+
+// TeamToken represents an API token of a team, it authenticates as the
+// service account User, a member of the team
+type TeamToken struct {
+	BaseModel
+	Team      Team
+	TeamID    uint   `gorm:"not null;unique_index:idx_team_token;"`
+	Name      string `gorm:"size:63;not null;unique_index:idx_team_token;"`
+	User      User
+	UserID    uint      `gorm:"not null;"`
+	ExpiresAt time.Time `gorm:"not null;"`
+}
+
+// DeployLock is held by the running deploy of an app, from its start to the
+// end of its rollout
+type DeployLock struct {
+	BaseModel
+	AppName  string `gorm:"size:63;not null;unique_index;"`
+	DeployID string `gorm:"size:64;not null;"`
+	Author   string `gorm:"size:64;not null;"`
+}
+
+// AppLock blocks the deploys of an app until it's unlocked, User is the
+// email of who locked it
+type AppLock struct {
+	BaseModel
+	AppName string `gorm:"size:63;not null;unique_index;"`
+	Reason  string `gorm:"size:255;"`
+	User    string `gorm:"size:64;not null;"`
+}
+
+// PendingDeploy is a deploy of a protected app waiting for the approval of
+// another user until ExpiresAt, ApprovedBy is set by the approver
+type PendingDeploy struct {
+	BaseModel
+	DeployID   string    `gorm:"size:64;not null;unique_index;"`
+	AppName    string    `gorm:"size:63;not null;"`
+	TeamName   string    `gorm:"size:64;not null;"`
+	Author     string    `gorm:"size:64;not null;"`
+	ApprovedBy string    `gorm:"size:64;"`
+	ExpiresAt  time.Time `gorm:"not null;"`
+}
+
+// AuditEntry represents a mutating RPC called by a user, the team and app
+// are kept by name so the entries outlive them
+type AuditEntry struct {
+	BaseModel
+	Author  string `gorm:"size:64;not null;index;"`
+	Method  string `gorm:"size:128;not null;"`
+	Team    string `gorm:"size:128;index;"`
+	App     string `gorm:"size:63;"`
+	Request string `gorm:"type:text;"`
+	Status  string `gorm:"size:32;not null;"`
+	// Impersonator is the admin acting as the author
+	Impersonator string `gorm:"size:64;"`
+}
+
+// Cluster represents a Kubernetes cluster the apps can be created on, besides
+// the one of the server
+type Cluster struct {
+	BaseModel
+	Name       string          `gorm:"size:63;not null;unique_index;"`
+	Kubeconfig EncryptedString `gorm:"type:text;not null;"`
+}
+
+// AppCluster assigns an app to a Cluster, the apps without one are on the
+// cluster of the server
+type AppCluster struct {
+	BaseModel
+	AppName string `gorm:"size:63;not null;unique_index;"`
+	Cluster string `gorm:"size:63;not null;index;"`
+}
+
+// Lease is held by the replica of the server running a background task
+// until it expires
+type Lease struct {
+	BaseModel
+	Name      string    `gorm:"size:63;not null;unique_index;"`
+	Holder    string    `gorm:"size:255;not null;"`
+	ExpiresAt time.Time `gorm:"not null;"`
 }
@@ -0,0 +1,190 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/pkg/errors"
+)
+
+// Approvals gives the protected apps of a team and the roles of its
+// members, the team admins approve the deploys of the protected apps
+type Approvals interface {
+	ProtectedApps(teamName string) ([]string, error)
+	HasRole(teamName string, user *database.User, role string) (bool, error)
+}
+
+// approvalCheckInterval is the time between checks of the approval of a
+// pending deploy
+var approvalCheckInterval = 2 * time.Second
+
+// PendingDeploys keeps the deploys of protected apps waiting for approval,
+// shared by all the replicas of the server. Get returns nil for a deploy
+// not pending (or expired) and Approve false if it was already approved
+type PendingDeploys interface {
+	Add(p *database.PendingDeploy) error
+	Get(deployId string) (*database.PendingDeploy, error)
+	Approve(deployId, approver string) (bool, error)
+	Remove(deployId string) error
+}
+
+// DatabasePendingDeploys keeps the pending deploys in the database
+type DatabasePendingDeploys struct {
+	DB *gorm.DB
+}
+
+func (d *DatabasePendingDeploys) Add(p *database.PendingDeploy) error {
+	if err := d.DB.Create(p).Error; err != nil {
+		return errors.Wrap(err, fmt.Sprintf("adding pending deploy %s of %s", p.DeployID, p.AppName))
+	}
+	return nil
+}
+
+func (d *DatabasePendingDeploys) Get(deployId string) (*database.PendingDeploy, error) {
+	p := new(database.PendingDeploy)
+	q := d.DB.Where("deploy_id = ? AND expires_at > ?", deployId, time.Now()).First(p)
+	if q.RecordNotFound() {
+		return nil, nil
+	}
+	if q.Error != nil {
+		return nil, errors.Wrap(q.Error, fmt.Sprintf("getting pending deploy %s", deployId))
+	}
+	return p, nil
+}
+
+func (d *DatabasePendingDeploys) Approve(deployId, approver string) (bool, error) {
+	q := d.DB.Model(&database.PendingDeploy{}).
+		Where("deploy_id = ? AND approved_by = ? AND expires_at > ?", deployId, "", time.Now()).
+		Update("approved_by", approver)
+	if q.Error != nil {
+		return false, errors.Wrap(q.Error, fmt.Sprintf("approving pending deploy %s", deployId))
+	}
+	return q.RowsAffected > 0, nil
+}
+
+func (d *DatabasePendingDeploys) Remove(deployId string) error {
+	q := d.DB.Where(&database.PendingDeploy{DeployID: deployId})
+	if err := q.Delete(database.PendingDeploy{}).Error; err != nil {
+		return errors.Wrap(err, fmt.Sprintf("removing pending deploy %s", deployId))
+	}
+	return nil
+}
+
+func NewDatabasePendingDeploys(db *gorm.DB) PendingDeploys {
+	return &DatabasePendingDeploys{DB: db}
+}
+
+func (ops *DeployOperations) SetPendingDeploys(p PendingDeploys) {
+	ops.pendings = p
+}
+
+func (ops *DeployOperations) SetApprovals(a Approvals) {
+	ops.approvals = a
+}
+
+func (ops *DeployOperations) isProtected(a *app.App) (bool, error) {
+	if ops.approvals == nil {
+		return false, nil
+	}
+	apps, err := ops.approvals.ProtectedApps(a.Team)
+	if err != nil {
+		return false, err
+	}
+	return hasString(apps, a.Name), nil
+}
+
+// waitApproval holds the deploy of a protected app until another user
+// approves it, on any replica of the server, or the approval timeout expires
+func (ops *DeployOperations) waitApproval(a *app.App, author, deployId string, w io.Writer) error {
+	protected, err := ops.isProtected(a)
+	if err != nil || !protected {
+		return err
+	}
+	if ops.pendings == nil {
+		return teresa_errors.NewInternalServerError(errors.New("pending deploys not set"))
+	}
+
+	p := &database.PendingDeploy{
+		DeployID:  deployId,
+		AppName:   a.Name,
+		TeamName:  a.Team,
+		Author:    author,
+		ExpiresAt: time.Now().Add(ops.opts.ApprovalTimeout),
+	}
+	if err := ops.pendings.Add(p); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	defer func() {
+		if err := ops.pendings.Remove(deployId); err != nil {
+			log.WithError(err).WithField("id", deployId).Errorf("removing pending deploy of app %s", a.Name)
+		}
+	}()
+
+	fmt.Fprintf(w, "The app %s is protected, waiting for the approval of the deploy %s\n", a.Name, deployId)
+	fmt.Fprintf(w, "An admin of the team %s must run: teresa deploy approve %s\n", a.Team, deployId)
+
+	ticker := time.NewTicker(approvalCheckInterval)
+	defer ticker.Stop()
+	timeout := time.After(ops.opts.ApprovalTimeout)
+	for {
+		select {
+		case <-ticker.C:
+		case <-timeout:
+			return ErrApprovalTimeout
+		}
+		p, err := ops.pendings.Get(deployId)
+		if err != nil {
+			log.WithError(err).WithField("id", deployId).Errorf("checking the approval of the deploy of app %s", a.Name)
+			continue
+		}
+		if p != nil && p.ApprovedBy != "" {
+			fmt.Fprintf(w, "Deploy approved by %s\n", p.ApprovedBy)
+			return nil
+		}
+	}
+}
+
+// Approve releases the pending deploy deployId. The approver must be an
+// admin or one of the admins of the app team, other than the author of the
+// deploy
+func (ops *DeployOperations) Approve(user *database.User, deployId string) error {
+	if ops.pendings == nil {
+		return ErrPendingDeployNotFound
+	}
+	p, err := ops.pendings.Get(deployId)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if p == nil || p.ApprovedBy != "" {
+		return ErrPendingDeployNotFound
+	}
+	if p.Author == user.Email {
+		return ErrSelfApproval
+	}
+	if !user.IsAdmin {
+		isTeamAdmin, err := ops.approvals.HasRole(p.TeamName, user, team.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		if !isTeamAdmin {
+			return auth.ErrPermissionDenied
+		}
+	}
+
+	approved, err := ops.pendings.Approve(deployId, user.Email)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !approved {
+		return ErrPendingDeployNotFound
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package deploy
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+type fakeApprovals struct {
+	apps      []string
+	approvers []string
+}
+
+func (f *fakeApprovals) ProtectedApps(teamName string) ([]string, error) {
+	return f.apps, nil
+}
+
+func (f *fakeApprovals) HasRole(teamName string, user *database.User, role string) (bool, error) {
+	return role == team.RoleAdmin && hasString(f.approvers, user.Email), nil
+}
+
+func newApprovalTestOps(t *testing.T, timeout time.Duration) (*gorm.DB, *DeployOperations) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	approvalCheckInterval = time.Millisecond
+
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	ops.opts.ApprovalTimeout = timeout
+	ops.SetApprovals(&fakeApprovals{
+		apps:      []string{"teresa"},
+		approvers: []string{"approver@luizalabs.com"},
+	})
+	ops.SetPendingDeploys(NewDatabasePendingDeploys(db))
+	return db, ops
+}
+
+// waitPending waits for the deploy to be held, the wait runs in background
+func waitPending(t *testing.T, ops *DeployOperations, deployId string) {
+	for {
+		p, err := ops.pendings.Get(deployId)
+		if err != nil {
+			t.Fatal("error getting pending deploy:", err)
+		}
+		if p != nil {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWaitApprovalNotProtected(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Minute)
+	defer db.Close()
+	a := &app.App{Name: "other", Team: "luizalabs"}
+
+	if err := ops.waitApproval(a, "gopher@luizalabs.com", "1", new(bytes.Buffer)); err != nil {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestWaitApprovalApproved(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Minute)
+	defer db.Close()
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- ops.waitApproval(a, "gopher@luizalabs.com", "1", new(bytes.Buffer))
+	}()
+	waitPending(t, ops, "1")
+
+	if err := ops.Approve(&database.User{Email: "approver@luizalabs.com"}, "1"); err != nil {
+		t.Fatal("got unexpected error approving:", err)
+	}
+	if err := <-errChan; err != nil {
+		t.Errorf("got unexpected error: %v", err)
+	}
+	if err := ops.Approve(&database.User{Email: "approver@luizalabs.com"}, "1"); err != ErrPendingDeployNotFound {
+		t.Errorf("expected ErrPendingDeployNotFound, got %v", err)
+	}
+}
+
+func TestWaitApprovalTimeout(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Millisecond)
+	defer db.Close()
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+
+	if err := ops.waitApproval(a, "gopher@luizalabs.com", "1", new(bytes.Buffer)); err != ErrApprovalTimeout {
+		t.Errorf("expected ErrApprovalTimeout, got %v", err)
+	}
+}
+
+func TestApproveErrors(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Minute)
+	defer db.Close()
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+	author := "approver@luizalabs.com"
+
+	go ops.waitApproval(a, author, "1", new(bytes.Buffer))
+	waitPending(t, ops, "1")
+
+	var testCases = []struct {
+		email    string
+		deployId string
+		expected error
+	}{
+		{author, "2", ErrPendingDeployNotFound},
+		{author, "1", ErrSelfApproval},
+		{"gopher@luizalabs.com", "1", auth.ErrPermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		if err := ops.Approve(&database.User{Email: tc.email}, tc.deployId); err != tc.expected {
+			t.Errorf("expected %v, got %v for %s approving %s", tc.expected, err, tc.email, tc.deployId)
+		}
+	}
+}
+
+func TestApproveAdmin(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Minute)
+	defer db.Close()
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+
+	go ops.waitApproval(a, "gopher@luizalabs.com", "1", new(bytes.Buffer))
+	waitPending(t, ops, "1")
+
+	if err := ops.Approve(&database.User{Email: "admin@luizalabs.com", IsAdmin: true}, "1"); err != nil {
+		t.Errorf("got unexpected error: %v", err)
+	}
+}
+
+func TestWaitApprovalRemovesPending(t *testing.T) {
+	db, ops := newApprovalTestOps(t, 10*time.Millisecond)
+	defer db.Close()
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+
+	if err := ops.waitApproval(a, "gopher@luizalabs.com", "1", new(bytes.Buffer)); err != ErrApprovalTimeout {
+		t.Fatalf("expected ErrApprovalTimeout, got %v", err)
+	}
+	var count int
+	if err := db.Model(&database.PendingDeploy{}).Count(&count).Error; err != nil {
+		t.Fatal("error counting pending deploys:", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no pending deploys, got %d", count)
+	}
+}
+
+func TestDatabasePendingDeploysApprove(t *testing.T) {
+	db, ops := newApprovalTestOps(t, time.Minute)
+	defer db.Close()
+	pendings := ops.pendings
+
+	p := &database.PendingDeploy{
+		DeployID:  "1",
+		AppName:   "teresa",
+		TeamName:  "luizalabs",
+		Author:    "gopher@luizalabs.com",
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+	if err := pendings.Add(p); err != nil {
+		t.Fatal("error adding pending deploy:", err)
+	}
+	expired := &database.PendingDeploy{DeployID: "2", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := pendings.Add(expired); err != nil {
+		t.Fatal("error adding pending deploy:", err)
+	}
+
+	var testCases = []struct {
+		deployId string
+		expected bool
+	}{
+		{"1", true},
+		{"1", false},
+		{"2", false},
+		{"3", false},
+	}
+	for _, tc := range testCases {
+		approved, err := pendings.Approve(tc.deployId, "approver@luizalabs.com")
+		if err != nil {
+			t.Fatal("error approving pending deploy:", err)
+		}
+		if approved != tc.expected {
+			t.Errorf("expected %v approving %s, got %v", tc.expected, tc.deployId, approved)
+		}
+	}
+
+	got, err := pendings.Get("1")
+	if err != nil {
+		t.Fatal("error getting pending deploy:", err)
+	}
+	if got == nil || got.ApprovedBy != "approver@luizalabs.com" {
+		t.Errorf("expected deploy approved by approver@luizalabs.com, got %+v", got)
+	}
+	if got, _ := pendings.Get("2"); got != nil {
+		t.Errorf("expected expired deploy not found, got %+v", got)
+	}
+}
@@ -0,0 +1,225 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const (
+	greenSuffix            = "-green"
+	blueGreenSwitchedAt    = "teresa.io/blue-green-switched-at"
+	blueGreenCheckInterval = time.Minute
+	// blueGreenLease keeps the other replicas of the server from promoting
+	// the same green deploy
+	blueGreenLease    = "blue-green-cleanup"
+	blueGreenLeaseTTL = 3 * blueGreenCheckInterval
+)
+
+func greenDeployName(appName string) string {
+	return appName + greenSuffix
+}
+
+// greenSwitchedAt returns when the service of the app was switched to the
+// green deploy, a zero time if it wasn't
+func (ops *DeployOperations) greenSwitchedAt(appName string) (time.Time, error) {
+	v, err := ops.k8s.DeployAnnotation(appName, greenDeployName(appName), blueGreenSwitchedAt)
+	if err != nil || v == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+// validateBlueGreen checks that the app is a web one without extra process
+// types and that its service isn't switched to the green deploy
+func (ops *DeployOperations) validateBlueGreen(a *app.App) error {
+	if !app.IsWebApp(a.ProcessType) || len(a.Processes) > 0 {
+		return ErrBlueGreenNotSupported
+	}
+	t, err := ops.greenSwitchedAt(a.Name)
+	if err != nil && !ops.k8s.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !t.IsZero() {
+		return ErrBlueGreenSwitched
+	}
+	return nil
+}
+
+// createOrUpdateGreen deploys the release aside the app pods with the same
+// replicas, the service keeps sending the traffic to the app pods until
+// it's switched
+func (ops *DeployOperations) createOrUpdateGreen(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, deployId string) error {
+	csp, err := ops.prepareDeploy(a, confFiles, w, slugURL, opts, deployId)
+	if err != nil {
+		return err
+	}
+
+	// the nginx config is shared with the app pods, it's only removed by a
+	// regular deploy
+	if confFiles.NginxConf != "" {
+		data := map[string]string{spec.NginxConfFile: confFiles.NginxConf}
+		if err := ops.k8s.CreateOrUpdateConfigMap(a.Name, a.Name, data); err != nil {
+			log.WithError(err).Errorf("Creating config to nginx of app %s", a.Name)
+			return err
+		}
+	}
+	deploySpec, _, err := ops.appDeploySpec(a, confFiles, slugURL, opts, csp)
+	if err != nil {
+		return err
+	}
+	replicas, err := ops.k8s.DeployReplicas(a.Name, a.Name)
+	if err != nil {
+		return err
+	}
+	renameDeploySpec(deploySpec, greenDeployName(a.Name))
+	deploySpec.Replicas = &replicas
+
+	if err := ops.k8s.CreateOrUpdateDeploy(deploySpec); err != nil {
+		log.WithError(err).Errorf("Creating green deploy of app %s", a.Name)
+		return err
+	}
+	fmt.Fprintf(w, "The green deploy of app %s has been successfully created with %d replica(s)\n", a.Name, replicas)
+	fmt.Fprintf(w, "Switch the traffic to it with: teresa deploy switch %s\n", a.Name)
+	return nil
+}
+
+// greenReady tells if all the replicas of the green deploy are ready to
+// take the traffic of the app
+func (ops *DeployOperations) greenReady(appName string) (bool, error) {
+	name := greenDeployName(appName)
+	replicas, err := ops.k8s.DeployReplicas(appName, name)
+	if err != nil {
+		return false, err
+	}
+	pods, err := ops.k8s.PodListByLabel(appName, runLabel, name)
+	if err != nil {
+		return false, err
+	}
+	return replicas > 0 && readyPods(pods) >= replicas, nil
+}
+
+// Switch sends the traffic of the app service to the green deploy, once
+// its pods are ready, or back to the app pods if it was already switched
+func (ops *DeployOperations) Switch(user *database.User, appName string, force bool) error {
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
+	if err != nil {
+		return err
+	}
+	id, err := ops.lockRelease(user, a, force)
+	if err != nil {
+		return err
+	}
+	defer ops.unlockDeploy(appName, id)
+	t, err := ops.greenSwitchedAt(appName)
+	if err != nil {
+		if ops.k8s.IsNotFound(err) {
+			return ErrBlueGreenNotFound
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	target, switchedAt := greenDeployName(appName), time.Now().UTC().Format(time.RFC3339)
+	if !t.IsZero() {
+		target, switchedAt = appName, ""
+	} else {
+		ready, err := ops.greenReady(appName)
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if !ready {
+			return ErrBlueGreenNotReady
+		}
+	}
+	if err := ops.k8s.ServiceSetSelector(appName, appName, target); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	err = ops.k8s.DeploySetAnnotation(appName, greenDeployName(appName), blueGreenSwitchedAt, switchedAt)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// deleteGreen sends the traffic back to the app pods, if needed, and
+// deletes the green deploy
+func (ops *DeployOperations) deleteGreen(appName string) error {
+	t, err := ops.greenSwitchedAt(appName)
+	if err != nil {
+		if ops.k8s.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !t.IsZero() {
+		if err := ops.k8s.ServiceSetSelector(appName, appName, appName); err != nil {
+			return err
+		}
+	}
+	return ops.k8s.DeleteDeploy(appName, greenDeployName(appName))
+}
+
+// finishBlueGreen rolls out the green release in the app deploy once the
+// grace period after the switch is over, then the service goes back to the
+// app pods and the green deploy is deleted
+func (ops *DeployOperations) finishBlueGreen(appName string, now time.Time) error {
+	t, err := ops.greenSwitchedAt(appName)
+	if err != nil {
+		if ops.k8s.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if t.IsZero() || now.Sub(t) < ops.opts.BlueGreenGracePeriod {
+		return nil
+	}
+
+	if err := ops.k8s.DeployPromote(appName, appName, greenDeployName(appName)); err != nil {
+		return err
+	}
+	if err := ops.k8s.WatchDeploy(appName, appName); err != nil {
+		return err
+	}
+	return ops.deleteGreen(appName)
+}
+
+func (ops *DeployOperations) finishBlueGreens(now time.Time) {
+	names, err := ops.k8s.NamespaceListByLabel(app.TeresaTeamLabel, "")
+	if err != nil {
+		log.WithError(err).Error("listing apps to clean up blue/green deploys")
+		return
+	}
+	for _, name := range names {
+		if err := ops.finishBlueGreen(name, now); err != nil {
+			log.WithError(err).Errorf("cleaning up blue/green deploy of app %s", name)
+		}
+	}
+}
+
+// RunBlueGreenCleanup finishes the switched blue/green deploys past the
+// grace period every minute, until stop is closed. Only the replica of the
+// server holding the lease of the cleanup finishes them.
+func (ops *DeployOperations) RunBlueGreenCleanup(l lease.Leases, stop <-chan struct{}) {
+	ticker := time.NewTicker(blueGreenCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case t := <-ticker.C:
+			if lease.Held(l, blueGreenLease, blueGreenLeaseTTL) {
+				ops.finishBlueGreens(t)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
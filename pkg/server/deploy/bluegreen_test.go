@@ -0,0 +1,157 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestValidateBlueGreen(t *testing.T) {
+	var testCases = []struct {
+		app        *app.App
+		switchedAt string
+		expected   error
+	}{
+		{&app.App{Name: "teresa", ProcessType: "web"}, "", nil},
+		{&app.App{Name: "teresa", ProcessType: "worker"}, "", ErrBlueGreenNotSupported},
+		{&app.App{Name: "teresa", ProcessType: "web", Processes: map[string]int32{"worker": 1}}, "", ErrBlueGreenNotSupported},
+		{&app.App{Name: "teresa", ProcessType: "web"}, "2018-01-01T10:00:00Z", ErrBlueGreenSwitched},
+	}
+
+	for _, tc := range testCases {
+		ops := newTestDeployOps(&fakeK8sOperations{greenSwitchedAt: tc.switchedAt})
+		if got := ops.validateBlueGreen(tc.app); got != tc.expected {
+			t.Errorf("expected %v, got %v", tc.expected, got)
+		}
+	}
+}
+
+func TestCreateOrUpdateGreen(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{deployReplicas: 3}
+	ops := newTestDeployOps(fakeK8s)
+	a := &app.App{Name: "teresa", ProcessType: "web"}
+	conf := &DeployConfigFiles{Procfile: map[string]string{"web": "python app.py"}}
+
+	if err := ops.createOrUpdateGreen(a, conf, new(bytes.Buffer), "slug", &DeployOptions{BlueGreen: true}, "123"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	ds := fakeK8s.lastDeploySpec
+	if ds.Name != "teresa-green" {
+		t.Errorf("expected teresa-green, got %s", ds.Name)
+	}
+	if got := ds.MatchLabels[runLabel]; got != "teresa-green" {
+		t.Errorf("expected teresa-green, got %s", got)
+	}
+	if ds.Replicas == nil || *ds.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %v", ds.Replicas)
+	}
+	if fakeK8s.serviceSelector != "" {
+		t.Errorf("expected the service untouched, got %s", fakeK8s.serviceSelector)
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{deployReplicas: 1, pods: []*app.Pod{{Name: "teresa-green-1", Ready: true}}}
+	ops := newTestDeployOps(fakeK8s)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Switch(u, "teresa", false); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fakeK8s.serviceSelector != "teresa-green" || fakeK8s.greenSwitchedAt == "" {
+		t.Errorf("expected the switch to teresa-green, got %s at %s", fakeK8s.serviceSelector, fakeK8s.greenSwitchedAt)
+	}
+
+	if err := ops.Switch(u, "teresa", false); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fakeK8s.serviceSelector != "teresa" || fakeK8s.greenSwitchedAt != "" {
+		t.Errorf("expected the switch back to teresa, got %s at %s", fakeK8s.serviceSelector, fakeK8s.greenSwitchedAt)
+	}
+}
+
+func TestSwitchErrBlueGreenNotReady(t *testing.T) {
+	var testCases = []struct {
+		replicas int32
+		pods     []*app.Pod
+	}{
+		{0, nil},
+		{2, []*app.Pod{{Name: "teresa-green-1", Ready: true}, {Name: "teresa-green-2"}}},
+	}
+
+	for _, tc := range testCases {
+		fakeK8s := &fakeK8sOperations{deployReplicas: tc.replicas, pods: tc.pods}
+		ops := newTestDeployOps(fakeK8s)
+		u := &database.User{Email: "gopher@luizalabs.com"}
+
+		if err := ops.Switch(u, "teresa", false); err != ErrBlueGreenNotReady {
+			t.Errorf("expected ErrBlueGreenNotReady, got %v", err)
+		}
+		if fakeK8s.serviceSelector != "" {
+			t.Errorf("expected the service untouched, got %s", fakeK8s.serviceSelector)
+		}
+	}
+}
+
+func TestSwitchErrBlueGreenNotFound(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{greenErr: errors.New("not found")})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Switch(u, "teresa", false); err != ErrBlueGreenNotFound {
+		t.Errorf("expected ErrBlueGreenNotFound, got %v", err)
+	}
+}
+
+func TestFinishBlueGreen(t *testing.T) {
+	switchedAt := time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC)
+	var testCases = []struct {
+		now      time.Time
+		finished bool
+	}{
+		{switchedAt.Add(5 * time.Minute), false},
+		{switchedAt.Add(10 * time.Minute), true},
+	}
+
+	for _, tc := range testCases {
+		fakeK8s := &fakeK8sOperations{greenSwitchedAt: switchedAt.Format(time.RFC3339)}
+		ops := newTestDeployOps(fakeK8s)
+		ops.opts.BlueGreenGracePeriod = 10 * time.Minute
+
+		if err := ops.finishBlueGreen("teresa", tc.now); err != nil {
+			t.Fatal("got unexpected error:", err)
+		}
+		if !tc.finished {
+			if fakeK8s.promotedDeploy != "" || len(fakeK8s.deletedDeploys) != 0 {
+				t.Errorf("expected nothing done at %v", tc.now)
+			}
+			continue
+		}
+		if fakeK8s.promotedDeploy != "teresa-green" {
+			t.Errorf("expected teresa-green promoted, got %s", fakeK8s.promotedDeploy)
+		}
+		if fakeK8s.serviceSelector != "teresa" {
+			t.Errorf("expected the switch back to teresa, got %s", fakeK8s.serviceSelector)
+		}
+		if expected := []string{"teresa-green"}; !reflect.DeepEqual(fakeK8s.deletedDeploys, expected) {
+			t.Errorf("expected %v, got %v", expected, fakeK8s.deletedDeploys)
+		}
+	}
+}
+
+func TestFinishBlueGreenNotSwitched(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+
+	if err := ops.finishBlueGreen("teresa", time.Now()); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fakeK8s.promotedDeploy != "" || len(fakeK8s.deletedDeploys) != 0 {
+		t.Error("expected the green deploy untouched")
+	}
+}
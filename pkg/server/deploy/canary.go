@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const canarySuffix = "-canary"
+
+func canaryDeployName(appName string) string {
+	return appName + canarySuffix
+}
+
+// renameDeploySpec names the deploy and labels its pods after name, the
+// containers keep the names of the app ones
+func renameDeploySpec(ds *spec.Deploy, name string) {
+	labels := map[string]string{runLabel: name}
+	ds.Name = name
+	ds.Labels = labels
+	ds.MatchLabels = labels
+}
+
+// validateCanary checks the weight of the canary, only web apps exposed by
+// ingress and without extra process types can have one
+func (ops *DeployOperations) validateCanary(a *app.App, weight int32) error {
+	if weight < 1 || weight > 99 {
+		return ErrInvalidCanaryWeight
+	}
+	if !app.IsWebApp(a.ProcessType) || len(a.Processes) > 0 {
+		return ErrCanaryNeedsIngress
+	}
+	hasIngress, err := ops.k8s.HasIngress(a.Name, a.Name)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if !hasIngress {
+		return ErrCanaryNeedsIngress
+	}
+	return nil
+}
+
+// createOrUpdateCanary deploys the release alongside the app pods, taking
+// the canary weight percent of the traffic of the app ingress. The
+// containers keep the names of the app ones to be promoted as they are
+func (ops *DeployOperations) createOrUpdateCanary(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, deployId string) error {
+	csp, err := ops.prepareDeploy(a, confFiles, w, slugURL, opts, deployId)
+	if err != nil {
+		return err
+	}
+
+	// the nginx config is shared with the app pods, it's only removed by a
+	// regular deploy
+	if confFiles.NginxConf != "" {
+		data := map[string]string{spec.NginxConfFile: confFiles.NginxConf}
+		if err := ops.k8s.CreateOrUpdateConfigMap(a.Name, a.Name, data); err != nil {
+			log.WithError(err).Errorf("Creating config to nginx of app %s", a.Name)
+			return err
+		}
+	}
+	deploySpec, _, err := ops.appDeploySpec(a, confFiles, slugURL, opts, csp)
+	if err != nil {
+		return err
+	}
+	name := canaryDeployName(a.Name)
+	renameDeploySpec(deploySpec, name)
+
+	if err := ops.k8s.CreateOrUpdateDeploy(deploySpec); err != nil {
+		log.WithError(err).Errorf("Creating canary deploy of app %s", a.Name)
+		return err
+	}
+	if err := ops.k8s.ExposeCanary(a.Name, a.Name, name, opts.CanaryWeight); err != nil {
+		log.WithError(err).Errorf("Exposing canary of app %s", a.Name)
+		return err
+	}
+	fmt.Fprintf(w, "The canary of app %s has been successfully deployed with %d%% of the traffic\n", a.Name, opts.CanaryWeight)
+	return nil
+}
+
+// Promote rolls out the canary release in the app deploy, the canary is
+// removed afterwards
+func (ops *DeployOperations) Promote(user *database.User, appName string, force bool) error {
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
+	if err != nil {
+		return err
+	}
+	id, err := ops.lockRelease(user, a, force)
+	if err != nil {
+		return err
+	}
+	defer ops.unlockDeploy(appName, id)
+	name := canaryDeployName(appName)
+	if err := ops.k8s.DeployPromote(appName, appName, name); err != nil {
+		if ops.k8s.IsNotFound(err) {
+			return ErrCanaryNotFound
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := ops.k8s.DeleteCanary(appName, name); err != nil && !ops.k8s.IsNotFound(err) {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := ops.appOps.SaveApp(a, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// Abort removes the canary, its traffic goes back to the app pods
+func (ops *DeployOperations) Abort(user *database.User, appName string) error {
+	if _, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer); err != nil {
+		return err
+	}
+	if err := ops.k8s.DeleteCanary(appName, canaryDeployName(appName)); err != nil {
+		if ops.k8s.IsNotFound(err) {
+			return ErrCanaryNotFound
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
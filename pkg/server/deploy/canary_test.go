@@ -0,0 +1,162 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	context "golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/test"
+)
+
+func newTestDeployOps(fakeK8s *fakeK8sOperations) *DeployOperations {
+	return NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	).(*DeployOperations)
+}
+
+func TestValidateCanary(t *testing.T) {
+	var testCases = []struct {
+		app        *app.App
+		weight     int32
+		hasIngress bool
+		expected   error
+	}{
+		{&app.App{Name: "teresa", ProcessType: "web"}, 10, true, nil},
+		{&app.App{Name: "teresa", ProcessType: "web"}, 99, true, nil},
+		{&app.App{Name: "teresa", ProcessType: "web"}, -1, true, ErrInvalidCanaryWeight},
+		{&app.App{Name: "teresa", ProcessType: "web"}, 100, true, ErrInvalidCanaryWeight},
+		{&app.App{Name: "teresa", ProcessType: "web"}, 10, false, ErrCanaryNeedsIngress},
+		{&app.App{Name: "teresa", ProcessType: "worker"}, 10, true, ErrCanaryNeedsIngress},
+		{&app.App{Name: "teresa", ProcessType: "web", Processes: map[string]int32{"worker": 1}}, 10, true, ErrCanaryNeedsIngress},
+	}
+
+	for _, tc := range testCases {
+		ops := newTestDeployOps(&fakeK8sOperations{hasIngress: tc.hasIngress})
+		if got := ops.validateCanary(tc.app, tc.weight); got != tc.expected {
+			t.Errorf("expected %v, got %v for weight %d", tc.expected, got, tc.weight)
+		}
+	}
+}
+
+func TestDeployCanaryErrInvalidWeight(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{hasIngress: true})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	_, errChan := ops.Deploy(context.Background(), u, "teresa", &test.FakeReadSeeker{}, &DeployOptions{CanaryWeight: 101})
+	if err := <-errChan; err != ErrInvalidCanaryWeight {
+		t.Errorf("expected ErrInvalidCanaryWeight, got %v", err)
+	}
+}
+
+func TestCreateOrUpdateCanary(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	a := &app.App{Name: "teresa", ProcessType: "web"}
+	conf := &DeployConfigFiles{Procfile: map[string]string{"web": "python app.py"}}
+
+	if err := ops.createOrUpdateCanary(a, conf, new(bytes.Buffer), "slug", &DeployOptions{CanaryWeight: 10}, "123"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	ds := fakeK8s.lastDeploySpec
+	if ds.Name != "teresa-canary" {
+		t.Errorf("expected teresa-canary, got %s", ds.Name)
+	}
+	expectedLabels := map[string]string{runLabel: "teresa-canary"}
+	if !reflect.DeepEqual(map[string]string(ds.Labels), expectedLabels) {
+		t.Errorf("expected %v, got %v", expectedLabels, ds.Labels)
+	}
+	if !reflect.DeepEqual(map[string]string(ds.MatchLabels), expectedLabels) {
+		t.Errorf("expected %v, got %v", expectedLabels, ds.MatchLabels)
+	}
+	if got := ds.Containers[0].Name; got != "teresa" {
+		t.Errorf("expected the app container name, got %s", got)
+	}
+	if fakeK8s.canaryWeight != 10 {
+		t.Errorf("expected 10, got %d", fakeK8s.canaryWeight)
+	}
+	if fakeK8s.deleteConfigMapWasCalled || fakeK8s.exposeDeployWasCalled {
+		t.Error("expected the app config and service untouched")
+	}
+}
+
+func TestCreateDeployDeletesCanary(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	a := &app.App{Name: "teresa", ProcessType: "web"}
+	conf := &DeployConfigFiles{Procfile: map[string]string{"web": "python app.py"}}
+
+	if err := ops.createOrUpdateDeploy(a, conf, new(bytes.Buffer), "slug", &DeployOptions{Description: "test"}, "123"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := []string{"teresa-canary"}; !reflect.DeepEqual(fakeK8s.deletedCanaries, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.deletedCanaries)
+	}
+}
+
+func TestPromote(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Promote(u, "teresa", false); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := []string{"teresa-canary"}; !reflect.DeepEqual(fakeK8s.deletedCanaries, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.deletedCanaries)
+	}
+}
+
+func TestPromoteErrCanaryNotFound(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{promoteCanaryErr: errors.New("not found")})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Promote(u, "teresa", false); err != ErrCanaryNotFound {
+		t.Errorf("expected ErrCanaryNotFound, got %v", err)
+	}
+}
+
+func TestPromoteErrPermissionDenied(t *testing.T) {
+	ops := newTestDeployOps(new(fakeK8sOperations))
+	u := &database.User{Email: "bad-user@luizalabs.com"}
+
+	if err := ops.Promote(u, "teresa", false); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Abort(u, "teresa"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := []string{"teresa-canary"}; !reflect.DeepEqual(fakeK8s.deletedCanaries, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.deletedCanaries)
+	}
+}
+
+func TestAbortErrCanaryNotFound(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{deleteCanaryErr: errors.New("not found")})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Abort(u, "teresa"); err != ErrCanaryNotFound {
+		t.Errorf("expected ErrCanaryNotFound, got %v", err)
+	}
+}
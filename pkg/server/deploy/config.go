@@ -8,22 +8,25 @@ import (
 	"io/ioutil"
 	"strings"
 
+	"github.com/luizalabs/teresa/pkg/server/app"
 	"github.com/luizalabs/teresa/pkg/server/spec"
 	yaml "gopkg.in/yaml.v2"
 )
 
 const (
-	ProcfileFileName       = "Procfile"
-	maxDrainTimeoutSeconds = 30
-	nginxConfFileName      = "nginx.conf"
+	ProcfileFileName  = "Procfile"
+	nginxConfFileName = "nginx.conf"
 )
 
 type Procfile map[string]string
 
+// DeployConfigFiles are the files of the tarball configuring the deploy,
+// Dockerfile tells if there's one to build the app image from
 type DeployConfigFiles struct {
 	TeresaYaml *spec.TeresaYaml
 	Procfile   Procfile
 	NginxConf  string
+	Dockerfile bool
 }
 
 func (d *DeployConfigFiles) fillTeresaYaml(r io.Reader, appName string) error {
@@ -84,7 +87,9 @@ func getDeployConfigFilesFromTarBall(tarBall io.ReadSeeker, appName, processType
 		if _, found := names[hdr.Name]; !found {
 			continue
 		}
-		if hdr.Name == nginxConfFileName {
+		if hdr.Name == spec.DockerfileName {
+			deployFiles.Dockerfile = true
+		} else if hdr.Name == nginxConfFileName {
 			deployFiles.NginxConf, err = readFileFromTarBall(tarReader)
 			if err != nil {
 				return nil, err
@@ -106,9 +111,28 @@ func getDeployConfigFilesFromTarBall(tarBall io.ReadSeeker, appName, processType
 }
 
 func validateTeresaYaml(tYaml *spec.TeresaYaml) error {
-	if tYaml.Lifecycle != nil && tYaml.Lifecycle.PreStop != nil {
-		if tYaml.Lifecycle.PreStop.DrainTimeoutSeconds > maxDrainTimeoutSeconds || tYaml.Lifecycle.PreStop.DrainTimeoutSeconds < 0 {
-			return fmt.Errorf("Invalid drainTimeoutSeconds: %d", tYaml.Lifecycle.PreStop.DrainTimeoutSeconds)
+	if app.ValidateRolloutTimeout(tYaml.RolloutTimeoutSeconds) != nil {
+		return fmt.Errorf("Invalid rolloutTimeoutSeconds: %d", tYaml.RolloutTimeoutSeconds)
+	}
+	if ru := tYaml.RollingUpdate; ru != nil {
+		if app.ValidateRollingUpdate(appRollingUpdate(ru)) != nil {
+			return fmt.Errorf("Invalid rollingUpdate: %s/%s", ru.MaxSurge, ru.MaxUnavailable)
+		}
+	}
+	lc := tYaml.Lifecycle
+	if lc == nil {
+		return nil
+	}
+	grace := lc.TerminationGracePeriodSeconds
+	if grace > app.MaxTerminationGracePeriodSeconds || grace < 0 {
+		return fmt.Errorf("Invalid terminationGracePeriodSeconds: %d", grace)
+	}
+	if grace == 0 {
+		grace = app.DefaultTerminationGracePeriodSeconds
+	}
+	if lc.PreStop != nil {
+		if lc.PreStop.DrainTimeoutSeconds > grace || lc.PreStop.DrainTimeoutSeconds < 0 {
+			return fmt.Errorf("Invalid drainTimeoutSeconds: %d", lc.PreStop.DrainTimeoutSeconds)
 		}
 	}
 	return nil
@@ -116,8 +140,9 @@ func validateTeresaYaml(tYaml *spec.TeresaYaml) error {
 
 func newConfigFileNames(processType string) map[string]bool {
 	m := map[string]bool{
-		ProcfileFileName:  true,
-		nginxConfFileName: true,
+		ProcfileFileName:    true,
+		nginxConfFileName:   true,
+		spec.DockerfileName: true,
 	}
 	for _, ext := range []string{".yaml", ".yml"} {
 		for _, item := range []string{"", processType} {
@@ -220,3 +220,19 @@ func TestGetTeresaYAMLAlternativeExtension(t *testing.T) {
 		t.Errorf("got %s; want %s", got, want)
 	}
 }
+
+func TestGetDockerfileFromDeployTarBall(t *testing.T) {
+	tarBall, err := os.Open(filepath.Join("testdata", "dockerfile.tgz"))
+	if err != nil {
+		t.Fatal("error getting tarBall:", err)
+	}
+	defer tarBall.Close()
+
+	deployConfig, err := getDeployConfigFilesFromTarBall(tarBall, "test", "test")
+	if err != nil {
+		t.Fatal("error getting deploy config file from tarball:", err)
+	}
+	if !deployConfig.Dockerfile {
+		t.Error("expected the Dockerfile to be found")
+	}
+}
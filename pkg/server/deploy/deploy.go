@@ -3,6 +3,10 @@ package deploy
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
@@ -14,8 +18,10 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/build"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/lease"
 	"github.com/luizalabs/teresa/pkg/server/spec"
 	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"github.com/luizalabs/teresa/pkg/server/uid"
 	"github.com/luizalabs/teresa/pkg/server/validation"
@@ -28,22 +34,72 @@ const (
 )
 
 type Operations interface {
-	Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, description string) (io.ReadCloser, <-chan error)
+	Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, opts *DeployOptions) (io.ReadCloser, <-chan error)
 	List(user *database.User, appName string) ([]*ReplicaSetListItem, error)
-	Rollback(user *database.User, appName, revision string) error
+	Rollback(user *database.User, appName, revision string, force bool) error
+	Promote(user *database.User, appName string, force bool) error
+	Abort(user *database.User, appName string) error
+	Switch(user *database.User, appName string, force bool) error
+	RunBlueGreenCleanup(l lease.Leases, stop <-chan struct{})
+	DeployImage(user *database.User, appName, image string, teresaYaml []byte, opts *DeployOptions) (io.ReadCloser, <-chan error)
+	PromoteBuild(user *database.User, srcApp, buildName, appName string, opts *DeployOptions) (io.ReadCloser, <-chan error)
+	SetApprovals(a Approvals)
+	SetPendingDeploys(p PendingDeploys)
+	SetFreezes(f Freezes)
+	SetAppLocks(l AppLocks)
+	SetTeamEnv(te TeamEnv)
+	SetDeployLocks(l DeployLocks)
+	Approve(user *database.User, deployId string) error
+}
+
+// DeployOptions are the options of a new deploy, a CanaryWeight greater
+// than zero deploys it as a canary taking that percent of the traffic.
+// BlueGreen deploys it aside, without traffic until it's switched. Author
+// is set by Deploy to the email of the user, Commit, Branch and Message are
+// the optional git metadata of the deployed code. Image is set by
+// DeployImage to run the app from it instead of a slug. Force lets admins
+// deploy locked or frozen apps. DryRun returns the diff of the deploy
+// instead of running it
+type DeployOptions struct {
+	Description  string
+	CanaryWeight int32
+	BlueGreen    bool
+	Author       string
+	Commit       string
+	Branch       string
+	Message      string
+	Image        string
+	Force        bool
+	DryRun       bool
 }
 
 type K8sOperations interface {
 	CreateOrUpdateDeploy(deploySpec *spec.Deploy) error
 	CreateOrUpdateCronJob(cronJobSpec *spec.CronJob) error
 	ExposeDeploy(namespace, name, svcType, portName string, vHosts []string, w io.Writer) error
+	IngressSetOptions(namespace, name string, opts map[string]string) error
+	Service(namespace, svcName string) (*spec.Service, error)
+	UpdateServicePorts(namespace, svcName string, ports []spec.ServicePort) error
+	IngressSetStreamPorts(namespace, svcName string, ports []spec.ServicePort) error
 	ReplicaSetListByLabel(namespace, label, value string) ([]*ReplicaSetListItem, error)
 	DeployRollbackToRevision(namespace, name, revision string) error
 	CreateOrUpdateConfigMap(namespace, name string, data map[string]string) error
 	DeleteConfigMap(namespace, name string) error
 	IsNotFound(err error) bool
-	ContainerExplicitEnvVars(namespace, deployName, containerName string) ([]*app.EnvVar, error)
+	IsRolloutStalled(err error) bool
 	WatchDeploy(namespace, deployName string) error
+	HasIngress(namespace, name string) (bool, error)
+	ExposeCanary(namespace, name, canaryName string, weight int32) error
+	DeployPromote(namespace, name, srcName string) error
+	DeleteCanary(namespace, canaryName string) error
+	NamespaceListByLabel(label, value string) ([]string, error)
+	DeployAnnotation(namespace, deployName, annotation string) (string, error)
+	DeploySetAnnotation(namespace, name, annotation, value string) error
+	DeployReplicas(namespace, name string) (int32, error)
+	DeleteDeploy(namespace, name string) error
+	ServiceSetSelector(namespace, svcName, deployName string) error
+	PodListByLabel(namespace, label, value string) ([]*app.Pod, error)
+	DeployDiff(deploySpec *spec.Deploy, svcSpec *spec.Service, vHosts []string) (string, error)
 }
 
 type DeployOperations struct {
@@ -53,22 +109,54 @@ type DeployOperations struct {
 	fileStorage storage.Storage
 	k8s         K8sOperations
 	opts        *Options
+	approvals   Approvals
+	freezes     Freezes
+	appLocks    AppLocks
+	teamEnv     TeamEnv
+	locks       DeployLocks
+	pendings    PendingDeploys
 }
 
-func (ops *DeployOperations) Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, description string) (io.ReadCloser, <-chan error) {
+func (ops *DeployOperations) Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, opts *DeployOptions) (io.ReadCloser, <-chan error) {
 	errChan := make(chan error, 1)
-	a, err := ops.appOps.CheckPermAndGet(user, appName)
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
 	if err != nil {
 		errChan <- err
 		return nil, errChan
 	}
 
+	opts.Author = user.Email
+	if err := validateMetadata(opts); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	if err := ops.validateStrategy(a, opts); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
 	teamName, err := ops.appOps.TeamName(appName)
 	if err != nil {
 		errChan <- err
 		return nil, errChan
 	}
 	a.Team = teamName
+	if err := ops.setTeamEnvVars(a); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	if !opts.DryRun {
+		if err := ops.checkFreeze(user, a, opts.Force); err != nil {
+			errChan <- err
+			return nil, errChan
+		}
+		if err := ops.appOps.CheckTeamQuota(a); err != nil {
+			errChan <- err
+			return nil, errChan
+		}
+	}
 
 	confFiles, err := getDeployConfigFilesFromTarBall(tarBall, a.Name, a.ProcessType)
 	if err != nil {
@@ -76,46 +164,236 @@ func (ops *DeployOperations) Deploy(ctx context.Context, user *database.User, ap
 		return nil, errChan
 	}
 
+	if err := ops.validateConfFiles(confFiles); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	imageBuild, err := ops.buildsImage(a, confFiles)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	if opts.DryRun {
+		diff, err := ops.dryRun(user, a, confFiles, imageBuild, opts)
+		if err != nil {
+			errChan <- err
+			return nil, errChan
+		}
+		return ioutil.NopCloser(strings.NewReader(diff)), errChan
+	}
+
 	deployId := uid.New()
+	if err := ops.lockDeploy(appName, deployId, opts.Author); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
 	buildIn := fmt.Sprintf("deploys/%s/%s/in/app.tgz", a.Name, deployId)
 	buildDest := fmt.Sprintf("deploys/%s/%s/out", appName, deployId)
+	var image, cacheImage string
+	if imageBuild {
+		image = buildImageName(ops.opts.BuildRegistry, appName, deployId)
+	}
+	if a.Builder == app.BuilderCNB {
+		cacheImage = build.CNBCacheImage(ops.opts.BuildRegistry, appName)
+	}
 
 	r, w := io.Pipe()
 	go func() {
 		defer w.Close()
+		defer ops.unlockDeploy(appName, deployId)
+		writeEvent(w, &event{Type: EventBuildStarted, Text: fmt.Sprintf("Building app %s", appName)})
 		err = ops.buildOps.CreateByOpts(ctx, &build.CreateOptions{
-			App:       a,
-			BuildName: deployId,
-			SlugIn:    buildIn,
-			SlugDest:  buildDest,
-			TarBall:   tarBall,
-			Stream:    w,
+			App:        a,
+			BuildName:  deployId,
+			SlugIn:     buildIn,
+			SlugDest:   buildDest,
+			Image:      image,
+			CacheImage: cacheImage,
+			TarBall:    tarBall,
+			Stream:     w,
 		})
 		if err != nil {
 			errChan <- err
 			log.WithError(err).WithField("id", deployId).Errorf("Building app %s", appName)
 			return
 		}
+		writeEvent(w, &event{Type: EventBuildFinished, Text: "Build finished"})
 		slugURL := fmt.Sprintf("%s/slug.tgz", buildDest)
-		if app.IsCronJob(a.ProcessType) {
-			err = ops.createOrUpdateCronJob(a, confFiles, w, slugURL, description)
-		} else {
-			err = ops.createOrUpdateDeploy(a, confFiles, w, slugURL, description, deployId)
+		if imageBuild {
+			slugURL = ""
+			opts.Image = image
 		}
-		if err != nil {
+		ops.release(user, a, confFiles, w, slugURL, opts, deployId, errChan)
+	}()
+	return r, errChan
+}
+
+// validateStrategy checks the canary or blue/green deploy of opts, if any
+func (ops *DeployOperations) validateStrategy(a *app.App, opts *DeployOptions) error {
+	canary := opts.CanaryWeight != 0
+	if canary && opts.BlueGreen {
+		return ErrInvalidDeployStrategy
+	}
+	if canary {
+		return ops.validateCanary(a, opts.CanaryWeight)
+	}
+	if opts.BlueGreen {
+		return ops.validateBlueGreen(a)
+	}
+	return nil
+}
+
+// release rolls out the built slug (or image) of a deploy as a canary, a
+// green deploy or the new pods of the app. Nothing of teresa.yaml is applied
+// before the approval of the deploy, if the app is protected
+func (ops *DeployOperations) release(user *database.User, a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, deployId string, errChan chan<- error) {
+	appName := a.Name
+	if err := ops.waitApproval(a, opts.Author, deployId, w); err != nil {
+		errChan <- err
+		return
+	}
+	// a canary or a green deploy leaves the settings shared with the app
+	// pods untouched
+	aside := opts.CanaryWeight != 0 || opts.BlueGreen
+	a, err := ops.applyTeresaYaml(user, a, confFiles, aside)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if opts.CanaryWeight != 0 {
+		if err := ops.createOrUpdateCanary(a, confFiles, w, slugURL, opts, deployId); err != nil {
 			errChan <- err
 			return
 		}
+		// the app is saved on promote
+		ops.watchDeploy(appName, canaryDeployName(appName), w, errChan)
+		return
+	}
+	if opts.BlueGreen {
+		if err := ops.createOrUpdateGreen(a, confFiles, w, slugURL, opts, deployId); err != nil {
+			errChan <- err
+			return
+		}
+		ops.watchDeploy(appName, greenDeployName(appName), w, errChan)
+		return
+	}
+
+	if app.IsCronJob(a.ProcessType) {
+		err = ops.createOrUpdateCronJob(a, confFiles, w, slugURL, opts.Description)
+	} else {
+		err = ops.createOrUpdateDeploy(a, confFiles, w, slugURL, opts, deployId)
+	}
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if err := ops.appOps.SaveApp(a, user.Email); err != nil {
+		log.WithError(err).WithField("id", deployId).Errorf("Saving last deploy user (%s) of app %s", user.Name, appName)
+	}
+
+	if !app.IsCronJob(a.ProcessType) {
+		rolloutErr := make(chan error, 1)
+		ops.watchRollout(a, w, rolloutErr)
+		ops.runPostDeployHooks(a, confFiles, slugURL, opts, deployId, w, rolloutErr, errChan)
+	}
+}
+
+// validateConfFiles checks the settings of teresa.yaml, if any
+func (ops *DeployOperations) validateConfFiles(confFiles *DeployConfigFiles) error {
+	ty := confFiles.TeresaYaml
+	if ty == nil {
+		return nil
+	}
+	if !validatePorts(ty.Ports) {
+		return ErrInvalidPorts
+	}
+	if !validateHostAliases(ty.HostAliases) {
+		return ErrInvalidHostAliases
+	}
+	if !validateHooks(ty.Hooks) {
+		return ErrInvalidHooks
+	}
+	if err := validateScheduling(ty, ops.opts.AllowedNodeLabels, ops.opts.AllowedTolerations); err != nil {
+		return err
+	}
+	if _, err := spec.NewSideCars(ty); err != nil {
+		return teresa_errors.New(ErrInvalidSideCar, err)
+	}
+	if err := spec.ValidateAppInitContainers(ty.InitContainers); err != nil {
+		return teresa_errors.New(ErrInvalidInitContainer, err)
+	}
+	if err := spec.ValidateHealthCheck(ty.HealthCheck); err != nil {
+		return teresa_errors.New(ErrInvalidHealthCheck, err)
+	}
+	return validateSecurityContext(ty.SecurityContext, ops.opts.baselineSecurityContext())
+}
+
+// applyTeresaYaml saves the app settings of teresa.yaml, returning the app
+// reloaded with them. The ones shared with the app pods (ingress options,
+// PDB and volumes) are skipped by deploys aside the current one
+func (ops *DeployOperations) applyTeresaYaml(user *database.User, a *app.App, confFiles *DeployConfigFiles, aside bool) (*app.App, error) {
+	ty := confFiles.TeresaYaml
+	if ty == nil {
+		return a, nil
+	}
+	appName, teamName, teamEnv := a.Name, a.Team, a.TeamEnvVars
+	reload := func() (*app.App, error) {
+		reloaded, err := ops.appOps.Get(appName)
+		if err != nil {
+			return nil, err
+		}
+		reloaded.Team = teamName
+		reloaded.TeamEnvVars = teamEnv
+		return reloaded, nil
+	}
 
-		if err := ops.appOps.SaveApp(a, user.Email); err != nil {
-			log.WithError(err).WithField("id", deployId).Errorf("Saving last deploy user (%s) of app %s", user.Name, appName)
+	// the options already set are skipped, so the deployers (like CI
+	// pipelines) can deploy apps with them
+	var err error
+	if ingressOptionsChanged(a, ty.Ingress) && app.IsWebApp(a.ProcessType) && !aside {
+		if err := ops.appOps.SetIngressOptions(user, a.Name, ty.Ingress); err != nil {
+			return nil, err
+		}
+		// reload the app to not overwrite its new ingress options on save
+		if a, err = reload(); err != nil {
+			return nil, err
 		}
+	}
 
-		if !app.IsCronJob(a.ProcessType) {
-			ops.watchDeploy(appName, deployId, w, errChan)
+	if ty.PDB != nil && ty.PDB.MinAvailable != a.MinAvailable && !app.IsCronJob(a.ProcessType) && !aside {
+		if err := ops.appOps.SetPDB(user, a.Name, ty.PDB.MinAvailable); err != nil {
+			return nil, err
 		}
-	}()
-	return r, errChan
+		// reload the app to not overwrite its new min available on save
+		if a, err = reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ty.VolumeClaims) > 0 && !app.IsCronJob(a.ProcessType) && !aside {
+		if err := ops.addVolumes(user, a, ty.VolumeClaims); err != nil {
+			return nil, err
+		}
+		// reload the app to not overwrite its new volumes on save
+		if a, err = reload(); err != nil {
+			return nil, err
+		}
+	}
+
+	// the lifecycle is saved along with the app, the new deploys apply it
+	if ty.Lifecycle != nil && !app.IsCronJob(a.ProcessType) {
+		a.Lifecycle = appLifecycle(ty.Lifecycle)
+	}
+	if ty.RollingUpdate != nil && !app.IsCronJob(a.ProcessType) {
+		a.RollingUpdate = appRollingUpdate(ty.RollingUpdate)
+	}
+	if ty.RolloutTimeoutSeconds != 0 && !app.IsCronJob(a.ProcessType) {
+		a.RolloutTimeoutSeconds = ty.RolloutTimeoutSeconds
+	}
+	return a, nil
 }
 
 func (ops *DeployOperations) runReleaseCmd(a *app.App, deployId, slugURL string, csp *spec.CloudSQLProxy, stream io.Writer) error {
@@ -139,18 +417,31 @@ func (ops *DeployOperations) runReleaseCmd(a *app.App, deployId, slugURL string,
 	return nil
 }
 
-func (ops *DeployOperations) createOrUpdateDeploy(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL, description, deployId string) error {
+// prepareDeploy runs the release command of the Procfile and the
+// pre-deploy hooks of teresa.yaml, if any, returning the Cloud SQL proxy of
+// teresa.yaml to run along with the new pods. Images have no slug to run
+// the release command from
+func (ops *DeployOperations) prepareDeploy(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, deployId string) (*spec.CloudSQLProxy, error) {
 	csp, err := spec.NewCloudSQLProxy(ops.opts.CloudSQLProxyImage, confFiles.TeresaYaml)
 	if err != nil {
-		return errors.Wrap(err, "failed to create the deploy")
+		return nil, errors.Wrap(err, "failed to create the deploy")
 	}
-	if releaseCmd := confFiles.Procfile[ProcfileReleaseCmd]; releaseCmd != "" {
+	if releaseCmd := confFiles.Procfile[ProcfileReleaseCmd]; releaseCmd != "" && slugURL != "" {
 		if err := ops.runReleaseCmd(a, deployId, slugURL, csp, w); err != nil {
 			log.WithError(err).WithField("id", deployId).Errorf("Running release command %s in app %s", releaseCmd, a.Name)
-			return err
+			return nil, err
 		}
 	}
-	labels := map[string]string{"run": a.Name}
+	if err := ops.runHooks(hookPreDeploy, a, confFiles, slugURL, opts.Image, deployId, csp, w); err != nil {
+		return nil, err
+	}
+	return csp, nil
+}
+
+// appDeploySpec returns the spec of the app deploy along with the sidecars
+// of teresa.yaml, shared with the deploys of the process types
+func (ops *DeployOperations) appDeploySpec(a *app.App, confFiles *DeployConfigFiles, slugURL string, opts *DeployOptions, csp *spec.CloudSQLProxy) (*spec.Deploy, []*spec.SideCar, error) {
+	labels := map[string]string{runLabel: a.Name}
 	podBuilder := spec.NewRunnerPodBuilder(a.Name, ops.opts.SlugRunnerImage, ops.opts.SlugStoreImage).
 		ForApp(a).
 		WithSlug(slugURL).
@@ -158,28 +449,71 @@ func (ops *DeployOperations) createOrUpdateDeploy(a *app.App, confFiles *DeployC
 		WithStorage(ops.fileStorage).
 		WithArgs([]string{"start", a.ProcessType})
 
+	if confFiles.NginxConf != "" && app.IsWebApp(a.ProcessType) {
+		podBuilder = podBuilder.WithNginxSideCar(ops.opts.NginxImage)
+	}
+	scs, err := spec.NewSideCars(confFiles.TeresaYaml)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create the deploy")
+	}
+	if opts.Image != "" {
+		var command []string
+		if confFiles.TeresaYaml != nil {
+			command = confFiles.TeresaYaml.Command
+		}
+		podBuilder = podBuilder.WithImage(opts.Image, command)
+	}
+	podBuilder = podBuilder.
+		WithCloudSQLProxySideCar(csp).
+		WithSideCars(scs).
+		WithAppInitContainers(appInitContainers(confFiles.TeresaYaml)).
+		WithVolumeClaims(a.VolumeClaims)
+
+	deploySpec := spec.NewDeployBuilder(slugURL).
+		WithPod(podBuilder.Build()).
+		WithDescription(opts.Description).
+		WithAuthor(opts.Author).
+		WithGitMetadata(opts.Commit, opts.Branch, opts.Message).
+		WithRevisionHistoryLimit(ops.opts.RevisionHistoryLimit).
+		WithDefaultSpread(ops.opts.DefaultSpreadZone, ops.opts.DefaultSpreadHost).
+		WithBaselineSecurityContext(ops.opts.baselineSecurityContext()).
+		WithTeresaYaml(confFiles.TeresaYaml).
+		WithLifecycle(deployLifecycle(a.Lifecycle)).
+		WithRollingUpdate(deployRollingUpdate(a.RollingUpdate)).
+		WithRolloutTimeout(a.RolloutTimeoutSeconds).
+		WithMatchLabels(labels).
+		WithProtocol(a.Protocol).
+		Build()
+	return deploySpec, scs, nil
+}
+
+func (ops *DeployOperations) createOrUpdateDeploy(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, deployId string) error {
+	csp, err := ops.prepareDeploy(a, confFiles, w, slugURL, opts, deployId)
+	if err != nil {
+		return err
+	}
+
 	if confFiles.NginxConf != "" && app.IsWebApp(a.ProcessType) {
 		data := map[string]string{spec.NginxConfFile: confFiles.NginxConf}
 		if err := ops.k8s.CreateOrUpdateConfigMap(a.Name, a.Name, data); err != nil {
 			log.WithError(err).Errorf("Creating config to nginx of app %s", a.Name)
 			return err
 		}
-		podBuilder = podBuilder.WithNginxSideCar(ops.opts.NginxImage)
 	} else {
 		err := ops.k8s.DeleteConfigMap(a.Name, a.Name)
 		if err != nil && !ops.k8s.IsNotFound(err) {
 			return err
 		}
 	}
-	podBuilder = podBuilder.WithCloudSQLProxySideCar(csp)
-
-	deploySpec := spec.NewDeployBuilder(slugURL).
-		WithPod(podBuilder.Build()).
-		WithDescription(description).
-		WithRevisionHistoryLimit(ops.opts.RevisionHistoryLimit).
-		WithTeresaYaml(confFiles.TeresaYaml).
-		WithMatchLabels(labels).
-		Build()
+	deploySpec, scs, err := ops.appDeploySpec(a, confFiles, slugURL, opts, csp)
+	if err != nil {
+		return err
+	}
+	// a paused app keeps no replicas until resumed
+	if a.Paused != nil {
+		var replicas int32
+		deploySpec.Replicas = &replicas
+	}
 
 	if err := ops.k8s.CreateOrUpdateDeploy(deploySpec); err != nil {
 		log.WithError(err).Errorf("Creating deploy app %s", a.Name)
@@ -190,10 +524,194 @@ func (ops *DeployOperations) createOrUpdateDeploy(a *app.App, confFiles *DeployC
 		log.WithError(err).Errorf("Exposing service %s", a.Name)
 		return err
 	}
+
+	var ports []spec.ExposedPort
+	if confFiles.TeresaYaml != nil {
+		ports = confFiles.TeresaYaml.Ports
+	}
+	if err := ops.exposePorts(a, ports, w); err != nil {
+		log.WithError(err).Errorf("Exposing ports of service %s", a.Name)
+		return err
+	}
+
+	if err := ops.createOrUpdateProcessDeploys(a, confFiles, w, slugURL, opts, csp, scs); err != nil {
+		log.WithError(err).Errorf("Creating process deploys of app %s", a.Name)
+		return err
+	}
+
+	// a regular deploy takes all the traffic back from the canary and the
+	// green deploy, if any
+	if app.IsWebApp(a.ProcessType) {
+		err := ops.k8s.DeleteCanary(a.Name, canaryDeployName(a.Name))
+		if err != nil && !ops.k8s.IsNotFound(err) {
+			log.WithError(err).Errorf("Deleting canary of app %s", a.Name)
+			return err
+		}
+		if err := ops.deleteGreen(a.Name); err != nil {
+			log.WithError(err).Errorf("Deleting green deploy of app %s", a.Name)
+			return err
+		}
+	}
 	fmt.Fprintln(w, fmt.Sprintf("The app %s has been successfully deployed", a.Name))
 	return nil
 }
 
+// syncProcesses updates the extra process types of the app with the ones of
+// the Procfile, the new ones start with one replica and the deploys of those
+// removed from it are deleted. It returns the process types to deploy.
+func (ops *DeployOperations) syncProcesses(a *app.App, procfile map[string]string, w io.Writer) ([]string, error) {
+	if a.Processes == nil {
+		a.Processes = make(map[string]int32)
+	}
+
+	var removed []string
+	for pt := range a.Processes {
+		if _, found := procfile[pt]; !found {
+			removed = append(removed, pt)
+		}
+	}
+	sort.Strings(removed)
+	for _, pt := range removed {
+		name := app.ProcessDeployName(a.Name, pt)
+		if err := ops.k8s.DeleteDeploy(a.Name, name); err != nil && !ops.k8s.IsNotFound(err) {
+			return nil, err
+		}
+		delete(a.Processes, pt)
+		if a.Paused != nil {
+			delete(a.Paused.Replicas, name)
+		}
+		fmt.Fprintf(w, "Process type %s removed from Procfile, deleted\n", pt)
+	}
+
+	var pts []string
+	for pt := range procfile {
+		if pt == a.ProcessType || !app.IsValidProcessType(pt) {
+			continue
+		}
+		if _, found := a.Processes[pt]; !found {
+			a.Processes[pt] = 1
+			// a paused app gets the new process type on resume
+			if a.Paused != nil && a.Paused.Replicas != nil {
+				a.Paused.Replicas[app.ProcessDeployName(a.Name, pt)] = 1
+			}
+		}
+		pts = append(pts, pt)
+	}
+	sort.Strings(pts)
+	return pts, nil
+}
+
+// createOrUpdateProcessDeploys creates a Deployment for each extra process
+// type of the Procfile, they aren't exposed and don't share the main health
+// checks
+func (ops *DeployOperations) createOrUpdateProcessDeploys(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL string, opts *DeployOptions, csp *spec.CloudSQLProxy, scs []*spec.SideCar) error {
+	pts, err := ops.syncProcesses(a, confFiles.Procfile, w)
+	if err != nil {
+		return err
+	}
+
+	var ty *spec.TeresaYaml
+	if confFiles.TeresaYaml != nil {
+		ty = &spec.TeresaYaml{
+			RollingUpdate:   confFiles.TeresaYaml.RollingUpdate,
+			Lifecycle:       confFiles.TeresaYaml.Lifecycle,
+			NodeSelector:    confFiles.TeresaYaml.NodeSelector,
+			Tolerations:     confFiles.TeresaYaml.Tolerations,
+			Affinity:        confFiles.TeresaYaml.Affinity,
+			Spread:          confFiles.TeresaYaml.Spread,
+			SecurityContext: confFiles.TeresaYaml.SecurityContext,
+			HostAliases:     confFiles.TeresaYaml.HostAliases,
+		}
+	}
+
+	for _, pt := range pts {
+		replicas := a.Processes[pt]
+		if a.Paused != nil {
+			replicas = 0
+		}
+
+		pa := *a
+		pa.ProcessType = pt
+		name := app.ProcessDeployName(a.Name, pt)
+		labels := map[string]string{runLabel: name}
+		podSpec := spec.NewRunnerPodBuilder(name, ops.opts.SlugRunnerImage, ops.opts.SlugStoreImage).
+			ForApp(&pa).
+			WithSlug(slugURL).
+			WithLabels(labels).
+			WithStorage(ops.fileStorage).
+			WithArgs([]string{"start", pt}).
+			WithCloudSQLProxySideCar(csp).
+			WithSideCars(scs).
+			WithAppInitContainers(appInitContainers(confFiles.TeresaYaml)).
+			Build()
+
+		var pty *spec.TeresaYaml
+		if ty != nil {
+			cp := *ty
+			cp.HealthCheck = confFiles.TeresaYaml.HealthCheck.ForProcess(pt)
+			pty = &cp
+		}
+
+		deploySpec := spec.NewDeployBuilder(slugURL).
+			WithPod(podSpec).
+			WithDescription(opts.Description).
+			WithAuthor(opts.Author).
+			WithGitMetadata(opts.Commit, opts.Branch, opts.Message).
+			WithRevisionHistoryLimit(ops.opts.RevisionHistoryLimit).
+			WithDefaultSpread(ops.opts.DefaultSpreadZone, ops.opts.DefaultSpreadHost).
+			WithBaselineSecurityContext(ops.opts.baselineSecurityContext()).
+			WithTeresaYaml(pty).
+			WithLifecycle(deployLifecycle(a.Lifecycle)).
+			WithRollingUpdate(deployRollingUpdate(a.RollingUpdate)).
+			WithRolloutTimeout(a.RolloutTimeoutSeconds).
+			WithMatchLabels(labels).
+			WithReplicas(replicas).
+			Build()
+
+		if err := ops.k8s.CreateOrUpdateDeploy(deploySpec); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Process type %s deployed with %d replica(s)\n", pt, replicas)
+	}
+	return nil
+}
+
+// addVolumes adds the volumes of teresa.yaml the app doesn't have yet, the
+// existing ones are kept as they are
+func (ops *DeployOperations) addVolumes(user *database.User, a *app.App, vols []spec.VolumeClaim) error {
+	names := make(map[string]bool)
+	for _, vc := range a.VolumeClaims {
+		names[vc.Name] = true
+	}
+	for _, v := range vols {
+		if names[v.Name] {
+			continue
+		}
+		vc := &app.VolumeClaim{
+			Name:         v.Name,
+			Size:         v.Size,
+			StorageClass: v.StorageClass,
+			AccessMode:   v.AccessMode,
+			MountPath:    v.MountPath,
+		}
+		if err := ops.appOps.AddVolume(user, a.Name, vc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ingressOptionsChanged tells if the ingress options of teresa.yaml change
+// the ones of the app, blank values unset them
+func ingressOptionsChanged(a *app.App, opts map[string]string) bool {
+	for k, v := range opts {
+		if a.IngressOptions[k] != v {
+			return true
+		}
+	}
+	return false
+}
+
 func (ops *DeployOperations) createOrUpdateCronJob(a *app.App, confFiles *DeployConfigFiles, w io.Writer, slugURL, description string) error {
 	if confFiles.TeresaYaml == nil || confFiles.TeresaYaml.Cron == nil {
 		return ErrCronScheduleNotFound
@@ -204,12 +722,15 @@ func (ops *DeployOperations) createOrUpdateCronJob(a *app.App, confFiles *Deploy
 		WithSlug(slugURL).
 		WithStorage(ops.fileStorage).
 		WithArgs(strings.Split(confFiles.Procfile[a.ProcessType], " ")).
+		WithAppInitContainers(confFiles.TeresaYaml.InitContainers).
 		Build()
 
 	cronSpec := spec.NewCronJobBuilder(slugURL).
 		WithPod(podSpec).
 		WithDescription(description).
 		WithSchedule(confFiles.TeresaYaml.Cron.Schedule).
+		WithTeresaYaml(confFiles.TeresaYaml).
+		WithBaselineSecurityContext(ops.opts.baselineSecurityContext()).
 		Build()
 
 	if err := ops.k8s.CreateOrUpdateCronJob(cronSpec); err != nil {
@@ -229,7 +750,32 @@ func (ops *DeployOperations) exposeApp(a *app.App, w io.Writer) error {
 	if err := ops.k8s.ExposeDeploy(a.Name, a.Name, svcType, a.Protocol, vHosts, w); err != nil {
 		return err
 	}
-	return nil // already exposed
+	// the ingress may have been just created
+	if len(a.IngressOptions) > 0 {
+		return ops.k8s.IngressSetOptions(a.Name, a.Name, a.IngressOptions)
+	}
+	return nil
+}
+
+// exposePorts sets the raw TCP and UDP ports of the app service, exposing
+// them on the ingress controller too
+func (ops *DeployOperations) exposePorts(a *app.App, ports []spec.ExposedPort, w io.Writer) error {
+	if !app.IsWebApp(a.ProcessType) {
+		return nil
+	}
+	svc, err := ops.k8s.Service(a.Name, a.Name)
+	if err != nil {
+		return err
+	}
+
+	svcPorts := servicePorts(svc.Ports, ports)
+	if !reflect.DeepEqual(svcPorts, svc.Ports) {
+		fmt.Fprintln(w, "Updating service ports")
+		if err := ops.k8s.UpdateServicePorts(a.Name, a.Name, svcPorts); err != nil {
+			return err
+		}
+	}
+	return ops.k8s.IngressSetStreamPorts(a.Name, a.Name, svcPorts[len(svcPorts)-len(ports):])
 }
 
 func (ops *DeployOperations) podRun(ctx context.Context, podSpec *spec.Pod, stream io.Writer) error {
@@ -251,7 +797,7 @@ func (ops *DeployOperations) List(user *database.User, appName string) ([]*Repli
 		return nil, err
 	}
 
-	if !ops.appOps.HasPermission(user, appName) {
+	if !ops.appOps.HasRole(user, appName, team.RoleViewer) {
 		return nil, auth.ErrPermissionDenied
 	}
 
@@ -259,22 +805,79 @@ func (ops *DeployOperations) List(user *database.User, appName string) ([]*Repli
 	if err != nil {
 		return nil, teresa_errors.NewInternalServerError(err)
 	}
+	for _, item := range items {
+		item.EnvVars = appEnvVars(item.EnvVars)
+	}
 
 	return items, nil
 }
 
-func (ops *DeployOperations) Rollback(user *database.User, appName, revision string) error {
-	a, err := ops.appOps.CheckPermAndGet(user, appName)
+// Rollback restores the revision of the app deploy, with its slug and env
+// vars. A blank revision is the one before the current
+func (ops *DeployOperations) Rollback(user *database.User, appName, revision string, force bool) error {
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
 	if err != nil {
 		return err
 	}
-	if err = ops.k8s.DeployRollbackToRevision(appName, appName, revision); err != nil {
-		return teresa_errors.NewInternalServerError(err)
+	id, err := ops.lockRelease(user, a, force)
+	if err != nil {
+		return err
 	}
-	env, err := ops.k8s.ContainerExplicitEnvVars(appName, appName, appName)
+	defer ops.unlockDeploy(appName, id)
+	items, err := ops.k8s.ReplicaSetListByLabel(appName, runLabel, appName)
 	if err != nil {
 		return teresa_errors.NewInternalServerError(err)
 	}
+	item := findRevision(items, revision)
+	if item == nil {
+		return ErrRevisionNotFound
+	}
+	if err = ops.k8s.DeployRollbackToRevision(appName, appName, item.Revision); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	// the deploy is rolled back by the controller, the env vars come from
+	// the revision itself
+	if a.Team, err = ops.appOps.TeamName(appName); err != nil {
+		return err
+	}
+	if err := ops.setTeamEnvVars(a); err != nil {
+		return err
+	}
+	a.EnvVars = withoutTeamEnvVars(appEnvVars(item.EnvVars), a.TeamEnvVars)
+	if err := ops.appOps.SaveApp(a, user.Email); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+// findRevision returns the item of the revision, a blank one returns the
+// newest revision that isn't the current
+func findRevision(items []*ReplicaSetListItem, revision string) *ReplicaSetListItem {
+	var found *ReplicaSetListItem
+	for _, item := range items {
+		if revision != "" {
+			if item.Revision == revision {
+				return item
+			}
+			continue
+		}
+		if item.Current {
+			continue
+		}
+		if found == nil || revisionNumber(item.Revision) > revisionNumber(found.Revision) {
+			found = item
+		}
+	}
+	return found
+}
+
+func revisionNumber(revision string) int {
+	n, _ := strconv.Atoi(revision)
+	return n
+}
+
+// appEnvVars removes the env vars set by Teresa
+func appEnvVars(env []*app.EnvVar) []*app.EnvVar {
 	appEnv := []*app.EnvVar{}
 	for _, ev := range env {
 		if validation.IsProtectedEnvVar(ev.Key) {
@@ -282,11 +885,7 @@ func (ops *DeployOperations) Rollback(user *database.User, appName, revision str
 		}
 		appEnv = append(appEnv, ev)
 	}
-	a.EnvVars = appEnv
-	if err := ops.appOps.SaveApp(a, user.Email); err != nil {
-		return teresa_errors.NewInternalServerError(err)
-	}
-	return nil
+	return appEnv
 }
 
 func (ops *DeployOperations) serviceType(a *app.App) string {
@@ -296,9 +895,17 @@ func (ops *DeployOperations) serviceType(a *app.App) string {
 	return ops.opts.DefaultServiceType
 }
 
-func (ops *DeployOperations) watchDeploy(appName, deployId string, w io.Writer, errChan chan<- error) {
+func (ops *DeployOperations) watchDeploy(appName, deployName string, w io.Writer, errChan chan<- error) {
 	fmt.Fprintln(w, "\nMonitoring rolling update...(hit Ctrl-C to quit)")
-	if err := ops.k8s.WatchDeploy(appName, appName); err != nil {
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		ops.reportProgress(appName, deployName, w, stop)
+		close(done)
+	}()
+	err := ops.k8s.WatchDeploy(appName, deployName)
+	close(stop)
+	<-done
+	if err != nil {
 		errChan <- err
 		return
 	}
@@ -315,3 +922,10 @@ func NewDeployOperations(aOps app.Operations, k8s K8sOperations, s storage.Stora
 		buildOps:    buildOps,
 	}
 }
+
+func appInitContainers(ty *spec.TeresaYaml) []*spec.AppInitContainer {
+	if ty == nil {
+		return nil
+	}
+	return ty.InitContainers
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	context "golang.org/x/net/context"
@@ -24,17 +25,37 @@ import (
 )
 
 type fakeK8sOperations struct {
-	lastDeploySpec                *spec.Deploy
-	lastCronJobSpec               *spec.CronJob
-	createDeployReturn            error
-	createCronJobReturn           error
-	hasSrvErr                     error
-	exposeDeployWasCalled         bool
-	replicaSetListByLabelErr      error
-	createConfigMapWasCalled      bool
-	deleteConfigMapWasCalled      bool
-	containerExplicitEnvVarsErr   error
-	containerExplicitEnvVarsValue []*app.EnvVar
+	lastDeploySpec              *spec.Deploy
+	deploySpecs                 []*spec.Deploy
+	lastCronJobSpec             *spec.CronJob
+	createDeployReturn          error
+	createCronJobReturn         error
+	hasSrvErr                   error
+	exposeDeployWasCalled       bool
+	replicaSetListByLabelErr    error
+	createConfigMapWasCalled    bool
+	deleteConfigMapWasCalled    bool
+	ingressOptions              map[string]string
+	servicePorts                []spec.ServicePort
+	updateServicePortsWasCalled bool
+	streamPorts                 []spec.ServicePort
+	hasIngress                  bool
+	canaryWeight                int32
+	promoteCanaryErr            error
+	deleteCanaryErr             error
+	deletedCanaries             []string
+	greenSwitchedAt             string
+	greenErr                    error
+	deployReplicas              int32
+	serviceSelector             string
+	promotedDeploy              string
+	deletedDeploys              []string
+	rollbackRevision            string
+	watchDeployErr              error
+	pods                        []*app.Pod
+	diffDeploySpec              *spec.Deploy
+	diffServiceSpec             *spec.Service
+	diff                        string
 }
 
 func (f *fakeK8sOperations) CreateOrUpdateConfigMap(namespace, name string, data map[string]string) error {
@@ -51,8 +72,13 @@ func (f *fakeK8sOperations) IsNotFound(err error) bool {
 	return true
 }
 
+func (f *fakeK8sOperations) IsRolloutStalled(err error) bool {
+	return err == errRolloutStalled
+}
+
 func (f *fakeK8sOperations) CreateOrUpdateDeploy(deploySpec *spec.Deploy) error {
 	f.lastDeploySpec = deploySpec
+	f.deploySpecs = append(f.deploySpecs, deploySpec)
 	return f.createDeployReturn
 }
 
@@ -66,6 +92,30 @@ func (f *fakeK8sOperations) ExposeDeploy(namespace, name, svcType, portName stri
 	return nil
 }
 
+func (f *fakeK8sOperations) IngressSetOptions(namespace, name string, opts map[string]string) error {
+	f.ingressOptions = opts
+	return nil
+}
+
+func (f *fakeK8sOperations) Service(namespace, svcName string) (*spec.Service, error) {
+	ports := f.servicePorts
+	if ports == nil {
+		ports = []spec.ServicePort{*spec.NewDefaultServicePort("")}
+	}
+	return &spec.Service{Name: svcName, Namespace: namespace, Ports: ports}, nil
+}
+
+func (f *fakeK8sOperations) UpdateServicePorts(namespace, svcName string, ports []spec.ServicePort) error {
+	f.updateServicePortsWasCalled = true
+	f.servicePorts = ports
+	return nil
+}
+
+func (f *fakeK8sOperations) IngressSetStreamPorts(namespace, svcName string, ports []spec.ServicePort) error {
+	f.streamPorts = ports
+	return nil
+}
+
 func (f *fakeK8sOperations) ReplicaSetListByLabel(namespace, label, value string) ([]*ReplicaSetListItem, error) {
 	items := []*ReplicaSetListItem{
 		{
@@ -73,6 +123,7 @@ func (f *fakeK8sOperations) ReplicaSetListByLabel(namespace, label, value string
 			Description: "Test 1",
 			CreatedAt:   "1",
 			Current:     false,
+			EnvVars:     []*app.EnvVar{{Key: "KEY", Value: "1"}, {Key: "PORT", Value: "5000"}},
 		},
 		{
 			Revision:    "2",
@@ -85,17 +136,70 @@ func (f *fakeK8sOperations) ReplicaSetListByLabel(namespace, label, value string
 }
 
 func (f *fakeK8sOperations) DeployRollbackToRevision(namespace, name, revision string) error {
+	f.rollbackRevision = revision
 	return nil
 }
 
-func (f *fakeK8sOperations) ContainerExplicitEnvVars(namespace, deployName, containerName string) ([]*app.EnvVar, error) {
-	return f.containerExplicitEnvVarsValue, f.containerExplicitEnvVarsErr
+func (f *fakeK8sOperations) WatchDeploy(namespace, deployName string) error {
+	return f.watchDeployErr
 }
 
-func (f *fakeK8sOperations) WatchDeploy(namespace, deployName string) error {
+func (f *fakeK8sOperations) HasIngress(namespace, name string) (bool, error) {
+	return f.hasIngress, nil
+}
+
+func (f *fakeK8sOperations) ExposeCanary(namespace, name, canaryName string, weight int32) error {
+	f.canaryWeight = weight
 	return nil
 }
 
+func (f *fakeK8sOperations) DeployPromote(namespace, name, srcName string) error {
+	f.promotedDeploy = srcName
+	return f.promoteCanaryErr
+}
+
+func (f *fakeK8sOperations) DeleteCanary(namespace, canaryName string) error {
+	f.deletedCanaries = append(f.deletedCanaries, canaryName)
+	return f.deleteCanaryErr
+}
+
+func (f *fakeK8sOperations) NamespaceListByLabel(label, value string) ([]string, error) {
+	return []string{"teresa"}, nil
+}
+
+func (f *fakeK8sOperations) DeployAnnotation(namespace, deployName, annotation string) (string, error) {
+	return f.greenSwitchedAt, f.greenErr
+}
+
+func (f *fakeK8sOperations) DeploySetAnnotation(namespace, name, annotation, value string) error {
+	f.greenSwitchedAt = value
+	return nil
+}
+
+func (f *fakeK8sOperations) DeployReplicas(namespace, name string) (int32, error) {
+	return f.deployReplicas, nil
+}
+
+func (f *fakeK8sOperations) DeleteDeploy(namespace, name string) error {
+	f.deletedDeploys = append(f.deletedDeploys, name)
+	return nil
+}
+
+func (f *fakeK8sOperations) ServiceSetSelector(namespace, svcName, deployName string) error {
+	f.serviceSelector = deployName
+	return nil
+}
+
+func (f *fakeK8sOperations) PodListByLabel(namespace, label, value string) ([]*app.Pod, error) {
+	return f.pods, nil
+}
+
+func (f *fakeK8sOperations) DeployDiff(deploySpec *spec.Deploy, svcSpec *spec.Service, vHosts []string) (string, error) {
+	f.diffDeploySpec = deploySpec
+	f.diffServiceSpec = svcSpec
+	return f.diff, nil
+}
+
 func TestDeployPermissionDenied(t *testing.T) {
 	ops := NewDeployOperations(
 		app.NewFakeOperations(),
@@ -107,7 +211,7 @@ func TestDeployPermissionDenied(t *testing.T) {
 	)
 	u := &database.User{Email: "bad-user@luizalabs.com"}
 	ctx := context.Background()
-	_, errChan := ops.Deploy(ctx, u, "teresa", &test.FakeReadSeeker{}, "test")
+	_, errChan := ops.Deploy(ctx, u, "teresa", &test.FakeReadSeeker{}, &DeployOptions{Description: "test"})
 
 	if err := <-errChan; err != auth.ErrPermissionDenied {
 		t.Errorf("expected ErrPermissionDenied, got %v", err)
@@ -136,7 +240,7 @@ func TestDeploy(t *testing.T) {
 	)
 	u := &database.User{Email: "gopher@luizalabs.com"}
 	ctx := context.Background()
-	r, errChan := ops.Deploy(ctx, u, "teresa", tarBall, "test")
+	r, errChan := ops.Deploy(ctx, u, "teresa", tarBall, &DeployOptions{Description: "test"})
 	select {
 	case err = <-errChan:
 	default:
@@ -150,7 +254,7 @@ func TestDeploy(t *testing.T) {
 
 func TestCreateDeploy(t *testing.T) {
 	expectedName := "Test app"
-	a := &app.App{Name: expectedName}
+	a := &app.App{Name: expectedName, ProcessType: "worker"}
 	expectedDescription := "test-description"
 	expectedSlugURL := "test-slug"
 	opts := &Options{RevisionHistoryLimit: 3}
@@ -172,7 +276,7 @@ func TestCreateDeploy(t *testing.T) {
 		conf,
 		new(bytes.Buffer),
 		expectedSlugURL,
-		expectedDescription,
+		&DeployOptions{Description: expectedDescription},
 		"123",
 	)
 
@@ -194,6 +298,261 @@ func TestCreateDeploy(t *testing.T) {
 	}
 }
 
+func TestCreateDeployProcessTypes(t *testing.T) {
+	a := &app.App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 3, "clock": 1},
+	}
+	conf := &DeployConfigFiles{
+		Procfile: map[string]string{
+			"web":     "python app.py",
+			"worker":  "python worker.py",
+			"beat":    "python beat.py",
+			"release": "python migrate.py",
+		},
+		TeresaYaml: &spec.TeresaYaml{
+			HealthCheck:  &spec.HealthCheck{Liveness: &spec.HealthCheckProbe{Path: "/healthcheck/"}},
+			NodeSelector: map[string]string{"pool": "workers"},
+		},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		conf,
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	if len(fakeK8s.deploySpecs) != 3 {
+		t.Fatalf("expected 3 deploys, got %d", len(fakeK8s.deploySpecs))
+	}
+	if len(fakeK8s.deletedDeploys) == 0 || fakeK8s.deletedDeploys[0] != "teresa-clock" {
+		t.Errorf("expected teresa-clock deleted, got %v", fakeK8s.deletedDeploys)
+	}
+	expectedProcesses := map[string]int32{"worker": 3, "beat": 1}
+	if !reflect.DeepEqual(a.Processes, expectedProcesses) {
+		t.Errorf("expected processes %v, got %v", expectedProcesses, a.Processes)
+	}
+
+	ds := fakeK8s.deploySpecs[1]
+	if ds.Name != "teresa-beat" {
+		t.Errorf("expected teresa-beat, got %s", ds.Name)
+	}
+	if ds.Replicas == nil || *ds.Replicas != 1 {
+		t.Errorf("expected 1 replica, got %v", ds.Replicas)
+	}
+
+	ds = fakeK8s.deploySpecs[2]
+	if ds.Name != "teresa-worker" {
+		t.Errorf("expected teresa-worker, got %s", ds.Name)
+	}
+	if ds.Replicas == nil || *ds.Replicas != 3 {
+		t.Errorf("expected 3 replicas, got %v", ds.Replicas)
+	}
+	if ds.HealthCheck != nil {
+		t.Errorf("expected no health check, got %v", ds.HealthCheck)
+	}
+	if actual := ds.Containers[0].Args; !reflect.DeepEqual(actual, []string{"start", "worker"}) {
+		t.Errorf("expected [start worker], got %v", actual)
+	}
+	if actual := ds.MatchLabels[runLabel]; actual != "teresa-worker" {
+		t.Errorf("expected run label teresa-worker, got %s", actual)
+	}
+	if actual := ds.NodeSelector["pool"]; actual != "workers" {
+		t.Errorf("expected node selector pool=workers, got %v", ds.NodeSelector)
+	}
+}
+
+func TestCreateDeployPaused(t *testing.T) {
+	a := &app.App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 3},
+		Paused:      &app.PausedState{Replicas: map[string]int32{"teresa": 2, "teresa-worker": 3}},
+	}
+	conf := &DeployConfigFiles{
+		Procfile: map[string]string{
+			"web":    "python app.py",
+			"worker": "python worker.py",
+		},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		conf,
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	if len(fakeK8s.deploySpecs) != 2 {
+		t.Fatalf("expected 2 deploys, got %d", len(fakeK8s.deploySpecs))
+	}
+	for _, ds := range fakeK8s.deploySpecs {
+		if ds.Replicas == nil || *ds.Replicas != 0 {
+			t.Errorf("expected no replicas for %s, got %v", ds.Name, ds.Replicas)
+		}
+	}
+}
+
+func TestCreateDeployProcessTypesHealthCheck(t *testing.T) {
+	a := &app.App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 1},
+	}
+	workerHC := &spec.HealthCheck{
+		Liveness: &spec.HealthCheckProbe{Type: spec.ProbeTypeExec, Command: []string{"pgrep", "worker"}},
+	}
+	conf := &DeployConfigFiles{
+		Procfile: map[string]string{
+			"web":    "python app.py",
+			"worker": "python worker.py",
+		},
+		TeresaYaml: &spec.TeresaYaml{
+			HealthCheck: &spec.HealthCheck{
+				Liveness:  &spec.HealthCheckProbe{Path: "/healthcheck/"},
+				Processes: map[string]*spec.HealthCheck{"worker": workerHC},
+			},
+		},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		conf,
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	if len(fakeK8s.deploySpecs) != 2 {
+		t.Fatalf("expected 2 deploys, got %d", len(fakeK8s.deploySpecs))
+	}
+	if actual := fakeK8s.deploySpecs[0].HealthCheck.Liveness.Path; actual != "/healthcheck/" {
+		t.Errorf("expected /healthcheck/, got %s", actual)
+	}
+	if actual := fakeK8s.deploySpecs[1].HealthCheck; actual != workerHC {
+		t.Errorf("expected %v, got %v", workerHC, actual)
+	}
+	if conf.TeresaYaml.HealthCheck.Processes == nil {
+		t.Error("expected the teresa.yaml of the app to be kept")
+	}
+}
+
+func TestCreateDeployVolumeClaims(t *testing.T) {
+	a := &app.App{
+		Name:         "teresa",
+		ProcessType:  "web",
+		VolumeClaims: []*app.VolumeClaim{{Name: "data", Size: "1Gi", MountPath: "/data"}},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		&DeployConfigFiles{},
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	var found bool
+	for _, v := range fakeK8s.lastDeploySpec.Volumes {
+		if v.ClaimName == "data" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected volume of claim data, got %v", fakeK8s.lastDeploySpec.Volumes)
+	}
+}
+
+func TestAddVolumes(t *testing.T) {
+	appOps := app.NewFakeOperations()
+	a := &app.App{
+		Name:         "teresa",
+		VolumeClaims: []*app.VolumeClaim{{Name: "data", Size: "1Gi", MountPath: "/data"}},
+	}
+	appOps.Storage[a.Name] = a
+	ops := NewDeployOperations(
+		appOps,
+		new(fakeK8sOperations),
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+	vols := []spec.VolumeClaim{
+		{Name: "data", Size: "10Gi", MountPath: "/other"},
+		{Name: "cache", Size: "1Gi", MountPath: "/cache"},
+	}
+
+	if err := ops.(*DeployOperations).addVolumes(u, a, vols); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	got := appOps.Storage[a.Name].VolumeClaims
+	if len(got) != 2 || got[0].Size != "1Gi" || got[1].Name != "cache" {
+		t.Errorf("expected data (1Gi) and cache, got %v", got)
+	}
+}
+
 func TestCreateDeployCreateNginxConfigMap(t *testing.T) {
 	conf := &DeployConfigFiles{NginxConf: "nginx conf"}
 
@@ -212,7 +571,7 @@ func TestCreateDeployCreateNginxConfigMap(t *testing.T) {
 		conf,
 		new(bytes.Buffer),
 		"test-slug",
-		"test-description",
+		&DeployOptions{Description: "test-description"},
 		"123",
 	)
 
@@ -242,7 +601,7 @@ func TestCreateDeploySkipCreatingNginxConfigMap(t *testing.T) {
 		conf,
 		new(bytes.Buffer),
 		"test-slug",
-		"test-description",
+		&DeployOptions{Description: "test-description"},
 		"123",
 	)
 
@@ -272,7 +631,7 @@ func TestCreateDeployDeleteNginxConfigMap(t *testing.T) {
 		conf,
 		new(bytes.Buffer),
 		"test-slug",
-		"test-description",
+		&DeployOptions{Description: "test-description"},
 		"123",
 	)
 
@@ -310,7 +669,7 @@ func TestCreateDeployCloudSQLProxySideCar(t *testing.T) {
 		cfg,
 		new(bytes.Buffer),
 		"test-slug",
-		"test-description",
+		&DeployOptions{Description: "test-description"},
 		"123",
 	)
 
@@ -343,7 +702,7 @@ func TestCreateDeployCloudSQLProxySideCarError(t *testing.T) {
 		cfg,
 		new(bytes.Buffer),
 		"test-slug",
-		"test-description",
+		&DeployOptions{Description: "test-description"},
 		"123",
 	)
 
@@ -370,7 +729,7 @@ func TestCreateDeployReturnError(t *testing.T) {
 		&DeployConfigFiles{Procfile: map[string]string{}},
 		new(bytes.Buffer),
 		"some slug",
-		"some desc",
+		&DeployOptions{Description: "some desc"},
 		"123",
 	)
 
@@ -522,6 +881,78 @@ func TestExposeApp(t *testing.T) {
 	}
 }
 
+func TestExposeAppIngressOptions(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	opts := map[string]string{app.IngressOptionProxyBodySize: "10m"}
+	a := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb, IngressOptions: opts}
+
+	if err := ops.(*DeployOperations).exposeApp(a, new(bytes.Buffer)); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(fakeK8s.ingressOptions, opts) {
+		t.Errorf("expected %v, got %v", opts, fakeK8s.ingressOptions)
+	}
+}
+
+func TestExposePorts(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	a := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb}
+	ports := []spec.ExposedPort{{Name: "mqtt", Port: 1883}, {Name: "dns", Port: 53, Protocol: "udp"}}
+
+	if err := ops.(*DeployOperations).exposePorts(a, ports, new(bytes.Buffer)); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(fakeK8s.servicePorts) != 3 {
+		t.Fatalf("expected 3 service ports, got %v", fakeK8s.servicePorts)
+	}
+	expected := []spec.ServicePort{
+		{Name: "mqtt", Port: 1883, TargetPort: 1883, Protocol: "TCP"},
+		{Name: "dns", Port: 53, TargetPort: 53, Protocol: "UDP"},
+	}
+	if !reflect.DeepEqual(fakeK8s.streamPorts, expected) {
+		t.Errorf("expected %v, got %v", expected, fakeK8s.streamPorts)
+	}
+}
+
+func TestExposePortsUnchanged(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{}
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	a := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb}
+
+	if err := ops.(*DeployOperations).exposePorts(a, nil, new(bytes.Buffer)); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if fakeK8s.updateServicePortsWasCalled {
+		t.Error("expected service ports not to be updated")
+	}
+	if len(fakeK8s.streamPorts) != 0 {
+		t.Errorf("expected no stream ports, got %v", fakeK8s.streamPorts)
+	}
+}
+
 func TestRunReleaseCmd(t *testing.T) {
 	var testCases = []struct {
 		commandErr  error
@@ -658,7 +1089,7 @@ func TestRollbackOpsSuccess(t *testing.T) {
 	user := &database.User{Email: "gopher@luizalabs.com"}
 	name := "teresa"
 
-	if err := ops.Rollback(user, name, ""); err != nil {
+	if err := ops.Rollback(user, name, "", false); err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
@@ -675,7 +1106,7 @@ func TestRollbackOpsErrPermissionDenied(t *testing.T) {
 	user := &database.User{Email: "bad-user@luizalabs.com"}
 	name := "teresa"
 
-	if err := ops.Rollback(user, name, ""); err != auth.ErrPermissionDenied {
+	if err := ops.Rollback(user, name, "", false); err != auth.ErrPermissionDenied {
 		t.Errorf("expected auth.ErrPermissionDenied, got %s", err)
 	}
 }
@@ -692,15 +1123,15 @@ func TestRollbackOpsErrNotFound(t *testing.T) {
 	user := &database.User{Email: "gopher@luizalabs.com"}
 	name := "bad-app"
 
-	if err := ops.Rollback(user, name, ""); err != app.ErrNotFound {
+	if err := ops.Rollback(user, name, "", false); err != app.ErrNotFound {
 		t.Errorf("expected app.ErrNotFound, got %s", err)
 	}
 }
 
-func TestRollbackErrorFromContainerEnvVars(t *testing.T) {
+func TestRollbackErrorFromReplicaSetList(t *testing.T) {
 	ops := NewDeployOperations(
 		app.NewFakeOperations(),
-		&fakeK8sOperations{containerExplicitEnvVarsErr: errors.New("test")},
+		&fakeK8sOperations{replicaSetListByLabelErr: errors.New("test")},
 		storage.NewFake(),
 		exec.NewFakeOperations(),
 		build.NewFakeOperations(),
@@ -709,11 +1140,73 @@ func TestRollbackErrorFromContainerEnvVars(t *testing.T) {
 	user := &database.User{Email: "gopher@luizalabs.com"}
 
 	want := teresa_errors.ErrInternalServerError
-	if err := ops.Rollback(user, "teresa", ""); teresa_errors.Get(err) != want {
+	if err := ops.Rollback(user, "teresa", "", false); teresa_errors.Get(err) != want {
 		t.Errorf("got %v; want %v", teresa_errors.Get(err), want)
 	}
 }
 
+func TestRollbackOpsRevision(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	user := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Rollback(user, "teresa", "2", false); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if fakeK8s.rollbackRevision != "2" {
+		t.Errorf("expected 2, got %s", fakeK8s.rollbackRevision)
+	}
+	if err := ops.Rollback(user, "teresa", "3", false); err != ErrRevisionNotFound {
+		t.Errorf("expected ErrRevisionNotFound, got %v", err)
+	}
+}
+
+func TestFindRevision(t *testing.T) {
+	items := []*ReplicaSetListItem{
+		{Revision: "9"},
+		{Revision: "10", Current: true},
+		{Revision: "8"},
+	}
+	var testCases = []struct {
+		revision string
+		expected string
+	}{
+		{"8", "8"},
+		{"10", "10"},
+		{"", "9"},
+		{"11", ""},
+	}
+
+	for _, tc := range testCases {
+		got := findRevision(items, tc.revision)
+		if tc.expected == "" {
+			if got != nil {
+				t.Errorf("expected no revision for %q, got %s", tc.revision, got.Revision)
+			}
+			continue
+		}
+		if got == nil || got.Revision != tc.expected {
+			t.Errorf("expected %s for %q, got %v", tc.expected, tc.revision, got)
+		}
+	}
+}
+
+func TestAppEnvVars(t *testing.T) {
+	env := []*app.EnvVar{{Key: "KEY", Value: "1"}, {Key: "PORT", Value: "5000"}}
+	expected := []*app.EnvVar{{Key: "KEY", Value: "1"}}
+
+	if got := appEnvVars(env); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
 func TestIsProtectedEnvVar(t *testing.T) {
 	var testCases = []struct {
 		name string
@@ -0,0 +1,114 @@
+package deploy
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/uid"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeployLocks serializes the deploys of an app, shared by all the replicas
+// of the server. Acquire returns the lock held by another deploy, if any
+type DeployLocks interface {
+	Acquire(appName, deployId, author string) (*database.DeployLock, error)
+	Release(appName, deployId string) error
+}
+
+// DatabaseDeployLocks keeps the locks in the database, the ones older than
+// TTL are left by deploys interrupted by a server restart and are taken over
+type DatabaseDeployLocks struct {
+	DB  *gorm.DB
+	TTL time.Duration
+}
+
+func (l *DatabaseDeployLocks) Acquire(appName, deployId, author string) (*database.DeployLock, error) {
+	if l.TTL > 0 {
+		stale := l.DB.Where("app_name = ? AND created_at < ?", appName, time.Now().Add(-l.TTL))
+		if err := stale.Delete(database.DeployLock{}).Error; err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("removing stale deploy lock of %s", appName))
+		}
+	}
+
+	lock := &database.DeployLock{AppName: appName, DeployID: deployId, Author: author}
+	if err := l.DB.Create(lock).Error; err == nil {
+		return nil, nil
+	}
+
+	holder := new(database.DeployLock)
+	if err := l.DB.Where(&database.DeployLock{AppName: appName}).First(holder).Error; err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("acquiring deploy lock of %s", appName))
+	}
+	return holder, nil
+}
+
+func (l *DatabaseDeployLocks) Release(appName, deployId string) error {
+	q := l.DB.Where(&database.DeployLock{AppName: appName, DeployID: deployId})
+	if err := q.Delete(database.DeployLock{}).Error; err != nil {
+		return errors.Wrap(err, fmt.Sprintf("releasing deploy lock of %s", appName))
+	}
+	return nil
+}
+
+func NewDatabaseDeployLocks(db *gorm.DB, ttl time.Duration) DeployLocks {
+	return &DatabaseDeployLocks{DB: db, TTL: ttl}
+}
+
+func (ops *DeployOperations) SetDeployLocks(l DeployLocks) {
+	ops.locks = l
+}
+
+// lockDeploy acquires the deploy lock of the app, rejecting the deploy
+// with the one holding it
+func (ops *DeployOperations) lockDeploy(appName, deployId, author string) error {
+	if ops.locks == nil {
+		return nil
+	}
+	holder, err := ops.locks.Acquire(appName, deployId, author)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if holder == nil {
+		return nil
+	}
+	return status.Errorf(
+		codes.FailedPrecondition,
+		"Deploy %s of app %s by %s in progress since %s, wait for it to finish",
+		holder.DeployID,
+		appName,
+		holder.Author,
+		holder.CreatedAt.Format(time.RFC3339),
+	)
+}
+
+// lockRelease gates the changes of the app pods out of a deploy (rollback,
+// promote and switch) like a deploy: rejected while the app is frozen or
+// being deployed. The returned id releases the deploy lock
+func (ops *DeployOperations) lockRelease(user *database.User, a *app.App, force bool) (string, error) {
+	if err := ops.checkFreeze(user, a, force); err != nil {
+		return "", err
+	}
+	id := uid.New()
+	if err := ops.lockDeploy(a.Name, id, user.Email); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// unlockDeploy releases the deploy lock of the app, a failure here is only
+// logged and the lock expires after the TTL
+func (ops *DeployOperations) unlockDeploy(appName, deployId string) {
+	if ops.locks == nil {
+		return
+	}
+	if err := ops.locks.Release(appName, deployId); err != nil {
+		log.WithError(err).WithField("id", deployId).Errorf("releasing deploy lock of app %s", appName)
+	}
+}
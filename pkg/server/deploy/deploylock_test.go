@@ -0,0 +1,125 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestDeployLocks(t *testing.T, ttl time.Duration) (*gorm.DB, DeployLocks) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	return db, NewDatabaseDeployLocks(db, ttl)
+}
+
+func TestDatabaseDeployLocksAcquire(t *testing.T) {
+	db, l := newTestDeployLocks(t, time.Hour)
+	defer db.Close()
+
+	holder, err := l.Acquire("teresa", "1", "gopher@luizalabs.com")
+	if err != nil {
+		t.Fatal("error acquiring deploy lock:", err)
+	}
+	if holder != nil {
+		t.Fatalf("expected lock acquired, got held by %+v", holder)
+	}
+
+	holder, err = l.Acquire("teresa", "2", "other@luizalabs.com")
+	if err != nil {
+		t.Fatal("error acquiring deploy lock:", err)
+	}
+	if holder == nil || holder.DeployID != "1" || holder.Author != "gopher@luizalabs.com" {
+		t.Errorf("expected lock held by deploy 1 of gopher@luizalabs.com, got %+v", holder)
+	}
+
+	if holder, _ := l.Acquire("other-app", "3", "other@luizalabs.com"); holder != nil {
+		t.Errorf("expected lock of another app acquired, got held by %+v", holder)
+	}
+}
+
+func TestDatabaseDeployLocksRelease(t *testing.T) {
+	db, l := newTestDeployLocks(t, time.Hour)
+	defer db.Close()
+
+	l.Acquire("teresa", "1", "gopher@luizalabs.com")
+	if err := l.Release("teresa", "2"); err != nil {
+		t.Fatal("error releasing deploy lock:", err)
+	}
+	if holder, _ := l.Acquire("teresa", "2", "other@luizalabs.com"); holder == nil {
+		t.Fatal("expected lock kept by the release of another deploy")
+	}
+
+	if err := l.Release("teresa", "1"); err != nil {
+		t.Fatal("error releasing deploy lock:", err)
+	}
+	if holder, _ := l.Acquire("teresa", "2", "other@luizalabs.com"); holder != nil {
+		t.Errorf("expected lock acquired after release, got held by %+v", holder)
+	}
+}
+
+func TestDatabaseDeployLocksTakeOverStale(t *testing.T) {
+	db, l := newTestDeployLocks(t, time.Hour)
+	defer db.Close()
+
+	stale := &database.DeployLock{AppName: "teresa", DeployID: "1", Author: "gopher@luizalabs.com"}
+	stale.CreatedAt = time.Now().Add(-2 * time.Hour)
+	if err := db.Create(stale).Error; err != nil {
+		t.Fatal("error creating stale deploy lock:", err)
+	}
+
+	holder, err := l.Acquire("teresa", "2", "other@luizalabs.com")
+	if err != nil {
+		t.Fatal("error acquiring deploy lock:", err)
+	}
+	if holder != nil {
+		t.Errorf("expected stale lock taken over, got held by %+v", holder)
+	}
+}
+
+func TestLockDeployHeld(t *testing.T) {
+	db, l := newTestDeployLocks(t, time.Hour)
+	defer db.Close()
+
+	ops := &DeployOperations{}
+	ops.SetDeployLocks(l)
+	if err := ops.lockDeploy("teresa", "1", "gopher@luizalabs.com"); err != nil {
+		t.Fatal("error locking deploy:", err)
+	}
+
+	err := ops.lockDeploy("teresa", "2", "other@luizalabs.com")
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gopher@luizalabs.com") || !strings.Contains(err.Error(), "Deploy 1 ") {
+		t.Errorf("expected the holder of the lock in %q", err)
+	}
+
+	ops.unlockDeploy("teresa", "1")
+	if err := ops.lockDeploy("teresa", "2", "other@luizalabs.com"); err != nil {
+		t.Errorf("expected lock acquired after unlock, got %v", err)
+	}
+}
+
+func TestRollbackDeployInProgress(t *testing.T) {
+	db, l := newTestDeployLocks(t, time.Hour)
+	defer db.Close()
+
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	ops.SetDeployLocks(l)
+	l.Acquire("teresa", "1", "other@luizalabs.com")
+
+	err := ops.Rollback(&database.User{Email: "gopher@luizalabs.com"}, "teresa", "", false)
+	if st, _ := status.FromError(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+}
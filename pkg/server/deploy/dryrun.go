@@ -0,0 +1,81 @@
+package deploy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/uid"
+)
+
+const noChangesMessage = "No changes to the deploy, service or ingress of the app"
+
+// dryRun returns the diff of the Deployment, Service and Ingress a deploy of
+// the app would apply against the live ones. Nothing is built, saved or
+// rolled out, the settings of teresa.yaml shared with the app pods (ingress
+// options, PDB and volumes) are left out
+func (ops *DeployOperations) dryRun(user *database.User, a *app.App, confFiles *DeployConfigFiles, imageBuild bool, opts *DeployOptions) (string, error) {
+	if opts.CanaryWeight != 0 || opts.BlueGreen || app.IsCronJob(a.ProcessType) {
+		return "", ErrDryRunNotSupported
+	}
+	a, err := ops.applyTeresaYaml(user, a, confFiles, true)
+	if err != nil {
+		return "", err
+	}
+
+	deployId := uid.New()
+	slugURL := fmt.Sprintf("deploys/%s/%s/out/slug.tgz", a.Name, deployId)
+	if imageBuild {
+		slugURL = ""
+		opts.Image = buildImageName(ops.opts.BuildRegistry, a.Name, deployId)
+	}
+	csp, err := spec.NewCloudSQLProxy(ops.opts.CloudSQLProxyImage, confFiles.TeresaYaml)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create the deploy")
+	}
+	deploySpec, _, err := ops.appDeploySpec(a, confFiles, slugURL, opts, csp)
+	if err != nil {
+		return "", err
+	}
+
+	var svcSpec *spec.Service
+	var vHosts []string
+	if app.IsWebApp(a.ProcessType) {
+		if svcSpec, err = ops.dryRunService(a, confFiles); err != nil {
+			return "", err
+		}
+		vHosts = strings.Split(a.VirtualHost, ",")
+	}
+
+	diff, err := ops.k8s.DeployDiff(deploySpec, svcSpec, vHosts)
+	if err != nil {
+		return "", teresa_errors.NewInternalServerError(err)
+	}
+	if diff == "" {
+		return noChangesMessage, nil
+	}
+	return diff, nil
+}
+
+// dryRunService returns the app service with the ports of teresa.yaml, a
+// new one if the app isn't exposed yet
+func (ops *DeployOperations) dryRunService(a *app.App, confFiles *DeployConfigFiles) (*spec.Service, error) {
+	svc, err := ops.k8s.Service(a.Name, a.Name)
+	if err != nil {
+		if !ops.k8s.IsNotFound(err) {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+		svc = spec.NewDefaultService(a.Name, ops.serviceType(a), a.Protocol)
+	}
+	var ports []spec.ExposedPort
+	if confFiles.TeresaYaml != nil {
+		ports = confFiles.TeresaYaml.Ports
+	}
+	svc.Ports = servicePorts(svc.Ports, ports)
+	return svc, nil
+}
@@ -0,0 +1,72 @@
+package deploy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	context "golang.org/x/net/context"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestDeployDryRun(t *testing.T) {
+	var testCases = []struct {
+		diff     string
+		expected string
+	}{
+		{"--- live/deployment/teresa\n", "--- live/deployment/teresa\n"},
+		{"", noChangesMessage},
+	}
+
+	for _, tc := range testCases {
+		tarBall, err := os.Open(filepath.Join("testdata", "teresaYaml.tgz"))
+		if err != nil {
+			t.Fatal("error getting tarBall:", err)
+		}
+		defer tarBall.Close()
+		fakeK8s := &fakeK8sOperations{diff: tc.diff}
+		ops := newTestDeployOps(fakeK8s)
+		u := &database.User{Email: "gopher@luizalabs.com"}
+
+		r, errChan := ops.Deploy(context.Background(), u, "teresa", tarBall, &DeployOptions{DryRun: true})
+		if r == nil {
+			t.Fatal("got unexpected error:", <-errChan)
+		}
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal("error reading the dry run:", err)
+		}
+
+		if got := string(b); got != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, got)
+		}
+		if fakeK8s.lastDeploySpec != nil {
+			t.Error("expected no deploy created on dry run")
+		}
+		if fakeK8s.diffDeploySpec == nil || fakeK8s.diffDeploySpec.Name != "teresa" {
+			t.Errorf("expected the app deploy diffed, got %v", fakeK8s.diffDeploySpec)
+		}
+		if fakeK8s.diffServiceSpec == nil {
+			t.Error("expected the web app service diffed")
+		}
+	}
+}
+
+func TestDeployDryRunNotSupported(t *testing.T) {
+	tarBall, err := os.Open(filepath.Join("testdata", "teresaYaml.tgz"))
+	if err != nil {
+		t.Fatal("error getting tarBall:", err)
+	}
+	defer tarBall.Close()
+	fakeK8s := &fakeK8sOperations{hasIngress: true}
+	ops := newTestDeployOps(fakeK8s)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	_, errChan := ops.Deploy(context.Background(), u, "teresa", tarBall, &DeployOptions{DryRun: true, BlueGreen: true})
+
+	if err := <-errChan; err != ErrDryRunNotSupported {
+		t.Errorf("expected ErrDryRunNotSupported, got %v", err)
+	}
+}
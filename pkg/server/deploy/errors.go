@@ -6,8 +6,41 @@ import (
 )
 
 var (
-	ErrPodRunFail            = status.Errorf(codes.Unknown, "Run command returned a non zero value")
-	ErrReleaseFail           = status.Errorf(codes.Unknown, "Release command returned a non zero value")
-	ErrInvalidTeresaYamlFile = status.Errorf(codes.InvalidArgument, "Invalid Teresa Yaml file")
-	ErrCronScheduleNotFound  = status.Errorf(codes.InvalidArgument, "Cron schedule not found in teresa yaml file")
+	ErrPodRunFail                = status.Errorf(codes.Unknown, "Run command returned a non zero value")
+	ErrReleaseFail               = status.Errorf(codes.Unknown, "Release command returned a non zero value")
+	ErrPreDeployHookFail         = status.Errorf(codes.Unknown, "Pre-deploy hook returned a non zero value")
+	ErrPostDeployHookFail        = status.Errorf(codes.Unknown, "Post-deploy hook returned a non zero value, the deploy was rolled out anyway")
+	ErrInvalidHooks              = status.Errorf(codes.InvalidArgument, "Invalid hooks in teresa yaml file")
+	ErrInvalidTeresaYamlFile     = status.Errorf(codes.InvalidArgument, "Invalid Teresa Yaml file")
+	ErrCronScheduleNotFound      = status.Errorf(codes.InvalidArgument, "Cron schedule not found in teresa yaml file")
+	ErrInvalidPorts              = status.Errorf(codes.InvalidArgument, "Invalid ports in teresa yaml file")
+	ErrInvalidScheduling         = status.Errorf(codes.InvalidArgument, "Invalid nodeSelector, tolerations, spread or affinity in teresa yaml file")
+	ErrInvalidSideCar            = status.Errorf(codes.InvalidArgument, "Invalid sidecar in teresa yaml file")
+	ErrInvalidInitContainer      = status.Errorf(codes.InvalidArgument, "Invalid init container in teresa yaml file")
+	ErrInvalidHealthCheck        = status.Errorf(codes.InvalidArgument, "Invalid health check in teresa yaml file")
+	ErrSchedulingNotAllowed      = status.Errorf(codes.PermissionDenied, "Node label or taint of teresa yaml file not allowed by the cluster")
+	ErrInvalidHostAliases        = status.Errorf(codes.InvalidArgument, "Invalid hostAliases in teresa yaml file")
+	ErrInvalidSecurityContext    = status.Errorf(codes.InvalidArgument, "Invalid security context in teresa yaml file")
+	ErrSecurityContextNotAllowed = status.Errorf(codes.PermissionDenied, "Security context of teresa yaml file loosens the one of the cluster")
+	ErrInvalidCanaryWeight       = status.Errorf(codes.InvalidArgument, "Invalid canary weight, it must be between 1 and 99")
+	ErrCanaryNeedsIngress        = status.Errorf(codes.FailedPrecondition, "Canary deploy requires a web app exposed by ingress without process types")
+	ErrCanaryNotFound            = status.Errorf(codes.NotFound, "Canary deploy not found")
+	ErrInvalidDeployStrategy     = status.Errorf(codes.InvalidArgument, "Canary and blue/green deploys can't be combined")
+	ErrBlueGreenNotSupported     = status.Errorf(codes.FailedPrecondition, "Blue/green deploy requires a web app without process types")
+	ErrBlueGreenSwitched         = status.Errorf(codes.FailedPrecondition, "Blue/green deploy already switched, wait for the cleanup of the old pods or switch back")
+	ErrRevisionNotFound          = status.Errorf(codes.NotFound, "Deploy revision not found")
+	ErrBlueGreenNotFound         = status.Errorf(codes.NotFound, "Green deploy not found")
+	ErrBlueGreenNotReady         = status.Errorf(codes.FailedPrecondition, "Green deploy isn't ready, wait for its pods before the switch")
+	ErrInvalidImage              = status.Errorf(codes.InvalidArgument, "Invalid image")
+	ErrImageDeployNotSupported   = status.Errorf(codes.FailedPrecondition, "Image deploy requires an app without process types that isn't a cron job")
+	ErrBuildNotFound             = status.Errorf(codes.NotFound, "Build not found")
+	ErrRegistryNotConfigured     = status.Errorf(codes.FailedPrecondition, "Cloud Native Buildpacks builds require a build registry on the cluster")
+	ErrApprovalTimeout           = status.Errorf(codes.DeadlineExceeded, "Deploy not approved in time")
+	ErrPendingDeployNotFound     = status.Errorf(codes.NotFound, "Deploy waiting for approval not found")
+	ErrSelfApproval              = status.Errorf(codes.PermissionDenied, "Deploy can't be approved by its author")
+	ErrAppLocked                 = status.Errorf(codes.FailedPrecondition, "App locked for deploys, see teresa app info")
+	ErrDeployFrozen              = status.Errorf(codes.FailedPrecondition, "Deploys of the app team are frozen, see teresa team list-freezes")
+	ErrRolledBack                = status.Errorf(codes.Aborted, "Deploy rolled back, its pods failed to get ready")
+	ErrDryRunNotSupported        = status.Errorf(codes.InvalidArgument, "Dry run isn't supported by canary, blue/green and cron job deploys")
+	ErrInvalidDeployMetadata     = status.Errorf(codes.InvalidArgument, "Invalid deploy metadata, the commit must be a git hash and the branch and message not too long")
 )
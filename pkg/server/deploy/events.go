@@ -0,0 +1,105 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
+)
+
+// Types of the events of the deploy stream, the lines of the deploy output
+// are sent as build-log until the build finishes and as log after it
+const (
+	EventBuildStarted    = "build-started"
+	EventBuildLog        = "build-log"
+	EventBuildFinished   = "build-finished"
+	EventLog             = "log"
+	EventRolloutProgress = "rollout-progress"
+	EventHealthCheck     = "healthcheck"
+	EventDone            = "done"
+	EventFailed          = "failed"
+)
+
+// eventMark starts the lines of the deploy output carrying an event
+const eventMark = "\x1e"
+
+type event struct {
+	Type  string `json:"type"`
+	Text  string `json:"text,omitempty"`
+	Ready int32  `json:"ready,omitempty"`
+	Total int32  `json:"total,omitempty"`
+}
+
+// writeEvent writes the event to the deploy output, streamDeploy sends it as
+// a typed response
+func writeEvent(w io.Writer, ev *event) {
+	b, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "%s%s\n", eventMark, b)
+}
+
+func writeProgress(w io.Writer, ready, total int32) {
+	writeEvent(w, &event{
+		Type:  EventRolloutProgress,
+		Text:  fmt.Sprintf("%d/%d pods ready", ready, total),
+		Ready: ready,
+		Total: total,
+	})
+}
+
+// deployEvents turns the lines of the deploy output into the responses of
+// the deploy stream
+type deployEvents struct {
+	building bool
+}
+
+func (de *deployEvents) response(line string) *dpb.DeployResponse {
+	if !strings.HasPrefix(line, eventMark) {
+		typ := EventLog
+		if de.building {
+			typ = EventBuildLog
+		}
+		return &dpb.DeployResponse{Type: typ, Text: line + "\n"}
+	}
+
+	ev := new(event)
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, eventMark)), ev); err != nil {
+		return &dpb.DeployResponse{Type: EventLog, Text: line + "\n"}
+	}
+	switch ev.Type {
+	case EventBuildStarted:
+		de.building = true
+	case EventBuildFinished:
+		de.building = false
+	}
+	return &dpb.DeployResponse{
+		Type:  ev.Type,
+		Text:  ev.Text + "\n",
+		Ready: ev.Ready,
+		Total: ev.Total,
+	}
+}
+
+func doneResponse() *dpb.DeployResponse {
+	return &dpb.DeployResponse{Type: EventDone, Text: "Deploy finished\n"}
+}
+
+// failedResponse carries the error message and its gRPC code
+func failedResponse(err error) *dpb.DeployResponse {
+	code, msg := codes.Unknown, err.Error()
+	if st, ok := status.FromError(err); ok {
+		code, msg = st.Code(), st.Message()
+	}
+	return &dpb.DeployResponse{
+		Type: EventFailed,
+		Text: msg + "\n",
+		Code: int32(code),
+	}
+}
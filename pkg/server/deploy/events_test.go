@@ -0,0 +1,82 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
+	"google.golang.org/grpc/codes"
+)
+
+type fakeDeployStream struct {
+	responses []*dpb.DeployResponse
+}
+
+func (f *fakeDeployStream) Send(resp *dpb.DeployResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func TestStreamDeployEvents(t *testing.T) {
+	out := new(bytes.Buffer)
+	out.WriteString("preparing\n")
+	writeEvent(out, &event{Type: EventBuildStarted, Text: "Building app teresa"})
+	out.WriteString("compiling\n")
+	writeEvent(out, &event{Type: EventBuildFinished, Text: "Build finished"})
+	writeProgress(out, 1, 2)
+	writeEvent(out, &event{Type: EventHealthCheck, Text: "All the pods are ready"})
+
+	s := &Service{options: &Options{KeepAliveTimeout: time.Minute}}
+	stream := new(fakeDeployStream)
+	if err := s.streamDeploy(stream, out, make(chan error, 1)); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	expected := []string{
+		EventLog,
+		EventBuildStarted,
+		EventBuildLog,
+		EventBuildFinished,
+		EventRolloutProgress,
+		EventHealthCheck,
+		EventDone,
+	}
+	if len(stream.responses) != len(expected) {
+		t.Fatalf("expected %d responses, got %v", len(expected), stream.responses)
+	}
+	for i, typ := range expected {
+		if got := stream.responses[i].Type; got != typ {
+			t.Errorf("expected %s, got %s", typ, got)
+		}
+	}
+	progress := stream.responses[4]
+	if progress.Ready != 1 || progress.Total != 2 || progress.Text != "1/2 pods ready\n" {
+		t.Errorf("expected 1/2 pods ready, got %v", progress)
+	}
+}
+
+func TestStreamDeployFailed(t *testing.T) {
+	errChan := make(chan error, 1)
+	errChan <- ErrRolledBack
+
+	s := &Service{options: &Options{KeepAliveTimeout: time.Minute}}
+	stream := new(fakeDeployStream)
+	if err := s.streamDeploy(stream, new(bytes.Buffer), errChan); err != ErrRolledBack {
+		t.Errorf("expected ErrRolledBack, got %v", err)
+	}
+
+	last := stream.responses[len(stream.responses)-1]
+	if last.Type != EventFailed || last.Code != int32(codes.Aborted) {
+		t.Errorf("expected a failed event with code %d, got %v", codes.Aborted, last)
+	}
+}
+
+func TestFailedResponseOfUnknownError(t *testing.T) {
+	resp := failedResponse(errors.New("boom"))
+
+	if resp.Code != int32(codes.Unknown) || resp.Text != "boom\n" {
+		t.Errorf("expected an unknown failure, got %v", resp)
+	}
+}
@@ -2,11 +2,14 @@ package deploy
 
 import (
 	"io"
+	"io/ioutil"
+	"strings"
 	"sync"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/lease"
 	context "golang.org/x/net/context"
 )
 
@@ -34,11 +37,81 @@ func (f *FakeOperations) List(user *database.User, appName string) ([]*ReplicaSe
 	return []*ReplicaSetListItem{}, nil
 }
 
-func (f *FakeOperations) Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, description string) (io.ReadCloser, <-chan error) {
+func (f *FakeOperations) Deploy(ctx context.Context, user *database.User, appName string, tarBall io.ReadSeeker, opts *DeployOptions) (io.ReadCloser, <-chan error) {
 	return nil, nil
 }
 
-func (f *FakeOperations) Rollback(user *database.User, appName, revision string) error {
+func (f *FakeOperations) DeployImage(user *database.User, appName, image string, teresaYaml []byte, opts *DeployOptions) (io.ReadCloser, <-chan error) {
+	errChan := make(chan error, 1)
+	if err := f.checkApp(user, appName); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	return ioutil.NopCloser(strings.NewReader("")), errChan
+}
+
+func (f *FakeOperations) PromoteBuild(user *database.User, srcApp, buildName, appName string, opts *DeployOptions) (io.ReadCloser, <-chan error) {
+	errChan := make(chan error, 1)
+	for _, name := range []string{srcApp, appName} {
+		if err := f.checkApp(user, name); err != nil {
+			errChan <- err
+			return nil, errChan
+		}
+	}
+	return ioutil.NopCloser(strings.NewReader("")), errChan
+}
+
+func (f *FakeOperations) Rollback(user *database.User, appName, revision string, force bool) error {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+
+	if _, found := f.Storage[appName]; !found {
+		return app.ErrNotFound
+	}
+
+	return nil
+}
+
+func (f *FakeOperations) Promote(user *database.User, appName string, force bool) error {
+	return f.checkApp(user, appName)
+}
+
+func (f *FakeOperations) Abort(user *database.User, appName string) error {
+	return f.checkApp(user, appName)
+}
+
+func (f *FakeOperations) Switch(user *database.User, appName string, force bool) error {
+	return f.checkApp(user, appName)
+}
+
+func (f *FakeOperations) RunBlueGreenCleanup(l lease.Leases, stop <-chan struct{}) {
+	<-stop
+}
+
+func (f *FakeOperations) SetApprovals(a Approvals) {}
+
+func (f *FakeOperations) SetPendingDeploys(p PendingDeploys) {}
+
+func (f *FakeOperations) SetFreezes(fr Freezes) {}
+
+func (f *FakeOperations) SetAppLocks(l AppLocks) {}
+
+func (f *FakeOperations) SetTeamEnv(te TeamEnv) {}
+
+func (f *FakeOperations) SetDeployLocks(l DeployLocks) {}
+
+func (f *FakeOperations) Approve(user *database.User, deployId string) error {
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (f *FakeOperations) checkApp(user *database.User, appName string) error {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
 
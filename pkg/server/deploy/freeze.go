@@ -0,0 +1,97 @@
+package deploy
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// Freezes gives the freeze windows of a team
+type Freezes interface {
+	FreezeWindows(teamName string) ([]*database.FreezeWindow, error)
+}
+
+// AppLocks gives the lock of an app, nil if it isn't locked
+type AppLocks interface {
+	Get(appName string) (*database.AppLock, error)
+}
+
+func (ops *DeployOperations) SetFreezes(f Freezes) {
+	ops.freezes = f
+}
+
+func (ops *DeployOperations) SetAppLocks(l AppLocks) {
+	ops.appLocks = l
+}
+
+// appLock returns the lock of the app, nil if it isn't locked
+func (ops *DeployOperations) appLock(appName string) (*database.AppLock, error) {
+	if ops.appLocks == nil {
+		return nil, nil
+	}
+	return ops.appLocks.Get(appName)
+}
+
+// activeFreeze returns the freeze window of the team active at t, if any
+func (ops *DeployOperations) activeFreeze(teamName string, t time.Time) (*database.FreezeWindow, error) {
+	if ops.freezes == nil {
+		return nil, nil
+	}
+	windows, err := ops.freezes.FreezeWindows(teamName)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range windows {
+		if !t.Before(w.StartsAt) && t.Before(w.EndsAt) {
+			return w, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkFreeze rejects the deploys (and rollbacks, promotions and switches)
+// of locked apps or of apps of a team in a freeze window. Admins can force
+// them, the override is logged
+func (ops *DeployOperations) checkFreeze(user *database.User, a *app.App, force bool) error {
+	lock, err := ops.appLock(a.Name)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	var frozenErr error
+	var reason string
+	if lock != nil {
+		frozenErr = ErrAppLocked
+		reason = fmt.Sprintf("app locked by %s: %s", lock.User, lock.Reason)
+	} else {
+		w, err := ops.activeFreeze(a.Team, time.Now())
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			return nil
+		}
+		frozenErr = ErrDeployFrozen
+		reason = fmt.Sprintf("freeze window %d of team %s: %s", w.ID, a.Team, w.Reason)
+	}
+
+	if !force {
+		return frozenErr
+	}
+	if !user.IsAdmin {
+		return auth.ErrPermissionDenied
+	}
+
+	log.WithFields(log.Fields{
+		"app":    a.Name,
+		"user":   user.Email,
+		"reason": reason,
+	}).Warn("Deploy freeze overridden")
+	return nil
+}
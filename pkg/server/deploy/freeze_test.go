@@ -0,0 +1,79 @@
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+type fakeFreezes struct {
+	windows []*database.FreezeWindow
+}
+
+func (f *fakeFreezes) FreezeWindows(teamName string) ([]*database.FreezeWindow, error) {
+	return f.windows, nil
+}
+
+type fakeAppLocks struct {
+	lock *database.AppLock
+}
+
+func (f *fakeAppLocks) Get(appName string) (*database.AppLock, error) {
+	return f.lock, nil
+}
+
+func TestCheckFreeze(t *testing.T) {
+	now := time.Now()
+	active := &database.FreezeWindow{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour), Reason: "holiday"}
+	past := &database.FreezeWindow{StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}
+	lock := &database.AppLock{AppName: "teresa", Reason: "incident", User: "gopher@luizalabs.com"}
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	admin := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+
+	var testCases = []struct {
+		lock     *database.AppLock
+		windows  []*database.FreezeWindow
+		user     *database.User
+		force    bool
+		expected error
+	}{
+		{nil, nil, user, false, nil},
+		{nil, []*database.FreezeWindow{past}, user, false, nil},
+		{nil, []*database.FreezeWindow{past, active}, user, false, ErrDeployFrozen},
+		{lock, nil, user, false, ErrAppLocked},
+		{lock, nil, user, true, auth.ErrPermissionDenied},
+		{lock, nil, admin, true, nil},
+		{nil, []*database.FreezeWindow{active}, admin, false, ErrDeployFrozen},
+		{nil, []*database.FreezeWindow{active}, admin, true, nil},
+	}
+
+	for i, tc := range testCases {
+		ops := newTestDeployOps(&fakeK8sOperations{})
+		ops.SetFreezes(&fakeFreezes{windows: tc.windows})
+		ops.SetAppLocks(&fakeAppLocks{lock: tc.lock})
+		a := &app.App{Name: "teresa", Team: "luizalabs"}
+
+		if err := ops.checkFreeze(tc.user, a, tc.force); err != tc.expected {
+			t.Errorf("expected %v, got %v for case %d", tc.expected, err, i)
+		}
+	}
+}
+
+func TestRollbackPromoteSwitchFrozen(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	ops.SetAppLocks(&fakeAppLocks{lock: &database.AppLock{AppName: "teresa"}})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if err := ops.Rollback(u, "teresa", "", false); err != ErrAppLocked {
+		t.Errorf("expected ErrAppLocked on rollback, got %v", err)
+	}
+	if err := ops.Promote(u, "teresa", false); err != ErrAppLocked {
+		t.Errorf("expected ErrAppLocked on promote, got %v", err)
+	}
+	if err := ops.Switch(u, "teresa", false); err != ErrAppLocked {
+		t.Errorf("expected ErrAppLocked on switch, got %v", err)
+	}
+}
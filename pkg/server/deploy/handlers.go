@@ -13,28 +13,47 @@ import (
 	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
 	"github.com/luizalabs/teresa/pkg/server/build"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/upload"
 )
 
 type Options struct {
-	KeepAliveTimeout     time.Duration `split_words:"true" default:"30s"`
-	RevisionHistoryLimit int           `split_words:"true" default:"5"`
-	SlugBuilderImage     string        `split_words:"true" default:"luizalabs/slugbuilder:v3.6.0"`
-	SlugRunnerImage      string        `split_words:"true" default:"luizalabs/slugrunner:v3.4.0"`
-	SlugStoreImage       string        `split_words:"true" default:"luizalabs/slugstore:v1.0.0"`
-	NginxImage           string        `split_words:"true" default:"nginx:1.13-alpine-perl"`
-	BuildLimitCPU        string        `split_words:"true" default:"800m"`
-	BuildLimitMemory     string        `split_words:"true" default:"1Gi"`
-	DefaultServiceType   string        `split_words:"true" default:"LoadBalancer"`
-	CloudSQLProxyImage   string        `split_words:"true" default:"gcr.io/cloudsql-docker/gce-proxy:1.11"`
+	KeepAliveTimeout       time.Duration `split_words:"true" default:"30s"`
+	RevisionHistoryLimit   int           `split_words:"true" default:"5"`
+	SlugBuilderImage       string        `split_words:"true" default:"luizalabs/slugbuilder:v3.6.0"`
+	SlugRunnerImage        string        `split_words:"true" default:"luizalabs/slugrunner:v3.4.0"`
+	SlugStoreImage         string        `split_words:"true" default:"luizalabs/slugstore:v1.0.0"`
+	NginxImage             string        `split_words:"true" default:"nginx:1.13-alpine-perl"`
+	BuildLimitCPU          string        `split_words:"true" default:"800m"`
+	BuildLimitMemory       string        `split_words:"true" default:"1Gi"`
+	DefaultServiceType     string        `split_words:"true" default:"LoadBalancer"`
+	CloudSQLProxyImage     string        `split_words:"true" default:"gcr.io/cloudsql-docker/gce-proxy:1.11"`
+	AllowedNodeLabels      []string      `split_words:"true"`
+	AllowedTolerations     []string      `split_words:"true"`
+	DefaultSpreadZone      string        `split_words:"true"`
+	DefaultSpreadHost      string        `split_words:"true"`
+	RunAsNonRoot           bool          `split_words:"true"`
+	RunAsUser              int64         `split_words:"true"`
+	ReadOnlyRootFilesystem bool          `split_words:"true"`
+	DropCapabilities       []string      `split_words:"true"`
+	BlueGreenGracePeriod   time.Duration `split_words:"true" default:"10m"`
+	BuildRegistry          string        `split_words:"true"`
+	BuildRegistryConfig    string        `split_words:"true"`
+	KanikoImage            string        `split_words:"true" default:"gcr.io/kaniko-project/executor:v0.9.0"`
+	CNBBuilderImage        string        `split_words:"true" default:"paketobuildpacks/builder:base"`
+	ApprovalTimeout        time.Duration `split_words:"true" default:"30m"`
+	AutoRollbackWindow     time.Duration `split_words:"true" default:"5m"`
+	LockTTL                time.Duration `split_words:"true" default:"2h"`
 }
 
 type Service struct {
 	ops     Operations
 	options *Options
+	uploads upload.Operations
 }
 
 func (s *Service) Make(stream dpb.Deploy_MakeServer) error {
-	var appName, description string
+	var appName, checksum string
+	opts := new(DeployOptions)
 	content := new(bytes.Buffer)
 
 	ctx := stream.Context()
@@ -50,44 +69,122 @@ func (s *Service) Make(stream dpb.Deploy_MakeServer) error {
 		}
 		if info := in.GetInfo(); info != nil {
 			appName = info.App
-			description = info.Description
+			opts.Description = info.Description
+			opts.CanaryWeight = info.CanaryWeight
+			opts.BlueGreen = info.BlueGreen
+			opts.Commit = info.Commit
+			opts.Branch = info.Branch
+			opts.Message = info.Message
+			opts.Force = info.Force
+			opts.DryRun = info.DryRun
+			checksum = info.UploadChecksum
 		}
 		if data := in.GetFile(); data != nil {
 			content.Write(data.Chunk)
 		}
 	}
 
-	rs := bytes.NewReader(content.Bytes())
-	rc, errChan := s.ops.Deploy(ctx, u, appName, rs, description)
+	rs, err := upload.Tarball(s.uploads, u, appName, checksum, content.Bytes())
+	if err != nil {
+		return deployFailed(stream, err)
+	}
+	rc, errChan := s.ops.Deploy(ctx, u, appName, rs, opts)
+	if rc == nil {
+		return deployFailed(stream, <-errChan)
+	}
+	defer rc.Close()
+	upload.Discard(s.uploads, appName, checksum)
+
+	return s.streamDeploy(stream, rc, errChan)
+}
+
+// Image deploys the image of the request, streaming the output of the
+// rollout like Make
+func (s *Service) Image(req *dpb.ImageRequest, stream dpb.Deploy_ImageServer) error {
+	u := stream.Context().Value("user").(*database.User)
+	opts := &DeployOptions{
+		Description: req.Description,
+		Commit:      req.Commit,
+		Branch:      req.Branch,
+		Message:     req.Message,
+		Force:       req.Force,
+	}
+
+	rc, errChan := s.ops.DeployImage(u, req.AppName, req.Image, req.TeresaYaml, opts)
 	if rc == nil {
-		return <-errChan
+		return deployFailed(stream, <-errChan)
 	}
 	defer rc.Close()
 
+	return s.streamDeploy(stream, rc, errChan)
+}
+
+func (s *Service) PromoteBuild(req *dpb.PromoteBuildRequest, stream dpb.Deploy_PromoteBuildServer) error {
+	u := stream.Context().Value("user").(*database.User)
+	opts := &DeployOptions{
+		Description:  req.Description,
+		CanaryWeight: req.CanaryWeight,
+		BlueGreen:    req.BlueGreen,
+		Force:        req.Force,
+	}
+
+	rc, errChan := s.ops.PromoteBuild(u, req.AppName, req.BuildName, req.ToApp, opts)
+	if rc == nil {
+		return deployFailed(stream, <-errChan)
+	}
+	defer rc.Close()
+
+	return s.streamDeploy(stream, rc, errChan)
+}
+
+type deployResponseSender interface {
+	Send(*dpb.DeployResponse) error
+}
+
+// streamDeploy sends the lines of the deploy output as typed events,
+// keeping the stream alive while there's none. The stream ends with a done
+// or failed event
+func (s *Service) streamDeploy(stream deployResponseSender, rc io.Reader, errChan <-chan error) error {
 	deployMsgs, deployErrCh := goutil.LineGenerator(rc)
-	var msg string
+	events := new(deployEvents)
+	var resp *dpb.DeployResponse
 
 	for {
 		select {
 		case <-time.After(s.options.KeepAliveTimeout):
-			msg = build.KeepAliveMessage
+			resp = &dpb.DeployResponse{Type: EventLog, Text: build.KeepAliveMessage + "\n"}
 		case err := <-errChan:
-			return err
+			return deployFailed(stream, err)
 		case err := <-deployErrCh:
-			return err
+			return deployFailed(stream, err)
 		case m, ok := <-deployMsgs:
 			if !ok {
-				return nil
+				// the deploy error, if any, is sent before its output ends
+				select {
+				case err := <-errChan:
+					return deployFailed(stream, err)
+				default:
+				}
+				return stream.Send(doneResponse())
 			}
-			msg = m
+			resp = events.response(m)
 		}
 
-		if err := stream.Send(&dpb.DeployResponse{Text: msg + "\n"}); err != nil {
+		if err := stream.Send(resp); err != nil {
 			return err
 		}
 	}
 }
 
+// deployFailed sends the failed event of the error, returning it
+func deployFailed(stream deployResponseSender, err error) error {
+	if err == nil {
+		return nil
+	}
+	stream.Send(failedResponse(err))
+	return err
+}
+
 func (s *Service) List(ctx context.Context, req *dpb.ListRequest) (*dpb.ListResponse, error) {
 	user := ctx.Value("user").(*database.User)
 
@@ -102,7 +199,7 @@ func (s *Service) List(ctx context.Context, req *dpb.ListRequest) (*dpb.ListResp
 func (s *Service) Rollback(ctx context.Context, req *dpb.RollbackRequest) (*dpb.Empty, error) {
 	user := ctx.Value("user").(*database.User)
 
-	err := s.ops.Rollback(user, req.AppName, req.Revision)
+	err := s.ops.Rollback(user, req.AppName, req.Revision, req.Force)
 	if err != nil {
 		return nil, err
 	}
@@ -110,10 +207,55 @@ func (s *Service) Rollback(ctx context.Context, req *dpb.RollbackRequest) (*dpb.
 	return &dpb.Empty{}, nil
 }
 
+func (s *Service) Promote(ctx context.Context, req *dpb.PromoteRequest) (*dpb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Promote(user, req.AppName, req.Force); err != nil {
+		return nil, err
+	}
+
+	return &dpb.Empty{}, nil
+}
+
+func (s *Service) Abort(ctx context.Context, req *dpb.AbortRequest) (*dpb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Abort(user, req.AppName); err != nil {
+		return nil, err
+	}
+
+	return &dpb.Empty{}, nil
+}
+
+func (s *Service) Approve(ctx context.Context, req *dpb.ApproveRequest) (*dpb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Approve(user, req.DeployId); err != nil {
+		return nil, err
+	}
+
+	return &dpb.Empty{}, nil
+}
+
+func (s *Service) Switch(ctx context.Context, req *dpb.SwitchRequest) (*dpb.Empty, error) {
+	user := ctx.Value("user").(*database.User)
+
+	if err := s.ops.Switch(user, req.AppName, req.Force); err != nil {
+		return nil, err
+	}
+
+	return &dpb.Empty{}, nil
+}
+
 func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	dpb.RegisterDeployServer(grpcServer, s)
 }
 
+// SetUploads sets the uploads the deploys can take the tarball from
+func (s *Service) SetUploads(uploads upload.Operations) {
+	s.uploads = uploads
+}
+
 func NewService(ops Operations, options *Options) *Service {
 	return &Service{ops: ops, options: options}
 }
@@ -84,3 +84,50 @@ func TestRollbackPermissionDenied(t *testing.T) {
 		t.Errorf("expected auth.ErrPermissionDenied, got %s", err)
 	}
 }
+
+func TestPromoteSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.(*FakeOperations).Storage[name] = true
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	srv := NewService(fake, nil)
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := srv.Promote(ctx, &dpb.PromoteRequest{AppName: name}); err != nil {
+		t.Error("got error on promote: ", err)
+	}
+}
+
+func TestAbortPermissionDenied(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.(*FakeOperations).Storage[name] = true
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	srv := NewService(fake, nil)
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := srv.Abort(ctx, &dpb.AbortRequest{AppName: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected auth.ErrPermissionDenied, got %s", err)
+	}
+}
+
+func TestSwitchAppNotFound(t *testing.T) {
+	fake := NewFakeOperations()
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	srv := NewService(fake, nil)
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := srv.Switch(ctx, &dpb.SwitchRequest{AppName: "teresa"}); err != app.ErrNotFound {
+		t.Errorf("expected app.ErrNotFound, got %s", err)
+	}
+}
+
+func TestApprovePermissionDenied(t *testing.T) {
+	user := &database.User{Email: "bad-user@luizalabs.com"}
+	srv := NewService(NewFakeOperations(), nil)
+	ctx := context.WithValue(context.Background(), "user", user)
+
+	if _, err := srv.Approve(ctx, &dpb.ApproveRequest{DeployId: "1"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected auth.ErrPermissionDenied, got %s", err)
+	}
+}
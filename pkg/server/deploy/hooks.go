@@ -0,0 +1,101 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+const (
+	hookPreDeploy  = "pre-deploy"
+	hookPostDeploy = "post-deploy"
+	maxHooks       = 10
+)
+
+// validateHooks checks the hooks of teresa.yaml, each one is a non blank
+// shell command
+func validateHooks(h *spec.Hooks) bool {
+	if h == nil {
+		return true
+	}
+	if len(h.PreDeploy) > maxHooks || len(h.PostDeploy) > maxHooks {
+		return false
+	}
+	for _, cmd := range append(h.PreDeploy, h.PostDeploy...) {
+		if strings.TrimSpace(cmd) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func deployHooks(ty *spec.TeresaYaml, kind string) []string {
+	if ty == nil || ty.Hooks == nil {
+		return nil
+	}
+	if kind == hookPreDeploy {
+		return ty.Hooks.PreDeploy
+	}
+	return ty.Hooks.PostDeploy
+}
+
+// runHooks runs the hooks of kind one after the other from the slug or the
+// image of the deploy, stopping at the first failing one
+func (ops *DeployOperations) runHooks(kind string, a *app.App, confFiles *DeployConfigFiles, slugURL, image, deployId string, csp *spec.CloudSQLProxy, w io.Writer) error {
+	hookErr := ErrPreDeployHookFail
+	if kind == hookPostDeploy {
+		hookErr = ErrPostDeployHookFail
+	}
+
+	for i, cmd := range deployHooks(confFiles.TeresaYaml, kind) {
+		podName := fmt.Sprintf("%s-%s-%s-%d", kind, a.Name, deployId, i)
+		command := []string{"sh", "-c", cmd}
+		podBuilder := spec.NewRunnerPodBuilder(podName, ops.opts.SlugRunnerImage, ops.opts.SlugStoreImage).
+			ForApp(a).
+			WithSlug(slugURL).
+			WithLimits(ops.opts.BuildLimitCPU, ops.opts.BuildLimitMemory).
+			WithStorage(ops.fileStorage).
+			WithArgs(command).
+			WithCloudSQLProxySideCar(csp)
+		if image != "" {
+			podBuilder = podBuilder.WithImage(image, command)
+		}
+
+		writeEvent(w, &event{Type: EventLog, Text: fmt.Sprintf("Running %s hook: %s", kind, cmd)})
+		if err := ops.podRun(context.Background(), podBuilder.Build(), w); err != nil {
+			log.WithError(err).WithField("id", deployId).Errorf("Running %s hook %s in app %s", kind, cmd, a.Name)
+			if err == ErrPodRunFail {
+				return hookErr
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// runPostDeployHooks runs the post-deploy hooks once the rollout watched
+// in rolloutErr finishes successfully, the deploy is already rolled out so
+// a failing one just flags it
+func (ops *DeployOperations) runPostDeployHooks(a *app.App, confFiles *DeployConfigFiles, slugURL string, opts *DeployOptions, deployId string, w io.Writer, rolloutErr <-chan error, errChan chan<- error) {
+	select {
+	case err := <-rolloutErr:
+		errChan <- err
+		return
+	default:
+	}
+
+	csp, err := spec.NewCloudSQLProxy(ops.opts.CloudSQLProxyImage, confFiles.TeresaYaml)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	if err := ops.runHooks(hookPostDeploy, a, confFiles, slugURL, opts.Image, deployId, csp, w); err != nil {
+		errChan <- err
+	}
+}
@@ -0,0 +1,106 @@
+package deploy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+)
+
+func TestValidateHooks(t *testing.T) {
+	var testCases = []struct {
+		hooks    *spec.Hooks
+		expected bool
+	}{
+		{nil, true},
+		{&spec.Hooks{PreDeploy: []string{"python manage.py migrate"}}, true},
+		{&spec.Hooks{PostDeploy: []string{"./notify.sh", "curl -X POST $WEBHOOK"}}, true},
+		{&spec.Hooks{PreDeploy: []string{" "}}, false},
+		{&spec.Hooks{PostDeploy: []string{""}}, false},
+		{&spec.Hooks{PreDeploy: make([]string, maxHooks+1)}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := validateHooks(tc.hooks); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.hooks)
+		}
+	}
+}
+
+func newTestHooksOps(commandErr error) *DeployOperations {
+	fakeExec := exec.NewFakeOperations()
+	fakeExec.ExpectedErr = commandErr
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		&fakeK8sOperations{},
+		storage.NewFake(),
+		fakeExec,
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	return ops.(*DeployOperations)
+}
+
+func TestRunHooks(t *testing.T) {
+	confFiles := &DeployConfigFiles{
+		TeresaYaml: &spec.TeresaYaml{Hooks: &spec.Hooks{
+			PreDeploy:  []string{"python manage.py migrate"},
+			PostDeploy: []string{"./notify.sh"},
+		}},
+	}
+	var testCases = []struct {
+		kind        string
+		commandErr  error
+		expectedErr error
+	}{
+		{hookPreDeploy, nil, nil},
+		{hookPreDeploy, exec.ErrNonZeroExitCode, ErrPreDeployHookFail},
+		{hookPostDeploy, exec.ErrNonZeroExitCode, ErrPostDeployHookFail},
+		{hookPostDeploy, exec.ErrTimeout, exec.ErrTimeout},
+	}
+
+	for _, tc := range testCases {
+		ops := newTestHooksOps(tc.commandErr)
+		w := new(bytes.Buffer)
+		err := ops.runHooks(tc.kind, &app.App{Name: "teresa"}, confFiles, "/slug.tgz", "", "123456", nil, w)
+		if err != tc.expectedErr {
+			t.Errorf("expected %v, got %v", tc.expectedErr, err)
+		}
+		if !strings.Contains(w.String(), "Running "+tc.kind+" hook") {
+			t.Errorf("expected the hook in the deploy output, got %q", w.String())
+		}
+	}
+}
+
+func TestRunHooksWithoutHooks(t *testing.T) {
+	ops := newTestHooksOps(exec.ErrNonZeroExitCode)
+	confFiles := &DeployConfigFiles{TeresaYaml: &spec.TeresaYaml{}}
+
+	err := ops.runHooks(hookPreDeploy, &app.App{Name: "teresa"}, confFiles, "/slug.tgz", "", "123456", nil, new(bytes.Buffer))
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestRunPostDeployHooksRolloutFailed(t *testing.T) {
+	ops := newTestHooksOps(nil)
+	confFiles := &DeployConfigFiles{
+		TeresaYaml: &spec.TeresaYaml{Hooks: &spec.Hooks{PostDeploy: []string{"./notify.sh"}}},
+	}
+	rolloutErr, errChan := make(chan error, 1), make(chan error, 1)
+	rolloutErr <- ErrRolledBack
+	w := new(bytes.Buffer)
+
+	ops.runPostDeployHooks(&app.App{Name: "teresa"}, confFiles, "/slug.tgz", &DeployOptions{}, "123456", w, rolloutErr, errChan)
+	if err := <-errChan; err != ErrRolledBack {
+		t.Errorf("expected %v, got %v", ErrRolledBack, err)
+	}
+	if strings.Contains(w.String(), "hook") {
+		t.Errorf("expected no hook run, got %q", w.String())
+	}
+}
@@ -0,0 +1,26 @@
+package deploy
+
+import (
+	"net"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/validation"
+)
+
+// validateHostAliases checks the hostAliases of teresa.yaml, each IP must
+// have at least one hostname and be listed once
+func validateHostAliases(aliases []spec.HostAlias) bool {
+	ips := make(map[string]bool)
+	for _, a := range aliases {
+		if net.ParseIP(a.IP) == nil || ips[a.IP] || len(a.Hostnames) == 0 {
+			return false
+		}
+		for _, h := range a.Hostnames {
+			if !validation.IsHostname(h) {
+				return false
+			}
+		}
+		ips[a.IP] = true
+	}
+	return true
+}
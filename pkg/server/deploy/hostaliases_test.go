@@ -0,0 +1,34 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+func TestValidateHostAliases(t *testing.T) {
+	var testCases = []struct {
+		aliases  []spec.HostAlias
+		expected bool
+	}{
+		{nil, true},
+		{[]spec.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"legacy-db", "db.legacy.internal"}}}, true},
+		{[]spec.HostAlias{{IP: "fe80::1", Hostnames: []string{"legacy-db"}}}, true},
+		{[]spec.HostAlias{{IP: "10.0.0", Hostnames: []string{"legacy-db"}}}, false},
+		{[]spec.HostAlias{{IP: "10.0.0.1"}}, false},
+		{[]spec.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"Legacy_DB"}}}, false},
+		{
+			[]spec.HostAlias{
+				{IP: "10.0.0.1", Hostnames: []string{"legacy-db"}},
+				{IP: "10.0.0.1", Hostnames: []string{"legacy-cache"}},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := validateHostAliases(tc.aliases); got != tc.expected {
+			t.Errorf("expected %t, got %t for %v", tc.expected, got, tc.aliases)
+		}
+	}
+}
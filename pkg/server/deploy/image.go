@@ -0,0 +1,140 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/uid"
+	"github.com/luizalabs/teresa/pkg/server/validation"
+)
+
+// validateImageApp checks that the app runs from a single deploy, images
+// have no Procfile to start process types or cron jobs from
+func validateImageApp(a *app.App) error {
+	if app.IsCronJob(a.ProcessType) || len(a.Processes) > 0 {
+		return ErrImageDeployNotSupported
+	}
+	return nil
+}
+
+func validateImageDeploy(a *app.App, image string) error {
+	if !validation.IsImage(image) {
+		return ErrInvalidImage
+	}
+	return validateImageApp(a)
+}
+
+// buildImageName is the image built from the Dockerfile or the buildpacks
+// of a deploy
+func buildImageName(registry, appName, deployId string) string {
+	return fmt.Sprintf("%s/%s:%s", strings.TrimSuffix(registry, "/"), appName, deployId)
+}
+
+// DeployImage rolls out the image built elsewhere, skipping the build of a
+// slug. The app container runs the command of teresaYaml, if any
+func (ops *DeployOperations) DeployImage(user *database.User, appName, image string, teresaYaml []byte, opts *DeployOptions) (io.ReadCloser, <-chan error) {
+	errChan := make(chan error, 1)
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	opts.Author = user.Email
+	opts.Image = image
+	if err := validateMetadata(opts); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	if err := validateImageDeploy(a, image); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	teamName, err := ops.appOps.TeamName(appName)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	a.Team = teamName
+	if err := ops.setTeamEnvVars(a); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	if err := ops.checkFreeze(user, a, opts.Force); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	if err := ops.appOps.CheckTeamQuota(a); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	confFiles := new(DeployConfigFiles)
+	if len(teresaYaml) > 0 {
+		if err := confFiles.fillTeresaYaml(bytes.NewReader(teresaYaml), a.Name); err != nil {
+			errChan <- teresa_errors.New(ErrInvalidTeresaYamlFile, err)
+			return nil, errChan
+		}
+	}
+	if err := ops.validateConfFiles(confFiles); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	deployId := uid.New()
+	if err := ops.lockDeploy(appName, deployId, opts.Author); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+		defer ops.unlockDeploy(appName, deployId)
+		if err := ops.waitApproval(a, opts.Author, deployId, w); err != nil {
+			errChan <- err
+			return
+		}
+		a, err := ops.applyTeresaYaml(user, a, confFiles, false)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		if err := ops.createOrUpdateDeploy(a, confFiles, w, "", opts, deployId); err != nil {
+			errChan <- err
+			return
+		}
+		if err := ops.appOps.SaveApp(a, user.Email); err != nil {
+			log.WithError(err).WithField("id", deployId).Errorf("Saving last deploy user (%s) of app %s", user.Name, appName)
+		}
+		rolloutErr := make(chan error, 1)
+		ops.watchDeploy(appName, appName, w, rolloutErr)
+		ops.runPostDeployHooks(a, confFiles, "", opts, deployId, w, rolloutErr, errChan)
+	}()
+	return r, errChan
+}
+
+// buildsImage tells if the deploy builds an image instead of a slug, the
+// Dockerfile is ignored unless the cluster has a registry to push the images
+// to but Cloud Native Buildpacks builds can't do without it
+func (ops *DeployOperations) buildsImage(a *app.App, confFiles *DeployConfigFiles) (bool, error) {
+	if a.Builder == app.BuilderCNB && ops.opts.BuildRegistry == "" {
+		return false, ErrRegistryNotConfigured
+	}
+	if a.Builder != app.BuilderCNB && (!confFiles.Dockerfile || ops.opts.BuildRegistry == "") {
+		return false, nil
+	}
+	if err := validateImageApp(a); err != nil {
+		return false, err
+	}
+	return true, nil
+}
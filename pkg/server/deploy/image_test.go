@@ -0,0 +1,135 @@
+package deploy
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestValidateImageDeploy(t *testing.T) {
+	var testCases = []struct {
+		app      *app.App
+		image    string
+		expected error
+	}{
+		{&app.App{ProcessType: app.ProcessTypeWeb}, "luizalabs/teresa:0.30.0", nil},
+		{&app.App{ProcessType: "worker"}, "gcr.io/project/worker:v1", nil},
+		{&app.App{ProcessType: app.ProcessTypeWeb}, "luizalabs/teresa:", ErrInvalidImage},
+		{&app.App{ProcessType: app.ProcessTypeWeb}, "", ErrInvalidImage},
+		{&app.App{ProcessType: "cron"}, "luizalabs/teresa", ErrImageDeployNotSupported},
+		{
+			&app.App{ProcessType: app.ProcessTypeWeb, Processes: map[string]int32{"worker": 1}},
+			"luizalabs/teresa",
+			ErrImageDeployNotSupported,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := validateImageDeploy(tc.app, tc.image); got != tc.expected {
+			t.Errorf("expected %v, got %v for %s", tc.expected, got, tc.image)
+		}
+	}
+}
+
+func TestBuildImageName(t *testing.T) {
+	var testCases = []struct {
+		registry string
+		expected string
+	}{
+		{"gcr.io/project", "gcr.io/project/teresa:abc"},
+		{"gcr.io/project/", "gcr.io/project/teresa:abc"},
+	}
+
+	for _, tc := range testCases {
+		if got := buildImageName(tc.registry, "teresa", "abc"); got != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, got)
+		}
+	}
+}
+
+func TestBuildsImage(t *testing.T) {
+	web := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb}
+	cnb := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb, Builder: app.BuilderCNB}
+	cron := &app.App{Name: "teresa", ProcessType: "cron", Builder: app.BuilderCNB}
+	var testCases = []struct {
+		app        *app.App
+		dockerfile bool
+		registry   string
+		expected   bool
+		err        error
+	}{
+		{web, false, "gcr.io/project", false, nil},
+		{web, true, "", false, nil},
+		{web, true, "gcr.io/project", true, nil},
+		{cnb, false, "gcr.io/project", true, nil},
+		{cnb, false, "", false, ErrRegistryNotConfigured},
+		{cron, false, "gcr.io/project", false, ErrImageDeployNotSupported},
+	}
+
+	for _, tc := range testCases {
+		ops := newTestDeployOps(new(fakeK8sOperations))
+		ops.opts.BuildRegistry = tc.registry
+
+		got, err := ops.buildsImage(tc.app, &DeployConfigFiles{Dockerfile: tc.dockerfile})
+		if got != tc.expected || err != tc.err {
+			t.Errorf("expected %v and %v, got %v and %v for %+v", tc.expected, tc.err, got, err, tc)
+		}
+	}
+}
+
+func TestDeployImage(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+	ty := []byte("command: [\"./server\", \"--port\", \"5000\"]\n")
+
+	r, errChan := ops.DeployImage(u, "teresa", "luizalabs/teresa:0.30.0", ty, &DeployOptions{Description: "test"})
+	if r == nil {
+		t.Fatal("got unexpected error:", <-errChan)
+	}
+	defer r.Close()
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal("got unexpected error reading the deploy output:", err)
+	}
+	select {
+	case err := <-errChan:
+		t.Fatal("got unexpected error:", err)
+	default:
+	}
+
+	ds := fakeK8s.lastDeploySpec
+	c := ds.Containers[0]
+	if c.Image != "luizalabs/teresa:0.30.0" {
+		t.Errorf("expected luizalabs/teresa:0.30.0, got %s", c.Image)
+	}
+	if expected := []string{"./server", "--port", "5000"}; !reflect.DeepEqual(c.Command, expected) {
+		t.Errorf("expected %v, got %v", expected, c.Command)
+	}
+	if ds.SlugURL != "" || len(ds.Pod.InitContainers) != 0 {
+		t.Errorf("expected no slug, got %s and %d init containers", ds.SlugURL, len(ds.Pod.InitContainers))
+	}
+}
+
+func TestDeployImageErrPermissionDenied(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	u := &database.User{Email: "bad-user@luizalabs.com"}
+
+	_, errChan := ops.DeployImage(u, "teresa", "luizalabs/teresa", nil, &DeployOptions{})
+	if err := <-errChan; err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestDeployImageErrInvalidTeresaYaml(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	_, errChan := ops.DeployImage(u, "teresa", "luizalabs/teresa", []byte("command: ["), &DeployOptions{})
+	if err := <-errChan; err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,29 @@
+package deploy
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+// appLifecycle converts the lifecycle of teresa.yaml, no preStop hook means
+// no drain
+func appLifecycle(lc *spec.Lifecycle) *app.Lifecycle {
+	alc := &app.Lifecycle{TerminationGracePeriodSeconds: int32(lc.TerminationGracePeriodSeconds)}
+	if lc.PreStop != nil {
+		alc.DrainTimeoutSeconds = int32(lc.PreStop.DrainTimeoutSeconds)
+	}
+	return alc
+}
+
+// deployLifecycle converts the lifecycle of the app, nil keeps the one of
+// teresa.yaml (or the default one)
+func deployLifecycle(lc *app.Lifecycle) *spec.Lifecycle {
+	if lc == nil {
+		return nil
+	}
+	slc := &spec.Lifecycle{TerminationGracePeriodSeconds: int(lc.TerminationGracePeriodSeconds)}
+	if lc.DrainTimeoutSeconds > 0 {
+		slc.PreStop = &spec.PreStop{DrainTimeoutSeconds: int(lc.DrainTimeoutSeconds)}
+	}
+	return slc
+}
@@ -0,0 +1,96 @@
+package deploy
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+)
+
+func TestValidateTeresaYamlLifecycle(t *testing.T) {
+	var testCases = []struct {
+		lc      *spec.Lifecycle
+		isValid bool
+	}{
+		{&spec.Lifecycle{PreStop: &spec.PreStop{DrainTimeoutSeconds: 30}}, true},
+		{&spec.Lifecycle{PreStop: &spec.PreStop{DrainTimeoutSeconds: 31}}, false},
+		{&spec.Lifecycle{PreStop: &spec.PreStop{DrainTimeoutSeconds: 120}, TerminationGracePeriodSeconds: 150}, true},
+		{&spec.Lifecycle{PreStop: &spec.PreStop{DrainTimeoutSeconds: 120}, TerminationGracePeriodSeconds: 60}, false},
+		{&spec.Lifecycle{TerminationGracePeriodSeconds: app.MaxTerminationGracePeriodSeconds + 1}, false},
+		{&spec.Lifecycle{TerminationGracePeriodSeconds: -1}, false},
+	}
+
+	for _, tc := range testCases {
+		err := validateTeresaYaml(&spec.TeresaYaml{Lifecycle: tc.lc})
+		if (err == nil) != tc.isValid {
+			t.Errorf("expected valid %t, got %v for %+v", tc.isValid, err, tc.lc)
+		}
+	}
+}
+
+func TestAppLifecycle(t *testing.T) {
+	lc := &spec.Lifecycle{
+		PreStop:                       &spec.PreStop{DrainTimeoutSeconds: 20},
+		TerminationGracePeriodSeconds: 60,
+	}
+	expected := &app.Lifecycle{DrainTimeoutSeconds: 20, TerminationGracePeriodSeconds: 60}
+
+	if got := appLifecycle(lc); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := appLifecycle(&spec.Lifecycle{}); got.DrainTimeoutSeconds != 0 {
+		t.Errorf("expected no drain, got %d", got.DrainTimeoutSeconds)
+	}
+}
+
+func TestCreateDeployLifecycle(t *testing.T) {
+	a := &app.App{
+		Name:        "teresa",
+		ProcessType: "web",
+		Processes:   map[string]int32{"worker": 1},
+		Lifecycle:   &app.Lifecycle{DrainTimeoutSeconds: 45, TerminationGracePeriodSeconds: 120},
+	}
+	conf := &DeployConfigFiles{
+		Procfile: map[string]string{
+			"web":    "python app.py",
+			"worker": "python worker.py",
+		},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		conf,
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	expected := &spec.Lifecycle{
+		PreStop:                       &spec.PreStop{DrainTimeoutSeconds: 45},
+		TerminationGracePeriodSeconds: 120,
+	}
+	for _, ds := range fakeK8s.deploySpecs {
+		if !reflect.DeepEqual(ds.Lifecycle, expected) {
+			t.Errorf("expected %v for %s, got %v", expected, ds.Name, ds.Lifecycle)
+		}
+	}
+}
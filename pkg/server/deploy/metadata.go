@@ -0,0 +1,22 @@
+package deploy
+
+import "regexp"
+
+const (
+	maxBranchLength  = 255
+	maxMessageLength = 4096
+)
+
+var commitRegexp = regexp.MustCompile(`^[0-9a-f]{4,64}$`)
+
+// validateMetadata checks the git metadata of the deploy, all of it is
+// optional. The commit is an abbreviated or full hash
+func validateMetadata(opts *DeployOptions) error {
+	if opts.Commit != "" && !commitRegexp.MatchString(opts.Commit) {
+		return ErrInvalidDeployMetadata
+	}
+	if len(opts.Branch) > maxBranchLength || len(opts.Message) > maxMessageLength {
+		return ErrInvalidDeployMetadata
+	}
+	return nil
+}
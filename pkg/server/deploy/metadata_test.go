@@ -0,0 +1,60 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/test"
+)
+
+func TestValidateMetadata(t *testing.T) {
+	var testCases = []struct {
+		opts     *DeployOptions
+		expected error
+	}{
+		{&DeployOptions{}, nil},
+		{&DeployOptions{Commit: "8a3f2c1", Branch: "feature/checkout", Message: "Fix checkout"}, nil},
+		{&DeployOptions{Commit: "8a3f2c1e5b6d7f8091a2b3c4d5e6f708192a3b4c"}, nil},
+		{&DeployOptions{Commit: "8A3F2C1"}, ErrInvalidDeployMetadata},
+		{&DeployOptions{Commit: "abc"}, ErrInvalidDeployMetadata},
+		{&DeployOptions{Commit: "master"}, ErrInvalidDeployMetadata},
+		{&DeployOptions{Branch: strings.Repeat("b", maxBranchLength+1)}, ErrInvalidDeployMetadata},
+		{&DeployOptions{Message: strings.Repeat("m", maxMessageLength+1)}, ErrInvalidDeployMetadata},
+	}
+
+	for _, tc := range testCases {
+		if got := validateMetadata(tc.opts); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.opts)
+		}
+	}
+}
+
+func TestDeployErrInvalidDeployMetadata(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	u := &database.User{Email: "gopher@luizalabs.com"}
+	opts := &DeployOptions{Commit: "not a hash"}
+
+	_, errChan := ops.Deploy(context.Background(), u, "teresa", &test.FakeReadSeeker{}, opts)
+	if err := <-errChan; err != ErrInvalidDeployMetadata {
+		t.Errorf("expected ErrInvalidDeployMetadata, got %v", err)
+	}
+}
+
+func TestCreateDeployGitMetadata(t *testing.T) {
+	fakeK8s := new(fakeK8sOperations)
+	ops := newTestDeployOps(fakeK8s)
+	a := &app.App{Name: "teresa", ProcessType: app.ProcessTypeWeb}
+	opts := &DeployOptions{Commit: "8a3f2c1", Branch: "master", Message: "Fix checkout"}
+
+	if err := ops.createOrUpdateDeploy(a, &DeployConfigFiles{}, new(bytes.Buffer), "slug", opts, "123"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	ds := fakeK8s.lastDeploySpec
+	if ds.Commit != opts.Commit || ds.Branch != opts.Branch || ds.Message != opts.Message {
+		t.Errorf("expected %+v, got %s %s %s", opts, ds.Commit, ds.Branch, ds.Message)
+	}
+}
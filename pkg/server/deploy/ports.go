@@ -0,0 +1,70 @@
+package deploy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+const (
+	protocolTCP = "TCP"
+	protocolUDP = "UDP"
+	sslPort     = 443
+)
+
+var portNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// reservedPorts are used by the web port of the app
+var reservedPorts = map[int]bool{
+	spec.DefaultExternalPort: true,
+	spec.DefaultPort:         true,
+	sslPort:                  true,
+}
+
+// validatePorts checks the ports of teresa.yaml, names and numbers must be
+// unique and the protocol either TCP (default) or UDP
+func validatePorts(ports []spec.ExposedPort) bool {
+	names := make(map[string]bool)
+	numbers := make(map[int]bool)
+	for _, p := range ports {
+		if len(p.Name) > 15 || !portNameRegexp.MatchString(p.Name) || names[p.Name] {
+			return false
+		}
+		if p.Port < 1 || p.Port > 65535 || reservedPorts[p.Port] || numbers[p.Port] {
+			return false
+		}
+		switch strings.ToUpper(p.Protocol) {
+		case "", protocolTCP, protocolUDP:
+		default:
+			return false
+		}
+		names[p.Name] = true
+		numbers[p.Port] = true
+	}
+	return true
+}
+
+// servicePorts returns the web ports of the current service of the app,
+// the ones targeting spec.DefaultPort, followed by the ports of teresa.yaml
+func servicePorts(current []spec.ServicePort, ports []spec.ExposedPort) []spec.ServicePort {
+	svcPorts := make([]spec.ServicePort, 0, len(current)+len(ports))
+	for _, p := range current {
+		if p.TargetPort == spec.DefaultPort {
+			svcPorts = append(svcPorts, p)
+		}
+	}
+	for _, p := range ports {
+		proto := strings.ToUpper(p.Protocol)
+		if proto == "" {
+			proto = protocolTCP
+		}
+		svcPorts = append(svcPorts, spec.ServicePort{
+			Name:       p.Name,
+			Port:       p.Port,
+			TargetPort: p.Port,
+			Protocol:   proto,
+		})
+	}
+	return svcPorts
+}
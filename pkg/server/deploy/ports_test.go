@@ -0,0 +1,53 @@
+package deploy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+func TestValidatePorts(t *testing.T) {
+	var testCases = []struct {
+		ports    []spec.ExposedPort
+		expected bool
+	}{
+		{nil, true},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 1883}}, true},
+		{[]spec.ExposedPort{{Name: "dns", Port: 53, Protocol: "udp"}}, true},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 1883, Protocol: "TCP"}, {Name: "dns", Port: 53, Protocol: "UDP"}}, true},
+		{[]spec.ExposedPort{{Name: "", Port: 1883}}, false},
+		{[]spec.ExposedPort{{Name: "Mqtt", Port: 1883}}, false},
+		{[]spec.ExposedPort{{Name: "a-very-long-port-name", Port: 1883}}, false},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 0}}, false},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 65536}}, false},
+		{[]spec.ExposedPort{{Name: "web", Port: spec.DefaultExternalPort}}, false},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 1883, Protocol: "SCTP"}}, false},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 1883}, {Name: "mqtt", Port: 8883}}, false},
+		{[]spec.ExposedPort{{Name: "mqtt", Port: 1883}, {Name: "mqtts", Port: 1883}}, false},
+	}
+
+	for _, tc := range testCases {
+		if got := validatePorts(tc.ports); got != tc.expected {
+			t.Errorf("expected %v, got %v for %v", tc.expected, got, tc.ports)
+		}
+	}
+}
+
+func TestServicePorts(t *testing.T) {
+	current := []spec.ServicePort{
+		*spec.NewDefaultServicePort("http"),
+		*spec.NewServicePort("ssl", 443, spec.DefaultPort),
+		{Name: "old", Port: 1883, TargetPort: 1883, Protocol: "TCP"},
+	}
+	ports := []spec.ExposedPort{{Name: "dns", Port: 53, Protocol: "udp"}}
+
+	expected := []spec.ServicePort{
+		current[0],
+		current[1],
+		{Name: "dns", Port: 53, TargetPort: 53, Protocol: "UDP"},
+	}
+	if got := servicePorts(current, ports); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,122 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/uid"
+)
+
+// buildConfFiles reads the Procfile and teresa.yaml of the tarball of a
+// build for the app it's promoted to
+func (ops *DeployOperations) buildConfFiles(srcApp, buildName, appName, processType string) (*DeployConfigFiles, error) {
+	items, err := ops.fileStorage.List(build.Dir(srcApp, buildName))
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	if len(items) == 0 {
+		return nil, ErrBuildNotFound
+	}
+
+	rc, err := ops.fileStorage.Download(build.TarBallPath(srcApp, buildName))
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	confFiles, err := getDeployConfigFilesFromTarBall(bytes.NewReader(b), appName, processType)
+	if err != nil {
+		return nil, teresa_errors.New(ErrInvalidTeresaYamlFile, err)
+	}
+	return confFiles, nil
+}
+
+// PromoteBuild deploys the slug of a build of srcApp to appName without
+// building it again, the slug is copied to the deploys of appName so it
+// outlives the builds of srcApp. The user must have access to both apps
+func (ops *DeployOperations) PromoteBuild(user *database.User, srcApp, buildName, appName string, opts *DeployOptions) (io.ReadCloser, <-chan error) {
+	errChan := make(chan error, 1)
+	if _, err := ops.appOps.CheckRoleAndGet(user, srcApp, team.RoleDeployer); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	a, err := ops.appOps.CheckRoleAndGet(user, appName, team.RoleDeployer)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	opts.Author = user.Email
+	if opts.Description == "" {
+		opts.Description = fmt.Sprintf("build %s of %s", buildName, srcApp)
+	}
+	if err := validateMetadata(opts); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	if err := ops.validateStrategy(a, opts); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	teamName, err := ops.appOps.TeamName(appName)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	a.Team = teamName
+	if err := ops.setTeamEnvVars(a); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	if err := ops.checkFreeze(user, a, opts.Force); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	if err := ops.appOps.CheckTeamQuota(a); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	confFiles, err := ops.buildConfFiles(srcApp, buildName, a.Name, a.ProcessType)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	if err := ops.validateConfFiles(confFiles); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+	deployId := uid.New()
+	if err := ops.lockDeploy(appName, deployId, opts.Author); err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	slugURL := fmt.Sprintf("deploys/%s/%s/out/slug.tgz", appName, deployId)
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+		defer ops.unlockDeploy(appName, deployId)
+		fmt.Fprintf(w, "Promoting the build %s of %s\n", buildName, srcApp)
+		if err := ops.fileStorage.Copy(build.SlugPath(srcApp, buildName), slugURL); err != nil {
+			log.WithError(err).WithField("id", deployId).Errorf("Copying the slug of build %s of %s to app %s", buildName, srcApp, appName)
+			errChan <- teresa_errors.NewInternalServerError(err)
+			return
+		}
+		ops.release(user, a, confFiles, w, slugURL, opts, deployId, errChan)
+	}()
+	return r, errChan
+}
@@ -0,0 +1,80 @@
+package deploy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+)
+
+func TestPromoteBuild(t *testing.T) {
+	tarBall, err := ioutil.ReadFile(filepath.Join("testdata", "procfile.tgz"))
+	if err != nil {
+		t.Fatal("error reading tarBall:", err)
+	}
+	st := storage.NewFake()
+	st.Files[build.TarBallPath("teresa", "v1")] = tarBall
+	st.Files[build.SlugPath("teresa", "v1")] = []byte("slug")
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		st,
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	r, errChan := ops.PromoteBuild(u, "teresa", "v1", "teresa", &DeployOptions{})
+	if r == nil {
+		t.Fatal("got unexpected error:", <-errChan)
+	}
+	defer r.Close()
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatal("got unexpected error reading the deploy output:", err)
+	}
+	select {
+	case err := <-errChan:
+		t.Fatal("got unexpected error:", err)
+	default:
+	}
+
+	ds := fakeK8s.lastDeploySpec
+	if ds == nil {
+		t.Fatal("expected a deploy, got nil")
+	}
+	if len(ds.Pod.InitContainers) == 0 {
+		t.Fatal("expected the slug init container, got none")
+	}
+	slugURL := ds.Pod.InitContainers[0].Env["SLUG_URL"]
+	if !strings.HasPrefix(slugURL, "deploys/teresa/") {
+		t.Errorf("expected the slug copied to the deploys of teresa, got %s", slugURL)
+	}
+	if string(st.Files[slugURL]) != "slug" {
+		t.Errorf("expected the slug of the build at %s, got %q", slugURL, st.Files[slugURL])
+	}
+	if expected := "build v1 of teresa"; ds.Description != expected {
+		t.Errorf("expected %q, got %q", expected, ds.Description)
+	}
+}
+
+func TestPromoteBuildErrors(t *testing.T) {
+	ops := newTestDeployOps(new(fakeK8sOperations))
+	u := &database.User{Email: "gopher@luizalabs.com"}
+
+	if _, errChan := ops.PromoteBuild(u, "teresa", "v1", "other", &DeployOptions{}); <-errChan != app.ErrNotFound {
+		t.Error("expected ErrNotFound for the target app")
+	}
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if _, errChan := ops.PromoteBuild(bad, "teresa", "v1", "teresa", &DeployOptions{}); <-errChan != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied")
+	}
+}
@@ -4,13 +4,24 @@ import (
 	"strconv"
 
 	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
+	"github.com/luizalabs/teresa/pkg/server/app"
 )
 
+// ReplicaSetListItem is a stored revision of the app deploy, EnvVars are
+// the explicit env vars of its app container and Commit, Branch and Message
+// the git metadata given on deploy
 type ReplicaSetListItem struct {
 	Revision    string
 	Description string
 	Current     bool
 	CreatedAt   string
+	Author      string
+	Slug        string
+	Image       string
+	EnvVars     []*app.EnvVar
+	Commit      string
+	Branch      string
+	Message     string
 }
 
 type ByRevision []*dpb.ListResponse_Deploy
@@ -50,6 +61,15 @@ func newListResponse(items []*ReplicaSetListItem) *dpb.ListResponse {
 			Description: item.Description,
 			Current:     item.Current,
 			CreatedAt:   item.CreatedAt,
+			Author:      item.Author,
+			Slug:        item.Slug,
+			Image:       item.Image,
+			Commit:      item.Commit,
+			Branch:      item.Branch,
+			Message:     item.Message,
+		}
+		for _, ev := range item.EnvVars {
+			resp.Deploys[i].Env = append(resp.Deploys[i].Env, &dpb.EnvVar{Key: ev.Key, Value: ev.Value})
 		}
 	}
 
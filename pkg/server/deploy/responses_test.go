@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	dpb "github.com/luizalabs/teresa/pkg/protobuf/deploy"
+	"github.com/luizalabs/teresa/pkg/server/app"
 )
 
 func TestNewListResponse(t *testing.T) {
@@ -45,6 +46,35 @@ func TestNewListResponse(t *testing.T) {
 	}
 }
 
+func TestNewListResponseRevisionDetails(t *testing.T) {
+	items := []*ReplicaSetListItem{{
+		Revision: "1",
+		Author:   "gopher@luizalabs.com",
+		Slug:     "deploys/teresa/1/out/slug.tgz",
+		Image:    "luizalabs/slugrunner:v3.4.0",
+		EnvVars:  []*app.EnvVar{{Key: "KEY", Value: "value"}},
+		Commit:   "8a3f2c1",
+		Branch:   "master",
+		Message:  "Fix checkout",
+	}}
+	want := []*dpb.ListResponse_Deploy{{
+		Revision: "1",
+		Author:   "gopher@luizalabs.com",
+		Slug:     "deploys/teresa/1/out/slug.tgz",
+		Image:    "luizalabs/slugrunner:v3.4.0",
+		Env:      []*dpb.EnvVar{{Key: "KEY", Value: "value"}},
+		Commit:   "8a3f2c1",
+		Branch:   "master",
+		Message:  "Fix checkout",
+	}}
+
+	resp := newListResponse(items)
+
+	if !reflect.DeepEqual(resp.Deploys, want) {
+		t.Fatalf("expected %v items, got %v", want, resp.Deploys)
+	}
+}
+
 func TestSortListResponseByRevision(t *testing.T) {
 	items := []*dpb.ListResponse_Deploy{
 		{
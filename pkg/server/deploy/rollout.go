@@ -0,0 +1,224 @@
+package deploy
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const (
+	crashLoopState   = "CrashLoopBackOff"
+	terminatingState = "Terminating"
+)
+
+// rolloutCheckInterval is the time between checks of the new pods
+var rolloutCheckInterval = 5 * time.Second
+
+// watchRollout monitors the rolling update of the app deploy, rolling it
+// back to the previous revision if it stalls, a pod crash-loops or the pods
+// aren't ready within the rollout window. The reason is written to the
+// deploy stream. A failure to watch the deploy isn't one of the rollout,
+// the deploy is left as is
+func (ops *DeployOperations) watchRollout(a *app.App, w io.Writer, errChan chan<- error) {
+	appName := a.Name
+	window := ops.rolloutWindow(a)
+	if window <= 0 {
+		ops.watchDeploy(appName, appName, w, errChan)
+		return
+	}
+
+	fmt.Fprintln(w, "\nMonitoring rolling update...(hit Ctrl-C to quit)")
+	reason, err := ops.rolloutFailure(appName, window, w)
+	if err != nil {
+		errChan <- teresa_errors.NewInternalServerError(err)
+		return
+	}
+	if reason == "" {
+		writeEvent(w, &event{Type: EventHealthCheck, Text: "All the pods are ready"})
+		fmt.Fprintln(w, "Rolling update finished successfully")
+		return
+	}
+
+	writeEvent(w, &event{Type: EventHealthCheck, Text: fmt.Sprintf("Rolling update failed: %s", reason)})
+	revision, err := ops.rollbackToPrevious(appName)
+	if err != nil {
+		log.WithError(err).Errorf("Rolling back the failed deploy of app %s", appName)
+		errChan <- err
+		return
+	}
+	log.WithFields(log.Fields{
+		"app":      appName,
+		"revision": revision,
+		"reason":   reason,
+	}).Warn("Deploy rolled back automatically")
+	fmt.Fprintf(w, "Rolled back to revision %s\n", revision)
+	errChan <- ErrRolledBack
+}
+
+// rolloutWindow is the rollout timeout of the app, falling back to the auto
+// rollback window of the cluster
+func (ops *DeployOperations) rolloutWindow(a *app.App) time.Duration {
+	if a.RolloutTimeoutSeconds > 0 {
+		return time.Duration(a.RolloutTimeoutSeconds) * time.Second
+	}
+	return ops.opts.AutoRollbackWindow
+}
+
+// rolloutFailure watches the app deploy for the window, returning why its
+// rollout failed or a blank reason once it finishes with all the pods ready.
+// The progress of the rollout is written to w
+func (ops *DeployOperations) rolloutFailure(appName string, window time.Duration, w io.Writer) (string, error) {
+	watched := make(chan error, 1)
+	go func() {
+		watched <- ops.k8s.WatchDeploy(appName, appName)
+	}()
+	timeout := time.After(window)
+	ticker := time.NewTicker(rolloutCheckInterval)
+	defer ticker.Stop()
+
+	finished := false
+	progress := new(rolloutProgress)
+	for {
+		select {
+		case err := <-watched:
+			if err != nil {
+				if ops.k8s.IsRolloutStalled(err) {
+					return err.Error(), nil
+				}
+				return "", err
+			}
+			finished = true
+		case <-timeout:
+			return fmt.Sprintf("pods not ready after %s", window), nil
+		case <-ticker.C:
+		}
+
+		pods, err := ops.k8s.PodListByLabel(appName, runLabel, appName)
+		if err != nil {
+			return "", err
+		}
+		if err := ops.updateProgress(progress, appName, appName, pods, w); err != nil {
+			return "", err
+		}
+		if name := crashLoopingPod(pods); name != "" {
+			return fmt.Sprintf("pod %s is crash-looping", name), nil
+		}
+		if finished && podsReady(pods) {
+			return "", nil
+		}
+	}
+}
+
+// rolloutProgress is the last progress written of a rollout
+type rolloutProgress struct {
+	ready, total int32
+}
+
+// updateProgress writes the ready pods of the deploy out of its replicas
+// whenever they change
+func (ops *DeployOperations) updateProgress(p *rolloutProgress, namespace, deployName string, pods []*app.Pod, w io.Writer) error {
+	total, err := ops.k8s.DeployReplicas(namespace, deployName)
+	if err != nil {
+		return err
+	}
+	ready := readyPods(pods)
+	if ready == p.ready && total == p.total {
+		return nil
+	}
+	p.ready, p.total = ready, total
+	writeProgress(w, ready, total)
+	return nil
+}
+
+// reportProgress writes the progress of the rollout of the deploy until
+// stop is closed
+func (ops *DeployOperations) reportProgress(namespace, deployName string, w io.Writer, stop <-chan struct{}) {
+	ticker := time.NewTicker(rolloutCheckInterval)
+	defer ticker.Stop()
+	progress := new(rolloutProgress)
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		pods, err := ops.k8s.PodListByLabel(namespace, runLabel, deployName)
+		if err == nil {
+			err = ops.updateProgress(progress, namespace, deployName, pods, w)
+		}
+		if err != nil {
+			log.WithError(err).Debugf("Reporting the rollout progress of %s", deployName)
+		}
+	}
+}
+
+// rollbackToPrevious rolls the app deploy back to the revision before the
+// newest one, returning it
+func (ops *DeployOperations) rollbackToPrevious(appName string) (string, error) {
+	items, err := ops.k8s.ReplicaSetListByLabel(appName, runLabel, appName)
+	if err != nil {
+		return "", teresa_errors.NewInternalServerError(err)
+	}
+	item := previousRevision(items)
+	if item == nil {
+		return "", ErrRevisionNotFound
+	}
+	if err := ops.k8s.DeployRollbackToRevision(appName, appName, item.Revision); err != nil {
+		return "", teresa_errors.NewInternalServerError(err)
+	}
+	return item.Revision, nil
+}
+
+// previousRevision returns the item with the second highest revision, the
+// newest one being the failed deploy
+func previousRevision(items []*ReplicaSetListItem) *ReplicaSetListItem {
+	var newest, previous *ReplicaSetListItem
+	for _, item := range items {
+		n := revisionNumber(item.Revision)
+		if newest == nil || n > revisionNumber(newest.Revision) {
+			newest, previous = item, newest
+		} else if previous == nil || n > revisionNumber(previous.Revision) {
+			previous = item
+		}
+	}
+	return previous
+}
+
+func crashLoopingPod(pods []*app.Pod) string {
+	for _, pod := range pods {
+		if pod.State == crashLoopState {
+			return pod.Name
+		}
+	}
+	return ""
+}
+
+func readyPods(pods []*app.Pod) int32 {
+	var n int32
+	for _, pod := range pods {
+		if pod.Ready && pod.State != terminatingState {
+			n++
+		}
+	}
+	return n
+}
+
+// podsReady tells if all the pods, except the terminating ones, are ready
+func podsReady(pods []*app.Pod) bool {
+	n := 0
+	for _, pod := range pods {
+		if pod.State == terminatingState {
+			continue
+		}
+		if !pod.Ready {
+			return false
+		}
+		n++
+	}
+	return n > 0
+}
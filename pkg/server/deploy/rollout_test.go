@@ -0,0 +1,148 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+var errRolloutStalled = errors.New("rolling update stalled")
+
+func TestWatchRollout(t *testing.T) {
+	rolloutCheckInterval = time.Millisecond
+	ready := &app.Pod{Name: "teresa-1", State: "Running", Ready: true}
+	starting := &app.Pod{Name: "teresa-2", State: "ContainerCreating"}
+	crashing := &app.Pod{Name: "teresa-3", State: crashLoopState}
+	terminating := &app.Pod{Name: "teresa-4", State: terminatingState}
+
+	var testCases = []struct {
+		pods     []*app.Pod
+		watchErr error
+		reason   string
+		expected error
+	}{
+		{[]*app.Pod{ready, terminating}, nil, "", nil},
+		{[]*app.Pod{ready, crashing}, nil, "pod teresa-3 is crash-looping", ErrRolledBack},
+		{[]*app.Pod{ready, starting}, nil, "pods not ready", ErrRolledBack},
+		{[]*app.Pod{ready}, errRolloutStalled, "rolling update stalled", ErrRolledBack},
+	}
+
+	for _, tc := range testCases {
+		fakeK8s := &fakeK8sOperations{pods: tc.pods, watchDeployErr: tc.watchErr}
+		ops := newTestDeployOps(fakeK8s)
+		ops.opts.AutoRollbackWindow = 50 * time.Millisecond
+		w := new(bytes.Buffer)
+		errChan := make(chan error, 1)
+
+		ops.watchRollout(&app.App{Name: "teresa"}, w, errChan)
+
+		var err error
+		select {
+		case err = <-errChan:
+		default:
+		}
+		if err != tc.expected {
+			t.Errorf("expected %v, got %v", tc.expected, err)
+		}
+		if tc.expected == nil {
+			if fakeK8s.rollbackRevision != "" {
+				t.Errorf("expected no rollback, got revision %s", fakeK8s.rollbackRevision)
+			}
+			continue
+		}
+		if fakeK8s.rollbackRevision != "1" {
+			t.Errorf("expected rollback to revision 1, got %q", fakeK8s.rollbackRevision)
+		}
+		if !strings.Contains(w.String(), tc.reason) {
+			t.Errorf("expected %q in the stream, got %q", tc.reason, w.String())
+		}
+	}
+}
+
+func TestWatchRolloutWatchError(t *testing.T) {
+	rolloutCheckInterval = time.Millisecond
+	fakeK8s := &fakeK8sOperations{
+		pods:           []*app.Pod{{Name: "teresa-1", State: "ContainerCreating"}},
+		watchDeployErr: errors.New("watch deploy failed"),
+	}
+	ops := newTestDeployOps(fakeK8s)
+	ops.opts.AutoRollbackWindow = time.Minute
+	errChan := make(chan error, 1)
+
+	ops.watchRollout(&app.App{Name: "teresa"}, new(bytes.Buffer), errChan)
+
+	if err := <-errChan; err == ErrRolledBack {
+		t.Errorf("expected the watch error, got %v", err)
+	}
+	if fakeK8s.rollbackRevision != "" {
+		t.Errorf("expected no rollback on a watch error, got revision %s", fakeK8s.rollbackRevision)
+	}
+}
+
+func TestWatchRolloutDisabled(t *testing.T) {
+	fakeK8s := &fakeK8sOperations{pods: []*app.Pod{{Name: "teresa-1", State: crashLoopState}}}
+	ops := newTestDeployOps(fakeK8s)
+	errChan := make(chan error, 1)
+
+	ops.watchRollout(&app.App{Name: "teresa"}, new(bytes.Buffer), errChan)
+
+	if len(errChan) != 0 || fakeK8s.rollbackRevision != "" {
+		t.Errorf("expected no rollback without a window, got %q", fakeK8s.rollbackRevision)
+	}
+}
+
+func TestRolloutWindow(t *testing.T) {
+	ops := newTestDeployOps(new(fakeK8sOperations))
+	ops.opts.AutoRollbackWindow = 5 * time.Minute
+
+	if got := ops.rolloutWindow(&app.App{Name: "teresa"}); got != 5*time.Minute {
+		t.Errorf("expected the window of the cluster, got %s", got)
+	}
+	a := &app.App{Name: "teresa", RolloutTimeoutSeconds: 900}
+	if got := ops.rolloutWindow(a); got != 15*time.Minute {
+		t.Errorf("expected the timeout of the app, got %s", got)
+	}
+
+	ops.opts.AutoRollbackWindow = 0
+	if got := ops.rolloutWindow(a); got != 15*time.Minute {
+		t.Errorf("expected the timeout of the app without a cluster window, got %s", got)
+	}
+}
+
+func TestValidateTeresaYamlRolloutTimeout(t *testing.T) {
+	var testCases = []struct {
+		seconds int32
+		isValid bool
+	}{
+		{0, true},
+		{900, true},
+		{5, false},
+		{app.MaxRolloutTimeoutSeconds + 1, false},
+	}
+
+	for _, tc := range testCases {
+		err := validateTeresaYaml(&spec.TeresaYaml{RolloutTimeoutSeconds: tc.seconds})
+		if (err == nil) != tc.isValid {
+			t.Errorf("expected valid %t, got %v for %d", tc.isValid, err, tc.seconds)
+		}
+	}
+}
+
+func TestPreviousRevision(t *testing.T) {
+	items := []*ReplicaSetListItem{
+		{Revision: "9", Current: true},
+		{Revision: "10", Current: true},
+		{Revision: "2"},
+	}
+	if got := previousRevision(items); got == nil || got.Revision != "9" {
+		t.Errorf("expected revision 9, got %v", got)
+	}
+	if got := previousRevision(items[:1]); got != nil {
+		t.Errorf("expected no previous revision, got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"strconv"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+var (
+	tolerationOperators = map[string]bool{"": true, "Equal": true, "Exists": true}
+	taintEffects        = map[string]bool{"": true, "NoSchedule": true, "PreferNoSchedule": true, "NoExecute": true}
+)
+
+// validateScheduling checks the nodeSelector, tolerations, spread and
+// affinity of teresa.yaml, the node labels and taint keys used must be allowed by the
+// cluster operator
+func validateScheduling(ty *spec.TeresaYaml, allowedLabels, allowedTaints []string) error {
+	for k, v := range ty.NodeSelector {
+		if k == "" || v == "" {
+			return ErrInvalidScheduling
+		}
+		if !hasString(allowedLabels, k) {
+			return ErrSchedulingNotAllowed
+		}
+	}
+
+	for _, t := range ty.Tolerations {
+		if t.Key == "" || !tolerationOperators[t.Operator] || !taintEffects[t.Effect] {
+			return ErrInvalidScheduling
+		}
+		if t.Operator == "Exists" && t.Value != "" {
+			return ErrInvalidScheduling
+		}
+		if !hasString(allowedTaints, t.Key) {
+			return ErrSchedulingNotAllowed
+		}
+	}
+
+	if sp := ty.Spread; sp != nil && (!spec.IsValidSpreadPolicy(sp.Zone) || !spec.IsValidSpreadPolicy(sp.Host)) {
+		return ErrInvalidScheduling
+	}
+
+	if ty.Affinity == nil {
+		return nil
+	}
+	for _, r := range ty.Affinity.Required {
+		if err := validateNodeRequirement(&r, allowedLabels); err != nil {
+			return err
+		}
+	}
+	for _, r := range ty.Affinity.Preferred {
+		if r.Weight < 1 || r.Weight > 100 {
+			return ErrInvalidScheduling
+		}
+		if err := validateNodeRequirement(&r.NodeRequirement, allowedLabels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateNodeRequirement(r *spec.NodeRequirement, allowedLabels []string) error {
+	if r.Key == "" {
+		return ErrInvalidScheduling
+	}
+	switch r.Operator {
+	case "In", "NotIn":
+		if len(r.Values) == 0 {
+			return ErrInvalidScheduling
+		}
+	case "Exists", "DoesNotExist":
+		if len(r.Values) != 0 {
+			return ErrInvalidScheduling
+		}
+	case "Gt", "Lt":
+		if len(r.Values) != 1 {
+			return ErrInvalidScheduling
+		}
+		if _, err := strconv.Atoi(r.Values[0]); err != nil {
+			return ErrInvalidScheduling
+		}
+	default:
+		return ErrInvalidScheduling
+	}
+	if !hasString(allowedLabels, r.Key) {
+		return ErrSchedulingNotAllowed
+	}
+	return nil
+}
+
+func hasString(s []string, v string) bool {
+	for _, item := range s {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+func TestValidateScheduling(t *testing.T) {
+	labels := []string{"pool", "gpu"}
+	taints := []string{"spot"}
+
+	var testCases = []struct {
+		ty       *spec.TeresaYaml
+		expected error
+	}{
+		{&spec.TeresaYaml{}, nil},
+		{&spec.TeresaYaml{NodeSelector: map[string]string{"pool": "gpu"}}, nil},
+		{&spec.TeresaYaml{NodeSelector: map[string]string{"pool": ""}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{NodeSelector: map[string]string{"zone": "a"}}, ErrSchedulingNotAllowed},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Key: "spot", Operator: "Exists", Effect: "NoSchedule"}}}, nil},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Key: "spot", Value: "true"}}}, nil},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Operator: "Exists"}}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Key: "spot", Operator: "Exists", Value: "true"}}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Key: "spot", Effect: "NoWay"}}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Tolerations: []spec.Toleration{{Key: "dedicated", Value: "db"}}}, ErrSchedulingNotAllowed},
+		{&spec.TeresaYaml{Spread: &spec.Spread{Zone: "required", Host: "preferred"}}, nil},
+		{&spec.TeresaYaml{Spread: &spec.Spread{Host: "none"}}, nil},
+		{&spec.TeresaYaml{Spread: &spec.Spread{Zone: "always"}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "pool", Operator: "In", Values: []string{"a", "b"}}},
+		}}, nil},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "gpu", Operator: "Gt", Values: []string{"1"}}},
+		}}, nil},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Preferred: []spec.PreferredNodeRequirement{{NodeRequirement: spec.NodeRequirement{Key: "pool", Operator: "Exists"}, Weight: 50}},
+		}}, nil},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "pool", Operator: "In"}},
+		}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "pool", Operator: "Exists", Values: []string{"a"}}},
+		}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "gpu", Operator: "Lt", Values: []string{"many"}}},
+		}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "pool", Operator: "Like", Values: []string{"a"}}},
+		}}, ErrInvalidScheduling},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "zone", Operator: "In", Values: []string{"a"}}},
+		}}, ErrSchedulingNotAllowed},
+		{&spec.TeresaYaml{Affinity: &spec.Affinity{
+			Preferred: []spec.PreferredNodeRequirement{{NodeRequirement: spec.NodeRequirement{Key: "pool", Operator: "Exists"}}},
+		}}, ErrInvalidScheduling},
+	}
+
+	for _, tc := range testCases {
+		if got := validateScheduling(tc.ty, labels, taints); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.ty)
+		}
+	}
+}
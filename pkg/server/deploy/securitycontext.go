@@ -0,0 +1,61 @@
+package deploy
+
+import (
+	"regexp"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+var capabilityRegexp = regexp.MustCompile(`^[A-Z_]+$`)
+
+// baselineSecurityContext is the security context of the app containers
+// enforced by the cluster operator, nil if there's none
+func (opts *Options) baselineSecurityContext() *spec.SecurityContext {
+	sc := new(spec.SecurityContext)
+	if opts.RunAsNonRoot {
+		sc.RunAsNonRoot = &opts.RunAsNonRoot
+	}
+	if opts.RunAsUser > 0 {
+		sc.RunAsUser = &opts.RunAsUser
+	}
+	if opts.ReadOnlyRootFilesystem {
+		sc.ReadOnlyRootFilesystem = &opts.ReadOnlyRootFilesystem
+	}
+	sc.DropCapabilities = opts.DropCapabilities
+	if sc.RunAsNonRoot == nil && sc.RunAsUser == nil && sc.ReadOnlyRootFilesystem == nil && len(sc.DropCapabilities) == 0 {
+		return nil
+	}
+	return sc
+}
+
+// validateSecurityContext checks the securityContext of teresa.yaml, apps
+// can tighten the baseline of the cluster but not loosen it. Running as root
+// (runAsUser 0) is never allowed, leave it unset to keep the user of the image
+func validateSecurityContext(sc, baseline *spec.SecurityContext) error {
+	if sc == nil {
+		return nil
+	}
+	for _, c := range sc.DropCapabilities {
+		if !capabilityRegexp.MatchString(c) {
+			return ErrInvalidSecurityContext
+		}
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser <= 0 {
+		return ErrInvalidSecurityContext
+	}
+
+	if baseline == nil {
+		return nil
+	}
+	if baseline.RunAsNonRoot != nil && *baseline.RunAsNonRoot {
+		if sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot {
+			return ErrSecurityContextNotAllowed
+		}
+	}
+	if baseline.ReadOnlyRootFilesystem != nil && *baseline.ReadOnlyRootFilesystem {
+		if sc.ReadOnlyRootFilesystem != nil && !*sc.ReadOnlyRootFilesystem {
+			return ErrSecurityContextNotAllowed
+		}
+	}
+	return nil
+}
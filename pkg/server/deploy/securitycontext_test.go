@@ -0,0 +1,53 @@
+package deploy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+func TestBaselineSecurityContext(t *testing.T) {
+	if sc := (&Options{}).baselineSecurityContext(); sc != nil {
+		t.Errorf("expected nil, got %+v", sc)
+	}
+
+	opts := &Options{RunAsNonRoot: true, RunAsUser: 1000, DropCapabilities: []string{"NET_RAW"}}
+	yes, user := true, int64(1000)
+	expected := &spec.SecurityContext{
+		RunAsNonRoot:     &yes,
+		RunAsUser:        &user,
+		DropCapabilities: []string{"NET_RAW"},
+	}
+	if got := opts.baselineSecurityContext(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestValidateSecurityContext(t *testing.T) {
+	yes, no := true, false
+	root, user, negative := int64(0), int64(1000), int64(-1)
+	strict := &spec.SecurityContext{RunAsNonRoot: &yes, ReadOnlyRootFilesystem: &yes}
+	var testCases = []struct {
+		sc       *spec.SecurityContext
+		baseline *spec.SecurityContext
+		expected error
+	}{
+		{nil, strict, nil},
+		{&spec.SecurityContext{RunAsUser: &root}, nil, ErrInvalidSecurityContext},
+		{&spec.SecurityContext{RunAsUser: &user, DropCapabilities: []string{"ALL"}}, strict, nil},
+		{&spec.SecurityContext{ReadOnlyRootFilesystem: &yes}, strict, nil},
+		{&spec.SecurityContext{DropCapabilities: []string{"net_raw"}}, nil, ErrInvalidSecurityContext},
+		{&spec.SecurityContext{RunAsUser: &negative}, nil, ErrInvalidSecurityContext},
+		{&spec.SecurityContext{RunAsNonRoot: &yes, RunAsUser: &root}, nil, ErrInvalidSecurityContext},
+		{&spec.SecurityContext{RunAsNonRoot: &no}, strict, ErrSecurityContextNotAllowed},
+		{&spec.SecurityContext{RunAsUser: &root}, strict, ErrInvalidSecurityContext},
+		{&spec.SecurityContext{ReadOnlyRootFilesystem: &no}, strict, ErrSecurityContextNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		if got := validateSecurityContext(tc.sc, tc.baseline); got != tc.expected {
+			t.Errorf("expected %v, got %v for %+v", tc.expected, got, tc.sc)
+		}
+	}
+}
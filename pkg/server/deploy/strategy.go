@@ -0,0 +1,27 @@
+package deploy
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+)
+
+func appRollingUpdate(ru *spec.RollingUpdate) *app.RollingUpdate {
+	return &app.RollingUpdate{
+		MaxSurge:       ru.MaxSurge,
+		MaxUnavailable: ru.MaxUnavailable,
+	}
+}
+
+// deployRollingUpdate converts the strategy of the app with the blank
+// values set to the defaults, nil keeps the one of teresa.yaml (or the
+// default one)
+func deployRollingUpdate(ru *app.RollingUpdate) *spec.RollingUpdate {
+	if ru == nil {
+		return nil
+	}
+	ru = ru.WithDefaults()
+	return &spec.RollingUpdate{
+		MaxSurge:       ru.MaxSurge,
+		MaxUnavailable: ru.MaxUnavailable,
+	}
+}
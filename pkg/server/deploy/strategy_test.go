@@ -0,0 +1,91 @@
+package deploy
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/build"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+)
+
+func TestValidateTeresaYamlRollingUpdate(t *testing.T) {
+	var testCases = []struct {
+		ru      *spec.RollingUpdate
+		isValid bool
+	}{
+		{&spec.RollingUpdate{MaxSurge: "1", MaxUnavailable: "0"}, true},
+		{&spec.RollingUpdate{MaxSurge: "50%"}, true},
+		{&spec.RollingUpdate{MaxSurge: "0", MaxUnavailable: "0"}, false},
+		{&spec.RollingUpdate{MaxUnavailable: "200%"}, false},
+	}
+
+	for _, tc := range testCases {
+		err := validateTeresaYaml(&spec.TeresaYaml{RollingUpdate: tc.ru})
+		if (err == nil) != tc.isValid {
+			t.Errorf("expected valid %t, got %v for %+v", tc.isValid, err, tc.ru)
+		}
+	}
+}
+
+func TestDeployRollingUpdate(t *testing.T) {
+	if got := deployRollingUpdate(nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+
+	expected := &spec.RollingUpdate{MaxSurge: app.DefaultMaxSurge, MaxUnavailable: "0"}
+	got := deployRollingUpdate(&app.RollingUpdate{MaxUnavailable: "0"})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestCreateDeployRollingUpdate(t *testing.T) {
+	a := &app.App{
+		Name:          "teresa",
+		ProcessType:   "web",
+		Processes:     map[string]int32{"worker": 1},
+		RollingUpdate: &app.RollingUpdate{MaxSurge: "3", MaxUnavailable: "1"},
+	}
+	conf := &DeployConfigFiles{
+		Procfile: map[string]string{
+			"web":    "python app.py",
+			"worker": "python worker.py",
+		},
+		TeresaYaml: &spec.TeresaYaml{
+			RollingUpdate: &spec.RollingUpdate{MaxSurge: "1"},
+		},
+	}
+
+	fakeK8s := new(fakeK8sOperations)
+	ops := NewDeployOperations(
+		app.NewFakeOperations(),
+		fakeK8s,
+		storage.NewFake(),
+		exec.NewFakeOperations(),
+		build.NewFakeOperations(),
+		&Options{},
+	)
+
+	err := ops.(*DeployOperations).createOrUpdateDeploy(
+		a,
+		conf,
+		new(bytes.Buffer),
+		"test-slug",
+		&DeployOptions{Description: "test-description"},
+		"123",
+	)
+	if err != nil {
+		t.Fatal("error create deploy:", err)
+	}
+
+	expected := &spec.RollingUpdate{MaxSurge: "3", MaxUnavailable: "1"}
+	for _, ds := range fakeK8s.deploySpecs {
+		if !reflect.DeepEqual(ds.RollingUpdate, expected) {
+			t.Errorf("expected %v for %s, got %v", expected, ds.Name, ds.RollingUpdate)
+		}
+	}
+}
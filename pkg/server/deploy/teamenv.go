@@ -0,0 +1,53 @@
+package deploy
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+// TeamEnv gives the default env vars of the apps of a team
+type TeamEnv interface {
+	EnvVars(teamName string) ([]*team.EnvVar, error)
+}
+
+func (ops *DeployOperations) SetTeamEnv(te TeamEnv) {
+	ops.teamEnv = te
+}
+
+// setTeamEnvVars sets the default env vars of the team of the app on it,
+// they're merged into the pods of the deploy
+func (ops *DeployOperations) setTeamEnvVars(a *app.App) error {
+	if ops.teamEnv == nil {
+		return nil
+	}
+	evs, err := ops.teamEnv.EnvVars(a.Team)
+	if err != nil {
+		return err
+	}
+	a.TeamEnvVars = make([]*app.EnvVar, len(evs))
+	for i, ev := range evs {
+		a.TeamEnvVars[i] = &app.EnvVar{Key: ev.Key, Value: ev.Value}
+	}
+	return nil
+}
+
+// withoutTeamEnvVars removes from env the env vars with the same value as
+// the default ones of the team, so a rollback doesn't turn them into env
+// vars of the app
+func withoutTeamEnvVars(env, teamEnv []*app.EnvVar) []*app.EnvVar {
+	if len(teamEnv) == 0 {
+		return env
+	}
+	defaults := make(map[string]string)
+	for _, ev := range teamEnv {
+		defaults[ev.Key] = ev.Value
+	}
+	appEnv := []*app.EnvVar{}
+	for _, ev := range env {
+		if v, found := defaults[ev.Key]; found && v == ev.Value {
+			continue
+		}
+		appEnv = append(appEnv, ev)
+	}
+	return appEnv
+}
@@ -0,0 +1,54 @@
+package deploy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+type fakeTeamEnv struct {
+	evs []*team.EnvVar
+}
+
+func (f *fakeTeamEnv) EnvVars(teamName string) ([]*team.EnvVar, error) {
+	return f.evs, nil
+}
+
+func TestSetTeamEnvVars(t *testing.T) {
+	ops := newTestDeployOps(&fakeK8sOperations{})
+	a := &app.App{Name: "teresa", Team: "luizalabs"}
+	if err := ops.setTeamEnvVars(a); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(a.TeamEnvVars) != 0 {
+		t.Errorf("expected no team env vars, got %v", a.TeamEnvVars)
+	}
+
+	ops.SetTeamEnv(&fakeTeamEnv{evs: []*team.EnvVar{{Key: "LOG_LEVEL", Value: "info"}}})
+	if err := ops.setTeamEnvVars(a); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := []*app.EnvVar{{Key: "LOG_LEVEL", Value: "info"}}
+	if !reflect.DeepEqual(a.TeamEnvVars, expected) {
+		t.Errorf("expected %v, got %v", expected, a.TeamEnvVars)
+	}
+}
+
+func TestWithoutTeamEnvVars(t *testing.T) {
+	env := []*app.EnvVar{
+		{Key: "LOG_LEVEL", Value: "info"},
+		{Key: "REGION", Value: "us"},
+		{Key: "FOO", Value: "bar"},
+	}
+	teamEnv := []*app.EnvVar{{Key: "LOG_LEVEL", Value: "info"}, {Key: "REGION", Value: "br"}}
+	expected := []*app.EnvVar{{Key: "REGION", Value: "us"}, {Key: "FOO", Value: "bar"}}
+
+	if got := withoutTeamEnvVars(env, teamEnv); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := withoutTeamEnvVars(env, nil); !reflect.DeepEqual(got, env) {
+		t.Errorf("expected %v, got %v", env, got)
+	}
+}
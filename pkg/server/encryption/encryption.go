@@ -0,0 +1,93 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// KeySize is the size of the keys, AES-256
+const KeySize = 32
+
+var (
+	ErrInvalidKey        = errors.New("Invalid encryption key, it must have 32 bytes")
+	ErrInvalidCiphertext = errors.New("Invalid ciphertext")
+)
+
+// Cipher encrypts the sensitive values stored in the database, like the
+// secrets of the two-factor authentication
+type Cipher interface {
+	Encrypt(plaintext []byte) (string, error)
+	Decrypt(ciphertext string) ([]byte, error)
+}
+
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// Encrypt returns the plaintext encrypted with AES-GCM, encoded in base64
+// along with its nonce
+func (c *aesCipher) Encrypt(plaintext []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesCipher) Decrypt(ciphertext string) ([]byte, error) {
+	b, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(b) < c.aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	n := c.aead.NonceSize()
+	plaintext, err := c.aead.Open(nil, b[:n], b[n:], nil)
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+// NewAESCipher returns a Cipher with the AES-256 key
+func NewAESCipher(key []byte) (Cipher, error) {
+	if len(key) != KeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &aesCipher{aead: aead}, nil
+}
+
+type multiCipher struct {
+	ciphers []Cipher
+}
+
+// Encrypt encrypts with the first cipher, the one of the current key
+func (c *multiCipher) Encrypt(plaintext []byte) (string, error) {
+	return c.ciphers[0].Encrypt(plaintext)
+}
+
+func (c *multiCipher) Decrypt(ciphertext string) ([]byte, error) {
+	for _, cc := range c.ciphers {
+		if plaintext, err := cc.Decrypt(ciphertext); err == nil {
+			return plaintext, nil
+		}
+	}
+	return nil, ErrInvalidCiphertext
+}
+
+// NewMultiCipher returns a Cipher encrypting with the current one and
+// decrypting with any of them, to rotate the key
+func NewMultiCipher(current Cipher, old ...Cipher) Cipher {
+	return &multiCipher{ciphers: append([]Cipher{current}, old...)}
+}
@@ -0,0 +1,74 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAESCipher(t *testing.T) {
+	c, err := NewAESCipher(bytes.Repeat([]byte{1}, KeySize))
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	plaintext := []byte("JBSWY3DPEHPK3PXP")
+	ciphertext, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if other, _ := c.Encrypt(plaintext); other == ciphertext {
+		t.Error("expected a new nonce on every encryption")
+	}
+	got, err := c.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("expected %s, got %s", plaintext, got)
+	}
+
+	other, _ := NewAESCipher(bytes.Repeat([]byte{2}, KeySize))
+	for _, ct := range []string{"not base64!", "c2hvcnQ=", ciphertext[:len(ciphertext)-4] + "AAAA"} {
+		if _, err := c.Decrypt(ct); err != ErrInvalidCiphertext {
+			t.Errorf("expected ErrInvalidCiphertext for %s, got %v", ct, err)
+		}
+	}
+	if _, err := other.Decrypt(ciphertext); err != ErrInvalidCiphertext {
+		t.Errorf("expected ErrInvalidCiphertext with other key, got %v", err)
+	}
+}
+
+func TestNewAESCipherInvalidKey(t *testing.T) {
+	if _, err := NewAESCipher([]byte("short")); err != ErrInvalidKey {
+		t.Errorf("expected ErrInvalidKey, got %v", err)
+	}
+}
+
+func TestMultiCipher(t *testing.T) {
+	old, _ := NewAESCipher(bytes.Repeat([]byte{1}, KeySize))
+	current, _ := NewAESCipher(bytes.Repeat([]byte{2}, KeySize))
+	c := NewMultiCipher(current, old)
+
+	plaintext := []byte("JBSWY3DPEHPK3PXP")
+	oldCiphertext, _ := old.Encrypt(plaintext)
+	if got, err := c.Decrypt(oldCiphertext); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("expected %s decrypted with the old key, got %s (%v)", plaintext, got, err)
+	}
+
+	ciphertext, err := c.Encrypt(plaintext)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got, err := current.Decrypt(ciphertext); err != nil || !bytes.Equal(got, plaintext) {
+		t.Errorf("expected encrypted with the current key, got %s (%v)", got, err)
+	}
+	if _, err := old.Decrypt(ciphertext); err != ErrInvalidCiphertext {
+		t.Errorf("expected ErrInvalidCiphertext with the old key, got %v", err)
+	}
+
+	other, _ := NewAESCipher(bytes.Repeat([]byte{3}, KeySize))
+	otherCiphertext, _ := other.Encrypt(plaintext)
+	if _, err := c.Decrypt(otherCiphertext); err != ErrInvalidCiphertext {
+		t.Errorf("expected ErrInvalidCiphertext with unknown key, got %v", err)
+	}
+}
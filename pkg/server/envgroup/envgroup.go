@@ -0,0 +1,404 @@
+package envgroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/validation"
+	"github.com/pkg/errors"
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// EnvGroup is a set of env vars owned by a team, every change on it is
+// rolled out to the attached apps
+type EnvGroup struct {
+	Name    string
+	Team    string
+	Apps    []string
+	EnvVars []*app.EnvVar
+}
+
+type Operations interface {
+	Create(user *database.User, name, teamName string) error
+	Delete(user *database.User, name string) error
+	List(user *database.User) ([]*EnvGroup, error)
+	Info(user *database.User, name string) (*EnvGroup, error)
+	SetEnv(user *database.User, name string, evs []*app.EnvVar) error
+	UnsetEnv(user *database.User, name string, evNames []string) error
+	Attach(user *database.User, name, appName string) error
+	Detach(user *database.User, name, appName string) error
+	AttachApp(name, appName string) error
+	RenameApp(oldName, newName string) error
+	DetachApp(appName string) error
+}
+
+type DatabaseOperations struct {
+	DB   *gorm.DB
+	tops team.Operations
+	aops app.Operations
+}
+
+func (ops *DatabaseOperations) Create(user *database.User, name, teamName string) error {
+	if len(name) > 63 || !nameRegexp.MatchString(name) {
+		return ErrInvalidName
+	}
+
+	t := new(database.Team)
+	if ops.DB.Where(&database.Team{Name: teamName}).First(t).RecordNotFound() {
+		return team.ErrNotFound
+	}
+	if err := ops.checkPerm(user, t.Name, team.RoleAdmin); err != nil {
+		return err
+	}
+
+	if !ops.DB.Where(&database.EnvGroup{Name: name}).First(new(database.EnvGroup)).RecordNotFound() {
+		return ErrAlreadyExists
+	}
+
+	eg := &EnvGroup{Name: name, Team: t.Name}
+	return ops.save(eg, &database.EnvGroup{TeamID: t.ID})
+}
+
+func (ops *DatabaseOperations) Delete(user *database.User, name string) error {
+	eg, dbeg, err := ops.checkPermAndGet(user, name, team.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	// apps deleted since the attachment don't hold the group
+	for _, appName := range eg.Apps {
+		_, err := ops.aops.Get(appName)
+		if err == nil {
+			return ErrInUse
+		}
+		if teresa_errors.Get(err) != app.ErrNotFound {
+			return err
+		}
+	}
+
+	if err := ops.DB.Delete(dbeg).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return nil
+}
+
+func (ops *DatabaseOperations) List(user *database.User) ([]*EnvGroup, error) {
+	q := ops.DB.Preload("Team").Order("env_groups.name")
+	if !user.IsAdmin {
+		teams, err := ops.tops.ListByUser(user.Email)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, len(teams))
+		for i, t := range teams {
+			names[i] = t.Name
+		}
+		q = q.Joins("JOIN teams ON teams.id = env_groups.team_id").
+			Where("teams.name in (?)", names)
+	}
+
+	var dbegs []*database.EnvGroup
+	if err := q.Find(&dbegs).Error; err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	egs := make([]*EnvGroup, len(dbegs))
+	for i, dbeg := range dbegs {
+		eg, err := newEnvGroup(dbeg)
+		if err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+		egs[i] = eg
+	}
+	return egs, nil
+}
+
+func (ops *DatabaseOperations) Info(user *database.User, name string) (*EnvGroup, error) {
+	eg, _, err := ops.checkPermAndGet(user, name, team.RoleViewer)
+	return eg, err
+}
+
+func (ops *DatabaseOperations) SetEnv(user *database.User, name string, evs []*app.EnvVar) error {
+	evNames := make([]string, len(evs))
+	for i := range evs {
+		evNames[i] = evs[i].Key
+	}
+	if err := checkForInvalidEnvVars(evNames); err != nil {
+		return err
+	}
+
+	eg, dbeg, err := ops.checkPermAndGet(user, name, team.RoleDeveloper)
+	if err != nil {
+		return err
+	}
+
+	for _, ev := range evs {
+		found := false
+		for _, cur := range eg.EnvVars {
+			if cur.Key == ev.Key {
+				cur.Value = ev.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			eg.EnvVars = append(eg.EnvVars, ev)
+		}
+	}
+
+	if err := ops.save(eg, dbeg); err != nil {
+		return err
+	}
+	return ops.rollout(user, eg)
+}
+
+func (ops *DatabaseOperations) UnsetEnv(user *database.User, name string, evNames []string) error {
+	if err := checkForInvalidEnvVars(evNames); err != nil {
+		return err
+	}
+
+	eg, dbeg, err := ops.checkPermAndGet(user, name, team.RoleDeveloper)
+	if err != nil {
+		return err
+	}
+
+	for _, evName := range evNames {
+		for i, cur := range eg.EnvVars {
+			if cur.Key == evName {
+				eg.EnvVars = append(eg.EnvVars[:i], eg.EnvVars[i+1:]...)
+				break
+			}
+		}
+	}
+
+	if err := ops.save(eg, dbeg); err != nil {
+		return err
+	}
+	return ops.rollout(user, eg)
+}
+
+func (ops *DatabaseOperations) Attach(user *database.User, name, appName string) error {
+	eg, dbeg, err := ops.checkPermAndGet(user, name, team.RoleDeveloper)
+	if err != nil {
+		return err
+	}
+	if !ops.aops.HasPermission(user, appName) {
+		return auth.ErrPermissionDenied
+	}
+
+	if err := ops.aops.SetEnvGroup(user, appName, eg.Name, eg.EnvVars); err != nil {
+		return err
+	}
+
+	if hasApp(eg, appName) {
+		return nil
+	}
+	eg.Apps = append(eg.Apps, appName)
+	return ops.save(eg, dbeg)
+}
+
+func (ops *DatabaseOperations) Detach(user *database.User, name, appName string) error {
+	eg, dbeg, err := ops.checkPermAndGet(user, name, team.RoleDeveloper)
+	if err != nil {
+		return err
+	}
+	if !hasApp(eg, appName) {
+		return ErrNotAttached
+	}
+
+	// a deleted app is only removed from the group
+	_, err = ops.aops.Get(appName)
+	if err != nil && teresa_errors.Get(err) != app.ErrNotFound {
+		return err
+	}
+	if err == nil {
+		if !ops.aops.HasPermission(user, appName) {
+			return auth.ErrPermissionDenied
+		}
+		err = ops.aops.UnsetEnvGroup(user, appName, eg.Name)
+		if err != nil && teresa_errors.Get(err) != app.ErrNotFound {
+			return err
+		}
+	}
+
+	eg.Apps = removeApp(eg.Apps, appName)
+	return ops.save(eg, dbeg)
+}
+
+// AttachApp adds the copy of an app to the group, its env vars were already
+// copied. Permissions are checked by the caller.
+func (ops *DatabaseOperations) AttachApp(name, appName string) error {
+	eg, dbeg, err := ops.get(name)
+	if err != nil {
+		return err
+	}
+	if hasApp(eg, appName) {
+		return nil
+	}
+	eg.Apps = append(eg.Apps, appName)
+	return ops.save(eg, dbeg)
+}
+
+// RenameApp replaces the old name of a renamed app in its groups
+func (ops *DatabaseOperations) RenameApp(oldName, newName string) error {
+	return ops.updateAppGroups(oldName, func(apps []string) []string {
+		return append(removeApp(apps, oldName), newName)
+	})
+}
+
+// DetachApp removes a deleted app from its groups
+func (ops *DatabaseOperations) DetachApp(appName string) error {
+	return ops.updateAppGroups(appName, func(apps []string) []string {
+		return removeApp(apps, appName)
+	})
+}
+
+// updateAppGroups sets the apps of every group with the app to the result
+// of fn
+func (ops *DatabaseOperations) updateAppGroups(appName string, fn func([]string) []string) error {
+	var dbegs []*database.EnvGroup
+	if err := ops.DB.Preload("Team").Find(&dbegs).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	for _, dbeg := range dbegs {
+		eg, err := newEnvGroup(dbeg)
+		if err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		if !hasApp(eg, appName) {
+			continue
+		}
+		eg.Apps = fn(eg.Apps)
+		if err := ops.save(eg, dbeg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollout updates the env group on every attached app, apps deleted
+// since the attachment are skipped
+func (ops *DatabaseOperations) rollout(user *database.User, eg *EnvGroup) error {
+	for _, appName := range eg.Apps {
+		err := ops.aops.SetEnvGroup(user, appName, eg.Name, eg.EnvVars)
+		if err != nil && teresa_errors.Get(err) != app.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPerm tells if the user has the role in the team of the group, the
+// admins of the team create and delete groups and its developers change them
+func (ops *DatabaseOperations) checkPerm(user *database.User, teamName, role string) error {
+	if user.IsAdmin {
+		return nil
+	}
+	hasPerm, err := ops.tops.HasRole(teamName, user, role)
+	if err != nil || !hasPerm {
+		return auth.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (ops *DatabaseOperations) get(name string) (*EnvGroup, *database.EnvGroup, error) {
+	dbeg := new(database.EnvGroup)
+	q := ops.DB.Preload("Team").Where(&database.EnvGroup{Name: name}).First(dbeg)
+	if q.RecordNotFound() {
+		return nil, nil, ErrNotFound
+	}
+	if q.Error != nil {
+		return nil, nil, teresa_errors.NewInternalServerError(q.Error)
+	}
+
+	eg, err := newEnvGroup(dbeg)
+	if err != nil {
+		return nil, nil, teresa_errors.NewInternalServerError(err)
+	}
+	return eg, dbeg, nil
+}
+
+func (ops *DatabaseOperations) checkPermAndGet(user *database.User, name, role string) (*EnvGroup, *database.EnvGroup, error) {
+	eg, dbeg, err := ops.get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := ops.checkPerm(user, eg.Team, role); err != nil {
+		return nil, nil, err
+	}
+	return eg, dbeg, nil
+}
+
+func (ops *DatabaseOperations) save(eg *EnvGroup, dbeg *database.EnvGroup) error {
+	evs, err := json.Marshal(eg.EnvVars)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	apps, err := json.Marshal(eg.Apps)
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	dbeg.Name = eg.Name
+	dbeg.EnvVars = database.EncryptedString(evs)
+	dbeg.Apps = string(apps)
+	if err := ops.DB.Save(dbeg).Error; err != nil {
+		return teresa_errors.NewInternalServerError(
+			errors.Wrap(err, fmt.Sprintf("saving env group %s", eg.Name)),
+		)
+	}
+	return nil
+}
+
+func newEnvGroup(dbeg *database.EnvGroup) (*EnvGroup, error) {
+	eg := &EnvGroup{Name: dbeg.Name, Team: dbeg.Team.Name}
+	if err := json.Unmarshal([]byte(dbeg.EnvVars), &eg.EnvVars); err != nil {
+		return nil, errors.Wrap(err, "unmarshal env vars failed")
+	}
+	if err := json.Unmarshal([]byte(dbeg.Apps), &eg.Apps); err != nil {
+		return nil, errors.Wrap(err, "unmarshal apps failed")
+	}
+	return eg, nil
+}
+
+func hasApp(eg *EnvGroup, appName string) bool {
+	for _, a := range eg.Apps {
+		if a == appName {
+			return true
+		}
+	}
+	return false
+}
+
+func removeApp(apps []string, appName string) []string {
+	res := make([]string, 0, len(apps))
+	for _, a := range apps {
+		if a != appName {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+func checkForInvalidEnvVars(evNames []string) error {
+	for _, name := range evNames {
+		if !validation.IsEnvVarName(name) {
+			return app.ErrInvalidEnvVarName
+		}
+		if validation.IsProtectedEnvVar(name) {
+			return app.ErrProtectedEnvVar
+		}
+	}
+	return nil
+}
+
+func NewDatabaseOperations(db *gorm.DB, tops team.Operations, aops app.Operations) Operations {
+	return &DatabaseOperations{DB: db, tops: tops, aops: aops}
+}
@@ -0,0 +1,300 @@
+package envgroup
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+func newTestOperations(t *testing.T, db *gorm.DB, user *database.User) (Operations, *app.FakeOperations) {
+	if err := db.Create(&database.Team{Name: "luizalabs"}).Error; err != nil {
+		t.Fatal("error creating team: ", err)
+	}
+
+	tops := team.NewFakeOperations()
+	tops.(*team.FakeOperations).Storage["luizalabs"] = &database.Team{
+		Name:  "luizalabs",
+		Users: []database.User{*user},
+	}
+	aops := app.NewFakeOperations()
+	aops.Storage["teresa"] = &app.App{Name: "teresa", Team: "luizalabs"}
+
+	return NewDatabaseOperations(db, tops, aops), aops
+}
+
+func TestDatabaseOperationsCreate(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, _ := newTestOperations(t, db, user)
+
+	if err := ops.Create(user, "shared", "luizalabs"); err != nil {
+		t.Fatal("error creating env group: ", err)
+	}
+	if err := ops.Create(user, "shared", "luizalabs"); err != ErrAlreadyExists {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	eg, err := ops.Info(user, "shared")
+	if err != nil {
+		t.Fatal("error getting env group: ", err)
+	}
+	if eg.Team != "luizalabs" {
+		t.Errorf("expected luizalabs, got %s", eg.Team)
+	}
+}
+
+func TestDatabaseOperationsCreateErrors(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, _ := newTestOperations(t, db, user)
+
+	var testCases = []struct {
+		user     *database.User
+		name     string
+		team     string
+		expected error
+	}{
+		{user, "Invalid_Name", "luizalabs", ErrInvalidName},
+		{user, "shared", "unknown", team.ErrNotFound},
+		{&database.User{Email: "other@luizalabs.com"}, "shared", "luizalabs", auth.ErrPermissionDenied},
+	}
+
+	for _, tc := range testCases {
+		if err := ops.Create(tc.user, tc.name, tc.team); err != tc.expected {
+			t.Errorf("expected %v, got %v", tc.expected, err)
+		}
+	}
+}
+
+func TestDatabaseOperationsSetEnvRollsOut(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, aops := newTestOperations(t, db, user)
+
+	if err := ops.Create(user, "shared", "luizalabs"); err != nil {
+		t.Fatal("error creating env group: ", err)
+	}
+	if err := ops.Attach(user, "shared", "teresa"); err != nil {
+		t.Fatal("error attaching env group: ", err)
+	}
+
+	evs := []*app.EnvVar{{Key: "KEY", Value: "VALUE"}}
+	if err := ops.SetEnv(user, "shared", evs); err != nil {
+		t.Fatal("error setting env group vars: ", err)
+	}
+
+	eg, err := ops.Info(user, "shared")
+	if err != nil {
+		t.Fatal("error getting env group: ", err)
+	}
+	if !reflect.DeepEqual(eg.EnvVars, evs) {
+		t.Errorf("expected %v, got %v", evs, eg.EnvVars)
+	}
+	if expected := []string{"shared"}; !reflect.DeepEqual(aops.Storage["teresa"].EnvGroups, expected) {
+		t.Errorf("expected %v, got %v", expected, aops.Storage["teresa"].EnvGroups)
+	}
+
+	if err := ops.UnsetEnv(user, "shared", []string{"KEY"}); err != nil {
+		t.Fatal("error unsetting env group vars: ", err)
+	}
+	if eg, _ = ops.Info(user, "shared"); len(eg.EnvVars) != 0 {
+		t.Errorf("expected no env vars, got %v", eg.EnvVars)
+	}
+}
+
+func TestDatabaseOperationsTeamRoles(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, _ := newTestOperations(t, db, user)
+	roles := make(map[string]string)
+	ops.(*DatabaseOperations).tops.(*team.FakeOperations).Roles["luizalabs"] = roles
+	evs := []*app.EnvVar{{Key: "KEY", Value: "VALUE"}}
+
+	roles[user.Email] = team.RoleDeveloper
+	if err := ops.Create(user, "shared", "luizalabs"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a developer, got %v", err)
+	}
+	roles[user.Email] = team.RoleAdmin
+	if err := ops.Create(user, "shared", "luizalabs"); err != nil {
+		t.Fatal("error creating env group: ", err)
+	}
+
+	roles[user.Email] = team.RoleViewer
+	if _, err := ops.Info(user, "shared"); err != nil {
+		t.Errorf("expected no error for a viewer, got %v", err)
+	}
+	if err := ops.SetEnv(user, "shared", evs); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a viewer, got %v", err)
+	}
+
+	roles[user.Email] = team.RoleDeveloper
+	if err := ops.SetEnv(user, "shared", evs); err != nil {
+		t.Errorf("expected no error for a developer, got %v", err)
+	}
+	if err := ops.UnsetEnv(user, "shared", []string{"KEY"}); err != nil {
+		t.Errorf("expected no error for a developer, got %v", err)
+	}
+	if err := ops.Delete(user, "shared"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a developer, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsDeleteInUse(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, aops := newTestOperations(t, db, user)
+
+	if err := ops.Create(user, "shared", "luizalabs"); err != nil {
+		t.Fatal("error creating env group: ", err)
+	}
+	if err := ops.Attach(user, "shared", "teresa"); err != nil {
+		t.Fatal("error attaching env group: ", err)
+	}
+	if err := ops.Delete(user, "shared"); err != ErrInUse {
+		t.Errorf("expected ErrInUse, got %v", err)
+	}
+
+	if err := ops.Detach(user, "shared", "teresa"); err != nil {
+		t.Fatal("error detaching env group: ", err)
+	}
+	if len(aops.Storage["teresa"].EnvGroups) != 0 {
+		t.Errorf("expected no env groups, got %v", aops.Storage["teresa"].EnvGroups)
+	}
+	if err := ops.Delete(user, "shared"); err != nil {
+		t.Fatal("error deleting env group: ", err)
+	}
+	if _, err := ops.Info(user, "shared"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsDeletedApps(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, _ := newTestOperations(t, db, user)
+
+	for _, name := range []string{"shared", "other"} {
+		if err := ops.Create(user, name, "luizalabs"); err != nil {
+			t.Fatal("error creating env group: ", err)
+		}
+		if err := ops.AttachApp(name, "teresa"); err != nil {
+			t.Fatal("error attaching app: ", err)
+		}
+	}
+
+	// gone was renamed from teresa and deleted without the cleanup
+	if err := ops.RenameApp("teresa", "gone"); err != nil {
+		t.Fatal("error renaming app: ", err)
+	}
+	eg, err := ops.Info(user, "shared")
+	if err != nil {
+		t.Fatal("error getting env group: ", err)
+	}
+	if !reflect.DeepEqual(eg.Apps, []string{"gone"}) {
+		t.Errorf("expected [gone], got %v", eg.Apps)
+	}
+
+	if err := ops.Detach(user, "shared", "gone"); err != nil {
+		t.Fatal("error detaching deleted app: ", err)
+	}
+	if err := ops.Delete(user, "other"); err != nil {
+		t.Fatal("error deleting env group of deleted app: ", err)
+	}
+
+	if err := ops.AttachApp("shared", "teresa"); err != nil {
+		t.Fatal("error attaching app: ", err)
+	}
+	if err := ops.DetachApp("teresa"); err != nil {
+		t.Fatal("error detaching app: ", err)
+	}
+	if eg, _ := ops.Info(user, "shared"); len(eg.Apps) != 0 {
+		t.Errorf("expected no apps, got %v", eg.Apps)
+	}
+}
+
+func TestDatabaseOperationsList(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	user := &database.User{Email: "gopher@luizalabs.com"}
+	ops, _ := newTestOperations(t, db, user)
+
+	if err := ops.Create(user, "shared", "luizalabs"); err != nil {
+		t.Fatal("error creating env group: ", err)
+	}
+
+	egs, err := ops.List(user)
+	if err != nil {
+		t.Fatal("error listing env groups: ", err)
+	}
+	if len(egs) != 1 || egs[0].Name != "shared" {
+		t.Errorf("expected [shared], got %v", egs)
+	}
+
+	egs, err = ops.List(&database.User{Email: "other@luizalabs.com"})
+	if err != nil {
+		t.Fatal("error listing env groups: ", err)
+	}
+	if len(egs) != 0 {
+		t.Errorf("expected no env groups, got %v", egs)
+	}
+}
@@ -0,0 +1,14 @@
+package envgroup
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrAlreadyExists = status.Errorf(codes.AlreadyExists, "Env group already exists")
+	ErrNotFound      = status.Errorf(codes.NotFound, "Env group not found")
+	ErrInvalidName   = status.Errorf(codes.InvalidArgument, "Invalid env group name")
+	ErrInUse         = status.Errorf(codes.FailedPrecondition, "Env group is attached to apps, detach it first")
+	ErrNotAttached   = status.Errorf(codes.NotFound, "Env group not attached to the app")
+)
@@ -0,0 +1,173 @@
+package envgroup
+
+import (
+	"sync"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+type FakeOperations struct {
+	mutex   *sync.RWMutex
+	Storage map[string]*EnvGroup
+}
+
+func hasPerm(email string) bool {
+	return email != "bad-user@luizalabs.com"
+}
+
+func (f *FakeOperations) get(user *database.User, name string) (*EnvGroup, error) {
+	if !hasPerm(user.Email) {
+		return nil, auth.ErrPermissionDenied
+	}
+	eg, found := f.Storage[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return eg, nil
+}
+
+func (f *FakeOperations) Create(user *database.User, name, teamName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !hasPerm(user.Email) {
+		return auth.ErrPermissionDenied
+	}
+	if _, found := f.Storage[name]; found {
+		return ErrAlreadyExists
+	}
+
+	f.Storage[name] = &EnvGroup{Name: name, Team: teamName}
+	return nil
+}
+
+func (f *FakeOperations) Delete(user *database.User, name string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	eg, err := f.get(user, name)
+	if err != nil {
+		return err
+	}
+	if len(eg.Apps) > 0 {
+		return ErrInUse
+	}
+
+	delete(f.Storage, name)
+	return nil
+}
+
+func (f *FakeOperations) List(user *database.User) ([]*EnvGroup, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	var egs []*EnvGroup
+	for _, eg := range f.Storage {
+		egs = append(egs, eg)
+	}
+	return egs, nil
+}
+
+func (f *FakeOperations) Info(user *database.User, name string) (*EnvGroup, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	return f.get(user, name)
+}
+
+func (f *FakeOperations) SetEnv(user *database.User, name string, evs []*app.EnvVar) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	eg, err := f.get(user, name)
+	if err != nil {
+		return err
+	}
+
+	eg.EnvVars = append(eg.EnvVars, evs...)
+	return nil
+}
+
+func (f *FakeOperations) UnsetEnv(user *database.User, name string, evNames []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	_, err := f.get(user, name)
+	return err
+}
+
+func (f *FakeOperations) Attach(user *database.User, name, appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	eg, err := f.get(user, name)
+	if err != nil {
+		return err
+	}
+
+	if !hasApp(eg, appName) {
+		eg.Apps = append(eg.Apps, appName)
+	}
+	return nil
+}
+
+func (f *FakeOperations) Detach(user *database.User, name, appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	eg, err := f.get(user, name)
+	if err != nil {
+		return err
+	}
+	if !hasApp(eg, appName) {
+		return ErrNotAttached
+	}
+
+	eg.Apps = removeApp(eg.Apps, appName)
+	return nil
+}
+
+func (f *FakeOperations) AttachApp(name, appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	eg, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+	if !hasApp(eg, appName) {
+		eg.Apps = append(eg.Apps, appName)
+	}
+	return nil
+}
+
+func (f *FakeOperations) RenameApp(oldName, newName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, eg := range f.Storage {
+		if hasApp(eg, oldName) {
+			eg.Apps = append(removeApp(eg.Apps, oldName), newName)
+		}
+	}
+	return nil
+}
+
+func (f *FakeOperations) DetachApp(appName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, eg := range f.Storage {
+		eg.Apps = removeApp(eg.Apps, appName)
+	}
+	return nil
+}
+
+func NewFakeOperations() Operations {
+	return &FakeOperations{
+		mutex:   &sync.RWMutex{},
+		Storage: make(map[string]*EnvGroup),
+	}
+}
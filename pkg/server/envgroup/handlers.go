@@ -0,0 +1,109 @@
+package envgroup
+
+import (
+	context "golang.org/x/net/context"
+
+	egpb "github.com/luizalabs/teresa/pkg/protobuf/envgroup"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"google.golang.org/grpc"
+)
+
+type Service struct {
+	ops Operations
+}
+
+func (s *Service) Create(ctx context.Context, req *egpb.CreateRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.Create(u, req.Name, req.Team); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) Delete(ctx context.Context, req *egpb.DeleteRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.Delete(u, req.Name); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) List(ctx context.Context, _ *egpb.Empty) (*egpb.ListResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	egs, err := s.ops.List(u)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &egpb.ListResponse{}
+	for _, eg := range egs {
+		resp.EnvGroups = append(resp.EnvGroups, &egpb.ListResponse_EnvGroup{
+			Name: eg.Name,
+			Team: eg.Team,
+			Apps: eg.Apps,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) Info(ctx context.Context, req *egpb.InfoRequest) (*egpb.InfoResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	eg, err := s.ops.Info(u, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &egpb.InfoResponse{Name: eg.Name, Team: eg.Team, Apps: eg.Apps}
+	for _, ev := range eg.EnvVars {
+		resp.EnvVars = append(resp.EnvVars, &egpb.InfoResponse_EnvVar{
+			Key:   ev.Key,
+			Value: ev.Value,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) SetEnv(ctx context.Context, req *egpb.SetEnvRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	evs := make([]*app.EnvVar, len(req.EnvVars))
+	for i, ev := range req.EnvVars {
+		evs[i] = &app.EnvVar{Key: ev.Key, Value: ev.Value}
+	}
+	if err := s.ops.SetEnv(u, req.Name, evs); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) UnsetEnv(ctx context.Context, req *egpb.UnsetEnvRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.UnsetEnv(u, req.Name, req.EnvVars); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) Attach(ctx context.Context, req *egpb.AttachRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.Attach(u, req.Name, req.AppName); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) Detach(ctx context.Context, req *egpb.DetachRequest) (*egpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.Detach(u, req.Name, req.AppName); err != nil {
+		return nil, err
+	}
+	return &egpb.Empty{}, nil
+}
+
+func (s *Service) RegisterService(grpcServer *grpc.Server) {
+	egpb.RegisterEnvGroupServer(grpcServer, s)
+}
+
+func NewService(ops Operations) *Service {
+	return &Service{ops: ops}
+}
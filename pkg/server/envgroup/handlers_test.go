@@ -0,0 +1,71 @@
+package envgroup
+
+import (
+	"testing"
+
+	context "golang.org/x/net/context"
+
+	egpb "github.com/luizalabs/teresa/pkg/protobuf/envgroup"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestEnvGroupCreateSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+
+	req := &egpb.CreateRequest{Name: "shared", Team: "luizalabs"}
+	if _, err := s.Create(ctx, req); err != nil {
+		t.Fatal("Got error on make Create: ", err)
+	}
+
+	if eg := fake.(*FakeOperations).Storage["shared"]; eg.Team != "luizalabs" {
+		t.Errorf("expected luizalabs, got %s", eg.Team)
+	}
+}
+
+func TestEnvGroupCreatePermissionDenied(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "bad-user@luizalabs.com"})
+
+	if _, err := s.Create(ctx, &egpb.CreateRequest{Name: "shared"}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestEnvGroupInfoSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.(*FakeOperations).Storage["shared"] = &EnvGroup{Name: "shared", Team: "luizalabs", Apps: []string{"teresa"}}
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+
+	resp, err := s.Info(ctx, &egpb.InfoRequest{Name: "shared"})
+	if err != nil {
+		t.Fatal("Got error on make Info: ", err)
+	}
+	if len(resp.Apps) != 1 || resp.Apps[0] != "teresa" {
+		t.Errorf("expected [teresa], got %v", resp.Apps)
+	}
+}
+
+func TestEnvGroupInfoNotFound(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+
+	if _, err := s.Info(ctx, &egpb.InfoRequest{Name: "shared"}); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestEnvGroupDetachNotAttached(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.(*FakeOperations).Storage["shared"] = &EnvGroup{Name: "shared", Team: "luizalabs"}
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+
+	req := &egpb.DetachRequest{Name: "shared", AppName: "teresa"}
+	if _, err := s.Detach(ctx, req); err != ErrNotAttached {
+		t.Errorf("expected ErrNotAttached, got %v", err)
+	}
+}
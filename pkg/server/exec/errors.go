@@ -9,4 +9,15 @@ var (
 	ErrDeployNotFound  = status.Errorf(codes.NotFound, "Current deploy not found")
 	ErrNonZeroExitCode = status.Errorf(codes.Unknown, "Exec command returned a non zero value")
 	ErrTimeout         = status.Errorf(codes.Aborted, "Timeout on performing the command")
+	ErrPodNotFound     = status.Errorf(codes.NotFound, "No running pod of the app")
+	ErrInvalidPath     = status.Errorf(codes.InvalidArgument, "Invalid path")
 )
+
+// newCopyError returns the error of a failed copy with the message of tar,
+// like a missing file
+func newCopyError(msg string) error {
+	if msg == "" {
+		return status.Errorf(codes.FailedPrecondition, "Copy failed")
+	}
+	return status.Errorf(codes.FailedPrecondition, "Copy failed: %s", msg)
+}
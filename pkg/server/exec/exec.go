@@ -1,8 +1,12 @@
 package exec
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"path"
+	"strings"
 
 	context "golang.org/x/net/context"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/spec"
 	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"github.com/luizalabs/teresa/pkg/server/uid"
 )
 
@@ -21,13 +26,41 @@ const (
 type Operations interface {
 	RunCommand(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan error)
 	RunCommandBySpec(ctx context.Context, podSpec *spec.Pod) (io.ReadCloser, <-chan error)
+	Run(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan int, error)
+	Shell(ctx context.Context, user *database.User, appName string, command []string, sess *Session) (int, error)
+	CopyFrom(ctx context.Context, user *database.User, appName, path string, w io.Writer) error
+	CopyTo(ctx context.Context, user *database.User, appName, path string, r io.Reader) error
+	SetTeamEnv(te TeamEnv)
 }
 
 type K8sOperations interface {
 	DeployAnnotation(namespace, deployName, annotation string) (string, error)
+	DeployContainerImage(namespace, deployName, container string) (string, error)
 	PodRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error)
+	JobRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error)
 	IsNotFound(err error) bool
 	DeletePod(namespace, podName string) error
+	DeleteJob(namespace, name string) error
+	PodExec(namespace, deployName string, command []string, sess *Session) (int, error)
+}
+
+// TerminalSize is the size in characters of the terminal of the client
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+// Session is the stdin and stdout of an interactive command, the resize
+// events of the terminal of the client are only sent with TTY. Without a
+// Stderr the errors of the command are written to Stdout. The command is
+// stopped once Done is closed
+type Session struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	TTY    bool
+	Resize <-chan TerminalSize
+	Done   <-chan struct{}
 }
 
 type Defaults struct {
@@ -42,6 +75,7 @@ type ExecOperations struct {
 	fs       storage.Storage
 	k8s      K8sOperations
 	defaults *Defaults
+	teamEnv  TeamEnv
 }
 
 func (ops *ExecOperations) RunCommand(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan error) {
@@ -52,26 +86,47 @@ func (ops *ExecOperations) RunCommand(ctx context.Context, user *database.User,
 		return nil, errChan
 	}
 
+	podName := fmt.Sprintf("exec-command-%s-%s", appName, uid.New())
+	podSpec, err := ops.currentDeployPod(a, podName, command)
+	if err != nil {
+		errChan <- err
+		return nil, errChan
+	}
+
+	return ops.RunCommandBySpec(ctx, podSpec)
+}
+
+// currentDeployPod returns a pod running the command from the slug or the
+// image of the current deploy of the app
+func (ops *ExecOperations) currentDeployPod(a *app.App, podName string, command []string) (*spec.Pod, error) {
+	if err := ops.setTeamEnvVars(a); err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
 	currentSlug, err := ops.k8s.DeployAnnotation(a.Name, a.Name, spec.SlugAnnotation)
 	if err != nil {
 		if ops.k8s.IsNotFound(err) {
-			errChan <- ErrDeployNotFound
-		} else {
-			errChan <- err
+			return nil, ErrDeployNotFound
 		}
-		return nil, errChan
+		return nil, err
 	}
 
-	podName := fmt.Sprintf("exec-command-%s-%s", appName, uid.New())
-	podSpec := spec.NewRunnerPodBuilder(podName, ops.defaults.RunnerImage, ops.defaults.StoreImage).
+	podBuilder := spec.NewRunnerPodBuilder(podName, ops.defaults.RunnerImage, ops.defaults.StoreImage).
 		ForApp(a).
 		WithSlug(currentSlug).
 		WithStorage(ops.fs).
 		WithLimits(ops.defaults.LimitsCPU, ops.defaults.LimitsMemory).
-		WithArgs(command).
-		Build()
+		WithArgs(command)
 
-	return ops.RunCommandBySpec(ctx, podSpec)
+	if currentSlug == "" {
+		image, err := ops.k8s.DeployContainerImage(a.Name, a.Name, a.Name)
+		if err != nil {
+			return nil, err
+		}
+		podBuilder = podBuilder.WithImage(image, command)
+	}
+
+	return podBuilder.Build(), nil
 }
 
 func (ops *ExecOperations) RunCommandBySpec(ctx context.Context, podSpec *spec.Pod) (io.ReadCloser, <-chan error) {
@@ -105,6 +160,99 @@ func (ops *ExecOperations) RunCommandBySpec(ctx context.Context, podSpec *spec.P
 	return r, errChan
 }
 
+// Run runs the command in a Job from the current deploy of the app, with its
+// env vars. The exit code of the command is sent once it ends, the Job is
+// deleted if ctx is done before
+func (ops *ExecOperations) Run(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan int, error) {
+	a, err := ops.appOps.CheckPermAndGet(user, appName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jobName := fmt.Sprintf("run-%s-%s", appName, uid.New())
+	podSpec, err := ops.currentDeployPod(a, jobName, command)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, jobExitCode, err := ops.k8s.JobRun(podSpec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exitCode := make(chan int, 1)
+	go func() {
+		defer close(exitCode)
+
+		select {
+		case <-ctx.Done():
+			go ops.k8s.DeleteJob(podSpec.Namespace, podSpec.Name)
+		case ec := <-jobExitCode:
+			exitCode <- ec
+		}
+	}()
+	return rc, exitCode, nil
+}
+
+// Shell runs the command interactively in a running pod of the app, like a
+// shell, returning its exit code. The command has the env of the pod, the
+// team env vars included as of its deploy
+func (ops *ExecOperations) Shell(ctx context.Context, user *database.User, appName string, command []string, sess *Session) (int, error) {
+	a, err := ops.appOps.CheckPermAndGet(user, appName)
+	if err != nil {
+		return 0, err
+	}
+
+	sess.Done = ctx.Done()
+	return ops.k8s.PodExec(a.Name, a.Name, command, sess)
+}
+
+// CopyFrom writes a tar of the file or directory of the path in a running
+// pod of the app to w, the entries are named after the base of the path
+func (ops *ExecOperations) CopyFrom(ctx context.Context, user *database.User, appName, filePath string, w io.Writer) error {
+	if filePath == "" {
+		return ErrInvalidPath
+	}
+	a, err := ops.appOps.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"tar", "cf", "-", "-C", path.Dir(filePath), path.Base(filePath)}
+	return ops.podCopy(ctx, a, command, nil, w)
+}
+
+// CopyTo extracts the tar read from r in the directory of the path in a
+// running pod of the app
+func (ops *ExecOperations) CopyTo(ctx context.Context, user *database.User, appName, dir string, r io.Reader) error {
+	if dir == "" {
+		return ErrInvalidPath
+	}
+	a, err := ops.appOps.CheckPermAndGet(user, appName)
+	if err != nil {
+		return err
+	}
+
+	command := []string{"tar", "xf", "-", "-C", dir}
+	return ops.podCopy(ctx, a, command, r, ioutil.Discard)
+}
+
+func (ops *ExecOperations) podCopy(ctx context.Context, a *app.App, command []string, in io.Reader, out io.Writer) error {
+	stderr := new(bytes.Buffer)
+	sess := &Session{Stdin: in, Stdout: out, Stderr: stderr, Done: ctx.Done()}
+	exitCode, err := ops.k8s.PodExec(a.Name, a.Name, command, sess)
+	if err != nil {
+		if err == ErrPodNotFound {
+			return err
+		}
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if exitCode != 0 {
+		return newCopyError(strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
 func NewOperations(appOps app.Operations, k8s K8sOperations, fs storage.Storage, defaults *Defaults) Operations {
 	return &ExecOperations{
 		appOps:   appOps,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/spec"
 	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
 	context "golang.org/x/net/context"
 )
 
@@ -22,12 +24,42 @@ type fakeK8sOperations struct {
 	isNotFound          bool
 	exitCodePodRun      int
 	podRunDelay         int
+	imageDeploy         bool
+	jobPodSpec          *spec.Pod
+	stderrPodExec       string
 }
 
 func (f *fakeK8sOperations) DeployAnnotation(namespace string, deployName string, annotation string) (string, error) {
+	if f.imageDeploy {
+		return "", f.errDeployAnnotation
+	}
 	return "slug", f.errDeployAnnotation
 }
 
+func (f *fakeK8sOperations) DeployContainerImage(namespace, deployName, container string) (string, error) {
+	return "luizalabs/teresa:v1", nil
+}
+
+func (f *fakeK8sOperations) JobRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error) {
+	f.jobPodSpec = podSpec
+	return f.PodRun(podSpec)
+}
+
+func (f *fakeK8sOperations) DeleteJob(namespace, name string) error {
+	return nil
+}
+
+func (f *fakeK8sOperations) PodExec(namespace, deployName string, command []string, sess *Session) (int, error) {
+	if sess.Stdin != nil {
+		io.Copy(ioutil.Discard, sess.Stdin)
+	}
+	fmt.Fprintf(sess.Stdout, "%s@%s", strings.Join(command, " "), deployName)
+	if sess.Stderr != nil {
+		fmt.Fprint(sess.Stderr, f.stderrPodExec)
+	}
+	return f.exitCodePodRun, nil
+}
+
 func (f *fakeK8sOperations) PodRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error) {
 	r := bytes.NewBufferString("foo\nbar")
 
@@ -135,3 +167,148 @@ func TestOpsRunCommandBySpecContextCancelation(t *testing.T) {
 		t.Errorf("expected context canceled, got %v", err)
 	}
 }
+
+func TestOpsRun(t *testing.T) {
+	k8sOps := &fakeK8sOperations{exitCodePodRun: 3}
+	ops := NewOperations(app.NewFakeOperations(), k8sOps, storage.NewFake(), &Defaults{})
+
+	rc, exitCode, err := ops.Run(context.Background(), &database.User{}, "teresa", "ls")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	defer rc.Close()
+
+	if ec := <-exitCode; ec != 3 {
+		t.Errorf("expected exit code 3, got %d", ec)
+	}
+	if name := k8sOps.jobPodSpec.Name; !strings.HasPrefix(name, "run-teresa-") {
+		t.Errorf("expected job run-teresa-*, got %s", name)
+	}
+}
+
+func TestOpsRunImageDeploy(t *testing.T) {
+	k8sOps := &fakeK8sOperations{imageDeploy: true}
+	ops := NewOperations(app.NewFakeOperations(), k8sOps, storage.NewFake(), &Defaults{RunnerImage: "runner"})
+
+	rc, exitCode, err := ops.Run(context.Background(), &database.User{}, "teresa", "ls")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	defer rc.Close()
+	<-exitCode
+
+	if image := k8sOps.jobPodSpec.Containers[0].Image; image != "luizalabs/teresa:v1" {
+		t.Errorf("expected the image of the deploy, got %s", image)
+	}
+}
+
+type fakeTeamEnv struct {
+	evs []*team.EnvVar
+}
+
+func (f *fakeTeamEnv) EnvVars(teamName string) ([]*team.EnvVar, error) {
+	return f.evs, nil
+}
+
+func TestOpsRunTeamEnvVars(t *testing.T) {
+	k8sOps := &fakeK8sOperations{}
+	ops := NewOperations(app.NewFakeOperations(), k8sOps, storage.NewFake(), &Defaults{})
+	ops.SetTeamEnv(&fakeTeamEnv{evs: []*team.EnvVar{{Key: "LOG_LEVEL", Value: "info"}}})
+
+	rc, exitCode, err := ops.Run(context.Background(), &database.User{}, "teresa", "ls")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	defer rc.Close()
+	<-exitCode
+
+	if v := k8sOps.jobPodSpec.Containers[0].Env["LOG_LEVEL"]; v != "info" {
+		t.Errorf("expected the env var of the team, got %q", v)
+	}
+}
+
+func TestOpsRunErrors(t *testing.T) {
+	var testCases = []struct {
+		user        *database.User
+		appName     string
+		k8sOps      *fakeK8sOperations
+		expectedErr error
+	}{
+		{&database.User{}, "notfound", &fakeK8sOperations{}, app.ErrNotFound},
+		{&database.User{Email: "bad-user@luizalabs.com"}, "teresa", &fakeK8sOperations{}, auth.ErrPermissionDenied},
+		{&database.User{}, "teresa", &fakeK8sOperations{errDeployAnnotation: fmt.Errorf("not found"), isNotFound: true}, ErrDeployNotFound},
+	}
+
+	for _, tc := range testCases {
+		ops := NewOperations(app.NewFakeOperations(), tc.k8sOps, storage.NewFake(), &Defaults{})
+		if _, _, err := ops.Run(context.Background(), tc.user, tc.appName, "ls"); err != tc.expectedErr {
+			t.Errorf("expected %v, got %v", tc.expectedErr, err)
+		}
+	}
+}
+
+func TestOpsShell(t *testing.T) {
+	k8sOps := &fakeK8sOperations{exitCodePodRun: 130}
+	ops := NewOperations(app.NewFakeOperations(), k8sOps, storage.NewFake(), &Defaults{})
+
+	out := new(bytes.Buffer)
+	sess := &Session{Stdin: new(bytes.Buffer), Stdout: out, TTY: true}
+	exitCode, err := ops.Shell(context.Background(), &database.User{}, "teresa", []string{"bash"}, sess)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if exitCode != 130 {
+		t.Errorf("expected exit code 130, got %d", exitCode)
+	}
+	if out.String() != "bash@teresa" {
+		t.Errorf("expected bash in the app deploy, got %q", out.String())
+	}
+}
+
+func TestOpsShellPermissionDenied(t *testing.T) {
+	ops := NewOperations(app.NewFakeOperations(), &fakeK8sOperations{}, storage.NewFake(), &Defaults{})
+
+	sess := &Session{Stdin: new(bytes.Buffer), Stdout: new(bytes.Buffer)}
+	_, err := ops.Shell(context.Background(), &database.User{Email: "bad-user@luizalabs.com"}, "teresa", []string{"bash"}, sess)
+	if err != auth.ErrPermissionDenied {
+		t.Errorf("expected auth.ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestOpsCopyFrom(t *testing.T) {
+	ops := NewOperations(app.NewFakeOperations(), &fakeK8sOperations{}, storage.NewFake(), &Defaults{})
+
+	out := new(bytes.Buffer)
+	if err := ops.CopyFrom(context.Background(), &database.User{}, "teresa", "/tmp/heap.hprof", out); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if expected := "tar cf - -C /tmp heap.hprof@teresa"; out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
+
+func TestOpsCopyTo(t *testing.T) {
+	ops := NewOperations(app.NewFakeOperations(), &fakeK8sOperations{}, storage.NewFake(), &Defaults{})
+
+	if err := ops.CopyTo(context.Background(), &database.User{}, "teresa", "/tmp", strings.NewReader("tar")); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+}
+
+func TestOpsCopyErrors(t *testing.T) {
+	k8sOps := &fakeK8sOperations{exitCodePodRun: 2, stderrPodExec: "tar: foo: No such file or directory\n"}
+	ops := NewOperations(app.NewFakeOperations(), k8sOps, storage.NewFake(), &Defaults{})
+	ctx := context.Background()
+
+	if err := ops.CopyFrom(ctx, &database.User{}, "teresa", "", new(bytes.Buffer)); err != ErrInvalidPath {
+		t.Errorf("expected ErrInvalidPath, got %v", err)
+	}
+	if err := ops.CopyTo(ctx, &database.User{Email: "bad-user@luizalabs.com"}, "teresa", "/tmp", new(bytes.Buffer)); err != auth.ErrPermissionDenied {
+		t.Errorf("expected auth.ErrPermissionDenied, got %v", err)
+	}
+
+	err := ops.CopyFrom(ctx, &database.User{}, "teresa", "foo", new(bytes.Buffer))
+	if err == nil || !strings.Contains(err.Error(), "No such file or directory") {
+		t.Errorf("expected the error of tar, got %v", err)
+	}
+}
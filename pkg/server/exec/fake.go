@@ -3,6 +3,7 @@ package exec
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/spec"
@@ -10,7 +11,8 @@ import (
 )
 
 type FakeOperations struct {
-	ExpectedErr error
+	ExpectedErr      error
+	ExpectedExitCode int
 }
 
 func (f *FakeOperations) RunCommand(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan error) {
@@ -30,6 +32,54 @@ func (f *FakeOperations) RunCommandBySpec(ctx context.Context, podSpec *spec.Pod
 	return r, errChan
 }
 
+func (f *FakeOperations) Run(ctx context.Context, user *database.User, appName string, command ...string) (io.ReadCloser, <-chan int, error) {
+	if f.ExpectedErr != nil {
+		return nil, nil, f.ExpectedErr
+	}
+
+	exitCode := make(chan int, 1)
+	r, w := io.Pipe()
+	go func() {
+		defer w.Close()
+
+		fmt.Fprintf(w, "command output")
+		exitCode <- f.ExpectedExitCode
+	}()
+
+	return r, exitCode, nil
+}
+
+func (f *FakeOperations) Shell(ctx context.Context, user *database.User, appName string, command []string, sess *Session) (int, error) {
+	if f.ExpectedErr != nil {
+		return 0, f.ExpectedErr
+	}
+
+	if _, err := io.Copy(sess.Stdout, sess.Stdin); err != nil {
+		return 0, err
+	}
+	return f.ExpectedExitCode, nil
+}
+
+func (f *FakeOperations) CopyFrom(ctx context.Context, user *database.User, appName, path string, w io.Writer) error {
+	if f.ExpectedErr != nil {
+		return f.ExpectedErr
+	}
+
+	_, err := fmt.Fprintf(w, "tar of %s", path)
+	return err
+}
+
+func (f *FakeOperations) CopyTo(ctx context.Context, user *database.User, appName, dir string, r io.Reader) error {
+	if f.ExpectedErr != nil {
+		return f.ExpectedErr
+	}
+
+	_, err := io.Copy(ioutil.Discard, r)
+	return err
+}
+
+func (f *FakeOperations) SetTeamEnv(te TeamEnv) {}
+
 func NewFakeOperations() *FakeOperations {
 	return new(FakeOperations)
 }
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"io"
 	"time"
 
 	"github.com/luizalabs/teresa/pkg/goutil"
@@ -49,6 +50,162 @@ func (s *Service) Command(req *execpb.CommandRequest, stream execpb.Exec_Command
 	}
 }
 
+func (s *Service) Run(req *execpb.RunRequest, stream execpb.Exec_RunServer) error {
+	ctx := stream.Context()
+	u := ctx.Value("user").(*database.User)
+
+	rc, exitCode, err := s.ops.Run(ctx, u, req.AppName, req.Command...)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	cmdMsgs, cmdErrCh := goutil.LineGenerator(rc)
+	var msg string
+	for {
+		select {
+		case <-time.After(s.keepAliveTimeout):
+			msg = keepAliveMessage
+		case err := <-cmdErrCh:
+			return err
+		case m, ok := <-cmdMsgs:
+			if !ok {
+				return stream.Send(&execpb.RunResponse{Finished: true, ExitCode: int32(<-exitCode)})
+			}
+			msg = m
+		}
+
+		if err := stream.Send(&execpb.RunResponse{Text: msg + "\n"}); err != nil {
+			return err
+		}
+	}
+}
+
+// shellWriter sends the output of an interactive command to the stream
+type shellWriter struct {
+	stream execpb.Exec_ShellServer
+}
+
+func (w *shellWriter) Write(p []byte) (int, error) {
+	out := make([]byte, len(p))
+	copy(out, p)
+	if err := w.stream.Send(&execpb.ShellResponse{Stdout: out}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Shell runs an interactive command, the first message has the command and
+// the next ones the stdin and the resize events of the terminal
+func (s *Service) Shell(stream execpb.Exec_ShellServer) error {
+	ctx := stream.Context()
+	u := ctx.Value("user").(*database.User)
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	stdinR, stdinW := io.Pipe()
+	defer stdinR.Close()
+	resize := make(chan TerminalSize, 1)
+	if req.Width > 0 && req.Height > 0 {
+		resize <- TerminalSize{Width: uint16(req.Width), Height: uint16(req.Height)}
+	}
+	go func() {
+		for {
+			in, err := stream.Recv()
+			if err != nil {
+				stdinW.CloseWithError(err)
+				return
+			}
+			if len(in.Stdin) > 0 {
+				if _, err := stdinW.Write(in.Stdin); err != nil {
+					return
+				}
+			}
+			if in.Width > 0 && in.Height > 0 {
+				select {
+				case resize <- TerminalSize{Width: uint16(in.Width), Height: uint16(in.Height)}:
+				default:
+				}
+			}
+		}
+	}()
+
+	sess := &Session{
+		Stdin:  stdinR,
+		Stdout: &shellWriter{stream: stream},
+		TTY:    req.Tty,
+		Resize: resize,
+	}
+	exitCode, err := s.ops.Shell(ctx, u, req.AppName, req.Command, sess)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&execpb.ShellResponse{Finished: true, ExitCode: int32(exitCode)})
+}
+
+// copyFromWriter sends the tar of the copied path to the stream
+type copyFromWriter struct {
+	stream execpb.Exec_CopyFromServer
+}
+
+func (w *copyFromWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&execpb.CopyFromResponse{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *Service) CopyFrom(req *execpb.CopyFromRequest, stream execpb.Exec_CopyFromServer) error {
+	ctx := stream.Context()
+	u := ctx.Value("user").(*database.User)
+
+	return s.ops.CopyFrom(ctx, u, req.AppName, req.Path, &copyFromWriter{stream: stream})
+}
+
+// CopyTo extracts a tar in a directory of the app, the first message has
+// the app and the directory and all of them the data of the tar
+func (s *Service) CopyTo(stream execpb.Exec_CopyToServer) error {
+	ctx := stream.Context()
+	u := ctx.Value("user").(*database.User)
+
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+	defer r.Close()
+	go func() {
+		data := req.Data
+		for {
+			if len(data) > 0 {
+				if _, err := w.Write(data); err != nil {
+					return
+				}
+			}
+			in, err := stream.Recv()
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				w.CloseWithError(err)
+				return
+			}
+			data = in.Data
+		}
+	}()
+
+	if err := s.ops.CopyTo(ctx, u, req.AppName, req.Path, r); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&execpb.CopyToResponse{})
+}
+
 func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	execpb.RegisterExecServer(grpcServer, s)
 }
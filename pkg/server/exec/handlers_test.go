@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"io"
 	"testing"
 	"time"
 
@@ -31,3 +32,163 @@ func TestCommand(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+type runStreamWrapper struct {
+	execpb.Exec_RunServer
+	ctx  context.Context
+	last *execpb.RunResponse
+}
+
+func (sw *runStreamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *runStreamWrapper) Send(resp *execpb.RunResponse) error {
+	sw.last = resp
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.ExpectedExitCode = 2
+	s := NewService(fake, 1*time.Minute)
+
+	user := &database.User{}
+	ctx := context.WithValue(context.Background(), "user", user)
+	req := &execpb.RunRequest{AppName: "teresa", Command: []string{"ls"}}
+
+	wrap := &runStreamWrapper{ctx: ctx}
+	if err := s.Run(req, wrap); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !wrap.last.Finished || wrap.last.ExitCode != 2 {
+		t.Errorf("expected finished with exit code 2, got %+v", wrap.last)
+	}
+}
+
+type shellStreamWrapper struct {
+	execpb.Exec_ShellServer
+	ctx  context.Context
+	reqs []*execpb.ShellRequest
+	out  []byte
+	last *execpb.ShellResponse
+}
+
+func (sw *shellStreamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *shellStreamWrapper) Recv() (*execpb.ShellRequest, error) {
+	if len(sw.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := sw.reqs[0]
+	sw.reqs = sw.reqs[1:]
+	return req, nil
+}
+
+func (sw *shellStreamWrapper) Send(resp *execpb.ShellResponse) error {
+	sw.out = append(sw.out, resp.Stdout...)
+	sw.last = resp
+	return nil
+}
+
+func TestShell(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.ExpectedExitCode = 1
+	s := NewService(fake, 1*time.Minute)
+
+	user := &database.User{}
+	ctx := context.WithValue(context.Background(), "user", user)
+	wrap := &shellStreamWrapper{
+		ctx: ctx,
+		reqs: []*execpb.ShellRequest{
+			{AppName: "teresa", Command: []string{"bash"}, Tty: true, Width: 80, Height: 24},
+			{Stdin: []byte("ls\n")},
+			{Width: 120, Height: 40},
+			{Stdin: []byte("exit 1\n")},
+		},
+	}
+	if err := s.Shell(wrap); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(wrap.out) != "ls\nexit 1\n" {
+		t.Errorf("expected the stdin echoed, got %q", wrap.out)
+	}
+	if !wrap.last.Finished || wrap.last.ExitCode != 1 {
+		t.Errorf("expected finished with exit code 1, got %+v", wrap.last)
+	}
+}
+
+type copyFromStreamWrapper struct {
+	execpb.Exec_CopyFromServer
+	ctx context.Context
+	out []byte
+}
+
+func (sw *copyFromStreamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *copyFromStreamWrapper) Send(resp *execpb.CopyFromResponse) error {
+	sw.out = append(sw.out, resp.Data...)
+	return nil
+}
+
+func TestCopyFrom(t *testing.T) {
+	s := NewService(NewFakeOperations(), 1*time.Minute)
+
+	ctx := context.WithValue(context.Background(), "user", &database.User{})
+	wrap := &copyFromStreamWrapper{ctx: ctx}
+	req := &execpb.CopyFromRequest{AppName: "teresa", Path: "/tmp/heap.hprof"}
+	if err := s.CopyFrom(req, wrap); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(wrap.out) != "tar of /tmp/heap.hprof" {
+		t.Errorf("expected the tar of the path, got %q", wrap.out)
+	}
+}
+
+type copyToStreamWrapper struct {
+	execpb.Exec_CopyToServer
+	ctx    context.Context
+	reqs   []*execpb.CopyToRequest
+	closed bool
+}
+
+func (sw *copyToStreamWrapper) Context() context.Context {
+	return sw.ctx
+}
+
+func (sw *copyToStreamWrapper) Recv() (*execpb.CopyToRequest, error) {
+	if len(sw.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := sw.reqs[0]
+	sw.reqs = sw.reqs[1:]
+	return req, nil
+}
+
+func (sw *copyToStreamWrapper) SendAndClose(resp *execpb.CopyToResponse) error {
+	sw.closed = true
+	return nil
+}
+
+func TestCopyTo(t *testing.T) {
+	s := NewService(NewFakeOperations(), 1*time.Minute)
+
+	ctx := context.WithValue(context.Background(), "user", &database.User{})
+	wrap := &copyToStreamWrapper{
+		ctx: ctx,
+		reqs: []*execpb.CopyToRequest{
+			{AppName: "teresa", Path: "/tmp", Data: []byte("first")},
+			{Data: []byte("second")},
+		},
+	}
+	if err := s.CopyTo(wrap); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !wrap.closed || len(wrap.reqs) != 0 {
+		t.Errorf("expected all the data read and the stream closed")
+	}
+}
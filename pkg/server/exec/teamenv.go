@@ -0,0 +1,36 @@
+package exec
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/team"
+)
+
+// TeamEnv gives the default env vars of the apps of a team
+type TeamEnv interface {
+	EnvVars(teamName string) ([]*team.EnvVar, error)
+}
+
+func (ops *ExecOperations) SetTeamEnv(te TeamEnv) {
+	ops.teamEnv = te
+}
+
+// setTeamEnvVars sets the default env vars of the team of the app on it, so
+// the commands run with the env of the deploys
+func (ops *ExecOperations) setTeamEnvVars(a *app.App) error {
+	if ops.teamEnv == nil {
+		return nil
+	}
+	teamName, err := ops.appOps.TeamName(a.Name)
+	if err != nil {
+		return err
+	}
+	evs, err := ops.teamEnv.EnvVars(teamName)
+	if err != nil {
+		return err
+	}
+	a.TeamEnvVars = make([]*app.EnvVar, len(evs))
+	for i, ev := range evs {
+		a.TeamEnvVars[i] = &app.EnvVar{Key: ev.Key, Value: ev.Value}
+	}
+	return nil
+}
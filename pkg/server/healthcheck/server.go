@@ -2,14 +2,20 @@ package healthcheck
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	context "golang.org/x/net/context"
 
 	"github.com/jinzhu/gorm"
 )
 
+// pingTimeout bounds the ping of the database, it waits for a connection
+// when the pool is exhausted
+const pingTimeout = time.Second
+
 type K8sOperations interface {
 	HealthCheck() error
 }
@@ -20,15 +26,46 @@ type Server struct {
 	httpServer *http.Server
 }
 
+// dbPoolStats are the stats of the connection pool of the database, the
+// servers built with Go older than 1.11 have the open connections only
+type dbPoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
 type healthCheckResponse struct {
-	K8sError string `json:"k8s_error"`
-	DBError  string `json:"db_error"`
+	K8sError string       `json:"k8s_error"`
+	DBError  string       `json:"db_error"`
+	DBPool   *dbPoolStats `json:"db_pool"`
+}
+
+func (s *Server) pingDB() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return s.DB.DB().PingContext(ctx)
+}
+
+// liveness answers OK while the server serves requests. Kubernetes and the
+// database are left to the readiness (healthCheck), restarting the server
+// fixes neither of them nor an exhausted connection pool.
+func (s *Server) liveness(w http.ResponseWriter, _ *http.Request) {
+	w.Write([]byte("OK"))
 }
 
-func (s *Server) healthCheck(w http.ResponseWriter, _ *http.Request) {
+// healthCheck is the readiness of the server, it answers OK when healthy,
+// the errors and the stats of the connection pool otherwise, or always with
+// ?verbose=true
+func (s *Server) healthCheck(w http.ResponseWriter, r *http.Request) {
 	k8sError := s.k8s.HealthCheck()
-	dbError := s.DB.DB().Ping()
-	if k8sError != nil || dbError != nil {
+	dbError := s.pingDB()
+	verbose := r.URL.Query().Get("verbose") == "true"
+	if k8sError != nil || dbError != nil || verbose {
 		k8sErrorMsg := ""
 		if k8sError != nil {
 			k8sErrorMsg = k8sError.Error()
@@ -39,16 +76,47 @@ func (s *Server) healthCheck(w http.ResponseWriter, _ *http.Request) {
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
+		if k8sError != nil || dbError != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 		json.NewEncoder(w).Encode(healthCheckResponse{
 			K8sError: k8sErrorMsg,
 			DBError:  dbErrorMsg,
+			DBPool:   newDBPoolStats(s.DB.DB()),
 		})
 		return
 	}
 	w.Write([]byte("OK"))
 }
 
+// metrics writes the status and the connection pool stats of the database
+// in the text format of Prometheus
+func (s *Server) metrics(w http.ResponseWriter, _ *http.Request) {
+	up := 1
+	if s.pingDB() != nil {
+		up = 0
+	}
+	st := newDBPoolStats(s.DB.DB())
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	for _, m := range []struct {
+		name, kind, help string
+		value            interface{}
+	}{
+		{"teresa_db_up", "gauge", "Whether the database answers the ping.", up},
+		{"teresa_db_max_open_connections", "gauge", "Maximum number of open connections, 0 is unlimited.", st.MaxOpenConnections},
+		{"teresa_db_open_connections", "gauge", "Number of open connections, in use and idle.", st.OpenConnections},
+		{"teresa_db_in_use_connections", "gauge", "Number of connections in use.", st.InUse},
+		{"teresa_db_idle_connections", "gauge", "Number of idle connections.", st.Idle},
+		{"teresa_db_wait_count_total", "counter", "Number of waits for a connection.", st.WaitCount},
+		{"teresa_db_wait_duration_seconds_total", "counter", "Time waited for connections.", float64(st.WaitDurationMs) / 1000},
+		{"teresa_db_max_idle_closed_total", "counter", "Number of connections closed by the idle limit.", st.MaxIdleClosed},
+		{"teresa_db_max_lifetime_closed_total", "counter", "Number of connections closed by the lifetime limit.", st.MaxLifetimeClosed},
+	} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", m.name, m.help, m.name, m.kind, m.name, m.value)
+	}
+}
+
 func (s *Server) Run(l net.Listener) error {
 	return s.httpServer.Serve(l)
 }
@@ -61,6 +129,8 @@ func New(k K8sOperations, db *gorm.DB) *Server {
 	s := &Server{k8s: k, DB: db}
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthcheck/", s.healthCheck)
+	mux.HandleFunc("/healthcheck/live", s.liveness)
+	mux.HandleFunc("/metrics", s.metrics)
 
 	server := &http.Server{Handler: mux}
 	s.httpServer = server
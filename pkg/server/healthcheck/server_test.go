@@ -1,11 +1,13 @@
 package healthcheck
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/jinzhu/gorm"
@@ -69,3 +71,120 @@ func TestHealthCheckHandlerError(t *testing.T) {
 		t.Errorf("expected %s, got %s", expectedK8sErrorMsg, hcRes.K8sError)
 	}
 }
+
+func TestHealthCheckHandlerVerbose(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database", err)
+	}
+	defer db.Close()
+	db.DB().SetMaxOpenConns(5)
+	s := New(&fakeK8s{}, db)
+
+	req, err := http.NewRequest("GET", "/healthcheck/?verbose=true", nil)
+	if err != nil {
+		t.Fatal("error creating http request", err)
+	}
+	res := httptest.NewRecorder()
+	s.healthCheck(res, req)
+
+	if res.Code != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, res.Code)
+	}
+	hcRes := new(healthCheckResponse)
+	if err := json.NewDecoder(res.Body).Decode(hcRes); err != nil {
+		t.Fatal("error decoding response", err)
+	}
+	if hcRes.DBError != "" || hcRes.DBPool == nil {
+		t.Errorf("expected the pool stats without errors, got %+v", hcRes)
+	}
+}
+
+func TestHealthCheckHandlerPoolExhausted(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database", err)
+	}
+	defer db.Close()
+	db.DB().SetMaxOpenConns(1)
+	conn, err := db.DB().Conn(context.Background())
+	if err != nil {
+		t.Fatal("error getting connection", err)
+	}
+	defer conn.Close()
+	s := New(&fakeK8s{}, db)
+
+	req, err := http.NewRequest("GET", "/healthcheck/", nil)
+	if err != nil {
+		t.Fatal("error creating http request", err)
+	}
+	res := httptest.NewRecorder()
+	s.healthCheck(res, req)
+
+	if res.Code != http.StatusInternalServerError {
+		t.Errorf("expected %d, got %d", http.StatusInternalServerError, res.Code)
+	}
+	hcRes := new(healthCheckResponse)
+	if err := json.NewDecoder(res.Body).Decode(hcRes); err != nil {
+		t.Fatal("error decoding response", err)
+	}
+	if hcRes.DBError == "" {
+		t.Error("expected the ping timed out")
+	}
+	if hcRes.DBPool == nil || hcRes.DBPool.OpenConnections != 1 {
+		t.Errorf("expected the pool exhausted, got %+v", hcRes.DBPool)
+	}
+}
+
+func TestLivenessPoolExhausted(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database", err)
+	}
+	defer db.Close()
+	db.DB().SetMaxOpenConns(1)
+	conn, err := db.DB().Conn(context.Background())
+	if err != nil {
+		t.Fatal("error getting connection", err)
+	}
+	defer conn.Close()
+	s := New(&fakeK8s{err: errors.New("boom")}, db)
+
+	req, err := http.NewRequest("GET", "/healthcheck/live", nil)
+	if err != nil {
+		t.Fatal("error creating http request", err)
+	}
+	res := httptest.NewRecorder()
+	s.httpServer.Handler.ServeHTTP(res, req)
+
+	if res.Code != http.StatusOK || res.Body.String() != "OK" {
+		t.Errorf("expected %d OK, got %d %s", http.StatusOK, res.Code, res.Body.String())
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database", err)
+	}
+	defer db.Close()
+	s := New(&fakeK8s{}, db)
+
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	if err != nil {
+		t.Fatal("error creating http request", err)
+	}
+	res := httptest.NewRecorder()
+	s.metrics(res, req)
+
+	body := res.Body.String()
+	for _, line := range []string{
+		"# TYPE teresa_db_up gauge\nteresa_db_up 1\n",
+		"# TYPE teresa_db_max_open_connections gauge\nteresa_db_max_open_connections 0\n",
+		"# TYPE teresa_db_wait_count_total counter\nteresa_db_wait_count_total 0\n",
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected %q in %s", line, body)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// +build go1.11
+
+package healthcheck
+
+import (
+	"database/sql"
+	"time"
+)
+
+func newDBPoolStats(db *sql.DB) *dbPoolStats {
+	s := db.Stats()
+	return &dbPoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     int64(s.WaitDuration / time.Millisecond),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,11 @@
+// +build !go1.11
+
+package healthcheck
+
+import "database/sql"
+
+// newDBPoolStats has the open connections only, the other stats came with
+// Go 1.11
+func newDBPoolStats(db *sql.DB) *dbPoolStats {
+	return &dbPoolStats{OpenConnections: db.Stats().OpenConnections}
+}
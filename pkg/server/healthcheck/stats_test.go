@@ -0,0 +1,35 @@
+// +build go1.11
+
+package healthcheck
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func TestNewDBPoolStats(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database", err)
+	}
+	defer db.Close()
+	db.DB().SetMaxOpenConns(1)
+	conn, err := db.DB().Conn(context.Background())
+	if err != nil {
+		t.Fatal("error getting connection", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	db.DB().PingContext(ctx)
+
+	st := newDBPoolStats(db.DB())
+	if st.MaxOpenConnections != 1 || st.InUse != 1 || st.WaitCount != 1 {
+		t.Errorf("expected the pool exhausted, got %+v", st)
+	}
+}
@@ -1,10 +1,15 @@
 package k8s
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
+	"path"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -14,7 +19,7 @@ import (
 	"github.com/pkg/errors"
 
 	"k8s.io/api/apps/v1beta2"
-	asv1 "k8s.io/api/autoscaling/v1"
+	asv2 "k8s.io/api/autoscaling/v2beta1"
 	"k8s.io/api/batch/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,32 +39,186 @@ const (
 	patchCronJobEnvVarsTmpl           = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update env vars"}}, "spec":{"template":{"metadata":{"annotations":{"date": "%s"}}}, "jobTemplate":{"spec": {"template": {"spec": {"containers":%s}}}}}}`
 	patchDeployRollbackToRevisionTmpl = `{"spec":{"rollbackTo":{"revision": %s}}}`
 	patchDeployReplicasTmpl           = `{"spec":{"replicas": %d}}`
+	patchDeployRestartTmpl            = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "restart"}}, "spec":{"template":{"metadata": {"annotations": {"date": "%s"}}}}}`
 	patchServiceAnnotationsTmpl       = `{"metadata":{"annotations": %s}}`
+	patchDeployResourcesTmpl          = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update resources"}}, "spec":{"template":{"spec":{"containers":%s}}}}`
+	patchDeployLifecycleTmpl          = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update lifecycle"}}, "spec":{"template":{"spec":{"terminationGracePeriodSeconds": %d, "containers":%s}}}}`
+	patchDeployRollingUpdateTmpl      = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update rolling update strategy"}}, "spec":{"strategy":{"rollingUpdate":%s}}}`
+	patchCronJobResourcesTmpl         = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update resources"}}, "spec":{"jobTemplate":{"spec": {"template": {"spec": {"containers":%s}}}}}}`
+	patchDeployEnvFromTmpl            = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update env groups"}}, "spec":{"template":{"spec":{"containers":%s}}}}`
+	patchCronJobEnvFromTmpl           = `{"metadata": {"annotations": {"kubernetes.io/change-cause": "update env groups"}}, "spec":{"jobTemplate":{"spec": {"template": {"spec": {"containers":%s}}}}}}`
 	revisionAnnotation                = "deployment.kubernetes.io/revision"
+	maintenanceServiceSuffix          = "-maintenance"
+	tlsSecretSuffix                   = "-tls"
 )
 
+// deploysReadyTimeout is the time the deploys of a renamed app have to get
+// ready before the old namespace is deleted
+const deploysReadyTimeout = 10 * time.Minute
+
 type Client struct {
-	conf          *restclient.Config
-	podRunTimeout time.Duration
-	ingress       bool
-	fake          kubernetes.Interface
-	testing       bool
+	conf               *restclient.Config
+	podRunTimeout      time.Duration
+	ingress            bool
+	maintenanceService string
+	maintenancePort    int
+	certManagerIssuer  string
+	ingressAllowedOpts []string
+	tcpServices        string
+	udpServices        string
+	pdbMinAvailable    string
+	fake               kubernetes.Interface
+	testing            bool
+
+	// clusters are the ones of the apps besides the cluster of the server,
+	// their configs are kept by name along with the hash of the kubeconfig
+	// they were read from
+	clusters     Clusters
+	clusterConfs map[string]*clusterConf
+	mutex        sync.Mutex
+}
+
+type clusterConf struct {
+	hash [sha256.Size]byte
+	conf *restclient.Config
+}
+
+// Clusters resolve the clusters of the apps, blank being the cluster of the
+// server
+type Clusters interface {
+	Names() ([]string, error)
+	Kubeconfig(name string) ([]byte, error)
+	AppCluster(appName string) (string, error)
+}
+
+// SetClusters routes the calls on the namespace of an app to the cluster of
+// the app
+func (k *Client) SetClusters(c Clusters) {
+	k.clusters = c
+}
+
+// restConfigFromKubeconfig returns the config of the current context of the
+// kubeconfig
+func restConfigFromKubeconfig(kubeconfig []byte) (*restclient.Config, error) {
+	conf, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return clientcmd.NewDefaultClientConfig(*conf, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+// CheckCluster checks the API server of the cluster of the kubeconfig is
+// reachable with it
+func CheckCluster(kubeconfig []byte) error {
+	conf, err := restConfigFromKubeconfig(kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "invalid kubeconfig")
+	}
+	kc, err := newClientset(conf)
+	if err != nil {
+		return err
+	}
+	_, err = kc.Discovery().ServerVersion()
+	return errors.Wrap(err, "get server version failed")
+}
+
+// clusterConfig returns the config of the cluster, the one of the server
+// when blank. The kubeconfig is read every time, a cluster removed and added
+// again with another one, on any replica of the server, gets a new config.
+func (k *Client) clusterConfig(name string) (*restclient.Config, error) {
+	if name == "" {
+		return k.conf, nil
+	}
+
+	kubeconfig, err := k.clusters.Kubeconfig(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get kubeconfig of cluster %s failed", name)
+	}
+	hash := sha256.Sum256(kubeconfig)
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	if cc, found := k.clusterConfs[name]; found && cc.hash == hash {
+		return cc.conf, nil
+	}
+	conf, err := restConfigFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid kubeconfig of cluster %s", name)
+	}
+	if k.clusterConfs == nil {
+		k.clusterConfs = make(map[string]*clusterConf)
+	}
+	k.clusterConfs[name] = &clusterConf{hash: hash, conf: conf}
+	return conf, nil
+}
+
+// restConfig returns the config of the cluster of the app of the namespace
+func (k *Client) restConfig(namespace string) (*restclient.Config, error) {
+	if k.clusters == nil || namespace == "" {
+		return k.conf, nil
+	}
+	name, err := k.clusters.AppCluster(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "get app cluster failed")
+	}
+	return k.clusterConfig(name)
+}
+
+func newClientset(conf *restclient.Config) (kubernetes.Interface, error) {
+	c, err := kubernetes.NewForConfig(conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "create k8s client failed")
+	}
+	return c, nil
 }
 
-func (k *Client) buildClient() (kubernetes.Interface, error) {
+// buildClient returns a client of the cluster of the app of the namespace,
+// blank being the cluster of the server
+func (k *Client) buildClient(namespace string) (kubernetes.Interface, error) {
 	if k.testing {
 		k.fake = fake.NewSimpleClientset()
 		return k.fake, nil
 	}
-	c, err := kubernetes.NewForConfig(k.conf)
+	conf, err := k.restConfig(namespace)
 	if err != nil {
-		return nil, errors.Wrap(err, "create k8s client failed")
+		return nil, err
 	}
-	return c, nil
+	return newClientset(conf)
+}
+
+// clusterClients returns a client of the cluster of the server and of each
+// registered cluster
+func (k *Client) clusterClients() ([]kubernetes.Interface, error) {
+	kc, err := k.buildClient("")
+	if err != nil {
+		return nil, err
+	}
+	kcs := []kubernetes.Interface{kc}
+	if k.testing || k.clusters == nil {
+		return kcs, nil
+	}
+	names, err := k.clusters.Names()
+	if err != nil {
+		return nil, errors.Wrap(err, "list clusters failed")
+	}
+	for _, name := range names {
+		conf, err := k.clusterConfig(name)
+		if err != nil {
+			return nil, err
+		}
+		c, err := newClientset(conf)
+		if err != nil {
+			return nil, err
+		}
+		kcs = append(kcs, c)
+	}
+	return kcs, nil
 }
 
+// HealthCheck checks the cluster of the server only, the others being
+// checked by the operations on their apps
 func (k *Client) HealthCheck() error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient("")
 	if err != nil {
 		return err
 	}
@@ -68,7 +227,7 @@ func (k *Client) HealthCheck() error {
 }
 
 func (k *Client) getNamespace(namespace string) (*k8sv1.Namespace, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +239,7 @@ func (k *Client) getNamespace(namespace string) (*k8sv1.Namespace, error) {
 }
 
 func (k *Client) DeployAnnotation(namespace, deployName, annotation string) (string, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return "", err
 	}
@@ -96,6 +255,49 @@ func (k *Client) DeployAnnotation(namespace, deployName, annotation string) (str
 	return d.Annotations[annotation], nil
 }
 
+// DeployContainerImage returns the image of the container of the deploy
+func (k *Client) DeployContainerImage(namespace, deployName, container string) (string, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	d, err := kc.AppsV1beta2().Deployments(namespace).Get(deployName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "get deploy failed")
+	}
+	for _, c := range d.Spec.Template.Spec.Containers {
+		if c.Name == container {
+			return c.Image, nil
+		}
+	}
+	return "", fmt.Errorf("container %s not found in deploy %s", container, deployName)
+}
+
+// DeployMetadata returns the author and git metadata of the current
+// revision of the deploy
+func (k *Client) DeployMetadata(namespace, name string) (*app.DeployMetadata, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := kc.AppsV1beta2().
+		Deployments(namespace).
+		Get(name, metav1.GetOptions{})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "get deploy metadata failed")
+	}
+
+	return &app.DeployMetadata{
+		Author:  d.Annotations[spec.AuthorAnnotation],
+		Commit:  d.Annotations[spec.CommitAnnotation],
+		Branch:  d.Annotations[spec.BranchAnnotation],
+		Message: d.Annotations[spec.MessageAnnotation],
+	}, nil
+}
+
 func (k *Client) NamespaceAnnotation(namespace, annotation string) (string, error) {
 	ns, err := k.getNamespace(namespace)
 	if err != nil {
@@ -115,7 +317,7 @@ func (k *Client) NamespaceLabel(namespace, label string) (string, error) {
 }
 
 func (k *Client) PodList(namespace string, opts *app.PodListOptions) ([]*app.Pod, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -132,20 +334,138 @@ func (k *Client) PodList(namespace string, opts *app.PodListOptions) ([]*app.Pod
 	return pods, nil
 }
 
+func (k *Client) PodListByLabel(namespace, label, value string) ([]*app.Pod, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", label, value)}
+	podList, err := kc.CoreV1().Pods(namespace).List(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods failed")
+	}
+
+	pods := make([]*app.Pod, len(podList.Items))
+	for i, pod := range podList.Items {
+		pods[i] = k8sPodToAppPod(&pod)
+	}
+	return pods, nil
+}
+
+func (k *Client) PodDetails(namespace string) ([]*app.PodDetail, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := kc.CoreV1().Pods(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods failed")
+	}
+
+	pods := make([]*app.PodDetail, len(podList.Items))
+	for i, pod := range podList.Items {
+		pods[i] = k8sPodToAppPodDetail(&pod)
+	}
+	return pods, nil
+}
+
+func (k *Client) Events(namespace string, opts *app.EventOptions, stop <-chan struct{}) (<-chan *app.Event, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+	evList, err := kc.CoreV1().Events(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list events failed")
+	}
+	sort.Slice(evList.Items, func(i, j int) bool {
+		return evList.Items[i].LastTimestamp.Before(&evList.Items[j].LastTimestamp)
+	})
+
+	var w watch.Interface
+	if opts.Follow {
+		w, err = kc.CoreV1().Events(namespace).Watch(metav1.ListOptions{
+			ResourceVersion: evList.ResourceVersion,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "watch events failed")
+		}
+	}
+
+	ch := make(chan *app.Event)
+	go func() {
+		defer close(ch)
+		if w != nil {
+			defer w.Stop()
+		}
+		for i := range evList.Items {
+			select {
+			case ch <- k8sEventToAppEvent(&evList.Items[i]):
+			case <-stop:
+				return
+			}
+		}
+		if w == nil {
+			return
+		}
+		for {
+			select {
+			case wev, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+				ev, ok := wev.Object.(*k8sv1.Event)
+				if !ok || wev.Type == watch.Deleted {
+					continue
+				}
+				select {
+				case ch <- k8sEventToAppEvent(ev):
+				case <-stop:
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func (k *Client) PodMetrics(namespace string) ([]*app.PodMetrics, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := kc.CoreV1().RESTClient().Get().
+		AbsPath(fmt.Sprintf(podMetricsPathTmpl, namespace)).
+		DoRaw()
+	if err != nil {
+		return nil, errors.Wrap(err, "get pod metrics failed")
+	}
+
+	pml := new(podMetricsList)
+	if err := json.Unmarshal(data, pml); err != nil {
+		return nil, errors.Wrap(err, "decode pod metrics failed")
+	}
+
+	pods := make([]*app.PodMetrics, len(pml.Items))
+	for i := range pml.Items {
+		pm, err := podMetricsToAppPodMetrics(&pml.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		pods[i] = pm
+	}
+	return pods, nil
+}
+
 func (k *Client) PodLogs(namespace string, podName string, opts *app.LogOptions) (io.ReadCloser, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
-	req := kc.CoreV1().Pods(namespace).GetLogs(
-		podName,
-		&k8sv1.PodLogOptions{
-			Follow:    opts.Follow,
-			TailLines: &opts.Lines,
-			Previous:  opts.Previous,
-			Container: opts.Container,
-		},
-	)
+	req := kc.CoreV1().Pods(namespace).GetLogs(podName, appLogOptsToK8s(opts))
 
 	return req.Stream()
 }
@@ -216,30 +536,86 @@ func newLimitRange(a *app.App) (*k8sv1.LimitRange, error) {
 	return lr, nil
 }
 
-func newHPA(a *app.App) *asv1.HorizontalPodAutoscaler {
-	tcpu := a.Autoscale.CPUTargetUtilization
+// newHPA returns an autoscaler of the app deploy targeting the CPU and
+// memory utilization and the custom metrics of the app pods
+func newHPA(a *app.App) (*asv2.HorizontalPodAutoscaler, error) {
 	minr := a.Autoscale.Min
+	metrics := make([]asv2.MetricSpec, 0, len(a.Autoscale.Metrics)+2)
+	if tcpu := a.Autoscale.CPUTargetUtilization; tcpu > 0 {
+		metrics = append(metrics, resourceMetric(k8sv1.ResourceCPU, tcpu))
+	}
+	if tmem := a.Autoscale.MemoryTargetUtilization; tmem > 0 {
+		metrics = append(metrics, resourceMetric(k8sv1.ResourceMemory, tmem))
+	}
+	for _, m := range a.Autoscale.Metrics {
+		v, err := resource.ParseQuantity(m.TargetAverageValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid metric target")
+		}
+		metrics = append(metrics, asv2.MetricSpec{
+			Type: asv2.PodsMetricSourceType,
+			Pods: &asv2.PodsMetricSource{
+				MetricName:         m.Name,
+				TargetAverageValue: v,
+			},
+		})
+	}
 
-	return &asv1.HorizontalPodAutoscaler{
+	return &asv2.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      a.Name,
 			Namespace: a.Name,
 		},
-		Spec: asv1.HorizontalPodAutoscalerSpec{
-			ScaleTargetRef: asv1.CrossVersionObjectReference{
+		Spec: asv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: asv2.CrossVersionObjectReference{
 				APIVersion: "extensions/v1beta1",
 				Kind:       "Deployment",
 				Name:       a.Name,
 			},
-			TargetCPUUtilizationPercentage: &tcpu,
-			MaxReplicas:                    a.Autoscale.Max,
-			MinReplicas:                    &minr,
+			Metrics:     metrics,
+			MaxReplicas: a.Autoscale.Max,
+			MinReplicas: &minr,
+		},
+	}, nil
+}
+
+func resourceMetric(name k8sv1.ResourceName, utilization int32) asv2.MetricSpec {
+	return asv2.MetricSpec{
+		Type: asv2.ResourceMetricSourceType,
+		Resource: &asv2.ResourceMetricSource{
+			Name:                     name,
+			TargetAverageUtilization: &utilization,
 		},
 	}
 }
 
+// hpaToAutoscale is the inverse of newHPA
+func hpaToAutoscale(hpa *asv2.HorizontalPodAutoscaler) *app.Autoscale {
+	as := &app.Autoscale{Max: hpa.Spec.MaxReplicas}
+	if hpa.Spec.MinReplicas != nil {
+		as.Min = *hpa.Spec.MinReplicas
+	}
+	for _, m := range hpa.Spec.Metrics {
+		switch {
+		case m.Resource != nil && m.Resource.TargetAverageUtilization != nil:
+			switch m.Resource.Name {
+			case k8sv1.ResourceCPU:
+				as.CPUTargetUtilization = *m.Resource.TargetAverageUtilization
+			case k8sv1.ResourceMemory:
+				as.MemoryTargetUtilization = *m.Resource.TargetAverageUtilization
+			}
+		case m.Pods != nil:
+			as.Metrics = append(as.Metrics, &app.AutoscaleMetric{
+				Name:               m.Pods.MetricName,
+				TargetAverageValue: m.Pods.TargetAverageValue.String(),
+			})
+		}
+	}
+	return as
+}
+
 func (k *Client) CreateNamespace(a *app.App, user string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(a.Name)
 	if err != nil {
 		return err
 	}
@@ -254,7 +630,7 @@ func (k *Client) CreateNamespace(a *app.App, user string) error {
 }
 
 func (k *Client) CreateQuota(a *app.App) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(a.Name)
 	if err != nil {
 		return err
 	}
@@ -269,7 +645,7 @@ func (k *Client) CreateQuota(a *app.App) error {
 }
 
 func (c *Client) GetSecret(namespace, secretName string) (map[string][]byte, error) {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -280,8 +656,16 @@ func (c *Client) GetSecret(namespace, secretName string) (map[string][]byte, err
 	return s.Data, nil
 }
 
+func (c *Client) DeleteSecret(namespace, secretName string) error {
+	kc, err := c.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	return kc.CoreV1().Secrets(namespace).Delete(secretName, &metav1.DeleteOptions{})
+}
+
 func (c *Client) CreateOrUpdateSecret(namespace, secretName string, data map[string][]byte) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -303,22 +687,38 @@ func (c *Client) CreateOrUpdateSecret(namespace, secretName string, data map[str
 }
 
 func (k *Client) CreateOrUpdateAutoscale(a *app.App) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(a.Name)
 	if err != nil {
 		return err
 	}
 
-	hpa := newHPA(a)
+	hpa, err := newHPA(a)
+	if err != nil {
+		return err
+	}
 
-	_, err = kc.AutoscalingV1().HorizontalPodAutoscalers(a.Name).Update(hpa)
+	_, err = kc.AutoscalingV2beta1().HorizontalPodAutoscalers(a.Name).Update(hpa)
 	if k.IsNotFound(err) {
-		_, err = kc.AutoscalingV1().HorizontalPodAutoscalers(a.Name).Create(hpa)
+		_, err = kc.AutoscalingV2beta1().HorizontalPodAutoscalers(a.Name).Create(hpa)
 	}
 	return err
 }
 
+func (k *Client) DeleteAutoscale(namespace string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	err = kc.AutoscalingV1().
+		HorizontalPodAutoscalers(namespace).
+		Delete(namespace, &metav1.DeleteOptions{})
+
+	return errors.Wrap(err, "delete autoscale failed")
+}
+
 func (k *Client) AddressList(namespace string) ([]*app.Address, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -342,7 +742,7 @@ func (k *Client) AddressList(namespace string) ([]*app.Address, error) {
 }
 
 func (k *Client) Status(namespace string) (*app.Status, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -375,12 +775,12 @@ func (k *Client) Status(namespace string) (*app.Status, error) {
 }
 
 func (k *Client) Autoscale(namespace string) (*app.Autoscale, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
 
-	hpa, err := kc.AutoscalingV1().
+	hpa, err := kc.AutoscalingV2beta1().
 		HorizontalPodAutoscalers(namespace).
 		Get(namespace, metav1.GetOptions{})
 
@@ -390,25 +790,11 @@ func (k *Client) Autoscale(namespace string) (*app.Autoscale, error) {
 		}
 		return nil, errors.Wrap(err, "get autoscale failed")
 	}
-
-	var cpu, min int32
-	if hpa.Spec.TargetCPUUtilizationPercentage != nil {
-		cpu = *hpa.Spec.TargetCPUUtilizationPercentage
-	}
-	if hpa.Spec.MinReplicas != nil {
-		min = *hpa.Spec.MinReplicas
-	}
-
-	as := &app.Autoscale{
-		CPUTargetUtilization: cpu,
-		Min:                  min,
-		Max:                  hpa.Spec.MaxReplicas,
-	}
-	return as, nil
+	return hpaToAutoscale(hpa), nil
 }
 
 func (k *Client) Limits(namespace, name string) (*app.Limits, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -444,7 +830,7 @@ func (k *Client) Limits(namespace, name string) (*app.Limits, error) {
 }
 
 func (k *Client) CreateOrUpdateConfigMap(namespace, name string, data map[string]string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -457,8 +843,20 @@ func (k *Client) CreateOrUpdateConfigMap(namespace, name string, data map[string
 	return err
 }
 
+func (k *Client) GetConfigMap(namespace, name string) (map[string]string, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := kc.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return cm.Data, nil
+}
+
 func (k *Client) DeleteConfigMap(namespace, name string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -466,13 +864,80 @@ func (k *Client) DeleteConfigMap(namespace, name string) error {
 	return errors.Wrap(err, "delete configMap failed")
 }
 
+// CreateOrUpdatePDB keeps at least minAvailable (a number or percentage)
+// pods of the app deploy running during voluntary disruptions, like node
+// drains
+func (k *Client) CreateOrUpdatePDB(namespace, name, minAvailable string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	pdb := pdbSpec(namespace, name, minAvailable)
+
+	old, err := kc.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !k.IsNotFound(err) {
+			return errors.Wrap(err, "get pdb failed")
+		}
+		_, err = kc.PolicyV1beta1().PodDisruptionBudgets(namespace).Create(pdb)
+		return errors.Wrap(err, "create pdb failed")
+	}
+	if old.Spec.MinAvailable != nil && *old.Spec.MinAvailable == *pdb.Spec.MinAvailable {
+		return nil
+	}
+	// the spec of a PodDisruptionBudget is immutable
+	if err := kc.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(name, &metav1.DeleteOptions{}); err != nil {
+		return errors.Wrap(err, "delete pdb failed")
+	}
+	_, err = kc.PolicyV1beta1().PodDisruptionBudgets(namespace).Create(pdb)
+	return errors.Wrap(err, "create pdb failed")
+}
+
+func (k *Client) DeletePDB(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	err = kc.PolicyV1beta1().PodDisruptionBudgets(namespace).Delete(name, &metav1.DeleteOptions{})
+	return errors.Wrap(err, "delete pdb failed")
+}
+
+func (k *Client) CreatePVC(namespace string, vc *app.VolumeClaim) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	pvc, err := pvcSpec(namespace, vc)
+	if err != nil {
+		return err
+	}
+	_, err = kc.CoreV1().PersistentVolumeClaims(namespace).Create(pvc)
+	return errors.Wrap(err, "create pvc failed")
+}
+
+func (k *Client) DeletePVC(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	err = kc.CoreV1().PersistentVolumeClaims(namespace).Delete(name, &metav1.DeleteOptions{})
+	return errors.Wrap(err, "delete pvc failed")
+}
+
+func (k *Client) DefaultPDBMinAvailable() string {
+	return k.pdbMinAvailable
+}
+
 func (k *Client) CreateOrUpdateDeploy(deploySpec *spec.Deploy) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(deploySpec.Namespace)
 	if err != nil {
 		return err
 	}
 
 	replicas := k.currentPodReplicasFromDeploy(deploySpec.Namespace, deploySpec.Name)
+	if deploySpec.Replicas != nil {
+		replicas = *deploySpec.Replicas
+	}
 	deployYaml, err := deploySpecToK8sDeploy(deploySpec, replicas)
 	if err != nil {
 		return err
@@ -486,7 +951,7 @@ func (k *Client) CreateOrUpdateDeploy(deploySpec *spec.Deploy) error {
 }
 
 func (c *Client) CreateOrUpdateCronJob(cronJobSpec *spec.CronJob) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(cronJobSpec.Namespace)
 	if err != nil {
 		return err
 	}
@@ -504,7 +969,7 @@ func (c *Client) CreateOrUpdateCronJob(cronJobSpec *spec.CronJob) error {
 }
 
 func (k *Client) PodRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(podSpec.Namespace)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -530,74 +995,155 @@ func (k *Client) PodRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error) {
 			close(exitCodeChan)
 		}()
 
-		if err := k.waitPodStart(pod, 1*time.Second, 5*time.Minute); err != nil {
-			if err == wait.ErrWaitTimeout {
-				exitCodeChan <- exec.ExitCodeTimeout
-			} else {
-				exitCodeChan <- exec.ExitCodeError
-			}
-			return
-		}
-
-		opts := &app.LogOptions{
-			Lines:     10,
-			Follow:    true,
-			Container: podSpec.Containers[0].Name,
-		}
-		stream, err := k.PodLogs(podSpec.Namespace, podSpec.Name, opts)
-		if err != nil {
-			exitCodeChan <- exec.ExitCodeError
-			return
-		}
-		io.Copy(w, stream)
-
-		if err = k.waitPodEnd(pod, 3*time.Second, k.podRunTimeout); err != nil {
-			if err == wait.ErrWaitTimeout {
-				exitCodeChan <- exec.ExitCodeTimeout
-			} else {
-				exitCodeChan <- exec.ExitCodeError
-			}
-			return
-		}
-
-		exitCode, err := k.podExitCode(pod)
-		if err != nil {
-			exitCodeChan <- exec.ExitCodeError
-			return
-		}
+		exitCode, err := k.followPodRun(pod, w)
 		exitCodeChan <- exitCode
-		go k.DeletePod(pod.Namespace, pod.Name)
+		if err == nil {
+			go k.DeletePod(pod.Namespace, pod.Name)
+		}
 	}()
 	return r, exitCodeChan, nil
 }
 
-func (k *Client) hasService(namespace, appName string) (bool, error) {
-	kc, err := k.buildClient()
-	if err != nil {
-		return false, err
+// followPodRun streams the logs of the main container of the pod to w until
+// it ends, returning its exit code. On failure the exit code is the one of
+// the timeout or of a generic error
+func (k *Client) followPodRun(pod *k8sv1.Pod, w io.Writer) (int, error) {
+	if err := k.waitPodStart(pod, 1*time.Second, 5*time.Minute); err != nil {
+		if err == wait.ErrWaitTimeout {
+			return exec.ExitCodeTimeout, err
+		}
+		return exec.ExitCodeError, err
 	}
-	_, err = kc.CoreV1().Services(namespace).Get(appName, metav1.GetOptions{})
+
+	opts := &app.LogOptions{
+		Lines:     10,
+		Follow:    true,
+		Container: pod.Spec.Containers[0].Name,
+	}
+	stream, err := k.PodLogs(pod.Namespace, pod.Name, opts)
 	if err != nil {
-		if k.IsNotFound(err) {
-			return false, nil
+		return exec.ExitCodeError, err
+	}
+	io.Copy(w, stream)
+
+	if err = k.waitPodEnd(pod, 3*time.Second, k.podRunTimeout); err != nil {
+		if err == wait.ErrWaitTimeout {
+			return exec.ExitCodeTimeout, err
 		}
-		return false, errors.Wrap(err, "get service failed")
+		return exec.ExitCodeError, err
 	}
-	return true, nil
-}
 
-func (k *Client) CreateService(svcSpec *spec.Service) error {
-	kc, err := k.buildClient()
+	exitCode, err := k.podExitCode(pod)
 	if err != nil {
-		return err
+		return exec.ExitCodeError, err
 	}
-	ss := serviceSpecToK8s(svcSpec)
+	return exitCode, nil
+}
+
+// JobRun runs the pod as a Job without retries, streaming the logs of its
+// pod and sending the exit code of the main container once it ends. The Job
+// and its pod are deleted afterwards
+func (k *Client) JobRun(podSpec *spec.Pod) (io.ReadCloser, <-chan int, error) {
+	kc, err := k.buildClient(podSpec.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(podSpec.Containers) < 1 {
+		return nil, nil, fmt.Errorf("Invalid PodSpec, expected at least 1 container")
+	}
+
+	job, err := podSpecToK8sJob(podSpec)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "define job spec failed")
+	}
+	if _, err := kc.BatchV1().Jobs(podSpec.Namespace).Create(job); err != nil {
+		return nil, nil, errors.Wrap(err, "job create failed")
+	}
+
+	// buffered, the Job is deleted even if the exit code is never read
+	exitCodeChan := make(chan int, 1)
+	r, w := io.Pipe()
+	go func() {
+		defer func() {
+			w.Close()
+			close(exitCodeChan)
+		}()
+		defer k.DeleteJob(job.Namespace, job.Name)
+
+		pod, err := k.waitJobPod(job.Namespace, job.Name, 1*time.Second, 5*time.Minute)
+		if err != nil {
+			exitCodeChan <- exec.ExitCodeError
+			return
+		}
+		exitCode, _ := k.followPodRun(pod, w)
+		exitCodeChan <- exitCode
+	}()
+	return r, exitCodeChan, nil
+}
+
+// waitJobPod waits for the Job controller to create the pod of the job
+func (k *Client) waitJobPod(namespace, jobName string, checkInterval, timeout time.Duration) (*k8sv1.Pod, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var pod *k8sv1.Pod
+	opts := metav1.ListOptions{LabelSelector: fmt.Sprintf("job-name=%s", jobName)}
+	err = wait.PollImmediate(checkInterval, timeout, func() (bool, error) {
+		pods, err := kc.CoreV1().Pods(namespace).List(opts)
+		if err != nil {
+			return false, err
+		}
+		if len(pods.Items) == 0 {
+			return false, nil
+		}
+		pod = &pods.Items[0]
+		return true, nil
+	})
+	return pod, err
+}
+
+// DeleteJob deletes the job and its pods
+func (k *Client) DeleteJob(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	policy := metav1.DeletePropagationBackground
+	err = kc.BatchV1().Jobs(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &policy})
+	return errors.Wrap(err, "delete job failed")
+}
+
+func (k *Client) hasService(namespace, appName string) (bool, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return false, err
+	}
+	_, err = kc.CoreV1().Services(namespace).Get(appName, metav1.GetOptions{})
+	if err != nil {
+		if k.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get service failed")
+	}
+	return true, nil
+}
+
+func (k *Client) CreateService(svcSpec *spec.Service) error {
+	kc, err := k.buildClient(svcSpec.Namespace)
+	if err != nil {
+		return err
+	}
+	ss := serviceSpecToK8s(svcSpec)
 	_, err = kc.CoreV1().Services(svcSpec.Namespace).Create(ss)
 	return errors.Wrap(err, "create service failed")
 }
 
 func (k *Client) DeleteService(namespace, name string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -606,7 +1152,7 @@ func (k *Client) DeleteService(namespace, name string) error {
 }
 
 func (k *Client) HasIngress(namespace, appName string) (bool, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return false, err
 	}
@@ -623,18 +1169,19 @@ func (k *Client) HasIngress(namespace, appName string) (bool, error) {
 	return true, nil
 }
 
-func (k *Client) createIngress(namespace, appName string, vHosts []string) error {
-	kc, err := k.buildClient()
+func (k *Client) createIngress(namespace, appName, protocol string, vHosts []string) error {
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
 	igsSpec := ingressSpec(namespace, appName, vHosts)
+	setIngressBackendProtocol(igsSpec, protocol)
 	_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Create(igsSpec)
 	return errors.Wrap(err, "create ingress failed")
 }
 
 func (k *Client) UpdateIngress(namespace, name string, vHosts []string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -645,11 +1192,248 @@ func (k *Client) UpdateIngress(namespace, name string, vHosts []string) error {
 		return errors.Wrap(err, "get ingress failed")
 	}
 	newSpec := ingressSpec(namespace, name, vHosts)
+	// keep the current backend, it may be the maintenance one
+	if b := ingressBackend(old); b != nil {
+		setIngressBackend(newSpec, b.ServiceName, b.ServicePort.IntValue())
+	}
 	old.Spec.Rules = newSpec.Spec.Rules
+	// the certificate must cover the new hosts too
+	for i := range old.Spec.TLS {
+		old.Spec.TLS[i].Hosts = vHosts
+	}
 	_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Update(old)
 	return errors.Wrap(err, "update ingress failed")
 }
 
+// IngressSetMaintenance routes the ingress of the app to the maintenance
+// service, through an ExternalName service in the app namespace, or back
+// to the app service
+func (k *Client) IngressSetMaintenance(namespace, name string, enabled bool) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	igs, err := kc.ExtensionsV1beta1().
+		Ingresses(namespace).
+		Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get ingress failed")
+	}
+
+	svcName := name + maintenanceServiceSuffix
+	if enabled {
+		svc := maintenanceServiceSpec(namespace, svcName, k.maintenanceService, k.maintenancePort)
+		old, err := kc.CoreV1().Services(namespace).Get(svcName, metav1.GetOptions{})
+		if err == nil {
+			old.Spec = svc.Spec
+			_, err = kc.CoreV1().Services(namespace).Update(old)
+		} else if k.IsNotFound(err) {
+			_, err = kc.CoreV1().Services(namespace).Create(svc)
+		}
+		if err != nil {
+			return errors.Wrap(err, "create maintenance service failed")
+		}
+		setIngressBackend(igs, svcName, k.maintenancePort)
+	} else {
+		setIngressBackend(igs, name, spec.DefaultExternalPort)
+	}
+
+	if _, err := kc.ExtensionsV1beta1().Ingresses(namespace).Update(igs); err != nil {
+		return errors.Wrap(err, "update ingress failed")
+	}
+
+	if !enabled {
+		err := kc.CoreV1().Services(namespace).Delete(svcName, &metav1.DeleteOptions{})
+		if err != nil && !k.IsNotFound(err) {
+			return errors.Wrap(err, "delete maintenance service failed")
+		}
+	}
+	return nil
+}
+
+func (k *Client) MaintenanceEnabled() bool {
+	return k.maintenanceService != ""
+}
+
+// IngressEnableTLS annotates the ingress of the app for cert-manager to
+// issue a certificate for its hosts
+func (k *Client) IngressEnableTLS(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	igs, err := kc.ExtensionsV1beta1().
+		Ingresses(namespace).
+		Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get ingress failed")
+	}
+
+	setIngressTLS(igs, k.certManagerIssuer, name+tlsSecretSuffix)
+	_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Update(igs)
+	return errors.Wrap(err, "update ingress failed")
+}
+
+// IngressTLSStatus returns the status of the certificate of the app
+// ingress, blank if TLS isn't enabled
+func (k *Client) IngressTLSStatus(namespace, name string) (string, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return "", err
+	}
+	igs, err := kc.ExtensionsV1beta1().
+		Ingresses(namespace).
+		Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "get ingress failed")
+	}
+	if len(igs.Spec.TLS) == 0 {
+		return "", nil
+	}
+
+	s, err := kc.CoreV1().
+		Secrets(namespace).
+		Get(igs.Spec.TLS[0].SecretName, metav1.GetOptions{})
+	if err != nil {
+		if k.IsNotFound(err) {
+			return app.TLSStatusPending, nil
+		}
+		return "", errors.Wrap(err, "get secret failed")
+	}
+	return certificateStatus(s.Data[k8sv1.TLSCertKey], time.Now()), nil
+}
+
+func (k *Client) CertManagerEnabled() bool {
+	return k.certManagerIssuer != ""
+}
+
+// IngressSetOptions sets the annotations of the ingress options of the
+// app, nothing is done if the app isn't exposed by ingress yet
+func (k *Client) IngressSetOptions(namespace, name string, opts map[string]string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	igs, err := kc.ExtensionsV1beta1().
+		Ingresses(namespace).
+		Get(name, metav1.GetOptions{})
+	if err != nil {
+		if k.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get ingress failed")
+	}
+
+	setIngressOptions(igs, opts)
+	_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Update(igs)
+	return errors.Wrap(err, "update ingress failed")
+}
+
+// IngressSetSourceRanges restricts the access to the app ingress to the
+// cidrs, an empty list lifts the restriction
+func (k *Client) IngressSetSourceRanges(namespace, name string, cidrs []string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	igs, err := kc.ExtensionsV1beta1().
+		Ingresses(namespace).
+		Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get ingress failed")
+	}
+
+	setIngressSourceRanges(igs, cidrs)
+	_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Update(igs)
+	return errors.Wrap(err, "update ingress failed")
+}
+
+// IngressSetStreamPorts exposes the TCP and UDP ports of the app service
+// on the ingress controller, replacing the ones previously exposed
+func (k *Client) IngressSetStreamPorts(namespace, svcName string, ports []spec.ServicePort) error {
+	var tcp, udp []spec.ServicePort
+	for _, p := range ports {
+		if p.Protocol == string(k8sv1.ProtocolUDP) {
+			udp = append(udp, p)
+		} else {
+			tcp = append(tcp, p)
+		}
+	}
+	err := k.updateStreamPorts(namespace, k.tcpServices, func(data map[string]string) (map[string]string, error) {
+		return streamPortsData(data, namespace, svcName, tcp)
+	})
+	if err != nil {
+		return err
+	}
+	return k.updateStreamPorts(namespace, k.udpServices, func(data map[string]string) (map[string]string, error) {
+		return streamPortsData(data, namespace, svcName, udp)
+	})
+}
+
+func (k *Client) IngressDeleteStreamPorts(namespace, svcName string) error {
+	return k.IngressSetStreamPorts(namespace, svcName, nil)
+}
+
+// IngressRenameStreamPorts points the ports exposed on the ingress
+// controller by the app src to the app dst
+func (k *Client) IngressRenameStreamPorts(src, dst string) error {
+	for _, cm := range []string{k.tcpServices, k.udpServices} {
+		err := k.updateStreamPorts(src, cm, func(data map[string]string) (map[string]string, error) {
+			return renameStreamPortsData(data, src, dst), nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateStreamPorts updates the data of the ingress-nginx ConfigMap
+// configMap (namespace/name) on the cluster of the app, nothing is done on
+// clusters without ingress
+func (k *Client) updateStreamPorts(appName, configMap string, update func(map[string]string) (map[string]string, error)) error {
+	if !k.ingress {
+		return nil
+	}
+	kc, err := k.buildClient(appName)
+	if err != nil {
+		return err
+	}
+	namespace, name := path.Split(configMap)
+	namespace = strings.TrimSuffix(namespace, "/")
+
+	cm, err := kc.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	notFound := k.IsNotFound(err)
+	if err != nil && !notFound {
+		return errors.Wrap(err, "get configMap failed")
+	}
+	if notFound {
+		cm = configMapSpec(namespace, name, nil)
+	}
+
+	data, err := update(cm.Data)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 && len(cm.Data) == 0 || reflect.DeepEqual(data, cm.Data) {
+		return nil
+	}
+	cm.Data = data
+	if notFound {
+		_, err = kc.CoreV1().ConfigMaps(namespace).Create(cm)
+		return errors.Wrap(err, "create configMap failed")
+	}
+	_, err = kc.CoreV1().ConfigMaps(namespace).Update(cm)
+	return errors.Wrap(err, "update configMap failed")
+}
+
+func (k *Client) IngressAllowedOptions() []string {
+	return k.ingressAllowedOpts
+}
+
 // ExposeDeploy creates a service and/or a ingress if needed
 func (k *Client) ExposeDeploy(namespace, appName, svcType, portName string, vHosts []string, w io.Writer) error {
 	hasSrv, err := k.hasService(namespace, appName)
@@ -673,7 +1457,7 @@ func (k *Client) ExposeDeploy(namespace, appName, svcType, portName string, vHos
 	}
 	if !hasIgs {
 		fmt.Fprintln(w, "Creating ingress")
-		if err := k.createIngress(namespace, appName, vHosts); err != nil {
+		if err := k.createIngress(namespace, appName, portName, vHosts); err != nil {
 			return err
 		}
 	}
@@ -681,8 +1465,142 @@ func (k *Client) ExposeDeploy(namespace, appName, svcType, portName string, vHos
 	return nil
 }
 
+// ExposeCanary creates or updates the service and ingress of the canary
+// deploy canaryName of the app, the ingress takes weight percent of the
+// traffic of the app ingress
+func (k *Client) ExposeCanary(namespace, name, canaryName string, weight int32) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	svc, err := kc.CoreV1().Services(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get service failed")
+	}
+	csvc := canaryServiceSpec(svc, canaryName)
+	old, err := kc.CoreV1().Services(namespace).Get(canaryName, metav1.GetOptions{})
+	if err == nil {
+		old.Spec.Ports = csvc.Spec.Ports
+		_, err = kc.CoreV1().Services(namespace).Update(old)
+	} else if k.IsNotFound(err) {
+		_, err = kc.CoreV1().Services(namespace).Create(csvc)
+	}
+	if err != nil {
+		return errors.Wrap(err, "create canary service failed")
+	}
+
+	igs, err := kc.ExtensionsV1beta1().Ingresses(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get ingress failed")
+	}
+	cigs := canaryIngressSpec(igs, canaryName, weight)
+	oldIgs, err := kc.ExtensionsV1beta1().Ingresses(namespace).Get(canaryName, metav1.GetOptions{})
+	if err == nil {
+		oldIgs.Annotations = cigs.Annotations
+		oldIgs.Spec = cigs.Spec
+		_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Update(oldIgs)
+	} else if k.IsNotFound(err) {
+		_, err = kc.ExtensionsV1beta1().Ingresses(namespace).Create(cigs)
+	}
+	return errors.Wrap(err, "create canary ingress failed")
+}
+
+// DeployPromote rolls out the pods of the deploy srcName, a canary or a
+// green one, in the app deploy
+func (k *Client) DeployPromote(namespace, name, srcName string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	src, err := kc.AppsV1beta2().Deployments(namespace).Get(srcName, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get source deploy failed")
+	}
+	d, err := kc.AppsV1beta2().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get deploy failed")
+	}
+
+	promoteDeploy(d, src)
+	_, err = kc.AppsV1beta2().Deployments(namespace).Update(d)
+	return errors.Wrap(err, "update deploy failed")
+}
+
+// DeleteCanary deletes the canary deploy canaryName with its service and
+// ingress, a not found error is returned if there's no canary deploy
+func (k *Client) DeleteCanary(namespace, canaryName string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	err = kc.ExtensionsV1beta1().Ingresses(namespace).Delete(canaryName, &metav1.DeleteOptions{})
+	if err != nil && !k.IsNotFound(err) {
+		return errors.Wrap(err, "delete canary ingress failed")
+	}
+	err = kc.CoreV1().Services(namespace).Delete(canaryName, &metav1.DeleteOptions{})
+	if err != nil && !k.IsNotFound(err) {
+		return errors.Wrap(err, "delete canary service failed")
+	}
+
+	policy := metav1.DeletePropagationForeground
+	err = kc.AppsV1beta2().Deployments(namespace).Delete(
+		canaryName,
+		&metav1.DeleteOptions{PropagationPolicy: &policy},
+	)
+	return errors.Wrap(err, "delete canary deploy failed")
+}
+
+// DeploySetAnnotation sets the annotation of the deploy, a blank value
+// removes it
+func (k *Client) DeploySetAnnotation(namespace, name, annotation, value string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	var v interface{}
+	if value != "" {
+		v = value
+	}
+	b, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{annotation: v},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to json encode annotation")
+	}
+	_, err = kc.AppsV1beta2().Deployments(namespace).Patch(name, types.StrategicMergePatchType, b)
+	return errors.Wrap(err, "patch deploy failed")
+}
+
+// DeleteDeploy deletes the deploy along with its pods
+func (k *Client) DeleteDeploy(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	policy := metav1.DeletePropagationForeground
+	err = kc.AppsV1beta2().Deployments(namespace).Delete(
+		name,
+		&metav1.DeleteOptions{PropagationPolicy: &policy},
+	)
+	return errors.Wrap(err, "delete deploy failed")
+}
+
+// ServiceSetSelector sends the traffic of the service to the pods with the
+// run label deployName
+func (k *Client) ServiceSetSelector(namespace, svcName, deployName string) error {
+	data := fmt.Sprintf(`{"spec":{"selector":{"run":%q}}}`, deployName)
+	return k.patchService(namespace, svcName, []byte(data))
+}
+
 func (k *Client) DeletePod(namespace, podName string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -691,7 +1609,7 @@ func (k *Client) DeletePod(namespace, podName string) error {
 }
 
 func (k *Client) waitPodStart(pod *k8sv1.Pod, checkInterval, timeout time.Duration) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(pod.Namespace)
 	if err != nil {
 		return err
 	}
@@ -710,7 +1628,7 @@ func (k *Client) waitPodStart(pod *k8sv1.Pod, checkInterval, timeout time.Durati
 }
 
 func (k *Client) waitPodEnd(pod *k8sv1.Pod, checkInterval, timeout time.Duration) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(pod.Namespace)
 	if err != nil {
 		return err
 	}
@@ -731,7 +1649,7 @@ func (k *Client) waitPodEnd(pod *k8sv1.Pod, checkInterval, timeout time.Duration
 }
 
 func (c *Client) WatchServiceURL(namespace, name string) ([]string, error) {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -765,7 +1683,7 @@ func (c *Client) WatchServiceURL(namespace, name string) ([]string, error) {
 }
 
 func (k *Client) podExitCode(pod *k8sv1.Pod) (int, error) {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(pod.Namespace)
 	if err != nil {
 		return 1, err
 	}
@@ -788,22 +1706,24 @@ func (k *Client) podExitCode(pod *k8sv1.Pod) (int, error) {
 	return 1, ErrPodStillRunning
 }
 
+// currentPodReplicasFromDeploy returns the desired replicas of the deploy,
+// zero included, or one for a new deploy
 func (k *Client) currentPodReplicasFromDeploy(namespace, appName string) int32 {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return 1
 	}
 
 	d, err := kc.AppsV1beta2().Deployments(
 		namespace).Get(appName, metav1.GetOptions{})
-	if err != nil || d.Status.Replicas < 1 {
+	if err != nil || d.Spec.Replicas == nil {
 		return 1
 	}
-	return d.Status.Replicas
+	return *d.Spec.Replicas
 }
 
 func (k *Client) SetNamespaceAnnotations(namespace string, annotations map[string]string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -821,7 +1741,7 @@ func (k *Client) SetNamespaceAnnotations(namespace string, annotations map[strin
 }
 
 func (k *Client) SetNamespaceLabels(namespace string, labels map[string]string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -838,8 +1758,8 @@ func (k *Client) SetNamespaceLabels(namespace string, labels map[string]string)
 	return err
 }
 
-func (c Client) getDeployContainerList(namespace, deploy string) ([]string, error) {
-	kc, err := c.buildClient()
+func (c *Client) getDeployContainerList(namespace, deploy string) ([]string, error) {
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -889,7 +1809,7 @@ func (c *Client) patchDeployEnvVars(namespace, name string, v interface{}) error
 		return err
 	}
 
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -909,7 +1829,7 @@ func (c *Client) patchCronJobEnvVars(namespace, name string, v interface{}) erro
 		return err
 	}
 
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -962,137 +1882,469 @@ func convertAppEnvVar(evs []*app.EnvVar) interface{} {
 		env[i] = &EnvVar{Name: evs[i].Key, Value: evs[i].Value}
 	}
 
-	return env
+	return env
+}
+
+func convertAppDeleteEnvVar(evNames []string) interface{} {
+	type EnvVar struct {
+		Name  string `json:"name"`
+		Patch string `json:"$patch"`
+	}
+	env := make([]*EnvVar, len(evNames))
+	for i := range evNames {
+		env[i] = &EnvVar{Name: evNames[i], Patch: "delete"}
+	}
+
+	return env
+}
+
+func (c *Client) CreateOrUpdateDeployEnvVars(namespace, name string, evs []*app.EnvVar) error {
+	return c.patchDeployEnvVars(namespace, name, convertAppEnvVar(evs))
+}
+
+func (c *Client) CreateOrUpdateCronJobEnvVars(namespace, name string, evs []*app.EnvVar) error {
+	return c.patchCronJobEnvVars(namespace, name, convertAppEnvVar(evs))
+}
+
+func (c *Client) CreateOrUpdateDeploySecretEnvVars(namespace, name, secretName string, secrets []string) error {
+	return c.patchDeployEnvVars(namespace, name, convertAppSecretEnvVar(secretName, secrets))
+}
+
+func (c *Client) CreateOrUpdateCronJobSecretEnvVars(namespace, name, secretName string, secrets []string) error {
+	return c.patchCronJobEnvVars(namespace, name, convertAppSecretEnvVar(secretName, secrets))
+}
+
+func (k *Client) DeleteDeployEnvVars(namespace, name string, evNames []string) error {
+	return k.patchDeployEnvVars(namespace, name, convertAppDeleteEnvVar(evNames))
+}
+
+func (k *Client) DeleteCronJobEnvVars(namespace, name string, evNames []string) error {
+	return k.patchCronJobEnvVars(namespace, name, convertAppDeleteEnvVar(evNames))
+}
+
+func (k *Client) DeleteNamespace(namespace string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	err = kc.CoreV1().Namespaces().Delete(namespace, &metav1.DeleteOptions{})
+	return errors.Wrap(err, "delete ns failed")
+}
+
+// WaitDeploysReady waits for the deploys of the namespace to roll out with
+// all their replicas available
+func (k *Client) WaitDeploysReady(namespace string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+	deploys := kc.AppsV1beta2().Deployments(namespace)
+	err = wait.PollImmediate(3*time.Second, deploysReadyTimeout, func() (bool, error) {
+		dl, err := deploys.List(metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, d := range dl.Items {
+			if !isDeployReady(&d) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	return errors.Wrap(err, "wait deploys failed")
+}
+
+func isDeployReady(d *v1beta2.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	st := d.Status
+	return st.ObservedGeneration >= d.Generation &&
+		st.UpdatedReplicas >= replicas &&
+		st.AvailableReplicas >= replicas
+}
+
+// CopyAppResources copies the deploys (or cronjob), nginx config, service and
+// ingress of srcApp to the namespace of dstApp, renaming them on the way.
+// Both apps must be on the same cluster.
+func (k *Client) CopyAppResources(srcApp, dstApp string) error {
+	kc, err := k.buildClient(srcApp)
+	if err != nil {
+		return err
+	}
+
+	cm, err := kc.CoreV1().ConfigMaps(srcApp).Get(srcApp, metav1.GetOptions{})
+	if err == nil {
+		ncm := configMapSpec(dstApp, dstApp, cm.Data)
+		if _, err := kc.CoreV1().ConfigMaps(dstApp).Create(ncm); err != nil {
+			return errors.Wrap(err, "create configMap failed")
+		}
+	} else if !k.IsNotFound(err) {
+		return errors.Wrap(err, "get configMap failed")
+	}
+
+	dl, err := kc.AppsV1beta2().Deployments(srcApp).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "list deploys failed")
+	}
+	for i := range dl.Items {
+		nd := renameK8sDeploy(&dl.Items[i], srcApp, dstApp)
+		if _, err := kc.AppsV1beta2().Deployments(dstApp).Create(nd); err != nil {
+			return errors.Wrap(err, "create deploy failed")
+		}
+	}
+
+	cj, err := kc.BatchV1beta1().CronJobs(srcApp).Get(srcApp, metav1.GetOptions{})
+	if err == nil {
+		ncj := renameK8sCronJob(cj, srcApp, dstApp)
+		if _, err := kc.BatchV1beta1().CronJobs(dstApp).Create(ncj); err != nil {
+			return errors.Wrap(err, "create cronjob failed")
+		}
+	} else if !k.IsNotFound(err) {
+		return errors.Wrap(err, "get cronjob failed")
+	}
+
+	svc, err := kc.CoreV1().Services(srcApp).Get(srcApp, metav1.GetOptions{})
+	if err == nil {
+		nsvc := renameK8sService(svc, srcApp, dstApp)
+		if _, err := kc.CoreV1().Services(dstApp).Create(nsvc); err != nil {
+			return errors.Wrap(err, "create service failed")
+		}
+	} else if !k.IsNotFound(err) {
+		return errors.Wrap(err, "get service failed")
+	}
+
+	igs, err := kc.ExtensionsV1beta1().Ingresses(srcApp).Get(srcApp, metav1.GetOptions{})
+	if err == nil {
+		nigs := renameK8sIngress(igs, srcApp, dstApp)
+		if _, err := kc.ExtensionsV1beta1().Ingresses(dstApp).Create(nigs); err != nil {
+			return errors.Wrap(err, "create ingress failed")
+		}
+	} else if !k.IsNotFound(err) {
+		return errors.Wrap(err, "get ingress failed")
+	}
+
+	return nil
+}
+
+// NamespaceListByLabel returns the namespaces with the label on all clusters
+func (k *Client) NamespaceListByLabel(label, value string) ([]string, error) {
+	kcs, err := k.clusterClients()
+	if err != nil {
+		return nil, err
+	}
+	labelSelector := fmt.Sprintf("%s=%s", label, value)
+	if value == "" {
+		labelSelector = fmt.Sprintf("%s", label)
+	}
+	namespaces := make([]string, 0)
+	for _, kc := range kcs {
+		nl, err := kc.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: labelSelector})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range nl.Items {
+			namespaces = append(namespaces, item.ObjectMeta.Name)
+		}
+	}
+	return namespaces, nil
+}
+
+func (k *Client) ReplicaSetListByLabel(namespace, label, value string) ([]*deploy.ReplicaSetListItem, error) {
+	cli, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build client")
+	}
+
+	labelSelector := fmt.Sprintf("%s=%s", label, value)
+	opts := metav1.ListOptions{LabelSelector: labelSelector}
+	rs, err := cli.ExtensionsV1beta1().ReplicaSets(namespace).List(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get replicasets")
+	}
+
+	resp := make([]*deploy.ReplicaSetListItem, len(rs.Items))
+	for i, item := range rs.Items {
+		resp[i] = &deploy.ReplicaSetListItem{
+			Revision:    item.Annotations[revisionAnnotation],
+			CreatedAt:   item.CreationTimestamp.Time.String(),
+			Current:     item.Status.ReadyReplicas > 0,
+			Description: item.Annotations[changeCauseAnnotation],
+			Author:      item.Annotations[spec.AuthorAnnotation],
+			Slug:        item.Annotations[spec.SlugAnnotation],
+			Commit:      item.Annotations[spec.CommitAnnotation],
+			Branch:      item.Annotations[spec.BranchAnnotation],
+			Message:     item.Annotations[spec.MessageAnnotation],
+		}
+		// the app container is named after the deploy
+		for _, c := range item.Spec.Template.Spec.Containers {
+			if c.Name == value {
+				resp[i].Image = c.Image
+				resp[i].EnvVars = k8sExplicitEnvToAppEnv(c.Env)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func (k *Client) DeployRollbackToRevision(namespace, name, revision string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	data := fmt.Sprintf(patchDeployRollbackToRevisionTmpl, revision)
+
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		[]byte(data),
+	)
+
+	return errors.Wrap(err, "patch deploy failed")
+}
+
+func (k *Client) DeploySetReplicas(namespace, name string, replicas int32) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	data := fmt.Sprintf(patchDeployReplicasTmpl, replicas)
+
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		[]byte(data),
+	)
+
+	return errors.Wrap(err, "patch deploy failed")
+}
+
+func (k *Client) DeployRestart(namespace, name string) error {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	data := fmt.Sprintf(patchDeployRestartTmpl, time.Now())
+
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		[]byte(data),
+	)
+
+	return errors.Wrap(err, "patch deploy failed")
 }
 
-func convertAppDeleteEnvVar(evNames []string) interface{} {
-	type EnvVar struct {
-		Name  string `json:"name"`
-		Patch string `json:"$patch"`
+func prepareResourcesPatch(name, template string, r *app.Resources) ([]byte, error) {
+	res, err := newResourceRequirements(r.CPU, r.Memory, r.CPURequest, r.MemoryRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid resources")
 	}
-	env := make([]*EnvVar, len(evNames))
-	for i := range evNames {
-		env[i] = &EnvVar{Name: evNames[i], Patch: "delete"}
+
+	type containerResources struct {
+		Name      string                     `json:"name"`
+		Resources k8sv1.ResourceRequirements `json:"resources"`
+	}
+	b, err := json.Marshal([]containerResources{{Name: name, Resources: *res}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to json encode resources")
 	}
 
-	return env
+	return []byte(fmt.Sprintf(template, string(b))), nil
 }
 
-func (c *Client) CreateOrUpdateDeployEnvVars(namespace, name string, evs []*app.EnvVar) error {
-	return c.patchDeployEnvVars(namespace, name, convertAppEnvVar(evs))
-}
+func (k *Client) DeploySetResources(namespace, name string, r *app.Resources) error {
+	data, err := prepareResourcesPatch(name, patchDeployResourcesTmpl, r)
+	if err != nil {
+		return err
+	}
 
-func (c *Client) CreateOrUpdateCronJobEnvVars(namespace, name string, evs []*app.EnvVar) error {
-	return c.patchCronJobEnvVars(namespace, name, convertAppEnvVar(evs))
-}
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
 
-func (c *Client) CreateOrUpdateDeploySecretEnvVars(namespace, name, secretName string, secrets []string) error {
-	return c.patchDeployEnvVars(namespace, name, convertAppSecretEnvVar(secretName, secrets))
-}
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		data,
+	)
 
-func (c *Client) CreateOrUpdateCronJobSecretEnvVars(namespace, name, secretName string, secrets []string) error {
-	return c.patchCronJobEnvVars(namespace, name, convertAppSecretEnvVar(secretName, secrets))
+	return errors.Wrap(err, "patch deploy failed")
 }
 
-func (k *Client) DeleteDeployEnvVars(namespace, name string, evNames []string) error {
-	return k.patchDeployEnvVars(namespace, name, convertAppDeleteEnvVar(evNames))
-}
+// DeploySetLifecycle replaces the preStop hook of the app container and the
+// termination grace period of the pods
+func (k *Client) DeploySetLifecycle(namespace, name string, lc *app.Lifecycle) error {
+	type containerLifecycle struct {
+		Name      string           `json:"name"`
+		Lifecycle *k8sv1.Lifecycle `json:"lifecycle"`
+	}
+	klc := lifecycleToK8sLifecycle(&spec.Lifecycle{
+		PreStop: &spec.PreStop{DrainTimeoutSeconds: int(lc.DrainTimeoutSeconds)},
+	})
+	b, err := json.Marshal([]containerLifecycle{{Name: name, Lifecycle: klc}})
+	if err != nil {
+		return errors.Wrap(err, "failed to json encode lifecycle")
+	}
 
-func (k *Client) DeleteCronJobEnvVars(namespace, name string, evNames []string) error {
-	return k.patchCronJobEnvVars(namespace, name, convertAppDeleteEnvVar(evNames))
-}
+	grace := lc.TerminationGracePeriodSeconds
+	if grace == 0 {
+		grace = app.DefaultTerminationGracePeriodSeconds
+	}
+	data := fmt.Sprintf(patchDeployLifecycleTmpl, grace, string(b))
 
-func (k *Client) DeleteNamespace(namespace string) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
-	err = kc.CoreV1().Namespaces().Delete(namespace, &metav1.DeleteOptions{})
-	return errors.Wrap(err, "delete ns failed")
+
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		[]byte(data),
+	)
+
+	return errors.Wrap(err, "patch deploy failed")
 }
 
-func (k *Client) NamespaceListByLabel(label, value string) ([]string, error) {
-	kc, err := k.buildClient()
+// DeploySetRollingUpdate changes the strategy of the next rollouts, the
+// pods of the deploy aren't replaced
+func (k *Client) DeploySetRollingUpdate(namespace, name string, ru *app.RollingUpdate) error {
+	maxSurge, maxUnavailable := rollingUpdateToK8sRollingUpdate(&spec.RollingUpdate{
+		MaxSurge:       ru.MaxSurge,
+		MaxUnavailable: ru.MaxUnavailable,
+	})
+	b, err := json.Marshal(&v1beta2.RollingUpdateDeployment{
+		MaxSurge:       &maxSurge,
+		MaxUnavailable: &maxUnavailable,
+	})
 	if err != nil {
-		return nil, err
-	}
-	labelSelector := fmt.Sprintf("%s=%s", label, value)
-	if value == "" {
-		labelSelector = fmt.Sprintf("%s", label)
+		return errors.Wrap(err, "failed to json encode rolling update")
 	}
-	nl, err := kc.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: labelSelector})
+	data := fmt.Sprintf(patchDeployRollingUpdateTmpl, string(b))
+
+	kc, err := k.buildClient(namespace)
 	if err != nil {
-		return nil, err
-	}
-	namespaces := make([]string, 0)
-	for _, item := range nl.Items {
-		namespaces = append(namespaces, item.ObjectMeta.Name)
+		return err
 	}
-	return namespaces, nil
+
+	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		[]byte(data),
+	)
+
+	return errors.Wrap(err, "patch deploy failed")
 }
 
-func (k *Client) ReplicaSetListByLabel(namespace, label, value string) ([]*deploy.ReplicaSetListItem, error) {
-	cli, err := k.buildClient()
+func (k *Client) CronJobSetResources(namespace, name string, r *app.Resources) error {
+	data, err := prepareResourcesPatch(name, patchCronJobResourcesTmpl, r)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to build client")
+		return err
 	}
 
-	labelSelector := fmt.Sprintf("%s=%s", label, value)
-	opts := metav1.ListOptions{LabelSelector: labelSelector}
-	rs, err := cli.ExtensionsV1beta1().ReplicaSets(namespace).List(opts)
+	kc, err := k.buildClient(namespace)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get replicasets")
+		return err
 	}
 
-	resp := make([]*deploy.ReplicaSetListItem, len(rs.Items))
-	for i, item := range rs.Items {
-		resp[i] = &deploy.ReplicaSetListItem{
-			Revision:    item.Annotations[revisionAnnotation],
-			CreatedAt:   item.CreationTimestamp.Time.String(),
-			Current:     item.Status.ReadyReplicas > 0,
-			Description: item.Annotations[changeCauseAnnotation],
+	_, err = kc.BatchV1beta1().CronJobs(namespace).Patch(
+		name,
+		types.StrategicMergePatchType,
+		data,
+	)
+
+	return errors.Wrap(err, "patch cronjob failed")
+}
+
+// prepareEnvFromPatch replaces the envFrom list of the container, it has
+// no merge key so the strategic merge patch doesn't append to it
+func prepareEnvFromPatch(name, template string, secretNames []string) ([]byte, error) {
+	envFrom := make([]k8sv1.EnvFromSource, len(secretNames))
+	for i, sn := range secretNames {
+		envFrom[i] = k8sv1.EnvFromSource{
+			SecretRef: &k8sv1.SecretEnvSource{
+				LocalObjectReference: k8sv1.LocalObjectReference{Name: sn},
+			},
 		}
 	}
 
-	return resp, nil
+	type containerEnvFrom struct {
+		Name    string                `json:"name"`
+		EnvFrom []k8sv1.EnvFromSource `json:"envFrom"`
+	}
+	b, err := json.Marshal([]containerEnvFrom{{Name: name, EnvFrom: envFrom}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to json encode env from")
+	}
+
+	return []byte(fmt.Sprintf(template, string(b))), nil
 }
 
-func (k *Client) DeployRollbackToRevision(namespace, name, revision string) error {
-	kc, err := k.buildClient()
+func (k *Client) DeploySetEnvFromSecrets(namespace, name string, secretNames []string) error {
+	data, err := prepareEnvFromPatch(name, patchDeployEnvFromTmpl, secretNames)
 	if err != nil {
 		return err
 	}
 
-	data := fmt.Sprintf(patchDeployRollbackToRevisionTmpl, revision)
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
 
 	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
 		name,
 		types.StrategicMergePatchType,
-		[]byte(data),
+		data,
 	)
 
 	return errors.Wrap(err, "patch deploy failed")
 }
 
-func (k *Client) DeploySetReplicas(namespace, name string, replicas int32) error {
-	kc, err := k.buildClient()
+func (k *Client) CronJobSetEnvFromSecrets(namespace, name string, secretNames []string) error {
+	data, err := prepareEnvFromPatch(name, patchCronJobEnvFromTmpl, secretNames)
 	if err != nil {
 		return err
 	}
 
-	data := fmt.Sprintf(patchDeployReplicasTmpl, replicas)
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return err
+	}
 
-	_, err = kc.ExtensionsV1beta1().Deployments(namespace).Patch(
+	_, err = kc.BatchV1beta1().CronJobs(namespace).Patch(
 		name,
 		types.StrategicMergePatchType,
-		[]byte(data),
+		data,
 	)
 
-	return errors.Wrap(err, "patch deploy failed")
+	return errors.Wrap(err, "patch cronjob failed")
+}
+
+func (k *Client) DeployReplicas(namespace, name string) (int32, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := kc.AppsV1beta2().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return 0, errors.Wrap(err, "get deploy failed")
+	}
+	if d.Spec.Replicas == nil {
+		return 1, nil
+	}
+	return *d.Spec.Replicas, nil
 }
 
 func (k *Client) changeCronJobState(namespace, name string, suspend bool) error {
-	kc, err := k.buildClient()
+	kc, err := k.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1114,8 +2366,73 @@ func (k *Client) SuspendCronJob(namespace, name string) error {
 	return k.changeCronJobState(namespace, name, true)
 }
 
-func (c *Client) CloudProviderName() (string, error) {
-	kc, err := c.buildClient()
+// CronJob returns the schedule and the last run of the CronJob
+func (k *Client) CronJob(namespace, name string) (*app.CronJob, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cj, err := kc.BatchV1beta1().CronJobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "get cronjob failed")
+	}
+	return k8sCronJobToAppCronJob(cj), nil
+}
+
+// CronJobRunNow creates a job from the template of the CronJob, like the
+// scheduled ones, returning its name
+func (k *Client) CronJobRunNow(namespace, name string) (string, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	cj, err := kc.BatchV1beta1().CronJobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "get cronjob failed")
+	}
+	job := k8sCronJobToManualJob(cj, manualJobName(name, time.Now()))
+	if _, err := kc.BatchV1().Jobs(namespace).Create(job); err != nil {
+		return "", errors.Wrap(err, "create job failed")
+	}
+	return job.Name, nil
+}
+
+// CronJobRuns returns the jobs of the CronJob kept by the cluster
+func (k *Client) CronJobRuns(namespace, name string) ([]*app.CronRun, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := kc.BatchV1beta1().CronJobs(namespace).Get(name, metav1.GetOptions{}); err != nil {
+		return nil, errors.Wrap(err, "get cronjob failed")
+	}
+	jobs, err := kc.BatchV1().Jobs(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list jobs failed")
+	}
+	pods, err := kc.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: "job-name"})
+	if err != nil {
+		return nil, errors.Wrap(err, "list pods failed")
+	}
+
+	now := time.Now()
+	runs := make([]*app.CronRun, 0, len(jobs.Items))
+	for i := range jobs.Items {
+		if !isCronJobRun(&jobs.Items[i], name) {
+			continue
+		}
+		runs = append(runs, k8sJobToAppCronRun(&jobs.Items[i], pods.Items, name, now))
+	}
+	return runs, nil
+}
+
+// CloudProviderName returns the cloud provider of the cluster of the app of
+// the namespace, the one of the server when blank
+func (c *Client) CloudProviderName(namespace string) (string, error) {
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return "", err
 	}
@@ -1139,7 +2456,7 @@ func (c *Client) SetServiceAnnotations(namespace, svcName string, annotations ma
 }
 
 func (c *Client) UpdateServicePorts(namespace, svcName string, ports []spec.ServicePort) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1162,7 +2479,7 @@ func (c *Client) patchServiceAnnotations(namespace, svcName string, annotations
 }
 
 func (c *Client) patchService(namespace, svcName string, data []byte) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1175,7 +2492,7 @@ func (c *Client) patchService(namespace, svcName string, data []byte) error {
 }
 
 func (c *Client) ServiceAnnotations(namespace, svcName string) (map[string]string, error) {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -1186,8 +2503,20 @@ func (c *Client) ServiceAnnotations(namespace, svcName string) (map[string]strin
 	return svc.Annotations, nil
 }
 
+func (c *Client) ServiceType(namespace, svcName string) (string, error) {
+	kc, err := c.buildClient(namespace)
+	if err != nil {
+		return "", err
+	}
+	svc, err := kc.CoreV1().Services(namespace).Get(svcName, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "get service failed")
+	}
+	return string(svc.Spec.Type), nil
+}
+
 func (c *Client) Service(namespace, svcName string) (*spec.Service, error) {
-	cs, err := c.buildClient()
+	cs, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -1199,7 +2528,7 @@ func (c *Client) Service(namespace, svcName string) (*spec.Service, error) {
 }
 
 func (c *Client) ContainerExplicitEnvVars(namespace, deployName, containerName string) ([]*app.EnvVar, error) {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -1221,7 +2550,7 @@ func (c *Client) ContainerExplicitEnvVars(namespace, deployName, containerName s
 }
 
 func (c *Client) WatchDeploy(namespace, deployName string) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1246,7 +2575,7 @@ func (c *Client) WatchDeploy(namespace, deployName string) error {
 			if last.LastUpdateTime.After(ts) && isRollingUpdateFinished(last) {
 				return nil
 			} else if isRollingUpdateStalled(last) {
-				return errors.New("rolling update stalled, still running the old deploy")
+				return ErrRolloutStalled
 			}
 		}
 	}
@@ -1265,8 +2594,8 @@ func (c *Client) SetLoadBalancerSourceRanges(namespace, svcName string, sourceRa
 	return c.patchService(namespace, svcName, []byte(data))
 }
 
-func (c *Client) CreateOrUpdateDeploySecretFile(namespace, deploy, filename string) error {
-	kc, err := c.buildClient()
+func (c *Client) CreateOrUpdateDeploySecretFile(namespace, deploy, filename, mountPath string) error {
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1290,10 +2619,15 @@ func (c *Client) CreateOrUpdateDeploySecretFile(namespace, deploy, filename stri
 		if cn.Name != deploy { //app container name is the same of deploy name
 			continue
 		}
-		d.Spec.Template.Spec.Containers[i].VolumeMounts = addVolumeMountOfSecrets(
-			d.Spec.Template.Spec.Containers[i].VolumeMounts,
+		d.Spec.Template.Spec.Containers[i].VolumeMounts = addVolumeMountOfSecretFile(
+			addVolumeMountOfSecrets(
+				d.Spec.Template.Spec.Containers[i].VolumeMounts,
+				spec.AppSecretName,
+				app.SecretPath,
+			),
 			spec.AppSecretName,
-			app.SecretPath,
+			filename,
+			mountPath,
 		)
 		break
 	}
@@ -1308,7 +2642,7 @@ func (c *Client) CreateOrUpdateDeploySecretFile(namespace, deploy, filename stri
 
 func addVolumeMountOfSecrets(vols []k8sv1.VolumeMount, volName, path string) []k8sv1.VolumeMount {
 	for _, vol := range vols {
-		if vol.Name == volName {
+		if vol.Name == volName && vol.SubPath == "" {
 			return vols
 		}
 	}
@@ -1322,6 +2656,24 @@ func addVolumeMountOfSecrets(vols []k8sv1.VolumeMount, volName, path string) []k
 	)
 }
 
+// addVolumeMountOfSecretFile mounts a single file of the secret volume at
+// the given directory, an empty mountPath removes the custom mount.
+func addVolumeMountOfSecretFile(vols []k8sv1.VolumeMount, volName, fileName, mountPath string) []k8sv1.VolumeMount {
+	vols = removeVolumeMountsOfSecretFiles(vols, volName, []string{fileName})
+	if mountPath == "" {
+		return vols
+	}
+	return append(
+		vols,
+		k8sv1.VolumeMount{
+			Name:      volName,
+			ReadOnly:  true,
+			MountPath: path.Join(mountPath, fileName),
+			SubPath:   fileName,
+		},
+	)
+}
+
 func addVolumeOfSecretFile(vols []k8sv1.Volume, volName, secretName, fileName string) []k8sv1.Volume {
 	for i := range vols {
 		if vols[i].Name != volName {
@@ -1333,6 +2685,11 @@ func addVolumeOfSecretFile(vols []k8sv1.Volume, volName, secretName, fileName st
 				Items:      make([]k8sv1.KeyToPath, 0),
 			}
 		}
+		for _, item := range vols[i].Secret.Items {
+			if item.Key == fileName {
+				return vols
+			}
+		}
 		vols[i].Secret.Items = append(
 			vols[i].Secret.Items,
 			k8sv1.KeyToPath{Key: fileName, Path: fileName},
@@ -1355,8 +2712,102 @@ func addVolumeOfSecretFile(vols []k8sv1.Volume, volName, secretName, fileName st
 	)
 }
 
+// SetDeployConfigFile mounts the file of the app config files at the
+// mountPath directory of the app container, a blank mountPath unmounts it
+func (c *Client) SetDeployConfigFile(namespace, deploy, fileName, mountPath string) error {
+	kc, err := c.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	d, err := kc.AppsV1beta2().
+		Deployments(namespace).
+		Get(deploy, metav1.GetOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	//app container name is the same of deploy name
+	setConfigFileInPodSpec(&d.Spec.Template.Spec, deploy, fileName, mountPath)
+
+	d.Annotations["kubernetes.io/change-cause"] = "update config file"
+	_, err = kc.AppsV1beta2().
+		Deployments(namespace).
+		Update(d)
+
+	return err
+}
+
+// SetCronJobConfigFile is like SetDeployConfigFile for the app cronjob
+func (c *Client) SetCronJobConfigFile(namespace, cronjob, fileName, mountPath string) error {
+	kc, err := c.buildClient(namespace)
+	if err != nil {
+		return err
+	}
+
+	cj, err := kc.BatchV1beta1().
+		CronJobs(namespace).
+		Get(cronjob, metav1.GetOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	//app container name is the same of cronjob name
+	setConfigFileInPodSpec(&cj.Spec.JobTemplate.Spec.Template.Spec, cronjob, fileName, mountPath)
+
+	cj.Annotations["kubernetes.io/change-cause"] = "update config file"
+	_, err = kc.BatchV1beta1().
+		CronJobs(namespace).
+		Update(cj)
+
+	return err
+}
+
+// setConfigFileInPodSpec replaces the mount of the config file in the
+// container, the config files volume is kept only while some file of it is
+// mounted
+func setConfigFileInPodSpec(ps *k8sv1.PodSpec, container, fileName, mountPath string) {
+	mounted := false
+	for i, cn := range ps.Containers {
+		if cn.Name != container {
+			continue
+		}
+		vols := removeVolumeMountsOfSecretFiles(cn.VolumeMounts, spec.AppConfigFilesName, []string{fileName})
+		if mountPath != "" {
+			vols = append(vols, k8sv1.VolumeMount{
+				Name:      spec.AppConfigFilesName,
+				ReadOnly:  true,
+				MountPath: path.Join(mountPath, fileName),
+				SubPath:   fileName,
+			})
+		}
+		for _, vol := range vols {
+			if vol.Name == spec.AppConfigFilesName {
+				mounted = true
+			}
+		}
+		ps.Containers[i].VolumeMounts = vols
+		break
+	}
+
+	for i, vol := range ps.Volumes {
+		if vol.Name == spec.AppConfigFilesName {
+			ps.Volumes = append(ps.Volumes[:i], ps.Volumes[i+1:]...)
+			break
+		}
+	}
+	if mounted {
+		vol := k8sv1.Volume{Name: spec.AppConfigFilesName}
+		vol.ConfigMap = &k8sv1.ConfigMapVolumeSource{}
+		vol.ConfigMap.Name = app.TeresaAppConfigFiles
+		ps.Volumes = append(ps.Volumes, vol)
+	}
+}
+
 func (c *Client) DeleteDeploySecrets(namespace, deploy string, envVars, volKeys []string) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1384,8 +2835,8 @@ func (c *Client) DeleteDeploySecrets(namespace, deploy string, envVars, volKeys
 	return err
 }
 
-func (c *Client) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName string) error {
-	kc, err := c.buildClient()
+func (c *Client) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName, mountPath string) error {
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1409,10 +2860,15 @@ func (c *Client) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName st
 		if cn.Name != cronjob { //app container name is the same of cronjob name
 			continue
 		}
-		cj.Spec.JobTemplate.Spec.Template.Spec.Containers[i].VolumeMounts = addVolumeMountOfSecrets(
-			cj.Spec.JobTemplate.Spec.Template.Spec.Containers[i].VolumeMounts,
+		cj.Spec.JobTemplate.Spec.Template.Spec.Containers[i].VolumeMounts = addVolumeMountOfSecretFile(
+			addVolumeMountOfSecrets(
+				cj.Spec.JobTemplate.Spec.Template.Spec.Containers[i].VolumeMounts,
+				spec.AppSecretName,
+				app.SecretPath,
+			),
 			spec.AppSecretName,
-			app.SecretPath,
+			fileName,
+			mountPath,
 		)
 		break
 	}
@@ -1427,7 +2883,7 @@ func (c *Client) CreateOrUpdateCronJobSecretFile(namespace, cronjob, fileName st
 }
 
 func (c *Client) DeleteCronJobSecrets(namespace, cronjob string, envVars, volKeys []string) error {
-	kc, err := c.buildClient()
+	kc, err := c.buildClient(namespace)
 	if err != nil {
 		return err
 	}
@@ -1489,6 +2945,7 @@ func removeEnvVars(evs []k8sv1.EnvVar, toRemove []string) []k8sv1.EnvVar {
 }
 
 func removeVolumesWithSecretsFromDeploy(d *v1beta2.Deployment, keys []string) *v1beta2.Deployment {
+	removeVol := true
 	for i, vol := range d.Spec.Template.Spec.Volumes {
 		if vol.Name != spec.AppSecretName {
 			continue
@@ -1496,7 +2953,8 @@ func removeVolumesWithSecretsFromDeploy(d *v1beta2.Deployment, keys []string) *v
 		cleanKeys := removeVolumeSecretsItems(vol.Secret.Items, keys)
 		if len(cleanKeys) > 0 {
 			d.Spec.Template.Spec.Volumes[i].Secret.Items = cleanKeys
-			return d
+			removeVol = false
+			break
 		}
 		d.Spec.Template.Spec.Volumes = append(
 			d.Spec.Template.Spec.Volumes[:i],
@@ -1509,7 +2967,10 @@ func removeVolumesWithSecretsFromDeploy(d *v1beta2.Deployment, keys []string) *v
 		if cn.Name != d.Name { //app container name is the same of deploy name
 			continue
 		}
-		cleanVolMounts := removeVolumeMounts(cn.VolumeMounts, spec.AppSecretName)
+		cleanVolMounts := removeVolumeMountsOfSecretFiles(cn.VolumeMounts, spec.AppSecretName, keys)
+		if removeVol {
+			cleanVolMounts = removeVolumeMounts(cleanVolMounts, spec.AppSecretName)
+		}
 		d.Spec.Template.Spec.Containers[i].VolumeMounts = cleanVolMounts
 		break
 	}
@@ -1517,6 +2978,7 @@ func removeVolumesWithSecretsFromDeploy(d *v1beta2.Deployment, keys []string) *v
 }
 
 func removeVolumesWithSecretsFromCronJob(cj *v1beta1.CronJob, keys []string) *v1beta1.CronJob {
+	removeVol := true
 	for i, vol := range cj.Spec.JobTemplate.Spec.Template.Spec.Volumes {
 		if vol.Name != spec.AppSecretName {
 			continue
@@ -1524,7 +2986,8 @@ func removeVolumesWithSecretsFromCronJob(cj *v1beta1.CronJob, keys []string) *v1
 		cleanKeys := removeVolumeSecretsItems(vol.Secret.Items, keys)
 		if len(cleanKeys) > 0 {
 			cj.Spec.JobTemplate.Spec.Template.Spec.Volumes[i].Secret.Items = cleanKeys
-			return cj
+			removeVol = false
+			break
 		}
 		cj.Spec.JobTemplate.Spec.Template.Spec.Volumes = append(
 			cj.Spec.JobTemplate.Spec.Template.Spec.Volumes[:i],
@@ -1537,7 +3000,10 @@ func removeVolumesWithSecretsFromCronJob(cj *v1beta1.CronJob, keys []string) *v1
 		if cn.Name != cj.Name { //app container name is the same of deploy name
 			continue
 		}
-		cleanVolMounts := removeVolumeMounts(cn.VolumeMounts, spec.AppSecretName)
+		cleanVolMounts := removeVolumeMountsOfSecretFiles(cn.VolumeMounts, spec.AppSecretName, keys)
+		if removeVol {
+			cleanVolMounts = removeVolumeMounts(cleanVolMounts, spec.AppSecretName)
+		}
 		cj.Spec.JobTemplate.Spec.Template.Spec.Containers[i].VolumeMounts = cleanVolMounts
 		break
 	}
@@ -1553,6 +3019,22 @@ func removeVolumeMounts(items []k8sv1.VolumeMount, volName string) []k8sv1.Volum
 	return items
 }
 
+func removeVolumeMountsOfSecretFiles(items []k8sv1.VolumeMount, volName string, keys []string) []k8sv1.VolumeMount {
+	clean := make([]k8sv1.VolumeMount, 0, len(items))
+Loop:
+	for _, item := range items {
+		if item.Name == volName && item.SubPath != "" {
+			for _, key := range keys {
+				if item.SubPath == key {
+					continue Loop
+				}
+			}
+		}
+		clean = append(clean, item)
+	}
+	return clean
+}
+
 func removeVolumeSecretsItems(items []k8sv1.KeyToPath, toRemove []string) []k8sv1.KeyToPath {
 	for _, tr := range toRemove {
 		for i, keyPath := range items {
@@ -1604,8 +3086,15 @@ func newInClusterK8sClient(conf *Config) (*Client, error) {
 		return nil, err
 	}
 	return &Client{
-		conf:    k8sConf,
-		ingress: conf.Ingress,
+		conf:               k8sConf,
+		ingress:            conf.Ingress,
+		maintenanceService: conf.MaintenanceService,
+		maintenancePort:    conf.MaintenancePort,
+		certManagerIssuer:  conf.CertManagerIssuer,
+		ingressAllowedOpts: conf.IngressAllowedOptions,
+		tcpServices:        conf.IngressTCPServices,
+		udpServices:        conf.IngressUDPServices,
+		pdbMinAvailable:    conf.DefaultPDBMinAvailable,
 	}, nil
 }
 
@@ -1615,8 +3104,15 @@ func newOutOfClusterK8sClient(conf *Config) (*Client, error) {
 		return nil, err
 	}
 	return &Client{
-		conf:          k8sConf,
-		podRunTimeout: conf.PodRunTimeout,
-		ingress:       conf.Ingress,
+		conf:               k8sConf,
+		podRunTimeout:      conf.PodRunTimeout,
+		ingress:            conf.Ingress,
+		maintenanceService: conf.MaintenanceService,
+		maintenancePort:    conf.MaintenancePort,
+		certManagerIssuer:  conf.CertManagerIssuer,
+		ingressAllowedOpts: conf.IngressAllowedOptions,
+		tcpServices:        conf.IngressTCPServices,
+		udpServices:        conf.IngressUDPServices,
+		pdbMinAvailable:    conf.DefaultPDBMinAvailable,
 	}, nil
 }
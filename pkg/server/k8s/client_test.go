@@ -1,6 +1,9 @@
 package k8s
 
 import (
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -10,6 +13,7 @@ import (
 	"k8s.io/api/batch/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
 )
 
 func TestAddVolumeMountOfSecrets(t *testing.T) {
@@ -57,6 +61,89 @@ func TestAddVolumeMountOfSecrets(t *testing.T) {
 	}
 }
 
+func TestAddVolumeMountOfSecretFile(t *testing.T) {
+	var testCases = []struct {
+		vols      []k8sv1.VolumeMount
+		mountPath string
+		expected  []k8sv1.VolumeMount
+	}{
+		{
+			vols:      []k8sv1.VolumeMount{{Name: "s", MountPath: "/teresa/secrets"}},
+			mountPath: "/secrets",
+			expected: []k8sv1.VolumeMount{
+				{Name: "s", MountPath: "/teresa/secrets"},
+				{Name: "s", MountPath: "/secrets/fs", SubPath: "fs", ReadOnly: true},
+			},
+		},
+		{
+			vols: []k8sv1.VolumeMount{
+				{Name: "s", MountPath: "/teresa/secrets"},
+				{Name: "s", MountPath: "/old/fs", SubPath: "fs", ReadOnly: true},
+			},
+			mountPath: "/secrets",
+			expected: []k8sv1.VolumeMount{
+				{Name: "s", MountPath: "/teresa/secrets"},
+				{Name: "s", MountPath: "/secrets/fs", SubPath: "fs", ReadOnly: true},
+			},
+		},
+		{
+			vols: []k8sv1.VolumeMount{
+				{Name: "s", MountPath: "/teresa/secrets"},
+				{Name: "s", MountPath: "/old/fs", SubPath: "fs", ReadOnly: true},
+			},
+			mountPath: "",
+			expected:  []k8sv1.VolumeMount{{Name: "s", MountPath: "/teresa/secrets"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		vols := addVolumeMountOfSecretFile(tc.vols, "s", "fs", tc.mountPath)
+		if !reflect.DeepEqual(vols, tc.expected) {
+			t.Errorf("expected %v, got %v", tc.expected, vols)
+		}
+	}
+}
+
+func TestSetConfigFileInPodSpec(t *testing.T) {
+	ps := &k8sv1.PodSpec{
+		Containers: []k8sv1.Container{
+			{Name: "nginx"},
+			{Name: "teresa", VolumeMounts: []k8sv1.VolumeMount{{Name: "s", MountPath: "/teresa/secrets"}}},
+		},
+	}
+
+	setConfigFileInPodSpec(ps, "teresa", "nginx.conf", "/etc/nginx")
+	expectedMounts := []k8sv1.VolumeMount{
+		{Name: "s", MountPath: "/teresa/secrets"},
+		{Name: spec.AppConfigFilesName, MountPath: "/etc/nginx/nginx.conf", SubPath: "nginx.conf", ReadOnly: true},
+	}
+	if got := ps.Containers[1].VolumeMounts; !reflect.DeepEqual(got, expectedMounts) {
+		t.Errorf("expected %v, got %v", expectedMounts, got)
+	}
+	if len(ps.Containers[0].VolumeMounts) != 0 {
+		t.Errorf("expected no mounts in the sidecar, got %v", ps.Containers[0].VolumeMounts)
+	}
+	if len(ps.Volumes) != 1 || ps.Volumes[0].ConfigMap == nil || ps.Volumes[0].ConfigMap.Name != app.TeresaAppConfigFiles {
+		t.Fatalf("expected the config files volume, got %v", ps.Volumes)
+	}
+
+	setConfigFileInPodSpec(ps, "teresa", "nginx.conf", "/etc/nginx/conf.d")
+	if got := ps.Containers[1].VolumeMounts[1].MountPath; got != "/etc/nginx/conf.d/nginx.conf" {
+		t.Errorf("expected /etc/nginx/conf.d/nginx.conf, got %s", got)
+	}
+	if len(ps.Volumes) != 1 {
+		t.Errorf("expected a single volume, got %v", ps.Volumes)
+	}
+
+	setConfigFileInPodSpec(ps, "teresa", "nginx.conf", "")
+	if got := ps.Containers[1].VolumeMounts; !reflect.DeepEqual(got, expectedMounts[:1]) {
+		t.Errorf("expected %v, got %v", expectedMounts[:1], got)
+	}
+	if len(ps.Volumes) != 0 {
+		t.Errorf("expected no volumes, got %v", ps.Volumes)
+	}
+}
+
 func TestAddVolumeOfSecretFile(t *testing.T) {
 	var testCases = []struct {
 		vols       []k8sv1.Volume
@@ -303,6 +390,49 @@ func testRemoveVolumesWithSecrets(fromDeploy bool) func(*testing.T) {
 				expectedVols:      []k8sv1.Volume{},
 				expectedVolMounts: []k8sv1.VolumeMount{},
 			},
+			{
+				Volumes: []k8sv1.Volume{{
+					Name: spec.AppSecretName,
+					VolumeSource: k8sv1.VolumeSource{
+						Secret: &k8sv1.SecretVolumeSource{
+							Items: []k8sv1.KeyToPath{{Key: "FOO"}, {Key: "BAR"}},
+						},
+					},
+				}},
+				VolumeMounts: []k8sv1.VolumeMount{
+					{Name: spec.AppSecretName},
+					{Name: spec.AppSecretName, SubPath: "FOO"},
+				},
+				toRemove: []string{"FOO"},
+				expectedVols: []k8sv1.Volume{{
+					Name: spec.AppSecretName,
+					VolumeSource: k8sv1.VolumeSource{
+						Secret: &k8sv1.SecretVolumeSource{
+							Items: []k8sv1.KeyToPath{{Key: "BAR"}},
+						},
+					},
+				}},
+				expectedVolMounts: []k8sv1.VolumeMount{{
+					Name: spec.AppSecretName,
+				}},
+			},
+			{
+				Volumes: []k8sv1.Volume{{
+					Name: spec.AppSecretName,
+					VolumeSource: k8sv1.VolumeSource{
+						Secret: &k8sv1.SecretVolumeSource{
+							Items: []k8sv1.KeyToPath{{Key: "FOO"}},
+						},
+					},
+				}},
+				VolumeMounts: []k8sv1.VolumeMount{
+					{Name: spec.AppSecretName},
+					{Name: spec.AppSecretName, SubPath: "FOO"},
+				},
+				toRemove:          []string{"FOO"},
+				expectedVols:      []k8sv1.Volume{},
+				expectedVolMounts: []k8sv1.VolumeMount{},
+			},
 		}
 
 		for _, tc := range testCases {
@@ -358,3 +488,138 @@ func TestClientCreateNamespace(t *testing.T) {
 		t.Errorf("got %s; want test", an)
 	}
 }
+
+func TestClientCreateOrUpdateAutoscale(t *testing.T) {
+	a := &app.App{
+		Name: "test",
+		Autoscale: &app.Autoscale{
+			CPUTargetUtilization:    70,
+			MemoryTargetUtilization: 80,
+			Min:                     2,
+			Max:                     10,
+			Metrics: []*app.AutoscaleMetric{
+				{Name: "http_requests_per_second", TargetAverageValue: "100"},
+				{Name: "queue_depth", TargetAverageValue: "500m"},
+			},
+		},
+	}
+	cli := &Client{testing: true}
+
+	if err := cli.CreateOrUpdateAutoscale(a); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	hpa, err := cli.fake.AutoscalingV2beta1().
+		HorizontalPodAutoscalers("test").
+		Get("test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := hpaToAutoscale(hpa); !reflect.DeepEqual(got, a.Autoscale) {
+		t.Errorf("got %v; want %v", got, a.Autoscale)
+	}
+}
+
+func TestNewHPAWithoutCPU(t *testing.T) {
+	a := &app.App{
+		Name: "test",
+		Autoscale: &app.Autoscale{
+			Max:     10,
+			Metrics: []*app.AutoscaleMetric{{Name: "queue_depth", TargetAverageValue: "30"}},
+		},
+	}
+
+	hpa, err := newHPA(a)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(hpa.Spec.Metrics) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(hpa.Spec.Metrics))
+	}
+	if hpa.Spec.Metrics[0].Pods == nil {
+		t.Errorf("expected a pods metric, got %v", hpa.Spec.Metrics[0])
+	}
+}
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: prod-us-east
+  cluster:
+    server: https://prod-us-east.example.com
+contexts:
+- name: prod-us-east
+  context:
+    cluster: prod-us-east
+    user: teresa
+current-context: prod-us-east
+users:
+- name: teresa
+  user:
+    token: secret
+`
+
+type fakeClusters struct {
+	apps map[string]string
+	// kubeconfig is the one of prod-us-east, testKubeconfig when blank
+	kubeconfig string
+}
+
+func (f *fakeClusters) Names() ([]string, error) {
+	return []string{"prod-us-east"}, nil
+}
+
+func (f *fakeClusters) Kubeconfig(name string) ([]byte, error) {
+	if name != "prod-us-east" {
+		return nil, errors.New("cluster not found")
+	}
+	if f.kubeconfig != "" {
+		return []byte(f.kubeconfig), nil
+	}
+	return []byte(testKubeconfig), nil
+}
+
+func (f *fakeClusters) AppCluster(appName string) (string, error) {
+	return f.apps[appName], nil
+}
+
+func TestClientRestConfig(t *testing.T) {
+	home := &restclient.Config{Host: "https://teresa.example.com"}
+	cli := &Client{conf: home}
+	if conf, err := cli.restConfig("teresa"); err != nil || conf != home {
+		t.Errorf("expected the config of the server without clusters, got %v (%v)", conf, err)
+	}
+
+	clusters := &fakeClusters{apps: map[string]string{"teresa": "prod-us-east", "lost": "prod-eu"}}
+	cli.SetClusters(clusters)
+	var testCases = []struct {
+		namespace    string
+		expectedHost string
+	}{
+		{"teresa", "https://prod-us-east.example.com"},
+		{"legacy", home.Host},
+		{"", home.Host},
+	}
+	for _, tc := range testCases {
+		conf, err := cli.restConfig(tc.namespace)
+		if err != nil {
+			t.Fatalf("error getting config of %q: %v", tc.namespace, err)
+		}
+		if conf.Host != tc.expectedHost {
+			t.Errorf("expected %s for %q, got %s", tc.expectedHost, tc.namespace, conf.Host)
+		}
+	}
+
+	first, _ := cli.restConfig("teresa")
+	if conf, _ := cli.restConfig("teresa"); conf != first {
+		t.Error("expected the config kept while the kubeconfig is the same")
+	}
+	// the cluster removed and added again with another kubeconfig
+	clusters.kubeconfig = strings.Replace(testKubeconfig, "prod-us-east.example.com", "new-us-east.example.com", 1)
+	if conf, err := cli.restConfig("teresa"); err != nil || conf.Host != "https://new-us-east.example.com" {
+		t.Errorf("expected the config of the new kubeconfig, got %v (%v)", conf, err)
+	}
+
+	if _, err := cli.restConfig("lost"); err == nil {
+		t.Error("expected error getting the config of an unknown cluster")
+	}
+}
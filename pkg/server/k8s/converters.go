@@ -1,8 +1,11 @@
 package k8s
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -12,6 +15,7 @@ import (
 	k8sv1beta1 "k8s.io/api/batch/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	k8s_extensions "k8s.io/api/extensions/v1beta1"
+	k8spolicy "k8s.io/api/policy/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -21,6 +25,13 @@ const (
 	changeCauseAnnotation       = "kubernetes.io/change-cause"
 	appTypeAnnotation           = "teresa.io/app-type"
 	clusterAutoscalerAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	certManagerIssuerAnnotation = "certmanager.k8s.io/cluster-issuer"
+	ingressClassAnnotation      = "kubernetes.io/ingress.class"
+	nginxAnnotationPrefix       = "nginx.ingress.kubernetes.io/"
+	zoneTopologyKey             = "failure-domain.beta.kubernetes.io/zone"
+	hostTopologyKey             = "kubernetes.io/hostname"
+	spreadPreferredWeight       = 10
+	spreadRequiredWeight        = 100
 )
 
 var defaultCronjobBackofflimit = int32(3)
@@ -38,21 +49,12 @@ func containerSpecsToK8sContainers(containerSpecs []*spec.Container) ([]k8sv1.Co
 			Image:           cs.Image,
 		}
 
-		if cs.ContainerLimits != nil {
-			cpu, err := resource.ParseQuantity(cs.ContainerLimits.CPU)
+		if cl := cs.ContainerLimits; cl != nil {
+			res, err := newResourceRequirements(cl.CPU, cl.Memory, cl.CPURequest, cl.MemoryRequest)
 			if err != nil {
 				return nil, err
 			}
-			memory, err := resource.ParseQuantity(cs.ContainerLimits.Memory)
-			if err != nil {
-				return nil, err
-			}
-			c.Resources = k8sv1.ResourceRequirements{
-				Limits: k8sv1.ResourceList{
-					k8sv1.ResourceCPU:    cpu,
-					k8sv1.ResourceMemory: memory,
-				},
-			}
+			c.Resources = *res
 		}
 
 		if len(cs.Command) > 0 {
@@ -76,6 +78,13 @@ func containerSpecsToK8sContainers(containerSpecs []*spec.Container) ([]k8sv1.Co
 				},
 			})
 		}
+		for _, name := range cs.EnvFromSecrets {
+			c.EnvFrom = append(c.EnvFrom, k8sv1.EnvFromSource{
+				SecretRef: &k8sv1.SecretEnvSource{
+					LocalObjectReference: k8sv1.LocalObjectReference{Name: name},
+				},
+			})
+		}
 		for _, vm := range cs.VolumeMounts {
 			c.VolumeMounts = append(c.VolumeMounts, k8sv1.VolumeMount{
 				Name:      vm.Name,
@@ -95,6 +104,41 @@ func containerSpecsToK8sContainers(containerSpecs []*spec.Container) ([]k8sv1.Co
 	return containers, nil
 }
 
+func newResourceList(cpu, memory string) (k8sv1.ResourceList, error) {
+	rl := make(k8sv1.ResourceList)
+	for name, value := range map[k8sv1.ResourceName]string{
+		k8sv1.ResourceCPU:    cpu,
+		k8sv1.ResourceMemory: memory,
+	} {
+		if value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, err
+		}
+		rl[name] = q
+	}
+	if len(rl) == 0 {
+		return nil, nil
+	}
+	return rl, nil
+}
+
+// newResourceRequirements returns the limits and requests of a container,
+// blank quantities are left out
+func newResourceRequirements(cpu, memory, cpuRequest, memoryRequest string) (*k8sv1.ResourceRequirements, error) {
+	limits, err := newResourceList(cpu, memory)
+	if err != nil {
+		return nil, err
+	}
+	requests, err := newResourceList(cpuRequest, memoryRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &k8sv1.ResourceRequirements{Limits: limits, Requests: requests}, nil
+}
+
 func podSpecVolumesToK8sVolumes(vols []*spec.Volume) []k8sv1.Volume {
 	volumes := make([]k8sv1.Volume, 0)
 	for _, v := range vols {
@@ -114,6 +158,10 @@ func podSpecVolumesToK8sVolumes(vols []*spec.Volume) []k8sv1.Volume {
 		} else if v.ConfigMapName != "" {
 			vol.ConfigMap = &k8sv1.ConfigMapVolumeSource{}
 			vol.ConfigMap.Name = v.ConfigMapName
+		} else if v.ClaimName != "" {
+			vol.PersistentVolumeClaim = &k8sv1.PersistentVolumeClaimVolumeSource{
+				ClaimName: v.ClaimName,
+			}
 		}
 		volumes = append(volumes, vol)
 	}
@@ -153,6 +201,30 @@ func podSpecToK8sPod(podSpec *spec.Pod) (*k8sv1.Pod, error) {
 	return pod, nil
 }
 
+// podSpecToK8sJob returns a job running the pod once, without retries
+func podSpecToK8sJob(podSpec *spec.Pod) (*k8sbatch.Job, error) {
+	pod, err := podSpecToK8sPod(podSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	var backoffLimit int32
+	return &k8sbatch.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: pod.ObjectMeta,
+		Spec: k8sbatch.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: k8sv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: pod.Labels},
+				Spec:       pod.Spec,
+			},
+		},
+	}, nil
+}
+
 func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.Deployment, error) {
 	containers, err := podSpecToK8sContainers(&deploySpec.Pod)
 	if err != nil {
@@ -161,11 +233,12 @@ func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.De
 	volumes := podSpecVolumesToK8sVolumes(deploySpec.Volumes)
 
 	if deploySpec.HealthCheck != nil {
+		tcp := app.IsHTTP2Protocol(deploySpec.Protocol)
 		if deploySpec.HealthCheck.Liveness != nil {
-			containers[0].LivenessProbe = healthCheckProbeToK8sProbe(deploySpec.HealthCheck.Liveness)
+			containers[0].LivenessProbe = healthCheckProbeToK8sProbe(deploySpec.HealthCheck.Liveness, tcp)
 		}
 		if deploySpec.HealthCheck.Readiness != nil {
-			containers[0].ReadinessProbe = healthCheckProbeToK8sProbe(deploySpec.HealthCheck.Readiness)
+			containers[0].ReadinessProbe = healthCheckProbeToK8sProbe(deploySpec.HealthCheck.Readiness, tcp)
 		}
 	}
 
@@ -178,6 +251,7 @@ func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.De
 	if err != nil {
 		return nil, err
 	}
+	setSecurityContext(deploySpec.SecurityContext, containers, initContainers)
 	ps := k8sv1.PodSpec{
 		RestartPolicy: k8sv1.RestartPolicyAlways,
 		Containers:    containers,
@@ -185,6 +259,13 @@ func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.De
 		AutomountServiceAccountToken: &f,
 		InitContainers:               initContainers,
 	}
+	if lc := deploySpec.Lifecycle; lc != nil && lc.TerminationGracePeriodSeconds > 0 {
+		grace := int64(lc.TerminationGracePeriodSeconds)
+		ps.TerminationGracePeriodSeconds = &grace
+	}
+	setPodScheduling(&ps, &deploySpec.TeresaYaml)
+	ps.HostAliases = hostAliasesToK8sHostAliases(deploySpec.HostAliases)
+	setPodSpread(&ps, deploySpec.Spread, deploySpec.MatchLabels)
 
 	var maxSurge, maxUnavailable *intstr.IntOrString
 	if deploySpec.RollingUpdate != nil {
@@ -203,8 +284,12 @@ func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.De
 			Namespace: deploySpec.Namespace,
 			Labels:    deploySpec.Labels,
 			Annotations: map[string]string{
-				changeCauseAnnotation: deploySpec.Description,
-				spec.SlugAnnotation:   deploySpec.SlugURL,
+				changeCauseAnnotation:  deploySpec.Description,
+				spec.SlugAnnotation:    deploySpec.SlugURL,
+				spec.AuthorAnnotation:  deploySpec.Author,
+				spec.CommitAnnotation:  deploySpec.Commit,
+				spec.BranchAnnotation:  deploySpec.Branch,
+				spec.MessageAnnotation: deploySpec.Message,
 			},
 		},
 		Spec: v1beta2.DeploymentSpec{
@@ -231,9 +316,116 @@ func deploySpecToK8sDeploy(deploySpec *spec.Deploy, replicas int32) (*v1beta2.De
 			},
 		},
 	}
+	if deploySpec.RolloutTimeoutSeconds > 0 {
+		deadline := deploySpec.RolloutTimeoutSeconds
+		d.Spec.ProgressDeadlineSeconds = &deadline
+	}
 	return d, nil
 }
 
+// setPodScheduling sets the nodeSelector, tolerations and node affinity of
+// teresa.yaml in the pod spec
+func setPodScheduling(ps *k8sv1.PodSpec, ty *spec.TeresaYaml) {
+	ps.NodeSelector = ty.NodeSelector
+
+	for _, t := range ty.Tolerations {
+		ps.Tolerations = append(ps.Tolerations, k8sv1.Toleration{
+			Key:      t.Key,
+			Operator: k8sv1.TolerationOperator(t.Operator),
+			Value:    t.Value,
+			Effect:   k8sv1.TaintEffect(t.Effect),
+		})
+	}
+
+	if ty.Affinity == nil {
+		return
+	}
+	na := new(k8sv1.NodeAffinity)
+	if len(ty.Affinity.Required) > 0 {
+		exprs := make([]k8sv1.NodeSelectorRequirement, len(ty.Affinity.Required))
+		for i := range ty.Affinity.Required {
+			exprs[i] = nodeRequirementToK8s(&ty.Affinity.Required[i])
+		}
+		na.RequiredDuringSchedulingIgnoredDuringExecution = &k8sv1.NodeSelector{
+			NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{MatchExpressions: exprs}},
+		}
+	}
+	for _, r := range ty.Affinity.Preferred {
+		na.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			na.PreferredDuringSchedulingIgnoredDuringExecution,
+			k8sv1.PreferredSchedulingTerm{
+				Weight: r.Weight,
+				Preference: k8sv1.NodeSelectorTerm{
+					MatchExpressions: []k8sv1.NodeSelectorRequirement{nodeRequirementToK8s(&r.NodeRequirement)},
+				},
+			},
+		)
+	}
+	ps.Affinity = &k8sv1.Affinity{NodeAffinity: na}
+}
+
+func hostAliasesToK8sHostAliases(aliases []spec.HostAlias) []k8sv1.HostAlias {
+	if len(aliases) == 0 {
+		return nil
+	}
+	has := make([]k8sv1.HostAlias, len(aliases))
+	for i, a := range aliases {
+		has[i] = k8sv1.HostAlias{IP: a.IP, Hostnames: a.Hostnames}
+	}
+	return has
+}
+
+// setPodSpread sets the pod anti-affinity spreading the pods matching labels
+// across zones and hosts
+func setPodSpread(ps *k8sv1.PodSpec, s *spec.Spread, labels spec.Labels) {
+	if s == nil {
+		return
+	}
+	paa := new(k8sv1.PodAntiAffinity)
+	policies := []struct{ policy, topologyKey string }{
+		{s.Zone, zoneTopologyKey},
+		{s.Host, hostTopologyKey},
+	}
+	for _, p := range policies {
+		var weight int32
+		switch p.policy {
+		case spec.SpreadRequired:
+			weight = spreadRequiredWeight
+		case spec.SpreadPreferred:
+			weight = spreadPreferredWeight
+		default:
+			continue
+		}
+		// required anti-affinity would allow a single pod per zone or host,
+		// so both policies are preferences, required being the strongest one
+		paa.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			paa.PreferredDuringSchedulingIgnoredDuringExecution,
+			k8sv1.WeightedPodAffinityTerm{
+				Weight: weight,
+				PodAffinityTerm: k8sv1.PodAffinityTerm{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+					TopologyKey:   p.topologyKey,
+				},
+			},
+		)
+	}
+	if paa.PreferredDuringSchedulingIgnoredDuringExecution == nil {
+		return
+	}
+	if ps.Affinity == nil {
+		ps.Affinity = new(k8sv1.Affinity)
+	}
+	ps.Affinity.PodAntiAffinity = paa
+}
+
+func nodeRequirementToK8s(r *spec.NodeRequirement) k8sv1.NodeSelectorRequirement {
+	return k8sv1.NodeSelectorRequirement{
+		Key:      r.Key,
+		Operator: k8sv1.NodeSelectorOperator(r.Operator),
+		Values:   r.Values,
+	}
+}
+
 func podSpecToK8sInitContainers(podSpec *spec.Pod) ([]k8sv1.Container, error) {
 	return containerSpecsToK8sContainers(podSpec.InitContainers)
 }
@@ -249,6 +441,7 @@ func cronJobSpecToK8sCronJob(cronJobSpec *spec.CronJob) (*k8sv1beta1.CronJob, er
 	if err != nil {
 		return nil, err
 	}
+	setSecurityContext(cronJobSpec.SecurityContext, containers, initContainers)
 
 	f := false
 	ps := k8sv1.PodSpec{
@@ -258,6 +451,8 @@ func cronJobSpecToK8sCronJob(cronJobSpec *spec.CronJob) (*k8sv1beta1.CronJob, er
 		AutomountServiceAccountToken: &f,
 		InitContainers:               initContainers,
 	}
+	setPodScheduling(&ps, &cronJobSpec.TeresaYaml)
+	ps.HostAliases = hostAliasesToK8sHostAliases(cronJobSpec.HostAliases)
 
 	successfulLim := cronJobSpec.SuccessfulJobsHistoryLimit
 	failedLim := cronJobSpec.FailedJobsHistoryLimit
@@ -303,20 +498,66 @@ func rollingUpdateToK8sRollingUpdate(ru *spec.RollingUpdate) (maxSurge, maxUnava
 	return conv(ru.MaxSurge), conv(ru.MaxUnavailable)
 }
 
-func healthCheckProbeToK8sProbe(probe *spec.HealthCheckProbe) *k8sv1.Probe {
-	return &k8sv1.Probe{
+// setSecurityContext restricts every container of the pod, otherwise a
+// sidecar or an init container would escape the baseline of the cluster
+func setSecurityContext(sc *spec.SecurityContext, containerLists ...[]k8sv1.Container) {
+	if sc == nil {
+		return
+	}
+	for _, containers := range containerLists {
+		for i := range containers {
+			containers[i].SecurityContext = securityContextToK8sSecurityContext(sc)
+		}
+	}
+}
+
+func securityContextToK8sSecurityContext(sc *spec.SecurityContext) *k8sv1.SecurityContext {
+	if sc == nil {
+		return nil
+	}
+	ksc := &k8sv1.SecurityContext{
+		RunAsNonRoot:           sc.RunAsNonRoot,
+		RunAsUser:              sc.RunAsUser,
+		ReadOnlyRootFilesystem: sc.ReadOnlyRootFilesystem,
+	}
+	if len(sc.DropCapabilities) > 0 {
+		ksc.Capabilities = &k8sv1.Capabilities{}
+		for _, c := range sc.DropCapabilities {
+			ksc.Capabilities.Drop = append(ksc.Capabilities.Drop, k8sv1.Capability(c))
+		}
+	}
+	return ksc
+}
+
+// healthCheckProbeToK8sProbe converts the probe to the handler of its type,
+// HTTP probes become TCP ones if tcp is true (the path is ignored in this
+// case)
+func healthCheckProbeToK8sProbe(probe *spec.HealthCheckProbe, tcp bool) *k8sv1.Probe {
+	p := &k8sv1.Probe{
 		InitialDelaySeconds: probe.InitialDelaySeconds,
 		TimeoutSeconds:      probe.TimeoutSeconds,
 		PeriodSeconds:       probe.PeriodSeconds,
 		FailureThreshold:    probe.FailureThreshold,
 		SuccessThreshold:    probe.SuccessThreshold,
-		Handler: k8sv1.Handler{
-			HTTPGet: &k8sv1.HTTPGetAction{
-				Port: intstr.FromInt(spec.DefaultPort),
-				Path: probe.Path,
-			},
-		},
 	}
+	port := spec.DefaultPort
+	if probe.Port != 0 {
+		port = probe.Port
+	}
+	switch {
+	case probe.Type == spec.ProbeTypeExec:
+		p.Handler.Exec = &k8sv1.ExecAction{Command: probe.Command}
+	case probe.Type == spec.ProbeTypeTCP || tcp:
+		p.Handler.TCPSocket = &k8sv1.TCPSocketAction{
+			Port: intstr.FromInt(port),
+		}
+	default:
+		p.Handler.HTTPGet = &k8sv1.HTTPGetAction{
+			Port: intstr.FromInt(port),
+			Path: probe.Path,
+		}
+	}
+	return p
 }
 
 func lifecycleToK8sLifecycle(lc *spec.Lifecycle) *k8sv1.Lifecycle {
@@ -389,12 +630,347 @@ func ingressSpec(namespace, name string, vHosts []string) *k8s_extensions.Ingres
 	}
 }
 
+func ingressBackend(igs *k8s_extensions.Ingress) *k8s_extensions.IngressBackend {
+	for _, r := range igs.Spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+		for _, p := range r.HTTP.Paths {
+			b := p.Backend
+			return &b
+		}
+	}
+	return nil
+}
+
+func setIngressBackend(igs *k8s_extensions.Ingress, serviceName string, servicePort int) {
+	for _, r := range igs.Spec.Rules {
+		if r.HTTP == nil {
+			continue
+		}
+		for i := range r.HTTP.Paths {
+			r.HTTP.Paths[i].Backend = k8s_extensions.IngressBackend{
+				ServiceName: serviceName,
+				ServicePort: intstr.FromInt(servicePort),
+			}
+		}
+	}
+}
+
+// canaryIngressSpec returns the canary ingress of the app ingress igs, it
+// routes weight percent of the requests to the hosts of the app to the
+// canary service. The TLS of the hosts is kept by the app ingress
+func canaryIngressSpec(igs *k8s_extensions.Ingress, canaryName string, weight int32) *k8s_extensions.Ingress {
+	c := &k8s_extensions.Ingress{
+		TypeMeta: igs.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        canaryName,
+			Namespace:   igs.Namespace,
+			Annotations: make(map[string]string),
+		},
+		Spec: *igs.Spec.DeepCopy(),
+	}
+	for k, v := range igs.Annotations {
+		c.Annotations[k] = v
+	}
+	delete(c.Annotations, certManagerIssuerAnnotation)
+	c.Spec.TLS = nil
+	c.Annotations[nginxAnnotationPrefix+"canary"] = "true"
+	c.Annotations[nginxAnnotationPrefix+"canary-weight"] = strconv.Itoa(int(weight))
+	setIngressBackend(c, canaryName, spec.DefaultExternalPort)
+	return c
+}
+
+// canaryServiceSpec returns the internal service of the canary pods with
+// the ports of the app service svc
+func canaryServiceSpec(svc *k8sv1.Service, canaryName string) *k8sv1.Service {
+	ports := make([]k8sv1.ServicePort, len(svc.Spec.Ports))
+	for i, p := range svc.Spec.Ports {
+		p.NodePort = 0
+		ports[i] = p
+	}
+	return &k8sv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      canaryName,
+			Namespace: svc.Namespace,
+		},
+		Spec: k8sv1.ServiceSpec{
+			Type:     k8sv1.ServiceTypeClusterIP,
+			Ports:    ports,
+			Selector: map[string]string{"run": canaryName},
+		},
+	}
+}
+
+// deployAnnotations describe the revision of a deploy and go along with its
+// pods on promote
+var deployAnnotations = []string{
+	changeCauseAnnotation,
+	spec.SlugAnnotation,
+	spec.AuthorAnnotation,
+	spec.CommitAnnotation,
+	spec.BranchAnnotation,
+	spec.MessageAnnotation,
+}
+
+// promoteDeploy replaces the pods of the app deploy d by the ones of the
+// deploy src. The pod anti-affinity of the app is kept, the one of src
+// selects its own pods
+func promoteDeploy(d, src *v1beta2.Deployment) {
+	var antiAffinity *k8sv1.PodAntiAffinity
+	if a := d.Spec.Template.Spec.Affinity; a != nil {
+		antiAffinity = a.PodAntiAffinity
+	}
+	d.Spec.Template.Spec = *src.Spec.Template.Spec.DeepCopy()
+	if a := d.Spec.Template.Spec.Affinity; a != nil {
+		a.PodAntiAffinity = antiAffinity
+	} else if antiAffinity != nil {
+		d.Spec.Template.Spec.Affinity = &k8sv1.Affinity{PodAntiAffinity: antiAffinity}
+	}
+	if d.Annotations == nil {
+		d.Annotations = make(map[string]string)
+	}
+	for _, k := range deployAnnotations {
+		d.Annotations[k] = src.Annotations[k]
+	}
+}
+
+func maintenanceServiceSpec(namespace, name, externalName string, port int) *k8sv1.Service {
+	return &k8sv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: k8sv1.ServiceSpec{
+			Type:         k8sv1.ServiceTypeExternalName,
+			ExternalName: externalName,
+			Ports: []k8sv1.ServicePort{
+				{Name: "http", Port: int32(port), TargetPort: intstr.FromInt(port)},
+			},
+		},
+	}
+}
+
+// setIngressTLS annotates the ingress for cert-manager to issue a
+// certificate, stored in the secret secretName, for all its hosts
+func setIngressTLS(igs *k8s_extensions.Ingress, issuer, secretName string) {
+	if igs.Annotations == nil {
+		igs.Annotations = make(map[string]string)
+	}
+	igs.Annotations[certManagerIssuerAnnotation] = issuer
+	igs.Spec.TLS = []k8s_extensions.IngressTLS{
+		{Hosts: ingressHosts(igs), SecretName: secretName},
+	}
+}
+
+// setIngressOptions replaces the annotations of the app ingress options
+func setIngressOptions(igs *k8s_extensions.Ingress, opts map[string]string) {
+	if igs.Annotations == nil {
+		igs.Annotations = make(map[string]string)
+	}
+	for _, k := range []string{
+		ingressClassAnnotation,
+		nginxAnnotationPrefix + app.IngressOptionProxyBodySize,
+		nginxAnnotationPrefix + app.IngressOptionProxyConnectTimeout,
+		nginxAnnotationPrefix + app.IngressOptionProxyReadTimeout,
+		nginxAnnotationPrefix + app.IngressOptionProxySendTimeout,
+		nginxAnnotationPrefix + "affinity",
+	} {
+		delete(igs.Annotations, k)
+	}
+
+	for k, v := range opts {
+		switch k {
+		case app.IngressOptionClass:
+			igs.Annotations[ingressClassAnnotation] = v
+		case app.IngressOptionStickySessions:
+			if sticky, _ := strconv.ParseBool(v); sticky {
+				igs.Annotations[nginxAnnotationPrefix+"affinity"] = "cookie"
+			}
+		default:
+			igs.Annotations[nginxAnnotationPrefix+k] = v
+		}
+	}
+}
+
+// pdbSpec returns a PodDisruptionBudget of the pods of the app deploy
+func pdbSpec(namespace, name, minAvailable string) *k8spolicy.PodDisruptionBudget {
+	ma := intstr.Parse(minAvailable)
+	return &k8spolicy.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1beta1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: k8spolicy.PodDisruptionBudgetSpec{
+			MinAvailable: &ma,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"run": name},
+			},
+		},
+	}
+}
+
+func pvcSpec(namespace string, vc *app.VolumeClaim) (*k8sv1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(vc.Size)
+	if err != nil {
+		return nil, err
+	}
+	accessMode := k8sv1.ReadWriteOnce
+	if vc.AccessMode != "" {
+		accessMode = k8sv1.PersistentVolumeAccessMode(vc.AccessMode)
+	}
+	pvc := &k8sv1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "PersistentVolumeClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vc.Name,
+			Namespace: namespace,
+		},
+		Spec: k8sv1.PersistentVolumeClaimSpec{
+			AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode},
+			Resources: k8sv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{k8sv1.ResourceStorage: size},
+			},
+		},
+	}
+	if vc.StorageClass != "" {
+		pvc.Spec.StorageClassName = &vc.StorageClass
+	}
+	return pvc, nil
+}
+
+// setIngressBackendProtocol makes the ingress controller talk gRPC with
+// the backend of grpc apps
+func setIngressBackendProtocol(igs *k8s_extensions.Ingress, protocol string) {
+	if protocol != app.ProtocolGRPC {
+		delete(igs.Annotations, nginxAnnotationPrefix+"backend-protocol")
+		return
+	}
+	if igs.Annotations == nil {
+		igs.Annotations = make(map[string]string)
+	}
+	igs.Annotations[nginxAnnotationPrefix+"backend-protocol"] = "GRPC"
+}
+
+// setIngressSourceRanges restricts the access to the ingress to the cidrs,
+// no restriction is made if cidrs is empty
+func setIngressSourceRanges(igs *k8s_extensions.Ingress, cidrs []string) {
+	if len(cidrs) == 0 {
+		delete(igs.Annotations, nginxAnnotationPrefix+"whitelist-source-range")
+		return
+	}
+	if igs.Annotations == nil {
+		igs.Annotations = make(map[string]string)
+	}
+	igs.Annotations[nginxAnnotationPrefix+"whitelist-source-range"] = strings.Join(cidrs, ",")
+}
+
+// streamPortsData returns the data of an ingress-nginx tcp or udp services
+// ConfigMap with the ports of the service namespace/svcName replaced
+func streamPortsData(data map[string]string, namespace, svcName string, ports []spec.ServicePort) (map[string]string, error) {
+	prefix := fmt.Sprintf("%s/%s:", namespace, svcName)
+	ndata := make(map[string]string, len(data)+len(ports))
+	for k, v := range data {
+		if !strings.HasPrefix(v, prefix) {
+			ndata[k] = v
+		}
+	}
+	for _, p := range ports {
+		k := strconv.Itoa(p.Port)
+		if _, found := ndata[k]; found {
+			return nil, ErrStreamPortInUse
+		}
+		ndata[k] = fmt.Sprintf("%s%d", prefix, p.Port)
+	}
+	return ndata, nil
+}
+
+func renameStreamPortsData(data map[string]string, src, dst string) map[string]string {
+	prefix := fmt.Sprintf("%s/%s:", src, src)
+	ndata := make(map[string]string, len(data))
+	for k, v := range data {
+		if strings.HasPrefix(v, prefix) {
+			v = fmt.Sprintf("%s/%s:%s", dst, dst, strings.TrimPrefix(v, prefix))
+		}
+		ndata[k] = v
+	}
+	return ndata
+}
+
+func ingressHosts(igs *k8s_extensions.Ingress) []string {
+	hosts := make([]string, len(igs.Spec.Rules))
+	for i, r := range igs.Spec.Rules {
+		hosts[i] = r.Host
+	}
+	return hosts
+}
+
+// certificateStatus returns the status of the PEM encoded certificate crt
+// at the time now
+func certificateStatus(crt []byte, now time.Time) string {
+	b, _ := pem.Decode(crt)
+	if b == nil {
+		return app.TLSStatusPending
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return app.TLSStatusPending
+	}
+	if now.After(cert.NotAfter) {
+		return app.TLSStatusExpired
+	}
+	return fmt.Sprintf("%s (expires %s)", app.TLSStatusIssued, cert.NotAfter.Format("2006-01-02"))
+}
+
+func appLogOptsToK8s(opts *app.LogOptions) *k8sv1.PodLogOptions {
+	k8sOpts := &k8sv1.PodLogOptions{
+		Follow:     opts.Follow,
+		Previous:   opts.Previous,
+		Container:  opts.Container,
+		Timestamps: opts.Timestamps,
+	}
+	if opts.Lines >= 0 {
+		lines := opts.Lines
+		k8sOpts.TailLines = &lines
+	}
+	if opts.SinceSeconds > 0 {
+		since := opts.SinceSeconds
+		k8sOpts.SinceSeconds = &since
+	}
+
+	return k8sOpts
+}
+
 func appPodListOptsToK8s(opts *app.PodListOptions) *metav1.ListOptions {
 	var k8sOpts metav1.ListOptions
 
 	if opts.PodName != "" {
 		k8sOpts.FieldSelector = fmt.Sprintf("metadata.name=%s", opts.PodName)
 	}
+	var labels []string
+	if opts.JobName != "" {
+		labels = append(labels, fmt.Sprintf("job-name=%s", opts.JobName))
+	} else if opts.AnyJob {
+		labels = append(labels, "job-name")
+	}
+	if opts.DeployName != "" {
+		labels = append(labels, fmt.Sprintf("run=%s", opts.DeployName))
+	}
+	k8sOpts.LabelSelector = strings.Join(labels, ",")
 
 	return &k8sOpts
 }
@@ -420,6 +996,7 @@ func servicePortsToK8sServicePorts(ports []spec.ServicePort) []k8sv1.ServicePort
 			Name:       ports[i].Name,
 			Port:       int32(ports[i].Port),
 			TargetPort: intstr.FromInt(ports[i].TargetPort),
+			Protocol:   k8sv1.Protocol(ports[i].Protocol),
 		}
 	}
 	return k8sPorts
@@ -457,6 +1034,7 @@ func k8sServicePortsToServicePorts(ports []k8sv1.ServicePort) []spec.ServicePort
 			Port:       int(ports[i].Port),
 			Name:       ports[i].Name,
 			TargetPort: int(ports[i].TargetPort.IntVal),
+			Protocol:   string(ports[i].Protocol),
 		}
 	}
 	return sp
@@ -494,3 +1072,288 @@ func k8sPodToAppPod(pod *k8sv1.Pod) *app.Pod {
 	}
 	return p
 }
+
+func k8sPodToAppPodDetail(pod *k8sv1.Pod) *app.PodDetail {
+	p := &app.PodDetail{
+		Pod:  *k8sPodToAppPod(pod),
+		Node: pod.Spec.NodeName,
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.LastTerminationState.Terminated != nil {
+			p.LastTerminationReason = status.LastTerminationState.Terminated.Reason
+		}
+	}
+	return p
+}
+
+func k8sEventToAppEvent(ev *k8sv1.Event) *app.Event {
+	e := &app.Event{
+		Type:    ev.Type,
+		Reason:  ev.Reason,
+		Object:  fmt.Sprintf("%s/%s", strings.ToLower(ev.InvolvedObject.Kind), ev.InvolvedObject.Name),
+		Message: ev.Message,
+		Count:   ev.Count,
+	}
+	if !ev.LastTimestamp.IsZero() {
+		e.LastSeen = ev.LastTimestamp.Unix()
+	}
+	return e
+}
+
+func k8sCronJobToAppCronJob(cj *k8sv1beta1.CronJob) *app.CronJob {
+	c := &app.CronJob{
+		Name:     cj.Name,
+		Schedule: cj.Spec.Schedule,
+		Active:   int32(len(cj.Status.Active)),
+	}
+	if cj.Spec.Suspend != nil {
+		c.Suspended = *cj.Spec.Suspend
+	}
+	if t := cj.Status.LastScheduleTime; t != nil && !t.IsZero() {
+		c.LastSchedule = t.Unix()
+	}
+	return c
+}
+
+// manualJobName names the jobs started out of the schedule of a CronJob,
+// the name is truncated to fit in a label value
+func manualJobName(cronJobName string, t time.Time) string {
+	suffix := fmt.Sprintf("-manual-%d", t.Unix())
+	if max := 63 - len(suffix); len(cronJobName) > max {
+		cronJobName = cronJobName[:max]
+	}
+	return cronJobName + suffix
+}
+
+// k8sCronJobToManualJob returns a job from the template of the CronJob,
+// owned by it like the scheduled ones
+func k8sCronJobToManualJob(cj *k8sv1beta1.CronJob, name string) *k8sbatch.Job {
+	annotations := map[string]string{"cronjob.kubernetes.io/instantiate": "manual"}
+	for k, v := range cj.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	isController := true
+	return &k8sbatch.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cj.Namespace,
+			Labels:      cj.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "batch/v1beta1",
+				Kind:       "CronJob",
+				Name:       cj.Name,
+				UID:        cj.UID,
+				Controller: &isController,
+			}},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+}
+
+// isCronJobRun checks if the job was created by the CronJob, either by its
+// schedule or by a manual run
+func isCronJobRun(job *k8sbatch.Job, cronJobName string) bool {
+	for _, ref := range job.OwnerReferences {
+		if ref.Kind == "CronJob" && ref.Name == cronJobName {
+			return true
+		}
+	}
+	return false
+}
+
+// k8sJobToAppCronRun returns the run of a CronJob, the exit code is the one
+// of the container in the last pod of the job
+func k8sJobToAppCronRun(job *k8sbatch.Job, pods []k8sv1.Pod, container string, now time.Time) *app.CronRun {
+	run := &app.CronRun{Name: job.Name, Status: app.CronRunStatusRunning}
+	end := now
+	for _, c := range job.Status.Conditions {
+		if c.Status != k8sv1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case k8sbatch.JobComplete:
+			run.Status = app.CronRunStatusSucceeded
+		case k8sbatch.JobFailed:
+			run.Status = app.CronRunStatusFailed
+			end = c.LastTransitionTime.Time
+		}
+	}
+	if t := job.Status.CompletionTime; t != nil && !t.IsZero() {
+		end = t.Time
+	}
+	if t := job.Status.StartTime; t != nil && !t.IsZero() {
+		run.StartTime = t.Unix()
+		run.Duration = int64(end.Sub(t.Time).Seconds())
+	}
+
+	var last *k8sv1.Pod
+	for i := range pods {
+		if pods[i].Labels["job-name"] != job.Name {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&pods[i].CreationTimestamp) {
+			last = &pods[i]
+		}
+	}
+	if last == nil {
+		return run
+	}
+	for _, cs := range last.Status.ContainerStatuses {
+		if cs.Name != container {
+			continue
+		}
+		if cs.State.Terminated != nil {
+			run.ExitCode = cs.State.Terminated.ExitCode
+		} else if cs.LastTerminationState.Terminated != nil {
+			run.ExitCode = cs.LastTerminationState.Terminated.ExitCode
+		}
+	}
+	return run
+}
+
+// renameValue renames values equal to src or prefixed by it, like the
+// Deployments of extra process types
+func renameValue(v, src, dst string) string {
+	if v == src || strings.HasPrefix(v, src+"-") {
+		return dst + strings.TrimPrefix(v, src)
+	}
+	return v
+}
+
+func renameLabels(labels map[string]string, src, dst string) map[string]string {
+	if labels == nil {
+		return nil
+	}
+	lb := make(map[string]string, len(labels))
+	for k, v := range labels {
+		lb[k] = renameValue(v, src, dst)
+	}
+	return lb
+}
+
+func renameK8sObjectMeta(om metav1.ObjectMeta, src, dst string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        renameValue(om.Name, src, dst),
+		Namespace:   dst,
+		Labels:      renameLabels(om.Labels, src, dst),
+		Annotations: om.Annotations,
+	}
+}
+
+func renameK8sContainers(containers []k8sv1.Container, src, dst string) []k8sv1.Container {
+	cs := make([]k8sv1.Container, len(containers))
+	for i, c := range containers {
+		c.Name = renameValue(c.Name, src, dst)
+		env := make([]k8sv1.EnvVar, len(c.Env))
+		for j, ev := range c.Env {
+			if ev.Name == "APP" {
+				ev.Value = dst
+			}
+			env[j] = ev
+		}
+		c.Env = env
+		cs[i] = c
+	}
+	return cs
+}
+
+func renameK8sPodTemplate(pt k8sv1.PodTemplateSpec, src, dst string) k8sv1.PodTemplateSpec {
+	pt.Labels = renameLabels(pt.Labels, src, dst)
+	pt.Spec.Containers = renameK8sContainers(pt.Spec.Containers, src, dst)
+	pt.Spec.InitContainers = renameK8sContainers(pt.Spec.InitContainers, src, dst)
+
+	vols := make([]k8sv1.Volume, len(pt.Spec.Volumes))
+	for i, v := range pt.Spec.Volumes {
+		if v.ConfigMap != nil && v.ConfigMap.Name == src {
+			cm := *v.ConfigMap
+			cm.Name = dst
+			v.ConfigMap = &cm
+		}
+		vols[i] = v
+	}
+	pt.Spec.Volumes = vols
+	return pt
+}
+
+func renameK8sDeploy(d *v1beta2.Deployment, src, dst string) *v1beta2.Deployment {
+	nd := &v1beta2.Deployment{
+		TypeMeta:   d.TypeMeta,
+		ObjectMeta: renameK8sObjectMeta(d.ObjectMeta, src, dst),
+		Spec:       d.Spec,
+	}
+	nd.Spec.Template = renameK8sPodTemplate(d.Spec.Template, src, dst)
+	if d.Spec.Selector != nil {
+		nd.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: renameLabels(d.Spec.Selector.MatchLabels, src, dst),
+		}
+	}
+	return nd
+}
+
+func renameK8sCronJob(cj *k8sv1beta1.CronJob, src, dst string) *k8sv1beta1.CronJob {
+	ncj := &k8sv1beta1.CronJob{
+		TypeMeta:   cj.TypeMeta,
+		ObjectMeta: renameK8sObjectMeta(cj.ObjectMeta, src, dst),
+		Spec:       cj.Spec,
+	}
+	ncj.Spec.JobTemplate.Spec.Template = renameK8sPodTemplate(
+		cj.Spec.JobTemplate.Spec.Template,
+		src,
+		dst,
+	)
+	return ncj
+}
+
+func renameK8sService(svc *k8sv1.Service, src, dst string) *k8sv1.Service {
+	ports := make([]k8sv1.ServicePort, len(svc.Spec.Ports))
+	for i, p := range svc.Spec.Ports {
+		p.NodePort = 0
+		ports[i] = p
+	}
+	nsvc := &k8sv1.Service{
+		TypeMeta:   svc.TypeMeta,
+		ObjectMeta: renameK8sObjectMeta(svc.ObjectMeta, src, dst),
+		Spec:       svc.Spec,
+	}
+	nsvc.Spec.ClusterIP = ""
+	nsvc.Spec.Ports = ports
+	nsvc.Spec.Selector = renameLabels(svc.Spec.Selector, src, dst)
+	return nsvc
+}
+
+func renameK8sIngress(igs *k8s_extensions.Ingress, src, dst string) *k8s_extensions.Ingress {
+	nigs := &k8s_extensions.Ingress{
+		TypeMeta:   igs.TypeMeta,
+		ObjectMeta: renameK8sObjectMeta(igs.ObjectMeta, src, dst),
+		Spec:       igs.Spec,
+	}
+	rules := make([]k8s_extensions.IngressRule, len(igs.Spec.Rules))
+	for i, r := range igs.Spec.Rules {
+		if r.HTTP != nil {
+			paths := make([]k8s_extensions.HTTPIngressPath, len(r.HTTP.Paths))
+			for j, p := range r.HTTP.Paths {
+				if p.Backend.ServiceName == src {
+					p.Backend.ServiceName = dst
+				}
+				paths[j] = p
+			}
+			r.HTTP = &k8s_extensions.HTTPIngressRuleValue{Paths: paths}
+		}
+		rules[i] = r
+	}
+	nigs.Spec.Rules = rules
+	tls := make([]k8s_extensions.IngressTLS, len(igs.Spec.TLS))
+	for i, t := range igs.Spec.TLS {
+		if t.SecretName == src+tlsSecretSuffix {
+			t.SecretName = dst + tlsSecretSuffix
+		}
+		tls[i] = t
+	}
+	nigs.Spec.TLS = tls
+	return nigs
+}
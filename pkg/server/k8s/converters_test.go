@@ -1,9 +1,21 @@
 package k8s
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"k8s.io/api/apps/v1beta2"
+	k8sbatch "k8s.io/api/batch/v1"
+	k8sv1beta1 "k8s.io/api/batch/v1beta1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -237,7 +249,7 @@ func TestHealthCheckProbeToK8sProbe(t *testing.T) {
 		TimeoutSeconds:      3,
 		Path:                "/hc/",
 	}
-	k8sHC := healthCheckProbeToK8sProbe(hc)
+	k8sHC := healthCheckProbeToK8sProbe(hc, false)
 
 	if k8sHC.InitialDelaySeconds != hc.InitialDelaySeconds {
 		t.Errorf("expected %d, got %d", hc.InitialDelaySeconds, k8sHC.InitialDelaySeconds)
@@ -341,6 +353,51 @@ func TestServiceSpec(t *testing.T) {
 	}
 }
 
+func TestHealthCheckProbeToK8sProbeTCP(t *testing.T) {
+	hc := &spec.HealthCheckProbe{PeriodSeconds: 5, Path: "/hc/"}
+	k8sHC := healthCheckProbeToK8sProbe(hc, true)
+
+	if k8sHC.Handler.HTTPGet != nil {
+		t.Errorf("expected no http probe, got %v", k8sHC.Handler.HTTPGet)
+	}
+	if k8sHC.Handler.TCPSocket == nil {
+		t.Fatal("expected a tcp probe, got nil")
+	}
+	if port := k8sHC.Handler.TCPSocket.Port.IntValue(); port != spec.DefaultPort {
+		t.Errorf("expected %d, got %d", spec.DefaultPort, port)
+	}
+	if k8sHC.PeriodSeconds != hc.PeriodSeconds {
+		t.Errorf("expected %d, got %d", hc.PeriodSeconds, k8sHC.PeriodSeconds)
+	}
+}
+
+func TestHealthCheckProbeToK8sProbeTypes(t *testing.T) {
+	tcp := healthCheckProbeToK8sProbe(&spec.HealthCheckProbe{Type: spec.ProbeTypeTCP, Port: 9090}, false)
+	if tcp.Handler.TCPSocket == nil {
+		t.Fatal("expected a tcp probe, got nil")
+	}
+	if port := tcp.Handler.TCPSocket.Port.IntValue(); port != 9090 {
+		t.Errorf("expected 9090, got %d", port)
+	}
+
+	cmd := []string{"pgrep", "worker"}
+	exec := healthCheckProbeToK8sProbe(&spec.HealthCheckProbe{Type: spec.ProbeTypeExec, Command: cmd}, true)
+	if exec.Handler.TCPSocket != nil || exec.Handler.HTTPGet != nil {
+		t.Errorf("expected only an exec probe, got %v", exec.Handler)
+	}
+	if exec.Handler.Exec == nil || !reflect.DeepEqual(exec.Handler.Exec.Command, cmd) {
+		t.Errorf("expected exec of %v, got %v", cmd, exec.Handler.Exec)
+	}
+
+	http := healthCheckProbeToK8sProbe(&spec.HealthCheckProbe{Type: spec.ProbeTypeHTTP, Port: 8081, Path: "/hc"}, false)
+	if http.Handler.HTTPGet == nil {
+		t.Fatal("expected a http probe, got nil")
+	}
+	if port := http.Handler.HTTPGet.Port.IntValue(); port != 8081 {
+		t.Errorf("expected 8081, got %d", port)
+	}
+}
+
 func TestIngressSpec(t *testing.T) {
 	name := "teresa"
 	namespace := "teresa"
@@ -363,6 +420,383 @@ func TestIngressSpec(t *testing.T) {
 	}
 }
 
+func TestSetIngressBackend(t *testing.T) {
+	i := ingressSpec("teresa", "teresa", []string{"test1.teresa-apps.io", "test2.teresa-apps.io"})
+	setIngressBackend(i, "teresa-maintenance", 8080)
+
+	for _, rule := range i.Spec.Rules {
+		b := rule.HTTP.Paths[0].Backend
+		if b.ServiceName != "teresa-maintenance" {
+			t.Errorf("expected teresa-maintenance, got %s", b.ServiceName)
+		}
+		if b.ServicePort.IntValue() != 8080 {
+			t.Errorf("expected 8080, got %d", b.ServicePort.IntValue())
+		}
+	}
+	if b := ingressBackend(i); b == nil || b.ServiceName != "teresa-maintenance" {
+		t.Errorf("expected teresa-maintenance backend, got %v", b)
+	}
+}
+
+func TestMaintenanceServiceSpec(t *testing.T) {
+	svc := maintenanceServiceSpec("teresa", "teresa-maintenance", "maintenance.teresa.svc.cluster.local", 80)
+
+	if svc.Spec.Type != k8sv1.ServiceTypeExternalName {
+		t.Errorf("expected %s, got %s", k8sv1.ServiceTypeExternalName, svc.Spec.Type)
+	}
+	if svc.Spec.ExternalName != "maintenance.teresa.svc.cluster.local" {
+		t.Errorf("expected maintenance.teresa.svc.cluster.local, got %s", svc.Spec.ExternalName)
+	}
+	if svc.Spec.Ports[0].Port != 80 {
+		t.Errorf("expected 80, got %d", svc.Spec.Ports[0].Port)
+	}
+}
+
+func TestSetIngressTLS(t *testing.T) {
+	vHosts := []string{"test1.teresa-apps.io", "test2.teresa-apps.io"}
+	i := ingressSpec("teresa", "teresa", vHosts)
+	setIngressTLS(i, "letsencrypt", "teresa-tls")
+
+	if got := i.Annotations[certManagerIssuerAnnotation]; got != "letsencrypt" {
+		t.Errorf("expected letsencrypt, got %s", got)
+	}
+	if len(i.Spec.TLS) != 1 {
+		t.Fatalf("expected 1 tls entry, got %d", len(i.Spec.TLS))
+	}
+	tls := i.Spec.TLS[0]
+	if tls.SecretName != "teresa-tls" {
+		t.Errorf("expected teresa-tls, got %s", tls.SecretName)
+	}
+	if !reflect.DeepEqual(tls.Hosts, vHosts) {
+		t.Errorf("expected %v, got %v", vHosts, tls.Hosts)
+	}
+}
+
+func TestCanaryIngressSpec(t *testing.T) {
+	i := ingressSpec("teresa", "teresa", []string{"test.teresa-apps.io"})
+	setIngressTLS(i, "letsencrypt", "teresa-tls")
+	c := canaryIngressSpec(i, "teresa-canary", 10)
+
+	if c.Name != "teresa-canary" {
+		t.Errorf("expected teresa-canary, got %s", c.Name)
+	}
+	if got := c.Annotations[nginxAnnotationPrefix+"canary"]; got != "true" {
+		t.Errorf("expected true, got %s", got)
+	}
+	if got := c.Annotations[nginxAnnotationPrefix+"canary-weight"]; got != "10" {
+		t.Errorf("expected 10, got %s", got)
+	}
+	if _, found := c.Annotations[certManagerIssuerAnnotation]; found || len(c.Spec.TLS) != 0 {
+		t.Errorf("expected no tls, got %v and %v", c.Annotations, c.Spec.TLS)
+	}
+	if b := ingressBackend(c); b == nil || b.ServiceName != "teresa-canary" {
+		t.Errorf("expected teresa-canary backend, got %v", b)
+	}
+	if b := ingressBackend(i); b == nil || b.ServiceName != "teresa" {
+		t.Errorf("expected the app ingress untouched, got %v", b)
+	}
+}
+
+func TestCanaryServiceSpec(t *testing.T) {
+	svc := &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa", Namespace: "teresa"},
+		Spec: k8sv1.ServiceSpec{
+			Type:  k8sv1.ServiceTypeNodePort,
+			Ports: []k8sv1.ServicePort{{Name: "http", Port: 80, NodePort: 30080}},
+		},
+	}
+	c := canaryServiceSpec(svc, "teresa-canary")
+
+	if c.Spec.Type != k8sv1.ServiceTypeClusterIP {
+		t.Errorf("expected %s, got %s", k8sv1.ServiceTypeClusterIP, c.Spec.Type)
+	}
+	if c.Spec.Ports[0].NodePort != 0 || svc.Spec.Ports[0].NodePort != 30080 {
+		t.Errorf("expected only the canary node port unset, got %v and %v", c.Spec.Ports, svc.Spec.Ports)
+	}
+	if got := c.Spec.Selector["run"]; got != "teresa-canary" {
+		t.Errorf("expected teresa-canary, got %s", got)
+	}
+}
+
+func TestPromoteDeploy(t *testing.T) {
+	antiAffinity := &k8sv1.PodAntiAffinity{}
+	d := &v1beta2.Deployment{}
+	d.Spec.Template.Spec.Affinity = &k8sv1.Affinity{PodAntiAffinity: antiAffinity}
+	canary := &v1beta2.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{changeCauseAnnotation: "canary", spec.SlugAnnotation: "slug"},
+		},
+	}
+	canary.Spec.Template.Spec.Containers = []k8sv1.Container{{Name: "teresa", Image: "new"}}
+	canary.Spec.Template.Spec.Affinity = &k8sv1.Affinity{
+		NodeAffinity:    &k8sv1.NodeAffinity{},
+		PodAntiAffinity: &k8sv1.PodAntiAffinity{},
+	}
+
+	promoteDeploy(d, canary)
+
+	if got := d.Spec.Template.Spec.Containers[0].Image; got != "new" {
+		t.Errorf("expected new, got %s", got)
+	}
+	if a := d.Spec.Template.Spec.Affinity; a.NodeAffinity == nil || a.PodAntiAffinity != antiAffinity {
+		t.Errorf("expected the canary node affinity and the app anti-affinity, got %v", a)
+	}
+	if got := d.Annotations[spec.SlugAnnotation]; got != "slug" {
+		t.Errorf("expected slug, got %s", got)
+	}
+}
+
+func TestSetIngressOptions(t *testing.T) {
+	i := ingressSpec("teresa", "teresa", []string{"teresa.io"})
+	i.Annotations = map[string]string{
+		"nginx.ingress.kubernetes.io/proxy-read-timeout": "60",
+		certManagerIssuerAnnotation:                      "letsencrypt",
+	}
+	setIngressOptions(i, map[string]string{
+		app.IngressOptionClass:          "nginx-internal",
+		app.IngressOptionProxyBodySize:  "10m",
+		app.IngressOptionStickySessions: "true",
+	})
+
+	expected := map[string]string{
+		"kubernetes.io/ingress.class":                 "nginx-internal",
+		"nginx.ingress.kubernetes.io/proxy-body-size": "10m",
+		"nginx.ingress.kubernetes.io/affinity":        "cookie",
+		certManagerIssuerAnnotation:                   "letsencrypt",
+	}
+	if !reflect.DeepEqual(i.Annotations, expected) {
+		t.Errorf("expected %v, got %v", expected, i.Annotations)
+	}
+}
+
+func TestSetIngressSourceRanges(t *testing.T) {
+	i := ingressSpec("teresa", "teresa", []string{"teresa.io"})
+	setIngressSourceRanges(i, []string{"10.0.0.0/8", "200.1.2.3/32"})
+
+	key := "nginx.ingress.kubernetes.io/whitelist-source-range"
+	if got := i.Annotations[key]; got != "10.0.0.0/8,200.1.2.3/32" {
+		t.Errorf("expected 10.0.0.0/8,200.1.2.3/32, got %s", got)
+	}
+
+	setIngressSourceRanges(i, nil)
+	if _, found := i.Annotations[key]; found {
+		t.Errorf("expected no %s annotation, got %v", key, i.Annotations)
+	}
+}
+
+func TestPDBSpec(t *testing.T) {
+	var testCases = []struct {
+		minAvailable string
+		expected     intstr.IntOrString
+	}{
+		{"2", intstr.FromInt(2)},
+		{"50%", intstr.FromString("50%")},
+	}
+
+	for _, tc := range testCases {
+		pdb := pdbSpec("teresa", "teresa", tc.minAvailable)
+		if *pdb.Spec.MinAvailable != tc.expected {
+			t.Errorf("expected %v, got %v", tc.expected, *pdb.Spec.MinAvailable)
+		}
+		if got := pdb.Spec.Selector.MatchLabels["run"]; got != "teresa" {
+			t.Errorf("expected teresa, got %s", got)
+		}
+	}
+}
+
+func TestSetPodScheduling(t *testing.T) {
+	ty := &spec.TeresaYaml{
+		NodeSelector: map[string]string{"pool": "gpu"},
+		Tolerations:  []spec.Toleration{{Key: "spot", Operator: "Exists", Effect: "NoSchedule"}},
+		Affinity: &spec.Affinity{
+			Required: []spec.NodeRequirement{{Key: "zone", Operator: "In", Values: []string{"a", "b"}}},
+			Preferred: []spec.PreferredNodeRequirement{
+				{NodeRequirement: spec.NodeRequirement{Key: "pool", Operator: "Exists"}, Weight: 10},
+			},
+		},
+	}
+	ps := new(k8sv1.PodSpec)
+	setPodScheduling(ps, ty)
+
+	if got := ps.NodeSelector["pool"]; got != "gpu" {
+		t.Errorf("expected gpu, got %s", got)
+	}
+	expectedTol := []k8sv1.Toleration{{Key: "spot", Operator: k8sv1.TolerationOpExists, Effect: k8sv1.TaintEffectNoSchedule}}
+	if !reflect.DeepEqual(ps.Tolerations, expectedTol) {
+		t.Errorf("expected %v, got %v", expectedTol, ps.Tolerations)
+	}
+	na := ps.Affinity.NodeAffinity
+	terms := na.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || len(terms[0].MatchExpressions) != 1 {
+		t.Fatalf("expected one required term with one expression, got %v", terms)
+	}
+	if expr := terms[0].MatchExpressions[0]; expr.Key != "zone" || expr.Operator != k8sv1.NodeSelectorOpIn || len(expr.Values) != 2 {
+		t.Errorf("got unexpected required expression %v", expr)
+	}
+	pref := na.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(pref) != 1 || pref[0].Weight != 10 || pref[0].Preference.MatchExpressions[0].Key != "pool" {
+		t.Errorf("got unexpected preferred terms %v", pref)
+	}
+}
+
+func TestSetPodSchedulingEmpty(t *testing.T) {
+	ps := new(k8sv1.PodSpec)
+	setPodScheduling(ps, new(spec.TeresaYaml))
+
+	if ps.NodeSelector != nil || ps.Tolerations != nil || ps.Affinity != nil {
+		t.Errorf("expected no scheduling constraints, got %v", ps)
+	}
+}
+
+func TestSetPodSpread(t *testing.T) {
+	labels := spec.Labels{"run": "teresa"}
+	ps := &k8sv1.PodSpec{Affinity: &k8sv1.Affinity{NodeAffinity: new(k8sv1.NodeAffinity)}}
+	setPodSpread(ps, &spec.Spread{Zone: spec.SpreadRequired, Host: spec.SpreadPreferred}, labels)
+
+	if ps.Affinity.NodeAffinity == nil {
+		t.Error("expected node affinity to be kept")
+	}
+	paa := ps.Affinity.PodAntiAffinity
+	if req := paa.RequiredDuringSchedulingIgnoredDuringExecution; req != nil {
+		t.Errorf("expected no required terms, got %v", req)
+	}
+	pref := paa.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(pref) != 2 {
+		t.Fatalf("expected 2 preferred terms, got %v", pref)
+	}
+	var testCases = []struct {
+		topologyKey string
+		weight      int32
+	}{
+		{zoneTopologyKey, spreadRequiredWeight},
+		{hostTopologyKey, spreadPreferredWeight},
+	}
+	for i, tc := range testCases {
+		term := pref[i].PodAffinityTerm
+		if term.TopologyKey != tc.topologyKey || pref[i].Weight != tc.weight || term.LabelSelector.MatchLabels["run"] != "teresa" {
+			t.Errorf("got unexpected preferred term %v", pref[i])
+		}
+	}
+}
+
+func TestSetPodSpreadNone(t *testing.T) {
+	for _, s := range []*spec.Spread{nil, {Zone: spec.SpreadNone, Host: spec.SpreadNone}} {
+		ps := new(k8sv1.PodSpec)
+		setPodSpread(ps, s, spec.Labels{"run": "teresa"})
+		if ps.Affinity != nil {
+			t.Errorf("expected no affinity for %v, got %v", s, ps.Affinity)
+		}
+	}
+}
+
+func TestPVCSpec(t *testing.T) {
+	vc := &app.VolumeClaim{Name: "data", Size: "10Gi", StorageClass: "ssd", MountPath: "/data"}
+	pvc, err := pvcSpec("teresa", vc)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if pvc.Name != "data" || pvc.Namespace != "teresa" {
+		t.Errorf("expected teresa/data, got %s/%s", pvc.Namespace, pvc.Name)
+	}
+	if got := pvc.Spec.AccessModes; len(got) != 1 || got[0] != k8sv1.ReadWriteOnce {
+		t.Errorf("expected [ReadWriteOnce], got %v", got)
+	}
+	size := pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]
+	if size.String() != "10Gi" {
+		t.Errorf("expected 10Gi, got %s", size.String())
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "ssd" {
+		t.Errorf("expected storage class ssd, got %v", pvc.Spec.StorageClassName)
+	}
+
+	vc = &app.VolumeClaim{Name: "data", Size: "1Gi", AccessMode: app.AccessModeReadWriteMany}
+	if pvc, err = pvcSpec("teresa", vc); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got := pvc.Spec.AccessModes[0]; got != k8sv1.ReadWriteMany {
+		t.Errorf("expected ReadWriteMany, got %s", got)
+	}
+	if pvc.Spec.StorageClassName != nil {
+		t.Errorf("expected default storage class, got %s", *pvc.Spec.StorageClassName)
+	}
+}
+
+func TestPodSpecPVCVolumeToK8s(t *testing.T) {
+	vols := podSpecVolumesToK8sVolumes([]*spec.Volume{{Name: "data", ClaimName: "data"}})
+	if len(vols) != 1 || vols[0].PersistentVolumeClaim == nil {
+		t.Fatalf("expected one pvc volume, got %v", vols)
+	}
+	if got := vols[0].PersistentVolumeClaim.ClaimName; got != "data" {
+		t.Errorf("expected data, got %s", got)
+	}
+}
+
+func TestSetIngressBackendProtocol(t *testing.T) {
+	i := ingressSpec("teresa", "teresa", []string{"teresa.io"})
+	key := "nginx.ingress.kubernetes.io/backend-protocol"
+
+	setIngressBackendProtocol(i, "grpc")
+	if got := i.Annotations[key]; got != "GRPC" {
+		t.Errorf("expected GRPC, got %s", got)
+	}
+
+	setIngressBackendProtocol(i, "http")
+	if _, found := i.Annotations[key]; found {
+		t.Errorf("expected no %s annotation, got %v", key, i.Annotations)
+	}
+}
+
+func TestStreamPortsData(t *testing.T) {
+	data := map[string]string{
+		"1883": "teresa/teresa:1883",
+		"5432": "postgres/postgres:5432",
+	}
+	ports := []spec.ServicePort{{Name: "mqtts", Port: 8883, TargetPort: 8883}}
+
+	got, err := streamPortsData(data, "teresa", "teresa", ports)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := map[string]string{
+		"8883": "teresa/teresa:8883",
+		"5432": "postgres/postgres:5432",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	ports = []spec.ServicePort{{Name: "pg", Port: 5432, TargetPort: 5432}}
+	if _, err := streamPortsData(data, "teresa", "teresa", ports); err != ErrStreamPortInUse {
+		t.Errorf("expected ErrStreamPortInUse, got %v", err)
+	}
+}
+
+func TestRenameStreamPortsData(t *testing.T) {
+	data := map[string]string{
+		"1883": "teresa/teresa:1883",
+		"5432": "postgres/postgres:5432",
+	}
+	expected := map[string]string{
+		"1883": "gopher/gopher:1883",
+		"5432": "postgres/postgres:5432",
+	}
+	if got := renameStreamPortsData(data, "teresa", "gopher"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestServicePortsToK8sServicePortsProtocol(t *testing.T) {
+	ports := []spec.ServicePort{{Name: "dns", Port: 53, TargetPort: 53, Protocol: "UDP"}}
+
+	k8sPorts := servicePortsToK8sServicePorts(ports)
+	if k8sPorts[0].Protocol != k8sv1.ProtocolUDP {
+		t.Errorf("expected %s, got %s", k8sv1.ProtocolUDP, k8sPorts[0].Protocol)
+	}
+	if sp := k8sServicePortsToServicePorts(k8sPorts); !reflect.DeepEqual(sp, ports) {
+		t.Errorf("expected %v, got %v", ports, sp)
+	}
+}
+
 func TestPodSpecToK8sPodShouldAddAutomountSATokenField(t *testing.T) {
 	ps := &spec.Pod{
 		Containers: []*spec.Container{{
@@ -410,6 +844,129 @@ func TestDeploySpecToK8sDeployShouldAddAutomountSATokenField(t *testing.T) {
 	}
 }
 
+func TestDeploySpecToK8sDeployTerminationGracePeriod(t *testing.T) {
+	ds := &spec.Deploy{
+		Pod: spec.Pod{
+			Containers: []*spec.Container{{
+				Name:  "Teresa",
+				Image: "luizalabs/teresa:0.0.1",
+			}},
+		},
+	}
+	ds.Lifecycle = &spec.Lifecycle{
+		PreStop:                       &spec.PreStop{DrainTimeoutSeconds: 60},
+		TerminationGracePeriodSeconds: 90,
+	}
+
+	k8sDeploy, err := deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+
+	ps := k8sDeploy.Spec.Template.Spec
+	if ps.TerminationGracePeriodSeconds == nil || *ps.TerminationGracePeriodSeconds != 90 {
+		t.Errorf("expected 90, got %v", ps.TerminationGracePeriodSeconds)
+	}
+
+	ds.Lifecycle.TerminationGracePeriodSeconds = 0
+	k8sDeploy, err = deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+	if grace := k8sDeploy.Spec.Template.Spec.TerminationGracePeriodSeconds; grace != nil {
+		t.Errorf("expected the default grace period, got %d", *grace)
+	}
+}
+
+func TestDeploySpecToK8sDeployProgressDeadline(t *testing.T) {
+	ds := &spec.Deploy{
+		Pod: spec.Pod{
+			Containers: []*spec.Container{{
+				Name:  "Teresa",
+				Image: "luizalabs/teresa:0.0.1",
+			}},
+		},
+	}
+
+	k8sDeploy, err := deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+	if deadline := k8sDeploy.Spec.ProgressDeadlineSeconds; deadline != nil {
+		t.Errorf("expected the default progress deadline, got %d", *deadline)
+	}
+
+	ds.RolloutTimeoutSeconds = 900
+	k8sDeploy, err = deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+	if deadline := k8sDeploy.Spec.ProgressDeadlineSeconds; deadline == nil || *deadline != 900 {
+		t.Errorf("expected 900, got %v", deadline)
+	}
+}
+
+func TestDeploySpecToK8sDeploySecurityContext(t *testing.T) {
+	ds := &spec.Deploy{
+		Pod: spec.Pod{
+			Containers: []*spec.Container{
+				{Name: "Teresa", Image: "luizalabs/teresa:0.0.1"},
+				{Name: "nginx", Image: "nginx"},
+			},
+			InitContainers: []*spec.Container{{Name: "migrate", Image: "luizalabs/teresa:0.0.1"}},
+		},
+	}
+	nonRoot, user := true, int64(1000)
+	ds.SecurityContext = &spec.SecurityContext{
+		RunAsNonRoot:     &nonRoot,
+		RunAsUser:        &user,
+		DropCapabilities: []string{"NET_RAW", "SYS_ADMIN"},
+	}
+
+	k8sDeploy, err := deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+
+	podSpec := k8sDeploy.Spec.Template.Spec
+	expected := &k8sv1.SecurityContext{
+		RunAsNonRoot: &nonRoot,
+		RunAsUser:    &user,
+		Capabilities: &k8sv1.Capabilities{Drop: []k8sv1.Capability{"NET_RAW", "SYS_ADMIN"}},
+	}
+	for _, c := range append(podSpec.Containers, podSpec.InitContainers...) {
+		if !reflect.DeepEqual(c.SecurityContext, expected) {
+			t.Errorf("expected %v for %s, got %v", expected, c.Name, c.SecurityContext)
+		}
+	}
+}
+
+func TestDeploySpecToK8sDeployHostAliases(t *testing.T) {
+	ds := &spec.Deploy{
+		Pod: spec.Pod{
+			Containers: []*spec.Container{{
+				Name:  "Teresa",
+				Image: "luizalabs/teresa:0.0.1",
+			}},
+		},
+	}
+	ds.HostAliases = []spec.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"legacy-db", "db.legacy.internal"}},
+	}
+
+	k8sDeploy, err := deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("error converting spec:", err)
+	}
+
+	expected := []k8sv1.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"legacy-db", "db.legacy.internal"}},
+	}
+	if got := k8sDeploy.Spec.Template.Spec.HostAliases; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
 func TestAppPodListOptsToK8s(t *testing.T) {
 	opts := &app.PodListOptions{PodName: "test-1234"}
 	expectedFs := "metadata.name=test-1234"
@@ -421,6 +978,44 @@ func TestAppPodListOptsToK8s(t *testing.T) {
 	}
 }
 
+func TestAppLogOptsToK8s(t *testing.T) {
+	opts := &app.LogOptions{Lines: 20, Container: "teresa", SinceSeconds: 3600, Timestamps: true}
+	k8sOpts := appLogOptsToK8s(opts)
+	if k8sOpts.TailLines == nil || *k8sOpts.TailLines != 20 {
+		t.Errorf("got tail lines %v, want 20", k8sOpts.TailLines)
+	}
+	if k8sOpts.SinceSeconds == nil || *k8sOpts.SinceSeconds != 3600 {
+		t.Errorf("got since seconds %v, want 3600", k8sOpts.SinceSeconds)
+	}
+	if !k8sOpts.Timestamps || k8sOpts.Container != "teresa" {
+		t.Errorf("got %+v, want timestamps of the container teresa", k8sOpts)
+	}
+
+	k8sOpts = appLogOptsToK8s(&app.LogOptions{Lines: -1})
+	if k8sOpts.TailLines != nil || k8sOpts.SinceSeconds != nil {
+		t.Errorf("got %+v, want all the lines", k8sOpts)
+	}
+}
+
+func TestAppPodListOptsToK8sLabels(t *testing.T) {
+	var testCases = []struct {
+		opts     *app.PodListOptions
+		expected string
+	}{
+		{&app.PodListOptions{}, ""},
+		{&app.PodListOptions{JobName: "teresa-1500000000"}, "job-name=teresa-1500000000"},
+		{&app.PodListOptions{AnyJob: true}, "job-name"},
+		{&app.PodListOptions{DeployName: "teresa-worker"}, "run=teresa-worker"},
+		{&app.PodListOptions{AnyJob: true, DeployName: "teresa"}, "job-name,run=teresa"},
+	}
+
+	for _, tc := range testCases {
+		if got := appPodListOptsToK8s(tc.opts).LabelSelector; got != tc.expected {
+			t.Errorf("got %q, want %q [case: %+v]", got, tc.expected, tc.opts)
+		}
+	}
+}
+
 func TestPodSpecToK8sInitContainers(t *testing.T) {
 	ps := &spec.Pod{
 		InitContainers: []*spec.Container{
@@ -708,6 +1303,168 @@ func TestK8sPodToAppPodTerminating(t *testing.T) {
 	}
 }
 
+func TestK8sPodToAppPodDetail(t *testing.T) {
+	k8sPod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod",
+		},
+		Spec: k8sv1.PodSpec{NodeName: "node"},
+		Status: k8sv1.PodStatus{
+			Phase: k8sv1.PodRunning,
+			ContainerStatuses: []k8sv1.ContainerStatus{
+				{
+					Ready:        true,
+					RestartCount: 3,
+					State: k8sv1.ContainerState{
+						Running: &k8sv1.ContainerStateRunning{},
+					},
+					LastTerminationState: k8sv1.ContainerState{
+						Terminated: &k8sv1.ContainerStateTerminated{Reason: "OOMKilled"},
+					},
+				},
+			},
+		},
+	}
+	want := &app.PodDetail{
+		Pod: app.Pod{
+			Name:     "pod",
+			Restarts: 3,
+			Ready:    true,
+			State:    "Running",
+		},
+		Node:                  "node",
+		LastTerminationReason: "OOMKilled",
+	}
+	pod := k8sPodToAppPodDetail(k8sPod)
+
+	if !reflect.DeepEqual(pod, want) {
+		t.Errorf("want %v; got %v", want, pod)
+	}
+}
+
+func TestK8sEventToAppEvent(t *testing.T) {
+	ts := time.Now()
+	k8sEv := &k8sv1.Event{
+		InvolvedObject: k8sv1.ObjectReference{Kind: "Pod", Name: "pod"},
+		Type:           "Warning",
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Count:          3,
+		LastTimestamp:  metav1.NewTime(ts),
+	}
+	want := &app.Event{
+		Type:     "Warning",
+		Reason:   "BackOff",
+		Object:   "pod/pod",
+		Message:  "Back-off restarting failed container",
+		Count:    3,
+		LastSeen: ts.Unix(),
+	}
+	ev := k8sEventToAppEvent(k8sEv)
+
+	if !reflect.DeepEqual(ev, want) {
+		t.Errorf("want %v; got %v", want, ev)
+	}
+}
+
+func TestK8sCronJobToAppCronJob(t *testing.T) {
+	ts := time.Now()
+	suspend := true
+	k8sCj := &k8sv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa"},
+		Spec: k8sv1beta1.CronJobSpec{
+			Schedule: "*/5 * * * *",
+			Suspend:  &suspend,
+		},
+		Status: k8sv1beta1.CronJobStatus{
+			Active:           []k8sv1.ObjectReference{{Name: "teresa-1"}},
+			LastScheduleTime: &metav1.Time{Time: ts},
+		},
+	}
+	want := &app.CronJob{
+		Name:         "teresa",
+		Schedule:     "*/5 * * * *",
+		Suspended:    true,
+		LastSchedule: ts.Unix(),
+		Active:       1,
+	}
+	cj := k8sCronJobToAppCronJob(k8sCj)
+
+	if !reflect.DeepEqual(cj, want) {
+		t.Errorf("want %v; got %v", want, cj)
+	}
+}
+
+func TestManualJobName(t *testing.T) {
+	ts := time.Unix(1500000000, 0)
+	if got := manualJobName("teresa", ts); got != "teresa-manual-1500000000" {
+		t.Errorf("got %s; want teresa-manual-1500000000", got)
+	}
+	long := manualJobName(strings.Repeat("a", 63), ts)
+	if len(long) != 63 || !strings.HasSuffix(long, "-manual-1500000000") {
+		t.Errorf("got %s; want it truncated to 63 chars", long)
+	}
+}
+
+func TestK8sCronJobToManualJob(t *testing.T) {
+	k8sCj := &k8sv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa", Namespace: "teresa"},
+	}
+	k8sCj.Spec.JobTemplate.Labels = map[string]string{"run": "teresa"}
+	job := k8sCronJobToManualJob(k8sCj, "teresa-manual-1")
+
+	if job.Name != "teresa-manual-1" || job.Namespace != "teresa" {
+		t.Errorf("got job %s/%s; want teresa/teresa-manual-1", job.Namespace, job.Name)
+	}
+	if job.Labels["run"] != "teresa" {
+		t.Errorf("got labels %v; want the ones of the job template", job.Labels)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != "teresa" {
+		t.Errorf("got owners %v; want the cronjob", job.OwnerReferences)
+	}
+}
+
+func TestK8sJobToAppCronRun(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	job := &k8sbatch.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "teresa-1",
+			Namespace:       "teresa",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "teresa"}},
+		},
+		Status: k8sbatch.JobStatus{
+			StartTime: &metav1.Time{Time: start},
+			Conditions: []k8sbatch.JobCondition{{
+				Type:               k8sbatch.JobFailed,
+				Status:             k8sv1.ConditionTrue,
+				LastTransitionTime: metav1.Time{Time: start.Add(30 * time.Second)},
+			}},
+		},
+	}
+	pod := k8sv1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": "teresa-1"}}}
+	pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+		Name:  "teresa",
+		State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{ExitCode: 2}},
+	}}
+	other := k8sv1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"job-name": "teresa-2"}}}
+
+	if !isCronJobRun(job, "teresa") || isCronJobRun(job, "other") {
+		t.Errorf("expected job owned only by the cronjob teresa, got %v", job.OwnerReferences)
+	}
+
+	run := k8sJobToAppCronRun(job, []k8sv1.Pod{other, pod}, "teresa", start.Add(time.Hour))
+	want := &app.CronRun{
+		Name:      "teresa-1",
+		Status:    app.CronRunStatusFailed,
+		StartTime: 1500000000,
+		Duration:  30,
+		ExitCode:  2,
+	}
+	if !reflect.DeepEqual(run, want) {
+		t.Errorf("got %+v; want %+v", run, want)
+	}
+}
+
 func TestDeploySpecPodTemplateAnnotations(t *testing.T) {
 	ds := &spec.Deploy{}
 	want := map[string]string{
@@ -724,3 +1481,224 @@ func TestDeploySpecPodTemplateAnnotations(t *testing.T) {
 		t.Errorf("got %v; want %v", got, want)
 	}
 }
+
+func TestDeploySpecAnnotations(t *testing.T) {
+	ds := &spec.Deploy{
+		Description: "release",
+		SlugURL:     "slug",
+		Author:      "gopher@luizalabs.com",
+		Commit:      "8a3f2c1",
+		Branch:      "master",
+		Message:     "Fix checkout",
+	}
+	want := map[string]string{
+		changeCauseAnnotation:  "release",
+		spec.SlugAnnotation:    "slug",
+		spec.AuthorAnnotation:  "gopher@luizalabs.com",
+		spec.CommitAnnotation:  "8a3f2c1",
+		spec.BranchAnnotation:  "master",
+		spec.MessageAnnotation: "Fix checkout",
+	}
+
+	k8sDeploy, err := deploySpecToK8sDeploy(ds, 1)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	if got := k8sDeploy.Annotations; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestRenameK8sDeploy(t *testing.T) {
+	d := &v1beta2.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "teresa",
+			Namespace: "teresa",
+			Labels:    map[string]string{"run": "teresa"},
+		},
+		Spec: v1beta2.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"run": "teresa"},
+			},
+			Template: k8sv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"run": "teresa"},
+				},
+				Spec: k8sv1.PodSpec{
+					Containers: []k8sv1.Container{{
+						Name: "teresa",
+						Env:  []k8sv1.EnvVar{{Name: "APP", Value: "teresa"}},
+					}},
+					Volumes: []k8sv1.Volume{{
+						Name: "nginx-conf",
+						VolumeSource: k8sv1.VolumeSource{
+							ConfigMap: &k8sv1.ConfigMapVolumeSource{
+								LocalObjectReference: k8sv1.LocalObjectReference{Name: "teresa"},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	nd := renameK8sDeploy(d, "teresa", "gopher")
+
+	if nd.Name != "gopher" || nd.Namespace != "gopher" {
+		t.Errorf("got %s/%s; want gopher/gopher", nd.Namespace, nd.Name)
+	}
+	if v := nd.Spec.Selector.MatchLabels["run"]; v != "gopher" {
+		t.Errorf("got selector %s; want gopher", v)
+	}
+	if v := nd.Spec.Template.Labels["run"]; v != "gopher" {
+		t.Errorf("got template label %s; want gopher", v)
+	}
+	c := nd.Spec.Template.Spec.Containers[0]
+	if c.Name != "gopher" || c.Env[0].Value != "gopher" {
+		t.Errorf("got container %s with APP=%s; want gopher", c.Name, c.Env[0].Value)
+	}
+	if v := nd.Spec.Template.Spec.Volumes[0].ConfigMap.Name; v != "gopher" {
+		t.Errorf("got configMap %s; want gopher", v)
+	}
+	if v := d.Spec.Template.Spec.Containers[0].Name; v != "teresa" {
+		t.Errorf("expected source deploy untouched, got container %s", v)
+	}
+}
+
+func TestRenameValue(t *testing.T) {
+	var testCases = []struct {
+		value    string
+		expected string
+	}{
+		{"teresa", "gopher"},
+		{"teresa-worker", "gopher-worker"},
+		{"teresaworker", "teresaworker"},
+		{"slugstore", "slugstore"},
+	}
+
+	for _, tc := range testCases {
+		if actual := renameValue(tc.value, "teresa", "gopher"); actual != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, actual)
+		}
+	}
+}
+
+func TestRenameK8sService(t *testing.T) {
+	svc := &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa", Namespace: "teresa"},
+		Spec: k8sv1.ServiceSpec{
+			ClusterIP: "10.0.0.1",
+			Selector:  map[string]string{"run": "teresa"},
+			Ports:     []k8sv1.ServicePort{{Port: 80, NodePort: 30000}},
+		},
+	}
+
+	nsvc := renameK8sService(svc, "teresa", "gopher")
+
+	if nsvc.Name != "gopher" || nsvc.Namespace != "gopher" {
+		t.Errorf("got %s/%s; want gopher/gopher", nsvc.Namespace, nsvc.Name)
+	}
+	if nsvc.Spec.ClusterIP != "" {
+		t.Errorf("expected empty cluster ip, got %s", nsvc.Spec.ClusterIP)
+	}
+	if np := nsvc.Spec.Ports[0].NodePort; np != 0 {
+		t.Errorf("expected no node port, got %d", np)
+	}
+	if v := nsvc.Spec.Selector["run"]; v != "gopher" {
+		t.Errorf("got selector %s; want gopher", v)
+	}
+}
+
+func TestRenameK8sIngress(t *testing.T) {
+	igs := ingressSpec("teresa", "teresa", []string{"teresa.io"})
+
+	nigs := renameK8sIngress(igs, "teresa", "gopher")
+
+	if nigs.Name != "gopher" || nigs.Namespace != "gopher" {
+		t.Errorf("got %s/%s; want gopher/gopher", nigs.Namespace, nigs.Name)
+	}
+	backend := nigs.Spec.Rules[0].HTTP.Paths[0].Backend
+	if backend.ServiceName != "gopher" {
+		t.Errorf("got backend %s; want gopher", backend.ServiceName)
+	}
+	if host := nigs.Spec.Rules[0].Host; host != "teresa.io" {
+		t.Errorf("got host %s; want teresa.io", host)
+	}
+}
+
+func TestNewResourceRequirements(t *testing.T) {
+	res, err := newResourceRequirements("500m", "", "100m", "128Mi")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	if _, found := res.Limits[k8sv1.ResourceMemory]; found {
+		t.Error("expected no memory limit")
+	}
+	if q := res.Limits[k8sv1.ResourceCPU]; q.String() != "500m" {
+		t.Errorf("got cpu limit %s; want 500m", q.String())
+	}
+	if q := res.Requests[k8sv1.ResourceCPU]; q.String() != "100m" {
+		t.Errorf("got cpu request %s; want 100m", q.String())
+	}
+	if q := res.Requests[k8sv1.ResourceMemory]; q.String() != "128Mi" {
+		t.Errorf("got memory request %s; want 128Mi", q.String())
+	}
+}
+
+func TestNewResourceRequirementsInvalidQuantity(t *testing.T) {
+	if _, err := newResourceRequirements("gopher", "", "", ""); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestRenameK8sIngressTLSSecret(t *testing.T) {
+	igs := ingressSpec("teresa", "teresa", []string{"teresa.io"})
+	setIngressTLS(igs, "letsencrypt", "teresa-tls")
+
+	nigs := renameK8sIngress(igs, "teresa", "gopher")
+
+	if name := nigs.Spec.TLS[0].SecretName; name != "gopher-tls" {
+		t.Errorf("got secret %s; want gopher-tls", name)
+	}
+	if name := igs.Spec.TLS[0].SecretName; name != "teresa-tls" {
+		t.Errorf("original ingress changed, got secret %s; want teresa-tls", name)
+	}
+}
+
+func TestCertificateStatus(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "teresa.io"},
+		NotBefore:    notAfter.AddDate(0, -3, 0),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	var testCases = []struct {
+		crt      []byte
+		now      time.Time
+		expected string
+	}{
+		{nil, notAfter, app.TLSStatusPending},
+		{[]byte("invalid"), notAfter, app.TLSStatusPending},
+		{crt, notAfter.AddDate(0, 0, -1), app.TLSStatusIssued + " (expires 2030-01-02)"},
+		{crt, notAfter.AddDate(0, 0, 1), app.TLSStatusExpired},
+	}
+
+	for _, tc := range testCases {
+		if got := certificateStatus(tc.crt, tc.now); got != tc.expected {
+			t.Errorf("got %s; want %s", got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const diffContextLines = 3
+
+// DeployDiff returns a diff of the Deployment of deploySpec and, if svcSpec
+// is given, of its Service and Ingress against the live ones. Deploys only
+// create a missing Ingress, an existing one is never diffed. The fields set
+// by the cluster (status, defaults, etc.) are left out of the live objects
+func (k *Client) DeployDiff(deploySpec *spec.Deploy, svcSpec *spec.Service, vHosts []string) (string, error) {
+	kc, err := k.buildClient(deploySpec.Namespace)
+	if err != nil {
+		return "", err
+	}
+	ns, name := deploySpec.Namespace, deploySpec.Name
+
+	replicas := k.currentPodReplicasFromDeploy(ns, name)
+	if deploySpec.Replicas != nil {
+		replicas = *deploySpec.Replicas
+	}
+	deploy, err := deploySpecToK8sDeploy(deploySpec, replicas)
+	if err != nil {
+		return "", err
+	}
+	var live interface{}
+	d, err := kc.AppsV1beta2().Deployments(ns).Get(name, metav1.GetOptions{})
+	if err == nil {
+		live = d
+	} else if !k.IsNotFound(err) {
+		return "", errors.Wrap(err, "get deploy failed")
+	}
+	diff, err := objectDiff("deployment/"+name, deploy, live)
+	if err != nil {
+		return "", err
+	}
+	if svcSpec == nil {
+		return diff, nil
+	}
+
+	live = nil
+	svc, err := kc.CoreV1().Services(svcSpec.Namespace).Get(svcSpec.Name, metav1.GetOptions{})
+	if err == nil {
+		live = svc
+	} else if !k.IsNotFound(err) {
+		return "", errors.Wrap(err, "get service failed")
+	}
+	svcDiff, err := objectDiff("service/"+svcSpec.Name, serviceSpecToK8s(svcSpec), live)
+	if err != nil {
+		return "", err
+	}
+	diff += svcDiff
+
+	if !k.ingress {
+		return diff, nil
+	}
+	hasIgs, err := k.HasIngress(ns, name)
+	if err != nil || hasIgs {
+		return diff, err
+	}
+	igs := ingressSpec(ns, name, vHosts)
+	setIngressBackendProtocol(igs, deploySpec.Protocol)
+	igsDiff, err := objectDiff("ingress/"+name, igs, nil)
+	if err != nil {
+		return "", err
+	}
+	return diff + igsDiff, nil
+}
+
+// objectDiff returns a unified diff of the YAML of the live object to the
+// desired one, blank if they're the same. A nil live object is a new one
+func objectDiff(name string, desired, live interface{}) (string, error) {
+	d, err := normalizeObject(desired)
+	if err != nil {
+		return "", err
+	}
+	var l interface{}
+	if live != nil {
+		if l, err = normalizeObject(live); err != nil {
+			return "", err
+		}
+		l = pruneObject(l, d)
+	}
+
+	dy, err := yaml.Marshal(d)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to yaml encode")
+	}
+	var ly []byte
+	if l != nil {
+		if ly, err = yaml.Marshal(l); err != nil {
+			return "", errors.Wrap(err, "failed to yaml encode")
+		}
+	}
+	hunks := lineDiff(splitLines(string(ly)), splitLines(string(dy)))
+	if hunks == "" {
+		return "", nil
+	}
+	return fmt.Sprintf("--- live/%s\n+++ dry-run/%s\n%s", name, name, hunks), nil
+}
+
+// normalizeObject returns the object as decoded from JSON, without its
+// status and with only the name, namespace, labels and annotations of its
+// metadata
+func normalizeObject(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to json encode")
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errors.Wrap(err, "failed to json decode")
+	}
+	delete(m, "status")
+	if md, ok := m["metadata"].(map[string]interface{}); ok {
+		for k := range md {
+			switch k {
+			case "name", "namespace", "labels", "annotations":
+			default:
+				delete(md, k)
+			}
+		}
+	}
+	return m, nil
+}
+
+// pruneObject removes from the live object the fields missing in the
+// desired one, they're set by the cluster
+func pruneObject(live, desired interface{}) interface{} {
+	switch l := live.(type) {
+	case map[string]interface{}:
+		d, ok := desired.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		for k, v := range l {
+			dv, found := d[k]
+			if !found {
+				delete(l, k)
+				continue
+			}
+			l[k] = pruneObject(v, dv)
+		}
+	case []interface{}:
+		d, ok := desired.([]interface{})
+		if !ok {
+			return live
+		}
+		for i := range l {
+			if i < len(d) {
+				l[i] = pruneObject(l[i], d[i])
+			}
+		}
+	}
+	return live
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// lineDiff returns the changed lines from a to b, prefixed by - and +, with
+// a few lines of context around them
+func lineDiff(a, b []string) string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []string
+	changed := false
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "-"+a[i])
+			i++
+			changed = true
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+			changed = true
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	// keep only the changes and their context
+	keep := make([]bool, len(lines))
+	for n, line := range lines {
+		if line[0] == ' ' {
+			continue
+		}
+		for c := n - diffContextLines; c <= n+diffContextLines; c++ {
+			if c >= 0 && c < len(lines) {
+				keep[c] = true
+			}
+		}
+	}
+	var out strings.Builder
+	for n, line := range lines {
+		if !keep[n] {
+			continue
+		}
+		if n == 0 || !keep[n-1] {
+			out.WriteString("@@\n")
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLineDiff(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"}
+	b := []string{"a", "b", "c", "d", "x", "f", "g", "h", "i", "j"}
+
+	expected := "@@\n b\n c\n d\n-e\n+x\n f\n g\n h\n i\n+j\n"
+	if got := lineDiff(a, b); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if got := lineDiff(a, a); got != "" {
+		t.Errorf("expected no diff, got %q", got)
+	}
+}
+
+func TestObjectDiffPrunesLiveFields(t *testing.T) {
+	desired := &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa", Namespace: "teresa"},
+		Spec: k8sv1.ServiceSpec{
+			Type:  k8sv1.ServiceTypeClusterIP,
+			Ports: []k8sv1.ServicePort{{Name: "http", Port: 80}},
+		},
+	}
+	live := &k8sv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "teresa", Namespace: "teresa", ResourceVersion: "42"},
+		Spec: k8sv1.ServiceSpec{
+			Type:      k8sv1.ServiceTypeClusterIP,
+			ClusterIP: "10.0.0.1",
+			Ports:     []k8sv1.ServicePort{{Name: "http", Port: 80, NodePort: 30000}},
+		},
+		Status: k8sv1.ServiceStatus{LoadBalancer: k8sv1.LoadBalancerStatus{}},
+	}
+
+	diff, err := objectDiff("service/teresa", desired, live)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if diff != "" {
+		t.Errorf("expected no diff, got %q", diff)
+	}
+
+	desired.Spec.Ports[0].Port = 8080
+	diff, err = objectDiff("service/teresa", desired, live)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	for _, line := range []string{"--- live/service/teresa", "-    port: 80\n", "+    port: 8080"} {
+		if !strings.Contains(diff, line) {
+			t.Errorf("expected %q in the diff, got %q", line, diff)
+		}
+	}
+}
+
+func TestObjectDiffNewObject(t *testing.T) {
+	desired := &k8sv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "teresa"}}
+
+	diff, err := objectDiff("service/teresa", desired, nil)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !strings.Contains(diff, "+  name: teresa") {
+		t.Errorf("expected the new object added, got %q", diff)
+	}
+}
@@ -11,9 +11,11 @@ import (
 
 var (
 	ErrInvalidServiceType = errors.New("Invalid service type")
+	ErrRolloutStalled     = errors.New("rolling update stalled, still running the old deploy")
 	ErrNotFound           = status.Errorf(codes.NotFound, "Resource not found")
 	ErrPodRunFailed       = status.Errorf(codes.Aborted, "Pod went into failed status")
 	ErrPodStillRunning    = status.Errorf(codes.Unknown, "Pod still running")
+	ErrStreamPortInUse    = status.Errorf(codes.AlreadyExists, "Port already exposed by another app")
 )
 
 func (k *Client) IsNotFound(err error) bool {
@@ -28,6 +30,12 @@ func (k *Client) IsInvalid(err error) bool {
 	return k8serrors.IsInvalid(errors.Cause(err))
 }
 
+// IsRolloutStalled tells if the error is the rollout of a deploy failing,
+// not the watch of the deploy
+func (k *Client) IsRolloutStalled(err error) bool {
+	return errors.Cause(err) == ErrRolloutStalled
+}
+
 func (k *Client) IsUnknown(err error) bool {
 	_, ok := errors.Cause(err).(k8serrors.APIStatus)
 	return !ok
@@ -0,0 +1,437 @@
+package k8s
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+
+	"github.com/luizalabs/teresa/pkg/server/exec"
+)
+
+// The exec API multiplexes the streams of the command in the messages of a
+// websocket, the first byte of each message is the stream
+const (
+	execProtocol     = "v4.channel.k8s.io"
+	execStreamStdin  = 0
+	execStreamStdout = 1
+	execStreamStderr = 2
+	execStreamError  = 3
+	execStreamResize = 4
+)
+
+const (
+	wsOpBinary       = 0x2
+	wsOpClose        = 0x8
+	wsOpPing         = 0x9
+	wsOpPong         = 0xA
+	wsMaxMessageSize = 1 << 20
+	wsAcceptGUID     = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+)
+
+// wsConn is the client side of a websocket, just enough of it to talk to
+// the exec API
+type wsConn struct {
+	conn io.ReadWriteCloser
+	r    *bufio.Reader
+	mu   sync.Mutex
+}
+
+func newWSConn(conn io.ReadWriteCloser, r *bufio.Reader) *wsConn {
+	if r == nil {
+		r = bufio.NewReader(conn)
+	}
+	return &wsConn{conn: conn, r: r}
+}
+
+// WriteMessage writes the payload in a single masked frame, it's safe to
+// call it from many goroutines
+func (c *wsConn) WriteMessage(opcode byte, payload []byte) error {
+	n := len(payload)
+	header := []byte{0x80 | opcode}
+	switch {
+	case n < 126:
+		header = append(header, 0x80|byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 0x80|126, byte(n>>8), byte(n))
+	default:
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], uint64(n))
+		header = append(append(header, 0x80|127), b[:]...)
+	}
+
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame := append(header, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// ReadMessage returns the payload of the next data message, the pings are
+// answered on the way and a close one ends the connection with io.EOF
+func (c *wsConn) ReadMessage() ([]byte, error) {
+	var msg []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case wsOpPing:
+			if err := c.WriteMessage(wsOpPong, payload); err != nil {
+				return nil, err
+			}
+			continue
+		case wsOpPong:
+			continue
+		case wsOpClose:
+			c.WriteMessage(wsOpClose, nil)
+			return nil, io.EOF
+		}
+		if len(msg)+len(payload) > wsMaxMessageSize {
+			return nil, errors.New("websocket message too big")
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msg, nil
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (bool, byte, []byte, error) {
+	var h [2]byte
+	if _, err := io.ReadFull(c.r, h[:]); err != nil {
+		return false, 0, nil, err
+	}
+	fin, opcode, masked := h[0]&0x80 != 0, h[0]&0x0F, h[1]&0x80 != 0
+
+	n := uint64(h[1] & 0x7F)
+	switch n {
+	case 126:
+		var b [2]byte
+		if _, err := io.ReadFull(c.r, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(b[:]))
+	case 127:
+		var b [8]byte
+		if _, err := io.ReadFull(c.r, b[:]); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(b[:])
+	}
+	if n > wsMaxMessageSize {
+		return false, 0, nil, errors.New("websocket frame too big")
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.r, mask[:]); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
+
+func wsAccept(key string) string {
+	h := sha1.Sum([]byte(key + wsAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// dialWebsocket opens a websocket to the API server with the credentials of
+// the config
+func dialWebsocket(conf *restclient.Config, u *url.URL, protocol string) (*wsConn, error) {
+	var (
+		conn net.Conn
+		err  error
+	)
+	if u.Scheme == "https" {
+		var tlsConf *tls.Config
+		tlsConf, err = restclient.TLSConfigFor(conf)
+		if err != nil {
+			return nil, err
+		}
+		if tlsConf == nil {
+			tlsConf = &tls.Config{}
+		}
+		if tlsConf.ServerName == "" {
+			tlsConf.ServerName = u.Hostname()
+		}
+		conn, err = tls.Dial("tcp", hostPort(u, "443"), tlsConf)
+	} else {
+		conn, err = net.Dial("tcp", hostPort(u, "80"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(b[:])
+
+	req := &http.Request{
+		Method:     http.MethodGet,
+		URL:        u,
+		Host:       u.Host,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", key)
+	req.Header.Set("Sec-WebSocket-Protocol", protocol)
+	if conf.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+conf.BearerToken)
+	} else if conf.Username != "" {
+		req.SetBasicAuth(conf.Username, conf.Password)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		conn.Close()
+		return nil, fmt.Errorf("websocket handshake failed with %s: %s", resp.Status, body)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != wsAccept(key) {
+		conn.Close()
+		return nil, errors.New("websocket handshake failed: invalid accept key")
+	}
+	return newWSConn(conn, r), nil
+}
+
+func hostPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
+
+// execURL is the exec endpoint of the container of the pod, the API server
+// doesn't split stderr from stdout with a TTY
+func execURL(host, namespace, podName, container string, command []string, tty bool) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/exec", strings.TrimSuffix(u.Path, "/"), namespace, podName)
+
+	q := url.Values{}
+	q.Set("container", container)
+	for _, c := range command {
+		q.Add("command", c)
+	}
+	q.Set("stdin", "true")
+	q.Set("stdout", "true")
+	q.Set("stderr", strconv.FormatBool(!tty))
+	q.Set("tty", strconv.FormatBool(tty))
+	u.RawQuery = q.Encode()
+	return u, nil
+}
+
+// execStatusExitCode returns the exit code of the command from the status
+// sent by the API server once it ends
+func execStatusExitCode(b []byte) (int, error) {
+	var st metav1.Status
+	if err := json.Unmarshal(b, &st); err != nil {
+		return exec.ExitCodeError, errors.Wrap(err, "invalid exec status")
+	}
+	if st.Status == metav1.StatusSuccess {
+		return 0, nil
+	}
+	if st.Reason == "NonZeroExitCode" && st.Details != nil {
+		for _, c := range st.Details.Causes {
+			if c.Type != "ExitCode" {
+				continue
+			}
+			if ec, err := strconv.Atoi(c.Message); err == nil {
+				return ec, nil
+			}
+		}
+	}
+	return exec.ExitCodeError, errors.New(st.Message)
+}
+
+func execCopyStdin(ws *wsConn, stdin io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			msg := append([]byte{execStreamStdin}, buf[:n]...)
+			if err := ws.WriteMessage(wsOpBinary, msg); err != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func execSendResizes(ws *wsConn, resize <-chan exec.TerminalSize, stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case size, ok := <-resize:
+			if !ok {
+				return
+			}
+			b, err := json.Marshal(size)
+			if err != nil {
+				continue
+			}
+			if err := ws.WriteMessage(wsOpBinary, append([]byte{execStreamResize}, b...)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// execStreams copies the output of the command to stdout until the API
+// server sends its status
+func execStreams(ws *wsConn, stdout, stderr io.Writer) (int, error) {
+	if stderr == nil {
+		stderr = stdout
+	}
+	for {
+		msg, err := ws.ReadMessage()
+		if err != nil {
+			if err == io.EOF {
+				err = errors.New("exec connection closed by the server")
+			}
+			return exec.ExitCodeError, err
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		switch msg[0] {
+		case execStreamStdout:
+			if _, err := stdout.Write(msg[1:]); err != nil {
+				return exec.ExitCodeError, err
+			}
+		case execStreamStderr:
+			if _, err := stderr.Write(msg[1:]); err != nil {
+				return exec.ExitCodeError, err
+			}
+		case execStreamError:
+			return execStatusExitCode(msg[1:])
+		}
+	}
+}
+
+// runningPod returns the name of a running pod of the deploy
+func (k *Client) runningPod(namespace, deployName string) (string, error) {
+	kc, err := k.buildClient(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	opts := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("run=%s", deployName),
+		FieldSelector: "status.phase=Running",
+	}
+	pods, err := kc.CoreV1().Pods(namespace).List(opts)
+	if err != nil {
+		return "", errors.Wrap(err, "list pods failed")
+	}
+	if len(pods.Items) == 0 {
+		return "", exec.ErrPodNotFound
+	}
+	return pods.Items[0].Name, nil
+}
+
+// PodExec runs the command interactively in the app container of a running
+// pod of the deploy, returning its exit code
+func (k *Client) PodExec(namespace, deployName string, command []string, sess *exec.Session) (int, error) {
+	podName, err := k.runningPod(namespace, deployName)
+	if err != nil {
+		return exec.ExitCodeError, err
+	}
+
+	conf, err := k.restConfig(namespace)
+	if err != nil {
+		return exec.ExitCodeError, err
+	}
+	u, err := execURL(conf.Host, namespace, podName, deployName, command, sess.TTY)
+	if err != nil {
+		return exec.ExitCodeError, errors.Wrap(err, "invalid API server host")
+	}
+	ws, err := dialWebsocket(conf, u, execProtocol)
+	if err != nil {
+		return exec.ExitCodeError, errors.Wrap(err, "exec failed")
+	}
+	defer ws.Close()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-sess.Done:
+			ws.Close()
+		case <-stop:
+		}
+	}()
+
+	if sess.Stdin != nil {
+		go execCopyStdin(ws, sess.Stdin)
+	}
+	if sess.TTY && sess.Resize != nil {
+		go execSendResizes(ws, sess.Resize, stop)
+	}
+	return execStreams(ws, sess.Stdout, sess.Stderr)
+}
@@ -0,0 +1,148 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/exec"
+)
+
+// serverFrame is an unmasked frame, like the ones sent by the API server
+func serverFrame(fin bool, opcode byte, payload []byte) []byte {
+	b := opcode
+	if fin {
+		b |= 0x80
+	}
+	return append([]byte{b, byte(len(payload))}, payload...)
+}
+
+func TestWSConnWriteMessage(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	ws := newWSConn(client, nil)
+
+	payload := bytes.Repeat([]byte("a"), 300)
+	go ws.WriteMessage(wsOpBinary, payload)
+
+	sc := newWSConn(server, nil)
+	fin, opcode, got, err := sc.readFrame()
+	if err != nil {
+		t.Fatal("error reading frame:", err)
+	}
+	if !fin || opcode != wsOpBinary || !bytes.Equal(got, payload) {
+		t.Errorf("got fin %v, opcode %d and %d bytes; want a single binary frame of 300 bytes", fin, opcode, len(got))
+	}
+}
+
+func TestWSConnReadMessage(t *testing.T) {
+	var in bytes.Buffer
+	in.Write(serverFrame(true, wsOpPing, []byte("ping")))
+	in.Write(serverFrame(false, wsOpBinary, []byte{execStreamStdout, 'f', 'o'}))
+	in.Write(serverFrame(true, 0x0, []byte("o")))
+	in.Write(serverFrame(true, wsOpClose, nil))
+
+	var out bytes.Buffer
+	ws := newWSConn(struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{&in, &out, nil}, bufio.NewReader(&in))
+
+	msg, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatal("error reading message:", err)
+	}
+	if string(msg) != "\x01foo" {
+		t.Errorf("got %q; want the fragments joined", msg)
+	}
+	if out.Len() == 0 || out.Bytes()[0] != 0x80|wsOpPong {
+		t.Errorf("expected the ping answered")
+	}
+	if _, err := ws.ReadMessage(); err != io.EOF {
+		t.Errorf("got %v; want io.EOF on close", err)
+	}
+}
+
+func TestExecStatusExitCode(t *testing.T) {
+	var testCases = []struct {
+		status   string
+		exitCode int
+		hasErr   bool
+	}{
+		{`{"status":"Success"}`, 0, false},
+		{`{"status":"Failure","reason":"NonZeroExitCode","details":{"causes":[{"reason":"ExitCode","message":"42"}]}}`, 42, false},
+		{`{"status":"Failure","message":"container not found"}`, exec.ExitCodeError, true},
+		{`invalid`, exec.ExitCodeError, true},
+	}
+
+	for _, tc := range testCases {
+		exitCode, err := execStatusExitCode([]byte(tc.status))
+		if exitCode != tc.exitCode || (err != nil) != tc.hasErr {
+			t.Errorf("got %d, %v for %s; want %d", exitCode, err, tc.status, tc.exitCode)
+		}
+	}
+}
+
+func TestExecURL(t *testing.T) {
+	u, err := execURL("10.0.0.1:443", "teresa", "teresa-1234", "teresa", []string{"bash", "-l"}, true)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	want := "https://10.0.0.1:443/api/v1/namespaces/teresa/pods/teresa-1234/exec?"
+	if got := u.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("got %s; want prefix %s", got, want)
+	}
+	q := u.Query()
+	if cmd := q["command"]; len(cmd) != 2 || cmd[0] != "bash" || cmd[1] != "-l" {
+		t.Errorf("got command %v; want [bash -l]", cmd)
+	}
+	if q.Get("tty") != "true" || q.Get("stderr") != "false" || q.Get("stdin") != "true" {
+		t.Errorf("got query %v; want stdin and tty without stderr", q)
+	}
+}
+
+func TestExecStreams(t *testing.T) {
+	var in bytes.Buffer
+	in.Write(serverFrame(true, wsOpBinary, []byte("\x01hello ")))
+	in.Write(serverFrame(true, wsOpBinary, []byte("\x02world")))
+	in.Write(serverFrame(true, wsOpBinary, append([]byte{execStreamError}, []byte(`{"status":"Success"}`)...)))
+	ws := newWSConn(struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{&in, new(bytes.Buffer), nil}, nil)
+
+	var out bytes.Buffer
+	exitCode, err := execStreams(ws, &out, nil)
+	if err != nil || exitCode != 0 {
+		t.Errorf("got %d, %v; want success", exitCode, err)
+	}
+	if out.String() != "hello world" {
+		t.Errorf("got %q; want stdout and stderr", out.String())
+	}
+}
+
+func TestExecStreamsStderr(t *testing.T) {
+	var in bytes.Buffer
+	in.Write(serverFrame(true, wsOpBinary, []byte("\x01data")))
+	in.Write(serverFrame(true, wsOpBinary, []byte("\x02tar: removing leading '/'")))
+	in.Write(serverFrame(true, wsOpBinary, append([]byte{execStreamError}, []byte(`{"status":"Success"}`)...)))
+	ws := newWSConn(struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{&in, new(bytes.Buffer), nil}, nil)
+
+	var out, errOut bytes.Buffer
+	if _, err := execStreams(ws, &out, &errOut); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if out.String() != "data" || errOut.String() != "tar: removing leading '/'" {
+		t.Errorf("got stdout %q and stderr %q; want them apart", out.String(), errOut.String())
+	}
+}
@@ -16,6 +16,23 @@ type Config struct {
 	ConfigFile    string        `split_words:"true"`
 	PodRunTimeout time.Duration `split_words:"true" default:"30m"`
 	Ingress       bool          `split_words:"true" default:"false"`
+	// MaintenanceService is the DNS name of the service serving the
+	// maintenance page, apps in maintenance mode route their ingress to it
+	MaintenanceService string `split_words:"true"`
+	MaintenancePort    int    `split_words:"true" default:"80"`
+	// CertManagerIssuer is the cert-manager ClusterIssuer used to issue
+	// the certificates of apps with TLS enabled
+	CertManagerIssuer string `split_words:"true"`
+	// IngressAllowedOptions are the ingress options apps can set, see
+	// app.SetIngressOptions
+	IngressAllowedOptions []string `split_words:"true"`
+	// IngressTCPServices and IngressUDPServices are the namespace/name of
+	// the ingress-nginx ConfigMaps exposing the raw ports of the apps
+	IngressTCPServices string `split_words:"true" default:"ingress-nginx/tcp-services"`
+	IngressUDPServices string `split_words:"true" default:"ingress-nginx/udp-services"`
+	// DefaultPDBMinAvailable is the minAvailable of the PodDisruptionBudget
+	// of apps that don't set one, blank means no PodDisruptionBudget
+	DefaultPDBMinAvailable string `split_words:"true"`
 }
 
 func New(conf *Config) (*Client, error) {
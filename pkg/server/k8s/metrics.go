@@ -0,0 +1,47 @@
+package k8s
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const podMetricsPathTmpl = "/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods"
+
+// podMetricsList mirrors the PodMetricsList of the metrics API, the
+// metrics client isn't vendored and only a few fields are needed
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type podMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Containers        []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string            `json:"name"`
+	Usage map[string]string `json:"usage"`
+}
+
+func podMetricsToAppPodMetrics(pm *podMetrics) (*app.PodMetrics, error) {
+	m := &app.PodMetrics{Name: pm.Name}
+	for _, c := range pm.Containers {
+		if v, ok := c.Usage["cpu"]; ok {
+			q, err := resource.ParseQuantity(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid cpu usage of container %s", c.Name)
+			}
+			m.CPU += q.MilliValue()
+		}
+		if v, ok := c.Usage["memory"]; ok {
+			q, err := resource.ParseQuantity(v)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid memory usage of container %s", c.Name)
+			}
+			m.Memory += q.Value()
+		}
+	}
+	return m, nil
+}
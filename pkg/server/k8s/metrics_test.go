@@ -0,0 +1,40 @@
+package k8s
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodMetricsToAppPodMetrics(t *testing.T) {
+	pm := &podMetrics{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod"},
+		Containers: []containerMetrics{
+			{Name: "app", Usage: map[string]string{"cpu": "250m", "memory": "64Mi"}},
+			{Name: "sidecar", Usage: map[string]string{"cpu": "1", "memory": "1Mi"}},
+		},
+	}
+	want := &app.PodMetrics{Name: "pod", CPU: 1250, Memory: 65 * 1024 * 1024}
+
+	m, err := podMetricsToAppPodMetrics(pm)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("want %v; got %v", want, m)
+	}
+}
+
+func TestPodMetricsToAppPodMetricsInvalidQuantity(t *testing.T) {
+	pm := &podMetrics{
+		Containers: []containerMetrics{
+			{Name: "app", Usage: map[string]string{"cpu": "gopher"}},
+		},
+	}
+
+	if _, err := podMetricsToAppPodMetrics(pm); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/exec"
+)
+
+// portForwardURL is the port forward endpoint of the pod, each websocket
+// forwards a single connection
+func portForwardURL(host, namespace, podName string, port int32) (*url.URL, error) {
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s/portforward", strings.TrimSuffix(u.Path, "/"), namespace, podName)
+	u.RawQuery = url.Values{"ports": []string{strconv.Itoa(int(port))}}.Encode()
+	return u, nil
+}
+
+// The port forward API sends the data of the port in the first stream and
+// its errors in the second one, the first message of each stream starts
+// with the port
+const (
+	portForwardStreamData  = 0
+	portForwardStreamError = 1
+)
+
+func portForwardCopyIn(ws *wsConn, in io.Reader) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := in.Read(buf)
+		if n > 0 {
+			msg := append([]byte{portForwardStreamData}, buf[:n]...)
+			if err := ws.WriteMessage(wsOpBinary, msg); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// portForwardStreams copies the data of the port to out until the pod or
+// the API server closes the connection
+func portForwardStreams(ws *wsConn, out io.Writer) error {
+	started := make(map[byte]bool)
+	for {
+		msg, err := ws.ReadMessage()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		stream, data := msg[0], msg[1:]
+		if !started[stream] {
+			started[stream] = true
+			if len(data) < 2 {
+				continue
+			}
+			data = data[2:]
+		}
+		switch stream {
+		case portForwardStreamData:
+			if _, err := out.Write(data); err != nil {
+				return err
+			}
+		case portForwardStreamError:
+			if len(data) > 0 {
+				return errors.New(string(data))
+			}
+		}
+	}
+}
+
+// PortForward forwards the tunnel to the port of the pod, a blank podName
+// is a running pod of the app deploy
+func (k *Client) PortForward(namespace, podName string, port int32, t *app.Tunnel) error {
+	if podName == "" {
+		name, err := k.runningPod(namespace, namespace)
+		if err == exec.ErrPodNotFound {
+			return k8serrors.NewNotFound(k8sv1.Resource("pods"), namespace)
+		}
+		if err != nil {
+			return err
+		}
+		podName = name
+	} else {
+		kc, err := k.buildClient(namespace)
+		if err != nil {
+			return err
+		}
+		if _, err := kc.CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{}); err != nil {
+			return errors.Wrap(err, "get pod failed")
+		}
+	}
+
+	conf, err := k.restConfig(namespace)
+	if err != nil {
+		return err
+	}
+	u, err := portForwardURL(conf.Host, namespace, podName, port)
+	if err != nil {
+		return errors.Wrap(err, "invalid API server host")
+	}
+	ws, err := dialWebsocket(conf, u, execProtocol)
+	if err != nil {
+		return errors.Wrap(err, "port forward failed")
+	}
+
+	var once sync.Once
+	closed := make(chan struct{})
+	closeTunnel := func() {
+		once.Do(func() {
+			close(closed)
+			ws.Close()
+		})
+	}
+	defer closeTunnel()
+	go func() {
+		select {
+		case <-t.Done:
+			closeTunnel()
+		case <-closed:
+		}
+	}()
+	go func() {
+		portForwardCopyIn(ws, t.In)
+		closeTunnel()
+	}()
+
+	err = portForwardStreams(ws, t.Out)
+	select {
+	case <-closed:
+		return nil
+	default:
+		return err
+	}
+}
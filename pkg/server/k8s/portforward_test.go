@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestPortForwardURL(t *testing.T) {
+	u, err := portForwardURL("10.0.0.1:443", "teresa", "teresa-1234", 8080)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	want := "https://10.0.0.1:443/api/v1/namespaces/teresa/pods/teresa-1234/portforward?ports=8080"
+	if got := u.String(); got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
+func TestPortForwardStreams(t *testing.T) {
+	var in bytes.Buffer
+	in.Write(serverFrame(true, wsOpBinary, []byte{portForwardStreamData, 0x90, 0x1f}))
+	in.Write(serverFrame(true, wsOpBinary, []byte{portForwardStreamError, 0x90, 0x1f}))
+	in.Write(serverFrame(true, wsOpBinary, []byte{portForwardStreamData, 'f', 'o', 'o'}))
+	in.Write(serverFrame(true, wsOpClose, nil))
+
+	ws := newWSConn(struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{&in, new(bytes.Buffer), nil}, bufio.NewReader(&in))
+
+	var out bytes.Buffer
+	if err := portForwardStreams(ws, &out); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if out.String() != "foo" {
+		t.Errorf("got %q; want the data without the port prefix", out.String())
+	}
+}
+
+func TestPortForwardStreamsError(t *testing.T) {
+	var in bytes.Buffer
+	in.Write(serverFrame(true, wsOpBinary, []byte{portForwardStreamError, 0x90, 0x1f}))
+	in.Write(serverFrame(true, wsOpBinary, []byte("\x01connection refused")))
+
+	ws := newWSConn(struct {
+		io.Reader
+		io.Writer
+		io.Closer
+	}{&in, new(bytes.Buffer), nil}, bufio.NewReader(&in))
+
+	err := portForwardStreams(ws, new(bytes.Buffer))
+	if err == nil || err.Error() != "connection refused" {
+		t.Errorf("got %v; want the error of the stream", err)
+	}
+}
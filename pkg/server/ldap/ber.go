@@ -0,0 +1,182 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The BER tags of the LDAP messages (RFC 4511) in use
+const (
+	tagBoolean     = 0x01
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagEnumerated  = 0x0a
+	tagSequence    = 0x30
+	tagSet         = 0x31
+
+	tagBindRequest       = 0x60
+	tagBindResponse      = 0x61
+	tagUnbindRequest     = 0x42
+	tagSearchRequest     = 0x63
+	tagSearchResultEntry = 0x64
+	tagSearchResultDone  = 0x65
+	tagSearchResultRef   = 0x73
+
+	tagSimpleAuth    = 0x80
+	tagEqualityMatch = 0xa3
+)
+
+// maxPacketSize caps the messages read, no response of a bind or search of
+// a single user is near it
+const maxPacketSize = 1 << 20
+
+var errMalformedPacket = errors.New("malformed LDAP packet")
+
+// packet is a BER element, the children are set for the constructed ones
+type packet struct {
+	tag      byte
+	value    []byte
+	children []*packet
+}
+
+func newPacket(tag byte, value []byte) *packet {
+	return &packet{tag: tag, value: value}
+}
+
+func newString(tag byte, s string) *packet {
+	return newPacket(tag, []byte(s))
+}
+
+func newInt(tag byte, n int) *packet {
+	var b []byte
+	for {
+		b = append([]byte{byte(n)}, b...)
+		n >>= 8
+		if (n == 0 && b[0]&0x80 == 0) || (n == -1 && b[0]&0x80 != 0) {
+			break
+		}
+	}
+	return newPacket(tag, b)
+}
+
+func newBool(b bool) *packet {
+	if b {
+		return newPacket(tagBoolean, []byte{0xff})
+	}
+	return newPacket(tagBoolean, []byte{0})
+}
+
+func newConstructed(tag byte, children ...*packet) *packet {
+	return &packet{tag: tag, children: children}
+}
+
+func (p *packet) constructed() bool {
+	return p.tag&0x20 != 0
+}
+
+func (p *packet) bytes() []byte {
+	value := p.value
+	if p.constructed() {
+		value = nil
+		for _, c := range p.children {
+			value = append(value, c.bytes()...)
+		}
+	}
+	return append(append([]byte{p.tag}, encodeLength(len(value))...), value...)
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// int returns the value of an INTEGER or ENUMERATED
+func (p *packet) int() (int, error) {
+	if len(p.value) == 0 || len(p.value) > 4 {
+		return 0, errMalformedPacket
+	}
+	n := int(int8(p.value[0]))
+	for _, b := range p.value[1:] {
+		n = n<<8 | int(b)
+	}
+	return n, nil
+}
+
+func (p *packet) child(i int) (*packet, error) {
+	if i >= len(p.children) {
+		return nil, errMalformedPacket
+	}
+	return p.children[i], nil
+}
+
+// readPacket reads a BER element from r
+func readPacket(r *bufio.Reader) (*packet, error) {
+	tag, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	n, err := readLength(r)
+	if err == io.EOF {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n > maxPacketSize {
+		return nil, fmt.Errorf("LDAP packet of %d bytes too large", n)
+	}
+	value := make([]byte, n)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return nil, err
+	}
+	return parsePacket(tag, value)
+}
+
+func readLength(r *bufio.Reader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b < 0x80 {
+		return int(b), nil
+	}
+	size := int(b & 0x7f)
+	if size == 0 || size > 4 {
+		return 0, errMalformedPacket
+	}
+	n := 0
+	for i := 0; i < size; i++ {
+		if b, err = r.ReadByte(); err != nil {
+			return 0, err
+		}
+		n = n<<8 | int(b)
+	}
+	return n, nil
+}
+
+func parsePacket(tag byte, value []byte) (*packet, error) {
+	p := newPacket(tag, value)
+	if !p.constructed() {
+		return p, nil
+	}
+	r := bufio.NewReader(bytes.NewReader(value))
+	for {
+		c, err := readPacket(r)
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return nil, errMalformedPacket
+		}
+		p.children = append(p.children, c)
+	}
+}
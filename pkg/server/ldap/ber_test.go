@@ -0,0 +1,67 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPacketRoundTrip(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	p := newConstructed(
+		tagSequence,
+		newInt(tagInteger, 1),
+		newInt(tagInteger, 128),
+		newInt(tagInteger, -1),
+		newString(tagOctetString, long),
+		newBool(true),
+	)
+
+	got, err := readPacket(bufio.NewReader(bytes.NewReader(p.bytes())))
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(got.children) != 5 {
+		t.Fatalf("expected 5 children, got %d", len(got.children))
+	}
+	for i, expected := range []int{1, 128, -1} {
+		if n, err := got.children[i].int(); err != nil || n != expected {
+			t.Errorf("expected %d, got %d (err: %v)", expected, n, err)
+		}
+	}
+	if s := string(got.children[3].value); s != long {
+		t.Errorf("expected a string of %d bytes, got %d", len(long), len(s))
+	}
+}
+
+func TestReadPacketMalformed(t *testing.T) {
+	for _, b := range [][]byte{
+		{tagSequence, 0x05, tagInteger, 0x01},
+		{tagSequence, 0x03, tagInteger, 0x05, 0x01},
+		{tagSequence, 0x85, 0x01, 0x01, 0x01, 0x01, 0x01},
+		{tagSequence},
+	} {
+		if _, err := readPacket(bufio.NewReader(bytes.NewReader(b))); err == nil {
+			t.Errorf("expected error for %x", b)
+		}
+	}
+}
+
+func TestGroupName(t *testing.T) {
+	var testCases = []struct {
+		dn       string
+		expected string
+	}{
+		{"cn=devs,ou=groups,dc=foo,dc=com", "devs"},
+		{"CN=Platform Devs,OU=Groups,DC=foo,DC=com", "Platform Devs"},
+		{"devs", "devs"},
+		{"ou=devs,dc=foo", "ou=devs,dc=foo"},
+	}
+
+	for _, tc := range testCases {
+		if got := groupName(tc.dn); got != tc.expected {
+			t.Errorf("expected %s for %s, got %s", tc.expected, tc.dn, got)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package ldap
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The LDAP result codes in use
+const (
+	resultSuccess            = 0
+	resultSizeLimitExceeded  = 4
+	resultInvalidCredentials = 49
+)
+
+var errInvalidCredentials = errors.New("invalid LDAP credentials")
+
+// conn is a connection to an LDAP server, doing one request at a time
+type conn struct {
+	c     net.Conn
+	r     *bufio.Reader
+	msgID int
+}
+
+// entry is an entry found by a search, its attributes by lower case name
+type entry struct {
+	dn    string
+	attrs map[string][]string
+}
+
+// dial connects to the server of the URL, ldap:// or ldaps://, the
+// connection is closed after timeout
+func dial(rawURL string, timeout time.Duration, insecure bool) (*conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "389"
+		if u.Scheme == "ldaps" {
+			port = "636"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var c net.Conn
+	switch u.Scheme {
+	case "ldap":
+		c, err = dialer.Dial("tcp", host)
+	case "ldaps":
+		c, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: insecure,
+		})
+	default:
+		return nil, fmt.Errorf("invalid LDAP URL scheme %s", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.SetDeadline(time.Now().Add(timeout))
+	return &conn{c: c, r: bufio.NewReader(c)}, nil
+}
+
+func (c *conn) send(op *packet) (int, error) {
+	c.msgID++
+	msg := newConstructed(tagSequence, newInt(tagInteger, c.msgID), op)
+	_, err := c.c.Write(msg.bytes())
+	return c.msgID, err
+}
+
+// receive returns the operation of the next message, which must be a
+// response to the request id
+func (c *conn) receive(id int) (*packet, error) {
+	msg, err := readPacket(c.r)
+	if err != nil {
+		return nil, err
+	}
+	if msg.tag != tagSequence || len(msg.children) < 2 {
+		return nil, errMalformedPacket
+	}
+	if got, err := msg.children[0].int(); err != nil || got != id {
+		return nil, fmt.Errorf("unexpected LDAP message id %d", got)
+	}
+	return msg.children[1], nil
+}
+
+// result returns the code and the message of an LDAPResult
+func result(op *packet) (int, string, error) {
+	code, err := op.child(0)
+	if err != nil {
+		return 0, "", err
+	}
+	n, err := code.int()
+	if err != nil {
+		return 0, "", err
+	}
+	var msg string
+	if diag, err := op.child(2); err == nil {
+		msg = string(diag.value)
+	}
+	return n, msg, nil
+}
+
+func (c *conn) bind(dn, password string) error {
+	id, err := c.send(newConstructed(
+		tagBindRequest,
+		newInt(tagInteger, 3),
+		newString(tagOctetString, dn),
+		newString(tagSimpleAuth, password),
+	))
+	if err != nil {
+		return err
+	}
+	op, err := c.receive(id)
+	if err != nil {
+		return err
+	}
+	if op.tag != tagBindResponse {
+		return errMalformedPacket
+	}
+	code, msg, err := result(op)
+	if err != nil {
+		return err
+	}
+	switch code {
+	case resultSuccess:
+		return nil
+	case resultInvalidCredentials:
+		return errInvalidCredentials
+	default:
+		return fmt.Errorf("LDAP bind of %s failed with code %d: %s", dn, code, msg)
+	}
+}
+
+// search returns up to limit entries under base whose attribute attr is
+// value, with the attributes attrs
+func (c *conn) search(base, attr, value string, attrs []string, limit int) ([]*entry, error) {
+	names := make([]*packet, len(attrs))
+	for i, a := range attrs {
+		names[i] = newString(tagOctetString, a)
+	}
+	id, err := c.send(newConstructed(
+		tagSearchRequest,
+		newString(tagOctetString, base),
+		newInt(tagEnumerated, 2), // whole subtree
+		newInt(tagEnumerated, 0), // never deref aliases
+		newInt(tagInteger, limit),
+		newInt(tagInteger, 0),
+		newBool(false),
+		newConstructed(tagEqualityMatch, newString(tagOctetString, attr), newString(tagOctetString, value)),
+		newConstructed(tagSequence, names...),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*entry
+	for {
+		op, err := c.receive(id)
+		if err != nil {
+			return nil, err
+		}
+		switch op.tag {
+		case tagSearchResultEntry:
+			e, err := parseEntry(op)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		case tagSearchResultRef:
+			// referrals to other servers aren't followed
+		case tagSearchResultDone:
+			code, msg, err := result(op)
+			if err != nil {
+				return nil, err
+			}
+			if code != resultSuccess && code != resultSizeLimitExceeded {
+				return nil, fmt.Errorf("LDAP search failed with code %d: %s", code, msg)
+			}
+			return entries, nil
+		default:
+			return nil, errMalformedPacket
+		}
+	}
+}
+
+func parseEntry(op *packet) (*entry, error) {
+	dn, err := op.child(0)
+	if err != nil {
+		return nil, err
+	}
+	list, err := op.child(1)
+	if err != nil {
+		return nil, err
+	}
+	e := &entry{dn: string(dn.value), attrs: make(map[string][]string)}
+	for _, a := range list.children {
+		name, err := a.child(0)
+		if err != nil {
+			return nil, err
+		}
+		vals, err := a.child(1)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(string(name.value))
+		for _, v := range vals.children {
+			e.attrs[key] = append(e.attrs[key], string(v.value))
+		}
+	}
+	return e, nil
+}
+
+func (e *entry) first(attr string) string {
+	if vals := e.attrs[strings.ToLower(attr)]; len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (c *conn) close() error {
+	c.send(newPacket(tagUnbindRequest, nil))
+	return c.c.Close()
+}
@@ -0,0 +1,13 @@
+package ldap
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidURL  = errors.New("Invalid LDAP URL, it must be ldap:// or ldaps://")
+	ErrInvalidRole = errors.New("Invalid LDAP role")
+
+	errUserNotFound = errors.New("LDAP user not found")
+	errAmbiguous    = errors.New("LDAP search found more than one user")
+)
@@ -0,0 +1,140 @@
+package ldap
+
+import (
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+// Config is the configuration of the LDAP server, the LDAP authentication
+// is disabled without an URL. The users are searched under SearchBase by
+// UserAttribute, their email, binding as BindDN (anonymously if blank).
+// Groups maps the groups of the users, by common name, to the teams their
+// members belong to, with Role.
+type Config struct {
+	URL            string            `envconfig:"url"`
+	BindDN         string            `envconfig:"bind_dn"`
+	BindPassword   string            `envconfig:"bind_password"`
+	SearchBase     string            `envconfig:"search_base"`
+	UserAttribute  string            `envconfig:"user_attribute" default:"mail"`
+	NameAttribute  string            `envconfig:"name_attribute" default:"cn"`
+	GroupAttribute string            `envconfig:"group_attribute" default:"memberOf"`
+	Groups         map[string]string `envconfig:"groups"`
+	Role           string            `envconfig:"role" default:"developer"`
+	LocalAdmins    bool              `envconfig:"local_admins" default:"true"`
+	Insecure       bool              `envconfig:"insecure"`
+	Timeout        time.Duration     `envconfig:"timeout" default:"10s"`
+}
+
+// UserOperations authenticates the logins against the LDAP server, the
+// other operations are the ones of the local users
+type UserOperations struct {
+	user.Operations
+	conf *Config
+	tOps team.Operations
+}
+
+// identity is the user authenticated by the LDAP server
+type identity struct {
+	name   string
+	groups []string
+}
+
+// groupName returns the common name of a group from its DN, like devs of
+// cn=devs,ou=groups,dc=foo,dc=com
+func groupName(dn string) string {
+	rdn := strings.SplitN(dn, ",", 2)[0]
+	kv := strings.SplitN(rdn, "=", 2)
+	if len(kv) == 2 && strings.EqualFold(strings.TrimSpace(kv[0]), "cn") {
+		return strings.TrimSpace(kv[1])
+	}
+	return dn
+}
+
+func (ops *UserOperations) authenticate(email, password string) (*identity, error) {
+	c, err := dial(ops.conf.URL, ops.conf.Timeout, ops.conf.Insecure)
+	if err != nil {
+		return nil, err
+	}
+	defer c.close()
+
+	if ops.conf.BindDN != "" {
+		if err := c.bind(ops.conf.BindDN, ops.conf.BindPassword); err != nil {
+			return nil, err
+		}
+	}
+	attrs := []string{ops.conf.NameAttribute, ops.conf.GroupAttribute}
+	entries, err := c.search(ops.conf.SearchBase, ops.conf.UserAttribute, email, attrs, 2)
+	if err != nil {
+		return nil, err
+	}
+	switch len(entries) {
+	case 0:
+		return nil, errUserNotFound
+	case 1:
+	default:
+		return nil, errAmbiguous
+	}
+
+	e := entries[0]
+	if err := c.bind(e.dn, password); err != nil {
+		return nil, err
+	}
+	id := &identity{name: e.first(ops.conf.NameAttribute)}
+	for _, dn := range e.attrs[strings.ToLower(ops.conf.GroupAttribute)] {
+		id.groups = append(id.groups, groupName(dn))
+	}
+	return id, nil
+}
+
+// Authenticate authenticates the user against the LDAP server. The users
+// are created on their first login and added to (or removed from) the teams
+// of their groups on every one. The local admins fall back to their local
+// passwords, to manage Teresa when the LDAP server is down.
+func (ops *UserOperations) Authenticate(email, password string) error {
+	// the binds without password are anonymous ones
+	if password == "" {
+		return auth.ErrPermissionDenied
+	}
+
+	id, err := ops.authenticate(email, password)
+	if err != nil {
+		if ops.conf.LocalAdmins {
+			if u, uErr := ops.Operations.GetUser(email); uErr == nil && u.IsAdmin {
+				return ops.Operations.Authenticate(email, password)
+			}
+		}
+		if err != errInvalidCredentials && err != errUserNotFound {
+			log.WithError(err).WithField("email", email).Warn("authenticating on the LDAP server")
+		}
+		return auth.ErrPermissionDenied
+	}
+
+	created, err := user.Provision(ops.Operations, id.name, email)
+	if err != nil {
+		return err
+	}
+	if created {
+		log.WithField("email", email).Info("user created by the LDAP login")
+	}
+	return team.SyncGroups(ops.tOps, email, id.groups, ops.conf.Groups, ops.conf.Role)
+}
+
+// New returns the operations of the users authenticating against the LDAP
+// server, uOps itself if it isn't configured
+func New(conf *Config, uOps user.Operations, tOps team.Operations) (user.Operations, error) {
+	if conf == nil || conf.URL == "" {
+		return uOps, nil
+	}
+	if !strings.HasPrefix(conf.URL, "ldap://") && !strings.HasPrefix(conf.URL, "ldaps://") {
+		return nil, ErrInvalidURL
+	}
+	if !team.IsValidRole(conf.Role) {
+		return nil, ErrInvalidRole
+	}
+	return &UserOperations{Operations: uOps, conf: conf, tOps: tOps}, nil
+}
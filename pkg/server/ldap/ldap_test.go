@@ -0,0 +1,210 @@
+package ldap
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+// fakeServer is an LDAP server with the entries of its directory, the
+// passwords by DN
+type fakeServer struct {
+	l         net.Listener
+	passwords map[string]string
+	entries   []*entry
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("error listening:", err)
+	}
+	s := &fakeServer{
+		l: l,
+		passwords: map[string]string{
+			"cn=teresa,dc=foo":               "svcpass",
+			"cn=Gopher,ou=people,dc=foo":     "secret",
+			"cn=Other,ou=people,dc=foo":      "secret",
+			"cn=Namesake,ou=people,dc=foo":   "secret",
+			"cn=Namesake 2,ou=people,dc=foo": "secret",
+		},
+		entries: []*entry{
+			{dn: "cn=Gopher,ou=people,dc=foo", attrs: map[string][]string{
+				"mail":     {"gopher@foo.com"},
+				"cn":       {"Gopher"},
+				"memberof": {"cn=platform-devs,ou=groups,dc=foo", "cn=others,ou=groups,dc=foo"},
+			}},
+			{dn: "cn=Namesake,ou=people,dc=foo", attrs: map[string][]string{"mail": {"twin@foo.com"}}},
+			{dn: "cn=Namesake 2,ou=people,dc=foo", attrs: map[string][]string{"mail": {"twin@foo.com"}}},
+		},
+	}
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "ldap://" + s.l.Addr().String()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		c, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(c)
+	}
+}
+
+func (s *fakeServer) handle(c net.Conn) {
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for {
+		msg, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		id, _ := msg.children[0].int()
+		op := msg.children[1]
+		reply := func(op *packet) {
+			c.Write(newConstructed(tagSequence, newInt(tagInteger, id), op).bytes())
+		}
+		ldapResult := func(tag byte, code int) *packet {
+			return newConstructed(tag, newInt(tagEnumerated, code), newString(tagOctetString, ""), newString(tagOctetString, ""))
+		}
+
+		switch op.tag {
+		case tagBindRequest:
+			dn, pass := string(op.children[1].value), string(op.children[2].value)
+			code := resultSuccess
+			if p, found := s.passwords[dn]; !found || p != pass {
+				code = resultInvalidCredentials
+			}
+			reply(ldapResult(tagBindResponse, code))
+		case tagSearchRequest:
+			filter := op.children[6]
+			attr, value := strings.ToLower(string(filter.children[0].value)), string(filter.children[1].value)
+			for _, e := range s.entries {
+				if len(e.attrs[attr]) == 0 || e.attrs[attr][0] != value {
+					continue
+				}
+				var attrs []*packet
+				for name, vals := range e.attrs {
+					set := newConstructed(tagSet)
+					for _, v := range vals {
+						set.children = append(set.children, newString(tagOctetString, v))
+					}
+					attrs = append(attrs, newConstructed(tagSequence, newString(tagOctetString, name), set))
+				}
+				reply(newConstructed(tagSearchResultEntry, newString(tagOctetString, e.dn), newConstructed(tagSequence, attrs...)))
+			}
+			reply(ldapResult(tagSearchResultDone, resultSuccess))
+		case tagUnbindRequest:
+			return
+		}
+	}
+}
+
+func newTestOperations(s *fakeServer) (user.Operations, user.Operations, team.Operations) {
+	uOps := user.NewFakeOperations()
+	tOps := team.NewFakeOperations()
+	tOps.(*team.FakeOperations).UserOps = uOps
+	tOps.(*team.FakeOperations).Storage["platform"] = &database.Team{Name: "platform"}
+	conf := &Config{
+		URL:            s.url(),
+		BindDN:         "cn=teresa,dc=foo",
+		BindPassword:   "svcpass",
+		SearchBase:     "ou=people,dc=foo",
+		UserAttribute:  "mail",
+		NameAttribute:  "cn",
+		GroupAttribute: "memberOf",
+		Groups:         map[string]string{"platform-devs": "platform"},
+		Role:           team.RoleDeveloper,
+		LocalAdmins:    true,
+		Timeout:        time.Second,
+	}
+	ops, _ := New(conf, uOps, tOps)
+	return ops, uOps, tOps
+}
+
+func TestUserOperationsAuthenticate(t *testing.T) {
+	s := newFakeServer(t)
+	defer s.l.Close()
+	ops, uOps, tOps := newTestOperations(s)
+	email := "gopher@foo.com"
+
+	if err := ops.Authenticate(email, "secret"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if u, err := uOps.GetUser(email); err != nil || u.Email != email {
+		t.Errorf("expected the user created, got %v (err: %v)", u, err)
+	}
+	if ok, _ := tOps.HasUser("platform", email); !ok {
+		t.Error("expected the user in the team platform")
+	}
+}
+
+func TestUserOperationsAuthenticateDenied(t *testing.T) {
+	s := newFakeServer(t)
+	defer s.l.Close()
+	ops, uOps, _ := newTestOperations(s)
+
+	var testCases = []struct {
+		email    string
+		password string
+	}{
+		{"gopher@foo.com", "wrong"},
+		{"gopher@foo.com", ""},
+		{"nobody@foo.com", "secret"},
+		{"twin@foo.com", "secret"},
+	}
+
+	for _, tc := range testCases {
+		if err := ops.Authenticate(tc.email, tc.password); err != auth.ErrPermissionDenied {
+			t.Errorf("expected ErrPermissionDenied for %s, got %v", tc.email, err)
+		}
+	}
+	if _, err := uOps.GetUser("twin@foo.com"); err != user.ErrNotFound {
+		t.Errorf("expected no user created, got %v", err)
+	}
+}
+
+func TestUserOperationsAuthenticateLocalAdmins(t *testing.T) {
+	s := newFakeServer(t)
+	ops, uOps, _ := newTestOperations(s)
+	uOps.(*user.FakeOperations).Storage["admin@foo.com"] = &database.User{Email: "admin@foo.com", Password: "local", IsAdmin: true}
+	uOps.(*user.FakeOperations).Storage["dev@foo.com"] = &database.User{Email: "dev@foo.com", Password: "local"}
+
+	// the LDAP server is down
+	s.l.Close()
+
+	if err := ops.Authenticate("admin@foo.com", "local"); err != nil {
+		t.Errorf("expected the local admin logged in, got %v", err)
+	}
+	if err := ops.Authenticate("dev@foo.com", "local"); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a local user, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	uOps := user.NewFakeOperations()
+	ops, err := New(&Config{}, uOps, team.NewFakeOperations())
+	if err != nil || ops != uOps {
+		t.Errorf("expected the local operations without URL, got %v (err: %v)", ops, err)
+	}
+	conf := &Config{URL: "http://ldap.foo.com", Role: team.RoleDeveloper}
+	if _, err := New(conf, uOps, team.NewFakeOperations()); err != ErrInvalidURL {
+		t.Errorf("expected ErrInvalidURL, got %v", err)
+	}
+	conf.URL, conf.Role = "ldaps://ldap.foo.com", "owner"
+	if _, err := New(conf, uOps, team.NewFakeOperations()); err != ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package lease
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/pkg/errors"
+)
+
+// Leases elect the replica of the server running each background task. The
+// holder renews its lease on every run, another replica takes it over once
+// expired.
+type Leases interface {
+	Acquire(name string, ttl time.Duration) (bool, error)
+}
+
+// DatabaseLeases keeps the leases in the database, Holder identifies the
+// replica (e.g. the pod name)
+type DatabaseLeases struct {
+	DB     *gorm.DB
+	Holder string
+}
+
+// Acquire takes or renews the lease for ttl, false means another replica
+// holds it
+func (l *DatabaseLeases) Acquire(name string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	expired := l.DB.Where("name = ? AND expires_at < ?", name, now)
+	if err := expired.Delete(database.Lease{}).Error; err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("removing expired lease %s", name))
+	}
+
+	q := l.DB.Model(&database.Lease{}).
+		Where("name = ? AND holder = ?", name, l.Holder).
+		Update("expires_at", now.Add(ttl))
+	if q.Error != nil {
+		return false, errors.Wrap(q.Error, fmt.Sprintf("renewing lease %s", name))
+	}
+	if q.RowsAffected > 0 {
+		return true, nil
+	}
+
+	lease := &database.Lease{Name: name, Holder: l.Holder, ExpiresAt: now.Add(ttl)}
+	if err := l.DB.Create(lease).Error; err == nil {
+		return true, nil
+	}
+
+	holder := new(database.Lease)
+	if err := l.DB.Where(&database.Lease{Name: name}).First(holder).Error; err != nil {
+		return false, errors.Wrap(err, fmt.Sprintf("acquiring lease %s", name))
+	}
+	return false, nil
+}
+
+func NewDatabaseLeases(db *gorm.DB, holder string) Leases {
+	return &DatabaseLeases{DB: db, Holder: holder}
+}
+
+// Held tells if the replica holds the lease, the failures are logged and
+// taken as not held. Without leases there's a single replica, always
+// holding them.
+func Held(l Leases, name string, ttl time.Duration) bool {
+	if l == nil {
+		return true
+	}
+	held, err := l.Acquire(name, ttl)
+	if err != nil {
+		log.WithError(err).Errorf("acquiring lease %s", name)
+		return false
+	}
+	return held
+}
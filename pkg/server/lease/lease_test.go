@@ -0,0 +1,52 @@
+package lease
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestDatabaseLeasesAcquire(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	pod1 := NewDatabaseLeases(db, "teresa-1")
+	pod2 := NewDatabaseLeases(db, "teresa-2")
+
+	var testCases = []struct {
+		leases   Leases
+		name     string
+		ttl      time.Duration
+		expected bool
+	}{
+		{pod1, "scheduler", time.Minute, true},
+		{pod2, "scheduler", time.Minute, false},
+		{pod1, "scheduler", -time.Minute, true},
+		{pod2, "scheduler", time.Minute, true},
+		{pod1, "scheduler", time.Minute, false},
+		{pod1, "cleanup", time.Minute, true},
+	}
+	for _, tc := range testCases {
+		held, err := tc.leases.Acquire(tc.name, tc.ttl)
+		if err != nil {
+			t.Fatal("error acquiring lease: ", err)
+		}
+		if held != tc.expected {
+			t.Errorf("expected %v acquiring %s by %s, got %v", tc.expected, tc.name, tc.leases.(*DatabaseLeases).Holder, held)
+		}
+	}
+}
+
+func TestHeldWithoutLeases(t *testing.T) {
+	if !Held(nil, "scheduler", time.Minute) {
+		t.Error("expected the lease held without leases")
+	}
+}
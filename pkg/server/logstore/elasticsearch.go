@@ -0,0 +1,171 @@
+package logstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// esMaxResults is the default index.max_result_window, the deepest hit a
+// search pages to
+const esMaxResults = 10000
+
+// Elasticsearch searches the documents with the metadata of the kubernetes
+// filter of fluentd and fluent-bit
+type Elasticsearch struct {
+	URL    string
+	Index  string
+	Limit  int
+	client *httpClient
+}
+
+type esDocument struct {
+	Timestamp  time.Time `json:"@timestamp"`
+	Log        string    `json:"log"`
+	Kubernetes struct {
+		NamespaceName string `json:"namespace_name"`
+		PodName       string `json:"pod_name"`
+		ContainerName string `json:"container_name"`
+	} `json:"kubernetes"`
+}
+
+type esResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source esDocument `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+func (e *Elasticsearch) Type() string {
+	return string(ElasticsearchType)
+}
+
+// Query searches the pods in the index and filters the lines matched, paging
+// while the filters drop hits and the limit isn't reached
+func (e *Elasticsearch) Query(q *Query) ([]*Entry, error) {
+	keep, err := q.filter()
+	if err != nil {
+		return nil, err
+	}
+	n := limit(q, e.Limit)
+
+	var entries []*Entry
+	for from := 0; ; {
+		hits, err := e.search(esSearch(q, from, n))
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range hits {
+			// the phrases match the analyzed fields partially
+			if doc.Kubernetes.NamespaceName != q.Namespace || doc.Kubernetes.ContainerName != q.Container {
+				continue
+			}
+			entry := &Entry{
+				Time: doc.Timestamp,
+				Pod:  doc.Kubernetes.PodName,
+				Line: strings.TrimSuffix(doc.Log, "\n"),
+			}
+			if keep(entry) {
+				entries = append(entries, entry)
+			}
+		}
+		from += len(hits)
+		if n <= 0 || len(hits) < n || len(entries) >= n || from+n > esMaxResults {
+			break
+		}
+	}
+	return lastEntries(entries, n), nil
+}
+
+func (e *Elasticsearch) search(search map[string]interface{}) ([]esDocument, error) {
+	body, err := json.Marshal(search)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/%s/_search", e.URL, e.Index)
+	rc, err := e.client.do("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	resp := new(esResponse)
+	if err := json.NewDecoder(rc).Decode(resp); err != nil {
+		return nil, err
+	}
+	docs := make([]esDocument, len(resp.Hits.Hits))
+	for i, hit := range resp.Hits.Hits {
+		docs[i] = hit.Source
+	}
+	return docs, nil
+}
+
+// esSearch is the body of the search of the page of n lines starting at
+// from, newest first. The pods are searched by phrase (prefix) and the
+// lines are filtered after, the excluded pods and the regexp of the lines
+// can't be matched on the analyzed fields
+func esSearch(q *Query, from, n int) map[string]interface{} {
+	filter := []interface{}{
+		map[string]interface{}{
+			"match_phrase": map[string]string{"kubernetes.namespace_name": q.Namespace},
+		},
+		map[string]interface{}{
+			"match_phrase": map[string]string{"kubernetes.container_name": q.Container},
+		},
+		map[string]interface{}{
+			"range": map[string]interface{}{
+				"@timestamp": map[string]string{
+					"gte": q.Since.UTC().Format(time.RFC3339Nano),
+					"lte": q.Until.UTC().Format(time.RFC3339Nano),
+				},
+			},
+		},
+	}
+	if q.Pod != "" {
+		filter = append(filter, map[string]interface{}{
+			"match_phrase": map[string]string{"kubernetes.pod_name": q.Pod},
+		})
+	}
+	if len(q.PodPrefixes) > 0 {
+		var should []interface{}
+		for _, p := range q.PodPrefixes {
+			should = append(should, map[string]interface{}{
+				"match_phrase_prefix": map[string]string{"kubernetes.pod_name": p},
+			})
+		}
+		filter = append(filter, map[string]interface{}{
+			"bool": map[string]interface{}{"should": should, "minimum_should_match": 1},
+		})
+	}
+
+	search := map[string]interface{}{
+		"sort": []interface{}{
+			map[string]interface{}{"@timestamp": map[string]string{"order": "desc"}},
+		},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": filter,
+			},
+		},
+	}
+	if from > 0 {
+		search["from"] = from
+	}
+	if n > 0 {
+		search["size"] = n
+	}
+	return search
+}
+
+func newElasticsearch(conf *Config) Store {
+	return &Elasticsearch{
+		URL:    strings.TrimSuffix(conf.URL, "/"),
+		Index:  conf.Index,
+		Limit:  conf.Limit,
+		client: newHTTPClient(conf),
+	}
+}
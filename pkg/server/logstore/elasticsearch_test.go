@@ -0,0 +1,105 @@
+package logstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestElasticsearchQuery(t *testing.T) {
+	var search map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if expected := "/logs-*/_search"; r.URL.Path != expected {
+			t.Errorf("expected %s, got %s", expected, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+			t.Error("error decoding the search:", err)
+		}
+		fmt.Fprint(w, `{"hits": {"hits": [
+			{"_source": {"@timestamp": "2017-07-14T02:40:02Z", "log": "second\n", "kubernetes": {"namespace_name": "teresa", "pod_name": "teresa-1", "container_name": "teresa"}}},
+			{"_source": {"@timestamp": "2017-07-14T02:40:01Z", "log": "other app\n", "kubernetes": {"namespace_name": "teresa-other", "pod_name": "teresa-other-1", "container_name": "teresa"}}},
+			{"_source": {"@timestamp": "2017-07-14T02:40:00Z", "log": "first\n", "kubernetes": {"namespace_name": "teresa", "pod_name": "teresa-2", "container_name": "teresa"}}}
+		]}}`)
+	}))
+	defer srv.Close()
+
+	s, err := New(&Config{Type: ElasticsearchType, URL: srv.URL, Index: "logs-*", Limit: 5000})
+	if err != nil {
+		t.Fatal("error creating the store:", err)
+	}
+	q := &Query{
+		Namespace: "teresa",
+		Container: "teresa",
+		Since:     time.Unix(1400000000, 0),
+		Until:     time.Unix(1600000000, 0),
+	}
+	entries, err := s.Query(q)
+	if err != nil {
+		t.Fatal("error querying:", err)
+	}
+
+	if size, _ := search["size"].(float64); size != 5000 {
+		t.Errorf("expected size 5000, got %v", search["size"])
+	}
+	expected := []string{"teresa-2 first", "teresa-1 second"}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, e := range entries {
+		if actual := e.Pod + " " + e.Line; actual != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], actual)
+		}
+	}
+}
+
+func TestElasticsearchQueryPages(t *testing.T) {
+	var froms []float64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var search map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&search); err != nil {
+			t.Error("error decoding the search:", err)
+		}
+		from, _ := search["from"].(float64)
+		froms = append(froms, from)
+		if from > 4 {
+			fmt.Fprint(w, `{"hits": {"hits": []}}`)
+			return
+		}
+		line := "debug"
+		if from == 4 {
+			line = "error"
+		}
+		fmt.Fprintf(w, `{"hits": {"hits": [
+			{"_source": {"@timestamp": "2017-07-14T02:40:02Z", "log": "%s\n", "kubernetes": {"namespace_name": "teresa", "pod_name": "teresa-1", "container_name": "teresa"}}},
+			{"_source": {"@timestamp": "2017-07-14T02:40:01Z", "log": "debug\n", "kubernetes": {"namespace_name": "teresa", "pod_name": "teresa-1", "container_name": "teresa"}}}
+		]}}`, line)
+	}))
+	defer srv.Close()
+
+	s, _ := New(&Config{Type: ElasticsearchType, URL: srv.URL, Index: "logs-*"})
+	entries, err := s.Query(&Query{Namespace: "teresa", Container: "teresa", Limit: 2, Grep: "error"})
+	if err != nil {
+		t.Fatal("error querying:", err)
+	}
+	if len(entries) != 1 || entries[0].Line != "error" {
+		t.Errorf("expected the error line, got %v", entries)
+	}
+	if len(froms) != 4 || froms[2] != 4 {
+		t.Errorf("expected the pages searched until the last one, got %v", froms)
+	}
+}
+
+func TestElasticsearchQueryError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "index_not_found_exception", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	s, _ := New(&Config{Type: ElasticsearchType, URL: srv.URL, Index: "logs-*"})
+	if _, err := s.Query(&Query{Namespace: "teresa", Container: "teresa"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
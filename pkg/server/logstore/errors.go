@@ -0,0 +1,10 @@
+package logstore
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidStoreType = errors.New("Invalid log store type")
+	ErrMissingURL       = errors.New("Missing log store URL")
+)
@@ -0,0 +1,38 @@
+package logstore
+
+const FakeType storeType = "fake"
+
+// Fake is a Store of the entries in memory
+type Fake struct {
+	Entries []*Entry
+	Err     error
+	// Queries are the ones done to the store
+	Queries []*Query
+}
+
+func (f *Fake) Type() string {
+	return string(FakeType)
+}
+
+func (f *Fake) Query(q *Query) ([]*Entry, error) {
+	f.Queries = append(f.Queries, q)
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	keep, err := q.filter()
+	if err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for _, e := range f.Entries {
+		if e.Time.Before(q.Since) || e.Time.After(q.Until) || !keep(e) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return lastEntries(entries, q.Limit), nil
+}
+
+func NewFake(entries ...*Entry) *Fake {
+	return &Fake{Entries: entries}
+}
@@ -0,0 +1,175 @@
+package logstore
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+type storeType string
+
+const (
+	NoneType          storeType = ""
+	LokiType          storeType = "loki"
+	ElasticsearchType storeType = "elasticsearch"
+)
+
+type Config struct {
+	Type     storeType     `envconfig:"type" default:""`
+	URL      string        `envconfig:"url"`
+	Index    string        `envconfig:"index" default:"logstash-*"`
+	Username string        `envconfig:"username"`
+	Password string        `envconfig:"password"`
+	Limit    int           `envconfig:"limit" default:"5000"`
+	Timeout  time.Duration `envconfig:"timeout" default:"30s"`
+}
+
+// Query selects the lines of a container of the pods of a namespace logged
+// between Since and Until. A Limit of 0 is the limit of the store, it
+// counts the lines kept by the filters of the pods and of the lines
+type Query struct {
+	Namespace string
+	Container string
+	Since     time.Time
+	Until     time.Time
+	Limit     int
+	// Pod is the pod of the lines, blank for any
+	Pod string
+	// PodPrefixes keep the lines of the pods named with one of them,
+	// ExcludedPodPrefixes drop the ones of the pods named with any of them
+	PodPrefixes         []string
+	ExcludedPodPrefixes []string
+	// Grep keeps the lines matching the expression (RE2 syntax)
+	Grep string
+}
+
+// filter tells if the entry is selected by the filters of the query, the
+// stores use it on the lines matched by their own (less precise) filters
+func (q *Query) filter() (func(*Entry) bool, error) {
+	var re *regexp.Regexp
+	if q.Grep != "" {
+		var err error
+		if re, err = regexp.Compile(q.Grep); err != nil {
+			return nil, err
+		}
+	}
+	return func(e *Entry) bool {
+		if q.Pod != "" && e.Pod != q.Pod {
+			return false
+		}
+		if len(q.PodPrefixes) > 0 && !hasPrefix(e.Pod, q.PodPrefixes) {
+			return false
+		}
+		if hasPrefix(e.Pod, q.ExcludedPodPrefixes) {
+			return false
+		}
+		return re == nil || re.MatchString(e.Line)
+	}, nil
+}
+
+func hasPrefix(s string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Entry is a line logged by a pod
+type Entry struct {
+	Time time.Time
+	Pod  string
+	Line string
+}
+
+// Store is a historical logs backend, with the lines collected from the
+// pods even after kubelet discarded them
+type Store interface {
+	Type() string
+	Query(q *Query) ([]*Entry, error)
+}
+
+// New returns the Store of the configuration, nil if there isn't one
+func New(conf *Config) (Store, error) {
+	switch conf.Type {
+	case NoneType:
+		return nil, nil
+	case LokiType:
+		if conf.URL == "" {
+			return nil, ErrMissingURL
+		}
+		return newLoki(conf), nil
+	case ElasticsearchType:
+		if conf.URL == "" {
+			return nil, ErrMissingURL
+		}
+		return newElasticsearch(conf), nil
+	default:
+		return nil, ErrInvalidStoreType
+	}
+}
+
+// httpClient does the requests to the HTTP APIs of the stores
+type httpClient struct {
+	client   *http.Client
+	username string
+	password string
+}
+
+func newHTTPClient(conf *Config) *httpClient {
+	return &httpClient{
+		client:   &http.Client{Timeout: conf.Timeout},
+		username: conf.Username,
+		password: conf.Password,
+	}
+}
+
+// do returns the body of the response, any status other than 200 is an error
+func (c *httpClient) do(method, url string, body io.Reader) (io.ReadCloser, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s %s: %s: %s", method, url, resp.Status, msg)
+	}
+	return resp.Body, nil
+}
+
+// limit returns the limit of the query capped by the one of the store
+func limit(q *Query, max int) int {
+	if q.Limit > 0 && (max <= 0 || q.Limit < max) {
+		return q.Limit
+	}
+	return max
+}
+
+// lastEntries sorts the entries from the oldest to the newest and keeps
+// the newest n ones
+func lastEntries(entries []*Entry, n int) []*Entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries
+}
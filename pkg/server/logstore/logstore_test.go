@@ -0,0 +1,72 @@
+package logstore
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewStore(t *testing.T) {
+	var testCases = []struct {
+		conf          *Config
+		expectedType  string
+		expectedError error
+	}{
+		{&Config{}, "", nil},
+		{&Config{Type: "loki", URL: "http://loki:3100"}, "loki", nil},
+		{&Config{Type: "elasticsearch", URL: "http://es:9200"}, "elasticsearch", nil},
+		{&Config{Type: "loki"}, "", ErrMissingURL},
+		{&Config{Type: "InvalidType"}, "", ErrInvalidStoreType},
+	}
+
+	for _, tc := range testCases {
+		s, err := New(tc.conf)
+		if err != tc.expectedError {
+			t.Errorf("expected %v, got %v", tc.expectedError, err)
+		}
+		if s == nil {
+			if tc.expectedType != "" {
+				t.Errorf("expected store %s, got nil", tc.expectedType)
+			}
+			continue
+		}
+		if actual := s.Type(); actual != tc.expectedType {
+			t.Errorf("expected %s, got %s", tc.expectedType, actual)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	var testCases = []struct {
+		queryLimit int
+		max        int
+		expected   int
+	}{
+		{0, 5000, 5000},
+		{10, 5000, 10},
+		{10000, 5000, 5000},
+		{10, 0, 10},
+	}
+
+	for _, tc := range testCases {
+		if actual := limit(&Query{Limit: tc.queryLimit}, tc.max); actual != tc.expected {
+			t.Errorf("expected %d, got %d", tc.expected, actual)
+		}
+	}
+}
+
+func TestLastEntries(t *testing.T) {
+	now := time.Now()
+	entries := []*Entry{
+		{Time: now, Line: "3"},
+		{Time: now.Add(-time.Second), Line: "2"},
+		{Time: now.Add(-2 * time.Second), Line: "1"},
+	}
+
+	entries = lastEntries(entries, 2)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2, got %d", len(entries))
+	}
+	if entries[0].Line != "2" || entries[1].Line != "3" {
+		t.Errorf("expected [2 3], got [%s %s]", entries[0].Line, entries[1].Line)
+	}
+}
@@ -0,0 +1,123 @@
+package logstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const lokiQueryRangePath = "/loki/api/v1/query_range"
+
+// Loki queries the streams labeled by namespace, pod and container, like
+// the ones of promtail
+type Loki struct {
+	URL    string
+	Limit  int
+	client *httpClient
+}
+
+type lokiResponse struct {
+	Data struct {
+		Result []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (l *Loki) Type() string {
+	return string(LokiType)
+}
+
+func (l *Loki) Query(q *Query) ([]*Entry, error) {
+	keep, err := q.filter()
+	if err != nil {
+		return nil, err
+	}
+	n := limit(q, l.Limit)
+	v := url.Values{}
+	v.Set("query", lokiQuery(q))
+	v.Set("start", strconv.FormatInt(q.Since.UnixNano(), 10))
+	v.Set("end", strconv.FormatInt(q.Until.UnixNano(), 10))
+	v.Set("direction", "backward")
+	if n > 0 {
+		v.Set("limit", strconv.Itoa(n))
+	}
+
+	body, err := l.client.do("GET", l.URL+lokiQueryRangePath+"?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	resp := new(lokiResponse)
+	if err := json.NewDecoder(body).Decode(resp); err != nil {
+		return nil, err
+	}
+	var entries []*Entry
+	for _, r := range resp.Data.Result {
+		for _, value := range r.Values {
+			if len(value) != 2 {
+				continue
+			}
+			ns, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			e := &Entry{
+				Time: time.Unix(0, ns),
+				Pod:  r.Stream["pod"],
+				Line: strings.TrimSuffix(value[1], "\n"),
+			}
+			if keep(e) {
+				entries = append(entries, e)
+			}
+		}
+	}
+	return lastEntries(entries, n), nil
+}
+
+// lokiQuery is the LogQL of the query, the pods are selected by their label
+// and the lines by a regexp filter
+func lokiQuery(q *Query) string {
+	matchers := []string{
+		fmt.Sprintf("namespace=%q", q.Namespace),
+		fmt.Sprintf("container=%q", q.Container),
+	}
+	if q.Pod != "" {
+		matchers = append(matchers, fmt.Sprintf("pod=%q", q.Pod))
+	}
+	if len(q.PodPrefixes) > 0 {
+		matchers = append(matchers, fmt.Sprintf("pod=~%q", prefixesRegexp(q.PodPrefixes)))
+	}
+	if len(q.ExcludedPodPrefixes) > 0 {
+		matchers = append(matchers, fmt.Sprintf("pod!~%q", prefixesRegexp(q.ExcludedPodPrefixes)))
+	}
+	query := fmt.Sprintf("{%s}", strings.Join(matchers, ","))
+	if q.Grep != "" {
+		query += fmt.Sprintf(" |~ %q", q.Grep)
+	}
+	return query
+}
+
+// prefixesRegexp matches the names starting with one of the prefixes, the
+// label matchers of Loki are anchored
+func prefixesRegexp(prefixes []string) string {
+	quoted := make([]string, len(prefixes))
+	for i, p := range prefixes {
+		quoted[i] = regexp.QuoteMeta(p)
+	}
+	return fmt.Sprintf("(%s).*", strings.Join(quoted, "|"))
+}
+
+func newLoki(conf *Config) Store {
+	return &Loki{
+		URL:    strings.TrimSuffix(conf.URL, "/"),
+		Limit:  conf.Limit,
+		client: newHTTPClient(conf),
+	}
+}
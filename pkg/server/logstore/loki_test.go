@@ -0,0 +1,88 @@
+package logstore
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLokiQuery(t *testing.T) {
+	var query, limit, user string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != lokiQueryRangePath {
+			t.Errorf("expected %s, got %s", lokiQueryRangePath, r.URL.Path)
+		}
+		query = r.URL.Query().Get("query")
+		limit = r.URL.Query().Get("limit")
+		user, _, _ = r.BasicAuth()
+		fmt.Fprint(w, `{"status": "success", "data": {"resultType": "streams", "result": [
+			{"stream": {"pod": "teresa-1"}, "values": [["1500000002000000000", "third\n"], ["1500000000000000000", "first\n"]]},
+			{"stream": {"pod": "teresa-2"}, "values": [["1500000001000000000", "second\n"]]}
+		]}}`)
+	}))
+	defer srv.Close()
+
+	s, err := New(&Config{Type: LokiType, URL: srv.URL + "/", Username: "gopher", Limit: 5000})
+	if err != nil {
+		t.Fatal("error creating the store:", err)
+	}
+	q := &Query{
+		Namespace: "teresa",
+		Container: "teresa",
+		Since:     time.Unix(1400000000, 0),
+		Until:     time.Unix(1600000000, 0),
+		Limit:     10,
+	}
+	entries, err := s.Query(q)
+	if err != nil {
+		t.Fatal("error querying:", err)
+	}
+
+	if expected := `{namespace="teresa",container="teresa"}`; query != expected {
+		t.Errorf("expected %s, got %s", expected, query)
+	}
+	if limit != "10" {
+		t.Errorf("expected 10, got %s", limit)
+	}
+	if user != "gopher" {
+		t.Errorf("expected gopher, got %s", user)
+	}
+	expected := []string{"teresa-1 first", "teresa-2 second", "teresa-1 third"}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, e := range entries {
+		if actual := e.Pod + " " + e.Line; actual != expected[i] {
+			t.Errorf("expected %s, got %s", expected[i], actual)
+		}
+	}
+}
+
+func TestLokiQueryFilters(t *testing.T) {
+	q := &Query{
+		Namespace:           "teresa",
+		Container:           "teresa",
+		Pod:                 "teresa-1",
+		PodPrefixes:         []string{"teresa-worker-"},
+		ExcludedPodPrefixes: []string{"teresa-worker.x-"},
+		Grep:                `error \d+`,
+	}
+	expected := `{namespace="teresa",container="teresa",pod="teresa-1",pod=~"(teresa-worker-).*",pod!~"(teresa-worker\\.x-).*"} |~ "error \\d+"`
+	if actual := lokiQuery(q); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestLokiQueryError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "parse error", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, _ := New(&Config{Type: LokiType, URL: srv.URL})
+	if _, err := s.Query(&Query{Namespace: "teresa", Container: "teresa"}); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
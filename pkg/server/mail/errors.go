@@ -0,0 +1,8 @@
+package mail
+
+import "errors"
+
+var (
+	ErrMissingFrom = errors.New("Missing the sender address of the e-mails")
+	ErrNoRecipient = errors.New("Missing the recipients of the e-mail")
+)
@@ -0,0 +1,24 @@
+package mail
+
+import "sync"
+
+// FakeMailer keeps the messages sent instead of sending them
+type FakeMailer struct {
+	mutex    sync.Mutex
+	Messages []*Message
+}
+
+func (f *FakeMailer) Send(msg *Message) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(msg.To) == 0 {
+		return ErrNoRecipient
+	}
+	f.Messages = append(f.Messages, msg)
+	return nil
+}
+
+func NewFake() *FakeMailer {
+	return new(FakeMailer)
+}
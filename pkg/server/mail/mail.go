@@ -0,0 +1,145 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	Host     string `envconfig:"host"`
+	Port     int    `envconfig:"port" default:"587"`
+	Username string `envconfig:"username"`
+	Password string `envconfig:"password"`
+	From     string `envconfig:"from"`
+	// TLS connects with implicit TLS (smtps, usually on port 465), the
+	// others upgrade with STARTTLS when the server supports it
+	TLS     bool          `envconfig:"tls" default:"false"`
+	Timeout time.Duration `envconfig:"timeout" default:"30s"`
+}
+
+// Message is a plain text e-mail
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer sends the e-mails of the server, like the ones of the password
+// resets
+type Mailer interface {
+	Send(msg *Message) error
+}
+
+// SMTPMailer sends the e-mails through an SMTP relay
+type SMTPMailer struct {
+	conf *Config
+}
+
+func (m *SMTPMailer) addr() string {
+	return net.JoinHostPort(m.conf.Host, strconv.Itoa(m.conf.Port))
+}
+
+func (m *SMTPMailer) dial() (*smtp.Client, error) {
+	dialer := &net.Dialer{Timeout: m.conf.Timeout}
+	tlsConf := &tls.Config{ServerName: m.conf.Host}
+	var (
+		conn net.Conn
+		err  error
+	)
+	if m.conf.TLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", m.addr(), tlsConf)
+	} else {
+		conn, err = dialer.Dial("tcp", m.addr())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.conf.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(m.conf.Timeout))
+	}
+
+	c, err := smtp.NewClient(conn, m.conf.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if ok, _ := c.Extension("STARTTLS"); ok && !m.conf.TLS {
+		if err := c.StartTLS(tlsConf); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if m.conf.Username != "" {
+		auth := smtp.PlainAuth("", m.conf.Username, m.conf.Password, m.conf.Host)
+		if err := c.Auth(auth); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (m *SMTPMailer) Send(msg *Message) error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipient
+	}
+	c, err := m.dial()
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if err := c.Mail(m.conf.From); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(format(m.conf.From, msg, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// format returns the message in the Internet Message Format (RFC 5322)
+func format(from string, msg *Message, date time.Time) []byte {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(buf, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	lines := strings.Split(strings.Replace(msg.Body, "\r\n", "\n", -1), "\n")
+	buf.WriteString(strings.Join(lines, "\r\n"))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// New returns the Mailer of the configuration, nil if there isn't an SMTP
+// host
+func New(conf *Config) (Mailer, error) {
+	if conf.Host == "" {
+		return nil, nil
+	}
+	if conf.From == "" {
+		return nil, ErrMissingFrom
+	}
+	return &SMTPMailer{conf: conf}, nil
+}
@@ -0,0 +1,125 @@
+package mail
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one message, sent to the channel with the commands
+// received before it
+func fakeSMTPServer(t *testing.T) (*Config, chan []string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("error listening:", err)
+	}
+	received := make(chan []string, 1)
+	go func() {
+		defer l.Close()
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var lines []string
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			lines = append(lines, line)
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "DATA":
+				reply("354 go ahead")
+				for {
+					data, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if data == ".\r\n" {
+						break
+					}
+					lines = append(lines, strings.TrimRight(data, "\r\n"))
+				}
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				received <- lines
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	p, _ := strconv.Atoi(port)
+	conf := &Config{Host: host, Port: p, From: "teresa@luizalabs.com", Timeout: 5 * time.Second}
+	return conf, received
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	conf, received := fakeSMTPServer(t)
+	m, err := New(conf)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	msg := &Message{To: []string{"gopher@luizalabs.com"}, Subject: "Hi", Body: "line 1\nline 2"}
+	if err := m.Send(msg); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	var lines []string
+	select {
+	case lines = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting the message")
+	}
+	text := strings.Join(lines, "\n")
+	for _, expected := range []string{
+		"MAIL FROM:<teresa@luizalabs.com>",
+		"RCPT TO:<gopher@luizalabs.com>",
+		"Subject: Hi",
+		"line 1\nline 2",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected %q in:\n%s", expected, text)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg := &Message{To: []string{"a@luizalabs.com", "b@luizalabs.com"}, Subject: "Hi", Body: "foo\nbar"}
+	expected := "From: teresa@luizalabs.com\r\n" +
+		"To: a@luizalabs.com, b@luizalabs.com\r\n" +
+		"Subject: Hi\r\n" +
+		"Date: Tue, 02 Jan 2018 03:04:05 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		"foo\r\nbar\r\n"
+	if actual := string(format("teresa@luizalabs.com", msg, date)); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if m, err := New(&Config{}); m != nil || err != nil {
+		t.Errorf("expected nil and no error, got %v:%v", m, err)
+	}
+	if _, err := New(&Config{Host: "localhost"}); err != ErrMissingFrom {
+		t.Errorf("expected ErrMissingFrom, got %v", err)
+	}
+}
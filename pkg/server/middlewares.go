@@ -7,6 +7,7 @@ import (
 	log "github.com/Sirupsen/logrus"
 	context "golang.org/x/net/context"
 
+	"github.com/luizalabs/teresa/pkg/server/audit"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
@@ -26,19 +27,62 @@ func (w *serverStreamWrapper) Context() context.Context {
 	return w.ctx
 }
 
+// auditServerStream keeps the first message received, the request of the
+// streaming RPCs
+type auditServerStream struct {
+	grpc.ServerStream
+	req interface{}
+}
+
+func (s *auditServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.req == nil {
+		s.req = m
+	}
+	return err
+}
+
+func auditStreamInterceptor(rec *audit.Recorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		imp, _ := stream.Context().Value("impersonator").(*database.User)
+		if !audit.IsMutating(info.FullMethod) && imp == nil {
+			return handler(srv, stream)
+		}
+
+		wrap := &auditServerStream{ServerStream: stream}
+		err := handler(srv, wrap)
+		u, _ := stream.Context().Value("user").(*database.User)
+		rec.Record(rec.NewEntry(u, imp, info.FullMethod, wrap.req), err)
+		return err
+	}
+}
+
+func auditUnaryInterceptor(rec *audit.Recorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		imp, _ := ctx.Value("impersonator").(*database.User)
+		if !audit.IsMutating(info.FullMethod) && imp == nil {
+			return handler(ctx, req)
+		}
+
+		u, _ := ctx.Value("user").(*database.User)
+		e := rec.NewEntry(u, imp, info.FullMethod, req)
+		resp, err := handler(ctx, req)
+		rec.Record(e, err)
+		return resp, err
+	}
+}
+
 func loginStreamInterceptor(a auth.Auth, uOps user.Operations) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if strings.HasSuffix(info.FullMethod, "Login") {
+		if isPublic(info.FullMethod) {
 			return handler(srv, stream)
 		}
 
-		ctx := stream.Context()
-		user, err := authorize(ctx, a, uOps)
+		ctx, err := userContext(stream.Context(), info.FullMethod, a, uOps)
 		if err != nil {
 			return err
 		}
 
-		ctx = context.WithValue(ctx, "user", user)
 		wrap := &serverStreamWrapper{stream, ctx}
 		return handler(srv, wrap)
 	}
@@ -46,20 +90,49 @@ func loginStreamInterceptor(a auth.Auth, uOps user.Operations) grpc.StreamServer
 
 func loginUnaryInterceptor(a auth.Auth, uOps user.Operations) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		if strings.HasSuffix(info.FullMethod, "Login") {
+		if isPublic(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		user, err := authorize(ctx, a, uOps)
+		ctx, err := userContext(ctx, info.FullMethod, a, uOps)
 		if err != nil {
 			return nil, err
 		}
-
-		ctx = context.WithValue(ctx, "user", user)
 		return handler(ctx, req)
 	}
 }
 
+// userContext returns ctx with the user of the request. Admins act as other
+// users with the "as" metadata, the admin goes as the impersonator.
+func userContext(ctx context.Context, method string, a auth.Auth, uOps user.Operations) (context.Context, error) {
+	u, err := authorize(ctx, a, uOps)
+	if err != nil {
+		return nil, err
+	}
+
+	md, _ := metadata.FromContext(ctx)
+	if len(md["as"]) < 1 || md["as"][0] == "" || md["as"][0] == u.Email {
+		return context.WithValue(ctx, "user", u), nil
+	}
+	// the accounts themselves, like their passwords and sessions, are off
+	// limits
+	if !u.IsAdmin || strings.HasPrefix(method, "/user.User/") {
+		return nil, auth.ErrPermissionDenied
+	}
+	target, err := uOps.GetUser(md["as"][0])
+	if err != nil {
+		return nil, err
+	}
+	if target.Suspended {
+		return nil, user.ErrUserSuspended
+	}
+	// the team two-factor requirements are met by the code of the admin
+	target.TwoFactorVerified = u.TwoFactorVerified
+
+	ctx = context.WithValue(ctx, "user", target)
+	return context.WithValue(ctx, "impersonator", u), nil
+}
+
 func authorize(ctx context.Context, a auth.Auth, uOps user.Operations) (*database.User, error) {
 	md, ok := metadata.FromContext(ctx)
 	if !ok {
@@ -68,11 +141,25 @@ func authorize(ctx context.Context, a auth.Auth, uOps user.Operations) (*databas
 	if len(md["token"]) < 1 || md["token"][0] == "" {
 		return nil, auth.ErrPermissionDenied
 	}
-	email, err := a.ValidateToken(md["token"][0])
+	claims, err := a.ParseToken(md["token"][0])
+	if err != nil {
+		return nil, err
+	}
+	twoFactor := false
+	if claims.Session != "" {
+		if twoFactor, err = uOps.CheckSession(claims.Session, claims.Email); err != nil {
+			return nil, err
+		}
+	}
+	u, err := uOps.GetUser(claims.Email)
 	if err != nil {
 		return nil, err
 	}
-	return uOps.GetUser(email)
+	if u.Suspended {
+		return nil, user.ErrUserSuspended
+	}
+	u.TwoFactorVerified = twoFactor
+	return u, nil
 }
 
 func buildRecFunc(dbg bool) func(p interface{}) error {
@@ -85,16 +172,22 @@ func buildRecFunc(dbg bool) func(p interface{}) error {
 	}
 }
 
-func isLoginOrUserCreate(method string) bool {
-	return strings.HasSuffix(method, "Login") || strings.HasSuffix(method, "User/Create")
+// isPublic tells if the RPC is called without a token, like the logins
+func isPublic(method string) bool {
+	return strings.HasSuffix(method, "Login") ||
+		strings.HasSuffix(method, "User/RequestPasswordReset") ||
+		strings.HasSuffix(method, "User/ResetPassword") ||
+		strings.HasSuffix(method, "User/Refresh")
 }
 
 func logUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	resp, err := handler(ctx, req)
 	if err != nil {
-		logger := log.WithField("route", info.FullMethod)
-		if !isLoginOrUserCreate(info.FullMethod) {
-			logger = logger.WithField("request", req).WithError(err)
+		logger := log.WithField("route", info.FullMethod).WithError(err)
+		// the summary of the audit log leaves out the passwords, codes,
+		// tokens and secrets of the request
+		if s := audit.Summary(info.FullMethod, req); s != "" {
+			logger = logger.WithField("request", s)
 		}
 		if u, ok := ctx.Value("user").(*database.User); ok {
 			logger = logger.WithField("user", u.Email)
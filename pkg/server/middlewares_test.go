@@ -1,10 +1,13 @@
 package server
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +18,10 @@ import (
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
+	log "github.com/Sirupsen/logrus"
+	appb "github.com/luizalabs/teresa/pkg/protobuf/app"
+	userpb "github.com/luizalabs/teresa/pkg/protobuf/user"
+	"github.com/luizalabs/teresa/pkg/server/audit"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
@@ -37,12 +44,37 @@ func TestAuthorize(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on generate token: ", err)
 	}
+	sessionToken, err := authenticator.GenerateSessionToken(validEmail, "1", time.Second)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	revokedSessionToken, err := authenticator.GenerateSessionToken(validEmail, "2", time.Second)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	twoFactorSessionToken, err := authenticator.GenerateSessionToken(validEmail, "3", time.Second)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	suspendedEmail := "suspended@luizalabs.com"
+	tokenForSuspendedUser, err := authenticator.GenerateToken(suspendedEmail, time.Second)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
 
 	uOps := user.NewFakeOperations()
 	uOps.(*user.FakeOperations).Storage[validEmail] = &database.User{
 		Password: "secret",
 		Email:    validEmail,
 	}
+	uOps.(*user.FakeOperations).Storage[suspendedEmail] = &database.User{
+		Password:  "secret",
+		Email:     suspendedEmail,
+		Suspended: true,
+	}
+	uOps.(*user.FakeOperations).Sessions["1"] = validEmail
+	uOps.(*user.FakeOperations).Sessions["3"] = validEmail
+	uOps.(*user.FakeOperations).TwoFactorSessions["3"] = true
 
 	var testCases = []struct {
 		token          string
@@ -67,6 +99,41 @@ func TestAuthorize(t *testing.T) {
 				}
 			},
 		},
+		{
+			sessionToken,
+			func(u *database.User, err error) {
+				if err != nil || u.Email != validEmail {
+					t.Errorf("expected %s, got %v (err: %v)", validEmail, u, err)
+				}
+				if u.TwoFactorVerified {
+					t.Error("expected the two-factor not verified")
+				}
+			},
+		},
+		{
+			twoFactorSessionToken,
+			func(u *database.User, err error) {
+				if err != nil || !u.TwoFactorVerified {
+					t.Errorf("expected the two-factor verified, got %v (err: %v)", u, err)
+				}
+			},
+		},
+		{
+			revokedSessionToken,
+			func(u *database.User, err error) {
+				if err != auth.ErrPermissionDenied {
+					t.Errorf("expected ErrPermissionDenied, got %v", err)
+				}
+			},
+		},
+		{
+			tokenForSuspendedUser,
+			func(u *database.User, err error) {
+				if err != user.ErrUserSuspended {
+					t.Errorf("expected ErrUserSuspended, got %v", err)
+				}
+			},
+		},
 		{
 			tokenForInvalidUser,
 			func(u *database.User, err error) {
@@ -229,3 +296,153 @@ func TestLogUnaryInterceptor(t *testing.T) {
 		}
 	}
 }
+
+func TestLogUnaryInterceptorRedactsSecrets(t *testing.T) {
+	buf := new(bytes.Buffer)
+	log.SetOutput(buf)
+	defer log.SetOutput(os.Stderr)
+
+	var testCases = []struct {
+		method string
+		req    interface{}
+	}{
+		{"/user.User/SetPassword", &userpb.SetPasswordRequest{Password: "s3cr3t", User: "gopher@luizalabs.com"}},
+		{"/user.User/SetTwoFactor", &userpb.SetTwoFactorRequest{Code: "s3cr3t"}},
+		{"/user.User/DisableTwoFactor", &userpb.DisableTwoFactorRequest{Code: "s3cr3t", User: "gopher@luizalabs.com"}},
+		{"/user.User/Login", &userpb.LoginRequest{Email: "gopher@luizalabs.com", Password: "s3cr3t", Otp: "s3cr3t"}},
+		{"/app.App/SetEnv", &appb.SetEnvRequest{
+			Name:    "teresa",
+			EnvVars: []*appb.SetEnvRequest_EnvVar{{Key: "DB_URL", Value: "s3cr3t"}},
+		}},
+	}
+
+	for _, tc := range testCases {
+		buf.Reset()
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("error")
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+
+		logUnaryInterceptor(context.Background(), tc.req, info, handler)
+		if out := buf.String(); strings.Contains(out, "s3cr3t") || !strings.Contains(out, "error") {
+			t.Errorf("expected the error logged without the secrets for %s, got %s", tc.method, out)
+		}
+	}
+}
+
+type fakeAppTeams struct{}
+
+func (*fakeAppTeams) TeamName(appName string) (string, error) {
+	return "luizalabs", nil
+}
+
+func TestAuditUnaryInterceptor(t *testing.T) {
+	aOps := audit.NewFakeOperations()
+	rec := audit.NewRecorder(aOps)
+	rec.SetAppTeams(&fakeAppTeams{})
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, auth.ErrPermissionDenied
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/app.App/Info"}
+	auditUnaryInterceptor(rec)(ctx, &appb.InfoRequest{Name: "teresa"}, info, handler)
+	info = &grpc.UnaryServerInfo{FullMethod: "/app.App/Delete"}
+	if _, err := auditUnaryInterceptor(rec)(ctx, &appb.DeleteRequest{Name: "teresa"}, info, handler); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	entries := aOps.(*audit.FakeOperations).Entries
+	if len(entries) != 1 {
+		t.Fatalf("expected only the delete recorded, got %d entries", len(entries))
+	}
+	e := entries[0]
+	if e.User != "gopher@luizalabs.com" || e.Team != "luizalabs" || e.App != "teresa" || e.Status != "PermissionDenied" {
+		t.Errorf("got unexpected entry %+v", e)
+	}
+}
+
+func TestUserContextImpersonation(t *testing.T) {
+	adminEmail, email := "admin@luizalabs.com", "gopher@luizalabs.com"
+	uOps := user.NewFakeOperations()
+	uOps.(*user.FakeOperations).Storage[adminEmail] = &database.User{Email: adminEmail, IsAdmin: true}
+	uOps.(*user.FakeOperations).Storage[email] = &database.User{Email: email}
+	adminToken, err := authenticator.GenerateToken(adminEmail, time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+	userToken, err := authenticator.GenerateToken(email, time.Minute)
+	if err != nil {
+		t.Fatal("error on generate token: ", err)
+	}
+
+	newCtx := func(token, as string) context.Context {
+		md := metadata.Pairs("token", token, "as", as)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	ctx, err := userContext(newCtx(adminToken, email), "/app.App/Info", authenticator, uOps)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if u := ctx.Value("user").(*database.User); u.Email != email {
+		t.Errorf("expected %s, got %s", email, u.Email)
+	}
+	if imp := ctx.Value("impersonator").(*database.User); imp.Email != adminEmail {
+		t.Errorf("expected %s, got %s", adminEmail, imp.Email)
+	}
+
+	if _, err := userContext(newCtx(userToken, adminEmail), "/app.App/Info", authenticator, uOps); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for a non admin, got %v", err)
+	}
+	if _, err := userContext(newCtx(adminToken, email), "/user.User/SetPassword", authenticator, uOps); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for the user service, got %v", err)
+	}
+	if _, err := userContext(newCtx(adminToken, "invalid@luizalabs.com"), "/app.App/Info", authenticator, uOps); err != user.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAuditUnaryInterceptorImpersonation(t *testing.T) {
+	aOps := audit.NewFakeOperations()
+	rec := audit.NewRecorder(aOps)
+	rec.SetAppTeams(&fakeAppTeams{})
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher@luizalabs.com"})
+	ctx = context.WithValue(ctx, "impersonator", &database.User{Email: "admin@luizalabs.com"})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/app.App/Info"}
+	if _, err := auditUnaryInterceptor(rec)(ctx, &appb.InfoRequest{Name: "teresa"}, info, handler); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	entries := aOps.(*audit.FakeOperations).Entries
+	if len(entries) != 1 {
+		t.Fatalf("expected the read only RPC recorded, got %d entries", len(entries))
+	}
+	if e := entries[0]; e.User != "gopher@luizalabs.com" || e.Impersonator != "admin@luizalabs.com" {
+		t.Errorf("got unexpected entry %+v", e)
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	var testCases = []struct {
+		method   string
+		expected bool
+	}{
+		{"/user.User/Login", true},
+		{"/sso.SSO/BeginLogin", true},
+		{"/user.User/RequestPasswordReset", true},
+		{"/user.User/ResetPassword", true},
+		{"/user.User/Refresh", true},
+		{"/user.User/SetPassword", false},
+		{"/app.App/Create", false},
+	}
+	for _, tc := range testCases {
+		if actual := isPublic(tc.method); actual != tc.expected {
+			t.Errorf("expected %v for %s, got %v", tc.expected, tc.method, actual)
+		}
+	}
+}
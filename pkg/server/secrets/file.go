@@ -3,7 +3,9 @@ package secrets
 import (
 	"crypto/rsa"
 	"crypto/tls"
+	"encoding/base64"
 	"io/ioutil"
+	"strings"
 
 	jwt "github.com/dgrijalva/jwt-go"
 )
@@ -11,8 +13,17 @@ import (
 type FileSystemSecretsConfig struct {
 	PrivateKey string `split_words:"true" default:"teresa.rsa"`
 	PublicKey  string `split_words:"true" default:"teresa.rsa.pub"`
-	TLSCert    string `envconfig:"tls_cert" default:"server.cert"`
-	TLSKey     string `envconfig:"tls_key" default:"server.key"`
+	// VerificationKeys are files of public keys accepted on top of the
+	// public key, to rotate the keypair
+	VerificationKeys []string `envconfig:"verification_keys"`
+	TLSCert          string   `envconfig:"tls_cert" default:"server.cert"`
+	TLSKey           string   `envconfig:"tls_key" default:"server.key"`
+	// EncryptionKey is a file with the base64 of the 32 bytes key of the
+	// values encrypted in the database
+	EncryptionKey string `envconfig:"encryption_key" default:"teresa.key"`
+	// DecryptionKeys are files of the previous encryption keys, the values
+	// encrypted with them are still decrypted, to rotate the key
+	DecryptionKeys []string `envconfig:"decryption_keys"`
 }
 
 type FileSystemSecrets struct {
@@ -23,6 +34,9 @@ type FileSystemSecrets struct {
 	tlsKeyPath     string
 	privateKeyPath string
 	publicKeypath  string
+	verKeyPaths    []string
+	encKeyPath     string
+	decKeyPaths    []string
 }
 
 func (f *FileSystemSecrets) PrivateKey() (*rsa.PrivateKey, error) {
@@ -49,6 +63,23 @@ func (f *FileSystemSecrets) PublicKey() (*rsa.PublicKey, error) {
 	return f.publicKey, err
 }
 
+// VerificationKeys returns the public keys accepted on top of the public key
+func (f *FileSystemSecrets) VerificationKeys() ([]*rsa.PublicKey, error) {
+	keys := make([]*rsa.PublicKey, 0, len(f.verKeyPaths))
+	for _, path := range f.verKeyPaths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(b)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func (f *FileSystemSecrets) TLSCertificate() (*tls.Certificate, error) {
 	if f.tlsCert != nil {
 		return f.tlsCert, nil
@@ -61,12 +92,41 @@ func (f *FileSystemSecrets) TLSCertificate() (*tls.Certificate, error) {
 	return f.tlsCert, nil
 }
 
+func readKey(path string) ([]byte, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.TrimSpace(string(b)))
+}
+
+// EncryptionKey returns the key of the values encrypted in the database
+func (f *FileSystemSecrets) EncryptionKey() ([]byte, error) {
+	return readKey(f.encKeyPath)
+}
+
+// DecryptionKeys returns the previous encryption keys
+func (f *FileSystemSecrets) DecryptionKeys() ([][]byte, error) {
+	keys := make([][]byte, 0, len(f.decKeyPaths))
+	for _, path := range f.decKeyPaths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
 func NewFileSystemSecrets(conf *FileSystemSecretsConfig) (Secrets, error) {
 	s := &FileSystemSecrets{
 		privateKeyPath: conf.PrivateKey,
 		publicKeypath:  conf.PublicKey,
+		verKeyPaths:    conf.VerificationKeys,
 		tlsCertPath:    conf.TLSCert,
 		tlsKeyPath:     conf.TLSKey,
+		encKeyPath:     conf.EncryptionKey,
+		decKeyPaths:    conf.DecryptionKeys,
 	}
 	return s, nil
 }
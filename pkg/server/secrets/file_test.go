@@ -7,10 +7,13 @@ import (
 
 func TestFileSystemSecrets(t *testing.T) {
 	f, err := NewFileSystemSecrets(&FileSystemSecretsConfig{
-		PrivateKey: filepath.Join("testdata", "fake.rsa"),
-		PublicKey:  filepath.Join("testdata", "fake.rsa.pub"),
-		TLSCert:    filepath.Join("testdata", "tls.crt"),
-		TLSKey:     filepath.Join("testdata", "tls.key"),
+		PrivateKey:       filepath.Join("testdata", "fake.rsa"),
+		PublicKey:        filepath.Join("testdata", "fake.rsa.pub"),
+		VerificationKeys: []string{filepath.Join("testdata", "fake.rsa.pub")},
+		TLSCert:          filepath.Join("testdata", "tls.crt"),
+		TLSKey:           filepath.Join("testdata", "tls.key"),
+		EncryptionKey:    filepath.Join("testdata", "fake.key"),
+		DecryptionKeys:   []string{filepath.Join("testdata", "fake.key")},
 	})
 	if err != nil {
 		t.Fatal("error on create file system secret: ", err)
@@ -21,7 +24,16 @@ func TestFileSystemSecrets(t *testing.T) {
 	if pub, err := f.PublicKey(); err != nil || pub == nil {
 		t.Errorf("invalid public key generation, key %v, error %v", pub, err)
 	}
+	if keys, err := f.VerificationKeys(); err != nil || len(keys) != 1 {
+		t.Errorf("invalid verification keys, keys %v, error %v", keys, err)
+	}
 	if cert, err := f.TLSCertificate(); err != nil || cert == nil {
 		t.Errorf("invalid TLS cert generation, cert %v, error %v", cert, err)
 	}
+	if key, err := f.EncryptionKey(); err != nil || len(key) != 32 {
+		t.Errorf("invalid encryption key, key %v, error %v", key, err)
+	}
+	if keys, err := f.DecryptionKeys(); err != nil || len(keys) != 1 || len(keys[0]) != 32 {
+		t.Errorf("invalid decryption keys, keys %v, error %v", keys, err)
+	}
 }
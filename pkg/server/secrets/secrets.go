@@ -8,5 +8,8 @@ import (
 type Secrets interface {
 	PrivateKey() (*rsa.PrivateKey, error)
 	PublicKey() (*rsa.PublicKey, error)
+	VerificationKeys() ([]*rsa.PublicKey, error)
 	TLSCertificate() (*tls.Certificate, error)
+	EncryptionKey() ([]byte, error)
+	DecryptionKeys() ([][]byte, error)
 }
@@ -15,16 +15,27 @@ import (
 	"github.com/grpc-ecosystem/go-grpc-middleware/recovery"
 	"github.com/jinzhu/gorm"
 	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/audit"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/build"
 	"github.com/luizalabs/teresa/pkg/server/cloudprovider"
+	"github.com/luizalabs/teresa/pkg/server/cluster"
 	"github.com/luizalabs/teresa/pkg/server/deploy"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+	"github.com/luizalabs/teresa/pkg/server/envgroup"
 	"github.com/luizalabs/teresa/pkg/server/exec"
 	"github.com/luizalabs/teresa/pkg/server/healthcheck"
 	"github.com/luizalabs/teresa/pkg/server/k8s"
+	"github.com/luizalabs/teresa/pkg/server/ldap"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+	"github.com/luizalabs/teresa/pkg/server/logstore"
+	"github.com/luizalabs/teresa/pkg/server/mail"
 	"github.com/luizalabs/teresa/pkg/server/service"
+	"github.com/luizalabs/teresa/pkg/server/sso"
 	st "github.com/luizalabs/teresa/pkg/server/storage"
 	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/upload"
 	"github.com/luizalabs/teresa/pkg/server/user"
 	"github.com/soheilhy/cmux"
 
@@ -39,14 +50,25 @@ type Options struct {
 	DB        *gorm.DB
 	Storage   st.Storage
 	K8s       *k8s.Client
+	LogStore  logstore.Store
 	DeployOpt *deploy.Options
-	Debug     bool
+	SSO       *sso.Config
+	LDAP      *ldap.Config
+	Cipher    encryption.Cipher
+	Mailer    mail.Mailer
+	// PasswordPolicy has no rules when nil
+	PasswordPolicy *user.PasswordPolicy
+	Debug          bool
 }
 
 type Server struct {
 	listener   net.Listener
 	grpcServer *grpc.Server
 	hcServer   *healthcheck.Server
+	appOps     app.Operations
+	deployOps  deploy.Operations
+	uploadOps  upload.Operations
+	leases     lease.Leases
 	opt        *Options
 }
 
@@ -65,6 +87,13 @@ func (s *Server) Run() error {
 	g.Go(func() error { return s.hcServer.Run(httpListener) })
 	g.Go(func() error { return m.Serve() })
 
+	stopScheduler := make(chan struct{})
+	defer close(stopScheduler)
+	go s.appOps.RunAutoscaleScheduler(s.leases, stopScheduler)
+	go s.appOps.SyncPDBs(s.leases)
+	go s.deployOps.RunBlueGreenCleanup(s.leases, stopScheduler)
+	go s.uploadOps.RunCleanup(s.leases, stopScheduler)
+
 	exitChan := make(chan os.Signal, 1)
 	signal.Notify(exitChan, syscall.SIGINT, syscall.SIGTERM)
 	defer close(exitChan)
@@ -82,18 +111,20 @@ func (s *Server) Run() error {
 	}
 }
 
-func createServerOps(opt Options, uOps user.Operations) []grpc.ServerOption {
+func createServerOps(opt Options, uOps user.Operations, rec *audit.Recorder) []grpc.ServerOption {
 	recOpts := []grpc_recovery.Option{
 		grpc_recovery.WithRecoveryHandler(buildRecFunc(opt.Debug)),
 	}
 	sOpts := []grpc.ServerOption{
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			loginUnaryInterceptor(opt.Auth, uOps),
+			auditUnaryInterceptor(rec),
 			logUnaryInterceptor,
 			grpc_recovery.UnaryServerInterceptor(recOpts...),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
 			loginStreamInterceptor(opt.Auth, uOps),
+			auditStreamInterceptor(rec),
 			logStreamInterceptor,
 			grpc_recovery.StreamServerInterceptor(recOpts...),
 		)),
@@ -105,21 +136,60 @@ func createServerOps(opt Options, uOps user.Operations) []grpc.ServerOption {
 	return sOpts
 }
 
-func registerServices(s *grpc.Server, opt Options, uOps user.Operations) error {
-	us := user.NewService(uOps)
-	us.RegisterService(s)
-
+func registerServices(s *grpc.Server, opt Options, uOps user.Operations, aOps audit.Operations, rec *audit.Recorder) (app.Operations, deploy.Operations, upload.Operations, error) {
 	tOps := team.NewDatabaseOperations(opt.DB, uOps)
 	t := team.NewService(tOps)
 	t.RegisterService(s)
 
+	// only the logins of the users go to the LDAP server, not the ones of
+	// the service accounts of the team tokens
+	loginOps, err := ldap.New(opt.LDAP, uOps, tOps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if loginOps != uOps {
+		log.Infoln("LDAP authentication with", opt.LDAP.URL)
+	}
+	us := user.NewService(loginOps)
+	us.RegisterService(s)
+
+	ssoOps, err := sso.New(opt.SSO, uOps, tOps)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if ssoOps != nil {
+		log.Infoln("single sign-on with", opt.SSO.Issuer)
+	}
+	ss := sso.NewService(ssoOps)
+	ss.RegisterService(s)
+
+	// the operations on the apps go to their clusters
+	cOps := cluster.NewDatabaseOperations(opt.DB)
+	opt.K8s.SetClusters(cOps)
+
 	appOps := app.NewOperations(tOps, opt.K8s, opt.Storage)
+	appOps.SetEnvHistory(app.NewDatabaseEnvHistory(opt.DB))
+	appLocks := app.NewDatabaseAppLocks(opt.DB)
+	appOps.SetAppLocks(appLocks)
+	appOps.SetClusters(cOps)
+	if opt.LogStore != nil {
+		appOps.SetLogStore(opt.LogStore)
+	}
 	a := app.NewService(appOps)
 	a.RegisterService(s)
 
 	// use appOps as teamExt to avoid circular import
 	tOps.SetTeamExt(appOps)
 
+	rec.SetAppTeams(appOps)
+	au := audit.NewService(aOps, tOps)
+	au.RegisterService(s)
+
+	egOps := envgroup.NewDatabaseOperations(opt.DB, tOps, appOps)
+	appOps.SetEnvGroups(egOps)
+	eg := envgroup.NewService(egOps)
+	eg.RegisterService(s)
+
 	execDefaults := &exec.Defaults{
 		RunnerImage:  opt.DeployOpt.SlugRunnerImage,
 		StoreImage:   opt.DeployOpt.SlugStoreImage,
@@ -127,6 +197,7 @@ func registerServices(s *grpc.Server, opt Options, uOps user.Operations) error {
 		LimitsMemory: opt.DeployOpt.BuildLimitMemory,
 	}
 	execOps := exec.NewOperations(appOps, opt.K8s, opt.Storage, execDefaults)
+	execOps.SetTeamEnv(tOps)
 	e := exec.NewService(execOps, opt.DeployOpt.KeepAliveTimeout)
 	e.RegisterService(s)
 
@@ -136,13 +207,29 @@ func registerServices(s *grpc.Server, opt Options, uOps user.Operations) error {
 		SlugStoreImage:   opt.DeployOpt.SlugStoreImage,
 		BuildLimitCPU:    opt.DeployOpt.BuildLimitCPU,
 		BuildLimitMemory: opt.DeployOpt.BuildLimitMemory,
+		KanikoImage:      opt.DeployOpt.KanikoImage,
+		CNBBuilderImage:  opt.DeployOpt.CNBBuilderImage,
+		Registry:         opt.DeployOpt.BuildRegistry,
+		RegistryConfig:   opt.DeployOpt.BuildRegistryConfig,
 	}
+	upOps := upload.NewOperations(appOps, opt.Storage)
+	up := upload.NewService(upOps)
+	up.RegisterService(s)
+
 	bOps := build.NewBuildOperations(opt.Storage, appOps, execOps, opt.K8s, buildOpts)
 	b := build.NewService(bOps, opt.DeployOpt.KeepAliveTimeout)
+	b.SetUploads(upOps)
 	b.RegisterService(s)
 
 	dOps := deploy.NewDeployOperations(appOps, opt.K8s, opt.Storage, execOps, bOps, opt.DeployOpt)
+	dOps.SetApprovals(tOps)
+	dOps.SetPendingDeploys(deploy.NewDatabasePendingDeploys(opt.DB))
+	dOps.SetFreezes(tOps)
+	dOps.SetAppLocks(appLocks)
+	dOps.SetTeamEnv(tOps)
+	dOps.SetDeployLocks(deploy.NewDatabaseDeployLocks(opt.DB, opt.DeployOpt.LockTTL))
 	d := deploy.NewService(dOps, opt.DeployOpt)
+	d.SetUploads(upOps)
 	d.RegisterService(s)
 
 	cpOps := cloudprovider.NewOperations(opt.K8s)
@@ -151,7 +238,7 @@ func registerServices(s *grpc.Server, opt Options, uOps user.Operations) error {
 	svcOps := service.NewOperations(appOps, cpOps, opt.K8s)
 	svc := service.NewService(svcOps)
 	svc.RegisterService(s)
-	return nil
+	return appOps, dOps, upOps, nil
 }
 
 func New(opt Options) (*Server, error) {
@@ -160,13 +247,33 @@ func New(opt Options) (*Server, error) {
 		return nil, err
 	}
 
+	database.SetCipher(opt.Cipher)
 	uOps := user.NewDatabaseOperations(opt.DB, opt.Auth)
-	sOpts := createServerOps(opt, uOps)
+	if opt.Cipher != nil {
+		uOps.SetCipher(opt.Cipher)
+	}
+	if opt.Mailer != nil {
+		uOps.SetMailer(opt.Mailer)
+	}
+	if opt.PasswordPolicy != nil {
+		uOps.SetPasswordPolicy(opt.PasswordPolicy)
+	}
+	aOps := audit.NewDatabaseOperations(opt.DB)
+	rec := audit.NewRecorder(aOps)
+	sOpts := createServerOps(opt, uOps, rec)
 	s := grpc.NewServer(sOpts...)
-	if err := registerServices(s, opt, uOps); err != nil {
+	appOps, dOps, upOps, err := registerServices(s, opt, uOps, aOps, rec)
+	if err != nil {
+		return nil, err
+	}
+
+	// the background tasks run on one replica, the pod name is the hostname
+	holder, err := os.Hostname()
+	if err != nil {
 		return nil, err
 	}
+	leases := lease.NewDatabaseLeases(opt.DB, holder)
 
 	hcServer := healthcheck.New(opt.K8s, opt.DB)
-	return &Server{listener: l, grpcServer: s, hcServer: hcServer, opt: &opt}, nil
+	return &Server{listener: l, grpcServer: s, hcServer: hcServer, appOps: appOps, deployOps: dOps, uploadOps: upOps, leases: leases, opt: &opt}, nil
 }
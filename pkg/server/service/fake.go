@@ -61,6 +61,10 @@ func (f *FakeAppOperations) HasPermission(user *database.User, appName string) b
 	return !f.NegateHasPermission
 }
 
+func (f *FakeAppOperations) HasRole(user *database.User, appName, role string) bool {
+	return !f.NegateHasPermission
+}
+
 func (f *FakeAppOperations) CheckPermAndGet(user *database.User, appName string) (*app.App, error) {
 	err := f.CheckPermAndGetErr
 	if f.NegateHasPermission {
@@ -5,6 +5,7 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/spec"
+	"github.com/luizalabs/teresa/pkg/server/team"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 )
 
@@ -29,6 +30,7 @@ type K8sOperations interface {
 
 type AppOperations interface {
 	HasPermission(user *database.User, appName string) bool
+	HasRole(user *database.User, appName, role string) bool
 	CheckPermAndGet(user *database.User, appName string) (*app.App, error)
 }
 
@@ -69,7 +71,7 @@ func (ops *ServiceOperations) EnableSSL(user *database.User, appName, cert strin
 }
 
 func (ops *ServiceOperations) Info(user *database.User, appName string) (*Info, error) {
-	if !ops.aops.HasPermission(user, appName) {
+	if !ops.aops.HasRole(user, appName, team.RoleViewer) {
 		return nil, auth.ErrPermissionDenied
 	}
 	ssl, err := ops.cops.SSLInfo(appName)
@@ -0,0 +1,66 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/pkg/errors"
+)
+
+// maxAppInitContainerNameLength leaves room for the prefix of the name of
+// the shared volume
+const maxAppInitContainerNameLength = 58
+
+// AppInitContainer is a container of teresa.yaml run to completion before
+// the app starts, like a schema check or an asset download. The files
+// written to SharedPath are seen by the app at the same path
+type AppInitContainer struct {
+	Name       string              `yaml:"name"`
+	Image      string              `yaml:"image"`
+	Command    []string            `yaml:"command,omitempty"`
+	Args       []string            `yaml:"args,omitempty"`
+	Env        map[string]string   `yaml:"env,omitempty"`
+	Resources  *ContainerResources `yaml:"resources,omitempty"`
+	SharedPath string              `yaml:"sharedPath,omitempty"`
+}
+
+// ValidateAppInitContainers checks the init containers of teresa.yaml, names
+// must be unique and not clash with the ones built by Teresa
+func ValidateAppInitContainers(ics []*AppInitContainer) error {
+	names := map[string]bool{"slugstore": true}
+	for _, ic := range ics {
+		if len(ic.Name) > maxAppInitContainerNameLength || !sideCarNameRegexp.MatchString(ic.Name) || names[ic.Name] {
+			return errors.Errorf("invalid init container name %s", ic.Name)
+		}
+		if ic.Image == "" {
+			return errors.Errorf("image of init container %s not found", ic.Name)
+		}
+		if ic.SharedPath != "" && ic.SharedPath[0] != '/' {
+			return errors.Errorf("shared path of init container %s must be absolute", ic.Name)
+		}
+		names[ic.Name] = true
+	}
+	return nil
+}
+
+// NewAppInitContainer returns the container of ic with the env vars and
+// secrets of the app, the env vars of ic take precedence
+func NewAppInitContainer(ic *AppInitContainer, a *app.App) *Container {
+	env := map[string]string{}
+	for _, e := range app.PodEnvVars(a) {
+		env[e.Key] = e.Value
+	}
+	return NewContainerBuilder(ic.Name, ic.Image).
+		WithCommand(ic.Command).
+		WithArgs(ic.Args).
+		WithEnv(env).
+		WithEnv(ic.Env).
+		WithSecrets(a.Secrets).
+		WithEnvFromSecrets(app.EnvGroupsSecretNames(a)).
+		WithResources(ic.Resources).
+		Build()
+}
+
+func appInitVolumeName(name string) string {
+	return fmt.Sprintf("init-%s", name)
+}
@@ -0,0 +1,59 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+)
+
+func TestValidateAppInitContainers(t *testing.T) {
+	var testCases = []struct {
+		ics     []*AppInitContainer
+		isValid bool
+	}{
+		{nil, true},
+		{[]*AppInitContainer{{Name: "migrate", Image: "app"}, {Name: "assets", Image: "curl", SharedPath: "/assets"}}, true},
+		{[]*AppInitContainer{{Name: "Migrate", Image: "app"}}, false},
+		{[]*AppInitContainer{{Name: strings.Repeat("a", 59), Image: "app"}}, false},
+		{[]*AppInitContainer{{Name: "slugstore", Image: "app"}}, false},
+		{[]*AppInitContainer{{Name: "migrate", Image: "app"}, {Name: "migrate", Image: "app"}}, false},
+		{[]*AppInitContainer{{Name: "migrate"}}, false},
+		{[]*AppInitContainer{{Name: "assets", Image: "curl", SharedPath: "assets"}}, false},
+	}
+
+	for _, tc := range testCases {
+		if err := ValidateAppInitContainers(tc.ics); (err == nil) != tc.isValid {
+			t.Errorf("expected valid %v, got %v for %v", tc.isValid, err, tc.ics)
+		}
+	}
+}
+
+func TestNewAppInitContainer(t *testing.T) {
+	a := &app.App{
+		Name:    "test",
+		EnvVars: []*app.EnvVar{{Key: "DB", Value: "app"}, {Key: "KEY", Value: "app"}},
+		Secrets: []string{"SECRET"},
+	}
+	ic := &AppInitContainer{
+		Name:      "migrate",
+		Image:     "app",
+		Args:      []string{"check"},
+		Env:       map[string]string{"KEY": "init"},
+		Resources: &ContainerResources{CPU: "100m"},
+	}
+	c := NewAppInitContainer(ic, a)
+
+	if c.Name != "migrate" || c.Image != "app" {
+		t.Errorf("got %s (%s); want migrate (app)", c.Name, c.Image)
+	}
+	if c.Env["DB"] != "app" || c.Env["KEY"] != "init" {
+		t.Errorf("got unexpected env %v", c.Env)
+	}
+	if len(c.Secrets) != 1 || c.Secrets[0] != "SECRET" {
+		t.Errorf("got %v; want [SECRET]", c.Secrets)
+	}
+	if c.ContainerLimits == nil || c.ContainerLimits.CPU != "100m" {
+		t.Errorf("got %v; want cpu limit 100m", c.ContainerLimits)
+	}
+}
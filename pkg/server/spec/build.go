@@ -1,6 +1,9 @@
 package spec
 
 import (
+	"fmt"
+	"path"
+
 	"github.com/luizalabs/teresa/pkg/server/app"
 	"github.com/luizalabs/teresa/pkg/server/storage"
 )
@@ -8,6 +11,16 @@ import (
 const (
 	vlName = "storage-keys"
 	vlPath = "/var/run/secrets/deis/objectstore/creds"
+	// DockerfileName is the Dockerfile of the tarball built as an image
+	DockerfileName = "Dockerfile"
+	cnbWorkspace   = "/workspace"
+	cnbCreatorCmd  = `tar xzf %s -C %s && exec /cnb/lifecycle/creator -app=%s -cache-image="$CACHE_IMAGE" "$IMAGE"`
+
+	// RegistrySecretName holds the config.json of docker with the push
+	// credentials of the build registry, mounted on the image builds
+	RegistrySecretName = "teresa-build-registry"
+	registryVolumeName = "build-registry"
+	registryConfigPath = "/var/run/secrets/teresa/registry"
 )
 
 type BuildPodBuilder struct {
@@ -18,6 +31,18 @@ type BuildPodBuilder struct {
 	app      *app.App
 	fs       storage.Storage
 	cl       *ContainerLimits
+	// imageDest is the image built from the Dockerfile of the tarball,
+	// downloaded by an init container of initImage
+	imageDest string
+	initImage string
+	// cacheImage holds the layers of Cloud Native Buildpacks builds
+	cacheImage string
+	// cachePath is the cache of the buildpacks kept in the storage between
+	// slug builds
+	cachePath string
+	// registrySecret is the secret with the credentials of the registry of
+	// imageDest, if any
+	registrySecret string
 }
 
 func (b *BuildPodBuilder) newAppBuildContainer() *Container {
@@ -26,7 +51,14 @@ func (b *BuildPodBuilder) newAppBuildContainer() *Container {
 		"PUT_PATH":        b.slugDest,
 		"BUILDER_STORAGE": b.fs.Type(),
 	}
-	for _, ev := range b.app.EnvVars {
+	if b.cachePath != "" {
+		env["CACHE_PATH"] = b.cachePath
+	}
+	for _, ev := range app.PodEnvVars(b.app) {
+		env[ev.Key] = ev.Value
+	}
+	// the build env vars win over the ones of the app
+	for _, ev := range b.app.BuildEnvVars {
 		env[ev.Key] = ev.Value
 	}
 	return NewContainerBuilder(b.name, b.image).
@@ -43,6 +75,55 @@ func (b *BuildPodBuilder) newAppBuildPod(appContainer *Container) *Pod {
 		Build()
 }
 
+// newImageBuildContainer runs kaniko on the tarball downloaded by the init
+// container, the env vars of the app aren't available to the build
+func (b *BuildPodBuilder) newImageBuildContainer() *Container {
+	args := []string{
+		"--dockerfile=" + DockerfileName,
+		"--context=tar://" + path.Join(slugVolumeMountPath, "slug.tgz"),
+		"--destination=" + b.imageDest,
+	}
+	return NewContainerBuilder(b.name, b.image).
+		WithArgs(args).
+		WithLimits(b.cl.CPU, b.cl.Memory).
+		Build()
+}
+
+// newCNBBuildContainer runs the lifecycle of the Cloud Native Buildpacks
+// builder image on the extracted tarball, as kaniko the env vars of the app
+// aren't available to the build
+func (b *BuildPodBuilder) newCNBBuildContainer() *Container {
+	tarBall := path.Join(slugVolumeMountPath, "slug.tgz")
+	cmd := fmt.Sprintf(cnbCreatorCmd, tarBall, cnbWorkspace, cnbWorkspace)
+	env := map[string]string{
+		"IMAGE":       b.imageDest,
+		"CACHE_IMAGE": b.cacheImage,
+	}
+	return NewContainerBuilder(b.name, b.image).
+		WithCommand([]string{"/bin/sh", "-c", cmd}).
+		WithEnv(env).
+		WithLimits(b.cl.CPU, b.cl.Memory).
+		Build()
+}
+
+func (b *BuildPodBuilder) newImageBuildPod(buildContainer *Container) *Pod {
+	var opts []func(*PodBuilder)
+	if b.registrySecret != "" {
+		// kaniko and the CNB lifecycle both read the docker config
+		buildContainer.Env["DOCKER_CONFIG"] = registryConfigPath
+		opts = append(opts, MountSecretInAppContainer(registryVolumeName, registryConfigPath, b.registrySecret))
+	}
+	init := NewInitContainer(b.initImage, b.tarPath, b.fs)
+	return NewPodBuilder(b.name, b.app.Name).
+		WithAppContainer(buildContainer, opts...).
+		WithInitContainer(
+			init,
+			MountSecretInInitContainer(vlName, vlPath, b.fs.K8sSecretName()),
+			ShareVolumeBetweenAppAndInitContainer(slugVolumeName, slugVolumeMountPath),
+		).
+		Build()
+}
+
 func (b *BuildPodBuilder) ForApp(a *app.App) *BuildPodBuilder {
 	b.app = a
 	return b
@@ -71,7 +152,43 @@ func (b *BuildPodBuilder) WithStorage(fs storage.Storage) *BuildPodBuilder {
 	return b
 }
 
+// WithCachePath restores the buildpacks cache from path before the slug
+// build, saving it back there afterwards
+func (b *BuildPodBuilder) WithCachePath(path string) *BuildPodBuilder {
+	b.cachePath = path
+	return b
+}
+
+// BuildImageTo builds the Dockerfile of the tarball instead of a slug,
+// pushing the image to dest. The builder image must be the one of kaniko
+func (b *BuildPodBuilder) BuildImageTo(dest, initImage string) *BuildPodBuilder {
+	b.imageDest = dest
+	b.initImage = initImage
+	return b
+}
+
+// WithRegistrySecret mounts the secret with the docker config.json holding
+// the credentials to push the image of BuildImageTo
+func (b *BuildPodBuilder) WithRegistrySecret(name string) *BuildPodBuilder {
+	b.registrySecret = name
+	return b
+}
+
+// WithBuildpacks builds the image with Cloud Native Buildpacks instead of
+// the Dockerfile, caching the layers in cacheImage. The builder image must
+// be a CNB one (e.g. paketo)
+func (b *BuildPodBuilder) WithBuildpacks(cacheImage string) *BuildPodBuilder {
+	b.cacheImage = cacheImage
+	return b
+}
+
 func (b *BuildPodBuilder) Build() *Pod {
+	if b.imageDest != "" && b.cacheImage != "" {
+		return b.newImageBuildPod(b.newCNBBuildContainer())
+	}
+	if b.imageDest != "" {
+		return b.newImageBuildPod(b.newImageBuildContainer())
+	}
 	return b.newAppBuildPod(b.newAppBuildContainer())
 }
 
@@ -1,6 +1,8 @@
 package spec
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -62,3 +64,120 @@ func TestBuildPodBuider(t *testing.T) {
 		t.Errorf("expected %s, got %s", "storage-keys", actual)
 	}
 }
+
+func TestBuildPodBuilderBuildEnvVars(t *testing.T) {
+	a := &app.App{
+		Name:         "test",
+		EnvVars:      []*app.EnvVar{{Key: "k", Value: "v"}, {Key: "t", Value: "runtime"}},
+		BuildEnvVars: []*app.EnvVar{{Key: "t", Value: "build"}},
+	}
+
+	ps := NewBuildPodBuilder("builder", "builder/image").
+		ForApp(a).
+		WithLimits("800m", "1Gi").
+		WithStorage(storage.NewFake()).
+		Build()
+
+	env := ps.Containers[0].Env
+	if env["k"] != "v" || env["t"] != "build" {
+		t.Errorf("expected the build env vars over the app ones, got %v", env)
+	}
+}
+
+func TestBuildPodBuilderWithCachePath(t *testing.T) {
+	ps := NewBuildPodBuilder("builder", "builder/image").
+		ForApp(&app.App{Name: "test"}).
+		WithLimits("800m", "1Gi").
+		WithStorage(storage.NewFake()).
+		WithCachePath("cache/test/cache.tgz").
+		Build()
+
+	if actual := ps.Containers[0].Env["CACHE_PATH"]; actual != "cache/test/cache.tgz" {
+		t.Errorf("expected cache/test/cache.tgz, got %s", actual)
+	}
+}
+
+func TestBuildPodBuilderBuildImageTo(t *testing.T) {
+	a := &app.App{Name: "test", EnvVars: []*app.EnvVar{{Key: "k", Value: "v"}}}
+
+	ps := NewBuildPodBuilder("builder", "kaniko/image").
+		ForApp(a).
+		WithTarBallPath("deploys/test/1/in/app.tgz").
+		WithLimits("800m", "1Gi").
+		WithStorage(storage.NewFake()).
+		BuildImageTo("registry.example.com/test:1", "store/image").
+		Build()
+
+	c := ps.Containers[0]
+	if c.Image != "kaniko/image" {
+		t.Errorf("expected kaniko/image, got %s", c.Image)
+	}
+	expectedArgs := []string{
+		"--dockerfile=Dockerfile",
+		"--context=tar:///slug/slug.tgz",
+		"--destination=registry.example.com/test:1",
+	}
+	if !reflect.DeepEqual(c.Args, expectedArgs) {
+		t.Errorf("expected %v, got %v", expectedArgs, c.Args)
+	}
+	if _, found := c.Env["k"]; found {
+		t.Errorf("expected no app env vars, got %v", c.Env)
+	}
+	if len(ps.InitContainers) != 1 {
+		t.Fatalf("expected 1 init container, got %d", len(ps.InitContainers))
+	}
+	ic := ps.InitContainers[0]
+	if ic.Image != "store/image" || ic.Env["SLUG_URL"] != "deploys/test/1/in/app.tgz" {
+		t.Errorf("expected the tarball downloaded by store/image, got %s %v", ic.Image, ic.Env)
+	}
+}
+
+func TestBuildPodBuilderWithRegistrySecret(t *testing.T) {
+	ps := NewBuildPodBuilder("builder", "kaniko/image").
+		ForApp(&app.App{Name: "test"}).
+		WithTarBallPath("deploys/test/1/in/app.tgz").
+		WithLimits("800m", "1Gi").
+		WithStorage(storage.NewFake()).
+		BuildImageTo("registry.example.com/test:1", "store/image").
+		WithRegistrySecret(RegistrySecretName).
+		Build()
+
+	c := ps.Containers[0]
+	if c.Env["DOCKER_CONFIG"] != registryConfigPath {
+		t.Errorf("expected DOCKER_CONFIG %s, got %v", registryConfigPath, c.Env)
+	}
+	mounted := false
+	for _, vm := range c.VolumeMounts {
+		mounted = mounted || (vm.Name == registryVolumeName && vm.MountPath == registryConfigPath)
+	}
+	if !mounted {
+		t.Errorf("expected the registry secret mounted, got %v", c.VolumeMounts)
+	}
+}
+
+func TestBuildPodBuilderWithBuildpacks(t *testing.T) {
+	a := &app.App{Name: "test"}
+
+	ps := NewBuildPodBuilder("builder", "paketo/builder").
+		ForApp(a).
+		WithTarBallPath("deploys/test/1/in/app.tgz").
+		WithLimits("800m", "1Gi").
+		WithStorage(storage.NewFake()).
+		BuildImageTo("registry.example.com/test:1", "store/image").
+		WithBuildpacks("registry.example.com/test:cache").
+		Build()
+
+	c := ps.Containers[0]
+	if c.Image != "paketo/builder" {
+		t.Errorf("expected paketo/builder, got %s", c.Image)
+	}
+	if len(c.Command) != 3 || !strings.Contains(c.Command[2], "/cnb/lifecycle/creator") {
+		t.Errorf("expected the lifecycle creator command, got %v", c.Command)
+	}
+	if c.Env["IMAGE"] != "registry.example.com/test:1" || c.Env["CACHE_IMAGE"] != "registry.example.com/test:cache" {
+		t.Errorf("expected the image and cache image env vars, got %v", c.Env)
+	}
+	if len(ps.InitContainers) != 1 {
+		t.Errorf("expected 1 init container, got %d", len(ps.InitContainers))
+	}
+}
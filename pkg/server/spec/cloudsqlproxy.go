@@ -39,7 +39,7 @@ func NewCloudSQLProxyContainer(csp *CloudSQLProxy, a *app.App) *Container {
 	args := newCloudSQLProxyContainerArgs(csp)
 	mpath := newCloudSQLProxyContainerMountPath(csp)
 	env := map[string]string{}
-	for _, e := range a.EnvVars {
+	for _, e := range app.PodEnvVars(a) {
 		env[e.Key] = e.Value
 	}
 	return NewContainerBuilder("cloudsql-proxy", csp.Image).
@@ -1,8 +1,10 @@
 package spec
 
 type ContainerLimits struct {
-	CPU    string
-	Memory string
+	CPU           string
+	Memory        string
+	CPURequest    string
+	MemoryRequest string
 }
 
 type VolumeMounts struct {
@@ -27,6 +29,7 @@ type Container struct {
 	Args            []string
 	Ports           []Port
 	Secrets         []string
+	EnvFromSecrets  []string
 }
 
 type ContainerBuilder struct {
@@ -55,6 +58,11 @@ func (b *ContainerBuilder) WithSecrets(s []string) *ContainerBuilder {
 	return b
 }
 
+func (b *ContainerBuilder) WithEnvFromSecrets(s []string) *ContainerBuilder {
+	b.c.EnvFromSecrets = append(b.c.EnvFromSecrets, s...)
+	return b
+}
+
 func (b *ContainerBuilder) WithLimits(cpu, memory string) *ContainerBuilder {
 	b.c.ContainerLimits = &ContainerLimits{
 		CPU:    cpu,
@@ -63,6 +71,25 @@ func (b *ContainerBuilder) WithLimits(cpu, memory string) *ContainerBuilder {
 	return b
 }
 
+func (b *ContainerBuilder) WithRequests(cpu, memory string) *ContainerBuilder {
+	if b.c.ContainerLimits == nil {
+		b.c.ContainerLimits = new(ContainerLimits)
+	}
+	b.c.ContainerLimits.CPURequest = cpu
+	b.c.ContainerLimits.MemoryRequest = memory
+	return b
+}
+
+// WithResources sets the limits and requests of r, if any
+func (b *ContainerBuilder) WithResources(r *ContainerResources) *ContainerBuilder {
+	if r == nil {
+		return b
+	}
+	return b.
+		WithLimits(r.CPU, r.Memory).
+		WithRequests(r.CPURequest, r.MemoryRequest)
+}
+
 func (b *ContainerBuilder) ExposePort(name string, port int) *ContainerBuilder {
 	b.c.Ports = append(b.c.Ports, Port{Name: name, ContainerPort: int32(port)})
 	return b
@@ -7,6 +7,7 @@ func TestContainerBuilder(t *testing.T) {
 	expectedImage := "test/image:v1"
 	expectedEnv := map[string]string{"Key": "Value"}
 	expectedSecrets := []string{"SECRET", "VERY-SECRET"}
+	expectedEnvFrom := []string{"teresa-envgroup-shared"}
 	expectedLimits := ContainerLimits{CPU: "200m", Memory: "256Mi"}
 	expectedCmd := []string{"echo", "hi"}
 	expectedArgs := []string{"hello", "from", "test"}
@@ -15,6 +16,7 @@ func TestContainerBuilder(t *testing.T) {
 	c := NewContainerBuilder(expectedName, expectedImage).
 		WithEnv(expectedEnv).
 		WithSecrets(expectedSecrets).
+		WithEnvFromSecrets(expectedEnvFrom).
 		WithLimits(expectedLimits.CPU, expectedLimits.Memory).
 		WithCommand(expectedCmd).
 		WithArgs(expectedArgs).
@@ -46,6 +48,11 @@ func TestContainerBuilder(t *testing.T) {
 			t.Errorf("expected %s, got %s", s, actual)
 		}
 	}
+	for i, s := range expectedEnvFrom {
+		if actual := c.EnvFromSecrets[i]; actual != s {
+			t.Errorf("expected %s, got %s", s, actual)
+		}
+	}
 	for i, cmd := range expectedCmd {
 		if actual := c.Command[i]; actual != cmd {
 			t.Errorf("expected %s, got %s", cmd, actual)
@@ -57,3 +64,15 @@ func TestContainerBuilder(t *testing.T) {
 		}
 	}
 }
+
+func TestContainerBuilderWithRequests(t *testing.T) {
+	c := NewContainerBuilder("test", "test/image:v1").
+		WithLimits("500m", "512Mi").
+		WithRequests("100m", "").
+		Build()
+
+	want := ContainerLimits{CPU: "500m", Memory: "512Mi", CPURequest: "100m"}
+	if actual := *c.ContainerLimits; actual != want {
+		t.Errorf("expected %v, got %v", want, actual)
+	}
+}
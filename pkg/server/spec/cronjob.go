@@ -10,8 +10,9 @@ type CronJob struct {
 }
 
 type CronJobBuilder struct {
-	d        Deploy
-	schedule string
+	d                       Deploy
+	schedule                string
+	baselineSecurityContext *SecurityContext
 }
 
 func (b *CronJobBuilder) WithDescription(description string) *CronJobBuilder {
@@ -24,12 +25,27 @@ func (b *CronJobBuilder) WithPod(p *Pod) *CronJobBuilder {
 	return b
 }
 
+func (b *CronJobBuilder) WithTeresaYaml(ty *TeresaYaml) *CronJobBuilder {
+	if ty != nil {
+		b.d.TeresaYaml = *ty
+	}
+	return b
+}
+
+// WithBaselineSecurityContext sets the security context of the cluster,
+// the one of teresa.yaml is applied over it
+func (b *CronJobBuilder) WithBaselineSecurityContext(sc *SecurityContext) *CronJobBuilder {
+	b.baselineSecurityContext = sc
+	return b
+}
+
 func (b *CronJobBuilder) WithSchedule(s string) *CronJobBuilder {
 	b.schedule = s
 	return b
 }
 
 func (b *CronJobBuilder) Build() *CronJob {
+	b.d.SecurityContext = b.d.SecurityContext.withBaseline(b.baselineSecurityContext)
 	return &CronJob{
 		Deploy:                     b.d,
 		Schedule:                   b.schedule,
@@ -4,24 +4,40 @@ const (
 	DefaultPort                = 5000
 	secondaryPort              = 6000
 	SlugAnnotation             = "teresa.io/slug"
+	AuthorAnnotation           = "teresa.io/author"
+	CommitAnnotation           = "teresa.io/git-commit"
+	BranchAnnotation           = "teresa.io/git-branch"
+	MessageAnnotation          = "teresa.io/git-message"
 	defaultDrainTimeoutSeconds = 10
 	DefaultExternalPort        = 80
 )
 
+// HealthCheckProbe is a HTTP (default) probe of Path, a TCP one or an exec
+// one running Command in the app container. Blank Port uses the app port
 type HealthCheckProbe struct {
-	FailureThreshold    int32  `yaml:"failureThreshold"`
-	InitialDelaySeconds int32  `yaml:"initialDelaySeconds"`
-	PeriodSeconds       int32  `yaml:"periodSeconds"`
-	SuccessThreshold    int32  `yaml:"successThreshold"`
-	TimeoutSeconds      int32  `yaml:"timeoutSeconds"`
-	Path                string `yaml:"path"`
+	FailureThreshold    int32    `yaml:"failureThreshold"`
+	InitialDelaySeconds int32    `yaml:"initialDelaySeconds"`
+	PeriodSeconds       int32    `yaml:"periodSeconds"`
+	SuccessThreshold    int32    `yaml:"successThreshold"`
+	TimeoutSeconds      int32    `yaml:"timeoutSeconds"`
+	Path                string   `yaml:"path"`
+	Type                string   `yaml:"type,omitempty"`
+	Port                int      `yaml:"port,omitempty"`
+	Command             []string `yaml:"command,omitempty"`
 }
 
+// HealthCheck holds the probes of the app deploy, Processes the ones of the
+// deploys of the process types. Startup is parsed only to be rejected, the
+// Kubernetes API of the server has no startupProbe
 type HealthCheck struct {
 	Liveness  *HealthCheckProbe
 	Readiness *HealthCheckProbe
+	Startup   *HealthCheckProbe
+	Processes map[string]*HealthCheck `yaml:"processes,omitempty"`
 }
 
+// RollingUpdate holds the max surge and max unavailable pods of the
+// rollouts, either numbers or percentages
 type RollingUpdate struct {
 	MaxSurge       string `yaml:"maxSurge,omitempty"`
 	MaxUnavailable string `yaml:"maxUnavailable,omitempty"`
@@ -31,8 +47,99 @@ type PreStop struct {
 	DrainTimeoutSeconds int `yaml:"drainTimeoutSeconds,omitempty"`
 }
 
+// Lifecycle controls the shutdown of the pods, blank
+// TerminationGracePeriodSeconds uses the default of Kubernetes
 type Lifecycle struct {
-	PreStop *PreStop `yaml:"preStop,omitempty"`
+	PreStop                       *PreStop `yaml:"preStop,omitempty"`
+	TerminationGracePeriodSeconds int      `yaml:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// ExposedPort is a raw TCP or UDP port of the app, exposed by the service
+// with the same number
+type ExposedPort struct {
+	Name     string `yaml:"name"`
+	Port     int    `yaml:"port"`
+	Protocol string `yaml:"protocol,omitempty"`
+}
+
+// PDB is the PodDisruptionBudget of the app, MinAvailable is a number of
+// pods or a percentage of them
+type PDB struct {
+	MinAvailable string `yaml:"minAvailable"`
+}
+
+// Toleration allows the pods of the app to run on nodes tainted with Key
+type Toleration struct {
+	Key      string `yaml:"key"`
+	Operator string `yaml:"operator,omitempty"`
+	Value    string `yaml:"value,omitempty"`
+	Effect   string `yaml:"effect,omitempty"`
+}
+
+// NodeRequirement matches the nodes by the label Key, Operator is one of
+// In, NotIn, Exists, DoesNotExist, Gt or Lt
+type NodeRequirement struct {
+	Key      string   `yaml:"key"`
+	Operator string   `yaml:"operator"`
+	Values   []string `yaml:"values,omitempty"`
+}
+
+// PreferredNodeRequirement is a NodeRequirement the scheduler tries to
+// satisfy, the higher the Weight (1-100) the stronger the preference
+type PreferredNodeRequirement struct {
+	NodeRequirement `yaml:",inline"`
+	Weight          int32 `yaml:"weight"`
+}
+
+// Affinity is the node affinity of the app, all Required requirements must
+// be satisfied by the node
+type Affinity struct {
+	Required  []NodeRequirement          `yaml:"required,omitempty"`
+	Preferred []PreferredNodeRequirement `yaml:"preferred,omitempty"`
+}
+
+const (
+	SpreadNone      = "none"
+	SpreadPreferred = "preferred"
+	SpreadRequired  = "required"
+)
+
+// Spread spreads the pods of the app across zones and hosts, each policy is
+// one of none, preferred or required. Blank policies use the server default
+type Spread struct {
+	Zone string `yaml:"zone,omitempty"`
+	Host string `yaml:"host,omitempty"`
+}
+
+func IsValidSpreadPolicy(policy string) bool {
+	switch policy {
+	case "", SpreadNone, SpreadPreferred, SpreadRequired:
+		return true
+	}
+	return false
+}
+
+// HostAlias resolves the Hostnames to IP in the /etc/hosts of the pods
+type HostAlias struct {
+	IP        string   `yaml:"ip"`
+	Hostnames []string `yaml:"hostnames"`
+}
+
+// VolumeClaim is a persistent volume of the app, created on the first
+// deploy declaring it
+type VolumeClaim struct {
+	Name         string `yaml:"name"`
+	Size         string `yaml:"size"`
+	StorageClass string `yaml:"storageClass,omitempty"`
+	AccessMode   string `yaml:"accessMode,omitempty"`
+	MountPath    string `yaml:"mountPath"`
+}
+
+// Hooks are shell commands run from the slug (or image) of a deploy, the
+// pre-deploy ones before its rollout and the post-deploy ones after it
+type Hooks struct {
+	PreDeploy  []string `yaml:"pre_deploy,omitempty"`
+	PostDeploy []string `yaml:"post_deploy,omitempty"`
 }
 
 type CronArgs struct {
@@ -40,11 +147,29 @@ type CronArgs struct {
 }
 
 type TeresaYaml struct {
-	HealthCheck   *HealthCheck       `yaml:"healthCheck,omitempty"`
-	RollingUpdate *RollingUpdate     `yaml:"rollingUpdate,omitempty"`
-	Lifecycle     *Lifecycle         `yaml:"lifecycle,omitempty"`
-	Cron          *CronArgs          `yaml:"cron,omitempty"`
-	SideCars      map[string]RawData `yaml:"sidecars,omitempty"`
+	HealthCheck     *HealthCheck        `yaml:"healthCheck,omitempty"`
+	RollingUpdate   *RollingUpdate      `yaml:"rollingUpdate,omitempty"`
+	Lifecycle       *Lifecycle          `yaml:"lifecycle,omitempty"`
+	Cron            *CronArgs           `yaml:"cron,omitempty"`
+	SideCars        map[string]RawData  `yaml:"sidecars,omitempty"`
+	Ingress         map[string]string   `yaml:"ingress,omitempty"`
+	Ports           []ExposedPort       `yaml:"ports,omitempty"`
+	PDB             *PDB                `yaml:"pdb,omitempty"`
+	NodeSelector    map[string]string   `yaml:"nodeSelector,omitempty"`
+	Tolerations     []Toleration        `yaml:"tolerations,omitempty"`
+	Affinity        *Affinity           `yaml:"affinity,omitempty"`
+	Spread          *Spread             `yaml:"spread,omitempty"`
+	InitContainers  []*AppInitContainer `yaml:"initContainers,omitempty"`
+	VolumeClaims    []VolumeClaim       `yaml:"volumes,omitempty"`
+	SecurityContext *SecurityContext    `yaml:"securityContext,omitempty"`
+	HostAliases     []HostAlias         `yaml:"hostAliases,omitempty"`
+	Hooks           *Hooks              `yaml:"hooks,omitempty"`
+	// Command runs the app on image deploys, blank uses the entrypoint of
+	// the image. Slug deploys use the Procfile
+	Command []string `yaml:"command,omitempty"`
+	// RolloutTimeoutSeconds is the time the pods of the deploy have to get
+	// ready, blank uses the one of the cluster
+	RolloutTimeoutSeconds int32 `yaml:"rolloutTimeoutSeconds,omitempty"`
 }
 
 type TeresaYamlV2 struct {
@@ -56,12 +181,20 @@ type Deploy struct {
 	TeresaYaml
 	RevisionHistoryLimit int
 	Description          string
+	Author               string
+	Commit               string
+	Branch               string
+	Message              string
 	SlugURL              string
 	MatchLabels          Labels
+	Replicas             *int32
+	Protocol             string
 }
 
 type DeployBuilder struct {
-	d *Deploy
+	d                       *Deploy
+	defaultSpread           Spread
+	baselineSecurityContext *SecurityContext
 }
 
 type RawData struct {
@@ -101,6 +234,72 @@ func (b *DeployBuilder) WithDescription(desc string) *DeployBuilder {
 	return b
 }
 
+// WithAuthor sets the email of the user deploying, kept along with the
+// revision
+func (b *DeployBuilder) WithAuthor(email string) *DeployBuilder {
+	b.d.Author = email
+	return b
+}
+
+// WithGitMetadata sets the git commit, branch and message given on deploy,
+// kept along with the revision
+func (b *DeployBuilder) WithGitMetadata(commit, branch, message string) *DeployBuilder {
+	b.d.Commit = commit
+	b.d.Branch = branch
+	b.d.Message = message
+	return b
+}
+
+func (b *DeployBuilder) WithReplicas(replicas int32) *DeployBuilder {
+	b.d.Replicas = &replicas
+	return b
+}
+
+func (b *DeployBuilder) WithProtocol(protocol string) *DeployBuilder {
+	b.d.Protocol = protocol
+	return b
+}
+
+// WithDefaultSpread sets the spread policies used when teresa.yaml doesn't
+// set them
+func (b *DeployBuilder) WithDefaultSpread(zone, host string) *DeployBuilder {
+	b.defaultSpread = Spread{Zone: zone, Host: host}
+	return b
+}
+
+// WithBaselineSecurityContext sets the security context of the cluster,
+// the one of teresa.yaml is applied over it
+func (b *DeployBuilder) WithBaselineSecurityContext(sc *SecurityContext) *DeployBuilder {
+	b.baselineSecurityContext = sc
+	return b
+}
+
+// WithLifecycle replaces the lifecycle of teresa.yaml, nil keeps it
+func (b *DeployBuilder) WithLifecycle(lc *Lifecycle) *DeployBuilder {
+	if lc != nil {
+		b.d.Lifecycle = lc
+	}
+	return b
+}
+
+// WithRollingUpdate replaces the rolling update of teresa.yaml, nil keeps
+// it
+func (b *DeployBuilder) WithRollingUpdate(ru *RollingUpdate) *DeployBuilder {
+	if ru != nil {
+		b.d.RollingUpdate = ru
+	}
+	return b
+}
+
+// WithRolloutTimeout replaces the rollout timeout of teresa.yaml, zero
+// keeps it
+func (b *DeployBuilder) WithRolloutTimeout(seconds int32) *DeployBuilder {
+	if seconds > 0 {
+		b.d.RolloutTimeoutSeconds = seconds
+	}
+	return b
+}
+
 func (b *DeployBuilder) WithPod(p *Pod) *DeployBuilder {
 	b.d.Pod = *p
 	return b
@@ -112,9 +311,27 @@ func (b *DeployBuilder) Build() *Deploy {
 			PreStop: &PreStop{DrainTimeoutSeconds: defaultDrainTimeoutSeconds},
 		}
 	}
+	b.d.Spread = b.spread()
+	b.d.SecurityContext = b.d.SecurityContext.withBaseline(b.baselineSecurityContext)
 	return b.d
 }
 
+func (b *DeployBuilder) spread() *Spread {
+	s := b.defaultSpread
+	if ts := b.d.Spread; ts != nil {
+		if ts.Zone != "" {
+			s.Zone = ts.Zone
+		}
+		if ts.Host != "" {
+			s.Host = ts.Host
+		}
+	}
+	if s.Zone == "" && s.Host == "" {
+		return nil
+	}
+	return &s
+}
+
 func NewDeployBuilder(slugURL string) *DeployBuilder {
 	d := &Deploy{
 		SlugURL:     slugURL,
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -62,6 +63,52 @@ func TestDeployBuilder(t *testing.T) {
 	}
 }
 
+func TestDeployBuilderSpread(t *testing.T) {
+	var testCases = []struct {
+		defaultZone string
+		defaultHost string
+		spread      *Spread
+		expected    *Spread
+	}{
+		{"", "", nil, nil},
+		{SpreadPreferred, "", nil, &Spread{Zone: SpreadPreferred}},
+		{SpreadPreferred, SpreadPreferred, &Spread{Host: SpreadRequired}, &Spread{Zone: SpreadPreferred, Host: SpreadRequired}},
+		{"", "", &Spread{Zone: SpreadNone}, &Spread{Zone: SpreadNone}},
+	}
+
+	for _, tc := range testCases {
+		ds := NewDeployBuilder("some/slug.tgz").
+			WithTeresaYaml(&TeresaYaml{Spread: tc.spread}).
+			WithDefaultSpread(tc.defaultZone, tc.defaultHost).
+			Build()
+		if !reflect.DeepEqual(ds.Spread, tc.expected) {
+			t.Errorf("expected %v, got %v", tc.expected, ds.Spread)
+		}
+	}
+}
+
+func TestDeployBuilderRolloutTimeout(t *testing.T) {
+	var testCases = []struct {
+		teresaYaml int32
+		app        int32
+		expected   int32
+	}{
+		{0, 0, 0},
+		{600, 0, 600},
+		{600, 1200, 1200},
+	}
+
+	for _, tc := range testCases {
+		ds := NewDeployBuilder("some/slug.tgz").
+			WithTeresaYaml(&TeresaYaml{RolloutTimeoutSeconds: tc.teresaYaml}).
+			WithRolloutTimeout(tc.app).
+			Build()
+		if ds.RolloutTimeoutSeconds != tc.expected {
+			t.Errorf("expected %d, got %d", tc.expected, ds.RolloutTimeoutSeconds)
+		}
+	}
+}
+
 func TestRawData(t *testing.T) {
 	b := []byte("field1: value1\nfield2: value2")
 	raw := new(RawData)
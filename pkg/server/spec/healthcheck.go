@@ -0,0 +1,84 @@
+package spec
+
+import "github.com/pkg/errors"
+
+const (
+	ProbeTypeHTTP = "http"
+	ProbeTypeTCP  = "tcp"
+	ProbeTypeExec = "exec"
+)
+
+// ValidateHealthCheck checks the probes of teresa.yaml, including the ones
+// of the process types
+func ValidateHealthCheck(hc *HealthCheck) error {
+	if hc == nil {
+		return nil
+	}
+	if err := validateProbes(hc); err != nil {
+		return err
+	}
+	for pt, phc := range hc.Processes {
+		if phc == nil {
+			continue
+		}
+		if len(phc.Processes) > 0 {
+			return errors.Errorf("process type %s can't have nested processes", pt)
+		}
+		if err := validateProbes(phc); err != nil {
+			return errors.Wrapf(err, "process type %s", pt)
+		}
+	}
+	return nil
+}
+
+func validateProbes(hc *HealthCheck) error {
+	if err := validateProbe(hc.Liveness); err != nil {
+		return errors.Wrap(err, "liveness")
+	}
+	if err := validateProbe(hc.Readiness); err != nil {
+		return errors.Wrap(err, "readiness")
+	}
+	// the vendored Kubernetes API has no startupProbe, slow starting apps
+	// delay the liveness probe instead
+	if hc.Startup != nil {
+		return errors.New("startup probes aren't supported, delay the liveness probe with initialDelaySeconds instead")
+	}
+	return nil
+}
+
+func validateProbe(p *HealthCheckProbe) error {
+	if p == nil {
+		return nil
+	}
+	if p.Port < 0 || p.Port > 65535 {
+		return errors.Errorf("invalid port %d", p.Port)
+	}
+	switch p.Type {
+	case "", ProbeTypeHTTP:
+		if len(p.Command) > 0 {
+			return errors.New("command is only allowed in exec probes")
+		}
+	case ProbeTypeTCP:
+		if p.Path != "" || len(p.Command) > 0 {
+			return errors.New("path and command aren't allowed in tcp probes")
+		}
+	case ProbeTypeExec:
+		if len(p.Command) == 0 {
+			return errors.New("command of exec probe not found")
+		}
+		if p.Path != "" || p.Port != 0 {
+			return errors.New("path and port aren't allowed in exec probes")
+		}
+	default:
+		return errors.Errorf("invalid probe type %s", p.Type)
+	}
+	return nil
+}
+
+// ForProcess returns the probes of the process type pt, nil if it has none
+func (hc *HealthCheck) ForProcess(pt string) *HealthCheck {
+	if hc == nil {
+		return nil
+	}
+	return hc.Processes[pt]
+}
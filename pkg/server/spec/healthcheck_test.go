@@ -0,0 +1,70 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateHealthCheck(t *testing.T) {
+	var testCases = []struct {
+		hc      *HealthCheck
+		isValid bool
+	}{
+		{nil, true},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Path: "/hc"}}, true},
+		{&HealthCheck{Readiness: &HealthCheckProbe{Type: ProbeTypeHTTP, Path: "/hc", Port: 8081}}, true},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Type: ProbeTypeTCP, Port: 9090}}, true},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Type: ProbeTypeExec, Command: []string{"pgrep", "worker"}}}, true},
+		{&HealthCheck{Processes: map[string]*HealthCheck{
+			"worker": {Liveness: &HealthCheckProbe{Type: ProbeTypeExec, Command: []string{"true"}}},
+		}}, true},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Type: "grpc"}}, false},
+		{&HealthCheck{Startup: &HealthCheckProbe{Path: "/hc"}}, false},
+		{&HealthCheck{Processes: map[string]*HealthCheck{
+			"worker": {Startup: &HealthCheckProbe{Type: ProbeTypeTCP}},
+		}}, false},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Port: 70000}}, false},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Path: "/hc", Command: []string{"true"}}}, false},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Type: ProbeTypeTCP, Path: "/hc"}}, false},
+		{&HealthCheck{Liveness: &HealthCheckProbe{Type: ProbeTypeExec}}, false},
+		{&HealthCheck{Readiness: &HealthCheckProbe{Type: ProbeTypeExec, Command: []string{"true"}, Port: 80}}, false},
+		{&HealthCheck{Processes: map[string]*HealthCheck{
+			"worker": {Liveness: &HealthCheckProbe{Type: ProbeTypeExec}},
+		}}, false},
+		{&HealthCheck{Processes: map[string]*HealthCheck{
+			"worker": {Processes: map[string]*HealthCheck{"clock": {}}},
+		}}, false},
+	}
+
+	for _, tc := range testCases {
+		if err := ValidateHealthCheck(tc.hc); (err == nil) != tc.isValid {
+			t.Errorf("expected valid %t, got %v for %+v", tc.isValid, err, tc.hc)
+		}
+	}
+}
+
+func TestValidateHealthCheckStartup(t *testing.T) {
+	err := ValidateHealthCheck(&HealthCheck{Startup: &HealthCheckProbe{Path: "/hc"}})
+	if err == nil || !strings.Contains(err.Error(), "initialDelaySeconds") {
+		t.Errorf("expected an error pointing to initialDelaySeconds, got %v", err)
+	}
+}
+
+func TestHealthCheckForProcess(t *testing.T) {
+	worker := &HealthCheck{Liveness: &HealthCheckProbe{Type: ProbeTypeTCP}}
+	hc := &HealthCheck{
+		Liveness:  &HealthCheckProbe{Path: "/hc"},
+		Processes: map[string]*HealthCheck{"worker": worker},
+	}
+
+	if actual := hc.ForProcess("worker"); actual != worker {
+		t.Errorf("expected %v, got %v", worker, actual)
+	}
+	if actual := hc.ForProcess("clock"); actual != nil {
+		t.Errorf("expected nil, got %v", actual)
+	}
+	var empty *HealthCheck
+	if actual := empty.ForProcess("worker"); actual != nil {
+		t.Errorf("expected nil, got %v", actual)
+	}
+}
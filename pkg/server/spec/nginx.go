@@ -46,7 +46,7 @@ func NewNginxContainer(image string, a *app.App) *Container {
 		"NGINX_BACKEND": fmt.Sprintf("http://localhost:%d", secondaryPort),
 	}
 	args := newNginxContainerArgs(env)
-	for _, e := range a.EnvVars {
+	for _, e := range app.PodEnvVars(a) {
 		env[e.Key] = e.Value
 	}
 	return NewContainerBuilder("nginx", image).
@@ -1,7 +1,11 @@
 package spec
 
 import (
+	"path"
+	"sort"
 	"strconv"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
 )
 
 const (
@@ -20,6 +24,7 @@ type Volume struct {
 	Name          string
 	SecretName    string
 	ConfigMapName string
+	ClaimName     string
 	EmptyDir      bool
 	Items         []VolumeItem
 }
@@ -34,10 +39,11 @@ type Pod struct {
 }
 
 type PodBuilder struct {
-	p             *Pod
-	initContainer *Container
-	appContainer  *Container
-	sideCars      []*Container
+	p                 *Pod
+	initContainer     *Container
+	appContainer      *Container
+	sideCars          []*Container
+	appInitContainers []*Container
 }
 
 func SwitchPortWithAppContainer(b *PodBuilder) {
@@ -68,6 +74,12 @@ func ShareVolumeBetweenAppAndSideCar(name, path string) func(*PodBuilder) {
 	}
 }
 
+func ShareVolumeBetweenAppAndAppInitContainer(name, path string) func(*PodBuilder) {
+	return func(b *PodBuilder) {
+		shareVolumeWithAppContainer(name, path, b.appInitContainers[len(b.appInitContainers)-1], b)
+	}
+}
+
 func ShareVolumeBetweenAppAndInitContainer(name, path string) func(*PodBuilder) {
 	return func(b *PodBuilder) {
 		shareVolumeWithAppContainer(name, path, b.initContainer, b)
@@ -134,6 +146,64 @@ func MountSecretItemsInAppContainer(name, path, secretName string, items []strin
 	}
 }
 
+// MountSecretItemsAtPathsInAppContainer mounts each secret item at its own
+// directory, mounts maps the item to the directory.
+func MountSecretItemsAtPathsInAppContainer(name string, mounts map[string]string) func(*PodBuilder) {
+	return func(b *PodBuilder) {
+		items := make([]string, 0, len(mounts))
+		for item := range mounts {
+			items = append(items, item)
+		}
+		sort.Strings(items)
+		for _, item := range items {
+			b.appContainer.VolumeMounts = append(
+				b.appContainer.VolumeMounts,
+				&VolumeMounts{
+					Name:      name,
+					MountPath: path.Join(mounts[item], item),
+					SubPath:   item,
+					ReadOnly:  true,
+				},
+			)
+		}
+	}
+}
+
+// MountConfigFilesInAppContainer mounts each item of the ConfigMap at its
+// own directory, mounts maps the item to the directory.
+func MountConfigFilesInAppContainer(name, configMapName string, mounts map[string]string) func(*PodBuilder) {
+	return func(b *PodBuilder) {
+		if len(mounts) == 0 {
+			return
+		}
+		MountSecretItemsAtPathsInAppContainer(name, mounts)(b)
+		b.p.Volumes = append(
+			b.p.Volumes,
+			&Volume{Name: name, ConfigMapName: configMapName},
+		)
+	}
+}
+
+func MountVolumeClaimsInAppContainer(claims []*app.VolumeClaim) func(*PodBuilder) {
+	return func(b *PodBuilder) {
+		for _, vc := range claims {
+			name := volumeClaimVolumeName(vc.Name)
+			b.appContainer.VolumeMounts = append(
+				b.appContainer.VolumeMounts,
+				&VolumeMounts{Name: name, MountPath: vc.MountPath},
+			)
+			b.p.Volumes = append(
+				b.p.Volumes,
+				&Volume{Name: name, ClaimName: vc.Name},
+			)
+		}
+	}
+}
+
+func volumeClaimVolumeName(name string) string {
+	return "pvc-" + name
+}
+
 func (b *PodBuilder) WithInitContainer(cn *Container, options ...func(*PodBuilder)) *PodBuilder {
 	b.initContainer = cn
 	for _, opt := range options {
@@ -142,6 +212,16 @@ func (b *PodBuilder) WithInitContainer(cn *Container, options ...func(*PodBuilde
 	return b
 }
 
+// WithAppInitContainer adds an init container run after the one set by
+// WithInitContainer, in the order they are added
+func (b *PodBuilder) WithAppInitContainer(cn *Container, options ...func(*PodBuilder)) *PodBuilder {
+	b.appInitContainers = append(b.appInitContainers, cn)
+	for _, opt := range options {
+		opt(b)
+	}
+	return b
+}
+
 func (b *PodBuilder) WithAppContainer(cn *Container, options ...func(*PodBuilder)) *PodBuilder {
 	b.appContainer = cn
 	for _, opt := range options {
@@ -174,6 +254,7 @@ func (b *PodBuilder) Build() *Pod {
 	if b.initContainer != nil {
 		b.p.InitContainers = []*Container{b.initContainer}
 	}
+	b.p.InitContainers = append(b.p.InitContainers, b.appInitContainers...)
 	return b.p
 }
 
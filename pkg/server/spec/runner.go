@@ -7,7 +7,10 @@ import (
 	"github.com/luizalabs/teresa/pkg/server/storage"
 )
 
-const AppSecretName = "secrets"
+const (
+	AppSecretName      = "secrets"
+	AppConfigFilesName = "config-files"
+)
 
 type RunnerPodBuilder struct {
 	name       string
@@ -21,21 +24,30 @@ type RunnerPodBuilder struct {
 	cl         *ContainerLimits
 	labels     Labels
 	csp        *CloudSQLProxy
+	sideCars   []*SideCar
+	initConts  []*AppInitContainer
+	claims     []*app.VolumeClaim
+	appImage   string
+	command    []string
 }
 
 func (b *RunnerPodBuilder) newAppRunnerContainer() *Container {
-	env := map[string]string{
-		"APP":      b.app.Name,
-		"SLUG_URL": b.slugURL,
-		"SLUG_DIR": slugVolumeMountPath,
-	}
-	for _, ev := range b.app.EnvVars {
+	env := map[string]string{"APP": b.app.Name}
+	for _, ev := range app.PodEnvVars(b.app) {
 		env[ev.Key] = ev.Value
 	}
-	builder := NewContainerBuilder(b.name, b.image).
+	var builder *ContainerBuilder
+	if b.appImage != "" {
+		builder = NewContainerBuilder(b.name, b.appImage).WithCommand(b.command)
+	} else {
+		env["SLUG_URL"] = b.slugURL
+		env["SLUG_DIR"] = slugVolumeMountPath
+		builder = NewContainerBuilder(b.name, b.image).WithArgs(b.args)
+	}
+	builder = builder.
 		WithEnv(env).
 		WithSecrets(b.app.Secrets).
-		WithArgs(b.args)
+		WithEnvFromSecrets(app.EnvGroupsSecretNames(b.app))
 
 	if app.IsWebApp(b.app.ProcessType) {
 		builder = builder.
@@ -44,26 +56,50 @@ func (b *RunnerPodBuilder) newAppRunnerContainer() *Container {
 	}
 	if b.cl != nil {
 		builder = builder.WithLimits(b.cl.CPU, b.cl.Memory)
+	} else if r := b.app.Resources; r != nil {
+		builder = builder.
+			WithLimits(r.CPU, r.Memory).
+			WithRequests(r.CPURequest, r.MemoryRequest)
 	}
 	return builder.Build()
 }
 
 func (b *RunnerPodBuilder) newAppRunnerPod(appContainer *Container) *Pod {
-	init := NewInitContainer(b.initImage, b.slugURL, b.fs)
-	mountSecretOpt := MountSecretInInitContainer(vlName, vlPath, b.fs.K8sSecretName())
-	shareVolOpt := ShareVolumeBetweenAppAndInitContainer(slugVolumeName, slugVolumeMountPath)
-
 	msc := MountSecretItemsInAppContainer(
 		AppSecretName,
 		app.SecretPath,
 		app.TeresaAppSecrets,
 		b.app.SecretFiles,
 	)
+	mscp := MountSecretItemsAtPathsInAppContainer(AppSecretName, b.app.SecretFileMounts)
+
+	mcf := MountConfigFilesInAppContainer(
+		AppConfigFilesName,
+		app.TeresaAppConfigFiles,
+		b.app.ConfigFiles,
+	)
+
+	mvc := MountVolumeClaimsInAppContainer(b.claims)
 
 	builder := NewPodBuilder(b.name, b.app.Name).
-		WithAppContainer(appContainer, msc).
-		WithLabels(b.labels).
-		WithInitContainer(init, mountSecretOpt, shareVolOpt)
+		WithAppContainer(appContainer, msc, mscp, mcf, mvc).
+		WithLabels(b.labels)
+
+	// image deploys have no slug to download
+	if b.appImage == "" {
+		init := NewInitContainer(b.initImage, b.slugURL, b.fs)
+		mountSecretOpt := MountSecretInInitContainer(vlName, vlPath, b.fs.K8sSecretName())
+		shareVolOpt := ShareVolumeBetweenAppAndInitContainer(slugVolumeName, slugVolumeMountPath)
+		builder = builder.WithInitContainer(init, mountSecretOpt, shareVolOpt)
+	}
+
+	for _, ic := range b.initConts {
+		var opts []func(*PodBuilder)
+		if ic.SharedPath != "" {
+			opts = append(opts, ShareVolumeBetweenAppAndAppInitContainer(appInitVolumeName(ic.Name), ic.SharedPath))
+		}
+		builder = builder.WithAppInitContainer(NewAppInitContainer(ic, b.app), opts...)
+	}
 
 	if b.nginxImage != "" {
 		nc := NewNginxContainer(b.nginxImage, b.app)
@@ -78,6 +114,10 @@ func (b *RunnerPodBuilder) newAppRunnerPod(appContainer *Container) *Pod {
 		builder = builder.WithSideCar(cn)
 	}
 
+	for _, sc := range b.sideCars {
+		builder = builder.WithSideCar(NewSideCarContainer(sc))
+	}
+
 	return builder.Build()
 }
 
@@ -91,6 +131,14 @@ func (b *RunnerPodBuilder) WithSlug(url string) *RunnerPodBuilder {
 	return b
 }
 
+// WithImage runs the app from the image instead of a slug, the command
+// overrides the entrypoint of the image
+func (b *RunnerPodBuilder) WithImage(image string, command []string) *RunnerPodBuilder {
+	b.appImage = image
+	b.command = command
+	return b
+}
+
 func (b *RunnerPodBuilder) WithLimits(cpu, memory string) *RunnerPodBuilder {
 	b.cl = &ContainerLimits{
 		CPU:    cpu,
@@ -130,6 +178,22 @@ func (b *RunnerPodBuilder) WithCloudSQLProxySideCar(csp *CloudSQLProxy) *RunnerP
 	return b
 }
 
+func (b *RunnerPodBuilder) WithSideCars(scs []*SideCar) *RunnerPodBuilder {
+	b.sideCars = scs
+	return b
+}
+
+func (b *RunnerPodBuilder) WithAppInitContainers(ics []*AppInitContainer) *RunnerPodBuilder {
+	b.initConts = ics
+	return b
+}
+
+// WithVolumeClaims mounts the persistent volumes in the app container
+func (b *RunnerPodBuilder) WithVolumeClaims(claims []*app.VolumeClaim) *RunnerPodBuilder {
+	b.claims = claims
+	return b
+}
+
 func NewRunnerPodBuilder(name, image, initImage string) *RunnerPodBuilder {
 	return &RunnerPodBuilder{
 		name:      name,
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/luizalabs/teresa/pkg/server/app"
@@ -88,6 +89,74 @@ func TestRunnerPodBuilder(t *testing.T) {
 	}
 }
 
+func TestRunnerPodBuilderWithSecretFileMounts(t *testing.T) {
+	a := &app.App{
+		Name:             "test",
+		ProcessType:      app.ProcessTypeWeb,
+		SecretFiles:      []string{"secret", "credentials.json"},
+		SecretFileMounts: map[string]string{"credentials.json": "/secrets"},
+	}
+
+	ps := NewRunnerPodBuilder("test", "test", "test").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		Build()
+
+	var found bool
+	for _, vm := range ps.Containers[0].VolumeMounts {
+		if vm.SubPath != "credentials.json" {
+			continue
+		}
+		found = true
+		if vm.Name != AppSecretName {
+			t.Errorf("expected %s, got %s", AppSecretName, vm.Name)
+		}
+		if expected := "/secrets/credentials.json"; vm.MountPath != expected {
+			t.Errorf("expected %s, got %s", expected, vm.MountPath)
+		}
+	}
+	if !found {
+		t.Error("expected a volume mount for the secret file")
+	}
+}
+
+func TestRunnerPodBuilderWithConfigFiles(t *testing.T) {
+	a := &app.App{
+		Name:        "test",
+		ProcessType: app.ProcessTypeWeb,
+		ConfigFiles: map[string]string{"nginx.conf": "/etc/nginx"},
+	}
+
+	ps := NewRunnerPodBuilder("test", "test", "test").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		Build()
+
+	var found bool
+	for _, vm := range ps.Containers[0].VolumeMounts {
+		if vm.Name != AppConfigFilesName {
+			continue
+		}
+		found = true
+		if expected := "/etc/nginx/nginx.conf"; vm.MountPath != expected || vm.SubPath != "nginx.conf" {
+			t.Errorf("expected %s from nginx.conf, got %s from %s", expected, vm.MountPath, vm.SubPath)
+		}
+	}
+	if !found {
+		t.Error("expected a volume mount for the config file")
+	}
+
+	found = false
+	for _, v := range ps.Volumes {
+		if v.Name == AppConfigFilesName {
+			found = v.ConfigMapName == app.TeresaAppConfigFiles
+		}
+	}
+	if !found {
+		t.Error("expected the config files volume")
+	}
+}
+
 func TestRunnerPodBuilderWithCloudSQLProxySideCar(t *testing.T) {
 	name := "cloudsql-proxy"
 	a := &app.App{
@@ -113,3 +182,128 @@ func TestRunnerPodBuilderWithCloudSQLProxySideCar(t *testing.T) {
 		t.Errorf("expected at least 1 env var, got %d", got)
 	}
 }
+
+func TestRunnerPodBuilderWithSideCars(t *testing.T) {
+	a := &app.App{Name: "test", ProcessType: app.ProcessTypeWeb}
+	scs := []*SideCar{
+		{Name: "envoy", Image: "envoy"},
+		{Name: "fluent-bit", Image: "fluent-bit"},
+	}
+
+	ps := NewRunnerPodBuilder("test", "test", "test").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		WithCloudSQLProxySideCar(&CloudSQLProxy{}).
+		WithSideCars(scs).
+		Build()
+
+	want := []string{"test", "cloudsql-proxy", "envoy", "fluent-bit"}
+	if len(ps.Containers) != len(want) {
+		t.Fatalf("got %d; want %d", len(ps.Containers), len(want))
+	}
+	for i, name := range want {
+		if got := ps.Containers[i].Name; got != name {
+			t.Errorf("got %s; want %s", got, name)
+		}
+	}
+}
+
+func TestRunnerPodBuilderWithAppInitContainers(t *testing.T) {
+	a := &app.App{Name: "test", ProcessType: app.ProcessTypeWeb}
+	ics := []*AppInitContainer{
+		{Name: "migrate", Image: "app"},
+		{Name: "assets", Image: "curl", SharedPath: "/assets"},
+	}
+
+	ps := NewRunnerPodBuilder("test", "test", "test").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		WithAppInitContainers(ics).
+		Build()
+
+	want := []string{"slugstore", "migrate", "assets"}
+	if len(ps.InitContainers) != len(want) {
+		t.Fatalf("got %d; want %d", len(ps.InitContainers), len(want))
+	}
+	for i, name := range want {
+		if got := ps.InitContainers[i].Name; got != name {
+			t.Errorf("got %s; want %s", got, name)
+		}
+	}
+
+	hasMount := func(c *Container) bool {
+		for _, vm := range c.VolumeMounts {
+			if vm.Name == "init-assets" && vm.MountPath == "/assets" {
+				return true
+			}
+		}
+		return false
+	}
+	if !hasMount(ps.InitContainers[2]) || !hasMount(ps.Containers[0]) {
+		t.Error("expected the shared volume mounted in the init and app containers")
+	}
+	if hasMount(ps.InitContainers[1]) {
+		t.Error("expected no shared volume in the init container without shared path")
+	}
+}
+
+func TestRunnerPodBuilderWithVolumeClaims(t *testing.T) {
+	a := &app.App{Name: "test", ProcessType: app.ProcessTypeWeb}
+	claims := []*app.VolumeClaim{{Name: "data", Size: "1Gi", MountPath: "/data"}}
+
+	ps := NewRunnerPodBuilder("test", "test", "test").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		WithVolumeClaims(claims).
+		Build()
+
+	var found bool
+	for _, v := range ps.Volumes {
+		if v.Name == "pvc-data" && v.ClaimName == "data" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected volume of claim data, got %v", ps.Volumes)
+	}
+	var mounted bool
+	for _, vm := range ps.Containers[0].VolumeMounts {
+		if vm.Name == "pvc-data" && vm.MountPath == "/data" && !vm.ReadOnly {
+			mounted = true
+		}
+	}
+	if !mounted {
+		t.Errorf("expected claim data mounted at /data, got %v", ps.Containers[0].VolumeMounts)
+	}
+}
+
+func TestRunnerPodBuilderWithImage(t *testing.T) {
+	a := &app.App{Name: "test", ProcessType: app.ProcessTypeWeb}
+	command := []string{"./server", "--port", "5000"}
+
+	ps := NewRunnerPodBuilder("test", "runner/image", "init/image").
+		ForApp(a).
+		WithStorage(storage.NewFake()).
+		WithArgs([]string{"start", "web"}).
+		WithImage("registry/app:v1", command).
+		Build()
+
+	c := ps.Containers[0]
+	if c.Image != "registry/app:v1" {
+		t.Errorf("expected registry/app:v1, got %s", c.Image)
+	}
+	if !reflect.DeepEqual(c.Command, command) || len(c.Args) != 0 {
+		t.Errorf("expected command %v without args, got %v %v", command, c.Command, c.Args)
+	}
+	if _, found := c.Env["SLUG_URL"]; found {
+		t.Errorf("expected no SLUG_URL, got %v", c.Env)
+	}
+	if len(ps.InitContainers) != 0 {
+		t.Errorf("expected no init containers, got %d", len(ps.InitContainers))
+	}
+	for _, vm := range c.VolumeMounts {
+		if vm.Name == slugVolumeName {
+			t.Errorf("expected no slug volume, got %v", c.VolumeMounts)
+		}
+	}
+}
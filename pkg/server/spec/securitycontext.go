@@ -0,0 +1,54 @@
+package spec
+
+// SecurityContext restricts the containers of the app (sidecars and init
+// containers included), nil fields keep the ones of the image. DropCapabilities are Linux capabilities (like NET_RAW) or ALL
+type SecurityContext struct {
+	RunAsNonRoot           *bool    `yaml:"runAsNonRoot,omitempty"`
+	RunAsUser              *int64   `yaml:"runAsUser,omitempty"`
+	ReadOnlyRootFilesystem *bool    `yaml:"readOnlyRootFilesystem,omitempty"`
+	DropCapabilities       []string `yaml:"dropCapabilities,omitempty"`
+}
+
+func (sc *SecurityContext) isEmpty() bool {
+	return sc.RunAsNonRoot == nil && sc.RunAsUser == nil &&
+		sc.ReadOnlyRootFilesystem == nil && len(sc.DropCapabilities) == 0
+}
+
+// withBaseline returns the security context of the app with the fields set
+// by the baseline of the cluster taking precedence, the dropped capabilities
+// of both are kept
+func (sc *SecurityContext) withBaseline(baseline *SecurityContext) *SecurityContext {
+	merged := new(SecurityContext)
+	for _, s := range []*SecurityContext{sc, baseline} {
+		if s == nil {
+			continue
+		}
+		if s.RunAsNonRoot != nil {
+			merged.RunAsNonRoot = s.RunAsNonRoot
+		}
+		if s.RunAsUser != nil {
+			merged.RunAsUser = s.RunAsUser
+		}
+		if s.ReadOnlyRootFilesystem != nil {
+			merged.ReadOnlyRootFilesystem = s.ReadOnlyRootFilesystem
+		}
+		for _, c := range s.DropCapabilities {
+			if !hasCapability(merged.DropCapabilities, c) {
+				merged.DropCapabilities = append(merged.DropCapabilities, c)
+			}
+		}
+	}
+	if merged.isEmpty() {
+		return nil
+	}
+	return merged
+}
+
+func hasCapability(caps []string, c string) bool {
+	for _, item := range caps {
+		if item == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecurityContextWithBaseline(t *testing.T) {
+	yes, no := true, false
+	user := int64(1000)
+	var testCases = []struct {
+		sc       *SecurityContext
+		baseline *SecurityContext
+		expected *SecurityContext
+	}{
+		{nil, nil, nil},
+		{&SecurityContext{}, nil, nil},
+		{
+			&SecurityContext{RunAsUser: &user},
+			nil,
+			&SecurityContext{RunAsUser: &user},
+		},
+		{
+			nil,
+			&SecurityContext{RunAsNonRoot: &yes, DropCapabilities: []string{"NET_RAW"}},
+			&SecurityContext{RunAsNonRoot: &yes, DropCapabilities: []string{"NET_RAW"}},
+		},
+		{
+			&SecurityContext{ReadOnlyRootFilesystem: &no, DropCapabilities: []string{"NET_RAW", "ALL"}},
+			&SecurityContext{ReadOnlyRootFilesystem: &yes, RunAsUser: &user, DropCapabilities: []string{"NET_RAW"}},
+			&SecurityContext{ReadOnlyRootFilesystem: &yes, RunAsUser: &user, DropCapabilities: []string{"NET_RAW", "ALL"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.sc.withBaseline(tc.baseline); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("expected %+v, got %+v", tc.expected, got)
+		}
+	}
+}
+
+func TestDeployBuilderSecurityContext(t *testing.T) {
+	yes := true
+	ty := &TeresaYaml{SecurityContext: &SecurityContext{DropCapabilities: []string{"ALL"}}}
+	baseline := &SecurityContext{RunAsNonRoot: &yes}
+
+	d := NewDeployBuilder("slug").
+		WithTeresaYaml(ty).
+		WithBaselineSecurityContext(baseline).
+		Build()
+
+	expected := &SecurityContext{RunAsNonRoot: &yes, DropCapabilities: []string{"ALL"}}
+	if !reflect.DeepEqual(d.SecurityContext, expected) {
+		t.Errorf("expected %+v, got %+v", expected, d.SecurityContext)
+	}
+	if ty.SecurityContext.RunAsNonRoot != nil {
+		t.Errorf("expected teresa.yaml unchanged, got %+v", ty.SecurityContext)
+	}
+}
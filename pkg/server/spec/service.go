@@ -8,6 +8,7 @@ type ServicePort struct {
 	Name       string
 	Port       int
 	TargetPort int
+	Protocol   string
 }
 
 type Service struct {
@@ -0,0 +1,100 @@
+package spec
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+var sideCarNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// reservedSideCars are the sidecars built by Teresa itself
+var reservedSideCars = map[string]bool{
+	"cloudsql-proxy": true,
+	"nginx":          true,
+}
+
+type SideCarPort struct {
+	Name string `yaml:"name"`
+	Port int    `yaml:"port"`
+}
+
+// ContainerResources are the limits and requests of the containers of
+// teresa.yaml
+type ContainerResources struct {
+	CPU           string `yaml:"cpu,omitempty"`
+	Memory        string `yaml:"memory,omitempty"`
+	CPURequest    string `yaml:"cpuRequest,omitempty"`
+	MemoryRequest string `yaml:"memoryRequest,omitempty"`
+}
+
+// SideCar is a container of teresa.yaml running alongside the app one, like
+// a local proxy or a log shipper
+type SideCar struct {
+	Name      string              `yaml:"-"`
+	Image     string              `yaml:"image"`
+	Command   []string            `yaml:"command,omitempty"`
+	Args      []string            `yaml:"args,omitempty"`
+	Env       map[string]string   `yaml:"env,omitempty"`
+	Ports     []SideCarPort       `yaml:"ports,omitempty"`
+	Resources *ContainerResources `yaml:"resources,omitempty"`
+}
+
+// NewSideCars returns the sidecars of teresa.yaml sorted by name, the ones
+// built by Teresa (like cloudsql-proxy) are skipped
+func NewSideCars(t *TeresaYaml) ([]*SideCar, error) {
+	if t == nil {
+		return nil, nil
+	}
+	names := make([]string, 0, len(t.SideCars))
+	for name := range t.SideCars {
+		if !reservedSideCars[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	scs := make([]*SideCar, len(names))
+	for i, name := range names {
+		v := t.SideCars[name]
+		sc := &SideCar{Name: name}
+		if err := v.Unmarshal(sc); err != nil {
+			return nil, errors.Wrapf(err, "failed to build sidecar %s", name)
+		}
+		if err := validateSideCar(sc); err != nil {
+			return nil, err
+		}
+		scs[i] = sc
+	}
+	return scs, nil
+}
+
+func validateSideCar(sc *SideCar) error {
+	if len(sc.Name) > 63 || !sideCarNameRegexp.MatchString(sc.Name) {
+		return errors.Errorf("invalid sidecar name %s", sc.Name)
+	}
+	if sc.Image == "" {
+		return errors.Errorf("image of sidecar %s not found", sc.Name)
+	}
+	for _, p := range sc.Ports {
+		if p.Port < 1 || p.Port > 65535 || p.Port == DefaultPort || p.Port == secondaryPort {
+			return errors.Errorf("invalid port %d of sidecar %s", p.Port, sc.Name)
+		}
+	}
+	return nil
+}
+
+func NewSideCarContainer(sc *SideCar) *Container {
+	builder := NewContainerBuilder(sc.Name, sc.Image).
+		WithCommand(sc.Command).
+		WithArgs(sc.Args).
+		WithEnv(sc.Env)
+
+	for _, p := range sc.Ports {
+		builder = builder.ExposePort(p.Name, p.Port)
+	}
+	return builder.
+		WithResources(sc.Resources).
+		Build()
+}
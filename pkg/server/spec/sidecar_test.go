@@ -0,0 +1,105 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const sideCarsYaml = `
+sidecars:
+  cloudsql-proxy:
+    instances: test
+  fluent-bit:
+    image: fluent/fluent-bit:0.13
+    env:
+      FLUENT_HOST: logs
+    resources:
+      cpu: 100m
+      memory: 64Mi
+  envoy:
+    image: envoyproxy/envoy:v1.7.0
+    args: ["-c", "/etc/envoy.yaml"]
+    ports:
+      - name: admin
+        port: 9901
+`
+
+func TestNewSideCars(t *testing.T) {
+	ty := new(TeresaYaml)
+	if err := yaml.Unmarshal([]byte(sideCarsYaml), ty); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	scs, err := NewSideCars(ty)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	want := []*SideCar{
+		{
+			Name:  "envoy",
+			Image: "envoyproxy/envoy:v1.7.0",
+			Args:  []string{"-c", "/etc/envoy.yaml"},
+			Ports: []SideCarPort{{Name: "admin", Port: 9901}},
+		},
+		{
+			Name:      "fluent-bit",
+			Image:     "fluent/fluent-bit:0.13",
+			Env:       map[string]string{"FLUENT_HOST": "logs"},
+			Resources: &ContainerResources{CPU: "100m", Memory: "64Mi"},
+		},
+	}
+	if !reflect.DeepEqual(scs, want) {
+		t.Errorf("got %v; want %v", scs, want)
+	}
+}
+
+func TestNewSideCarsInvalid(t *testing.T) {
+	var testCases = []string{
+		"sidecars:\n  Envoy:\n    image: envoy",
+		"sidecars:\n  envoy:\n    args: [\"-c\"]",
+		"sidecars:\n  envoy:\n    image: envoy\n    ports:\n      - name: web\n        port: 5000",
+		"sidecars:\n  envoy:\n    image: envoy\n    ports:\n      - name: admin\n        port: 70000",
+		"sidecars:\n  envoy:\n    image: [envoy]",
+	}
+
+	for _, tc := range testCases {
+		ty := new(TeresaYaml)
+		if err := yaml.Unmarshal([]byte(tc), ty); err != nil {
+			t.Fatal("got unexpected error:", err)
+		}
+		if _, err := NewSideCars(ty); err == nil {
+			t.Errorf("got nil; want error for %s", tc)
+		}
+	}
+}
+
+func TestNewSideCarContainer(t *testing.T) {
+	sc := &SideCar{
+		Name:      "envoy",
+		Image:     "envoy",
+		Command:   []string{"envoy"},
+		Env:       map[string]string{"KEY": "value"},
+		Ports:     []SideCarPort{{Name: "admin", Port: 9901}},
+		Resources: &ContainerResources{CPU: "100m", MemoryRequest: "32Mi"},
+	}
+	c := NewSideCarContainer(sc)
+
+	if c.Name != sc.Name || c.Image != sc.Image {
+		t.Errorf("got %s (%s); want %s (%s)", c.Name, c.Image, sc.Name, sc.Image)
+	}
+	if !reflect.DeepEqual(c.Command, sc.Command) {
+		t.Errorf("got %v; want %v", c.Command, sc.Command)
+	}
+	if c.Env["KEY"] != "value" {
+		t.Errorf("got %s; want value", c.Env["KEY"])
+	}
+	if want := []Port{{Name: "admin", ContainerPort: 9901}}; !reflect.DeepEqual(c.Ports, want) {
+		t.Errorf("got %v; want %v", c.Ports, want)
+	}
+	want := &ContainerLimits{CPU: "100m", MemoryRequest: "32Mi"}
+	if !reflect.DeepEqual(c.ContainerLimits, want) {
+		t.Errorf("got %v; want %v", c.ContainerLimits, want)
+	}
+}
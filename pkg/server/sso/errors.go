@@ -0,0 +1,16 @@
+package sso
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrMissingClient      = errors.New("Missing single sign-on client id or secret")
+	ErrInvalidRole        = errors.New("Invalid single sign-on role")
+	ErrNotConfigured      = status.Errorf(codes.FailedPrecondition, "Single sign-on isn't configured on the server")
+	ErrInvalidRedirectURL = status.Errorf(codes.InvalidArgument, "Invalid redirect URL, it must be a local http one")
+	ErrMissingEmail       = status.Errorf(codes.PermissionDenied, "The identity provider didn't send a verified email")
+)
@@ -0,0 +1,37 @@
+package sso
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+// FakeOperations signs in the users of Codes, the emails by code
+type FakeOperations struct {
+	Codes map[string]string
+}
+
+func (f *FakeOperations) AuthURL(redirectURL, state string) (string, error) {
+	if err := checkRedirectURL(redirectURL); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://sso.example.com/auth?redirect_uri=%s&state=%s", redirectURL, state), nil
+}
+
+func (f *FakeOperations) Login(code, redirectURL string, exp time.Duration) (*user.Tokens, string, error) {
+	if err := checkRedirectURL(redirectURL); err != nil {
+		return nil, "", err
+	}
+	email, found := f.Codes[code]
+	if !found {
+		return nil, "", auth.ErrPermissionDenied
+	}
+	tk := &user.Tokens{Access: "good token", Refresh: "good refresh token", ExpiresAt: time.Now().Add(time.Minute)}
+	return tk, email, nil
+}
+
+func NewFakeOperations() Operations {
+	return &FakeOperations{Codes: make(map[string]string)}
+}
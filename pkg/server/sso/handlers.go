@@ -0,0 +1,60 @@
+package sso
+
+import (
+	"time"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	ssopb "github.com/luizalabs/teresa/pkg/protobuf/sso"
+)
+
+// defaultExpiration is the expiration of the tokens, like the ones of the
+// password logins
+const defaultExpiration = 15 * 24 * time.Hour
+
+type Service struct {
+	ops Operations
+}
+
+func (s *Service) BeginLogin(ctx context.Context, request *ssopb.BeginLoginRequest) (*ssopb.BeginLoginResponse, error) {
+	if s.ops == nil {
+		return nil, ErrNotConfigured
+	}
+	u, err := s.ops.AuthURL(request.RedirectUrl, request.State)
+	if err != nil {
+		return nil, err
+	}
+	return &ssopb.BeginLoginResponse{AuthUrl: u}, nil
+}
+
+func (s *Service) Login(ctx context.Context, request *ssopb.LoginRequest) (*ssopb.LoginResponse, error) {
+	if s.ops == nil {
+		return nil, ErrNotConfigured
+	}
+	exp := defaultExpiration
+	if request.ExpiresIn != 0 {
+		exp = time.Duration(request.ExpiresIn)
+	}
+	tk, email, err := s.ops.Login(request.Code, request.RedirectUrl, exp)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ssopb.LoginResponse{
+		Token:        tk.Access,
+		Email:        email,
+		RefreshToken: tk.Refresh,
+		ExpiresAt:    tk.ExpiresAt.Unix(),
+	}
+	return resp, nil
+}
+
+func (s *Service) RegisterService(grpcServer *grpc.Server) {
+	ssopb.RegisterSSOServer(grpcServer, s)
+}
+
+// NewService returns the service of the single sign-on, ops is nil when
+// it isn't configured
+func NewService(ops Operations) *Service {
+	return &Service{ops: ops}
+}
@@ -0,0 +1,51 @@
+package sso
+
+import (
+	"testing"
+
+	context "golang.org/x/net/context"
+
+	ssopb "github.com/luizalabs/teresa/pkg/protobuf/sso"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+)
+
+func TestLogin(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.(*FakeOperations).Codes["good code"] = "gopher@luizalabs.com"
+	s := NewService(fake)
+	ctx := context.Background()
+
+	resp, err := s.BeginLogin(ctx, &ssopb.BeginLoginRequest{RedirectUrl: "http://127.0.0.1:8085/callback", State: "xyz"})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if resp.AuthUrl == "" {
+		t.Error("expected an auth URL, got none")
+	}
+
+	req := &ssopb.LoginRequest{Code: "good code", RedirectUrl: "http://127.0.0.1:8085/callback"}
+	login, err := s.Login(ctx, req)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if login.Token == "" || login.Email != "gopher@luizalabs.com" {
+		t.Errorf("expected a token of gopher@luizalabs.com, got %v", login)
+	}
+
+	req.Code = "bad code"
+	if _, err := s.Login(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestLoginNotConfigured(t *testing.T) {
+	s := NewService(nil)
+	ctx := context.Background()
+
+	if _, err := s.BeginLogin(ctx, &ssopb.BeginLoginRequest{}); err != ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+	if _, err := s.Login(ctx, &ssopb.LoginRequest{}); err != ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
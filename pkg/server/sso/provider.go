@@ -0,0 +1,183 @@
+package sso
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// provider is an OIDC provider, its metadata and keys are fetched on the
+// first use
+type provider struct {
+	issuer   string
+	clientID string
+	client   *http.Client
+
+	mutex    sync.Mutex
+	metadata *providerMetadata
+	keys     map[string]*rsa.PublicKey
+}
+
+// providerMetadata is the part of the discovery document of the provider
+// in use
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+func newProvider(conf *Config) *provider {
+	return &provider{
+		issuer:   strings.TrimSuffix(conf.Issuer, "/"),
+		clientID: conf.ClientID,
+		client:   &http.Client{Timeout: conf.Timeout},
+	}
+}
+
+// getJSON decodes the body of a GET to url into v, any status other than
+// 200 is an error
+func (p *provider) getJSON(url string, v interface{}) error {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GET %s: %s: %s", url, resp.Status, msg)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (p *provider) getMetadata() (*providerMetadata, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+	md := new(providerMetadata)
+	if err := p.getJSON(p.issuer+"/.well-known/openid-configuration", md); err != nil {
+		return nil, err
+	}
+	if md.Issuer != p.issuer {
+		return nil, fmt.Errorf("issuer %s of the discovery document isn't %s", md.Issuer, p.issuer)
+	}
+	p.metadata = md
+	return md, nil
+}
+
+// key returns the RSA key kid of the provider, the keys are fetched again
+// on unknown ids so the rotations of the provider are followed
+func (p *provider) key(kid string) (*rsa.PublicKey, error) {
+	md, err := p.getMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if k, found := p.keys[kid]; found {
+		return k, nil
+	}
+	var jwks struct {
+		Keys []*jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(md.JWKSURI, &jwks); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		k, err := rsaKey(jwk)
+		if err != nil {
+			return nil, err
+		}
+		keys[jwk.Kid] = k
+	}
+	p.keys = keys
+
+	k, found := keys[kid]
+	if !found {
+		return nil, fmt.Errorf("unknown key %s", kid)
+	}
+	return k, nil
+}
+
+func rsaKey(jwk *jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus of key %s: %v", jwk.Kid, err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent of key %s: %v", jwk.Kid, err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// verify checks the signature, issuer, audience and expiration of an ID
+// token and returns its claims
+func (p *provider) verify(idToken string) (jwt.MapClaims, error) {
+	parser := &jwt.Parser{ValidMethods: []string{"RS256"}}
+	token, err := parser.Parse(idToken, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(kid)
+	})
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid ID token: %v", err)
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+	if !claims.VerifyIssuer(p.issuer, true) {
+		return nil, fmt.Errorf("invalid ID token issuer %v", claims["iss"])
+	}
+	if !hasAudience(claims, p.clientID) {
+		return nil, fmt.Errorf("invalid ID token audience %v", claims["aud"])
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("expired ID token")
+	}
+	return claims, nil
+}
+
+// hasAudience tells if the aud claim, a string or a list of them, has the
+// client id
+func hasAudience(claims jwt.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package sso
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/user"
+	context "golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+// Config is the configuration of the OIDC provider, the single sign-on is
+// disabled without an issuer. Groups maps the groups of the provider to
+// the teams their members belong to, with Role.
+type Config struct {
+	Issuer       string            `envconfig:"issuer"`
+	ClientID     string            `envconfig:"client_id"`
+	ClientSecret string            `envconfig:"client_secret"`
+	Scopes       []string          `envconfig:"scopes" default:"openid,email,profile"`
+	GroupsClaim  string            `envconfig:"groups_claim" default:"groups"`
+	Groups       map[string]string `envconfig:"groups"`
+	Role         string            `envconfig:"role" default:"developer"`
+	Timeout      time.Duration     `envconfig:"timeout" default:"30s"`
+}
+
+type Operations interface {
+	AuthURL(redirectURL, state string) (string, error)
+	Login(code, redirectURL string, exp time.Duration) (*user.Tokens, string, error)
+}
+
+type OIDCOperations struct {
+	conf     *Config
+	provider *provider
+	uOps     user.Operations
+	tOps     team.Operations
+}
+
+// identity is the user signed in by the provider
+type identity struct {
+	email  string
+	name   string
+	groups []string
+}
+
+// checkRedirectURL allows only the local http URLs, where the client waits
+// for the code
+func checkRedirectURL(redirectURL string) error {
+	u, err := url.Parse(redirectURL)
+	if err != nil || u.Scheme != "http" {
+		return ErrInvalidRedirectURL
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return ErrInvalidRedirectURL
+	}
+	return nil
+}
+
+func (ops *OIDCOperations) oauth2Config(redirectURL string) (*oauth2.Config, error) {
+	md, err := ops.provider.getMetadata()
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	return &oauth2.Config{
+		ClientID:     ops.conf.ClientID,
+		ClientSecret: ops.conf.ClientSecret,
+		Endpoint:     oauth2.Endpoint{AuthURL: md.AuthorizationEndpoint, TokenURL: md.TokenEndpoint},
+		RedirectURL:  redirectURL,
+		Scopes:       ops.conf.Scopes,
+	}, nil
+}
+
+// AuthURL returns the URL of the provider the user signs in, which
+// redirects to redirectURL with the code and the state
+func (ops *OIDCOperations) AuthURL(redirectURL, state string) (string, error) {
+	if err := checkRedirectURL(redirectURL); err != nil {
+		return "", err
+	}
+	cfg, err := ops.oauth2Config(redirectURL)
+	if err != nil {
+		return "", err
+	}
+	return cfg.AuthCodeURL(state), nil
+}
+
+// Login exchanges the code of the provider for an ID token and returns the
+// tokens of a session of the user signed in, along with its email. The users are created
+// on their first login and added to (or removed from) the teams of their
+// groups on every one.
+func (ops *OIDCOperations) Login(code, redirectURL string, exp time.Duration) (*user.Tokens, string, error) {
+	if err := checkRedirectURL(redirectURL); err != nil {
+		return nil, "", err
+	}
+	cfg, err := ops.oauth2Config(redirectURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, ops.provider.client)
+	tk, err := cfg.Exchange(ctx, code)
+	if err != nil {
+		log.WithError(err).Warn("exchanging the single sign-on code")
+		return nil, "", auth.ErrPermissionDenied
+	}
+	idToken, _ := tk.Extra("id_token").(string)
+	claims, err := ops.provider.verify(idToken)
+	if err != nil {
+		log.WithError(err).Warn("verifying the single sign-on ID token")
+		return nil, "", auth.ErrPermissionDenied
+	}
+	id, err := ops.identityOf(claims)
+	if err != nil {
+		return nil, "", err
+	}
+
+	created, err := user.Provision(ops.uOps, id.name, id.email)
+	if err != nil {
+		return nil, "", err
+	}
+	if created {
+		log.WithField("email", id.email).Info("user created by the single sign-on")
+	}
+	if err := team.SyncGroups(ops.tOps, id.email, id.groups, ops.conf.Groups, ops.conf.Role); err != nil {
+		return nil, "", err
+	}
+	tokens, err := ops.uOps.CreateSession(id.email, false, exp)
+	if err != nil {
+		return nil, "", err
+	}
+	return tokens, id.email, nil
+}
+
+func (ops *OIDCOperations) identityOf(claims jwt.MapClaims) (*identity, error) {
+	email, _ := claims["email"].(string)
+	if verified, found := claims["email_verified"].(bool); email == "" || (found && !verified) {
+		return nil, ErrMissingEmail
+	}
+	id := &identity{email: email}
+	id.name, _ = claims["name"].(string)
+
+	switch groups := claims[ops.conf.GroupsClaim].(type) {
+	case string:
+		id.groups = []string{groups}
+	case []interface{}:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				id.groups = append(id.groups, s)
+			}
+		}
+	}
+	return id, nil
+}
+
+// New returns the operations of the single sign-on, nil if it isn't
+// configured
+func New(conf *Config, uOps user.Operations, tOps team.Operations) (Operations, error) {
+	if conf == nil || conf.Issuer == "" {
+		return nil, nil
+	}
+	if conf.ClientID == "" || conf.ClientSecret == "" {
+		return nil, ErrMissingClient
+	}
+	if !team.IsValidRole(conf.Role) {
+		return nil, ErrInvalidRole
+	}
+	return &OIDCOperations{
+		conf:     conf,
+		provider: newProvider(conf),
+		uOps:     uOps,
+		tOps:     tOps,
+	}, nil
+}
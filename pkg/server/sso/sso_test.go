@@ -0,0 +1,230 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+const (
+	testClientID = "teresa"
+	testCode     = "good code"
+)
+
+// fakeProvider is an OIDC provider signing in the user of its claims
+type fakeProvider struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal("error generating key:", err)
+	}
+	p := &fakeProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&providerMetadata{
+			Issuer:                p.URL,
+			AuthorizationEndpoint: p.URL + "/auth",
+			TokenEndpoint:         p.URL + "/token",
+			JWKSURI:               p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		jwk := &jsonWebKey{
+			Kid: "1",
+			Kty: "RSA",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []*jsonWebKey{jwk}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != testCode {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims)
+		token.Header["kid"] = "1"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token":     idToken,
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	return p
+}
+
+func (p *fakeProvider) signIn(email string, groups ...string) {
+	p.claims = jwt.MapClaims{
+		"iss":            p.URL,
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          email,
+		"email_verified": true,
+		"name":           "Gopher",
+		"groups":         groups,
+	}
+}
+
+func newTestOperations(p *fakeProvider, groups map[string]string) (Operations, user.Operations, team.Operations) {
+	uOps := user.NewFakeOperations()
+	tOps := team.NewFakeOperations()
+	tOps.(*team.FakeOperations).UserOps = uOps
+	for _, name := range []string{"platform", "payments"} {
+		tOps.(*team.FakeOperations).Storage[name] = &database.Team{Name: name}
+	}
+	conf := &Config{
+		Issuer:       p.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		Scopes:       []string{"openid", "email"},
+		GroupsClaim:  "groups",
+		Groups:       groups,
+		Role:         team.RoleDeveloper,
+		Timeout:      time.Second,
+	}
+	ops, _ := New(conf, uOps, tOps)
+	return ops, uOps, tOps
+}
+
+func TestOIDCOperationsLogin(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.Close()
+	groups := map[string]string{"platform-devs": "platform", "payments-devs": "payments"}
+	ops, uOps, tOps := newTestOperations(p, groups)
+	email := "gopher@luizalabs.com"
+	redirectURL := "http://127.0.0.1:8085/callback"
+
+	p.signIn(email, "platform-devs", "others")
+	tk, gotEmail, err := ops.Login(testCode, redirectURL, time.Hour)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if tk.Access == "" || tk.Refresh == "" || gotEmail != email {
+		t.Errorf("expected the tokens of %s, got %v of %s", email, tk, gotEmail)
+	}
+	if u, err := uOps.GetUser(email); err != nil || u.Email != email {
+		t.Errorf("expected the user created, got %v (err: %v)", u, err)
+	}
+	if ok, _ := tOps.HasUser("platform", email); !ok {
+		t.Error("expected the user in the team platform")
+	}
+	if ok, _ := tOps.HasUser("payments", email); ok {
+		t.Error("expected the user out of the team payments")
+	}
+
+	p.signIn(email, "payments-devs")
+	if _, _, err := ops.Login(testCode, redirectURL, time.Hour); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if ok, _ := tOps.HasUser("platform", email); ok {
+		t.Error("expected the user removed from the team platform")
+	}
+	if ok, _ := tOps.HasUser("payments", email); !ok {
+		t.Error("expected the user in the team payments")
+	}
+}
+
+func TestOIDCOperationsLoginDenied(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.Close()
+	ops, _, _ := newTestOperations(p, nil)
+	redirectURL := "http://localhost:8085/callback"
+
+	p.signIn("gopher@luizalabs.com")
+	if _, _, err := ops.Login("bad code", redirectURL, time.Hour); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	p.claims["aud"] = "other"
+	if _, _, err := ops.Login(testCode, redirectURL, time.Hour); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for other audience, got %v", err)
+	}
+
+	p.signIn("gopher@luizalabs.com")
+	p.claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	if _, _, err := ops.Login(testCode, redirectURL, time.Hour); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied for expired token, got %v", err)
+	}
+
+	p.signIn("gopher@luizalabs.com")
+	p.claims["email_verified"] = false
+	if _, _, err := ops.Login(testCode, redirectURL, time.Hour); err != ErrMissingEmail {
+		t.Errorf("expected ErrMissingEmail, got %v", err)
+	}
+}
+
+func TestOIDCOperationsAuthURL(t *testing.T) {
+	p := newFakeProvider(t)
+	defer p.Close()
+	ops, _, _ := newTestOperations(p, nil)
+
+	u, err := ops.AuthURL("http://127.0.0.1:8085/callback", "xyz")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	expected := p.URL + "/auth?client_id=teresa&redirect_uri=http%3A%2F%2F127.0.0.1%3A8085%2Fcallback&response_type=code&scope=openid+email&state=xyz"
+	if u != expected {
+		t.Errorf("expected %s, got %s", expected, u)
+	}
+}
+
+func TestCheckRedirectURL(t *testing.T) {
+	var testCases = []struct {
+		url         string
+		expectedErr error
+	}{
+		{"http://127.0.0.1:8085/callback", nil},
+		{"http://localhost:8085/callback", nil},
+		{"http://[::1]:8085/callback", nil},
+		{"https://127.0.0.1:8085/callback", ErrInvalidRedirectURL},
+		{"http://evil.com/callback", ErrInvalidRedirectURL},
+		{"http://10.0.0.1/callback", ErrInvalidRedirectURL},
+		{"%", ErrInvalidRedirectURL},
+	}
+
+	for _, tc := range testCases {
+		if err := checkRedirectURL(tc.url); err != tc.expectedErr {
+			t.Errorf("expected %v for %s, got %v", tc.expectedErr, tc.url, err)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	ops, err := New(&Config{}, user.NewFakeOperations(), team.NewFakeOperations())
+	if ops != nil || err != nil {
+		t.Errorf("expected no operations without issuer, got %v (err: %v)", ops, err)
+	}
+	conf := &Config{Issuer: "https://sso.example.com", Role: team.RoleDeveloper}
+	if _, err := New(conf, user.NewFakeOperations(), team.NewFakeOperations()); err != ErrMissingClient {
+		t.Errorf("expected ErrMissingClient, got %v", err)
+	}
+	conf.ClientID, conf.ClientSecret, conf.Role = "teresa", "secret", "owner"
+	if _, err := New(conf, user.NewFakeOperations(), team.NewFakeOperations()); err != ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
@@ -1,7 +1,10 @@
 package storage
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"time"
 )
 
@@ -10,6 +13,8 @@ type fake struct {
 	Secret string
 	Region string
 	Bucket string
+	// Files are the contents returned by Download
+	Files map[string][]byte
 }
 
 func (f *fake) K8sSecretName() string {
@@ -40,6 +45,23 @@ func (f *fake) Delete(path string) error {
 	return nil
 }
 
+func (f *fake) Download(path string) (io.ReadCloser, error) {
+	b, found := f.Files[path]
+	if !found {
+		return nil, fmt.Errorf("%s not found", path)
+	}
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
+}
+
+func (f *fake) Copy(src, dst string) error {
+	b, found := f.Files[src]
+	if !found {
+		return fmt.Errorf("%s not found", src)
+	}
+	f.Files[dst] = b
+	return nil
+}
+
 func (f *fake) Type() string {
 	return string(FakeType)
 }
@@ -54,5 +76,6 @@ func NewFake() *fake {
 		Region: "region",
 		Secret: "secret",
 		Bucket: "bucket",
+		Files:  make(map[string][]byte),
 	}
 }
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"io/ioutil"
 	"testing"
 )
 
@@ -75,3 +76,19 @@ func TestFakeDelete(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+func TestFakeDownload(t *testing.T) {
+	fake := NewFake()
+	fake.Files["slug.tgz"] = []byte("slug")
+
+	if _, err := fake.Download("other.tgz"); err == nil {
+		t.Error("expected error, got nil")
+	}
+	rc, err := fake.Download("slug.tgz")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if b, _ := ioutil.ReadAll(rc); string(b) != "slug" {
+		t.Errorf("expected slug, got %s", b)
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"io"
+	"net/url"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -14,6 +15,8 @@ type S3Client interface {
 	PutObject(*s3.PutObjectInput) (*s3.PutObjectOutput, error)
 	ListObjects(*s3.ListObjectsInput) (*s3.ListObjectsOutput, error)
 	DeleteObject(*s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error)
+	GetObject(*s3.GetObjectInput) (*s3.GetObjectOutput, error)
+	CopyObject(*s3.CopyObjectInput) (*s3.CopyObjectOutput, error)
 }
 
 type S3 struct {
@@ -51,20 +54,25 @@ func (s *S3) UploadFile(path string, file io.ReadSeeker) error {
 }
 
 func (s *S3) List(path string) ([]*Object, error) {
-	res, err := s.s3List(path)
+	items, err := s.s3List(path)
 	if err != nil {
 		return nil, err
 	}
 
 	out := []*Object{}
-	m := make(map[string]bool)
-	for _, item := range res.Contents {
+	m := make(map[string]*Object)
+	for _, item := range items {
 		name := strings.TrimPrefix(*item.Key, path)
 		name = strings.Split(name, "/")[0]
-		if _, found := m[name]; !found {
-			m[name] = true
-			out = append(out, &Object{Name: name, LastModified: *item.LastModified})
+		if obj, found := m[name]; found {
+			if item.LastModified.After(obj.LastModified) {
+				obj.LastModified = *item.LastModified
+			}
+			continue
 		}
+		obj := &Object{Name: name, LastModified: *item.LastModified}
+		m[name] = obj
+		out = append(out, obj)
 	}
 
 	return out, nil
@@ -76,7 +84,7 @@ func (s *S3) Delete(path string) error {
 		return err
 	}
 
-	for _, obj := range objs.Contents {
+	for _, obj := range objs {
 		di := &s3.DeleteObjectInput{
 			Bucket: &s.Bucket,
 			Key:    obj.Key,
@@ -89,13 +97,54 @@ func (s *S3) Delete(path string) error {
 	return nil
 }
 
-func (s *S3) s3List(path string) (*s3.ListObjectsOutput, error) {
+func (s *S3) Download(path string) (io.ReadCloser, error) {
+	gi := &s3.GetObjectInput{
+		Bucket: &s.Bucket,
+		Key:    &path,
+	}
+	out, err := s.Client.GetObject(gi)
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// Copy copies the object inside the bucket, without downloading it
+func (s *S3) Copy(src, dst string) error {
+	ci := &s3.CopyObjectInput{
+		Bucket:     &s.Bucket,
+		CopySource: aws.String(url.PathEscape(s.Bucket + "/" + src)),
+		Key:        &dst,
+	}
+	_, err := s.Client.CopyObject(ci)
+	return err
+}
+
+// s3List returns all the objects under path, going through the pages of
+// the listing (up to 1000 objects each)
+func (s *S3) s3List(path string) ([]*s3.Object, error) {
 	li := &s3.ListObjectsInput{
 		Bucket: &s.Bucket,
 		Prefix: aws.String(path),
 	}
 
-	return s.Client.ListObjects(li)
+	var objs []*s3.Object
+	for {
+		res, err := s.Client.ListObjects(li)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, res.Contents...)
+		if !aws.BoolValue(res.IsTruncated) || len(res.Contents) == 0 {
+			return objs, nil
+		}
+		// NextMarker is only set when listing with a delimiter
+		marker := res.NextMarker
+		if marker == nil {
+			marker = res.Contents[len(res.Contents)-1].Key
+		}
+		li.Marker = marker
+	}
 }
 
 func (s *S3) Type() string {
@@ -1,9 +1,13 @@
 package storage
 
 import (
+	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
@@ -32,6 +36,14 @@ func (f *fakeS3Client) DeleteObject(*s3.DeleteObjectInput) (*s3.DeleteObjectOutp
 	return nil, nil
 }
 
+func (f *fakeS3Client) CopyObject(*s3.CopyObjectInput) (*s3.CopyObjectOutput, error) {
+	return nil, nil
+}
+
+func (f *fakeS3Client) GetObject(*s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader("slug"))}, nil
+}
+
 func TestS3K8sSecretName(t *testing.T) {
 	s3 := newS3(&Config{})
 
@@ -95,6 +107,29 @@ func TestS3Delete(t *testing.T) {
 	}
 }
 
+func TestS3Download(t *testing.T) {
+	s3 := newS3(&Config{})
+	s3.Client = &fakeS3Client{}
+
+	rc, err := s3.Download("/test")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	defer rc.Close()
+	if b, _ := ioutil.ReadAll(rc); string(b) != "slug" {
+		t.Errorf("expected slug, got %s", b)
+	}
+}
+
+func TestS3Copy(t *testing.T) {
+	s3 := newS3(&Config{})
+	s3.Client = &fakeS3Client{}
+
+	if err := s3.Copy("/src", "/dst"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
 func TestS3PodEnvVars(t *testing.T) {
 	s3 := newS3(&Config{})
 	ev := s3.PodEnvVars()
@@ -110,3 +145,59 @@ func TestS3List(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 }
+
+// pagedS3Client lists its keys in pages of two, like S3 does of 1000
+type pagedS3Client struct {
+	fakeS3Client
+	keys    []string
+	deleted []string
+}
+
+func (f *pagedS3Client) ListObjects(li *s3.ListObjectsInput) (*s3.ListObjectsOutput, error) {
+	start := 0
+	for i, key := range f.keys {
+		if li.Marker != nil && key == *li.Marker {
+			start = i + 1
+		}
+	}
+	end := start + 2
+	if end > len(f.keys) {
+		end = len(f.keys)
+	}
+	out := &s3.ListObjectsOutput{IsTruncated: aws.Bool(end < len(f.keys))}
+	for i, key := range f.keys[start:end] {
+		t := time.Date(2026, 1, 1, 0, start+i, 0, 0, time.UTC)
+		out.Contents = append(out.Contents, &s3.Object{Key: aws.String(key), LastModified: &t})
+	}
+	return out, nil
+}
+
+func (f *pagedS3Client) DeleteObject(di *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	f.deleted = append(f.deleted, *di.Key)
+	return nil, nil
+}
+
+func TestS3ListPages(t *testing.T) {
+	st := newS3(&Config{})
+	client := &pagedS3Client{keys: []string{"b/a/1", "b/a/2", "b/b/1", "b/c/1", "b/c/2"}}
+	st.Client = client
+
+	objs, err := st.List("b/")
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objs))
+	}
+	newest := time.Date(2026, 1, 1, 0, 4, 0, 0, time.UTC)
+	if objs[2].Name != "c" || !objs[2].LastModified.Equal(newest) {
+		t.Errorf("expected c modified at %s, got %s at %s", newest, objs[2].Name, objs[2].LastModified)
+	}
+
+	if err := st.Delete("b/"); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !reflect.DeepEqual(client.deleted, client.keys) {
+		t.Errorf("expected %v deleted, got %v", client.keys, client.deleted)
+	}
+}
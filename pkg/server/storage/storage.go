@@ -24,6 +24,8 @@ type Config struct {
 	AwsS3ForcePathStyle bool        `envconfig:"aws_s3_force_path_style" default:"false"`
 }
 
+// Object is a file or a directory of the storage, LastModified is the one
+// of its newest file for a directory
 type Object struct {
 	Name         string
 	LastModified time.Time
@@ -37,6 +39,8 @@ type Storage interface {
 	PodEnvVars() map[string]string
 	List(path string) ([]*Object, error)
 	Delete(path string) error
+	Download(path string) (io.ReadCloser, error)
+	Copy(src, dst string) error
 }
 
 func New(conf *Config) (Storage, error) {
@@ -0,0 +1,144 @@
+package team
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/validation"
+	"github.com/pkg/errors"
+)
+
+// EnvVar is a default env var of the apps of a team, merged into their
+// pods at deploy time unless the app sets it too
+type EnvVar struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func checkForInvalidEnvVars(keys []string) error {
+	for _, key := range keys {
+		if !validation.IsEnvVarName(key) {
+			return ErrInvalidEnvVarName
+		}
+		if validation.IsProtectedEnvVar(key) {
+			return ErrProtectedEnvVar
+		}
+	}
+	return nil
+}
+
+func envVarsOf(t *database.Team) ([]*EnvVar, error) {
+	var evs []*EnvVar
+	if t.EnvVars == "" {
+		return evs, nil
+	}
+	if err := json.Unmarshal([]byte(t.EnvVars), &evs); err != nil {
+		return nil, err
+	}
+	return evs, nil
+}
+
+func setEnvVarsOn(t *database.Team, evs []*EnvVar) error {
+	b, err := json.Marshal(evs)
+	if err != nil {
+		return err
+	}
+	t.EnvVars = string(b)
+	return nil
+}
+
+// mergeEnvVars sets the env vars evs on cur, updating the existing ones
+func mergeEnvVars(cur, evs []*EnvVar) []*EnvVar {
+	for _, ev := range evs {
+		found := false
+		for _, c := range cur {
+			if c.Key == ev.Key {
+				c.Value = ev.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			cur = append(cur, ev)
+		}
+	}
+	return cur
+}
+
+// removeEnvVars removes the env vars with the given keys from cur
+func removeEnvVars(cur []*EnvVar, keys []string) []*EnvVar {
+	remove := make(map[string]bool)
+	for _, k := range keys {
+		remove[k] = true
+	}
+	evs := make([]*EnvVar, 0, len(cur))
+	for _, ev := range cur {
+		if !remove[ev.Key] {
+			evs = append(evs, ev)
+		}
+	}
+	return evs
+}
+
+func envVarsKeys(evs []*EnvVar) []string {
+	keys := make([]string, len(evs))
+	for i := range evs {
+		keys[i] = evs[i].Key
+	}
+	return keys
+}
+
+// updateEnvVars applies change to the env vars of the team and saves them
+func (dbt *DatabaseOperations) updateEnvVars(name string, change func([]*EnvVar) []*EnvVar) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	evs, err := envVarsOf(t)
+	if err == nil {
+		err = setEnvVarsOn(t, change(evs))
+	}
+	if err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("updating the env vars of team %s", name)),
+		)
+	}
+	return dbt.save(t)
+}
+
+// SetEnv creates or updates the default env vars of the team apps, they
+// take effect on the next deploy of each app
+func (dbt *DatabaseOperations) SetEnv(name string, evs []*EnvVar) error {
+	if err := checkForInvalidEnvVars(envVarsKeys(evs)); err != nil {
+		return err
+	}
+	return dbt.updateEnvVars(name, func(cur []*EnvVar) []*EnvVar {
+		return mergeEnvVars(cur, evs)
+	})
+}
+
+// UnsetEnv removes default env vars of the team apps
+func (dbt *DatabaseOperations) UnsetEnv(name string, keys []string) error {
+	if err := checkForInvalidEnvVars(keys); err != nil {
+		return err
+	}
+	return dbt.updateEnvVars(name, func(cur []*EnvVar) []*EnvVar {
+		return removeEnvVars(cur, keys)
+	})
+}
+
+func (dbt *DatabaseOperations) EnvVars(name string) ([]*EnvVar, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+	evs, err := envVarsOf(t)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	return evs, nil
+}
@@ -0,0 +1,81 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+func TestDatabaseOperationsEnv(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	evs := []*EnvVar{{Key: "LOG_LEVEL", Value: "info"}, {Key: "REGION", Value: "br"}}
+	if err := dbt.SetEnv(name, evs); err != nil {
+		t.Fatal("error setting env vars:", err)
+	}
+	if err := dbt.SetEnv(name, []*EnvVar{{Key: "LOG_LEVEL", Value: "debug"}}); err != nil {
+		t.Fatal("error setting env vars:", err)
+	}
+	if err := dbt.UnsetEnv(name, []string{"REGION"}); err != nil {
+		t.Fatal("error unsetting env vars:", err)
+	}
+
+	got, err := dbt.EnvVars(name)
+	if err != nil {
+		t.Fatal("error getting env vars:", err)
+	}
+	if len(got) != 1 || got[0].Key != "LOG_LEVEL" || got[0].Value != "debug" {
+		t.Errorf("expected only LOG_LEVEL=debug, got %v", got)
+	}
+}
+
+func TestDatabaseOperationsSetEnvInvalid(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	var testCases = []struct {
+		key         string
+		expectedErr error
+	}{
+		{"1FOO", ErrInvalidEnvVarName},
+		{"FOO BAR", ErrInvalidEnvVarName},
+		{"PYTHONPATH", ErrProtectedEnvVar},
+	}
+
+	for _, tc := range testCases {
+		err := dbt.SetEnv(name, []*EnvVar{{Key: tc.key, Value: "bar"}})
+		if err != tc.expectedErr {
+			t.Errorf("expected %v for %s, got %v", tc.expectedErr, tc.key, err)
+		}
+	}
+	if err := dbt.SetEnv("gophers", []*EnvVar{{Key: "FOO", Value: "bar"}}); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
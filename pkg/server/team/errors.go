@@ -6,8 +6,21 @@ import (
 )
 
 var (
-	ErrTeamAlreadyExists = status.Errorf(codes.AlreadyExists, "Team already exists")
-	ErrUserAlreadyInTeam = status.Errorf(codes.AlreadyExists, "User already in Team")
-	ErrNotFound          = status.Errorf(codes.NotFound, "Team Not Found")
-	ErrUserNotInTeam     = status.Errorf(codes.NotFound, "User not in team")
+	ErrTeamAlreadyExists      = status.Errorf(codes.AlreadyExists, "Team already exists")
+	ErrUserAlreadyInTeam      = status.Errorf(codes.AlreadyExists, "User already in Team")
+	ErrNotFound               = status.Errorf(codes.NotFound, "Team Not Found")
+	ErrUserNotInTeam          = status.Errorf(codes.NotFound, "User not in team")
+	ErrInvalidDomain          = status.Errorf(codes.InvalidArgument, "Invalid domain")
+	ErrInvalidFreeze          = status.Errorf(codes.InvalidArgument, "Invalid freeze window, it must end after its start")
+	ErrFreezeNotFound         = status.Errorf(codes.NotFound, "Freeze window not found")
+	ErrTeamHasApps            = status.Errorf(codes.FailedPrecondition, "Team still has apps, delete or transfer them first")
+	ErrInvalidQuota           = status.Errorf(codes.InvalidArgument, "Invalid quota")
+	ErrInvalidEnvVarName      = status.Errorf(codes.InvalidArgument, "Invalid Env Var Name")
+	ErrProtectedEnvVar        = status.Errorf(codes.InvalidArgument, "Can't change protected env vars")
+	ErrInvalidRole            = status.Errorf(codes.InvalidArgument, "Invalid role, it must be admin, developer, deployer or viewer")
+	ErrInvalidTokenName       = status.Errorf(codes.InvalidArgument, "Invalid token name")
+	ErrInvalidTokenRole       = status.Errorf(codes.InvalidArgument, "Invalid token role, it must be developer, deployer or viewer")
+	ErrTokenAlreadyExists     = status.Errorf(codes.AlreadyExists, "Token already exists")
+	ErrTokenNotFound          = status.Errorf(codes.NotFound, "Token not found")
+	ErrInvalidTokenExpiration = status.Errorf(codes.InvalidArgument, "Invalid token expiration, it must be up to 365 days")
 )
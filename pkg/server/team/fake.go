@@ -1,7 +1,10 @@
 package team
 
 import (
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/teamext"
@@ -11,6 +14,15 @@ import (
 type FakeOperations struct {
 	mutex   *sync.RWMutex
 	Storage map[string]*database.Team
+	// Freezes are the freeze windows by team name
+	Freezes map[string][]*database.FreezeWindow
+	// Roles are the roles of the members by team name and user email, the
+	// members without one are admins
+	Roles map[string]map[string]string
+	// Usages are the usages returned by Usage by team name
+	Usages map[string]*teamext.Usage
+	// Tokens are the tokens by team name
+	Tokens map[string][]*Token
 
 	UserOps user.Operations
 }
@@ -27,7 +39,14 @@ func (f *FakeOperations) Create(name, email, url string) error {
 	return nil
 }
 
-func (f *FakeOperations) AddUser(name, userEmail string) error {
+func (f *FakeOperations) AddUser(name, userEmail, role string) error {
+	if role == "" {
+		role = RoleDeveloper
+	}
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -48,9 +67,98 @@ func (f *FakeOperations) AddUser(name, userEmail string) error {
 	}
 
 	t.Users = append(t.Users, *u)
+	f.setRole(name, userEmail, role)
 	return nil
 }
 
+func (f *FakeOperations) setRole(name, userEmail, role string) {
+	if f.Roles[name] == nil {
+		f.Roles[name] = make(map[string]string)
+	}
+	f.Roles[name][userEmail] = role
+}
+
+func (f *FakeOperations) Role(name, userEmail string) (string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return "", ErrNotFound
+	}
+
+	for _, userOfTeam := range t.Users {
+		if userOfTeam.Email != userEmail {
+			continue
+		}
+		if role, found := f.Roles[name][userEmail]; found {
+			return role, nil
+		}
+		return RoleAdmin, nil
+	}
+	return "", nil
+}
+
+func (f *FakeOperations) ListUsers(name string) ([]*Member, error) {
+	f.mutex.RLock()
+	t, found := f.Storage[name]
+	f.mutex.RUnlock()
+	if !found {
+		return nil, ErrNotFound
+	}
+
+	var members []*Member
+	for _, u := range t.Users {
+		role, _ := f.Role(name, u.Email)
+		members = append(members, &Member{Name: u.Name, Email: u.Email, Role: role})
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Email < members[j].Email })
+	return members, nil
+}
+
+func (f *FakeOperations) SetRole(name, userEmail, role string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	cur, err := f.Role(name, userEmail)
+	if err != nil {
+		return err
+	}
+	if cur == "" {
+		return ErrUserNotInTeam
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.setRole(name, userEmail, role)
+	return nil
+}
+
+func (f *FakeOperations) HasRole(name string, user *database.User, role string) (bool, error) {
+	r, err := f.Role(name, user.Email)
+	if err != nil {
+		return false, err
+	}
+	if !RoleAllows(r, role) {
+		return false, nil
+	}
+
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t := f.Storage[name]
+	if !t.RequireTwoFactor || user.TwoFactorVerified {
+		return true, nil
+	}
+	for _, tk := range f.Tokens[name] {
+		if tk.User == user.Email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (f *FakeOperations) HasUser(name, userEmail string) (bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
@@ -115,7 +223,22 @@ func (f *FakeOperations) RemoveUser(name, userEmail string) error {
 	}
 
 	t.Users = append(t.Users[:idx], t.Users[idx+1:]...)
+	delete(f.Roles[name], userEmail)
+
+	return nil
+}
+
+func (f *FakeOperations) Delete(name string, cascade bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
 
+	if _, found := f.Storage[name]; !found {
+		return ErrNotFound
+	}
+
+	delete(f.Storage, name)
+	delete(f.Freezes, name)
+	delete(f.Roles, name)
 	return nil
 }
 
@@ -136,12 +259,279 @@ func (f *FakeOperations) Rename(oldName, newName string) error {
 	return nil
 }
 
+func (f *FakeOperations) SetDomains(name string, domains []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+
+	t.Domains = strings.Join(domains, ",")
+	return nil
+}
+
+func (f *FakeOperations) Get(name string) (*database.Team, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return t, nil
+}
+
+func (f *FakeOperations) SetQuota(name string, q *Quota) error {
+	if err := validateQuota(q); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+	t.QuotaApps = q.Apps
+	t.QuotaReplicas = q.Replicas
+	t.QuotaCPU = q.CPU
+	t.QuotaMemory = q.Memory
+	return nil
+}
+
+// Quota returns the quota of the team, the teams not in the storage are
+// unlimited
+func (f *FakeOperations) Quota(name string) (*Quota, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return &Quota{}, nil
+	}
+	return quotaOf(t), nil
+}
+
+func (f *FakeOperations) Usage(name string) (*teamext.Usage, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if _, found := f.Storage[name]; !found {
+		return nil, ErrNotFound
+	}
+	if u, found := f.Usages[name]; found {
+		return u, nil
+	}
+	return &teamext.Usage{}, nil
+}
+
+func (f *FakeOperations) updateEnvVars(name string, change func([]*EnvVar) []*EnvVar) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+	evs, err := envVarsOf(t)
+	if err != nil {
+		return err
+	}
+	return setEnvVarsOn(t, change(evs))
+}
+
+func (f *FakeOperations) SetEnv(name string, evs []*EnvVar) error {
+	if err := checkForInvalidEnvVars(envVarsKeys(evs)); err != nil {
+		return err
+	}
+	return f.updateEnvVars(name, func(cur []*EnvVar) []*EnvVar {
+		return mergeEnvVars(cur, evs)
+	})
+}
+
+func (f *FakeOperations) UnsetEnv(name string, keys []string) error {
+	if err := checkForInvalidEnvVars(keys); err != nil {
+		return err
+	}
+	return f.updateEnvVars(name, func(cur []*EnvVar) []*EnvVar {
+		return removeEnvVars(cur, keys)
+	})
+}
+
+func (f *FakeOperations) EnvVars(name string) ([]*EnvVar, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return envVarsOf(t)
+}
+
+func (f *FakeOperations) Domains(name string) ([]string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return splitDomains(t.Domains), nil
+}
+
+func (f *FakeOperations) SetApproval(name string, apps []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+
+	t.ProtectedApps = strings.Join(apps, ",")
+	return nil
+}
+
+func (f *FakeOperations) ProtectedApps(name string) ([]string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return nil, ErrNotFound
+	}
+	return splitDomains(t.ProtectedApps), nil
+}
+
+func (f *FakeOperations) AddFreezeWindow(name string, startsAt, endsAt time.Time, reason string) error {
+	if err := validateFreezeWindow(startsAt, endsAt, reason); err != nil {
+		return err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+
+	w := &database.FreezeWindow{TeamID: t.ID, StartsAt: startsAt, EndsAt: endsAt, Reason: reason}
+	w.ID = uint(len(f.Freezes[name]) + 1)
+	f.Freezes[name] = append(f.Freezes[name], w)
+	return nil
+}
+
+func (f *FakeOperations) RemoveFreezeWindow(name string, id uint) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Storage[name]; !found {
+		return ErrNotFound
+	}
+
+	for i, w := range f.Freezes[name] {
+		if w.ID == id {
+			f.Freezes[name] = append(f.Freezes[name][:i], f.Freezes[name][i+1:]...)
+			return nil
+		}
+	}
+	return ErrFreezeNotFound
+}
+
+func (f *FakeOperations) FreezeWindows(name string) ([]*database.FreezeWindow, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if _, found := f.Storage[name]; !found {
+		return nil, ErrNotFound
+	}
+	return f.Freezes[name], nil
+}
+
 func (f *FakeOperations) SetTeamExt(ext teamext.TeamExt) {
 }
 
+func (f *FakeOperations) CreateToken(name, tokenName, role string, exp time.Duration) (*Token, string, error) {
+	if exp == 0 {
+		exp = DefaultTokenExpiration
+	}
+	if err := validateToken(tokenName, role, exp); err != nil {
+		return nil, "", err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Storage[name]; !found {
+		return nil, "", ErrNotFound
+	}
+	for _, tk := range f.Tokens[name] {
+		if tk.Name == tokenName {
+			return nil, "", ErrTokenAlreadyExists
+		}
+	}
+	now := time.Now()
+	tk := &Token{
+		Name:      tokenName,
+		Role:      role,
+		User:      serviceAccountEmail(tokenName),
+		CreatedAt: now,
+		ExpiresAt: now.Add(exp),
+	}
+	f.Tokens[name] = append(f.Tokens[name], tk)
+	return tk, "good token", nil
+}
+
+func (f *FakeOperations) RevokeToken(name, tokenName string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Storage[name]; !found {
+		return ErrNotFound
+	}
+	for i, tk := range f.Tokens[name] {
+		if tk.Name == tokenName {
+			f.Tokens[name] = append(f.Tokens[name][:i], f.Tokens[name][i+1:]...)
+			return nil
+		}
+	}
+	return ErrTokenNotFound
+}
+
+func (f *FakeOperations) ListTokens(name string) ([]*Token, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if _, found := f.Storage[name]; !found {
+		return nil, ErrNotFound
+	}
+	return f.Tokens[name], nil
+}
+
+func (f *FakeOperations) SetTwoFactorRequired(name string, required bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	t, found := f.Storage[name]
+	if !found {
+		return ErrNotFound
+	}
+	t.RequireTwoFactor = required
+	return nil
+}
+
 func NewFakeOperations() Operations {
 	return &FakeOperations{
 		mutex:   &sync.RWMutex{},
 		Storage: make(map[string]*database.Team),
+		Freezes: make(map[string][]*database.FreezeWindow),
+		Roles:   make(map[string]map[string]string),
+		Usages:  make(map[string]*teamext.Usage),
+		Tokens:  make(map[string][]*Token),
 		UserOps: user.NewFakeOperations()}
 }
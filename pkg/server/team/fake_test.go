@@ -56,7 +56,7 @@ func TestFakeOperationsAddUser(t *testing.T) {
 		t.Fatal("error trying to create a fake team:", err)
 	}
 
-	if err := fake.AddUser(expectedTeam, expectedUserEmail); err != nil {
+	if err := fake.AddUser(expectedTeam, expectedUserEmail, RoleDeveloper); err != nil {
 		t.Errorf("error trying on add user to a team: %v", err)
 	}
 }
@@ -91,7 +91,7 @@ func TestFakeOperationsHasUserFalse(t *testing.T) {
 func TestFakeOperationsAddUserTeamNotFound(t *testing.T) {
 	fake := NewFakeOperations()
 
-	if err := fake.AddUser("teresa", "gopher"); err != ErrNotFound {
+	if err := fake.AddUser("teresa", "gopher", RoleDeveloper); err != ErrNotFound {
 		t.Errorf("expected error ErrNotFound, got %v", err)
 	}
 }
@@ -104,7 +104,7 @@ func TestFakeOperationsAddUserUserNotFound(t *testing.T) {
 		t.Fatal("error trying to create a fake team:", err)
 	}
 
-	if err := fake.AddUser(expectedTeam, "gopher"); err != user.ErrNotFound {
+	if err := fake.AddUser(expectedTeam, "gopher", RoleDeveloper); err != user.ErrNotFound {
 		t.Errorf("expected error ErrNotFound, got %v", err)
 	}
 }
@@ -120,7 +120,7 @@ func TestFakeOperationsAddUserUserAlreadyInTeam(t *testing.T) {
 		Users: []database.User{{Email: expectedUserEmail}},
 	}
 
-	if err := fake.AddUser(expectedName, expectedUserEmail); err != ErrUserAlreadyInTeam {
+	if err := fake.AddUser(expectedName, expectedUserEmail, RoleDeveloper); err != ErrUserAlreadyInTeam {
 		t.Errorf("expected error ErrUserAlreadyInTeam, got %v", err)
 	}
 }
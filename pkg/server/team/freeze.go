@@ -0,0 +1,75 @@
+package team
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/pkg/errors"
+)
+
+const maxFreezeReasonLength = 255
+
+func validateFreezeWindow(startsAt, endsAt time.Time, reason string) error {
+	if !endsAt.After(startsAt) || len(reason) > maxFreezeReasonLength {
+		return ErrInvalidFreeze
+	}
+	return nil
+}
+
+func (dbt *DatabaseOperations) AddFreezeWindow(name string, startsAt, endsAt time.Time, reason string) error {
+	if err := validateFreezeWindow(startsAt, endsAt, reason); err != nil {
+		return err
+	}
+
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	w := &database.FreezeWindow{TeamID: t.ID, StartsAt: startsAt, EndsAt: endsAt, Reason: reason}
+	if err := dbt.DB.Create(w).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("saving freeze window of team %s", name)),
+		)
+	}
+	return nil
+}
+
+func (dbt *DatabaseOperations) RemoveFreezeWindow(name string, id uint) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	w := new(database.FreezeWindow)
+	if dbt.DB.Where(&database.FreezeWindow{BaseModel: database.BaseModel{ID: id}, TeamID: t.ID}).First(w).RecordNotFound() {
+		return ErrFreezeNotFound
+	}
+	if err := dbt.DB.Delete(w).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("removing freeze window %d of team %s", id, name)),
+		)
+	}
+	return nil
+}
+
+// FreezeWindows returns the freeze windows of the team sorted by start
+func (dbt *DatabaseOperations) FreezeWindows(name string) ([]*database.FreezeWindow, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []*database.FreezeWindow
+	if err := dbt.DB.Where(&database.FreezeWindow{TeamID: t.ID}).Order("starts_at").Find(&windows).Error; err != nil {
+		return nil, teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("finding freeze windows of team %s", name)),
+		)
+	}
+	return windows, nil
+}
@@ -0,0 +1,75 @@
+package team
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+func TestDatabaseOperationsFreezeWindows(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	expectedTeam := "teresa"
+	if err := dbt.Create(expectedTeam, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+
+	start := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	if err := dbt.AddFreezeWindow(expectedTeam, start.AddDate(0, 1, 0), start.AddDate(0, 1, 1), "later"); err != nil {
+		t.Fatal("error adding freeze window:", err)
+	}
+	if err := dbt.AddFreezeWindow(expectedTeam, start, start.AddDate(0, 0, 15), "holiday freeze"); err != nil {
+		t.Fatal("error adding freeze window:", err)
+	}
+
+	windows, err := dbt.FreezeWindows(expectedTeam)
+	if err != nil {
+		t.Fatal("error getting freeze windows:", err)
+	}
+	if len(windows) != 2 || windows[0].Reason != "holiday freeze" || !windows[0].StartsAt.Equal(start) {
+		t.Fatalf("expected the holiday freeze first, got %v", windows)
+	}
+
+	if err := dbt.RemoveFreezeWindow(expectedTeam, windows[0].ID); err != nil {
+		t.Fatal("error removing freeze window:", err)
+	}
+	if err := dbt.RemoveFreezeWindow(expectedTeam, windows[0].ID); err != ErrFreezeNotFound {
+		t.Errorf("expected ErrFreezeNotFound, got %v", err)
+	}
+	if windows, _ = dbt.FreezeWindows(expectedTeam); len(windows) != 1 || windows[0].Reason != "later" {
+		t.Errorf("expected the later window, got %v", windows)
+	}
+}
+
+func TestDatabaseOperationsAddFreezeWindowInvalid(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	expectedTeam := "teresa"
+	if err := dbt.Create(expectedTeam, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+
+	now := time.Now()
+	if err := dbt.AddFreezeWindow(expectedTeam, now, now.Add(-time.Hour), ""); err != ErrInvalidFreeze {
+		t.Errorf("expected ErrInvalidFreeze, got %v", err)
+	}
+}
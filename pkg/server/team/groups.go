@@ -0,0 +1,48 @@
+package team
+
+import (
+	"sort"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// SyncGroups makes the user a member, with role, of the teams mapped from
+// its groups of an external identity provider, and only of them among the
+// mapped teams. The members already in a team keep their role.
+func SyncGroups(ops Operations, userEmail string, groups []string, mapping map[string]string, role string) error {
+	inGroup := make(map[string]bool)
+	for _, g := range groups {
+		inGroup[g] = true
+	}
+	want := make(map[string]bool)
+	for group, name := range mapping {
+		want[name] = want[name] || inGroup[group]
+	}
+
+	names := make([]string, 0, len(want))
+	for name := range want {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		member, err := ops.HasUser(name, userEmail)
+		if err == ErrNotFound {
+			log.WithField("team", name).Warn("team of the identity provider groups not found")
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		switch {
+		case want[name] && !member:
+			err = ops.AddUser(name, userEmail, role)
+		case !want[name] && member:
+			err = ops.RemoveUser(name, userEmail)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,11 +1,14 @@
 package team
 
 import (
+	"time"
+
 	context "golang.org/x/net/context"
 
 	teampb "github.com/luizalabs/teresa/pkg/protobuf/team"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"google.golang.org/grpc"
 )
 
@@ -24,12 +27,24 @@ func (s *Service) Create(ctx context.Context, request *teampb.CreateRequest) (*t
 	return &teampb.Empty{}, nil
 }
 
+// checkTeamAdmin allows the admins and the admins of the team
+func (s *Service) checkTeamAdmin(u *database.User, teamName string) error {
+	if u.IsAdmin {
+		return nil
+	}
+	ok, err := s.ops.HasRole(teamName, u, RoleAdmin)
+	if err != nil || !ok {
+		return auth.ErrPermissionDenied
+	}
+	return nil
+}
+
 func (s *Service) AddUser(ctx context.Context, request *teampb.AddUserRequest) (*teampb.Empty, error) {
 	u := ctx.Value("user").(*database.User)
-	if !u.IsAdmin {
-		return nil, auth.ErrPermissionDenied
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
 	}
-	if err := s.ops.AddUser(request.Name, request.User); err != nil {
+	if err := s.ops.AddUser(request.Name, request.User, request.Role); err != nil {
 		return nil, err
 	}
 	return &teampb.Empty{}, nil
@@ -54,7 +69,12 @@ func (s *Service) List(ctx context.Context, _ *teampb.Empty) (*teampb.ListRespon
 
 	resp := &teampb.ListResponse{}
 	for _, t := range teams {
-		currentTeam := &teampb.ListResponse_Team{Name: t.Name, Email: t.Email, Url: t.URL}
+		currentTeam := &teampb.ListResponse_Team{
+			Name:    t.Name,
+			Email:   t.Email,
+			Url:     t.URL,
+			Domains: splitDomains(t.Domains),
+		}
 		for _, user := range t.Users {
 			currentUser := &teampb.ListResponse_User{Name: user.Name, Email: user.Email}
 			currentTeam.Users = append(currentTeam.Users, currentUser)
@@ -67,8 +87,8 @@ func (s *Service) List(ctx context.Context, _ *teampb.Empty) (*teampb.ListRespon
 
 func (s *Service) RemoveUser(ctx context.Context, request *teampb.RemoveUserRequest) (*teampb.Empty, error) {
 	u := ctx.Value("user").(*database.User)
-	if !u.IsAdmin {
-		return nil, auth.ErrPermissionDenied
+	if err := s.checkTeamAdmin(u, request.Team); err != nil {
+		return nil, err
 	}
 
 	if err := s.ops.RemoveUser(request.Team, request.User); err != nil {
@@ -89,6 +109,290 @@ func (s *Service) Rename(ctx context.Context, request *teampb.RenameRequest) (*t
 	return &teampb.Empty{}, nil
 }
 
+func (s *Service) Delete(ctx context.Context, request *teampb.DeleteRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if err := s.ops.Delete(request.Name, request.Cascade); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) SetQuota(ctx context.Context, request *teampb.SetQuotaRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	q := &Quota{
+		Apps:     request.Apps,
+		Replicas: request.Replicas,
+		CPU:      request.Cpu,
+		Memory:   request.Memory,
+	}
+	if err := s.ops.SetQuota(request.Name, q); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) Info(ctx context.Context, request *teampb.InfoRequest) (*teampb.InfoResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		ok, err := s.ops.HasRole(request.Name, u, RoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, auth.ErrPermissionDenied
+		}
+	}
+
+	t, err := s.ops.Get(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.ops.Usage(request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	evs, err := envVarsOf(t)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	q := quotaOf(t)
+	resp := &teampb.InfoResponse{
+		Name:    t.Name,
+		Email:   t.Email,
+		Url:     t.URL,
+		Domains: splitDomains(t.Domains),
+		Quota: &teampb.InfoResponse_Quota{
+			Apps:     q.Apps,
+			Replicas: q.Replicas,
+			Cpu:      q.CPU,
+			Memory:   q.Memory,
+		},
+		Usage: &teampb.InfoResponse_Quota{
+			Apps:     usage.Apps,
+			Replicas: usage.Replicas,
+			Cpu:      usage.CPU,
+			Memory:   usage.Memory,
+		},
+	}
+	resp.TwoFactorRequired = t.RequireTwoFactor
+	for _, ev := range evs {
+		resp.EnvVars = append(resp.EnvVars, &teampb.InfoResponse_EnvVar{Key: ev.Key, Value: ev.Value})
+	}
+	return resp, nil
+}
+
+func (s *Service) SetEnv(ctx context.Context, request *teampb.SetEnvRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+
+	evs := make([]*EnvVar, len(request.EnvVars))
+	for i, ev := range request.EnvVars {
+		evs[i] = &EnvVar{Key: ev.Key, Value: ev.Value}
+	}
+	if err := s.ops.SetEnv(request.Name, evs); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) UnsetEnv(ctx context.Context, request *teampb.UnsetEnvRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+	if err := s.ops.UnsetEnv(request.Name, request.EnvVars); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) CreateToken(ctx context.Context, request *teampb.CreateTokenRequest) (*teampb.CreateTokenResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+
+	exp := time.Duration(request.ExpiresInSeconds) * time.Second
+	tk, token, err := s.ops.CreateToken(request.Name, request.TokenName, request.Role, exp)
+	if err != nil {
+		return nil, err
+	}
+	return &teampb.CreateTokenResponse{
+		Token:     token,
+		User:      tk.User,
+		ExpiresAt: tk.ExpiresAt.Unix(),
+	}, nil
+}
+
+func (s *Service) RevokeToken(ctx context.Context, request *teampb.RevokeTokenRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+	if err := s.ops.RevokeToken(request.Name, request.TokenName); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) ListTokens(ctx context.Context, request *teampb.ListTokensRequest) (*teampb.ListTokensResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+
+	tks, err := s.ops.ListTokens(request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &teampb.ListTokensResponse{}
+	for _, tk := range tks {
+		resp.Tokens = append(resp.Tokens, &teampb.ListTokensResponse_Token{
+			Name:      tk.Name,
+			Role:      tk.Role,
+			User:      tk.User,
+			CreatedAt: tk.CreatedAt.Unix(),
+			ExpiresAt: tk.ExpiresAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) SetTwoFactorRequired(ctx context.Context, request *teampb.SetTwoFactorRequiredRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+	if err := s.ops.SetTwoFactorRequired(request.Name, request.Required); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) SetDomains(ctx context.Context, request *teampb.SetDomainsRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if err := s.ops.SetDomains(request.Name, request.Domains); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) SetApproval(ctx context.Context, request *teampb.SetApprovalRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if err := s.ops.SetApproval(request.Name, request.Apps); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) AddFreezeWindow(ctx context.Context, request *teampb.AddFreezeWindowRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	startsAt, endsAt := time.Unix(request.Start, 0), time.Unix(request.End, 0)
+	if err := s.ops.AddFreezeWindow(request.Name, startsAt, endsAt, request.Reason); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) RemoveFreezeWindow(ctx context.Context, request *teampb.RemoveFreezeWindowRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if err := s.ops.RemoveFreezeWindow(request.Name, uint(request.Id)); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
+func (s *Service) ListFreezeWindows(ctx context.Context, request *teampb.ListFreezeWindowsRequest) (*teampb.ListFreezeWindowsResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		ok, err := s.ops.HasRole(request.Name, u, RoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, auth.ErrPermissionDenied
+		}
+	}
+
+	windows, err := s.ops.FreezeWindows(request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &teampb.ListFreezeWindowsResponse{}
+	for _, w := range windows {
+		resp.Windows = append(resp.Windows, &teampb.ListFreezeWindowsResponse_Window{
+			Id:     int64(w.ID),
+			Start:  w.StartsAt.Unix(),
+			End:    w.EndsAt.Unix(),
+			Reason: w.Reason,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) ListUsers(ctx context.Context, request *teampb.ListUsersRequest) (*teampb.ListUsersResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		ok, err := s.ops.HasRole(request.Name, u, RoleViewer)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, auth.ErrPermissionDenied
+		}
+	}
+
+	members, err := s.ops.ListUsers(request.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &teampb.ListUsersResponse{}
+	for _, m := range members {
+		resp.Users = append(resp.Users, &teampb.ListUsersResponse_User{
+			Name:  m.Name,
+			Email: m.Email,
+			Role:  m.Role,
+		})
+	}
+	return resp, nil
+}
+
+func (s *Service) SetRole(ctx context.Context, request *teampb.SetRoleRequest) (*teampb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.checkTeamAdmin(u, request.Name); err != nil {
+		return nil, err
+	}
+	if err := s.ops.SetRole(request.Name, request.User, request.Role); err != nil {
+		return nil, err
+	}
+	return &teampb.Empty{}, nil
+}
+
 func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	teampb.RegisterTeamServer(grpcServer, s)
 }
@@ -2,12 +2,14 @@ package team
 
 import (
 	"testing"
+	"time"
 
 	context "golang.org/x/net/context"
 
 	teampb "github.com/luizalabs/teresa/pkg/protobuf/team"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
 	"github.com/luizalabs/teresa/pkg/server/user"
 )
 
@@ -348,3 +350,361 @@ func TestTeamRenameTeamAlreadyExists(t *testing.T) {
 		t.Errorf("expected ErrTeamAlreadyExists, got %v", err)
 	}
 }
+
+func TestTeamSetDomainsSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name}
+
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher", IsAdmin: true})
+
+	req := &teampb.SetDomainsRequest{Name: name, Domains: []string{"teresa.io"}}
+	if _, err := s.SetDomains(ctx, req); err != nil {
+		t.Fatal("Got error setting team domains:", err)
+	}
+
+	if domains := fake.(*FakeOperations).Storage[name].Domains; domains != "teresa.io" {
+		t.Errorf("expected teresa.io, got %s", domains)
+	}
+}
+
+func TestTeamSetDomainsErrPermissionDenied(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	ctx := context.WithValue(context.Background(), "user", &database.User{IsAdmin: false})
+
+	req := &teampb.SetDomainsRequest{Name: "teresa", Domains: []string{"teresa.io"}}
+	if _, err := s.SetDomains(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamSetApprovalSuccess(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name}
+
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", &database.User{Email: "gopher", IsAdmin: true})
+
+	req := &teampb.SetApprovalRequest{Name: name, Apps: []string{"teresa"}}
+	if _, err := s.SetApproval(ctx, req); err != nil {
+		t.Fatal("Got error setting team approval:", err)
+	}
+
+	team := fake.(*FakeOperations).Storage[name]
+	if team.ProtectedApps != "teresa" {
+		t.Errorf("expected teresa, got %s", team.ProtectedApps)
+	}
+}
+
+func TestTeamSetApprovalErrPermissionDenied(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	ctx := context.WithValue(context.Background(), "user", &database.User{IsAdmin: false})
+
+	req := &teampb.SetApprovalRequest{Name: "teresa", Apps: []string{"teresa"}}
+	if _, err := s.SetApproval(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamAddFreezeWindowErrPermissionDenied(t *testing.T) {
+	s := NewService(NewFakeOperations())
+	ctx := context.WithValue(context.Background(), "user", &database.User{IsAdmin: false})
+
+	req := &teampb.AddFreezeWindowRequest{Name: "teresa", Start: 1, End: 2}
+	if _, err := s.AddFreezeWindow(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamListFreezeWindows(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	member := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{member}}
+	s := NewService(fake)
+
+	admin := context.WithValue(context.Background(), "user", &database.User{IsAdmin: true})
+	req := &teampb.AddFreezeWindowRequest{Name: name, Start: 1, End: 2, Reason: "freeze"}
+	if _, err := s.AddFreezeWindow(admin, req); err != nil {
+		t.Fatal("Got error adding freeze window:", err)
+	}
+
+	ctx := context.WithValue(context.Background(), "user", &member)
+	resp, err := s.ListFreezeWindows(ctx, &teampb.ListFreezeWindowsRequest{Name: name})
+	if err != nil {
+		t.Fatal("Got error listing freeze windows:", err)
+	}
+	if len(resp.Windows) != 1 || resp.Windows[0].Reason != "freeze" || resp.Windows[0].End != 2 {
+		t.Errorf("expected the freeze window, got %v", resp.Windows)
+	}
+
+	outsider := context.WithValue(context.Background(), "user", &database.User{Email: "bad-user@luizalabs.com"})
+	if _, err := s.ListFreezeWindows(outsider, &teampb.ListFreezeWindowsRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamAddUserByTeamAdmin(t *testing.T) {
+	fake := NewFakeOperations()
+
+	name := "teresa"
+	admin := database.User{Email: "admin@luizalabs.com"}
+	developer := database.User{Email: "developer@luizalabs.com"}
+	newUserEmail := "gopher@luizalabs.com"
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{admin, developer}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{developer.Email: RoleDeveloper}
+	fake.(*FakeOperations).UserOps.(*user.FakeOperations).Storage[newUserEmail] = &database.User{Email: newUserEmail}
+	s := NewService(fake)
+	req := &teampb.AddUserRequest{Name: name, User: newUserEmail, Role: RoleViewer}
+
+	ctx := context.WithValue(context.Background(), "user", &developer)
+	if _, err := s.AddUser(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx = context.WithValue(context.Background(), "user", &admin)
+	if _, err := s.AddUser(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if role, _ := fake.Role(name, newUserEmail); role != RoleViewer {
+		t.Errorf("expected %s, got %s", RoleViewer, role)
+	}
+}
+
+func TestTeamListUsers(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	member := database.User{Name: "gopher", Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{member}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{member.Email: RoleViewer}
+	s := NewService(fake)
+
+	ctx := context.WithValue(context.Background(), "user", &member)
+	resp, err := s.ListUsers(ctx, &teampb.ListUsersRequest{Name: name})
+	if err != nil {
+		t.Fatal("Got error listing the members:", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Email != member.Email || resp.Users[0].Role != RoleViewer {
+		t.Errorf("expected the member with its role, got %v", resp.Users)
+	}
+
+	outsider := context.WithValue(context.Background(), "user", &database.User{Email: "bad-user@luizalabs.com"})
+	if _, err := s.ListUsers(outsider, &teampb.ListUsersRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamSetRole(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	admin := database.User{Email: "admin@luizalabs.com"}
+	developer := database.User{Email: "developer@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{admin, developer}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{developer.Email: RoleDeveloper}
+	s := NewService(fake)
+	req := &teampb.SetRoleRequest{Name: name, User: admin.Email, Role: RoleViewer}
+
+	ctx := context.WithValue(context.Background(), "user", &developer)
+	if _, err := s.SetRole(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx = context.WithValue(context.Background(), "user", &admin)
+	req.User = developer.Email
+	if _, err := s.SetRole(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if role, _ := fake.Role(name, developer.Email); role != RoleViewer {
+		t.Errorf("expected %s, got %s", RoleViewer, role)
+	}
+
+	req.User = "gopher@luizalabs.com"
+	if _, err := s.SetRole(ctx, req); err != ErrUserNotInTeam {
+		t.Errorf("expected ErrUserNotInTeam, got %v", err)
+	}
+}
+
+func TestTeamDelete(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	member := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{member}}
+	s := NewService(fake)
+	req := &teampb.DeleteRequest{Name: name}
+
+	ctx := context.WithValue(context.Background(), "user", &member)
+	if _, err := s.Delete(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx = context.WithValue(context.Background(), "user", &database.User{IsAdmin: true})
+	if _, err := s.Delete(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, err := s.Delete(ctx, req); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTeamSetQuotaAndInfo(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	member := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{member}}
+	fake.(*FakeOperations).Usages[name] = &teamext.Usage{Apps: 2, Replicas: 3, CPU: "1500m", Memory: "1Gi"}
+	s := NewService(fake)
+	req := &teampb.SetQuotaRequest{Name: name, Apps: 5, Cpu: "4"}
+
+	ctx := context.WithValue(context.Background(), "user", &member)
+	if _, err := s.SetQuota(ctx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	admin := context.WithValue(context.Background(), "user", &database.User{IsAdmin: true})
+	if _, err := s.SetQuota(admin, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	resp, err := s.Info(ctx, &teampb.InfoRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if resp.Quota.Apps != 5 || resp.Quota.Cpu != "4" || resp.Quota.Memory != "" {
+		t.Errorf("expected the quota set, got %v", resp.Quota)
+	}
+	if resp.Usage.Apps != 2 || resp.Usage.Cpu != "1500m" {
+		t.Errorf("expected the usage of the team, got %v", resp.Usage)
+	}
+
+	outsider := context.WithValue(context.Background(), "user", &database.User{Email: "bad-user@luizalabs.com"})
+	if _, err := s.Info(outsider, &teampb.InfoRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestTeamSetEnvAndUnsetEnv(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	admin := database.User{Email: "admin@luizalabs.com"}
+	dev := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{admin, dev}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{dev.Email: RoleDeveloper}
+	s := NewService(fake)
+	req := &teampb.SetEnvRequest{
+		Name:    name,
+		EnvVars: []*teampb.SetEnvRequest_EnvVar{{Key: "LOG_LEVEL", Value: "info"}, {Key: "REGION", Value: "br"}},
+	}
+
+	devCtx := context.WithValue(context.Background(), "user", &dev)
+	if _, err := s.SetEnv(devCtx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), "user", &admin)
+	if _, err := s.SetEnv(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, err := s.UnsetEnv(ctx, &teampb.UnsetEnvRequest{Name: name, EnvVars: []string{"REGION"}}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	resp, err := s.Info(devCtx, &teampb.InfoRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.EnvVars) != 1 || resp.EnvVars[0].Key != "LOG_LEVEL" || resp.EnvVars[0].Value != "info" {
+		t.Errorf("expected only LOG_LEVEL=info, got %v", resp.EnvVars)
+	}
+}
+
+func TestTeamCreateAndRevokeToken(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	admin := database.User{Email: "admin@luizalabs.com"}
+	dev := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{admin, dev}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{dev.Email: RoleDeveloper}
+	s := NewService(fake)
+	req := &teampb.CreateTokenRequest{Name: name, TokenName: "ci", Role: RoleDeployer, ExpiresInSeconds: 3600}
+
+	devCtx := context.WithValue(context.Background(), "user", &dev)
+	if _, err := s.CreateToken(devCtx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), "user", &admin)
+	resp, err := s.CreateToken(ctx, req)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if resp.Token == "" || resp.User == "" {
+		t.Errorf("expected the token and its user, got %v", resp)
+	}
+	if exp := resp.ExpiresAt - time.Now().Unix(); exp < 3590 || exp > 3600 {
+		t.Errorf("expected the token to expire in an hour, got %ds", exp)
+	}
+
+	list, err := s.ListTokens(ctx, &teampb.ListTokensRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(list.Tokens) != 1 || list.Tokens[0].Name != "ci" || list.Tokens[0].Role != RoleDeployer {
+		t.Errorf("expected only the token ci, got %v", list.Tokens)
+	}
+
+	if _, err := s.RevokeToken(ctx, &teampb.RevokeTokenRequest{Name: name, TokenName: "ci"}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, err := s.RevokeToken(ctx, &teampb.RevokeTokenRequest{Name: name, TokenName: "ci"}); err != ErrTokenNotFound {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+}
+
+func TestTeamSetTwoFactorRequired(t *testing.T) {
+	fake := NewFakeOperations()
+	name := "teresa"
+	admin := database.User{Email: "admin@luizalabs.com", TOTPEnabled: true, TwoFactorVerified: true}
+	other := database.User{Email: "other-admin@luizalabs.com"}
+	dev := database.User{Email: "gopher@luizalabs.com"}
+	fake.(*FakeOperations).Storage[name] = &database.Team{Name: name, Users: []database.User{admin, other, dev}}
+	fake.(*FakeOperations).Roles[name] = map[string]string{dev.Email: RoleDeveloper}
+	s := NewService(fake)
+	req := &teampb.SetTwoFactorRequiredRequest{Name: name, Required: true}
+
+	devCtx := context.WithValue(context.Background(), "user", &dev)
+	if _, err := s.SetTwoFactorRequired(devCtx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), "user", &admin)
+	if _, err := s.SetTwoFactorRequired(ctx, req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	resp, err := s.Info(ctx, &teampb.InfoRequest{Name: name})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if !resp.TwoFactorRequired {
+		t.Error("expected two-factor required")
+	}
+
+	// the members not logged in with a code can't read the team either
+	if _, err := s.Info(devCtx, &teampb.InfoRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied reading the info, got %v", err)
+	}
+	if _, err := s.ListUsers(devCtx, &teampb.ListUsersRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied listing the users, got %v", err)
+	}
+	if _, err := s.ListFreezeWindows(devCtx, &teampb.ListFreezeWindowsRequest{Name: name}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied listing the freeze windows, got %v", err)
+	}
+
+	// the admins of the team not logged in with a code lose their role as well
+	otherCtx := context.WithValue(context.Background(), "user", &other)
+	req.Required = false
+	if _, err := s.SetTwoFactorRequired(otherCtx, req); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package team
+
+import (
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
+	"github.com/luizalabs/teresa/pkg/server/validation"
+)
+
+// Quota limits the resources the apps of a team can use, CPU and memory
+// are the sum of the requests of the pods. Zero or blank means no limit.
+type Quota struct {
+	Apps     int32
+	Replicas int32
+	CPU      string
+	Memory   string
+}
+
+// IsUnlimited tells if the quota doesn't limit anything
+func (q *Quota) IsUnlimited() bool {
+	return q.Apps == 0 && q.Replicas == 0 && q.CPU == "" && q.Memory == ""
+}
+
+func validateQuota(q *Quota) error {
+	if q.Apps < 0 || q.Replicas < 0 {
+		return ErrInvalidQuota
+	}
+	for _, v := range []string{q.CPU, q.Memory} {
+		if v != "" && !validation.IsQuantity(v) {
+			return ErrInvalidQuota
+		}
+	}
+	return nil
+}
+
+func quotaOf(t *database.Team) *Quota {
+	return &Quota{
+		Apps:     t.QuotaApps,
+		Replicas: t.QuotaReplicas,
+		CPU:      t.QuotaCPU,
+		Memory:   t.QuotaMemory,
+	}
+}
+
+func (dbt *DatabaseOperations) SetQuota(name string, q *Quota) error {
+	if err := validateQuota(q); err != nil {
+		return err
+	}
+
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	t.QuotaApps = q.Apps
+	t.QuotaReplicas = q.Replicas
+	t.QuotaCPU = q.CPU
+	t.QuotaMemory = q.Memory
+	return dbt.save(t)
+}
+
+func (dbt *DatabaseOperations) Quota(name string) (*Quota, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+	return quotaOf(t), nil
+}
+
+// Usage returns the resources used by the apps of the team
+func (dbt *DatabaseOperations) Usage(name string) (*teamext.Usage, error) {
+	if _, err := dbt.getTeam(name); err != nil {
+		return nil, err
+	}
+	return dbt.Ext.TeamUsage(name)
+}
@@ -0,0 +1,80 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+func TestDatabaseOperationsQuota(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	dbt.SetTeamExt(&fakeExt{})
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	q, err := dbt.Quota(name)
+	if err != nil {
+		t.Fatal("error getting the quota:", err)
+	}
+	if !q.IsUnlimited() {
+		t.Errorf("expected an unlimited quota, got %+v", q)
+	}
+
+	expected := Quota{Apps: 10, Replicas: 30, CPU: "8", Memory: "16Gi"}
+	if err := dbt.SetQuota(name, &expected); err != nil {
+		t.Fatal("error setting the quota:", err)
+	}
+	if q, err = dbt.Quota(name); err != nil {
+		t.Fatal("error getting the quota:", err)
+	}
+	if *q != expected {
+		t.Errorf("expected %+v, got %+v", expected, *q)
+	}
+
+	u, err := dbt.Usage(name)
+	if err != nil {
+		t.Fatal("error getting the usage:", err)
+	}
+	if u.Apps != 1 || u.CPU != "200m" {
+		t.Errorf("expected the usage of the ext, got %+v", u)
+	}
+}
+
+func TestDatabaseOperationsSetQuotaInvalid(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	for _, q := range []*Quota{{Apps: -1}, {Replicas: -1}, {CPU: "lots"}, {Memory: "1 GB"}} {
+		if err := dbt.SetQuota(name, q); err != ErrInvalidQuota {
+			t.Errorf("expected ErrInvalidQuota for %+v, got %v", q, err)
+		}
+	}
+	if err := dbt.SetQuota("gophers", &Quota{Apps: 1}); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package team
+
+const (
+	// RoleAdmin members manage the team members and create and delete apps
+	RoleAdmin = "admin"
+	// RoleDeveloper members deploy and change the apps
+	RoleDeveloper = "developer"
+	// RoleDeployer members deploy the apps and read them, like CI pipelines
+	RoleDeployer = "deployer"
+	// RoleViewer members only read the apps, like their info and logs
+	RoleViewer = "viewer"
+)
+
+// Member is a user of a team with the role of the user in the team
+type Member struct {
+	Name  string
+	Email string
+	Role  string
+}
+
+// roleLevels orders the roles, a role has the permissions of the lower ones
+var roleLevels = map[string]int{
+	RoleViewer:    1,
+	RoleDeployer:  2,
+	RoleDeveloper: 3,
+	RoleAdmin:     4,
+}
+
+func IsValidRole(role string) bool {
+	_, found := roleLevels[role]
+	return found
+}
+
+// RoleAllows tells if a member with the role has the permissions of the
+// required one, a blank role isn't a member
+func RoleAllows(role, required string) bool {
+	level, found := roleLevels[role]
+	return found && level >= roleLevels[required]
+}
@@ -0,0 +1,26 @@
+package team
+
+import "testing"
+
+func TestRoleAllows(t *testing.T) {
+	var testCases = []struct {
+		role     string
+		required string
+		expected bool
+	}{
+		{RoleAdmin, RoleAdmin, true},
+		{RoleAdmin, RoleViewer, true},
+		{RoleDeveloper, RoleDeveloper, true},
+		{RoleDeveloper, RoleAdmin, false},
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleDeveloper, false},
+		{"", RoleViewer, false},
+		{"owner", RoleViewer, false},
+	}
+
+	for _, tc := range testCases {
+		if actual := RoleAllows(tc.role, tc.required); actual != tc.expected {
+			t.Errorf("expected %t, got %t for %s as %s", tc.expected, actual, tc.role, tc.required)
+		}
+	}
+}
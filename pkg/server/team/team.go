@@ -2,23 +2,49 @@ package team
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/luizalabs/teresa/pkg/server/database"
 	"github.com/luizalabs/teresa/pkg/server/teamext"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"github.com/luizalabs/teresa/pkg/server/user"
+	"github.com/luizalabs/teresa/pkg/server/validation"
 	"github.com/pkg/errors"
 )
 
 type Operations interface {
 	Create(name, email, url string) error
-	AddUser(name, userEmail string) error
+	AddUser(name, userEmail, role string) error
 	List() ([]*database.Team, error)
 	ListByUser(userEmail string) ([]*database.Team, error)
 	RemoveUser(name, userEmail string) error
 	Rename(oldName, newName string) error
+	Delete(name string, cascade bool) error
+	Get(name string) (*database.Team, error)
 	HasUser(name, userEmail string) (bool, error)
+	Role(name, userEmail string) (string, error)
+	HasRole(name string, user *database.User, role string) (bool, error)
+	ListUsers(name string) ([]*Member, error)
+	SetRole(name, userEmail, role string) error
+	SetDomains(name string, domains []string) error
+	Domains(name string) ([]string, error)
+	SetApproval(name string, apps []string) error
+	ProtectedApps(name string) ([]string, error)
+	AddFreezeWindow(name string, startsAt, endsAt time.Time, reason string) error
+	RemoveFreezeWindow(name string, id uint) error
+	FreezeWindows(name string) ([]*database.FreezeWindow, error)
+	SetQuota(name string, q *Quota) error
+	Quota(name string) (*Quota, error)
+	Usage(name string) (*teamext.Usage, error)
+	SetEnv(name string, evs []*EnvVar) error
+	UnsetEnv(name string, keys []string) error
+	EnvVars(name string) ([]*EnvVar, error)
+	CreateToken(name, tokenName, role string, exp time.Duration) (*Token, string, error)
+	RevokeToken(name, tokenName string) error
+	ListTokens(name string) ([]*Token, error)
+	SetTwoFactorRequired(name string, required bool) error
 	SetTeamExt(ext teamext.TeamExt)
 }
 
@@ -51,7 +77,16 @@ func (dbt *DatabaseOperations) save(t *database.Team) error {
 	return nil
 }
 
-func (dbt *DatabaseOperations) AddUser(name, userEmail string) error {
+// AddUser adds the user to the team with the role, a blank one is
+// RoleDeveloper
+func (dbt *DatabaseOperations) AddUser(name, userEmail, role string) error {
+	if role == "" {
+		role = RoleDeveloper
+	}
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
 	t, err := dbt.getTeam(name)
 	if err != nil {
 		return err
@@ -69,7 +104,23 @@ func (dbt *DatabaseOperations) AddUser(name, userEmail string) error {
 		}
 	}
 
-	return dbt.DB.Model(t).Association("Users").Append(u).Error
+	if err := dbt.DB.Model(t).Association("Users").Append(u).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	return dbt.setRole(t, u, role)
+}
+
+func (dbt *DatabaseOperations) setRole(t *database.Team, u *database.User, role string) error {
+	err := dbt.DB.Model(&database.TeamUser{}).
+		Where("team_id = ? AND user_id = ?", t.ID, u.ID).
+		Update("role", role).Error
+	if err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("setting the role of %s in team %s", u.Email, t.Name)),
+		)
+	}
+	return nil
 }
 
 func (dbt *DatabaseOperations) HasUser(name, userEmail string) (bool, error) {
@@ -89,6 +140,95 @@ func (dbt *DatabaseOperations) HasUser(name, userEmail string) (bool, error) {
 	return false, nil
 }
 
+// Role returns the role of the user in the team, blank if the user isn't
+// a member
+func (dbt *DatabaseOperations) Role(name, userEmail string) (string, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return "", err
+	}
+
+	var members []*database.TeamUser
+	err = dbt.DB.Joins("JOIN users ON users.id = teams_users.user_id").
+		Where("teams_users.team_id = ? AND users.email = ?", t.ID, userEmail).
+		Find(&members).Error
+	if err != nil {
+		return "", teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("finding the role of %s in team %s", userEmail, name)),
+		)
+	}
+	if len(members) == 0 {
+		return "", nil
+	}
+	return members[0].Role, nil
+}
+
+// HasRole tells if the user is a member of the team with the permissions
+// of the role, meeting the two-factor authentication requirement of the team
+func (dbt *DatabaseOperations) HasRole(name string, user *database.User, role string) (bool, error) {
+	r, err := dbt.Role(name, user.Email)
+	if err != nil {
+		return false, err
+	}
+	if !RoleAllows(r, role) {
+		return false, nil
+	}
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return false, err
+	}
+	return dbt.meetsTwoFactor(t, user)
+}
+
+// ListUsers returns the members of the team ordered by email
+func (dbt *DatabaseOperations) ListUsers(name string) ([]*Member, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*Member
+	err = dbt.DB.Table("users").
+		Select("users.name, users.email, teams_users.role").
+		Joins("JOIN teams_users ON teams_users.user_id = users.id").
+		Where("teams_users.team_id = ?", t.ID).
+		Order("users.email").
+		Scan(&members).Error
+	if err != nil {
+		return nil, teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("finding the members of team %s", name)),
+		)
+	}
+	return members, nil
+}
+
+// SetRole changes the role of a member of the team
+func (dbt *DatabaseOperations) SetRole(name, userEmail, role string) error {
+	if !IsValidRole(role) {
+		return ErrInvalidRole
+	}
+
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+	u, err := dbt.UserOps.GetUser(userEmail)
+	if err != nil {
+		return err
+	}
+
+	cur, err := dbt.Role(name, userEmail)
+	if err != nil {
+		return err
+	}
+	if cur == "" {
+		return ErrUserNotInTeam
+	}
+	return dbt.setRole(t, u, role)
+}
+
 func (dbt *DatabaseOperations) List() ([]*database.Team, error) {
 	var teams []*database.Team
 	if err := dbt.DB.Find(&teams).Error; err != nil {
@@ -144,6 +284,11 @@ func (dbt *DatabaseOperations) getTeam(name string) (*database.Team, error) {
 	return t, nil
 }
 
+// Get returns the team without its users
+func (dbt *DatabaseOperations) Get(name string) (*database.Team, error) {
+	return dbt.getTeam(name)
+}
+
 func (dbt *DatabaseOperations) RemoveUser(name, userEmail string) error {
 	t, err := dbt.getTeam(name)
 	if err != nil {
@@ -195,11 +340,129 @@ func (dbt *DatabaseOperations) Rename(oldName, newName string) error {
 	return nil
 }
 
+// Delete deletes the team along with its memberships and freeze windows.
+// It refuses to delete a team that still has apps unless cascade is set,
+// which deletes the apps as well.
+func (dbt *DatabaseOperations) Delete(name string, cascade bool) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	apps, err := dbt.Ext.ListByTeam(name)
+	if err != nil {
+		return err
+	}
+	if len(apps) > 0 && !cascade {
+		return ErrTeamHasApps
+	}
+	for _, a := range apps {
+		if err := dbt.Ext.DeleteApp(a); err != nil {
+			return err
+		}
+	}
+
+	var tks []*database.TeamToken
+	if err := dbt.DB.Preload("User").Where("team_id = ?", t.ID).Find(&tks).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+
+	// the rows of the team go all at once, a failure keeps the team whole
+	tx := dbt.DB.Begin()
+	models := []interface{}{
+		&database.TeamUser{},
+		&database.FreezeWindow{},
+		&database.EnvGroup{},
+		&database.TeamToken{},
+	}
+	for _, model := range models {
+		if err := tx.Where("team_id = ?", t.ID).Delete(model).Error; err != nil {
+			tx.Rollback()
+			return teresa_errors.New(
+				teresa_errors.ErrInternalServerError,
+				errors.Wrap(err, fmt.Sprintf("deleting team %s", name)),
+			)
+		}
+	}
+	if err := tx.Delete(t).Error; err != nil {
+		tx.Rollback()
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("deleting team %s", name)),
+		)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("deleting team %s", name)),
+		)
+	}
+
+	// the service accounts of the revoked tokens
+	for _, tk := range tks {
+		if err := dbt.UserOps.Delete(tk.User.Email); err != nil && err != user.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dbt *DatabaseOperations) SetDomains(name string, domains []string) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	for i := range domains {
+		domains[i] = strings.ToLower(strings.TrimSpace(domains[i]))
+		if !validation.IsDomain(domains[i]) {
+			return ErrInvalidDomain
+		}
+	}
+
+	t.Domains = strings.Join(domains, ",")
+	return dbt.save(t)
+}
+
+func (dbt *DatabaseOperations) Domains(name string) ([]string, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+	return splitDomains(t.Domains), nil
+}
+
+// SetApproval sets the team apps whose deploys must be approved by one of
+// the team admins
+func (dbt *DatabaseOperations) SetApproval(name string, apps []string) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+
+	t.ProtectedApps = strings.Join(apps, ",")
+	return dbt.save(t)
+}
+
+func (dbt *DatabaseOperations) ProtectedApps(name string) ([]string, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+	return splitDomains(t.ProtectedApps), nil
+}
+
+func splitDomains(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
 func (dbt *DatabaseOperations) SetTeamExt(ext teamext.TeamExt) {
 	dbt.Ext = ext
 }
 
 func NewDatabaseOperations(db *gorm.DB, uOps user.Operations) Operations {
-	db.AutoMigrate(&database.Team{})
 	return &DatabaseOperations{DB: db, UserOps: uOps}
 }
@@ -2,10 +2,12 @@ package team
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teamext"
 	"github.com/luizalabs/teresa/pkg/server/user"
 )
 
@@ -22,6 +24,14 @@ func (fakeExt) ChangeTeam(oldTeam, newName string) error {
 	return nil
 }
 
+func (fakeExt) DeleteApp(appName string) error {
+	return nil
+}
+
+func (fakeExt) TeamUsage(teamName string) (*teamext.Usage, error) {
+	return &teamext.Usage{Apps: 1, Replicas: 2, CPU: "200m", Memory: "512Mi"}, nil
+}
+
 func createFakeTeam(db *gorm.DB, name, email, url string) error {
 	t := &database.Team{
 		Name:  name,
@@ -35,6 +45,9 @@ func TestDatabaseOperationsCreate(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -68,6 +81,9 @@ func TestDatabaseOperationsCreateTeamAlreadyExists(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -87,7 +103,9 @@ func TestDatabaseOperationsAddUser(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher"
@@ -100,7 +118,7 @@ func TestDatabaseOperationsAddUser(t *testing.T) {
 		t.Fatal("error on create a team:", err)
 	}
 
-	if err := dbt.AddUser(expectedTeam, expectedUserEmail); err != nil {
+	if err := dbt.AddUser(expectedTeam, expectedUserEmail, RoleDeveloper); err != nil {
 		t.Errorf("error trying on add user to a team: %v", err)
 	}
 }
@@ -110,10 +128,13 @@ func TestDatabaseOperationsAddUserTeamNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
-	if err := dbt.AddUser("teresa", "gopher"); err != ErrNotFound {
+	if err := dbt.AddUser("teresa", "gopher", RoleDeveloper); err != ErrNotFound {
 		t.Errorf("expected error ErrNotFound, got %v", err)
 	}
 }
@@ -123,7 +144,9 @@ func TestDatabaseOperationsAddUserUserNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -133,7 +156,7 @@ func TestDatabaseOperationsAddUserUserNotFound(t *testing.T) {
 		t.Fatal("error on create a team:", err)
 	}
 
-	if err := dbt.AddUser(expectedTeam, "gopher"); err != user.ErrNotFound {
+	if err := dbt.AddUser(expectedTeam, "gopher", RoleDeveloper); err != user.ErrNotFound {
 		t.Errorf("expected error ErrNotFound, got %v", err)
 	}
 }
@@ -143,7 +166,9 @@ func TestDatabaseOperationsAddUserUserAlreadyInTeam(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher"
@@ -157,7 +182,7 @@ func TestDatabaseOperationsAddUserUserAlreadyInTeam(t *testing.T) {
 	}
 
 	for _, expectedErr := range []error{nil, ErrUserAlreadyInTeam} {
-		if err := dbt.AddUser(expectedTeam, expectedUserEmail); err != expectedErr {
+		if err := dbt.AddUser(expectedTeam, expectedUserEmail, RoleDeveloper); err != expectedErr {
 			t.Errorf("expected %v, got %v", expectedErr, err)
 		}
 	}
@@ -168,7 +193,9 @@ func TestDatabaseOperationsHasUser(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher"
@@ -181,7 +208,7 @@ func TestDatabaseOperationsHasUser(t *testing.T) {
 		t.Fatal("error creating a team:", err)
 	}
 
-	if err := dbt.AddUser(expectedTeam, expectedUserEmail); err != nil {
+	if err := dbt.AddUser(expectedTeam, expectedUserEmail, RoleDeveloper); err != nil {
 		t.Fatal("error trying to add user to a team:", err)
 	}
 
@@ -195,6 +222,9 @@ func TestDatabaseOperationsHasUserFalse(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -213,6 +243,9 @@ func TestDatabaseOperationsHasUserTeamNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -227,6 +260,9 @@ func TestDatabaseOperationsListWithoutTeams(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -252,6 +288,9 @@ func TestDatabaseOperationsList(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	uOps := user.NewDatabaseOperations(db, auth.NewFake())
@@ -264,7 +303,7 @@ func TestDatabaseOperationsList(t *testing.T) {
 			if err := uOps.Create(email, email, "12345678", false); err != nil {
 				t.Fatal("error on create user", err)
 			}
-			if err := dbt.AddUser(tc.teamName, email); err != nil {
+			if err := dbt.AddUser(tc.teamName, email, RoleDeveloper); err != nil {
 				t.Fatal("error on add user to team: ", err)
 			}
 		}
@@ -318,6 +357,9 @@ func TestDatabaseOperationsListByUser(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	uOps := user.NewDatabaseOperations(db, auth.NewFake())
@@ -331,7 +373,7 @@ func TestDatabaseOperationsListByUser(t *testing.T) {
 			if err != nil && err != user.ErrUserAlreadyExists {
 				t.Fatal("error on create user", err)
 			}
-			if err := dbt.AddUser(tc.teamName, email); err != nil {
+			if err := dbt.AddUser(tc.teamName, email, RoleDeveloper); err != nil {
 				t.Fatal("error on add user to team: ", err)
 			}
 		}
@@ -360,6 +402,9 @@ func TestDatabaseOperationsListByUserWithoutTeams(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher@luizalabs.com"
@@ -385,7 +430,9 @@ func TestDatabaseOpsRemoveUserSuccess(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher"
@@ -395,7 +442,7 @@ func TestDatabaseOpsRemoveUserSuccess(t *testing.T) {
 	if err := dbt.Create(expectedTeam, "", ""); err != nil {
 		t.Fatal("error creating team: ", err)
 	}
-	if err := dbt.AddUser(expectedTeam, expectedUserEmail); err != nil {
+	if err := dbt.AddUser(expectedTeam, expectedUserEmail, RoleDeveloper); err != nil {
 		t.Fatal("error trying to add user to team: ", err)
 	}
 
@@ -409,6 +456,9 @@ func TestDatabaseOpsRemoveUserTeamNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -422,7 +472,9 @@ func TestDatabaseOpsRemoveUserNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedTeam := "teresa"
@@ -441,7 +493,9 @@ func TestDatabaseOpsRemoveUserNotInTeam(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
-	db.AutoMigrate(&database.User{})
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	expectedUserEmail := "gopher"
@@ -462,6 +516,9 @@ func TestDatabaseOperationsRename(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -492,6 +549,9 @@ func TestDatabaseOperationsRenameTeamAlreadyExists(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -511,6 +571,9 @@ func TestDatabaseOperationsRenameNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
@@ -519,3 +582,395 @@ func TestDatabaseOperationsRenameNotFound(t *testing.T) {
 		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
+
+func TestDatabaseOperationsSetDomains(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	expectedTeam := "teresa"
+	if err := dbt.Create(expectedTeam, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+
+	if err := dbt.SetDomains(expectedTeam, []string{"teresa.io", " Luizalabs.com "}); err != nil {
+		t.Fatal("error setting team domains:", err)
+	}
+
+	domains, err := dbt.Domains(expectedTeam)
+	if err != nil {
+		t.Fatal("error getting team domains:", err)
+	}
+	if len(domains) != 2 || domains[0] != "teresa.io" || domains[1] != "luizalabs.com" {
+		t.Errorf("expected [teresa.io luizalabs.com], got %v", domains)
+	}
+}
+
+func TestDatabaseOperationsSetDomainsInvalidDomain(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	expectedTeam := "teresa"
+	if err := dbt.Create(expectedTeam, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+
+	if err := dbt.SetDomains(expectedTeam, []string{"localhost"}); err != ErrInvalidDomain {
+		t.Errorf("expected ErrInvalidDomain, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsSetApproval(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	expectedTeam := "teresa"
+	if err := dbt.Create(expectedTeam, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+
+	if err := dbt.SetApproval(expectedTeam, []string{"teresa", "gopher"}); err != nil {
+		t.Fatal("error setting team approval:", err)
+	}
+
+	apps, err := dbt.ProtectedApps(expectedTeam)
+	if err != nil {
+		t.Fatal("error getting team protected apps:", err)
+	}
+	if len(apps) != 2 || apps[0] != "teresa" || apps[1] != "gopher" {
+		t.Errorf("expected [teresa gopher], got %v", apps)
+	}
+}
+
+func TestDatabaseOperationsSetApprovalTeamNotFound(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	if err := dbt.SetApproval("teresa", []string{"teresa"}); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsRole(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	dbt := NewDatabaseOperations(db, uOps)
+	for _, email := range []string{"viewer@luizalabs.com", "developer@luizalabs.com"} {
+		if err := uOps.Create(email, email, "12345678", false); err != nil {
+			t.Fatal("error on create user", err)
+		}
+	}
+	teamName := "teresa"
+	if err := dbt.Create(teamName, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+	if err := dbt.AddUser(teamName, "viewer@luizalabs.com", RoleViewer); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+	if err := dbt.AddUser(teamName, "developer@luizalabs.com", ""); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+
+	var testCases = []struct {
+		email    string
+		expected string
+	}{
+		{"viewer@luizalabs.com", RoleViewer},
+		{"developer@luizalabs.com", RoleDeveloper},
+		{"gopher@luizalabs.com", ""},
+	}
+	for _, tc := range testCases {
+		role, err := dbt.Role(teamName, tc.email)
+		if err != nil {
+			t.Fatal("error getting the role:", err)
+		}
+		if role != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, role)
+		}
+	}
+
+	if ok, err := dbt.HasRole(teamName, &database.User{Email: "viewer@luizalabs.com"}, RoleDeveloper); ok || err != nil {
+		t.Errorf("expected false and no error, got %v:%v", ok, err)
+	}
+	if ok, err := dbt.HasRole(teamName, &database.User{Email: "developer@luizalabs.com"}, RoleViewer); !ok || err != nil {
+		t.Errorf("expected true and no error, got %v:%v", ok, err)
+	}
+}
+
+func TestDatabaseOperationsAddUserInvalidRole(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	if err := dbt.AddUser("teresa", "gopher", "owner"); err != ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsListUsersAndSetRole(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	dbt := NewDatabaseOperations(db, uOps)
+	for _, email := range []string{"viewer@luizalabs.com", "developer@luizalabs.com", "gopher@luizalabs.com"} {
+		if err := uOps.Create(email, email, "12345678", false); err != nil {
+			t.Fatal("error on create user", err)
+		}
+	}
+	teamName := "teresa"
+	if err := dbt.Create(teamName, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+	if err := dbt.AddUser(teamName, "viewer@luizalabs.com", RoleViewer); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+	if err := dbt.AddUser(teamName, "developer@luizalabs.com", ""); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+
+	if err := dbt.SetRole(teamName, "developer@luizalabs.com", RoleAdmin); err != nil {
+		t.Fatal("error setting the role:", err)
+	}
+	if err := dbt.SetRole(teamName, "gopher@luizalabs.com", RoleAdmin); err != ErrUserNotInTeam {
+		t.Errorf("expected ErrUserNotInTeam, got %v", err)
+	}
+	if err := dbt.SetRole(teamName, "viewer@luizalabs.com", "owner"); err != ErrInvalidRole {
+		t.Errorf("expected ErrInvalidRole, got %v", err)
+	}
+
+	members, err := dbt.ListUsers(teamName)
+	if err != nil {
+		t.Fatal("error listing the members:", err)
+	}
+	expected := []Member{
+		{Name: "developer@luizalabs.com", Email: "developer@luizalabs.com", Role: RoleAdmin},
+		{Name: "viewer@luizalabs.com", Email: "viewer@luizalabs.com", Role: RoleViewer},
+	}
+	if len(members) != len(expected) {
+		t.Fatalf("expected %d members, got %d", len(expected), len(members))
+	}
+	for i := range expected {
+		if *members[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], *members[i])
+		}
+	}
+}
+
+func TestDatabaseOperationsListUsersTeamNotFound(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	if _, err := dbt.ListUsers("teresa"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+type fakeDeleteExt struct {
+	fakeExt
+	apps    []string
+	deleted []string
+}
+
+func (f *fakeDeleteExt) ListByTeam(teamName string) ([]string, error) {
+	return f.apps, nil
+}
+
+func (f *fakeDeleteExt) DeleteApp(appName string) error {
+	f.deleted = append(f.deleted, appName)
+	return nil
+}
+
+func TestDatabaseOperationsDelete(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	dbt := NewDatabaseOperations(db, uOps)
+	ext := &fakeDeleteExt{apps: []string{"teresa"}}
+	dbt.SetTeamExt(ext)
+
+	name := "teresa"
+	email := "gopher@luizalabs.com"
+	if err := uOps.Create(email, email, "12345678", false); err != nil {
+		t.Fatal("error on create user", err)
+	}
+	if err := dbt.Create(name, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+	if err := dbt.AddUser(name, email, RoleAdmin); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+	if err := dbt.AddFreezeWindow(name, time.Now(), time.Now().Add(time.Hour), "freeze"); err != nil {
+		t.Fatal("error adding a freeze window:", err)
+	}
+	tk, _, err := dbt.CreateToken(name, "ci", RoleDeveloper, time.Hour)
+	if err != nil {
+		t.Fatal("error creating a token:", err)
+	}
+	tm, _ := dbt.(*DatabaseOperations).getTeam(name)
+	eg := &database.EnvGroup{Name: "shared", TeamID: tm.ID, EnvVars: "[]", Apps: "[]"}
+	if err := db.Create(eg).Error; err != nil {
+		t.Fatal("error creating an env group:", err)
+	}
+
+	if err := dbt.Delete(name, false); err != ErrTeamHasApps {
+		t.Errorf("expected ErrTeamHasApps, got %v", err)
+	}
+	if len(ext.deleted) != 0 {
+		t.Errorf("expected no deleted apps, got %v", ext.deleted)
+	}
+
+	if err := dbt.Delete(name, true); err != nil {
+		t.Fatal("error deleting the team:", err)
+	}
+	if len(ext.deleted) != 1 || ext.deleted[0] != "teresa" {
+		t.Errorf("expected the app teresa deleted, got %v", ext.deleted)
+	}
+	if _, err := dbt.(*DatabaseOperations).getTeam(name); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	for _, model := range []interface{}{&database.TeamUser{}, &database.FreezeWindow{}, &database.EnvGroup{}, &database.TeamToken{}} {
+		var count int
+		db.Model(model).Count(&count)
+		if count != 0 {
+			t.Errorf("expected no rows of %T, got %d", model, count)
+		}
+	}
+
+	if _, err := uOps.GetUser(tk.User); err != user.ErrNotFound {
+		t.Errorf("expected the service account deleted, got %v", err)
+	}
+
+	if err := dbt.Create(name, "", ""); err != nil {
+		t.Errorf("expected to create the team again, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsDeleteNotFound(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewFakeOperations())
+	if err := dbt.Delete("teresa", true); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsHasRoleTwoFactorRequired(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	dbt := NewDatabaseOperations(db, uOps)
+	email := "developer@luizalabs.com"
+	if err := uOps.Create(email, email, "12345678", false); err != nil {
+		t.Fatal("error on create user", err)
+	}
+	teamName := "teresa"
+	if err := dbt.Create(teamName, "", ""); err != nil {
+		t.Fatal("error creating a team:", err)
+	}
+	if err := dbt.AddUser(teamName, email, RoleDeveloper); err != nil {
+		t.Fatal("error trying to add user to a team:", err)
+	}
+	tk, _, err := dbt.CreateToken(teamName, "ci", RoleDeployer, 0)
+	if err != nil {
+		t.Fatal("error creating a token:", err)
+	}
+	if err := dbt.SetTwoFactorRequired(teamName, true); err != nil {
+		t.Fatal("error requiring two-factor:", err)
+	}
+
+	u := &database.User{Email: email}
+	if ok, err := dbt.HasRole(teamName, u, RoleViewer); ok || err != nil {
+		t.Errorf("expected false and no error, got %v:%v", ok, err)
+	}
+	if ok, err := dbt.HasRole(teamName, &database.User{Email: tk.User}, RoleDeployer); !ok || err != nil {
+		t.Errorf("expected true and no error for the token, got %v:%v", ok, err)
+	}
+
+	// enabling the two-factor isn't enough, the session must check a code
+	if err := db.Model(&database.User{}).Where("email = ?", email).Update("totp_enabled", true).Error; err != nil {
+		t.Fatal("error enabling two-factor:", err)
+	}
+	if ok, err := dbt.HasRole(teamName, u, RoleViewer); ok || err != nil {
+		t.Errorf("expected false and no error, got %v:%v", ok, err)
+	}
+	u.TwoFactorVerified = true
+	if ok, err := dbt.HasRole(teamName, u, RoleViewer); !ok || err != nil {
+		t.Errorf("expected true and no error, got %v:%v", ok, err)
+	}
+}
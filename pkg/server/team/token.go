@@ -0,0 +1,230 @@
+package team
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+	"github.com/luizalabs/teresa/pkg/server/uid"
+	"github.com/luizalabs/teresa/pkg/server/user"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTokenExpiration is the expiration of the tokens created
+	// without one
+	DefaultTokenExpiration = 90 * 24 * time.Hour
+	maxTokenExpiration     = 365 * 24 * time.Hour
+)
+
+var tokenNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Token is an API token of a team, for CI pipelines. It authenticates as a
+// service account, a user created along with it and member of the team with
+// the role of the token.
+type Token struct {
+	Name      string
+	Role      string
+	User      string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func validateToken(tokenName, role string, exp time.Duration) error {
+	if len(tokenName) > 63 || !tokenNameRegexp.MatchString(tokenName) {
+		return ErrInvalidTokenName
+	}
+	// tokens don't manage the teams
+	if !IsValidRole(role) || role == RoleAdmin {
+		return ErrInvalidTokenRole
+	}
+	if exp <= 0 || exp > maxTokenExpiration {
+		return ErrInvalidTokenExpiration
+	}
+	return nil
+}
+
+// serviceAccountEmail returns a new email for the service account of a
+// token, unique so the tokens revoked don't come back with a new one of the
+// same name
+func serviceAccountEmail(tokenName string) string {
+	return fmt.Sprintf("%s-%s@%s", tokenName, uid.New(), user.ServiceAccountDomain)
+}
+
+func randomPassword() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (dbt *DatabaseOperations) getToken(t *database.Team, tokenName string) (*database.TeamToken, error) {
+	tk := new(database.TeamToken)
+	q := dbt.DB.Preload("User").Where(&database.TeamToken{TeamID: t.ID, Name: tokenName}).First(tk)
+	if q.RecordNotFound() {
+		return nil, ErrTokenNotFound
+	}
+	if q.Error != nil {
+		return nil, teresa_errors.NewInternalServerError(q.Error)
+	}
+	return tk, nil
+}
+
+// CreateToken creates a token of the team with the role, expiring after
+// exp (DefaultTokenExpiration if zero), and returns it along with the token
+// itself, which isn't saved anywhere so it's shown only once
+func (dbt *DatabaseOperations) CreateToken(name, tokenName, role string, exp time.Duration) (*Token, string, error) {
+	if exp == 0 {
+		exp = DefaultTokenExpiration
+	}
+	if err := validateToken(tokenName, role, exp); err != nil {
+		return nil, "", err
+	}
+
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := dbt.getToken(t, tokenName); err != ErrTokenNotFound {
+		if err == nil {
+			return nil, "", ErrTokenAlreadyExists
+		}
+		return nil, "", err
+	}
+
+	// nobody knows the password, the service account is only used through
+	// the token
+	pass, err := randomPassword()
+	if err != nil {
+		return nil, "", teresa_errors.NewInternalServerError(err)
+	}
+	email := serviceAccountEmail(tokenName)
+	displayName := fmt.Sprintf("token %s of %s", tokenName, t.Name)
+	if err := dbt.UserOps.Create(displayName, email, pass, false); err != nil {
+		return nil, "", err
+	}
+	tk, token, err := dbt.saveToken(t, tokenName, email, role, exp)
+	if err != nil {
+		// the service account is of no use without its token
+		dbt.deleteServiceAccount(email)
+		return nil, "", err
+	}
+	return &Token{
+		Name:      tk.Name,
+		Role:      role,
+		User:      email,
+		CreatedAt: tk.CreatedAt,
+		ExpiresAt: tk.ExpiresAt,
+	}, token, nil
+}
+
+// saveToken adds the service account to the team and saves its token
+func (dbt *DatabaseOperations) saveToken(t *database.Team, tokenName, email, role string, exp time.Duration) (*database.TeamToken, string, error) {
+	if err := dbt.AddUser(t.Name, email, role); err != nil {
+		return nil, "", err
+	}
+	u, err := dbt.UserOps.GetUser(email)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := dbt.UserOps.ServiceAccountToken(email, exp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tk := &database.TeamToken{
+		TeamID:    t.ID,
+		Name:      tokenName,
+		UserID:    u.ID,
+		ExpiresAt: time.Now().Add(exp),
+	}
+	if err := dbt.DB.Save(tk).Error; err != nil {
+		return nil, "", teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("saving token %s of team %s", tokenName, t.Name)),
+		)
+	}
+	return tk, token, nil
+}
+
+// deleteServiceAccount removes the service account of a token not created,
+// along with its memberships
+func (dbt *DatabaseOperations) deleteServiceAccount(email string) {
+	if u, err := dbt.UserOps.GetUser(email); err == nil {
+		if err := dbt.DB.Where("user_id = ?", u.ID).Delete(&database.TeamUser{}).Error; err != nil {
+			log.WithError(err).Errorf("removing the memberships of service account %s", email)
+		}
+	}
+	if err := dbt.UserOps.Delete(email); err != nil && err != user.ErrNotFound {
+		log.WithError(err).Errorf("deleting service account %s", email)
+	}
+}
+
+// RevokeToken deletes the token of the team along with its service
+// account, the token stops working right away
+func (dbt *DatabaseOperations) RevokeToken(name, tokenName string) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+	tk, err := dbt.getToken(t, tokenName)
+	if err != nil {
+		return err
+	}
+	return dbt.deleteToken(t, tk)
+}
+
+func (dbt *DatabaseOperations) deleteToken(t *database.Team, tk *database.TeamToken) error {
+	if err := dbt.DB.Where("user_id = ?", tk.UserID).Delete(&database.TeamUser{}).Error; err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	// the requests of the token are authenticated as its service account,
+	// without it the token stops working
+	if err := dbt.UserOps.Delete(tk.User.Email); err != nil && err != user.ErrNotFound {
+		return err
+	}
+	if err := dbt.DB.Delete(tk).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("revoking token %s of team %s", tk.Name, t.Name)),
+		)
+	}
+	return nil
+}
+
+// ListTokens returns the tokens of the team ordered by name, with the
+// current role of their service accounts
+func (dbt *DatabaseOperations) ListTokens(name string) ([]*Token, error) {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var tks []*database.TeamToken
+	if err := dbt.DB.Preload("User").Where("team_id = ?", t.ID).Order("name").Find(&tks).Error; err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	tokens := make([]*Token, len(tks))
+	for i, tk := range tks {
+		role, err := dbt.Role(name, tk.User.Email)
+		if err != nil {
+			return nil, err
+		}
+		tokens[i] = &Token{
+			Name:      tk.Name,
+			Role:      role,
+			User:      tk.User.Email,
+			CreatedAt: tk.CreatedAt,
+			ExpiresAt: tk.ExpiresAt,
+		}
+	}
+	return tokens, nil
+}
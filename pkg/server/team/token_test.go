@@ -0,0 +1,172 @@
+package team
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/user"
+)
+
+func TestDatabaseOperationsTokens(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	dbt := NewDatabaseOperations(db, uOps)
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	tk, token, err := dbt.CreateToken(name, "ci", RoleDeployer, 0)
+	if err != nil {
+		t.Fatal("error creating token:", err)
+	}
+	if token == "" {
+		t.Error("expected a token, got none")
+	}
+	if exp := time.Until(tk.ExpiresAt); exp < DefaultTokenExpiration-time.Minute || exp > DefaultTokenExpiration {
+		t.Errorf("expected the token to expire in %v, got %v", DefaultTokenExpiration, exp)
+	}
+	if role, err := dbt.Role(name, tk.User); err != nil || role != RoleDeployer {
+		t.Errorf("expected role %s, got %s (err: %v)", RoleDeployer, role, err)
+	}
+	if _, _, err := dbt.CreateToken(name, "ci", RoleDeployer, 0); err != ErrTokenAlreadyExists {
+		t.Errorf("expected ErrTokenAlreadyExists, got %v", err)
+	}
+
+	tks, err := dbt.ListTokens(name)
+	if err != nil {
+		t.Fatal("error listing tokens:", err)
+	}
+	if len(tks) != 1 || tks[0].Name != "ci" || tks[0].Role != RoleDeployer || tks[0].User != tk.User {
+		t.Errorf("expected only the token ci, got %v", tks)
+	}
+
+	if err := dbt.RevokeToken(name, "ci"); err != nil {
+		t.Fatal("error revoking token:", err)
+	}
+	if _, err := uOps.GetUser(tk.User); err != user.ErrNotFound {
+		t.Errorf("expected the service account deleted, got %v", err)
+	}
+	if ok, _ := dbt.HasUser(name, tk.User); ok {
+		t.Error("expected the service account out of the team")
+	}
+	if err := dbt.RevokeToken(name, "ci"); err != ErrTokenNotFound {
+		t.Errorf("expected ErrTokenNotFound, got %v", err)
+	}
+	if tks, _ := dbt.ListTokens(name); len(tks) != 0 {
+		t.Errorf("expected no tokens, got %v", tks)
+	}
+}
+
+func TestDatabaseOperationsCreateTokenInvalid(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbt := NewDatabaseOperations(db, user.NewDatabaseOperations(db, auth.NewFake()))
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	var testCases = []struct {
+		tokenName   string
+		role        string
+		exp         time.Duration
+		expectedErr error
+	}{
+		{"CI", RoleDeployer, 0, ErrInvalidTokenName},
+		{"ci-", RoleDeployer, 0, ErrInvalidTokenName},
+		{"ci", RoleAdmin, 0, ErrInvalidTokenRole},
+		{"ci", "owner", 0, ErrInvalidTokenRole},
+		{"ci", RoleDeployer, -time.Hour, ErrInvalidTokenExpiration},
+		{"ci", RoleDeployer, 366 * 24 * time.Hour, ErrInvalidTokenExpiration},
+	}
+
+	for _, tc := range testCases {
+		if _, _, err := dbt.CreateToken(name, tc.tokenName, tc.role, tc.exp); err != tc.expectedErr {
+			t.Errorf("expected %v for %s, got %v", tc.expectedErr, tc.tokenName, err)
+		}
+	}
+	if _, _, err := dbt.CreateToken("gophers", "ci", RoleDeployer, 0); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDatabaseOperationsCreateTokenPasswordPolicy(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := user.NewDatabaseOperations(db, auth.NewFake())
+	// the random password of the service account has no upper case letter
+	uOps.SetPasswordPolicy(&user.PasswordPolicy{RequireUpper: true, MaxAge: time.Nanosecond})
+	dbt := NewDatabaseOperations(db, uOps)
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	if _, token, err := dbt.CreateToken(name, "ci", RoleDeployer, 0); err != nil || token == "" {
+		t.Errorf("expected a token and no error, got %q:%v", token, err)
+	}
+}
+
+// failingTokenUserOps fails to sign the tokens of the service accounts
+type failingTokenUserOps struct {
+	user.Operations
+}
+
+func (failingTokenUserOps) ServiceAccountToken(email string, exp time.Duration) (string, error) {
+	return "", errors.New("signing failed")
+}
+
+func TestDatabaseOperationsCreateTokenCleanup(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	uOps := failingTokenUserOps{user.NewDatabaseOperations(db, auth.NewFake())}
+	dbt := NewDatabaseOperations(db, uOps)
+	name := "teresa"
+	if err := createFakeTeam(db, name, "", ""); err != nil {
+		t.Fatal("error on create a fake team:", err)
+	}
+
+	if _, _, err := dbt.CreateToken(name, "ci", RoleDeployer, 0); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	var users, members int
+	db.Model(&database.User{}).Where("email like ?", "%@"+user.ServiceAccountDomain).Count(&users)
+	db.Model(&database.TeamUser{}).Count(&members)
+	if users != 0 || members != 0 {
+		t.Errorf("expected no service account left, got %d users and %d members", users, members)
+	}
+}
@@ -0,0 +1,49 @@
+package team
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// SetTwoFactorRequired sets if the members of the team need the two-factor
+// authentication enabled to use their roles, the tokens of the team aren't
+// affected
+func (dbt *DatabaseOperations) SetTwoFactorRequired(name string, required bool) error {
+	t, err := dbt.getTeam(name)
+	if err != nil {
+		return err
+	}
+	if err := dbt.DB.Model(t).Update("require_two_factor", required).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("updating two-factor requirement of team %s", name)),
+		)
+	}
+	return nil
+}
+
+// meetsTwoFactor tells if the member of the team meets its two-factor
+// authentication requirement, a code checked by the session of the request
+// or being a service account of the team
+func (dbt *DatabaseOperations) meetsTwoFactor(t *database.Team, user *database.User) (bool, error) {
+	if !t.RequireTwoFactor || user.TwoFactorVerified {
+		return true, nil
+	}
+	u, err := dbt.UserOps.GetUser(user.Email)
+	if err != nil {
+		return false, err
+	}
+
+	var count int
+	err = dbt.DB.Model(&database.TeamToken{}).
+		Where("team_id = ? AND user_id = ?", t.ID, u.ID).
+		Count(&count).Error
+	if err != nil {
+		return false, teresa_errors.NewInternalServerError(err)
+	}
+	return count > 0, nil
+}
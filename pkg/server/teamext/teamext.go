@@ -4,4 +4,15 @@ package teamext
 type TeamExt interface {
 	ChangeTeam(appName, teamName string) error
 	ListByTeam(teamName string) ([]string, error)
+	DeleteApp(appName string) error
+	TeamUsage(teamName string) (*Usage, error)
+}
+
+// Usage is the usage of the resources of a team by its apps, CPU and memory
+// are the sum of the requests of the pods
+type Usage struct {
+	Apps     int32
+	Replicas int32
+	CPU      string
+	Memory   string
 }
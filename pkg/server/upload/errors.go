@@ -0,0 +1,14 @@
+package upload
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidChecksum       = status.Errorf(codes.InvalidArgument, "Invalid upload checksum")
+	ErrInvalidOffset         = status.Errorf(codes.OutOfRange, "Chunk out of the upload offset, resume from the current one")
+	ErrChunkChecksumMismatch = status.Errorf(codes.DataLoss, "Chunk doesn't match its checksum, send it again")
+	ErrChecksumMismatch      = status.Errorf(codes.DataLoss, "Upload doesn't match its checksum, send it again")
+	ErrNotFound              = status.Errorf(codes.NotFound, "Upload not found")
+)
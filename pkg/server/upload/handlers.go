@@ -0,0 +1,65 @@
+package upload
+
+import (
+	"io"
+
+	context "golang.org/x/net/context"
+
+	upb "github.com/luizalabs/teresa/pkg/protobuf/upload"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"google.golang.org/grpc"
+)
+
+type Service struct {
+	ops Operations
+}
+
+// Send stores the chunks of the stream, replying with the offset of the
+// upload once it ends
+func (s *Service) Send(stream upb.Upload_SendServer) error {
+	u := stream.Context().Value("user").(*database.User)
+
+	var w *Writer
+	for {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if w == nil {
+			if w, err = s.ops.Writer(u, in.App, in.Checksum); err != nil {
+				return err
+			}
+		}
+		if err := w.WriteChunk(in.Offset, in.Chunk, in.ChunkChecksum); err != nil {
+			return err
+		}
+	}
+
+	resp := new(upb.StatusResponse)
+	if w != nil {
+		resp.Offset = w.Offset()
+	}
+	return stream.SendAndClose(resp)
+}
+
+// Status returns the offset of the upload, where the client resumes it
+func (s *Service) Status(ctx context.Context, req *upb.StatusRequest) (*upb.StatusResponse, error) {
+	u := ctx.Value("user").(*database.User)
+
+	w, err := s.ops.Writer(u, req.App, req.Checksum)
+	if err != nil {
+		return nil, err
+	}
+	return &upb.StatusResponse{Offset: w.Offset()}, nil
+}
+
+func (s *Service) RegisterService(grpcServer *grpc.Server) {
+	upb.RegisterUploadServer(grpcServer, s)
+}
+
+func NewService(ops Operations) *Service {
+	return &Service{ops: ops}
+}
@@ -0,0 +1,77 @@
+package upload
+
+import (
+	"io"
+	"testing"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	upb "github.com/luizalabs/teresa/pkg/protobuf/upload"
+)
+
+type fakeSendStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*upb.SendRequest
+	resp *upb.StatusResponse
+}
+
+func (f *fakeSendStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeSendStream) Recv() (*upb.SendRequest, error) {
+	if len(f.reqs) == 0 {
+		return nil, io.EOF
+	}
+	req := f.reqs[0]
+	f.reqs = f.reqs[1:]
+	return req, nil
+}
+
+func (f *fakeSendStream) SendAndClose(resp *upb.StatusResponse) error {
+	f.resp = resp
+	return nil
+}
+
+func TestSendAndStatus(t *testing.T) {
+	ops, _ := newTestOperations()
+	s := NewService(ops)
+	ctx := context.WithValue(context.Background(), "user", testUser)
+	content := []byte("some tarball content")
+	checksum := sum(content)
+
+	stream := &fakeSendStream{ctx: ctx, reqs: []*upb.SendRequest{
+		{App: "teresa", Checksum: checksum, Offset: 0, Chunk: content[:10], ChunkChecksum: sum(content[:10])},
+		{App: "teresa", Checksum: checksum, Offset: 10, Chunk: content[10:15], ChunkChecksum: sum(content[10:15])},
+	}}
+	if err := s.Send(stream); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if stream.resp.Offset != 15 {
+		t.Errorf("expected offset 15, got %d", stream.resp.Offset)
+	}
+
+	resp, err := s.Status(ctx, &upb.StatusRequest{App: "teresa", Checksum: checksum})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if resp.Offset != 15 {
+		t.Errorf("expected offset 15, got %d", resp.Offset)
+	}
+}
+
+func TestSendInvalidOffset(t *testing.T) {
+	ops, _ := newTestOperations()
+	s := NewService(ops)
+	ctx := context.WithValue(context.Background(), "user", testUser)
+	chunk := []byte("chunk")
+
+	stream := &fakeSendStream{ctx: ctx, reqs: []*upb.SendRequest{
+		{App: "teresa", Checksum: sum(chunk), Offset: 3, Chunk: chunk, ChunkChecksum: sum(chunk)},
+	}}
+	if err := s.Send(stream); err != ErrInvalidOffset {
+		t.Errorf("expected ErrInvalidOffset, got %v", err)
+	}
+}
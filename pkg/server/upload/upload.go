@@ -0,0 +1,283 @@
+package upload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/lease"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+	"github.com/luizalabs/teresa/pkg/server/team"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const (
+	uploadsDir = "uploads/"
+	// uploadTTL is how long the chunks of an upload never finished (or
+	// never deployed) are kept since the last one was stored
+	uploadTTL    = 24 * time.Hour
+	cleanupLease = "upload-cleanup"
+)
+
+// cleanupInterval is the time between the removals of the stale uploads
+var cleanupInterval = time.Hour
+
+var (
+	checksumRegexp  = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	chunkNameRegexp = regexp.MustCompile(`^([0-9]{16})-([0-9]{16})$`)
+)
+
+// Operations keeps the tarballs sent in chunks to the file storage, an
+// upload is named by the sha256 checksum of its content so an interrupted
+// one is resumed from the last chunk stored
+type Operations interface {
+	Writer(user *database.User, appName, checksum string) (*Writer, error)
+	Open(user *database.User, appName, checksum string) (io.ReadSeeker, error)
+	Remove(appName, checksum string) error
+	RunCleanup(l lease.Leases, stop <-chan struct{})
+}
+
+type StorageOperations struct {
+	aops    app.Operations
+	storage storage.Storage
+}
+
+// Writer appends the chunks of an upload, each one stored as an object
+// named by its start and end offsets
+type Writer struct {
+	storage storage.Storage
+	dir     string
+	offset  int64
+}
+
+type chunk struct {
+	start, end int64
+	name       string
+}
+
+// Offset is the size of the upload stored, where the next chunk starts
+func (w *Writer) Offset() int64 {
+	return w.offset
+}
+
+// WriteChunk stores the chunk starting at offset, it must be the current
+// one of the upload
+func (w *Writer) WriteChunk(offset int64, data []byte, checksum string) error {
+	if offset != w.offset {
+		return ErrInvalidOffset
+	}
+	if sum(data) != checksum {
+		return ErrChunkChecksumMismatch
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	end := offset + int64(len(data))
+	path := w.dir + chunkName(offset, end)
+	if err := w.storage.UploadFile(path, bytes.NewReader(data)); err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	w.offset = end
+	return nil
+}
+
+func (ops *StorageOperations) Writer(user *database.User, appName, checksum string) (*Writer, error) {
+	if !checksumRegexp.MatchString(checksum) {
+		return nil, ErrInvalidChecksum
+	}
+	if _, err := ops.aops.CheckRoleAndGet(user, appName, team.RoleDeployer); err != nil {
+		return nil, err
+	}
+
+	dir := uploadDir(appName, checksum)
+	chunks, err := ops.chunks(dir)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	w := &Writer{storage: ops.storage, dir: dir}
+	if n := len(chunks); n > 0 {
+		w.offset = chunks[n-1].end
+	}
+	return w, nil
+}
+
+// Open returns the content of the upload, checking it against its
+// checksum. A mismatching upload is removed to be sent again
+func (ops *StorageOperations) Open(user *database.User, appName, checksum string) (io.ReadSeeker, error) {
+	if !checksumRegexp.MatchString(checksum) {
+		return nil, ErrInvalidChecksum
+	}
+	if _, err := ops.aops.CheckRoleAndGet(user, appName, team.RoleDeployer); err != nil {
+		return nil, err
+	}
+
+	dir := uploadDir(appName, checksum)
+	chunks, err := ops.chunks(dir)
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	if len(chunks) == 0 {
+		return nil, ErrNotFound
+	}
+
+	content := new(bytes.Buffer)
+	for _, c := range chunks {
+		if err := ops.download(dir+c.name, content); err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+	}
+	if sum(content.Bytes()) != checksum {
+		ops.storage.Delete(dir)
+		return nil, ErrChecksumMismatch
+	}
+	return bytes.NewReader(content.Bytes()), nil
+}
+
+// Remove deletes the chunks of the upload, once its content was taken
+func (ops *StorageOperations) Remove(appName, checksum string) error {
+	return ops.storage.Delete(uploadDir(appName, checksum))
+}
+
+// removeStale deletes the uploads whose last chunk is older than the TTL,
+// left by clients that gave up on them
+func (ops *StorageOperations) removeStale(now time.Time) {
+	apps, err := ops.storage.List(uploadsDir)
+	if err != nil {
+		log.WithError(err).Error("Listing uploads to clean up")
+		return
+	}
+	for _, a := range apps {
+		dir := uploadsDir + a.Name + "/"
+		uploads, err := ops.storage.List(dir)
+		if err != nil {
+			log.WithError(err).Errorf("Listing uploads of app %s to clean up", a.Name)
+			continue
+		}
+		for _, u := range uploads {
+			if now.Sub(u.LastModified) < uploadTTL {
+				continue
+			}
+			if err := ops.storage.Delete(dir + u.Name + "/"); err != nil {
+				log.WithError(err).Errorf("Removing stale upload %s of app %s", u.Name, a.Name)
+			}
+		}
+	}
+}
+
+// RunCleanup removes the stale uploads every hour, until stop is closed.
+// Only the replica of the server holding the lease of the cleanup removes
+// them.
+func (ops *StorageOperations) RunCleanup(l lease.Leases, stop <-chan struct{}) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case t := <-ticker.C:
+			if lease.Held(l, cleanupLease, 3*cleanupInterval) {
+				ops.removeStale(t)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (ops *StorageOperations) download(path string, w io.Writer) error {
+	rc, err := ops.storage.Download(path)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(w, rc)
+	return err
+}
+
+// chunks returns the contiguous chunks of the upload from its start,
+// leftovers of an interrupted chunk are skipped
+func (ops *StorageOperations) chunks(dir string) ([]*chunk, error) {
+	objs, err := ops.storage.List(dir)
+	if err != nil {
+		return nil, err
+	}
+	var all []*chunk
+	for _, obj := range objs {
+		if c := parseChunkName(obj.Name); c != nil {
+			all = append(all, c)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].start < all[j].start })
+
+	var chunks []*chunk
+	var offset int64
+	for _, c := range all {
+		if c.start == offset {
+			chunks = append(chunks, c)
+			offset = c.end
+		}
+	}
+	return chunks, nil
+}
+
+// Tarball returns the content of the upload named by checksum or, without
+// a checksum, the one sent inline along with the request
+func Tarball(ops Operations, user *database.User, appName, checksum string, inline []byte) (io.ReadSeeker, error) {
+	if checksum == "" {
+		return bytes.NewReader(inline), nil
+	}
+	if ops == nil {
+		return nil, ErrNotFound
+	}
+	return ops.Open(user, appName, checksum)
+}
+
+// Discard removes the upload named by checksum, if any, logging the
+// failures
+func Discard(ops Operations, appName, checksum string) {
+	if checksum == "" || ops == nil {
+		return
+	}
+	if err := ops.Remove(appName, checksum); err != nil {
+		log.WithError(err).Errorf("Removing upload %s of app %s", checksum, appName)
+	}
+}
+
+func uploadDir(appName, checksum string) string {
+	return fmt.Sprintf("%s%s/%s/", uploadsDir, appName, checksum)
+}
+
+func chunkName(start, end int64) string {
+	return fmt.Sprintf("%016d-%016d", start, end)
+}
+
+func parseChunkName(name string) *chunk {
+	m := chunkNameRegexp.FindStringSubmatch(name)
+	if m == nil {
+		return nil
+	}
+	c := &chunk{name: name}
+	c.start, _ = strconv.ParseInt(m[1], 10, 64)
+	c.end, _ = strconv.ParseInt(m[2], 10, 64)
+	if c.end <= c.start {
+		return nil
+	}
+	return c
+}
+
+func sum(data []byte) string {
+	s := sha256.Sum256(data)
+	return hex.EncodeToString(s[:])
+}
+
+func NewOperations(aops app.Operations, s storage.Storage) Operations {
+	return &StorageOperations{aops: aops, storage: s}
+}
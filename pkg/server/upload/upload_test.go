@@ -0,0 +1,198 @@
+package upload
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/luizalabs/teresa/pkg/server/app"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/storage"
+)
+
+// memStorage keeps the files in memory, listing them like S3
+type memStorage struct {
+	storage.Storage
+	files    map[string][]byte
+	modified map[string]time.Time
+}
+
+func (m *memStorage) UploadFile(path string, file io.ReadSeeker) error {
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	m.files[path] = b
+	m.modified[path] = time.Now()
+	return nil
+}
+
+func (m *memStorage) List(path string) ([]*storage.Object, error) {
+	var objs []*storage.Object
+	found := make(map[string]*storage.Object)
+	for name := range m.files {
+		if !strings.HasPrefix(name, path) {
+			continue
+		}
+		child := strings.Split(strings.TrimPrefix(name, path), "/")[0]
+		obj, ok := found[child]
+		if !ok {
+			obj = &storage.Object{Name: child}
+			found[child] = obj
+			objs = append(objs, obj)
+		}
+		if m.modified[name].After(obj.LastModified) {
+			obj.LastModified = m.modified[name]
+		}
+	}
+	return objs, nil
+}
+
+func (m *memStorage) Delete(path string) error {
+	for name := range m.files {
+		if strings.HasPrefix(name, path) {
+			delete(m.files, name)
+		}
+	}
+	return nil
+}
+
+func (m *memStorage) Download(path string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(m.files[path])), nil
+}
+
+func newTestOperations() (Operations, *memStorage) {
+	s := &memStorage{files: make(map[string][]byte), modified: make(map[string]time.Time)}
+	return NewOperations(app.NewFakeOperations(), s), s
+}
+
+var testUser = &database.User{Email: "gopher@luizalabs.com"}
+
+func TestWriterResume(t *testing.T) {
+	ops, _ := newTestOperations()
+	content := []byte("some tarball content")
+	checksum := sum(content)
+
+	w, err := ops.Writer(testUser, "teresa", checksum)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if err := w.WriteChunk(0, content[:4], sum(content[:4])); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	w, err = ops.Writer(testUser, "teresa", checksum)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if w.Offset() != 4 {
+		t.Fatalf("expected offset 4, got %d", w.Offset())
+	}
+	if err := w.WriteChunk(0, content[:4], sum(content[:4])); err != ErrInvalidOffset {
+		t.Errorf("expected ErrInvalidOffset, got %v", err)
+	}
+	if err := w.WriteChunk(4, content[4:], sum(content[:4])); err != ErrChunkChecksumMismatch {
+		t.Errorf("expected ErrChunkChecksumMismatch, got %v", err)
+	}
+	if err := w.WriteChunk(4, content[4:], sum(content[4:])); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	rs, err := ops.Open(testUser, "teresa", checksum)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	got, _ := ioutil.ReadAll(rs)
+	if !bytes.Equal(got, content) {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	ops, _ := newTestOperations()
+	checksum := sum([]byte("content"))
+
+	if _, err := ops.Writer(testUser, "teresa", "abc"); err != ErrInvalidChecksum {
+		t.Errorf("expected ErrInvalidChecksum, got %v", err)
+	}
+	bad := &database.User{Email: "bad-user@luizalabs.com"}
+	if _, err := ops.Writer(bad, "teresa", checksum); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := ops.Writer(testUser, "other", checksum); err != app.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestOpenChecksumMismatch(t *testing.T) {
+	ops, s := newTestOperations()
+	checksum := sum([]byte("content"))
+	w, err := ops.Writer(testUser, "teresa", checksum)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if err := w.WriteChunk(0, []byte("other"), sum([]byte("other"))); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	if _, err := ops.Open(testUser, "teresa", checksum); err != ErrChecksumMismatch {
+		t.Errorf("expected ErrChecksumMismatch, got %v", err)
+	}
+	if len(s.files) != 0 {
+		t.Errorf("expected the upload removed, got %v", s.files)
+	}
+	if _, err := ops.Open(testUser, "teresa", checksum); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChunksSkipsLeftovers(t *testing.T) {
+	ops, s := newTestOperations()
+	dir := uploadDir("teresa", sum([]byte("content")))
+	s.files[dir+chunkName(0, 3)] = []byte("con")
+	s.files[dir+chunkName(5, 7)] = []byte("nt")
+	s.files[dir+"invalid"] = []byte("x")
+
+	chunks, err := ops.(*StorageOperations).chunks(dir)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(chunks) != 1 || chunks[0].end != 3 {
+		t.Errorf("expected only the first chunk, got %v", chunks)
+	}
+}
+
+func TestTarball(t *testing.T) {
+	rs, err := Tarball(nil, testUser, "teresa", "", []byte("inline"))
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if got, _ := ioutil.ReadAll(rs); string(got) != "inline" {
+		t.Errorf("expected the inline content, got %q", got)
+	}
+	if _, err := Tarball(nil, testUser, "teresa", sum(nil), nil); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRemoveStale(t *testing.T) {
+	ops, s := newTestOperations()
+	stale := uploadDir("teresa", sum([]byte("stale"))) + chunkName(0, 5)
+	recent := uploadDir("teresa", sum([]byte("recent"))) + chunkName(0, 6)
+	s.files[stale], s.files[recent] = []byte("stale"), []byte("recent")
+	s.modified[stale] = time.Now().Add(-2 * uploadTTL)
+	s.modified[recent] = time.Now()
+
+	ops.(*StorageOperations).removeStale(time.Now().Add(time.Hour))
+
+	if _, found := s.files[stale]; found {
+		t.Error("expected the stale upload removed")
+	}
+	if _, found := s.files[recent]; !found {
+		t.Error("expected the recent upload kept")
+	}
+}
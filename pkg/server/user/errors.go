@@ -10,4 +10,25 @@ var (
 	ErrUserAlreadyExists = status.Errorf(codes.AlreadyExists, "User already exists")
 	ErrInvalidPassword   = status.Errorf(codes.InvalidArgument, "Invalid password")
 	ErrInvalidEmail      = status.Errorf(codes.InvalidArgument, "Invalid e-mail")
+	// ErrTwoFactorRequired asks the client for the code of the two-factor
+	// authentication
+	ErrTwoFactorRequired       = status.Errorf(codes.Unauthenticated, "Two-factor authentication code required")
+	ErrInvalidTwoFactorCode    = status.Errorf(codes.PermissionDenied, "Invalid two-factor authentication code")
+	ErrTwoFactorBlocked        = status.Errorf(codes.ResourceExhausted, "Too many invalid two-factor authentication codes, try again later")
+	ErrTwoFactorAlreadyEnabled = status.Errorf(codes.FailedPrecondition, "Two-factor authentication already enabled, disable it first")
+	ErrTwoFactorNotEnabled     = status.Errorf(codes.FailedPrecondition, "Two-factor authentication not enabled")
+	ErrTwoFactorNotConfigured  = status.Errorf(codes.FailedPrecondition, "Two-factor authentication isn't configured on the server")
+	ErrResetNotConfigured      = status.Errorf(codes.FailedPrecondition, "Password reset isn't configured on the server")
+	ErrInvalidResetToken       = status.Errorf(codes.PermissionDenied, "Invalid or expired password reset token")
+	ErrSessionNotFound         = status.Errorf(codes.NotFound, "Session not found")
+	ErrUserSuspended           = status.Errorf(codes.PermissionDenied, "User suspended")
+	ErrSuspendYourself         = status.Errorf(codes.InvalidArgument, "You can't suspend yourself")
+	ErrServiceAccountPassword  = status.Errorf(codes.FailedPrecondition, "Service accounts have no password, they authenticate by the token")
+	// ErrPasswordChangeRequired asks the client for a new password on login
+	ErrPasswordChangeRequired = status.Errorf(codes.FailedPrecondition, "Password expired or not following the password policy, set a new one")
+	ErrPasswordReused         = status.Errorf(codes.InvalidArgument, "Password used recently, choose another one")
 )
+
+func newWeakPasswordError(missing string) error {
+	return status.Errorf(codes.InvalidArgument, "Weak password, it must have %s", missing)
+}
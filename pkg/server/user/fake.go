@@ -1,16 +1,27 @@
 package user
 
 import (
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+	"github.com/luizalabs/teresa/pkg/server/mail"
 )
 
 type FakeOperations struct {
 	mutex   *sync.RWMutex
 	Storage map[string]*database.User
+	// Resets are the emails of the users by password reset token
+	Resets map[string]string
+	// Sessions are the emails of the users by session id
+	Sessions map[string]string
+	// TwoFactorSessions are the ids of the sessions logged in with a
+	// two-factor code
+	TwoFactorSessions map[string]bool
+	policy   *PasswordPolicy
 }
 
 func (f *FakeOperations) Login(email, password string, exp time.Duration) (string, error) {
@@ -23,6 +34,129 @@ func (f *FakeOperations) Login(email, password string, exp time.Duration) (strin
 	return "good token", nil
 }
 
+func (f *FakeOperations) ServiceAccountToken(email string, exp time.Duration) (string, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if _, ok := f.Storage[email]; !ok {
+		return "", ErrNotFound
+	}
+	return "good token", nil
+}
+
+func (f *FakeOperations) Authenticate(email, password string) error {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	user, ok := f.Storage[email]
+	if !ok || user.Password != password || IsServiceAccount(email) {
+		return auth.ErrPermissionDenied
+	}
+	if user.Suspended {
+		return ErrUserSuspended
+	}
+	if f.policy != nil && (f.policy.Expired(passwordChangedAt(user)) || f.policy.Check(password) != nil) {
+		return ErrPasswordChangeRequired
+	}
+	return nil
+}
+
+func (f *FakeOperations) CreateSession(email string, twoFactor bool, exp time.Duration) (*Tokens, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if _, found := f.Storage[email]; !found {
+		return nil, ErrNotFound
+	}
+	return &Tokens{Access: "good token", Refresh: "good refresh token", ExpiresAt: time.Now().Add(accessTokenExpiration)}, nil
+}
+
+func (f *FakeOperations) RefreshSession(refreshToken string) (*Tokens, error) {
+	if refreshToken != "good refresh token" {
+		return nil, auth.ErrPermissionDenied
+	}
+	return &Tokens{Access: "good token", Refresh: "good refresh token", ExpiresAt: time.Now().Add(accessTokenExpiration)}, nil
+}
+
+func (f *FakeOperations) CheckSession(id, email string) (bool, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	if f.Sessions[id] != email {
+		return false, auth.ErrPermissionDenied
+	}
+	return f.TwoFactorSessions[id], nil
+}
+
+func (f *FakeOperations) sessionsOwner(user *database.User, userTarget string) (string, error) {
+	if userTarget == "" || userTarget == user.Email {
+		return user.Email, nil
+	}
+	if !user.IsAdmin {
+		return "", auth.ErrPermissionDenied
+	}
+	if _, found := f.Storage[userTarget]; !found {
+		return "", ErrNotFound
+	}
+	return userTarget, nil
+}
+
+func (f *FakeOperations) ListSessions(user *database.User, userTarget string) ([]*database.Session, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	email, err := f.sessionsOwner(user, userTarget)
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*database.Session
+	for id, e := range f.Sessions {
+		if e != email {
+			continue
+		}
+		sid, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		s := &database.Session{ExpiresAt: time.Now().Add(time.Hour)}
+		s.ID = uint(sid)
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
+
+func (f *FakeOperations) RevokeSession(user *database.User, id uint, userTarget string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	email, err := f.sessionsOwner(user, userTarget)
+	if err != nil {
+		return err
+	}
+	sid := strconv.FormatUint(uint64(id), 10)
+	if f.Sessions[sid] != email {
+		return ErrSessionNotFound
+	}
+	delete(f.Sessions, sid)
+	return nil
+}
+
+func (f *FakeOperations) RevokeSessions(user *database.User, userTarget string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	email, err := f.sessionsOwner(user, userTarget)
+	if err != nil {
+		return err
+	}
+	for id, e := range f.Sessions {
+		if e == email {
+			delete(f.Sessions, id)
+		}
+	}
+	return nil
+}
+
 func (f *FakeOperations) GetUser(email string) (*database.User, error) {
 	f.mutex.RLock()
 	defer f.mutex.RUnlock()
@@ -31,7 +165,8 @@ func (f *FakeOperations) GetUser(email string) (*database.User, error) {
 	if !found {
 		return nil, ErrNotFound
 	}
-	return &database.User{Email: user.Email, Password: user.Password}, nil
+	u := *user
+	return &u, nil
 }
 
 func (f *FakeOperations) SetPassword(user *database.User, newPassword, targetUser string) error {
@@ -49,6 +184,9 @@ func (f *FakeOperations) SetPassword(user *database.User, newPassword, targetUse
 	if _, found := f.Storage[email]; !found {
 		return ErrNotFound
 	}
+	if IsServiceAccount(email) {
+		return ErrServiceAccountPassword
+	}
 	f.Storage[email] = &database.User{Password: newPassword, Email: email}
 	return nil
 }
@@ -64,6 +202,26 @@ func (f *FakeOperations) Delete(email string) error {
 	return nil
 }
 
+func (f *FakeOperations) Suspend(email string) error {
+	return f.setSuspended(email, true)
+}
+
+func (f *FakeOperations) Reactivate(email string) error {
+	return f.setSuspended(email, false)
+}
+
+func (f *FakeOperations) setSuspended(email string, suspended bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	u, found := f.Storage[email]
+	if !found {
+		return ErrNotFound
+	}
+	u.Suspended = suspended
+	return nil
+}
+
 func (f *FakeOperations) Create(name, email, pass string, admin bool) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
@@ -80,8 +238,155 @@ func (f *FakeOperations) Create(name, email, pass string, admin bool) error {
 	return nil
 }
 
+func (f *FakeOperations) SetCipher(c encryption.Cipher) {}
+
+func (f *FakeOperations) BeginTwoFactor(user *database.User) (string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	u, found := f.Storage[user.Email]
+	if !found {
+		return "", ErrNotFound
+	}
+	if u.TOTPEnabled {
+		return "", ErrTwoFactorAlreadyEnabled
+	}
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	u.TOTPSecret = database.EncryptedString(secret)
+	return secret, nil
+}
+
+func (f *FakeOperations) EnableTwoFactor(user *database.User, code string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	u, found := f.Storage[user.Email]
+	if !found {
+		return ErrNotFound
+	}
+	if u.TOTPEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if u.TOTPSecret == "" {
+		return ErrTwoFactorNotEnabled
+	}
+	if !f.checkTOTP(u, code) {
+		return ErrInvalidTwoFactorCode
+	}
+	u.TOTPEnabled = true
+	return nil
+}
+
+func (f *FakeOperations) DisableTwoFactor(user *database.User, code, userTarget string) error {
+	email := user.Email
+	if userTarget != "" && userTarget != email {
+		if !user.IsAdmin {
+			return auth.ErrPermissionDenied
+		}
+		email = userTarget
+	}
+	if email == user.Email {
+		if _, err := f.CheckTwoFactor(email, code); err != nil {
+			return err
+		}
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	u, found := f.Storage[email]
+	if !found {
+		return ErrNotFound
+	}
+	if !u.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	u.TOTPSecret = ""
+	u.TOTPEnabled = false
+	return nil
+}
+
+func (f *FakeOperations) CheckTwoFactor(email, code string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	u, found := f.Storage[email]
+	if !found {
+		return false, ErrNotFound
+	}
+	if !u.TOTPEnabled {
+		return false, nil
+	}
+	if code == "" {
+		return false, ErrTwoFactorRequired
+	}
+	if !f.checkTOTP(u, code) {
+		return false, ErrInvalidTwoFactorCode
+	}
+	return true, nil
+}
+
+// checkTOTP checks the code like the DatabaseOperations, accepting each one
+// only once
+func (f *FakeOperations) checkTOTP(u *database.User, code string) bool {
+	counter, ok := validTOTP(string(u.TOTPSecret), code, time.Now())
+	if !ok || counter <= u.TOTPCounter {
+		return false
+	}
+	u.TOTPCounter = counter
+	return true
+}
+
+func (f *FakeOperations) SetMailer(m mail.Mailer) {}
+
+func (f *FakeOperations) SetPasswordPolicy(p *PasswordPolicy) {
+	f.policy = p
+}
+
+func (f *FakeOperations) RequestPasswordReset(email string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if _, found := f.Storage[email]; !found {
+		return ErrNotFound
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return err
+	}
+	f.Resets[token] = email
+	return nil
+}
+
+func (f *FakeOperations) ResetPassword(token, newPassword string) error {
+	if len(newPassword) < minPassLength {
+		return ErrInvalidPassword
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	email, found := f.Resets[token]
+	if !found {
+		return ErrInvalidResetToken
+	}
+	delete(f.Resets, token)
+	u, found := f.Storage[email]
+	if !found {
+		return ErrInvalidResetToken
+	}
+	u.Password = newPassword
+	return nil
+}
+
 func NewFakeOperations() Operations {
 	return &FakeOperations{
-		mutex:   &sync.RWMutex{},
-		Storage: make(map[string]*database.User)}
+		mutex:             &sync.RWMutex{},
+		Storage:           make(map[string]*database.User),
+		Resets:            make(map[string]string),
+		Sessions:          make(map[string]string),
+		TwoFactorSessions: make(map[string]bool)}
 }
@@ -20,11 +20,116 @@ func (s *Service) Login(ctx context.Context, request *userpb.LoginRequest) (*use
 	if request.ExpiresIn != 0 {
 		exp = time.Duration(request.ExpiresIn)
 	}
-	token, err := s.ops.Login(request.Email, request.Password, exp)
+	changePassword := false
+	if err := s.ops.Authenticate(request.Email, request.Password); err != nil {
+		switch {
+		case err == ErrPasswordChangeRequired && request.NewPassword != "":
+			changePassword = true
+		case err == ErrPasswordChangeRequired || err == ErrUserSuspended:
+			return nil, err
+		default:
+			return nil, auth.ErrPermissionDenied
+		}
+	}
+	twoFactor, err := s.ops.CheckTwoFactor(request.Email, request.Otp)
 	if err != nil {
+		if err == ErrTwoFactorRequired || err == ErrInvalidTwoFactorCode || err == ErrTwoFactorBlocked {
+			return nil, err
+		}
 		return nil, auth.ErrPermissionDenied
 	}
-	return &userpb.LoginResponse{Token: token}, nil
+	if changePassword {
+		u, err := s.ops.GetUser(request.Email)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.ops.SetPassword(u, request.NewPassword, ""); err != nil {
+			return nil, err
+		}
+	}
+	tk, err := s.ops.CreateSession(request.Email, twoFactor, exp)
+	if err != nil {
+		return nil, err
+	}
+	return loginResponse(tk), nil
+}
+
+func loginResponse(tk *Tokens) *userpb.LoginResponse {
+	return &userpb.LoginResponse{
+		Token:        tk.Access,
+		RefreshToken: tk.Refresh,
+		ExpiresAt:    tk.ExpiresAt.Unix(),
+	}
+}
+
+// Refresh renews the tokens of a session, called without token like the
+// logins
+func (s *Service) Refresh(ctx context.Context, request *userpb.RefreshRequest) (*userpb.LoginResponse, error) {
+	tk, err := s.ops.RefreshSession(request.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return loginResponse(tk), nil
+}
+
+func (s *Service) ListSessions(ctx context.Context, request *userpb.ListSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	sessions, err := s.ops.ListSessions(u, request.User)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userpb.ListSessionsResponse{}
+	for _, se := range sessions {
+		resp.Sessions = append(resp.Sessions, &userpb.ListSessionsResponse_Session{
+			Id:          uint64(se.ID),
+			CreatedAt:   se.CreatedAt.Unix(),
+			RefreshedAt: se.UpdatedAt.Unix(),
+			ExpiresAt:   se.ExpiresAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// RevokeSession revokes a session of the user, or all of them
+func (s *Service) RevokeSession(ctx context.Context, request *userpb.RevokeSessionRequest) (*userpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	var err error
+	if request.All {
+		err = s.ops.RevokeSessions(u, request.User)
+	} else {
+		err = s.ops.RevokeSession(u, uint(request.Id), request.User)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
+// SetTwoFactor begins the enrollment of the two-factor authentication when
+// no code is given, returning the new secret, and enables it otherwise
+func (s *Service) SetTwoFactor(ctx context.Context, request *userpb.SetTwoFactorRequest) (*userpb.SetTwoFactorResponse, error) {
+	u := ctx.Value("user").(*database.User)
+	if request.Code != "" {
+		if err := s.ops.EnableTwoFactor(u, request.Code); err != nil {
+			return nil, err
+		}
+		return &userpb.SetTwoFactorResponse{}, nil
+	}
+
+	secret, err := s.ops.BeginTwoFactor(u)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.SetTwoFactorResponse{Secret: secret, Url: totpURL(secret, u.Email)}, nil
+}
+
+func (s *Service) DisableTwoFactor(ctx context.Context, request *userpb.DisableTwoFactorRequest) (*userpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if err := s.ops.DisableTwoFactor(u, request.Code, request.User); err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
 }
 
 func (s *Service) SetPassword(ctx context.Context, request *userpb.SetPasswordRequest) (*userpb.Empty, error) {
@@ -46,6 +151,31 @@ func (s *Service) Delete(ctx context.Context, request *userpb.DeleteRequest) (*u
 	return &userpb.Empty{}, nil
 }
 
+func (s *Service) Suspend(ctx context.Context, request *userpb.SuspendRequest) (*userpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if request.Email == u.Email {
+		return nil, ErrSuspendYourself
+	}
+	if err := s.ops.Suspend(request.Email); err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
+func (s *Service) Reactivate(ctx context.Context, request *userpb.ReactivateRequest) (*userpb.Empty, error) {
+	u := ctx.Value("user").(*database.User)
+	if !u.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	if err := s.ops.Reactivate(request.Email); err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
 func (s *Service) Create(ctx context.Context, request *userpb.CreateRequest) (*userpb.Empty, error) {
 	u := ctx.Value("user").(*database.User)
 	if !u.IsAdmin {
@@ -63,6 +193,22 @@ func (s *Service) Create(ctx context.Context, request *userpb.CreateRequest) (*u
 	return &userpb.Empty{}, nil
 }
 
+// RequestPasswordReset e-mails a password reset token to the user, the
+// unknown emails aren't told apart
+func (s *Service) RequestPasswordReset(ctx context.Context, request *userpb.RequestPasswordResetRequest) (*userpb.Empty, error) {
+	if err := s.ops.RequestPasswordReset(request.Email); err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
+func (s *Service) ResetPassword(ctx context.Context, request *userpb.ResetPasswordRequest) (*userpb.Empty, error) {
+	if err := s.ops.ResetPassword(request.Token, request.Password); err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
 func (s *Service) RegisterService(grpcServer *grpc.Server) {
 	userpb.RegisterUserServer(grpcServer, s)
 }
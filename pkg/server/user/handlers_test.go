@@ -178,3 +178,178 @@ func TestCreateErrUserAlreadyExists(t *testing.T) {
 		t.Errorf("expected ErrUserAlreadyExists, got %s", err)
 	}
 }
+
+func TestUserLoginTwoFactor(t *testing.T) {
+	fake := NewFakeOperations()
+	email := "teresa@luizalabs.com"
+	u := &database.User{Email: email, Password: "123456"}
+	fake.(*FakeOperations).Storage[email] = u
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", u)
+
+	resp, err := s.SetTwoFactor(ctx, &userpb.SetTwoFactorRequest{})
+	if err != nil {
+		t.Fatal("error beginning two-factor:", err)
+	}
+	if resp.Secret == "" || resp.Url == "" {
+		t.Fatalf("expected secret and url, got %v", resp)
+	}
+	req := &userpb.SetTwoFactorRequest{Code: stepTOTP(t, resp.Secret, -1)}
+	if _, err := s.SetTwoFactor(ctx, req); err != nil {
+		t.Fatal("error enabling two-factor:", err)
+	}
+
+	login := &userpb.LoginRequest{Email: email, Password: "123456"}
+	if _, err := s.Login(context.Background(), login); err != ErrTwoFactorRequired {
+		t.Error("expected ErrTwoFactorRequired, got", err)
+	}
+	login.Otp = currentTOTP(t, resp.Secret)
+	if _, err := s.Login(context.Background(), login); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	if _, err := s.Login(context.Background(), login); err != ErrInvalidTwoFactorCode {
+		t.Error("expected ErrInvalidTwoFactorCode for a replayed code, got", err)
+	}
+	login.Password = "bad"
+	if _, err := s.Login(context.Background(), login); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	fake := NewFakeOperations()
+	email := "teresa@luizalabs.com"
+	fake.(*FakeOperations).Storage[email] = &database.User{Email: email, Password: "123456"}
+	s := NewService(fake)
+
+	req := &userpb.RequestPasswordResetRequest{Email: "gopher@luizalabs.com"}
+	if _, err := s.RequestPasswordReset(context.Background(), req); err != nil {
+		t.Error("expected no error for unknown users, got", err)
+	}
+	req.Email = email
+	if _, err := s.RequestPasswordReset(context.Background(), req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	var token string
+	for token = range fake.(*FakeOperations).Resets {
+	}
+	reset := &userpb.ResetPasswordRequest{Token: token, Password: "new-password"}
+	if _, err := s.ResetPassword(context.Background(), reset); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, err := s.ResetPassword(context.Background(), reset); err != ErrInvalidResetToken {
+		t.Error("expected ErrInvalidResetToken, got", err)
+	}
+	if p := fake.(*FakeOperations).Storage[email].Password; p != "new-password" {
+		t.Errorf("expected new-password, got %s", p)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	s := NewService(NewFakeOperations())
+
+	req := &userpb.RefreshRequest{RefreshToken: "good refresh token"}
+	res, err := s.Refresh(context.Background(), req)
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if res.Token != "good token" || res.RefreshToken != "good refresh token" {
+		t.Errorf("expected the new tokens, got %v", res)
+	}
+
+	req.RefreshToken = "bad refresh token"
+	if _, err := s.Refresh(context.Background(), req); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+}
+
+func TestListAndRevokeSessions(t *testing.T) {
+	fake := NewFakeOperations()
+	email := "teresa@luizalabs.com"
+	u := &database.User{Email: email}
+	fake.(*FakeOperations).Storage[email] = u
+	fake.(*FakeOperations).Sessions["1"] = email
+	fake.(*FakeOperations).Sessions["2"] = "gopher@luizalabs.com"
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", u)
+
+	resp, err := s.ListSessions(ctx, &userpb.ListSessionsRequest{})
+	if err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if len(resp.Sessions) != 1 || resp.Sessions[0].Id != 1 {
+		t.Errorf("expected the session 1, got %v", resp.Sessions)
+	}
+
+	if _, err := s.RevokeSession(ctx, &userpb.RevokeSessionRequest{Id: 2}); err != ErrSessionNotFound {
+		t.Error("expected ErrSessionNotFound, got", err)
+	}
+	req := &userpb.RevokeSessionRequest{User: "gopher@luizalabs.com", All: true}
+	if _, err := s.RevokeSession(ctx, req); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	if _, err := s.RevokeSession(ctx, &userpb.RevokeSessionRequest{Id: 1}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if _, found := fake.(*FakeOperations).Sessions["1"]; found {
+		t.Error("expected the session 1 revoked")
+	}
+}
+
+func TestSuspendAndReactivate(t *testing.T) {
+	fake := NewFakeOperations()
+	admin := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	email := "teresa@luizalabs.com"
+	fake.(*FakeOperations).Storage[email] = &database.User{Password: "gopher", Email: email}
+	s := NewService(fake)
+	ctx := context.WithValue(context.Background(), "user", admin)
+
+	userCtx := context.WithValue(context.Background(), "user", &database.User{Email: email})
+	if _, err := s.Suspend(userCtx, &userpb.SuspendRequest{Email: email}); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := s.Suspend(ctx, &userpb.SuspendRequest{Email: admin.Email}); err != ErrSuspendYourself {
+		t.Errorf("expected ErrSuspendYourself, got %v", err)
+	}
+	if _, err := s.Suspend(ctx, &userpb.SuspendRequest{Email: email}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+
+	login := &userpb.LoginRequest{Email: email, Password: "gopher"}
+	if _, err := s.Login(context.Background(), login); err != ErrUserSuspended {
+		t.Errorf("expected ErrUserSuspended, got %v", err)
+	}
+	login.Password = "wrong"
+	if _, err := s.Login(context.Background(), login); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	if _, err := s.Reactivate(ctx, &userpb.ReactivateRequest{Email: email}); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	login.Password = "gopher"
+	if _, err := s.Login(context.Background(), login); err != nil {
+		t.Error("got unexpected error:", err)
+	}
+}
+
+func TestLoginPasswordChangeRequired(t *testing.T) {
+	fake := NewFakeOperations()
+	fake.SetPasswordPolicy(&PasswordPolicy{MinLength: 8, RequireDigit: true})
+	email := "teresa@luizalabs.com"
+	fake.(*FakeOperations).Storage[email] = &database.User{Email: email, Password: "password"}
+	s := NewService(fake)
+
+	req := &userpb.LoginRequest{Email: email, Password: "password"}
+	if _, err := s.Login(context.Background(), req); err != ErrPasswordChangeRequired {
+		t.Errorf("expected ErrPasswordChangeRequired, got %v", err)
+	}
+	req.NewPassword = "password1"
+	if _, err := s.Login(context.Background(), req); err != nil {
+		t.Fatal("got unexpected error:", err)
+	}
+	if p := fake.(*FakeOperations).Storage[email].Password; p != "password1" {
+		t.Errorf("expected password1, got %s", p)
+	}
+}
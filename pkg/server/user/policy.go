@@ -0,0 +1,131 @@
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// PasswordPolicy are the rules of the passwords set by the users, the zero
+// value has none
+type PasswordPolicy struct {
+	MinLength     int  `split_words:"true" default:"8"`
+	RequireUpper  bool `split_words:"true"`
+	RequireLower  bool `split_words:"true"`
+	RequireDigit  bool `split_words:"true"`
+	RequireSymbol bool `split_words:"true"`
+	// History is the number of the last passwords of a user, the current one
+	// included, that can't be set again
+	History int
+	// MaxAge is how long a password is valid, the users set a new one on the
+	// next login after it
+	MaxAge time.Duration `split_words:"true"`
+}
+
+// Check checks the password against the rules of the policy, the error
+// tells the ones missing
+func (p *PasswordPolicy) Check(password string) error {
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+
+	var missing []string
+	if len(password) < p.MinLength {
+		missing = append(missing, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if p.RequireUpper && !upper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) > 0 {
+		return newWeakPasswordError(strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Expired tells if a password set at changedAt is too old
+func (p *PasswordPolicy) Expired(changedAt time.Time) bool {
+	return p.MaxAge > 0 && time.Now().After(changedAt.Add(p.MaxAge))
+}
+
+func (dbu *DatabaseOperations) SetPasswordPolicy(p *PasswordPolicy) {
+	dbu.policy = p
+}
+
+// passwordChangedAt returns when the password of the user was set, the
+// users created before the policies count from their creation
+func passwordChangedAt(u *database.User) time.Time {
+	if u.PasswordChangedAt != nil {
+		return *u.PasswordChangedAt
+	}
+	return u.CreatedAt
+}
+
+// checkPasswordReuse checks the password isn't one of the last ones of the
+// user, as many as the history of the policy
+func (dbu *DatabaseOperations) checkPasswordReuse(u *database.User, password string) error {
+	if dbu.policy.History < 1 {
+		return nil
+	}
+
+	hashes := []string{u.Password}
+	var olds []*database.PasswordHistory
+	err := dbu.DB.Where("user_id = ?", u.ID).Order("id desc").Limit(dbu.policy.History - 1).Find(&olds).Error
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	for _, old := range olds {
+		hashes = append(hashes, old.Password)
+	}
+
+	for _, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+			return ErrPasswordReused
+		}
+	}
+	return nil
+}
+
+// savePasswordHistory keeps the replaced password hash of the user, dropping
+// the ones older than the history of the policy
+func (dbu *DatabaseOperations) savePasswordHistory(u *database.User, hash string) error {
+	if dbu.policy.History < 2 {
+		return dbu.DB.Where("user_id = ?", u.ID).Delete(&database.PasswordHistory{}).Error
+	}
+
+	old := &database.PasswordHistory{UserID: u.ID, Password: hash}
+	if err := dbu.DB.Create(old).Error; err != nil {
+		return err
+	}
+	var keep []uint
+	err := dbu.DB.Model(&database.PasswordHistory{}).Where("user_id = ?", u.ID).
+		Order("id desc").Limit(dbu.policy.History-1).Pluck("id", &keep).Error
+	if err != nil {
+		return err
+	}
+	return dbu.DB.Where("user_id = ? AND id NOT IN (?)", u.ID, keep).Delete(&database.PasswordHistory{}).Error
+}
@@ -0,0 +1,128 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	p := &PasswordPolicy{MinLength: 10, RequireUpper: true, RequireLower: true, RequireDigit: true, RequireSymbol: true}
+	var testCases = []struct {
+		password string
+		valid    bool
+	}{
+		{"Sh0rt!", false},
+		{"longpassword", false},
+		{"Longpassword1", false},
+		{"Longpassword1!", true},
+		{"LONGPASSWORD1!", false},
+	}
+
+	for _, tc := range testCases {
+		if err := p.Check(tc.password); (err == nil) != tc.valid {
+			t.Errorf("expected valid %v for %s, got %v", tc.valid, tc.password, err)
+		}
+	}
+	if err := new(PasswordPolicy).Check(""); err != nil {
+		t.Error("expected no rules on the zero policy, got", err)
+	}
+}
+
+func TestPasswordPolicyExpired(t *testing.T) {
+	p := &PasswordPolicy{MaxAge: time.Hour}
+	if p.Expired(time.Now()) {
+		t.Error("expected a new password valid")
+	}
+	if !p.Expired(time.Now().Add(-2 * time.Hour)) {
+		t.Error("expected an old password expired")
+	}
+	if new(PasswordPolicy).Expired(time.Time{}) {
+		t.Error("expected no max age on the zero policy")
+	}
+}
+
+func TestDatabaseOperationsPasswordReuse(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	dbu.SetPasswordPolicy(&PasswordPolicy{MinLength: 8, History: 3})
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "password-0", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	u := &database.User{Email: email}
+
+	if err := dbu.SetPassword(u, "short", ""); err == nil {
+		t.Error("expected an error for a short password")
+	}
+	for _, p := range []string{"password-1", "password-2"} {
+		if err := dbu.SetPassword(u, p, ""); err != nil {
+			t.Fatal("error setting password:", err)
+		}
+	}
+	for _, p := range []string{"password-0", "password-1", "password-2"} {
+		if err := dbu.SetPassword(u, p, ""); err != ErrPasswordReused {
+			t.Errorf("expected ErrPasswordReused for %s, got %v", p, err)
+		}
+	}
+	if err := dbu.SetPassword(u, "password-3", ""); err != nil {
+		t.Fatal("error setting password:", err)
+	}
+	if err := dbu.SetPassword(u, "password-0", ""); err != nil {
+		t.Error("expected a password out of the history allowed, got", err)
+	}
+
+	var count int
+	db.Model(&database.PasswordHistory{}).Count(&count)
+	if count != 2 {
+		t.Errorf("expected 2 passwords in the history, got %d", count)
+	}
+}
+
+func TestDatabaseOperationsAuthenticatePasswordPolicy(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err := dbu.Create("Test", email, "password", false); err != nil {
+		t.Fatal("error on create user: ", err)
+	}
+
+	dbu.SetPasswordPolicy(&PasswordPolicy{MinLength: 8, MaxAge: time.Hour})
+	if err := dbu.Authenticate(email, "password"); err != nil {
+		t.Error("expected no error, got", err)
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := db.Model(&database.User{}).Update("password_changed_at", old).Error; err != nil {
+		t.Fatal("error updating user:", err)
+	}
+	if err := dbu.Authenticate(email, "password"); err != ErrPasswordChangeRequired {
+		t.Error("expected ErrPasswordChangeRequired for an expired password, got", err)
+	}
+	if err := dbu.Authenticate(email, "wrong"); err == nil || err == ErrPasswordChangeRequired {
+		t.Error("expected a permission denied error, got", err)
+	}
+
+	dbu.SetPasswordPolicy(&PasswordPolicy{MinLength: 8, RequireDigit: true})
+	if err := dbu.Authenticate(email, "password"); err != ErrPasswordChangeRequired {
+		t.Error("expected ErrPasswordChangeRequired for a weak password, got", err)
+	}
+}
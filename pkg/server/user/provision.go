@@ -0,0 +1,36 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// Provision creates the user signed in by an external identity provider on
+// its first login, with a random password nobody knows. It tells if the
+// user was created.
+func Provision(ops Operations, name, email string) (bool, error) {
+	if _, err := ops.GetUser(email); err != ErrNotFound {
+		return false, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return false, teresa_errors.NewInternalServerError(err)
+	}
+	pass := hex.EncodeToString(b)
+
+	if name == "" {
+		name = email
+	}
+	err := ops.Create(name, email, pass, false)
+	if err != nil && name != email {
+		// the names are unique, the email is the name of the namesakes
+		err = ops.Create(email, email, pass, false)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
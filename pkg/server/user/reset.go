@@ -0,0 +1,135 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/mail"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// passwordResetExpiration is how long the tokens of the password resets
+// are valid
+const passwordResetExpiration = time.Hour
+
+const resetBody = `Hi %s,
+
+A password reset was requested for your Teresa account. To choose a new
+password run:
+
+    $ teresa reset-password --user %s --token %s
+
+The token expires in %s. If you didn't request it, ignore this e-mail.
+`
+
+// SetMailer sets the mailer of the e-mails of the password resets, without
+// it the password reset is disabled
+func (dbu *DatabaseOperations) SetMailer(m mail.Mailer) {
+	dbu.mailer = m
+}
+
+// newSecretToken returns a random token, like the ones of the password
+// resets and the refresh tokens, stored only by its hash
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func resetMessage(u *database.User, token string) *mail.Message {
+	return &mail.Message{
+		To:      []string{u.Email},
+		Subject: "Teresa password reset",
+		Body:    fmt.Sprintf(resetBody, u.Name, u.Email, token, passwordResetExpiration),
+	}
+}
+
+func (dbu *DatabaseOperations) deleteResets(u *database.User) error {
+	if err := dbu.DB.Where("user_id = ?", u.ID).Delete(&database.PasswordReset{}).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Deleting password resets of user %s", u.Email)),
+		)
+	}
+	return nil
+}
+
+// RequestPasswordReset e-mails a token to the user to reset the password,
+// replacing the previous ones
+func (dbu *DatabaseOperations) RequestPasswordReset(email string) error {
+	if dbu.mailer == nil {
+		return ErrResetNotConfigured
+	}
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return err
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return teresa_errors.NewInternalServerError(err)
+	}
+	if err := dbu.deleteResets(u); err != nil {
+		return err
+	}
+	r := &database.PasswordReset{
+		UserID:    u.ID,
+		TokenHash: hashSecretToken(token),
+		ExpiresAt: time.Now().Add(passwordResetExpiration),
+	}
+	if err := dbu.DB.Create(r).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Creating password reset of user %s", email)),
+		)
+	}
+
+	if err := dbu.mailer.Send(resetMessage(u, token)); err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Sending password reset to user %s", email)),
+		)
+	}
+	return nil
+}
+
+// ResetPassword sets the password of the user of the token, the token is
+// valid only once
+func (dbu *DatabaseOperations) ResetPassword(token, newPassword string) error {
+	if len(newPassword) < minPassLength {
+		return ErrInvalidPassword
+	}
+	if err := dbu.policy.Check(newPassword); err != nil {
+		return err
+	}
+
+	r := new(database.PasswordReset)
+	if dbu.DB.Preload("User").Where(&database.PasswordReset{TokenHash: hashSecretToken(token)}).First(r).RecordNotFound() {
+		return ErrInvalidResetToken
+	}
+	if time.Now().After(r.ExpiresAt) || r.User.ID == 0 {
+		if err := dbu.DB.Delete(r).Error; err != nil {
+			return teresa_errors.NewInternalServerError(err)
+		}
+		return ErrInvalidResetToken
+	}
+	if err := dbu.savePassword(&r.User, newPassword); err != nil {
+		return err
+	}
+	// whoever got the old password is logged out as well
+	return dbu.deleteSessions(&r.User)
+}
@@ -0,0 +1,115 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/mail"
+)
+
+// tokenOf returns the token of the password reset e-mail
+func tokenOf(t *testing.T, msg *mail.Message) string {
+	fields := strings.Fields(msg.Body)
+	for i, f := range fields {
+		if f == "--token" && i+1 < len(fields) {
+			return fields[i+1]
+		}
+	}
+	t.Fatal("token not found in:", msg.Body)
+	return ""
+}
+
+func TestDatabaseOperationsResetPassword(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	mailer := mail.NewFake()
+	dbu.SetMailer(mailer)
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+
+	if err := dbu.RequestPasswordReset("gopher@luizalabs.com"); err != ErrNotFound {
+		t.Error("expected ErrNotFound, got", err)
+	}
+	if err := dbu.RequestPasswordReset(email); err != nil {
+		t.Fatal("error requesting password reset:", err)
+	}
+	if len(mailer.Messages) != 1 || mailer.Messages[0].To[0] != email {
+		t.Fatalf("expected an e-mail to %s, got %v", email, mailer.Messages)
+	}
+	token := tokenOf(t, mailer.Messages[0])
+
+	if err := dbu.ResetPassword(token, "short"); err != ErrInvalidPassword {
+		t.Error("expected ErrInvalidPassword, got", err)
+	}
+	if err := dbu.ResetPassword("bad-token", "new-password"); err != ErrInvalidResetToken {
+		t.Error("expected ErrInvalidResetToken, got", err)
+	}
+	if err := dbu.ResetPassword(token, "new-password"); err != nil {
+		t.Fatal("error resetting password:", err)
+	}
+	if _, err := dbu.Login(email, "new-password", time.Second); err != nil {
+		t.Error("error on login with the new password:", err)
+	}
+	if err := dbu.ResetPassword(token, "other-password"); err != ErrInvalidResetToken {
+		t.Error("expected ErrInvalidResetToken on the reuse of the token, got", err)
+	}
+}
+
+func TestDatabaseOperationsResetPasswordExpired(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	mailer := mail.NewFake()
+	dbu.SetMailer(mailer)
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	if err := dbu.RequestPasswordReset(email); err != nil {
+		t.Fatal("error requesting password reset:", err)
+	}
+	err = db.Model(&database.PasswordReset{}).Update("expires_at", time.Now().Add(-time.Minute)).Error
+	if err != nil {
+		t.Fatal("error expiring the password reset:", err)
+	}
+	if err := dbu.ResetPassword(tokenOf(t, mailer.Messages[0]), "new-password"); err != ErrInvalidResetToken {
+		t.Error("expected ErrInvalidResetToken, got", err)
+	}
+}
+
+func TestDatabaseOperationsResetPasswordNotConfigured(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	if err := dbu.RequestPasswordReset("teresa@luizalabs.com"); err != ErrResetNotConfigured {
+		t.Error("expected ErrResetNotConfigured, got", err)
+	}
+}
@@ -0,0 +1,171 @@
+package user
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+// accessTokenExpiration is how long the access tokens of the sessions are
+// valid, the clients renew them with the refresh tokens
+const accessTokenExpiration = 15 * time.Minute
+
+// Tokens are the tokens of a session, ExpiresAt is the expiration of the
+// access token
+type Tokens struct {
+	Access    string
+	Refresh   string
+	ExpiresAt time.Time
+}
+
+func (dbu *DatabaseOperations) deleteSessions(u *database.User) error {
+	if err := dbu.DB.Where("user_id = ?", u.ID).Delete(&database.Session{}).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Deleting sessions of user %s", u.Email)),
+		)
+	}
+	return nil
+}
+
+// issueTokens returns a new access token of the session along with a new
+// refresh token, replacing the previous one
+func (dbu *DatabaseOperations) issueTokens(s *database.Session, email string) (*Tokens, error) {
+	refresh, err := newSecretToken()
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	s.RefreshHash = hashSecretToken(refresh)
+	if err := dbu.DB.Save(s).Error; err != nil {
+		return nil, teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Saving session of user %s", email)),
+		)
+	}
+
+	exp := accessTokenExpiration
+	if left := s.ExpiresAt.Sub(time.Now()); left < exp {
+		exp = left
+	}
+	access, err := dbu.auth.GenerateSessionToken(email, strconv.FormatUint(uint64(s.ID), 10), exp)
+	if err != nil {
+		return nil, teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, "Signing JWT token"),
+		)
+	}
+	return &Tokens{Access: access, Refresh: refresh, ExpiresAt: time.Now().Add(exp)}, nil
+}
+
+// CreateSession starts a session of the user lasting exp, dropping its
+// expired ones, twoFactor tells if a two-factor code was checked
+func (dbu *DatabaseOperations) CreateSession(email string, twoFactor bool, exp time.Duration) (*Tokens, error) {
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return nil, err
+	}
+	if u.Suspended {
+		return nil, ErrUserSuspended
+	}
+	err = dbu.DB.Where("user_id = ? AND expires_at < ?", u.ID, time.Now()).Delete(&database.Session{}).Error
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+
+	s := &database.Session{UserID: u.ID, ExpiresAt: time.Now().Add(exp), TwoFactor: twoFactor}
+	return dbu.issueTokens(s, email)
+}
+
+// RefreshSession returns new tokens of the session of the refresh token,
+// which is valid only once
+func (dbu *DatabaseOperations) RefreshSession(refreshToken string) (*Tokens, error) {
+	s := new(database.Session)
+	if dbu.DB.Preload("User").Where(&database.Session{RefreshHash: hashSecretToken(refreshToken)}).First(s).RecordNotFound() {
+		return nil, auth.ErrPermissionDenied
+	}
+	if time.Now().After(s.ExpiresAt) || s.User.ID == 0 || s.User.Suspended {
+		if err := dbu.DB.Delete(s).Error; err != nil {
+			return nil, teresa_errors.NewInternalServerError(err)
+		}
+		return nil, auth.ErrPermissionDenied
+	}
+	return dbu.issueTokens(s, s.User.Email)
+}
+
+// CheckSession checks the session of an access token is still valid, the
+// sessions deleted revoke their access tokens right away. It tells if the
+// session checked a two-factor code on the login.
+func (dbu *DatabaseOperations) CheckSession(id, email string) (bool, error) {
+	sid, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return false, auth.ErrPermissionDenied
+	}
+	s := new(database.Session)
+	if dbu.DB.Preload("User").First(s, sid).RecordNotFound() {
+		return false, auth.ErrPermissionDenied
+	}
+	if s.User.Email != email || time.Now().After(s.ExpiresAt) {
+		return false, auth.ErrPermissionDenied
+	}
+	return s.TwoFactor, nil
+}
+
+// sessionsOwner returns the user whose sessions are managed, only admins
+// manage the sessions of other users
+func (dbu *DatabaseOperations) sessionsOwner(user *database.User, userTarget string) (*database.User, error) {
+	if userTarget == "" || userTarget == user.Email {
+		return user, nil
+	}
+	if !user.IsAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	return dbu.GetUser(userTarget)
+}
+
+// ListSessions returns the sessions of the user not expired yet
+func (dbu *DatabaseOperations) ListSessions(user *database.User, userTarget string) ([]*database.Session, error) {
+	u, err := dbu.sessionsOwner(user, userTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []*database.Session
+	err = dbu.DB.Where("user_id = ? AND expires_at >= ?", u.ID, time.Now()).Order("id").Find(&sessions).Error
+	if err != nil {
+		return nil, teresa_errors.NewInternalServerError(err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession deletes a session of the user, its access token is denied
+// right away and its refresh token can't be used anymore
+func (dbu *DatabaseOperations) RevokeSession(user *database.User, id uint, userTarget string) error {
+	u, err := dbu.sessionsOwner(user, userTarget)
+	if err != nil {
+		return err
+	}
+
+	res := dbu.DB.Where("id = ? AND user_id = ?", id, u.ID).Delete(&database.Session{})
+	if res.Error != nil {
+		return teresa_errors.NewInternalServerError(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeSessions deletes all sessions of the user
+func (dbu *DatabaseOperations) RevokeSessions(user *database.User, userTarget string) error {
+	u, err := dbu.sessionsOwner(user, userTarget)
+	if err != nil {
+		return err
+	}
+	return dbu.deleteSessions(u)
+}
@@ -0,0 +1,199 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+)
+
+func TestDatabaseOperationsSessions(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+
+	if _, err := dbu.CreateSession("gopher@luizalabs.com", false, time.Hour); err != ErrNotFound {
+		t.Error("expected ErrNotFound, got", err)
+	}
+	tk, err := dbu.CreateSession(email, false, time.Hour)
+	if err != nil {
+		t.Fatal("error creating session:", err)
+	}
+	if tk.Access == "" || tk.Refresh == "" {
+		t.Errorf("expected tokens, got %v", tk)
+	}
+	if tk.ExpiresAt.After(time.Now().Add(accessTokenExpiration)) {
+		t.Errorf("expected the access token to expire in %v, got %v", accessTokenExpiration, tk.ExpiresAt)
+	}
+	if twoFactor, err := dbu.CheckSession("1", email); twoFactor || err != nil {
+		t.Errorf("expected a valid session without two-factor, got %v:%v", twoFactor, err)
+	}
+	if _, err := dbu.CheckSession("1", "gopher@luizalabs.com"); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+
+	refreshed, err := dbu.RefreshSession(tk.Refresh)
+	if err != nil {
+		t.Fatal("error refreshing session:", err)
+	}
+	if refreshed.Refresh == tk.Refresh {
+		t.Error("expected a new refresh token")
+	}
+	if _, err := dbu.RefreshSession(tk.Refresh); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied for a used refresh token, got", err)
+	}
+
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		t.Fatal("error getting user:", err)
+	}
+	if err := dbu.(*DatabaseOperations).deleteSessions(u); err != nil {
+		t.Fatal("error deleting sessions:", err)
+	}
+	if _, err := dbu.CheckSession("1", email); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied for a revoked session, got", err)
+	}
+	if _, err := dbu.RefreshSession(refreshed.Refresh); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied for a revoked session, got", err)
+	}
+}
+
+func TestDatabaseOperationsExpiredSession(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	tk, err := dbu.CreateSession(email, false, time.Hour)
+	if err != nil {
+		t.Fatal("error creating session:", err)
+	}
+	if err := db.Model(&database.Session{}).Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatal("error expiring session:", err)
+	}
+
+	if _, err := dbu.CheckSession("1", email); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	if _, err := dbu.RefreshSession(tk.Refresh); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	var count int
+	db.Model(&database.Session{}).Count(&count)
+	if count != 0 {
+		t.Errorf("expected the expired session deleted, got %d sessions", count)
+	}
+}
+
+func TestDatabaseOperationsSessionTwoFactor(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	tk, err := dbu.CreateSession(email, true, time.Hour)
+	if err != nil {
+		t.Fatal("error creating session:", err)
+	}
+	if twoFactor, err := dbu.CheckSession("1", email); !twoFactor || err != nil {
+		t.Errorf("expected a session with two-factor, got %v:%v", twoFactor, err)
+	}
+
+	// the refreshed tokens keep the session
+	if _, err := dbu.RefreshSession(tk.Refresh); err != nil {
+		t.Fatal("error refreshing session:", err)
+	}
+	if twoFactor, err := dbu.CheckSession("1", email); !twoFactor || err != nil {
+		t.Errorf("expected a session with two-factor, got %v:%v", twoFactor, err)
+	}
+}
+
+func TestDatabaseOperationsRevokeSessions(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email, other := "teresa@luizalabs.com", "gopher@luizalabs.com"
+	for _, e := range []string{email, other} {
+		if err = createFakeUser(db, e, e, "secret", false); err != nil {
+			t.Fatal("error on create fake user: ", err)
+		}
+		for i := 0; i < 2; i++ {
+			if _, err := dbu.CreateSession(e, false, time.Hour); err != nil {
+				t.Fatal("error creating session:", err)
+			}
+		}
+	}
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		t.Fatal("error getting user:", err)
+	}
+
+	sessions, err := dbu.ListSessions(u, "")
+	if err != nil {
+		t.Fatal("error listing sessions:", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != 1 || sessions[1].ID != 2 {
+		t.Fatalf("expected sessions 1 and 2, got %v", sessions)
+	}
+	if _, err := dbu.ListSessions(u, other); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	if err := dbu.RevokeSession(u, 3, ""); err != ErrSessionNotFound {
+		t.Error("expected ErrSessionNotFound for a session of other user, got", err)
+	}
+	if err := dbu.RevokeSession(u, 1, ""); err != nil {
+		t.Fatal("error revoking session:", err)
+	}
+	if _, err := dbu.CheckSession("1", email); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied for a revoked session, got", err)
+	}
+
+	u.IsAdmin = true
+	if err := dbu.RevokeSessions(u, other); err != nil {
+		t.Fatal("error revoking sessions:", err)
+	}
+	if sessions, _ = dbu.ListSessions(u, other); len(sessions) != 0 {
+		t.Errorf("expected no sessions, got %v", sessions)
+	}
+	if _, err := dbu.CheckSession("2", email); err != nil {
+		t.Error("expected the session of the admin kept, got", err)
+	}
+}
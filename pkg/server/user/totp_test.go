@@ -0,0 +1,77 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func currentTOTP(t *testing.T, secret string) string {
+	return stepTOTP(t, secret, 0)
+}
+
+// stepTOTP returns the code of the secret step periods from now
+func stepTOTP(t *testing.T, secret string, step int64) string {
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatal("error decoding the secret:", err)
+	}
+	return totpCode(key, uint64(time.Now().Unix()/totpPeriod+step))
+}
+
+func TestTOTPCode(t *testing.T) {
+	// the test vectors of the RFC 4226, truncated to 6 digits
+	key := []byte("12345678901234567890")
+	var testCases = []struct {
+		counter  uint64
+		expected string
+	}{
+		{0, "755224"},
+		{1, "287082"},
+		{9, "520489"},
+	}
+	for _, tc := range testCases {
+		if code := totpCode(key, tc.counter); code != tc.expected {
+			t.Errorf("expected %s, got %s", tc.expected, code)
+		}
+	}
+}
+
+func TestValidTOTP(t *testing.T) {
+	secret, err := newTOTPSecret()
+	if err != nil {
+		t.Fatal("error generating a secret:", err)
+	}
+	key, _ := totpEncoding.DecodeString(secret)
+	now := time.Now()
+	counter := uint64(now.Unix() / totpPeriod)
+
+	var testCases = []struct {
+		code     string
+		expected bool
+		counter  uint64
+	}{
+		{totpCode(key, counter), true, counter},
+		{totpCode(key, counter-1), true, counter - 1},
+		{totpCode(key, counter+1), true, counter + 1},
+		{totpCode(key, counter-3), false, 0},
+		{"", false, 0},
+		{"12345", false, 0},
+	}
+	for _, tc := range testCases {
+		c, actual := validTOTP(secret, tc.code, now)
+		if actual != tc.expected || uint64(c) != tc.counter {
+			t.Errorf("expected %v (%d) for code %q, got %v (%d)", tc.expected, tc.counter, tc.code, actual, c)
+		}
+	}
+	if _, ok := validTOTP(strings.ToLower(secret), totpCode(key, counter), now); !ok {
+		t.Error("expected lower case secret to be valid")
+	}
+}
+
+func TestTOTPURL(t *testing.T) {
+	expected := "otpauth://totp/Teresa:gopher@luizalabs.com?issuer=Teresa&secret=ABC"
+	if actual := totpURL("ABC", "gopher@luizalabs.com"); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
+)
+
+const (
+	// totpMaxFailures codes rejected in a row block the codes of the user
+	// until totpBlockTime after the last one
+	totpMaxFailures = 5
+	totpBlockTime   = 5 * time.Minute
+)
+
+// SetCipher sets the cipher of the database, the one encrypting the secrets
+// of the two-factor authentication, without it the two-factor authentication
+// is disabled
+func (dbu *DatabaseOperations) SetCipher(c encryption.Cipher) {
+	dbu.cipher = c
+}
+
+func (dbu *DatabaseOperations) saveTOTP(u *database.User, secret database.EncryptedString, enabled bool) error {
+	u.TOTPSecret = secret
+	u.TOTPEnabled = enabled
+	err := dbu.DB.Model(u).Updates(map[string]interface{}{
+		"totp_secret":  secret,
+		"totp_enabled": enabled,
+	}).Error
+	if err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Updating two-factor authentication of user %s", u.Email)),
+		)
+	}
+	return nil
+}
+
+// BeginTwoFactor generates a new secret to the user, only enabled after
+// a valid code is given to EnableTwoFactor
+func (dbu *DatabaseOperations) BeginTwoFactor(user *database.User) (string, error) {
+	if dbu.cipher == nil {
+		return "", ErrTwoFactorNotConfigured
+	}
+	u, err := dbu.GetUser(user.Email)
+	if err != nil {
+		return "", err
+	}
+	if u.TOTPEnabled {
+		return "", ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := newTOTPSecret()
+	if err != nil {
+		return "", teresa_errors.NewInternalServerError(err)
+	}
+	if err := dbu.saveTOTP(u, database.EncryptedString(secret), false); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+func (dbu *DatabaseOperations) EnableTwoFactor(user *database.User, code string) error {
+	if dbu.cipher == nil {
+		return ErrTwoFactorNotConfigured
+	}
+	u, err := dbu.GetUser(user.Email)
+	if err != nil {
+		return err
+	}
+	if u.TOTPEnabled {
+		return ErrTwoFactorAlreadyEnabled
+	}
+	if u.TOTPSecret == "" {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if err := dbu.checkTOTP(u, code); err != nil {
+		return err
+	}
+	return dbu.saveTOTP(u, u.TOTPSecret, true)
+}
+
+// DisableTwoFactor disables the two-factor authentication of the user,
+// admins are able to disable the one of other users without its code
+func (dbu *DatabaseOperations) DisableTwoFactor(user *database.User, code, userTarget string) error {
+	email := user.Email
+	if userTarget != "" && userTarget != email {
+		if !user.IsAdmin {
+			return auth.ErrPermissionDenied
+		}
+		email = userTarget
+	}
+
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return err
+	}
+	if !u.TOTPEnabled {
+		return ErrTwoFactorNotEnabled
+	}
+	if email == user.Email {
+		if _, err := dbu.CheckTwoFactor(email, code); err != nil {
+			return err
+		}
+	}
+	return dbu.saveTOTP(u, "", false)
+}
+
+// CheckTwoFactor checks the code of the user, telling if there was one to
+// check, users without the two-factor authentication enabled need none
+func (dbu *DatabaseOperations) CheckTwoFactor(email, code string) (bool, error) {
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return false, err
+	}
+	if !u.TOTPEnabled {
+		return false, nil
+	}
+	if code == "" {
+		return false, ErrTwoFactorRequired
+	}
+	if dbu.cipher == nil {
+		return false, ErrTwoFactorNotConfigured
+	}
+
+	if err := dbu.checkTOTP(u, code); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkTOTP checks the code of the user, each code is accepted only once
+// and too many codes rejected in a row block the user for a while
+func (dbu *DatabaseOperations) checkTOTP(u *database.User, code string) error {
+	now := time.Now()
+	recent := u.TOTPFailedAt != nil && now.Sub(*u.TOTPFailedAt) < totpBlockTime
+	if recent && u.TOTPFailures >= totpMaxFailures {
+		return ErrTwoFactorBlocked
+	}
+
+	if counter, ok := validTOTP(string(u.TOTPSecret), code, now); ok {
+		// the update is conditional so concurrent logins can't both take
+		// the same code
+		res := dbu.DB.Model(&database.User{}).
+			Where("id = ? AND totp_counter < ?", u.ID, counter).
+			Updates(map[string]interface{}{"totp_counter": counter, "totp_failures": 0})
+		if res.Error != nil {
+			return teresa_errors.New(
+				teresa_errors.ErrInternalServerError,
+				errors.Wrap(res.Error, fmt.Sprintf("Updating two-factor counter of user %s", u.Email)),
+			)
+		}
+		if res.RowsAffected > 0 {
+			return nil
+		}
+	}
+
+	failures := 1
+	if recent {
+		failures = u.TOTPFailures + 1
+	}
+	err := dbu.DB.Model(&database.User{}).
+		Where("id = ?", u.ID).
+		Updates(map[string]interface{}{"totp_failures": failures, "totp_failed_at": now}).
+		Error
+	if err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Updating two-factor failures of user %s", u.Email)),
+		)
+	}
+	return ErrInvalidTwoFactorCode
+}
@@ -0,0 +1,185 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/luizalabs/teresa/pkg/server/auth"
+	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+)
+
+func newTwoFactorOps(t *testing.T, db *gorm.DB) Operations {
+	c, err := encryption.NewAESCipher(make([]byte, encryption.KeySize))
+	if err != nil {
+		t.Fatal("error creating the cipher:", err)
+	}
+	database.SetCipher(c)
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	dbu.SetCipher(c)
+	return dbu
+}
+
+func TestDatabaseOperationsTwoFactor(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := newTwoFactorOps(t, db)
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	u := &database.User{Email: email}
+
+	secret, err := dbu.BeginTwoFactor(u)
+	if err != nil {
+		t.Fatal("error beginning two-factor:", err)
+	}
+	var stored string
+	if err := db.Table("users").Where("email = ?", email).Select("totp_secret").Row().Scan(&stored); err != nil {
+		t.Fatal("error getting user:", err)
+	}
+	if stored == "" || strings.Contains(stored, secret) {
+		t.Errorf("expected the secret stored encrypted, got %q", stored)
+	}
+	if checked, err := dbu.CheckTwoFactor(email, ""); checked || err != nil {
+		t.Errorf("expected false and no error before enabling, got %v:%v", checked, err)
+	}
+
+	if err := dbu.EnableTwoFactor(u, "abcdef"); err != ErrInvalidTwoFactorCode {
+		t.Error("expected ErrInvalidTwoFactorCode, got", err)
+	}
+	if err := dbu.EnableTwoFactor(u, stepTOTP(t, secret, -1)); err != nil {
+		t.Fatal("error enabling two-factor:", err)
+	}
+	if _, err := dbu.BeginTwoFactor(u); err != ErrTwoFactorAlreadyEnabled {
+		t.Error("expected ErrTwoFactorAlreadyEnabled, got", err)
+	}
+
+	if _, err := dbu.CheckTwoFactor(email, ""); err != ErrTwoFactorRequired {
+		t.Error("expected ErrTwoFactorRequired, got", err)
+	}
+	if _, err := dbu.CheckTwoFactor(email, stepTOTP(t, secret, -1)); err != ErrInvalidTwoFactorCode {
+		t.Error("expected ErrInvalidTwoFactorCode for the code used to enable, got", err)
+	}
+	if checked, err := dbu.CheckTwoFactor(email, currentTOTP(t, secret)); !checked || err != nil {
+		t.Errorf("expected true and no error, got %v:%v", checked, err)
+	}
+	if _, err := dbu.CheckTwoFactor(email, currentTOTP(t, secret)); err != ErrInvalidTwoFactorCode {
+		t.Error("expected ErrInvalidTwoFactorCode for a replayed code, got", err)
+	}
+
+	if err := dbu.DisableTwoFactor(u, "", ""); err != ErrTwoFactorRequired {
+		t.Error("expected ErrTwoFactorRequired, got", err)
+	}
+	if err := dbu.DisableTwoFactor(u, stepTOTP(t, secret, 1), ""); err != nil {
+		t.Fatal("error disabling two-factor:", err)
+	}
+	if _, err := dbu.CheckTwoFactor(email, ""); err != nil {
+		t.Error("expected no error after disabling, got", err)
+	}
+}
+
+func TestDatabaseOperationsCheckTwoFactorBlocked(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := newTwoFactorOps(t, db)
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	u := &database.User{Email: email}
+	secret, err := dbu.BeginTwoFactor(u)
+	if err != nil {
+		t.Fatal("error beginning two-factor:", err)
+	}
+	if err := dbu.EnableTwoFactor(u, stepTOTP(t, secret, -1)); err != nil {
+		t.Fatal("error enabling two-factor:", err)
+	}
+
+	for i := 0; i < totpMaxFailures; i++ {
+		if _, err := dbu.CheckTwoFactor(email, "000000"); err != ErrInvalidTwoFactorCode {
+			t.Fatal("expected ErrInvalidTwoFactorCode, got", err)
+		}
+	}
+	if _, err := dbu.CheckTwoFactor(email, currentTOTP(t, secret)); err != ErrTwoFactorBlocked {
+		t.Fatal("expected ErrTwoFactorBlocked, got", err)
+	}
+
+	blockedAt := time.Now().Add(-totpBlockTime)
+	if err := db.Model(&database.User{}).Where("email = ?", email).Update("totp_failed_at", blockedAt).Error; err != nil {
+		t.Fatal("error updating the failures:", err)
+	}
+	if _, err := dbu.CheckTwoFactor(email, currentTOTP(t, secret)); err != nil {
+		t.Error("expected no error after the block, got", err)
+	}
+}
+
+func TestDatabaseOperationsDisableTwoFactorOfOtherUser(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := newTwoFactorOps(t, db)
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	u := &database.User{Email: email}
+	secret, err := dbu.BeginTwoFactor(u)
+	if err != nil {
+		t.Fatal("error beginning two-factor:", err)
+	}
+	if err := dbu.EnableTwoFactor(u, currentTOTP(t, secret)); err != nil {
+		t.Fatal("error enabling two-factor:", err)
+	}
+
+	other := &database.User{Email: "gopher@luizalabs.com"}
+	if err := dbu.DisableTwoFactor(other, "", email); err != auth.ErrPermissionDenied {
+		t.Error("expected ErrPermissionDenied, got", err)
+	}
+	admin := &database.User{Email: "admin@luizalabs.com", IsAdmin: true}
+	if err := dbu.DisableTwoFactor(admin, "", email); err != nil {
+		t.Error("expected no error, got", err)
+	}
+}
+
+func TestDatabaseOperationsTwoFactorNotConfigured(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err = createFakeUser(db, "Test", email, "secret", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+	if _, err := dbu.BeginTwoFactor(&database.User{Email: email}); err != ErrTwoFactorNotConfigured {
+		t.Error("expected ErrTwoFactorNotConfigured, got", err)
+	}
+}
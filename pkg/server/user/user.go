@@ -2,6 +2,7 @@ package user
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,49 +11,115 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/luizalabs/teresa/pkg/server/auth"
 	"github.com/luizalabs/teresa/pkg/server/database"
+	"github.com/luizalabs/teresa/pkg/server/encryption"
+	"github.com/luizalabs/teresa/pkg/server/mail"
 	"github.com/luizalabs/teresa/pkg/server/teresa_errors"
 	"github.com/luizalabs/teresa/pkg/server/validation"
 )
 
 const (
 	minPassLength = 8
+	// ServiceAccountDomain is the domain of the emails of the service
+	// accounts, the users of the team tokens
+	ServiceAccountDomain = "tokens.teresa"
 )
 
+// IsServiceAccount tells if the email is of the service account of a team
+// token, these users have no password and only authenticate by the token
+func IsServiceAccount(email string) bool {
+	return strings.HasSuffix(email, "@"+ServiceAccountDomain)
+}
+
 type Operations interface {
 	Login(email, password string, exp time.Duration) (string, error)
+	Authenticate(email, password string) error
+	ServiceAccountToken(email string, exp time.Duration) (string, error)
+	CreateSession(email string, twoFactor bool, exp time.Duration) (*Tokens, error)
+	RefreshSession(refreshToken string) (*Tokens, error)
+	CheckSession(id, email string) (bool, error)
+	ListSessions(user *database.User, userTarget string) ([]*database.Session, error)
+	RevokeSession(user *database.User, id uint, userTarget string) error
+	RevokeSessions(user *database.User, userTarget string) error
 	GetUser(email string) (*database.User, error)
 	SetPassword(user *database.User, newPassword, userTarget string) error
 	Delete(email string) error
+	Suspend(email string) error
+	Reactivate(email string) error
 	Create(name, email, pass string, admin bool) error
+	SetCipher(c encryption.Cipher)
+	BeginTwoFactor(user *database.User) (string, error)
+	EnableTwoFactor(user *database.User, code string) error
+	DisableTwoFactor(user *database.User, code, userTarget string) error
+	CheckTwoFactor(email, code string) (bool, error)
+	SetMailer(m mail.Mailer)
+	SetPasswordPolicy(p *PasswordPolicy)
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
 }
 
 type DatabaseOperations struct {
-	DB   *gorm.DB
-	auth auth.Auth
+	DB     *gorm.DB
+	auth   auth.Auth
+	cipher encryption.Cipher
+	mailer mail.Mailer
+	policy *PasswordPolicy
 }
 
+// Login returns a token of the user not bound to a session, valid until
+// it expires
 func (dbu *DatabaseOperations) Login(email, password string, exp time.Duration) (string, error) {
-	u, err := dbu.GetUser(email)
-	if err != nil {
-		return "", auth.ErrPermissionDenied
+	if err := dbu.Authenticate(email, password); err != nil {
+		return "", err
 	}
-	if err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+
+	token, err := dbu.auth.GenerateToken(email, exp)
+	if err != nil {
 		return "", teresa_errors.New(
 			auth.ErrPermissionDenied,
-			errors.Wrap(err, fmt.Sprintf("Authentication failed for user %s", email)),
+			errors.Wrap(err, "Signing JWT token"),
 		)
 	}
+	return token, nil
+}
 
+// ServiceAccountToken signs a token of the service account of a team token,
+// nobody knows its password so it isn't checked, nor the password policy
+func (dbu *DatabaseOperations) ServiceAccountToken(email string, exp time.Duration) (string, error) {
+	if _, err := dbu.GetUser(email); err != nil {
+		return "", err
+	}
 	token, err := dbu.auth.GenerateToken(email, exp)
 	if err != nil {
 		return "", teresa_errors.New(
-			auth.ErrPermissionDenied,
+			teresa_errors.ErrInternalServerError,
 			errors.Wrap(err, "Signing JWT token"),
 		)
 	}
 	return token, nil
 }
 
+// Authenticate checks the password of the user, the expired passwords and
+// the ones not following the password policy have to be changed
+func (dbu *DatabaseOperations) Authenticate(email, password string) error {
+	u, err := dbu.GetUser(email)
+	if err != nil || IsServiceAccount(u.Email) {
+		return auth.ErrPermissionDenied
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)); err != nil {
+		return teresa_errors.New(
+			auth.ErrPermissionDenied,
+			errors.Wrap(err, fmt.Sprintf("Authentication failed for user %s", email)),
+		)
+	}
+	if u.Suspended {
+		return ErrUserSuspended
+	}
+	if dbu.policy.Expired(passwordChangedAt(u)) || dbu.policy.Check(password) != nil {
+		return ErrPasswordChangeRequired
+	}
+	return nil
+}
+
 func (dbu *DatabaseOperations) GetUser(email string) (*database.User, error) {
 	u := new(database.User)
 	if dbu.DB.Where(&database.User{Email: email}).First(u).RecordNotFound() {
@@ -74,22 +141,46 @@ func (dbu *DatabaseOperations) SetPassword(user *database.User, newPassword, use
 	if err != nil {
 		return err
 	}
+	return dbu.savePassword(u, newPassword)
+}
+
+// savePassword sets the password of the user following the password policy,
+// dropping its pending password resets
+func (dbu *DatabaseOperations) savePassword(u *database.User, newPassword string) error {
+	if IsServiceAccount(u.Email) {
+		return ErrServiceAccountPassword
+	}
+	if err := dbu.policy.Check(newPassword); err != nil {
+		return err
+	}
+	if err := dbu.checkPasswordReuse(u, newPassword); err != nil {
+		return err
+	}
 
 	pass, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
 	if err != nil {
 		return teresa_errors.New(
 			teresa_errors.ErrInternalServerError,
-			errors.Wrap(err, fmt.Sprintf("Generating the password hash to user %s", email)),
+			errors.Wrap(err, fmt.Sprintf("Generating the password hash to user %s", u.Email)),
 		)
 	}
+	old := u.Password
+	now := time.Now()
 	u.Password = string(pass)
+	u.PasswordChangedAt = &now
 	if err = dbu.DB.Save(u).Error; err != nil {
 		return teresa_errors.New(
 			teresa_errors.ErrInternalServerError,
-			errors.Wrap(err, fmt.Sprintf("Updating password of user %s", email)),
+			errors.Wrap(err, fmt.Sprintf("Updating password of user %s", u.Email)),
 		)
 	}
-	return nil
+	if err = dbu.savePasswordHistory(u, old); err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Saving password history of user %s", u.Email)),
+		)
+	}
+	return dbu.deleteResets(u)
 }
 
 func (dbu *DatabaseOperations) Delete(email string) error {
@@ -97,6 +188,12 @@ func (dbu *DatabaseOperations) Delete(email string) error {
 	if err != nil {
 		return err
 	}
+	if err = dbu.deleteResets(u); err != nil {
+		return err
+	}
+	if err = dbu.deleteSessions(u); err != nil {
+		return err
+	}
 	if err = dbu.DB.Delete(u).Error; err != nil {
 		return teresa_errors.New(
 			teresa_errors.ErrInternalServerError,
@@ -106,6 +203,41 @@ func (dbu *DatabaseOperations) Delete(email string) error {
 	return nil
 }
 
+// Suspend keeps the user but denies its logins and RPCs, ending its
+// sessions and pending password resets
+func (dbu *DatabaseOperations) Suspend(email string) error {
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return err
+	}
+	if err = dbu.setSuspended(u, true); err != nil {
+		return err
+	}
+	if err = dbu.deleteResets(u); err != nil {
+		return err
+	}
+	return dbu.deleteSessions(u)
+}
+
+// Reactivate gives back the access of a suspended user, it must login again
+func (dbu *DatabaseOperations) Reactivate(email string) error {
+	u, err := dbu.GetUser(email)
+	if err != nil {
+		return err
+	}
+	return dbu.setSuspended(u, false)
+}
+
+func (dbu *DatabaseOperations) setSuspended(u *database.User, suspended bool) error {
+	if err := dbu.DB.Model(u).Update("suspended", suspended).Error; err != nil {
+		return teresa_errors.New(
+			teresa_errors.ErrInternalServerError,
+			errors.Wrap(err, fmt.Sprintf("Updating suspension of user %s", u.Email)),
+		)
+	}
+	return nil
+}
+
 func (dbu *DatabaseOperations) Create(name, email, pass string, admin bool) error {
 	if !validation.IsValidEmail(email) {
 		return ErrInvalidEmail
@@ -129,6 +261,8 @@ func (dbu *DatabaseOperations) Create(name, email, pass string, admin bool) erro
 	u.Email = email
 	u.Password = string(hash)
 	u.IsAdmin = admin
+	now := time.Now()
+	u.PasswordChangedAt = &now
 	if err = dbu.DB.Save(u).Error; err != nil {
 		return teresa_errors.New(
 			teresa_errors.ErrInternalServerError,
@@ -139,6 +273,5 @@ func (dbu *DatabaseOperations) Create(name, email, pass string, admin bool) erro
 }
 
 func NewDatabaseOperations(db *gorm.DB, a auth.Auth) Operations {
-	db.AutoMigrate(&database.User{})
-	return &DatabaseOperations{DB: db, auth: a}
+	return &DatabaseOperations{DB: db, auth: a, policy: new(PasswordPolicy)}
 }
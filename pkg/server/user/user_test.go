@@ -31,6 +31,9 @@ func TestDatabaseOperationsLogin(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -55,6 +58,9 @@ func TestDatabaseOperationsBadLogin(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -69,6 +75,9 @@ func TestDatabaseOperationsGetUser(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -92,6 +101,9 @@ func TestDatabaseOperationsGetUserNotFound(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -105,6 +117,9 @@ func TestDatabaseOperationsSetPassword(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -139,11 +154,46 @@ func TestDatabaseOperationsSetPassword(t *testing.T) {
 	}
 }
 
+func TestDatabaseOperationsServiceAccountPassword(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error on open in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "ci-1234@" + ServiceAccountDomain
+	if err = createFakeUser(db, "token ci of luizalabs", email, "123456", false); err != nil {
+		t.Fatal("error on create fake user: ", err)
+	}
+
+	user := &database.User{Email: email}
+	if err := dbu.SetPassword(user, "secret", ""); err != ErrServiceAccountPassword {
+		t.Errorf("expected ErrServiceAccountPassword, got %v", err)
+	}
+	admin := &database.User{Email: "sre@luizalabs.com", IsAdmin: true}
+	if err := dbu.SetPassword(admin, "secret", email); err != ErrServiceAccountPassword {
+		t.Errorf("expected ErrServiceAccountPassword, got %v", err)
+	}
+	if _, err := dbu.Login(email, "123456", time.Second); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+	if _, err := dbu.ServiceAccountToken(email, time.Second); err != nil {
+		t.Errorf("expected no error signing the token, got %v", err)
+	}
+}
+
 func TestDatabaseOperationsSetPasswordForInvalidTargetUser(t *testing.T) {
 	db, err := gorm.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -167,6 +217,9 @@ func TestDatabaseOperationsSetPasswordErrPermissionDenied(t *testing.T) {
 	if err != nil {
 		t.Fatal("error on open in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -193,6 +246,9 @@ func TestDatabaseOperationsDelete(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -208,11 +264,62 @@ func TestDatabaseOperationsDelete(t *testing.T) {
 	}
 }
 
+func TestDatabaseOperationsSuspend(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal("error opening in memory database ", err)
+	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
+	defer db.Close()
+
+	dbu := NewDatabaseOperations(db, auth.NewFake())
+	email := "teresa@luizalabs.com"
+	if err := createFakeUser(db, "Test", email, "123456", false); err != nil {
+		t.Fatal("error creating fake user: ", err)
+	}
+	tk, err := dbu.CreateSession(email, false, time.Hour)
+	if err != nil {
+		t.Fatal("error creating session: ", err)
+	}
+
+	if err := dbu.Suspend(email); err != nil {
+		t.Fatal("error suspending user: ", err)
+	}
+	u, err := dbu.GetUser(email)
+	if err != nil || !u.Suspended {
+		t.Fatalf("expected the user kept suspended, got %v (err: %v)", u, err)
+	}
+	if err := dbu.Authenticate(email, "123456"); err != ErrUserSuspended {
+		t.Errorf("expected ErrUserSuspended, got %v", err)
+	}
+	if _, err := dbu.CreateSession(email, false, time.Hour); err != ErrUserSuspended {
+		t.Errorf("expected ErrUserSuspended, got %v", err)
+	}
+	if _, err := dbu.RefreshSession(tk.Refresh); err != auth.ErrPermissionDenied {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+
+	if err := dbu.Reactivate(email); err != nil {
+		t.Fatal("error reactivating user: ", err)
+	}
+	if err := dbu.Authenticate(email, "123456"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := dbu.Suspend("gopher@luizalabs.com"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
 func TestDatabaseOperationsDeleteUserNotFound(t *testing.T) {
 	db, err := gorm.Open("sqlite3", ":memory:")
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -226,6 +333,9 @@ func TestDatabaseOperationsCreate(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -259,6 +369,9 @@ func TestDatabaseOperationsCreateUserAlreadyExists(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -277,6 +390,9 @@ func TestDatabaseOperationsCreateUserInvalidPassword(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
@@ -292,6 +408,9 @@ func TestDatabaseOperationsCreateUserInvalidEmail(t *testing.T) {
 	if err != nil {
 		t.Fatal("error opening in memory database ", err)
 	}
+	if _, err := database.MigrateUp(db); err != nil {
+		t.Fatal("error migrating in memory database ", err)
+	}
 	defer db.Close()
 
 	dbu := NewDatabaseOperations(db, auth.NewFake())
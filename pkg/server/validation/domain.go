@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+var domainLabelRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// IsDomain reports whether d is a lower case DNS name with at least two
+// labels, like teresa.io
+func IsDomain(d string) bool {
+	return strings.Contains(d, ".") && IsHostname(d)
+}
+
+// IsHostname reports whether h is a lower case DNS name, single labels like
+// localhost included
+func IsHostname(h string) bool {
+	if len(h) > 253 {
+		return false
+	}
+	for _, l := range strings.Split(h, ".") {
+		if len(l) > 63 || !domainLabelRegexp.MatchString(l) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubdomain reports whether host is the domain itself or one of
+// its subdomains
+func IsSubdomain(host, domain string) bool {
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
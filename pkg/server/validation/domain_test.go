@@ -0,0 +1,66 @@
+package validation
+
+import "testing"
+
+func TestIsDomain(t *testing.T) {
+	var testCases = []struct {
+		domain string
+		res    bool
+	}{
+		{"teresa.io", true},
+		{"apps.luizalabs.com", true},
+		{"my-app.teresa.io", true},
+		{"", false},
+		{"localhost", false},
+		{"Teresa.io", false},
+		{"-teresa.io", false},
+		{"teresa..io", false},
+		{"*.teresa.io", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsDomain(tc.domain); b != tc.res {
+			t.Errorf("want %v; got %v (domain: %s)", tc.res, b, tc.domain)
+		}
+	}
+}
+
+func TestIsHostname(t *testing.T) {
+	var testCases = []struct {
+		host string
+		res  bool
+	}{
+		{"legacy-db", true},
+		{"db.legacy.internal", true},
+		{"", false},
+		{"DB", false},
+		{"db..internal", false},
+		{"db_1", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsHostname(tc.host); b != tc.res {
+			t.Errorf("want %v; got %v (host: %s)", tc.res, b, tc.host)
+		}
+	}
+}
+
+func TestIsSubdomain(t *testing.T) {
+	var testCases = []struct {
+		host   string
+		domain string
+		res    bool
+	}{
+		{"teresa.io", "teresa.io", true},
+		{"myapp.teresa.io", "teresa.io", true},
+		{"a.b.teresa.io", "teresa.io", true},
+		{"myteresa.io", "teresa.io", false},
+		{"teresa.io.evil.com", "teresa.io", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsSubdomain(tc.host, tc.domain); b != tc.res {
+			t.Errorf("want %v; got %v (host: %s, domain: %s)", tc.res, b, tc.host, tc.domain)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package validation
+
+import "regexp"
+
+var imageRegexp = regexp.MustCompile(
+	`^([a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?/)?[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*` +
+		`(:[A-Za-z0-9_][A-Za-z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`,
+)
+
+// IsImage reports whether s is a container image reference, like
+// registry.example.com:5000/team/app:v1.2 or one pinned by digest
+func IsImage(s string) bool {
+	return imageRegexp.MatchString(s)
+}
@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsImage(t *testing.T) {
+	var testCases = []struct {
+		image string
+		res   bool
+	}{
+		{"nginx", true},
+		{"luizalabs/teresa:0.30.0", true},
+		{"gcr.io/project/app:v1.2", true},
+		{"registry.example.com:5000/team/app", true},
+		{"luizalabs/teresa@sha256:" + strings.Repeat("a", 64), true},
+		{"", false},
+		{"Luizalabs/teresa", false},
+		{"luizalabs/teresa:", false},
+		{"luizalabs/teresa:v1 --privileged", false},
+		{"luizalabs//teresa", false},
+		{"luizalabs/teresa@sha256:abc", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsImage(tc.image); b != tc.res {
+			t.Errorf("want %v; got %v (image: %s)", tc.res, b, tc.image)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package validation
+
+import (
+	"path"
+	"strings"
+)
+
+// IsMountPath reports whether p is an absolute, clean directory path
+// other than the root, like /secrets
+func IsMountPath(p string) bool {
+	if !path.IsAbs(p) || strings.TrimSuffix(p, "/") != path.Clean(p) {
+		return false
+	}
+	return path.Clean(p) != "/"
+}
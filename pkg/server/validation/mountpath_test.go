@@ -0,0 +1,26 @@
+package validation
+
+import "testing"
+
+func TestIsMountPath(t *testing.T) {
+	var testCases = []struct {
+		path string
+		res  bool
+	}{
+		{"/secrets", true},
+		{"/secrets/", true},
+		{"/etc/app/credentials", true},
+		{"", false},
+		{"/", false},
+		{"secrets", false},
+		{"./secrets", false},
+		{"/secrets/../etc", false},
+		{"//secrets", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsMountPath(tc.path); b != tc.res {
+			t.Errorf("want %v; got %v (path: %s)", tc.res, b, tc.path)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package validation
+
+import "k8s.io/apimachinery/pkg/api/resource"
+
+// IsQuantity reports whether q is a valid, non negative, Kubernetes resource
+// quantity, like 500m or 512Mi
+func IsQuantity(q string) bool {
+	qt, err := resource.ParseQuantity(q)
+	return err == nil && qt.Sign() >= 0
+}
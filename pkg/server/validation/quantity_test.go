@@ -0,0 +1,29 @@
+package validation
+
+import "testing"
+
+func TestIsQuantity(t *testing.T) {
+	var testCases = []struct {
+		quantity string
+		res      bool
+	}{
+		{"500m", true},
+		{"1", true},
+		{"0.5", true},
+		{"512Mi", true},
+		{"1G", true},
+		{"1e3", true},
+		{"", false},
+		{"-1", false},
+		{"512MB", false},
+		{"1.5.0", false},
+		{"1Gi1", false},
+		{"gopher", false},
+	}
+
+	for _, tc := range testCases {
+		if b := IsQuantity(tc.quantity); b != tc.res {
+			t.Errorf("want %v; got %v (quantity: %s)", tc.res, b, tc.quantity)
+		}
+	}
+}